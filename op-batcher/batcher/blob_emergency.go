@@ -0,0 +1,125 @@
+package batcher
+
+import (
+	"sync"
+	"time"
+)
+
+// calldataEmergencyProvider is a channel config provider that can be made to
+// return calldata channel configs, like the RuntimeChannelConfig.
+type calldataEmergencyProvider interface {
+	SetCalldataEmergency(active bool) error
+}
+
+// blobFailureTracker counts consecutive failures of blob transactions, e.g.
+// because of tx pool rejections or failing blob fee estimation during blob
+// related L1 incidents. Once the failure threshold is reached, the blob
+// emergency mode is active for the emergency duration, during which pending
+// channel data is submitted as calldata.
+type blobFailureTracker struct {
+	mu sync.Mutex
+
+	threshold int
+	duration  time.Duration
+	now       func() time.Time
+
+	// ids of in-flight blob txs
+	blobTxs map[string]struct{}
+	// number of consecutive failed blob txs
+	failures int
+	// end of the emergency mode, zero if not active
+	until time.Time
+}
+
+func newBlobFailureTracker(threshold uint64, duration time.Duration) *blobFailureTracker {
+	return &blobFailureTracker{
+		threshold: int(threshold),
+		duration:  duration,
+		now:       time.Now,
+		blobTxs:   make(map[string]struct{}),
+	}
+}
+
+// sent records a sent tx, of which the result is recorded later.
+func (t *blobFailureTracker) sent(id txID, asBlob bool) {
+	if !asBlob {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.blobTxs[id.String()] = struct{}{}
+}
+
+// result records the result of a sent tx. It returns true if the failure of a
+// blob tx starts the emergency mode.
+func (t *blobFailureTracker) result(id txID, err error) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := id.String()
+	if _, ok := t.blobTxs[key]; !ok {
+		return false
+	}
+	delete(t.blobTxs, key)
+	if err == nil {
+		t.failures = 0
+		return false
+	}
+	t.failures++
+	if t.failures < t.threshold || !t.until.IsZero() {
+		return false
+	}
+	t.until = t.now().Add(t.duration)
+	return true
+}
+
+// status returns whether the emergency mode is active, and whether it just
+// ended. Consecutive failures are counted anew after the emergency mode ended.
+func (t *blobFailureTracker) status() (active bool, ended bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.until.IsZero() {
+		return false, false
+	}
+	if t.now().Before(t.until) {
+		return true, false
+	}
+	t.until = time.Time{}
+	t.failures = 0
+	return false, true
+}
+
+// startBlobEmergency makes new channels use calldata, after blob transactions
+// failed persistently.
+func (l *BatchSubmitter) startBlobEmergency(err error) {
+	l.Log.Warn("Blob transactions are failing persistently, submitting channel data as calldata",
+		"failures", l.Config.BlobFailureThreshold, "duration", l.Config.BlobEmergencyDuration, "err", err)
+	l.setCalldataEmergency(true)
+}
+
+// checkBlobEmergency ends an expired blob emergency mode, or requeues the data
+// of blob channels into calldata channels while the emergency mode is active.
+func (l *BatchSubmitter) checkBlobEmergency() {
+	active, ended := l.blobFailures.status()
+	if ended {
+		l.Log.Info("Blob emergency mode ended, submitting channel data as configured")
+		l.setCalldataEmergency(false)
+	}
+	if active {
+		if n := l.state.RequeueBlobChannels(); n > 0 {
+			l.Log.Info("Requeued blob channels for calldata submission", "channels", n)
+		}
+	}
+}
+
+func (l *BatchSubmitter) setCalldataEmergency(active bool) {
+	provider, ok := l.ChannelConfig.(calldataEmergencyProvider)
+	if !ok {
+		l.Log.Error("Channel config does not support the blob emergency mode")
+		return
+	}
+	if err := provider.SetCalldataEmergency(active); err != nil {
+		l.Log.Error("Failed to set blob emergency mode", "active", active, "err", err)
+		return
+	}
+	l.Metr.RecordBlobEmergency(active)
+}
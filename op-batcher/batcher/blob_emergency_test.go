@@ -0,0 +1,53 @@
+package batcher
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+func TestBlobFailureTracker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tr := newBlobFailureTracker(2, time.Minute)
+	tr.now = func() time.Time { return now }
+	txErr := errors.New("blob tx rejected")
+	tx := func(i byte) txID {
+		return txID{{chID: derive.ChannelID{i}}}
+	}
+
+	tr.sent(tx(1), true)
+	tr.sent(tx(2), false)
+	tr.sent(tx(3), true)
+	tr.sent(tx(4), true)
+	require.False(t, tr.result(tx(1), txErr))
+	require.False(t, tr.result(tx(2), txErr), "calldata txs are not counted")
+	require.False(t, tr.result(tx(3), nil), "success resets the failure count")
+	require.False(t, tr.result(tx(4), txErr))
+	active, ended := tr.status()
+	require.False(t, active)
+	require.False(t, ended)
+
+	tr.sent(tx(5), true)
+	tr.sent(tx(6), true)
+	require.True(t, tr.result(tx(5), txErr), "threshold reached")
+	require.False(t, tr.result(tx(6), txErr), "already active")
+	require.False(t, tr.result(tx(5), txErr), "unknown tx")
+	active, ended = tr.status()
+	require.True(t, active)
+	require.False(t, ended)
+
+	now = now.Add(time.Minute)
+	active, ended = tr.status()
+	require.False(t, active)
+	require.True(t, ended)
+	active, ended = tr.status()
+	require.False(t, active)
+	require.False(t, ended)
+
+	tr.sent(tx(7), true)
+	require.False(t, tr.result(tx(7), txErr), "failures are counted anew")
+}
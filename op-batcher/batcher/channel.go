@@ -3,11 +3,15 @@ package batcher
 import (
 	"fmt"
 	"math"
+	"slices"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -25,6 +29,8 @@ type channel struct {
 	pendingTransactions map[string]txData
 	// Set of confirmed txID -> inclusion block. For determining if the channel is timed out
 	confirmedTransactions map[string]eth.BlockID
+	// Confirmed txs in order of confirmation, for observability
+	includedTxs []rpc.ChannelTx
 
 	// True if confirmed TX list is updated. Set to false after updated min/max inclusion blocks.
 	confirmedTxUpdated bool
@@ -71,7 +77,7 @@ func (s *channel) TxFailed(id string) {
 // a channel have been marked as confirmed on L1 the channel may be invalid & need to be
 // resubmitted.
 // This function may reset the pending channel if the pending channel has timed out.
-func (s *channel) TxConfirmed(id string, inclusionBlock eth.BlockID) (bool, []*types.Block) {
+func (s *channel) TxConfirmed(id string, inclusionBlock eth.BlockID, txHash common.Hash) (bool, []*types.Block) {
 	s.metr.RecordBatchTxSubmitted()
 	s.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
 	if _, ok := s.pendingTransactions[id]; !ok {
@@ -83,7 +89,9 @@ func (s *channel) TxConfirmed(id string, inclusionBlock eth.BlockID) (bool, []*t
 	delete(s.pendingTransactions, id)
 	s.confirmedTransactions[id] = inclusionBlock
 	s.confirmedTxUpdated = true
+	s.includedTxs = append(s.includedTxs, rpc.ChannelTx{TxHash: txHash, InclusionBlock: inclusionBlock})
 	s.channelBuilder.FramePublished(inclusionBlock.Number)
+	s.checkSeqWindowMargin(inclusionBlock)
 
 	// If this channel timed out, put the pending blocks back into the local saved blocks
 	// and then reset this state so it can try to build a new channel.
@@ -95,6 +103,7 @@ func (s *channel) TxConfirmed(id string, inclusionBlock eth.BlockID) (bool, []*t
 	// If we are done with this channel, record that.
 	if s.isFullySubmitted() {
 		s.metr.RecordChannelFullySubmitted(s.ID())
+		s.metr.RecordChannelIncluded(len(s.channelBuilder.Blocks()), len(s.includedTxs), s.maxInclusionBlock-s.minInclusionBlock)
 		s.log.Info("Channel is fully submitted", "id", s.ID(), "min_inclusion_block", s.minInclusionBlock, "max_inclusion_block", s.maxInclusionBlock)
 		return true, nil
 	}
@@ -102,6 +111,41 @@ func (s *channel) TxConfirmed(id string, inclusionBlock eth.BlockID) (bool, []*t
 	return false, nil
 }
 
+// checkSeqWindowMargin records the number of L1 blocks that were left until the
+// end of the channel's sequencing window at the inclusion of one of its txs.
+func (s *channel) checkSeqWindowMargin(inclusionBlock eth.BlockID) {
+	swEnd := s.channelBuilder.SeqWindowEnd()
+	if swEnd == 0 {
+		return
+	}
+	margin := int64(swEnd) - int64(inclusionBlock.Number)
+	s.metr.RecordSeqWindowMargin(margin)
+	if margin < 0 {
+		s.log.Error("Batcher tx included after end of sequencing window, batches are invalid",
+			"id", s.ID(), "block", inclusionBlock, "seq_window_end", swEnd)
+	} else {
+		s.log.Debug("Batcher tx included within sequencing window",
+			"id", s.ID(), "block", inclusionBlock, "seq_window_end", swEnd, "margin", margin)
+	}
+}
+
+// SubmissionDeadline returns the last L1 block number at which the channel's
+// remaining frames should be submitted, to leave the sub safety margin for
+// their inclusion before the end of the sequencing window and the channel
+// timeout. It returns false if the channel holds no blocks yet.
+func (s *channel) SubmissionDeadline() (uint64, bool) {
+	swEnd := s.channelBuilder.SeqWindowEnd()
+	if swEnd == 0 {
+		return 0, false
+	}
+	deadline := swEnd - s.cfg.SubSafetyMargin
+	s.updateInclusionBlocks()
+	if len(s.confirmedTransactions) > 0 {
+		deadline = min(deadline, s.minInclusionBlock+s.cfg.ChannelTimeout-s.cfg.SubSafetyMargin)
+	}
+	return deadline, true
+}
+
 // Timeout returns the channel timeout L1 block number. If there is no timeout set, it returns 0.
 func (s *channel) Timeout() uint64 {
 	return s.channelBuilder.Timeout()
@@ -144,6 +188,42 @@ func (s *channel) isFullySubmitted() bool {
 	return s.IsFull() && len(s.pendingTransactions)+s.PendingFrames() == 0
 }
 
+// Status returns the submission status of the channel.
+func (s *channel) Status() rpc.ChannelStatus {
+	switch {
+	case len(s.confirmedTransactions) > 0 && s.isTimedOut():
+		return rpc.ChannelTimedOut
+	case s.isFullySubmitted():
+		return rpc.ChannelFullySubmitted
+	case s.IsFull():
+		return rpc.ChannelSubmitting
+	default:
+		return rpc.ChannelOpen
+	}
+}
+
+// Info returns the details of the channel. Confirmations of its txs are left
+// empty.
+func (s *channel) Info() rpc.ChannelInfo {
+	info := rpc.ChannelInfo{
+		ID:          s.ID(),
+		Status:      s.Status(),
+		NumBlocks:   len(s.channelBuilder.Blocks()),
+		NumFrames:   s.TotalFrames(),
+		InputBytes:  s.InputBytes(),
+		OutputBytes: s.OutputBytes(),
+		Txs:         slices.Clone(s.includedTxs),
+	}
+	if info.NumBlocks > 0 {
+		info.FirstBlock = s.channelBuilder.OldestL2()
+		info.LastBlock = s.channelBuilder.LatestL2()
+	}
+	if info.InputBytes > 0 {
+		info.ComprRatio = float64(info.OutputBytes) / float64(info.InputBytes)
+	}
+	return info
+}
+
 func (s *channel) NoneSubmitted() bool {
 	return len(s.confirmedTransactions) == 0 && len(s.pendingTransactions) == 0
 }
@@ -160,7 +240,7 @@ func (s *channel) ID() derive.ChannelID {
 // NextTxData should only be called after HasTxData returned true.
 func (s *channel) NextTxData() txData {
 	nf := s.cfg.MaxFramesPerTx()
-	txdata := txData{frames: make([]frameData, 0, nf)}
+	txdata := txData{frames: make([]frameData, 0, nf), asBlob: s.cfg.UseBlobs}
 	for i := 0; i < nf && s.channelBuilder.HasFrame(); i++ {
 		frame := s.channelBuilder.NextFrame()
 		txdata.frames = append(txdata.frames, frame)
@@ -209,6 +289,14 @@ func (s *channel) OutputBytes() int {
 	return s.channelBuilder.OutputBytes()
 }
 
+func (s *channel) CompressionAlgo() derive.CompressionAlgo {
+	return s.channelBuilder.CompressionAlgo()
+}
+
+func (s *channel) CompressionDuration() time.Duration {
+	return s.channelBuilder.CompressionDuration()
+}
+
 func (s *channel) TotalFrames() int {
 	return s.channelBuilder.TotalFrames()
 }
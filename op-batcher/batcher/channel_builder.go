@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
@@ -15,6 +16,8 @@ import (
 
 var (
 	ErrInvalidChannelTimeout = errors.New("channel timeout is less than the safety margin")
+	ErrInvalidSeqWindowSize  = errors.New("sequencing window size is not larger than the safety margin")
+	ErrMaxBlocksPerSpanBatch = errors.New("max blocks per span batch reached")
 	ErrMaxFrameIndex         = errors.New("max frame index reached (uint16)")
 	ErrMaxDurationReached    = errors.New("max channel duration reached")
 	ErrChannelTimeoutClose   = errors.New("close to channel timeout")
@@ -58,6 +61,10 @@ type ChannelBuilder struct {
 	timeout uint64
 	// reason for currently set timeout
 	timeoutReason error
+	// last L1 block number at which the channel's batches can be included,
+	// i.e., the end of the earliest sequencing window of all added batches.
+	// 0 if no batches added yet.
+	seqWindowEnd uint64
 
 	// Reason for the channel being full. Set by setFullErr so it's always
 	// guaranteed to be a ChannelFullError wrapping the specific reason.
@@ -80,6 +87,9 @@ type ChannelBuilder struct {
 	numFrames int
 	// total amount of output data of all frames created yet
 	outputBytes int
+	// total time spent adding blocks to and closing the channel out, which is
+	// dominated by compression
+	comprDuration time.Duration
 }
 
 // NewChannelBuilder creates a new channel builder or returns an error if the
@@ -130,6 +140,17 @@ func (c *ChannelBuilder) OutputBytes() int {
 	return c.outputBytes
 }
 
+// CompressionAlgo returns the compression algorithm used by this channel.
+func (c *ChannelBuilder) CompressionAlgo() derive.CompressionAlgo {
+	return c.cfg.CompressorConfig.CompressionAlgo
+}
+
+// CompressionDuration returns the total time spent adding blocks to the
+// channel and closing it, which is dominated by compressing the input data.
+func (c *ChannelBuilder) CompressionDuration() time.Duration {
+	return c.comprDuration
+}
+
 // Blocks returns a backup list of all blocks that were added to the channel. It
 // can be used in case the channel needs to be rebuilt.
 func (c *ChannelBuilder) Blocks() []*types.Block {
@@ -177,7 +198,10 @@ func (c *ChannelBuilder) AddBlock(block *types.Block) (*derive.L1BlockInfo, erro
 		return l1info, fmt.Errorf("converting block to batch: %w", err)
 	}
 
-	if err = c.co.AddSingularBatch(batch, l1info.SequenceNumber); errors.Is(err, derive.ErrTooManyRLPBytes) || errors.Is(err, derive.ErrCompressorFull) {
+	start := time.Now()
+	err = c.co.AddSingularBatch(batch, l1info.SequenceNumber)
+	c.comprDuration += time.Since(start)
+	if errors.Is(err, derive.ErrTooManyRLPBytes) || errors.Is(err, derive.ErrCompressorFull) {
 		c.setFullErr(err)
 		return l1info, c.FullErr()
 	} else if err != nil {
@@ -209,6 +233,9 @@ func (c *ChannelBuilder) AddBlock(block *types.Block) (*derive.L1BlockInfo, erro
 	if err = c.co.FullErr(); err != nil {
 		c.setFullErr(err)
 		// Adding this block still worked, so don't return error, just mark as full
+	} else if c.cfg.BatchType == derive.SpanBatchType && c.cfg.MaxBlocksPerSpanBatch > 0 &&
+		len(c.blocks) >= c.cfg.MaxBlocksPerSpanBatch {
+		c.setFullErr(ErrMaxBlocksPerSpanBatch)
 	}
 
 	return l1info, nil
@@ -247,10 +274,21 @@ func (c *ChannelBuilder) updateDurationTimeout(l1BlockNum uint64) {
 // if the derived sequencer window timeout is earlier than the currently set
 // timeout.
 func (c *ChannelBuilder) updateSwTimeout(batch *derive.SingularBatch) {
-	timeout := uint64(batch.EpochNum) + c.cfg.SeqWindowSize - c.cfg.SubSafetyMargin
+	swEnd := uint64(batch.EpochNum) + c.cfg.SeqWindowSize
+	if c.seqWindowEnd == 0 || swEnd < c.seqWindowEnd {
+		c.seqWindowEnd = swEnd
+	}
+	timeout := swEnd - c.cfg.SubSafetyMargin
 	c.updateTimeout(timeout, ErrSeqWindowClose)
 }
 
+// SeqWindowEnd returns the last L1 block number at which all batches of the
+// channel can still be included, i.e., the end of the earliest sequencing
+// window of all added batches. It returns 0 if no batches got added yet.
+func (c *ChannelBuilder) SeqWindowEnd() uint64 {
+	return c.seqWindowEnd
+}
+
 // updateTimeout updates the timeout block to the given block number if it is
 // earlier than the current block timeout, or if it still unset.
 //
@@ -294,6 +332,8 @@ func (c *ChannelBuilder) IsFull() bool {
 //   - ErrMaxFrameIndex if the maximum number of frames has been generated
 //     (uint16),
 //   - ErrMaxDurationReached if the max channel duration got reached,
+//   - ErrMaxBlocksPerSpanBatch if the max number of blocks per span batch got
+//     reached,
 //   - ErrChannelTimeoutClose if the consensus channel timeout got too close,
 //   - ErrSeqWindowClose if the end of the sequencer window got too close,
 //   - ErrTerminated if the channel was explicitly terminated.
@@ -343,7 +383,10 @@ func (c *ChannelBuilder) outputReadyFrames() error {
 }
 
 func (c *ChannelBuilder) closeAndOutputAllFrames() error {
-	if err := c.co.Close(); err != nil {
+	start := time.Now()
+	err := c.co.Close()
+	c.comprDuration += time.Since(start)
+	if err != nil {
 		return fmt.Errorf("closing channel out: %w", err)
 	}
 
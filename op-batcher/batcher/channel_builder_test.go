@@ -622,6 +622,47 @@ func ChannelBuilder_AddBlock(t *testing.T, batchType uint) {
 	require.ErrorIs(t, addMiniBlock(cb), derive.ErrCompressorFull)
 }
 
+func TestChannelBuilder_MaxBlocksPerSpanBatch(t *testing.T) {
+	for _, batchType := range []uint{derive.SingularBatchType, derive.SpanBatchType} {
+		channelConfig := defaultTestChannelConfig()
+		channelConfig.BatchType = batchType
+		channelConfig.MaxBlocksPerSpanBatch = 2
+
+		cb, err := NewChannelBuilder(channelConfig, defaultTestRollupConfig, latestL1BlockOrigin)
+		require.NoError(t, err)
+
+		require.NoError(t, addMiniBlock(cb))
+		require.False(t, cb.IsFull())
+		require.NoError(t, addMiniBlock(cb))
+		if batchType == derive.SpanBatchType {
+			require.ErrorIs(t, cb.FullErr(), ErrMaxBlocksPerSpanBatch)
+			require.ErrorIs(t, addMiniBlock(cb), ErrMaxBlocksPerSpanBatch)
+			require.Len(t, cb.Blocks(), 2)
+		} else {
+			// limit doesn't apply to singular batches
+			require.False(t, cb.IsFull())
+		}
+	}
+}
+
+func TestChannelBuilder_SeqWindowEnd(t *testing.T) {
+	cfg := defaultTestChannelConfig()
+	cfg.MaxChannelDuration = 0
+	cb, err := NewChannelBuilder(cfg, defaultTestRollupConfig, latestL1BlockOrigin)
+	require.NoError(t, err)
+	require.Zero(t, cb.SeqWindowEnd())
+
+	_, err = cb.AddBlock(newMiniL2BlockWithNumberParentAndL1Information(0, big.NewInt(1), common.Hash{}, 2, 100))
+	require.NoError(t, err)
+	require.Equal(t, 2+cb.cfg.SeqWindowSize, cb.SeqWindowEnd())
+	require.Equal(t, cb.SeqWindowEnd()-cb.cfg.SubSafetyMargin, cb.Timeout())
+
+	// later epochs don't move the end of the sequencing window
+	_, err = cb.AddBlock(newMiniL2BlockWithNumberParentAndL1Information(0, big.NewInt(2), common.Hash{}, 3, 110))
+	require.NoError(t, err)
+	require.Equal(t, 2+cb.cfg.SeqWindowSize, cb.SeqWindowEnd())
+}
+
 func TestChannelBuilder_CheckTimeout(t *testing.T) {
 	channelConfig := defaultTestChannelConfig()
 
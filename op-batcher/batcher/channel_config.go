@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 type ChannelConfig struct {
@@ -23,6 +24,11 @@ type ChannelConfig struct {
 	//
 	// If 0, duration checks are disabled.
 	MaxChannelDuration uint64
+	// MaxBlocksPerSpanBatch is the maximum number of L2 blocks to add to a span
+	// batch. Only applies to the span batch type.
+	//
+	// If 0, the number of blocks is only limited by the channel size.
+	MaxBlocksPerSpanBatch int
 	// The batcher tx submission safety margin (in #L1-blocks) to subtract from
 	// a channel's timeout and sequencing window, to guarantee safe inclusion of
 	// a channel on L1.
@@ -46,6 +52,16 @@ type ChannelConfig struct {
 	// Whether to put all frames of a channel inside a single tx.
 	// Should only be used for blob transactions.
 	MultiFrameTxs bool
+
+	// UseBlobs is true if the channel's frames should be submitted as blobs,
+	// instead of as calldata.
+	UseBlobs bool
+}
+
+// ChannelConfig returns a copy of the receiver.
+// This allows the receiver to be a static ChannelConfigProvider of itself.
+func (cc ChannelConfig) ChannelConfig() ChannelConfig {
+	return cc
 }
 
 // InitCompressorConfig (re)initializes the channel configuration's compressor
@@ -90,6 +106,17 @@ func (cc *ChannelConfig) Check() error {
 		return ErrInvalidChannelTimeout
 	}
 
+	// The [SeqWindowSize] must be larger than the [SubSafetyMargin].
+	// Otherwise, channels would always be closed too late to be safely included
+	// within the sequencing window of their batches.
+	if cc.SeqWindowSize <= cc.SubSafetyMargin {
+		return ErrInvalidSeqWindowSize
+	}
+
+	if cc.MaxBlocksPerSpanBatch < 0 {
+		return fmt.Errorf("invalid max blocks per span batch %d", cc.MaxBlocksPerSpanBatch)
+	}
+
 	// The max frame size must at least be able to accommodate the constant
 	// frame overhead.
 	if cc.MaxFrameSize < derive.FrameV0OverHeadSize {
@@ -105,6 +132,12 @@ func (cc *ChannelConfig) Check() error {
 		return fmt.Errorf("invalid number of frames %d", nf)
 	}
 
+	// Each blob holds at least one full frame, so the number of frames per
+	// multi-frame tx is bounded by the number of blobs per tx.
+	if cc.MultiFrameTxs && cc.TargetNumFrames > eth.MaxBlobsPerBlobTx {
+		return fmt.Errorf("too many frames %d for multi-frame txs, max %d", cc.TargetNumFrames, eth.MaxBlobsPerBlobTx)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,136 @@
+package batcher
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DefaultDASwitchHysteresis is the relative cost advantage that the currently unused data
+// availability type needs to have over the currently used type before the batcher switches.
+// It prevents flapping between blobs and calldata when both are priced similarly.
+const DefaultDASwitchHysteresis = 0.1
+
+type (
+	// ChannelConfigProvider provides the channel config to use for a new channel.
+	ChannelConfigProvider interface {
+		ChannelConfig() ChannelConfig
+	}
+
+	GasPricer interface {
+		SuggestGasPriceCaps(ctx context.Context) (tipCap *big.Int, baseFee *big.Int, blobBaseFee *big.Int, err error)
+	}
+
+	// DynamicEthChannelConfig switches between the blob and calldata channel
+	// configs, depending on which data availability type is currently cheaper
+	// per byte of channel data on L1.
+	DynamicEthChannelConfig struct {
+		log        log.Logger
+		metr       metrics.Metricer
+		timeout    time.Duration // query timeout
+		gasPricer  GasPricer
+		hysteresis float64
+
+		blobConfig     ChannelConfig
+		calldataConfig ChannelConfig
+		lastConfig     *ChannelConfig
+	}
+)
+
+var _ ChannelConfigProvider = (*DynamicEthChannelConfig)(nil)
+
+func NewDynamicEthChannelConfig(lgr log.Logger, metr metrics.Metricer,
+	reqTimeout time.Duration, gasPricer GasPricer,
+	blobConfig ChannelConfig, calldataConfig ChannelConfig,
+) *DynamicEthChannelConfig {
+	dec := &DynamicEthChannelConfig{
+		log:            lgr,
+		metr:           metr,
+		timeout:        reqTimeout,
+		gasPricer:      gasPricer,
+		hysteresis:     DefaultDASwitchHysteresis,
+		blobConfig:     blobConfig,
+		calldataConfig: calldataConfig,
+	}
+	// start with blob config
+	dec.lastConfig = &dec.blobConfig
+	return dec
+}
+
+// ChannelConfig returns the channel config of the data availability type that
+// is currently cheaper. A switch away from the previously returned config only
+// happens if the other type is cheaper by more than the hysteresis margin.
+// If the fee query fails, the previously returned config is used.
+func (dec *DynamicEthChannelConfig) ChannelConfig() ChannelConfig {
+	ctx, cancel := context.WithTimeout(context.Background(), dec.timeout)
+	defer cancel()
+	tipCap, baseFee, blobBaseFee, err := dec.gasPricer.SuggestGasPriceCaps(ctx)
+	if err != nil {
+		dec.log.Warn("Error querying gas prices, returning last config", "err", err)
+		return *dec.lastConfig
+	} else if blobBaseFee == nil {
+		dec.log.Warn("No blob base fee available, using calldata config")
+		return dec.setConfig(&dec.calldataConfig)
+	}
+
+	costRatio := dec.blobCostRatio(tipCap, baseFee, blobBaseFee)
+	dec.metr.RecordDACostRatio(costRatio)
+
+	lgr := dec.log.New("base_fee", baseFee, "blob_base_fee", blobBaseFee, "tip_cap", tipCap,
+		"cost_ratio", costRatio, "using_blobs", dec.lastConfig.UseBlobs)
+
+	if dec.lastConfig.UseBlobs && costRatio > 1+dec.hysteresis {
+		lgr.Info("Calldata is cheaper than blobs, switching to calldata channel config")
+		return dec.setConfig(&dec.calldataConfig)
+	} else if !dec.lastConfig.UseBlobs && costRatio*(1+dec.hysteresis) < 1 {
+		lgr.Info("Blobs are cheaper than calldata, switching to blob channel config")
+		return dec.setConfig(&dec.blobConfig)
+	}
+	lgr.Debug("Keeping last channel config")
+	return dec.setConfig(dec.lastConfig)
+}
+
+func (dec *DynamicEthChannelConfig) setConfig(cfg *ChannelConfig) ChannelConfig {
+	dec.lastConfig = cfg
+	if cfg.UseBlobs {
+		dec.metr.RecordDAType(flags.BlobsType.String())
+	} else {
+		dec.metr.RecordDAType(flags.CalldataType.String())
+	}
+	return *cfg
+}
+
+// blobCostRatio returns the ratio of the cost per byte of blob data and the
+// cost per byte of calldata, under the assumption that a frame of either type
+// is filled completely and that compressed data contains no zero bytes.
+// A ratio larger than 1 means that calldata is currently cheaper.
+func (dec *DynamicEthChannelConfig) blobCostRatio(tipCap, baseFee, blobBaseFee *big.Int) float64 {
+	calldataPrice := new(big.Int).Add(baseFee, tipCap)
+
+	calldataBytes := dec.calldataConfig.MaxFrameSize + 1 // + 1 version byte
+	calldataGas := new(big.Int).SetUint64(calldataBytes*params.TxDataNonZeroGasEIP2028 + params.TxGas)
+	calldataCost := new(big.Int).Mul(calldataGas, calldataPrice)
+
+	numBlobs := int64(dec.blobConfig.MaxFramesPerTx())
+	blobGas := big.NewInt(params.BlobTxBlobGasPerBlob * numBlobs)
+	blobCost := new(big.Int).Mul(blobGas, blobBaseFee)
+	// blob txs still have to pay for the intrinsic tx gas
+	blobCost.Add(blobCost, new(big.Int).Mul(big.NewInt(int64(params.TxGas)), calldataPrice))
+	blobDataBytes := big.NewInt(eth.MaxBlobDataSize * numBlobs)
+
+	// (blobCost / blobDataBytes) / (calldataCost / calldataBytes)
+	num := new(big.Float).SetInt(new(big.Int).Mul(blobCost, new(big.Int).SetUint64(calldataBytes)))
+	denom := new(big.Float).SetInt(new(big.Int).Mul(calldataCost, blobDataBytes))
+	if denom.Sign() == 0 {
+		return 0
+	}
+	ratio, _ := new(big.Float).Quo(num, denom).Float64()
+	return ratio
+}
@@ -0,0 +1,139 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type mockGasPricer struct {
+	err         error
+	tipCap      int64
+	baseFee     int64
+	blobBaseFee int64
+}
+
+func (gp *mockGasPricer) SuggestGasPriceCaps(context.Context) (tipCap *big.Int, baseFee *big.Int, blobBaseFee *big.Int, err error) {
+	if gp.err != nil {
+		return nil, nil, nil, gp.err
+	}
+	return big.NewInt(gp.tipCap), big.NewInt(gp.baseFee), big.NewInt(gp.blobBaseFee), nil
+}
+
+func TestDynamicEthChannelConfig_ChannelConfig(t *testing.T) {
+	calldataCfg := ChannelConfig{
+		MaxFrameSize:    120_000 - 1,
+		TargetNumFrames: 1,
+	}
+	blobCfg := ChannelConfig{
+		MaxFrameSize:    130_000 - 1,
+		TargetNumFrames: 3, // gets closest to amortized fixed tx costs
+		MultiFrameTxs:   true,
+		UseBlobs:        true,
+	}
+
+	tests := []struct {
+		name         string
+		tipCap       int64
+		baseFee      int64
+		blobBaseFee  int64
+		wantCalldata bool
+	}{
+		{
+			name:        "much-cheaper-blobs",
+			tipCap:      1e3,
+			baseFee:     1e6,
+			blobBaseFee: 1,
+		},
+		{
+			name:        "close-cheaper-blobs",
+			tipCap:      1e3,
+			baseFee:     1e6,
+			blobBaseFee: 16e6, // because of amortized fixed 21000 tx cost, blobs are still cheaper here...
+		},
+		{
+			name:        "close-cheaper-calldata-within-hysteresis",
+			tipCap:      1e3,
+			baseFee:     1e6,
+			blobBaseFee: 161e5, // ...but then calldata becomes cheaper, within the hysteresis margin
+		},
+		{
+			name:         "much-cheaper-calldata",
+			tipCap:       1e3,
+			baseFee:      1e6,
+			blobBaseFee:  1e9,
+			wantCalldata: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lgr := testlog.Logger(t, log.LevelInfo)
+			gp := &mockGasPricer{
+				tipCap:      tt.tipCap,
+				baseFee:     tt.baseFee,
+				blobBaseFee: tt.blobBaseFee,
+			}
+			dec := NewDynamicEthChannelConfig(lgr, metrics.NoopMetrics, 1*time.Second, gp, blobCfg, calldataCfg)
+			exp := blobCfg
+			if tt.wantCalldata {
+				exp = calldataCfg
+			}
+			require.Equal(t, exp, dec.ChannelConfig())
+		})
+	}
+}
+
+func TestDynamicEthChannelConfig_Hysteresis(t *testing.T) {
+	calldataCfg := ChannelConfig{MaxFrameSize: 120_000 - 1, TargetNumFrames: 1}
+	blobCfg := ChannelConfig{MaxFrameSize: 130_000 - 1, TargetNumFrames: 3, MultiFrameTxs: true, UseBlobs: true}
+	lgr := testlog.Logger(t, log.LevelInfo)
+	gp := &mockGasPricer{tipCap: 1e3, baseFee: 1e6, blobBaseFee: 1e9}
+	dec := NewDynamicEthChannelConfig(lgr, metrics.NoopMetrics, 1*time.Second, gp, blobCfg, calldataCfg)
+
+	require.Equal(t, calldataCfg, dec.ChannelConfig(), "switch to calldata if much cheaper")
+
+	// Find blob base fee at which both DA types are priced equally and make blobs slightly cheaper.
+	equalFee := equalCostBlobBaseFee(gp.tipCap, gp.baseFee, calldataCfg, blobCfg)
+	gp.blobBaseFee = equalFee * 98 / 100
+	require.Equal(t, calldataCfg, dec.ChannelConfig(), "stick with calldata within hysteresis margin")
+
+	gp.blobBaseFee = equalFee / 2
+	require.Equal(t, blobCfg, dec.ChannelConfig(), "switch to blobs if much cheaper")
+
+	gp.blobBaseFee = equalFee * 102 / 100
+	require.Equal(t, blobCfg, dec.ChannelConfig(), "stick with blobs within hysteresis margin")
+}
+
+func TestDynamicEthChannelConfig_ErrorKeepsLastConfig(t *testing.T) {
+	calldataCfg := ChannelConfig{MaxFrameSize: 120_000 - 1, TargetNumFrames: 1}
+	blobCfg := ChannelConfig{MaxFrameSize: 130_000 - 1, TargetNumFrames: 3, MultiFrameTxs: true, UseBlobs: true}
+	lgr := testlog.Logger(t, log.LevelInfo)
+	gp := &mockGasPricer{tipCap: 1e3, baseFee: 1e6, blobBaseFee: 1e9}
+	dec := NewDynamicEthChannelConfig(lgr, metrics.NoopMetrics, 1*time.Second, gp, blobCfg, calldataCfg)
+
+	require.Equal(t, calldataCfg, dec.ChannelConfig())
+	gp.err = errors.New("gp-error")
+	require.Equal(t, calldataCfg, dec.ChannelConfig())
+}
+
+// equalCostBlobBaseFee returns the blob base fee at which blob and calldata
+// submission cost the same per byte of channel data.
+func equalCostBlobBaseFee(tipCap, baseFee int64, calldataCfg, blobCfg ChannelConfig) int64 {
+	price := tipCap + baseFee
+	calldataBytes := int64(calldataCfg.MaxFrameSize + 1)
+	calldataCostPerByte := float64((calldataBytes*int64(params.TxDataNonZeroGasEIP2028)+int64(params.TxGas))*price) / float64(calldataBytes)
+	numBlobs := int64(blobCfg.MaxFramesPerTx())
+	blobBytes := float64(eth.MaxBlobDataSize * numBlobs)
+	// blobBaseFee * blobGas + TxGas * price = calldataCostPerByte * blobBytes
+	return int64((calldataCostPerByte*blobBytes - float64(int64(params.TxGas)*price)) / float64(params.BlobTxBlobGasPerBlob*numBlobs))
+}
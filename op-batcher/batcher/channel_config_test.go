@@ -48,6 +48,37 @@ func TestChannelConfig_Check(t *testing.T) {
 				require.ErrorIs(t, output, ErrInvalidChannelTimeout)
 			},
 		},
+		{
+			input: func() ChannelConfig {
+				cfg := defaultTestChannelConfig()
+				cfg.SeqWindowSize = cfg.SubSafetyMargin
+				return cfg
+			},
+			assertion: func(output error) {
+				require.ErrorIs(t, output, ErrInvalidSeqWindowSize)
+			},
+		},
+		{
+			input: func() ChannelConfig {
+				cfg := defaultTestChannelConfig()
+				cfg.MaxBlocksPerSpanBatch = -1
+				return cfg
+			},
+			assertion: func(output error) {
+				require.EqualError(t, output, "invalid max blocks per span batch -1")
+			},
+		},
+		{
+			input: func() ChannelConfig {
+				cfg := defaultTestChannelConfig()
+				cfg.MultiFrameTxs = true
+				cfg.TargetNumFrames = 7
+				return cfg
+			},
+			assertion: func(output error) {
+				require.EqualError(t, output, "too many frames 7 for multi-frame txs, max 6")
+			},
+		},
 	}
 	for i := 0; i < derive.FrameV0OverHeadSize; i++ {
 		expectedErr := fmt.Sprintf("max frame size %d is less than the minimum 23", i)
@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"sync"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -17,6 +20,10 @@ import (
 
 var ErrReorg = errors.New("block does not extend existing chain")
 
+// channelHistorySize is the number of completed or timed out channels whose
+// details are kept for observability.
+const channelHistorySize = 128
+
 // channelManager stores a contiguous set of blocks & turns them into channels.
 // Upon receiving tx confirmation (or a tx failure), it does channel error handling.
 //
@@ -25,14 +32,18 @@ var ErrReorg = errors.New("block does not extend existing chain")
 // channel.
 // Public functions on channelManager are safe for concurrent access.
 type channelManager struct {
-	mu        sync.Mutex
-	log       log.Logger
-	metr      metrics.Metricer
-	cfg       ChannelConfig
-	rollupCfg *rollup.Config
+	mu          sync.Mutex
+	log         log.Logger
+	metr        metrics.Metricer
+	cfgProvider ChannelConfigProvider
+	rollupCfg   *rollup.Config
+	// gates the configured compression algorithm by hardfork activation
+	comprFactory *compressor.CompressorFactory
 
 	// All blocks since the last request for new tx data.
 	blocks []*types.Block
+	// estimated total batch size of all blocks in blocks
+	pendingDABytes int64
 	// The latest L1 block from all the L2 blocks in the most recently closed channel
 	l1OriginLastClosedChannel eth.BlockID
 	// last block hash - for reorg detection
@@ -44,18 +55,28 @@ type channelManager struct {
 	channelQueue []*channel
 	// used to lookup channels by tx ID upon tx success / failure
 	txChannels map[string]*channel
+	// highest L1 block number at which a batcher tx got confirmed
+	lastInclusionBlock uint64
+	// details of the most recent channels that got removed from the channel
+	// queue after their submission completed or timed out, oldest first
+	channelHistory []rpc.ChannelInfo
 
 	// if set to true, prevents production of any new channel frames
 	closed bool
+
+	// number of the L2 block after which the current channel is closed, so the channel is
+	// submitted without waiting for newer blocks. Zero if unset.
+	closeAfterBlock uint64
 }
 
-func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollupCfg *rollup.Config) *channelManager {
+func NewChannelManager(log log.Logger, metr metrics.Metricer, cfgProvider ChannelConfigProvider, rollupCfg *rollup.Config) *channelManager {
 	return &channelManager{
-		log:        log,
-		metr:       metr,
-		cfg:        cfg,
-		rollupCfg:  rollupCfg,
-		txChannels: make(map[string]*channel),
+		log:          log,
+		metr:         metr,
+		cfgProvider:  cfgProvider,
+		rollupCfg:    rollupCfg,
+		comprFactory: compressor.NewCompressorFactory(rollupCfg),
+		txChannels:   make(map[string]*channel),
 	}
 }
 
@@ -66,12 +87,23 @@ func (s *channelManager) Clear(l1OriginLastClosedChannel eth.BlockID) {
 	defer s.mu.Unlock()
 	s.log.Trace("clearing channel manager state")
 	s.blocks = s.blocks[:0]
+	s.pendingDABytes = 0
 	s.l1OriginLastClosedChannel = l1OriginLastClosedChannel
 	s.tip = common.Hash{}
 	s.closed = false
 	s.currentChannel = nil
 	s.channelQueue = nil
 	s.txChannels = make(map[string]*channel)
+	s.lastInclusionBlock = 0
+	s.closeAfterBlock = 0
+}
+
+// CloseChannelAfter closes the channel that the given L2 block gets added to, right after adding it,
+// so the channel is submitted without waiting for newer blocks.
+func (s *channelManager) CloseChannelAfter(blockNumber uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeAfterBlock = blockNumber
 }
 
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
@@ -96,15 +128,22 @@ func (s *channelManager) TxFailed(_id txID) {
 // a channel have been marked as confirmed on L1 the channel may be invalid & need to be
 // resubmitted.
 // This function may reset the pending channel if the pending channel has timed out.
-func (s *channelManager) TxConfirmed(_id txID, inclusionBlock eth.BlockID) {
+func (s *channelManager) TxConfirmed(_id txID, inclusionBlock eth.BlockID, txHash common.Hash) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	id := _id.String()
+	if inclusionBlock.Number > s.lastInclusionBlock {
+		s.lastInclusionBlock = inclusionBlock.Number
+	}
 	if channel, ok := s.txChannels[id]; ok {
 		delete(s.txChannels, id)
-		done, blocks := channel.TxConfirmed(id, inclusionBlock)
+		done, blocks := channel.TxConfirmed(id, inclusionBlock, txHash)
+		for _, b := range blocks {
+			s.pendingDABytes += int64(metrics.EstimateBatchSize(b))
+		}
 		s.blocks = append(blocks, s.blocks...)
 		if done {
+			s.recordChannelHistory(channel)
 			s.removePendingChannel(channel)
 		}
 	} else {
@@ -114,6 +153,117 @@ func (s *channelManager) TxConfirmed(_id txID, inclusionBlock eth.BlockID) {
 	s.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
 }
 
+// OldestBlockNumber returns the number of the oldest L2 block that the channel
+// manager still holds, either in a channel that isn't fully submitted yet or as
+// a pending block. It returns false if it holds no blocks.
+func (s *channelManager) OldestBlockNumber() (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.channelQueue {
+		// the channel queue is ordered, so the first non-empty channel holds the oldest block
+		if blocks := ch.channelBuilder.Blocks(); len(blocks) > 0 {
+			return blocks[0].NumberU64(), true
+		}
+	}
+	if len(s.blocks) > 0 {
+		return s.blocks[0].NumberU64(), true
+	}
+	return 0, false
+}
+
+// SubmissionDeadline returns the earliest submission deadline, as L1 block
+// number, of all data that isn't fully submitted yet, see
+// [channel.SubmissionDeadline]. For blocks that aren't added to a channel yet,
+// the given subSafetyMargin is subtracted from the end of their sequencing
+// window. It returns false if there's no pending data.
+func (s *channelManager) SubmissionDeadline(subSafetyMargin uint64) (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deadline uint64
+	found := false
+	for _, ch := range s.channelQueue {
+		if d, ok := ch.SubmissionDeadline(); ok && (!found || d < deadline) {
+			deadline, found = d, true
+		}
+	}
+	if len(s.blocks) > 0 {
+		// blocks are ordered, so the first block has the earliest sequencing window
+		batch, _, err := derive.BlockToSingularBatch(s.rollupCfg, s.blocks[0])
+		if err != nil {
+			s.log.Warn("Failed to get L1 origin of pending block, assuming immediate deadline", "err", err)
+			return 0, true
+		}
+		swEnd := uint64(batch.EpochNum) + s.rollupCfg.SeqWindowSize
+		d := uint64(0)
+		if swEnd > subSafetyMargin {
+			d = swEnd - subSafetyMargin
+		}
+		if !found || d < deadline {
+			deadline, found = d, true
+		}
+	}
+	return deadline, found
+}
+
+// PendingUseBlobs returns whether the next tx data is submitted as blobs. This
+// is determined by the config of the channel that the next tx data is taken
+// from, which is the oldest channel with tx data or the current channel. If a
+// new channel has to be created first, the config that it would be created
+// with is used.
+func (s *channelManager) PendingUseBlobs() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.channelQueue {
+		if ch.HasTxData() {
+			return ch.cfg.UseBlobs
+		}
+	}
+	if s.currentChannel != nil && !s.currentChannel.IsFull() {
+		return s.currentChannel.cfg.UseBlobs
+	}
+	return s.cfgProvider.ChannelConfig().UseBlobs
+}
+
+// LastInclusionBlock returns the highest L1 block number at which a batcher tx
+// got confirmed, or 0 if none got confirmed yet.
+func (s *channelManager) LastInclusionBlock() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastInclusionBlock
+}
+
+// InFlightTxs returns the number of batcher txs that were sent, but are not confirmed or failed yet.
+func (s *channelManager) InFlightTxs() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.txChannels)
+}
+
+// recordChannelHistory keeps the details of a channel that completed
+// submission or timed out, dropping the oldest recorded channel if there are
+// more than channelHistorySize.
+func (s *channelManager) recordChannelHistory(channel *channel) {
+	s.channelHistory = append(s.channelHistory, channel.Info())
+	if len(s.channelHistory) > channelHistorySize {
+		s.channelHistory = slices.Delete(s.channelHistory, 0, len(s.channelHistory)-channelHistorySize)
+	}
+}
+
+// ChannelInfos returns the details of recently completed or timed out channels
+// and of the channels in the channel queue, oldest first.
+func (s *channelManager) ChannelInfos() []rpc.ChannelInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	infos := slices.Clone(s.channelHistory)
+	for i := range infos {
+		infos[i].Txs = slices.Clone(infos[i].Txs)
+	}
+	for _, ch := range s.channelQueue {
+		infos = append(infos, ch.Info())
+	}
+	return infos
+}
+
 // removePendingChannel removes the given completed channel from the manager's state.
 func (s *channelManager) removePendingChannel(channel *channel) {
 	if s.currentChannel == channel {
@@ -133,6 +283,40 @@ func (s *channelManager) removePendingChannel(channel *channel) {
 	s.channelQueue = append(s.channelQueue[:index], s.channelQueue[index+1:]...)
 }
 
+// RequeueBlobChannels removes the most recent blob channels that have no
+// in-flight transactions from the channel queue, and puts their blocks back in
+// front of the pending blocks. The blocks are then re-encoded into new channels
+// of the current channel config, e.g. into calldata channels while blob
+// transactions fail. Already confirmed frames of a requeued channel are
+// abandoned, and dropped by the derivation pipeline once the channel times out.
+// Only a suffix of the channel queue is requeued, to keep the order of blocks.
+// It returns the number of requeued channels.
+func (s *channelManager) RequeueBlobChannels() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// a closed channel manager doesn't create new channels
+	if s.closed {
+		return 0
+	}
+	var requeued int
+	for i := len(s.channelQueue) - 1; i >= 0; i-- {
+		ch := s.channelQueue[i]
+		if !ch.cfg.UseBlobs || len(ch.pendingTransactions) > 0 {
+			break
+		}
+		blocks := ch.channelBuilder.Blocks()
+		for _, b := range blocks {
+			s.pendingDABytes += int64(metrics.EstimateBatchSize(b))
+		}
+		s.blocks = append(slices.Clone(blocks), s.blocks...)
+		s.log.Info("Requeued blocks of blob channel", "id", ch.ID(), "blocks", len(blocks),
+			"confirmed_txs", len(ch.confirmedTransactions))
+		s.removePendingChannel(ch)
+		requeued++
+	}
+	return requeued
+}
+
 // nextTxData pops off s.datas & handles updating the internal state
 func (s *channelManager) nextTxData(channel *channel) (txData, error) {
 	if channel == nil || !channel.HasTxData() {
@@ -145,11 +329,13 @@ func (s *channelManager) nextTxData(channel *channel) (txData, error) {
 }
 
 // TxData returns the next tx data that should be submitted to L1.
+// The L1 head's time is used to select a compression algorithm for new
+// channels that the derivation pipeline can decode.
 //
 // If the pending channel is
 // full, it only returns the remaining frames of this channel until it got
 // successfully fully sent to L1. It returns io.EOF if there's no pending tx data.
-func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
+func (s *channelManager) TxData(l1Head eth.L1BlockRef) (txData, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	var firstWithTxData *channel
@@ -161,7 +347,7 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 	}
 
 	dataPending := firstWithTxData != nil && firstWithTxData.HasTxData()
-	s.log.Debug("Requested tx data", "l1Head", l1Head, "txdata_pending", dataPending, "blocks_pending", len(s.blocks))
+	s.log.Debug("Requested tx data", "l1Head", l1Head.ID(), "txdata_pending", dataPending, "blocks_pending", len(s.blocks))
 
 	// Short circuit if there is pending tx data or the channel manager is closed.
 	if dataPending || s.closed {
@@ -198,12 +384,20 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 // ensureChannelWithSpace ensures currentChannel is populated with a channel that has
 // space for more data (i.e. channel.IsFull returns false). If currentChannel is nil
 // or full, a new channel is created.
-func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
+func (s *channelManager) ensureChannelWithSpace(l1Head eth.L1BlockRef) error {
 	if s.currentChannel != nil && !s.currentChannel.IsFull() {
 		return nil
 	}
 
-	pc, err := newChannel(s.log, s.metr, s.cfg, s.rollupCfg, s.l1OriginLastClosedChannel.Number)
+	// Each new channel picks up the latest channel config, which may switch the
+	// data availability type depending on current L1 fee market conditions.
+	cfg := s.cfgProvider.ChannelConfig()
+	if comprCfg := s.comprFactory.Config(cfg.CompressorConfig, l1Head.Time); comprCfg.CompressionAlgo != cfg.CompressorConfig.CompressionAlgo {
+		s.log.Warn("Configured compression algorithm not decodable yet, using fallback",
+			"configured", cfg.CompressorConfig.CompressionAlgo, "fallback", comprCfg.CompressionAlgo, "l1_time", l1Head.Time)
+		cfg.CompressorConfig = comprCfg
+	}
+	pc, err := newChannel(s.log, s.metr, cfg, s.rollupCfg, s.l1OriginLastClosedChannel.Number)
 	if err != nil {
 		return fmt.Errorf("creating new channel: %w", err)
 	}
@@ -213,13 +407,14 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 
 	s.log.Info("Created channel",
 		"id", pc.ID(),
-		"l1Head", l1Head,
+		"l1Head", l1Head.ID(),
 		"l1OriginLastClosedChannel", s.l1OriginLastClosedChannel,
 		"blocks_pending", len(s.blocks),
-		"batch_type", s.cfg.BatchType,
-		"compression_algo", s.cfg.CompressorConfig.CompressionAlgo,
-		"target_num_frames", s.cfg.TargetNumFrames,
-		"max_frame_size", s.cfg.MaxFrameSize,
+		"batch_type", cfg.BatchType,
+		"compression_algo", cfg.CompressorConfig.CompressionAlgo,
+		"target_num_frames", cfg.TargetNumFrames,
+		"max_frame_size", cfg.MaxFrameSize,
+		"use_blobs", cfg.UseBlobs,
 	)
 	s.metr.RecordChannelOpened(pc.ID(), len(s.blocks))
 
@@ -227,10 +422,10 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 }
 
 // registerL1Block registers the given block at the pending channel.
-func (s *channelManager) registerL1Block(l1Head eth.BlockID) {
+func (s *channelManager) registerL1Block(l1Head eth.L1BlockRef) {
 	s.currentChannel.CheckTimeout(l1Head.Number)
 	s.log.Debug("new L1-block registered at channel builder",
-		"l1Head", l1Head,
+		"l1Head", l1Head.ID(),
 		"channel_full", s.currentChannel.IsFull(),
 		"full_reason", s.currentChannel.FullErr(),
 	)
@@ -255,8 +450,14 @@ func (s *channelManager) processBlocks() error {
 		s.log.Debug("Added block to channel", "id", s.currentChannel.ID(), "block", eth.ToBlockID(block))
 
 		blocksAdded += 1
+		s.pendingDABytes -= int64(metrics.EstimateBatchSize(block))
 		latestL2ref = l2BlockRefFromBlockAndL1Info(block, l1info)
 		s.metr.RecordL2BlockInChannel(block)
+		if s.closeAfterBlock != 0 && block.NumberU64() == s.closeAfterBlock {
+			s.log.Info("Closing channel after block", "id", s.currentChannel.ID(), "block", eth.ToBlockID(block))
+			s.currentChannel.Close()
+			s.closeAfterBlock = 0
+		}
 		// current block got added but channel is now full
 		if s.currentChannel.IsFull() {
 			break
@@ -308,6 +509,12 @@ func (s *channelManager) outputFrames() error {
 		outBytes,
 		s.currentChannel.FullErr(),
 	)
+	s.metr.RecordChannelCompression(
+		s.currentChannel.CompressionAlgo(),
+		inBytes,
+		outBytes,
+		s.currentChannel.CompressionDuration(),
+	)
 
 	var comprRatio float64
 	if inBytes > 0 {
@@ -326,6 +533,8 @@ func (s *channelManager) outputFrames() error {
 		"latest_l2", s.currentChannel.LatestL2(),
 		"full_reason", s.currentChannel.FullErr(),
 		"compr_ratio", comprRatio,
+		"compression_algo", s.currentChannel.CompressionAlgo(),
+		"compr_duration", s.currentChannel.CompressionDuration(),
 		"latest_l1_origin", s.l1OriginLastClosedChannel,
 	)
 	return nil
@@ -343,11 +552,21 @@ func (s *channelManager) AddL2Block(block *types.Block) error {
 
 	s.metr.RecordL2BlockInPendingQueue(block)
 	s.blocks = append(s.blocks, block)
+	s.pendingDABytes += int64(metrics.EstimateBatchSize(block))
 	s.tip = block.Hash()
 
 	return nil
 }
 
+// PendingDABytes returns the estimated total batch size of all L2 blocks that
+// haven't been added to a channel yet. It is used to detect a growing backlog
+// of data that still has to be submitted to L1.
+func (s *channelManager) PendingDABytes() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pendingDABytes
+}
+
 func l2BlockRefFromBlockAndL1Info(block *types.Block, l1info *derive.L1BlockInfo) eth.L2BlockRef {
 	return eth.L2BlockRef{
 		Hash:           block.Hash(),
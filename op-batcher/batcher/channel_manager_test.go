@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
@@ -103,9 +105,9 @@ func ChannelManagerReturnsErrReorgWhenDrained(t *testing.T, batchType uint) {
 
 	require.NoError(t, m.AddL2Block(a))
 
-	_, err := m.TxData(eth.BlockID{})
+	_, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(t, err)
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(t, err, io.EOF)
 
 	require.ErrorIs(t, m.AddL2Block(x), ErrReorg)
@@ -139,14 +141,14 @@ func ChannelManager_Clear(t *testing.T, batchType uint) {
 	// Add a block to the channel manager
 	a := derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)
 	newL1Tip := a.Hash()
-	l1BlockID := eth.BlockID{
+	l1BlockRef := eth.L1BlockRef{
 		Hash:   a.Hash(),
 		Number: a.NumberU64(),
 	}
 	require.NoError(m.AddL2Block(a))
 
 	// Make sure there is a channel
-	require.NoError(m.ensureChannelWithSpace(l1BlockID))
+	require.NoError(m.ensureChannelWithSpace(l1BlockRef))
 	require.NotNil(m.currentChannel)
 	require.Len(m.currentChannel.confirmedTransactions, 0)
 
@@ -202,7 +204,7 @@ func ChannelManager_TxResend(t *testing.T, batchType uint) {
 
 	require.NoError(m.AddL2Block(a))
 
-	txdata0, err := m.TxData(eth.BlockID{})
+	txdata0, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err)
 	txdata0bytes := txdata0.CallData()
 	data0 := make([]byte, len(txdata0bytes))
@@ -210,13 +212,13 @@ func ChannelManager_TxResend(t *testing.T, batchType uint) {
 	copy(data0, txdata0bytes)
 
 	// ensure channel is drained
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF)
 
 	// requeue frame
 	m.TxFailed(txdata0.ID())
 
-	txdata1, err := m.TxData(eth.BlockID{})
+	txdata1, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err)
 
 	data1 := txdata1.CallData()
@@ -245,7 +247,7 @@ func ChannelManagerCloseBeforeFirstUse(t *testing.T, batchType uint) {
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to contain no tx data")
 }
 
@@ -266,12 +268,12 @@ func ChannelManagerCloseNoPendingChannel(t *testing.T, batchType uint) {
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	txdata, err := m.TxData(eth.BlockID{})
+	txdata, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to return valid tx data")
 
-	m.TxConfirmed(txdata.ID(), eth.BlockID{})
+	m.TxConfirmed(txdata.ID(), eth.BlockID{}, common.Hash{})
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected channel manager to EOF")
 
 	require.NoError(m.Close(), "Expected to close channel manager gracefully")
@@ -279,7 +281,7 @@ func ChannelManagerCloseNoPendingChannel(t *testing.T, batchType uint) {
 	err = m.AddL2Block(b)
 	require.NoError(err, "Failed to add L2 block")
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to return no new tx data")
 }
 
@@ -303,24 +305,24 @@ func ChannelManagerClosePendingChannel(t *testing.T, batchType uint) {
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	txdata, err := m.TxData(eth.BlockID{})
+	txdata, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to produce valid tx data")
 	log.Info("generated first tx data", "len", txdata.Len())
 
-	m.TxConfirmed(txdata.ID(), eth.BlockID{})
+	m.TxConfirmed(txdata.ID(), eth.BlockID{}, common.Hash{})
 
 	require.ErrorIs(m.Close(), ErrPendingAfterClose, "Expected channel manager to error on close because of pending tx data")
 
-	txdata, err = m.TxData(eth.BlockID{})
+	txdata, err = m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to produce tx data from remaining L2 block data")
 	log.Info("generated more tx data", "len", txdata.Len())
 
-	m.TxConfirmed(txdata.ID(), eth.BlockID{})
+	m.TxConfirmed(txdata.ID(), eth.BlockID{}, common.Hash{})
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected channel manager to have no more tx data")
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
 
@@ -364,27 +366,27 @@ func TestChannelManager_Close_PartiallyPendingChannel(t *testing.T) {
 	// The NonCompressor will flush the first, but not the second block, when
 	// adding the second block, setting up the test with a partially flushed
 	// compressor.
-	txdata, err := m.TxData(eth.BlockID{})
+	txdata, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to produce valid tx data")
 	log.Info("generated first tx data", "len", txdata.Len())
 
-	m.TxConfirmed(txdata.ID(), eth.BlockID{})
+	m.TxConfirmed(txdata.ID(), eth.BlockID{}, common.Hash{})
 
 	// ensure no new ready data before closing
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected unclosed channel manager to only return a single frame")
 
 	require.ErrorIs(m.Close(), ErrPendingAfterClose, "Expected channel manager to error on close because of pending tx data")
 	require.NotNil(m.currentChannel)
 	require.ErrorIs(m.currentChannel.FullErr(), ErrTerminated, "Expected current channel to be terminated by Close")
 
-	txdata, err = m.TxData(eth.BlockID{})
+	txdata, err = m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to produce tx data from remaining L2 block data")
 	log.Info("generated more tx data", "len", txdata.Len())
 
-	m.TxConfirmed(txdata.ID(), eth.BlockID{})
+	m.TxConfirmed(txdata.ID(), eth.BlockID{}, common.Hash{})
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
 
@@ -408,7 +410,7 @@ func ChannelManagerCloseAllTxsFailed(t *testing.T, batchType uint) {
 
 	drainTxData := func() (txdatas []txData) {
 		for {
-			txdata, err := m.TxData(eth.BlockID{})
+			txdata, err := m.TxData(eth.L1BlockRef{})
 			if err == io.EOF {
 				return
 			}
@@ -436,7 +438,7 @@ func ChannelManagerCloseAllTxsFailed(t *testing.T, batchType uint) {
 
 	require.NoError(m.Close(), "Expected to close channel manager gracefully")
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
 
@@ -476,10 +478,189 @@ func TestChannelManager_ChannelCreation(t *testing.T) {
 			m.l1OriginLastClosedChannel = test.safeL1Block
 			require.Nil(t, m.currentChannel)
 
-			require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+			require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
 
 			require.NotNil(t, m.currentChannel)
 			require.Equal(t, test.expectedChannelTimeout, m.currentChannel.Timeout())
 		})
 	}
 }
+
+func TestChannelManager_CompressionAlgoGating(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(1000, derive.SpanBatchType)
+	cfg.InitShadowCompressor(derive.Brotli10)
+	fjordTime := uint64(1000)
+	rollupCfg := defaultTestRollupConfig
+	rollupCfg.FjordTime = &fjordTime
+
+	for _, tt := range []struct {
+		name         string
+		l1Time       uint64
+		expectedAlgo derive.CompressionAlgo
+	}{
+		{name: "pre-fjord", l1Time: fjordTime - 1, expectedAlgo: derive.Zlib},
+		{name: "at-fjord", l1Time: fjordTime, expectedAlgo: derive.Brotli10},
+		{name: "post-fjord", l1Time: fjordTime + 12, expectedAlgo: derive.Brotli10},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewChannelManager(l, metrics.NoopMetrics, cfg, &rollupCfg)
+			require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{Time: tt.l1Time}))
+			require.Equal(t, tt.expectedAlgo, m.currentChannel.CompressionAlgo())
+		})
+	}
+}
+
+func TestChannelManager_ChannelInfos(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(10_000, derive.SingularBatchType)
+	cfg.ChannelTimeout = 1000
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear(eth.BlockID{})
+	require.Empty(m.ChannelInfos())
+
+	block := newMiniL2BlockWithNumberParent(0, big.NewInt(5), common.Hash{})
+	require.NoError(m.AddL2Block(block))
+	_, err := m.TxData(eth.L1BlockRef{})
+	require.ErrorIs(err, io.EOF)
+
+	infos := m.ChannelInfos()
+	require.Len(infos, 1)
+	require.Equal(rpc.ChannelOpen, infos[0].Status)
+	require.Equal(1, infos[0].NumBlocks)
+	require.Equal(eth.ToBlockID(block), infos[0].FirstBlock)
+	require.Equal(eth.ToBlockID(block), infos[0].LastBlock)
+	require.True(infos[0].ContainsBlock(5))
+	require.False(infos[0].ContainsBlock(6))
+
+	m.currentChannel.Close()
+	require.NoError(m.outputFrames())
+	txdata, err := m.TxData(eth.L1BlockRef{})
+	require.NoError(err)
+	infos = m.ChannelInfos()
+	require.Len(infos, 1)
+	require.Equal(rpc.ChannelSubmitting, infos[0].Status)
+	require.Equal(1, infos[0].NumFrames)
+	require.Positive(infos[0].OutputBytes)
+	require.Positive(infos[0].ComprRatio)
+
+	inclusionBlock := eth.BlockID{Number: 10, Hash: common.Hash{0x0a}}
+	m.TxConfirmed(txdata.ID(), inclusionBlock, common.Hash{0x01})
+	require.Empty(m.channelQueue)
+	infos = m.ChannelInfos()
+	require.Len(infos, 1)
+	require.Equal(rpc.ChannelFullySubmitted, infos[0].Status)
+	require.Equal([]rpc.ChannelTx{{TxHash: common.Hash{0x01}, InclusionBlock: inclusionBlock}}, infos[0].Txs)
+
+	// history is bounded
+	for i := 0; i < channelHistorySize; i++ {
+		m.channelHistory = append(m.channelHistory, rpc.ChannelInfo{NumBlocks: i})
+	}
+	ch, err := newChannel(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig, 0)
+	require.NoError(err)
+	m.recordChannelHistory(ch)
+	require.Len(m.ChannelInfos(), channelHistorySize)
+	require.Equal(1, m.ChannelInfos()[0].NumBlocks)
+}
+
+func TestChannelManager_RequeueBlobChannels(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LevelCrit)
+	calldataCfg := channelManagerTestConfig(10_000, derive.SingularBatchType)
+	blobCfg := channelManagerTestConfig(10_000, derive.SingularBatchType)
+	blobCfg.UseBlobs = true
+	rc, err := NewRuntimeChannelConfig(log, flags.BlobsType, map[flags.DataAvailabilityType]ChannelConfigProvider{
+		flags.CalldataType: calldataCfg,
+		flags.BlobsType:    blobCfg,
+	})
+	require.NoError(err)
+	m := NewChannelManager(log, metrics.NoopMetrics, rc, &defaultTestRollupConfig)
+	m.Clear(eth.BlockID{})
+
+	a := newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{})
+	require.NoError(m.AddL2Block(a))
+	_, err = m.TxData(eth.L1BlockRef{})
+	require.ErrorIs(err, io.EOF)
+	m.currentChannel.Close()
+	require.NoError(m.outputFrames())
+	txdata, err := m.TxData(eth.L1BlockRef{})
+	require.NoError(err)
+	require.True(txdata.asBlob)
+
+	require.Zero(m.RequeueBlobChannels(), "channel has an in-flight tx")
+	m.TxFailed(txdata.ID())
+
+	require.NoError(rc.SetCalldataEmergency(true))
+	require.Equal(1, m.RequeueBlobChannels())
+	require.Empty(m.channelQueue)
+	require.Nil(m.currentChannel)
+	require.Equal([]*types.Block{a}, m.blocks)
+	require.Equal(int64(metrics.EstimateBatchSize(a)), m.PendingDABytes())
+
+	_, err = m.TxData(eth.L1BlockRef{})
+	require.ErrorIs(err, io.EOF)
+	m.currentChannel.Close()
+	require.NoError(m.outputFrames())
+	txdata, err = m.TxData(eth.L1BlockRef{})
+	require.NoError(err)
+	require.False(txdata.asBlob, "blocks are re-encoded as calldata")
+	require.Zero(m.RequeueBlobChannels(), "calldata channels are not requeued")
+}
+
+func TestChannelManager_CloseChannelAfter(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(10_000, derive.SingularBatchType)
+	cfg.ChannelTimeout = 1000
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear(eth.BlockID{})
+	m.CloseChannelAfter(2)
+
+	a := newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{})
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(2), a.Hash())
+	c := newMiniL2BlockWithNumberParent(0, big.NewInt(3), b.Hash())
+	for _, block := range []*types.Block{a, b, c} {
+		require.NoError(m.AddL2Block(block))
+	}
+
+	// the channel is submitted right away, without the block after the closing block
+	_, err := m.TxData(eth.L1BlockRef{})
+	require.NoError(err)
+	infos := m.ChannelInfos()
+	require.Len(infos, 1)
+	require.Equal(rpc.ChannelSubmitting, infos[0].Status)
+	require.Equal(eth.ToBlockID(b), infos[0].LastBlock)
+	require.Equal([]*types.Block{c}, m.blocks)
+	require.Zero(m.closeAfterBlock)
+}
+
+func TestChannelManager_PendingUseBlobs(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LevelCrit)
+	calldataCfg := channelManagerTestConfig(10_000, derive.SingularBatchType)
+	blobCfg := channelManagerTestConfig(10_000, derive.SingularBatchType)
+	blobCfg.UseBlobs = true
+	rc, err := NewRuntimeChannelConfig(log, flags.BlobsType, map[flags.DataAvailabilityType]ChannelConfigProvider{
+		flags.CalldataType: calldataCfg,
+		flags.BlobsType:    blobCfg,
+	})
+	require.NoError(err)
+	m := NewChannelManager(log, metrics.NoopMetrics, rc, &defaultTestRollupConfig)
+	m.Clear(eth.BlockID{})
+	require.True(m.PendingUseBlobs(), "new channel")
+
+	require.NoError(m.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{})))
+	_, err = m.TxData(eth.L1BlockRef{})
+	require.ErrorIs(err, io.EOF)
+	require.NoError(rc.SetCalldataEmergency(true))
+	require.True(m.PendingUseBlobs(), "current channel uses blobs")
+
+	m.currentChannel.Close()
+	require.NoError(m.outputFrames())
+	require.True(m.PendingUseBlobs(), "pending frames of blob channel")
+
+	_, err = m.TxData(eth.L1BlockRef{})
+	require.NoError(err)
+	require.False(m.PendingUseBlobs(), "next channel uses calldata")
+}
@@ -40,7 +40,7 @@ func TestChannelTimeout(t *testing.T) {
 	require.Nil(t, m.currentChannel)
 
 	// Set the pending channel
-	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
 	channel := m.currentChannel
 	require.NotNil(t, channel)
 
@@ -88,7 +88,7 @@ func TestChannelManager_NextTxData(t *testing.T) {
 	// Set the pending channel
 	// The nextTxData function should still return EOF
 	// since the pending channel has no frames
-	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
 	channel := m.currentChannel
 	require.NotNil(t, channel)
 	returnedTxData, err = m.nextTxData(channel)
@@ -222,7 +222,7 @@ func TestChannelTxConfirmed(t *testing.T) {
 
 	// Let's add a valid pending transaction to the channel manager
 	// So we can demonstrate that TxConfirmed's correctness
-	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
 	channelID := m.currentChannel.ID()
 	frame := frameData{
 		data: []byte{},
@@ -249,14 +249,14 @@ func TestChannelTxConfirmed(t *testing.T) {
 	require.NotEqual(t, actualChannelID, unknownChannelID)
 	unknownTxID := singleFrameTxID(unknownChannelID, 0)
 	blockID := eth.BlockID{Number: 0, Hash: common.Hash{0x69}}
-	m.TxConfirmed(unknownTxID, blockID)
+	m.TxConfirmed(unknownTxID, blockID, common.Hash{})
 	require.Empty(t, m.currentChannel.confirmedTransactions)
 	require.Len(t, m.currentChannel.pendingTransactions, 1)
 
 	// Now let's mark the pending transaction as confirmed
 	// and check that it is removed from the pending transactions map
 	// and added to the confirmed transactions map
-	m.TxConfirmed(expectedChannelID, blockID)
+	m.TxConfirmed(expectedChannelID, blockID, common.Hash{})
 	require.Empty(t, m.currentChannel.pendingTransactions)
 	require.Len(t, m.currentChannel.confirmedTransactions, 1)
 	require.Equal(t, blockID, m.currentChannel.confirmedTransactions[expectedChannelID.String()])
@@ -273,7 +273,7 @@ func TestChannelTxFailed(t *testing.T) {
 
 	// Let's add a valid pending transaction to the channel
 	// manager so we can demonstrate correctness
-	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.NoError(t, m.ensureChannelWithSpace(eth.L1BlockRef{}))
 	channelID := m.currentChannel.ID()
 	frame := frameData{
 		data: []byte{},
@@ -12,10 +12,12 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -39,6 +41,10 @@ type CLIConfig struct {
 	// If 0, duration checks are disabled.
 	MaxChannelDuration uint64
 
+	// MaxBlocksPerSpanBatch is the maximum number of L2 blocks to add to a span
+	// batch. If 0, the number of blocks is only limited by the channel size.
+	MaxBlocksPerSpanBatch int
+
 	// The batcher tx submission safety margin (in #L1-blocks) to subtract from
 	// a channel's timeout and sequencing window, to guarantee safe inclusion of
 	// a channel on L1.
@@ -68,7 +74,7 @@ type CLIConfig struct {
 	// Type of compressor to use. Must be one of [compressor.KindKeys].
 	Compressor string
 
-	// Type of compression algorithm to use. Must be one of [zlib, brotli, brotli[9-11]]
+	// Type of compression algorithm to use. Must be one of [zlib, zlib-(1|6|9), brotli, brotli-(9|10|11)]
 	CompressionAlgo derive.CompressionAlgo
 
 	// If Stopped is true, the batcher starts stopped and won't start batching right away.
@@ -95,12 +101,58 @@ type CLIConfig struct {
 	// ActiveSequencerCheckDuration is the duration between checks to determine the active sequencer endpoint.
 	ActiveSequencerCheckDuration time.Duration
 
+	// L2HeadSubscription enables subscribing to sync status updates of the rollup node,
+	// to load new blocks right away instead of waiting for the next poll interval.
+	L2HeadSubscription bool
+
+	// DryRun enables building and compressing channels without submitting them
+	// to L1. Instead, the batcher reports what submitting them would have cost.
+	DryRun bool
+
+	// ChainsConfig is the path to a JSON file with additional chains to submit
+	// batches for, see ChainConfig. If empty, only a single chain is batched.
+	ChainsConfig string
+
+	// ThrottleThreshold is the number of pending L2 block bytes above which the batcher throttles
+	// the DA size of new blocks at the sequencer's execution engine. 0 disables throttling.
+	ThrottleThreshold uint64
+	// ThrottleTxSize is the max DA size of a single tx in a new block while throttling.
+	ThrottleTxSize uint64
+	// ThrottleBlockSize is the max total DA size of a new block while throttling.
+	ThrottleBlockSize uint64
+	// ThrottleAlwaysBlockSize is the max total DA size of a new block while not throttling.
+	ThrottleAlwaysBlockSize uint64
+
+	// FeeSchedulerBaseFeePercentile is the percentile (0-100) of the L1 base fees
+	// of the last FeeSchedulerWindow L1 blocks, above which non-urgent batch
+	// submissions are delayed. 0 disables delaying by base fee.
+	FeeSchedulerBaseFeePercentile float64
+	// FeeSchedulerBlobFeePercentile is the equivalent of FeeSchedulerBaseFeePercentile
+	// for the L1 blob base fee, which is only considered when submitting blobs.
+	FeeSchedulerBlobFeePercentile float64
+	// FeeSchedulerWindow is the number of recent L1 blocks whose fees the fee
+	// scheduler percentiles are calculated over.
+	FeeSchedulerWindow uint64
+
+	// BlobFailureThreshold is the number of consecutive failed blob transactions,
+	// after which the batcher enters the blob emergency mode: pending channel data
+	// is re-encoded and submitted as calldata. 0 disables the emergency mode.
+	BlobFailureThreshold uint64
+	// BlobEmergencyDuration is how long the batcher stays in the blob emergency
+	// mode, before it tries to submit blobs again.
+	BlobEmergencyDuration time.Duration
+
 	TxMgrConfig   txmgr.CLIConfig
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
+	TracingConfig optracing.CLIConfig
 	RPC           oprpc.CLIConfig
 	PlasmaDA      plasma.CLIConfig
+
+	// PlasmaFallbackToL1 enables posting batch data directly to L1 calldata if
+	// it cannot be posted to the Plasma DA server.
+	PlasmaFallbackToL1 bool
 }
 
 func (c *CLIConfig) Check() error {
@@ -116,6 +168,13 @@ func (c *CLIConfig) Check() error {
 	if strings.Count(c.RollupRpc, ",") != strings.Count(c.L2EthRpc, ",") {
 		return errors.New("number of rollup and eth URLs must match")
 	}
+	if c.L2HeadSubscription {
+		for _, url := range strings.Split(c.RollupRpc, ",") {
+			if !strings.HasPrefix(url, "ws") {
+				return fmt.Errorf("L2 head subscription requires websocket rollup RPC URL: %q", url)
+			}
+		}
+	}
 	if c.PollInterval == 0 {
 		return errors.New("must set PollInterval")
 	}
@@ -137,8 +196,23 @@ func (c *CLIConfig) Check() error {
 	if c.CheckRecentTxsDepth > 128 {
 		return fmt.Errorf("CheckRecentTxsDepth cannot be set higher than 128: %v", c.CheckRecentTxsDepth)
 	}
-	if c.DataAvailabilityType == flags.BlobsType && c.TargetNumFrames > 6 {
-		return errors.New("too many frames for blob transactions, max 6")
+	if (c.DataAvailabilityType == flags.BlobsType || c.DataAvailabilityType == flags.AutoType) && c.TargetNumFrames > eth.MaxBlobsPerBlobTx {
+		return fmt.Errorf("too many frames for blob transactions, max %d", eth.MaxBlobsPerBlobTx)
+	}
+	if c.ThrottleThreshold > 0 && c.ThrottleBlockSize < c.ThrottleTxSize {
+		return fmt.Errorf("throttle block size %d must not be smaller than throttle tx size %d", c.ThrottleBlockSize, c.ThrottleTxSize)
+	}
+	if c.FeeSchedulerBaseFeePercentile < 0 || c.FeeSchedulerBaseFeePercentile > 100 {
+		return fmt.Errorf("fee scheduler base fee percentile %v must be in [0, 100]", c.FeeSchedulerBaseFeePercentile)
+	}
+	if c.FeeSchedulerBlobFeePercentile < 0 || c.FeeSchedulerBlobFeePercentile > 100 {
+		return fmt.Errorf("fee scheduler blob fee percentile %v must be in [0, 100]", c.FeeSchedulerBlobFeePercentile)
+	}
+	if (c.FeeSchedulerBaseFeePercentile > 0 || c.FeeSchedulerBlobFeePercentile > 0) && c.FeeSchedulerWindow == 0 {
+		return errors.New("fee scheduler window must be larger than 0")
+	}
+	if c.BlobFailureThreshold > 0 && c.BlobEmergencyDuration <= 0 {
+		return errors.New("blob emergency duration must be larger than 0")
 	}
 	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
 		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
@@ -149,6 +223,9 @@ func (c *CLIConfig) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.TracingConfig.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -169,24 +246,39 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		PollInterval:    ctx.Duration(flags.PollIntervalFlag.Name),
 
 		/* Optional Flags */
-		MaxPendingTransactions:       ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
-		MaxChannelDuration:           ctx.Uint64(flags.MaxChannelDurationFlag.Name),
-		MaxL1TxSize:                  ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
-		TargetNumFrames:              ctx.Int(flags.TargetNumFramesFlag.Name),
-		ApproxComprRatio:             ctx.Float64(flags.ApproxComprRatioFlag.Name),
-		Compressor:                   ctx.String(flags.CompressorFlag.Name),
-		CompressionAlgo:              derive.CompressionAlgo(ctx.String(flags.CompressionAlgoFlag.Name)),
-		Stopped:                      ctx.Bool(flags.StoppedFlag.Name),
-		WaitNodeSync:                 ctx.Bool(flags.WaitNodeSyncFlag.Name),
-		CheckRecentTxsDepth:          ctx.Int(flags.CheckRecentTxsDepthFlag.Name),
-		BatchType:                    ctx.Uint(flags.BatchTypeFlag.Name),
-		DataAvailabilityType:         flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
-		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
-		TxMgrConfig:                  txmgr.ReadCLIConfig(ctx),
-		LogConfig:                    oplog.ReadCLIConfig(ctx),
-		MetricsConfig:                opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:                  oppprof.ReadCLIConfig(ctx),
-		RPC:                          oprpc.ReadCLIConfig(ctx),
-		PlasmaDA:                     plasma.ReadCLIConfig(ctx),
+		MaxPendingTransactions:        ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
+		MaxChannelDuration:            ctx.Uint64(flags.MaxChannelDurationFlag.Name),
+		MaxBlocksPerSpanBatch:         ctx.Int(flags.MaxBlocksPerSpanBatchFlag.Name),
+		MaxL1TxSize:                   ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
+		TargetNumFrames:               ctx.Int(flags.TargetNumFramesFlag.Name),
+		ApproxComprRatio:              ctx.Float64(flags.ApproxComprRatioFlag.Name),
+		Compressor:                    ctx.String(flags.CompressorFlag.Name),
+		CompressionAlgo:               derive.CompressionAlgo(ctx.String(flags.CompressionAlgoFlag.Name)),
+		Stopped:                       ctx.Bool(flags.StoppedFlag.Name),
+		WaitNodeSync:                  ctx.Bool(flags.WaitNodeSyncFlag.Name),
+		CheckRecentTxsDepth:           ctx.Int(flags.CheckRecentTxsDepthFlag.Name),
+		BatchType:                     ctx.Uint(flags.BatchTypeFlag.Name),
+		DataAvailabilityType:          flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
+		ActiveSequencerCheckDuration:  ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
+		L2HeadSubscription:            ctx.Bool(flags.L2HeadSubscriptionFlag.Name),
+		ChainsConfig:                  ctx.String(flags.ChainsConfigFlag.Name),
+		DryRun:                        ctx.Bool(flags.DryRunFlag.Name),
+		ThrottleThreshold:             ctx.Uint64(flags.ThrottleThresholdFlag.Name),
+		ThrottleTxSize:                ctx.Uint64(flags.ThrottleTxSizeFlag.Name),
+		ThrottleBlockSize:             ctx.Uint64(flags.ThrottleBlockSizeFlag.Name),
+		ThrottleAlwaysBlockSize:       ctx.Uint64(flags.ThrottleAlwaysBlockSizeFlag.Name),
+		FeeSchedulerBaseFeePercentile: ctx.Float64(flags.FeeSchedulerBaseFeePercentileFlag.Name),
+		FeeSchedulerBlobFeePercentile: ctx.Float64(flags.FeeSchedulerBlobFeePercentileFlag.Name),
+		FeeSchedulerWindow:            ctx.Uint64(flags.FeeSchedulerWindowFlag.Name),
+		BlobFailureThreshold:          ctx.Uint64(flags.BlobFailureThresholdFlag.Name),
+		BlobEmergencyDuration:         ctx.Duration(flags.BlobEmergencyDurationFlag.Name),
+		TxMgrConfig:                   txmgr.ReadCLIConfig(ctx),
+		LogConfig:                     oplog.ReadCLIConfig(ctx),
+		MetricsConfig:                 opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:                   oppprof.ReadCLIConfig(ctx),
+		TracingConfig:                 optracing.ReadCLIConfig(ctx),
+		RPC:                           oprpc.ReadCLIConfig(ctx),
+		PlasmaDA:                      plasma.ReadCLIConfig(ctx),
+		PlasmaFallbackToL1:            ctx.Bool(flags.PlasmaFallbackToL1Flag.Name),
 	}
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +36,7 @@ func validBatcherConfig() batcher.CLIConfig {
 		LogConfig:              log.DefaultCLIConfig(),
 		MetricsConfig:          metrics.DefaultCLIConfig(),
 		PprofConfig:            oppprof.DefaultCLIConfig(),
+		TracingConfig:          optracing.DefaultCLIConfig(),
 		// The compressor config is not checked in config.Check()
 		RPC:             rpc.DefaultCLIConfig(),
 		CompressionAlgo: derive.Zlib,
@@ -44,6 +46,10 @@ func validBatcherConfig() batcher.CLIConfig {
 func TestValidBatcherConfig(t *testing.T) {
 	cfg := validBatcherConfig()
 	require.NoError(t, cfg.Check(), "valid config should pass the check function")
+
+	cfg.L2HeadSubscription = true
+	cfg.RollupRpc = "ws://localhost:8547"
+	require.NoError(t, cfg.Check(), "L2 head subscription with websocket URL should pass the check function")
 }
 
 func TestBatcherConfig(t *testing.T) {
@@ -67,6 +73,14 @@ func TestBatcherConfig(t *testing.T) {
 			override:  func(c *batcher.CLIConfig) { c.RollupRpc = "" },
 			errString: "empty rollup RPC URL",
 		},
+		{
+			name: "L2 head subscription without websocket",
+			override: func(c *batcher.CLIConfig) {
+				c.L2HeadSubscription = true
+				c.RollupRpc = "http://localhost:8547"
+			},
+			errString: "L2 head subscription requires websocket rollup RPC URL: \"http://localhost:8547\"",
+		},
 		{
 			name:      "empty poll interval",
 			override:  func(c *batcher.CLIConfig) { c.PollInterval = 0 },
@@ -113,6 +127,33 @@ func TestBatcherConfig(t *testing.T) {
 			},
 			errString: "invalid ApproxComprRatio 4.2 for ratio compressor",
 		},
+		{
+			name: "throttle block size smaller than tx size",
+			override: func(c *batcher.CLIConfig) {
+				c.ThrottleThreshold = 1000
+				c.ThrottleTxSize = 500
+				c.ThrottleBlockSize = 400
+			},
+			errString: "throttle block size 400 must not be smaller than throttle tx size 500",
+		},
+		{
+			name:      "fee scheduler base fee percentile too large",
+			override:  func(c *batcher.CLIConfig) { c.FeeSchedulerBaseFeePercentile = 101 },
+			errString: "fee scheduler base fee percentile 101 must be in [0, 100]",
+		},
+		{
+			name:      "negative fee scheduler blob fee percentile",
+			override:  func(c *batcher.CLIConfig) { c.FeeSchedulerBlobFeePercentile = -1 },
+			errString: "fee scheduler blob fee percentile -1 must be in [0, 100]",
+		},
+		{
+			name: "zero fee scheduler window",
+			override: func(c *batcher.CLIConfig) {
+				c.FeeSchedulerBlobFeePercentile = 90
+				c.FeeSchedulerWindow = 0
+			},
+			errString: "fee scheduler window must be larger than 0",
+		},
 	}
 
 	for _, test := range tests {
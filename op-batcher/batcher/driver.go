@@ -7,23 +7,33 @@ import (
 	"io"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/health"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 var ErrBatcherNotRunning = errors.New("batcher is not running")
 
+// SetMaxDASizeMethod is the RPC method of the sequencer's execution engine to limit
+// the data availability size of transactions included in new blocks.
+const SetMaxDASizeMethod = "miner_setMaxDASize"
+
 type L1Client interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
@@ -37,17 +47,25 @@ type RollupClient interface {
 	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 }
 
+// DAClient posts batch data to an alternative DA provider. The returned
+// commitment is submitted to the L1 batch inbox instead of the data itself, to
+// be resolved by the alt-DA derivation pipeline.
+type DAClient interface {
+	SetInput(ctx context.Context, img []byte) (plasma.CommitmentData, error)
+}
+
 // DriverSetup is the collection of input/output interfaces and configuration that the driver operates on.
 type DriverSetup struct {
 	Log              log.Logger
 	Metr             metrics.Metricer
+	Tracer           optracing.Tracer // optional, defaults to no tracing
 	RollupConfig     *rollup.Config
 	Config           BatcherConfig
 	Txmgr            txmgr.TxManager
 	L1Client         L1Client
 	EndpointProvider dial.L2EndpointProvider
-	ChannelConfig    ChannelConfig
-	PlasmaDA         *plasma.DAClient
+	ChannelConfig    ChannelConfigProvider
+	PlasmaDA         DAClient
 }
 
 // BatchSubmitter encapsulates a service responsible for submitting L2 tx
@@ -64,20 +82,49 @@ type BatchSubmitter struct {
 
 	mutex   sync.Mutex
 	running bool
+	// loopFailed is set when the batch submission loop exits before it is stopped, see Liveness
+	loopFailed atomic.Bool
+
+	// paused pauses the submission of batch transactions, see PauseSubmission
+	paused atomic.Bool
 
 	// lastStoredBlock is the last block loaded into `state`. If it is empty it should be set to the l2 safe head.
 	lastStoredBlock eth.BlockID
 	lastL1Tip       eth.L1BlockRef
 
+	// recoveryTarget is the last block of the L2 range that is re-batched after a data gap, ahead of newer blocks.
+	// Empty if not recovering from a data gap.
+	recoveryTarget eth.BlockID
+
+	// whether the last tx queued for sending was a blob tx, to detect DA type switches
+	lastTxAsBlob bool
+
+	// feeScheduler delays non-urgent submissions while L1 fees are high, nil if disabled
+	feeScheduler *feeScheduler
+
+	// blobFailures detects persistently failing blob txs, nil if the blob emergency mode is disabled
+	blobFailures *blobFailureTracker
+
 	state *channelManager
 }
 
 // NewBatchSubmitter initializes the BatchSubmitter driver from a preconfigured DriverSetup
 func NewBatchSubmitter(setup DriverSetup) *BatchSubmitter {
-	return &BatchSubmitter{
+	l := &BatchSubmitter{
 		DriverSetup: setup,
 		state:       NewChannelManager(setup.Log, setup.Metr, setup.ChannelConfig, setup.RollupConfig),
 	}
+	if l.Tracer == nil {
+		l.Tracer = optracing.NoopTracer{}
+	}
+	if setup.Config.FeeSchedulerBaseFeePercentile > 0 || setup.Config.FeeSchedulerBlobFeePercentile > 0 {
+		l.feeScheduler = newFeeScheduler(setup.Config.FeeSchedulerBaseFeePercentile,
+			setup.Config.FeeSchedulerBlobFeePercentile, setup.Config.FeeSchedulerWindow)
+	}
+	if setup.Config.BlobFailureThreshold > 0 {
+		l.blobFailures = newBlobFailureTracker(setup.Config.BlobFailureThreshold, setup.Config.BlobEmergencyDuration)
+	}
+	return l
 }
 
 func (l *BatchSubmitter) StartBatchSubmitting() error {
@@ -96,6 +143,7 @@ func (l *BatchSubmitter) StartBatchSubmitting() error {
 	l.clearState(l.shutdownCtx)
 	l.lastStoredBlock = eth.BlockID{}
 
+	l.loopFailed.Store(false)
 	l.wg.Add(1)
 	go l.loop()
 
@@ -140,6 +188,30 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 	return nil
 }
 
+// Liveness is the liveness probe of the batch submission loop, which is failing if the loop exited while the
+// batcher is running, e.g. after failing to wait for the node to sync, and no batches are submitted anymore.
+func (l *BatchSubmitter) Liveness(ctx context.Context) health.Result {
+	if l.loopFailed.Load() {
+		return health.Failing("batch submission loop exited")
+	}
+	return health.OK()
+}
+
+// PauseSubmission pauses the submission of batch transactions. New L2 blocks
+// are still loaded into the state and open channels are kept, so submission
+// continues where it left off after ResumeSubmission. Pending data is still
+// submitted on L2 reorgs and shutdown.
+func (l *BatchSubmitter) PauseSubmission() {
+	l.Log.Info("Pausing batch submission")
+	l.paused.Store(true)
+}
+
+// ResumeSubmission resumes the submission of batch transactions after PauseSubmission.
+func (l *BatchSubmitter) ResumeSubmission() {
+	l.Log.Info("Resuming batch submission")
+	l.paused.Store(false)
+}
+
 // loadBlocksIntoState loads all blocks since the previous stored block
 // It does the following:
 // 1. Fetch the sync status of the sequencer
@@ -148,7 +220,12 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 // 4. Load all new blocks into the local state.
 // If there is a reorg, it will reset the last stored block but not clear the internal state so
 // the state can be flushed to L1.
-func (l *BatchSubmitter) loadBlocksIntoState(ctx context.Context) error {
+func (l *BatchSubmitter) loadBlocksIntoState(ctx context.Context) (outErr error) {
+	ctx, span := l.Tracer.Start(ctx, "batcher.load_blocks")
+	defer func() {
+		span.RecordError(outErr)
+		span.End()
+	}()
 	start, end, err := l.calculateL2BlockRangeToStore(ctx)
 	if err != nil {
 		l.Log.Warn("Error calculating L2 block range", "err", err)
@@ -156,6 +233,7 @@ func (l *BatchSubmitter) loadBlocksIntoState(ctx context.Context) error {
 	} else if start.Number >= end.Number {
 		return errors.New("start number is >= end number")
 	}
+	span.SetAttributes(optracing.Uint64("l2.start", start.Number+1), optracing.Uint64("l2.end", end.Number))
 
 	var latestBlock *types.Block
 	// Add all blocks to "state"
@@ -231,9 +309,22 @@ func (l *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 	if l.lastStoredBlock == (eth.BlockID{}) {
 		l.Log.Info("Starting batch-submitter work at safe-head", "safe", syncStatus.SafeL2)
 		l.lastStoredBlock = syncStatus.SafeL2.ID()
+		l.recoveryTarget = eth.BlockID{}
 	} else if l.lastStoredBlock.Number < syncStatus.SafeL2.Number {
 		l.Log.Warn("Last submitted block lagged behind L2 safe head: batch submission will continue from the safe head now", "last", l.lastStoredBlock, "safe", syncStatus.SafeL2)
 		l.lastStoredBlock = syncStatus.SafeL2.ID()
+	} else if l.hasDataGap(syncStatus) {
+		// The missing blocks can only be recovered by rebuilding all channels from the safe head.
+		// The affected range is submitted in its own channel before any newer blocks are loaded,
+		// to bound how long the safe head stalls.
+		if l.lastStoredBlock.Number > l.recoveryTarget.Number {
+			l.recoveryTarget = l.lastStoredBlock
+		}
+		l.Log.Warn("L2 blocks after the safe head are missing from the batcher state: reloading blocks from the safe head",
+			"last", l.lastStoredBlock, "safe", syncStatus.SafeL2, "current_l1", syncStatus.CurrentL1, "recovery_target", l.recoveryTarget)
+		l.clearState(ctx)
+		l.lastStoredBlock = syncStatus.SafeL2.ID()
+		l.state.CloseChannelAfter(l.recoveryTarget.Number)
 	}
 
 	// Check if we should even attempt to load any blocks. TODO: May not need this check
@@ -241,7 +332,44 @@ func (l *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 		return eth.BlockID{}, eth.BlockID{}, errors.New("L2 safe head ahead of L2 unsafe head")
 	}
 
-	return l.lastStoredBlock, syncStatus.UnsafeL2.ID(), nil
+	end := syncStatus.UnsafeL2.ID()
+	if l.recoveryTarget != (eth.BlockID{}) {
+		if l.lastStoredBlock.Number >= l.recoveryTarget.Number {
+			l.Log.Info("Loaded all L2 blocks affected by the data gap, resuming with new blocks", "recovery_target", l.recoveryTarget)
+			l.recoveryTarget = eth.BlockID{}
+		} else if end.Number > l.recoveryTarget.Number {
+			end = l.recoveryTarget
+		}
+	}
+	return l.lastStoredBlock, end, nil
+}
+
+// dataGapL1Margin is the number of L1 blocks that the derivation pipeline must
+// have progressed past the last batcher tx inclusion block, before L2 blocks
+// missing between the safe head and the batcher state are considered lost.
+const dataGapL1Margin = 2
+
+// hasDataGap returns whether L2 blocks between the safe head and the oldest
+// block of the batcher state are missing from the state, even though the
+// derivation pipeline already processed all confirmed batcher txs. This can
+// happen if state got lost, e.g. if a confirmed channel turned out to be invalid.
+// No gap is reported while batcher txs are in flight: recovering from the gap
+// clears the state, which would drop the tracking of their results, and the
+// txs may still include the missing blocks.
+func (l *BatchSubmitter) hasDataGap(syncStatus *eth.SyncStatus) bool {
+	firstHeld := l.lastStoredBlock.Number + 1
+	if oldest, ok := l.state.OldestBlockNumber(); ok {
+		firstHeld = oldest
+	}
+	if syncStatus.SafeL2.Number+1 >= firstHeld {
+		return false
+	}
+	if inFlight := l.state.InFlightTxs(); inFlight > 0 {
+		l.Log.Info("L2 blocks after the safe head may be missing from the batcher state, waiting for in-flight txs before recovering",
+			"safe", syncStatus.SafeL2, "first_held", firstHeld, "in_flight", inFlight)
+		return false
+	}
+	return syncStatus.CurrentL1.Number >= l.state.LastInclusionBlock()+dataGapL1Margin
 }
 
 // The following things occur:
@@ -261,6 +389,7 @@ func (l *BatchSubmitter) loop() {
 		err := l.waitNodeSync()
 		if err != nil {
 			l.Log.Error("Error waiting for node sync", "err", err)
+			l.loopFailed.Store(l.shutdownCtx.Err() == nil)
 			return
 		}
 	}
@@ -284,6 +413,21 @@ func (l *BatchSubmitter) loop() {
 		}
 	}()
 
+	if l.Config.ThrottleThreshold > 0 {
+		throttlingLoopDone := make(chan struct{})
+		defer close(throttlingLoopDone) // shut down throttling loop
+		l.wg.Add(1)
+		go l.throttlingLoop(throttlingLoopDone)
+	}
+
+	// nil, and so never ready, if the L2 head subscription is disabled
+	var newL2Heads chan struct{}
+	if l.Config.L2HeadSubscription {
+		newL2Heads = make(chan struct{}, 1)
+		l.wg.Add(1)
+		go l.l2HeadsLoop(l.shutdownCtx, newL2Heads)
+	}
+
 	ticker := time.NewTicker(l.Config.PollInterval)
 	defer ticker.Stop()
 
@@ -296,25 +440,38 @@ func (l *BatchSubmitter) loop() {
 		}
 	}
 
+	loadAndPublish := func() {
+		if err := l.loadBlocksIntoState(l.shutdownCtx); errors.Is(err, ErrReorg) {
+			err := l.state.Close()
+			if err != nil {
+				if errors.Is(err, ErrPendingAfterClose) {
+					l.Log.Warn("Closed channel manager to handle L2 reorg with pending channel(s) remaining - submitting")
+				} else {
+					l.Log.Error("Error closing the channel manager to handle a L2 reorg", "err", err)
+				}
+			}
+			// on reorg we want to publish all pending state then wait until each result clears before resetting
+			// the state.
+			publishAndWait()
+			l.clearState(l.shutdownCtx)
+			return
+		}
+		if l.paused.Load() {
+			l.Log.Debug("Batch submission paused, not publishing")
+			return
+		}
+		if l.feeScheduler != nil && l.delaySubmission(l.shutdownCtx) {
+			return
+		}
+		l.publishStateToL1(queue, receiptsCh)
+	}
+
 	for {
 		select {
 		case <-ticker.C:
-			if err := l.loadBlocksIntoState(l.shutdownCtx); errors.Is(err, ErrReorg) {
-				err := l.state.Close()
-				if err != nil {
-					if errors.Is(err, ErrPendingAfterClose) {
-						l.Log.Warn("Closed channel manager to handle L2 reorg with pending channel(s) remaining - submitting")
-					} else {
-						l.Log.Error("Error closing the channel manager to handle a L2 reorg", "err", err)
-					}
-				}
-				// on reorg we want to publish all pending state then wait until each result clears before resetting
-				// the state.
-				publishAndWait()
-				l.clearState(l.shutdownCtx)
-				continue
-			}
-			l.publishStateToL1(queue, receiptsCh)
+			loadAndPublish()
+		case <-newL2Heads:
+			loadAndPublish()
 		case <-l.shutdownCtx.Done():
 			if l.Txmgr.IsClosed() {
 				l.Log.Info("Txmgr is closed, remaining channel data won't be sent")
@@ -337,6 +494,131 @@ func (l *BatchSubmitter) loop() {
 	}
 }
 
+// l2HeadsLoop subscribes to sync status updates of the rollup node and signals them
+// on the notify channel, so new unsafe blocks are loaded as soon as the rollup node
+// processed them. Notifications are coalesced if the previous one hasn't been
+// processed yet. If the subscription fails, e.g. because the active sequencer
+// changed, it resubscribes after the poll interval. Polling at the poll interval
+// continues regardless, as a fallback.
+func (l *BatchSubmitter) l2HeadsLoop(ctx context.Context, notify chan<- struct{}) {
+	defer l.wg.Done()
+	for {
+		err := l.subscribeL2Heads(ctx, notify)
+		if ctx.Err() != nil {
+			l.Log.Info("L2 head subscription loop done")
+			return
+		}
+		l.Log.Warn("L2 head subscription failed, resubscribing", "err", err)
+		select {
+		case <-time.After(l.Config.PollInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// subscribeL2Heads subscribes to sync status updates of the rollup node and forwards
+// new unsafe heads to notify until the subscription fails or the context is done.
+func (l *BatchSubmitter) subscribeL2Heads(ctx context.Context, notify chan<- struct{}) error {
+	rollupClient, err := l.EndpointProvider.RollupClient(ctx)
+	if err != nil {
+		return fmt.Errorf("getting rollup client: %w", err)
+	}
+	subscriber, ok := rollupClient.(dial.SyncStatusSubscriber)
+	if !ok {
+		return errors.New("rollup client does not support sync status subscriptions")
+	}
+
+	updates := make(chan *eth.SyncStatus, 1)
+	sub, err := subscriber.SubscribeSyncStatus(ctx, updates)
+	if err != nil {
+		return fmt.Errorf("subscribing to rollup node sync status: %w", err)
+	}
+	defer sub.Unsubscribe()
+	l.Log.Info("Subscribed to rollup node sync status")
+
+	var unsafeHead eth.L2BlockRef
+	for {
+		select {
+		case status := <-updates:
+			if status.UnsafeL2 == unsafeHead {
+				continue
+			}
+			unsafeHead = status.UnsafeL2
+			l.Log.Debug("Received new L2 head", "head", unsafeHead)
+			select {
+			case notify <- struct{}{}:
+			default: // previous notification still pending
+			}
+		case err := <-sub.Err():
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// throttlingLoop periodically checks the backlog of L2 block data that hasn't been
+// added to a channel yet. If it exceeds the throttle threshold, the sequencer's
+// execution engine is instructed to limit the DA size of new blocks until the
+// backlog has drained again.
+func (l *BatchSubmitter) throttlingLoop(done <-chan struct{}) {
+	defer l.wg.Done()
+	l.Log.Info("Starting DA throttling loop", "threshold", l.Config.ThrottleThreshold)
+
+	ticker := time.NewTicker(l.Config.PollInterval)
+	defer ticker.Stop()
+
+	var throttling bool
+	for {
+		select {
+		case <-ticker.C:
+			t, err := l.updateThrottling(l.shutdownCtx)
+			if err != nil {
+				l.Log.Warn("Failed to update DA throttling", "err", err)
+				continue
+			}
+			if t != throttling {
+				l.Log.Warn("DA throttling changed", "throttling", t, "pending_bytes", l.state.PendingDABytes())
+			}
+			throttling = t
+		case <-done:
+			l.Log.Info("DA throttling loop done")
+			return
+		}
+	}
+}
+
+// updateThrottling sets the max DA sizes at the sequencer's execution engine,
+// depending on whether the pending DA bytes exceed the throttle threshold.
+// It returns whether throttling is active.
+func (l *BatchSubmitter) updateThrottling(ctx context.Context) (bool, error) {
+	pendingBytes := l.state.PendingDABytes()
+	throttling := pendingBytes > int64(l.Config.ThrottleThreshold)
+	maxTxSize, maxBlockSize := uint64(0), l.Config.ThrottleAlwaysBlockSize
+	if throttling {
+		maxTxSize, maxBlockSize = l.Config.ThrottleTxSize, l.Config.ThrottleBlockSize
+	}
+	l.Metr.RecordThrottling(pendingBytes, throttling)
+
+	l2Client, err := l.EndpointProvider.EthClient(ctx)
+	if err != nil {
+		return throttling, fmt.Errorf("getting L2 client: %w", err)
+	}
+
+	cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+
+	var success bool
+	if err := l2Client.Client().CallContext(cCtx, &success, SetMaxDASizeMethod,
+		hexutil.Uint64(maxTxSize), hexutil.Uint64(maxBlockSize)); err != nil {
+		return throttling, fmt.Errorf("calling %s: %w", SetMaxDASizeMethod, err)
+	} else if !success {
+		return throttling, fmt.Errorf("%s returned failure", SetMaxDASizeMethod)
+	}
+	return throttling, nil
+}
+
 // waitNodeSync Check to see if there was a batcher tx sent recently that
 // still needs more block confirmations before being considered finalized
 func (l *BatchSubmitter) waitNodeSync() error {
@@ -437,8 +719,12 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 	}
 	l.recordL1Tip(l1tip)
 
+	if l.blobFailures != nil {
+		l.checkBlobEmergency()
+	}
+
 	// Collect next transaction data
-	txdata, err := l.state.TxData(l1tip.ID())
+	txdata, err := l.state.TxData(l1tip)
 
 	if err == io.EOF {
 		l.Log.Trace("No transaction data available")
@@ -448,7 +734,21 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 		return err
 	}
 
+	// A sender can't have blob and calldata txs in the mempool at the same time, so
+	// before switching the DA type, wait for all pending txs of the other type.
+	if txdata.asBlob != l.lastTxAsBlob {
+		l.Log.Info("DA type switched, waiting for pending transactions", "use_blobs", txdata.asBlob)
+		queue.Wait()
+		l.lastTxAsBlob = txdata.asBlob
+	}
+
+	ctx, span := l.Tracer.Start(ctx, "batcher.send_tx",
+		optracing.String("tx.id", txdata.ID().String()),
+		optracing.Bool("tx.as_blob", txdata.asBlob),
+		optracing.Int64("tx.frames", int64(len(txdata.frames))))
+	defer span.End()
 	if err = l.sendTransaction(ctx, txdata, queue, receiptsCh); err != nil {
+		span.RecordError(err)
 		return fmt.Errorf("BatchSubmitter.sendTransaction failed: %w", err)
 	}
 	return nil
@@ -481,11 +781,15 @@ func (l *BatchSubmitter) safeL1Origin(ctx context.Context) (eth.BlockID, error)
 // sendTransaction creates & queues for sending a transaction to the batch inbox address with the given `txData`.
 // The method will block if the queue's MaxPendingTransactions is exceeded.
 func (l *BatchSubmitter) sendTransaction(ctx context.Context, txdata txData, queue *txmgr.Queue[txID], receiptsCh chan txmgr.TxReceipt[txID]) error {
+	if l.Config.DryRun {
+		return l.dryRunTx(ctx, txdata)
+	}
+
 	var err error
 	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
 
 	var candidate *txmgr.TxCandidate
-	if l.Config.UseBlobs {
+	if txdata.asBlob {
 		if candidate, err = l.blobTxCandidate(txdata); err != nil {
 			// We could potentially fall through and try a calldata tx instead, but this would
 			// likely result in the chain spending more in gas fees than it is tuned for, so best
@@ -502,15 +806,20 @@ func (l *BatchSubmitter) sendTransaction(ctx context.Context, txdata txData, que
 		// if plasma DA is enabled we post the txdata to the DA Provider and replace it with the commitment.
 		if l.Config.UsePlasma {
 			comm, err := l.PlasmaDA.SetInput(ctx, data)
-			if err != nil {
+			if err != nil && l.Config.PlasmaFallbackToL1 {
+				// The alt-DA derivation pipeline also accepts frames posted directly
+				// to L1, so the data stays available even if the DA provider is down.
+				l.Log.Warn("Failed to post input to Plasma DA, falling back to L1 calldata", "tx", txdata.ID(), "err", err)
+			} else if err != nil {
 				l.Log.Error("Failed to post input to Plasma DA", "error", err)
 				// requeue frame if we fail to post to the DA Provider so it can be retried
 				l.recordFailedTx(txdata.ID(), err)
 				return nil
+			} else {
+				l.Log.Info("Set plasma input", "commitment", comm, "tx", txdata.ID())
+				// signal plasma commitment tx with TxDataVersion1
+				data = comm.TxData()
 			}
-			l.Log.Info("Set plasma input", "commitment", comm, "tx", txdata.ID())
-			// signal plasma commitment tx with TxDataVersion1
-			data = comm.TxData()
 		}
 		candidate = l.calldataTxCandidate(data)
 	}
@@ -523,6 +832,9 @@ func (l *BatchSubmitter) sendTransaction(ctx context.Context, txdata txData, que
 		candidate.GasLimit = intrinsicGas
 	}
 
+	if l.blobFailures != nil {
+		l.blobFailures.sent(txdata.ID(), txdata.asBlob)
+	}
 	queue.Send(txdata.ID(), *candidate, receiptsCh)
 	return nil
 }
@@ -535,8 +847,10 @@ func (l *BatchSubmitter) blobTxCandidate(data txData) (*txmgr.TxCandidate, error
 	size := data.Len()
 	lastSize := len(data.frames[len(data.frames)-1].data)
 	l.Log.Info("Building Blob transaction candidate",
-		"size", size, "last_size", lastSize, "num_blobs", len(blobs))
+		"size", size, "last_size", lastSize, "num_blobs", len(blobs),
+		"blob_gas", uint64(len(blobs))*params.BlobTxBlobGasPerBlob)
 	l.Metr.RecordBlobUsedBytes(lastSize)
+	l.Metr.RecordBlobsPerTx(len(blobs))
 	return &txmgr.TxCandidate{
 		To:    &l.RollupConfig.BatchInboxAddress,
 		Blobs: blobs,
@@ -552,6 +866,9 @@ func (l *BatchSubmitter) calldataTxCandidate(data []byte) *txmgr.TxCandidate {
 }
 
 func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txID]) {
+	if l.blobFailures != nil && l.blobFailures.result(r.ID, r.Err) {
+		l.startBlobEmergency(r.Err)
+	}
 	// Record TX Status
 	if r.Err != nil {
 		l.recordFailedTx(r.ID, r.Err)
@@ -576,7 +893,41 @@ func (l *BatchSubmitter) recordFailedTx(id txID, err error) {
 func (l *BatchSubmitter) recordConfirmedTx(id txID, receipt *types.Receipt) {
 	l.Log.Info("Transaction confirmed", logFields(id, receipt)...)
 	l1block := eth.ReceiptBlockID(receipt)
-	l.state.TxConfirmed(id, l1block)
+	l.state.TxConfirmed(id, l1block, receipt.TxHash)
+}
+
+// Channels returns the details of the current and recently submitted channels,
+// oldest first. Confirmations of their txs are counted up to the current L1 tip.
+func (l *BatchSubmitter) Channels(ctx context.Context) ([]rpc.ChannelInfo, error) {
+	l1tip, err := l.l1Tip(ctx)
+	if err != nil {
+		return nil, err
+	}
+	infos := l.state.ChannelInfos()
+	for i := range infos {
+		for j := range infos[i].Txs {
+			tx := &infos[i].Txs[j]
+			if l1tip.Number >= tx.InclusionBlock.Number {
+				tx.Confirmations = hexutil.Uint64(l1tip.Number - tx.InclusionBlock.Number + 1)
+			}
+		}
+	}
+	return infos, nil
+}
+
+// ChannelByL2Block returns the latest current or recently submitted channel
+// that contains the L2 block with number num, or nil if there's none.
+func (l *BatchSubmitter) ChannelByL2Block(ctx context.Context, num uint64) (*rpc.ChannelInfo, error) {
+	infos, err := l.Channels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(infos) - 1; i >= 0; i-- {
+		if infos[i].ContainsBlock(num) {
+			return &infos[i], nil
+		}
+	}
+	return nil, nil
 }
 
 // l1Tip gets the current L1 tip as a L1BlockRef. The passed context is assumed
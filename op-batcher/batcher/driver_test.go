@@ -1,16 +1,36 @@
 package batcher
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
+	"math/big"
+	"math/rand"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	batcherrpc "github.com/ethereum-optimism/optimism/op-batcher/rpc"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/health"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -117,3 +137,403 @@ func TestBatchSubmitter_SafeL1Origin_FailsToResolveRollupClient(t *testing.T) {
 	_, err := bs.safeL1Origin(context.Background())
 	require.Error(t, err)
 }
+
+type mockMinerAPI struct {
+	maxTxSize    hexutil.Uint64
+	maxBlockSize hexutil.Uint64
+}
+
+func (m *mockMinerAPI) SetMaxDASize(maxTxSize hexutil.Uint64, maxBlockSize hexutil.Uint64) bool {
+	m.maxTxSize, m.maxBlockSize = maxTxSize, maxBlockSize
+	return true
+}
+
+func TestBatchSubmitter_UpdateThrottling(t *testing.T) {
+	bs, ep := setup(t)
+	bs.Config.NetworkTimeout = time.Second
+	bs.Config.ThrottleTxSize = 300
+	bs.Config.ThrottleBlockSize = 21_000
+	bs.Config.ThrottleAlwaysBlockSize = 130_000
+
+	miner := new(mockMinerAPI)
+	srv := rpc.NewServer()
+	t.Cleanup(srv.Stop)
+	require.NoError(t, srv.RegisterName("miner", miner))
+	cl := rpc.DialInProc(srv)
+	t.Cleanup(cl.Close)
+	ep.ethClient.ExpectClient(cl)
+
+	rng := rand.New(rand.NewSource(123))
+	block := derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)
+	blockSize := int64(metrics.EstimateBatchSize(block))
+	bs.Config.ThrottleThreshold = uint64(blockSize)
+
+	// empty backlog, not throttling
+	throttling, err := bs.updateThrottling(context.Background())
+	require.NoError(t, err)
+	require.False(t, throttling)
+	require.Equal(t, hexutil.Uint64(0), miner.maxTxSize)
+	require.Equal(t, hexutil.Uint64(130_000), miner.maxBlockSize)
+
+	// backlog at threshold, still not throttling
+	require.NoError(t, bs.state.AddL2Block(block))
+	require.Equal(t, blockSize, bs.state.PendingDABytes())
+	throttling, err = bs.updateThrottling(context.Background())
+	require.NoError(t, err)
+	require.False(t, throttling)
+
+	// backlog above threshold, throttling
+	bs.Config.ThrottleThreshold = uint64(blockSize - 1)
+	throttling, err = bs.updateThrottling(context.Background())
+	require.NoError(t, err)
+	require.True(t, throttling)
+	require.Equal(t, hexutil.Uint64(300), miner.maxTxSize)
+	require.Equal(t, hexutil.Uint64(21_000), miner.maxBlockSize)
+
+	// backlog cleared, throttling stops
+	bs.state.Clear(eth.BlockID{})
+	require.Zero(t, bs.state.PendingDABytes())
+	throttling, err = bs.updateThrottling(context.Background())
+	require.NoError(t, err)
+	require.False(t, throttling)
+	require.Equal(t, hexutil.Uint64(0), miner.maxTxSize)
+	require.Equal(t, hexutil.Uint64(130_000), miner.maxBlockSize)
+}
+
+type mockSyncStatusAPI struct {
+	updates chan *eth.SyncStatus
+}
+
+func (api *mockSyncStatusAPI) SyncStatusUpdates(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case status := <-api.updates:
+				_ = notifier.Notify(sub.ID, status)
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// subscribingEndpointProvider serves a rollup client that supports subscriptions.
+type subscribingEndpointProvider struct {
+	*mockL2EndpointProvider
+	rollupClient dial.RollupClientInterface
+}
+
+func (p *subscribingEndpointProvider) RollupClient(context.Context) (dial.RollupClientInterface, error) {
+	return p.rollupClient, nil
+}
+
+func TestBatchSubmitter_L2HeadsLoop(t *testing.T) {
+	bs, ep := setup(t)
+	bs.Config.PollInterval = 10 * time.Millisecond
+
+	api := &mockSyncStatusAPI{updates: make(chan *eth.SyncStatus)}
+	srv := rpc.NewServer()
+	t.Cleanup(srv.Stop)
+	require.NoError(t, srv.RegisterName("optimism", api))
+	cl := rpc.DialInProc(srv)
+	t.Cleanup(cl.Close)
+	bs.EndpointProvider = &subscribingEndpointProvider{
+		mockL2EndpointProvider: ep,
+		rollupClient:           sources.NewRollupClient(client.NewBaseRPCClient(cl)),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	notify := make(chan struct{}, 1)
+	bs.wg.Add(1)
+	go bs.l2HeadsLoop(ctx, notify)
+
+	for i := uint64(1); i <= 3; i++ {
+		api.updates <- &eth.SyncStatus{UnsafeL2: eth.L2BlockRef{Number: i}}
+		select {
+		case <-notify:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no notification for L2 head %d", i)
+		}
+	}
+
+	// updates that don't change the unsafe head are not signalled
+	api.updates <- &eth.SyncStatus{UnsafeL2: eth.L2BlockRef{Number: 3}, SafeL2: eth.L2BlockRef{Number: 1}}
+	select {
+	case <-notify:
+		t.Fatal("unexpected notification without new L2 head")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	bs.wg.Wait()
+}
+
+type mockDAClient struct {
+	err error
+}
+
+func (c *mockDAClient) SetInput(_ context.Context, img []byte) (plasma.CommitmentData, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return plasma.NewKeccak256Commitment(img), nil
+}
+
+func TestBatchSubmitter_SendTransaction_PlasmaFallback(t *testing.T) {
+	txdata := txData{frames: []frameData{{data: []byte{0x01, 0x02, 0x03}}}}
+	errDA := errors.New("DA server unavailable")
+
+	tests := []struct {
+		name       string
+		daErr      error
+		fallback   bool
+		expectData []byte // nil if no tx expected
+	}{
+		{
+			name:       "commitment",
+			expectData: plasma.NewKeccak256Commitment(txdata.CallData()).TxData(),
+		},
+		{
+			name:       "commitment-with-fallback",
+			fallback:   true,
+			expectData: plasma.NewKeccak256Commitment(txdata.CallData()).TxData(),
+		},
+		{
+			name:  "da-error",
+			daErr: errDA,
+		},
+		{
+			name:       "da-error-with-fallback",
+			daErr:      errDA,
+			fallback:   true,
+			expectData: txdata.CallData(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs, _ := setup(t)
+			bs.Config.UsePlasma = true
+			bs.Config.PlasmaFallbackToL1 = tt.fallback
+			bs.PlasmaDA = &mockDAClient{err: tt.daErr}
+
+			txMgr := new(mocks.TxManager)
+			if tt.expectData != nil {
+				txMgr.On("Send", mock.Anything, mock.MatchedBy(func(c txmgr.TxCandidate) bool {
+					return bytes.Equal(c.TxData, tt.expectData)
+				})).Return(new(types.Receipt), nil).Once()
+			}
+			queue := txmgr.NewQueue[txID](context.Background(), txMgr, 1)
+			receiptsCh := make(chan txmgr.TxReceipt[txID], 1)
+
+			require.NoError(t, bs.sendTransaction(context.Background(), txdata, queue, receiptsCh))
+			queue.Wait()
+			txMgr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBatchSubmitter_HasDataGap(t *testing.T) {
+	syncStatus := func(safe, currentL1 uint64) *eth.SyncStatus {
+		return &eth.SyncStatus{
+			SafeL2:    eth.L2BlockRef{Number: safe},
+			CurrentL1: eth.L1BlockRef{Number: currentL1},
+		}
+	}
+
+	t.Run("empty-state", func(t *testing.T) {
+		bs, _ := setup(t)
+		bs.lastStoredBlock = eth.BlockID{Number: 10}
+		require.False(t, bs.hasDataGap(syncStatus(10, 100)), "all blocks derived")
+		require.True(t, bs.hasDataGap(syncStatus(5, 100)), "blocks 6-10 missing")
+
+		// derivation didn't process the last batcher tx yet
+		bs.state.lastInclusionBlock = 99
+		require.False(t, bs.hasDataGap(syncStatus(5, 100)))
+		require.True(t, bs.hasDataGap(syncStatus(5, 101)))
+	})
+
+	t.Run("pending-blocks", func(t *testing.T) {
+		bs, _ := setup(t)
+		bs.lastStoredBlock = eth.BlockID{Number: 10}
+		require.NoError(t, bs.state.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(8), common.Hash{})))
+		oldest, ok := bs.state.OldestBlockNumber()
+		require.True(t, ok)
+		require.EqualValues(t, 8, oldest)
+
+		require.False(t, bs.hasDataGap(syncStatus(7, 100)), "next block to derive is held")
+		require.True(t, bs.hasDataGap(syncStatus(6, 100)), "block 7 missing")
+	})
+
+	t.Run("in-flight-txs", func(t *testing.T) {
+		bs, _ := setup(t)
+		bs.lastStoredBlock = eth.BlockID{Number: 10}
+		id := txID{{chID: derive.ChannelID{0x01}}}
+		bs.state.txChannels[id.String()] = &channel{log: bs.Log, metr: metrics.NoopMetrics, pendingTransactions: make(map[string]txData)}
+		require.False(t, bs.hasDataGap(syncStatus(5, 100)), "the state is not cleared while txs are in flight")
+
+		bs.state.TxFailed(id)
+		require.True(t, bs.hasDataGap(syncStatus(5, 100)))
+	})
+}
+
+func TestBatchSubmitter_DataGapRecovery(t *testing.T) {
+	bs, ep := setup(t)
+	bs.lastStoredBlock = eth.BlockID{Number: 10}
+	syncStatus := &eth.SyncStatus{
+		HeadL1:    eth.L1BlockRef{Number: 100},
+		CurrentL1: eth.L1BlockRef{Number: 100},
+		SafeL2:    eth.L2BlockRef{Number: 5},
+		UnsafeL2:  eth.L2BlockRef{Number: 20},
+	}
+	// once to calculate the range, once to clear the state at the safe L1 origin
+	ep.rollupClient.ExpectSyncStatus(syncStatus, nil)
+	ep.rollupClient.ExpectSyncStatus(syncStatus, nil)
+
+	// the affected range is loaded ahead of new blocks, and closes its channel
+	start, end, err := bs.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 5, start.Number)
+	require.EqualValues(t, 10, end.Number)
+	require.EqualValues(t, 10, bs.state.closeAfterBlock)
+
+	// loading the affected range completes the recovery
+	require.NoError(t, bs.state.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(6), common.Hash{})))
+	bs.lastStoredBlock = eth.BlockID{Number: 10}
+	ep.rollupClient.ExpectSyncStatus(syncStatus, nil)
+	start, end, err = bs.calculateL2BlockRangeToStore(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 10, start.Number)
+	require.EqualValues(t, 20, end.Number)
+	require.Equal(t, eth.BlockID{}, bs.recoveryTarget)
+}
+
+func TestBatchSubmitter_ChannelByL2Block(t *testing.T) {
+	bs, _ := setup(t)
+	bs.L1Client = &stubL1Client{head: &types.Header{Number: big.NewInt(12)}}
+	txHash := common.Hash{0x01}
+	bs.state.channelHistory = []batcherrpc.ChannelInfo{
+		{
+			Status:     batcherrpc.ChannelTimedOut,
+			FirstBlock: eth.BlockID{Number: 4},
+			LastBlock:  eth.BlockID{Number: 6},
+			NumBlocks:  3,
+		},
+		{
+			Status:     batcherrpc.ChannelFullySubmitted,
+			FirstBlock: eth.BlockID{Number: 4},
+			LastBlock:  eth.BlockID{Number: 8},
+			NumBlocks:  5,
+			Txs:        []batcherrpc.ChannelTx{{TxHash: txHash, InclusionBlock: eth.BlockID{Number: 10}}},
+		},
+	}
+
+	ch, err := bs.ChannelByL2Block(context.Background(), 5)
+	require.NoError(t, err)
+	require.NotNil(t, ch)
+	require.Equal(t, batcherrpc.ChannelFullySubmitted, ch.Status, "latest channel containing block")
+	require.Equal(t, []batcherrpc.ChannelTx{{TxHash: txHash, InclusionBlock: eth.BlockID{Number: 10}, Confirmations: 3}}, ch.Txs)
+
+	ch, err = bs.ChannelByL2Block(context.Background(), 9)
+	require.NoError(t, err)
+	require.Nil(t, ch)
+
+	// confirmations are only added to returned copies
+	require.Zero(t, bs.state.channelHistory[1].Txs[0].Confirmations)
+}
+
+func TestBatchSubmitter_Liveness(t *testing.T) {
+	bs, ep := setup(t)
+	bs.Config.WaitNodeSync = true
+	ep.rollupClientErr = errors.New("failed to resolve rollup client")
+	require.Equal(t, health.StatusOK, bs.Liveness(context.Background()).Status)
+
+	runLoop := func() {
+		bs.wg.Add(1)
+		bs.loop()
+	}
+	bs.shutdownCtx, bs.cancelShutdownCtx = context.WithCancel(context.Background())
+	bs.killCtx, bs.cancelKillCtx = context.WithCancel(context.Background())
+	runLoop()
+	require.Equal(t, health.StatusFailing, bs.Liveness(context.Background()).Status, "the loop exited while running")
+
+	bs.loopFailed.Store(false)
+	bs.cancelShutdownCtx()
+	runLoop()
+	require.Equal(t, health.StatusOK, bs.Liveness(context.Background()).Status, "the loop exited on shutdown")
+	bs.cancelKillCtx()
+}
+
+func TestBatchSubmitter_PublishTxToL1_DATypeSwitch(t *testing.T) {
+	bs, _ := setup(t)
+	bs.L1Client = &stubL1Client{head: &types.Header{Number: big.NewInt(100)}}
+	calldataCfg := channelManagerTestConfig(10_000, derive.SingularBatchType)
+	blobCfg := channelManagerTestConfig(10_000, derive.SingularBatchType)
+	blobCfg.UseBlobs = true
+	rc, err := NewRuntimeChannelConfig(bs.Log, flags.BlobsType, map[flags.DataAvailabilityType]ChannelConfigProvider{
+		flags.CalldataType: calldataCfg,
+		flags.BlobsType:    blobCfg,
+	})
+	require.NoError(t, err)
+	bs.state.cfgProvider = rc
+	bs.state.Clear(eth.BlockID{})
+	// closes the current channel with the given block, so that its frames are submitted next
+	addChannel := func(block *types.Block) {
+		require.NoError(t, bs.state.AddL2Block(block))
+		_, err := bs.state.TxData(eth.L1BlockRef{})
+		require.ErrorIs(t, err, io.EOF)
+		bs.state.currentChannel.Close()
+		require.NoError(t, bs.state.outputFrames())
+	}
+
+	txMgr := new(mocks.TxManager)
+	queue := txmgr.NewQueue[txID](context.Background(), txMgr, 10)
+	receiptsCh := make(chan txmgr.TxReceipt[txID], 2)
+	blobTxDone := make(chan time.Time)
+	txMgr.On("Send", mock.Anything, mock.MatchedBy(func(c txmgr.TxCandidate) bool {
+		return len(c.Blobs) > 0
+	})).WaitUntil(blobTxDone).Return(new(types.Receipt), nil).Once()
+
+	a := newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{})
+	addChannel(a)
+	require.NoError(t, bs.publishTxToL1(context.Background(), queue, receiptsCh))
+	require.True(t, bs.lastTxAsBlob)
+
+	// the next channel uses calldata, while the blob tx is still in flight
+	require.NoError(t, rc.SetCalldataEmergency(true))
+	addChannel(newMiniL2BlockWithNumberParent(0, big.NewInt(2), a.Hash()))
+	calldataSent := make(chan struct{})
+	txMgr.On("Send", mock.Anything, mock.MatchedBy(func(c txmgr.TxCandidate) bool {
+		return len(c.Blobs) == 0
+	})).Run(func(mock.Arguments) {
+		close(calldataSent)
+	}).Return(new(types.Receipt), nil).Once()
+
+	published := make(chan error, 1)
+	go func() {
+		published <- bs.publishTxToL1(context.Background(), queue, receiptsCh)
+	}()
+	select {
+	case <-published:
+		t.Fatal("calldata tx published while the blob tx is pending")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(blobTxDone)
+	require.NoError(t, <-published)
+	require.False(t, bs.lastTxAsBlob)
+	select {
+	case r := <-receiptsCh:
+		require.NoError(t, r.Err)
+	default:
+		t.Fatal("blob tx not confirmed before the calldata tx was published")
+	}
+	<-calldataSent
+	queue.Wait()
+	txMgr.AssertExpectations(t)
+}
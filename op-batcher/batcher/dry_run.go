@@ -0,0 +1,87 @@
+package batcher
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// dryRunCost is the estimated L1 cost of submitting tx data, both as calldata
+// and as blobs.
+type dryRunCost struct {
+	CalldataTxs int
+	CalldataGas uint64
+	CalldataFee *big.Int
+
+	// The blob cost is only estimated if the L1 has a blob base fee, and
+	// BlobFee is nil otherwise, e.g. before Ecotone.
+	BlobTxs  int
+	NumBlobs int
+	BlobFee  *big.Int
+}
+
+// estimateDryRunCost estimates the L1 cost of submitting the frames of txdata
+// at the given gas prices. Calldata txs hold a single frame each, and blob txs
+// hold up to [eth.MaxBlobsPerBlobTx] frames, each in its own blob.
+// Blobs are unavailable if blobBaseFee is nil, so only the calldata cost is estimated then.
+func estimateDryRunCost(txdata txData, tipCap, baseFee, blobBaseFee *big.Int) (*dryRunCost, error) {
+	gasPrice := new(big.Int).Add(baseFee, tipCap)
+	cost := &dryRunCost{
+		CalldataTxs: len(txdata.frames),
+	}
+	for _, f := range txdata.frames {
+		data := append([]byte{derive.DerivationVersion0}, f.data...)
+		gas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+		if err != nil {
+			return nil, fmt.Errorf("calculating intrinsic gas: %w", err)
+		}
+		cost.CalldataGas += gas
+	}
+	cost.CalldataFee = new(big.Int).Mul(new(big.Int).SetUint64(cost.CalldataGas), gasPrice)
+
+	if blobBaseFee == nil {
+		return cost, nil
+	}
+	cost.NumBlobs = len(txdata.frames)
+	cost.BlobTxs = (len(txdata.frames) + eth.MaxBlobsPerBlobTx - 1) / eth.MaxBlobsPerBlobTx
+	blobTxGas := new(big.Int).SetUint64(uint64(cost.BlobTxs) * params.TxGas)
+	blobGas := new(big.Int).SetUint64(uint64(cost.NumBlobs) * params.BlobTxBlobGasPerBlob)
+	cost.BlobFee = new(big.Int).Add(
+		new(big.Int).Mul(blobTxGas, gasPrice),
+		new(big.Int).Mul(blobGas, blobBaseFee))
+	return cost, nil
+}
+
+// dryRunTx reports what submitting txdata would have cost, instead of sending
+// it. The tx data is then marked as confirmed at the current L1 tip, so that
+// channel building continues as if the tx got included right away.
+func (l *BatchSubmitter) dryRunTx(ctx context.Context, txdata txData) error {
+	cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	tipCap, baseFee, blobBaseFee, err := l.Txmgr.SuggestGasPriceCaps(cCtx)
+	if err != nil {
+		// requeue frames, so they can be retried
+		l.recordFailedTx(txdata.ID(), fmt.Errorf("dry run: suggesting gas price caps: %w", err))
+		return nil
+	}
+	cost, err := estimateDryRunCost(txdata, tipCap, baseFee, blobBaseFee)
+	if err != nil {
+		return fmt.Errorf("dry run: %w", err)
+	}
+
+	l.Log.Info("Dry run: not sending batcher tx",
+		"tx", txdata.ID(), "as_blob", txdata.asBlob, "num_frames", len(txdata.frames), "size", txdata.Len(),
+		"calldata_txs", cost.CalldataTxs, "calldata_gas", cost.CalldataGas, "calldata_fee", cost.CalldataFee,
+		"blob_txs", cost.BlobTxs, "num_blobs", cost.NumBlobs, "blob_fee", cost.BlobFee,
+		"tip_cap", tipCap, "base_fee", baseFee, "blob_base_fee", blobBaseFee)
+	l.Metr.RecordDryRunTx(len(txdata.frames), txdata.Len(), cost.CalldataFee, cost.BlobFee)
+	l.state.TxConfirmed(txdata.ID(), l.lastL1Tip.ID(), common.Hash{})
+	return nil
+}
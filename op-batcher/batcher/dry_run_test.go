@@ -0,0 +1,69 @@
+package batcher
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+func TestEstimateDryRunCost(t *testing.T) {
+	frames := make([]frameData, 7)
+	for i := range frames {
+		// 10 zero and 10 non-zero bytes
+		frames[i].data = make([]byte, 20)
+		for j := 10; j < 20; j++ {
+			frames[i].data[j] = 1
+		}
+	}
+	txdata := txData{frames: frames}
+	tipCap, baseFee, blobBaseFee := big.NewInt(1), big.NewInt(9), big.NewInt(2)
+
+	cost, err := estimateDryRunCost(txdata, tipCap, baseFee, blobBaseFee)
+	require.NoError(t, err)
+
+	// per calldata tx: base tx gas + zero version byte + frame bytes
+	calldataGas := params.TxGas + 11*params.TxDataZeroGas + 10*params.TxDataNonZeroGasEIP2028
+	require.Equal(t, 7, cost.CalldataTxs)
+	require.Equal(t, 7*calldataGas, cost.CalldataGas)
+	require.Equal(t, new(big.Int).SetUint64(7*calldataGas*10), cost.CalldataFee)
+
+	// 7 blobs need 2 blob txs
+	require.Equal(t, 2, cost.BlobTxs)
+	require.Equal(t, 7, cost.NumBlobs)
+	require.Equal(t, new(big.Int).SetUint64(2*params.TxGas*10+7*params.BlobTxBlobGasPerBlob*2), cost.BlobFee)
+
+	t.Run("pre-Ecotone", func(t *testing.T) {
+		cost, err := estimateDryRunCost(txdata, tipCap, baseFee, nil)
+		require.NoError(t, err)
+		require.Equal(t, 7*calldataGas, cost.CalldataGas)
+		require.Equal(t, new(big.Int).SetUint64(7*calldataGas*10), cost.CalldataFee)
+		require.Zero(t, cost.BlobTxs)
+		require.Zero(t, cost.NumBlobs)
+		require.Nil(t, cost.BlobFee)
+	})
+}
+
+func TestBatchSubmitter_SendTransaction_DryRun(t *testing.T) {
+	bs, _ := setup(t)
+	bs.Config.DryRun = true
+	txMgr := new(mocks.TxManager)
+	txMgr.On("SuggestGasPriceCaps", mock.Anything).Return(big.NewInt(1), big.NewInt(10), big.NewInt(1), nil).Once()
+	bs.Txmgr = txMgr
+
+	queue := txmgr.NewQueue[txID](context.Background(), txMgr, 1)
+	receiptsCh := make(chan txmgr.TxReceipt[txID], 1)
+	txdata := txData{frames: []frameData{{data: []byte{0x01, 0x02, 0x03}}}}
+	require.NoError(t, bs.sendTransaction(context.Background(), txdata, queue, receiptsCh))
+	queue.Wait()
+
+	// no tx must be sent
+	txMgr.AssertExpectations(t)
+	txMgr.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
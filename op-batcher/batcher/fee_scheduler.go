@@ -0,0 +1,118 @@
+package batcher
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"slices"
+)
+
+// feeScheduler delays non-urgent batch submissions while L1 fees are high,
+// compared to the fees of recent L1 blocks. This smooths DA spend over fee
+// volatility.
+type feeScheduler struct {
+	// percentiles (0-100) of recent fees above which submission is delayed, 0 if disabled
+	baseFeePercentile float64
+	blobFeePercentile float64
+	// number of recent L1 blocks to keep fee samples of
+	window int
+
+	lastL1Num uint64
+	// fee samples of recent L1 blocks, oldest first
+	baseFees []float64
+	blobFees []float64
+}
+
+func newFeeScheduler(baseFeePercentile, blobFeePercentile float64, window uint64) *feeScheduler {
+	return &feeScheduler{
+		baseFeePercentile: baseFeePercentile,
+		blobFeePercentile: blobFeePercentile,
+		window:            int(window),
+	}
+}
+
+// addSample records the fees of the L1 block with number l1Num. Only the first
+// sample of an L1 block is recorded. The blob base fee is nil if the L1 has no
+// blob fee market yet, in which case no blob fee sample is recorded.
+func (s *feeScheduler) addSample(l1Num uint64, baseFee, blobBaseFee *big.Int) {
+	if l1Num <= s.lastL1Num {
+		return
+	}
+	s.lastL1Num = l1Num
+	s.baseFees = appendWindowed(s.baseFees, bigToFloat(baseFee), s.window)
+	if blobBaseFee != nil {
+		s.blobFees = appendWindowed(s.blobFees, bigToFloat(blobBaseFee), s.window)
+	}
+}
+
+// feesTooHigh returns whether the given fees are above the configured
+// percentiles of recent fees. The blob base fee is only considered if blobs are
+// used and the blob base fee is known. Fees are never considered too high
+// before a full window of samples got recorded.
+func (s *feeScheduler) feesTooHigh(baseFee, blobBaseFee *big.Int, useBlobs bool) bool {
+	if len(s.baseFees) < s.window {
+		return false
+	}
+	if s.baseFeePercentile > 0 && bigToFloat(baseFee) > percentile(s.baseFees, s.baseFeePercentile) {
+		return true
+	}
+	if !useBlobs || blobBaseFee == nil || len(s.blobFees) < s.window {
+		return false
+	}
+	return s.blobFeePercentile > 0 && bigToFloat(blobBaseFee) > percentile(s.blobFees, s.blobFeePercentile)
+}
+
+// delaySubmission returns whether batch submission should be delayed because
+// of high L1 fees. Submission is never delayed at or after the submission
+// deadline of the pending data, to respect the sequencing window and channel
+// timeout safety margins. The blob base fee is only considered if the pending
+// data is submitted as blobs.
+func (l *BatchSubmitter) delaySubmission(ctx context.Context) bool {
+	l1tip, err := l.l1Tip(ctx)
+	if err != nil {
+		l.Log.Warn("Failed to query L1 tip for fee scheduling, not delaying submission", "err", err)
+		return false
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	_, baseFee, blobBaseFee, err := l.Txmgr.SuggestGasPriceCaps(cCtx)
+	if err != nil {
+		l.Log.Warn("Failed to query L1 fees for fee scheduling, not delaying submission", "err", err)
+		return false
+	}
+	l.feeScheduler.addSample(l1tip.Number, baseFee, blobBaseFee)
+
+	delay := l.feeScheduler.feesTooHigh(baseFee, blobBaseFee, l.state.PendingUseBlobs())
+	if deadline, ok := l.state.SubmissionDeadline(l.Config.SubSafetyMargin); delay && ok && l1tip.Number >= deadline {
+		l.Log.Info("L1 fees are high, but submitting to meet deadline", "l1_tip", l1tip.ID(), "deadline", deadline,
+			"base_fee", baseFee, "blob_base_fee", blobBaseFee)
+		delay = false
+	} else if delay {
+		l.Log.Info("Delaying batch submission due to high L1 fees", "l1_tip", l1tip.ID(), "deadline", deadline,
+			"base_fee", baseFee, "blob_base_fee", blobBaseFee)
+	}
+	l.Metr.RecordFeeSchedulerDelay(delay)
+	return delay
+}
+
+func appendWindowed(samples []float64, sample float64, window int) []float64 {
+	samples = append(samples, sample)
+	if len(samples) > window {
+		samples = samples[len(samples)-window:]
+	}
+	return samples
+}
+
+// percentile returns the p-th percentile (0-100) of the samples, using the
+// nearest-rank method.
+func percentile(samples []float64, p float64) float64 {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+func bigToFloat(x *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(x).Float64()
+	return f
+}
@@ -0,0 +1,149 @@
+package batcher
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+type stubL1Client struct {
+	head *types.Header
+}
+
+func (c *stubL1Client) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return c.head, nil
+}
+
+func (c *stubL1Client) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	return 0, nil
+}
+
+func TestPercentile(t *testing.T) {
+	samples := []float64{5, 1, 4, 2, 3}
+	require.Equal(t, 1.0, percentile(samples, 0))
+	require.Equal(t, 1.0, percentile(samples, 20))
+	require.Equal(t, 3.0, percentile(samples, 50))
+	require.Equal(t, 4.0, percentile(samples, 80))
+	require.Equal(t, 5.0, percentile(samples, 81))
+	require.Equal(t, 5.0, percentile(samples, 100))
+	require.Equal(t, []float64{5, 1, 4, 2, 3}, samples, "samples must not be modified")
+}
+
+func TestFeeScheduler_FeesTooHigh(t *testing.T) {
+	s := newFeeScheduler(50, 0, 4)
+	for i := uint64(1); i <= 3; i++ {
+		s.addSample(i, big.NewInt(int64(i)), big.NewInt(1))
+	}
+	require.False(t, s.feesTooHigh(big.NewInt(100), big.NewInt(1), false), "window not full yet")
+
+	s.addSample(3, big.NewInt(100), big.NewInt(1))
+	require.Len(t, s.baseFees, 3, "only first sample per L1 block is recorded")
+
+	s.addSample(4, big.NewInt(4), big.NewInt(1))
+	require.Equal(t, []float64{1, 2, 3, 4}, s.baseFees)
+	require.False(t, s.feesTooHigh(big.NewInt(2), big.NewInt(100), true))
+	require.True(t, s.feesTooHigh(big.NewInt(3), big.NewInt(1), false))
+
+	s.addSample(5, big.NewInt(5), big.NewInt(1))
+	require.Equal(t, []float64{2, 3, 4, 5}, s.baseFees, "oldest sample dropped")
+	require.False(t, s.feesTooHigh(big.NewInt(3), big.NewInt(1), false))
+
+	t.Run("blob-fee", func(t *testing.T) {
+		s := newFeeScheduler(0, 50, 2)
+		s.addSample(1, big.NewInt(100), big.NewInt(1))
+		s.addSample(2, big.NewInt(100), big.NewInt(2))
+		require.False(t, s.feesTooHigh(big.NewInt(1000), big.NewInt(1), true), "base fee percentile disabled")
+		require.True(t, s.feesTooHigh(big.NewInt(1), big.NewInt(3), true))
+		require.False(t, s.feesTooHigh(big.NewInt(1), big.NewInt(3), false), "blob fee ignored for calldata")
+	})
+
+	t.Run("no-blob-fee", func(t *testing.T) {
+		s := newFeeScheduler(50, 50, 2)
+		s.addSample(1, big.NewInt(1), nil)
+		s.addSample(2, big.NewInt(2), nil)
+		require.Empty(t, s.blobFees)
+		require.False(t, s.feesTooHigh(big.NewInt(1), nil, true))
+		require.True(t, s.feesTooHigh(big.NewInt(3), nil, true))
+
+		// blob fees are only considered once a full window of blob fee samples got recorded
+		s.addSample(3, big.NewInt(1), big.NewInt(1))
+		require.False(t, s.feesTooHigh(big.NewInt(1), big.NewInt(100), true))
+		s.addSample(4, big.NewInt(1), big.NewInt(1))
+		require.True(t, s.feesTooHigh(big.NewInt(1), big.NewInt(100), true))
+	})
+}
+
+func TestBatchSubmitter_DelaySubmission(t *testing.T) {
+	bs, _ := setup(t)
+	bs.RollupConfig.SeqWindowSize = 10
+	bs.Config.SubSafetyMargin = 2
+	bs.feeScheduler = newFeeScheduler(50, 0, 1)
+	bs.state.cfgProvider = defaultTestChannelConfig()
+	l1 := &stubL1Client{}
+	bs.L1Client = l1
+	txMgr := new(mocks.TxManager)
+	bs.Txmgr = txMgr
+	suggestFees := func(baseFee int64) {
+		txMgr.On("SuggestGasPriceCaps", mock.Anything).Return(big.NewInt(1), big.NewInt(baseFee), big.NewInt(1), nil).Once()
+	}
+
+	l1.head = &types.Header{Number: big.NewInt(100)}
+	suggestFees(10)
+	require.False(t, bs.delaySubmission(context.Background()))
+
+	// fees above window percentile
+	l1.head = &types.Header{Number: big.NewInt(101)}
+	suggestFees(20)
+	require.False(t, bs.delaySubmission(context.Background()))
+	suggestFees(30)
+	require.True(t, bs.delaySubmission(context.Background()), "no pending data")
+
+	// pending block with L1 origin 100 has submission deadline 100+10-2
+	require.NoError(t, bs.state.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{})))
+	deadline, ok := bs.state.SubmissionDeadline(bs.Config.SubSafetyMargin)
+	require.True(t, ok)
+	require.EqualValues(t, 108, deadline)
+	suggestFees(30)
+	require.True(t, bs.delaySubmission(context.Background()))
+
+	l1.head = &types.Header{Number: big.NewInt(108)}
+	suggestFees(40)
+	require.False(t, bs.delaySubmission(context.Background()), "deadline reached")
+	txMgr.AssertExpectations(t)
+}
+
+func TestBatchSubmitter_DelaySubmissionBlobFee(t *testing.T) {
+	bs, _ := setup(t)
+	bs.feeScheduler = newFeeScheduler(0, 50, 1)
+	calldataCfg := defaultTestChannelConfig()
+	blobCfg := defaultTestChannelConfig()
+	blobCfg.UseBlobs = true
+	bs.state.cfgProvider = blobCfg
+	l1 := &stubL1Client{}
+	bs.L1Client = l1
+	txMgr := new(mocks.TxManager)
+	bs.Txmgr = txMgr
+	suggestFees := func(blobBaseFee int64) {
+		txMgr.On("SuggestGasPriceCaps", mock.Anything).Return(big.NewInt(1), big.NewInt(1), big.NewInt(blobBaseFee), nil).Once()
+	}
+
+	l1.head = &types.Header{Number: big.NewInt(100)}
+	suggestFees(10)
+	require.False(t, bs.delaySubmission(context.Background()))
+	suggestFees(20)
+	require.True(t, bs.delaySubmission(context.Background()), "new channel uses blobs")
+
+	// the DA type of the pending data counts, not the one of the last tx
+	bs.lastTxAsBlob = true
+	bs.state.cfgProvider = calldataCfg
+	suggestFees(20)
+	require.False(t, bs.delaySubmission(context.Background()), "new channel uses calldata")
+	txMgr.AssertExpectations(t)
+}
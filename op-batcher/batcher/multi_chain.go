@@ -0,0 +1,208 @@
+package batcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// ChainConfig configures an additional chain that a multi-chain batcher submits
+// batches for. Each chain has its own L2 endpoints, rollup config, batcher
+// signer, metrics and Plasma DA client. The L1 connection, tx manager fee
+// settings and all other batcher settings are shared with the main chain. The
+// admin RPC only controls the main chain.
+type ChainConfig struct {
+	// Name identifies the chain in logs, and namespaces its metrics.
+	Name string `json:"name"`
+
+	// L2EthRpc and RollupRpc are the L2 endpoints of the chain, with the same
+	// format as the equally named CLIConfig fields.
+	L2EthRpc  string `json:"l2_eth_rpc"`
+	RollupRpc string `json:"rollup_rpc"`
+
+	// Batcher signer of the chain. A remote signer uses the TLS config of the
+	// main chain's signer.
+	opcrypto.ChainSignerConfig
+
+	// PlasmaDA configures the Plasma DA server of the chain. If not set, the
+	// chain uses the Plasma DA config of the main chain.
+	PlasmaDA *ChainPlasmaConfig `json:"plasma_da,omitempty"`
+}
+
+// ChainPlasmaConfig is the Plasma DA config of an additional chain, with the
+// same meaning as the equally named plasma.CLIConfig and CLIConfig fields.
+type ChainPlasmaConfig struct {
+	Enabled      bool   `json:"enabled"`
+	DAServerURL  string `json:"da_server"`
+	VerifyOnRead bool   `json:"verify_on_read"`
+	GenericDA    bool   `json:"generic_da"`
+	FallbackToL1 bool   `json:"fallback_to_l1"`
+}
+
+func (c *ChainPlasmaConfig) CLIConfig() plasma.CLIConfig {
+	return plasma.CLIConfig{
+		Enabled:      c.Enabled,
+		DAServerURL:  c.DAServerURL,
+		VerifyOnRead: c.VerifyOnRead,
+		GenericDA:    c.GenericDA,
+	}
+}
+
+var chainNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+func (c *ChainConfig) Check() error {
+	if c.Name == "" {
+		return errors.New("empty chain name")
+	}
+	if !chainNameRegex.MatchString(c.Name) {
+		return errors.New("chain name may only contain letters, digits, dashes and underscores")
+	}
+	if c.L2EthRpc == "" {
+		return errors.New("empty L2 RPC URL")
+	}
+	if c.RollupRpc == "" {
+		return errors.New("empty rollup RPC URL")
+	}
+	if strings.Count(c.RollupRpc, ",") != strings.Count(c.L2EthRpc, ",") {
+		return errors.New("number of rollup and eth URLs must match")
+	}
+	if c.PlasmaDA != nil {
+		if err := c.PlasmaDA.CLIConfig().Check(); err != nil {
+			return err
+		}
+	}
+	return c.ChainSignerConfig.Check()
+}
+
+// ReadChainsConfig reads the additional chains of a multi-chain batcher from
+// the JSON file at path. The file contains a list of ChainConfig objects.
+func ReadChainsConfig(path string) ([]ChainConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chains config: %w", err)
+	}
+	var chains []ChainConfig
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("failed to decode chains config: %w", err)
+	}
+	// names are compared by metrics namespace, which doesn't distinguish dashes and underscores
+	names := make(map[string]bool)
+	for i := range chains {
+		if err := chains[i].Check(); err != nil {
+			return nil, fmt.Errorf("invalid chain config %d: %w", i, err)
+		}
+		ns := metrics.ChainNamespace(chains[i].Name)
+		if names[ns] {
+			return nil, fmt.Errorf("duplicate chain name %q", chains[i].Name)
+		}
+		names[ns] = true
+	}
+	return chains, nil
+}
+
+// initChains initializes a batcher service per additional chain of the chains
+// config. They share the L1 client, the tx manager's L1 backend and fee
+// settings and the metrics server with the main batcher service.
+// Must be called after the main service got initialized.
+func (bs *BatcherService) initChains(ctx context.Context, cfg *CLIConfig) error {
+	if cfg.ChainsConfig == "" {
+		return nil
+	}
+	chains, err := ReadChainsConfig(cfg.ChainsConfig)
+	if err != nil {
+		return err
+	}
+
+	// Batcher addresses must not be shared, to not mess up nonces, and each
+	// chain must only be batched once.
+	addrs := map[common.Address]string{bs.TxManager.From(): "main"}
+	chainIDs := map[string]string{bs.RollupConfig.L2ChainID.String(): "main"}
+	for _, chain := range chains {
+		cs, err := bs.initChain(ctx, cfg, chain)
+		if cs != nil {
+			// also append partially initialized services, to stop them in Stop
+			bs.chains = append(bs.chains, cs)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to init chain %s: %w", chain.Name, err)
+		}
+		if other, ok := addrs[cs.TxManager.From()]; ok {
+			return fmt.Errorf("chain %s uses the same batcher address %s as chain %s", chain.Name, cs.TxManager.From(), other)
+		}
+		addrs[cs.TxManager.From()] = chain.Name
+		chainID := cs.RollupConfig.L2ChainID.String()
+		if other, ok := chainIDs[chainID]; ok {
+			return fmt.Errorf("chain %s has the same L2 chain ID %s as chain %s", chain.Name, chainID, other)
+		}
+		chainIDs[chainID] = chain.Name
+		cs.Log.Info("Initialized additional chain", "l2_chain_id", chainID, "batcher_address", cs.TxManager.From())
+	}
+	return nil
+}
+
+func (bs *BatcherService) initChain(ctx context.Context, cfg *CLIConfig, chain ChainConfig) (*BatcherService, error) {
+	cs := &BatcherService{
+		Log:           bs.Log.New("chain", chain.Name),
+		Metrics:       bs.Metrics,
+		Tracer:        bs.Tracer,
+		L1Client:      bs.L1Client,
+		BatcherConfig: bs.BatcherConfig,
+		Version:       bs.Version,
+	}
+	// the metrics of the chain are served by the metrics server of the main service
+	if m, ok := bs.Metrics.(*metrics.Metrics); ok {
+		cs.Metrics = m.NewChainMetrics(chain.Name)
+	}
+	chainCfg := *cfg
+	chainCfg.L2EthRpc = chain.L2EthRpc
+	chainCfg.RollupRpc = chain.RollupRpc
+	if chain.PlasmaDA != nil {
+		chainCfg.PlasmaDA = chain.PlasmaDA.CLIConfig()
+		chainCfg.PlasmaFallbackToL1 = chain.PlasmaDA.FallbackToL1
+	}
+	if err := chainCfg.Check(); err != nil {
+		return nil, err
+	}
+	if err := cs.initPlasmaDA(&chainCfg); err != nil {
+		return nil, fmt.Errorf("failed to init plasma DA: %w", err)
+	}
+	if err := cs.initL2EndpointProvider(ctx, &chainCfg); err != nil {
+		return cs, err
+	}
+	if err := cs.initRollupConfig(ctx); err != nil {
+		return cs, fmt.Errorf("failed to load rollup config: %w", err)
+	}
+
+	signerFactory, from, err := chain.SignerFactory(cs.Log, cfg.TxMgrConfig.SignerCLIConfig)
+	if err != nil {
+		return cs, fmt.Errorf("could not init signer: %w", err)
+	}
+	txCfg := bs.txMgrConfig
+	txCfg.Signer = signerFactory(txCfg.ChainID)
+	txCfg.From = from
+	txManager, err := txmgr.NewSimpleTxManagerFromConfig("batcher", cs.Log, cs.Metrics, txCfg)
+	if err != nil {
+		return cs, fmt.Errorf("failed to init Tx manager: %w", err)
+	}
+	cs.TxManager = txManager
+	cs.initBalanceMonitor(&chainCfg)
+
+	if err := cs.initChannelConfig(&chainCfg); err != nil {
+		return cs, fmt.Errorf("failed to init channel config: %w", err)
+	}
+	cs.initDriver()
+	cs.Metrics.RecordInfo(cs.Version)
+	cs.Metrics.RecordUp()
+	return cs, nil
+}
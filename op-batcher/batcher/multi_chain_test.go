@@ -0,0 +1,172 @@
+package batcher
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
+)
+
+func validChainConfig() ChainConfig {
+	return ChainConfig{
+		Name:      "chain-a",
+		L2EthRpc:  "http://localhost:9545",
+		RollupRpc: "http://localhost:8545",
+		ChainSignerConfig: opcrypto.ChainSignerConfig{
+			PrivateKeyEnv: "CHAIN_A_BATCHER_KEY",
+		},
+	}
+}
+
+func TestChainConfig_Check(t *testing.T) {
+	require.NoError(t, (&ChainConfig{
+		Name:      "remote-signer",
+		L2EthRpc:  "http://localhost:9545",
+		RollupRpc: "http://localhost:8545",
+		ChainSignerConfig: opcrypto.ChainSignerConfig{
+			SignerEndpoint: "http://localhost:8000",
+			SignerAddress:  "0x3c44cdddb6a900fa2b585dd299e03d12fa4293bc",
+		},
+	}).Check())
+
+	tests := []struct {
+		name      string
+		override  func(c *ChainConfig)
+		errString string
+	}{
+		{
+			name:      "empty name",
+			override:  func(c *ChainConfig) { c.Name = "" },
+			errString: "empty chain name",
+		},
+		{
+			name:      "invalid name",
+			override:  func(c *ChainConfig) { c.Name = "chain a" },
+			errString: "chain name may only contain letters, digits, dashes and underscores",
+		},
+		{
+			name:      "plasma DA without server",
+			override:  func(c *ChainConfig) { c.PlasmaDA = &ChainPlasmaConfig{Enabled: true} },
+			errString: "DA server URL is required when plasma da is enabled",
+		},
+		{
+			name:      "empty L2 RPC",
+			override:  func(c *ChainConfig) { c.L2EthRpc = "" },
+			errString: "empty L2 RPC URL",
+		},
+		{
+			name:      "empty rollup RPC",
+			override:  func(c *ChainConfig) { c.RollupRpc = "" },
+			errString: "empty rollup RPC URL",
+		},
+		{
+			name:      "mismatched URLs",
+			override:  func(c *ChainConfig) { c.L2EthRpc = "http://a,http://b" },
+			errString: "number of rollup and eth URLs must match",
+		},
+		{
+			name:      "no signer",
+			override:  func(c *ChainConfig) { c.PrivateKeyEnv = "" },
+			errString: "must provide a private key or mnemonic environment variable, a signer endpoint or a KMS key",
+		},
+		{
+			name:      "signer endpoint without address",
+			override:  func(c *ChainConfig) { c.PrivateKeyEnv, c.SignerEndpoint = "", "http://localhost:8000" },
+			errString: "signer endpoint and address must both be set",
+		},
+		{
+			name: "signer endpoint and local key",
+			override: func(c *ChainConfig) {
+				c.SignerEndpoint = "http://localhost:8000"
+				c.SignerAddress = "0x3c44cdddb6a900fa2b585dd299e03d12fa4293bc"
+			},
+			errString: "only one of a local key, a signer endpoint or a KMS key may be set",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validChainConfig()
+			tc.override(&cfg)
+			require.ErrorContains(t, cfg.Check(), tc.errString)
+		})
+	}
+}
+
+func TestReadChainsConfig(t *testing.T) {
+	writeConfig := func(t *testing.T, data string) string {
+		path := filepath.Join(t.TempDir(), "chains.json")
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		return path
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		path := writeConfig(t, `[
+			{"name": "chain-a", "l2_eth_rpc": "http://a:9545", "rollup_rpc": "http://a:8545", "private_key_env": "KEY_A"},
+			{"name": "chain-b", "l2_eth_rpc": "ws://b:9546", "rollup_rpc": "http://b:8545", "mnemonic_env": "MNEMONIC_B", "hd_path": "m/44'/60'/0'/0/1",
+				"plasma_da": {"enabled": true, "da_server": "http://da-b:3100", "fallback_to_l1": true}}
+		]`)
+		chains, err := ReadChainsConfig(path)
+		require.NoError(t, err)
+		require.Equal(t, []ChainConfig{
+			{Name: "chain-a", L2EthRpc: "http://a:9545", RollupRpc: "http://a:8545",
+				ChainSignerConfig: opcrypto.ChainSignerConfig{PrivateKeyEnv: "KEY_A"}},
+			{Name: "chain-b", L2EthRpc: "ws://b:9546", RollupRpc: "http://b:8545",
+				ChainSignerConfig: opcrypto.ChainSignerConfig{MnemonicEnv: "MNEMONIC_B", HDPath: "m/44'/60'/0'/0/1"},
+				PlasmaDA:          &ChainPlasmaConfig{Enabled: true, DAServerURL: "http://da-b:3100", FallbackToL1: true}},
+		}, chains)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		path := writeConfig(t, `[
+			{"name": "chain-a", "l2_eth_rpc": "http://a:9545", "rollup_rpc": "http://a:8545", "private_key_env": "KEY_A"},
+			{"name": "chain-a", "l2_eth_rpc": "http://b:9545", "rollup_rpc": "http://b:8545", "private_key_env": "KEY_B"}
+		]`)
+		_, err := ReadChainsConfig(path)
+		require.ErrorContains(t, err, `duplicate chain name "chain-a"`)
+
+		// both use the same metrics namespace
+		path = writeConfig(t, `[
+			{"name": "chain-a", "l2_eth_rpc": "http://a:9545", "rollup_rpc": "http://a:8545", "private_key_env": "KEY_A"},
+			{"name": "chain_a", "l2_eth_rpc": "http://b:9545", "rollup_rpc": "http://b:8545", "private_key_env": "KEY_B"}
+		]`)
+		_, err = ReadChainsConfig(path)
+		require.ErrorContains(t, err, `duplicate chain name "chain_a"`)
+	})
+
+	t.Run("invalid chain", func(t *testing.T) {
+		path := writeConfig(t, `[{"name": "chain-a", "rollup_rpc": "http://a:8545", "private_key_env": "KEY_A"}]`)
+		_, err := ReadChainsConfig(path)
+		require.ErrorContains(t, err, "invalid chain config 0: empty L2 RPC URL")
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := ReadChainsConfig(writeConfig(t, `{`))
+		require.ErrorContains(t, err, "failed to decode chains config")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ReadChainsConfig(filepath.Join(t.TempDir(), "missing.json"))
+		require.ErrorContains(t, err, "failed to read chains config")
+	})
+}
+
+func TestChainMetrics(t *testing.T) {
+	m := metrics.NewMetrics("")
+	m.NewChainMetrics("chain-a").RecordUp()
+
+	// the chain metrics are served from the registry of the main metrics
+	families, err := m.Registry().Gather()
+	require.NoError(t, err)
+	up := make(map[string]float64)
+	for _, f := range families {
+		if strings.HasSuffix(f.GetName(), "_up") {
+			up[f.GetName()] = f.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	require.Equal(t, map[string]float64{"op_batcher_default_up": 0, "op_batcher_chain_chain_a_up": 1}, up)
+}
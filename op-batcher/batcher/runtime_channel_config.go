@@ -0,0 +1,127 @@
+package batcher
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// RuntimeChannelConfig is a ChannelConfigProvider whose data availability type
+// and some channel parameters can be changed at runtime, e.g. via the admin API.
+// Changes only apply to new channels, so already open channels are unaffected.
+type RuntimeChannelConfig struct {
+	mu  sync.Mutex
+	log log.Logger
+
+	daType    flags.DataAvailabilityType
+	providers map[flags.DataAvailabilityType]ChannelConfigProvider
+	// calldataEmergency selects calldata channels, regardless of daType
+	calldataEmergency bool
+
+	// overrides, not set if nil
+	maxChannelDuration *uint64
+	maxFrameSize       *uint64
+}
+
+var _ ChannelConfigProvider = (*RuntimeChannelConfig)(nil)
+
+// NewRuntimeChannelConfig creates a new RuntimeChannelConfig that initially
+// uses the channel config provider of the given data availability type.
+// The providers map holds the providers of all data availability types that
+// can be selected at runtime.
+func NewRuntimeChannelConfig(lgr log.Logger, daType flags.DataAvailabilityType,
+	providers map[flags.DataAvailabilityType]ChannelConfigProvider,
+) (*RuntimeChannelConfig, error) {
+	if _, ok := providers[daType]; !ok {
+		return nil, fmt.Errorf("no channel config for data availability type %q", daType)
+	}
+	return &RuntimeChannelConfig{
+		log:       lgr,
+		daType:    daType,
+		providers: providers,
+	}, nil
+}
+
+// ChannelConfig returns the channel config of the current data availability
+// type, with the runtime overrides applied.
+func (rc *RuntimeChannelConfig) ChannelConfig() ChannelConfig {
+	rc.mu.Lock()
+	provider := rc.providers[rc.daType]
+	if rc.calldataEmergency {
+		provider = rc.providers[flags.CalldataType]
+	}
+	maxChannelDuration, maxFrameSize := rc.maxChannelDuration, rc.maxFrameSize
+	rc.mu.Unlock()
+
+	// provider may query the L1, so don't hold the lock
+	cfg := provider.ChannelConfig()
+	if maxChannelDuration != nil {
+		cfg.MaxChannelDuration = *maxChannelDuration
+	}
+	// The frame size override can only lower the frame size, because the
+	// configured frame size is the max that the data availability type supports.
+	if maxFrameSize != nil && *maxFrameSize < cfg.MaxFrameSize {
+		cfg.MaxFrameSize = *maxFrameSize
+		cc := cfg.CompressorConfig
+		cfg.InitCompressorConfig(cc.ApproxComprRatio, cc.Kind, cc.CompressionAlgo)
+	}
+	return cfg
+}
+
+// DAType returns the currently selected data availability type.
+func (rc *RuntimeChannelConfig) DAType() flags.DataAvailabilityType {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.daType
+}
+
+// SetDAType selects the data availability type to use for new channels.
+func (rc *RuntimeChannelConfig) SetDAType(daType flags.DataAvailabilityType) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if _, ok := rc.providers[daType]; !ok {
+		return fmt.Errorf("data availability type %q not available", daType)
+	}
+	rc.log.Info("Setting data availability type for new channels", "da_type", daType, "prev_da_type", rc.daType)
+	rc.daType = daType
+	return nil
+}
+
+// SetMaxChannelDuration sets the max channel duration (in #L1-blocks) of new
+// channels. 0 disables duration checks.
+func (rc *RuntimeChannelConfig) SetMaxChannelDuration(duration uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.log.Info("Setting max channel duration for new channels", "max_channel_duration", duration)
+	rc.maxChannelDuration = &duration
+}
+
+// SetMaxFrameSize sets the max frame size of new channels. It is capped by the
+// frame size that the selected data availability type is configured with.
+func (rc *RuntimeChannelConfig) SetMaxFrameSize(size uint64) error {
+	if size <= derive.FrameV0OverHeadSize {
+		return fmt.Errorf("max frame size %d must be larger than the frame overhead %d", size, derive.FrameV0OverHeadSize)
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.log.Info("Setting max frame size for new channels", "max_frame_size", size)
+	rc.maxFrameSize = &size
+	return nil
+}
+
+// SetCalldataEmergency makes new channels use calldata, regardless of the
+// selected data availability type, while active. It is used to keep submitting
+// batches while blob transactions fail persistently.
+func (rc *RuntimeChannelConfig) SetCalldataEmergency(active bool) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if _, ok := rc.providers[flags.CalldataType]; !ok {
+		return fmt.Errorf("data availability type %q not available", flags.CalldataType)
+	}
+	rc.calldataEmergency = active
+	return nil
+}
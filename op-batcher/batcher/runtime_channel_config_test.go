@@ -0,0 +1,112 @@
+package batcher
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func runtimeTestChannelConfigs() (calldataCfg, blobCfg ChannelConfig) {
+	calldataCfg = ChannelConfig{
+		SeqWindowSize:      15,
+		MaxChannelDuration: 10,
+		MaxFrameSize:       120_000 - 1,
+		TargetNumFrames:    1,
+	}
+	calldataCfg.InitCompressorConfig(0.4, compressor.ShadowKind, derive.Zlib)
+	blobCfg = ChannelConfig{
+		SeqWindowSize:      15,
+		MaxChannelDuration: 10,
+		MaxFrameSize:       130_000 - 1,
+		TargetNumFrames:    3,
+		MultiFrameTxs:      true,
+		UseBlobs:           true,
+	}
+	blobCfg.InitCompressorConfig(0.4, compressor.ShadowKind, derive.Zlib)
+	return calldataCfg, blobCfg
+}
+
+func TestNewRuntimeChannelConfig_MissingDAType(t *testing.T) {
+	calldataCfg, _ := runtimeTestChannelConfigs()
+	_, err := NewRuntimeChannelConfig(testlog.Logger(t, log.LevelInfo), flags.BlobsType,
+		map[flags.DataAvailabilityType]ChannelConfigProvider{flags.CalldataType: calldataCfg})
+	require.ErrorContains(t, err, "no channel config")
+}
+
+func TestRuntimeChannelConfig_SetDAType(t *testing.T) {
+	calldataCfg, blobCfg := runtimeTestChannelConfigs()
+	rc, err := NewRuntimeChannelConfig(testlog.Logger(t, log.LevelInfo), flags.BlobsType,
+		map[flags.DataAvailabilityType]ChannelConfigProvider{
+			flags.CalldataType: calldataCfg,
+			flags.BlobsType:    blobCfg,
+		})
+	require.NoError(t, err)
+	require.Equal(t, flags.BlobsType, rc.DAType())
+	require.Equal(t, blobCfg, rc.ChannelConfig())
+
+	require.NoError(t, rc.SetDAType(flags.CalldataType))
+	require.Equal(t, flags.CalldataType, rc.DAType())
+	require.Equal(t, calldataCfg, rc.ChannelConfig())
+
+	require.ErrorContains(t, rc.SetDAType(flags.AutoType), "not available")
+	require.Equal(t, flags.CalldataType, rc.DAType())
+}
+
+func TestRuntimeChannelConfig_Overrides(t *testing.T) {
+	calldataCfg, blobCfg := runtimeTestChannelConfigs()
+	rc, err := NewRuntimeChannelConfig(testlog.Logger(t, log.LevelInfo), flags.BlobsType,
+		map[flags.DataAvailabilityType]ChannelConfigProvider{
+			flags.CalldataType: calldataCfg,
+			flags.BlobsType:    blobCfg,
+		})
+	require.NoError(t, err)
+
+	rc.SetMaxChannelDuration(0)
+	cfg := rc.ChannelConfig()
+	require.Zero(t, cfg.MaxChannelDuration)
+	require.Equal(t, blobCfg.MaxFrameSize, cfg.MaxFrameSize)
+
+	require.ErrorContains(t, rc.SetMaxFrameSize(derive.FrameV0OverHeadSize), "frame overhead")
+
+	require.NoError(t, rc.SetMaxFrameSize(125_000))
+	cfg = rc.ChannelConfig()
+	require.EqualValues(t, 125_000, cfg.MaxFrameSize)
+	// compressor target size must follow the frame size
+	require.Equal(t, MaxDataSize(cfg.TargetNumFrames, cfg.MaxFrameSize), cfg.CompressorConfig.TargetOutputSize)
+	require.NoError(t, cfg.Check())
+
+	// overrides also apply after switching the DA type, but the frame size is
+	// capped by the DA type's frame size
+	require.NoError(t, rc.SetDAType(flags.CalldataType))
+	cfg = rc.ChannelConfig()
+	require.Zero(t, cfg.MaxChannelDuration)
+	require.Equal(t, calldataCfg.MaxFrameSize, cfg.MaxFrameSize)
+	require.Equal(t, calldataCfg.CompressorConfig, cfg.CompressorConfig)
+}
+
+func TestRuntimeChannelConfig_CalldataEmergency(t *testing.T) {
+	calldataCfg, blobCfg := runtimeTestChannelConfigs()
+	rc, err := NewRuntimeChannelConfig(testlog.Logger(t, log.LevelInfo), flags.BlobsType,
+		map[flags.DataAvailabilityType]ChannelConfigProvider{
+			flags.CalldataType: calldataCfg,
+			flags.BlobsType:    blobCfg,
+		})
+	require.NoError(t, err)
+
+	require.NoError(t, rc.SetCalldataEmergency(true))
+	require.Equal(t, calldataCfg, rc.ChannelConfig())
+	require.Equal(t, flags.BlobsType, rc.DAType(), "selected DA type is kept")
+	require.NoError(t, rc.SetCalldataEmergency(false))
+	require.Equal(t, blobCfg, rc.ChannelConfig())
+
+	rc, err = NewRuntimeChannelConfig(testlog.Logger(t, log.LevelInfo), flags.BlobsType,
+		map[flags.DataAvailabilityType]ChannelConfigProvider{flags.BlobsType: blobCfg})
+	require.NoError(t, err)
+	require.ErrorContains(t, rc.SetCalldataEmergency(true), "not available")
+}
@@ -21,10 +21,12 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/health"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -35,15 +37,43 @@ type BatcherConfig struct {
 	PollInterval           time.Duration
 	MaxPendingTransactions uint64
 
-	// UseBlobs is true if the batcher should use blobs instead of calldata for posting blobs
+	// UseBlobs is true if the batcher should use blobs instead of calldata for posting blobs.
+	// If the auto data availability type is configured, blobs may only be used for some channels.
 	UseBlobs bool
 
 	// UsePlasma is true if the rollup config has a DA challenge address so the batcher
 	// will post inputs to the Plasma DA server and post commitments to blobs or calldata.
 	UsePlasma bool
+	// PlasmaFallbackToL1 enables posting inputs directly to L1 calldata if they
+	// cannot be posted to the Plasma DA server.
+	PlasmaFallbackToL1 bool
 
 	WaitNodeSync        bool
 	CheckRecentTxsDepth int
+
+	// DryRun enables building channels without submitting them to L1, only
+	// reporting what submitting them would have cost.
+	DryRun bool
+
+	// L2HeadSubscription enables loading new L2 blocks on sync status notifications of the rollup node.
+	L2HeadSubscription bool
+
+	// Throttling of the DA size of new L2 blocks at the sequencer, see the equally named CLIConfig fields.
+	ThrottleThreshold       uint64
+	ThrottleTxSize          uint64
+	ThrottleBlockSize       uint64
+	ThrottleAlwaysBlockSize uint64
+
+	// Fee-scaled submission scheduling, see the equally named CLIConfig fields.
+	FeeSchedulerBaseFeePercentile float64
+	FeeSchedulerBlobFeePercentile float64
+	FeeSchedulerWindow            uint64
+	// Fallback to calldata on persistent blob tx failures, see the equally named CLIConfig fields.
+	BlobFailureThreshold  uint64
+	BlobEmergencyDuration time.Duration
+	// SubSafetyMargin is the channel config's sub safety margin, respected by
+	// the fee scheduler for blocks that aren't in a channel yet.
+	SubSafetyMargin uint64
 }
 
 // BatcherService represents a full batch-submitter instance and its resources,
@@ -51,6 +81,7 @@ type BatcherConfig struct {
 type BatcherService struct {
 	Log              log.Logger
 	Metrics          metrics.Metricer
+	Tracer           optracing.Tracer
 	L1Client         *ethclient.Client
 	EndpointProvider dial.L2EndpointProvider
 	TxManager        txmgr.TxManager
@@ -62,14 +93,26 @@ type BatcherService struct {
 
 	// Channel builder parameters
 	ChannelConfig ChannelConfig
+	// ChannelConfigProvider provides the channel config for each new channel. It
+	// uses the channel config of the currently selected data availability type,
+	// which, like some channel parameters, can be changed via the admin API.
+	// For the auto data availability type, it switches dynamically between a
+	// blob and calldata channel config.
+	ChannelConfigProvider *RuntimeChannelConfig
 
 	driver *BatchSubmitter
 
+	// txMgrConfig is the config of the TxManager, shared with additional chains.
+	txMgrConfig txmgr.Config
+	// chains are the batcher services of additional chains, see ChainConfig.
+	chains []*BatcherService
+
 	Version string
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
 	rpcServer    *oprpc.Server
+	closeTracer  func(ctx context.Context) error
 
 	balanceMetricer io.Closer
 	stopped         atomic.Bool
@@ -94,24 +137,40 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	bs.NotSubmittingOnStart = cfg.Stopped
 
 	bs.initMetrics(cfg)
+	if err := bs.initTracer(cfg); err != nil {
+		return fmt.Errorf("failed to init tracing: %w", err)
+	}
 
 	bs.PollInterval = cfg.PollInterval
 	bs.MaxPendingTransactions = cfg.MaxPendingTransactions
 	bs.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
 	bs.CheckRecentTxsDepth = cfg.CheckRecentTxsDepth
 	bs.WaitNodeSync = cfg.WaitNodeSync
+	bs.L2HeadSubscription = cfg.L2HeadSubscription
+	bs.DryRun = cfg.DryRun
+	bs.ThrottleThreshold = cfg.ThrottleThreshold
+	bs.ThrottleTxSize = cfg.ThrottleTxSize
+	bs.ThrottleBlockSize = cfg.ThrottleBlockSize
+	bs.ThrottleAlwaysBlockSize = cfg.ThrottleAlwaysBlockSize
+	bs.FeeSchedulerBaseFeePercentile = cfg.FeeSchedulerBaseFeePercentile
+	bs.FeeSchedulerBlobFeePercentile = cfg.FeeSchedulerBlobFeePercentile
+	bs.FeeSchedulerWindow = cfg.FeeSchedulerWindow
+	bs.BlobFailureThreshold = cfg.BlobFailureThreshold
+	bs.BlobEmergencyDuration = cfg.BlobEmergencyDuration
+	bs.SubSafetyMargin = cfg.SubSafetyMargin
 	if err := bs.initRPCClients(ctx, cfg); err != nil {
 		return err
 	}
 	if err := bs.initRollupConfig(ctx); err != nil {
 		return fmt.Errorf("failed to load rollup config: %w", err)
 	}
-	if err := bs.initChannelConfig(cfg); err != nil {
-		return fmt.Errorf("failed to init channel config: %w", err)
-	}
 	if err := bs.initTxManager(cfg); err != nil {
 		return fmt.Errorf("failed to init Tx manager: %w", err)
 	}
+	// init after Tx manager, which is used as gas pricer for the auto DA type
+	if err := bs.initChannelConfig(cfg); err != nil {
+		return fmt.Errorf("failed to init channel config: %w", err)
+	}
 	bs.initBalanceMonitor(cfg)
 	if err := bs.initMetricsServer(cfg); err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
@@ -124,6 +183,9 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 		return fmt.Errorf("failed to init plasma DA: %w", err)
 	}
 	bs.initDriver()
+	if err := bs.initChains(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init chains: %w", err)
+	}
 	if err := bs.initRPCServer(cfg); err != nil {
 		return fmt.Errorf("failed to start RPC server: %w", err)
 	}
@@ -140,7 +202,14 @@ func (bs *BatcherService) initRPCClients(ctx context.Context, cfg *CLIConfig) er
 	}
 	bs.L1Client = l1Client
 
-	var endpointProvider dial.L2EndpointProvider
+	return bs.initL2EndpointProvider(ctx, cfg)
+}
+
+func (bs *BatcherService) initL2EndpointProvider(ctx context.Context, cfg *CLIConfig) error {
+	var (
+		endpointProvider dial.L2EndpointProvider
+		err              error
+	)
 	if strings.Contains(cfg.RollupRpc, ",") && strings.Contains(cfg.L2EthRpc, ",") {
 		rollupUrls := strings.Split(cfg.RollupRpc, ",")
 		ethUrls := strings.Split(cfg.L2EthRpc, ",")
@@ -165,6 +234,16 @@ func (bs *BatcherService) initMetrics(cfg *CLIConfig) {
 	}
 }
 
+func (bs *BatcherService) initTracer(cfg *CLIConfig) error {
+	tracer, closeTracer, err := optracing.NewTracer(bs.Log, "op-batcher", cfg.TracingConfig)
+	if err != nil {
+		return err
+	}
+	bs.Tracer = tracer
+	bs.closeTracer = closeTracer
+	return nil
+}
+
 // initBalanceMonitor depends on Metrics, L1Client and TxManager to start background-monitoring of the batcher balance.
 func (bs *BatcherService) initBalanceMonitor(cfg *CLIConfig) {
 	if cfg.MetricsConfig.Enabled {
@@ -191,22 +270,24 @@ func (bs *BatcherService) initRollupConfig(ctx context.Context) error {
 
 func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 	cc := ChannelConfig{
-		SeqWindowSize:      bs.RollupConfig.SeqWindowSize,
-		ChannelTimeout:     bs.RollupConfig.ChannelTimeout,
-		MaxChannelDuration: cfg.MaxChannelDuration,
-		MaxFrameSize:       cfg.MaxL1TxSize - 1, // account for version byte prefix; reset for blobs
-		TargetNumFrames:    cfg.TargetNumFrames,
-		SubSafetyMargin:    cfg.SubSafetyMargin,
-		BatchType:          cfg.BatchType,
+		SeqWindowSize:         bs.RollupConfig.SeqWindowSize,
+		ChannelTimeout:        bs.RollupConfig.ChannelTimeout,
+		MaxChannelDuration:    cfg.MaxChannelDuration,
+		MaxBlocksPerSpanBatch: cfg.MaxBlocksPerSpanBatch,
+		MaxFrameSize:          cfg.MaxL1TxSize - 1, // account for version byte prefix; reset for blobs
+		TargetNumFrames:       cfg.TargetNumFrames,
+		SubSafetyMargin:       cfg.SubSafetyMargin,
+		BatchType:             cfg.BatchType,
 	}
 
 	switch cfg.DataAvailabilityType {
-	case flags.BlobsType:
+	case flags.BlobsType, flags.AutoType:
 		if !cfg.TestUseMaxTxSizeForBlobs {
 			// account for version byte prefix
 			cc.MaxFrameSize = eth.MaxBlobDataSize - 1
 		}
 		cc.MultiFrameTxs = true
+		cc.UseBlobs = true
 		bs.UseBlobs = true
 	case flags.CalldataType:
 		bs.UseBlobs = false
@@ -228,14 +309,52 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 	}
 
 	// Checking for brotli compression only post Fjord
-	if bs.ChannelConfig.CompressorConfig.CompressionAlgo.IsBrotli() && !bs.RollupConfig.IsFjord(uint64(time.Now().Unix())) {
+	if cc.CompressorConfig.CompressionAlgo.IsBrotli() && !bs.RollupConfig.IsFjord(uint64(time.Now().Unix())) {
 		return fmt.Errorf("cannot use brotli compression before Fjord")
 	}
 
 	if err := cc.Check(); err != nil {
 		return fmt.Errorf("invalid channel configuration: %w", err)
 	}
+
+	// Build the channel configs of all data availability types, so that the
+	// data availability type can be switched at runtime via the admin API.
+	calldataCC := cc
+	calldataCC.MaxFrameSize = cfg.MaxL1TxSize - 1 // account for version byte prefix
+	calldataCC.MultiFrameTxs = false
+	calldataCC.UseBlobs = false
+	calldataCC.InitCompressorConfig(cfg.ApproxComprRatio, cfg.Compressor, cfg.CompressionAlgo)
+	blobCC := cc
+	if !cfg.TestUseMaxTxSizeForBlobs {
+		// account for version byte prefix
+		blobCC.MaxFrameSize = eth.MaxBlobDataSize - 1
+	}
+	blobCC.MultiFrameTxs = true
+	blobCC.UseBlobs = true
+	blobCC.InitCompressorConfig(cfg.ApproxComprRatio, cfg.Compressor, cfg.CompressionAlgo)
+
+	providers := make(map[flags.DataAvailabilityType]ChannelConfigProvider)
+	calldataErr := bs.checkRuntimeChannelConfig(calldataCC)
+	if calldataErr == nil {
+		providers[flags.CalldataType] = calldataCC
+	}
+	if err := bs.checkRuntimeChannelConfig(blobCC); err == nil {
+		providers[flags.BlobsType] = blobCC
+		if calldataErr == nil {
+			providers[flags.AutoType] = NewDynamicEthChannelConfig(bs.Log, bs.Metrics,
+				bs.NetworkTimeout, bs.TxManager, blobCC, calldataCC)
+		}
+	}
+	if (cfg.DataAvailabilityType == flags.AutoType || cfg.BlobFailureThreshold > 0) && calldataErr != nil {
+		return fmt.Errorf("invalid calldata channel configuration: %w", calldataErr)
+	}
+	runtimeCC, err := NewRuntimeChannelConfig(bs.Log, cfg.DataAvailabilityType, providers)
+	if err != nil {
+		return err
+	}
+	bs.ChannelConfigProvider = runtimeCC
 	bs.Log.Info("Initialized channel-config",
+		"da_type", cfg.DataAvailabilityType,
 		"use_blobs", bs.UseBlobs,
 		"use_plasma", bs.UsePlasma,
 		"max_frame_size", cc.MaxFrameSize,
@@ -244,6 +363,7 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		"compression_algo", cc.CompressorConfig.CompressionAlgo,
 		"batch_type", cc.BatchType,
 		"max_channel_duration", cc.MaxChannelDuration,
+		"max_blocks_per_span_batch", cc.MaxBlocksPerSpanBatch,
 		"channel_timeout", cc.ChannelTimeout,
 		"sub_safety_margin", cc.SubSafetyMargin)
 	if bs.UsePlasma {
@@ -253,24 +373,31 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 	return nil
 }
 
+// checkRuntimeChannelConfig checks whether the channel config of a data
+// availability type can be selected at runtime.
+func (bs *BatcherService) checkRuntimeChannelConfig(cc ChannelConfig) error {
+	if bs.UsePlasma && cc.MaxFrameSize > plasma.MaxInputSize {
+		return fmt.Errorf("max frame size %d exceeds plasma max input size %d", cc.MaxFrameSize, plasma.MaxInputSize)
+	}
+	return cc.Check()
+}
+
 func (bs *BatcherService) initTxManager(cfg *CLIConfig) error {
-	txManager, err := txmgr.NewSimpleTxManager("batcher", bs.Log, bs.Metrics, cfg.TxMgrConfig)
+	txMgrConfig, err := txmgr.NewConfig(cfg.TxMgrConfig, bs.Log)
 	if err != nil {
 		return err
 	}
+	txManager, err := txmgr.NewSimpleTxManagerFromConfig("batcher", bs.Log, bs.Metrics, txMgrConfig)
+	if err != nil {
+		return err
+	}
+	bs.txMgrConfig = txMgrConfig
 	bs.TxManager = txManager
 	return nil
 }
 
 func (bs *BatcherService) initPProf(cfg *CLIConfig) error {
-	bs.pprofService = oppprof.New(
-		cfg.PprofConfig.ListenEnabled,
-		cfg.PprofConfig.ListenAddr,
-		cfg.PprofConfig.ListenPort,
-		cfg.PprofConfig.ProfileType,
-		cfg.PprofConfig.ProfileDir,
-		cfg.PprofConfig.ProfileFilename,
-	)
+	bs.pprofService = oppprof.NewFromConfig(cfg.PprofConfig)
 
 	if err := bs.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -302,27 +429,48 @@ func (bs *BatcherService) initDriver() {
 	bs.driver = NewBatchSubmitter(DriverSetup{
 		Log:              bs.Log,
 		Metr:             bs.Metrics,
+		Tracer:           bs.Tracer,
 		RollupConfig:     bs.RollupConfig,
 		Config:           bs.BatcherConfig,
 		Txmgr:            bs.TxManager,
 		L1Client:         bs.L1Client,
 		EndpointProvider: bs.EndpointProvider,
-		ChannelConfig:    bs.ChannelConfig,
+		ChannelConfig:    bs.ChannelConfigProvider,
 		PlasmaDA:         bs.PlasmaDA,
 	})
 }
 
+// healthChecks returns the health probes of the batcher, served by the RPC server.
+func (bs *BatcherService) healthChecks() *health.Checks {
+	checks := health.NewChecks(bs.Version)
+	checks.RegisterLiveness("driver", bs.driver.Liveness)
+	return checks
+}
+
 func (bs *BatcherService) initRPCServer(cfg *CLIConfig) error {
+	adminAuth, err := oprpc.NewAdminAuth(cfg.RPC.AdminAuth, []string{"admin"}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to configure admin RPC authentication: %w", err)
+	}
 	server := oprpc.NewServer(
 		cfg.RPC.ListenAddr,
 		cfg.RPC.ListenPort,
 		bs.Version,
 		oprpc.WithLogger(bs.Log),
+		oprpc.WithRPCMetrics(bs.Metrics),
+		oprpc.WithMiddleware(optracing.NewHTTPMiddleware(bs.Tracer, "batcher-rpc")),
+		oprpc.WithAdminAuth(adminAuth),
+		oprpc.WithHealthChecks(bs.healthChecks()),
 	)
+	server.AddAPI(rpc.GetBatcherAPI(rpc.NewBatcherAPI(bs.driver)))
 	if cfg.RPC.EnableAdmin {
-		adminAPI := rpc.NewAdminAPI(bs.driver, bs.Metrics, bs.Log)
+		adminAPI := rpc.NewAdminAPI(bs.driver, bs.ChannelConfigProvider, bs.Log)
 		server.AddAPI(rpc.GetAdminAPI(adminAPI))
-		bs.Log.Info("Admin RPC enabled")
+		if adminAuth == nil {
+			bs.Log.Warn("Admin RPC enabled without authentication")
+		} else {
+			bs.Log.Info("Admin RPC enabled")
+		}
 	}
 	bs.Log.Info("Starting JSON-RPC server")
 	if err := server.Start(); err != nil {
@@ -339,6 +487,7 @@ func (bs *BatcherService) initPlasmaDA(cfg *CLIConfig) error {
 	}
 	bs.PlasmaDA = config.NewDAClient()
 	bs.UsePlasma = config.Enabled
+	bs.PlasmaFallbackToL1 = cfg.PlasmaFallbackToL1
 	return nil
 }
 
@@ -348,7 +497,14 @@ func (bs *BatcherService) Start(_ context.Context) error {
 	bs.driver.Log.Info("Starting batcher", "notSubmittingOnStart", bs.NotSubmittingOnStart)
 
 	if !bs.NotSubmittingOnStart {
-		return bs.driver.StartBatchSubmitting()
+		if err := bs.driver.StartBatchSubmitting(); err != nil {
+			return err
+		}
+		for _, cs := range bs.chains {
+			if err := cs.driver.StartBatchSubmitting(); err != nil {
+				return fmt.Errorf("failed to start chain: %w", err)
+			}
+		}
 	}
 	return nil
 }
@@ -379,6 +535,11 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 	if bs.TxManager != nil {
 		bs.TxManager.Close()
 	}
+	for _, cs := range bs.chains {
+		if cs.TxManager != nil {
+			cs.TxManager.Close()
+		}
+	}
 
 	var result error
 	if bs.driver != nil {
@@ -386,6 +547,21 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to stop batch submitting: %w", err))
 		}
 	}
+	for _, cs := range bs.chains {
+		if cs.driver != nil {
+			if err := cs.driver.StopBatchSubmittingIfRunning(ctx); err != nil {
+				result = errors.Join(result, fmt.Errorf("failed to stop batch submitting of chain: %w", err))
+			}
+		}
+		if cs.EndpointProvider != nil {
+			cs.EndpointProvider.Close()
+		}
+		if cs.balanceMetricer != nil {
+			if err := cs.balanceMetricer.Close(); err != nil {
+				result = errors.Join(result, fmt.Errorf("failed to close balance metricer of chain: %w", err))
+			}
+		}
+	}
 
 	if bs.rpcServer != nil {
 		// TODO(7685): the op-service RPC server is not built on top of op-service httputil Server, and has poor shutdown
@@ -410,6 +586,12 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 		}
 	}
 
+	if bs.closeTracer != nil {
+		if err := bs.closeTracer(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close tracer: %w", err))
+		}
+	}
+
 	if bs.L1Client != nil {
 		bs.L1Client.Close()
 	}
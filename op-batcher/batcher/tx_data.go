@@ -15,6 +15,8 @@ import (
 // different channels.
 type txData struct {
 	frames []frameData
+	// asBlob is true if the frames should be submitted as blobs, instead of as calldata.
+	asBlob bool
 }
 
 func singleFrameTxData(frame frameData) txData {
@@ -41,7 +43,13 @@ func (td *txData) CallData() []byte {
 	return data
 }
 
+// Blobs returns the transaction data as blobs, one blob per frame.
+// Each blob is a version byte (0) followed by the frame data.
+// It returns an error if the frames don't fit into a single blob tx.
 func (td *txData) Blobs() ([]*eth.Blob, error) {
+	if nf := len(td.frames); nf > eth.MaxBlobsPerBlobTx {
+		return nil, fmt.Errorf("too many frames for blob tx: %d, max %d", nf, eth.MaxBlobsPerBlobTx)
+	}
 	blobs := make([]*eth.Blob, 0, len(td.frames))
 	for _, f := range td.frames {
 		var blob eth.Blob
@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 func TestTxID_String(t *testing.T) {
@@ -58,3 +61,23 @@ func TestTxID_String(t *testing.T) {
 		})
 	}
 }
+
+func TestTxData_Blobs(t *testing.T) {
+	chID := derive.ChannelID{0xca, 0xfe}
+	t.Run("multi-blob", func(t *testing.T) {
+		td := txData{frames: makeMockFrameDatas(chID, eth.MaxBlobsPerBlobTx), asBlob: true}
+		blobs, err := td.Blobs()
+		require.NoError(t, err)
+		require.Len(t, blobs, eth.MaxBlobsPerBlobTx)
+		for i, blob := range blobs {
+			data, err := blob.ToData()
+			require.NoError(t, err)
+			require.Equal(t, eth.Data{derive.DerivationVersion0, byte(i)}, data)
+		}
+	})
+	t.Run("too-many-blobs", func(t *testing.T) {
+		td := txData{frames: makeMockFrameDatas(chID, eth.MaxBlobsPerBlobTx+1), asBlob: true}
+		_, err := td.Blobs()
+		require.ErrorContains(t, err, "too many frames")
+	})
+}
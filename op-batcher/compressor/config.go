@@ -17,7 +17,7 @@ type Config struct {
 	// will default to RatioKind.
 	Kind string
 
-	// Type of compression algorithm to use. Must be one of [zlib, brotli-(9|10|11)]
+	// Type of compression algorithm to use. Must be one of [zlib, zlib-(1|6|9), brotli, brotli-(9|10|11)]
 	CompressionAlgo derive.CompressionAlgo
 }
 
@@ -0,0 +1,52 @@
+package compressor
+
+import (
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// CompressorFactory creates compressors for new channels. It makes sure that
+// only compression algorithms are used that the derivation pipeline can decode
+// at the time the channel is submitted. Algorithms that are only decodable
+// after a future hardfork activation are replaced by the FallbackAlgo until
+// then.
+type CompressorFactory struct {
+	rollupCfg *rollup.Config
+
+	// FallbackAlgo is used instead of an algorithm that isn't decodable yet.
+	FallbackAlgo derive.CompressionAlgo
+}
+
+func NewCompressorFactory(rollupCfg *rollup.Config) *CompressorFactory {
+	return &CompressorFactory{
+		rollupCfg:    rollupCfg,
+		FallbackAlgo: derive.Zlib,
+	}
+}
+
+// Decodable returns whether channels compressed with algo can be decoded by the
+// derivation pipeline when they are submitted to L1 at the given L1 time.
+func (f *CompressorFactory) Decodable(algo derive.CompressionAlgo, l1Time uint64) bool {
+	if algo.IsBrotli() {
+		// brotli channels are only accepted if Fjord is active at the L1 origin
+		// of the channel reader, which is at least the L1 time of submission.
+		return f.rollupCfg.IsFjord(l1Time)
+	}
+	return derive.ValidCompressionAlgo(algo)
+}
+
+// Config returns the compressor config to use for a new channel that is
+// submitted at the given L1 time. If the configured compression algorithm isn't
+// decodable yet at l1Time, it is replaced by the FallbackAlgo.
+func (f *CompressorFactory) Config(cfg Config, l1Time uint64) Config {
+	if !f.Decodable(cfg.CompressionAlgo, l1Time) {
+		cfg.CompressionAlgo = f.FallbackAlgo
+	}
+	return cfg
+}
+
+// NewCompressor creates a new compressor for a channel that is submitted at the
+// given L1 time.
+func (f *CompressorFactory) NewCompressor(cfg Config, l1Time uint64) (derive.Compressor, error) {
+	return f.Config(cfg, l1Time).NewCompressor()
+}
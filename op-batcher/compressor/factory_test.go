@@ -0,0 +1,54 @@
+package compressor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+func TestCompressorFactory_Config(t *testing.T) {
+	fjordTime := uint64(1000)
+	f := NewCompressorFactory(&rollup.Config{FjordTime: &fjordTime})
+
+	tests := []struct {
+		name     string
+		algo     derive.CompressionAlgo
+		l1Time   uint64
+		expected derive.CompressionAlgo
+	}{
+		{name: "zlib-pre-fjord", algo: derive.Zlib, l1Time: 999, expected: derive.Zlib},
+		{name: "zlib1-pre-fjord", algo: derive.Zlib1, l1Time: 999, expected: derive.Zlib1},
+		{name: "brotli-pre-fjord", algo: derive.Brotli10, l1Time: 999, expected: derive.Zlib},
+		{name: "brotli-at-fjord", algo: derive.Brotli10, l1Time: 1000, expected: derive.Brotli10},
+		{name: "brotli-post-fjord", algo: derive.Brotli11, l1Time: 2000, expected: derive.Brotli11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{
+				TargetOutputSize: 100_000,
+				Kind:             ShadowKind,
+				CompressionAlgo:  tt.algo,
+			}
+			got := f.Config(cfg, tt.l1Time)
+			require.Equal(t, tt.expected, got.CompressionAlgo)
+			require.Equal(t, tt.expected == tt.algo, f.Decodable(tt.algo, tt.l1Time))
+			// other settings must remain untouched
+			cfg.CompressionAlgo = tt.expected
+			require.Equal(t, cfg, got)
+
+			c, err := f.NewCompressor(cfg, tt.l1Time)
+			require.NoError(t, err)
+			require.NotNil(t, c)
+		})
+	}
+}
+
+func TestCompressorFactory_NoFjord(t *testing.T) {
+	f := NewCompressorFactory(&rollup.Config{})
+	require.False(t, f.Decodable(derive.Brotli, 1<<40))
+	require.True(t, f.Decodable(derive.Zlib6, 0))
+	require.False(t, f.Decodable(derive.CompressionAlgo("zstd"), 0))
+}
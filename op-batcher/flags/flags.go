@@ -17,6 +17,7 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -59,8 +60,9 @@ var (
 		EnvVars: prefixEnvVars("POLL_INTERVAL"),
 	}
 	MaxPendingTransactionsFlag = &cli.Uint64Flag{
-		Name:    "max-pending-tx",
-		Usage:   "The maximum number of pending transactions. 0 for no limit.",
+		Name: "max-pending-tx",
+		Usage: "The maximum number of pending transactions, which are signed with consecutive nonces and " +
+			"submitted concurrently. 0 for no limit.",
 		Value:   1,
 		EnvVars: prefixEnvVars("MAX_PENDING_TX"),
 	}
@@ -70,6 +72,12 @@ var (
 		Value:   0,
 		EnvVars: prefixEnvVars("MAX_CHANNEL_DURATION"),
 	}
+	MaxBlocksPerSpanBatchFlag = &cli.IntFlag{
+		Name:    "max-blocks-per-span-batch",
+		Usage:   "The maximum number of L2 blocks to add to a span batch. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_BLOCKS_PER_SPAN_BATCH"),
+	}
 	MaxL1TxSizeBytesFlag = &cli.Uint64Flag{
 		Name:    "max-l1-tx-size-bytes",
 		Usage:   "The maximum size of a batch tx submitted to L1. Ignored for blobs, where max blob size will be used.",
@@ -78,7 +86,7 @@ var (
 	}
 	TargetNumFramesFlag = &cli.IntFlag{
 		Name:    "target-num-frames",
-		Usage:   "The target number of frames to create per channel. Controls number of blobs per blob tx, if using Blob DA.",
+		Usage:   "The target number of frames to create per channel. Controls number of blobs per blob tx, if using Blob DA (max 6).",
 		Value:   1,
 		EnvVars: prefixEnvVars("TARGET_NUM_FRAMES"),
 	}
@@ -123,7 +131,8 @@ var (
 	}
 	DataAvailabilityTypeFlag = &cli.GenericFlag{
 		Name: "data-availability-type",
-		Usage: "The data availability type to use for submitting batches to the L1. Valid options: " +
+		Usage: "The data availability type to use for submitting batches to the L1. The auto type " +
+			"picks the cheaper of blobs and calldata for each new channel. Valid options: " +
 			openum.EnumString(DataAvailabilityTypes),
 		Value: func() *DataAvailabilityType {
 			out := CalldataType
@@ -151,6 +160,88 @@ var (
 		Value:   false,
 		EnvVars: prefixEnvVars("WAIT_NODE_SYNC"),
 	}
+	L2HeadSubscriptionFlag = &cli.BoolFlag{
+		Name: "l2-head-subscription",
+		Usage: "Subscribe to sync status updates of the rollup node to load new blocks as soon as the sequencer " +
+			"produced them, polling at the poll interval only as a fallback. Requires websocket rollup RPC URLs.",
+		EnvVars: prefixEnvVars("L2_HEAD_SUBSCRIPTION"),
+	}
+	DryRunFlag = &cli.BoolFlag{
+		Name: "dry-run",
+		Usage: "Build and compress channels as usual, but don't submit them to L1. Instead, report what submitting " +
+			"them would have cost with calldata and with blobs. Useful for capacity planning and validating config changes.",
+		EnvVars: prefixEnvVars("DRY_RUN"),
+	}
+	ChainsConfigFlag = &cli.StringFlag{
+		Name: "chains-config",
+		Usage: "Path to a JSON file listing additional chains to submit batches for. Each chain has its own L2 " +
+			"endpoints, batcher signer, metrics namespace and optionally Plasma DA config, and shares the L1 connection " +
+			"and all other settings with the main chain. " +
+			"Signer keys are not part of the file, but read from the environment variables it names, or held by a remote signer or KMS.",
+		EnvVars: prefixEnvVars("CHAINS_CONFIG"),
+	}
+	PlasmaFallbackToL1Flag = &cli.BoolFlag{
+		Name:    "altda.fallback-to-l1",
+		Aliases: []string{"plasma.fallback-to-l1"},
+		Usage: "Post batch data directly to L1 calldata if it cannot be posted to the Alt-DA server, " +
+			"instead of retrying until the Alt-DA server is available again.",
+		EnvVars: prefixEnvVars("ALTDA_FALLBACK_TO_L1"),
+	}
+	ThrottleThresholdFlag = &cli.Uint64Flag{
+		Name: "throttle-threshold",
+		Usage: "The number of pending bytes of L2 block data, not yet submitted to L1, above which the batcher " +
+			"instructs the sequencer's execution engine to throttle the DA size of new blocks. 0 disables throttling.",
+		Value:   1_000_000,
+		EnvVars: prefixEnvVars("THROTTLE_THRESHOLD"),
+	}
+	ThrottleTxSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-tx-size",
+		Usage:   "The maximum DA size of a single transaction included in a new block while throttling.",
+		Value:   300,
+		EnvVars: prefixEnvVars("THROTTLE_TX_SIZE"),
+	}
+	ThrottleBlockSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-block-size",
+		Usage:   "The maximum total DA size of transactions included in a new block while throttling.",
+		Value:   21_000,
+		EnvVars: prefixEnvVars("THROTTLE_BLOCK_SIZE"),
+	}
+	ThrottleAlwaysBlockSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-always-block-size",
+		Usage:   "The maximum total DA size of transactions included in a new block while not throttling. 0 for no limit.",
+		Value:   130_000,
+		EnvVars: prefixEnvVars("THROTTLE_ALWAYS_BLOCK_SIZE"),
+	}
+	FeeSchedulerBaseFeePercentileFlag = &cli.Float64Flag{
+		Name:    "fee-scheduler-base-fee-percentile",
+		Usage:   "Percentile (0-100) of the L1 base fees of recent L1 blocks, above which non-urgent batch submissions are delayed. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("FEE_SCHEDULER_BASE_FEE_PERCENTILE"),
+	}
+	FeeSchedulerBlobFeePercentileFlag = &cli.Float64Flag{
+		Name:    "fee-scheduler-blob-fee-percentile",
+		Usage:   "Percentile (0-100) of the L1 blob base fees of recent L1 blocks, above which non-urgent blob submissions are delayed. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("FEE_SCHEDULER_BLOB_FEE_PERCENTILE"),
+	}
+	FeeSchedulerWindowFlag = &cli.Uint64Flag{
+		Name:    "fee-scheduler-window",
+		Usage:   "Number of recent L1 blocks whose fees the fee scheduler percentiles are calculated over.",
+		Value:   300,
+		EnvVars: prefixEnvVars("FEE_SCHEDULER_WINDOW"),
+	}
+	BlobFailureThresholdFlag = &cli.Uint64Flag{
+		Name:    "blob-failure-threshold",
+		Usage:   "Number of consecutive failed blob transactions, after which pending channel data is re-encoded and submitted as calldata. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("BLOB_FAILURE_THRESHOLD"),
+	}
+	BlobEmergencyDurationFlag = &cli.DurationFlag{
+		Name:    "blob-emergency-duration",
+		Usage:   "Duration to submit calldata after blob transactions failed persistently, before trying blobs again.",
+		Value:   10 * time.Minute,
+		EnvVars: prefixEnvVars("BLOB_EMERGENCY_DURATION"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -168,6 +259,7 @@ var optionalFlags = []cli.Flag{
 	PollIntervalFlag,
 	MaxPendingTransactionsFlag,
 	MaxChannelDurationFlag,
+	MaxBlocksPerSpanBatchFlag,
 	MaxL1TxSizeBytesFlag,
 	TargetNumFramesFlag,
 	ApproxComprRatioFlag,
@@ -178,6 +270,19 @@ var optionalFlags = []cli.Flag{
 	DataAvailabilityTypeFlag,
 	ActiveSequencerCheckDurationFlag,
 	CompressionAlgoFlag,
+	L2HeadSubscriptionFlag,
+	DryRunFlag,
+	ChainsConfigFlag,
+	PlasmaFallbackToL1Flag,
+	ThrottleThresholdFlag,
+	ThrottleTxSizeFlag,
+	ThrottleBlockSizeFlag,
+	ThrottleAlwaysBlockSizeFlag,
+	FeeSchedulerBaseFeePercentileFlag,
+	FeeSchedulerBlobFeePercentileFlag,
+	FeeSchedulerWindowFlag,
+	BlobFailureThresholdFlag,
+	BlobEmergencyDurationFlag,
 }
 
 func init() {
@@ -185,6 +290,7 @@ func init() {
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, optracing.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, plasma.CLIFlags(EnvVarPrefix, "")...)
 
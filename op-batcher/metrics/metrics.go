@@ -2,6 +2,9 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -41,12 +44,25 @@ type Metricer interface {
 	RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason error)
 	RecordChannelFullySubmitted(id derive.ChannelID)
 	RecordChannelTimedOut(id derive.ChannelID)
+	RecordChannelIncluded(numBlocks int, numTxs int, inclusionSpan uint64)
+	RecordChannelCompression(algo derive.CompressionAlgo, inputBytes int, outputComprBytes int, duration time.Duration)
+	RecordSeqWindowMargin(margin int64)
+
+	RecordDryRunTx(numFrames int, numBytes int, calldataFee *big.Int, blobFee *big.Int)
+	RecordFeeSchedulerDelay(delaying bool)
+	RecordBlobEmergency(active bool)
 
 	RecordBatchTxSubmitted()
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
 
 	RecordBlobUsedBytes(num int)
+	RecordBlobsPerTx(num int)
+
+	RecordDAType(daType string)
+	RecordDACostRatio(ratio float64)
+
+	RecordThrottling(pendingBytes int64, throttling bool)
 
 	Document() []opmetrics.DocumentedMetric
 }
@@ -80,9 +96,35 @@ type Metrics struct {
 	channelInputBytesTotal  prometheus.Counter
 	channelOutputBytesTotal prometheus.Counter
 
+	channelNumBlocks     prometheus.Gauge
+	channelNumTxs        prometheus.Gauge
+	channelInclusionSpan prometheus.Histogram
+
+	// label by compression algorithm
+	channelAlgoComprRatio prometheus.HistogramVec
+	channelComprDuration  prometheus.HistogramVec
+
 	batcherTxEvs opmetrics.EventVec
 
 	blobUsedBytes prometheus.Histogram
+	blobsPerTx    prometheus.Histogram
+
+	// label by data availability type chosen for a new channel
+	daTypeEvs   opmetrics.EventVec
+	daCostRatio prometheus.Gauge
+
+	pendingDABytes prometheus.Gauge
+	throttling     prometheus.Gauge
+
+	seqWindowMargin prometheus.Gauge
+
+	dryRunFramesTotal prometheus.Counter
+	dryRunBytesTotal  prometheus.Counter
+	// label by data availability type
+	dryRunFeeTotal prometheus.CounterVec
+
+	feeSchedulerDelaying prometheus.Gauge
+	blobEmergency        prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -94,9 +136,23 @@ func NewMetrics(procName string) *Metrics {
 	if procName == "" {
 		procName = "default"
 	}
-	ns := Namespace + "_" + procName
+	return newMetrics(Namespace+"_"+procName, opmetrics.NewRegistry())
+}
 
-	registry := opmetrics.NewRegistry()
+// NewChainMetrics creates the metrics of an additional chain of a multi-chain
+// batcher. They are registered in the registry of m, so that they are served
+// by the same metrics server, in a namespace of the chain name.
+func (m *Metrics) NewChainMetrics(chainName string) *Metrics {
+	return newMetrics(ChainNamespace(chainName), m.registry)
+}
+
+// ChainNamespace returns the metrics namespace of an additional chain of a
+// multi-chain batcher, with the name's dashes replaced by underscores.
+func ChainNamespace(chainName string) string {
+	return Namespace + "_chain_" + strings.ReplaceAll(chainName, "-", "_")
+}
+
+func newMetrics(ns string, registry *prometheus.Registry) *Metrics {
 	factory := opmetrics.With(registry)
 
 	return &Metrics{
@@ -185,14 +241,95 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "output_bytes_total",
 			Help:      "Total number of compressed output bytes from a channel.",
 		}),
+		channelNumBlocks: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "channel_num_blocks",
+			Help:      "Number of L2 blocks of the last fully submitted channel.",
+		}),
+		channelNumTxs: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "channel_num_txs",
+			Help:      "Number of batcher txs of the last fully submitted channel.",
+		}),
+		channelInclusionSpan: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "channel_inclusion_span_blocks",
+			Help:      "Number of L1 blocks between the inclusion of the first and last tx of fully submitted channels.",
+			Buckets:   []float64{0, 1, 2, 4, 8, 16, 32, 64, 128, 256},
+		}),
+		channelAlgoComprRatio: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "channel_algo_compr_ratio",
+			Help:      "Compression ratios of closed channels, by compression algorithm.",
+			Buckets:   append([]float64{0.1, 0.2}, prometheus.LinearBuckets(0.3, 0.05, 14)...),
+		}, []string{"algo"}),
+		channelComprDuration: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "channel_compr_duration_seconds",
+			Help:      "Time spent compressing the data of closed channels, by compression algorithm.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{"algo"}),
 		blobUsedBytes: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: ns,
 			Name:      "blob_used_bytes",
 			Help:      "Blob size in bytes (of last blob only for multi-blob txs).",
 			Buckets:   prometheus.LinearBuckets(0.0, eth.MaxBlobDataSize/13, 14),
 		}),
+		blobsPerTx: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "blobs_per_tx",
+			Help:      "Number of blobs per blob tx.",
+			Buckets:   prometheus.LinearBuckets(1, 1, eth.MaxBlobsPerBlobTx),
+		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		daTypeEvs: opmetrics.NewEventVec(factory, ns, "", "da_type", "DA type selection", []string{"type"}),
+		daCostRatio: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "da_cost_ratio",
+			Help:      "Ratio of blob to calldata cost per byte of channel data, as last estimated by the automatic DA type selection.",
+		}),
+		pendingDABytes: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "pending_da_bytes",
+			Help:      "Estimated size of L2 block data not yet added to a channel, as last checked for DA throttling.",
+		}),
+		throttling: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "throttling",
+			Help:      "1 if the batcher currently throttles the DA size of new L2 blocks, 0 otherwise.",
+		}),
+		seqWindowMargin: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "seq_window_margin_blocks",
+			Help:      "Number of L1 blocks that were left until the end of the sequencing window when the last batcher tx got included. Negative if the batches got included too late.",
+		}),
+		dryRunFramesTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "dry_run_frames_total",
+			Help:      "Total number of frames that would have been submitted in dry-run mode.",
+		}),
+		dryRunBytesTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "dry_run_bytes_total",
+			Help:      "Total number of frame bytes that would have been submitted in dry-run mode.",
+		}),
+		dryRunFeeTotal: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "dry_run_fee_wei_total",
+			Help:      "Total L1 fees in wei that submitting would have cost in dry-run mode, by data availability type.",
+		}, []string{"da_type"}),
+		feeSchedulerDelaying: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "fee_scheduler_delaying",
+			Help:      "1 if the fee scheduler currently delays batch submission due to high L1 fees, 0 otherwise.",
+		}),
+		blobEmergency: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "blob_emergency",
+			Help:      "1 if channel data is submitted as calldata because blob transactions failed persistently, 0 otherwise.",
+		}),
 	}
 }
 
@@ -277,14 +414,31 @@ func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int,
 	m.channelClosedReason.Set(float64(ClosedReasonToNum(reason)))
 }
 
+// RecordChannelIncluded records the number of blocks and txs of a fully
+// submitted channel and the number of L1 blocks its inclusion spanned.
+func (m *Metrics) RecordChannelIncluded(numBlocks int, numTxs int, inclusionSpan uint64) {
+	m.channelNumBlocks.Set(float64(numBlocks))
+	m.channelNumTxs.Set(float64(numTxs))
+	m.channelInclusionSpan.Observe(float64(inclusionSpan))
+}
+
+// RecordChannelCompression records the compression ratio and the total time
+// spent compressing a closed channel, labeled by the used compression algorithm.
+func (m *Metrics) RecordChannelCompression(algo derive.CompressionAlgo, inputBytes int, outputComprBytes int, duration time.Duration) {
+	if inputBytes > 0 {
+		m.channelAlgoComprRatio.WithLabelValues(algo.String()).Observe(float64(outputComprBytes) / float64(inputBytes))
+	}
+	m.channelComprDuration.WithLabelValues(algo.String()).Observe(duration.Seconds())
+}
+
 func (m *Metrics) RecordL2BlockInPendingQueue(block *types.Block) {
-	size := float64(estimateBatchSize(block))
+	size := float64(EstimateBatchSize(block))
 	m.pendingBlocksBytesTotal.Add(size)
 	m.pendingBlocksBytesCurrent.Add(size)
 }
 
 func (m *Metrics) RecordL2BlockInChannel(block *types.Block) {
-	size := float64(estimateBatchSize(block))
+	size := float64(EstimateBatchSize(block))
 	m.pendingBlocksBytesCurrent.Add(-1 * size)
 	// Refer to RecordL2BlocksAdded to see the current + count of bytes added to a channel
 }
@@ -318,8 +472,61 @@ func (m *Metrics) RecordBlobUsedBytes(num int) {
 	m.blobUsedBytes.Observe(float64(num))
 }
 
-// estimateBatchSize estimates the size of the batch
-func estimateBatchSize(block *types.Block) uint64 {
+func (m *Metrics) RecordBlobsPerTx(num int) {
+	m.blobsPerTx.Observe(float64(num))
+}
+
+// RecordDAType records the data availability type that got selected for a new channel.
+func (m *Metrics) RecordDAType(daType string) {
+	m.daTypeEvs.Record(daType)
+}
+
+func (m *Metrics) RecordDACostRatio(ratio float64) {
+	m.daCostRatio.Set(ratio)
+}
+
+func (m *Metrics) RecordThrottling(pendingBytes int64, throttling bool) {
+	m.pendingDABytes.Set(float64(pendingBytes))
+	if throttling {
+		m.throttling.Set(1)
+	} else {
+		m.throttling.Set(0)
+	}
+}
+
+func (m *Metrics) RecordSeqWindowMargin(margin int64) {
+	m.seqWindowMargin.Set(float64(margin))
+}
+
+func (m *Metrics) RecordDryRunTx(numFrames int, numBytes int, calldataFee *big.Int, blobFee *big.Int) {
+	m.dryRunFramesTotal.Add(float64(numFrames))
+	m.dryRunBytesTotal.Add(float64(numBytes))
+	cf, _ := new(big.Float).SetInt(calldataFee).Float64()
+	m.dryRunFeeTotal.WithLabelValues("calldata").Add(cf)
+	if blobFee != nil {
+		bf, _ := new(big.Float).SetInt(blobFee).Float64()
+		m.dryRunFeeTotal.WithLabelValues("blobs").Add(bf)
+	}
+}
+
+func (m *Metrics) RecordFeeSchedulerDelay(delaying bool) {
+	if delaying {
+		m.feeSchedulerDelaying.Set(1)
+	} else {
+		m.feeSchedulerDelaying.Set(0)
+	}
+}
+
+func (m *Metrics) RecordBlobEmergency(active bool) {
+	if active {
+		m.blobEmergency.Set(1)
+	} else {
+		m.blobEmergency.Set(0)
+	}
+}
+
+// EstimateBatchSize estimates the size of the batch of the given block.
+func EstimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
 	for _, tx := range block.Transactions() {
 		// Don't include deposit transactions in the batch.
@@ -2,6 +2,8 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -38,11 +40,22 @@ func (*noopMetrics) RecordChannelClosed(derive.ChannelID, int, int, int, int, er
 
 func (*noopMetrics) RecordChannelFullySubmitted(derive.ChannelID) {}
 func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)       {}
-
-func (*noopMetrics) RecordBatchTxSubmitted() {}
-func (*noopMetrics) RecordBatchTxSuccess()   {}
-func (*noopMetrics) RecordBatchTxFailed()    {}
-func (*noopMetrics) RecordBlobUsedBytes(int) {}
+func (*noopMetrics) RecordChannelIncluded(int, int, uint64)       {}
+
+func (*noopMetrics) RecordChannelCompression(derive.CompressionAlgo, int, int, time.Duration) {}
+func (*noopMetrics) RecordDryRunTx(int, int, *big.Int, *big.Int)                              {}
+func (*noopMetrics) RecordSeqWindowMargin(int64)                                              {}
+func (*noopMetrics) RecordFeeSchedulerDelay(bool)                                             {}
+func (*noopMetrics) RecordBlobEmergency(bool)                                                 {}
+
+func (*noopMetrics) RecordBatchTxSubmitted()      {}
+func (*noopMetrics) RecordBatchTxSuccess()        {}
+func (*noopMetrics) RecordBatchTxFailed()         {}
+func (*noopMetrics) RecordBlobUsedBytes(int)      {}
+func (*noopMetrics) RecordBlobsPerTx(int)         {}
+func (*noopMetrics) RecordDAType(string)          {}
+func (*noopMetrics) RecordDACostRatio(float64)    {}
+func (*noopMetrics) RecordThrottling(int64, bool) {}
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
 }
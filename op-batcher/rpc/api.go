@@ -3,27 +3,39 @@ package rpc
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
-	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
 
 type BatcherDriver interface {
 	StartBatchSubmitting() error
 	StopBatchSubmitting(ctx context.Context) error
+	PauseSubmission()
+	ResumeSubmission()
+}
+
+// ChannelConfigSetter changes the channel parameters of new channels at runtime.
+type ChannelConfigSetter interface {
+	SetMaxChannelDuration(duration uint64)
+	SetMaxFrameSize(size uint64) error
+	SetDAType(daType flags.DataAvailabilityType) error
 }
 
 type adminAPI struct {
 	*rpc.CommonAdminAPI
-	b BatcherDriver
+	b  BatcherDriver
+	cc ChannelConfigSetter
 }
 
-func NewAdminAPI(dr BatcherDriver, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+func NewAdminAPI(dr BatcherDriver, cc ChannelConfigSetter, log log.Logger) *adminAPI {
 	return &adminAPI{
-		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
+		CommonAdminAPI: rpc.NewCommonAdminAPI(log),
 		b:              dr,
+		cc:             cc,
 	}
 }
 
@@ -41,3 +53,34 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.StopBatchSubmitting(ctx)
 }
+
+// PauseBatcher pauses the submission of batch transactions, without closing
+// open channels. New L2 blocks are still loaded.
+func (a *adminAPI) PauseBatcher(_ context.Context) error {
+	a.b.PauseSubmission()
+	return nil
+}
+
+// ResumeBatcher resumes the submission of batch transactions after PauseBatcher.
+func (a *adminAPI) ResumeBatcher(_ context.Context) error {
+	a.b.ResumeSubmission()
+	return nil
+}
+
+// SetMaxChannelDuration sets the max channel duration (in #L1-blocks) of new
+// channels. 0 disables duration checks.
+func (a *adminAPI) SetMaxChannelDuration(_ context.Context, duration hexutil.Uint64) error {
+	a.cc.SetMaxChannelDuration(uint64(duration))
+	return nil
+}
+
+// SetMaxFrameSize sets the max frame size of new channels. It cannot exceed
+// the frame size supported by the data availability type.
+func (a *adminAPI) SetMaxFrameSize(_ context.Context, size hexutil.Uint64) error {
+	return a.cc.SetMaxFrameSize(uint64(size))
+}
+
+// SetDAType sets the data availability type (blobs, calldata or auto) of new channels.
+func (a *adminAPI) SetDAType(_ context.Context, daType flags.DataAvailabilityType) error {
+	return a.cc.SetDAType(daType)
+}
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ChannelStatus is the submission status of a channel.
+type ChannelStatus string
+
+const (
+	// ChannelOpen channels still accept new L2 blocks.
+	ChannelOpen ChannelStatus = "open"
+	// ChannelSubmitting channels are full and have frames that aren't included on L1 yet.
+	ChannelSubmitting ChannelStatus = "submitting"
+	// ChannelFullySubmitted channels have all their frames included on L1.
+	ChannelFullySubmitted ChannelStatus = "fully_submitted"
+	// ChannelTimedOut channels weren't fully included on L1 within the channel
+	// timeout. Their blocks got resubmitted in a new channel.
+	ChannelTimedOut ChannelStatus = "timed_out"
+)
+
+// ChannelTx is a batcher transaction of a channel that got included on L1.
+type ChannelTx struct {
+	// TxHash is the hash of the batcher tx, empty in dry-run mode.
+	TxHash         common.Hash    `json:"txHash"`
+	InclusionBlock eth.BlockID    `json:"inclusionBlock"`
+	Confirmations  hexutil.Uint64 `json:"confirmations"`
+}
+
+// ChannelInfo describes a channel of the batcher.
+type ChannelInfo struct {
+	ID     derive.ChannelID `json:"id"`
+	Status ChannelStatus    `json:"status"`
+	// FirstBlock and LastBlock are the oldest and latest L2 block of the
+	// channel, they are empty if NumBlocks is 0.
+	FirstBlock  eth.BlockID `json:"firstBlock"`
+	LastBlock   eth.BlockID `json:"lastBlock"`
+	NumBlocks   int         `json:"numBlocks"`
+	NumFrames   int         `json:"numFrames"`
+	InputBytes  int         `json:"inputBytes"`
+	OutputBytes int         `json:"outputBytes"`
+	// ComprRatio is the ratio of output to input bytes.
+	ComprRatio float64     `json:"comprRatio"`
+	Txs        []ChannelTx `json:"txs"`
+}
+
+// ContainsBlock returns whether the channel contains the L2 block with number num.
+func (c *ChannelInfo) ContainsBlock(num uint64) bool {
+	return c.NumBlocks > 0 && c.FirstBlock.Number <= num && num <= c.LastBlock.Number
+}
+
+// ChannelInfoProvider provides details about the current and recent channels
+// of the batcher.
+type ChannelInfoProvider interface {
+	// Channels returns the current and recent channels, oldest first.
+	Channels(ctx context.Context) ([]ChannelInfo, error)
+	// ChannelByL2Block returns the latest current or recent channel that
+	// contains the L2 block with number num, or nil if there's none.
+	ChannelByL2Block(ctx context.Context, num uint64) (*ChannelInfo, error)
+}
+
+type batcherAPI struct {
+	c ChannelInfoProvider
+}
+
+// NewBatcherAPI creates the read-only batcher API.
+func NewBatcherAPI(c ChannelInfoProvider) *batcherAPI {
+	return &batcherAPI{c: c}
+}
+
+func GetBatcherAPI(api *batcherAPI) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "batcher",
+		Service:   api,
+	}
+}
+
+// Channels returns the current and recent channels of the batcher, oldest first.
+func (a *batcherAPI) Channels(ctx context.Context) ([]ChannelInfo, error) {
+	return a.c.Channels(ctx)
+}
+
+// ChannelByL2Block returns the latest current or recent channel that contains
+// the L2 block with number num, including the L1 txs that it got included in.
+// It returns null if the batcher doesn't know of such a channel.
+func (a *batcherAPI) ChannelByL2Block(ctx context.Context, num hexutil.Uint64) (*ChannelInfo, error) {
+	return a.c.ChannelByL2Block(ctx, uint64(num))
+}
@@ -30,6 +30,10 @@ func (g *gossipNoop) OnUnsafeL2Payload(_ context.Context, _ peer.ID, _ *eth.Exec
 	return nil
 }
 
+func (g *gossipNoop) OnSafeHeadAttestation(_ context.Context, _ peer.ID, _ *p2p.SafeHeadAttestation) error {
+	return nil
+}
+
 type gossipConfig struct{}
 
 func (g *gossipConfig) P2PSequencerAddress() common.Address {
@@ -79,14 +83,14 @@ func Main(cliCtx *cli.Context) error {
 	if err := rpcCfg.Check(); err != nil {
 		return fmt.Errorf("failed to validate RPC config")
 	}
-	rpcServer := oprpc.NewServer(rpcCfg.ListenAddr, rpcCfg.ListenPort, "", oprpc.WithLogger(logger))
+	rpcServer := oprpc.NewServer(rpcCfg.ListenAddr, rpcCfg.ListenPort, "", oprpc.WithLogger(logger), oprpc.WithRPCMetrics(m))
 	if rpcCfg.EnableAdmin {
 		logger.Info("Admin RPC enabled but does nothing for the bootnode")
 	}
 	rpcServer.AddAPI(rpc.API{
 		Namespace:     p2p.NamespaceRPC,
 		Version:       "",
-		Service:       p2p.NewP2PAPIBackend(p2pNode, logger, m),
+		Service:       p2p.NewP2PAPIBackend(p2pNode, logger),
 		Authenticated: false,
 	})
 	if err := rpcServer.Start(); err != nil {
@@ -0,0 +1,97 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// webhookTimeout is the maximum time to wait for the webhook to accept an alert.
+const webhookTimeout = 10 * time.Second
+
+type Type string
+
+const (
+	// TypeInvalidProposal is raised when the root claim of a game does not match the output root of the local node.
+	TypeInvalidProposal Type = "invalid_proposal"
+	// TypeHonestOutcomeAtRisk is raised when a game would resolve incorrectly if no further moves were made.
+	TypeHonestOutcomeAtRisk Type = "honest_outcome_at_risk"
+	// TypeIncorrectResolution is raised when a game resolved differently to the outcome expected by the local node.
+	TypeIncorrectResolution Type = "incorrect_resolution"
+	// TypeInvalidOracleOutput is raised when an output of the L2OutputOracle does not match the output root of the
+	// local node.
+	TypeInvalidOracleOutput Type = "invalid_oracle_output"
+)
+
+type Metricer interface {
+	RecordAlert(alertType string)
+}
+
+// Alert is a suspicious event detected in a game or the L2OutputOracle. It is the JSON body posted to the webhook.
+// Game is the address of the L2OutputOracle for alerts about oracle outputs.
+type Alert struct {
+	Type    Type           `json:"type"`
+	Game    common.Address `json:"game"`
+	Message string         `json:"message"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Alerter logs and records metrics for alerts and, if a webhook URL is configured, posts them to the webhook.
+type Alerter struct {
+	logger     log.Logger
+	metrics    Metricer
+	webhookURL string
+	client     *http.Client
+}
+
+// NewAlerter creates an Alerter. Alerts are not posted to a webhook if webhookURL is empty.
+func NewAlerter(logger log.Logger, m Metricer, webhookURL string) *Alerter {
+	return &Alerter{
+		logger:     logger,
+		metrics:    m,
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Alert raises the alert. Failures to post to the webhook are logged rather than returned, as the alert has still
+// been raised through the logs and metrics.
+func (a *Alerter) Alert(ctx context.Context, alert Alert) {
+	a.logger.Error("Alert raised", "type", alert.Type, "game", alert.Game, "message", alert.Message, "details", alert.Details)
+	a.metrics.RecordAlert(string(alert.Type))
+	if a.webhookURL == "" {
+		return
+	}
+	if err := a.post(ctx, alert); err != nil {
+		a.logger.Error("Failed to post alert to webhook", "type", alert.Type, "game", alert.Game, "err", err)
+	}
+}
+
+func (a *Alerter) post(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %v", resp.Status)
+	}
+	return nil
+}
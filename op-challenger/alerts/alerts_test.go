@@ -0,0 +1,67 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlerter(t *testing.T) {
+	alert := Alert{
+		Type:    TypeInvalidProposal,
+		Game:    common.Address{0xaa},
+		Message: "Invalid root claim",
+		Details: map[string]any{"l2BlockNum": float64(42)},
+	}
+
+	t.Run("NoWebhook", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelError)
+		m := &stubMetrics{}
+		NewAlerter(logger, m, "").Alert(context.Background(), alert)
+		require.Equal(t, []string{string(TypeInvalidProposal)}, m.alerts)
+		require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Alert raised")))
+	})
+
+	t.Run("PostsToWebhook", func(t *testing.T) {
+		var received []Alert
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var body Alert
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			received = append(received, body)
+		}))
+		defer server.Close()
+		logger, logs := testlog.CaptureLogger(t, log.LevelError)
+		NewAlerter(logger, &stubMetrics{}, server.URL).Alert(context.Background(), alert)
+		require.Equal(t, []Alert{alert}, received)
+		require.Nil(t, logs.FindLog(testlog.NewMessageFilter("Failed to post alert to webhook")))
+	})
+
+	t.Run("WebhookFails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		logger, logs := testlog.CaptureLogger(t, log.LevelError)
+		m := &stubMetrics{}
+		NewAlerter(logger, m, server.URL).Alert(context.Background(), alert)
+		require.Len(t, m.alerts, 1, "alert still recorded")
+		require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Failed to post alert to webhook")))
+	})
+}
+
+type stubMetrics struct {
+	alerts []string
+}
+
+func (s *stubMetrics) RecordAlert(alertType string) {
+	s.alerts = append(s.alerts, alertType)
+}
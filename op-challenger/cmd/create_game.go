@@ -3,26 +3,35 @@ package main
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	challenger "github.com/ethereum-optimism/optimism/op-challenger"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/flags"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-challenger/tools"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 )
 
 var (
-	TraceTypeFlag = &cli.StringFlag{
-		Name:    "trace-type",
-		Usage:   "Trace types to support.",
-		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "TRACE_TYPE"),
-		Value:   types.TraceTypeCannon.String(),
+	GameTypeFlag = &cli.Uint64Flag{
+		Name:    "game-type",
+		Usage:   "The type of the dispute game to create.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "GAME_TYPE"),
+		Value:   uint64(types.CannonGameType),
 	}
 	OutputRootFlag = &cli.StringFlag{
 		Name:    "output-root",
@@ -31,16 +40,38 @@ var (
 	}
 	L2BlockNumFlag = &cli.StringFlag{
 		Name:    "l2-block-num",
+		Aliases: []string{"l2-block"},
 		Usage:   "The l2 block number for the game.",
 		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "L2_BLOCK_NUM"),
 	}
+	PlayFlag = &cli.BoolFlag{
+		Name: "play",
+		Usage: "Participate honestly in the created game until it is resolved. " +
+			"Requires the same flags as running the challenger.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "PLAY"),
+	}
 )
 
 func CreateGame(ctx *cli.Context) error {
 	outputRoot := common.HexToHash(ctx.String(OutputRootFlag.Name))
-	traceType := ctx.Uint64(TraceTypeFlag.Name)
+	gameType := ctx.Uint64(GameTypeFlag.Name)
 	l2BlockNum := ctx.Uint64(L2BlockNumFlag.Name)
 
+	// Check the game can be played before creating it
+	var playCfg *config.Config
+	var logger log.Logger
+	if ctx.Bool(PlayFlag.Name) {
+		var err error
+		logger, err = setupLogging(ctx)
+		if err != nil {
+			return err
+		}
+		playCfg, err = newPlayConfig(ctx, logger, types.GameType(gameType))
+		if err != nil {
+			return fmt.Errorf("invalid config to play the game: %w", err)
+		}
+	}
+
 	contract, txMgr, err := NewContractWithTxMgr[*contracts.DisputeGameFactoryContract](ctx, flags.FactoryAddress,
 		func(ctx context.Context, metricer contractMetrics.ContractMetricer, address common.Address, caller *batching.MultiCaller) (*contracts.DisputeGameFactoryContract, error) {
 			return contracts.NewDisputeGameFactoryContract(metricer, address, caller), nil
@@ -50,32 +81,136 @@ func CreateGame(ctx *cli.Context) error {
 	}
 
 	creator := tools.NewGameCreator(contract, txMgr)
-	gameAddr, err := creator.CreateGame(ctx.Context, outputRoot, traceType, l2BlockNum)
+	gameAddr, err := creator.CreateGame(ctx.Context, outputRoot, gameType, l2BlockNum)
 	if err != nil {
 		return fmt.Errorf("failed to create game: %w", err)
 	}
 	fmt.Printf("Fetched Game Address: %s\n", gameAddr.String())
+	if playCfg == nil {
+		return nil
+	}
+	return playGame(ctx, logger, playCfg, gameAddr)
+}
+
+// newPlayConfig returns the challenger config to play a game of the given type. The trace type is derived from the
+// game type, so only the flags required by that trace type have to be set.
+func newPlayConfig(ctx *cli.Context, logger log.Logger, gameType types.GameType) (*config.Config, error) {
+	traceType, err := traceTypeForGameType(gameType)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.IsSet(flags.TraceTypeFlag.Name) {
+		return nil, fmt.Errorf("flag %s is derived from %s and must not be set", flags.TraceTypeFlag.Name, GameTypeFlag.Name)
+	}
+	if err := ctx.Set(flags.TraceTypeFlag.Name, traceType.String()); err != nil {
+		return nil, err
+	}
+	cfg, err := flags.NewConfigFromCLI(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// playGame runs the challenger against only the game at gameAddr, until the game is resolved.
+func playGame(ctx *cli.Context, logger log.Logger, cfg *config.Config, gameAddr common.Address) error {
+	cfg.GameAllowlist = []common.Address{gameAddr}
+
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, cfg.L1EthRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer l1Client.Close()
+	caller := batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize)
+	game, err := contracts.NewFaultDisputeGameContract(ctx.Context, contractMetrics.NoopContractMetrics, gameAddr, caller)
+	if err != nil {
+		return fmt.Errorf("failed to create dispute game bindings: %w", err)
+	}
+
+	service, err := challenger.Main(ctx.Context, logger, cfg, metrics.NewMetrics())
+	if err != nil {
+		return fmt.Errorf("failed to create challenger: %w", err)
+	}
+	if err := service.Start(ctx.Context); err != nil {
+		return fmt.Errorf("failed to start challenger: %w", err)
+	}
+	defer func() {
+		if err := service.Stop(context.Background()); err != nil {
+			logger.Error("Failed to stop challenger", "err", err)
+		}
+	}()
+
+	logger.Info("Playing game", "game", gameAddr, "traceType", cfg.TraceTypes[0])
+	status, err := waitForResolution(ctx.Context, game, cfg.PollInterval)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Game %v resolved: %v\n", gameAddr, status)
 	return nil
 }
 
+type gameStatusReader interface {
+	GetStatus(ctx context.Context) (gameTypes.GameStatus, error)
+}
+
+// waitForResolution polls the status of the game until it is resolved or the context is done.
+func waitForResolution(ctx context.Context, game gameStatusReader, pollInterval time.Duration) (gameTypes.GameStatus, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := game.GetStatus(ctx)
+		if err != nil {
+			return status, fmt.Errorf("failed to get game status: %w", err)
+		}
+		if status != gameTypes.GameStatusInProgress {
+			return status, nil
+		}
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// traceTypeForGameType returns the trace type used to play games of the given game type.
+func traceTypeForGameType(gameType types.GameType) (types.TraceType, error) {
+	for _, traceType := range types.TraceTypes {
+		if traceType.GameType() == gameType {
+			return traceType, nil
+		}
+	}
+	return "", fmt.Errorf("no trace type for game type %d", uint32(gameType))
+}
+
 func createGameFlags() []cli.Flag {
 	cliFlags := []cli.Flag{
 		flags.L1EthRpcFlag,
 		flags.NetworkFlag,
 		flags.FactoryAddressFlag,
-		TraceTypeFlag,
+		GameTypeFlag,
 		OutputRootFlag,
 		L2BlockNumFlag,
+		PlayFlag,
 	}
 	cliFlags = append(cliFlags, txmgr.CLIFlagsWithDefaults(flags.EnvVarPrefix, txmgr.DefaultChallengerFlagValues)...)
 	cliFlags = append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
+	// Include the remaining challenger flags so the created game can be played
+	for _, flag := range flags.Flags {
+		if !slices.ContainsFunc(cliFlags, func(f cli.Flag) bool { return f.Names()[0] == flag.Names()[0] }) {
+			cliFlags = append(cliFlags, flag)
+		}
+	}
 	return cliFlags
 }
 
 var CreateGameCommand = &cli.Command{
 	Name:        "create-game",
 	Usage:       "Creates a dispute game via the factory",
-	Description: "Creates a dispute game via the factory",
+	Description: "Creates a dispute game via the factory and, if --play is set, participates honestly in it until it is resolved",
 	Action:      Interruptible(CreateGame),
 	Flags:       createGameFlags(),
 }
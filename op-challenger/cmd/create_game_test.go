@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestTraceTypeForGameType(t *testing.T) {
+	for _, traceType := range types.TraceTypes {
+		traceType := traceType
+		t.Run(traceType.String(), func(t *testing.T) {
+			actual, err := traceTypeForGameType(traceType.GameType())
+			require.NoError(t, err)
+			require.Equal(t, traceType, actual)
+		})
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := traceTypeForGameType(types.GameType(42))
+		require.ErrorContains(t, err, "no trace type for game type 42")
+	})
+}
+
+func TestCreateGameChecksPlayConfig(t *testing.T) {
+	run := func(args ...string) error {
+		app := cli.NewApp()
+		app.Commands = []*cli.Command{CreateGameCommand}
+		return app.Run(append([]string{"op-challenger", "create-game", "--play",
+			"--l1-eth-rpc", l1EthRpc, "--game-factory-address", gameFactoryAddressValue}, args...))
+	}
+
+	t.Run("UnknownGameType", func(t *testing.T) {
+		err := run("--game-type", "42")
+		require.ErrorContains(t, err, "invalid config to play the game: no trace type for game type 42")
+	})
+
+	t.Run("TraceTypeSet", func(t *testing.T) {
+		err := run("--game-type", "255", "--trace-type", types.TraceTypeCannon.String())
+		require.ErrorContains(t, err, "invalid config to play the game: flag trace-type is derived from game-type")
+	})
+
+	t.Run("MissingTraceTypeFlags", func(t *testing.T) {
+		err := run("--game-type", "0", "--l1-beacon", l1Beacon, "--l2-eth-rpc", l2EthRpc, "--rollup-rpc", rollupRpc, "--datadir", datadir)
+		require.ErrorContains(t, err, "invalid config to play the game")
+		require.ErrorContains(t, err, "cannon")
+	})
+}
+
+func TestWaitForResolution(t *testing.T) {
+	t.Run("Resolved", func(t *testing.T) {
+		game := &stubGameStatusReader{statuses: []gameTypes.GameStatus{gameTypes.GameStatusInProgress, gameTypes.GameStatusInProgress, gameTypes.GameStatusDefenderWon}}
+		status, err := waitForResolution(context.Background(), game, time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, gameTypes.GameStatusDefenderWon, status)
+		require.Equal(t, 3, game.calls)
+	})
+
+	t.Run("StatusError", func(t *testing.T) {
+		game := &stubGameStatusReader{err: errors.New("boom")}
+		_, err := waitForResolution(context.Background(), game, time.Millisecond)
+		require.ErrorIs(t, err, game.err)
+	})
+
+	t.Run("ContextDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		game := &stubGameStatusReader{statuses: []gameTypes.GameStatus{gameTypes.GameStatusInProgress}}
+		_, err := waitForResolution(ctx, game, time.Hour)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+type stubGameStatusReader struct {
+	calls    int
+	statuses []gameTypes.GameStatus
+	err      error
+}
+
+func (s *stubGameStatusReader) GetStatus(_ context.Context) (gameTypes.GameStatus, error) {
+	s.calls++
+	if s.err != nil {
+		return 0, s.err
+	}
+	return s.statuses[min(s.calls, len(s.statuses))-1], nil
+}
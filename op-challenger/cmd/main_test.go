@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -224,6 +225,147 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestMaxConcurrentTraceGen(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Zero(t, cfg.MaxConcurrentTraceGen)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--max-concurrent-trace-generation", "2"))
+		require.Equal(t, uint(2), cfg.MaxConcurrentTraceGen)
+	})
+}
+
+func TestLargePreimageMaxBaseFee(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Nil(t, cfg.LargePreimageMaxBaseFee)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--large-preimage-max-base-fee", "1.5"))
+		require.Equal(t, big.NewInt(1_500_000_000), cfg.LargePreimageMaxBaseFee)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid large-preimage-max-base-fee", addRequiredArgs(types.TraceTypeAlphabet, "--large-preimage-max-base-fee", "-1"))
+	})
+}
+
+func TestBatchResolution(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.False(t, cfg.BatchResolution)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--batch-resolution"))
+		require.True(t, cfg.BatchResolution)
+	})
+}
+
+func TestOnlyContradictingGames(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.False(t, cfg.OnlyContradictingGames)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--only-contradicting-games"))
+		require.True(t, cfg.OnlyContradictingGames)
+	})
+}
+
+func TestMaxBondsAtRisk(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Nil(t, cfg.MaxBondsAtRisk)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--max-bonds-at-risk", "2.5"))
+		expected, ok := new(big.Int).SetString("2500000000000000000", 10)
+		require.True(t, ok)
+		require.Equal(t, expected, cfg.MaxBondsAtRisk)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid max-bonds-at-risk", addRequiredArgs(types.TraceTypeAlphabet, "--max-bonds-at-risk", "-1"))
+	})
+}
+
+func TestMonitorOnly(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.False(t, cfg.MonitorOnly)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--monitor-only"))
+		require.True(t, cfg.MonitorOnly)
+	})
+
+	t.Run("VMFlagsNotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(types.TraceTypeCannon, "--cannon-bin", "--monitor-only"))
+		require.True(t, cfg.MonitorOnly)
+		require.Equal(t, []types.TraceType{types.TraceTypeCannon}, cfg.TraceTypes)
+	})
+}
+
+func TestRPCEnabled(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.False(t, cfg.RPCEnabled)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--rpc.enabled"))
+		require.True(t, cfg.RPCEnabled)
+	})
+}
+
+func TestAlertWebhookURL(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Empty(t, cfg.AlertWebhookURL)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--alert-webhook-url", "https://example.com/alerts"))
+		require.Equal(t, "https://example.com/alerts", cfg.AlertWebhookURL)
+	})
+}
+
+func TestOutputScanner(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.False(t, cfg.OutputScanner)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--output-scanner"))
+		require.True(t, cfg.OutputScanner)
+	})
+}
+
+func TestL2OutputOracleAddress(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Equal(t, common.Address{}, cfg.L2OutputOracleAddress)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		addr := common.Address{0xbb, 0xcc, 0xdd}
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--l2-output-oracle-address", addr.Hex()))
+		require.Equal(t, addr, cfg.L2OutputOracleAddress)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid l2-output-oracle-address", addRequiredArgs(types.TraceTypeAlphabet, "--l2-output-oracle-address", "foo"))
+	})
+}
+
 func TestMaxPendingTx(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		expected := uint64(345)
@@ -761,6 +903,32 @@ func TestUnsafeAllowInvalidPrestate(t *testing.T) {
 	})
 }
 
+func TestSplitDepth(t *testing.T) {
+	t.Run("DefaultsToEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Empty(t, cfg.SplitDepths)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--split-depth", "alphabet=4,cannon=30"))
+		require.Equal(t, map[types.GameType]types.Depth{
+			types.AlphabetGameType: 4,
+			types.CannonGameType:   30,
+		}, cfg.SplitDepths)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "expected <trace-type>=<depth>",
+			addRequiredArgs(types.TraceTypeAlphabet, "--split-depth", "30"))
+		verifyArgsInvalid(t, "invalid split depth",
+			addRequiredArgs(types.TraceTypeAlphabet, "--split-depth", "foo=30"))
+		verifyArgsInvalid(t, "invalid split depth",
+			addRequiredArgs(types.TraceTypeAlphabet, "--split-depth", "cannon=abc"))
+		verifyArgsInvalid(t, "duplicate split depth",
+			addRequiredArgs(types.TraceTypeAlphabet, "--split-depth", "cannon=30,cannon=14"))
+	})
+}
+
 func TestAdditionalBondClaimants(t *testing.T) {
 	t.Run("DefaultsToEmpty", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgsExcept(types.TraceTypeAlphabet, "--additional-bond-claimants"))
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"net/url"
 	"runtime"
 	"slices"
@@ -13,6 +14,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -37,6 +39,7 @@ var (
 	ErrCannonNetworkAndL2Genesis        = errors.New("only specify one of network or l2 genesis path")
 	ErrCannonNetworkUnknown             = errors.New("unknown cannon network")
 	ErrMissingRollupRpc                 = errors.New("missing rollup rpc url")
+	ErrInvalidAlertWebhookURL           = errors.New("invalid alert webhook url")
 
 	ErrMissingAsteriscBin                 = errors.New("missing asterisc bin")
 	ErrMissingAsteriscServer              = errors.New("missing asterisc server")
@@ -70,22 +73,39 @@ const (
 // This also contains config options for auxiliary services.
 // It is used to initialize the challenger.
 type Config struct {
-	L1EthRpc             string           // L1 RPC Url
-	L1Beacon             string           // L1 Beacon API Url
-	GameFactoryAddress   common.Address   // Address of the dispute game factory
-	GameAllowlist        []common.Address // Allowlist of fault game addresses
-	GameWindow           time.Duration    // Maximum time duration to look for games to progress
-	Datadir              string           // Data Directory
-	MaxConcurrency       uint             // Maximum number of threads to use when progressing games
-	PollInterval         time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
-	AllowInvalidPrestate bool             // Whether to allow responding to games where the prestate does not match
+	L1EthRpc              string           // L1 RPC Url
+	L1Beacon              string           // L1 Beacon API Url
+	GameFactoryAddress    common.Address   // Address of the dispute game factory
+	GameAllowlist         []common.Address // Allowlist of fault game addresses
+	GameWindow            time.Duration    // Maximum time duration to look for games to progress
+	Datadir               string           // Data Directory
+	MaxConcurrency        uint             // Maximum number of threads to use when progressing games
+	MaxConcurrentTraceGen uint             // Maximum number of concurrent VM executions across all games (0 for no limit)
+	PollInterval          time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
+	AllowInvalidPrestate  bool             // Whether to allow responding to games where the prestate does not match
 
 	AdditionalBondClaimants []common.Address // List of addresses to claim bonds for in addition to the tx manager sender
 
 	SelectiveClaimResolution bool // Whether to only resolve claims for the claimants in AdditionalBondClaimants union [TxSender.From()]
 
+	BatchResolution bool // Whether to resolve claims and games in batches across games instead of by each game's agent
+
+	OnlyContradictingGames bool     // Whether to only act on games where the root claim contradicts the local node, or is valid but challenged
+	MaxBondsAtRisk         *big.Int // Maximum total bond in wei to post in games in progress (nil == no limit)
+
+	MonitorOnly     bool   // Whether to only watch games and raise alerts without sending any transactions
+	AlertWebhookURL string // URL to POST alerts about invalid proposals and suspicious games to (empty == no webhook)
+
+	OutputScanner         bool           // Whether to compare all published output roots against the local node and keep their verdicts
+	L2OutputOracleAddress common.Address // Address of the L2OutputOracle to scan output roots from in addition to games (zero == games only)
+
 	TraceTypes []types.TraceType // Type of traces supported
 
+	// SplitDepths are the split depths between the output root bisection and the execution trace bisection, per game
+	// type. They are used for games that don't report their split depth, and games reporting a different split depth
+	// are not played. The split depth of the game is used if unset.
+	SplitDepths map[types.GameType]types.Depth
+
 	RollupRpc string // L2 Rollup RPC Url
 
 	L2Rpc string // L2 RPC Url
@@ -102,9 +122,13 @@ type Config struct {
 
 	MaxPendingTx uint64 // Maximum number of pending transactions (0 == no limit)
 
+	LargePreimageMaxBaseFee *big.Int // Maximum L1 base fee in wei to upload large preimage data at (nil == no limit)
+
 	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
+	RPCEnabled    bool // Whether to start the RPC server
+	RPCConfig     oprpc.CLIConfig
 }
 
 func NewConfig(
@@ -132,6 +156,7 @@ func NewConfig(
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc, txmgr.DefaultChallengerFlagValues),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
+		RPCConfig:     oprpc.DefaultCLIConfig(),
 
 		Datadir: datadir,
 
@@ -184,6 +209,15 @@ func (c Config) Check() error {
 	if c.MaxConcurrency == 0 {
 		return ErrMaxConcurrencyZero
 	}
+	if c.AlertWebhookURL != "" {
+		if u, err := url.Parse(c.AlertWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %v", ErrInvalidAlertWebhookURL, c.AlertWebhookURL)
+		}
+	}
+	if c.MonitorOnly {
+		// Games are only watched so no VM is run and no transactions are sent.
+		return c.checkServerConfigs()
+	}
 	if c.TraceTypeEnabled(types.TraceTypeCannon) || c.TraceTypeEnabled(types.TraceTypePermissioned) {
 		if c.Cannon.VmBin == "" {
 			return ErrMissingCannonBin
@@ -263,11 +297,20 @@ func (c Config) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	return c.checkServerConfigs()
+}
+
+func (c Config) checkServerConfigs() error {
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if c.RPCEnabled {
+		if err := c.RPCConfig.Check(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -110,6 +110,51 @@ func TestGameAllowlistNotRequired(t *testing.T) {
 	require.NoError(t, config.Check())
 }
 
+func TestMonitorOnly(t *testing.T) {
+	t.Run("SkipsVMConfig", func(t *testing.T) {
+		config := NewConfig(validGameFactoryAddress, validL1EthRpc, validL1BeaconUrl, validRollupRpc, validL2Rpc, validDatadir, types.TraceTypeCannon)
+		config.MonitorOnly = true
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("SkipsTxMgrConfig", func(t *testing.T) {
+		config := validConfig(types.TraceTypeCannon)
+		config.MonitorOnly = true
+		config.TxMgrConfig = txmgr.CLIConfig{}
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("RequiresTraceType", func(t *testing.T) {
+		config := validConfig(types.TraceTypeCannon)
+		config.MonitorOnly = true
+		config.TraceTypes = nil
+		require.ErrorIs(t, config.Check(), ErrMissingTraceType)
+	})
+}
+
+func TestAlertWebhookURL(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		config := validConfig(types.TraceTypeCannon)
+		config.AlertWebhookURL = ""
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		config := validConfig(types.TraceTypeCannon)
+		config.AlertWebhookURL = "https://example.com/alerts"
+		require.NoError(t, config.Check())
+	})
+
+	for _, invalid := range []string{"example.com/alerts", "ftp://example.com", "http://", "://"} {
+		invalid := invalid
+		t.Run("Invalid-"+invalid, func(t *testing.T) {
+			config := validConfig(types.TraceTypeCannon)
+			config.AlertWebhookURL = invalid
+			require.ErrorIs(t, config.Check(), ErrInvalidAlertWebhookURL)
+		})
+	}
+}
+
 func TestCannonRequiredArgs(t *testing.T) {
 	for _, traceType := range cannonTraceTypes {
 		traceType := traceType
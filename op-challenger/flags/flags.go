@@ -2,9 +2,12 @@ package flags
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"net/url"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
@@ -13,15 +16,18 @@ import (
 	"github.com/ethereum-optimism/superchain-registry/superchain"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -78,6 +84,11 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   uint(runtime.NumCPU()),
 	}
+	MaxConcurrentTraceGenFlag = &cli.UintFlag{
+		Name:    "max-concurrent-trace-generation",
+		Usage:   "Maximum number of cannon or asterisc executions to run concurrently across all games. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_CONCURRENT_TRACE_GENERATION"),
+	}
 	L2EthRpcFlag = &cli.StringFlag{
 		Name:    "l2-eth-rpc",
 		Usage:   "L2 Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)  (cannon/asterisc trace type only)",
@@ -89,6 +100,11 @@ var (
 		Value:   config.DefaultMaxPendingTx,
 		EnvVars: prefixEnvVars("MAX_PENDING_TX"),
 	}
+	LargePreimageMaxBaseFeeFlag = &cli.Float64Flag{
+		Name:    "large-preimage-max-base-fee",
+		Usage:   "Maximum L1 base fee in gwei to upload large preimage data at. Uploads are deferred while the base fee is higher, unless the chess clock of the step would expire. 0 for no limit.",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_MAX_BASE_FEE"),
+	}
 	HTTPPollInterval = &cli.DurationFlag{
 		Name:    "http-poll-interval",
 		Usage:   "Polling interval for latest-block subscription when using an HTTP RPC provider.",
@@ -100,6 +116,13 @@ var (
 		Usage:   "List of addresses to claim bonds for, in addition to the configured transaction sender",
 		EnvVars: prefixEnvVars("ADDITIONAL_BOND_CLAIMANTS"),
 	}
+	SplitDepthFlag = &cli.StringSliceFlag{
+		Name: "split-depth",
+		Usage: "Split depth between output root and execution trace bisection, as <trace-type>=<depth>. " +
+			"Used for games of the trace type that don't report their split depth; games reporting a different split depth are not played. " +
+			"Uses the split depth of the game if unset.",
+		EnvVars: prefixEnvVars("SPLIT_DEPTH"),
+	}
 	CannonNetworkFlag = &cli.StringFlag{
 		Name:    "cannon-network",
 		Usage:   fmt.Sprintf("Deprecated: Use %v instead", flags.NetworkFlagName),
@@ -213,6 +236,48 @@ var (
 		Usage:   "Only resolve claims for the configured claimants",
 		EnvVars: prefixEnvVars("SELECTIVE_CLAIM_RESOLUTION"),
 	}
+	RPCEnabledFlag = &cli.BoolFlag{
+		Name:    "rpc.enabled",
+		Usage:   "Start the RPC server serving the challenger API, e.g. the bond accounting",
+		EnvVars: prefixEnvVars("RPC_ENABLED"),
+	}
+	BatchResolutionFlag = &cli.BoolFlag{
+		Name: "batch-resolution",
+		Usage: "Resolve claims and games once their clocks expire in batches across all games, before claiming bonds. " +
+			"Otherwise each game is resolved individually while it is progressed.",
+		EnvVars: prefixEnvVars("BATCH_RESOLUTION"),
+	}
+	OnlyContradictingGamesFlag = &cli.BoolFlag{
+		Name:    "only-contradicting-games",
+		Usage:   "Only act on games where the root claim contradicts the local node, or where a valid root claim is challenged",
+		EnvVars: prefixEnvVars("ONLY_CONTRADICTING_GAMES"),
+	}
+	MaxBondsAtRiskFlag = &cli.Float64Flag{
+		Name:    "max-bonds-at-risk",
+		Usage:   "Maximum total bond in ether to post in games in progress. No further moves are made once reached. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_BONDS_AT_RISK"),
+	}
+	MonitorOnlyFlag = &cli.BoolFlag{
+		Name:    "monitor-only",
+		Usage:   "Only watch games, and the L2OutputOracle outputs if l2-output-oracle-address is set, and raise alerts for invalid ones. No transactions are sent.",
+		EnvVars: prefixEnvVars("MONITOR_ONLY"),
+	}
+	AlertWebhookURLFlag = &cli.StringFlag{
+		Name:    "alert-webhook-url",
+		Usage:   "URL to POST alerts about invalid proposals and suspicious games to as JSON",
+		EnvVars: prefixEnvVars("ALERT_WEBHOOK_URL"),
+	}
+	OutputScannerFlag = &cli.BoolFlag{
+		Name: "output-scanner",
+		Usage: "Compare the output roots of all games, and of the L2OutputOracle if configured, against the local node " +
+			"and serve the verdicts over RPC",
+		EnvVars: prefixEnvVars("OUTPUT_SCANNER"),
+	}
+	L2OutputOracleAddressFlag = &cli.StringFlag{
+		Name:    "l2-output-oracle-address",
+		Usage:   "Address of the L2OutputOracle to scan output roots from. Used by the output scanner, which is always enabled in monitor-only mode if set.",
+		EnvVars: prefixEnvVars("L2_OUTPUT_ORACLE_ADDRESS"),
+	}
 	UnsafeAllowInvalidPrestate = &cli.BoolFlag{
 		Name:    "unsafe-allow-invalid-prestate",
 		Usage:   "Allow responding to games where the absolute prestate is configured incorrectly. THIS IS UNSAFE!",
@@ -235,10 +300,13 @@ var optionalFlags = []cli.Flag{
 	FactoryAddressFlag,
 	TraceTypeFlag,
 	MaxConcurrencyFlag,
+	MaxConcurrentTraceGenFlag,
 	L2EthRpcFlag,
 	MaxPendingTransactionsFlag,
+	LargePreimageMaxBaseFeeFlag,
 	HTTPPollInterval,
 	AdditionalBondClaimants,
+	SplitDepthFlag,
 	GameAllowlistFlag,
 	CannonNetworkFlag,
 	CannonRollupConfigFlag,
@@ -261,7 +329,15 @@ var optionalFlags = []cli.Flag{
 	AsteriscInfoFreqFlag,
 	GameWindowFlag,
 	SelectiveClaimResolutionFlag,
+	BatchResolutionFlag,
+	OnlyContradictingGamesFlag,
+	MaxBondsAtRiskFlag,
+	MonitorOnlyFlag,
+	AlertWebhookURLFlag,
+	OutputScannerFlag,
+	L2OutputOracleAddressFlag,
 	UnsafeAllowInvalidPrestate,
+	RPCEnabledFlag,
 }
 
 func init() {
@@ -269,6 +345,7 @@ func init() {
 	optionalFlags = append(optionalFlags, txmgr.CLIFlagsWithDefaults(EnvVarPrefix, txmgr.DefaultChallengerFlagValues)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, oprpc.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -382,6 +459,33 @@ func parseTraceTypes(ctx *cli.Context) ([]types.TraceType, error) {
 	return traceTypes, nil
 }
 
+func parseSplitDepths(ctx *cli.Context) (map[types.GameType]types.Depth, error) {
+	if !ctx.IsSet(SplitDepthFlag.Name) {
+		return nil, nil
+	}
+	splitDepths := make(map[types.GameType]types.Depth)
+	for _, entry := range ctx.StringSlice(SplitDepthFlag.Name) {
+		typeName, depthStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid split depth %q, expected <trace-type>=<depth>", entry)
+		}
+		traceType := new(types.TraceType)
+		if err := traceType.Set(typeName); err != nil {
+			return nil, fmt.Errorf("invalid split depth %q: %w", entry, err)
+		}
+		depth, err := strconv.ParseUint(depthStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid split depth %q: %w", entry, err)
+		}
+		gameType := traceType.GameType()
+		if _, ok := splitDepths[gameType]; ok {
+			return nil, fmt.Errorf("duplicate split depth for trace type %v", traceType)
+		}
+		splitDepths[gameType] = types.Depth(depth)
+	}
+	return splitDepths, nil
+}
+
 func getL2Rpc(ctx *cli.Context, logger log.Logger) (string, error) {
 	if ctx.IsSet(CannonL2Flag.Name) && ctx.IsSet(L2EthRpcFlag.Name) {
 		return "", fmt.Errorf("flag %v and %v must not be both set", CannonL2Flag.Name, L2EthRpcFlag.Name)
@@ -434,7 +538,12 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 	if err != nil {
 		return nil, err
 	}
-	if err := CheckRequired(ctx, traceTypes); err != nil {
+	requiredTraceTypes := traceTypes
+	if ctx.Bool(MonitorOnlyFlag.Name) {
+		// No VM is run when only monitoring so the trace type specific flags are not required.
+		requiredTraceTypes = nil
+	}
+	if err := CheckRequired(ctx, requiredTraceTypes); err != nil {
 		return nil, err
 	}
 	gameFactoryAddress, err := FactoryAddress(ctx)
@@ -470,6 +579,10 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			claimants = append(claimants, claimant)
 		}
 	}
+	splitDepths, err := parseSplitDepths(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var cannonPrestatesURL *url.URL
 	if ctx.IsSet(CannonPreStatesURLFlag.Name) {
 		parsed, err := url.Parse(ctx.String(CannonPreStatesURLFlag.Name))
@@ -486,6 +599,28 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		}
 		asteriscPreStatesURL = parsed
 	}
+	var largePreimageMaxBaseFee *big.Int
+	if maxBaseFee := ctx.Float64(LargePreimageMaxBaseFeeFlag.Name); maxBaseFee < 0 {
+		return nil, fmt.Errorf("invalid %v: must not be negative", LargePreimageMaxBaseFeeFlag.Name)
+	} else if maxBaseFee != 0 {
+		largePreimageMaxBaseFee, err = eth.GweiToWei(maxBaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v: %w", LargePreimageMaxBaseFeeFlag.Name, err)
+		}
+	}
+	var maxBondsAtRisk *big.Int
+	if maxBonds := ctx.Float64(MaxBondsAtRiskFlag.Name); maxBonds < 0 || math.IsNaN(maxBonds) || math.IsInf(maxBonds, 0) {
+		return nil, fmt.Errorf("invalid %v: %v", MaxBondsAtRiskFlag.Name, maxBonds)
+	} else if maxBonds != 0 {
+		maxBondsAtRisk, _ = new(big.Float).Mul(big.NewFloat(maxBonds), big.NewFloat(params.Ether)).Int(nil)
+	}
+	var l2OutputOracleAddress common.Address
+	if ctx.IsSet(L2OutputOracleAddressFlag.Name) {
+		l2OutputOracleAddress, err = opservice.ParseAddress(ctx.String(L2OutputOracleAddressFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v: %w", L2OutputOracleAddressFlag.Name, err)
+		}
+	}
 	l2Rpc, err := getL2Rpc(ctx, logger)
 	if err != nil {
 		return nil, err
@@ -505,12 +640,15 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		L1EthRpc:                l1EthRpc,
 		L1Beacon:                l1Beacon,
 		TraceTypes:              traceTypes,
+		SplitDepths:             splitDepths,
 		GameFactoryAddress:      gameFactoryAddress,
 		GameAllowlist:           allowedGames,
 		GameWindow:              ctx.Duration(GameWindowFlag.Name),
 		MaxConcurrency:          maxConcurrency,
+		MaxConcurrentTraceGen:   ctx.Uint(MaxConcurrentTraceGenFlag.Name),
 		L2Rpc:                   l2Rpc,
 		MaxPendingTx:            ctx.Uint64(MaxPendingTransactionsFlag.Name),
+		LargePreimageMaxBaseFee: largePreimageMaxBaseFee,
 		PollInterval:            ctx.Duration(HTTPPollInterval.Name),
 		AdditionalBondClaimants: claimants,
 		RollupRpc:               ctx.String(RollupRpcFlag.Name),
@@ -548,7 +686,16 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		TxMgrConfig:                     txMgrConfig,
 		MetricsConfig:                   metricsConfig,
 		PprofConfig:                     pprofConfig,
+		RPCEnabled:                      ctx.Bool(RPCEnabledFlag.Name),
+		RPCConfig:                       oprpc.ReadCLIConfig(ctx),
 		SelectiveClaimResolution:        ctx.Bool(SelectiveClaimResolutionFlag.Name),
+		BatchResolution:                 ctx.Bool(BatchResolutionFlag.Name),
+		OnlyContradictingGames:          ctx.Bool(OnlyContradictingGamesFlag.Name),
+		MaxBondsAtRisk:                  maxBondsAtRisk,
+		MonitorOnly:                     ctx.Bool(MonitorOnlyFlag.Name),
+		AlertWebhookURL:                 ctx.String(AlertWebhookURLFlag.Name),
+		OutputScanner:                   ctx.Bool(OutputScannerFlag.Name),
+		L2OutputOracleAddress:           l2OutputOracleAddress,
 		AllowInvalidPrestate:            ctx.Bool(UnsafeAllowInvalidPrestate.Name),
 	}, nil
 }
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
@@ -31,6 +34,7 @@ type Responder interface {
 type ClaimLoader interface {
 	GetAllClaims(ctx context.Context, block rpcblock.Block) ([]types.Claim, error)
 	IsL2BlockNumberChallenged(ctx context.Context, block rpcblock.Block) (bool, error)
+	GetRequiredBond(ctx context.Context, position types.Position) (*big.Int, error)
 }
 
 type Agent struct {
@@ -44,7 +48,17 @@ type Agent struct {
 	claimants        []common.Address
 	maxDepth         types.Depth
 	maxClockDuration time.Duration
+	state            *GameStateStore
+	policy           GamePolicy
+	resolution       claims.ResolutionPolicy
 	log              log.Logger
+
+	// clockExpiry is the unix time at which the first chess clock of the game expires, or 0 if not yet known.
+	clockExpiry atomic.Int64
+	// forecast is the gameTypes.GameForecast of the game as of the last time it was acted on.
+	forecast atomic.Uint32
+	// requiredBonds caches the required bond of moves by the generalized index of their position.
+	requiredBonds map[string]*big.Int
 }
 
 func NewAgent(
@@ -56,6 +70,9 @@ func NewAgent(
 	maxClockDuration time.Duration,
 	trace types.TraceAccessor,
 	responder Responder,
+	state *GameStateStore,
+	policy GamePolicy,
+	resolution claims.ResolutionPolicy,
 	log log.Logger,
 	selective bool,
 	claimants []common.Address,
@@ -71,13 +88,17 @@ func NewAgent(
 		claimants:        claimants,
 		maxDepth:         maxDepth,
 		maxClockDuration: maxClockDuration,
+		state:            state,
+		policy:           policy,
+		resolution:       resolution,
 		log:              log,
+		requiredBonds:    make(map[string]*big.Int),
 	}
 }
 
 // Act iterates the game & performs all of the next actions.
 func (a *Agent) Act(ctx context.Context) error {
-	if a.tryResolve(ctx) {
+	if !a.resolution.DeferResolution() && a.tryResolve(ctx) {
 		return nil
 	}
 
@@ -97,23 +118,172 @@ func (a *Agent) Act(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("create game from contracts: %w", err)
 	}
+	a.recordClockExpiry(game)
+	if err := a.state.UpdateClaims(game.Claims()); err != nil {
+		a.log.Error("Failed to store game state", "err", err)
+	}
+	posted := a.postedBonds(game)
+
+	agree, agreeErr := a.solver.AgreeWithRootClaim(ctx, game)
+	if agreeErr != nil {
+		a.log.Warn("Failed to determine if root claim is valid", "err", agreeErr)
+		a.forecast.Store(uint32(gameTypes.ForecastUnknown))
+	} else if agree && a.policy.OnlyContradicting() && len(game.Claims()) == 1 {
+		// Valid root claims are always defended once challenged, so only unchallenged ones can be skipped
+		a.log.Debug("Not acting on unchallenged game with a valid root claim")
+		a.policy.UpdateBondsAtRisk(posted, new(big.Int))
+		a.recordForecast(game, agree, nil)
+		return nil
+	}
 
 	actions, err := a.solver.CalculateNextActions(ctx, game)
 	if err != nil {
 		a.log.Error("Failed to calculate all required moves", "err", err)
 	}
+	// submitted holds the actions that were already submitted, or are submitted now, and are expected to be included
+	var submitted []types.Action
+	pendingBonds := new(big.Int)
+	actions = slices.DeleteFunc(actions, func(action types.Action) bool {
+		if !a.state.IsPending(action, a.systemClock.Now()) {
+			return false
+		}
+		a.log.Debug("Skipping action that was already submitted", "action", action.Type, "parent", action.ParentClaim.ContractIndex)
+		submitted = append(submitted, action)
+		if action.Type == types.ActionTypeMove {
+			if bond, err := a.requiredBond(ctx, movePosition(action)); err != nil {
+				a.log.Warn("Failed to load required bond of pending move", "parent", action.ParentClaim.ContractIndex, "err", err)
+			} else {
+				pendingBonds.Add(pendingBonds, bond)
+			}
+		}
+		return true
+	})
+	a.policy.UpdateBondsAtRisk(posted, pendingBonds)
+
+	// Reserve the bond of each move before sending it, so concurrent moves across games can't exceed the limit.
+	// bonds holds the reserved bond of each action to perform, or nil if it isn't a move.
+	var toPerform []types.Action
+	var bonds []*big.Int
+	now := a.l1Clock.Now()
+	for _, action := range actions {
+		var bond *big.Int
+		if action.Type == types.ActionTypeStep {
+			// The step must be included before the clock to counter its parent claim expires
+			action.Deadline = now.Add(a.maxClockDuration - game.ChessClock(now, action.ParentClaim))
+		}
+		if action.Type == types.ActionTypeMove {
+			bond, err = a.requiredBond(ctx, movePosition(action))
+			if err != nil {
+				a.log.Error("Failed to load required bond of move", "parent", action.ParentClaim.ContractIndex, "err", err)
+				continue
+			}
+			if !a.policy.ReserveBond(bond) {
+				a.log.Warn("Skipping move because the maximum bonds at risk has been reached", "parent", action.ParentClaim.ContractIndex, "bond", bond)
+				continue
+			}
+		}
+		toPerform = append(toPerform, action)
+		bonds = append(bonds, bond)
+	}
+	actions = toPerform
 
 	var wg sync.WaitGroup
 	wg.Add(len(actions))
-	for _, action := range actions {
-		go a.performAction(ctx, &wg, action)
+	performed := make([]bool, len(actions))
+	for i, action := range actions {
+		go func(i int, action types.Action) {
+			defer wg.Done()
+			performed[i] = a.performAction(ctx, action)
+		}(i, action)
 	}
 	wg.Wait()
+	for i, action := range actions {
+		if performed[i] {
+			submitted = append(submitted, action)
+		} else if bonds[i] != nil {
+			a.policy.ReleaseBond(bonds[i])
+		}
+	}
+	if agreeErr == nil {
+		a.recordForecast(game, agree, submitted)
+	}
 	return nil
 }
 
-func (a *Agent) performAction(ctx context.Context, wg *sync.WaitGroup, action types.Action) {
-	defer wg.Done()
+// ClockExpiry returns the time at which the chess clock of the first uncountered claim in the game expires, as of
+// the last time the game was acted on. Returns the zero time if the game hasn't been loaded yet.
+func (a *Agent) ClockExpiry() time.Time {
+	expiry := a.clockExpiry.Load()
+	if expiry == 0 {
+		return time.Time{}
+	}
+	return time.Unix(expiry, 0)
+}
+
+func (a *Agent) recordClockExpiry(game types.Game) {
+	if expiry := earliestClockExpiry(game, a.l1Clock.Now(), a.maxClockDuration); !expiry.IsZero() {
+		a.clockExpiry.Store(expiry.Unix())
+	}
+}
+
+// earliestClockExpiry returns the time at which the first chess clock of an uncountered claim in the game expires,
+// or the zero time if the game has no claims.
+func earliestClockExpiry(game types.Game, now time.Time, maxClockDuration time.Duration) time.Time {
+	claims := game.Claims()
+	countered := make(map[int]bool, len(claims))
+	for _, claim := range claims {
+		if !claim.IsRootPosition() {
+			countered[claim.ParentContractIndex] = true
+		}
+	}
+	var earliest time.Time
+	for _, claim := range claims {
+		if countered[claim.ContractIndex] {
+			continue
+		}
+		expiry := now.Add(maxClockDuration - game.ChessClock(now, claim))
+		if earliest.IsZero() || expiry.Before(earliest) {
+			earliest = expiry
+		}
+	}
+	return earliest
+}
+
+// postedBonds returns the total bond posted by the claimants in the game.
+func (a *Agent) postedBonds(game types.Game) *big.Int {
+	posted := new(big.Int)
+	for _, claim := range game.Claims() {
+		if slices.Contains(a.claimants, claim.Claimant) && claim.Bond != nil {
+			posted.Add(posted, claim.Bond)
+		}
+	}
+	return posted
+}
+
+// requiredBond returns the bond required to post a claim at the position.
+func (a *Agent) requiredBond(ctx context.Context, pos types.Position) (*big.Int, error) {
+	gIndex := pos.ToGIndex().String()
+	if bond, ok := a.requiredBonds[gIndex]; ok {
+		return bond, nil
+	}
+	bond, err := a.loader.GetRequiredBond(ctx, pos)
+	if err != nil {
+		return nil, err
+	}
+	a.requiredBonds[gIndex] = bond
+	return bond, nil
+}
+
+// movePosition returns the position of the claim posted by the move.
+func movePosition(action types.Action) types.Position {
+	if action.IsAttack {
+		return action.ParentClaim.Position.Attack()
+	}
+	return action.ParentClaim.Position.Defend()
+}
+
+// performAction performs the action, returning true if it was sent successfully.
+func (a *Agent) performAction(ctx context.Context, action types.Action) bool {
 	actionLog := a.log.New("action", action.Type)
 	if action.Type == types.ActionTypeStep {
 		containsOracleData := action.OracleData != nil
@@ -142,10 +312,18 @@ func (a *Agent) performAction(ctx context.Context, wg *sync.WaitGroup, action ty
 		a.metrics.RecordGameL2Challenge()
 	}
 	actionLog.Info("Performing action")
+	if err := a.state.Submitted(action, a.systemClock.Now()); err != nil {
+		actionLog.Error("Failed to store submitted action", "err", err)
+	}
 	err := a.responder.PerformAction(ctx, action)
 	if err != nil {
 		actionLog.Error("Action failed", "err", err)
+		if err := a.state.Failed(action); err != nil {
+			actionLog.Error("Failed to store failed action", "err", err)
+		}
+		return false
 	}
+	return true
 }
 
 // tryResolve resolves the game if it is in a winning state
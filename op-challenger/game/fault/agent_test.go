@@ -3,11 +3,14 @@ package fault
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
@@ -59,6 +62,20 @@ func TestDoNotMakeMovesWhenGameIsResolvable(t *testing.T) {
 	}
 }
 
+func TestDeferResolution(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	agent.resolution = claims.BatchResolution(true)
+	responder.callResolveStatus = gameTypes.GameStatusDefenderWon
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	claimLoader.claims = claimBuilder.GameBuilder().Game.Claims()
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Zero(t, responder.callResolveCount, "should leave resolution to the resolver")
+	require.Zero(t, responder.callResolveClaimCount)
+	require.Zero(t, responder.resolveCount)
+}
+
 func TestDoNotMakeMovesWhenL2BlockNumberChallenged(t *testing.T) {
 	ctx := context.Background()
 
@@ -167,6 +184,131 @@ func TestSkipAttemptingToResolveClaimsWhenClockNotExpired(t *testing.T) {
 	require.Equal(t, 2, responder.callResolveClaimCount)
 }
 
+func TestRecordClockExpiry(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	require.Zero(t, agent.ClockExpiry(), "unknown before acting")
+
+	rootTime := l1Time.Add(-time.Minute)
+	gameBuilder := claimBuilder.GameBuilder(test.WithClock(rootTime, 0))
+	claimLoader.claims = gameBuilder.Game.Claims()
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, l1Time.Add(2*time.Minute).Unix(), agent.ClockExpiry().Unix())
+
+	// Only the clock of the uncountered claim can still expire
+	gameBuilder.Seq().Attack(test.WithClock(l1Time.Add(-30*time.Second), time.Minute))
+	claimLoader.claims = gameBuilder.Game.Claims()
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, l1Time.Add(150*time.Second).Unix(), agent.ClockExpiry().Unix())
+}
+
+func TestSkipActionsSubmittedBeforeRestart(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim(test.WithInvalidValue(true))}
+
+	responder.performActionErr = errors.New("boom")
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 1, responder.performActionCount)
+
+	// Failed actions are retried
+	responder.performActionErr = nil
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 2, responder.performActionCount)
+
+	// After a restart, the action is assumed to still be in flight
+	state, err := NewGameStateStore(filepath.Dir(agent.state.path))
+	require.NoError(t, err)
+	agent.state = state
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 2, responder.performActionCount)
+
+	// Until it expires without being included in the game
+	agent.systemClock.(*clock.DeterministicClock).AdvanceTime(pendingActionExpiry)
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 3, responder.performActionCount)
+}
+
+func TestOnlyContradictingGames(t *testing.T) {
+	for _, onlyContradicting := range []bool{false, true} {
+		onlyContradicting := onlyContradicting
+		t.Run(fmt.Sprintf("OnlyContradicting-%v", onlyContradicting), func(t *testing.T) {
+			agent, claimLoader, responder := setupTestAgent(t)
+			agent.policy = NewParticipationPolicy(onlyContradicting, nil).ForGame(common.Address{0xaa})
+			responder.callResolveErr = errors.New("game is not resolvable")
+			responder.callResolveClaimErr = errors.New("claim is not resolvable")
+			depth := types.Depth(4)
+			claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+
+			// Unchallenged valid root claim, which needs no action
+			claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim()}
+			require.NoError(t, agent.Act(context.Background()))
+			require.Zero(t, responder.performActionCount)
+			require.Equal(t, gameTypes.ForecastHonestWinning, agent.Forecast())
+
+			// Valid root claims are always defended once challenged
+			gameBuilder := claimBuilder.GameBuilder()
+			gameBuilder.Seq().Attack(test.WithValue(common.Hash{0xaa}))
+			claimLoader.claims = gameBuilder.Game.Claims()
+			require.NoError(t, agent.Act(context.Background()))
+			require.Equal(t, 1, responder.performActionCount)
+
+			// Invalid root claims are always countered
+			claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim(test.WithInvalidValue(true))}
+			responder.performActionCount = 0
+			require.NoError(t, agent.Act(context.Background()))
+			require.Equal(t, 1, responder.performActionCount)
+		})
+	}
+}
+
+func TestMaxBondsAtRisk(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	claimant := common.Address{0xbb}
+	agent.claimants = []common.Address{claimant}
+	policy := NewParticipationPolicy(false, big.NewInt(100))
+	agent.policy = policy.ForGame(common.Address{0xaa})
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+
+	// Another game has bonds at risk but within the limit
+	policy.ForGame(common.Address{0xcc}).UpdateBondsAtRisk(big.NewInt(50), big.NewInt(0))
+	claimLoader.requiredBond = big.NewInt(1)
+	gameBuilder := claimBuilder.GameBuilder(test.WithInvalidValue(true))
+	seq := gameBuilder.Seq().Attack(test.WithClaimant(claimant))
+	seq.Attack(test.WithInvalidValue(true))
+	claims := gameBuilder.Game.Claims()
+	claims[1].Bond = big.NewInt(50)
+	claimLoader.claims = claims
+	require.NoError(t, agent.Act(context.Background()))
+	require.Zero(t, responder.performActionCount, "should not move if the bond would exceed the limit")
+	require.Equal(t, big.NewInt(100), policy.BondsAtRisk())
+
+	// A failed move releases its reserved bond
+	claims[1].Bond = big.NewInt(49)
+	responder.performActionErr = errors.New("boom")
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 1, responder.performActionCount)
+	require.Equal(t, big.NewInt(99), policy.BondsAtRisk())
+
+	// The bond of a sent move is reserved until it is included in the game
+	responder.performActionErr = nil
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 2, responder.performActionCount)
+	require.Equal(t, big.NewInt(100), policy.BondsAtRisk())
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 2, responder.performActionCount, "should not repeat the pending move")
+	require.Equal(t, big.NewInt(100), policy.BondsAtRisk(), "pending move should remain reserved")
+}
+
 func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	// Checks that if the game isn't resolvable, that the agent continues on to start checking claims
 	agent, claimLoader, responder := setupTestAgent(t)
@@ -195,7 +337,9 @@ func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	responder := &stubResponder{}
 	systemClock := clock.NewDeterministicClock(time.UnixMilli(120200))
 	l1Clock := clock.NewDeterministicClock(l1Time)
-	agent := NewAgent(metrics.NoopMetrics, systemClock, l1Clock, claimLoader, depth, gameDuration, trace.NewSimpleTraceAccessor(provider), responder, logger, false, []common.Address{})
+	state, err := NewGameStateStore(t.TempDir())
+	require.NoError(t, err)
+	agent := NewAgent(metrics.NoopMetrics, systemClock, l1Clock, claimLoader, depth, gameDuration, trace.NewSimpleTraceAccessor(provider), responder, state, NewParticipationPolicy(false, nil).ForGame(common.Address{0xaa}), claims.BatchResolution(false), logger, false, []common.Address{})
 	return agent, claimLoader, responder
 }
 
@@ -204,12 +348,20 @@ type stubClaimLoader struct {
 	maxLoads           int
 	claims             []types.Claim
 	blockNumChallenged bool
+	requiredBond       *big.Int
 }
 
 func (s *stubClaimLoader) IsL2BlockNumberChallenged(_ context.Context, _ rpcblock.Block) (bool, error) {
 	return s.blockNumChallenged, nil
 }
 
+func (s *stubClaimLoader) GetRequiredBond(_ context.Context, _ types.Position) (*big.Int, error) {
+	if s.requiredBond == nil {
+		return big.NewInt(0), nil
+	}
+	return s.requiredBond, nil
+}
+
 func (s *stubClaimLoader) GetAllClaims(_ context.Context, _ rpcblock.Block) ([]types.Claim, error) {
 	s.callCount++
 	if s.callCount > s.maxLoads && s.maxLoads != 0 {
@@ -230,6 +382,9 @@ type stubResponder struct {
 	callResolveClaimCount int
 	callResolveClaimErr   error
 	resolveClaimCount     int
+
+	performActionCount int
+	performActionErr   error
 }
 
 func (s *stubResponder) CallResolve(_ context.Context) (gameTypes.GameStatus, error) {
@@ -261,5 +416,8 @@ func (s *stubResponder) ResolveClaims(claims ...uint64) error {
 }
 
 func (s *stubResponder) PerformAction(_ context.Context, _ types.Action) error {
-	return nil
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.performActionCount++
+	return s.performActionErr
 }
@@ -0,0 +1,192 @@
+package claims
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type BondAccountingMetrics interface {
+	RecordBondAccounting(posted, claimable, claimed, expectedProfit *big.Int)
+}
+
+// GameBonds is the bond accounting of the claimants in a single game.
+type GameBonds struct {
+	Game   common.Address   `json:"game"`
+	Status types.GameStatus `json:"status"`
+	// ClaimCount is the number of claims in the game when the posted bonds were last counted.
+	ClaimCount uint64 `json:"claimCount"`
+	// Posted is the total bond posted by the claimants.
+	Posted *big.Int `json:"posted"`
+	// Claimable is the credit of the claimants that hasn't been claimed yet.
+	Claimable *big.Int `json:"claimable"`
+	// Claimed is the credit claimed by the claimants.
+	Claimed *big.Int `json:"claimed"`
+	// ExpectedProfit is the profit (or loss if negative) of the claimants. While the game is in progress, it is the
+	// total bond of the opposing claims countered by the claimants, assuming the claimants win their subgames.
+	// Once resolved, it is the credit paid out to the claimants less the bonds they posted.
+	ExpectedProfit *big.Int `json:"expectedProfit"`
+}
+
+// BondTotals is the bond accounting summed over all games.
+type BondTotals struct {
+	Posted         *big.Int `json:"posted"`
+	Claimable      *big.Int `json:"claimable"`
+	Claimed        *big.Int `json:"claimed"`
+	ExpectedProfit *big.Int `json:"expectedProfit"`
+}
+
+// settled returns true if the game is resolved and all credit has been claimed, so the accounting is final.
+func (b *GameBonds) settled() bool {
+	return b.Status != types.GameStatusInProgress && b.Claimable.Sign() == 0
+}
+
+// participated returns true if the claimants posted bonds in or received credit from the game.
+func (b *GameBonds) participated() bool {
+	return b.Posted.Sign() != 0 || b.Claimable.Sign() != 0 || b.Claimed.Sign() != 0
+}
+
+// BondAccountant tracks the bonds posted and the credit received by the claimants per game, in the BondStore.
+type BondAccountant struct {
+	logger          log.Logger
+	metrics         BondAccountingMetrics
+	store           *BondStore
+	contractCreator BondContractCreator
+	claimants       []common.Address
+}
+
+func NewBondAccountant(l log.Logger, m BondAccountingMetrics, store *BondStore, contractCreator BondContractCreator, claimants ...common.Address) *BondAccountant {
+	return &BondAccountant{
+		logger:          l,
+		metrics:         m,
+		store:           store,
+		contractCreator: contractCreator,
+		claimants:       claimants,
+	}
+}
+
+// Account updates the accounting of the games. Games that the claimants did not participate in aren't stored.
+// Settled games that are no longer in the given games, i.e. have left the game window, are pruned from the store.
+func (a *BondAccountant) Account(ctx context.Context, games []types.GameMetadata) (err error) {
+	current := make(map[common.Address]bool, len(games))
+	for _, game := range games {
+		current[game.Proxy] = true
+		err = errors.Join(err, a.accountGame(ctx, game))
+	}
+	if pruneErr := a.store.Prune(func(bonds GameBonds) bool {
+		return current[bonds.Game] || !bonds.settled()
+	}); pruneErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to prune bond accounting: %w", pruneErr))
+	}
+	a.recordMetrics()
+	return err
+}
+
+func (a *BondAccountant) accountGame(ctx context.Context, game types.GameMetadata) error {
+	bonds, ok := a.store.Game(game.Proxy)
+	if ok && bonds.settled() {
+		return nil
+	} else if !ok {
+		bonds = GameBonds{Game: game.Proxy, Posted: new(big.Int), Claimed: new(big.Int), ExpectedProfit: new(big.Int)}
+	}
+
+	contract, err := a.contractCreator(game)
+	if err != nil {
+		return fmt.Errorf("failed to create bond contract: %w", err)
+	}
+	// Read the claim count, status and credits in one batch, only loading the claims if new claims were posted.
+	claimCount, status, credits, err := contract.GetClaimCountAndCredits(ctx, rpcblock.Latest, a.claimants...)
+	if err != nil {
+		return fmt.Errorf("failed to get claim count and credits: %w", err)
+	}
+	if claimCount != bonds.ClaimCount {
+		claims, err := contract.GetAllClaims(ctx, rpcblock.Latest)
+		if err != nil {
+			return fmt.Errorf("failed to get claims: %w", err)
+		}
+		bonds.ClaimCount = uint64(len(claims))
+		bonds.Posted, bonds.ExpectedProfit = a.postedAndCountered(claims)
+	}
+
+	claimable := new(big.Int)
+	for _, credit := range credits {
+		claimable.Add(claimable, credit)
+	}
+	bonds.Status = status
+	bonds.Claimable = claimable
+	if bonds.Status != types.GameStatusInProgress {
+		bonds.ExpectedProfit = new(big.Int).Add(bonds.Claimable, bonds.Claimed)
+		bonds.ExpectedProfit.Sub(bonds.ExpectedProfit, bonds.Posted)
+	}
+
+	if !ok && !bonds.participated() {
+		return nil
+	}
+	a.logger.Debug("Updated bond accounting", "game", game.Proxy, "status", bonds.Status, "posted", bonds.Posted,
+		"claimable", bonds.Claimable, "claimed", bonds.Claimed, "expectedProfit", bonds.ExpectedProfit)
+	if err := a.store.Put(bonds); err != nil {
+		return fmt.Errorf("failed to store bond accounting: %w", err)
+	}
+	return nil
+}
+
+// postedAndCountered returns the total bond of the claimants' claims and the total bond of the opposing claims they
+// countered.
+func (a *BondAccountant) postedAndCountered(claims []faultTypes.Claim) (*big.Int, *big.Int) {
+	posted := new(big.Int)
+	countered := new(big.Int)
+	counted := make(map[int]bool)
+	for _, claim := range claims {
+		if !slices.Contains(a.claimants, claim.Claimant) {
+			continue
+		}
+		posted.Add(posted, claim.Bond)
+		if claim.IsRoot() || counted[claim.ParentContractIndex] {
+			continue
+		}
+		parent := claims[claim.ParentContractIndex]
+		if !slices.Contains(a.claimants, parent.Claimant) {
+			countered.Add(countered, parent.Bond)
+			counted[claim.ParentContractIndex] = true
+		}
+	}
+	return posted, countered
+}
+
+// RecordClaimed records credit claimed by a claimant from the game.
+func (a *BondAccountant) RecordClaimed(game common.Address, amount *big.Int) {
+	if err := a.store.Claimed(game, amount); err != nil {
+		a.logger.Error("Failed to store claimed credit", "game", game, "amount", amount, "err", err)
+	}
+	a.recordMetrics()
+}
+
+// Bonds returns the accounting of all games the claimants participated in, that haven't been pruned.
+func (a *BondAccountant) Bonds() []GameBonds {
+	return a.store.Games()
+}
+
+// Totals returns the accounting summed over all games the claimants participated in, that haven't been pruned.
+func (a *BondAccountant) Totals() BondTotals {
+	totals := BondTotals{Posted: new(big.Int), Claimable: new(big.Int), Claimed: new(big.Int), ExpectedProfit: new(big.Int)}
+	for _, bonds := range a.store.Games() {
+		totals.Posted.Add(totals.Posted, bonds.Posted)
+		totals.Claimable.Add(totals.Claimable, bonds.Claimable)
+		totals.Claimed.Add(totals.Claimed, bonds.Claimed)
+		totals.ExpectedProfit.Add(totals.ExpectedProfit, bonds.ExpectedProfit)
+	}
+	return totals
+}
+
+func (a *BondAccountant) recordMetrics() {
+	totals := a.Totals()
+	a.metrics.RecordBondAccounting(totals.Posted, totals.Claimable, totals.Claimed, totals.ExpectedProfit)
+}
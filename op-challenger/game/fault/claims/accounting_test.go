@@ -0,0 +1,166 @@
+package claims
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ourClaimant   = common.Address{0xaa}
+	otherClaimant = common.Address{0xbb}
+)
+
+func TestBondAccountant_Account(t *testing.T) {
+	t.Run("InProgressGame", func(t *testing.T) {
+		accountant, m, contract := newTestAccountant(t)
+		contract.claims = []faultTypes.Claim{
+			testClaim(0, -1, otherClaimant, 10),
+			testClaim(1, 0, ourClaimant, 20),
+			testClaim(2, 0, ourClaimant, 30), // Counters the same claim, so its bond isn't counted twice
+			testClaim(3, 2, otherClaimant, 40),
+		}
+		require.NoError(t, accountant.Account(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+
+		bonds := accountant.Bonds()
+		require.Len(t, bonds, 1)
+		require.Equal(t, gameAddr, bonds[0].Game)
+		require.Equal(t, types.GameStatusInProgress, bonds[0].Status)
+		require.EqualValues(t, 4, bonds[0].ClaimCount)
+		require.Equal(t, big.NewInt(50), bonds[0].Posted)
+		require.Zero(t, bonds[0].Claimable.Sign())
+		require.Equal(t, big.NewInt(10), bonds[0].ExpectedProfit)
+		require.Equal(t, big.NewInt(50), m.totals.Posted)
+	})
+
+	t.Run("SkipGamesNotParticipatedIn", func(t *testing.T) {
+		accountant, _, contract := newTestAccountant(t)
+		contract.claims = []faultTypes.Claim{testClaim(0, -1, otherClaimant, 10)}
+		require.NoError(t, accountant.Account(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.Empty(t, accountant.Bonds())
+	})
+
+	t.Run("OnlyLoadClaimsWhenCountChanges", func(t *testing.T) {
+		accountant, _, contract := newTestAccountant(t)
+		contract.claims = []faultTypes.Claim{testClaim(0, -1, ourClaimant, 10)}
+		games := []types.GameMetadata{{Proxy: gameAddr}}
+		require.NoError(t, accountant.Account(context.Background(), games))
+		require.NoError(t, accountant.Account(context.Background(), games))
+		require.Equal(t, 1, contract.claimsLoads)
+
+		contract.claims = append(contract.claims, testClaim(1, 0, otherClaimant, 20))
+		require.NoError(t, accountant.Account(context.Background(), games))
+		require.Equal(t, 2, contract.claimsLoads)
+		// The claim count and credits are read in a single batch per game
+		require.Equal(t, 3, contract.creditLoads)
+	})
+
+	t.Run("ResolvedGame", func(t *testing.T) {
+		accountant, m, contract := newTestAccountant(t)
+		contract.claims = []faultTypes.Claim{
+			testClaim(0, -1, otherClaimant, 10),
+			testClaim(1, 0, ourClaimant, 20),
+		}
+		contract.status = types.GameStatusChallengerWon
+		contract.credit[ourClaimant] = 30
+		games := []types.GameMetadata{{Proxy: gameAddr}}
+		require.NoError(t, accountant.Account(context.Background(), games))
+		bonds := accountant.Bonds()[0]
+		require.Equal(t, big.NewInt(30), bonds.Claimable)
+		require.Equal(t, big.NewInt(10), bonds.ExpectedProfit)
+
+		accountant.RecordClaimed(gameAddr, big.NewInt(30))
+		bonds = accountant.Bonds()[0]
+		require.Zero(t, bonds.Claimable.Sign())
+		require.Equal(t, big.NewInt(30), bonds.Claimed)
+		require.Equal(t, big.NewInt(30), m.totals.Claimed)
+		require.Equal(t, big.NewInt(10), m.totals.ExpectedProfit)
+
+		// Settled games are not reloaded
+		contract.credit[ourClaimant] = 0
+		require.NoError(t, accountant.Account(context.Background(), games))
+		require.Equal(t, 1, contract.claimsLoads)
+		require.Equal(t, bonds, accountant.Bonds()[0])
+
+		// Settled games are pruned once they leave the game window
+		require.NoError(t, accountant.Account(context.Background(), nil))
+		require.Empty(t, accountant.Bonds())
+	})
+
+	t.Run("KeepUnsettledGamesOutsideWindow", func(t *testing.T) {
+		accountant, _, contract := newTestAccountant(t)
+		contract.claims = []faultTypes.Claim{testClaim(0, -1, ourClaimant, 10)}
+		require.NoError(t, accountant.Account(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.NoError(t, accountant.Account(context.Background(), nil))
+		require.Len(t, accountant.Bonds(), 1)
+	})
+
+	t.Run("LostGame", func(t *testing.T) {
+		accountant, _, contract := newTestAccountant(t)
+		contract.claims = []faultTypes.Claim{
+			testClaim(0, -1, otherClaimant, 10),
+			testClaim(1, 0, ourClaimant, 20),
+		}
+		contract.status = types.GameStatusDefenderWon
+		require.NoError(t, accountant.Account(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.Equal(t, big.NewInt(-20), accountant.Bonds()[0].ExpectedProfit)
+	})
+}
+
+func TestClaimer_RecordsClaimedBonds(t *testing.T) {
+	c, _, contract, txSender := newTestClaimer(t)
+	m := &stubAccountingMetrics{}
+	c.accountant = NewBondAccountant(testlog.Logger(t, log.LvlDebug), m, newTestStore(t), func(game types.GameMetadata) (BondContract, error) {
+		return contract, nil
+	}, txSender.From())
+	contract.claims = []faultTypes.Claim{testClaim(0, -1, txSender.From(), 10)}
+	contract.credit[txSender.From()] = 10
+
+	require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+	bonds := c.accountant.Bonds()
+	require.Len(t, bonds, 1)
+	require.Equal(t, big.NewInt(10), bonds[0].Claimed)
+	require.Zero(t, bonds[0].Claimable.Sign())
+	require.Zero(t, bonds[0].ExpectedProfit.Sign())
+}
+
+var gameAddr = common.HexToAddress("0x1234")
+
+func testClaim(idx int, parentIdx int, claimant common.Address, bond int64) faultTypes.Claim {
+	return faultTypes.Claim{
+		ClaimData:           faultTypes.ClaimData{Bond: big.NewInt(bond), Position: faultTypes.NewPositionFromGIndex(big.NewInt(int64(idx + 1)))},
+		Claimant:            claimant,
+		ContractIndex:       idx,
+		ParentContractIndex: parentIdx,
+	}
+}
+
+func newTestAccountant(t *testing.T) (*BondAccountant, *stubAccountingMetrics, *stubBondContract) {
+	m := &stubAccountingMetrics{}
+	contract := &stubBondContract{status: types.GameStatusInProgress, credit: make(map[common.Address]int64)}
+	creator := func(game types.GameMetadata) (BondContract, error) {
+		return contract, nil
+	}
+	return NewBondAccountant(testlog.Logger(t, log.LvlDebug), m, newTestStore(t), creator, ourClaimant), m, contract
+}
+
+func newTestStore(t *testing.T) *BondStore {
+	store, err := NewBondStore(t.TempDir())
+	require.NoError(t, err)
+	return store
+}
+
+type stubAccountingMetrics struct {
+	totals BondTotals
+}
+
+func (m *stubAccountingMetrics) RecordBondAccounting(posted, claimable, claimed, expectedProfit *big.Int) {
+	m.totals = BondTotals{Posted: posted, Claimable: claimable, Claimed: claimed, ExpectedProfit: expectedProfit}
+}
@@ -7,7 +7,9 @@ import (
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -15,6 +17,7 @@ import (
 
 type TxSender interface {
 	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
+	SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidate) []error
 }
 
 type BondClaimMetrics interface {
@@ -24,6 +27,8 @@ type BondClaimMetrics interface {
 type BondContract interface {
 	GetCredit(ctx context.Context, recipient common.Address) (*big.Int, types.GameStatus, error)
 	ClaimCreditTx(ctx context.Context, recipient common.Address) (txmgr.TxCandidate, error)
+	GetClaimCountAndCredits(ctx context.Context, block rpcblock.Block, recipients ...common.Address) (uint64, types.GameStatus, []*big.Int, error)
+	GetAllClaims(ctx context.Context, block rpcblock.Block) ([]faultTypes.Claim, error)
 }
 
 type BondContractCreator func(game types.GameMetadata) (BondContract, error)
@@ -33,64 +38,100 @@ type Claimer struct {
 	metrics         BondClaimMetrics
 	contractCreator BondContractCreator
 	txSender        TxSender
+	accountant      *BondAccountant
 	claimants       []common.Address
 }
 
 var _ BondClaimer = (*Claimer)(nil)
 
-func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContractCreator, txSender TxSender, claimants ...common.Address) *Claimer {
+// NewBondClaimer creates a Claimer. The accountant is optional and, if set, is updated with the bonds of each game
+// before claiming and with the credit claimed.
+func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContractCreator, txSender TxSender, accountant *BondAccountant, claimants ...common.Address) *Claimer {
 	return &Claimer{
 		logger:          l,
 		metrics:         m,
 		contractCreator: contractCreator,
 		txSender:        txSender,
+		accountant:      accountant,
 		claimants:       claimants,
 	}
 }
 
+// ClaimBonds claims the credit of each claimant in the games. The claims across all games are sent as a single batch
+// so the transaction manager can assign sequential nonces without waiting for each claim to be included.
 func (c *Claimer) ClaimBonds(ctx context.Context, games []types.GameMetadata) (err error) {
+	if c.accountant != nil {
+		err = c.accountant.Account(ctx, games)
+	}
+	var claims []creditClaim
 	for _, game := range games {
 		for _, claimant := range c.claimants {
-			err = errors.Join(err, c.claimBond(ctx, game, claimant))
+			claim, ok, claimErr := c.prepareClaim(ctx, game, claimant)
+			if claimErr != nil {
+				err = errors.Join(err, claimErr)
+			} else if ok {
+				claims = append(claims, claim)
+			}
+		}
+	}
+	if len(claims) == 0 {
+		return err
+	}
+	txs := make([]txmgr.TxCandidate, len(claims))
+	for i, claim := range claims {
+		txs[i] = claim.tx
+	}
+	for i, sendErr := range c.txSender.SendAndWaitDetailed("claim credit", txs...) {
+		claim := claims[i]
+		if sendErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to claim credit from game %v for %v: %w", claim.game, claim.addr, sendErr))
+			continue
+		}
+		c.metrics.RecordBondClaimed(claim.credit.Uint64())
+		if c.accountant != nil {
+			c.accountant.RecordClaimed(claim.game, claim.credit)
 		}
 	}
 	return err
 }
 
-func (c *Claimer) claimBond(ctx context.Context, game types.GameMetadata, addr common.Address) error {
+type creditClaim struct {
+	game   common.Address
+	addr   common.Address
+	credit *big.Int
+	tx     txmgr.TxCandidate
+}
+
+// prepareClaim creates the transaction to claim the credit of addr in the game. Returns false if there is no credit
+// that can be claimed yet.
+func (c *Claimer) prepareClaim(ctx context.Context, game types.GameMetadata, addr common.Address) (creditClaim, bool, error) {
 	c.logger.Debug("Attempting to claim bonds for", "game", game.Proxy, "addr", addr)
 
 	contract, err := c.contractCreator(game)
 	if err != nil {
-		return fmt.Errorf("failed to create bond contract: %w", err)
+		return creditClaim{}, false, fmt.Errorf("failed to create bond contract: %w", err)
 	}
 
 	credit, status, err := contract.GetCredit(ctx, addr)
 	if err != nil {
-		return fmt.Errorf("failed to get credit: %w", err)
+		return creditClaim{}, false, fmt.Errorf("failed to get credit: %w", err)
 	}
 
 	if status == types.GameStatusInProgress {
 		c.logger.Debug("Not claiming credit from in progress game", "game", game.Proxy, "addr", addr, "status", status)
-		return nil
+		return creditClaim{}, false, nil
 	}
 	if credit.Cmp(big.NewInt(0)) == 0 {
 		c.logger.Debug("No credit to claim", "game", game.Proxy, "addr", addr)
-		return nil
+		return creditClaim{}, false, nil
 	}
 
 	candidate, err := contract.ClaimCreditTx(ctx, addr)
 	if errors.Is(err, contracts.ErrSimulationFailed) {
 		c.logger.Debug("Credit still locked", "game", game.Proxy, "addr", addr)
-		return nil
+		return creditClaim{}, false, nil
 	} else if err != nil {
-		return fmt.Errorf("failed to create credit claim tx: %w", err)
+		return creditClaim{}, false, fmt.Errorf("failed to create credit claim tx: %w", err)
 	}
-
-	if err = c.txSender.SendAndWaitSimple("claim credit", candidate); err != nil {
-		return fmt.Errorf("failed to claim credit: %w", err)
-	}
-
-	c.metrics.RecordBondClaimed(credit.Uint64())
-	return nil
+	return creditClaim{game: game.Proxy, addr: addr, credit: credit, tx: candidate}, true, nil
 }
@@ -8,7 +8,9 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,6 +30,7 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 		err := c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}, {Proxy: gameAddr}, {Proxy: gameAddr}})
 		require.NoError(t, err)
 		require.Equal(t, 3, txSender.sends)
+		require.Equal(t, 1, txSender.batches, "should batch claims across games")
 		require.Equal(t, 3, m.RecordBondClaimedCalls)
 	})
 
@@ -122,7 +125,7 @@ func newTestClaimer(t *testing.T, claimants ...common.Address) (*Claimer, *mockC
 	if len(claimants) == 0 {
 		claimants = []common.Address{txSender.From()}
 	}
-	c := NewBondClaimer(logger, m, contractCreator, txSender, claimants...)
+	c := NewBondClaimer(logger, m, contractCreator, txSender, nil, claimants...)
 	return c, m, bondContract, txSender
 }
 
@@ -136,6 +139,7 @@ func (m *mockClaimMetrics) RecordBondClaimed(amount uint64) {
 
 type mockTxSender struct {
 	sends      int
+	batches    int
 	sendFails  bool
 	statusFail bool
 }
@@ -144,21 +148,45 @@ func (s *mockTxSender) From() common.Address {
 	return common.HexToAddress("0x33333")
 }
 
-func (s *mockTxSender) SendAndWaitSimple(_ string, _ ...txmgr.TxCandidate) error {
-	s.sends++
-	if s.sendFails {
-		return mockTxMgrSendError
-	}
-	if s.statusFail {
-		return errors.New("transaction reverted")
+func (s *mockTxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
+	return errors.Join(s.SendAndWaitDetailed(txPurpose, txs...)...)
+}
+
+func (s *mockTxSender) SendAndWaitDetailed(_ string, txs ...txmgr.TxCandidate) []error {
+	s.batches++
+	errs := make([]error, len(txs))
+	for i := range txs {
+		s.sends++
+		if s.sendFails {
+			errs[i] = mockTxMgrSendError
+		} else if s.statusFail {
+			errs[i] = errors.New("transaction reverted")
+		}
 	}
-	return nil
+	return errs
 }
 
 type stubBondContract struct {
 	credit               map[common.Address]int64
 	status               types.GameStatus
 	claimSimulationFails bool
+	claims               []faultTypes.Claim
+	claimsLoads          int
+	creditLoads          int
+}
+
+func (s *stubBondContract) GetClaimCountAndCredits(_ context.Context, _ rpcblock.Block, recipients ...common.Address) (uint64, types.GameStatus, []*big.Int, error) {
+	s.creditLoads++
+	credits := make([]*big.Int, 0, len(recipients))
+	for _, recipient := range recipients {
+		credits = append(credits, big.NewInt(s.credit[recipient]))
+	}
+	return uint64(len(s.claims)), s.status, credits, nil
+}
+
+func (s *stubBondContract) GetAllClaims(_ context.Context, _ rpcblock.Block) ([]faultTypes.Claim, error) {
+	s.claimsLoads++
+	return s.claims, nil
 }
 
 func (s *stubBondContract) GetCredit(_ context.Context, addr common.Address) (*big.Int, types.GameStatus, error) {
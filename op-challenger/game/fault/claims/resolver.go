@@ -0,0 +1,229 @@
+package claims
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type ResolverMetrics interface {
+	RecordClaimsResolved(count int)
+	RecordGameResolved()
+}
+
+// ResolvableContract is a game contract that claims and the game itself can be resolved on.
+type ResolvableContract interface {
+	GetStatus(ctx context.Context) (types.GameStatus, error)
+	GetMaxClockDuration(ctx context.Context) (time.Duration, error)
+	GetAllClaims(ctx context.Context, block rpcblock.Block) ([]faultTypes.Claim, error)
+	IsResolved(ctx context.Context, block rpcblock.Block, claims ...faultTypes.Claim) ([]bool, error)
+	ResolveClaimTx(claimIdx uint64) (txmgr.TxCandidate, error)
+	CallResolve(ctx context.Context) (types.GameStatus, error)
+	ResolveTx() (txmgr.TxCandidate, error)
+}
+
+// ResolutionPolicy decides whether the claims and games are resolved by the agent of each game as it is progressed,
+// or in batches across games by the Resolver.
+type ResolutionPolicy interface {
+	// DeferResolution returns true if claims and games are resolved in batches across games by the Resolver rather
+	// than by the game's agent.
+	DeferResolution() bool
+}
+
+// BatchResolution is a ResolutionPolicy that defers resolution to the Resolver if true.
+type BatchResolution bool
+
+func (b BatchResolution) DeferResolution() bool {
+	return bool(b)
+}
+
+// Resolver resolves the claims of games once their clocks expire and then resolves the games, so bonds can be claimed
+// without relying on manual scripts. Claims are resolved in dependency order: each round resolves the claims whose
+// children are all resolved, batched across all games.
+type Resolver struct {
+	logger          log.Logger
+	metrics         ResolverMetrics
+	clock           faultTypes.ClockReader
+	contractCreator BondContractCreator
+	txSender        TxSender
+	self            common.Address
+}
+
+// NewResolver creates a Resolver. Only games that self, the address the resolution transactions are sent from,
+// participated in are resolved, so it doesn't pay for the resolution of games whose bonds are all claimed by others.
+func NewResolver(logger log.Logger, m ResolverMetrics, clock faultTypes.ClockReader, contractCreator BondContractCreator, txSender TxSender, self common.Address) *Resolver {
+	return &Resolver{
+		logger:          logger,
+		metrics:         m,
+		clock:           clock,
+		contractCreator: contractCreator,
+		txSender:        txSender,
+		self:            self,
+	}
+}
+
+type resolution struct {
+	game   common.Address
+	claims []uint64
+	// resolveGame is true if the transaction resolves the game rather than a claim.
+	resolveGame bool
+}
+
+// ResolveGames resolves as many claims and games as possible, sending a batch of transactions across all games for
+// each level of the game trees until nothing further can be resolved.
+func (r *Resolver) ResolveGames(ctx context.Context, games []types.GameMetadata) error {
+	contracts := make(map[common.Address]ResolvableContract)
+	for _, game := range games {
+		contract, err := r.contractCreator(game)
+		if err != nil {
+			return fmt.Errorf("failed to create contract for game %v: %w", game.Proxy, err)
+		}
+		resolvable, ok := contract.(ResolvableContract)
+		if !ok {
+			r.logger.Debug("Skipping resolution of unsupported game", "game", game.Proxy, "type", game.GameType)
+			continue
+		}
+		contracts[game.Proxy] = resolvable
+	}
+	var err error
+	for {
+		var txs []txmgr.TxCandidate
+		var resolutions []resolution
+		for _, game := range games {
+			contract, ok := contracts[game.Proxy]
+			if !ok {
+				continue
+			}
+			gameTxs, res, gameErr := r.nextResolution(ctx, game.Proxy, contract)
+			if gameErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to check resolution of game %v: %w", game.Proxy, gameErr))
+				delete(contracts, game.Proxy)
+				continue
+			}
+			if len(gameTxs) == 0 {
+				// Nothing can be resolved so skip the game in later rounds
+				delete(contracts, game.Proxy)
+				continue
+			}
+			txs = append(txs, gameTxs...)
+			resolutions = append(resolutions, res)
+		}
+		if len(txs) == 0 {
+			return err
+		}
+		r.logger.Info("Resolving games", "games", len(resolutions), "txs", len(txs))
+		errs := r.txSender.SendAndWaitDetailed("resolve", txs...)
+		offset := 0
+		for _, res := range resolutions {
+			count := len(res.claims)
+			if res.resolveGame {
+				count = 1
+			}
+			if sendErr := errors.Join(errs[offset : offset+count]...); sendErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to resolve game %v: %w", res.game, sendErr))
+				// Stop resolving the game as later rounds depend on these transactions
+				delete(contracts, res.game)
+			} else if res.resolveGame {
+				r.logger.Info("Resolved game", "game", res.game)
+				r.metrics.RecordGameResolved()
+				delete(contracts, res.game)
+			} else {
+				r.metrics.RecordClaimsResolved(len(res.claims))
+			}
+			offset += count
+		}
+	}
+}
+
+// nextResolution returns the transactions to resolve the claims of the game whose children are all resolved, or to
+// resolve the game itself once the root claim is resolved.
+func (r *Resolver) nextResolution(ctx context.Context, addr common.Address, contract ResolvableContract) ([]txmgr.TxCandidate, resolution, error) {
+	res := resolution{game: addr}
+	status, err := contract.GetStatus(ctx)
+	if err != nil {
+		return nil, res, fmt.Errorf("failed to get status: %w", err)
+	}
+	if status != types.GameStatusInProgress {
+		return nil, res, nil
+	}
+	claims, err := contract.GetAllClaims(ctx, rpcblock.Latest)
+	if err != nil {
+		return nil, res, fmt.Errorf("failed to load claims: %w", err)
+	}
+	if !r.participated(claims) {
+		r.logger.Debug("Skipping resolution of game without own claims", "game", addr)
+		return nil, res, nil
+	}
+	resolved, err := contract.IsResolved(ctx, rpcblock.Latest, claims...)
+	if err != nil {
+		return nil, res, fmt.Errorf("failed to check resolved claims: %w", err)
+	}
+	if len(resolved) > 0 && resolved[0] {
+		if _, err := contract.CallResolve(ctx); err != nil {
+			r.logger.Debug("Game not resolvable", "game", addr, "err", err)
+			return nil, res, nil
+		}
+		tx, err := contract.ResolveTx()
+		if err != nil {
+			return nil, res, fmt.Errorf("failed to create resolve tx: %w", err)
+		}
+		res.resolveGame = true
+		return []txmgr.TxCandidate{tx}, res, nil
+	}
+	maxClockDuration, err := contract.GetMaxClockDuration(ctx)
+	if err != nil {
+		return nil, res, fmt.Errorf("failed to get max clock duration: %w", err)
+	}
+	res.claims = resolvableClaims(claims, resolved, r.clock.Now(), maxClockDuration)
+	txs := make([]txmgr.TxCandidate, 0, len(res.claims))
+	for _, idx := range res.claims {
+		tx, err := contract.ResolveClaimTx(idx)
+		if err != nil {
+			return nil, res, fmt.Errorf("failed to create resolve claim tx: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+	return txs, res, nil
+}
+
+// participated returns true if the resolver's own address posted a claim in the game or countered a claim with a step.
+func (r *Resolver) participated(claims []faultTypes.Claim) bool {
+	return slices.ContainsFunc(claims, func(claim faultTypes.Claim) bool {
+		return claim.Claimant == r.self || claim.CounteredBy == r.self
+	})
+}
+
+// resolvableClaims returns the indices of the unresolved claims whose clocks have expired and whose children are all
+// resolved, so they can be resolved in any order.
+func resolvableClaims(claims []faultTypes.Claim, resolved []bool, now time.Time, maxClockDuration time.Duration) []uint64 {
+	blocked := make([]bool, len(claims))
+	for i, claim := range claims {
+		if !claim.IsRootPosition() && !resolved[i] {
+			blocked[claim.ParentContractIndex] = true
+		}
+	}
+	var resolvable []uint64
+	for i, claim := range claims {
+		if resolved[i] || blocked[i] {
+			continue
+		}
+		var parent faultTypes.Claim
+		if !claim.IsRootPosition() {
+			parent = claims[claim.ParentContractIndex]
+		}
+		if faultTypes.ChessClock(now, claim, parent) <= maxClockDuration {
+			continue
+		}
+		resolvable = append(resolvable, uint64(i))
+	}
+	return resolvable
+}
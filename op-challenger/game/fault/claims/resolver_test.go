@@ -0,0 +1,299 @@
+package claims
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	resolverNow      = time.Unix(10_000, 0)
+	resolverClaimant = common.Address{0xaa}
+	maxClockDuration = time.Hour
+	resolveGameData  = []byte("resolve")
+)
+
+func TestResolver_ResolvesInDependencyOrder(t *testing.T) {
+	game1 := common.Address{0x01}
+	game2 := common.Address{0x02}
+	resolver, contracts, sender, m := setupTestResolver(t, game1, game2)
+	contracts[game2].claims = contracts[game2].claims[:2]
+
+	require.NoError(t, resolver.ResolveGames(context.Background(), gamesFor(game1, game2)))
+	require.Equal(t, [][]resolvedTx{
+		{{game1, 2}, {game1, 3}, {game2, 1}},
+		{{game1, 1}, {game2, 0}},
+		{{game1, 0}, {game2, -1}},
+		{{game1, -1}},
+	}, sender.batches)
+	require.Equal(t, types.GameStatusDefenderWon, contracts[game1].status)
+	require.Equal(t, types.GameStatusDefenderWon, contracts[game2].status)
+	require.Equal(t, 6, m.claimsResolved)
+	require.Equal(t, 2, m.gamesResolved)
+}
+
+func TestResolver_SkipClaimsWithClockRunning(t *testing.T) {
+	game := common.Address{0x01}
+	resolver, contracts, sender, m := setupTestResolver(t, game)
+	contracts[game].claims[3].Clock.Timestamp = resolverNow.Add(-time.Minute)
+
+	require.NoError(t, resolver.ResolveGames(context.Background(), gamesFor(game)))
+	// Claim 3 blocks the root claim from being resolved
+	require.Equal(t, [][]resolvedTx{{{game, 2}}, {{game, 1}}}, sender.batches)
+	require.Equal(t, types.GameStatusInProgress, contracts[game].status)
+	require.Equal(t, 2, m.claimsResolved)
+	require.Zero(t, m.gamesResolved)
+}
+
+func TestResolver_SkipGamesWithoutClaimants(t *testing.T) {
+	game := common.Address{0x01}
+	resolver, contracts, sender, _ := setupTestResolver(t, game)
+	for i := range contracts[game].claims {
+		contracts[game].claims[i].Claimant = common.Address{0xbb}
+	}
+
+	require.NoError(t, resolver.ResolveGames(context.Background(), gamesFor(game)))
+	require.Empty(t, sender.batches)
+}
+
+func TestResolver_SkipGamesWithOnlyOtherClaimantsBonds(t *testing.T) {
+	game := common.Address{0x01}
+	resolver, contracts, sender, _ := setupTestResolver(t, game)
+	// Bonds of additional claimants are claimed for them, but they don't pay for the resolution of their games
+	for i := range contracts[game].claims {
+		contracts[game].claims[i].Claimant = common.Address{0xcc}
+	}
+	contracts[game].claims[1].CounteredBy = common.Address{0xcc}
+
+	require.NoError(t, resolver.ResolveGames(context.Background(), gamesFor(game)))
+	require.Empty(t, sender.batches)
+}
+
+func TestResolver_ResolveGamesWithOwnCounter(t *testing.T) {
+	game := common.Address{0x01}
+	resolver, contracts, sender, _ := setupTestResolver(t, game)
+	for i := range contracts[game].claims {
+		contracts[game].claims[i].Claimant = common.Address{0xbb}
+	}
+	contracts[game].claims[2].CounteredBy = resolverClaimant
+
+	require.NoError(t, resolver.ResolveGames(context.Background(), gamesFor(game)))
+	require.NotEmpty(t, sender.batches)
+}
+
+func TestResolver_SkipResolvedGames(t *testing.T) {
+	game := common.Address{0x01}
+	resolver, contracts, sender, _ := setupTestResolver(t, game)
+	contracts[game].status = types.GameStatusChallengerWon
+
+	require.NoError(t, resolver.ResolveGames(context.Background(), gamesFor(game)))
+	require.Empty(t, sender.batches)
+}
+
+func TestResolver_StopResolvingGameOnFailure(t *testing.T) {
+	game1 := common.Address{0x01}
+	game2 := common.Address{0x02}
+	resolver, _, sender, _ := setupTestResolver(t, game1, game2)
+	sender.failGame = game1
+
+	err := resolver.ResolveGames(context.Background(), gamesFor(game1, game2))
+	require.ErrorIs(t, err, errResolveFailed)
+	require.Equal(t, [][]resolvedTx{
+		{{game1, 2}, {game1, 3}, {game2, 2}, {game2, 3}},
+		{{game2, 1}},
+		{{game2, 0}},
+		{{game2, -1}},
+	}, sender.batches)
+}
+
+func TestResolver_SkipUnsupportedContracts(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	creator := func(game types.GameMetadata) (BondContract, error) {
+		return &stubBondContract{}, nil
+	}
+	sender := &stubResolveSender{}
+	resolver := NewResolver(logger, &stubResolverMetrics{}, clock.NewDeterministicClock(resolverNow), creator, sender, resolverClaimant)
+	require.NoError(t, resolver.ResolveGames(context.Background(), gamesFor(common.Address{0x01})))
+	require.Empty(t, sender.batches)
+}
+
+func TestResolvableClaims(t *testing.T) {
+	claims := newResolvableGame().claims
+	expired := resolverNow
+	t.Run("Leaves", func(t *testing.T) {
+		require.Equal(t, []uint64{2, 3}, resolvableClaims(claims, make([]bool, len(claims)), expired, maxClockDuration))
+	})
+
+	t.Run("ChildrenResolved", func(t *testing.T) {
+		require.Equal(t, []uint64{0}, resolvableClaims(claims, []bool{false, true, true, true}, expired, maxClockDuration))
+	})
+
+	t.Run("ClockNotExpired", func(t *testing.T) {
+		require.Empty(t, resolvableClaims(claims, make([]bool, len(claims)), resolverNow.Add(-2*time.Hour), maxClockDuration))
+	})
+}
+
+func gamesFor(addrs ...common.Address) []types.GameMetadata {
+	games := make([]types.GameMetadata, len(addrs))
+	for i, addr := range addrs {
+		games[i] = types.GameMetadata{Proxy: addr}
+	}
+	return games
+}
+
+func setupTestResolver(t *testing.T, addrs ...common.Address) (*Resolver, map[common.Address]*stubResolvableGame, *stubResolveSender, *stubResolverMetrics) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	games := make(map[common.Address]*stubResolvableGame)
+	for _, addr := range addrs {
+		game := newResolvableGame()
+		game.addr = addr
+		games[addr] = game
+	}
+	creator := func(game types.GameMetadata) (BondContract, error) {
+		return games[game.Proxy], nil
+	}
+	sender := &stubResolveSender{games: games}
+	m := &stubResolverMetrics{}
+	resolver := NewResolver(logger, m, clock.NewDeterministicClock(resolverNow), creator, sender, resolverClaimant)
+	return resolver, games, sender, m
+}
+
+// newResolvableGame creates a game with the tree 0 <- 1 <- 2 and 0 <- 3 where all clocks have expired.
+func newResolvableGame() *stubResolvableGame {
+	expired := faultTypes.Clock{Timestamp: resolverNow.Add(-2 * time.Hour)}
+	root := faultTypes.Claim{
+		ClaimData: faultTypes.ClaimData{Position: faultTypes.RootPosition},
+		Claimant:  resolverClaimant,
+		Clock:     expired,
+	}
+	child := func(idx int, parent faultTypes.Claim) faultTypes.Claim {
+		return faultTypes.Claim{
+			ClaimData:           faultTypes.ClaimData{Position: parent.Position.Attack()},
+			Claimant:            common.Address{0xbb},
+			Clock:               expired,
+			ContractIndex:       idx,
+			ParentContractIndex: parent.ContractIndex,
+		}
+	}
+	claim1 := child(1, root)
+	claim2 := child(2, claim1)
+	claim3 := child(3, root)
+	return &stubResolvableGame{
+		stubBondContract: stubBondContract{credit: make(map[common.Address]int64)},
+		status:           types.GameStatusInProgress,
+		claims:           []faultTypes.Claim{root, claim1, claim2, claim3},
+		resolved:         make(map[int]bool),
+	}
+}
+
+type stubResolvableGame struct {
+	stubBondContract
+	addr     common.Address
+	status   types.GameStatus
+	claims   []faultTypes.Claim
+	resolved map[int]bool
+}
+
+func (s *stubResolvableGame) GetStatus(_ context.Context) (types.GameStatus, error) {
+	return s.status, nil
+}
+
+func (s *stubResolvableGame) GetMaxClockDuration(_ context.Context) (time.Duration, error) {
+	return maxClockDuration, nil
+}
+
+func (s *stubResolvableGame) GetAllClaims(_ context.Context, _ rpcblock.Block) ([]faultTypes.Claim, error) {
+	return s.claims, nil
+}
+
+func (s *stubResolvableGame) IsResolved(_ context.Context, _ rpcblock.Block, claims ...faultTypes.Claim) ([]bool, error) {
+	resolved := make([]bool, len(claims))
+	for i, claim := range claims {
+		resolved[i] = s.resolved[claim.ContractIndex]
+	}
+	return resolved, nil
+}
+
+func (s *stubResolvableGame) ResolveClaimTx(claimIdx uint64) (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{To: &s.addr, TxData: new(big.Int).SetUint64(claimIdx).Bytes()}, nil
+}
+
+func (s *stubResolvableGame) CallResolve(_ context.Context) (types.GameStatus, error) {
+	if !s.resolved[0] {
+		return types.GameStatusInProgress, errors.New("root not resolved")
+	}
+	return types.GameStatusDefenderWon, nil
+}
+
+func (s *stubResolvableGame) ResolveTx() (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{To: &s.addr, TxData: resolveGameData}, nil
+}
+
+var _ ResolvableContract = (*stubResolvableGame)(nil)
+
+var errResolveFailed = errors.New("resolve failed")
+
+// resolvedTx is a transaction sent by the resolver. A claim index of -1 resolves the game.
+type resolvedTx struct {
+	game  common.Address
+	claim int
+}
+
+type stubResolveSender struct {
+	games    map[common.Address]*stubResolvableGame
+	failGame common.Address
+	batches  [][]resolvedTx
+}
+
+func (s *stubResolveSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
+	return errors.Join(s.SendAndWaitDetailed(txPurpose, txs...)...)
+}
+
+func (s *stubResolveSender) SendAndWaitDetailed(_ string, txs ...txmgr.TxCandidate) []error {
+	var batch []resolvedTx
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		game := s.games[*tx.To]
+		sent := resolvedTx{game: *tx.To, claim: -1}
+		if string(tx.TxData) != string(resolveGameData) {
+			sent.claim = int(new(big.Int).SetBytes(tx.TxData).Int64())
+		}
+		batch = append(batch, sent)
+		if *tx.To == s.failGame {
+			errs[i] = errResolveFailed
+			continue
+		}
+		if sent.claim < 0 {
+			game.status = types.GameStatusDefenderWon
+		} else {
+			game.resolved[sent.claim] = true
+		}
+	}
+	s.batches = append(s.batches, batch)
+	return errs
+}
+
+type stubResolverMetrics struct {
+	claimsResolved int
+	gamesResolved  int
+}
+
+func (s *stubResolverMetrics) RecordClaimsResolved(count int) {
+	s.claimsResolved += count
+}
+
+func (s *stubResolverMetrics) RecordGameResolved() {
+	s.gamesResolved++
+}
@@ -12,13 +12,18 @@ type BondClaimer interface {
 	ClaimBonds(ctx context.Context, games []types.GameMetadata) error
 }
 
+type GameResolver interface {
+	ResolveGames(ctx context.Context, games []types.GameMetadata) error
+}
+
 type BondClaimScheduler struct {
-	log     log.Logger
-	metrics BondClaimSchedulerMetrics
-	ch      chan schedulerMessage
-	claimer BondClaimer
-	cancel  func()
-	wg      sync.WaitGroup
+	log      log.Logger
+	metrics  BondClaimSchedulerMetrics
+	ch       chan schedulerMessage
+	resolver GameResolver
+	claimer  BondClaimer
+	cancel   func()
+	wg       sync.WaitGroup
 }
 
 type BondClaimSchedulerMetrics interface {
@@ -30,12 +35,15 @@ type schedulerMessage struct {
 	games       []types.GameMetadata
 }
 
-func NewBondClaimScheduler(logger log.Logger, metrics BondClaimSchedulerMetrics, claimer BondClaimer) *BondClaimScheduler {
+// NewBondClaimScheduler creates a BondClaimScheduler. The resolver is optional and, if set, resolves the games before
+// bonds are claimed from them.
+func NewBondClaimScheduler(logger log.Logger, metrics BondClaimSchedulerMetrics, resolver GameResolver, claimer BondClaimer) *BondClaimScheduler {
 	return &BondClaimScheduler{
-		log:     logger,
-		metrics: metrics,
-		ch:      make(chan schedulerMessage, 1),
-		claimer: claimer,
+		log:      logger,
+		metrics:  metrics,
+		ch:       make(chan schedulerMessage, 1),
+		resolver: resolver,
+		claimer:  claimer,
 	}
 }
 
@@ -59,6 +67,11 @@ func (s *BondClaimScheduler) run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case msg := <-s.ch:
+			if s.resolver != nil {
+				if err := s.resolver.ResolveGames(ctx, msg.games); err != nil {
+					s.log.Error("Failed to resolve games", "blockNumber", msg.blockNumber, "err", err)
+				}
+			}
 			if err := s.claimer.ClaimBonds(ctx, msg.games); err != nil {
 				s.metrics.RecordBondClaimFailed()
 				s.log.Error("Failed to claim bonds", "blockNumber", msg.blockNumber, "err", err)
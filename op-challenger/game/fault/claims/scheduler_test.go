@@ -3,6 +3,7 @@ package claims
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -72,11 +73,32 @@ func TestBondClaimScheduler_Schedule(t *testing.T) {
 	}
 }
 
+func TestBondClaimScheduler_ResolvesBeforeClaiming(t *testing.T) {
+	for _, resolveErr := range []error{nil, errors.New("boom")} {
+		resolveErr := resolveErr
+		t.Run(fmt.Sprintf("ResolveErr-%v", resolveErr), func(t *testing.T) {
+			logger := testlog.Logger(t, log.LvlInfo)
+			claimer := &stubClaimer{}
+			resolver := &stubResolver{claimer: claimer, resolveErr: resolveErr}
+			scheduler := NewBondClaimScheduler(logger, &stubMetrics{}, resolver, claimer)
+			scheduler.Start(context.Background())
+			defer scheduler.Close()
+
+			require.NoError(t, scheduler.Schedule(1, []types.GameMetadata{{}}))
+			require.Eventually(t, func() bool {
+				return claimer.claimCalls.Load() == 1
+			}, 10*time.Second, 10*time.Millisecond)
+			require.EqualValues(t, 1, resolver.resolveCalls.Load())
+			require.Zero(t, resolver.claimsBeforeResolve.Load(), "should resolve before claiming")
+		})
+	}
+}
+
 func setupTestBondClaimScheduler(t *testing.T) (*BondClaimScheduler, *stubMetrics, *stubClaimer) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	metrics := &stubMetrics{}
 	claimer := &stubClaimer{}
-	scheduler := NewBondClaimScheduler(logger, metrics, claimer)
+	scheduler := NewBondClaimScheduler(logger, metrics, nil, claimer)
 	return scheduler, metrics, claimer
 }
 
@@ -97,3 +119,16 @@ func (s *stubClaimer) ClaimBonds(ctx context.Context, games []types.GameMetadata
 	s.claimCalls.Add(1)
 	return s.claimErr
 }
+
+type stubResolver struct {
+	claimer             *stubClaimer
+	resolveErr          error
+	resolveCalls        atomic.Int64
+	claimsBeforeResolve atomic.Int64
+}
+
+func (s *stubResolver) ResolveGames(_ context.Context, _ []types.GameMetadata) error {
+	s.resolveCalls.Add(1)
+	s.claimsBeforeResolve.Store(s.claimer.claimCalls.Load())
+	return s.resolveErr
+}
@@ -0,0 +1,197 @@
+package claims
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const bondStoreFile = "bonds.jsonl"
+
+// minCompactRecords is the number of records the store file can hold before it is compacted, regardless of the
+// number of stored games.
+const minCompactRecords = 100
+
+// bondRecord is a single line of the store file, either the updated accounting of a game or the removal of a game.
+type bondRecord struct {
+	Bonds   *GameBonds      `json:"bonds,omitempty"`
+	Removed *common.Address `json:"removed,omitempty"`
+}
+
+// BondStore persists the bond accounting of games in a JSON lines file, so it survives restarts of the challenger.
+// Updates are appended to the file, which is compacted once it holds more than twice as many records as games.
+type BondStore struct {
+	mu      sync.Mutex
+	path    string
+	games   []GameBonds
+	records int
+}
+
+// NewBondStore creates a BondStore in dir, loading the accounting previously stored there, if any.
+func NewBondStore(dir string) (*BondStore, error) {
+	s := &BondStore{path: filepath.Join(dir, bondStoreFile)}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load bond accounting: %w", err)
+	}
+	return s, nil
+}
+
+func (s *BondStore) load() error {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var record bondRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("invalid record %v: %w", s.records, err)
+		}
+		s.records++
+		if record.Removed != nil {
+			s.remove(*record.Removed)
+		} else if record.Bonds != nil {
+			s.put(*record.Bonds)
+		}
+	}
+	return scanner.Err()
+}
+
+// Game returns the stored accounting of the game.
+func (s *BondStore) Game(game common.Address) (GameBonds, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.index(game); i >= 0 {
+		return s.games[i], true
+	}
+	return GameBonds{}, false
+}
+
+// Games returns the stored accounting of all games, in the order they were first stored.
+func (s *BondStore) Games() []GameBonds {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]GameBonds(nil), s.games...)
+}
+
+// Put stores the accounting of a game, replacing any previously stored accounting.
+func (s *BondStore) Put(bonds GameBonds) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(bonds)
+	return s.append(bondRecord{Bonds: &bonds})
+}
+
+// Claimed moves the amount of claimed credit of the game from claimable to claimed.
+func (s *BondStore) Claimed(game common.Address, amount *big.Int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.index(game)
+	if i < 0 {
+		return fmt.Errorf("no bond accounting for game %v", game)
+	}
+	bonds := s.games[i]
+	bonds.Claimed = new(big.Int).Add(bonds.Claimed, amount)
+	bonds.Claimable = new(big.Int).Sub(bonds.Claimable, amount)
+	if bonds.Claimable.Sign() < 0 {
+		bonds.Claimable = new(big.Int)
+	}
+	s.games[i] = bonds
+	return s.append(bondRecord{Bonds: &bonds})
+}
+
+// Prune removes the accounting of the games for which keep returns false.
+func (s *BondStore) Prune(keep func(bonds GameBonds) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	for _, bonds := range append([]GameBonds(nil), s.games...) {
+		if keep(bonds) {
+			continue
+		}
+		s.remove(bonds.Game)
+		err = errors.Join(err, s.append(bondRecord{Removed: &bonds.Game}))
+	}
+	return err
+}
+
+func (s *BondStore) put(bonds GameBonds) {
+	if i := s.index(bonds.Game); i >= 0 {
+		s.games[i] = bonds
+	} else {
+		s.games = append(s.games, bonds)
+	}
+}
+
+func (s *BondStore) remove(game common.Address) {
+	if i := s.index(game); i >= 0 {
+		s.games = append(s.games[:i], s.games[i+1:]...)
+	}
+}
+
+func (s *BondStore) index(game common.Address) int {
+	for i, bonds := range s.games {
+		if bonds.Game == game {
+			return i
+		}
+	}
+	return -1
+}
+
+// append writes the record to the end of the store file, compacting the file if it holds too many stale records.
+func (s *BondStore) append(record bondRecord) error {
+	if s.records >= max(2*len(s.games), minCompactRecords) {
+		return s.compact()
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return errors.Join(err, f.Close())
+	}
+	s.records++
+	return f.Close()
+}
+
+// compact replaces the store file with one holding a single record per stored game.
+func (s *BondStore) compact() error {
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for i := range s.games {
+		if err := enc.Encode(bondRecord{Bonds: &s.games[i]}); err != nil {
+			return errors.Join(err, f.Close())
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return errors.Join(err, f.Close())
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	s.records = len(s.games)
+	return nil
+}
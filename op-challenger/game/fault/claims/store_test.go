@@ -0,0 +1,60 @@
+package claims
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBondStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewBondStore(dir)
+	require.NoError(t, err)
+	require.Empty(t, store.Games())
+
+	game1 := GameBonds{Game: common.Address{0x01}, Status: types.GameStatusChallengerWon, ClaimCount: 3, Posted: big.NewInt(10), Claimable: big.NewInt(25), Claimed: new(big.Int), ExpectedProfit: big.NewInt(15)}
+	game2 := GameBonds{Game: common.Address{0x02}, Posted: big.NewInt(5), Claimable: new(big.Int), Claimed: new(big.Int), ExpectedProfit: big.NewInt(7)}
+	require.NoError(t, store.Put(game1))
+	require.NoError(t, store.Put(game2))
+	game2.ClaimCount = 4
+	require.NoError(t, store.Put(game2))
+	require.NoError(t, store.Claimed(game1.Game, big.NewInt(20)))
+	require.ErrorContains(t, store.Claimed(common.Address{0x03}, big.NewInt(1)), "no bond accounting")
+
+	game1.Claimable = big.NewInt(5)
+	game1.Claimed = big.NewInt(20)
+	require.Equal(t, []GameBonds{game1, game2}, store.Games())
+	actual, ok := store.Game(game2.Game)
+	require.True(t, ok)
+	require.Equal(t, game2, actual)
+
+	reloaded, err := NewBondStore(dir)
+	require.NoError(t, err)
+	require.Equal(t, []GameBonds{game1, game2}, reloaded.Games())
+
+	require.NoError(t, store.Prune(func(bonds GameBonds) bool { return bonds.Game != game1.Game }))
+	require.Equal(t, []GameBonds{game2}, store.Games())
+	reloaded, err = NewBondStore(dir)
+	require.NoError(t, err)
+	require.Equal(t, []GameBonds{game2}, reloaded.Games())
+}
+
+func TestBondStore_Compact(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewBondStore(dir)
+	require.NoError(t, err)
+	game := GameBonds{Game: common.Address{0x01}, Posted: big.NewInt(5), Claimable: new(big.Int), Claimed: new(big.Int), ExpectedProfit: new(big.Int)}
+	for i := 0; i < 3*minCompactRecords; i++ {
+		game.ClaimCount = uint64(i)
+		require.NoError(t, store.Put(game))
+	}
+	require.LessOrEqual(t, store.records, minCompactRecords)
+
+	reloaded, err := NewBondStore(dir)
+	require.NoError(t, err)
+	require.Equal(t, []GameBonds{game}, reloaded.Games())
+	require.Equal(t, store.records, reloaded.records)
+}
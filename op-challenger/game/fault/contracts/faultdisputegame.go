@@ -302,6 +302,32 @@ func (f *FaultDisputeGameContractLatest) GetCredits(ctx context.Context, block r
 	return credits, nil
 }
 
+// GetClaimCountAndCredits returns the number of claims, the status and the credit of each recipient in one batch.
+func (f *FaultDisputeGameContractLatest) GetClaimCountAndCredits(ctx context.Context, block rpcblock.Block, recipients ...common.Address) (uint64, gameTypes.GameStatus, []*big.Int, error) {
+	defer f.metrics.StartContractRequest("GetClaimCountAndCredits")()
+	calls := make([]batching.Call, 0, len(recipients)+2)
+	calls = append(calls, f.contract.Call(methodClaimCount), f.contract.Call(methodStatus))
+	for _, recipient := range recipients {
+		calls = append(calls, f.contract.Call(methodCredit, recipient))
+	}
+	results, err := f.multiCaller.Call(ctx, block, calls...)
+	if err != nil {
+		return 0, gameTypes.GameStatusInProgress, nil, fmt.Errorf("failed to retrieve claim count and credits: %w", err)
+	}
+	if len(results) != len(calls) {
+		return 0, gameTypes.GameStatusInProgress, nil, fmt.Errorf("expected %v results but got %v", len(calls), len(results))
+	}
+	status, err := gameTypes.GameStatusFromUint8(results[1].GetUint8(0))
+	if err != nil {
+		return 0, gameTypes.GameStatusInProgress, nil, fmt.Errorf("invalid game status: %w", err)
+	}
+	credits := make([]*big.Int, 0, len(recipients))
+	for _, result := range results[2:] {
+		credits = append(credits, result.GetBigInt(0))
+	}
+	return results[0].GetBigInt(0).Uint64(), status, credits, nil
+}
+
 func (f *FaultDisputeGameContractLatest) ClaimCreditTx(ctx context.Context, recipient common.Address) (txmgr.TxCandidate, error) {
 	defer f.metrics.StartContractRequest("ClaimCredit")()
 	call := f.contract.Call(methodClaimCredit, recipient)
@@ -612,6 +638,7 @@ type FaultDisputeGameContract interface {
 	GetCredit(ctx context.Context, recipient common.Address) (*big.Int, gameTypes.GameStatus, error)
 	GetRequiredBonds(ctx context.Context, block rpcblock.Block, positions ...*big.Int) ([]*big.Int, error)
 	GetCredits(ctx context.Context, block rpcblock.Block, recipients ...common.Address) ([]*big.Int, error)
+	GetClaimCountAndCredits(ctx context.Context, block rpcblock.Block, recipients ...common.Address) (uint64, gameTypes.GameStatus, []*big.Int, error)
 	ClaimCreditTx(ctx context.Context, recipient common.Address) (txmgr.TxCandidate, error)
 	GetRequiredBond(ctx context.Context, position types.Position) (*big.Int, error)
 	UpdateOracleTx(ctx context.Context, claimIdx uint64, data *types.PreimageOracleData) (txmgr.TxCandidate, error)
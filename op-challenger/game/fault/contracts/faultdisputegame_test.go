@@ -647,6 +647,33 @@ func TestFaultDisputeGame_GetCredits(t *testing.T) {
 	}
 }
 
+func TestFaultDisputeGame_GetClaimCountAndCredits(t *testing.T) {
+	for _, version := range versions {
+		version := version
+		t.Run(version.version, func(t *testing.T) {
+			stubRpc, game := setupFaultDisputeGameTest(t, version)
+
+			block := rpcblock.ByNumber(482)
+			addrs := []common.Address{{0x01}, {0x02}}
+			expected := []*big.Int{big.NewInt(1), big.NewInt(0)}
+			stubRpc.SetResponse(fdgAddr, methodClaimCount, block, nil, []interface{}{big.NewInt(7)})
+			stubRpc.SetResponse(fdgAddr, methodStatus, block, nil, []interface{}{types.GameStatusDefenderWon})
+			for i, addr := range addrs {
+				stubRpc.SetResponse(fdgAddr, methodCredit, block, []interface{}{addr}, []interface{}{expected[i]})
+			}
+
+			claimCount, status, credits, err := game.GetClaimCountAndCredits(context.Background(), block, addrs...)
+			require.NoError(t, err)
+			require.EqualValues(t, 7, claimCount)
+			require.Equal(t, types.GameStatusDefenderWon, status)
+			require.Len(t, credits, len(expected))
+			for i := range expected {
+				require.Zerof(t, expected[i].Cmp(credits[i]), "expected: %v actual: %v", expected[i], credits[i])
+			}
+		})
+	}
+}
+
 func TestFaultDisputeGame_ClaimCreditTx(t *testing.T) {
 	for _, version := range versions {
 		version := version
@@ -0,0 +1,87 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	methodNextOutputIndex = "nextOutputIndex"
+	methodGetL2Output     = "getL2Output"
+)
+
+// OutputProposal is an output root proposed to the L2OutputOracle.
+type OutputProposal struct {
+	Index         uint64
+	OutputRoot    common.Hash
+	Timestamp     uint64
+	L2BlockNumber uint64
+}
+
+type L2OutputOracleContract struct {
+	metrics     metrics.ContractMetricer
+	multiCaller *batching.MultiCaller
+	contract    *batching.BoundContract
+}
+
+func NewL2OutputOracleContract(metrics metrics.ContractMetricer, addr common.Address, caller *batching.MultiCaller) *L2OutputOracleContract {
+	contractAbi := snapshots.LoadL2OutputOracleABI()
+	return &L2OutputOracleContract{
+		metrics:     metrics,
+		multiCaller: caller,
+		contract:    batching.NewBoundContract(contractAbi, addr),
+	}
+}
+
+func (o *L2OutputOracleContract) Addr() common.Address {
+	return o.contract.Addr()
+}
+
+// NextOutputIndex returns the index the next output proposal will be stored at, which is the number of proposals.
+func (o *L2OutputOracleContract) NextOutputIndex(ctx context.Context, block rpcblock.Block) (uint64, error) {
+	defer o.metrics.StartContractRequest("NextOutputIndex")()
+	result, err := o.multiCaller.SingleCall(ctx, block, o.contract.Call(methodNextOutputIndex))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch next output index: %w", err)
+	}
+	return result.GetBigInt(0).Uint64(), nil
+}
+
+// GetOutputProposals returns the output proposals from index start (inclusive) to end (exclusive).
+func (o *L2OutputOracleContract) GetOutputProposals(ctx context.Context, block rpcblock.Block, start uint64, end uint64) ([]OutputProposal, error) {
+	defer o.metrics.StartContractRequest("GetOutputProposals")()
+	if end <= start {
+		return nil, nil
+	}
+	calls := make([]batching.Call, 0, end-start)
+	for i := start; i < end; i++ {
+		calls = append(calls, o.contract.Call(methodGetL2Output, new(big.Int).SetUint64(i)))
+	}
+	results, err := o.multiCaller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch output proposals: %w", err)
+	}
+	proposals := make([]OutputProposal, 0, len(results))
+	for i, result := range results {
+		var proposal struct {
+			OutputRoot    [32]byte
+			Timestamp     *big.Int
+			L2BlockNumber *big.Int
+		}
+		result.GetStruct(0, &proposal)
+		proposals = append(proposals, OutputProposal{
+			Index:         start + uint64(i),
+			OutputRoot:    proposal.OutputRoot,
+			Timestamp:     proposal.Timestamp.Uint64(),
+			L2BlockNumber: proposal.L2BlockNumber.Uint64(),
+		})
+	}
+	return proposals, nil
+}
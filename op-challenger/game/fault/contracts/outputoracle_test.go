@@ -0,0 +1,67 @@
+package contracts
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	outputOracleAddr = common.HexToAddress("0x3B8a5f1d2d8b8d4E5D5a6c2bA3F6c1c3f4b8a9e0")
+)
+
+func TestL2OutputOracle_NextOutputIndex(t *testing.T) {
+	stubRpc, oracle := setupL2OutputOracleTest(t)
+	block := rpcblock.ByNumber(482)
+	stubRpc.SetResponse(outputOracleAddr, methodNextOutputIndex, block, nil, []interface{}{big.NewInt(12)})
+
+	actual, err := oracle.NextOutputIndex(context.Background(), block)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), actual)
+}
+
+func TestL2OutputOracle_GetOutputProposals(t *testing.T) {
+	stubRpc, oracle := setupL2OutputOracleTest(t)
+	block := rpcblock.ByNumber(482)
+	expected := []OutputProposal{
+		{Index: 3, OutputRoot: common.Hash{0xaa}, Timestamp: 1000, L2BlockNumber: 300},
+		{Index: 4, OutputRoot: common.Hash{0xbb}, Timestamp: 2000, L2BlockNumber: 400},
+	}
+	for _, proposal := range expected {
+		stubRpc.SetResponse(outputOracleAddr, methodGetL2Output, block, []interface{}{new(big.Int).SetUint64(proposal.Index)}, []interface{}{
+			struct {
+				OutputRoot    [32]byte
+				Timestamp     *big.Int
+				L2BlockNumber *big.Int
+			}{
+				OutputRoot:    proposal.OutputRoot,
+				Timestamp:     new(big.Int).SetUint64(proposal.Timestamp),
+				L2BlockNumber: new(big.Int).SetUint64(proposal.L2BlockNumber),
+			},
+		})
+	}
+
+	actual, err := oracle.GetOutputProposals(context.Background(), block, 3, 5)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	actual, err = oracle.GetOutputProposals(context.Background(), block, 5, 5)
+	require.NoError(t, err)
+	require.Empty(t, actual)
+}
+
+func setupL2OutputOracleTest(t *testing.T) (*batchingTest.AbiBasedRpc, *L2OutputOracleContract) {
+	oracleAbi := snapshots.LoadL2OutputOracleABI()
+	stubRpc := batchingTest.NewAbiBasedRpc(t, outputOracleAddr, oracleAbi)
+	caller := batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize)
+	oracle := NewL2OutputOracleContract(contractMetrics.NoopContractMetrics, outputOracleAddr, caller)
+	return stubRpc, oracle
+}
@@ -0,0 +1,126 @@
+package fault
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Forecast returns whether the honest outcome of the game would win if no further moves were made, as of the last
+// time the game was acted on.
+func (a *Agent) Forecast() gameTypes.GameForecast {
+	return gameTypes.GameForecast(a.forecast.Load())
+}
+
+// recordForecast compares the status the game would resolve to, once the submitted actions of the agent are included,
+// against the honest outcome. If the honest outcome is at risk, operators have until the remaining chess clock time
+// expires to intervene. Once no clock has time remaining, the outcome can no longer change and the honest outcome is lost.
+func (a *Agent) recordForecast(game types.Game, agree bool, submitted []types.Action) {
+	honest := gameTypes.GameStatusChallengerWon
+	if agree {
+		honest = gameTypes.GameStatusDefenderWon
+	}
+	now := a.l1Clock.Now()
+	claims := withSubmittedActions(game.Claims(), submitted, now)
+	projected := forecastStatus(claims)
+	if projected == honest {
+		a.forecast.Store(uint32(gameTypes.ForecastHonestWinning))
+		return
+	}
+	remaining := remainingClock(claims, now, a.maxClockDuration)
+	if remaining <= 0 {
+		a.forecast.Store(uint32(gameTypes.ForecastHonestLost))
+		a.log.Error("Honest outcome lost, game will resolve incorrectly as no chess clock has time remaining",
+			"honest", honest, "projected", projected)
+		return
+	}
+	a.forecast.Store(uint32(gameTypes.ForecastHonestAtRisk))
+	a.log.Warn("Honest outcome at risk, game would resolve incorrectly if no further moves are made",
+		"honest", honest, "projected", projected, "remaining", remaining)
+}
+
+// withSubmittedActions returns the claims of the game as they will be once the submitted actions are included.
+// Moves add a claim to their parent and steps counter their parent.
+func withSubmittedActions(claims []types.Claim, submitted []types.Action, now time.Time) []types.Claim {
+	if len(submitted) == 0 {
+		return claims
+	}
+	claims = append([]types.Claim(nil), claims...)
+	for _, action := range submitted {
+		parentIdx := action.ParentClaim.ContractIndex
+		if parentIdx < 0 || parentIdx >= len(claims) {
+			continue
+		}
+		switch action.Type {
+		case types.ActionTypeMove:
+			parent := claims[parentIdx]
+			var grandparent types.Claim
+			if !parent.IsRootPosition() {
+				grandparent = claims[parent.ParentContractIndex]
+			}
+			pos := parent.Position.Defend()
+			if action.IsAttack {
+				pos = parent.Position.Attack()
+			}
+			claims = append(claims, types.Claim{
+				ClaimData:           types.ClaimData{Value: action.Value, Position: pos},
+				Clock:               types.NewClock(types.ChessClock(now, parent, grandparent), now),
+				ContractIndex:       len(claims),
+				ParentContractIndex: parentIdx,
+			})
+		case types.ActionTypeStep:
+			// Any non-zero address marks the claim as countered, the actual counter is our own address
+			if claims[parentIdx].CounteredBy == (common.Address{}) {
+				claims[parentIdx].CounteredBy = common.Address{0x01}
+			}
+		}
+	}
+	return claims
+}
+
+// forecastStatus returns the status the game would resolve to with the given claims. A claim is countered if it was
+// stepped against or if any of its children are uncountered, so the defender wins if the root claim is uncountered.
+func forecastStatus(claims []types.Claim) gameTypes.GameStatus {
+	if len(claims) == 0 {
+		return gameTypes.GameStatusInProgress
+	}
+	if counteredClaims(claims)[0] {
+		return gameTypes.GameStatusChallengerWon
+	}
+	return gameTypes.GameStatusDefenderWon
+}
+
+// counteredClaims returns whether each of the claims would be countered if the game were resolved with the claims.
+func counteredClaims(claims []types.Claim) []bool {
+	countered := make([]bool, len(claims))
+	for i := len(claims) - 1; i >= 0; i-- {
+		claim := claims[i]
+		if claim.CounteredBy != (common.Address{}) {
+			countered[i] = true
+		}
+		if !claim.IsRootPosition() && !countered[i] {
+			countered[claim.ParentContractIndex] = true
+		}
+	}
+	return countered
+}
+
+// remainingClock returns the most chess clock time remaining to counter any claim that isn't countered yet, i.e. how
+// long the outcome of the game can still be changed. Returns zero or less if every such clock has expired.
+func remainingClock(claims []types.Claim, now time.Time, maxClockDuration time.Duration) time.Duration {
+	countered := counteredClaims(claims)
+	var remaining time.Duration
+	for i, claim := range claims {
+		if countered[i] {
+			continue
+		}
+		var parent types.Claim
+		if !claim.IsRootPosition() {
+			parent = claims[claim.ParentContractIndex]
+		}
+		remaining = max(remaining, maxClockDuration-types.ChessClock(now, claim, parent))
+	}
+	return remaining
+}
@@ -0,0 +1,126 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastStatus(t *testing.T) {
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+
+	t.Run("NoClaims", func(t *testing.T) {
+		require.Equal(t, gameTypes.GameStatusInProgress, forecastStatus(nil))
+	})
+
+	t.Run("UncounteredRoot", func(t *testing.T) {
+		gameBuilder := claimBuilder.GameBuilder()
+		require.Equal(t, gameTypes.GameStatusDefenderWon, forecastStatus(gameBuilder.Game.Claims()))
+	})
+
+	t.Run("CounteredRoot", func(t *testing.T) {
+		gameBuilder := claimBuilder.GameBuilder()
+		gameBuilder.Seq().Attack()
+		require.Equal(t, gameTypes.GameStatusChallengerWon, forecastStatus(gameBuilder.Game.Claims()))
+	})
+
+	t.Run("CounterCountered", func(t *testing.T) {
+		gameBuilder := claimBuilder.GameBuilder()
+		gameBuilder.Seq().Attack().Attack()
+		require.Equal(t, gameTypes.GameStatusDefenderWon, forecastStatus(gameBuilder.Game.Claims()))
+	})
+
+	t.Run("AnyUncounteredChildCounters", func(t *testing.T) {
+		gameBuilder := claimBuilder.GameBuilder()
+		root := gameBuilder.Seq()
+		root.Attack().Attack()
+		root.Attack(test.WithValue(common.Hash{0xaa}))
+		require.Equal(t, gameTypes.GameStatusChallengerWon, forecastStatus(gameBuilder.Game.Claims()))
+	})
+
+	t.Run("SteppedClaim", func(t *testing.T) {
+		gameBuilder := claimBuilder.GameBuilder()
+		gameBuilder.Seq().Attack().Attack()
+		claims := gameBuilder.Game.Claims()
+		claims[2].CounteredBy = common.Address{0xaa}
+		require.Equal(t, gameTypes.GameStatusChallengerWon, forecastStatus(claims))
+	})
+}
+
+func TestRecordForecast(t *testing.T) {
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	setup := func(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
+		agent, claimLoader, responder := setupTestAgent(t)
+		responder.callResolveErr = errors.New("game is not resolvable")
+		responder.callResolveClaimErr = errors.New("claim is not resolvable")
+		return agent, claimLoader, responder
+	}
+
+	t.Run("HonestWinning", func(t *testing.T) {
+		agent, claimLoader, _ := setup(t)
+		require.Equal(t, gameTypes.ForecastUnknown, agent.Forecast(), "unknown before acting")
+		gameBuilder := claimBuilder.GameBuilder(test.WithClock(l1Time.Add(-time.Minute), 0))
+		claimLoader.claims = gameBuilder.Game.Claims()
+		require.NoError(t, agent.Act(context.Background()))
+		require.Equal(t, gameTypes.ForecastHonestWinning, agent.Forecast())
+	})
+
+	t.Run("IncludesSubmittedActions", func(t *testing.T) {
+		agent, claimLoader, responder := setup(t)
+		gameBuilder := claimBuilder.GameBuilder(test.WithClock(l1Time.Add(-time.Minute), 0))
+		gameBuilder.Seq().Attack(test.WithValue(common.Hash{0xaa}), test.WithClock(l1Time.Add(-time.Minute), 0))
+		claimLoader.claims = gameBuilder.Game.Claims()
+		require.NoError(t, agent.Act(context.Background()))
+		require.Equal(t, 1, responder.performActionCount)
+		// The honest root claim is countered until the counter submitted by the agent is included
+		require.Equal(t, gameTypes.ForecastHonestWinning, agent.Forecast())
+
+		// The pending counter is still taken into account while it isn't included
+		require.NoError(t, agent.Act(context.Background()))
+		require.Equal(t, 1, responder.performActionCount)
+		require.Equal(t, gameTypes.ForecastHonestWinning, agent.Forecast())
+	})
+
+	t.Run("HonestAtRiskWhenActionFails", func(t *testing.T) {
+		agent, claimLoader, responder := setup(t)
+		responder.performActionErr = errors.New("boom")
+		gameBuilder := claimBuilder.GameBuilder(test.WithClock(l1Time.Add(-time.Minute), 0))
+		gameBuilder.Seq().Attack(test.WithValue(common.Hash{0xaa}), test.WithClock(l1Time.Add(-time.Minute), 0))
+		claimLoader.claims = gameBuilder.Game.Claims()
+		require.NoError(t, agent.Act(context.Background()))
+		require.Equal(t, gameTypes.ForecastHonestAtRisk, agent.Forecast())
+	})
+
+	t.Run("HonestLostWhenClockExpired", func(t *testing.T) {
+		agent, claimLoader, responder := setup(t)
+		responder.performActionErr = errors.New("boom")
+		gameBuilder := claimBuilder.GameBuilder(test.WithClock(l1Time.Add(-5*time.Minute), 0))
+		gameBuilder.Seq().Attack(test.WithValue(common.Hash{0xaa}), test.WithClock(l1Time.Add(-4*time.Minute), 0))
+		claimLoader.claims = gameBuilder.Game.Claims()
+		require.NoError(t, agent.Act(context.Background()))
+		require.Equal(t, gameTypes.ForecastHonestLost, agent.Forecast())
+	})
+}
+
+func TestRemainingClock(t *testing.T) {
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	gameBuilder := claimBuilder.GameBuilder(test.WithClock(l1Time.Add(-3*time.Minute), 0))
+	gameBuilder.Seq().
+		Attack(test.WithValue(common.Hash{0xaa}), test.WithClock(l1Time.Add(-2*time.Minute), 0)).
+		Attack(test.WithClock(l1Time.Add(-time.Minute), time.Minute))
+	// Only the uncountered leaf claim can still be countered, using the remaining clock of the team disagreeing with it
+	require.Equal(t, 6*time.Minute, remainingClock(gameBuilder.Game.Claims(), l1Time, 7*time.Minute))
+	require.Zero(t, remainingClock(gameBuilder.Game.Claims(), l1Time, time.Minute))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
@@ -34,6 +35,7 @@ type SyncValidator interface {
 
 type L1HeaderSource interface {
 	HeaderByHash(context.Context, common.Hash) (*gethTypes.Header, error)
+	HeaderByNumber(context.Context, *big.Int) (*gethTypes.Header, error)
 }
 
 type TxSender interface {
@@ -49,6 +51,10 @@ type GamePlayer struct {
 	prestateValidators []Validator
 	status             gameTypes.GameStatus
 	gameL1Head         eth.BlockID
+	clockExpiry        func() time.Time
+	forecast           func() gameTypes.GameForecast
+	policy             GamePolicy
+	state              *GameStateStore
 }
 
 type GameContract interface {
@@ -80,6 +86,9 @@ func NewGamePlayer(
 	validators []Validator,
 	creator resourceCreator,
 	l1HeaderSource L1HeaderSource,
+	maxLargePreimageBaseFee *big.Int,
+	policy *ParticipationPolicy,
+	resolution claims.ResolutionPolicy,
 	selective bool,
 	claimants []common.Address,
 ) (*GamePlayer, error) {
@@ -91,6 +100,10 @@ func NewGamePlayer(
 	}
 	if status != gameTypes.GameStatusInProgress {
 		logger.Info("Game already resolved", "status", status)
+		// The state of the game is no longer needed to resume it
+		if err := removeGameState(dir); err != nil {
+			logger.Error("Failed to remove game state", "err", err)
+		}
 		// Game is already complete so skip creating the trace provider, loading game inputs etc.
 		return &GamePlayer{
 			logger:             logger,
@@ -139,16 +152,29 @@ func NewGamePlayer(
 		return nil, fmt.Errorf("failed to load min large preimage size: %w", err)
 	}
 	direct := preimages.NewDirectPreimageUploader(logger, txSender, loader)
-	large := preimages.NewLargePreimageUploader(logger, l1Clock, txSender, oracle)
+	large := preimages.NewLargePreimageUploader(logger, l1Clock, txSender, oracle, l1HeaderSource, maxLargePreimageBaseFee)
 	uploader := preimages.NewSplitPreimageUploader(direct, large, minLargePreimageSize)
 	responder, err := responder.NewFaultResponder(logger, txSender, loader, uploader, oracle)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
 
-	agent := NewAgent(m, systemClock, l1Clock, loader, gameDepth, maxClockDuration, accessor, responder, logger, selective, claimants)
+	state, err := NewGameStateStore(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the game state: %w", err)
+	}
+	if claimsSeen := state.ClaimsSeen(); claimsSeen > 0 {
+		logger.Info("Resuming game", "claimsSeen", claimsSeen)
+	}
+
+	gamePolicy := policy.ForGame(addr)
+	agent := NewAgent(m, systemClock, l1Clock, loader, gameDepth, maxClockDuration, accessor, responder, state, gamePolicy, resolution, logger, selective, claimants)
 	return &GamePlayer{
 		act:                agent.Act,
+		clockExpiry:        agent.ClockExpiry,
+		forecast:           agent.Forecast,
+		policy:             gamePolicy,
+		state:              state,
 		loader:             loader,
 		logger:             logger,
 		status:             status,
@@ -171,6 +197,24 @@ func (g *GamePlayer) Status() gameTypes.GameStatus {
 	return g.status
 }
 
+// ClockExpiry returns the time at which the first chess clock of the game expires, as of the last time the game was
+// progressed. Returns the zero time if unknown.
+func (g *GamePlayer) ClockExpiry() time.Time {
+	if g.clockExpiry == nil {
+		return time.Time{}
+	}
+	return g.clockExpiry()
+}
+
+// Forecast returns whether the honest outcome of the game would win if no further moves were made, as of the last
+// time the game was progressed.
+func (g *GamePlayer) Forecast() gameTypes.GameForecast {
+	if g.forecast == nil {
+		return gameTypes.ForecastUnknown
+	}
+	return g.forecast()
+}
+
 func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 	if g.status != gameTypes.GameStatusInProgress {
 		// Game is already complete so don't try to perform further actions.
@@ -195,6 +239,14 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 	}
 	g.logGameStatus(ctx, status)
 	g.status = status
+	if status != gameTypes.GameStatusInProgress && g.policy != nil {
+		g.policy.Release()
+	}
+	if status != gameTypes.GameStatusInProgress && g.state != nil {
+		if err := g.state.Remove(); err != nil {
+			g.logger.Error("Failed to remove game state", "err", err)
+		}
+	}
 	return status
 }
 
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -94,6 +96,22 @@ func TestDoNotActOnCompleteGame(t *testing.T) {
 	}
 }
 
+func TestRemoveStateOfCompleteGame(t *testing.T) {
+	_, game, gameState, _ := setupProgressGameTest(t)
+	dir := t.TempDir()
+	state, err := NewGameStateStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, state.UpdateClaims([]faultTypes.Claim{{}}))
+	game.state = state
+
+	game.ProgressGame(context.Background())
+	require.FileExists(t, filepath.Join(dir, gameStateFile), "keeps state of game in progress")
+
+	gameState.status = types.GameStatusDefenderWon
+	game.ProgressGame(context.Background())
+	require.NoFileExists(t, filepath.Join(dir, gameStateFile))
+}
+
 func TestValidateLocalNodeSync(t *testing.T) {
 	_, game, gameState, syncValidator := setupProgressGameTest(t)
 
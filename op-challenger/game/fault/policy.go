@@ -0,0 +1,130 @@
+package fault
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GamePolicy decides how the challenger participates in a single game.
+type GamePolicy interface {
+	// OnlyContradicting returns true if the challenger only starts acting on games where the root claim contradicts
+	// its node. Games with a valid root claim are still defended once the root claim is challenged.
+	OnlyContradicting() bool
+	// UpdateBondsAtRisk records the total bond posted by the challenger in the game, and the bond of its moves that
+	// were sent but aren't included in the game yet. This replaces the bonds reserved for moves before.
+	UpdateBondsAtRisk(posted *big.Int, pending *big.Int)
+	// ReserveBond reserves the bond of a move, if it is within the maximum bonds at risk, and returns true if it was
+	// reserved. The bond must be released with ReleaseBond if the move is not sent.
+	ReserveBond(bond *big.Int) bool
+	// ReleaseBond releases the bond reserved for a move that was not sent.
+	ReleaseBond(bond *big.Int)
+	// Release removes the bonds of the game from the bonds at risk, once it is resolved.
+	Release()
+}
+
+// ParticipationPolicy restricts which games the challenger acts on so operators with limited capital can bound their
+// exposure. The bonds at risk are shared across all games.
+type ParticipationPolicy struct {
+	onlyContradicting bool
+	maxBondsAtRisk    *big.Int
+
+	mu          sync.Mutex
+	bondsAtRisk map[common.Address]*gameBonds
+}
+
+// gameBonds are the bonds at risk in a game.
+type gameBonds struct {
+	// posted is the bond posted in the game, as of the last time it was loaded.
+	posted *big.Int
+	// reserved is the bond of moves that were sent, or are being sent, but aren't included in the game yet.
+	reserved *big.Int
+}
+
+// NewParticipationPolicy creates a ParticipationPolicy. If maxBondsAtRisk is nil, bonds are not limited.
+func NewParticipationPolicy(onlyContradicting bool, maxBondsAtRisk *big.Int) *ParticipationPolicy {
+	return &ParticipationPolicy{
+		onlyContradicting: onlyContradicting,
+		maxBondsAtRisk:    maxBondsAtRisk,
+		bondsAtRisk:       make(map[common.Address]*gameBonds),
+	}
+}
+
+// ForGame returns the GamePolicy for the game at addr.
+func (p *ParticipationPolicy) ForGame(addr common.Address) GamePolicy {
+	return &gamePolicy{policy: p, addr: addr}
+}
+
+// BondsAtRisk returns the total bond posted, or reserved for moves, by the challenger in games that are in progress.
+func (p *ParticipationPolicy) BondsAtRisk() *big.Int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.total()
+}
+
+func (p *ParticipationPolicy) total() *big.Int {
+	total := new(big.Int)
+	for _, bonds := range p.bondsAtRisk {
+		total.Add(total, bonds.posted)
+		total.Add(total, bonds.reserved)
+	}
+	return total
+}
+
+// bonds returns the bonds of the game at addr. The caller must hold the lock.
+func (p *ParticipationPolicy) bonds(addr common.Address) *gameBonds {
+	bonds, ok := p.bondsAtRisk[addr]
+	if !ok {
+		bonds = &gameBonds{posted: new(big.Int), reserved: new(big.Int)}
+		p.bondsAtRisk[addr] = bonds
+	}
+	return bonds
+}
+
+type gamePolicy struct {
+	policy *ParticipationPolicy
+	addr   common.Address
+}
+
+func (g *gamePolicy) OnlyContradicting() bool {
+	return g.policy.onlyContradicting
+}
+
+func (g *gamePolicy) UpdateBondsAtRisk(posted *big.Int, pending *big.Int) {
+	g.policy.mu.Lock()
+	defer g.policy.mu.Unlock()
+	bonds := g.policy.bonds(g.addr)
+	bonds.posted = new(big.Int).Set(posted)
+	bonds.reserved = new(big.Int).Set(pending)
+}
+
+func (g *gamePolicy) ReserveBond(bond *big.Int) bool {
+	g.policy.mu.Lock()
+	defer g.policy.mu.Unlock()
+	if limit := g.policy.maxBondsAtRisk; limit != nil && new(big.Int).Add(g.policy.total(), bond).Cmp(limit) > 0 {
+		return false
+	}
+	bonds := g.policy.bonds(g.addr)
+	bonds.reserved.Add(bonds.reserved, bond)
+	return true
+}
+
+func (g *gamePolicy) ReleaseBond(bond *big.Int) {
+	g.policy.mu.Lock()
+	defer g.policy.mu.Unlock()
+	bonds, ok := g.policy.bondsAtRisk[g.addr]
+	if !ok {
+		return
+	}
+	bonds.reserved.Sub(bonds.reserved, bond)
+	if bonds.reserved.Sign() < 0 {
+		bonds.reserved.SetUint64(0)
+	}
+}
+
+func (g *gamePolicy) Release() {
+	g.policy.mu.Lock()
+	defer g.policy.mu.Unlock()
+	delete(g.policy.bondsAtRisk, g.addr)
+}
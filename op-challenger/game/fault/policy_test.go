@@ -0,0 +1,72 @@
+package fault
+
+import (
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParticipationPolicy(t *testing.T) {
+	t.Run("Unlimited", func(t *testing.T) {
+		policy := NewParticipationPolicy(false, nil)
+		game := policy.ForGame(common.Address{0xaa})
+		game.UpdateBondsAtRisk(big.NewInt(1_000_000), big.NewInt(0))
+		require.True(t, game.ReserveBond(big.NewInt(1_000_000)))
+		require.False(t, game.OnlyContradicting())
+	})
+
+	t.Run("OnlyContradicting", func(t *testing.T) {
+		policy := NewParticipationPolicy(true, nil)
+		require.True(t, policy.ForGame(common.Address{0xaa}).OnlyContradicting())
+	})
+
+	t.Run("LimitSharedAcrossGames", func(t *testing.T) {
+		policy := NewParticipationPolicy(false, big.NewInt(100))
+		game1 := policy.ForGame(common.Address{0xaa})
+		game2 := policy.ForGame(common.Address{0xbb})
+		require.Zero(t, policy.BondsAtRisk().Sign())
+
+		game1.UpdateBondsAtRisk(big.NewInt(60), big.NewInt(0))
+		game2.UpdateBondsAtRisk(big.NewInt(30), big.NewInt(9))
+		require.Equal(t, big.NewInt(99), policy.BondsAtRisk())
+		require.True(t, game1.ReserveBond(big.NewInt(1)))
+		require.False(t, game2.ReserveBond(big.NewInt(1)), "reserved bonds count towards the limit")
+		require.Equal(t, big.NewInt(100), policy.BondsAtRisk())
+
+		game1.ReleaseBond(big.NewInt(1))
+		require.Equal(t, big.NewInt(99), policy.BondsAtRisk())
+
+		// Updates replace the previous bonds of the game, including reservations now included in the game
+		game2.UpdateBondsAtRisk(big.NewInt(40), big.NewInt(0))
+		require.Equal(t, big.NewInt(100), policy.BondsAtRisk())
+		require.False(t, game1.ReserveBond(big.NewInt(1)))
+
+		game1.Release()
+		require.Equal(t, big.NewInt(40), policy.BondsAtRisk())
+		require.True(t, game2.ReserveBond(big.NewInt(60)))
+		require.False(t, game2.ReserveBond(big.NewInt(1)))
+	})
+
+	t.Run("ConcurrentReservations", func(t *testing.T) {
+		policy := NewParticipationPolicy(false, big.NewInt(10))
+		var wg sync.WaitGroup
+		var reserved atomic.Int32
+		for i := 0; i < 100; i++ {
+			game := policy.ForGame(common.Address{byte(i)})
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if game.ReserveBond(big.NewInt(1)) {
+					reserved.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		require.EqualValues(t, 10, reserved.Load())
+		require.Equal(t, big.NewInt(10), policy.BondsAtRisk())
+	})
+}
@@ -3,6 +3,7 @@ package preimages
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -28,7 +29,7 @@ func NewDirectPreimageUploader(logger log.Logger, txSender TxSender, contract Pr
 	return &DirectPreimageUploader{logger, txSender, contract}
 }
 
-func (d *DirectPreimageUploader) UploadPreimage(ctx context.Context, claimIdx uint64, data *types.PreimageOracleData) error {
+func (d *DirectPreimageUploader) UploadPreimage(ctx context.Context, claimIdx uint64, deadline time.Time, data *types.PreimageOracleData) error {
 	if data == nil {
 		return ErrNilPreimageData
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -23,7 +24,7 @@ func TestDirectPreimageUploader_UploadPreimage(t *testing.T) {
 	t.Run("UpdateOracleTxFails", func(t *testing.T) {
 		oracle, txMgr, contract := newTestDirectPreimageUploader(t)
 		contract.updateFails = true
-		err := oracle.UploadPreimage(context.Background(), 0, &types.PreimageOracleData{})
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, &types.PreimageOracleData{})
 		require.ErrorIs(t, err, mockUpdateOracleTxError)
 		require.Equal(t, 1, contract.updateCalls)
 		require.Equal(t, 0, txMgr.sends) // verify that the tx was not sent
@@ -32,7 +33,7 @@ func TestDirectPreimageUploader_UploadPreimage(t *testing.T) {
 	t.Run("SendFails", func(t *testing.T) {
 		oracle, txMgr, contract := newTestDirectPreimageUploader(t)
 		txMgr.sendFails = true
-		err := oracle.UploadPreimage(context.Background(), 0, &types.PreimageOracleData{})
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, &types.PreimageOracleData{})
 		require.ErrorIs(t, err, mockTxMgrSendError)
 		require.Equal(t, 1, contract.updateCalls)
 		require.Equal(t, 1, txMgr.sends)
@@ -40,13 +41,13 @@ func TestDirectPreimageUploader_UploadPreimage(t *testing.T) {
 
 	t.Run("NilPreimageData", func(t *testing.T) {
 		oracle, _, _ := newTestDirectPreimageUploader(t)
-		err := oracle.UploadPreimage(context.Background(), 0, nil)
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, nil)
 		require.ErrorIs(t, err, ErrNilPreimageData)
 	})
 
 	t.Run("Success", func(t *testing.T) {
 		oracle, _, contract := newTestDirectPreimageUploader(t)
-		err := oracle.UploadPreimage(context.Background(), 0, &types.PreimageOracleData{})
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, &types.PreimageOracleData{})
 		require.NoError(t, err)
 		require.Equal(t, 1, contract.updateCalls)
 	})
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
@@ -16,6 +17,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -25,6 +27,13 @@ var _ PreimageUploader = (*LargePreimageUploader)(nil)
 // ErrChallengePeriodNotOver is returned when the challenge period is not over.
 var ErrChallengePeriodNotOver = errors.New("challenge period not over")
 
+// ErrBaseFeeTooHigh is returned when the L1 base fee is above the maximum to upload large preimage data at.
+var ErrBaseFeeTooHigh = errors.New("base fee too high to upload large preimage")
+
+// largePreimageUploadMargin is the time reserved to upload the data of a large preimage and step, once the upload is
+// no longer deferred due to a high base fee.
+const largePreimageUploadMargin = time.Hour
+
 // MaxBlocksPerChunk is the maximum number of keccak blocks per chunk.
 const MaxBlocksPerChunk = 300
 
@@ -42,13 +51,21 @@ type LargePreimageUploader struct {
 	clock    types.ClockReader
 	txSender TxSender
 	contract PreimageOracleContract
+
+	// baseFees and maxBaseFee defer uploading while the L1 base fee is above maxBaseFee. No limit if maxBaseFee is nil.
+	baseFees   BaseFeeSource
+	maxBaseFee *big.Int
 }
 
-func NewLargePreimageUploader(logger log.Logger, cl types.ClockReader, txSender TxSender, contract PreimageOracleContract) *LargePreimageUploader {
-	return &LargePreimageUploader{logger, cl, txSender, contract}
+type BaseFeeSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error)
 }
 
-func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint64, data *types.PreimageOracleData) error {
+func NewLargePreimageUploader(logger log.Logger, cl types.ClockReader, txSender TxSender, contract PreimageOracleContract, baseFees BaseFeeSource, maxBaseFee *big.Int) *LargePreimageUploader {
+	return &LargePreimageUploader{logger, cl, txSender, contract, baseFees, maxBaseFee}
+}
+
+func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint64, deadline time.Time, data *types.PreimageOracleData) error {
 	p.log.Debug("Upload large preimage", "key", hexutil.Bytes(data.OracleKey))
 	stateMatrix, calls, err := p.splitCalls(data)
 	if err != nil {
@@ -65,12 +82,7 @@ func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint6
 	}
 
 	// The proposal is not initialized if the queried metadata has a claimed size of 0.
-	if len(metadata) == 1 && metadata[0].ClaimedSize == 0 {
-		err = p.initLargePreimage(uuid, data.OracleOffset, uint32(len(data.GetPreimageWithoutSize())))
-		if err != nil {
-			return fmt.Errorf("failed to initialize large preimage with uuid: %s: %w", uuid, err)
-		}
-	}
+	needsInit := len(metadata) == 1 && metadata[0].ClaimedSize == 0
 
 	// Filter out any chunks that have already been uploaded to the Preimage Oracle.
 	if len(metadata) > 0 {
@@ -82,6 +94,18 @@ func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint6
 		}
 	}
 
+	if needsInit || len(calls) > 0 {
+		if err := p.checkBaseFee(ctx, deadline); err != nil {
+			return err
+		}
+	}
+	if needsInit {
+		err = p.initLargePreimage(uuid, data.OracleOffset, uint32(len(data.GetPreimageWithoutSize())))
+		if err != nil {
+			return fmt.Errorf("failed to initialize large preimage with uuid: %s: %w", uuid, err)
+		}
+	}
+
 	err = p.addLargePreimageData(uuid, calls)
 	if err != nil {
 		return fmt.Errorf("failed to add leaves to large preimage with uuid: %s: %w", uuid, err)
@@ -90,6 +114,40 @@ func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint6
 	return p.Squeeze(ctx, uuid, stateMatrix)
 }
 
+// checkBaseFee returns ErrBaseFeeTooHigh if the L1 base fee is above the maximum to upload large preimage data at.
+// The upload is retried the next time the game is progressed, resuming from the last uploaded chunk.
+// Uploads are only deferred while there is enough time left before the deadline to upload the data, wait out the
+// challenge period of the preimage oracle and step, so a high base fee never causes the step to be missed.
+func (p *LargePreimageUploader) checkBaseFee(ctx context.Context, deadline time.Time) error {
+	if p.maxBaseFee == nil {
+		return nil
+	}
+	header, err := p.baseFees.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get L1 base fee: %w", err)
+	}
+	if header.BaseFee == nil || header.BaseFee.Cmp(p.maxBaseFee) <= 0 {
+		return nil
+	}
+	if !deadline.IsZero() {
+		challengePeriod, err := p.contract.ChallengePeriod(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get challenge period: %w", err)
+		}
+		remaining := deadline.Sub(p.clock.Now()) - time.Duration(challengePeriod)*time.Second
+		if remaining <= largePreimageUploadMargin {
+			p.log.Warn("Uploading large preimage despite high base fee, as the chess clock is running out",
+				"baseFee", header.BaseFee, "maxBaseFee", p.maxBaseFee, "deadline", deadline)
+			return nil
+		}
+		p.log.Warn("Deferring large preimage upload until base fee decreases", "baseFee", header.BaseFee,
+			"maxBaseFee", p.maxBaseFee, "remaining", remaining-largePreimageUploadMargin)
+		return ErrBaseFeeTooHigh
+	}
+	p.log.Warn("Deferring large preimage upload until base fee decreases", "baseFee", header.BaseFee, "maxBaseFee", p.maxBaseFee)
+	return ErrBaseFeeTooHigh
+}
+
 // NewUUID generates a new unique identifier for the preimage by hashing the
 // concatenated preimage data, preimage offset, and sender address.
 func NewUUID(sender common.Address, data *types.PreimageOracleData) *big.Int {
@@ -20,6 +20,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
@@ -74,7 +75,7 @@ func TestLargePreimageUploader_UploadPreimage_EdgeCases(t *testing.T) {
 		oracle, _, _, contract := newTestLargePreimageUploader(t)
 		contract.initFails = true
 		data := mockPreimageOracleData()
-		err := oracle.UploadPreimage(context.Background(), 0, data)
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
 		require.ErrorIs(t, err, mockInitLPPError)
 		require.Equal(t, 1, contract.initCalls)
 	})
@@ -83,7 +84,7 @@ func TestLargePreimageUploader_UploadPreimage_EdgeCases(t *testing.T) {
 		oracle, _, _, contract := newTestLargePreimageUploader(t)
 		contract.addFails = true
 		data := mockPreimageOracleData()
-		err := oracle.UploadPreimage(context.Background(), 0, data)
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
 		require.ErrorIs(t, err, mockAddLeavesError)
 		require.Equal(t, 1, contract.addCalls)
 	})
@@ -92,7 +93,7 @@ func TestLargePreimageUploader_UploadPreimage_EdgeCases(t *testing.T) {
 		oracle, _, _, contract := newTestLargePreimageUploader(t)
 		data := mockPreimageOracleData()
 		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
-		err := oracle.UploadPreimage(context.Background(), 0, data)
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
 		require.NoError(t, err)
 		require.Equal(t, 1, contract.initCalls)
 		require.Equal(t, 6, contract.addCalls)
@@ -104,7 +105,7 @@ func TestLargePreimageUploader_UploadPreimage_EdgeCases(t *testing.T) {
 		data := mockPreimageOracleData()
 		contract.initialized = true
 		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
-		err := oracle.UploadPreimage(context.Background(), 0, data)
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
 		require.NoError(t, err)
 		require.Equal(t, 0, contract.initCalls)
 		require.Equal(t, 6, contract.addCalls)
@@ -116,12 +117,12 @@ func TestLargePreimageUploader_UploadPreimage_EdgeCases(t *testing.T) {
 		contract.bytesProcessed = 5*MaxChunkSize + 1
 		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
 		contract.timestamp = uint64(cl.Now().Unix())
-		err := oracle.UploadPreimage(context.Background(), 0, data)
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
 		require.ErrorIs(t, err, ErrChallengePeriodNotOver)
 		require.Equal(t, 0, contract.squeezeCalls)
 		// Squeeze should be called once the challenge period has elapsed.
 		cl.AdvanceTime(time.Duration(mockChallengePeriod) * time.Second)
-		err = oracle.UploadPreimage(context.Background(), 0, data)
+		err = oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
 		require.NoError(t, err)
 		require.Equal(t, 1, contract.squeezeCalls)
 	})
@@ -133,7 +134,7 @@ func TestLargePreimageUploader_UploadPreimage_EdgeCases(t *testing.T) {
 		contract.timestamp = 123
 		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
 		contract.squeezeCallFails = true
-		err := oracle.UploadPreimage(context.Background(), 0, data)
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
 		require.ErrorIs(t, err, mockSqueezeCallError)
 		require.Equal(t, 0, contract.squeezeCalls)
 	})
@@ -145,18 +146,78 @@ func TestLargePreimageUploader_UploadPreimage_EdgeCases(t *testing.T) {
 		contract.timestamp = 123
 		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
 		contract.squeezeFails = true
-		err := oracle.UploadPreimage(context.Background(), 0, data)
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
 		require.ErrorIs(t, err, mockSqueezeError)
 		require.Equal(t, 1, contract.squeezeCalls)
 	})
 
+	t.Run("BaseFeeTooHigh", func(t *testing.T) {
+		oracle, _, _, contract := newTestLargePreimageUploader(t)
+		oracle.baseFees = &stubBaseFeeSource{baseFee: big.NewInt(101)}
+		oracle.maxBaseFee = big.NewInt(100)
+		data := mockPreimageOracleData()
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
+		require.ErrorIs(t, err, ErrBaseFeeTooHigh)
+		require.Equal(t, 0, contract.initCalls)
+		require.Equal(t, 0, contract.addCalls)
+	})
+
+	t.Run("BaseFeeTooHighWithTimeRemaining", func(t *testing.T) {
+		oracle, cl, _, contract := newTestLargePreimageUploader(t)
+		oracle.baseFees = &stubBaseFeeSource{baseFee: big.NewInt(101)}
+		oracle.maxBaseFee = big.NewInt(100)
+		data := mockPreimageOracleData()
+		deadline := cl.Now().Add(time.Duration(mockChallengePeriod)*time.Second + largePreimageUploadMargin + time.Second)
+		err := oracle.UploadPreimage(context.Background(), 0, deadline, data)
+		require.ErrorIs(t, err, ErrBaseFeeTooHigh)
+		require.Equal(t, 0, contract.initCalls)
+	})
+
+	t.Run("BaseFeeTooHighButClockRunningOut", func(t *testing.T) {
+		oracle, cl, _, contract := newTestLargePreimageUploader(t)
+		oracle.baseFees = &stubBaseFeeSource{baseFee: big.NewInt(101)}
+		oracle.maxBaseFee = big.NewInt(100)
+		data := mockPreimageOracleData()
+		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
+		deadline := cl.Now().Add(time.Duration(mockChallengePeriod)*time.Second + largePreimageUploadMargin)
+		err := oracle.UploadPreimage(context.Background(), 0, deadline, data)
+		require.NoError(t, err)
+		require.Equal(t, 1, contract.initCalls)
+		require.Equal(t, 6, contract.addCalls)
+	})
+
+	t.Run("BaseFeeAtMax", func(t *testing.T) {
+		oracle, _, _, contract := newTestLargePreimageUploader(t)
+		oracle.baseFees = &stubBaseFeeSource{baseFee: big.NewInt(100)}
+		oracle.maxBaseFee = big.NewInt(100)
+		data := mockPreimageOracleData()
+		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
+		require.NoError(t, err)
+		require.Equal(t, 1, contract.initCalls)
+		require.Equal(t, 6, contract.addCalls)
+	})
+
+	t.Run("BaseFeeIgnoredWhenOnlySqueezing", func(t *testing.T) {
+		oracle, _, _, contract := newTestLargePreimageUploader(t)
+		oracle.baseFees = &stubBaseFeeSource{baseFee: big.NewInt(101)}
+		oracle.maxBaseFee = big.NewInt(100)
+		data := mockPreimageOracleData()
+		contract.bytesProcessed = 5*MaxChunkSize + 1
+		contract.timestamp = 123
+		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
+		require.NoError(t, err)
+		require.Equal(t, 1, contract.squeezeCalls)
+	})
+
 	t.Run("AllBytesProcessed", func(t *testing.T) {
 		oracle, _, _, contract := newTestLargePreimageUploader(t)
 		data := mockPreimageOracleData()
 		contract.bytesProcessed = 5*MaxChunkSize + 1
 		contract.timestamp = 123
 		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
-		err := oracle.UploadPreimage(context.Background(), 0, data)
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
 		require.NoError(t, err)
 		require.Equal(t, 0, contract.initCalls)
 		require.Equal(t, 0, contract.addCalls)
@@ -229,7 +290,7 @@ func TestLargePreimageUploader_UploadPreimage_Succeeds(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			oracle, _, _, contract := newTestLargePreimageUploader(t)
 			data := makePreimageData(test.input, 0)
-			err := oracle.UploadPreimage(context.Background(), 0, data)
+			err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, data)
 			require.NoError(t, err)
 			require.Equal(t, test.addCalls, contract.addCalls)
 			// There must always be at least one init and squeeze call
@@ -258,7 +319,15 @@ func newTestLargePreimageUploader(t *testing.T) (*LargePreimageUploader, *clock.
 	contract := &mockPreimageOracleContract{
 		addData: make([]byte, 0),
 	}
-	return NewLargePreimageUploader(logger, cl, txSender, contract), cl, txSender, contract
+	return NewLargePreimageUploader(logger, cl, txSender, contract, &stubBaseFeeSource{}, nil), cl, txSender, contract
+}
+
+type stubBaseFeeSource struct {
+	baseFee *big.Int
+}
+
+func (s *stubBaseFeeSource) HeaderByNumber(_ context.Context, _ *big.Int) (*gethTypes.Header, error) {
+	return &gethTypes.Header{BaseFee: s.baseFee}, nil
 }
 
 type mockPreimageOracleContract struct {
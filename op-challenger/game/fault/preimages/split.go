@@ -2,6 +2,7 @@ package preimages
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 )
@@ -20,14 +21,14 @@ func NewSplitPreimageUploader(directUploader PreimageUploader, largeUploader Pre
 	return &SplitPreimageUploader{minLargePreimageSize, directUploader, largeUploader}
 }
 
-func (s *SplitPreimageUploader) UploadPreimage(ctx context.Context, parent uint64, data *types.PreimageOracleData) error {
+func (s *SplitPreimageUploader) UploadPreimage(ctx context.Context, parent uint64, deadline time.Time, data *types.PreimageOracleData) error {
 	if data == nil {
 		return ErrNilPreimageData
 	}
 	// Always route local preimage uploads to the direct uploader.
 	if data.IsLocal || uint64(len(data.GetPreimageWithoutSize())) < s.largePreimageSizeThreshold {
-		return s.directUploader.UploadPreimage(ctx, parent, data)
+		return s.directUploader.UploadPreimage(ctx, parent, deadline, data)
 	} else {
-		return s.largeUploader.UploadPreimage(ctx, parent, data)
+		return s.largeUploader.UploadPreimage(ctx, parent, deadline, data)
 	}
 }
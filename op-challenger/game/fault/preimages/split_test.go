@@ -3,6 +3,7 @@ package preimages
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/stretchr/testify/require"
@@ -13,7 +14,7 @@ var mockLargePreimageSizeThreshold = uint64(100)
 func TestSplitPreimageUploader_UploadPreimage(t *testing.T) {
 	t.Run("DirectUploadSucceeds", func(t *testing.T) {
 		oracle, direct, large := newTestSplitPreimageUploader(t, mockLargePreimageSizeThreshold)
-		err := oracle.UploadPreimage(context.Background(), 0, makePreimageData(nil, 0))
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, makePreimageData(nil, 0))
 		require.NoError(t, err)
 		require.Equal(t, 1, direct.updates)
 		require.Equal(t, 0, large.updates)
@@ -21,7 +22,7 @@ func TestSplitPreimageUploader_UploadPreimage(t *testing.T) {
 
 	t.Run("LocalDataUploadSucceeds", func(t *testing.T) {
 		oracle, direct, large := newTestSplitPreimageUploader(t, mockLargePreimageSizeThreshold)
-		err := oracle.UploadPreimage(context.Background(), 0, &types.PreimageOracleData{IsLocal: true})
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, &types.PreimageOracleData{IsLocal: true})
 		require.NoError(t, err)
 		require.Equal(t, 1, direct.updates)
 		require.Equal(t, 0, large.updates)
@@ -29,7 +30,7 @@ func TestSplitPreimageUploader_UploadPreimage(t *testing.T) {
 
 	t.Run("MaxSizeDirectUploadSucceeds", func(t *testing.T) {
 		oracle, direct, large := newTestSplitPreimageUploader(t, mockLargePreimageSizeThreshold)
-		err := oracle.UploadPreimage(context.Background(), 0, makePreimageData(make([]byte, mockLargePreimageSizeThreshold-1), 0))
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, makePreimageData(make([]byte, mockLargePreimageSizeThreshold-1), 0))
 		require.NoError(t, err)
 		require.Equal(t, 1, direct.updates)
 		require.Equal(t, 0, large.updates)
@@ -37,7 +38,7 @@ func TestSplitPreimageUploader_UploadPreimage(t *testing.T) {
 
 	t.Run("LargeUploadSucceeds", func(t *testing.T) {
 		oracle, direct, large := newTestSplitPreimageUploader(t, mockLargePreimageSizeThreshold)
-		err := oracle.UploadPreimage(context.Background(), 0, makePreimageData(make([]byte, mockLargePreimageSizeThreshold), 0))
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, makePreimageData(make([]byte, mockLargePreimageSizeThreshold), 0))
 		require.NoError(t, err)
 		require.Equal(t, 1, large.updates)
 		require.Equal(t, 0, direct.updates)
@@ -45,7 +46,7 @@ func TestSplitPreimageUploader_UploadPreimage(t *testing.T) {
 
 	t.Run("NilPreimageOracleData", func(t *testing.T) {
 		oracle, _, _ := newTestSplitPreimageUploader(t, mockLargePreimageSizeThreshold)
-		err := oracle.UploadPreimage(context.Background(), 0, nil)
+		err := oracle.UploadPreimage(context.Background(), 0, time.Time{}, nil)
 		require.ErrorIs(t, err, ErrNilPreimageData)
 	})
 }
@@ -55,7 +56,7 @@ type mockPreimageUploader struct {
 	uploadFails bool
 }
 
-func (s *mockPreimageUploader) UploadPreimage(ctx context.Context, parent uint64, data *types.PreimageOracleData) error {
+func (s *mockPreimageUploader) UploadPreimage(ctx context.Context, parent uint64, deadline time.Time, data *types.PreimageOracleData) error {
 	s.updates++
 	if s.uploadFails {
 		return mockUpdateOracleTxError
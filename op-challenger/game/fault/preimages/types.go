@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
@@ -17,8 +18,9 @@ var ErrNilPreimageData = fmt.Errorf("cannot upload nil preimage data")
 
 // PreimageUploader is responsible for posting preimages.
 type PreimageUploader interface {
-	// UploadPreimage uploads the provided preimage.
-	UploadPreimage(ctx context.Context, claimIdx uint64, data *types.PreimageOracleData) error
+	// UploadPreimage uploads the provided preimage. The deadline is the time by which the step that uses the preimage
+	// must be included in the game, or the zero time if unknown.
+	UploadPreimage(ctx context.Context, claimIdx uint64, deadline time.Time, data *types.PreimageOracleData) error
 }
 
 type TxSender interface {
@@ -2,7 +2,10 @@ package fault
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"net/url"
 	"path/filepath"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
@@ -14,6 +17,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/prestates"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
@@ -27,6 +31,9 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// ErrUnexpectedSplitDepth is returned for games of which the split depth does not match the configured split depth.
+var ErrUnexpectedSplitDepth = errors.New("unexpected split depth")
+
 type CloseFunc func()
 
 type Registry interface {
@@ -73,28 +80,40 @@ func RegisterGameTypes(
 	}
 	syncValidator := newSyncStatusValidator(rollupClient)
 
+	if cfg.MaxConcurrentTraceGen > 0 {
+		// Share a single limiter between all VM types so the total number of executions is bounded.
+		limited := *cfg
+		limiter := vm.NewExecutionLimiter(cfg.MaxConcurrentTraceGen)
+		limited.Cannon.Limiter = limiter
+		limited.Asterisc.Limiter = limiter
+		cfg = &limited
+	}
+
+	policy := NewParticipationPolicy(cfg.OnlyContradictingGames, cfg.MaxBondsAtRisk)
+	resolution := claims.BatchResolution(cfg.BatchResolution)
+
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeCannon) {
-		if err := registerCannon(faultTypes.CannonGameType, registry, oracles, ctx, systemClock, l1Clock, logger, m, cfg, syncValidator, rollupClient, txSender, gameFactory, caller, l2Client, l1HeaderSource, selective, claimants); err != nil {
+		if err := registerVM(faultTypes.CannonGameType, cannonVM(cfg), registry, oracles, ctx, systemClock, l1Clock, logger, m, cfg, syncValidator, rollupClient, txSender, gameFactory, caller, l2Client, l1HeaderSource, policy, resolution, selective, claimants); err != nil {
 			return nil, fmt.Errorf("failed to register cannon game type: %w", err)
 		}
 	}
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypePermissioned) {
-		if err := registerCannon(faultTypes.PermissionedGameType, registry, oracles, ctx, systemClock, l1Clock, logger, m, cfg, syncValidator, rollupClient, txSender, gameFactory, caller, l2Client, l1HeaderSource, selective, claimants); err != nil {
+		if err := registerVM(faultTypes.PermissionedGameType, cannonVM(cfg), registry, oracles, ctx, systemClock, l1Clock, logger, m, cfg, syncValidator, rollupClient, txSender, gameFactory, caller, l2Client, l1HeaderSource, policy, resolution, selective, claimants); err != nil {
 			return nil, fmt.Errorf("failed to register permissioned cannon game type: %w", err)
 		}
 	}
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeAsterisc) {
-		if err := registerAsterisc(faultTypes.AsteriscGameType, registry, oracles, ctx, systemClock, l1Clock, logger, m, cfg, syncValidator, rollupClient, txSender, gameFactory, caller, l2Client, l1HeaderSource, selective, claimants); err != nil {
+		if err := registerVM(faultTypes.AsteriscGameType, asteriscVM(cfg), registry, oracles, ctx, systemClock, l1Clock, logger, m, cfg, syncValidator, rollupClient, txSender, gameFactory, caller, l2Client, l1HeaderSource, policy, resolution, selective, claimants); err != nil {
 			return nil, fmt.Errorf("failed to register asterisc game type: %w", err)
 		}
 	}
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeFast) {
-		if err := registerAlphabet(faultTypes.FastGameType, registry, oracles, ctx, systemClock, l1Clock, logger, m, syncValidator, rollupClient, l2Client, txSender, gameFactory, caller, l1HeaderSource, selective, claimants); err != nil {
+		if err := registerAlphabet(faultTypes.FastGameType, registry, oracles, ctx, systemClock, l1Clock, logger, m, syncValidator, rollupClient, l2Client, txSender, gameFactory, caller, l1HeaderSource, cfg.LargePreimageMaxBaseFee, cfg.SplitDepths, policy, resolution, selective, claimants); err != nil {
 			return nil, fmt.Errorf("failed to register fast game type: %w", err)
 		}
 	}
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeAlphabet) {
-		if err := registerAlphabet(faultTypes.AlphabetGameType, registry, oracles, ctx, systemClock, l1Clock, logger, m, syncValidator, rollupClient, l2Client, txSender, gameFactory, caller, l1HeaderSource, selective, claimants); err != nil {
+		if err := registerAlphabet(faultTypes.AlphabetGameType, registry, oracles, ctx, systemClock, l1Clock, logger, m, syncValidator, rollupClient, l2Client, txSender, gameFactory, caller, l1HeaderSource, cfg.LargePreimageMaxBaseFee, cfg.SplitDepths, policy, resolution, selective, claimants); err != nil {
 			return nil, fmt.Errorf("failed to register alphabet game type: %w", err)
 		}
 	}
@@ -117,6 +136,10 @@ func registerAlphabet(
 	gameFactory *contracts.DisputeGameFactoryContract,
 	caller *batching.MultiCaller,
 	l1HeaderSource L1HeaderSource,
+	maxLargePreimageBaseFee *big.Int,
+	splitDepths map[faultTypes.GameType]faultTypes.Depth,
+	policy *ParticipationPolicy,
+	resolution claims.ResolutionPolicy,
 	selective bool,
 	claimants []common.Address,
 ) error {
@@ -134,7 +157,7 @@ func registerAlphabet(
 		if err != nil {
 			return nil, err
 		}
-		splitDepth, err := contract.GetSplitDepth(ctx)
+		splitDepth, err := loadSplitDepth(ctx, logger, contract, splitDepths, gameType)
 		if err != nil {
 			return nil, err
 		}
@@ -152,7 +175,7 @@ func registerAlphabet(
 		}
 		prestateValidator := NewPrestateValidator("alphabet", contract.GetAbsolutePrestateHash, alphabet.PrestateProvider)
 		startingValidator := NewPrestateValidator("output root", contract.GetStartingRootHash, prestateProvider)
-		return NewGamePlayer(ctx, systemClock, l1Clock, logger, m, dir, game.Proxy, txSender, contract, syncValidator, []Validator{prestateValidator, startingValidator}, creator, l1HeaderSource, selective, claimants)
+		return NewGamePlayer(ctx, systemClock, l1Clock, logger, m, dir, game.Proxy, txSender, contract, syncValidator, []Validator{prestateValidator, startingValidator}, creator, l1HeaderSource, maxLargePreimageBaseFee, policy, resolution, selective, claimants)
 	}
 	err := registerOracle(ctx, m, oracles, gameFactory, caller, gameType)
 	if err != nil {
@@ -184,101 +207,44 @@ func registerOracle(ctx context.Context, m metrics.Metricer, oracles OracleRegis
 	return nil
 }
 
-func registerAsterisc(
-	gameType faultTypes.GameType,
-	registry Registry,
-	oracles OracleRegistry,
-	ctx context.Context,
-	systemClock clock.Clock,
-	l1Clock faultTypes.ClockReader,
-	logger log.Logger,
-	m metrics.Metricer,
-	cfg *config.Config,
-	syncValidator SyncValidator,
-	rollupClient outputs.OutputRollupClient,
-	txSender TxSender,
-	gameFactory *contracts.DisputeGameFactoryContract,
-	caller *batching.MultiCaller,
-	l2Client utils.L2HeaderSource,
-	l1HeaderSource L1HeaderSource,
-	selective bool,
-	claimants []common.Address,
-) error {
-	var prestateSource PrestateSource
-	if cfg.AsteriscAbsolutePreStateBaseURL != nil {
-		prestateSource = prestates.NewMultiPrestateProvider(cfg.AsteriscAbsolutePreStateBaseURL, filepath.Join(cfg.Datadir, "asterisc-prestates"))
-	} else {
-		prestateSource = prestates.NewSinglePrestateSource(cfg.AsteriscAbsolutePreState)
-	}
-	prestateProviderCache := prestates.NewPrestateProviderCache(m, fmt.Sprintf("prestates-%v", gameType), func(prestateHash common.Hash) (faultTypes.PrestateProvider, error) {
-		prestatePath, err := prestateSource.PrestatePath(prestateHash)
-		if err != nil {
-			return nil, fmt.Errorf("required prestate %v not available: %w", prestateHash, err)
-		}
-		return asterisc.NewPrestateProvider(prestatePath), nil
-	})
-	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		contract, err := contracts.NewFaultDisputeGameContract(ctx, m, game.Proxy, caller)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create fault dispute game contracts: %w", err)
-		}
-		requiredPrestatehash, err := contract.GetAbsolutePrestateHash(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load prestate hash for game %v: %w", game.Proxy, err)
-		}
-		asteriscPrestateProvider, err := prestateProviderCache.GetOrCreate(requiredPrestatehash)
-		if err != nil {
-			return nil, fmt.Errorf("required prestate %v not available for game %v: %w", requiredPrestatehash, game.Proxy, err)
-		}
+// vmType is a fault proof VM that the execution traces of a game type are generated with, and its absolute prestates.
+type vmType struct {
+	cfg                     vm.Config
+	absolutePreState        string
+	absolutePreStateBaseURL *url.URL
+	newPrestateProvider     func(prestatePath string) faultTypes.PrestateProvider
+	newTraceProvider        outputs.VMTraceProviderCreator
+}
 
-		oracle, err := contract.GetOracle(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load oracle for game %v: %w", game.Proxy, err)
-		}
-		oracles.RegisterOracle(oracle)
-		prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
-		if err != nil {
-			return nil, err
-		}
-		splitDepth, err := contract.GetSplitDepth(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load split depth: %w", err)
-		}
-		l1HeadID, err := loadL1Head(contract, ctx, l1HeaderSource)
-		if err != nil {
-			return nil, err
-		}
-		prestateProvider := outputs.NewPrestateProvider(rollupClient, prestateBlock)
-		creator := func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, dir string) (faultTypes.TraceAccessor, error) {
-			asteriscPrestate, err := prestateSource.PrestatePath(requiredPrestatehash)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get asterisc prestate: %w", err)
-			}
-			accessor, err := outputs.NewOutputAsteriscTraceAccessor(logger, m, cfg.Asterisc, l2Client, prestateProvider, asteriscPrestate, rollupClient, dir, l1HeadID, splitDepth, prestateBlock, poststateBlock)
-			if err != nil {
-				return nil, err
-			}
-			return accessor, nil
-		}
-		prestateValidator := NewPrestateValidator("asterisc", contract.GetAbsolutePrestateHash, asteriscPrestateProvider)
-		genesisValidator := NewPrestateValidator("output root", contract.GetStartingRootHash, prestateProvider)
-		return NewGamePlayer(ctx, systemClock, l1Clock, logger, m, dir, game.Proxy, txSender, contract, syncValidator, []Validator{prestateValidator, genesisValidator}, creator, l1HeaderSource, selective, claimants)
-	}
-	err := registerOracle(ctx, m, oracles, gameFactory, caller, gameType)
-	if err != nil {
-		return err
+func cannonVM(cfg *config.Config) vmType {
+	return vmType{
+		cfg:                     cfg.Cannon,
+		absolutePreState:        cfg.CannonAbsolutePreState,
+		absolutePreStateBaseURL: cfg.CannonAbsolutePreStateBaseURL,
+		newPrestateProvider: func(prestatePath string) faultTypes.PrestateProvider {
+			return cannon.NewPrestateProvider(prestatePath)
+		},
+		newTraceProvider: outputs.CannonTraceProvider,
 	}
-	registry.RegisterGameType(gameType, playerCreator)
+}
 
-	contractCreator := func(game types.GameMetadata) (claims.BondContract, error) {
-		return contracts.NewFaultDisputeGameContract(ctx, m, game.Proxy, caller)
+func asteriscVM(cfg *config.Config) vmType {
+	return vmType{
+		cfg:                     cfg.Asterisc,
+		absolutePreState:        cfg.AsteriscAbsolutePreState,
+		absolutePreStateBaseURL: cfg.AsteriscAbsolutePreStateBaseURL,
+		newPrestateProvider: func(prestatePath string) faultTypes.PrestateProvider {
+			return asterisc.NewPrestateProvider(prestatePath)
+		},
+		newTraceProvider: outputs.AsteriscTraceProvider,
 	}
-	registry.RegisterBondContract(gameType, contractCreator)
-	return nil
 }
 
-func registerCannon(
+// registerVM registers an output root game type, which bisects the execution trace generated by the fault proof VM
+// below the split depth. The absolute prestates are loaded per VM, either from a single file or a prestates server.
+func registerVM(
 	gameType faultTypes.GameType,
+	vmType vmType,
 	registry Registry,
 	oracles OracleRegistry,
 	ctx context.Context,
@@ -294,21 +260,24 @@ func registerCannon(
 	caller *batching.MultiCaller,
 	l2Client utils.L2HeaderSource,
 	l1HeaderSource L1HeaderSource,
+	policy *ParticipationPolicy,
+	resolution claims.ResolutionPolicy,
 	selective bool,
 	claimants []common.Address,
 ) error {
+	vmName := vmType.cfg.VmType.String()
 	var prestateSource PrestateSource
-	if cfg.CannonAbsolutePreStateBaseURL != nil {
-		prestateSource = prestates.NewMultiPrestateProvider(cfg.CannonAbsolutePreStateBaseURL, filepath.Join(cfg.Datadir, "cannon-prestates"))
+	if vmType.absolutePreStateBaseURL != nil {
+		prestateSource = prestates.NewMultiPrestateProvider(vmType.absolutePreStateBaseURL, filepath.Join(cfg.Datadir, vmName+"-prestates"))
 	} else {
-		prestateSource = prestates.NewSinglePrestateSource(cfg.CannonAbsolutePreState)
+		prestateSource = prestates.NewSinglePrestateSource(vmType.absolutePreState)
 	}
 	prestateProviderCache := prestates.NewPrestateProviderCache(m, fmt.Sprintf("prestates-%v", gameType), func(prestateHash common.Hash) (faultTypes.PrestateProvider, error) {
 		prestatePath, err := prestateSource.PrestatePath(prestateHash)
 		if err != nil {
 			return nil, fmt.Errorf("required prestate %v not available: %w", prestateHash, err)
 		}
-		return cannon.NewPrestateProvider(prestatePath), nil
+		return vmType.newPrestateProvider(prestatePath), nil
 	})
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
 		contract, err := contracts.NewFaultDisputeGameContract(ctx, m, game.Proxy, caller)
@@ -319,9 +288,7 @@ func registerCannon(
 		if err != nil {
 			return nil, fmt.Errorf("failed to load prestate hash for game %v: %w", game.Proxy, err)
 		}
-
-		cannonPrestateProvider, err := prestateProviderCache.GetOrCreate(requiredPrestatehash)
-
+		vmPrestateProvider, err := prestateProviderCache.GetOrCreate(requiredPrestatehash)
 		if err != nil {
 			return nil, fmt.Errorf("required prestate %v not available for game %v: %w", requiredPrestatehash, game.Proxy, err)
 		}
@@ -335,9 +302,9 @@ func registerCannon(
 		if err != nil {
 			return nil, err
 		}
-		splitDepth, err := contract.GetSplitDepth(ctx)
+		splitDepth, err := loadSplitDepth(ctx, logger, contract, cfg.SplitDepths, gameType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load split depth: %w", err)
+			return nil, err
 		}
 		l1HeadID, err := loadL1Head(contract, ctx, l1HeaderSource)
 		if err != nil {
@@ -345,19 +312,19 @@ func registerCannon(
 		}
 		prestateProvider := outputs.NewPrestateProvider(rollupClient, prestateBlock)
 		creator := func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, dir string) (faultTypes.TraceAccessor, error) {
-			cannonPrestate, err := prestateSource.PrestatePath(requiredPrestatehash)
+			vmPrestate, err := prestateSource.PrestatePath(requiredPrestatehash)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get cannon prestate: %w", err)
+				return nil, fmt.Errorf("failed to get %v prestate: %w", vmName, err)
 			}
-			accessor, err := outputs.NewOutputCannonTraceAccessor(logger, m, cfg.Cannon, l2Client, prestateProvider, cannonPrestate, rollupClient, dir, l1HeadID, splitDepth, prestateBlock, poststateBlock)
+			accessor, err := outputs.NewOutputVMTraceAccessor(logger, m, vmType.cfg, vmType.newTraceProvider, l2Client, prestateProvider, vmPrestate, rollupClient, dir, l1HeadID, splitDepth, prestateBlock, poststateBlock)
 			if err != nil {
 				return nil, err
 			}
 			return accessor, nil
 		}
-		prestateValidator := NewPrestateValidator("cannon", contract.GetAbsolutePrestateHash, cannonPrestateProvider)
+		prestateValidator := NewPrestateValidator(vmName, contract.GetAbsolutePrestateHash, vmPrestateProvider)
 		startingValidator := NewPrestateValidator("output root", contract.GetStartingRootHash, prestateProvider)
-		return NewGamePlayer(ctx, systemClock, l1Clock, logger, m, dir, game.Proxy, txSender, contract, syncValidator, []Validator{prestateValidator, startingValidator}, creator, l1HeaderSource, selective, claimants)
+		return NewGamePlayer(ctx, systemClock, l1Clock, logger, m, dir, game.Proxy, txSender, contract, syncValidator, []Validator{prestateValidator, startingValidator}, creator, l1HeaderSource, cfg.LargePreimageMaxBaseFee, policy, resolution, selective, claimants)
 	}
 	err := registerOracle(ctx, m, oracles, gameFactory, caller, gameType)
 	if err != nil {
@@ -372,6 +339,30 @@ func registerCannon(
 	return nil
 }
 
+type SplitDepthSource interface {
+	GetSplitDepth(ctx context.Context) (faultTypes.Depth, error)
+}
+
+// loadSplitDepth returns the depth to split the trace providers of the top and bottom levels of a game at.
+// The split depth configured for the game type is used if set, so games of contracts that don't report their split
+// depth can be played. Otherwise, the split depth of the game is used. Games that report a split depth different from
+// the configured one are rejected.
+func loadSplitDepth(ctx context.Context, logger log.Logger, contract SplitDepthSource, splitDepths map[faultTypes.GameType]faultTypes.Depth, gameType faultTypes.GameType) (faultTypes.Depth, error) {
+	configured, ok := splitDepths[gameType]
+	gameDepth, err := contract.GetSplitDepth(ctx)
+	if err != nil {
+		if !ok {
+			return 0, fmt.Errorf("failed to load split depth: %w", err)
+		}
+		logger.Warn("Failed to load split depth of game, using configured split depth", "gameType", gameType, "splitDepth", configured, "err", err)
+		return configured, nil
+	}
+	if ok && gameDepth != configured {
+		return 0, fmt.Errorf("%w: game type %v expected %v, game has %v", ErrUnexpectedSplitDepth, gameType, configured, gameDepth)
+	}
+	return gameDepth, nil
+}
+
 func loadL1Head(contract contracts.FaultDisputeGameContract, ctx context.Context, l1HeaderSource L1HeaderSource) (eth.BlockID, error) {
 	l1Head, err := contract.GetL1Head(ctx)
 	if err != nil {
@@ -0,0 +1,92 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/asterisc"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMTypes(t *testing.T) {
+	cfg := config.NewConfig(common.Address{0xaa}, "http://localhost:8545", "http://localhost:9000", "http://localhost:8555", "http://localhost:8565", t.TempDir())
+	cfg.CannonAbsolutePreState = "cannon-prestate.json"
+	cfg.AsteriscAbsolutePreState = "asterisc-prestate.json"
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	t.Run("Cannon", func(t *testing.T) {
+		vm := cannonVM(&cfg)
+		require.Equal(t, faultTypes.TraceTypeCannon, vm.cfg.VmType)
+		require.Equal(t, cfg.CannonAbsolutePreState, vm.absolutePreState)
+		require.IsType(t, &cannon.CannonPrestateProvider{}, vm.newPrestateProvider("prestate.json"))
+		provider := vm.newTraceProvider(logger, metrics.NoopMetrics, vm.cfg, nil, "prestate.json", utils.LocalGameInputs{}, t.TempDir(), 10)
+		require.IsType(t, &cannon.CannonTraceProvider{}, provider)
+	})
+
+	t.Run("Asterisc", func(t *testing.T) {
+		vm := asteriscVM(&cfg)
+		require.Equal(t, faultTypes.TraceTypeAsterisc, vm.cfg.VmType)
+		require.Equal(t, cfg.AsteriscAbsolutePreState, vm.absolutePreState)
+		require.IsType(t, &asterisc.AsteriscPreStateProvider{}, vm.newPrestateProvider("prestate.json"))
+		provider := vm.newTraceProvider(logger, metrics.NoopMetrics, vm.cfg, nil, "prestate.json", utils.LocalGameInputs{}, t.TempDir(), 10)
+		require.IsType(t, &asterisc.AsteriscTraceProvider{}, provider)
+	})
+}
+
+func TestLoadSplitDepth(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	splitDepths := map[faultTypes.GameType]faultTypes.Depth{faultTypes.CannonGameType: 30}
+	load := func(splitDepths map[faultTypes.GameType]faultTypes.Depth, gameType faultTypes.GameType, contract SplitDepthSource) (faultTypes.Depth, error) {
+		return loadSplitDepth(context.Background(), logger, contract, splitDepths, gameType)
+	}
+
+	t.Run("UseGameSplitDepth", func(t *testing.T) {
+		depth, err := load(nil, faultTypes.CannonGameType, &stubSplitDepthSource{depth: 14})
+		require.NoError(t, err)
+		require.Equal(t, faultTypes.Depth(14), depth)
+
+		depth, err = load(splitDepths, faultTypes.AsteriscGameType, &stubSplitDepthSource{depth: 14})
+		require.NoError(t, err)
+		require.Equal(t, faultTypes.Depth(14), depth, "no split depth configured for the game type")
+	})
+
+	t.Run("MatchingConfiguredSplitDepth", func(t *testing.T) {
+		depth, err := load(splitDepths, faultTypes.CannonGameType, &stubSplitDepthSource{depth: 30})
+		require.NoError(t, err)
+		require.Equal(t, faultTypes.Depth(30), depth)
+	})
+
+	t.Run("UseConfiguredSplitDepthWhenGameDoesNotReportIt", func(t *testing.T) {
+		depth, err := load(splitDepths, faultTypes.CannonGameType, &stubSplitDepthSource{err: errors.New("execution reverted")})
+		require.NoError(t, err)
+		require.Equal(t, faultTypes.Depth(30), depth)
+	})
+
+	t.Run("ErrorWhenNoSplitDepth", func(t *testing.T) {
+		_, err := load(nil, faultTypes.CannonGameType, &stubSplitDepthSource{err: errors.New("execution reverted")})
+		require.ErrorContains(t, err, "execution reverted")
+	})
+
+	t.Run("RejectMismatchedSplitDepth", func(t *testing.T) {
+		_, err := load(splitDepths, faultTypes.CannonGameType, &stubSplitDepthSource{depth: 14})
+		require.ErrorIs(t, err, ErrUnexpectedSplitDepth)
+	})
+}
+
+type stubSplitDepthSource struct {
+	depth faultTypes.Depth
+	err   error
+}
+
+func (s *stubSplitDepthSource) GetSplitDepth(_ context.Context) (faultTypes.Depth, error) {
+	return s.depth, s.err
+}
@@ -99,7 +99,7 @@ func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action)
 		}
 		// Always upload local preimages
 		if !preimageExists {
-			err := r.uploader.UploadPreimage(ctx, uint64(action.ParentClaim.ContractIndex), action.OracleData)
+			err := r.uploader.UploadPreimage(ctx, uint64(action.ParentClaim.ContractIndex), action.Deadline, action.OracleData)
 			if errors.Is(err, preimages.ErrChallengePeriodNotOver) {
 				r.log.Debug("Large Preimage Squeeze failed, challenge period not over")
 				return nil
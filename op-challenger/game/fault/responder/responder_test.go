@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
@@ -212,6 +213,7 @@ func TestPerformAction(t *testing.T) {
 			OracleData: &types.PreimageOracleData{
 				IsLocal: false,
 			},
+			Deadline: time.Unix(1000, 0),
 		}
 		err := responder.PerformAction(context.Background(), action)
 		require.NoError(t, err)
@@ -220,6 +222,7 @@ func TestPerformAction(t *testing.T) {
 		require.Nil(t, contract.updateOracleArgs) // mock uploader returns nil
 		require.Equal(t, ([]byte)("step"), mockTxMgr.sent[0].TxData)
 		require.Equal(t, 1, uploader.updates)
+		require.Equal(t, action.Deadline, uploader.deadline)
 		require.Equal(t, 1, oracle.existCalls)
 	})
 
@@ -313,10 +316,12 @@ func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mock
 type mockPreimageUploader struct {
 	updates     int
 	uploadFails bool
+	deadline    time.Time
 }
 
-func (m *mockPreimageUploader) UploadPreimage(ctx context.Context, parent uint64, data *types.PreimageOracleData) error {
+func (m *mockPreimageUploader) UploadPreimage(ctx context.Context, parent uint64, deadline time.Time, data *types.PreimageOracleData) error {
 	m.updates++
+	m.deadline = deadline
 	if m.uploadFails {
 		return mockPreimageUploadErr
 	}
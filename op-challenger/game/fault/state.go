@@ -0,0 +1,170 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const gameStateFile = "game_state.json"
+
+// pendingActionExpiry is how long a submitted action is assumed to still be in flight. If it hasn't been included in
+// the game by then, for example because the challenger crashed before sending it, the action is retried.
+const pendingActionExpiry = 10 * time.Minute
+
+// SubmittedAction is a move, step or L2 block number challenge submitted to the game.
+type SubmittedAction struct {
+	Type        types.ActionType `json:"type"`
+	ParentIndex int              `json:"parentIndex"`
+	IsAttack    bool             `json:"isAttack"`
+	Value       common.Hash      `json:"value"`
+	Submitted   time.Time        `json:"submitted"`
+}
+
+func newSubmittedAction(action types.Action, now time.Time) SubmittedAction {
+	return SubmittedAction{
+		Type:        action.Type,
+		ParentIndex: action.ParentClaim.ContractIndex,
+		IsAttack:    action.IsAttack,
+		Value:       action.Value,
+		Submitted:   now,
+	}
+}
+
+func (a SubmittedAction) matches(b SubmittedAction) bool {
+	return a.Type == b.Type && a.ParentIndex == b.ParentIndex && a.IsAttack == b.IsAttack && a.Value == b.Value
+}
+
+// includedIn returns true if the action is reflected in the claims of the game.
+func (a SubmittedAction) includedIn(claims []types.Claim) bool {
+	if a.ParentIndex >= len(claims) {
+		return false
+	}
+	parent := claims[a.ParentIndex]
+	switch a.Type {
+	case types.ActionTypeMove:
+		pos := parent.Position.Defend()
+		if a.IsAttack {
+			pos = parent.Position.Attack()
+		}
+		return slices.ContainsFunc(claims, func(claim types.Claim) bool {
+			return claim.ParentContractIndex == a.ParentIndex && claim.Position.ToGIndex().Cmp(pos.ToGIndex()) == 0 && claim.Value == a.Value
+		})
+	case types.ActionTypeStep:
+		return parent.CounteredBy != (common.Address{})
+	default:
+		return false
+	}
+}
+
+type gameState struct {
+	// ClaimsSeen is the number of claims in the game when it was last acted on.
+	ClaimsSeen int `json:"claimsSeen"`
+	// Pending are the actions submitted to the game that have not been seen in its claims yet.
+	Pending []SubmittedAction `json:"pending"`
+}
+
+// GameStateStore persists the state of a game to its data directory, so a restarted challenger resumes the game
+// without submitting actions that may still be in flight again.
+type GameStateStore struct {
+	mu    sync.Mutex
+	path  string
+	state gameState
+}
+
+// NewGameStateStore creates a GameStateStore in dir, loading the state previously stored there, if any.
+// The directory is created if it does not exist yet.
+func NewGameStateStore(dir string) (*GameStateStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create game state dir: %w", err)
+	}
+	s := &GameStateStore{path: filepath.Join(dir, gameStateFile)}
+	state, err := jsonutil.LoadJSON[gameState](s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load game state: %w", err)
+	}
+	s.state = *state
+	return s, nil
+}
+
+// ClaimsSeen returns the number of claims in the game when it was last acted on.
+func (s *GameStateStore) ClaimsSeen() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.ClaimsSeen
+}
+
+// UpdateClaims records the current claims of the game, removing any pending actions that have been included.
+func (s *GameStateStore) UpdateClaims(claims []types.Claim) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pending := slices.DeleteFunc(slices.Clone(s.state.Pending), func(action SubmittedAction) bool {
+		return action.includedIn(claims)
+	})
+	if len(claims) == s.state.ClaimsSeen && len(pending) == len(s.state.Pending) {
+		return nil
+	}
+	s.state.ClaimsSeen = len(claims)
+	s.state.Pending = pending
+	return s.save()
+}
+
+// IsPending returns true if the action was submitted less than pendingActionExpiry ago and hasn't been included yet.
+func (s *GameStateStore) IsPending(action types.Action, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	submitted := newSubmittedAction(action, now)
+	return slices.ContainsFunc(s.state.Pending, func(pending SubmittedAction) bool {
+		return pending.matches(submitted) && now.Sub(pending.Submitted) < pendingActionExpiry
+	})
+}
+
+// Submitted records that the action is about to be submitted. It must be recorded before the transaction is sent so
+// the action isn't repeated if the challenger crashes while the transaction is in flight.
+func (s *GameStateStore) Submitted(action types.Action, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	submitted := newSubmittedAction(action, now)
+	s.state.Pending = slices.DeleteFunc(s.state.Pending, submitted.matches)
+	s.state.Pending = append(s.state.Pending, submitted)
+	return s.save()
+}
+
+// Failed removes the action from the pending actions so it is retried.
+func (s *GameStateStore) Failed(action types.Action) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	failed := newSubmittedAction(action, time.Time{})
+	s.state.Pending = slices.DeleteFunc(s.state.Pending, failed.matches)
+	return s.save()
+}
+
+// Remove deletes the stored state, once the game is complete and no further actions are submitted.
+func (s *GameStateStore) Remove() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = gameState{}
+	return removeGameState(filepath.Dir(s.path))
+}
+
+// removeGameState deletes the game state stored in dir, if any.
+func removeGameState(dir string) error {
+	if err := os.Remove(filepath.Join(dir, gameStateFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove game state: %w", err)
+	}
+	return nil
+}
+
+func (s *GameStateStore) save() error {
+	return jsonutil.WriteJSON(s.path, s.state, 0o644)
+}
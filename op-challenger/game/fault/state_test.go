@@ -0,0 +1,120 @@
+package fault
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameStateStore(t *testing.T) {
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	gameBuilder := claimBuilder.GameBuilder(test.WithInvalidValue(true))
+	root := gameBuilder.Game.Claims()[0]
+	move := types.Action{Type: types.ActionTypeMove, ParentClaim: root, IsAttack: true, Value: claimBuilder.CorrectClaimAtPosition(root.Position.Attack())}
+	now := time.Unix(1000, 0)
+
+	t.Run("EmptyWhenNoStateStored", func(t *testing.T) {
+		store, err := NewGameStateStore(t.TempDir())
+		require.NoError(t, err)
+		require.Zero(t, store.ClaimsSeen())
+		require.False(t, store.IsPending(move, now))
+	})
+
+	t.Run("PersistsState", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewGameStateStore(dir)
+		require.NoError(t, err)
+		require.NoError(t, store.UpdateClaims(gameBuilder.Game.Claims()))
+		require.NoError(t, store.Submitted(move, now))
+
+		reloaded, err := NewGameStateStore(dir)
+		require.NoError(t, err)
+		require.Equal(t, 1, reloaded.ClaimsSeen())
+		require.True(t, reloaded.IsPending(move, now))
+	})
+
+	t.Run("CreatesMissingDir", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "game-0x1234")
+		store, err := NewGameStateStore(dir)
+		require.NoError(t, err)
+		require.NoError(t, store.Submitted(move, now))
+
+		reloaded, err := NewGameStateStore(dir)
+		require.NoError(t, err)
+		require.True(t, reloaded.IsPending(move, now))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewGameStateStore(dir)
+		require.NoError(t, err)
+		require.NoError(t, store.Submitted(move, now))
+		require.NoError(t, store.Remove())
+		require.False(t, store.IsPending(move, now))
+		require.NoFileExists(t, filepath.Join(dir, gameStateFile))
+		// removing again is a no-op
+		require.NoError(t, store.Remove())
+	})
+
+	t.Run("PendingUntilExpiry", func(t *testing.T) {
+		store, err := NewGameStateStore(t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, store.Submitted(move, now))
+		require.True(t, store.IsPending(move, now.Add(pendingActionExpiry-time.Second)))
+		require.False(t, store.IsPending(move, now.Add(pendingActionExpiry)))
+
+		defend := move
+		defend.IsAttack = false
+		require.False(t, store.IsPending(defend, now), "different action")
+	})
+
+	t.Run("FailedNotPending", func(t *testing.T) {
+		store, err := NewGameStateStore(t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, store.Submitted(move, now))
+		require.NoError(t, store.Failed(move))
+		require.False(t, store.IsPending(move, now))
+	})
+
+	t.Run("IncludedMoveNotPending", func(t *testing.T) {
+		store, err := NewGameStateStore(t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, store.Submitted(move, now))
+		require.NoError(t, store.UpdateClaims(gameBuilder.Game.Claims()))
+		require.True(t, store.IsPending(move, now))
+
+		builder := claimBuilder.GameBuilder(test.WithInvalidValue(true))
+		builder.Seq().Attack(test.WithValue(common.Hash{0xaa}))
+		require.NoError(t, store.UpdateClaims(builder.Game.Claims()))
+		require.True(t, store.IsPending(move, now), "different value")
+
+		builder = claimBuilder.GameBuilder(test.WithInvalidValue(true))
+		builder.Seq().Attack()
+		require.NoError(t, store.UpdateClaims(builder.Game.Claims()))
+		require.False(t, store.IsPending(move, now))
+		require.Equal(t, 2, store.ClaimsSeen())
+	})
+
+	t.Run("IncludedStepNotPending", func(t *testing.T) {
+		store, err := NewGameStateStore(t.TempDir())
+		require.NoError(t, err)
+		step := types.Action{Type: types.ActionTypeStep, ParentClaim: root, IsAttack: true}
+		require.NoError(t, store.Submitted(step, now))
+		require.NoError(t, store.UpdateClaims(gameBuilder.Game.Claims()))
+		require.True(t, store.IsPending(step, now))
+
+		claims := gameBuilder.Game.Claims()
+		countered := append([]types.Claim(nil), claims...)
+		countered[0].CounteredBy = common.Address{0xaa}
+		require.NoError(t, store.UpdateClaims(countered))
+		require.False(t, store.IsPending(step, now))
+	})
+}
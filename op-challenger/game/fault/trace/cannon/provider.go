@@ -119,6 +119,11 @@ func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*utils.P
 		}
 		// Try opening the file again now and it should exist.
 		file, err = ioutil.OpenDecompressed(path)
+		if err == nil {
+			if err := vm.SnapshotPostState(p.dir, i); err != nil {
+				p.logger.Warn("Failed to keep post-state of proof as snapshot", "proof", i, "err", err)
+			}
+		}
 		if errors.Is(err, os.ErrNotExist) {
 			// Expected proof wasn't generated, check if we reached the end of execution
 			state, err := p.finalState()
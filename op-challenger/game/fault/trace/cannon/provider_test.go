@@ -126,6 +126,10 @@ func TestGetStepData(t *testing.T) {
 		require.EqualValues(t, generator.proof.ProofData, proof)
 		expectedData := types.NewPreimageOracleData(generator.proof.OracleKey, generator.proof.OracleValue, generator.proof.OracleOffset)
 		require.EqualValues(t, expectedData, data)
+
+		snapshot, err := vm.FindStartingSnapshot(provider.logger, filepath.Join(dataDir, vm.SnapsDir), provider.prestate, 6)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dataDir, vm.SnapsDir, "5.json.gz"), snapshot, "post-state of proof should be kept as snapshot")
 	})
 
 	t.Run("ProofAfterEndOfTrace", func(t *testing.T) {
@@ -266,6 +270,14 @@ func (e *stubGenerator) GenerateProof(ctx context.Context, dir string, i uint64)
 		return ioutil.WriteCompressedBytes(proofFile, data, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0o644)
 	}
 	if e.proof != nil {
+		// Like the VM, stop right after the proof and write the post-state as final state.
+		data, err = json.Marshal(&mipsevm.State{Memory: &mipsevm.Memory{}, Step: i + 1})
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteCompressedBytes(filepath.Join(dir, vm.FinalState), data, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0o644); err != nil {
+			return err
+		}
 		proofFile = filepath.Join(dir, utils.ProofsDir, fmt.Sprintf("%d.json.gz", i))
 		data, err = json.Marshal(e.proof)
 		if err != nil {
@@ -0,0 +1,55 @@
+package outputs
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/split"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// VMTraceProviderCreator creates the trace provider of a fault proof VM, used for the execution trace bisection below
+// the split depth.
+type VMTraceProviderCreator func(logger log.Logger, m vm.Metricer, cfg vm.Config, prestateProvider types.PrestateProvider, vmPrestate string, localInputs utils.LocalGameInputs, dir string, gameDepth types.Depth) types.TraceProvider
+
+// NewOutputVMTraceAccessor creates a trace accessor for output root games, bisecting the execution trace generated by
+// the fault proof VM of vmCreator below the split depth.
+func NewOutputVMTraceAccessor(
+	logger log.Logger,
+	m metrics.Metricer,
+	cfg vm.Config,
+	vmCreator VMTraceProviderCreator,
+	l2Client utils.L2HeaderSource,
+	prestateProvider types.PrestateProvider,
+	vmPrestate string,
+	rollupClient OutputRollupClient,
+	dir string,
+	l1Head eth.BlockID,
+	splitDepth types.Depth,
+	prestateBlock uint64,
+	poststateBlock uint64,
+) (*trace.Accessor, error) {
+	outputProvider := NewTraceProvider(logger, prestateProvider, rollupClient, l2Client, l1Head, splitDepth, prestateBlock, poststateBlock)
+	creator := func(ctx context.Context, localContext common.Hash, depth types.Depth, agreed contracts.Proposal, claimed contracts.Proposal) (types.TraceProvider, error) {
+		logger := logger.New("pre", agreed.OutputRoot, "post", claimed.OutputRoot, "localContext", localContext)
+		subdir := filepath.Join(dir, localContext.Hex())
+		localInputs, err := utils.FetchLocalInputsFromProposals(ctx, l1Head.Hash, l2Client, agreed, claimed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %v local inputs: %w", cfg.VmType, err)
+		}
+		return vmCreator(logger, m, cfg, prestateProvider, vmPrestate, localInputs, subdir, depth), nil
+	}
+
+	cache := NewProviderCache(m, fmt.Sprintf("output_%v_provider", cfg.VmType), creator)
+	selector := split.NewSplitProviderSelector(outputProvider, splitDepth, OutputRootSplitAdapter(outputProvider, cache.GetOrCreate))
+	return trace.NewAccessor(selector), nil
+}
@@ -31,6 +31,8 @@ type Config struct {
 	L2GenesisPath    string
 	SnapshotFreq     uint // Frequency of snapshots to create when executing (in VM instructions)
 	InfoFreq         uint // Frequency of progress log messages (in VM instructions)
+	// Limiter limits the concurrent executions, shared by the VMs of all games. Nil for no limit.
+	Limiter *ExecutionLimiter
 }
 
 type Executor struct {
@@ -119,6 +121,10 @@ func (e *Executor) DoGenerateProof(ctx context.Context, dir string, begin uint64
 	if err := os.MkdirAll(proofDir, 0755); err != nil {
 		return fmt.Errorf("could not create proofs directory %v: %w", proofDir, err)
 	}
+	if err := e.cfg.Limiter.acquire(ctx); err != nil {
+		return fmt.Errorf("wait for available execution slot: %w", err)
+	}
+	defer e.cfg.Limiter.release()
 	e.logger.Info("Generating trace", "proof", end, "cmd", e.cfg.VmBin, "args", strings.Join(args, ", "))
 	execStart := time.Now()
 	err = e.cmdExecutor(ctx, e.logger.New("proof", end), e.cfg.VmBin, args...)
@@ -0,0 +1,34 @@
+package vm
+
+import "context"
+
+// ExecutionLimiter limits the number of concurrent VM executions across all games, as trace generation is CPU and
+// memory intensive. A nil ExecutionLimiter doesn't limit executions.
+type ExecutionLimiter struct {
+	slots chan struct{}
+}
+
+func NewExecutionLimiter(maxConcurrent uint) *ExecutionLimiter {
+	return &ExecutionLimiter{slots: make(chan struct{}, maxConcurrent)}
+}
+
+// acquire blocks until an execution slot is available or ctx is done.
+func (l *ExecutionLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the execution slot taken by acquire.
+func (l *ExecutionLimiter) release() {
+	if l == nil {
+		return
+	}
+	<-l.slots
+}
@@ -0,0 +1,64 @@
+package vm
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionLimiter(t *testing.T) {
+	t.Run("NilDoesNotLimit", func(t *testing.T) {
+		var limiter *ExecutionLimiter
+		require.NoError(t, limiter.acquire(context.Background()))
+		require.NoError(t, limiter.acquire(context.Background()))
+		limiter.release()
+	})
+
+	t.Run("BlocksUntilReleased", func(t *testing.T) {
+		limiter := NewExecutionLimiter(1)
+		require.NoError(t, limiter.acquire(context.Background()))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, limiter.acquire(ctx), context.DeadlineExceeded)
+		limiter.release()
+		require.NoError(t, limiter.acquire(context.Background()))
+	})
+
+	t.Run("LimitsExecutorsSharingConfig", func(t *testing.T) {
+		cfg := Config{VmBin: "./bin/testvm", Limiter: NewExecutionLimiter(2)}
+		var running, maxRunning atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 6; i++ {
+			executor := NewExecutor(testlog.Logger(t, log.LevelInfo), &stubVmMetrics{}, cfg, "pre.json", utils.LocalGameInputs{})
+			executor.selectSnapshot = func(log.Logger, string, string, uint64) (string, error) {
+				return "pre.json", nil
+			}
+			executor.cmdExecutor = func(context.Context, log.Logger, string, ...string) error {
+				n := running.Add(1)
+				for {
+					prev := maxRunning.Load()
+					if n <= prev || maxRunning.CompareAndSwap(prev, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+				return nil
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.NoError(t, executor.GenerateProof(context.Background(), t.TempDir(), 10))
+			}()
+		}
+		wg.Wait()
+		require.EqualValues(t, 2, maxRunning.Load())
+	})
+}
@@ -79,3 +79,23 @@ func FindStartingSnapshot(logger log.Logger, snapDir string, absolutePreState st
 
 	return startFrom, nil
 }
+
+// SnapshotPostState keeps the final state of a VM run that generated the proof at traceIndex as snapshot in the
+// snapshots dir of dir. The run stops right after the proof, so its final state is the state at step traceIndex+1.
+// Bisection requests increasingly close trace indices, so later runs can start from these snapshots instead of
+// re-executing up to SnapshotFreq instructions for every move.
+func SnapshotPostState(dir string, traceIndex uint64) error {
+	snapshotDir := filepath.Join(dir, SnapsDir)
+	if err := os.MkdirAll(snapshotDir, 0755); err != nil {
+		return fmt.Errorf("could not create snapshot directory %v: %w", snapshotDir, err)
+	}
+	path := filepath.Join(snapshotDir, fmt.Sprintf("%d.json.gz", traceIndex+1))
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	// The final state is replaced by the next run, not modified, so a hard link avoids copying the full state.
+	if err := os.Link(filepath.Join(dir, FinalState), path); err != nil {
+		return fmt.Errorf("could not snapshot final state: %w", err)
+	}
+	return nil
+}
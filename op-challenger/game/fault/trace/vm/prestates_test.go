@@ -0,0 +1,37 @@
+package vm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotPostState(t *testing.T) {
+	dir := t.TempDir()
+	snapDir := filepath.Join(dir, SnapsDir)
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	require.ErrorIs(t, SnapshotPostState(dir, 10), os.ErrNotExist, "no final state")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FinalState), []byte("state at 11"), 0o644))
+	require.NoError(t, SnapshotPostState(dir, 10))
+	snapshot, err := FindStartingSnapshot(logger, snapDir, "prestate", 20)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(snapDir, "11.json.gz"), snapshot)
+
+	// Replacing the final state, as the next run does, must not modify the snapshot.
+	require.NoError(t, os.Remove(filepath.Join(dir, FinalState)))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FinalState), []byte("state at 21"), 0o644))
+	data, err := os.ReadFile(snapshot)
+	require.NoError(t, err)
+	require.Equal(t, "state at 11", string(data))
+
+	require.NoError(t, SnapshotPostState(dir, 10), "existing snapshot")
+	data, err = os.ReadFile(snapshot)
+	require.NoError(t, err)
+	require.Equal(t, "state at 11", string(data))
+}
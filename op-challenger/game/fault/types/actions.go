@@ -1,6 +1,10 @@
 package types
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type ActionType string
 
@@ -28,6 +32,9 @@ type Action struct {
 	PreState   []byte
 	ProofData  []byte
 	OracleData *PreimageOracleData
+	// Deadline is the time by which the step must be included in the game before the chess clock of its parent claim
+	// expires, or the zero time if unknown.
+	Deadline time.Time
 
 	// Challenge L2 Block Number
 	InvalidL2BlockNumberChallenge *InvalidL2BlockNumberChallenge
@@ -0,0 +1,222 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/alerts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type Alerter interface {
+	Alert(ctx context.Context, alert alerts.Alert)
+}
+
+type WatchedGameContract interface {
+	GetGameMetadata(ctx context.Context, block rpcblock.Block) (contracts.GameMetadata, error)
+	GetAllClaims(ctx context.Context, block rpcblock.Block) ([]faultTypes.Claim, error)
+	GetMaxGameDepth(ctx context.Context) (faultTypes.Depth, error)
+}
+
+// WatchPlayer watches a game without ever acting on it. It validates the proposal against the local node and raises
+// alerts for invalid proposals, games where the honest outcome is at risk and games that resolve incorrectly.
+type WatchPlayer struct {
+	logger         log.Logger
+	l1Clock        faultTypes.ClockReader
+	addr           common.Address
+	contract       WatchedGameContract
+	rollupClient   outputs.OutputRollupClient
+	l1HeaderSource L1HeaderSource
+	alerter        Alerter
+
+	status      types.GameStatus
+	agree       *bool
+	forecast    types.GameForecast
+	clockExpiry time.Time
+}
+
+func NewWatchPlayer(logger log.Logger, l1Clock faultTypes.ClockReader, addr common.Address, contract WatchedGameContract, rollupClient outputs.OutputRollupClient, l1HeaderSource L1HeaderSource, alerter Alerter) *WatchPlayer {
+	return &WatchPlayer{
+		logger:         logger.New("game", addr),
+		l1Clock:        l1Clock,
+		addr:           addr,
+		contract:       contract,
+		rollupClient:   rollupClient,
+		l1HeaderSource: l1HeaderSource,
+		alerter:        alerter,
+		status:         types.GameStatusInProgress,
+	}
+}
+
+// ValidatePrestate always succeeds as no VM is run for watched games.
+func (w *WatchPlayer) ValidatePrestate(_ context.Context) error {
+	return nil
+}
+
+func (w *WatchPlayer) Status() types.GameStatus {
+	return w.status
+}
+
+func (w *WatchPlayer) ClockExpiry() time.Time {
+	return w.clockExpiry
+}
+
+func (w *WatchPlayer) Forecast() types.GameForecast {
+	return w.forecast
+}
+
+func (w *WatchPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	if w.status != types.GameStatusInProgress {
+		w.logger.Trace("Skipping completed game")
+		return w.status
+	}
+	metadata, err := w.contract.GetGameMetadata(ctx, rpcblock.Latest)
+	if err != nil {
+		w.logger.Error("Failed to load game metadata", "err", err)
+		return w.status
+	}
+	if w.agree == nil {
+		agree, err := w.validateProposal(ctx, metadata)
+		if err != nil {
+			w.logger.Error("Failed to validate proposal", "err", err)
+			return w.status
+		}
+		w.agree = &agree
+	}
+	honest := types.GameStatusChallengerWon
+	if *w.agree {
+		honest = types.GameStatusDefenderWon
+	}
+	if metadata.Status != types.GameStatusInProgress {
+		if metadata.Status != honest {
+			w.alerter.Alert(ctx, alerts.Alert{
+				Type:    alerts.TypeIncorrectResolution,
+				Game:    w.addr,
+				Message: "Game resolved differently to the outcome expected by the local node",
+				Details: map[string]any{"expected": honest.String(), "actual": metadata.Status.String()},
+			})
+		}
+		w.logger.Info("Game resolved", "status", metadata.Status)
+		w.status = metadata.Status
+		return w.status
+	}
+	maxClockDuration := time.Duration(metadata.MaxClockDuration) * time.Second
+	if err := w.checkForecast(ctx, honest, maxClockDuration); err != nil {
+		w.logger.Error("Failed to forecast game outcome", "err", err)
+	}
+	return w.status
+}
+
+// validateProposal returns true if the root claim of the game matches the output root of the local node at the
+// proposed block, or at the safe head as of the game's L1 head if the proposed block is not yet safe.
+func (w *WatchPlayer) validateProposal(ctx context.Context, metadata contracts.GameMetadata) (bool, error) {
+	l1Head, err := w.l1HeaderSource.HeaderByHash(ctx, metadata.L1Head)
+	if err != nil {
+		return false, fmt.Errorf("failed to load L1 header %v: %w", metadata.L1Head, err)
+	}
+	safeHead, err := w.rollupClient.SafeHeadAtL1Block(ctx, l1Head.Number.Uint64())
+	if err != nil {
+		return false, fmt.Errorf("failed to get safe head at L1 block %v: %w", l1Head.Number, err)
+	}
+	outputBlock := min(metadata.L2BlockNum, safeHead.SafeHead.Number)
+	output, err := w.rollupClient.OutputAtBlock(ctx, outputBlock)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch output at block %v: %w", outputBlock, err)
+	}
+	expected := common.Hash(output.OutputRoot)
+	if expected == metadata.RootClaim {
+		return true, nil
+	}
+	w.alerter.Alert(ctx, alerts.Alert{
+		Type:    alerts.TypeInvalidProposal,
+		Game:    w.addr,
+		Message: "Root claim does not match the output root of the local node",
+		Details: map[string]any{
+			"l2BlockNum": metadata.L2BlockNum,
+			"rootClaim":  metadata.RootClaim,
+			"expected":   expected,
+		},
+	})
+	return false, nil
+}
+
+// checkForecast raises an alert when the game becomes at risk of resolving incorrectly if no further moves are made,
+// and again if no chess clock has time remaining to change the outcome.
+func (w *WatchPlayer) checkForecast(ctx context.Context, honest types.GameStatus, maxClockDuration time.Duration) error {
+	claims, err := w.contract.GetAllClaims(ctx, rpcblock.Latest)
+	if err != nil {
+		return fmt.Errorf("failed to load claims: %w", err)
+	}
+	depth, err := w.contract.GetMaxGameDepth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load max game depth: %w", err)
+	}
+	now := w.l1Clock.Now()
+	game := faultTypes.NewGameState(claims, depth)
+	if expiry := earliestClockExpiry(game, now, maxClockDuration); !expiry.IsZero() {
+		w.clockExpiry = expiry
+	}
+	projected := forecastStatus(claims)
+	if projected == honest {
+		w.forecast = types.ForecastHonestWinning
+		return nil
+	}
+	forecast := types.ForecastHonestAtRisk
+	message := "Game would resolve incorrectly if no further moves are made"
+	remaining := remainingClock(claims, now, maxClockDuration)
+	if remaining <= 0 {
+		forecast = types.ForecastHonestLost
+		message = "Game will resolve incorrectly, no chess clock has time remaining"
+	}
+	if w.forecast != forecast {
+		w.alerter.Alert(ctx, alerts.Alert{
+			Type:    alerts.TypeHonestOutcomeAtRisk,
+			Game:    w.addr,
+			Message: message,
+			Details: map[string]any{
+				"honest":      honest.String(),
+				"projected":   projected.String(),
+				"clockExpiry": w.clockExpiry,
+				"remaining":   max(remaining, 0).String(),
+			},
+		})
+	}
+	w.forecast = forecast
+	return nil
+}
+
+// RegisterGameWatchers registers a WatchPlayer for the game type of each configured trace type, so games are
+// monitored without running a VM or sending transactions.
+func RegisterGameWatchers(
+	ctx context.Context,
+	l1Clock faultTypes.ClockReader,
+	m metrics.Metricer,
+	traceTypes []faultTypes.TraceType,
+	registry Registry,
+	rollupClient outputs.OutputRollupClient,
+	caller *batching.MultiCaller,
+	l1HeaderSource L1HeaderSource,
+	alerter Alerter,
+	logger log.Logger,
+) {
+	watcherCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		contract, err := contracts.NewFaultDisputeGameContract(ctx, m, game.Proxy, caller)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create fault dispute game contract: %w", err)
+		}
+		return NewWatchPlayer(logger, l1Clock, game.Proxy, contract, rollupClient, l1HeaderSource, alerter), nil
+	}
+	for _, traceType := range traceTypes {
+		registry.RegisterGameType(traceType.GameType(), watcherCreator)
+	}
+}
@@ -0,0 +1,216 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/alerts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	watchedGameAddr   = common.Address{0xbb}
+	watchedL1Head     = common.Hash{0x11}
+	watchedOutputRoot = common.Hash{0x22}
+)
+
+func TestWatchPlayer_ValidProposal(t *testing.T) {
+	player, contract, _, alerter := setupWatchPlayerTest(t, watchedOutputRoot)
+	require.Equal(t, gameTypes.GameStatusInProgress, player.ProgressGame(context.Background()))
+	require.Empty(t, alerter.alerts)
+	require.Equal(t, gameTypes.ForecastHonestWinning, player.Forecast())
+	require.Equal(t, l1Time.Add(time.Hour-time.Minute), player.ClockExpiry())
+
+	// Resolving as expected does not raise an alert
+	contract.metadata.Status = gameTypes.GameStatusDefenderWon
+	require.Equal(t, gameTypes.GameStatusDefenderWon, player.ProgressGame(context.Background()))
+	require.Equal(t, gameTypes.GameStatusDefenderWon, player.Status())
+	require.Empty(t, alerter.alerts)
+}
+
+func TestWatchPlayer_InvalidProposal(t *testing.T) {
+	player, _, _, alerter := setupWatchPlayerTest(t, common.Hash{0xaa})
+	require.Equal(t, gameTypes.GameStatusInProgress, player.ProgressGame(context.Background()))
+	require.Len(t, alerter.alerts, 2)
+	require.Equal(t, alerts.TypeInvalidProposal, alerter.alerts[0].Type)
+	require.Equal(t, watchedGameAddr, alerter.alerts[0].Game)
+	require.Equal(t, watchedOutputRoot, alerter.alerts[0].Details["expected"])
+	// The invalid root claim is uncountered so the honest outcome is at risk
+	require.Equal(t, alerts.TypeHonestOutcomeAtRisk, alerter.alerts[1].Type)
+	require.Equal(t, gameTypes.ForecastHonestAtRisk, player.Forecast())
+
+	// Alerts are only raised once
+	require.Equal(t, gameTypes.GameStatusInProgress, player.ProgressGame(context.Background()))
+	require.Len(t, alerter.alerts, 2)
+}
+
+func TestWatchPlayer_UseSafeHeadWhenProposalNotSafe(t *testing.T) {
+	player, contract, rollup, alerter := setupWatchPlayerTest(t, watchedOutputRoot)
+	contract.metadata.L2BlockNum = 500
+	rollup.safeHead = 100
+	player.ProgressGame(context.Background())
+	require.Empty(t, alerter.alerts)
+	require.Equal(t, uint64(100), rollup.requestedBlock)
+}
+
+func TestWatchPlayer_HonestOutcomeAtRisk(t *testing.T) {
+	player, contract, _, alerter := setupWatchPlayerTest(t, watchedOutputRoot)
+	player.ProgressGame(context.Background())
+	require.Empty(t, alerter.alerts)
+
+	clk := test.WithClock(l1Time.Add(-time.Minute), 0)
+	seq := contract.gameBuilder.Seq().Attack(test.WithValue(common.Hash{0xaa}), clk)
+	player.ProgressGame(context.Background())
+	require.Len(t, alerter.alerts, 1)
+	require.Equal(t, alerts.TypeHonestOutcomeAtRisk, alerter.alerts[0].Type)
+	require.Equal(t, gameTypes.ForecastHonestAtRisk, player.Forecast())
+
+	// Alerts again only after recovering
+	player.ProgressGame(context.Background())
+	require.Len(t, alerter.alerts, 1)
+	seq.Attack(clk)
+	player.ProgressGame(context.Background())
+	require.Equal(t, gameTypes.ForecastHonestWinning, player.Forecast())
+	contract.gameBuilder.Seq().Attack(test.WithValue(common.Hash{0xcc}), clk)
+	player.ProgressGame(context.Background())
+	require.Len(t, alerter.alerts, 2)
+}
+
+func TestWatchPlayer_HonestOutcomeLost(t *testing.T) {
+	player, contract, _, alerter := setupWatchPlayerTest(t, watchedOutputRoot)
+	contract.gameBuilder.Seq().Attack(test.WithValue(common.Hash{0xaa}), test.WithClock(l1Time.Add(-time.Minute), 0))
+	player.ProgressGame(context.Background())
+	require.Equal(t, gameTypes.ForecastHonestAtRisk, player.Forecast())
+	require.Len(t, alerter.alerts, 1)
+
+	// Alerts again once the clock to counter the invalid claim has expired
+	contract.metadata.MaxClockDuration = uint64(time.Minute.Seconds())
+	player.ProgressGame(context.Background())
+	require.Equal(t, gameTypes.ForecastHonestLost, player.Forecast())
+	require.Len(t, alerter.alerts, 2)
+	require.Equal(t, alerts.TypeHonestOutcomeAtRisk, alerter.alerts[1].Type)
+}
+
+func TestWatchPlayer_IncorrectResolution(t *testing.T) {
+	player, contract, _, alerter := setupWatchPlayerTest(t, watchedOutputRoot)
+	contract.metadata.Status = gameTypes.GameStatusChallengerWon
+	require.Equal(t, gameTypes.GameStatusChallengerWon, player.ProgressGame(context.Background()))
+	require.Len(t, alerter.alerts, 1)
+	require.Equal(t, alerts.TypeIncorrectResolution, alerter.alerts[0].Type)
+
+	// Resolved games are not checked again
+	require.Equal(t, gameTypes.GameStatusChallengerWon, player.ProgressGame(context.Background()))
+	require.Len(t, alerter.alerts, 1)
+}
+
+func TestWatchPlayer_RetryValidationOnError(t *testing.T) {
+	player, _, rollup, alerter := setupWatchPlayerTest(t, common.Hash{0xaa})
+	logger, logs := testlog.CaptureLogger(t, log.LevelError)
+	player.logger = logger
+	rollup.err = errors.New("boom")
+	require.Equal(t, gameTypes.GameStatusInProgress, player.ProgressGame(context.Background()))
+	require.Empty(t, alerter.alerts)
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Failed to validate proposal")))
+	require.Equal(t, gameTypes.ForecastUnknown, player.Forecast())
+
+	rollup.err = nil
+	player.ProgressGame(context.Background())
+	require.Equal(t, alerts.TypeInvalidProposal, alerter.alerts[0].Type)
+}
+
+func setupWatchPlayerTest(t *testing.T, rootClaim common.Hash) (*WatchPlayer, *stubWatchedGame, *stubWatchRollupClient, *stubAlerter) {
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	contract := &stubWatchedGame{
+		metadata: contracts.GameMetadata{
+			L1Head:           watchedL1Head,
+			L2BlockNum:       50,
+			RootClaim:        rootClaim,
+			Status:           gameTypes.GameStatusInProgress,
+			MaxClockDuration: uint64(time.Hour.Seconds()),
+		},
+		depth:       depth,
+		gameBuilder: claimBuilder.GameBuilder(test.WithClock(l1Time.Add(-time.Minute), 0)),
+	}
+	rollup := &stubWatchRollupClient{safeHead: 1000}
+	l1Headers := &stubWatchL1Headers{}
+	alerter := &stubAlerter{}
+	l1Clock := clock.NewDeterministicClock(l1Time)
+	player := NewWatchPlayer(testlog.Logger(t, log.LevelInfo), l1Clock, watchedGameAddr, contract, rollup, l1Headers, alerter)
+	return player, contract, rollup, alerter
+}
+
+type stubWatchedGame struct {
+	metadata    contracts.GameMetadata
+	depth       types.Depth
+	gameBuilder *test.GameBuilder
+}
+
+func (s *stubWatchedGame) GetGameMetadata(_ context.Context, _ rpcblock.Block) (contracts.GameMetadata, error) {
+	return s.metadata, nil
+}
+
+func (s *stubWatchedGame) GetAllClaims(_ context.Context, _ rpcblock.Block) ([]types.Claim, error) {
+	return s.gameBuilder.Game.Claims(), nil
+}
+
+func (s *stubWatchedGame) GetMaxGameDepth(_ context.Context) (types.Depth, error) {
+	return s.depth, nil
+}
+
+type stubWatchRollupClient struct {
+	safeHead       uint64
+	requestedBlock uint64
+	err            error
+}
+
+func (s *stubWatchRollupClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.requestedBlock = blockNum
+	return &eth.OutputResponse{OutputRoot: eth.Bytes32(watchedOutputRoot)}, nil
+}
+
+func (s *stubWatchRollupClient) SafeHeadAtL1Block(_ context.Context, l1BlockNum uint64) (*eth.SafeHeadResponse, error) {
+	return &eth.SafeHeadResponse{
+		L1Block:  eth.BlockID{Number: l1BlockNum},
+		SafeHead: eth.BlockID{Number: s.safeHead},
+	}, nil
+}
+
+type stubWatchL1Headers struct{}
+
+func (s *stubWatchL1Headers) HeaderByHash(_ context.Context, hash common.Hash) (*gethTypes.Header, error) {
+	if hash != watchedL1Head {
+		return nil, errors.New("unknown header")
+	}
+	return &gethTypes.Header{Number: big.NewInt(1234)}, nil
+}
+
+func (s *stubWatchL1Headers) HeaderByNumber(_ context.Context, _ *big.Int) (*gethTypes.Header, error) {
+	return nil, errors.New("not implemented")
+}
+
+type stubAlerter struct {
+	alerts []alerts.Alert
+}
+
+func (s *stubAlerter) Alert(_ context.Context, alert alerts.Alert) {
+	s.alerts = append(s.alerts, alert)
+}
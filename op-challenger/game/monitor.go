@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/health"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -19,6 +21,10 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// monitorStallTimeout is how long the game monitor may go without processing an L1 head,
+// before it is reported as not live, see gameMonitor.Liveness.
+const monitorStallTimeout = 10 * time.Minute
+
 // gameSource loads information about the games available to play
 type gameSource interface {
 	GetGamesAtOrAfter(ctx context.Context, blockHash common.Hash, earliestTimestamp uint64) ([]types.GameMetadata, error)
@@ -41,6 +47,10 @@ type claimer interface {
 	Schedule(blockNumber uint64, games []types.GameMetadata) error
 }
 
+type proposalScanner interface {
+	Schedule(blockNumber uint64, games []types.GameMetadata) error
+}
+
 type gameMonitor struct {
 	logger       log.Logger
 	clock        RWClock
@@ -49,10 +59,14 @@ type gameMonitor struct {
 	preimages    preimageScheduler
 	gameWindow   time.Duration
 	claimer      claimer
+	scanner      proposalScanner
 	allowedGames []common.Address
 	l1HeadsSub   ethereum.Subscription
 	l1Source     *headSource
 	runState     sync.Mutex
+	// lastHead is the wall-clock time in unix nanoseconds at which the last L1 head was processed,
+	// or at which monitoring started.
+	lastHead atomic.Int64
 }
 
 type MinimalSubscriber interface {
@@ -75,6 +89,7 @@ func newGameMonitor(
 	preimages preimageScheduler,
 	gameWindow time.Duration,
 	claimer claimer,
+	scanner proposalScanner,
 	allowedGames []common.Address,
 	l1Source MinimalSubscriber,
 ) *gameMonitor {
@@ -86,6 +101,7 @@ func newGameMonitor(
 		source:       source,
 		gameWindow:   gameWindow,
 		claimer:      claimer,
+		scanner:      scanner,
 		allowedGames: allowedGames,
 		l1Source:     &headSource{inner: l1Source},
 	}
@@ -109,6 +125,12 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash,
 	if err != nil {
 		return fmt.Errorf("failed to load games: %w", err)
 	}
+	// Proposals are scanned regardless of the allow list so the verdicts cover every published output root
+	if m.scanner != nil {
+		if err := m.scanner.Schedule(blockNumber, games); err != nil {
+			return fmt.Errorf("failed to schedule proposal scan: %w", err)
+		}
+	}
 	var gamesToPlay []types.GameMetadata
 	for _, game := range games {
 		if !m.allowedGame(game.Proxy) {
@@ -117,8 +139,11 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash,
 		}
 		gamesToPlay = append(gamesToPlay, game)
 	}
-	if err := m.claimer.Schedule(blockNumber, gamesToPlay); err != nil {
-		return fmt.Errorf("failed to schedule bond claims: %w", err)
+	// Bonds are not claimed when only monitoring games
+	if m.claimer != nil {
+		if err := m.claimer.Schedule(blockNumber, gamesToPlay); err != nil {
+			return fmt.Errorf("failed to schedule bond claims: %w", err)
+		}
 	}
 	if err := m.scheduler.Schedule(gamesToPlay, blockNumber); errors.Is(err, scheduler.ErrBusy) {
 		m.logger.Info("Scheduler still busy with previous update")
@@ -129,10 +154,14 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash,
 }
 
 func (m *gameMonitor) onNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
+	defer m.lastHead.Store(time.Now().UnixNano())
 	m.clock.SetTime(sig.Time)
 	if err := m.progressGames(ctx, sig.Hash, sig.Number); err != nil {
 		m.logger.Error("Failed to progress games", "err", err)
 	}
+	if m.preimages == nil {
+		return
+	}
 	if err := m.preimages.Schedule(sig.Hash, sig.Number); err != nil {
 		m.logger.Error("Failed to validate large preimages", "err", err)
 	}
@@ -155,6 +184,7 @@ func (m *gameMonitor) StartMonitoring() {
 	if m.l1HeadsSub != nil {
 		return // already started
 	}
+	m.lastHead.Store(time.Now().UnixNano())
 	m.l1HeadsSub = event.ResubscribeErr(time.Second*10, m.resubscribeFunction())
 }
 
@@ -167,3 +197,18 @@ func (m *gameMonitor) StopMonitoring() {
 	m.l1HeadsSub.Unsubscribe()
 	m.l1HeadsSub = nil
 }
+
+// Liveness is the liveness probe of the game monitor, which is failing if the monitor is running,
+// but did not process an L1 head for the monitorStallTimeout, e.g. because it is stuck progressing the games.
+func (m *gameMonitor) Liveness(ctx context.Context) health.Result {
+	m.runState.Lock()
+	running := m.l1HeadsSub != nil
+	m.runState.Unlock()
+	if !running {
+		return health.OK()
+	}
+	if since := time.Since(time.Unix(0, m.lastHead.Load())); since > monitorStallTimeout {
+		return health.Failing("no L1 head processed for %v", since.Truncate(time.Second))
+	}
+	return health.OK()
+}
@@ -18,6 +18,8 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/health"
 )
 
 // TestMonitorGames tests that the monitor can handle a new head event
@@ -142,6 +144,20 @@ func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	require.Equal(t, 1, stubClaimer.scheduledGames)
 }
 
+func TestMonitorScansAllGames(t *testing.T) {
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	monitor, source, sched, _, _, _ := setupMonitorTest(t, []common.Address{addr2})
+	scanner := &mockScheduler{}
+	monitor.scanner = scanner
+	source.games = []types.GameMetadata{newFDG(addr1, 9999), newFDG(addr2, 9999)}
+
+	require.NoError(t, monitor.progressGames(context.Background(), common.Hash{0x01}, 0))
+
+	require.Equal(t, []common.Address{addr2}, sched.Scheduled()[0])
+	require.Equal(t, 2, scanner.scheduledGames)
+}
+
 func newFDG(proxy common.Address, timestamp uint64) types.GameMetadata {
 	return types.GameMetadata{
 		Proxy:     proxy,
@@ -149,6 +165,21 @@ func newFDG(proxy common.Address, timestamp uint64) types.GameMetadata {
 	}
 }
 
+func TestMonitorLiveness(t *testing.T) {
+	monitor, _, _, _, _, _ := setupMonitorTest(t, []common.Address{})
+	require.Equal(t, health.StatusOK, monitor.Liveness(context.Background()).Status, "not running")
+
+	monitor.StartMonitoring()
+	defer monitor.StopMonitoring()
+	require.Equal(t, health.StatusOK, monitor.Liveness(context.Background()).Status, "just started")
+
+	monitor.lastHead.Store(time.Now().Add(-monitorStallTimeout - time.Minute).UnixNano())
+	require.Equal(t, health.StatusFailing, monitor.Liveness(context.Background()).Status, "stalled")
+
+	monitor.onNewL1Head(context.Background(), eth.L1BlockRef{Hash: common.Hash{0x01}})
+	require.Equal(t, health.StatusOK, monitor.Liveness(context.Background()).Status, "processed a head")
+}
+
 func setupMonitorTest(
 	t *testing.T,
 	allowedGames []common.Address,
@@ -167,6 +198,7 @@ func setupMonitorTest(
 		preimages,
 		time.Duration(0),
 		stubClaimer,
+		nil,
 		allowedGames,
 		mockHeadSource,
 	)
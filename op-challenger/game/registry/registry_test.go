@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -82,3 +84,11 @@ func (s *stubBondContract) GetCredit(_ context.Context, _ common.Address) (*big.
 func (s *stubBondContract) ClaimCreditTx(_ context.Context, _ common.Address) (txmgr.TxCandidate, error) {
 	panic("not supported")
 }
+
+func (s *stubBondContract) GetClaimCountAndCredits(_ context.Context, _ rpcblock.Block, _ ...common.Address) (uint64, types.GameStatus, []*big.Int, error) {
+	panic("not supported")
+}
+
+func (s *stubBondContract) GetAllClaims(_ context.Context, _ rpcblock.Block) ([]faultTypes.Claim, error) {
+	panic("not supported")
+}
@@ -0,0 +1,340 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/alerts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxOracleOutputsPerScan limits the number of new oracle outputs loaded in a single scan, so the initial scan of an
+// oracle with a long history is spread across several L1 blocks.
+const maxOracleOutputsPerScan = 100
+
+// maxOracleVerdicts is the number of most recent oracle outputs that valid verdicts are kept for.
+// Verdicts of older valid outputs are dropped, while invalid verdicts are kept until the output is deleted.
+const maxOracleVerdicts = 1000
+
+type ProposalType string
+
+const (
+	ProposalTypeGame   ProposalType = "game"
+	ProposalTypeOracle ProposalType = "oracle"
+)
+
+type Verdict string
+
+const (
+	// VerdictPending is the verdict of proposals for blocks the local node has not yet marked safe.
+	VerdictPending Verdict = "pending"
+	VerdictValid   Verdict = "valid"
+	VerdictInvalid Verdict = "invalid"
+)
+
+// ProposalVerdict is the verdict of an output root published by a dispute game or the L2OutputOracle. Source is the
+// address of the game or oracle and Index is the index of the game in the factory or of the output in the oracle.
+type ProposalVerdict struct {
+	Type       ProposalType   `json:"type"`
+	Source     common.Address `json:"source"`
+	Index      uint64         `json:"index"`
+	L2BlockNum uint64         `json:"l2BlockNum"`
+	OutputRoot common.Hash    `json:"outputRoot"`
+	Expected   common.Hash    `json:"expected"`
+	Verdict    Verdict        `json:"verdict"`
+}
+
+func (v ProposalVerdict) key() proposalKey {
+	return proposalKey{v.Source, v.Index}
+}
+
+type Metrics interface {
+	RecordProposalVerdicts(valid, invalid, pending int)
+}
+
+type RollupClient interface {
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+type Alerter interface {
+	Alert(ctx context.Context, alert alerts.Alert)
+}
+
+type GameContract interface {
+	GetGameMetadata(ctx context.Context, block rpcblock.Block) (contracts.GameMetadata, error)
+}
+
+type GameContractCreator func(game types.GameMetadata) (GameContract, error)
+
+type OutputOracle interface {
+	Addr() common.Address
+	NextOutputIndex(ctx context.Context, block rpcblock.Block) (uint64, error)
+	GetOutputProposals(ctx context.Context, block rpcblock.Block, start uint64, end uint64) ([]contracts.OutputProposal, error)
+}
+
+type scanMessage struct {
+	blockNumber uint64
+	games       []types.GameMetadata
+}
+
+// Scanner compares the output roots published by dispute games and the L2OutputOracle against the output roots of
+// the local node, keeping a persistent verdict for each proposal. Proposals for blocks beyond the local safe head are
+// pending and are checked again on later scans, while valid and invalid verdicts are final.
+// Verdicts of games that are no longer scanned, i.e. that left the game window, and of old valid oracle outputs
+// are dropped.
+type Scanner struct {
+	logger       log.Logger
+	metrics      Metrics
+	store        *VerdictStore
+	rollupClient RollupClient
+	gameCreator  GameContractCreator
+	oracle       OutputOracle
+	alerter      Alerter
+	ch           chan scanMessage
+	cancel       func()
+	wg           sync.WaitGroup
+}
+
+// NewScanner creates a Scanner. The oracle is optional and, if nil, only the output roots of games are scanned.
+// Invalid oracle outputs are alerted with the alerter. Games are not alerted, as they are watched by their players.
+func NewScanner(logger log.Logger, m Metrics, store *VerdictStore, rollupClient RollupClient, gameCreator GameContractCreator, oracle OutputOracle, alerter Alerter) *Scanner {
+	return &Scanner{
+		logger:       logger,
+		metrics:      m,
+		store:        store,
+		rollupClient: rollupClient,
+		gameCreator:  gameCreator,
+		oracle:       oracle,
+		alerter:      alerter,
+		ch:           make(chan scanMessage, 1),
+	}
+}
+
+func (s *Scanner) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+func (s *Scanner) Close() error {
+	if s.cancel == nil {
+		return nil // never started
+	}
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Scanner) run(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-s.ch:
+			if err := s.scan(ctx, msg.games); err != nil {
+				s.logger.Error("Failed to scan proposals", "blockNumber", msg.blockNumber, "err", err)
+			}
+		}
+	}
+}
+
+// Schedule queues a scan of the games and of any new oracle outputs, skipping it if a scan is already in progress.
+func (s *Scanner) Schedule(blockNumber uint64, games []types.GameMetadata) error {
+	select {
+	case s.ch <- scanMessage{blockNumber, games}:
+	default:
+		s.logger.Trace("Skipping proposal scan while scan in progress")
+	}
+	return nil
+}
+
+// Verdict returns the verdict of the proposal with the given index from source, which is a game or the oracle.
+func (s *Scanner) Verdict(source common.Address, index uint64) (ProposalVerdict, bool) {
+	return s.store.Get(source, index)
+}
+
+// Verdicts returns the verdicts of all scanned proposals.
+func (s *Scanner) Verdicts() []ProposalVerdict {
+	return s.store.All()
+}
+
+func (s *Scanner) scan(ctx context.Context, games []types.GameMetadata) error {
+	var err error
+	var proposals []ProposalVerdict
+	for _, game := range games {
+		if _, ok := s.store.Get(game.Proxy, game.Index); ok {
+			continue
+		}
+		proposal, gameErr := s.gameProposal(ctx, game)
+		if gameErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to load proposal of game %v: %w", game.Proxy, gameErr))
+			continue
+		}
+		proposals = append(proposals, proposal)
+	}
+	if s.oracle != nil {
+		oracleProposals, oracleErr := s.oracleProposals(ctx)
+		if oracleErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to load oracle proposals: %w", oracleErr))
+		}
+		proposals = append(proposals, oracleProposals...)
+	}
+	if putErr := s.store.Put(proposals...); putErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to store proposals: %w", putErr))
+	}
+	if checkErr := s.checkPending(ctx); checkErr != nil {
+		err = errors.Join(err, checkErr)
+	}
+	if pruneErr := s.prune(games); pruneErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to prune verdicts: %w", pruneErr))
+	}
+	s.recordMetrics()
+	return err
+}
+
+func (s *Scanner) gameProposal(ctx context.Context, game types.GameMetadata) (ProposalVerdict, error) {
+	contract, err := s.gameCreator(game)
+	if err != nil {
+		return ProposalVerdict{}, fmt.Errorf("failed to create contract: %w", err)
+	}
+	metadata, err := contract.GetGameMetadata(ctx, rpcblock.Latest)
+	if err != nil {
+		return ProposalVerdict{}, fmt.Errorf("failed to load metadata: %w", err)
+	}
+	return ProposalVerdict{
+		Type:       ProposalTypeGame,
+		Source:     game.Proxy,
+		Index:      game.Index,
+		L2BlockNum: metadata.L2BlockNum,
+		OutputRoot: metadata.RootClaim,
+		Verdict:    VerdictPending,
+	}, nil
+}
+
+func (s *Scanner) oracleProposals(ctx context.Context) ([]ProposalVerdict, error) {
+	next, err := s.oracle.NextOutputIndex(ctx, rpcblock.Latest)
+	if err != nil {
+		return nil, err
+	}
+	start := s.store.NextIndex(s.oracle.Addr())
+	if next < start {
+		s.logger.Warn("Oracle outputs were deleted, dropping their verdicts", "next", next, "scanned", start)
+		if err := s.store.Truncate(s.oracle.Addr(), next); err != nil {
+			return nil, fmt.Errorf("failed to drop verdicts of deleted outputs: %w", err)
+		}
+		start = next
+	}
+	end := min(next, start+maxOracleOutputsPerScan)
+	outputs, err := s.oracle.GetOutputProposals(ctx, rpcblock.Latest, start, end)
+	if err != nil {
+		return nil, err
+	}
+	proposals := make([]ProposalVerdict, 0, len(outputs))
+	for _, output := range outputs {
+		proposals = append(proposals, ProposalVerdict{
+			Type:       ProposalTypeOracle,
+			Source:     s.oracle.Addr(),
+			Index:      output.Index,
+			L2BlockNum: output.L2BlockNumber,
+			OutputRoot: output.OutputRoot,
+			Verdict:    VerdictPending,
+		})
+	}
+	return proposals, nil
+}
+
+// checkPending compares the output roots of pending proposals at or below the local safe head against the local node.
+func (s *Scanner) checkPending(ctx context.Context) error {
+	status, err := s.rollupClient.SyncStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sync status: %w", err)
+	}
+	safeHead := status.SafeL2.Number
+	var checked []ProposalVerdict
+	for _, verdict := range s.store.All() {
+		if verdict.Verdict != VerdictPending || verdict.L2BlockNum > safeHead {
+			continue
+		}
+		output, outputErr := s.rollupClient.OutputAtBlock(ctx, verdict.L2BlockNum)
+		if outputErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to fetch output at block %v: %w", verdict.L2BlockNum, outputErr))
+			continue
+		}
+		verdict.Expected = common.Hash(output.OutputRoot)
+		if verdict.Expected == verdict.OutputRoot {
+			verdict.Verdict = VerdictValid
+		} else {
+			verdict.Verdict = VerdictInvalid
+			s.logger.Warn("Found invalid proposal", "type", verdict.Type, "source", verdict.Source, "index", verdict.Index,
+				"l2BlockNum", verdict.L2BlockNum, "outputRoot", verdict.OutputRoot, "expected", verdict.Expected)
+			if verdict.Type == ProposalTypeOracle {
+				s.alerter.Alert(ctx, alerts.Alert{
+					Type:    alerts.TypeInvalidOracleOutput,
+					Game:    verdict.Source,
+					Message: "L2OutputOracle output does not match the output root of the local node",
+					Details: map[string]any{
+						"index":      verdict.Index,
+						"l2BlockNum": verdict.L2BlockNum,
+						"outputRoot": verdict.OutputRoot,
+						"expected":   verdict.Expected,
+					},
+				})
+			}
+		}
+		checked = append(checked, verdict)
+	}
+	if putErr := s.store.Put(checked...); putErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to store verdicts: %w", putErr))
+	}
+	return err
+}
+
+// prune drops the verdicts of games that are not in the scanned games, and of valid oracle outputs more than
+// maxOracleVerdicts before the next oracle output.
+func (s *Scanner) prune(games []types.GameMetadata) error {
+	scanned := make(map[proposalKey]bool, len(games))
+	for _, game := range games {
+		scanned[proposalKey{game.Proxy, game.Index}] = true
+	}
+	var oracleCutoff uint64
+	if s.oracle != nil {
+		if next := s.store.NextIndex(s.oracle.Addr()); next > maxOracleVerdicts {
+			oracleCutoff = next - maxOracleVerdicts
+		}
+	}
+	return s.store.Prune(func(verdict ProposalVerdict) bool {
+		switch verdict.Type {
+		case ProposalTypeGame:
+			return scanned[verdict.key()]
+		case ProposalTypeOracle:
+			return verdict.Verdict != VerdictValid || verdict.Index >= oracleCutoff
+		default:
+			return true
+		}
+	})
+}
+
+func (s *Scanner) recordMetrics() {
+	var valid, invalid, pending int
+	for _, verdict := range s.store.All() {
+		switch verdict.Verdict {
+		case VerdictValid:
+			valid++
+		case VerdictInvalid:
+			invalid++
+		default:
+			pending++
+		}
+	}
+	s.metrics.RecordProposalVerdicts(valid, invalid, pending)
+}
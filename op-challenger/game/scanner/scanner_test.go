@@ -0,0 +1,273 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	opalerts "github.com/ethereum-optimism/optimism/op-challenger/alerts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oracleAddr = common.Address{0xaa}
+	game1      = types.GameMetadata{Index: 1, Proxy: common.Address{0x01}}
+	game2      = types.GameMetadata{Index: 2, Proxy: common.Address{0x02}}
+)
+
+func TestScanner_ValidatesGames(t *testing.T) {
+	scanner, rollup, gameContracts, _, m := setupTestScanner(t, false)
+	gameContracts[game1.Proxy] = &stubGameContract{l2BlockNum: 100, rootClaim: outputRoot(100)}
+	gameContracts[game2.Proxy] = &stubGameContract{l2BlockNum: 200, rootClaim: common.Hash{0xba, 0xd0}}
+	rollup.safeHead = 300
+
+	require.NoError(t, scanner.scan(context.Background(), []types.GameMetadata{game1, game2}))
+	requireVerdict(t, scanner, game1.Proxy, game1.Index, VerdictValid, outputRoot(100))
+	requireVerdict(t, scanner, game2.Proxy, game2.Index, VerdictInvalid, outputRoot(200))
+	require.Equal(t, [3]int{1, 1, 0}, m.verdicts)
+	require.Empty(t, scanner.alerter.(*stubAlerter).alerts, "games are alerted by their players")
+}
+
+func TestScanner_PendingUntilSafe(t *testing.T) {
+	scanner, rollup, gameContracts, _, m := setupTestScanner(t, false)
+	gameContracts[game1.Proxy] = &stubGameContract{l2BlockNum: 100, rootClaim: outputRoot(100)}
+	rollup.safeHead = 99
+
+	require.NoError(t, scanner.scan(context.Background(), []types.GameMetadata{game1}))
+	requireVerdict(t, scanner, game1.Proxy, game1.Index, VerdictPending, common.Hash{})
+	require.Equal(t, [3]int{0, 0, 1}, m.verdicts)
+
+	rollup.safeHead = 100
+	require.NoError(t, scanner.scan(context.Background(), []types.GameMetadata{game1}))
+	requireVerdict(t, scanner, game1.Proxy, game1.Index, VerdictValid, outputRoot(100))
+	require.Equal(t, [3]int{1, 0, 0}, m.verdicts)
+	// Game metadata is only loaded once
+	require.Equal(t, 1, gameContracts[game1.Proxy].loads)
+}
+
+func TestScanner_DoNotRecheckFinalVerdicts(t *testing.T) {
+	scanner, rollup, gameContracts, _, _ := setupTestScanner(t, false)
+	gameContracts[game1.Proxy] = &stubGameContract{l2BlockNum: 100, rootClaim: outputRoot(100)}
+	rollup.safeHead = 100
+
+	require.NoError(t, scanner.scan(context.Background(), []types.GameMetadata{game1}))
+	require.Equal(t, 1, rollup.outputRequests)
+	require.NoError(t, scanner.scan(context.Background(), []types.GameMetadata{game1}))
+	require.Equal(t, 1, rollup.outputRequests)
+}
+
+func TestScanner_ScansOracleOutputs(t *testing.T) {
+	scanner, rollup, _, oracle, _ := setupTestScanner(t, true)
+	rollup.safeHead = 10_000
+	for i := uint64(0); i < maxOracleOutputsPerScan+5; i++ {
+		root := outputRoot(i * 10)
+		if i == 3 {
+			root = common.Hash{0xba, 0xd0}
+		}
+		oracle.outputs = append(oracle.outputs, contracts.OutputProposal{Index: i, L2BlockNumber: i * 10, OutputRoot: root})
+	}
+
+	require.NoError(t, scanner.scan(context.Background(), nil))
+	require.Len(t, scanner.Verdicts(), maxOracleOutputsPerScan)
+	requireVerdict(t, scanner, oracleAddr, 2, VerdictValid, outputRoot(20))
+	requireVerdict(t, scanner, oracleAddr, 3, VerdictInvalid, outputRoot(30))
+	alerts := scanner.alerter.(*stubAlerter).alerts
+	require.Len(t, alerts, 1)
+	require.Equal(t, opalerts.TypeInvalidOracleOutput, alerts[0].Type)
+	require.Equal(t, oracleAddr, alerts[0].Game)
+	require.EqualValues(t, 3, alerts[0].Details["index"])
+
+	// Continues from the last scanned output
+	require.NoError(t, scanner.scan(context.Background(), nil))
+	require.Len(t, scanner.Verdicts(), maxOracleOutputsPerScan+5)
+	requireVerdict(t, scanner, oracleAddr, maxOracleOutputsPerScan+4, VerdictValid, outputRoot((maxOracleOutputsPerScan+4)*10))
+}
+
+func TestScanner_PrunesGamesNoLongerScanned(t *testing.T) {
+	scanner, rollup, gameContracts, _, m := setupTestScanner(t, false)
+	gameContracts[game1.Proxy] = &stubGameContract{l2BlockNum: 100, rootClaim: outputRoot(100)}
+	gameContracts[game2.Proxy] = &stubGameContract{l2BlockNum: 200, rootClaim: outputRoot(200)}
+	rollup.safeHead = 300
+	require.NoError(t, scanner.scan(context.Background(), []types.GameMetadata{game1, game2}))
+	require.Len(t, scanner.Verdicts(), 2)
+
+	// game1 left the game window
+	require.NoError(t, scanner.scan(context.Background(), []types.GameMetadata{game2}))
+	_, ok := scanner.Verdict(game1.Proxy, game1.Index)
+	require.False(t, ok)
+	requireVerdict(t, scanner, game2.Proxy, game2.Index, VerdictValid, outputRoot(200))
+	require.Equal(t, [3]int{1, 0, 0}, m.verdicts)
+}
+
+func TestScanner_PrunesOldValidOracleOutputs(t *testing.T) {
+	scanner, rollup, _, oracle, _ := setupTestScanner(t, true)
+	rollup.safeHead = 100_000
+	for i := uint64(0); i < maxOracleVerdicts+10; i++ {
+		root := outputRoot(i)
+		if i == 3 {
+			root = common.Hash{0xba, 0xd0}
+		}
+		oracle.outputs = append(oracle.outputs, contracts.OutputProposal{Index: i, L2BlockNumber: i, OutputRoot: root})
+	}
+	for scanner.store.NextIndex(oracleAddr) < uint64(len(oracle.outputs)) {
+		require.NoError(t, scanner.scan(context.Background(), nil))
+	}
+
+	// Invalid verdicts are kept, only the most recent valid verdicts are kept
+	require.Len(t, scanner.Verdicts(), maxOracleVerdicts+1)
+	requireVerdict(t, scanner, oracleAddr, 3, VerdictInvalid, outputRoot(3))
+	_, ok := scanner.Verdict(oracleAddr, 9)
+	require.False(t, ok)
+	requireVerdict(t, scanner, oracleAddr, 10, VerdictValid, outputRoot(10))
+
+	// Pruned outputs are not scanned again
+	require.NoError(t, scanner.scan(context.Background(), nil))
+	require.Len(t, scanner.Verdicts(), maxOracleVerdicts+1)
+}
+
+func TestScanner_RescansDeletedOracleOutputs(t *testing.T) {
+	scanner, rollup, _, oracle, _ := setupTestScanner(t, true)
+	rollup.safeHead = 1000
+	for i := uint64(0); i < 5; i++ {
+		oracle.outputs = append(oracle.outputs, contracts.OutputProposal{Index: i, L2BlockNumber: i * 10, OutputRoot: outputRoot(i * 10)})
+	}
+	oracle.outputs[3].OutputRoot = common.Hash{0xba, 0xd0}
+	require.NoError(t, scanner.scan(context.Background(), nil))
+	requireVerdict(t, scanner, oracleAddr, 3, VerdictInvalid, outputRoot(30))
+
+	// The invalid outputs are deleted and replaced
+	oracle.outputs = oracle.outputs[:3]
+	require.NoError(t, scanner.scan(context.Background(), nil))
+	_, ok := scanner.Verdict(oracleAddr, 3)
+	require.False(t, ok)
+	oracle.outputs = append(oracle.outputs, contracts.OutputProposal{Index: 3, L2BlockNumber: 30, OutputRoot: outputRoot(30)})
+	require.NoError(t, scanner.scan(context.Background(), nil))
+	requireVerdict(t, scanner, oracleAddr, 3, VerdictValid, outputRoot(30))
+}
+
+func TestScanner_RetryGamesThatFailToLoad(t *testing.T) {
+	scanner, rollup, gameContracts, _, _ := setupTestScanner(t, false)
+	gameContracts[game1.Proxy] = &stubGameContract{err: errors.New("boom")}
+	gameContracts[game2.Proxy] = &stubGameContract{l2BlockNum: 200, rootClaim: outputRoot(200)}
+	rollup.safeHead = 300
+
+	err := scanner.scan(context.Background(), []types.GameMetadata{game1, game2})
+	require.ErrorContains(t, err, "boom")
+	_, ok := scanner.Verdict(game1.Proxy, game1.Index)
+	require.False(t, ok)
+	requireVerdict(t, scanner, game2.Proxy, game2.Index, VerdictValid, outputRoot(200))
+
+	gameContracts[game1.Proxy] = &stubGameContract{l2BlockNum: 100, rootClaim: outputRoot(100)}
+	require.NoError(t, scanner.scan(context.Background(), []types.GameMetadata{game1, game2}))
+	requireVerdict(t, scanner, game1.Proxy, game1.Index, VerdictValid, outputRoot(100))
+}
+
+func TestScanner_PersistsVerdicts(t *testing.T) {
+	scanner, rollup, gameContracts, _, _ := setupTestScanner(t, false)
+	gameContracts[game1.Proxy] = &stubGameContract{l2BlockNum: 100, rootClaim: outputRoot(100)}
+	rollup.safeHead = 100
+	require.NoError(t, scanner.scan(context.Background(), []types.GameMetadata{game1}))
+
+	reloaded, err := NewVerdictStore(filepath.Dir(scanner.store.path))
+	require.NoError(t, err)
+	require.Equal(t, scanner.Verdicts(), reloaded.All())
+}
+
+func requireVerdict(t *testing.T, scanner *Scanner, source common.Address, index uint64, verdict Verdict, expected common.Hash) {
+	actual, ok := scanner.Verdict(source, index)
+	require.True(t, ok)
+	require.Equal(t, verdict, actual.Verdict)
+	require.Equal(t, expected, actual.Expected)
+}
+
+func outputRoot(blockNum uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(blockNum + 1))
+}
+
+func setupTestScanner(t *testing.T, withOracle bool) (*Scanner, *stubRollupClient, map[common.Address]*stubGameContract, *stubOracle, *stubMetrics) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	store, err := NewVerdictStore(t.TempDir())
+	require.NoError(t, err)
+	rollup := &stubRollupClient{}
+	gameContracts := make(map[common.Address]*stubGameContract)
+	creator := func(game types.GameMetadata) (GameContract, error) {
+		return gameContracts[game.Proxy], nil
+	}
+	m := &stubMetrics{}
+	oracle := &stubOracle{}
+	var outputOracle OutputOracle
+	if withOracle {
+		outputOracle = oracle
+	}
+	return NewScanner(logger, m, store, rollup, creator, outputOracle, &stubAlerter{}), rollup, gameContracts, oracle, m
+}
+
+type stubRollupClient struct {
+	safeHead       uint64
+	outputRequests int
+}
+
+func (s *stubRollupClient) SyncStatus(_ context.Context) (*eth.SyncStatus, error) {
+	return &eth.SyncStatus{SafeL2: eth.L2BlockRef{Number: s.safeHead}}, nil
+}
+
+func (s *stubRollupClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	s.outputRequests++
+	return &eth.OutputResponse{OutputRoot: eth.Bytes32(outputRoot(blockNum))}, nil
+}
+
+type stubGameContract struct {
+	l2BlockNum uint64
+	rootClaim  common.Hash
+	err        error
+	loads      int
+}
+
+func (s *stubGameContract) GetGameMetadata(_ context.Context, _ rpcblock.Block) (contracts.GameMetadata, error) {
+	s.loads++
+	if s.err != nil {
+		return contracts.GameMetadata{}, s.err
+	}
+	return contracts.GameMetadata{L2BlockNum: s.l2BlockNum, RootClaim: s.rootClaim}, nil
+}
+
+type stubOracle struct {
+	outputs []contracts.OutputProposal
+}
+
+func (s *stubOracle) Addr() common.Address {
+	return oracleAddr
+}
+
+func (s *stubOracle) NextOutputIndex(_ context.Context, _ rpcblock.Block) (uint64, error) {
+	return uint64(len(s.outputs)), nil
+}
+
+func (s *stubOracle) GetOutputProposals(_ context.Context, _ rpcblock.Block, start uint64, end uint64) ([]contracts.OutputProposal, error) {
+	return s.outputs[start:end], nil
+}
+
+type stubMetrics struct {
+	verdicts [3]int
+}
+
+func (s *stubMetrics) RecordProposalVerdicts(valid, invalid, pending int) {
+	s.verdicts = [3]int{valid, invalid, pending}
+}
+
+type stubAlerter struct {
+	alerts []opalerts.Alert
+}
+
+func (s *stubAlerter) Alert(_ context.Context, alert opalerts.Alert) {
+	s.alerts = append(s.alerts, alert)
+}
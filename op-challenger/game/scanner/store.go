@@ -0,0 +1,138 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const verdictStoreFile = "verdicts.json"
+
+type proposalKey struct {
+	source common.Address
+	index  uint64
+}
+
+// verdictState is the content of the store file.
+type verdictState struct {
+	Verdicts []ProposalVerdict `json:"verdicts"`
+	// Next is the index after the highest proposal index ever stored from each source.
+	// It is kept when the verdicts of the source are pruned, so pruned proposals are not scanned again.
+	Next map[common.Address]uint64 `json:"next"`
+}
+
+// VerdictStore persists the verdicts of scanned proposals in a JSON file, so proposals that are already known to be
+// valid or invalid are not checked again after the challenger restarts.
+// Verdicts that are no longer needed are dropped with Prune, so the store doesn't grow without bound.
+type VerdictStore struct {
+	mu      sync.Mutex
+	path    string
+	state   verdictState
+	indices map[proposalKey]int
+}
+
+// NewVerdictStore creates a VerdictStore in dir, loading the verdicts previously stored there, if any.
+func NewVerdictStore(dir string) (*VerdictStore, error) {
+	s := &VerdictStore{
+		path:    filepath.Join(dir, verdictStoreFile),
+		state:   verdictState{Next: make(map[common.Address]uint64)},
+		indices: make(map[proposalKey]int),
+	}
+	state, err := jsonutil.LoadJSON[verdictState](s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load proposal verdicts: %w", err)
+	}
+	s.state.Verdicts = state.Verdicts
+	for source, next := range state.Next {
+		s.state.Next[source] = next
+	}
+	s.reindex()
+	return s, nil
+}
+
+func (s *VerdictStore) reindex() {
+	clear(s.indices)
+	for i, verdict := range s.state.Verdicts {
+		s.indices[verdict.key()] = i
+	}
+}
+
+// Get returns the stored verdict of the proposal with the given index from source.
+func (s *VerdictStore) Get(source common.Address, index uint64) (ProposalVerdict, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i, ok := s.indices[proposalKey{source, index}]; ok {
+		return s.state.Verdicts[i], true
+	}
+	return ProposalVerdict{}, false
+}
+
+// All returns all stored verdicts, in the order the proposals were first stored.
+func (s *VerdictStore) All() []ProposalVerdict {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ProposalVerdict(nil), s.state.Verdicts...)
+}
+
+// NextIndex returns the index after the highest proposal index ever stored from source, or 0 if there are none.
+// The index doesn't decrease when the verdicts of the source are pruned, only when they are truncated.
+func (s *VerdictStore) NextIndex(source common.Address) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.Next[source]
+}
+
+// Put stores the verdicts, replacing any previously stored verdicts for the same proposals.
+func (s *VerdictStore) Put(verdicts ...ProposalVerdict) error {
+	if len(verdicts) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, verdict := range verdicts {
+		if i, ok := s.indices[verdict.key()]; ok {
+			s.state.Verdicts[i] = verdict
+		} else {
+			s.indices[verdict.key()] = len(s.state.Verdicts)
+			s.state.Verdicts = append(s.state.Verdicts, verdict)
+		}
+		s.state.Next[verdict.Source] = max(s.state.Next[verdict.Source], verdict.Index+1)
+	}
+	return jsonutil.WriteJSON(s.path, s.state, 0o644)
+}
+
+// Prune drops the verdicts for which keep returns false. The next index of their sources is kept.
+func (s *VerdictStore) Prune(keep func(verdict ProposalVerdict) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.state.Verdicts)
+	s.state.Verdicts = slices.DeleteFunc(s.state.Verdicts, func(verdict ProposalVerdict) bool {
+		return !keep(verdict)
+	})
+	if len(s.state.Verdicts) == n {
+		return nil
+	}
+	s.reindex()
+	return jsonutil.WriteJSON(s.path, s.state, 0o644)
+}
+
+// Truncate drops the verdicts of the proposals from source with an index at or after next, and resets the next index
+// of source to next, e.g. when outputs were deleted from the oracle so their indices are reused.
+func (s *VerdictStore) Truncate(source common.Address, next uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.Verdicts = slices.DeleteFunc(s.state.Verdicts, func(verdict ProposalVerdict) bool {
+		return verdict.Source == source && verdict.Index >= next
+	})
+	s.state.Next[source] = min(s.state.Next[source], next)
+	s.reindex()
+	return jsonutil.WriteJSON(s.path, s.state, 0o644)
+}
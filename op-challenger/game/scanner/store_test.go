@@ -0,0 +1,85 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerdictStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewVerdictStore(dir)
+	require.NoError(t, err)
+	require.Empty(t, store.All())
+
+	oracle := common.Address{0xaa}
+	game := ProposalVerdict{Type: ProposalTypeGame, Source: common.Address{0x01}, Index: 7, L2BlockNum: 100, OutputRoot: common.Hash{0x01}, Verdict: VerdictPending}
+	output1 := ProposalVerdict{Type: ProposalTypeOracle, Source: oracle, Index: 0, L2BlockNum: 50, OutputRoot: common.Hash{0x02}, Expected: common.Hash{0x02}, Verdict: VerdictValid}
+	output2 := ProposalVerdict{Type: ProposalTypeOracle, Source: oracle, Index: 1, L2BlockNum: 60, OutputRoot: common.Hash{0x03}, Verdict: VerdictPending}
+	require.NoError(t, store.Put(game, output1, output2))
+	require.NoError(t, store.Put())
+	game.Expected = common.Hash{0x04}
+	game.Verdict = VerdictInvalid
+	require.NoError(t, store.Put(game))
+
+	require.Equal(t, []ProposalVerdict{game, output1, output2}, store.All())
+	actual, ok := store.Get(game.Source, game.Index)
+	require.True(t, ok)
+	require.Equal(t, game, actual)
+	_, ok = store.Get(game.Source, 8)
+	require.False(t, ok)
+	require.Equal(t, uint64(2), store.NextIndex(oracle))
+	require.Zero(t, store.NextIndex(common.Address{0xbb}))
+
+	reloaded, err := NewVerdictStore(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ProposalVerdict{game, output1, output2}, reloaded.All())
+	actual, ok = reloaded.Get(output2.Source, output2.Index)
+	require.True(t, ok)
+	require.Equal(t, output2, actual)
+}
+
+func TestVerdictStore_Prune(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewVerdictStore(dir)
+	require.NoError(t, err)
+	oracle := common.Address{0xaa}
+	output1 := ProposalVerdict{Type: ProposalTypeOracle, Source: oracle, Index: 0, Verdict: VerdictValid}
+	output2 := ProposalVerdict{Type: ProposalTypeOracle, Source: oracle, Index: 1, Verdict: VerdictPending}
+	require.NoError(t, store.Put(output1, output2))
+
+	require.NoError(t, store.Prune(func(verdict ProposalVerdict) bool {
+		return verdict.Verdict != VerdictValid
+	}))
+	require.Equal(t, []ProposalVerdict{output2}, store.All())
+	_, ok := store.Get(oracle, 0)
+	require.False(t, ok)
+	actual, ok := store.Get(oracle, 1)
+	require.True(t, ok)
+	require.Equal(t, output2, actual)
+
+	// The next index is kept when all verdicts are pruned, also after reloading
+	require.NoError(t, store.Prune(func(verdict ProposalVerdict) bool { return false }))
+	require.Empty(t, store.All())
+	require.Equal(t, uint64(2), store.NextIndex(oracle))
+	reloaded, err := NewVerdictStore(dir)
+	require.NoError(t, err)
+	require.Empty(t, reloaded.All())
+	require.Equal(t, uint64(2), reloaded.NextIndex(oracle))
+}
+
+func TestVerdictStore_Truncate(t *testing.T) {
+	store, err := NewVerdictStore(t.TempDir())
+	require.NoError(t, err)
+	oracle := common.Address{0xaa}
+	game := ProposalVerdict{Type: ProposalTypeGame, Source: common.Address{0x01}, Index: 5, Verdict: VerdictValid}
+	output1 := ProposalVerdict{Type: ProposalTypeOracle, Source: oracle, Index: 0, Verdict: VerdictValid}
+	output2 := ProposalVerdict{Type: ProposalTypeOracle, Source: oracle, Index: 1, Verdict: VerdictInvalid}
+	require.NoError(t, store.Put(game, output1, output2))
+
+	require.NoError(t, store.Truncate(oracle, 1))
+	require.Equal(t, []ProposalVerdict{game, output1}, store.All())
+	require.Equal(t, uint64(1), store.NextIndex(oracle))
+	require.Equal(t, uint64(6), store.NextIndex(game.Source))
+}
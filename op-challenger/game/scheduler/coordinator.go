@@ -19,6 +19,7 @@ type PlayerCreator func(game types.GameMetadata, dir string) (GamePlayer, error)
 type CoordinatorMetricer interface {
 	RecordActedL1Block(n uint64)
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
+	RecordGamesForecast(honestWinning, honestAtRisk, honestLost int)
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
 }
@@ -70,6 +71,9 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 	var gamesInProgress int
 	var gamesChallengerWon int
 	var gamesDefenderWon int
+	var gamesHonestWinning int
+	var gamesHonestAtRisk int
+	var gamesHonestLost int
 	var errs []error
 	var jobs []job
 	// Next collect all the jobs to schedule and ensure all games are recorded in the states map.
@@ -87,6 +91,17 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 			switch state.status {
 			case types.GameStatusInProgress:
 				gamesInProgress++
+				if state.player == nil {
+					break
+				}
+				switch state.player.Forecast() {
+				case types.ForecastHonestWinning:
+					gamesHonestWinning++
+				case types.ForecastHonestAtRisk:
+					gamesHonestAtRisk++
+				case types.ForecastHonestLost:
+					gamesHonestLost++
+				}
 			case types.GameStatusDefenderWon:
 				gamesDefenderWon++
 			case types.GameStatusChallengerWon:
@@ -97,6 +112,7 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 		}
 	}
 	c.m.RecordGamesStatus(gamesInProgress, gamesDefenderWon, gamesChallengerWon)
+	c.m.RecordGamesForecast(gamesHonestWinning, gamesHonestAtRisk, gamesHonestLost)
 
 	lowestProcessedBlockNum := blockNumber
 	for _, state := range c.states {
@@ -105,7 +121,8 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 	c.lastScheduledBlockNum = blockNumber
 	c.m.RecordActedL1Block(lowestProcessedBlockNum)
 
-	// Finally, enqueue the jobs
+	// Finally, enqueue the jobs, prioritising the games closest to a clock expiring
+	slices.SortStableFunc(jobs, compareClockExpiry)
 	for _, j := range jobs {
 		if err := c.enqueueJob(ctx, j); err != nil {
 			errs = append(errs, fmt.Errorf("failed to enqueue job for game %v: %w", j.addr, err))
@@ -149,7 +166,21 @@ func (c *coordinator) createJob(ctx context.Context, game types.GameMetadata, bl
 		return nil, nil
 	}
 	state.inflight = true
-	return newJob(blockNumber, game.Proxy, state.player, state.status), nil
+	return newJob(blockNumber, game.Proxy, state.player, state.status, state.player.ClockExpiry()), nil
+}
+
+// compareClockExpiry orders jobs by the expiry of their earliest chess clock. Games with an unknown expiry haven't
+// been progressed yet, so are ordered first to learn what they require.
+func compareClockExpiry(a, b job) int {
+	switch {
+	case a.clockExpiry.Equal(b.clockExpiry):
+		return 0
+	case a.clockExpiry.IsZero():
+		return -1
+	case b.clockExpiry.IsZero():
+		return 1
+	}
+	return a.clockExpiry.Compare(b.clockExpiry)
 }
 
 func (c *coordinator) enqueueJob(ctx context.Context, j job) error {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
@@ -49,6 +50,58 @@ func TestSkipSchedulingInflightGames(t *testing.T) {
 	require.Len(t, workQueue, 1, "should not reschedule in-flight game")
 }
 
+func TestSchedulePrioritisesClockExpiry(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	gameAddr3 := common.Address{0xcc}
+	gameAddr4 := common.Address{0xdd}
+	ctx := context.Background()
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2, gameAddr3), 0))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.processResult(<-workQueue))
+	}
+
+	now := time.Unix(1000, 0)
+	games.created[gameAddr1].ClockExpiryValue = now.Add(time.Hour)
+	games.created[gameAddr2].ClockExpiryValue = now.Add(2 * time.Hour)
+	games.created[gameAddr3].ClockExpiryValue = now.Add(time.Minute)
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2, gameAddr3, gameAddr4), 1))
+
+	var order []common.Address
+	for i := 0; i < 4; i++ {
+		order = append(order, (<-workQueue).addr)
+	}
+	// New games have an unknown clock expiry so are scheduled first
+	require.Equal(t, []common.Address{gameAddr4, gameAddr3, gameAddr1, gameAddr2}, order)
+}
+
+func TestScheduleRecordsGamesForecast(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	gameAddr3 := common.Address{0xcc}
+	gameAddr4 := common.Address{0xdd}
+	gameAddr5 := common.Address{0xee}
+	ctx := context.Background()
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2, gameAddr3, gameAddr4, gameAddr5), 0))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, c.processResult(<-workQueue))
+	}
+
+	games.created[gameAddr1].ForecastValue = types.ForecastHonestWinning
+	games.created[gameAddr2].ForecastValue = types.ForecastHonestAtRisk
+	games.created[gameAddr3].ForecastValue = types.ForecastHonestAtRisk
+	games.created[gameAddr5].ForecastValue = types.ForecastHonestLost
+	// gameAddr4 has an unknown forecast so isn't counted
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2, gameAddr3, gameAddr4, gameAddr5), 1))
+
+	m := c.m.(*stubSchedulerMetrics)
+	require.Equal(t, 1, m.honestWinning)
+	require.Equal(t, 2, m.honestAtRisk)
+	require.Equal(t, 1, m.honestLost)
+}
+
 func TestExitWhenContextDoneWhileSchedulingJob(t *testing.T) {
 	// No space in buffer to schedule a job
 	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 0)
@@ -439,6 +492,9 @@ func (c *createdGames) CreateGame(fdg types.GameMetadata, dir string) (GamePlaye
 
 type stubSchedulerMetrics struct {
 	actedL1Blocks uint64
+	honestWinning int
+	honestAtRisk  int
+	honestLost    int
 }
 
 func (s *stubSchedulerMetrics) RecordActedL1Block(n uint64) {
@@ -446,8 +502,15 @@ func (s *stubSchedulerMetrics) RecordActedL1Block(n uint64) {
 }
 
 func (s *stubSchedulerMetrics) RecordGamesStatus(_, _, _ int) {}
-func (s *stubSchedulerMetrics) RecordGameUpdateScheduled()    {}
-func (s *stubSchedulerMetrics) RecordGameUpdateCompleted()    {}
+
+func (s *stubSchedulerMetrics) RecordGamesForecast(honestWinning, honestAtRisk, honestLost int) {
+	s.honestWinning = honestWinning
+	s.honestAtRisk = honestAtRisk
+	s.honestLost = honestLost
+}
+
+func (s *stubSchedulerMetrics) RecordGameUpdateScheduled() {}
+func (s *stubSchedulerMetrics) RecordGameUpdateCompleted() {}
 
 type stubDiskManager struct {
 	gameDirExists map[common.Address]bool
@@ -14,6 +14,7 @@ var ErrBusy = errors.New("busy scheduling previous update")
 type SchedulerMetricer interface {
 	RecordActedL1Block(n uint64)
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
+	RecordGamesForecast(honestWinning, honestAtRisk, honestLost int)
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
 	IncActiveExecutors()
@@ -2,17 +2,20 @@ package test
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type StubGamePlayer struct {
-	Addr          common.Address
-	ProgressCount int
-	StatusValue   types.GameStatus
-	Dir           string
-	PrestateErr   error
+	Addr             common.Address
+	ProgressCount    int
+	StatusValue      types.GameStatus
+	Dir              string
+	PrestateErr      error
+	ClockExpiryValue time.Time
+	ForecastValue    types.GameForecast
 }
 
 func (g *StubGamePlayer) ValidatePrestate(_ context.Context) error {
@@ -27,3 +30,11 @@ func (g *StubGamePlayer) ProgressGame(_ context.Context) types.GameStatus {
 func (g *StubGamePlayer) Status() types.GameStatus {
 	return g.StatusValue
 }
+
+func (g *StubGamePlayer) ClockExpiry() time.Time {
+	return g.ClockExpiryValue
+}
+
+func (g *StubGamePlayer) Forecast() types.GameForecast {
+	return g.ForecastValue
+}
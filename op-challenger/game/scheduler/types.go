@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -12,6 +13,10 @@ type GamePlayer interface {
 	ValidatePrestate(ctx context.Context) error
 	ProgressGame(ctx context.Context) types.GameStatus
 	Status() types.GameStatus
+	// ClockExpiry returns the time at which the first chess clock of the game expires, or the zero time if unknown.
+	ClockExpiry() time.Time
+	// Forecast returns whether the honest outcome of the game would win if no further moves were made.
+	Forecast() types.GameForecast
 }
 
 type DiskManager interface {
@@ -20,17 +25,19 @@ type DiskManager interface {
 }
 
 type job struct {
-	block  uint64
-	addr   common.Address
-	player GamePlayer
-	status types.GameStatus
+	block       uint64
+	addr        common.Address
+	player      GamePlayer
+	status      types.GameStatus
+	clockExpiry time.Time
 }
 
-func newJob(block uint64, addr common.Address, player GamePlayer, status types.GameStatus) *job {
+func newJob(block uint64, addr common.Address, player GamePlayer, status types.GameStatus, clockExpiry time.Time) *job {
 	return &job{
-		block:  block,
-		addr:   addr,
-		player: player,
-		status: status,
+		block:       block,
+		addr:        addr,
+		player:      player,
+		status:      status,
+		clockExpiry: clockExpiry,
 	}
 }
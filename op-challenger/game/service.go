@@ -15,20 +15,26 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/alerts"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scanner"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/rpc"
 	"github.com/ethereum-optimism/optimism/op-challenger/version"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/health"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
@@ -49,8 +55,12 @@ type Service struct {
 	systemClock clock.Clock
 	l1Clock     *clock.SimpleClock
 
-	claimants []common.Address
-	claimer   *claims.BondClaimScheduler
+	claimants  []common.Address
+	claimer    *claims.BondClaimScheduler
+	accountant *claims.BondAccountant
+
+	outputScanner *scanner.Scanner
+	alerter       *alerts.Alerter
 
 	factoryContract *contracts.DisputeGameFactoryContract
 	registry        *registry.GameTypeRegistry
@@ -62,6 +72,7 @@ type Service struct {
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
+	rpcServer    *oprpc.Server
 
 	balanceMetricer io.Closer
 
@@ -86,10 +97,12 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config, m me
 }
 
 func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error {
-	if err := s.initTxManager(ctx, cfg); err != nil {
-		return fmt.Errorf("failed to init tx manager: %w", err)
+	if !cfg.MonitorOnly {
+		if err := s.initTxManager(ctx, cfg); err != nil {
+			return fmt.Errorf("failed to init tx manager: %w", err)
+		}
+		s.initClaimants(cfg)
 	}
-	s.initClaimants(cfg)
 	if err := s.initL1Client(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init l1 client: %w", err)
 	}
@@ -108,21 +121,40 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.initFactoryContract(cfg); err != nil {
 		return fmt.Errorf("failed to create factory contract bindings: %w", err)
 	}
-	if err := s.registerGameTypes(ctx, cfg); err != nil {
-		return fmt.Errorf("failed to register game types: %w", err)
-	}
-	if err := s.initBondClaims(); err != nil {
-		return fmt.Errorf("failed to init bond claiming: %w", err)
+	s.alerter = alerts.NewAlerter(s.logger, s.metrics, cfg.AlertWebhookURL)
+	if cfg.MonitorOnly {
+		s.registerGameWatchers(ctx, cfg)
+	} else {
+		if err := s.registerGameTypes(ctx, cfg); err != nil {
+			return fmt.Errorf("failed to register game types: %w", err)
+		}
+		if err := s.initBondClaims(cfg); err != nil {
+			return fmt.Errorf("failed to init bond claiming: %w", err)
+		}
 	}
 	if err := s.initScheduler(cfg); err != nil {
 		return fmt.Errorf("failed to init scheduler: %w", err)
 	}
-	if err := s.initLargePreimages(); err != nil {
-		return fmt.Errorf("failed to init large preimage scheduler: %w", err)
+	if !cfg.MonitorOnly {
+		if err := s.initLargePreimages(); err != nil {
+			return fmt.Errorf("failed to init large preimage scheduler: %w", err)
+		}
+	}
+	// Outputs of the L2OutputOracle are only watched by the output scanner, so it is always run when monitoring one
+	if cfg.OutputScanner || (cfg.MonitorOnly && cfg.L2OutputOracleAddress != (common.Address{})) {
+		if err := s.initOutputScanner(ctx, cfg); err != nil {
+			return fmt.Errorf("failed to init output scanner: %w", err)
+		}
 	}
 
 	s.initMonitor(cfg)
 
+	if cfg.RPCEnabled {
+		if err := s.initRPCServer(&cfg.RPCConfig); err != nil {
+			return fmt.Errorf("failed to init rpc server: %w", err)
+		}
+	}
+
 	s.metrics.RecordInfo(version.SimpleWithMeta)
 	s.metrics.RecordUp()
 	return nil
@@ -162,14 +194,7 @@ func (s *Service) initPollClient(ctx context.Context, cfg *config.Config) error
 }
 
 func (s *Service) initPProf(cfg *oppprof.CLIConfig) error {
-	s.pprofService = oppprof.New(
-		cfg.ListenEnabled,
-		cfg.ListenAddr,
-		cfg.ListenPort,
-		cfg.ProfileType,
-		cfg.ProfileDir,
-		cfg.ProfileFilename,
-	)
+	s.pprofService = oppprof.NewFromConfig(*cfg)
 
 	if err := s.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -193,7 +218,9 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 	}
 	s.logger.Info("started metrics server", "addr", metricsSrv.Addr())
 	s.metricsSrv = metricsSrv
-	s.balanceMetricer = s.metrics.StartBalanceMetrics(s.logger, s.l1Client, s.txSender.From())
+	if s.txSender != nil {
+		s.balanceMetricer = s.metrics.StartBalanceMetrics(s.logger, s.l1Client, s.txSender.From())
+	}
 	return nil
 }
 
@@ -204,9 +231,61 @@ func (s *Service) initFactoryContract(cfg *config.Config) error {
 	return nil
 }
 
-func (s *Service) initBondClaims() error {
-	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.claimants...)
-	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, claimer)
+func (s *Service) initBondClaims(cfg *config.Config) error {
+	store, err := claims.NewBondStore(cfg.Datadir)
+	if err != nil {
+		return fmt.Errorf("failed to create bond store: %w", err)
+	}
+	s.accountant = claims.NewBondAccountant(s.logger, s.metrics, store, s.registry.CreateBondContract, s.claimants...)
+	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.accountant, s.claimants...)
+	var resolver claims.GameResolver
+	if cfg.BatchResolution {
+		resolver = claims.NewResolver(s.logger, s.metrics, s.l1Clock, s.registry.CreateBondContract, s.txSender, s.txSender.From())
+	}
+	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, resolver, claimer)
+	return nil
+}
+
+func (s *Service) initOutputScanner(ctx context.Context, cfg *config.Config) error {
+	store, err := scanner.NewVerdictStore(cfg.Datadir)
+	if err != nil {
+		return fmt.Errorf("failed to create verdict store: %w", err)
+	}
+	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
+	gameCreator := func(game types.GameMetadata) (scanner.GameContract, error) {
+		return contracts.NewFaultDisputeGameContract(ctx, s.metrics, game.Proxy, caller)
+	}
+	var oracle scanner.OutputOracle
+	if cfg.L2OutputOracleAddress != (common.Address{}) {
+		oracle = contracts.NewL2OutputOracleContract(s.metrics, cfg.L2OutputOracleAddress, caller)
+	}
+	s.outputScanner = scanner.NewScanner(s.logger, s.metrics, store, s.rollupClient, gameCreator, oracle, s.alerter)
+	return nil
+}
+
+func (s *Service) initRPCServer(cfg *oprpc.CLIConfig) error {
+	checks := health.NewChecks(version.SimpleWithMeta)
+	checks.RegisterLiveness("monitor", s.monitor.Liveness)
+	server := oprpc.NewServer(cfg.ListenAddr, cfg.ListenPort, version.SimpleWithMeta, oprpc.WithLogger(s.logger),
+		oprpc.WithRPCMetrics(s.metrics), oprpc.WithHealthChecks(checks))
+	if s.accountant != nil || s.outputScanner != nil {
+		// Avoid passing nil pointers as non-nil interfaces for the disabled features.
+		var bonds rpc.BondAccounting
+		if s.accountant != nil {
+			bonds = s.accountant
+		}
+		var verdicts rpc.ProposalVerdicts
+		if s.outputScanner != nil {
+			verdicts = s.outputScanner
+		}
+		server.AddAPI(rpc.GetChallengerAPI(rpc.NewChallengerAPI(bonds, verdicts)))
+	}
+	s.logger.Debug("starting rpc server", "addr", cfg.ListenAddr, "port", cfg.ListenPort)
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("unable to start rpc server: %w", err)
+	}
+	s.logger.Info("started rpc server", "addr", server.Endpoint())
+	s.rpcServer = server
 	return nil
 }
 
@@ -236,6 +315,14 @@ func (s *Service) registerGameTypes(ctx context.Context, cfg *config.Config) err
 	return nil
 }
 
+// registerGameWatchers registers players that only watch games and raise alerts, without sending any transactions.
+func (s *Service) registerGameWatchers(ctx context.Context, cfg *config.Config) {
+	gameTypeRegistry := registry.NewGameTypeRegistry()
+	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
+	fault.RegisterGameWatchers(ctx, s.l1Clock, s.metrics, cfg.TraceTypes, gameTypeRegistry, s.rollupClient, caller, s.l1Client, s.alerter, s.logger)
+	s.registry = gameTypeRegistry
+}
+
 func (s *Service) initScheduler(cfg *config.Config) error {
 	disk := newDiskManager(cfg.Datadir)
 	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, s.registry.CreatePlayer, cfg.AllowInvalidPrestate)
@@ -251,14 +338,34 @@ func (s *Service) initLargePreimages() error {
 }
 
 func (s *Service) initMonitor(cfg *config.Config) {
-	s.monitor = newGameMonitor(s.logger, s.l1Clock, s.factoryContract, s.sched, s.preimages, cfg.GameWindow, s.claimer, cfg.GameAllowlist, s.pollClient)
+	// Avoid passing nil pointers as non-nil interfaces when bond claims, large preimages or the output scanner are disabled.
+	var claimer claimer
+	if s.claimer != nil {
+		claimer = s.claimer
+	}
+	var preimages preimageScheduler
+	if s.preimages != nil {
+		preimages = s.preimages
+	}
+	var outputScanner proposalScanner
+	if s.outputScanner != nil {
+		outputScanner = s.outputScanner
+	}
+	s.monitor = newGameMonitor(s.logger, s.l1Clock, s.factoryContract, s.sched, preimages, cfg.GameWindow, claimer, outputScanner, cfg.GameAllowlist, s.pollClient)
 }
 
 func (s *Service) Start(ctx context.Context) error {
 	s.logger.Info("starting scheduler")
 	s.sched.Start(ctx)
-	s.claimer.Start(ctx)
-	s.preimages.Start(ctx)
+	if s.claimer != nil {
+		s.claimer.Start(ctx)
+	}
+	if s.preimages != nil {
+		s.preimages.Start(ctx)
+	}
+	if s.outputScanner != nil {
+		s.outputScanner.Start(ctx)
+	}
 	s.logger.Info("starting monitoring")
 	s.monitor.StartMonitoring()
 	s.logger.Info("challenger game service start completed")
@@ -286,6 +393,11 @@ func (s *Service) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to close claimer: %w", err))
 		}
 	}
+	if s.outputScanner != nil {
+		if err := s.outputScanner.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close output scanner: %w", err))
+		}
+	}
 	if s.faultGamesCloser != nil {
 		s.faultGamesCloser()
 	}
@@ -313,6 +425,11 @@ func (s *Service) Stop(ctx context.Context) error {
 	if s.l1Client != nil {
 		s.l1Client.Close()
 	}
+	if s.rpcServer != nil {
+		if err := s.rpcServer.Stop(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close rpc server: %w", err))
+		}
+	}
 	if s.metricsSrv != nil {
 		if err := s.metricsSrv.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close metrics server: %w", err))
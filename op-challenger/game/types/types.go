@@ -39,6 +39,32 @@ func GameStatusFromUint8(i uint8) (GameStatus, error) {
 	return GameStatus(i), nil
 }
 
+// GameForecast is the forecast of whether the honest outcome of an in-progress game would win if the game were
+// resolved with its current claims, i.e. if no further moves were made before the chess clocks expire.
+type GameForecast uint8
+
+const (
+	ForecastUnknown GameForecast = iota
+	ForecastHonestWinning
+	ForecastHonestAtRisk
+	// ForecastHonestLost means the honest outcome would not win and no chess clock has time remaining to change it.
+	ForecastHonestLost
+)
+
+// String returns the string representation of the game forecast.
+func (f GameForecast) String() string {
+	switch f {
+	case ForecastHonestWinning:
+		return "Honest Winning"
+	case ForecastHonestAtRisk:
+		return "Honest At Risk"
+	case ForecastHonestLost:
+		return "Honest Lost"
+	default:
+		return "Unknown"
+	}
+}
+
 type GameMetadata struct {
 	Index     uint64
 	GameType  uint32
@@ -2,8 +2,10 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 	"github.com/ethereum/go-ethereum/common"
@@ -33,11 +35,16 @@ type Metricer interface {
 	// Record contract metrics
 	contractMetrics.ContractMetricer
 
+	// Record RPC server metrics
+	opmetrics.RPCServerMetricer
+
 	RecordActedL1Block(n uint64)
 
 	RecordGameStep()
 	RecordGameMove()
 	RecordGameL2Challenge()
+	RecordClaimsResolved(count int)
+	RecordGameResolved()
 	RecordVmExecutionTime(vmType string, t time.Duration)
 	RecordClaimResolutionTime(t float64)
 	RecordGameActTime(t float64)
@@ -47,8 +54,14 @@ type Metricer interface {
 
 	RecordBondClaimFailed()
 	RecordBondClaimed(amount uint64)
+	RecordBondAccounting(posted, claimable, claimed, expectedProfit *big.Int)
 
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
+	RecordGamesForecast(honestWinning, honestAtRisk, honestLost int)
+
+	RecordAlert(alertType string)
+
+	RecordProposalVerdicts(valid, invalid, pending int)
 
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
@@ -70,6 +83,7 @@ type Metrics struct {
 	txmetrics.TxMetrics
 	*opmetrics.CacheMetrics
 	*contractMetrics.ContractMetrics
+	opmetrics.RPCServerMetrics
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
@@ -78,6 +92,7 @@ type Metrics struct {
 
 	bondClaimFailures prometheus.Counter
 	bondsClaimed      prometheus.Counter
+	bondAccounting    prometheus.GaugeVec
 
 	preimageChallenged      prometheus.Counter
 	preimageChallengeFailed prometheus.Counter
@@ -88,12 +103,20 @@ type Metrics struct {
 	steps        prometheus.Counter
 	l2Challenges prometheus.Counter
 
+	claimsResolved prometheus.Counter
+	gamesResolved  prometheus.Counter
+
 	claimResolutionTime prometheus.Histogram
 	gameActTime         prometheus.Histogram
 	vmExecutionTime     *prometheus.HistogramVec
 
 	trackedGames  prometheus.GaugeVec
+	gamesForecast prometheus.GaugeVec
 	inflightGames prometheus.Gauge
+
+	alerts prometheus.CounterVec
+
+	proposalVerdicts prometheus.GaugeVec
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -117,6 +140,8 @@ func NewMetrics() *Metrics {
 
 		ContractMetrics: contractMetrics.MakeContractMetrics(Namespace, factory),
 
+		RPCServerMetrics: opmetrics.MakeRPCServerMetrics(Namespace, factory),
+
 		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "info",
@@ -151,6 +176,16 @@ func NewMetrics() *Metrics {
 			Name:      "l2_challenges",
 			Help:      "Number of L2 challenges made by the challenge agent",
 		}),
+		claimsResolved: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "claims_resolved",
+			Help:      "Number of claims resolved by the resolver",
+		}),
+		gamesResolved: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "games_resolved",
+			Help:      "Number of games resolved by the resolver",
+		}),
 		claimResolutionTime: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: Namespace,
 			Name:      "claim_resolution_time",
@@ -183,6 +218,13 @@ func NewMetrics() *Metrics {
 			Name:      "bonds",
 			Help:      "Number of bonds claimed by the challenge agent",
 		}),
+		bondAccounting: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "bond_accounting",
+			Help:      "Total bonds (in ether) of the tracked games by category: posted, claimable, claimed and expected_profit",
+		}, []string{
+			"category",
+		}),
 		preimageChallenged: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "preimage_challenged",
@@ -200,6 +242,27 @@ func NewMetrics() *Metrics {
 		}, []string{
 			"status",
 		}),
+		gamesForecast: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "games_forecast",
+			Help:      "Number of in progress games by whether the honest outcome would win if no further moves were made",
+		}, []string{
+			"forecast",
+		}),
+		alerts: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "alerts",
+			Help:      "Number of alerts raised for suspicious games by type",
+		}, []string{
+			"type",
+		}),
+		proposalVerdicts: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "proposal_verdicts",
+			Help:      "Number of scanned output proposals by whether they match the local node",
+		}, []string{
+			"verdict",
+		}),
 		highestActedL1Block: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "highest_acted_l1_block",
@@ -249,6 +312,14 @@ func (m *Metrics) RecordGameStep() {
 	m.steps.Add(1)
 }
 
+func (m *Metrics) RecordClaimsResolved(count int) {
+	m.claimsResolved.Add(float64(count))
+}
+
+func (m *Metrics) RecordGameResolved() {
+	m.gamesResolved.Add(1)
+}
+
 func (m *Metrics) RecordGameL2Challenge() {
 	m.l2Challenges.Add(1)
 }
@@ -269,6 +340,13 @@ func (m *Metrics) RecordBondClaimed(amount uint64) {
 	m.bondsClaimed.Add(float64(amount))
 }
 
+func (m *Metrics) RecordBondAccounting(posted, claimable, claimed, expectedProfit *big.Int) {
+	m.bondAccounting.WithLabelValues("posted").Set(eth.WeiToEther(posted))
+	m.bondAccounting.WithLabelValues("claimable").Set(eth.WeiToEther(claimable))
+	m.bondAccounting.WithLabelValues("claimed").Set(eth.WeiToEther(claimed))
+	m.bondAccounting.WithLabelValues("expected_profit").Set(eth.WeiToEther(expectedProfit))
+}
+
 func (m *Metrics) RecordVmExecutionTime(vmType string, dur time.Duration) {
 	m.vmExecutionTime.WithLabelValues(vmType).Observe(dur.Seconds())
 }
@@ -303,6 +381,22 @@ func (m *Metrics) RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 	m.trackedGames.WithLabelValues("challenger_won").Set(float64(challengerWon))
 }
 
+func (m *Metrics) RecordGamesForecast(honestWinning, honestAtRisk, honestLost int) {
+	m.gamesForecast.WithLabelValues("honest_winning").Set(float64(honestWinning))
+	m.gamesForecast.WithLabelValues("honest_at_risk").Set(float64(honestAtRisk))
+	m.gamesForecast.WithLabelValues("honest_lost").Set(float64(honestLost))
+}
+
+func (m *Metrics) RecordAlert(alertType string) {
+	m.alerts.WithLabelValues(alertType).Inc()
+}
+
+func (m *Metrics) RecordProposalVerdicts(valid, invalid, pending int) {
+	m.proposalVerdicts.WithLabelValues("valid").Set(float64(valid))
+	m.proposalVerdicts.WithLabelValues("invalid").Set(float64(invalid))
+	m.proposalVerdicts.WithLabelValues("pending").Set(float64(pending))
+}
+
 func (m *Metrics) RecordActedL1Block(n uint64) {
 	m.highestActedL1Block.Set(float64(n))
 }
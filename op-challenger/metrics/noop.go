@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 	"time"
 
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
@@ -9,12 +10,14 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
 
 type NoopMetricsImpl struct {
 	txmetrics.NoopTxMetrics
 	contractMetrics.NoopMetrics
+	opmetrics.NoopRPCMetrics
 }
 
 func (i *NoopMetricsImpl) StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer {
@@ -30,6 +33,9 @@ func (*NoopMetricsImpl) RecordGameMove()        {}
 func (*NoopMetricsImpl) RecordGameStep()        {}
 func (*NoopMetricsImpl) RecordGameL2Challenge() {}
 
+func (*NoopMetricsImpl) RecordClaimsResolved(_ int) {}
+func (*NoopMetricsImpl) RecordGameResolved()        {}
+
 func (*NoopMetricsImpl) RecordActedL1Block(_ uint64) {}
 
 func (*NoopMetricsImpl) RecordPreimageChallenged()      {}
@@ -38,12 +44,20 @@ func (*NoopMetricsImpl) RecordPreimageChallengeFailed() {}
 func (*NoopMetricsImpl) RecordBondClaimFailed()   {}
 func (*NoopMetricsImpl) RecordBondClaimed(uint64) {}
 
+func (*NoopMetricsImpl) RecordBondAccounting(_, _, _, _ *big.Int) {}
+
 func (*NoopMetricsImpl) RecordVmExecutionTime(_ string, _ time.Duration) {}
 func (*NoopMetricsImpl) RecordClaimResolutionTime(t float64)             {}
 func (*NoopMetricsImpl) RecordGameActTime(t float64)                     {}
 
 func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
 
+func (*NoopMetricsImpl) RecordGamesForecast(honestWinning, honestAtRisk, honestLost int) {}
+
+func (*NoopMetricsImpl) RecordAlert(alertType string) {}
+
+func (*NoopMetricsImpl) RecordProposalVerdicts(valid, invalid, pending int) {}
+
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
 func (*NoopMetricsImpl) RecordGameUpdateCompleted() {}
 
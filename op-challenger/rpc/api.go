@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scanner"
+)
+
+var (
+	ErrBondAccountingDisabled = errors.New("bond accounting is disabled")
+	ErrOutputScannerDisabled  = errors.New("output scanner is disabled")
+)
+
+type BondAccounting interface {
+	Bonds() []claims.GameBonds
+	Totals() claims.BondTotals
+}
+
+type ProposalVerdicts interface {
+	Verdict(source common.Address, index uint64) (scanner.ProposalVerdict, bool)
+	Verdicts() []scanner.ProposalVerdict
+}
+
+// BondsResponse is the bond accounting of the challenger's claimants, per game and in total.
+type BondsResponse struct {
+	Totals claims.BondTotals  `json:"totals"`
+	Games  []claims.GameBonds `json:"games"`
+}
+
+type challengerAPI struct {
+	bonds    BondAccounting
+	verdicts ProposalVerdicts
+}
+
+// NewChallengerAPI creates the challenger API. Either of bonds and verdicts may be nil if the feature is disabled.
+func NewChallengerAPI(bonds BondAccounting, verdicts ProposalVerdicts) *challengerAPI {
+	return &challengerAPI{bonds: bonds, verdicts: verdicts}
+}
+
+func GetChallengerAPI(api *challengerAPI) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "challenger",
+		Service:   api,
+	}
+}
+
+// Bonds returns the bonds posted, the credit claimable and claimed, and the expected profit of the games the
+// challenger's claimants participated in. Amounts are in wei.
+func (a *challengerAPI) Bonds(_ context.Context) (BondsResponse, error) {
+	if a.bonds == nil {
+		return BondsResponse{}, ErrBondAccountingDisabled
+	}
+	return BondsResponse{
+		Totals: a.bonds.Totals(),
+		Games:  a.bonds.Bonds(),
+	}, nil
+}
+
+// ProposalVerdicts returns the verdicts of all output roots scanned from games and the L2OutputOracle.
+func (a *challengerAPI) ProposalVerdicts(_ context.Context) ([]scanner.ProposalVerdict, error) {
+	if a.verdicts == nil {
+		return nil, ErrOutputScannerDisabled
+	}
+	return a.verdicts.Verdicts(), nil
+}
+
+// ProposalVerdict returns the verdict of the output root published by source, which is a game or the
+// L2OutputOracle, at the given index, or null if it has not been scanned yet.
+func (a *challengerAPI) ProposalVerdict(_ context.Context, source common.Address, index hexutil.Uint64) (*scanner.ProposalVerdict, error) {
+	if a.verdicts == nil {
+		return nil, ErrOutputScannerDisabled
+	}
+	verdict, ok := a.verdicts.Verdict(source, uint64(index))
+	if !ok {
+		return nil, nil
+	}
+	return &verdict, nil
+}
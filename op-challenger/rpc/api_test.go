@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scanner"
+)
+
+type stubBondAccounting struct {
+	bonds  []claims.GameBonds
+	totals claims.BondTotals
+}
+
+func (s *stubBondAccounting) Bonds() []claims.GameBonds { return s.bonds }
+
+func (s *stubBondAccounting) Totals() claims.BondTotals { return s.totals }
+
+type stubProposalVerdicts struct {
+	verdicts []scanner.ProposalVerdict
+}
+
+func (s *stubProposalVerdicts) Verdict(source common.Address, index uint64) (scanner.ProposalVerdict, bool) {
+	for _, verdict := range s.verdicts {
+		if verdict.Source == source && verdict.Index == index {
+			return verdict, true
+		}
+	}
+	return scanner.ProposalVerdict{}, false
+}
+
+func (s *stubProposalVerdicts) Verdicts() []scanner.ProposalVerdict { return s.verdicts }
+
+func TestChallengerAPI_Bonds(t *testing.T) {
+	accounting := &stubBondAccounting{
+		bonds:  []claims.GameBonds{{Game: common.Address{0x01}, Posted: big.NewInt(10)}},
+		totals: claims.BondTotals{Posted: big.NewInt(10)},
+	}
+	api := NewChallengerAPI(accounting, nil)
+	resp, err := api.Bonds(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, accounting.bonds, resp.Games)
+	require.Equal(t, accounting.totals, resp.Totals)
+}
+
+func TestChallengerAPI_ProposalVerdicts(t *testing.T) {
+	verdicts := &stubProposalVerdicts{
+		verdicts: []scanner.ProposalVerdict{
+			{Type: scanner.ProposalTypeGame, Source: common.Address{0x01}, Index: 4, Verdict: scanner.VerdictValid},
+			{Type: scanner.ProposalTypeOracle, Source: common.Address{0x02}, Index: 0, Verdict: scanner.VerdictInvalid},
+		},
+	}
+	api := NewChallengerAPI(nil, verdicts)
+
+	all, err := api.ProposalVerdicts(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, verdicts.verdicts, all)
+
+	verdict, err := api.ProposalVerdict(context.Background(), common.Address{0x02}, 0)
+	require.NoError(t, err)
+	require.Equal(t, &verdicts.verdicts[1], verdict)
+
+	verdict, err = api.ProposalVerdict(context.Background(), common.Address{0x02}, 1)
+	require.NoError(t, err)
+	require.Nil(t, verdict)
+}
+
+func TestChallengerAPI_Disabled(t *testing.T) {
+	api := NewChallengerAPI(nil, nil)
+	_, err := api.Bonds(context.Background())
+	require.ErrorIs(t, err, ErrBondAccountingDisabled)
+	_, err = api.ProposalVerdicts(context.Background())
+	require.ErrorIs(t, err, ErrOutputScannerDisabled)
+	_, err = api.ProposalVerdict(context.Background(), common.Address{0x01}, 0)
+	require.ErrorIs(t, err, ErrOutputScannerDisabled)
+}
@@ -3,6 +3,7 @@ package sender
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -170,5 +171,9 @@ func (s *stubTxMgr) BlockNumber(_ context.Context) (uint64, error) {
 	panic("unsupported")
 }
 
+func (s *stubTxMgr) SuggestGasPriceCaps(_ context.Context) (*big.Int, *big.Int, *big.Int, error) {
+	panic("unsupported")
+}
+
 func (s *stubTxMgr) Close() {
 }
@@ -25,6 +25,65 @@ func (_m *SequencerControl) EXPECT() *SequencerControl_Expecter {
 	return &SequencerControl_Expecter{mock: &_m.Mock}
 }
 
+// BlockInfoByNumber provides a mock function with given fields: ctx, number
+func (_m *SequencerControl) BlockInfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error) {
+	ret := _m.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockInfoByNumber")
+	}
+
+	var r0 eth.BlockInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (eth.BlockInfo, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) eth.BlockInfo); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(eth.BlockInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SequencerControl_BlockInfoByNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockInfoByNumber'
+type SequencerControl_BlockInfoByNumber_Call struct {
+	*mock.Call
+}
+
+// BlockInfoByNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - number uint64
+func (_e *SequencerControl_Expecter) BlockInfoByNumber(ctx interface{}, number interface{}) *SequencerControl_BlockInfoByNumber_Call {
+	return &SequencerControl_BlockInfoByNumber_Call{Call: _e.mock.On("BlockInfoByNumber", ctx, number)}
+}
+
+func (_c *SequencerControl_BlockInfoByNumber_Call) Run(run func(ctx context.Context, number uint64)) *SequencerControl_BlockInfoByNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *SequencerControl_BlockInfoByNumber_Call) Return(_a0 eth.BlockInfo, _a1 error) *SequencerControl_BlockInfoByNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SequencerControl_BlockInfoByNumber_Call) RunAndReturn(run func(context.Context, uint64) (eth.BlockInfo, error)) *SequencerControl_BlockInfoByNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LatestUnsafeBlock provides a mock function with given fields: ctx
 func (_m *SequencerControl) LatestUnsafeBlock(ctx context.Context) (eth.BlockInfo, error) {
 	ret := _m.Called(ctx)
@@ -17,6 +17,7 @@ type SequencerControl interface {
 	StopSequencer(ctx context.Context) (common.Hash, error)
 	SequencerActive(ctx context.Context) (bool, error)
 	LatestUnsafeBlock(ctx context.Context) (eth.BlockInfo, error)
+	BlockInfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error)
 	PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 }
 
@@ -40,6 +41,11 @@ func (s *sequencerController) LatestUnsafeBlock(ctx context.Context) (eth.BlockI
 	return s.exec.InfoByLabel(ctx, eth.Unsafe)
 }
 
+// BlockInfoByNumber implements SequencerControl.
+func (s *sequencerController) BlockInfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error) {
+	return s.exec.InfoByNumber(ctx, number)
+}
+
 // StartSequencer implements SequencerControl.
 func (s *sequencerController) StartSequencer(ctx context.Context, hash common.Hash) error {
 	return s.node.StartSequencer(ctx, hash)
@@ -0,0 +1,136 @@
+package conductor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
+)
+
+const (
+	// ClusterStatusPath is the path of the read-only cluster status endpoint, served next to the conductor RPC.
+	ClusterStatusPath = "/cluster/status"
+
+	// clusterStatusTimeout bounds querying the members, or the leader, for the cluster status.
+	clusterStatusTimeout = 10 * time.Second
+
+	// forwardedParam marks a cluster status request forwarded to the leader, which is not forwarded again.
+	forwardedParam = "forwarded"
+)
+
+// clusterStatusClient forwards cluster status requests to the leader.
+var clusterStatusClient = &http.Client{Timeout: clusterStatusTimeout}
+
+// ClusterStatus returns the leader's view of the cluster: the health, unsafe head and lag of each member, the unsafe
+// head of the cluster and the time of the last commit. Other members are queried at the configured peer RPC URLs.
+// It may only be called on the leader.
+func (oc *OpConductor) ClusterStatus(ctx context.Context) (*conductorrpc.ClusterStatus, error) {
+	if !oc.cons.Leader() {
+		return nil, consensus.ErrNotLeader
+	}
+	health, err := oc.ClusterHealth(ctx, oc.cfg.PeerRPCURLs)
+	if err != nil {
+		return nil, err
+	}
+	unsafe, err := oc.cons.LatestUnsafePayload()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve unsafe head from consensus: %w", err)
+	}
+
+	status := &conductorrpc.ClusterStatus{Leader: oc.cons.ServerID(), Version: health.Version}
+	if unsafe != nil {
+		status.UnsafeHead = unsafe.ExecutionPayload.ID()
+	}
+	if ms := oc.lastCommit.Load(); ms != 0 {
+		t := time.UnixMilli(ms)
+		status.LastCommitTime = &t
+	}
+	for _, sh := range health.Servers {
+		member := conductorrpc.MemberStatus{ServerHealth: sh}
+		if sh.Reachable && status.UnsafeHead.Number > sh.UnsafeHead.Number {
+			member.Lag = status.UnsafeHead.Number - sh.UnsafeHead.Number
+		}
+		status.Members = append(status.Members, member)
+	}
+	return status, nil
+}
+
+// clusterStatusHandler serves the leader's view of the cluster as JSON. The leader reports the status itself,
+// other members forward the request to the leader.
+func (oc *OpConductor) clusterStatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeStatusError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), clusterStatusTimeout)
+		defer cancel()
+
+		if oc.cons.Leader() {
+			status, err := oc.ClusterStatus(ctx)
+			if err != nil {
+				oc.log.Warn("failed to get cluster status", "err", err)
+				writeStatusError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(status)
+			return
+		}
+		if r.URL.Query().Has(forwardedParam) {
+			writeStatusError(w, http.StatusServiceUnavailable, "not the leader")
+			return
+		}
+		leader := oc.cons.LeaderWithID()
+		if leader == nil {
+			writeStatusError(w, http.StatusServiceUnavailable, "no leader")
+			return
+		}
+		rpcURL, ok := oc.cfg.PeerRPCURLs[leader.ID]
+		if !ok {
+			writeStatusError(w, http.StatusServiceUnavailable, fmt.Sprintf("no conductor RPC URL of leader %s", leader.ID))
+			return
+		}
+		if err := forwardClusterStatus(ctx, w, rpcURL); err != nil {
+			oc.log.Warn("failed to forward cluster status request to leader", "leader", leader.ID, "err", err)
+			writeStatusError(w, http.StatusBadGateway, err.Error())
+		}
+	})
+}
+
+// forwardClusterStatus copies the cluster status response of the leader's conductor at rpcURL.
+func forwardClusterStatus(ctx context.Context, w http.ResponseWriter, rpcURL string) error {
+	u, err := url.Parse(rpcURL)
+	if err != nil {
+		return fmt.Errorf("invalid leader RPC URL: %w", err)
+	}
+	u = u.JoinPath(ClusterStatusPath)
+	u.RawQuery = url.Values{forwardedParam: {"true"}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	res, err := clusterStatusClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query leader: %w", err)
+	}
+	defer res.Body.Close()
+	w.Header().Set("Content-Type", res.Header.Get("Content-Type"))
+	w.WriteHeader(res.StatusCode)
+	_, _ = io.Copy(w, res.Body)
+	return nil
+}
+
+func writeStatusError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{msg})
+}
@@ -3,6 +3,8 @@ package conductor
 import (
 	"fmt"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/pkg/errors"
@@ -15,16 +17,59 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+const (
+	ConsensusBackendRaft = "raft"
+	ConsensusBackendEtcd = "etcd"
+)
+
+const (
+	CommitModeSync  = "sync"
+	CommitModeAsync = "async"
 )
 
 type Config struct {
+	// ConsensusBackend is the consensus backend used for leader election, either raft between the conductors or a
+	// leadership lease in etcd.
+	ConsensusBackend string
+
+	// ConsensusLeaseTTL is how long the leadership lease is held without being renewed, for lease based backends.
+	ConsensusLeaseTTL time.Duration
+
+	// EtcdEndpoint is the HTTP or HTTPS endpoint of the etcd cluster used by the etcd consensus backend.
+	EtcdEndpoint string
+
+	// EtcdTLS holds the CA certificate, client certificate and client key used to connect to etcd over HTTPS.
+	EtcdTLS optls.CLIConfig
+
+	// EtcdUsername and EtcdPassword authenticate to etcd, if it has authentication enabled.
+	EtcdUsername string
+	EtcdPassword string
+
+	// EtcdKeyPrefix is the prefix of the keys used by the etcd consensus backend, allowing clusters to share etcd.
+	EtcdKeyPrefix string
+
+	// CommitMode is how unsafe payloads are committed. In sync mode a commit returns once the consensus backend has
+	// committed the payload to a quorum. In async mode a commit returns once the leader has queued the payload, which is
+	// then replicated in the background, trading durability for block time headroom.
+	CommitMode string
+
+	// CommitLatencyBudget is the commit latency above which a commit is reported as over budget. Zero disables it.
+	CommitLatencyBudget time.Duration
+
+	// RecordProvenance enables recording the provenance of unsafe payloads supplied by the sequencer. Snapshots with
+	// provenance cannot be restored by conductors that do not track it, so it is disabled by default.
+	RecordProvenance bool
+
 	// ConsensusAddr is the address to listen for consensus connections.
 	ConsensusAddr string
 
 	// ConsensusPort is the port to listen for consensus connections.
 	ConsensusPort int
 
-	// RaftServerID is the unique ID for this server used by raft consensus.
+	// RaftServerID is the unique ID for this server used by consensus.
 	RaftServerID string
 
 	// RaftStorageDir is the directory to store raft data.
@@ -33,12 +78,32 @@ type Config struct {
 	// RaftBootstrap is true if this node should bootstrap a new raft cluster.
 	RaftBootstrap bool
 
+	// RaftSnapshotInterval is how often raft checks whether to snapshot the unsafe head and compact the log.
+	RaftSnapshotInterval time.Duration
+
+	// RaftSnapshotThreshold is the number of log entries since the last snapshot required to take a new snapshot.
+	RaftSnapshotThreshold uint64
+
+	// RaftTrailingLogs is the number of log entries retained after a snapshot.
+	RaftTrailingLogs uint64
+
+	// RaftSnapshotRetain is the number of snapshots retained on disk.
+	RaftSnapshotRetain int
+
 	// NodeRPC is the HTTP provider URL for op-node.
 	NodeRPC string
 
+	// NodeAdminAPIKey is the API key to call the admin RPC methods of op-node with, optional.
+	NodeAdminAPIKey string
+
 	// ExecutionRPC is the HTTP provider URL for execution layer.
 	ExecutionRPC string
 
+	// Observer is true if this server is an observer, a non-voting member that replicates the unsafe payload log for
+	// durability and monitoring but never sequences. Observers added to the cluster as voters demote themselves to
+	// non-voters once they become leader.
+	Observer bool
+
 	// Paused is true if the conductor should start in a paused state.
 	Paused bool
 
@@ -51,6 +116,24 @@ type Config struct {
 	// RPCEnableProxy is true if the sequencer RPC proxy should be enabled.
 	RPCEnableProxy bool
 
+	// TransferLeaderMaxUnsafeLag is the maximum number of blocks a server's unsafe head may be behind the latest
+	// unsafe payload for leadership to be transferred to it with TransferLeaderToHealthyServer.
+	TransferLeaderMaxUnsafeLag uint64
+
+	// AdminRPCAddr is the listen address of the cluster membership admin RPC server.
+	AdminRPCAddr string
+
+	// AdminRPCPort is the listen port of the cluster membership admin RPC server.
+	AdminRPCPort int
+
+	// AdminRPCJWTSecret is the path to the JWT secret file authenticating requests to the cluster membership admin
+	// RPC server. The admin RPC server is disabled if it is not set.
+	AdminRPCJWTSecret string
+
+	// PeerRPCURLs are the conductor RPC URLs of the other cluster members, keyed by server ID, to report the status of
+	// the cluster with.
+	PeerRPCURLs map[string]string
+
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
@@ -68,8 +151,50 @@ func (c *Config) Check() error {
 	if c.RaftServerID == "" {
 		return fmt.Errorf("missing raft server ID")
 	}
-	if c.RaftStorageDir == "" {
-		return fmt.Errorf("missing raft storage directory")
+	switch c.ConsensusBackend {
+	case ConsensusBackendRaft:
+		if c.RaftStorageDir == "" {
+			return fmt.Errorf("missing raft storage directory")
+		}
+		if c.RaftSnapshotInterval <= 0 {
+			return fmt.Errorf("invalid raft snapshot interval")
+		}
+		if c.RaftSnapshotThreshold == 0 {
+			return fmt.Errorf("invalid raft snapshot threshold")
+		}
+		if c.RaftSnapshotRetain < 1 {
+			return fmt.Errorf("invalid raft snapshot retain count")
+		}
+	case ConsensusBackendEtcd:
+		if c.EtcdEndpoint == "" {
+			return fmt.Errorf("missing etcd endpoint")
+		}
+		if c.EtcdTLS.TLSEnabled() && !strings.HasPrefix(c.EtcdEndpoint, "https://") {
+			return fmt.Errorf("etcd tls requires an https endpoint")
+		}
+		if (c.EtcdTLS.TLSCert == "") != (c.EtcdTLS.TLSKey == "") {
+			return fmt.Errorf("etcd tls cert and key must be set together")
+		}
+		if c.EtcdPassword != "" && c.EtcdUsername == "" {
+			return fmt.Errorf("missing etcd username")
+		}
+		if c.ConsensusLeaseTTL <= 0 {
+			return fmt.Errorf("invalid consensus lease TTL")
+		}
+	default:
+		return fmt.Errorf("unknown consensus backend: %v", c.ConsensusBackend)
+	}
+	if c.Observer && c.RaftBootstrap {
+		return fmt.Errorf("observer cannot bootstrap the cluster")
+	}
+	if c.Observer && c.ConsensusBackend != ConsensusBackendRaft {
+		return fmt.Errorf("observer requires the %v consensus backend", ConsensusBackendRaft)
+	}
+	if c.CommitMode != CommitModeSync && c.CommitMode != CommitModeAsync {
+		return fmt.Errorf("unknown commit mode: %v", c.CommitMode)
+	}
+	if c.CommitLatencyBudget < 0 {
+		return fmt.Errorf("invalid commit latency budget")
 	}
 	if c.NodeRPC == "" {
 		return fmt.Errorf("missing node RPC")
@@ -77,6 +202,9 @@ func (c *Config) Check() error {
 	if c.ExecutionRPC == "" {
 		return fmt.Errorf("missing geth RPC")
 	}
+	if c.AdminRPCJWTSecret != "" && (c.AdminRPCPort < 0 || c.AdminRPCPort > math.MaxUint16) {
+		return fmt.Errorf("invalid admin RPC port")
+	}
 	if err := c.HealthCheck.Check(); err != nil {
 		return errors.Wrap(err, "invalid health check config")
 	}
@@ -106,31 +234,84 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 		return nil, errors.Wrap(err, "failed to load rollup config")
 	}
 
+	peerRPCURLs, err := parsePeerRPCURLs(ctx.StringSlice(flags.PeerRPCURLs.Name))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid peer RPC URLs")
+	}
+
+	etcdTLS := optls.CLIConfig{
+		TLSCaCert: ctx.String(flags.EtcdTLSCaCert.Name),
+		TLSCert:   ctx.String(flags.EtcdTLSCert.Name),
+		TLSKey:    ctx.String(flags.EtcdTLSKey.Name),
+	}
+
 	return &Config{
-		ConsensusAddr:  ctx.String(flags.ConsensusAddr.Name),
-		ConsensusPort:  ctx.Int(flags.ConsensusPort.Name),
-		RaftBootstrap:  ctx.Bool(flags.RaftBootstrap.Name),
-		RaftServerID:   ctx.String(flags.RaftServerID.Name),
-		RaftStorageDir: ctx.String(flags.RaftStorageDir.Name),
-		NodeRPC:        ctx.String(flags.NodeRPC.Name),
-		ExecutionRPC:   ctx.String(flags.ExecutionRPC.Name),
-		Paused:         ctx.Bool(flags.Paused.Name),
+		ConsensusBackend:      ctx.String(flags.ConsensusBackend.Name),
+		ConsensusLeaseTTL:     ctx.Duration(flags.ConsensusLeaseTTL.Name),
+		EtcdEndpoint:          ctx.String(flags.EtcdEndpoint.Name),
+		EtcdKeyPrefix:         ctx.String(flags.EtcdKeyPrefix.Name),
+		EtcdTLS:               etcdTLS,
+		EtcdUsername:          ctx.String(flags.EtcdUsername.Name),
+		EtcdPassword:          ctx.String(flags.EtcdPassword.Name),
+		CommitMode:            ctx.String(flags.CommitMode.Name),
+		CommitLatencyBudget:   ctx.Duration(flags.CommitLatencyBudget.Name),
+		RecordProvenance:      ctx.Bool(flags.RecordProvenance.Name),
+		ConsensusAddr:         ctx.String(flags.ConsensusAddr.Name),
+		ConsensusPort:         ctx.Int(flags.ConsensusPort.Name),
+		RaftBootstrap:         ctx.Bool(flags.RaftBootstrap.Name),
+		RaftServerID:          ctx.String(flags.RaftServerID.Name),
+		RaftStorageDir:        ctx.String(flags.RaftStorageDir.Name),
+		RaftSnapshotInterval:  ctx.Duration(flags.RaftSnapshotInterval.Name),
+		RaftSnapshotThreshold: ctx.Uint64(flags.RaftSnapshotThreshold.Name),
+		RaftTrailingLogs:      ctx.Uint64(flags.RaftTrailingLogs.Name),
+		RaftSnapshotRetain:    ctx.Int(flags.RaftSnapshotRetain.Name),
+		NodeRPC:               ctx.String(flags.NodeRPC.Name),
+		NodeAdminAPIKey:       ctx.String(flags.NodeAdminAPIKey.Name),
+		ExecutionRPC:          ctx.String(flags.ExecutionRPC.Name),
+		Observer:              ctx.Bool(flags.Observer.Name),
+		Paused:                ctx.Bool(flags.Paused.Name),
 		HealthCheck: HealthCheckConfig{
-			Interval:       ctx.Uint64(flags.HealthCheckInterval.Name),
-			UnsafeInterval: ctx.Uint64(flags.HealthCheckUnsafeInterval.Name),
-			SafeEnabled:    ctx.Bool(flags.HealthCheckSafeEnabled.Name),
-			SafeInterval:   ctx.Uint64(flags.HealthCheckSafeInterval.Name),
-			MinPeerCount:   ctx.Uint64(flags.HealthCheckMinPeerCount.Name),
+			Interval:              ctx.Uint64(flags.HealthCheckInterval.Name),
+			UnsafeInterval:        ctx.Uint64(flags.HealthCheckUnsafeInterval.Name),
+			SafeEnabled:           ctx.Bool(flags.HealthCheckSafeEnabled.Name),
+			SafeInterval:          ctx.Uint64(flags.HealthCheckSafeInterval.Name),
+			MinPeerCount:          ctx.Uint64(flags.HealthCheckMinPeerCount.Name),
+			UnhealthyThreshold:    ctx.Uint64(flags.HealthCheckUnhealthyThreshold.Name),
+			HealthyThreshold:      ctx.Uint64(flags.HealthCheckHealthyThreshold.Name),
+			EngineTimeout:         ctx.Uint64(flags.HealthCheckEngineTimeout.Name),
+			BuilderStrict:         ctx.Bool(flags.HealthCheckBuilderStrict.Name),
+			MaxBuilderFailureRate: ctx.Float64(flags.HealthCheckMaxBuilderFailureRate.Name),
 		},
-		RollupCfg:      *rollupCfg,
-		RPCEnableProxy: ctx.Bool(flags.RPCEnableProxy.Name),
-		LogConfig:      oplog.ReadCLIConfig(ctx),
-		MetricsConfig:  opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:    oppprof.ReadCLIConfig(ctx),
-		RPC:            oprpc.ReadCLIConfig(ctx),
+		RollupCfg:                  *rollupCfg,
+		RPCEnableProxy:             ctx.Bool(flags.RPCEnableProxy.Name),
+		TransferLeaderMaxUnsafeLag: ctx.Uint64(flags.TransferLeaderMaxUnsafeLag.Name),
+		AdminRPCAddr:               ctx.String(flags.AdminRPCAddr.Name),
+		AdminRPCPort:               ctx.Int(flags.AdminRPCPort.Name),
+		AdminRPCJWTSecret:          ctx.String(flags.AdminRPCJWTSecret.Name),
+		PeerRPCURLs:                peerRPCURLs,
+		LogConfig:                  oplog.ReadCLIConfig(ctx),
+		MetricsConfig:              opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:                oppprof.ReadCLIConfig(ctx),
+		RPC:                        oprpc.ReadCLIConfig(ctx),
 	}, nil
 }
 
+// parsePeerRPCURLs parses conductor RPC URLs of cluster members, given as <server-id>=<url>.
+func parsePeerRPCURLs(entries []string) (map[string]string, error) {
+	urls := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		id, url, ok := strings.Cut(entry, "=")
+		if !ok || id == "" || url == "" {
+			return nil, fmt.Errorf("expected <server-id>=<url>, got %q", entry)
+		}
+		if _, ok := urls[id]; ok {
+			return nil, fmt.Errorf("duplicate server ID %q", id)
+		}
+		urls[id] = url
+	}
+	return urls, nil
+}
+
 // HealthCheckConfig defines health check configuration.
 type HealthCheckConfig struct {
 	// Interval is the interval (in seconds) to check the health of the sequencer.
@@ -147,6 +328,24 @@ type HealthCheckConfig struct {
 
 	// MinPeerCount is the minimum number of peers required for the sequencer to be healthy.
 	MinPeerCount uint64
+
+	// UnhealthyThreshold is the number of consecutive failed health checks required to consider the sequencer
+	// unhealthy, which transfers leadership away from an active sequencer. Zero is treated as one.
+	UnhealthyThreshold uint64
+
+	// HealthyThreshold is the number of consecutive passed health checks required to consider an unhealthy sequencer
+	// healthy again. Zero is treated as one.
+	HealthyThreshold uint64
+
+	// EngineTimeout is the timeout (in seconds) of the execution engine responsiveness probe, defaulting to Interval.
+	EngineTimeout uint64
+
+	// BuilderStrict requires the sequencer to have a healthy block builder: the builder is available,
+	// and at most MaxBuilderFailureRate of the recent blocks were not built by the builder.
+	BuilderStrict bool
+
+	// MaxBuilderFailureRate is the maximum fraction of recent blocks that were not built by the block builder.
+	MaxBuilderFailureRate float64
 }
 
 func (c *HealthCheckConfig) Check() error {
@@ -159,5 +358,8 @@ func (c *HealthCheckConfig) Check() error {
 	if c.MinPeerCount == 0 {
 		return fmt.Errorf("missing minimum peer count")
 	}
+	if c.BuilderStrict && (c.MaxBuilderFailureRate < 0 || c.MaxBuilderFailureRate > 1) {
+		return fmt.Errorf("max builder failure rate must be between 0 and 1, got %v", c.MaxBuilderFailureRate)
+	}
 	return nil
 }
@@ -12,7 +12,6 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/hashicorp/go-multierror"
-	"github.com/hashicorp/raft"
 	"github.com/pkg/errors"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/client"
@@ -26,8 +25,10 @@ import (
 	opclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	ophealth "github.com/ethereum-optimism/optimism/op-service/health"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
@@ -37,8 +38,36 @@ var (
 	ErrPauseTimeout       = errors.New("timeout to pause conductor")
 	ErrUnsafeHeadMismatch = errors.New("unsafe head mismatch")
 	ErrNoUnsafeHead       = errors.New("no unsafe head")
+
+	ErrTransferTargetUnhealthy = errors.New("leadership transfer target is not healthy")
+	ErrTransferTargetBehind    = errors.New("leadership transfer target is too far behind the unsafe head")
+	ErrTransferTargetDiverged  = errors.New("leadership transfer target is not on the chain of the unsafe head")
+
+	ErrCommitQueueClosed = errors.New("commit queue closed")
 )
 
+// commitQueueSize is the number of unsafe payloads that may be queued for replication in async commit mode.
+const commitQueueSize = 64
+
+// peerHealthTimeout bounds querying the health of another cluster member.
+const peerHealthTimeout = 5 * time.Second
+
+// peerConductor is the conductor API of another server in the cluster, checked before transferring leadership to it
+// and when reporting the health of the cluster.
+type peerConductor interface {
+	SequencerHealthy(ctx context.Context) (bool, error)
+	SequencerUnsafeHead(ctx context.Context) (eth.BlockID, error)
+	Close()
+}
+
+func dialPeerConductor(ctx context.Context, rpcURL string) (peerConductor, error) {
+	c, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	return conductorrpc.NewAPIClient(c), nil
+}
+
 // New creates a new OpConductor instance.
 func New(ctx context.Context, cfg *Config, log log.Logger, version string) (*OpConductor, error) {
 	return NewOpConductor(ctx, cfg, log, metrics.NewMetrics(), version, nil, nil, nil)
@@ -69,10 +98,12 @@ func NewOpConductor(
 		resumeCh:     make(chan struct{}),
 		resumeDoneCh: make(chan struct{}),
 		actionCh:     make(chan struct{}, 1),
+		commitQueue:  make(chan unsafeCommit, commitQueueSize),
 		ctrl:         ctrl,
 		cons:         cons,
 		hmon:         hmon,
 		retryBackoff: func() time.Duration { return time.Duration(rand.Intn(2000)) * time.Millisecond },
+		dialPeer:     dialPeerConductor,
 	}
 	oc.loopActionFn = oc.loopAction
 
@@ -114,6 +145,9 @@ func (c *OpConductor) init(ctx context.Context) error {
 	if err := c.initRPCServer(ctx); err != nil {
 		return errors.Wrap(err, "failed to initialize rpc server")
 	}
+	if err := c.initAdminRPCServer(ctx); err != nil {
+		return errors.Wrap(err, "failed to initialize admin rpc server")
+	}
 	return nil
 }
 
@@ -133,7 +167,7 @@ func (c *OpConductor) initSequencerControl(ctx context.Context) error {
 		return errors.Wrap(err, "failed to create geth client")
 	}
 
-	nc, err := opclient.NewRPC(ctx, c.log, c.cfg.NodeRPC)
+	nc, err := opclient.NewRPC(ctx, c.log, c.cfg.NodeRPC, c.nodeRPCOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "failed to create node rpc client")
 	}
@@ -148,16 +182,60 @@ func (c *OpConductor) initConsensus(ctx context.Context) error {
 		return nil
 	}
 
-	serverAddr := fmt.Sprintf("%s:%d", c.cfg.ConsensusAddr, c.cfg.ConsensusPort)
-	cons, err := consensus.NewRaftConsensus(c.log, c.cfg.RaftServerID, serverAddr, c.cfg.RaftStorageDir, c.cfg.RaftBootstrap, &c.cfg.RollupCfg)
+	cons, err := c.newConsensus()
 	if err != nil {
-		return errors.Wrap(err, "failed to create raft consensus")
+		return err
 	}
 	c.cons = cons
 	c.leaderUpdateCh = c.cons.LeaderCh()
 	return nil
 }
 
+func (c *OpConductor) newConsensus() (consensus.Consensus, error) {
+	serverAddr := fmt.Sprintf("%s:%d", c.cfg.ConsensusAddr, c.cfg.ConsensusPort)
+	switch c.cfg.ConsensusBackend {
+	case ConsensusBackendEtcd:
+		leaseConsensusConfig := &consensus.LeaseConsensusConfig{
+			ServerID:   c.cfg.RaftServerID,
+			ServerAddr: serverAddr,
+			Bootstrap:  c.cfg.RaftBootstrap,
+			LeaseTTL:   c.cfg.ConsensusLeaseTTL,
+		}
+		store, err := consensus.NewEtcdLeaseStore(&consensus.EtcdConfig{
+			Endpoint:  c.cfg.EtcdEndpoint,
+			KeyPrefix: c.cfg.EtcdKeyPrefix,
+			TLS:       c.cfg.EtcdTLS,
+			Username:  c.cfg.EtcdUsername,
+			Password:  c.cfg.EtcdPassword,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create etcd lease store")
+		}
+		cons, err := consensus.NewLeaseConsensus(c.log, leaseConsensusConfig, store)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create etcd lease consensus")
+		}
+		return cons, nil
+	default:
+		raftConsensusConfig := &consensus.RaftConsensusConfig{
+			ServerID:          c.cfg.RaftServerID,
+			ServerAddr:        serverAddr,
+			StorageDir:        c.cfg.RaftStorageDir,
+			Bootstrap:         c.cfg.RaftBootstrap,
+			RollupCfg:         &c.cfg.RollupCfg,
+			SnapshotInterval:  c.cfg.RaftSnapshotInterval,
+			SnapshotThreshold: c.cfg.RaftSnapshotThreshold,
+			TrailingLogs:      c.cfg.RaftTrailingLogs,
+			SnapshotRetain:    c.cfg.RaftSnapshotRetain,
+		}
+		cons, err := consensus.NewRaftConsensus(c.log, raftConsensusConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create raft consensus")
+		}
+		return cons, nil
+	}
+}
+
 func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 	if c.hmon != nil {
 		return nil
@@ -175,6 +253,16 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 	}
 	p2p := opp2p.NewClient(pc)
 
+	// the builder status is only checked if the cluster requires a healthy builder,
+	// which is rejected if the sequencer does not report the status of a builder.
+	var builder health.BuilderStatusProvider
+	if c.cfg.HealthCheck.BuilderStrict {
+		if err := health.CheckBuilderConfigured(ctx, node); err != nil {
+			return errors.Wrap(err, "builder-strict health check requires a sequencer with a block builder")
+		}
+		builder = node
+	}
+
 	c.hmon = health.NewSequencerHealthMonitor(
 		c.log,
 		c.metrics,
@@ -182,10 +270,16 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 		c.cfg.HealthCheck.UnsafeInterval,
 		c.cfg.HealthCheck.SafeInterval,
 		c.cfg.HealthCheck.MinPeerCount,
+		c.cfg.HealthCheck.UnhealthyThreshold,
+		c.cfg.HealthCheck.HealthyThreshold,
 		c.cfg.HealthCheck.SafeEnabled,
 		&c.cfg.RollupCfg,
 		node,
 		p2p,
+		c.ctrl,
+		c.cfg.HealthCheck.EngineTimeout,
+		builder,
+		c.cfg.HealthCheck.MaxBuilderFailureRate,
 	)
 	c.healthUpdateCh = c.hmon.Subscribe()
 
@@ -193,11 +287,20 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 }
 
 func (oc *OpConductor) initRPCServer(ctx context.Context) error {
+	// the node admin methods are proxied if the proxy is enabled
+	adminAuth, err := oprpc.NewAdminAuth(oc.cfg.RPC.AdminAuth, []string{conductorrpc.NodeAdminRPCNamespace}, conductorrpc.AdminMethods)
+	if err != nil {
+		return errors.Wrap(err, "failed to configure admin RPC authentication")
+	}
 	server := oprpc.NewServer(
 		oc.cfg.RPC.ListenAddr,
 		oc.cfg.RPC.ListenPort,
 		oc.version,
 		oprpc.WithLogger(oc.log),
+		oprpc.WithRPCMetrics(oc.metrics),
+		oprpc.WithHealthChecks(oc.healthChecks()),
+		oprpc.WithAdminAuth(adminAuth),
+		oprpc.WithHTTPHandler(ClusterStatusPath, oc.clusterStatusHandler()),
 	)
 	api := conductorrpc.NewAPIBackend(oc.log, oc)
 	server.AddAPI(rpc.API{
@@ -217,10 +320,11 @@ func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 			Service:   executionProxy,
 		})
 
-		nodeClient, err := dial.DialRollupClientWithTimeout(ctx, 1*time.Minute, oc.log, oc.cfg.NodeRPC)
+		nc, err := opclient.NewRPC(ctx, oc.log, oc.cfg.NodeRPC, oc.nodeRPCOptions()...)
 		if err != nil {
 			return errors.Wrap(err, "failed to create node rpc client")
 		}
+		nodeClient := sources.NewRollupClient(nc)
 		nodeProxy := conductorrpc.NewNodeProxyBackend(oc.log, oc, nodeClient)
 		server.AddAPI(rpc.API{
 			Namespace: conductorrpc.NodeRPCNamespace,
@@ -238,6 +342,68 @@ func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 	return nil
 }
 
+// nodeRPCOptions returns the options of the op-node RPC clients that call admin RPC methods.
+func (oc *OpConductor) nodeRPCOptions() []opclient.RPCOption {
+	if oc.cfg.NodeAdminAPIKey == "" {
+		return nil
+	}
+	return []opclient.RPCOption{opclient.WithGethRPCOptions(rpc.WithHeader(oprpc.APIKeyHeader, oc.cfg.NodeAdminAPIKey))}
+}
+
+// healthChecks returns the health probes of the conductor, served by the RPC server.
+func (oc *OpConductor) healthChecks() *ophealth.Checks {
+	checks := ophealth.NewChecks(oc.version)
+	checks.Register("conductor", func(ctx context.Context) ophealth.Result {
+		switch {
+		case oc.Stopped():
+			return ophealth.Failing("conductor is stopped")
+		case oc.Paused():
+			return ophealth.Degraded("conductor is paused")
+		default:
+			return ophealth.OK()
+		}
+	})
+	checks.Register("sequencer", func(ctx context.Context) ophealth.Result {
+		if !oc.SequencerHealthy(ctx) {
+			return ophealth.Degraded("sequencer is not healthy")
+		}
+		return ophealth.OK()
+	})
+	return checks
+}
+
+// initAdminRPCServer initializes the cluster membership admin RPC server, which is only enabled with a JWT secret.
+func (oc *OpConductor) initAdminRPCServer(_ context.Context) error {
+	if oc.cfg.AdminRPCJWTSecret == "" {
+		oc.log.Info("admin RPC server disabled, no JWT secret configured")
+		return nil
+	}
+	secrets, err := opclient.NewJWTSecretsFromFile(oc.log, oc.cfg.AdminRPCJWTSecret)
+	if err != nil {
+		return err
+	}
+	adminAuth, err := oprpc.NewAdminAuth(oc.cfg.RPC.AdminAuth, []string{conductorrpc.AdminRPCNamespace}, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to configure admin RPC authentication")
+	}
+	server := oprpc.NewServer(
+		oc.cfg.AdminRPCAddr,
+		oc.cfg.AdminRPCPort,
+		oc.version,
+		oprpc.WithLogger(oc.log),
+		oprpc.WithRPCMetrics(oc.metrics),
+		oprpc.WithJWTSecrets(secrets),
+		oprpc.WithAdminAuth(adminAuth),
+	)
+	server.AddAPI(rpc.API{
+		Namespace: conductorrpc.AdminRPCNamespace,
+		Version:   oc.version,
+		Service:   conductorrpc.NewAdminAPIBackend(oc.log, oc),
+	})
+	oc.adminRPCServer = server
+	return nil
+}
+
 // OpConductor represents a full conductor instance and its resources, it does:
 //  1. performs health checks on sequencer
 //  2. participate in consensus protocol for leader election
@@ -278,10 +444,16 @@ type OpConductor struct {
 	shutdownCtx    context.Context
 	shutdownCancel context.CancelFunc
 
-	rpcServer     *oprpc.Server
-	metricsServer *httputil.HTTPServer
+	commitQueue chan unsafeCommit // commitQueue holds unsafe payloads to replicate in async commit mode.
+	lastCommit  atomic.Int64      // lastCommit is the unix time in milliseconds of the last successful commit, 0 if none.
+
+	rpcServer      *oprpc.Server
+	adminRPCServer *oprpc.Server
+	metricsServer  *httputil.HTTPServer
+	pprofService   *oppprof.Service
 
 	retryBackoff func() time.Duration
+	dialPeer     func(ctx context.Context, rpcURL string) (peerConductor, error)
 }
 
 type state struct {
@@ -320,6 +492,13 @@ func (oc *OpConductor) Start(ctx context.Context) error {
 		return errors.Wrap(err, "failed to start JSON-RPC server")
 	}
 
+	if oc.adminRPCServer != nil {
+		oc.log.Info("starting admin JSON-RPC server")
+		if err := oc.adminRPCServer.Start(); err != nil {
+			return errors.Wrap(err, "failed to start admin JSON-RPC server")
+		}
+	}
+
 	if oc.cfg.MetricsConfig.Enabled {
 		oc.log.Info("starting metrics server")
 		m, ok := oc.metrics.(opmetrics.RegistryMetricer)
@@ -333,9 +512,24 @@ func (oc *OpConductor) Start(ctx context.Context) error {
 		oc.metricsServer = metricsServer
 	}
 
+	oc.pprofService = oppprof.NewFromConfig(oc.cfg.PprofConfig)
+	if err := oc.pprofService.Start(); err != nil {
+		return errors.Wrap(err, "failed to start pprof service")
+	}
+
 	oc.wg.Add(1)
 	go oc.loop()
 
+	if oc.cfg.CommitMode == CommitModeAsync {
+		oc.wg.Add(1)
+		go oc.commitLoop()
+	}
+
+	if oc.cfg.Observer {
+		oc.wg.Add(1)
+		go oc.observeLoop()
+	}
+
 	oc.metrics.RecordInfo(oc.version)
 	oc.metrics.RecordUp()
 
@@ -367,6 +561,12 @@ func (oc *OpConductor) Stop(ctx context.Context) error {
 		}
 	}
 
+	if oc.adminRPCServer != nil {
+		if err := oc.adminRPCServer.Stop(); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to stop admin rpc server"))
+		}
+	}
+
 	// stop health check
 	if oc.hmon != nil {
 		if err := oc.hmon.Stop(); err != nil {
@@ -386,6 +586,12 @@ func (oc *OpConductor) Stop(ctx context.Context) error {
 		}
 	}
 
+	if oc.pprofService != nil {
+		if err := oc.pprofService.Stop(ctx); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to stop pprof service"))
+		}
+	}
+
 	if result.ErrorOrNil() != nil {
 		oc.log.Error("failed to stop OpConductor", "err", result.ErrorOrNil())
 		return result.ErrorOrNil()
@@ -477,9 +683,215 @@ func (oc *OpConductor) TransferLeaderToServer(_ context.Context, id string, addr
 	return oc.cons.TransferLeaderTo(id, addr)
 }
 
+// TransferLeaderToHealthyServer transfers leadership to a specific server only if its sequencer, checked through the
+// server's configured peer conductor RPC URL, is healthy and its unsafe head is the latest unsafe payload, or a local
+// ancestor of it within the configured number of blocks.
+func (oc *OpConductor) TransferLeaderToHealthyServer(ctx context.Context, id string, addr string) error {
+	if !oc.cons.Leader() {
+		return consensus.ErrNotLeader
+	}
+	rpcURL, ok := oc.cfg.PeerRPCURLs[id]
+	if !ok {
+		return fmt.Errorf("no conductor RPC URL of transfer target %s", id)
+	}
+	peer, err := oc.dialPeer(ctx, rpcURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to dial conductor of transfer target")
+	}
+	defer peer.Close()
+
+	healthy, err := peer.SequencerHealthy(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get health of transfer target")
+	}
+	if !healthy {
+		return ErrTransferTargetUnhealthy
+	}
+	peerHead, err := peer.SequencerUnsafeHead(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get unsafe head of transfer target")
+	}
+	unsafeInCons, err := oc.cons.LatestUnsafePayload()
+	if err != nil {
+		return errors.Wrap(err, "unable to retrieve unsafe head from consensus")
+	}
+	if unsafeInCons == nil {
+		return ErrNoUnsafeHead
+	}
+	head := unsafeInCons.ExecutionPayload.ID()
+	if head.Number > peerHead.Number && head.Number-peerHead.Number > oc.cfg.TransferLeaderMaxUnsafeLag {
+		return fmt.Errorf("%w: %d blocks behind", ErrTransferTargetBehind, head.Number-peerHead.Number)
+	}
+	if err := oc.checkCanonical(ctx, head, peerHead); err != nil {
+		return err
+	}
+
+	oc.log.Info("transferring leadership to healthy server", "server", oc.cons.ServerID(), "target", id, "consensus_head", head, "target_head", peerHead)
+	err = oc.cons.TransferLeaderTo(id, addr)
+	oc.metrics.RecordLeaderTransfer(err == nil)
+	return err
+}
+
+// checkCanonical returns ErrTransferTargetDiverged unless the unsafe head of the transfer target is the unsafe head
+// in consensus, or one of its ancestors, as known to the local sequencer.
+func (oc *OpConductor) checkCanonical(ctx context.Context, head eth.BlockID, peerHead eth.BlockID) error {
+	if peerHead.Number >= head.Number {
+		if peerHead != head {
+			return fmt.Errorf("%w: target head %s, consensus head %s", ErrTransferTargetDiverged, peerHead, head)
+		}
+		return nil
+	}
+	info, err := oc.ctrl.BlockInfoByNumber(ctx, peerHead.Number)
+	if err != nil {
+		return errors.Wrap(err, "failed to get local block at unsafe head of transfer target")
+	}
+	if info.Hash() != peerHead.Hash {
+		return fmt.Errorf("%w: target head %s, local block %s", ErrTransferTargetDiverged, peerHead, info.Hash())
+	}
+	return nil
+}
+
 // CommitUnsafePayload commits an unsafe payload (latest head) to the cluster FSM ensuring strong consistency by leveraging Raft consensus mechanisms.
-func (oc *OpConductor) CommitUnsafePayload(_ context.Context, payload *eth.ExecutionPayloadEnvelope) error {
-	return oc.cons.CommitUnsafePayload(payload)
+// In async commit mode, it returns once the payload is queued on the leader, and the payload is replicated in the background.
+func (oc *OpConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
+	return oc.CommitUnsafePayloadWithMetadata(ctx, payload, nil)
+}
+
+// CommitUnsafePayloadWithMetadata commits an unsafe payload like CommitUnsafePayload, along with its provenance
+// supplied by the sequencer. The provenance is dropped unless recording it is enabled.
+func (oc *OpConductor) CommitUnsafePayloadWithMetadata(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *consensus.PayloadMetadata) error {
+	if !oc.cfg.RecordProvenance {
+		metadata = nil
+	}
+	commit := unsafeCommit{payload: payload, metadata: metadata}
+	if oc.cfg.CommitMode != CommitModeAsync {
+		return oc.commitUnsafePayload(commit)
+	}
+
+	if !oc.cons.Leader() {
+		return consensus.ErrNotLeader
+	}
+	select {
+	case oc.commitQueue <- commit:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-oc.shutdownCtx.Done():
+		return ErrCommitQueueClosed
+	}
+}
+
+// unsafeCommit is an unsafe payload to commit along with its provenance, if known.
+type unsafeCommit struct {
+	payload  *eth.ExecutionPayloadEnvelope
+	metadata *consensus.PayloadMetadata
+}
+
+// commitUnsafePayload commits an unsafe payload to consensus, recording the commit latency against the budget.
+func (oc *OpConductor) commitUnsafePayload(commit unsafeCommit) error {
+	start := time.Now()
+	err := oc.cons.CommitUnsafePayload(commit.payload, commit.metadata)
+	elapsed := time.Since(start)
+
+	oc.metrics.RecordCommit(oc.cfg.CommitMode, err == nil, elapsed.Seconds())
+	if err == nil {
+		oc.lastCommit.Store(time.Now().UnixMilli())
+	}
+	if budget := oc.cfg.CommitLatencyBudget; budget > 0 && elapsed > budget {
+		oc.log.Warn("unsafe payload commit exceeded latency budget", "number", uint64(commit.payload.ExecutionPayload.BlockNumber), "elapsed", elapsed, "budget", budget)
+		oc.metrics.RecordCommitBudgetExceeded(oc.cfg.CommitMode)
+	}
+	return err
+}
+
+// PayloadProvenance returns the provenance of a recently committed unsafe block, or nil if it is not known.
+func (oc *OpConductor) PayloadProvenance(_ context.Context, number uint64) (*consensus.BlockProvenance, error) {
+	return oc.cons.PayloadProvenance(number)
+}
+
+// observeLoop records the replication lag of the unsafe payload log on observers, at the health check interval.
+func (oc *OpConductor) observeLoop() {
+	defer oc.wg.Done()
+
+	ticker := time.NewTicker(time.Duration(oc.cfg.HealthCheck.Interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			oc.recordReplicationLag()
+			if err := oc.ensureNonVoter(); err != nil {
+				oc.log.Error("failed to demote observer to a non-voter", "server", oc.cons.ServerID(), "err", err)
+			}
+		case <-oc.shutdownCtx.Done():
+			return
+		}
+	}
+}
+
+// recordReplicationLag records how many log entries committed by the leader are not yet applied to this server, along
+// with the latest unsafe payload applied.
+func (oc *OpConductor) recordReplicationLag() {
+	commitIndex, appliedIndex, err := oc.cons.ReplicationIndexes()
+	if err != nil {
+		oc.log.Warn("failed to get replication indexes", "err", err)
+		return
+	}
+	var lag uint64
+	if commitIndex > appliedIndex {
+		lag = commitIndex - appliedIndex
+	}
+	var unsafeHead uint64
+	payload, err := oc.cons.LocalUnsafePayload()
+	if err != nil {
+		oc.log.Warn("failed to get replicated unsafe payload", "err", err)
+		return
+	}
+	if payload != nil {
+		unsafeHead = uint64(payload.ExecutionPayload.BlockNumber)
+	}
+	oc.metrics.RecordReplicationLag(unsafeHead, lag)
+}
+
+// ensureNonVoter demotes this observer to a non-voter if it was added to the cluster as a voter. Membership changes
+// can only be applied by the leader, so a voting observer that is a follower is only reported until it becomes leader.
+func (oc *OpConductor) ensureNonVoter() error {
+	membership, err := oc.cons.ClusterMembership()
+	if err != nil {
+		return errors.Wrap(err, "failed to get cluster membership")
+	}
+	id := oc.cons.ServerID()
+	for _, server := range membership.Servers {
+		if server.ID != id || server.Suffrage != consensus.Voter {
+			continue
+		}
+		if !oc.cons.Leader() {
+			oc.log.Error("observer is a voting member of the cluster, it should be added as a non-voter", "server", id)
+			return nil
+		}
+		oc.log.Warn("demoting observer to a non-voter", "server", id)
+		return oc.cons.DemoteVoter(id, membership.Version)
+	}
+	return nil
+}
+
+// commitLoop replicates queued unsafe payloads in order in async commit mode.
+func (oc *OpConductor) commitLoop() {
+	defer oc.wg.Done()
+
+	for {
+		select {
+		case commit := <-oc.commitQueue:
+			if err := oc.commitUnsafePayload(commit); err != nil {
+				payload := commit.payload.ExecutionPayload
+				oc.log.Error("failed to replicate unsafe payload", "number", uint64(payload.BlockNumber), "hash", payload.BlockHash, "err", err)
+			}
+		case <-oc.shutdownCtx.Done():
+			if n := len(oc.commitQueue); n > 0 {
+				oc.log.Warn("dropping unsafe payloads queued for replication", "count", n)
+			}
+			return
+		}
+	}
 }
 
 // SequencerHealthy returns true if sequencer is healthy.
@@ -487,11 +899,87 @@ func (oc *OpConductor) SequencerHealthy(_ context.Context) bool {
 	return oc.healthy.Load()
 }
 
+// SequencerUnsafeHead returns the latest unsafe block of the local sequencer.
+func (oc *OpConductor) SequencerUnsafeHead(ctx context.Context) (eth.BlockID, error) {
+	info, err := oc.ctrl.LatestUnsafeBlock(ctx)
+	if err != nil {
+		return eth.BlockID{}, errors.Wrap(err, "failed to get latest unsafe block")
+	}
+	return eth.ToBlockID(info), nil
+}
+
 // ClusterMembership returns current cluster's membership information.
 func (oc *OpConductor) ClusterMembership(_ context.Context) (*consensus.ClusterMembership, error) {
 	return oc.cons.ClusterMembership()
 }
 
+// ClusterHealth returns current cluster's membership information with the health of each member. The health of this
+// server is reported locally, the health of other members is queried from their conductors at the RPC URLs in rpcURLs
+// keyed by server ID. Members that cannot be queried are reported as unreachable.
+func (oc *OpConductor) ClusterHealth(ctx context.Context, rpcURLs map[string]string) (*conductorrpc.ClusterHealth, error) {
+	membership, err := oc.cons.ClusterMembership()
+	if err != nil {
+		return nil, err
+	}
+	leader := oc.cons.LeaderWithID()
+	health := &conductorrpc.ClusterHealth{
+		Version: membership.Version,
+		Servers: make([]conductorrpc.ServerHealth, len(membership.Servers)),
+	}
+	// Query the members concurrently, so that unreachable members do not delay the health of the others.
+	var wg sync.WaitGroup
+	for i, server := range membership.Servers {
+		health.Servers[i] = conductorrpc.ServerHealth{
+			ServerInfo: server,
+			Leader:     leader != nil && leader.ID == server.ID,
+		}
+		wg.Add(1)
+		go func(sh *conductorrpc.ServerHealth) {
+			defer wg.Done()
+			if err := oc.serverHealth(ctx, sh, rpcURLs[sh.ID]); err != nil {
+				sh.Error = err.Error()
+			} else {
+				sh.Reachable = true
+			}
+		}(&health.Servers[i])
+	}
+	wg.Wait()
+	return health, nil
+}
+
+func (oc *OpConductor) serverHealth(ctx context.Context, sh *conductorrpc.ServerHealth, rpcURL string) error {
+	if sh.ID == oc.cons.ServerID() {
+		head, err := oc.SequencerUnsafeHead(ctx)
+		if err != nil {
+			return err
+		}
+		sh.Healthy, sh.UnsafeHead = oc.healthy.Load(), head
+		return nil
+	}
+	if rpcURL == "" {
+		return errors.New("no conductor RPC URL")
+	}
+	ctx, cancel := context.WithTimeout(ctx, peerHealthTimeout)
+	defer cancel()
+	peer, err := oc.dialPeer(ctx, rpcURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to dial conductor")
+	}
+	defer peer.Close()
+	if sh.Healthy, err = peer.SequencerHealthy(ctx); err != nil {
+		return errors.Wrap(err, "failed to get sequencer health")
+	}
+	if sh.UnsafeHead, err = peer.SequencerUnsafeHead(ctx); err != nil {
+		return errors.Wrap(err, "failed to get sequencer unsafe head")
+	}
+	return nil
+}
+
+// Bootstrap bootstraps a new cluster with this server as the only voter.
+func (oc *OpConductor) Bootstrap(_ context.Context) error {
+	return oc.cons.Bootstrap()
+}
+
 // LatestUnsafePayload returns the latest unsafe payload envelope from FSM in a strongly consistent fashion.
 func (oc *OpConductor) LatestUnsafePayload(_ context.Context) (*eth.ExecutionPayloadEnvelope, error) {
 	return oc.cons.LatestUnsafePayload()
@@ -578,8 +1066,25 @@ func (oc *OpConductor) action() {
 	status := NewState(oc.leader.Load(), oc.healthy.Load(), oc.seqActive.Load())
 	oc.log.Debug("entering action with status", "status", status)
 
-	// exhaust all cases below for completeness, 3 state, 8 cases.
+	// exhaust all cases below for completeness, 3 state, 8 cases, and observers.
 	switch {
+	case oc.cfg.Observer:
+		// observers replicate the unsafe payload log without sequencing, so stop sequencing and demote this server to
+		// a non-voter if it became leader, which only happens if it was added to the cluster as a voter. The demotion
+		// makes the leader step down and hands over leadership.
+		var result *multierror.Error
+		if status.active {
+			if e := oc.stopSequencer(); e != nil {
+				result = multierror.Append(result, e)
+			}
+		}
+		if status.leader {
+			oc.log.Error("observer became leader", "server", oc.cons.ServerID())
+			if e := oc.ensureNonVoter(); e != nil {
+				result = multierror.Append(result, e)
+			}
+		}
+		err = result.ErrorOrNil()
 	case !status.leader && !status.healthy && !status.active:
 		// if follower is not healthy and not sequencing, just log an error
 		oc.log.Error("server (follower) is not healthy", "server", oc.cons.ServerID())
@@ -596,7 +1101,7 @@ func (oc *OpConductor) action() {
 		// 1. current node is follower, active sequencer became unhealthy and started the leadership transfer process.
 		//    however if leadership transfer took longer than the time for health monitor to treat the node as unhealthy,
 		//    then basically the entire network is stalled and we need to start sequencing in this case.
-		if !oc.prevState.leader && !oc.prevState.active && !errors.Is(oc.hcerr, health.ErrSequencerConnectionDown) {
+		if !oc.prevState.leader && !oc.prevState.active && !sequencerUnreachable(oc.hcerr) {
 			err = oc.startSequencer()
 			if err != nil {
 				oc.log.Error("failed to start sequencer, transferring leadership instead", "server", oc.cons.ServerID(), "err", err)
@@ -612,9 +1117,11 @@ func (oc *OpConductor) action() {
 		// There are two scenarios we need to handle here:
 		// 1. we're transitioned from case status.leader && !status.healthy && !status.active, see description above
 		//    then we should continue to sequence blocks and try to bring ourselves back to healthy state.
-		//    note: we need to also make sure that the health error is not due to ErrSequencerConnectionDown
-		//    		because in this case, we should stop sequencing and transfer leadership to other nodes.
-		if oc.prevState.leader && !oc.prevState.healthy && !oc.prevState.active && !errors.Is(oc.hcerr, health.ErrSequencerConnectionDown) {
+		//    note: we need to also make sure that the health error is not due to ErrSequencerConnectionDown,
+		//    		ErrSequencerEngineUnresponsive or ErrSequencerBuilderUnhealthy because in this case, the sequencer
+		//    		cannot recover by itself and
+		//    		we should stop sequencing and transfer leadership to other nodes.
+		if oc.prevState.leader && !oc.prevState.healthy && !oc.prevState.active && !sequencerUnreachable(oc.hcerr) {
 			err = errors.New("waiting for sequencing to become healthy by itself")
 			break
 		}
@@ -660,6 +1167,15 @@ func (oc *OpConductor) action() {
 	}
 }
 
+// sequencerUnreachable returns true if the health check failed because the sequencer's node or execution engine is
+// not responding, or its block builder is unhealthy, in which case it cannot recover by sequencing and leadership
+// should be transferred instead.
+func sequencerUnreachable(hcerr error) bool {
+	return errors.Is(hcerr, health.ErrSequencerConnectionDown) ||
+		errors.Is(hcerr, health.ErrSequencerEngineUnresponsive) ||
+		errors.Is(hcerr, health.ErrSequencerBuilderUnhealthy)
+}
+
 // transferLeader tries to transfer leadership to another server.
 func (oc *OpConductor) transferLeader() error {
 	// TransferLeader here will do round robin to try to transfer leadership to the next healthy node.
@@ -672,7 +1188,7 @@ func (oc *OpConductor) transferLeader() error {
 	}
 
 	switch {
-	case errors.Is(err, raft.ErrNotLeader):
+	case errors.Is(err, consensus.ErrNotLeader):
 		// This node is not the leader, do nothing.
 		oc.log.Warn("cannot transfer leadership since current server is not the leader")
 		return nil
@@ -2,8 +2,11 @@ package conductor
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -13,13 +16,16 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	clientmocks "github.com/ethereum-optimism/optimism/op-conductor/client/mocks"
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
 	consensusmocks "github.com/ethereum-optimism/optimism/op-conductor/consensus/mocks"
 	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	healthmocks "github.com/ethereum-optimism/optimism/op-conductor/health/mocks"
 	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
+	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -29,14 +35,20 @@ import (
 func mockConfig(t *testing.T) Config {
 	now := uint64(time.Now().Unix())
 	return Config{
-		ConsensusAddr:  "127.0.0.1",
-		ConsensusPort:  50050,
-		RaftServerID:   "SequencerA",
-		RaftStorageDir: "/tmp/raft",
-		RaftBootstrap:  false,
-		NodeRPC:        "http://node:8545",
-		ExecutionRPC:   "http://geth:8545",
-		Paused:         false,
+		ConsensusAddr:         "127.0.0.1",
+		ConsensusPort:         50050,
+		RaftServerID:          "SequencerA",
+		ConsensusBackend:      ConsensusBackendRaft,
+		CommitMode:            CommitModeSync,
+		RaftStorageDir:        "/tmp/raft",
+		RaftBootstrap:         false,
+		RaftSnapshotInterval:  120 * time.Second,
+		RaftSnapshotThreshold: 8192,
+		RaftTrailingLogs:      10240,
+		RaftSnapshotRetain:    1,
+		NodeRPC:               "http://node:8545",
+		ExecutionRPC:          "http://geth:8545",
+		Paused:                false,
 		HealthCheck: HealthCheckConfig{
 			Interval:       1,
 			UnsafeInterval: 3,
@@ -74,7 +86,8 @@ func mockConfig(t *testing.T) Config {
 			L1SystemConfigAddress:   [20]byte{3, 4},
 			ProtocolVersionsAddress: [20]byte{4, 5},
 		},
-		RPCEnableProxy: false,
+		RPCEnableProxy:             false,
+		TransferLeaderMaxUnsafeLag: 5,
 	}
 }
 
@@ -532,6 +545,65 @@ func (s *OpConductorTestSuite) TestScenario7() {
 	s.cons.AssertCalled(s.T(), "TransferLeader")
 }
 
+// In this test, we have a leader that started sequencing while unhealthy to unblock a stalled network, then its
+// execution engine stops responding. Waiting for it to catch up cannot help, so we expect it to transfer leadership.
+// 1. [leader, unhealthy, sequencing] -- engine unresponsive -->
+// 2. [leader, unhealthy, sequencing] -- stop sequencing, transfer leadership --> [follower, unhealthy, not sequencing]
+func (s *OpConductorTestSuite) TestScenarioEngineUnresponsive() {
+	s.enableSynchronization()
+
+	// set initial state
+	s.conductor.leader.Store(true)
+	s.conductor.healthy.Store(false)
+	s.conductor.seqActive.Store(true)
+	s.conductor.prevState = &state{
+		leader:  true,
+		healthy: false,
+		active:  false,
+	}
+
+	s.cons.EXPECT().TransferLeader().Return(nil).Times(1)
+	s.ctrl.EXPECT().StopSequencer(mock.Anything).Return(common.Hash{}, nil).Times(1)
+
+	s.updateHealthStatusAndExecuteAction(health.ErrSequencerEngineUnresponsive)
+
+	s.False(s.conductor.leader.Load())
+	s.False(s.conductor.healthy.Load())
+	s.False(s.conductor.seqActive.Load())
+	s.ctrl.AssertCalled(s.T(), "StopSequencer", mock.Anything)
+	s.cons.AssertCalled(s.T(), "TransferLeader")
+}
+
+// In this test, we have a leader of a builder-strict cluster that started sequencing while unhealthy to unblock a stalled
+// network, then its block builder becomes unhealthy. Sequencing cannot fix the builder, so we expect it to transfer leadership
+// to a sequencer with a healthy builder.
+// 1. [leader, unhealthy, sequencing] -- builder unhealthy -->
+// 2. [leader, unhealthy, sequencing] -- stop sequencing, transfer leadership --> [follower, unhealthy, not sequencing]
+func (s *OpConductorTestSuite) TestScenarioBuilderUnhealthy() {
+	s.enableSynchronization()
+
+	// set initial state
+	s.conductor.leader.Store(true)
+	s.conductor.healthy.Store(false)
+	s.conductor.seqActive.Store(true)
+	s.conductor.prevState = &state{
+		leader:  true,
+		healthy: false,
+		active:  false,
+	}
+
+	s.cons.EXPECT().TransferLeader().Return(nil).Times(1)
+	s.ctrl.EXPECT().StopSequencer(mock.Anything).Return(common.Hash{}, nil).Times(1)
+
+	s.updateHealthStatusAndExecuteAction(health.ErrSequencerBuilderUnhealthy)
+
+	s.False(s.conductor.leader.Load())
+	s.False(s.conductor.healthy.Load())
+	s.False(s.conductor.seqActive.Load())
+	s.ctrl.AssertCalled(s.T(), "StopSequencer", mock.Anything)
+	s.cons.AssertCalled(s.T(), "TransferLeader")
+}
+
 // In this test, we have a leader that is healthy and sequencing, we send a unhealthy update to it and expect it to stop sequencing and transfer leadership.
 // However, the action we needed to take failed temporarily, so we expect it to retry until it succeeds.
 // 1. [leader, healthy, sequencing] -- become unhealthy -->
@@ -875,6 +947,552 @@ func (s *OpConductorTestSuite) TestHandleInitError() {
 	s.False(ok)
 }
 
+type stubPeerConductor struct {
+	healthy    bool
+	unsafeHead eth.BlockID
+	closed     bool
+}
+
+func (p *stubPeerConductor) SequencerHealthy(_ context.Context) (bool, error) {
+	return p.healthy, nil
+}
+
+func (p *stubPeerConductor) SequencerUnsafeHead(_ context.Context) (eth.BlockID, error) {
+	return p.unsafeHead, nil
+}
+
+func (p *stubPeerConductor) Close() {
+	p.closed = true
+}
+
+func (s *OpConductorTestSuite) setupPeerConductor(peer *stubPeerConductor, unsafeNum uint64) {
+	cfg := *s.conductor.cfg
+	cfg.PeerRPCURLs = map[string]string{"SequencerB": "http://sequencer-b:8547"}
+	s.conductor.cfg = &cfg
+	s.conductor.dialPeer = func(_ context.Context, rpcURL string) (peerConductor, error) {
+		s.Equal("http://sequencer-b:8547", rpcURL)
+		return peer, nil
+	}
+	mockPayload := &eth.ExecutionPayloadEnvelope{
+		ExecutionPayload: &eth.ExecutionPayload{
+			BlockNumber: hexutil.Uint64(unsafeNum),
+			BlockHash:   [32]byte{1, 2, 3},
+		},
+	}
+	s.cons.EXPECT().Leader().Return(true)
+	s.cons.EXPECT().LatestUnsafePayload().Return(mockPayload, nil)
+}
+
+func (s *OpConductorTestSuite) TestTransferLeaderToHealthyServer() {
+	peer := &stubPeerConductor{healthy: true, unsafeHead: eth.BlockID{Number: 95, Hash: [32]byte{9}}}
+	s.setupPeerConductor(peer, 100)
+	s.ctrl.EXPECT().BlockInfoByNumber(mock.Anything, uint64(95)).Return(&testutils.MockBlockInfo{InfoNum: 95, InfoHash: [32]byte{9}}, nil).Times(1)
+	s.cons.EXPECT().TransferLeaderTo("SequencerB", "sequencer-b:50050").Return(nil).Times(1)
+
+	err := s.conductor.TransferLeaderToHealthyServer(s.ctx, "SequencerB", "sequencer-b:50050")
+	s.NoError(err)
+	s.True(peer.closed)
+	s.cons.AssertCalled(s.T(), "TransferLeaderTo", "SequencerB", "sequencer-b:50050")
+}
+
+func (s *OpConductorTestSuite) TestTransferLeaderToHealthyServerAtHead() {
+	peer := &stubPeerConductor{healthy: true, unsafeHead: eth.BlockID{Number: 100, Hash: [32]byte{1, 2, 3}}}
+	s.setupPeerConductor(peer, 100)
+	s.cons.EXPECT().TransferLeaderTo("SequencerB", "sequencer-b:50050").Return(nil).Times(1)
+
+	err := s.conductor.TransferLeaderToHealthyServer(s.ctx, "SequencerB", "sequencer-b:50050")
+	s.NoError(err)
+	s.ctrl.AssertNotCalled(s.T(), "BlockInfoByNumber", mock.Anything, mock.Anything)
+}
+
+func (s *OpConductorTestSuite) TestTransferLeaderToHealthyServerUnhealthy() {
+	peer := &stubPeerConductor{healthy: false, unsafeHead: eth.BlockID{Number: 100}}
+	s.setupPeerConductor(peer, 100)
+
+	err := s.conductor.TransferLeaderToHealthyServer(s.ctx, "SequencerB", "sequencer-b:50050")
+	s.ErrorIs(err, ErrTransferTargetUnhealthy)
+	s.True(peer.closed)
+	s.cons.AssertNotCalled(s.T(), "TransferLeaderTo", mock.Anything, mock.Anything)
+}
+
+func (s *OpConductorTestSuite) TestTransferLeaderToHealthyServerBehind() {
+	peer := &stubPeerConductor{healthy: true, unsafeHead: eth.BlockID{Number: 94}}
+	s.setupPeerConductor(peer, 100)
+
+	err := s.conductor.TransferLeaderToHealthyServer(s.ctx, "SequencerB", "sequencer-b:50050")
+	s.ErrorIs(err, ErrTransferTargetBehind)
+	s.cons.AssertNotCalled(s.T(), "TransferLeaderTo", mock.Anything, mock.Anything)
+}
+
+func (s *OpConductorTestSuite) TestTransferLeaderToHealthyServerDiverged() {
+	// the target is within the lag, but its head is not a block of the local chain
+	peer := &stubPeerConductor{healthy: true, unsafeHead: eth.BlockID{Number: 98, Hash: [32]byte{8}}}
+	s.setupPeerConductor(peer, 100)
+	s.ctrl.EXPECT().BlockInfoByNumber(mock.Anything, uint64(98)).Return(&testutils.MockBlockInfo{InfoNum: 98, InfoHash: [32]byte{7}}, nil).Times(1)
+
+	err := s.conductor.TransferLeaderToHealthyServer(s.ctx, "SequencerB", "sequencer-b:50050")
+	s.ErrorIs(err, ErrTransferTargetDiverged)
+	s.cons.AssertNotCalled(s.T(), "TransferLeaderTo", mock.Anything, mock.Anything)
+}
+
+func (s *OpConductorTestSuite) TestTransferLeaderToHealthyServerDivergedAtHead() {
+	peer := &stubPeerConductor{healthy: true, unsafeHead: eth.BlockID{Number: 100, Hash: [32]byte{8}}}
+	s.setupPeerConductor(peer, 100)
+
+	err := s.conductor.TransferLeaderToHealthyServer(s.ctx, "SequencerB", "sequencer-b:50050")
+	s.ErrorIs(err, ErrTransferTargetDiverged)
+	s.cons.AssertNotCalled(s.T(), "TransferLeaderTo", mock.Anything, mock.Anything)
+}
+
+func (s *OpConductorTestSuite) TestTransferLeaderToHealthyServerUnknownPeer() {
+	s.cons.EXPECT().Leader().Return(true)
+	s.conductor.dialPeer = func(_ context.Context, _ string) (peerConductor, error) {
+		s.Fail("should not dial peer without a configured RPC URL")
+		return nil, nil
+	}
+
+	err := s.conductor.TransferLeaderToHealthyServer(s.ctx, "SequencerC", "sequencer-c:50050")
+	s.ErrorContains(err, "no conductor RPC URL")
+	s.cons.AssertNotCalled(s.T(), "TransferLeaderTo", mock.Anything, mock.Anything)
+}
+
+func (s *OpConductorTestSuite) TestTransferLeaderToHealthyServerNotLeader() {
+	s.cons.EXPECT().Leader().Return(false)
+	s.conductor.dialPeer = func(_ context.Context, _ string) (peerConductor, error) {
+		s.Fail("should not dial peer when not leader")
+		return nil, nil
+	}
+
+	err := s.conductor.TransferLeaderToHealthyServer(s.ctx, "SequencerB", "sequencer-b:50050")
+	s.ErrorIs(err, consensus.ErrNotLeader)
+}
+
+func (s *OpConductorTestSuite) TestClusterHealth() {
+	peer := &stubPeerConductor{healthy: false, unsafeHead: eth.BlockID{Number: 90}}
+	s.conductor.dialPeer = func(_ context.Context, rpcURL string) (peerConductor, error) {
+		s.Equal("http://sequencer-b:8547", rpcURL)
+		return peer, nil
+	}
+	mockBlockInfo := &testutils.MockBlockInfo{InfoNum: 100, InfoHash: [32]byte{1, 2, 3}}
+	s.ctrl.EXPECT().LatestUnsafeBlock(mock.Anything).Return(mockBlockInfo, nil).Times(1)
+	s.cons.EXPECT().LeaderWithID().Return(&consensus.ServerInfo{ID: "SequencerA", Addr: "sequencer-a:50050"})
+	s.cons.EXPECT().ClusterMembership().Return(&consensus.ClusterMembership{
+		Servers: []consensus.ServerInfo{
+			{ID: "SequencerA", Addr: "sequencer-a:50050", Suffrage: consensus.Voter},
+			{ID: "SequencerB", Addr: "sequencer-b:50050", Suffrage: consensus.Voter},
+			{ID: "SequencerC", Addr: "sequencer-c:50050", Suffrage: consensus.Nonvoter},
+		},
+		Version: 3,
+	}, nil)
+
+	health, err := s.conductor.ClusterHealth(s.ctx, map[string]string{"SequencerB": "http://sequencer-b:8547"})
+	s.NoError(err)
+	s.Equal(uint64(3), health.Version)
+	s.Len(health.Servers, 3)
+
+	a, b, c := health.Servers[0], health.Servers[1], health.Servers[2]
+	s.True(a.Leader)
+	s.True(a.Reachable)
+	s.True(a.Healthy)
+	s.Equal(eth.BlockID{Number: 100, Hash: [32]byte{1, 2, 3}}, a.UnsafeHead)
+
+	s.False(b.Leader)
+	s.True(b.Reachable)
+	s.False(b.Healthy)
+	s.Equal(uint64(90), b.UnsafeHead.Number)
+	s.True(peer.closed)
+
+	s.Equal(consensus.Nonvoter, c.Suffrage)
+	s.False(c.Reachable, "no conductor RPC URL given")
+	s.NotEmpty(c.Error)
+}
+
+// barrierPeerConductor only reports its health once all peers are queried.
+type barrierPeerConductor struct {
+	stubPeerConductor
+	queried *sync.WaitGroup
+}
+
+func (p *barrierPeerConductor) SequencerHealthy(ctx context.Context) (bool, error) {
+	p.queried.Done()
+	done := make(chan struct{})
+	go func() {
+		p.queried.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return p.healthy, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func (s *OpConductorTestSuite) TestClusterHealthQueriesPeersConcurrently() {
+	var queried sync.WaitGroup
+	queried.Add(2)
+	s.conductor.dialPeer = func(_ context.Context, rpcURL string) (peerConductor, error) {
+		return &barrierPeerConductor{stubPeerConductor: stubPeerConductor{healthy: true}, queried: &queried}, nil
+	}
+	s.cons.EXPECT().LeaderWithID().Return(&consensus.ServerInfo{ID: "SequencerA", Addr: "sequencer-a:50050"})
+	s.cons.EXPECT().ClusterMembership().Return(&consensus.ClusterMembership{
+		Servers: []consensus.ServerInfo{
+			{ID: "SequencerB", Addr: "sequencer-b:50050", Suffrage: consensus.Voter},
+			{ID: "SequencerC", Addr: "sequencer-c:50050", Suffrage: consensus.Voter},
+		},
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(s.ctx, 2*time.Second)
+	defer cancel()
+	health, err := s.conductor.ClusterHealth(ctx, map[string]string{
+		"SequencerB": "http://sequencer-b:8547",
+		"SequencerC": "http://sequencer-c:8547",
+	})
+	s.NoError(err)
+	for _, sh := range health.Servers {
+		s.True(sh.Reachable, sh.Error)
+		s.True(sh.Healthy)
+	}
+}
+
+func (s *OpConductorTestSuite) TestClusterStatus() {
+	cfg := s.cfg
+	cfg.PeerRPCURLs = map[string]string{"SequencerB": "http://sequencer-b:8547"}
+	s.conductor.cfg = &cfg
+	peer := &stubPeerConductor{healthy: true, unsafeHead: eth.BlockID{Number: 97}}
+	s.conductor.dialPeer = func(_ context.Context, rpcURL string) (peerConductor, error) {
+		s.Equal("http://sequencer-b:8547", rpcURL)
+		return peer, nil
+	}
+	mockBlockInfo := &testutils.MockBlockInfo{InfoNum: 100, InfoHash: [32]byte{1, 2, 3}}
+	s.ctrl.EXPECT().LatestUnsafeBlock(mock.Anything).Return(mockBlockInfo, nil)
+	s.cons.EXPECT().LeaderWithID().Return(&consensus.ServerInfo{ID: "SequencerA", Addr: "sequencer-a:50050"})
+	s.cons.EXPECT().ClusterMembership().Return(&consensus.ClusterMembership{
+		Servers: []consensus.ServerInfo{
+			{ID: "SequencerA", Addr: "sequencer-a:50050", Suffrage: consensus.Voter},
+			{ID: "SequencerB", Addr: "sequencer-b:50050", Suffrage: consensus.Voter},
+		},
+		Version: 2,
+	}, nil)
+	s.cons.EXPECT().LatestUnsafePayload().Return(&eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+		BlockNumber: 100,
+		BlockHash:   [32]byte{1, 2, 3},
+	}}, nil)
+
+	s.cons.EXPECT().Leader().Return(false).Once()
+	_, err := s.conductor.ClusterStatus(s.ctx)
+	s.ErrorIs(err, consensus.ErrNotLeader)
+
+	s.cons.EXPECT().Leader().Return(true)
+	status, err := s.conductor.ClusterStatus(s.ctx)
+	s.NoError(err)
+	s.Equal("SequencerA", status.Leader)
+	s.Equal(eth.BlockID{Number: 100, Hash: [32]byte{1, 2, 3}}, status.UnsafeHead)
+	s.Nil(status.LastCommitTime, "no commit yet")
+	s.Equal(uint64(2), status.Version)
+	s.Len(status.Members, 2)
+	s.True(status.Members[0].Leader)
+	s.Zero(status.Members[0].Lag)
+	s.True(status.Members[1].Reachable)
+	s.Equal(uint64(3), status.Members[1].Lag)
+
+	s.conductor.lastCommit.Store(1000)
+	status, err = s.conductor.ClusterStatus(s.ctx)
+	s.NoError(err)
+	s.Equal(time.UnixMilli(1000), *status.LastCommitTime)
+
+	// the status endpoint serves the leader's view
+	rec := httptest.NewRecorder()
+	s.conductor.clusterStatusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ClusterStatusPath, nil))
+	s.Equal(http.StatusOK, rec.Code)
+	var served conductorrpc.ClusterStatus
+	s.NoError(json.Unmarshal(rec.Body.Bytes(), &served))
+	s.Equal(status.Members, served.Members)
+	s.Equal(status.UnsafeHead, served.UnsafeHead)
+}
+
+func (s *OpConductorTestSuite) TestClusterStatusForwardedToLeader() {
+	leaderSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(ClusterStatusPath, r.URL.Path)
+		s.True(r.URL.Query().Has(forwardedParam))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"leader":"SequencerB"}`))
+	}))
+	defer leaderSrv.Close()
+	cfg := s.cfg
+	cfg.PeerRPCURLs = map[string]string{"SequencerB": leaderSrv.URL}
+	s.conductor.cfg = &cfg
+	s.cons.EXPECT().Leader().Return(false)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.conductor.clusterStatusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	s.cons.EXPECT().LeaderWithID().Return(nil).Once()
+	s.Equal(http.StatusServiceUnavailable, get(ClusterStatusPath).Code, "no leader")
+
+	s.cons.EXPECT().LeaderWithID().Return(&consensus.ServerInfo{ID: "SequencerC"}).Once()
+	rec := get(ClusterStatusPath)
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+	s.Contains(rec.Body.String(), "no conductor RPC URL")
+
+	s.cons.EXPECT().LeaderWithID().Return(&consensus.ServerInfo{ID: "SequencerB"}).Once()
+	rec = get(ClusterStatusPath)
+	s.Equal(http.StatusOK, rec.Code)
+	s.JSONEq(`{"leader":"SequencerB"}`, rec.Body.String())
+
+	s.Equal(http.StatusServiceUnavailable, get(ClusterStatusPath+"?forwarded=true").Code, "forwarded requests are not forwarded again")
+}
+
+func TestParsePeerRPCURLs(t *testing.T) {
+	urls, err := parsePeerRPCURLs([]string{"SequencerB=http://sequencer-b:8547", "SequencerC=http://sequencer-c:8547"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"SequencerB": "http://sequencer-b:8547", "SequencerC": "http://sequencer-c:8547"}, urls)
+
+	_, err = parsePeerRPCURLs([]string{"http://sequencer-b:8547"})
+	require.ErrorContains(t, err, "expected <server-id>=<url>")
+	_, err = parsePeerRPCURLs([]string{"SequencerB=http://b:8547", "SequencerB=http://c:8547"})
+	require.ErrorContains(t, err, "duplicate server ID")
+}
+
+func (s *OpConductorTestSuite) TestBootstrap() {
+	s.cons.EXPECT().Bootstrap().Return(nil).Once()
+	s.NoError(s.conductor.Bootstrap(s.ctx))
+
+	s.cons.EXPECT().Bootstrap().Return(consensus.ErrCantBootstrap).Once()
+	s.ErrorIs(s.conductor.Bootstrap(s.ctx), consensus.ErrCantBootstrap)
+}
+
+func (s *OpConductorTestSuite) TestCommitUnsafePayloadAsync() {
+	cfg := s.cfg
+	cfg.CommitMode = CommitModeAsync
+	s.conductor.cfg = &cfg
+	s.enableSynchronization()
+
+	release, committed := make(chan struct{}), make(chan struct{})
+	payload := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 1}}
+	s.cons.EXPECT().Leader().Return(true).Once()
+	s.cons.EXPECT().CommitUnsafePayload(payload, mock.Anything).Run(func(_ *eth.ExecutionPayloadEnvelope, _ *consensus.PayloadMetadata) {
+		<-release
+		close(committed)
+	}).Return(nil).Once()
+
+	// returns before the payload is replicated
+	s.NoError(s.conductor.CommitUnsafePayload(s.ctx, payload))
+	close(release)
+	select {
+	case <-committed:
+	case <-time.After(5 * time.Second):
+		s.Fail("payload was not replicated")
+	}
+
+	// followers reject commits without queueing them
+	s.cons.EXPECT().Leader().Return(false).Once()
+	s.ErrorIs(s.conductor.CommitUnsafePayload(s.ctx, payload), consensus.ErrNotLeader)
+}
+
+func (s *OpConductorTestSuite) TestCommitUnsafePayloadLatencyBudget() {
+	m := &recordingMetrics{}
+	s.conductor.metrics = m
+	cfg := s.cfg
+	cfg.CommitLatencyBudget = time.Millisecond
+	s.conductor.cfg = &cfg
+
+	payload := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 1}}
+	s.cons.EXPECT().CommitUnsafePayload(payload, mock.Anything).Return(nil).Once()
+	s.NoError(s.conductor.CommitUnsafePayload(s.ctx, payload))
+	s.Equal(1, m.commits)
+	s.Equal(0, m.overBudget)
+
+	s.cons.EXPECT().CommitUnsafePayload(payload, mock.Anything).Run(func(_ *eth.ExecutionPayloadEnvelope, _ *consensus.PayloadMetadata) {
+		time.Sleep(5 * time.Millisecond)
+	}).Return(nil).Once()
+	s.NoError(s.conductor.CommitUnsafePayload(s.ctx, payload))
+	s.Equal(2, m.commits)
+	s.Equal(1, m.overBudget)
+}
+
+func (s *OpConductorTestSuite) TestObserverDemotesItselfWhenLeader() {
+	cfg := s.cfg
+	cfg.Observer = true
+	s.conductor.cfg = &cfg
+	s.enableSynchronization()
+	s.conductor.seqActive.Store(true)
+
+	membership := &consensus.ClusterMembership{
+		Servers: []consensus.ServerInfo{
+			{ID: "SequencerA", Addr: "127.0.0.1:50050", Suffrage: consensus.Voter},
+			{ID: "SequencerB", Addr: "127.0.0.1:50051", Suffrage: consensus.Voter},
+		},
+		Version: 7,
+	}
+	s.ctrl.EXPECT().StopSequencer(mock.Anything).Return(common.Hash{}, nil).Times(1)
+	s.cons.EXPECT().ClusterMembership().Return(membership, nil).Times(1)
+	s.cons.EXPECT().Leader().Return(true).Times(1)
+	s.cons.EXPECT().DemoteVoter("SequencerA", uint64(7)).Return(nil).Times(1)
+
+	s.updateLeaderStatusAndExecuteAction(true)
+
+	// [leader, healthy, not sequencing], until the demotion makes it step down
+	s.True(s.conductor.leader.Load())
+	s.True(s.conductor.healthy.Load())
+	s.False(s.conductor.seqActive.Load())
+	s.ctrl.AssertNumberOfCalls(s.T(), "StopSequencer", 1)
+	s.cons.AssertNumberOfCalls(s.T(), "DemoteVoter", 1)
+}
+
+func (s *OpConductorTestSuite) TestObserverEnsureNonVoter() {
+	voter := &consensus.ClusterMembership{
+		Servers: []consensus.ServerInfo{{ID: "SequencerA", Suffrage: consensus.Voter}},
+		Version: 3,
+	}
+	nonVoter := &consensus.ClusterMembership{
+		Servers: []consensus.ServerInfo{{ID: "SequencerA", Suffrage: consensus.Nonvoter}},
+		Version: 4,
+	}
+
+	// a non-voter is left alone
+	s.cons.EXPECT().ClusterMembership().Return(nonVoter, nil).Once()
+	s.NoError(s.conductor.ensureNonVoter())
+
+	// a voting follower cannot change the membership
+	s.cons.EXPECT().ClusterMembership().Return(voter, nil).Once()
+	s.cons.EXPECT().Leader().Return(false).Once()
+	s.NoError(s.conductor.ensureNonVoter())
+
+	s.cons.EXPECT().ClusterMembership().Return(voter, nil).Once()
+	s.cons.EXPECT().Leader().Return(true).Once()
+	s.cons.EXPECT().DemoteVoter("SequencerA", uint64(3)).Return(nil).Once()
+	s.NoError(s.conductor.ensureNonVoter())
+	s.cons.AssertNumberOfCalls(s.T(), "DemoteVoter", 1)
+}
+
+func (s *OpConductorTestSuite) TestRecordReplicationLag() {
+	m := &recordingMetrics{}
+	s.conductor.metrics = m
+
+	s.cons.EXPECT().ReplicationIndexes().Return(uint64(0), uint64(0), nil).Once()
+	s.cons.EXPECT().LocalUnsafePayload().Return(nil, nil).Once()
+	s.conductor.recordReplicationLag()
+	s.Zero(m.replicatedHead, "nothing replicated yet")
+	s.Zero(m.replicationLag)
+
+	s.cons.EXPECT().ReplicationIndexes().Return(uint64(110), uint64(100), nil).Once()
+	s.cons.EXPECT().LocalUnsafePayload().Return(&eth.ExecutionPayloadEnvelope{
+		ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 42},
+	}, nil).Once()
+	s.conductor.recordReplicationLag()
+	s.Equal(uint64(42), m.replicatedHead)
+	s.Equal(uint64(10), m.replicationLag)
+}
+
+func (s *OpConductorTestSuite) TestCommitUnsafePayloadWithMetadata() {
+	payload := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 1}}
+	metadata := &consensus.PayloadMetadata{Source: consensus.PayloadSourceBuilder, BuilderID: "builder-1"}
+
+	// provenance is dropped unless recording it is enabled
+	s.cons.EXPECT().CommitUnsafePayload(payload, (*consensus.PayloadMetadata)(nil)).Return(nil).Once()
+	s.NoError(s.conductor.CommitUnsafePayloadWithMetadata(s.ctx, payload, metadata))
+
+	s.conductor.cfg.RecordProvenance = true
+	s.cons.EXPECT().CommitUnsafePayload(payload, metadata).Return(nil).Once()
+	s.NoError(s.conductor.CommitUnsafePayloadWithMetadata(s.ctx, payload, metadata))
+
+	provenance := &consensus.BlockProvenance{Number: 1, PayloadMetadata: *metadata}
+	s.cons.EXPECT().PayloadProvenance(uint64(1)).Return(provenance, nil).Once()
+	actual, err := s.conductor.PayloadProvenance(s.ctx, 1)
+	s.NoError(err)
+	s.Equal(provenance, actual)
+}
+
+type recordingMetrics struct {
+	metrics.NoopMetricsImpl
+	commits, overBudget int
+
+	replicatedHead uint64
+	replicationLag uint64
+}
+
+func (m *recordingMetrics) RecordReplicationLag(unsafeHead uint64, lag uint64) {
+	m.replicatedHead, m.replicationLag = unsafeHead, lag
+}
+
+func (m *recordingMetrics) RecordCommit(_ string, _ bool, _ float64) {
+	m.commits++
+}
+
+func (m *recordingMetrics) RecordCommitBudgetExceeded(_ string) {
+	m.overBudget++
+}
+
 func TestControlLoop(t *testing.T) {
 	suite.Run(t, new(OpConductorTestSuite))
 }
+
+func TestConfigCheckConsensusBackend(t *testing.T) {
+	cfg := mockConfig(t)
+	require.NoError(t, cfg.Check())
+
+	cfg.ConsensusBackend = ConsensusBackendEtcd
+	require.ErrorContains(t, cfg.Check(), "missing etcd endpoint")
+	cfg.EtcdEndpoint = "http://127.0.0.1:2379"
+	require.ErrorContains(t, cfg.Check(), "invalid consensus lease TTL")
+	cfg.ConsensusLeaseTTL = 10 * time.Second
+	cfg.RaftStorageDir = ""
+	require.NoError(t, cfg.Check(), "raft storage is not required by the etcd backend")
+
+	cfg.EtcdTLS.TLSCaCert = "ca.crt"
+	require.ErrorContains(t, cfg.Check(), "etcd tls requires an https endpoint")
+	cfg.EtcdEndpoint = "https://127.0.0.1:2379"
+	require.NoError(t, cfg.Check())
+	cfg.EtcdTLS.TLSCert = "tls.crt"
+	require.ErrorContains(t, cfg.Check(), "etcd tls cert and key must be set together")
+	cfg.EtcdTLS.TLSKey = "tls.key"
+	require.NoError(t, cfg.Check())
+	cfg.EtcdPassword = "secret"
+	require.ErrorContains(t, cfg.Check(), "missing etcd username")
+	cfg.EtcdUsername = "conductor"
+	require.NoError(t, cfg.Check())
+
+	cfg.ConsensusBackend = "zookeeper"
+	require.ErrorContains(t, cfg.Check(), "unknown consensus backend")
+}
+
+func TestConfigCheckObserver(t *testing.T) {
+	cfg := mockConfig(t)
+	cfg.Observer = true
+	require.NoError(t, cfg.Check())
+
+	cfg.RaftBootstrap = true
+	require.ErrorContains(t, cfg.Check(), "observer cannot bootstrap the cluster")
+
+	cfg.RaftBootstrap = false
+	cfg.ConsensusBackend = ConsensusBackendEtcd
+	cfg.EtcdEndpoint = "http://127.0.0.1:2379"
+	cfg.ConsensusLeaseTTL = 5 * time.Second
+	require.ErrorContains(t, cfg.Check(), "observer requires the raft consensus backend")
+}
+
+func TestConfigCheckCommitMode(t *testing.T) {
+	cfg := mockConfig(t)
+	cfg.CommitMode = CommitModeAsync
+	require.NoError(t, cfg.Check())
+
+	cfg.CommitMode = "eventual"
+	require.ErrorContains(t, cfg.Check(), "unknown commit mode")
+	cfg.CommitMode = CommitModeSync
+	cfg.CommitLatencyBudget = -time.Second
+	require.ErrorContains(t, cfg.Check(), "invalid commit latency budget")
+}
+
+func TestConfigCheckAdminRPC(t *testing.T) {
+	cfg := mockConfig(t)
+	cfg.AdminRPCPort = -1
+	require.NoError(t, cfg.Check(), "admin RPC port is unused without a JWT secret")
+
+	cfg.AdminRPCJWTSecret = "/tmp/jwt.hex"
+	require.ErrorContains(t, cfg.Check(), "invalid admin RPC port")
+	cfg.AdminRPCPort = 8547
+	require.NoError(t, cfg.Check())
+}
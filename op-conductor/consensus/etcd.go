@@ -0,0 +1,408 @@
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+const etcdLeaderKey = "leader"
+
+var errEtcdUnauthenticated = errors.New("etcd request is not authenticated")
+
+// EtcdConfig is the configuration of the etcd lease store.
+type EtcdConfig struct {
+	// Endpoint is the HTTP or HTTPS endpoint of the etcd cluster.
+	Endpoint string
+	// KeyPrefix is the prefix of all keys of the store.
+	KeyPrefix string
+	// TLS holds the CA certificate that verifies etcd and the client certificate and key presented to it, each of
+	// which is optional. Without a CA certificate, etcd is verified against the system roots.
+	TLS optls.CLIConfig
+	// Username and Password authenticate to etcd, if it has authentication enabled.
+	Username string
+	Password string
+}
+
+// EtcdLeaseStore implements LeaseStore on etcd, using the JSON gateway of the etcd v3 API. The leadership lease is a
+// key attached to an etcd lease, which etcd deletes once the lease is no longer kept alive.
+type EtcdLeaseStore struct {
+	endpoint string
+	prefix   string
+	client   *http.Client
+	username string
+	password string
+
+	mu      sync.Mutex
+	leaseID int64
+
+	authMu sync.Mutex
+	token  string
+}
+
+var _ LeaseStore = (*EtcdLeaseStore)(nil)
+
+// NewEtcdLeaseStore creates an EtcdLeaseStore for the etcd cluster of the config.
+func NewEtcdLeaseStore(cfg *EtcdConfig) (*EtcdLeaseStore, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.TLS.TLSEnabled() {
+		tlsConfig, err := etcdTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &EtcdLeaseStore{
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		prefix:   cfg.KeyPrefix,
+		client:   &http.Client{Timeout: defaultTimeout, Transport: transport},
+		username: cfg.Username,
+		password: cfg.Password,
+	}, nil
+}
+
+func etcdTLSConfig(cfg optls.CLIConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.TLSCaCert != "" {
+		caCert, err := os.ReadFile(cfg.TLSCaCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read etcd tls ca")
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("no certificates in etcd tls ca")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	if cfg.TLSCert != "" || cfg.TLSKey != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCert, cfg.TLSKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load etcd tls cert and key")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+type etcdKeyValue struct {
+	Key            []byte `json:"key"`
+	Value          []byte `json:"value"`
+	CreateRevision int64  `json:"create_revision,string,omitempty"`
+	ModRevision    int64  `json:"mod_revision,string,omitempty"`
+	Lease          int64  `json:"lease,string,omitempty"`
+}
+
+type etcdRangeRequest struct {
+	Key []byte `json:"key"`
+}
+
+type etcdRangeResponse struct {
+	Kvs []etcdKeyValue `json:"kvs"`
+}
+
+type etcdPutRequest struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+	Lease int64  `json:"lease,string,omitempty"`
+}
+
+type etcdCompare struct {
+	Key            []byte `json:"key"`
+	Target         string `json:"target"`
+	Result         string `json:"result"`
+	Value          []byte `json:"value,omitempty"`
+	CreateRevision *int64 `json:"create_revision,string,omitempty"`
+	ModRevision    *int64 `json:"mod_revision,string,omitempty"`
+	Lease          *int64 `json:"lease,string,omitempty"`
+}
+
+type etcdRequestOp struct {
+	RequestRange       *etcdRangeRequest `json:"request_range,omitempty"`
+	RequestPut         *etcdPutRequest   `json:"request_put,omitempty"`
+	RequestDeleteRange *etcdRangeRequest `json:"request_delete_range,omitempty"`
+}
+
+type etcdTxnRequest struct {
+	Compare []etcdCompare   `json:"compare"`
+	Success []etcdRequestOp `json:"success"`
+	Failure []etcdRequestOp `json:"failure"`
+}
+
+type etcdTxnResponse struct {
+	Succeeded bool `json:"succeeded"`
+	Responses []struct {
+		ResponseRange *etcdRangeResponse `json:"response_range"`
+	} `json:"responses"`
+}
+
+type etcdLease struct {
+	ID  int64 `json:"ID,string"`
+	TTL int64 `json:"TTL,string,omitempty"`
+}
+
+type etcdKeepAliveResponse struct {
+	Result etcdLease `json:"result"`
+}
+
+type etcdAuthenticateRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type etcdAuthenticateResponse struct {
+	Token string `json:"token"`
+}
+
+func (s *EtcdLeaseStore) key(key string) []byte {
+	return []byte(s.prefix + key)
+}
+
+// call sends a request to etcd, authenticating first if a username is configured. A request rejected as
+// unauthenticated is retried once with a new token, as etcd expires tokens.
+func (s *EtcdLeaseStore) call(ctx context.Context, path string, req any, resp any) error {
+	if s.username == "" {
+		return s.post(ctx, path, "", req, resp)
+	}
+	token, err := s.authToken(ctx, "")
+	if err != nil {
+		return err
+	}
+	err = s.post(ctx, path, token, req, resp)
+	if !errors.Is(err, errEtcdUnauthenticated) {
+		return err
+	}
+	if token, err = s.authToken(ctx, token); err != nil {
+		return err
+	}
+	return s.post(ctx, path, token, req, resp)
+}
+
+// authToken returns the auth token of the store, authenticating if there is none or it is the rejected token.
+func (s *EtcdLeaseStore) authToken(ctx context.Context, rejected string) (string, error) {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+	if s.token != "" && s.token != rejected {
+		return s.token, nil
+	}
+	var resp etcdAuthenticateResponse
+	if err := s.post(ctx, "/v3/auth/authenticate", "", etcdAuthenticateRequest{Name: s.username, Password: s.password}, &resp); err != nil {
+		return "", errors.Wrap(err, "failed to authenticate to etcd")
+	}
+	s.token = resp.Token
+	return s.token, nil
+}
+
+func (s *EtcdLeaseStore) post(ctx context.Context, path string, token string, req any, resp any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode etcd request")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd request")
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		httpReq.Header.Set("Authorization", token)
+	}
+	httpResp, err := s.client.Do(httpReq)
+	if err != nil {
+		return errors.Wrapf(err, "etcd request %v failed", path)
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read etcd response to %v", path)
+	}
+	if httpResp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("%w: %v: %s", errEtcdUnauthenticated, path, respBody)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("etcd request %v failed with status %v: %s", path, httpResp.StatusCode, respBody)
+	}
+	if resp == nil {
+		return nil
+	}
+	return errors.Wrapf(json.Unmarshal(respBody, resp), "failed to decode etcd response to %v", path)
+}
+
+// keepAlive renews the etcd lease of this store, granting a new lease if there is none or it has expired.
+func (s *EtcdLeaseStore) keepAlive(ctx context.Context, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.leaseID != 0 {
+		var resp etcdKeepAliveResponse
+		if err := s.call(ctx, "/v3/lease/keepalive", etcdLease{ID: s.leaseID}, &resp); err != nil {
+			return 0, err
+		}
+		if resp.Result.TTL > 0 {
+			return s.leaseID, nil
+		}
+	}
+	leaseID, err := s.grantLease(ctx, ttl)
+	if err != nil {
+		return 0, err
+	}
+	s.leaseID = leaseID
+	return s.leaseID, nil
+}
+
+// grantLease grants a new etcd lease of ttl, rounded down to whole seconds.
+func (s *EtcdLeaseStore) grantLease(ctx context.Context, ttl time.Duration) (int64, error) {
+	var lease etcdLease
+	if err := s.call(ctx, "/v3/lease/grant", etcdLease{TTL: int64(max(ttl/time.Second, 1))}, &lease); err != nil {
+		return 0, err
+	}
+	return lease.ID, nil
+}
+
+func (s *EtcdLeaseStore) currentLease() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leaseID
+}
+
+// Campaign implements LeaseStore.
+func (s *EtcdLeaseStore) Campaign(ctx context.Context, server ServerInfo, ttl time.Duration) (*ServerInfo, error) {
+	leaseID, err := s.keepAlive(ctx, ttl)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to keep etcd lease alive")
+	}
+	value, err := json.Marshal(server)
+	if err != nil {
+		return nil, err
+	}
+	noRevision := int64(0)
+	var resp etcdTxnResponse
+	err = s.call(ctx, "/v3/kv/txn", etcdTxnRequest{
+		Compare: []etcdCompare{{Key: s.key(etcdLeaderKey), Target: "CREATE", Result: "EQUAL", CreateRevision: &noRevision}},
+		Success: []etcdRequestOp{{RequestPut: &etcdPutRequest{Key: s.key(etcdLeaderKey), Value: value, Lease: leaseID}}},
+		Failure: []etcdRequestOp{{RequestRange: &etcdRangeRequest{Key: s.key(etcdLeaderKey)}}},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Succeeded {
+		return &server, nil
+	}
+	if len(resp.Responses) == 0 || resp.Responses[0].ResponseRange == nil {
+		return nil, errors.New("missing leader in etcd response")
+	}
+	return decodeEtcdLeader(resp.Responses[0].ResponseRange.Kvs)
+}
+
+// Resign implements LeaseStore.
+func (s *EtcdLeaseStore) Resign(ctx context.Context, id string) error {
+	leader, err := s.Leader(ctx)
+	if err != nil || leader == nil || leader.ID != id {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.leaseID == 0 {
+		return nil
+	}
+	// Revoking the lease deletes the leader key attached to it.
+	if err := s.call(ctx, "/v3/lease/revoke", etcdLease{ID: s.leaseID}, nil); err != nil {
+		return err
+	}
+	s.leaseID = 0
+	return nil
+}
+
+// Leader implements LeaseStore.
+func (s *EtcdLeaseStore) Leader(ctx context.Context) (*ServerInfo, error) {
+	var resp etcdRangeResponse
+	if err := s.call(ctx, "/v3/kv/range", etcdRangeRequest{Key: s.key(etcdLeaderKey)}, &resp); err != nil {
+		return nil, err
+	}
+	return decodeEtcdLeader(resp.Kvs)
+}
+
+func decodeEtcdLeader(kvs []etcdKeyValue) (*ServerInfo, error) {
+	if len(kvs) == 0 {
+		return nil, nil
+	}
+	var leader ServerInfo
+	if err := json.Unmarshal(kvs[0].Value, &leader); err != nil {
+		return nil, errors.Wrap(err, "failed to decode leader")
+	}
+	return &leader, nil
+}
+
+// Get implements LeaseStore.
+func (s *EtcdLeaseStore) Get(ctx context.Context, key string) ([]byte, uint64, error) {
+	var resp etcdRangeResponse
+	if err := s.call(ctx, "/v3/kv/range", etcdRangeRequest{Key: s.key(key)}, &resp); err != nil {
+		return nil, 0, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, 0, nil
+	}
+	return resp.Kvs[0].Value, uint64(resp.Kvs[0].ModRevision), nil
+}
+
+// CompareAndSwap implements LeaseStore.
+func (s *EtcdLeaseStore) CompareAndSwap(ctx context.Context, key string, value []byte, revision uint64, leaderID string, ttl time.Duration) error {
+	modRevision := int64(revision)
+	compare := []etcdCompare{{Key: s.key(key), Target: "MOD", Result: "EQUAL", ModRevision: &modRevision}}
+	if leaderID != "" {
+		// Only this store's lease can be checked, which is held by the server campaigning through it.
+		leader, err := s.Leader(ctx)
+		if err != nil {
+			return err
+		}
+		leaseID := s.currentLease()
+		if leader == nil || leader.ID != leaderID || leaseID == 0 {
+			return ErrNotLeader
+		}
+		compare = append(compare, etcdCompare{Key: s.key(etcdLeaderKey), Target: "LEASE", Result: "EQUAL", Lease: &leaseID})
+	}
+	put := &etcdPutRequest{Key: s.key(key), Value: value}
+	if ttl > 0 {
+		// The key is attached to a lease of its own, so it expires by the clock of etcd rather than of any server.
+		leaseID, err := s.grantLease(ctx, ttl)
+		if err != nil {
+			return errors.Wrap(err, "failed to grant etcd lease")
+		}
+		put.Lease = leaseID
+	}
+	var resp etcdTxnResponse
+	err := s.call(ctx, "/v3/kv/txn", etcdTxnRequest{
+		Compare: compare,
+		Success: []etcdRequestOp{{RequestPut: put}},
+		Failure: []etcdRequestOp{{RequestRange: &etcdRangeRequest{Key: s.key(etcdLeaderKey)}}},
+	}, &resp)
+	if err != nil {
+		return err
+	}
+	if resp.Succeeded {
+		return nil
+	}
+	if leaderID != "" {
+		if len(resp.Responses) == 0 || resp.Responses[0].ResponseRange == nil || len(resp.Responses[0].ResponseRange.Kvs) == 0 ||
+			resp.Responses[0].ResponseRange.Kvs[0].Lease != s.currentLease() {
+			return ErrNotLeader
+		}
+	}
+	return ErrRevisionMismatch
+}
+
+// Close implements LeaseStore.
+func (s *EtcdLeaseStore) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
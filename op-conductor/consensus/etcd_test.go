@@ -0,0 +1,339 @@
+package consensus
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+func TestEtcdLeaseStore(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(newFakeEtcd())
+	t.Cleanup(server.Close)
+
+	a := newTestEtcdLeaseStore(t, &EtcdConfig{Endpoint: server.URL, KeyPrefix: "/conductor/"})
+	b := newTestEtcdLeaseStore(t, &EtcdConfig{Endpoint: server.URL, KeyPrefix: "/conductor/"})
+	serverA := ServerInfo{ID: "A", Addr: "A:50050", Suffrage: Voter}
+	serverB := ServerInfo{ID: "B", Addr: "B:50050", Suffrage: Voter}
+
+	t.Run("Campaign", func(t *testing.T) {
+		leader, err := a.Campaign(ctx, serverA, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, &serverA, leader)
+
+		leader, err = b.Campaign(ctx, serverB, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, &serverA, leader)
+
+		leader, err = b.Leader(ctx)
+		require.NoError(t, err)
+		require.Equal(t, &serverA, leader)
+	})
+
+	t.Run("CompareAndSwap", func(t *testing.T) {
+		value, revision, err := a.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Nil(t, value)
+		require.Zero(t, revision)
+
+		require.NoError(t, a.CompareAndSwap(ctx, "key", []byte("one"), 0, "A", 0))
+		require.ErrorIs(t, a.CompareAndSwap(ctx, "key", []byte("two"), 0, "A", 0), ErrRevisionMismatch)
+		require.ErrorIs(t, b.CompareAndSwap(ctx, "key", []byte("two"), 0, "B", 0), ErrNotLeader)
+
+		value, revision, err = b.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("one"), value)
+		require.NoError(t, b.CompareAndSwap(ctx, "key", []byte("two"), revision, "", 0))
+	})
+
+	t.Run("Resign", func(t *testing.T) {
+		require.NoError(t, b.Resign(ctx, "B"), "no-op if not the leader")
+		require.NoError(t, a.Resign(ctx, "A"))
+
+		leader, err := b.Campaign(ctx, serverB, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, &serverB, leader)
+		require.ErrorIs(t, a.CompareAndSwap(ctx, "key", []byte("three"), 0, "A", 0), ErrNotLeader)
+	})
+}
+
+func TestEtcdLeaseStoreAuth(t *testing.T) {
+	ctx := context.Background()
+	etcd := newFakeEtcd()
+	etcd.username, etcd.password = "conductor", "secret"
+	server := httptest.NewServer(etcd)
+	t.Cleanup(server.Close)
+
+	_, _, err := newTestEtcdLeaseStore(t, &EtcdConfig{Endpoint: server.URL}).Get(ctx, "key")
+	require.ErrorIs(t, err, errEtcdUnauthenticated)
+	_, _, err = newTestEtcdLeaseStore(t, &EtcdConfig{Endpoint: server.URL, Username: "conductor", Password: "wrong"}).Get(ctx, "key")
+	require.ErrorContains(t, err, "failed to authenticate to etcd")
+
+	store := newTestEtcdLeaseStore(t, &EtcdConfig{Endpoint: server.URL, Username: "conductor", Password: "secret"})
+	serverA := ServerInfo{ID: "A", Addr: "A:50050", Suffrage: Voter}
+	leader, err := store.Campaign(ctx, serverA, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, &serverA, leader)
+
+	// an expired token is replaced
+	etcd.expireToken()
+	require.NoError(t, store.CompareAndSwap(ctx, "key", []byte("one"), 0, "A", 0))
+}
+
+func TestEtcdLeaseStoreTLS(t *testing.T) {
+	ctx := context.Background()
+	certPath, keyPath, cert := writeTestCert(t)
+	caCertPool := x509.NewCertPool()
+	caCertPool.AddCert(cert.Leaf)
+	server := httptest.NewUnstartedServer(newFakeEtcd())
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    caCertPool,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	_, _, err := newTestEtcdLeaseStore(t, &EtcdConfig{Endpoint: server.URL}).Get(ctx, "key")
+	require.ErrorContains(t, err, "certificate", "etcd is not verified without the CA")
+	_, _, err = newTestEtcdLeaseStore(t, &EtcdConfig{Endpoint: server.URL, TLS: optls.CLIConfig{TLSCaCert: certPath}}).Get(ctx, "key")
+	require.Error(t, err, "etcd requires a client certificate")
+
+	store := newTestEtcdLeaseStore(t, &EtcdConfig{
+		Endpoint: server.URL,
+		TLS:      optls.CLIConfig{TLSCaCert: certPath, TLSCert: certPath, TLSKey: keyPath},
+	})
+	require.NoError(t, store.CompareAndSwap(ctx, "key", []byte("one"), 0, "", 0))
+	value, _, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("one"), value)
+}
+
+func TestLeaseConsensusWithEtcd(t *testing.T) {
+	server := httptest.NewServer(newFakeEtcd())
+	t.Cleanup(server.Close)
+
+	a := newTestLeaseConsensus(t, newTestEtcdLeaseStore(t, &EtcdConfig{Endpoint: server.URL, KeyPrefix: "/conductor/"}), "A", true)
+	require.True(t, <-a.LeaderCh())
+	require.NoError(t, a.AddVoter("B", "B:50050", 0))
+	b := newTestLeaseConsensus(t, newTestEtcdLeaseStore(t, &EtcdConfig{Endpoint: server.URL, KeyPrefix: "/conductor/"}), "B", false)
+
+	require.NoError(t, a.CommitUnsafePayload(createPayloadEnvelope(1), nil))
+	payload, err := b.LatestUnsafePayload()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, payload.ExecutionPayload.BlockNumber)
+
+	require.NoError(t, a.TransferLeaderTo("B", "B:50050"))
+	require.True(t, <-b.LeaderCh())
+	require.NoError(t, b.CommitUnsafePayload(createPayloadEnvelope(2), nil))
+	require.ErrorIs(t, a.CommitUnsafePayload(createPayloadEnvelope(3), nil), ErrNotLeader)
+}
+
+func newTestEtcdLeaseStore(t *testing.T, cfg *EtcdConfig) *EtcdLeaseStore {
+	store, err := NewEtcdLeaseStore(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	return store
+}
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 and its key, usable by both the server and the client.
+func writeTestCert(t *testing.T) (string, string, tls.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "etcd"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyPath, keyPEM, 0o600))
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return certPath, keyPath, cert
+}
+
+// fakeEtcd implements the subset of the etcd v3 JSON gateway used by EtcdLeaseStore. Leases never expire unless revoked.
+// If username is set, requests must carry the token returned by authenticating with username and password.
+type fakeEtcd struct {
+	mu       sync.Mutex
+	revision int64
+	nextID   int64
+	kvs      map[string]etcdKeyValue
+	leases   map[int64]bool
+
+	username string
+	password string
+	tokens   int
+	token    string
+}
+
+func newFakeEtcd() *fakeEtcd {
+	return &fakeEtcd{
+		kvs:    make(map[string]etcdKeyValue),
+		leases: make(map[int64]bool),
+	}
+}
+
+func (e *fakeEtcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.username != "" && r.URL.Path != "/v3/auth/authenticate" && (e.token == "" || r.Header.Get("Authorization") != e.token) {
+		http.Error(w, `{"error":"etcdserver: invalid auth token","code":16}`, http.StatusUnauthorized)
+		return
+	}
+	var resp any
+	switch r.URL.Path {
+	case "/v3/auth/authenticate":
+		var req etcdAuthenticateRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if req.Name != e.username || req.Password != e.password {
+			http.Error(w, `{"error":"etcdserver: authentication failed, invalid user ID or password","code":3}`, http.StatusBadRequest)
+			return
+		}
+		e.tokens++
+		e.token = fmt.Sprintf("token.%d", e.tokens)
+		resp = etcdAuthenticateResponse{Token: e.token}
+	case "/v3/lease/grant":
+		e.nextID++
+		e.leases[e.nextID] = true
+		resp = etcdLease{ID: e.nextID, TTL: 60}
+	case "/v3/lease/keepalive":
+		var req etcdLease
+		if !decode(w, r, &req) {
+			return
+		}
+		if e.leases[req.ID] {
+			resp = etcdKeepAliveResponse{Result: etcdLease{ID: req.ID, TTL: 60}}
+		} else {
+			resp = etcdKeepAliveResponse{Result: etcdLease{ID: req.ID}}
+		}
+	case "/v3/lease/revoke":
+		var req etcdLease
+		if !decode(w, r, &req) {
+			return
+		}
+		delete(e.leases, req.ID)
+		for key, kv := range e.kvs {
+			if kv.Lease == req.ID {
+				delete(e.kvs, key)
+			}
+		}
+		resp = struct{}{}
+	case "/v3/kv/range":
+		var req etcdRangeRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		resp = e.rangeKey(req.Key)
+	case "/v3/kv/txn":
+		var req etcdTxnRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		resp = e.txn(req)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (e *fakeEtcd) expireToken() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.token = ""
+}
+
+func decode(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func (e *fakeEtcd) rangeKey(key []byte) *etcdRangeResponse {
+	resp := &etcdRangeResponse{}
+	if kv, ok := e.kvs[string(key)]; ok {
+		resp.Kvs = append(resp.Kvs, kv)
+	}
+	return resp
+}
+
+func (e *fakeEtcd) txn(req etcdTxnRequest) etcdTxnResponse {
+	succeeded := true
+	for _, cmp := range req.Compare {
+		kv := e.kvs[string(cmp.Key)]
+		switch cmp.Target {
+		case "CREATE":
+			succeeded = succeeded && kv.CreateRevision == *cmp.CreateRevision
+		case "MOD":
+			succeeded = succeeded && kv.ModRevision == *cmp.ModRevision
+		case "LEASE":
+			succeeded = succeeded && kv.Lease == *cmp.Lease
+		}
+	}
+	ops := req.Failure
+	if succeeded {
+		ops = req.Success
+	}
+	resp := etcdTxnResponse{Succeeded: succeeded}
+	for _, op := range ops {
+		var result struct {
+			ResponseRange *etcdRangeResponse `json:"response_range"`
+		}
+		switch {
+		case op.RequestRange != nil:
+			result.ResponseRange = e.rangeKey(op.RequestRange.Key)
+		case op.RequestPut != nil:
+			e.revision++
+			kv := e.kvs[string(op.RequestPut.Key)]
+			if kv.CreateRevision == 0 {
+				kv.CreateRevision = e.revision
+			}
+			kv.Key, kv.Value, kv.Lease, kv.ModRevision = op.RequestPut.Key, op.RequestPut.Value, op.RequestPut.Lease, e.revision
+			e.kvs[string(op.RequestPut.Key)] = kv
+		}
+		resp.Responses = append(resp.Responses, result)
+	}
+	return resp
+}
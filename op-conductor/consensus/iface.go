@@ -1,9 +1,18 @@
 package consensus
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+var (
+	// ErrNotLeader is returned by operations that may only be performed by the leader of the cluster.
+	ErrNotLeader = errors.New("node is not the leader")
+	// ErrCantBootstrap is returned when bootstrapping a server that already has cluster state.
+	ErrCantBootstrap = errors.New("bootstrap only works on new clusters")
+)
+
 // ServerSuffrage determines whether a Server in a Configuration gets a vote.
 type ServerSuffrage int
 
@@ -68,11 +77,24 @@ type Consensus interface {
 	TransferLeaderTo(id, addr string) error
 	// ClusterMembership returns the current cluster membership configuration and associated version.
 	ClusterMembership() (*ClusterMembership, error)
+	// Bootstrap bootstraps a new cluster with this server as the only voter, returning ErrCantBootstrap if there is
+	// already cluster state.
+	Bootstrap() error
 
-	// CommitPayload commits latest unsafe payload to the FSM in a strongly consistent fashion.
-	CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope) error
+	// CommitPayload commits latest unsafe payload to the FSM in a strongly consistent fashion, along with its
+	// provenance if supplied by the sequencer.
+	CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope, metadata *PayloadMetadata) error
 	// LatestUnsafeBlock returns the latest unsafe payload from FSM in a strongly consistent fashion.
 	LatestUnsafePayload() (*eth.ExecutionPayloadEnvelope, error)
+	// LocalUnsafePayload returns the latest unsafe payload replicated to this server without consulting the leader,
+	// which may lag behind the payload committed to the cluster.
+	LocalUnsafePayload() (*eth.ExecutionPayloadEnvelope, error)
+	// ReplicationIndexes returns the index of the latest log entry committed by the leader, as known to this server,
+	// and the index of the latest log entry applied to this server. The difference is the replication lag.
+	ReplicationIndexes() (commitIndex uint64, appliedIndex uint64, err error)
+	// PayloadProvenance returns the provenance of a recently committed unsafe block replicated to this server, or nil
+	// if it is not known.
+	PayloadProvenance(number uint64) (*BlockProvenance, error)
 
 	// Shutdown shuts down the consensus protocol client.
 	Shutdown() error
@@ -0,0 +1,598 @@
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/pkg/errors"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	membershipKey    = "membership"
+	unsafePayloadKey = "unsafe-payload"
+	transferKey      = "leader-transfer"
+	// provenanceKeyPrefix prefixes the keys of the provenance of recent blocks.
+	provenanceKeyPrefix = "provenance-"
+)
+
+var (
+	ErrRevisionMismatch     = errors.New("revision mismatch")
+	ErrConfigurationChanged = errors.New("cluster membership changed since the given version")
+	ErrUnknownServer        = errors.New("server is not a voting member of the cluster")
+)
+
+// LeaseStore is an external coordination service, such as etcd or a cloud lock service, that provides a leadership
+// lease and a strongly consistent key-value store to LeaseConsensus.
+type LeaseStore interface {
+	// Campaign acquires or renews the leadership lease for server for ttl, unless another server holds an unexpired
+	// lease. It returns the current leader, or nil if the lease is not held by any server.
+	Campaign(ctx context.Context, server ServerInfo, ttl time.Duration) (*ServerInfo, error)
+	// Resign releases the leadership lease if it is held by the server with the given ID.
+	Resign(ctx context.Context, id string) error
+	// Leader returns the current holder of the leadership lease, or nil if the lease is not held by any server.
+	Leader(ctx context.Context) (*ServerInfo, error)
+	// Get returns the value and revision of key, or a nil value and zero revision if the key does not exist.
+	Get(ctx context.Context, key string) ([]byte, uint64, error)
+	// CompareAndSwap sets key to value if the revision of key matches revision, where zero requires the key to not
+	// exist, and returns ErrRevisionMismatch otherwise. If leaderID is not empty, the swap also requires the server
+	// with that ID to hold the leadership lease and returns ErrNotLeader otherwise, fencing out deposed leaders.
+	// If ttl is not zero, the store deletes the key once ttl has passed.
+	CompareAndSwap(ctx context.Context, key string, value []byte, revision uint64, leaderID string, ttl time.Duration) error
+	// Close releases the resources of the store.
+	Close() error
+}
+
+// LeaseConsensusConfig is the configuration of the lease consensus.
+type LeaseConsensusConfig struct {
+	ServerID   string
+	ServerAddr string
+	// Bootstrap adds this server as the only voter if the cluster has no members yet.
+	Bootstrap bool
+	// LeaseTTL is how long the leadership lease is held without being renewed. The lease is renewed every third of it.
+	// It must be at least Timeout, so that a lease renewal times out before the lease expires.
+	LeaseTTL time.Duration
+	// Timeout bounds each request to the store. It defaults to 5 seconds.
+	Timeout time.Duration
+}
+
+// leaderTransfer records an in-progress leadership transfer, during which only the target campaigns for the lease.
+// The store deletes the record after a lease TTL, so a transfer to a server that never acquires the lease times out
+// without comparing the clocks of different servers.
+type leaderTransfer struct {
+	ID string `json:"id"`
+}
+
+// LeaseConsensus implements Consensus using the leadership lease of an external coordination service, instead of
+// running raft between the conductors. Cluster membership and the latest unsafe payload are kept in the store.
+type LeaseConsensus struct {
+	log   log.Logger
+	cfg   LeaseConsensusConfig
+	store LeaseStore
+
+	leader   atomic.Bool
+	leaderID atomic.Pointer[ServerInfo]
+	leaderCh chan bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ Consensus = (*LeaseConsensus)(nil)
+
+// NewLeaseConsensus creates a new LeaseConsensus instance and starts campaigning for the leadership lease.
+func NewLeaseConsensus(log log.Logger, cfg *LeaseConsensusConfig, store LeaseStore) (*LeaseConsensus, error) {
+	if cfg.LeaseTTL <= 0 {
+		return nil, errors.New("invalid lease TTL")
+	}
+	leaseCfg := *cfg
+	if leaseCfg.Timeout == 0 {
+		leaseCfg.Timeout = defaultTimeout
+	}
+	if leaseCfg.LeaseTTL < leaseCfg.Timeout {
+		return nil, fmt.Errorf("lease TTL %v is shorter than the store timeout %v", leaseCfg.LeaseTTL, leaseCfg.Timeout)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	lc := &LeaseConsensus{
+		log:      log,
+		cfg:      leaseCfg,
+		store:    store,
+		leaderCh: make(chan bool, 1),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	if cfg.Bootstrap {
+		if err := lc.Bootstrap(); errors.Is(err, ErrCantBootstrap) {
+			log.Info("cluster membership already exists, skipping bootstrap")
+		} else if err != nil {
+			cancel()
+			return nil, errors.Wrap(err, "failed to bootstrap cluster membership")
+		}
+	}
+
+	lc.wg.Add(1)
+	go lc.campaignLoop()
+	return lc, nil
+}
+
+// Bootstrap implements Consensus, it bootstraps a new cluster with this server as the only voter.
+func (lc *LeaseConsensus) Bootstrap() error {
+	membership, err := lc.ClusterMembership()
+	if err != nil {
+		return err
+	}
+	if len(membership.Servers) > 0 {
+		return ErrCantBootstrap
+	}
+	servers := []ServerInfo{lc.self()}
+	if err := lc.storeMembership(servers, membership.Version, ""); errors.Is(err, ErrConfigurationChanged) {
+		// another server bootstrapped the cluster concurrently
+		return ErrCantBootstrap
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (lc *LeaseConsensus) campaignLoop() {
+	defer lc.wg.Done()
+	ticker := time.NewTicker(lc.cfg.LeaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		lc.campaign()
+		select {
+		case <-lc.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (lc *LeaseConsensus) campaign() {
+	ctx, cancel := context.WithTimeout(lc.ctx, lc.cfg.Timeout)
+	defer cancel()
+
+	eligible, err := lc.eligible(ctx)
+	if err != nil {
+		lc.log.Warn("failed to check leadership eligibility", "err", err)
+	}
+
+	var leader *ServerInfo
+	if eligible {
+		leader, err = lc.store.Campaign(ctx, lc.self(), lc.cfg.LeaseTTL)
+	} else {
+		leader, err = lc.store.Leader(ctx)
+	}
+	if err != nil {
+		// Without a renewed lease this server can no longer be sure it is the leader.
+		lc.log.Error("failed to campaign for leadership lease", "err", err)
+		lc.leaderID.Store(nil)
+		lc.setLeader(false)
+		return
+	}
+	lc.leaderID.Store(leader)
+	isLeader := leader != nil && leader.ID == lc.cfg.ServerID
+	if isLeader {
+		lc.completeTransfer(ctx)
+	}
+	lc.setLeader(isLeader)
+}
+
+// eligible returns true if this server is a voter and no leadership transfer to another server is in progress.
+func (lc *LeaseConsensus) eligible(ctx context.Context) (bool, error) {
+	servers, _, err := lc.loadMembership(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !isVoter(servers, lc.cfg.ServerID) {
+		return false, nil
+	}
+	transfer, _, err := lc.loadTransfer(ctx)
+	if err != nil {
+		return false, err
+	}
+	return transfer == nil || transfer.ID == lc.cfg.ServerID, nil
+}
+
+func (lc *LeaseConsensus) completeTransfer(ctx context.Context) {
+	transfer, revision, err := lc.loadTransfer(ctx)
+	if err != nil || transfer == nil {
+		return
+	}
+	// An empty record clears the transfer, as the store has no delete operation.
+	if err := lc.store.CompareAndSwap(ctx, transferKey, []byte{}, revision, lc.cfg.ServerID, 0); err != nil {
+		lc.log.Warn("failed to clear completed leadership transfer", "err", err)
+	}
+}
+
+func (lc *LeaseConsensus) setLeader(leader bool) {
+	if lc.leader.Swap(leader) == leader {
+		return
+	}
+	lc.log.Info("leadership status changed", "server", lc.cfg.ServerID, "leader", leader)
+	// Only the latest status matters, so replace an unread notification rather than blocking.
+	select {
+	case <-lc.leaderCh:
+	default:
+	}
+	lc.leaderCh <- leader
+}
+
+func (lc *LeaseConsensus) self() ServerInfo {
+	return ServerInfo{ID: lc.cfg.ServerID, Addr: lc.cfg.ServerAddr, Suffrage: Voter}
+}
+
+func (lc *LeaseConsensus) loadMembership(ctx context.Context) ([]ServerInfo, uint64, error) {
+	value, revision, err := lc.store.Get(ctx, membershipKey)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to load cluster membership")
+	}
+	var servers []ServerInfo
+	if len(value) > 0 {
+		if err := json.Unmarshal(value, &servers); err != nil {
+			return nil, 0, errors.Wrap(err, "failed to decode cluster membership")
+		}
+	}
+	return servers, revision, nil
+}
+
+func (lc *LeaseConsensus) storeMembership(servers []ServerInfo, revision uint64, leaderID string) error {
+	value, err := json.Marshal(servers)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode cluster membership")
+	}
+	ctx, cancel := context.WithTimeout(lc.ctx, lc.cfg.Timeout)
+	defer cancel()
+	if err := lc.store.CompareAndSwap(ctx, membershipKey, value, revision, leaderID, 0); errors.Is(err, ErrRevisionMismatch) {
+		return ErrConfigurationChanged
+	} else if err != nil {
+		return errors.Wrap(err, "failed to store cluster membership")
+	}
+	return nil
+}
+
+func (lc *LeaseConsensus) loadTransfer(ctx context.Context) (*leaderTransfer, uint64, error) {
+	value, revision, err := lc.store.Get(ctx, transferKey)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to load leadership transfer")
+	}
+	if len(value) == 0 {
+		return nil, revision, nil
+	}
+	var transfer leaderTransfer
+	if err := json.Unmarshal(value, &transfer); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to decode leadership transfer")
+	}
+	return &transfer, revision, nil
+}
+
+// updateMembership applies update to the current cluster membership, if this server is the leader and, when version
+// is non-zero, the membership is still at that version.
+func (lc *LeaseConsensus) updateMembership(version uint64, update func([]ServerInfo) []ServerInfo) error {
+	if !lc.Leader() {
+		return ErrNotLeader
+	}
+	ctx, cancel := context.WithTimeout(lc.ctx, lc.cfg.Timeout)
+	defer cancel()
+	servers, revision, err := lc.loadMembership(ctx)
+	if err != nil {
+		return err
+	}
+	if version != 0 && version != revision {
+		return ErrConfigurationChanged
+	}
+	return lc.storeMembership(update(servers), revision, lc.cfg.ServerID)
+}
+
+func (lc *LeaseConsensus) addServer(id, addr string, suffrage ServerSuffrage, version uint64) error {
+	return lc.updateMembership(version, func(servers []ServerInfo) []ServerInfo {
+		servers = removeServer(servers, id)
+		return append(servers, ServerInfo{ID: id, Addr: addr, Suffrage: suffrage})
+	})
+}
+
+// AddVoter implements Consensus, it tries to add a voting member into the cluster.
+func (lc *LeaseConsensus) AddVoter(id, addr string, version uint64) error {
+	if err := lc.addServer(id, addr, Voter, version); err != nil {
+		lc.log.Error("failed to add voter", "id", id, "addr", addr, "version", version, "err", err)
+		return err
+	}
+	return nil
+}
+
+// AddNonVoter implements Consensus, it tries to add a non-voting member into the cluster.
+func (lc *LeaseConsensus) AddNonVoter(id, addr string, version uint64) error {
+	if err := lc.addServer(id, addr, Nonvoter, version); err != nil {
+		lc.log.Error("failed to add non-voter", "id", id, "addr", addr, "version", version, "err", err)
+		return err
+	}
+	return nil
+}
+
+// DemoteVoter implements Consensus, it tries to demote a voting member into a non-voting member in the cluster.
+// A demoted leader stops renewing its lease, so leadership moves to another voter once the lease expires.
+func (lc *LeaseConsensus) DemoteVoter(id string, version uint64) error {
+	err := lc.updateMembership(version, func(servers []ServerInfo) []ServerInfo {
+		for i := range servers {
+			if servers[i].ID == id {
+				servers[i].Suffrage = Nonvoter
+			}
+		}
+		return servers
+	})
+	if err != nil {
+		lc.log.Error("failed to demote voter", "id", id, "version", version, "err", err)
+		return err
+	}
+	return nil
+}
+
+// RemoveServer implements Consensus, it tries to remove a member (both voter or non-voter) from the cluster.
+// A removed leader stops renewing its lease, so leadership moves to another voter once the lease expires.
+func (lc *LeaseConsensus) RemoveServer(id string, version uint64) error {
+	err := lc.updateMembership(version, func(servers []ServerInfo) []ServerInfo {
+		return removeServer(servers, id)
+	})
+	if err != nil {
+		lc.log.Error("failed to remove server", "id", id, "version", version, "err", err)
+		return err
+	}
+	return nil
+}
+
+// LeaderCh implements Consensus, it returns a channel that will be notified when leadership status changes (true = leader, false = follower).
+func (lc *LeaseConsensus) LeaderCh() <-chan bool {
+	return lc.leaderCh
+}
+
+// Leader implements Consensus, it returns true if it is the leader of the cluster.
+func (lc *LeaseConsensus) Leader() bool {
+	return lc.leader.Load()
+}
+
+// LeaderWithID implements Consensus, it returns the leader's server ID and address.
+func (lc *LeaseConsensus) LeaderWithID() *ServerInfo {
+	if leader := lc.leaderID.Load(); leader != nil {
+		return leader
+	}
+	return &ServerInfo{}
+}
+
+// ServerID implements Consensus, it returns the server ID of the current server.
+func (lc *LeaseConsensus) ServerID() string {
+	return lc.cfg.ServerID
+}
+
+// TransferLeader implements Consensus, it triggers leadership transfer to another voter in the cluster.
+func (lc *LeaseConsensus) TransferLeader() error {
+	if !lc.Leader() {
+		return nil
+	}
+	servers, _, err := lc.loadMembership(lc.ctx)
+	if err != nil {
+		return err
+	}
+	for _, server := range servers {
+		if server.Suffrage == Voter && server.ID != lc.cfg.ServerID {
+			return lc.TransferLeaderTo(server.ID, server.Addr)
+		}
+	}
+	return errors.New("no other voter to transfer leadership to")
+}
+
+// TransferLeaderTo implements Consensus, it triggers leadership transfer to a specific member in the cluster.
+// Other servers do not campaign for the lease until the target acquires it or the transfer times out after a lease TTL.
+func (lc *LeaseConsensus) TransferLeaderTo(id string, addr string) error {
+	if err := lc.transferLeaderTo(id); err != nil {
+		lc.log.Error("failed to transfer leadership to server", "id", id, "addr", addr, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (lc *LeaseConsensus) transferLeaderTo(id string) error {
+	if !lc.Leader() {
+		return ErrNotLeader
+	}
+	ctx, cancel := context.WithTimeout(lc.ctx, lc.cfg.Timeout)
+	defer cancel()
+	servers, _, err := lc.loadMembership(ctx)
+	if err != nil {
+		return err
+	}
+	if !isVoter(servers, id) {
+		return ErrUnknownServer
+	}
+	_, revision, err := lc.loadTransfer(ctx)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(leaderTransfer{ID: id})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode leadership transfer")
+	}
+	if err := lc.store.CompareAndSwap(ctx, transferKey, value, revision, lc.cfg.ServerID, lc.cfg.LeaseTTL); err != nil {
+		return errors.Wrap(err, "failed to store leadership transfer")
+	}
+	if err := lc.store.Resign(ctx, lc.cfg.ServerID); err != nil {
+		return errors.Wrap(err, "failed to resign leadership lease")
+	}
+	lc.leaderID.Store(nil)
+	lc.setLeader(false)
+	return nil
+}
+
+// ClusterMembership implements Consensus, it returns the current cluster membership configuration.
+func (lc *LeaseConsensus) ClusterMembership() (*ClusterMembership, error) {
+	ctx, cancel := context.WithTimeout(lc.ctx, lc.cfg.Timeout)
+	defer cancel()
+	servers, revision, err := lc.loadMembership(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ClusterMembership{
+		Servers: servers,
+		Version: revision,
+	}, nil
+}
+
+// CommitUnsafePayload implements Consensus, it stores the latest unsafe payload while this server holds the leadership lease.
+// The provenance of the payload, if supplied, is stored under its own key per block, so that the unsafe payload stays
+// small and readable by servers that do not track provenance.
+func (lc *LeaseConsensus) CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope, metadata *PayloadMetadata) error {
+	lc.log.Debug("committing unsafe payload", "number", uint64(payload.ExecutionPayload.BlockNumber), "hash", payload.ExecutionPayload.BlockHash.Hex())
+	if !lc.Leader() {
+		return ErrNotLeader
+	}
+
+	ctx, cancel := context.WithTimeout(lc.ctx, lc.cfg.Timeout)
+	defer cancel()
+	_, revision, err := lc.loadUnsafeState(ctx)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if _, err := payload.MarshalSSZ(&buf); err != nil {
+		return errors.Wrap(err, "failed to marshal payload envelope")
+	}
+	if err := lc.store.CompareAndSwap(ctx, unsafePayloadKey, buf.Bytes(), revision, lc.cfg.ServerID, 0); err != nil {
+		return errors.Wrap(err, "failed to store payload envelope")
+	}
+	lc.log.Debug("unsafe payload committed", "number", uint64(payload.ExecutionPayload.BlockNumber), "hash", payload.ExecutionPayload.BlockHash.Hex())
+
+	if metadata != nil {
+		// the payload is committed, so a failure to store its provenance only loses the provenance
+		if err := lc.storeProvenance(ctx, payload, metadata); err != nil {
+			lc.log.Error("failed to store payload provenance", "number", uint64(payload.ExecutionPayload.BlockNumber), "err", err)
+		}
+	}
+	return nil
+}
+
+// provenanceKey returns the key of the provenance of the block with the given number. Keys are reused for blocks
+// provenanceHistory apart, bounding the provenance in the store to that of the most recent blocks.
+func provenanceKey(number uint64) string {
+	return fmt.Sprintf("%s%d", provenanceKeyPrefix, number%provenanceHistory)
+}
+
+func (lc *LeaseConsensus) storeProvenance(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *PayloadMetadata) error {
+	key := provenanceKey(uint64(payload.ExecutionPayload.BlockNumber))
+	_, revision, err := lc.store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(BlockProvenance{
+		Number:          uint64(payload.ExecutionPayload.BlockNumber),
+		Hash:            payload.ExecutionPayload.BlockHash,
+		PayloadMetadata: *metadata,
+	})
+	if err != nil {
+		return err
+	}
+	return lc.store.CompareAndSwap(ctx, key, value, revision, lc.cfg.ServerID, 0)
+}
+
+// LocalUnsafePayload implements Consensus. The store is the only replica of the unsafe payload, so it is the same as
+// LatestUnsafePayload.
+func (lc *LeaseConsensus) LocalUnsafePayload() (*eth.ExecutionPayloadEnvelope, error) {
+	return lc.LatestUnsafePayload()
+}
+
+// ReplicationIndexes implements Consensus. The store is the only replica of the unsafe payload, so there is no
+// replication lag and no log to report indexes of.
+func (lc *LeaseConsensus) ReplicationIndexes() (uint64, uint64, error) {
+	return 0, 0, nil
+}
+
+// LatestUnsafePayload implements Consensus, it returns the latest unsafe payload from the store.
+func (lc *LeaseConsensus) LatestUnsafePayload() (*eth.ExecutionPayloadEnvelope, error) {
+	ctx, cancel := context.WithTimeout(lc.ctx, lc.cfg.Timeout)
+	defer cancel()
+	payload, _, err := lc.loadUnsafeState(ctx)
+	return payload, err
+}
+
+// PayloadProvenance implements Consensus, it returns the provenance of a recent block from the store.
+func (lc *LeaseConsensus) PayloadProvenance(number uint64) (*BlockProvenance, error) {
+	ctx, cancel := context.WithTimeout(lc.ctx, lc.cfg.Timeout)
+	defer cancel()
+	value, _, err := lc.store.Get(ctx, provenanceKey(number))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load payload provenance")
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	var provenance BlockProvenance
+	if err := json.Unmarshal(value, &provenance); err != nil {
+		return nil, fmt.Errorf("error unmarshalling payload provenance: %w", err)
+	}
+	// the key holds the provenance of an older block if the provenance of this block was not recorded
+	if provenance.Number != number {
+		return nil, nil
+	}
+	return &provenance, nil
+}
+
+func (lc *LeaseConsensus) loadUnsafeState(ctx context.Context) (*eth.ExecutionPayloadEnvelope, uint64, error) {
+	value, revision, err := lc.store.Get(ctx, unsafePayloadKey)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to load unsafe payload")
+	}
+	if len(value) == 0 {
+		return nil, revision, nil
+	}
+	payload := &eth.ExecutionPayloadEnvelope{}
+	if err := payload.UnmarshalSSZ(uint32(len(value)), bytes.NewReader(value)); err != nil {
+		return nil, 0, fmt.Errorf("error unmarshalling unsafe payload: %w", err)
+	}
+	return payload, revision, nil
+}
+
+// Shutdown implements Consensus, it stops campaigning and releases the leadership lease if held.
+func (lc *LeaseConsensus) Shutdown() error {
+	lc.cancel()
+	lc.wg.Wait()
+
+	var result error
+	if lc.leader.Load() {
+		ctx, cancel := context.WithTimeout(context.Background(), lc.cfg.Timeout)
+		defer cancel()
+		if err := lc.store.Resign(ctx, lc.cfg.ServerID); err != nil {
+			result = errors.Wrap(err, "failed to resign leadership lease")
+		}
+	}
+	if err := lc.store.Close(); err != nil && result == nil {
+		result = errors.Wrap(err, "failed to close lease store")
+	}
+	return result
+}
+
+func isVoter(servers []ServerInfo, id string) bool {
+	for _, server := range servers {
+		if server.ID == id && server.Suffrage == Voter {
+			return true
+		}
+	}
+	return false
+}
+
+func removeServer(servers []ServerInfo, id string) []ServerInfo {
+	result := make([]ServerInfo, 0, len(servers))
+	for _, server := range servers {
+		if server.ID != id {
+			result = append(result, server)
+		}
+	}
+	return result
+}
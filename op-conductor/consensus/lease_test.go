@@ -0,0 +1,381 @@
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+const testLeaseTTL = 300 * time.Millisecond
+
+func TestLeaseConsensus(t *testing.T) {
+	t.Run("BootstrapBecomesLeader", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+
+		require.True(t, <-a.LeaderCh())
+		require.True(t, a.Leader())
+		require.Equal(t, "A", a.LeaderWithID().ID)
+
+		membership, err := a.ClusterMembership()
+		require.NoError(t, err)
+		require.Equal(t, []ServerInfo{{ID: "A", Addr: "A:50050", Suffrage: Voter}}, membership.Servers)
+	})
+
+	t.Run("BootstrapExistingCluster", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+		b := newTestLeaseConsensus(t, store, "B", true)
+		<-a.LeaderCh()
+
+		require.ErrorIs(t, a.Bootstrap(), ErrCantBootstrap)
+		require.ErrorIs(t, b.Bootstrap(), ErrCantBootstrap)
+		membership, err := b.ClusterMembership()
+		require.NoError(t, err)
+		require.Equal(t, []ServerInfo{{ID: "A", Addr: "A:50050", Suffrage: Voter}}, membership.Servers)
+	})
+
+	t.Run("CommitAndRead", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+		b := newTestLeaseConsensus(t, store, "B", false)
+		<-a.LeaderCh()
+
+		payload, err := a.LatestUnsafePayload()
+		require.NoError(t, err)
+		require.Nil(t, payload)
+
+		require.NoError(t, a.CommitUnsafePayload(createPayloadEnvelope(1), nil))
+		require.NoError(t, a.CommitUnsafePayload(createPayloadEnvelope(2), nil))
+		require.ErrorIs(t, b.CommitUnsafePayload(createPayloadEnvelope(3), nil), ErrNotLeader)
+
+		payload, err = b.LatestUnsafePayload()
+		require.NoError(t, err)
+		require.Equal(t, createPayloadEnvelope(2).ExecutionPayload.BlockNumber, payload.ExecutionPayload.BlockNumber)
+	})
+
+	t.Run("CommitWithProvenance", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+		b := newTestLeaseConsensus(t, store, "B", false)
+		<-a.LeaderCh()
+
+		require.NoError(t, a.CommitUnsafePayload(createPayloadEnvelope(1), &PayloadMetadata{Source: PayloadSourceLocal}))
+		require.NoError(t, a.CommitUnsafePayload(createPayloadEnvelope(2), &PayloadMetadata{Source: PayloadSourceBuilder, BuilderID: "builder-1"}))
+
+		provenance, err := b.PayloadProvenance(2)
+		require.NoError(t, err)
+		require.Equal(t, PayloadMetadata{Source: PayloadSourceBuilder, BuilderID: "builder-1"}, provenance.PayloadMetadata)
+		provenance, err = b.PayloadProvenance(1)
+		require.NoError(t, err)
+		require.Equal(t, PayloadSourceLocal, provenance.Source)
+		provenance, err = b.PayloadProvenance(3)
+		require.NoError(t, err)
+		require.Nil(t, provenance)
+
+		// the unsafe payload is stored without provenance, readable by servers that do not track it
+		value, _, err := store.Get(context.Background(), unsafePayloadKey)
+		require.NoError(t, err)
+		var legacy bytes.Buffer
+		_, err = createPayloadEnvelope(2).MarshalSSZ(&legacy)
+		require.NoError(t, err)
+		require.Equal(t, legacy.Bytes(), value)
+
+		// the key of a block is reused for a block provenanceHistory later
+		require.NoError(t, a.CommitUnsafePayload(createPayloadEnvelope(provenanceHistory+1), &PayloadMetadata{Source: PayloadSourceBuilder}))
+		provenance, err = b.PayloadProvenance(1)
+		require.NoError(t, err)
+		require.Nil(t, provenance)
+		provenance, err = b.PayloadProvenance(provenanceHistory + 1)
+		require.NoError(t, err)
+		require.Equal(t, PayloadSourceBuilder, provenance.Source)
+	})
+
+	t.Run("MembershipChangesRequireMatchingVersion", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+		<-a.LeaderCh()
+
+		membership, err := a.ClusterMembership()
+		require.NoError(t, err)
+		require.ErrorIs(t, a.AddVoter("B", "B:50050", membership.Version+1), ErrConfigurationChanged)
+		require.NoError(t, a.AddVoter("B", "B:50050", membership.Version))
+		require.NoError(t, a.AddNonVoter("C", "C:50050", 0))
+		require.NoError(t, a.DemoteVoter("B", 0))
+
+		membership, err = a.ClusterMembership()
+		require.NoError(t, err)
+		require.Equal(t, []ServerInfo{
+			{ID: "A", Addr: "A:50050", Suffrage: Voter},
+			{ID: "B", Addr: "B:50050", Suffrage: Nonvoter},
+			{ID: "C", Addr: "C:50050", Suffrage: Nonvoter},
+		}, membership.Servers)
+
+		require.NoError(t, a.RemoveServer("C", 0))
+		membership, err = a.ClusterMembership()
+		require.NoError(t, err)
+		require.Len(t, membership.Servers, 2)
+	})
+
+	t.Run("FollowerCannotChangeMembership", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+		b := newTestLeaseConsensus(t, store, "B", false)
+		<-a.LeaderCh()
+
+		require.ErrorIs(t, b.AddVoter("C", "C:50050", 0), ErrNotLeader)
+	})
+
+	t.Run("TransferLeaderTo", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+		<-a.LeaderCh()
+		require.NoError(t, a.AddVoter("B", "B:50050", 0))
+		b := newTestLeaseConsensus(t, store, "B", false)
+
+		require.ErrorIs(t, a.TransferLeaderTo("C", "C:50050"), ErrUnknownServer)
+		require.NoError(t, a.TransferLeaderTo("B", "B:50050"))
+		require.False(t, <-a.LeaderCh())
+		require.True(t, <-b.LeaderCh())
+		require.Eventually(t, func() bool {
+			return a.LeaderWithID().ID == "B"
+		}, 5*testLeaseTTL, testLeaseTTL/10)
+		require.ErrorIs(t, a.TransferLeaderTo("B", "B:50050"), ErrNotLeader)
+	})
+
+	t.Run("TransferLeader", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+		<-a.LeaderCh()
+		require.NoError(t, a.AddVoter("B", "B:50050", 0))
+		b := newTestLeaseConsensus(t, store, "B", false)
+
+		require.NoError(t, b.TransferLeader(), "no-op on followers")
+		require.NoError(t, a.TransferLeader())
+		require.True(t, <-b.LeaderCh())
+	})
+
+	t.Run("TransferAfterCompletedTransfer", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+		<-a.LeaderCh()
+		require.NoError(t, a.AddVoter("B", "B:50050", 0))
+		b := newTestLeaseConsensus(t, store, "B", false)
+
+		require.NoError(t, a.TransferLeaderTo("B", "B:50050"))
+		require.False(t, <-a.LeaderCh())
+		require.True(t, <-b.LeaderCh())
+
+		// the new leader clears the completed transfer, which must not block the next one
+		value, revision, err := store.Get(context.Background(), transferKey)
+		require.NoError(t, err)
+		require.Empty(t, value)
+		require.NotZero(t, revision)
+		require.NoError(t, b.TransferLeaderTo("A", "A:50050"))
+		require.True(t, <-a.LeaderCh())
+		require.Eventually(t, func() bool {
+			return b.LeaderWithID().ID == "A"
+		}, 5*testLeaseTTL, testLeaseTTL/10)
+	})
+
+	t.Run("TransferTimesOut", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+		<-a.LeaderCh()
+		require.NoError(t, a.AddVoter("B", "B:50050", 0))
+
+		// B is not running, so A campaigns again once the store expires the transfer
+		require.NoError(t, a.TransferLeaderTo("B", "B:50050"))
+		require.False(t, <-a.LeaderCh())
+		require.True(t, <-a.LeaderCh())
+		transfer, _, err := a.loadTransfer(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, transfer)
+	})
+
+	t.Run("DeposedLeaderIsFenced", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a := newTestLeaseConsensus(t, store, "A", true)
+		<-a.LeaderCh()
+		require.NoError(t, a.AddVoter("B", "B:50050", 0))
+
+		// the lease renewal of A blocks, so its lease expires while it still considers itself the leader
+		release := make(chan struct{})
+		defer close(release)
+		store.setCampaignHook(func(server ServerInfo) {
+			if server.ID == "A" {
+				<-release
+			}
+		})
+		b := newTestLeaseConsensus(t, store, "B", false)
+		require.True(t, <-b.LeaderCh())
+
+		require.True(t, a.Leader())
+		require.ErrorIs(t, a.CommitUnsafePayload(createPayloadEnvelope(1), nil), ErrNotLeader)
+		require.ErrorIs(t, a.AddNonVoter("C", "C:50050", 0), ErrNotLeader)
+		require.NoError(t, b.CommitUnsafePayload(createPayloadEnvelope(2), nil))
+		payload, err := b.LatestUnsafePayload()
+		require.NoError(t, err)
+		require.EqualValues(t, 2, payload.ExecutionPayload.BlockNumber)
+	})
+
+	t.Run("LeaseTTLShorterThanTimeout", func(t *testing.T) {
+		cfg := leaseConfig("A", true)
+		cfg.Timeout = 0
+		_, err := NewLeaseConsensus(testlog.Logger(t, log.LevelDebug), cfg, newMemoryLeaseStore())
+		require.ErrorContains(t, err, "shorter than the store timeout")
+
+		cfg.LeaseTTL = defaultTimeout
+		lc, err := NewLeaseConsensus(testlog.Logger(t, log.LevelDebug), cfg, newMemoryLeaseStore())
+		require.NoError(t, err)
+		require.NoError(t, lc.Shutdown())
+	})
+
+	t.Run("LeaseExpiresAfterShutdown", func(t *testing.T) {
+		store := newMemoryLeaseStore()
+		a, err := NewLeaseConsensus(testlog.Logger(t, log.LevelDebug), leaseConfig("A", true), store)
+		require.NoError(t, err)
+		<-a.LeaderCh()
+		require.NoError(t, a.AddVoter("B", "B:50050", 0))
+		b := newTestLeaseConsensus(t, store, "B", false)
+
+		require.NoError(t, a.Shutdown())
+		require.True(t, <-b.LeaderCh())
+	})
+}
+
+func leaseConfig(id string, bootstrap bool) *LeaseConsensusConfig {
+	return &LeaseConsensusConfig{
+		ServerID:   id,
+		ServerAddr: id + ":50050",
+		Bootstrap:  bootstrap,
+		LeaseTTL:   testLeaseTTL,
+		Timeout:    testLeaseTTL,
+	}
+}
+
+func newTestLeaseConsensus(t *testing.T, store LeaseStore, id string, bootstrap bool) *LeaseConsensus {
+	lc, err := NewLeaseConsensus(testlog.Logger(t, log.LevelDebug), leaseConfig(id, bootstrap), store)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, lc.Shutdown())
+	})
+	return lc
+}
+
+type memoryValue struct {
+	value    []byte
+	revision uint64
+	expiry   time.Time
+}
+
+// memoryLeaseStore is a LeaseStore shared by the servers of a test cluster.
+type memoryLeaseStore struct {
+	mu          sync.Mutex
+	leader      *ServerInfo
+	leaseExpiry time.Time
+	revision    uint64
+	values      map[string]memoryValue
+	// campaignHook, if set, is called before each campaign, outside of the lock.
+	campaignHook func(server ServerInfo)
+}
+
+func newMemoryLeaseStore() *memoryLeaseStore {
+	return &memoryLeaseStore{values: make(map[string]memoryValue)}
+}
+
+func (s *memoryLeaseStore) currentLeader() *ServerInfo {
+	if s.leader != nil && time.Now().After(s.leaseExpiry) {
+		s.leader = nil
+	}
+	return s.leader
+}
+
+func (s *memoryLeaseStore) setCampaignHook(hook func(server ServerInfo)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.campaignHook = hook
+}
+
+func (s *memoryLeaseStore) value(key string) memoryValue {
+	v := s.values[key]
+	if !v.expiry.IsZero() && time.Now().After(v.expiry) {
+		delete(s.values, key)
+		return memoryValue{}
+	}
+	return v
+}
+
+func (s *memoryLeaseStore) Campaign(_ context.Context, server ServerInfo, ttl time.Duration) (*ServerInfo, error) {
+	s.mu.Lock()
+	hook := s.campaignHook
+	s.mu.Unlock()
+	if hook != nil {
+		hook(server)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if leader := s.currentLeader(); leader == nil || leader.ID == server.ID {
+		s.leader = &server
+		s.leaseExpiry = time.Now().Add(ttl)
+	}
+	leader := *s.leader
+	return &leader, nil
+}
+
+func (s *memoryLeaseStore) Resign(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if leader := s.currentLeader(); leader != nil && leader.ID == id {
+		s.leader = nil
+	}
+	return nil
+}
+
+func (s *memoryLeaseStore) Leader(_ context.Context) (*ServerInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if leader := s.currentLeader(); leader != nil {
+		leaderCopy := *leader
+		return &leaderCopy, nil
+	}
+	return nil, nil
+}
+
+func (s *memoryLeaseStore) Get(_ context.Context, key string) ([]byte, uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.value(key)
+	return v.value, v.revision, nil
+}
+
+func (s *memoryLeaseStore) CompareAndSwap(_ context.Context, key string, value []byte, revision uint64, leaderID string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if leaderID != "" {
+		if leader := s.currentLeader(); leader == nil || leader.ID != leaderID {
+			return ErrNotLeader
+		}
+	}
+	if s.value(key).revision != revision {
+		return ErrRevisionMismatch
+	}
+	s.revision++
+	v := memoryValue{value: value, revision: s.revision}
+	if ttl > 0 {
+		v.expiry = time.Now().Add(ttl)
+	}
+	s.values[key] = v
+	return nil
+}
+
+func (s *memoryLeaseStore) Close() error {
+	return nil
+}
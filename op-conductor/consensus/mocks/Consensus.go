@@ -118,6 +118,51 @@ func (_c *Consensus_AddVoter_Call) RunAndReturn(run func(string, string, uint64)
 	return _c
 }
 
+// Bootstrap provides a mock function with given fields:
+func (_m *Consensus) Bootstrap() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bootstrap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Consensus_Bootstrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Bootstrap'
+type Consensus_Bootstrap_Call struct {
+	*mock.Call
+}
+
+// Bootstrap is a helper method to define mock.On call
+func (_e *Consensus_Expecter) Bootstrap() *Consensus_Bootstrap_Call {
+	return &Consensus_Bootstrap_Call{Call: _e.mock.On("Bootstrap")}
+}
+
+func (_c *Consensus_Bootstrap_Call) Run(run func()) *Consensus_Bootstrap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Consensus_Bootstrap_Call) Return(_a0 error) *Consensus_Bootstrap_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Consensus_Bootstrap_Call) RunAndReturn(run func() error) *Consensus_Bootstrap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ClusterMembership provides a mock function with given fields:
 func (_m *Consensus) ClusterMembership() (*consensus.ClusterMembership, error) {
 	ret := _m.Called()
@@ -175,17 +220,17 @@ func (_c *Consensus_ClusterMembership_Call) RunAndReturn(run func() (*consensus.
 	return _c
 }
 
-// CommitUnsafePayload provides a mock function with given fields: payload
-func (_m *Consensus) CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope) error {
-	ret := _m.Called(payload)
+// CommitUnsafePayload provides a mock function with given fields: payload, metadata
+func (_m *Consensus) CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope, metadata *consensus.PayloadMetadata) error {
+	ret := _m.Called(payload, metadata)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CommitUnsafePayload")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*eth.ExecutionPayloadEnvelope) error); ok {
-		r0 = rf(payload)
+	if rf, ok := ret.Get(0).(func(*eth.ExecutionPayloadEnvelope, *consensus.PayloadMetadata) error); ok {
+		r0 = rf(payload, metadata)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -200,13 +245,14 @@ type Consensus_CommitUnsafePayload_Call struct {
 
 // CommitUnsafePayload is a helper method to define mock.On call
 //   - payload *eth.ExecutionPayloadEnvelope
-func (_e *Consensus_Expecter) CommitUnsafePayload(payload interface{}) *Consensus_CommitUnsafePayload_Call {
-	return &Consensus_CommitUnsafePayload_Call{Call: _e.mock.On("CommitUnsafePayload", payload)}
+//   - metadata *consensus.PayloadMetadata
+func (_e *Consensus_Expecter) CommitUnsafePayload(payload interface{}, metadata interface{}) *Consensus_CommitUnsafePayload_Call {
+	return &Consensus_CommitUnsafePayload_Call{Call: _e.mock.On("CommitUnsafePayload", payload, metadata)}
 }
 
-func (_c *Consensus_CommitUnsafePayload_Call) Run(run func(payload *eth.ExecutionPayloadEnvelope)) *Consensus_CommitUnsafePayload_Call {
+func (_c *Consensus_CommitUnsafePayload_Call) Run(run func(payload *eth.ExecutionPayloadEnvelope, metadata *consensus.PayloadMetadata)) *Consensus_CommitUnsafePayload_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*eth.ExecutionPayloadEnvelope))
+		run(args[0].(*eth.ExecutionPayloadEnvelope), args[1].(*consensus.PayloadMetadata))
 	})
 	return _c
 }
@@ -216,7 +262,7 @@ func (_c *Consensus_CommitUnsafePayload_Call) Return(_a0 error) *Consensus_Commi
 	return _c
 }
 
-func (_c *Consensus_CommitUnsafePayload_Call) RunAndReturn(run func(*eth.ExecutionPayloadEnvelope) error) *Consensus_CommitUnsafePayload_Call {
+func (_c *Consensus_CommitUnsafePayload_Call) RunAndReturn(run func(*eth.ExecutionPayloadEnvelope, *consensus.PayloadMetadata) error) *Consensus_CommitUnsafePayload_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -464,6 +510,183 @@ func (_c *Consensus_LeaderWithID_Call) RunAndReturn(run func() *consensus.Server
 	return _c
 }
 
+// LocalUnsafePayload provides a mock function with given fields:
+func (_m *Consensus) LocalUnsafePayload() (*eth.ExecutionPayloadEnvelope, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LocalUnsafePayload")
+	}
+
+	var r0 *eth.ExecutionPayloadEnvelope
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*eth.ExecutionPayloadEnvelope, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *eth.ExecutionPayloadEnvelope); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*eth.ExecutionPayloadEnvelope)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Consensus_LocalUnsafePayload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LocalUnsafePayload'
+type Consensus_LocalUnsafePayload_Call struct {
+	*mock.Call
+}
+
+// LocalUnsafePayload is a helper method to define mock.On call
+func (_e *Consensus_Expecter) LocalUnsafePayload() *Consensus_LocalUnsafePayload_Call {
+	return &Consensus_LocalUnsafePayload_Call{Call: _e.mock.On("LocalUnsafePayload")}
+}
+
+func (_c *Consensus_LocalUnsafePayload_Call) Run(run func()) *Consensus_LocalUnsafePayload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Consensus_LocalUnsafePayload_Call) Return(_a0 *eth.ExecutionPayloadEnvelope, _a1 error) *Consensus_LocalUnsafePayload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Consensus_LocalUnsafePayload_Call) RunAndReturn(run func() (*eth.ExecutionPayloadEnvelope, error)) *Consensus_LocalUnsafePayload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplicationIndexes provides a mock function with given fields:
+func (_m *Consensus) ReplicationIndexes() (uint64, uint64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplicationIndexes")
+	}
+
+	var r0 uint64
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (uint64, uint64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func() uint64); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Consensus_ReplicationIndexes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplicationIndexes'
+type Consensus_ReplicationIndexes_Call struct {
+	*mock.Call
+}
+
+// ReplicationIndexes is a helper method to define mock.On call
+func (_e *Consensus_Expecter) ReplicationIndexes() *Consensus_ReplicationIndexes_Call {
+	return &Consensus_ReplicationIndexes_Call{Call: _e.mock.On("ReplicationIndexes")}
+}
+
+func (_c *Consensus_ReplicationIndexes_Call) Run(run func()) *Consensus_ReplicationIndexes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Consensus_ReplicationIndexes_Call) Return(commitIndex uint64, appliedIndex uint64, err error) *Consensus_ReplicationIndexes_Call {
+	_c.Call.Return(commitIndex, appliedIndex, err)
+	return _c
+}
+
+func (_c *Consensus_ReplicationIndexes_Call) RunAndReturn(run func() (uint64, uint64, error)) *Consensus_ReplicationIndexes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PayloadProvenance provides a mock function with given fields: number
+func (_m *Consensus) PayloadProvenance(number uint64) (*consensus.BlockProvenance, error) {
+	ret := _m.Called(number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PayloadProvenance")
+	}
+
+	var r0 *consensus.BlockProvenance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (*consensus.BlockProvenance, error)); ok {
+		return rf(number)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) *consensus.BlockProvenance); ok {
+		r0 = rf(number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*consensus.BlockProvenance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Consensus_PayloadProvenance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PayloadProvenance'
+type Consensus_PayloadProvenance_Call struct {
+	*mock.Call
+}
+
+// PayloadProvenance is a helper method to define mock.On call
+//   - number uint64
+func (_e *Consensus_Expecter) PayloadProvenance(number interface{}) *Consensus_PayloadProvenance_Call {
+	return &Consensus_PayloadProvenance_Call{Call: _e.mock.On("PayloadProvenance", number)}
+}
+
+func (_c *Consensus_PayloadProvenance_Call) Run(run func(number uint64)) *Consensus_PayloadProvenance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *Consensus_PayloadProvenance_Call) Return(_a0 *consensus.BlockProvenance, _a1 error) *Consensus_PayloadProvenance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Consensus_PayloadProvenance_Call) RunAndReturn(run func(uint64) (*consensus.BlockProvenance, error)) *Consensus_PayloadProvenance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveServer provides a mock function with given fields: id, version
 func (_m *Consensus) RemoveServer(id string, version uint64) error {
 	ret := _m.Called(id, version)
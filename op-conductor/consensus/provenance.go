@@ -0,0 +1,133 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// PayloadSource is where an unsafe payload was built.
+type PayloadSource = eth.PayloadSource
+
+const (
+	PayloadSourceLocal   = eth.PayloadSourceLocal
+	PayloadSourceBuilder = eth.PayloadSourceBuilder
+)
+
+// PayloadMetadata is the provenance of an unsafe payload, supplied by the sequencer when committing it.
+type PayloadMetadata = eth.PayloadMetadata
+
+// BlockProvenance is the provenance of a committed unsafe block.
+type BlockProvenance struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	PayloadMetadata
+}
+
+// provenanceHistory is the number of recent blocks whose provenance is retained.
+const provenanceHistory = 1024
+
+// appendProvenance records the provenance of payload, keeping the provenance of the most recent blocks only.
+func appendProvenance(history []BlockProvenance, payload *eth.ExecutionPayloadEnvelope, metadata *PayloadMetadata) []BlockProvenance {
+	if metadata == nil {
+		return history
+	}
+	history = append(history, BlockProvenance{
+		Number:          uint64(payload.ExecutionPayload.BlockNumber),
+		Hash:            payload.ExecutionPayload.BlockHash,
+		PayloadMetadata: *metadata,
+	})
+	if len(history) > provenanceHistory {
+		history = append([]BlockProvenance(nil), history[len(history)-provenanceHistory:]...)
+	}
+	return history
+}
+
+// findProvenance returns the provenance of the most recently committed block with the given number, or nil if it is
+// not known.
+func findProvenance(history []BlockProvenance, number uint64) *BlockProvenance {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Number == number {
+			p := history[i]
+			return &p
+		}
+	}
+	return nil
+}
+
+// unsafeStateMagic prefixes encoded unsafe state, followed by a version byte. Unsafe state without it is a bare SSZ
+// encoded payload envelope, as written before provenance was tracked, which can never start with it as it starts with
+// a small SSZ offset.
+var unsafeStateMagic = []byte("OPCS")
+
+// unsafeStateV1 is the version of unsafe state holding the length prefixed SSZ encoded unsafe head, followed by the
+// JSON encoded provenance of recent blocks.
+const unsafeStateV1 = 1
+
+// encodeUnsafeState writes the unsafe head and the provenance of recent blocks to w.
+// Without provenance it writes the bare SSZ encoded unsafe head, which conductors that do not track provenance can read.
+func encodeUnsafeState(w io.Writer, unsafeHead *eth.ExecutionPayloadEnvelope, history []BlockProvenance) error {
+	var payload bytes.Buffer
+	if _, err := unsafeHead.MarshalSSZ(&payload); err != nil {
+		return fmt.Errorf("error marshalling unsafe head: %w", err)
+	}
+	if len(history) == 0 {
+		_, err := w.Write(payload.Bytes())
+		return err
+	}
+	provenance, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("error marshalling provenance: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.Write(unsafeStateMagic)
+	buf.WriteByte(unsafeStateV1)
+	_ = binary.Write(&buf, binary.BigEndian, uint32(payload.Len()))
+	buf.Write(payload.Bytes())
+	buf.Write(provenance)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// decodeUnsafeState decodes unsafe state written by encodeUnsafeState.
+func decodeUnsafeState(data []byte) (*eth.ExecutionPayloadEnvelope, []BlockProvenance, error) {
+	unsafeHead := &eth.ExecutionPayloadEnvelope{}
+	if !bytes.HasPrefix(data, unsafeStateMagic) {
+		if err := unsafeHead.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
+			return nil, nil, err
+		}
+		return unsafeHead, nil, nil
+	}
+
+	data = data[len(unsafeStateMagic):]
+	if len(data) < 1 {
+		return nil, nil, errors.New("unsafe state without version")
+	}
+	if version := data[0]; version != unsafeStateV1 {
+		return nil, nil, fmt.Errorf("unsupported unsafe state version: %d", version)
+	}
+	data = data[1:]
+	if len(data) < 4 {
+		return nil, nil, fmt.Errorf("unsafe state too short: %d bytes", len(data))
+	}
+	n := binary.BigEndian.Uint32(data)
+	data = data[4:]
+	if uint64(len(data)) < uint64(n) {
+		return nil, nil, fmt.Errorf("unsafe head length %d exceeds unsafe state", n)
+	}
+	if err := unsafeHead.UnmarshalSSZ(n, bytes.NewReader(data[:n])); err != nil {
+		return nil, nil, err
+	}
+	var history []BlockProvenance
+	if err := json.Unmarshal(data[n:], &history); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshalling provenance: %w", err)
+	}
+	return unsafeHead, history, nil
+}
@@ -0,0 +1,74 @@
+package consensus
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendProvenance(t *testing.T) {
+	var history []BlockProvenance
+	history = appendProvenance(history, createPayloadEnvelope(1), nil)
+	require.Empty(t, history, "unknown provenance is not recorded")
+
+	for i := uint64(1); i <= provenanceHistory+10; i++ {
+		history = appendProvenance(history, createPayloadEnvelope(i), &PayloadMetadata{Source: PayloadSourceLocal})
+	}
+	require.Len(t, history, provenanceHistory)
+	require.Nil(t, findProvenance(history, 10))
+	require.NotNil(t, findProvenance(history, 11))
+	require.NotNil(t, findProvenance(history, provenanceHistory+10))
+
+	// a reorged block at the same height takes precedence
+	reorged := createPayloadEnvelope(provenanceHistory + 10)
+	reorged.ExecutionPayload.BlockHash[0] = 1
+	history = appendProvenance(history, reorged, &PayloadMetadata{Source: PayloadSourceBuilder})
+	require.Equal(t, PayloadSourceBuilder, findProvenance(history, provenanceHistory+10).Source)
+}
+
+func TestEncodeDecodeUnsafeState(t *testing.T) {
+	payload := createPayloadEnvelope(5)
+	history := appendProvenance(nil, payload, &PayloadMetadata{Source: PayloadSourceBuilder, BuilderID: "builder-1"})
+
+	var buf bytes.Buffer
+	require.NoError(t, encodeUnsafeState(&buf, payload, history))
+	decoded, decodedHistory, err := decodeUnsafeState(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, payload.ExecutionPayload.BlockNumber, decoded.ExecutionPayload.BlockNumber)
+	require.Equal(t, history, decodedHistory)
+
+	t.Run("Legacy", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := payload.MarshalSSZ(&buf)
+		require.NoError(t, err)
+		decoded, decodedHistory, err := decodeUnsafeState(buf.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, payload.ExecutionPayload.BlockNumber, decoded.ExecutionPayload.BlockNumber)
+		require.Nil(t, decodedHistory)
+	})
+
+	t.Run("WithoutProvenance", func(t *testing.T) {
+		var buf, legacy bytes.Buffer
+		require.NoError(t, encodeUnsafeState(&buf, payload, nil))
+		_, err := payload.MarshalSSZ(&legacy)
+		require.NoError(t, err)
+		require.Equal(t, legacy.Bytes(), buf.Bytes(), "must be readable by conductors that do not track provenance")
+	})
+
+	t.Run("UnknownVersion", func(t *testing.T) {
+		data := bytes.Clone(buf.Bytes())
+		data[len(unsafeStateMagic)] = unsafeStateV1 + 1
+		_, _, err := decodeUnsafeState(data)
+		require.ErrorContains(t, err, "unsupported unsafe state version")
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		_, _, err := decodeUnsafeState(buf.Bytes()[:len(unsafeStateMagic)])
+		require.ErrorContains(t, err, "without version")
+		_, _, err = decodeUnsafeState(buf.Bytes()[:len(unsafeStateMagic)+3])
+		require.ErrorContains(t, err, "too short")
+		_, _, err = decodeUnsafeState(buf.Bytes()[:len(unsafeStateMagic)+9])
+		require.ErrorContains(t, err, "exceeds unsafe state")
+	})
+}
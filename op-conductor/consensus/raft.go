@@ -2,13 +2,16 @@ package consensus
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/raft"
 	boltdb "github.com/hashicorp/raft-boltdb"
 	"github.com/pkg/errors"
@@ -26,18 +29,46 @@ type RaftConsensus struct {
 	log       log.Logger
 	rollupCfg *rollup.Config
 
-	serverID raft.ServerID
-	r        *raft.Raft
+	serverID   raft.ServerID
+	serverAddr raft.ServerAddress
+	r          *raft.Raft
+
+	logStore    *boltdb.BoltStore
+	stableStore *boltdb.BoltStore
+	transport   *raft.NetworkTransport
 
 	unsafeTracker *unsafeHeadTracker
 }
 
+// RaftConsensusConfig is the configuration of the raft consensus.
+type RaftConsensusConfig struct {
+	ServerID   string
+	ServerAddr string
+	StorageDir string
+	Bootstrap  bool
+	RollupCfg  *rollup.Config
+
+	// SnapshotInterval is how often raft checks whether to snapshot the unsafe head and compact the log.
+	SnapshotInterval time.Duration
+	// SnapshotThreshold is the number of log entries since the last snapshot required to take a new snapshot.
+	SnapshotThreshold uint64
+	// TrailingLogs is the number of log entries retained after a snapshot, so followers that are only slightly behind
+	// can catch up from the log instead of installing the snapshot.
+	TrailingLogs uint64
+	// SnapshotRetain is the number of snapshots retained on disk.
+	SnapshotRetain int
+}
+
 // NewRaftConsensus creates a new RaftConsensus instance.
-func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, bootstrap bool, rollupCfg *rollup.Config) (*RaftConsensus, error) {
+func NewRaftConsensus(log log.Logger, cfg *RaftConsensusConfig) (*RaftConsensus, error) {
 	rc := raft.DefaultConfig()
-	rc.LocalID = raft.ServerID(serverID)
+	rc.LocalID = raft.ServerID(cfg.ServerID)
+	rc.SnapshotInterval = cfg.SnapshotInterval
+	rc.SnapshotThreshold = cfg.SnapshotThreshold
+	rc.TrailingLogs = cfg.TrailingLogs
 
-	baseDir := filepath.Join(storageDir, serverID)
+	serverID, serverAddr := cfg.ServerID, cfg.ServerAddr
+	baseDir := filepath.Join(cfg.StorageDir, serverID)
 	if _, err := os.Stat(baseDir); os.IsNotExist(err) {
 		if err := os.MkdirAll(baseDir, 0o755); err != nil {
 			return nil, fmt.Errorf("error creating storage dir: %w", err)
@@ -57,7 +88,7 @@ func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, b
 		return nil, fmt.Errorf(`boltdb.NewBoltStore(%q): %w`, stableStorePath, err)
 	}
 
-	snapshotStore, err := raft.NewFileSnapshotStoreWithLogger(baseDir, 1, rc.Logger)
+	snapshotStore, err := raft.NewFileSnapshotStoreWithLogger(baseDir, cfg.SnapshotRetain, rc.Logger)
 	if err != nil {
 		return nil, fmt.Errorf(`raft.NewFileSnapshotStore(%q): %w`, baseDir, err)
 	}
@@ -83,37 +114,32 @@ func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, b
 		return nil, errors.Wrap(err, "failed to create raft")
 	}
 
+	cons := &RaftConsensus{
+		log:           log,
+		r:             r,
+		logStore:      logStore,
+		stableStore:   stableStore,
+		transport:     transport,
+		serverID:      raft.ServerID(serverID),
+		serverAddr:    raft.ServerAddress(serverAddr),
+		unsafeTracker: fsm,
+		rollupCfg:     cfg.RollupCfg,
+	}
+
 	// If bootstrap = true, start raft in bootstrap mode, this will allow the current node to elect itself as leader when there's no other participants
 	// and allow other nodes to join the cluster.
-	if bootstrap {
-		cfg := raft.Configuration{
-			Servers: []raft.Server{
-				{
-					ID:       rc.LocalID,
-					Address:  raft.ServerAddress(serverAddr),
-					Suffrage: raft.Voter,
-				},
-			},
-		}
-
-		f := r.BootstrapCluster(cfg)
-		if err := f.Error(); err != nil {
+	if cfg.Bootstrap {
+		if err := cons.Bootstrap(); err != nil {
 			return nil, errors.Wrap(err, "failed to bootstrap raft cluster")
 		}
 	}
 
-	return &RaftConsensus{
-		log:           log,
-		r:             r,
-		serverID:      raft.ServerID(serverID),
-		unsafeTracker: fsm,
-		rollupCfg:     rollupCfg,
-	}, nil
+	return cons, nil
 }
 
 // AddNonVoter implements Consensus, it tries to add a non-voting member into the cluster.
 func (rc *RaftConsensus) AddNonVoter(id string, addr string, version uint64) error {
-	if err := rc.r.AddNonvoter(raft.ServerID(id), raft.ServerAddress(addr), version, defaultTimeout).Error(); err != nil {
+	if err := raftErr(rc.r.AddNonvoter(raft.ServerID(id), raft.ServerAddress(addr), version, defaultTimeout).Error()); err != nil {
 		rc.log.Error("failed to add non-voter", "id", id, "addr", addr, "version", version, "err", err)
 		return err
 	}
@@ -122,7 +148,7 @@ func (rc *RaftConsensus) AddNonVoter(id string, addr string, version uint64) err
 
 // AddVoter implements Consensus, it tries to add a voting member into the cluster.
 func (rc *RaftConsensus) AddVoter(id string, addr string, version uint64) error {
-	if err := rc.r.AddVoter(raft.ServerID(id), raft.ServerAddress(addr), version, defaultTimeout).Error(); err != nil {
+	if err := raftErr(rc.r.AddVoter(raft.ServerID(id), raft.ServerAddress(addr), version, defaultTimeout).Error()); err != nil {
 		rc.log.Error("failed to add voter", "id", id, "addr", addr, "version", version, "err", err)
 		return err
 	}
@@ -131,7 +157,7 @@ func (rc *RaftConsensus) AddVoter(id string, addr string, version uint64) error
 
 // DemoteVoter implements Consensus, it tries to demote a voting member into a non-voting member in the cluster.
 func (rc *RaftConsensus) DemoteVoter(id string, version uint64) error {
-	if err := rc.r.DemoteVoter(raft.ServerID(id), version, defaultTimeout).Error(); err != nil {
+	if err := raftErr(rc.r.DemoteVoter(raft.ServerID(id), version, defaultTimeout).Error()); err != nil {
 		rc.log.Error("failed to demote voter", "id", id, "version", version, "err", err)
 		return err
 	}
@@ -140,7 +166,7 @@ func (rc *RaftConsensus) DemoteVoter(id string, version uint64) error {
 
 // RemoveServer implements Consensus, it tries to remove a member (both voter or non-voter) from the cluster, if leader is being removed, it will cause a new leader election.
 func (rc *RaftConsensus) RemoveServer(id string, version uint64) error {
-	if err := rc.r.RemoveServer(raft.ServerID(id), version, defaultTimeout).Error(); err != nil {
+	if err := raftErr(rc.r.RemoveServer(raft.ServerID(id), version, defaultTimeout).Error()); err != nil {
 		rc.log.Error("failed to remove voter", "id", id, "version", version, "err", err)
 		return err
 	}
@@ -188,7 +214,7 @@ func (rc *RaftConsensus) TransferLeader() error {
 
 // TransferLeaderTo implements Consensus, it triggers leadership transfer to a specific member in the cluster.
 func (rc *RaftConsensus) TransferLeaderTo(id string, addr string) error {
-	if err := rc.r.LeadershipTransferToServer(raft.ServerID(id), raft.ServerAddress(addr)).Error(); err != nil {
+	if err := raftErr(rc.r.LeadershipTransferToServer(raft.ServerID(id), raft.ServerAddress(addr)).Error()); err != nil {
 		rc.log.Error("failed to transfer leadership to server", "id", id, "addr", addr, "err", err)
 		return err
 	}
@@ -201,11 +227,23 @@ func (rc *RaftConsensus) Shutdown() error {
 		rc.log.Error("failed to shutdown raft", "err", err)
 		return err
 	}
-	return nil
+
+	// Release the stores and transport, so the storage directory can be reopened.
+	var result *multierror.Error
+	if err := rc.transport.Close(); err != nil {
+		result = multierror.Append(result, errors.Wrap(err, "failed to close raft transport"))
+	}
+	if err := rc.logStore.Close(); err != nil {
+		result = multierror.Append(result, errors.Wrap(err, "failed to close raft log store"))
+	}
+	if err := rc.stableStore.Close(); err != nil {
+		result = multierror.Append(result, errors.Wrap(err, "failed to close raft stable store"))
+	}
+	return result.ErrorOrNil()
 }
 
 // CommitUnsafePayload implements Consensus, it commits latest unsafe payload to the cluster FSM in a strongly consistent fashion.
-func (rc *RaftConsensus) CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope) error {
+func (rc *RaftConsensus) CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope, metadata *PayloadMetadata) error {
 	rc.log.Debug("committing unsafe payload", "number", uint64(payload.ExecutionPayload.BlockNumber), "hash", payload.ExecutionPayload.BlockHash.Hex())
 
 	var buf bytes.Buffer
@@ -213,8 +251,17 @@ func (rc *RaftConsensus) CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelo
 		return errors.Wrap(err, "failed to marshal payload envelope")
 	}
 
-	f := rc.r.Apply(buf.Bytes(), defaultTimeout)
-	if err := f.Error(); err != nil {
+	entry := raft.Log{Data: buf.Bytes()}
+	if metadata != nil {
+		ext, err := json.Marshal(metadata)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal payload metadata")
+		}
+		entry.Extensions = ext
+	}
+
+	f := rc.r.ApplyLog(entry, defaultTimeout)
+	if err := raftErr(f.Error()); err != nil {
 		return errors.Wrap(err, "failed to apply payload envelope")
 	}
 	rc.log.Debug("unsafe payload committed", "number", uint64(payload.ExecutionPayload.BlockNumber), "hash", payload.ExecutionPayload.BlockHash.Hex())
@@ -224,13 +271,62 @@ func (rc *RaftConsensus) CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelo
 
 // LatestUnsafePayload implements Consensus, it returns the latest unsafe payload from FSM in a strongly consistent fashion.
 func (rc *RaftConsensus) LatestUnsafePayload() (*eth.ExecutionPayloadEnvelope, error) {
-	if err := rc.r.Barrier(defaultTimeout).Error(); err != nil {
+	if err := raftErr(rc.r.Barrier(defaultTimeout).Error()); err != nil {
 		return nil, errors.Wrap(err, "failed to apply barrier")
 	}
 
 	return rc.unsafeTracker.UnsafeHead(), nil
 }
 
+// raftErr maps the errors of the raft library to the errors of the Consensus interface,
+// so callers can handle them regardless of the consensus backend.
+func raftErr(err error) error {
+	if errors.Is(err, raft.ErrNotLeader) {
+		return ErrNotLeader
+	}
+	return err
+}
+
+// LocalUnsafePayload implements Consensus, it returns the latest unsafe payload applied to the local FSM.
+func (rc *RaftConsensus) LocalUnsafePayload() (*eth.ExecutionPayloadEnvelope, error) {
+	return rc.unsafeTracker.UnsafeHead(), nil
+}
+
+// ReplicationIndexes implements Consensus. Followers learn the commit index of the leader from its log replication
+// requests, so the commit index lags behind the leader by at most one heartbeat.
+func (rc *RaftConsensus) ReplicationIndexes() (uint64, uint64, error) {
+	commitIndex, err := strconv.ParseUint(rc.r.Stats()["commit_index"], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to parse commit index")
+	}
+	return commitIndex, rc.r.AppliedIndex(), nil
+}
+
+// PayloadProvenance implements Consensus, it returns the provenance of a recent block from the local FSM.
+func (rc *RaftConsensus) PayloadProvenance(number uint64) (*BlockProvenance, error) {
+	return rc.unsafeTracker.Provenance(number), nil
+}
+
+// Bootstrap implements Consensus, it bootstraps a new cluster with this server as the only voter.
+func (rc *RaftConsensus) Bootstrap() error {
+	cfg := raft.Configuration{
+		Servers: []raft.Server{
+			{
+				ID:       rc.serverID,
+				Address:  rc.serverAddr,
+				Suffrage: raft.Voter,
+			},
+		},
+	}
+	if err := rc.r.BootstrapCluster(cfg).Error(); errors.Is(err, raft.ErrCantBootstrap) {
+		return ErrCantBootstrap
+	} else if err != nil {
+		rc.log.Error("failed to bootstrap raft cluster", "err", err)
+		return err
+	}
+	return nil
+}
+
 // ClusterMembership implements Consensus, it returns the current cluster membership configuration.
 func (rc *RaftConsensus) ClusterMembership() (*ClusterMembership, error) {
 	var future raft.ConfigurationFuture
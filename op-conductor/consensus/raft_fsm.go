@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"sync"
@@ -19,6 +20,7 @@ type unsafeHeadTracker struct {
 	log        log.Logger
 	mtx        sync.RWMutex
 	unsafeHead *eth.ExecutionPayloadEnvelope
+	provenance []BlockProvenance
 }
 
 func NewUnsafeHeadTracker(log log.Logger) *unsafeHeadTracker {
@@ -28,6 +30,8 @@ func NewUnsafeHeadTracker(log log.Logger) *unsafeHeadTracker {
 }
 
 // Apply implements raft.FSM, it applies the latest change (latest unsafe head payload) to FSM.
+// The provenance of the payload, if supplied, is carried in the log extensions so that the log data remains readable
+// by servers that do not track provenance.
 func (t *unsafeHeadTracker) Apply(l *raft.Log) interface{} {
 	if l.Data == nil || len(l.Data) == 0 {
 		return fmt.Errorf("log data is nil or empty")
@@ -38,12 +42,21 @@ func (t *unsafeHeadTracker) Apply(l *raft.Log) interface{} {
 		return err
 	}
 
+	var metadata *PayloadMetadata
+	if len(l.Extensions) > 0 {
+		metadata = &PayloadMetadata{}
+		if err := json.Unmarshal(l.Extensions, metadata); err != nil {
+			return fmt.Errorf("error unmarshalling payload metadata: %w", err)
+		}
+	}
+
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 	t.log.Debug("applying new unsafe head", "number", uint64(data.ExecutionPayload.BlockNumber), "hash", data.ExecutionPayload.BlockHash.Hex())
 	if t.unsafeHead == nil || t.unsafeHead.ExecutionPayload.BlockNumber < data.ExecutionPayload.BlockNumber {
 		t.unsafeHead = data
 	}
+	t.provenance = appendProvenance(t.provenance, data, metadata)
 
 	return nil
 }
@@ -57,14 +70,19 @@ func (t *unsafeHeadTracker) Restore(snapshot io.ReadCloser) error {
 		return fmt.Errorf("error reading snapshot data: %w", err)
 	}
 
-	data := &eth.ExecutionPayloadEnvelope{}
-	if err := data.UnmarshalSSZ(uint32(n), bytes.NewReader(buf.Bytes())); err != nil {
-		return fmt.Errorf("error unmarshalling snapshot: %w", err)
+	// An empty snapshot is taken before any unsafe payload is committed, e.g. when only the membership changed.
+	var data *eth.ExecutionPayloadEnvelope
+	var provenance []BlockProvenance
+	if n > 0 {
+		if data, provenance, err = decodeUnsafeState(buf.Bytes()); err != nil {
+			return fmt.Errorf("error unmarshalling snapshot: %w", err)
+		}
 	}
 
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 	t.unsafeHead = data
+	t.provenance = provenance
 	return nil
 }
 
@@ -74,7 +92,9 @@ func (t *unsafeHeadTracker) Snapshot() (raft.FSMSnapshot, error) {
 	defer t.mtx.RUnlock()
 
 	return &snapshot{
+		log:        t.log,
 		unsafeHead: t.unsafeHead,
+		provenance: t.provenance,
 	}, nil
 }
 
@@ -86,16 +106,28 @@ func (t *unsafeHeadTracker) UnsafeHead() *eth.ExecutionPayloadEnvelope {
 	return t.unsafeHead
 }
 
+// Provenance returns the provenance of a recently committed block, or nil if it is not known.
+func (t *unsafeHeadTracker) Provenance(number uint64) *BlockProvenance {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	return findProvenance(t.provenance, number)
+}
+
 var _ raft.FSMSnapshot = (*snapshot)(nil)
 
 type snapshot struct {
 	log        log.Logger
 	unsafeHead *eth.ExecutionPayloadEnvelope
+	provenance []BlockProvenance
 }
 
 // Persist implements raft.FSMSnapshot, it writes the snapshot to the given sink.
 func (s *snapshot) Persist(sink raft.SnapshotSink) error {
-	if _, err := s.unsafeHead.MarshalSSZ(sink); err != nil {
+	if s.unsafeHead == nil {
+		return sink.Close()
+	}
+	if err := encodeUnsafeState(sink, s.unsafeHead, s.provenance); err != nil {
 		if cerr := sink.Cancel(); cerr != nil {
 			s.log.Error("error cancelling snapshot sink", "error", cerr)
 		}
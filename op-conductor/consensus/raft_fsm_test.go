@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"io"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -69,8 +70,56 @@ func TestUnsafeHeadTracker(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, hexutil.Uint64(333), tracker.unsafeHead.ExecutionPayload.BlockNumber)
 	})
+
+	t.Run("SnapshotAndRestoreProvenance", func(t *testing.T) {
+		data := createPayloadEnvelope(444)
+		var buf bytes.Buffer
+		_, err := data.MarshalSSZ(&buf)
+		require.NoError(t, err)
+
+		l := raft.Log{Data: buf.Bytes(), Extensions: []byte(`{"source":"builder","builderID":"builder-1","bidValue":"0x64"}`)}
+		require.Nil(t, tracker.Apply(&l))
+		want := &BlockProvenance{
+			Number:          444,
+			Hash:            data.ExecutionPayload.BlockHash,
+			PayloadMetadata: PayloadMetadata{Source: PayloadSourceBuilder, BuilderID: "builder-1", BidValue: (*hexutil.Big)(big.NewInt(100))},
+		}
+		require.Equal(t, want, tracker.Provenance(444))
+		require.Nil(t, tracker.Provenance(333), "no provenance supplied")
+
+		snapshot, err := tracker.Snapshot()
+		require.NoError(t, err)
+		sink := &bufferSnapshotSink{}
+		require.NoError(t, snapshot.Persist(sink))
+
+		restored := &unsafeHeadTracker{log: testlog.Logger(t, log.LevelDebug)}
+		require.NoError(t, restored.Restore(io.NopCloser(&sink.Buffer)))
+		require.Equal(t, hexutil.Uint64(444), restored.UnsafeHead().ExecutionPayload.BlockNumber)
+		require.Equal(t, want, restored.Provenance(444))
+	})
+
+	t.Run("SnapshotAndRestoreEmpty", func(t *testing.T) {
+		empty := &unsafeHeadTracker{log: testlog.Logger(t, log.LevelDebug)}
+		snapshot, err := empty.Snapshot()
+		require.NoError(t, err)
+
+		sink := &bufferSnapshotSink{}
+		require.NoError(t, snapshot.Persist(sink))
+		require.Zero(t, sink.Len())
+
+		require.NoError(t, tracker.Restore(io.NopCloser(&sink.Buffer)))
+		require.Nil(t, tracker.unsafeHead)
+	})
+}
+
+type bufferSnapshotSink struct {
+	bytes.Buffer
 }
 
+func (s *bufferSnapshotSink) ID() string    { return "buffer" }
+func (s *bufferSnapshotSink) Cancel() error { return nil }
+func (s *bufferSnapshotSink) Close() error  { return nil }
+
 type mockReadCloser struct {
 	currentPosition int
 	data            *eth.ExecutionPayloadEnvelope
@@ -30,7 +30,19 @@ func TestCommitAndRead(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	cons, err := NewRaftConsensus(log, serverID, serverAddr, storageDir, bootstrap, rollupCfg)
+	raftConsensusConfig := &RaftConsensusConfig{
+		ServerID:          serverID,
+		ServerAddr:        serverAddr,
+		StorageDir:        storageDir,
+		Bootstrap:         bootstrap,
+		RollupCfg:         rollupCfg,
+		SnapshotInterval:  120 * time.Second,
+		SnapshotThreshold: 8192,
+		TrailingLogs:      10240,
+		SnapshotRetain:    1,
+	}
+
+	cons, err := NewRaftConsensus(log, raftConsensusConfig)
 	require.NoError(t, err)
 
 	// wait till it became leader
@@ -46,7 +58,7 @@ func TestCommitAndRead(t *testing.T) {
 		},
 	}
 
-	err = cons.CommitUnsafePayload(payload)
+	err = cons.CommitUnsafePayload(payload, nil)
 	// ExecutionPayloadEnvelope is expected to fail when unmarshalling a blockV1
 	require.Error(t, err)
 
@@ -66,11 +78,128 @@ func TestCommitAndRead(t *testing.T) {
 		},
 	}
 
-	err = cons.CommitUnsafePayload(payload)
+	err = cons.CommitUnsafePayload(payload, nil)
 	// ExecutionPayloadEnvelope is expected to succeed when unmarshalling a blockV3
 	require.NoError(t, err)
 
 	unsafeHead, err := cons.LatestUnsafePayload()
 	require.NoError(t, err)
 	require.Equal(t, payload, unsafeHead)
+
+	localHead, err := cons.LocalUnsafePayload()
+	require.NoError(t, err)
+	require.Equal(t, payload, localHead)
+
+	commitIndex, appliedIndex, err := cons.ReplicationIndexes()
+	require.NoError(t, err)
+	require.NotZero(t, commitIndex)
+	require.Equal(t, commitIndex, appliedIndex)
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	log := testlog.Logger(t, log.LevelInfo)
+	now := uint64(time.Now().Unix())
+	cfg := &RaftConsensusConfig{
+		ServerID:          "SequencerA",
+		ServerAddr:        "127.0.0.1:0",
+		StorageDir:        t.TempDir(),
+		Bootstrap:         true,
+		RollupCfg:         &rollup.Config{CanyonTime: &now},
+		SnapshotInterval:  100 * time.Millisecond,
+		SnapshotThreshold: 2,
+		TrailingLogs:      1,
+		SnapshotRetain:    1,
+	}
+
+	cons, err := NewRaftConsensus(log, cfg)
+	require.NoError(t, err)
+	<-cons.LeaderCh()
+
+	var payload *eth.ExecutionPayloadEnvelope
+	for i := uint64(1); i <= 5; i++ {
+		payload = createPayloadEnvelope(i)
+		require.NoError(t, cons.CommitUnsafePayload(payload, &PayloadMetadata{Source: PayloadSourceBuilder, BuilderID: "builder-1"}))
+	}
+
+	// wait for the log to be snapshotted and compacted
+	require.Eventually(t, func() bool {
+		return cons.r.Stats()["last_snapshot_index"] != "0"
+	}, 10*time.Second, 100*time.Millisecond)
+	require.NoError(t, cons.Shutdown())
+
+	// restart from the same storage directory, which restores the unsafe head from the snapshot
+	cfg.Bootstrap = false
+	cons, err = NewRaftConsensus(log, cfg)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, cons.Shutdown())
+	}()
+	<-cons.LeaderCh()
+
+	unsafeHead, err := cons.LatestUnsafePayload()
+	require.NoError(t, err)
+	require.Equal(t, payload.ExecutionPayload.BlockNumber, unsafeHead.ExecutionPayload.BlockNumber)
+
+	provenance, err := cons.PayloadProvenance(uint64(payload.ExecutionPayload.BlockNumber))
+	require.NoError(t, err)
+	require.Equal(t, "builder-1", provenance.BuilderID)
+}
+
+func TestBootstrap(t *testing.T) {
+	log := testlog.Logger(t, log.LevelInfo)
+	now := uint64(time.Now().Unix())
+	cons, err := NewRaftConsensus(log, &RaftConsensusConfig{
+		ServerID:          "SequencerA",
+		ServerAddr:        "127.0.0.1:0",
+		StorageDir:        t.TempDir(),
+		Bootstrap:         false,
+		RollupCfg:         &rollup.Config{CanyonTime: &now},
+		SnapshotInterval:  120 * time.Second,
+		SnapshotThreshold: 8192,
+		TrailingLogs:      10240,
+		SnapshotRetain:    1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, cons.Shutdown())
+	}()
+
+	require.NoError(t, cons.Bootstrap())
+	<-cons.LeaderCh()
+	require.ErrorIs(t, cons.Bootstrap(), ErrCantBootstrap)
+
+	membership, err := cons.ClusterMembership()
+	require.NoError(t, err)
+	require.Equal(t, []ServerInfo{{ID: "SequencerA", Addr: "127.0.0.1:0", Suffrage: Voter}}, membership.Servers)
+}
+
+func TestNotLeader(t *testing.T) {
+	log := testlog.Logger(t, log.LevelInfo)
+	now := uint64(time.Now().Unix())
+	// a server that is not bootstrapped has no cluster to lead
+	cons, err := NewRaftConsensus(log, &RaftConsensusConfig{
+		ServerID:          "SequencerA",
+		ServerAddr:        "127.0.0.1:0",
+		StorageDir:        t.TempDir(),
+		Bootstrap:         false,
+		RollupCfg:         &rollup.Config{CanyonTime: &now},
+		SnapshotInterval:  120 * time.Second,
+		SnapshotThreshold: 8192,
+		TrailingLogs:      10240,
+		SnapshotRetain:    1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, cons.Shutdown())
+	}()
+
+	require.ErrorIs(t, cons.CommitUnsafePayload(createPayloadEnvelope(1), nil), ErrNotLeader)
+	_, err = cons.LatestUnsafePayload()
+	require.ErrorIs(t, err, ErrNotLeader)
+	require.ErrorIs(t, cons.AddVoter("SequencerB", "127.0.0.1:0", 0), ErrNotLeader)
+	require.ErrorIs(t, cons.AddNonVoter("SequencerB", "127.0.0.1:0", 0), ErrNotLeader)
+	require.ErrorIs(t, cons.DemoteVoter("SequencerA", 0), ErrNotLeader)
+	require.ErrorIs(t, cons.RemoveServer("SequencerA", 0), ErrNotLeader)
+	require.ErrorIs(t, cons.TransferLeaderTo("SequencerB", "127.0.0.1:0"), ErrNotLeader)
+	require.NoError(t, cons.TransferLeader(), "no-op on followers")
 }
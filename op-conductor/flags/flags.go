@@ -2,6 +2,7 @@ package flags
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
@@ -16,6 +17,72 @@ import (
 const EnvVarPrefix = "OP_CONDUCTOR"
 
 var (
+	ConsensusBackend = &cli.StringFlag{
+		Name:    "consensus.backend",
+		Usage:   "Consensus backend used for leader election. Options: raft, etcd",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "CONSENSUS_BACKEND"),
+		Value:   "raft",
+	}
+	ConsensusLeaseTTL = &cli.DurationFlag{
+		Name:    "consensus.lease-ttl",
+		Usage:   "Duration the leadership lease is held without being renewed, for lease based consensus backends",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "CONSENSUS_LEASE_TTL"),
+		Value:   10 * time.Second,
+	}
+	CommitMode = &cli.StringFlag{
+		Name:    "consensus.commit-mode",
+		Usage:   "How unsafe payloads are committed. Options: sync (wait for quorum), async (return once queued by the leader, replicate in the background)",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "CONSENSUS_COMMIT_MODE"),
+		Value:   "sync",
+	}
+	CommitLatencyBudget = &cli.DurationFlag{
+		Name:    "consensus.commit-latency-budget",
+		Usage:   "Commit latency above which an unsafe payload commit is reported as over budget, 0 to disable",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "CONSENSUS_COMMIT_LATENCY_BUDGET"),
+		Value:   500 * time.Millisecond,
+	}
+	RecordProvenance = &cli.BoolFlag{
+		Name:    "consensus.record-provenance",
+		Usage:   "Record the provenance of unsafe payloads supplied by the sequencer. Changes the raft snapshot format, so only enable it once all conductors of the cluster are upgraded",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "CONSENSUS_RECORD_PROVENANCE"),
+		Value:   false,
+	}
+	EtcdEndpoint = &cli.StringFlag{
+		Name:    "etcd.endpoint",
+		Usage:   "HTTP or HTTPS endpoint of the etcd cluster used by the etcd consensus backend",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ETCD_ENDPOINT"),
+	}
+	EtcdTLSCaCert = &cli.StringFlag{
+		Name:    "etcd.tls.ca",
+		Usage:   "Path of the CA certificate that verifies etcd, the system roots are used if not set",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ETCD_TLS_CA"),
+	}
+	EtcdTLSCert = &cli.StringFlag{
+		Name:    "etcd.tls.cert",
+		Usage:   "Path of the client certificate presented to etcd",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ETCD_TLS_CERT"),
+	}
+	EtcdTLSKey = &cli.StringFlag{
+		Name:    "etcd.tls.key",
+		Usage:   "Path of the key of the client certificate presented to etcd",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ETCD_TLS_KEY"),
+	}
+	EtcdUsername = &cli.StringFlag{
+		Name:    "etcd.username",
+		Usage:   "Username to authenticate to etcd with, if etcd has authentication enabled",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ETCD_USERNAME"),
+	}
+	EtcdPassword = &cli.StringFlag{
+		Name:    "etcd.password",
+		Usage:   "Password to authenticate to etcd with",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ETCD_PASSWORD"),
+	}
+	EtcdKeyPrefix = &cli.StringFlag{
+		Name:    "etcd.key-prefix",
+		Usage:   "Prefix of the keys used by the etcd consensus backend",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ETCD_KEY_PREFIX"),
+		Value:   "/op-conductor/",
+	}
 	ConsensusAddr = &cli.StringFlag{
 		Name:    "consensus.addr",
 		Usage:   "Address to listen for consensus connections",
@@ -36,19 +103,48 @@ var (
 	}
 	RaftServerID = &cli.StringFlag{
 		Name:    "raft.server.id",
-		Usage:   "Unique ID for this server used by raft consensus",
+		Usage:   "Unique ID for this server used by consensus",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_SERVER_ID"),
 	}
 	RaftStorageDir = &cli.StringFlag{
 		Name:    "raft.storage.dir",
-		Usage:   "Directory to store raft data",
+		Usage:   "Directory to store raft data, required by the raft consensus backend",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_STORAGE_DIR"),
 	}
+	RaftSnapshotInterval = &cli.DurationFlag{
+		Name:    "raft.snapshot-interval",
+		Usage:   "Interval to check whether to snapshot the unsafe head and compact the raft log",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_SNAPSHOT_INTERVAL"),
+		Value:   120 * time.Second,
+	}
+	RaftSnapshotThreshold = &cli.Uint64Flag{
+		Name:    "raft.snapshot-threshold",
+		Usage:   "Number of raft log entries since the last snapshot required to take a new snapshot",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_SNAPSHOT_THRESHOLD"),
+		Value:   8192,
+	}
+	RaftTrailingLogs = &cli.Uint64Flag{
+		Name:    "raft.trailing-logs",
+		Usage:   "Number of raft log entries to retain after a snapshot, so slightly lagging followers can catch up from the log",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_TRAILING_LOGS"),
+		Value:   10240,
+	}
+	RaftSnapshotRetain = &cli.IntFlag{
+		Name:    "raft.snapshot-retain",
+		Usage:   "Number of raft snapshots to retain on disk",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_SNAPSHOT_RETAIN"),
+		Value:   1,
+	}
 	NodeRPC = &cli.StringFlag{
 		Name:    "node.rpc",
 		Usage:   "HTTP provider URL for op-node",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "NODE_RPC"),
 	}
+	NodeAdminAPIKey = &cli.StringFlag{
+		Name:    "node.admin-api-key",
+		Usage:   "API key to call the admin RPC methods of op-node with, if op-node authenticates admin RPC methods with API keys",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "NODE_ADMIN_API_KEY"),
+	}
 	ExecutionRPC = &cli.StringFlag{
 		Name:    "execution.rpc",
 		Usage:   "HTTP provider URL for execution layer",
@@ -81,6 +177,42 @@ var (
 		Usage:   "Minimum number of peers required to be considered healthy",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MIN_PEER_COUNT"),
 	}
+	HealthCheckUnhealthyThreshold = &cli.Uint64Flag{
+		Name:    "healthcheck.unhealthy-threshold",
+		Usage:   "Number of consecutive failed health checks required to consider the sequencer unhealthy",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_UNHEALTHY_THRESHOLD"),
+		Value:   2,
+	}
+	HealthCheckHealthyThreshold = &cli.Uint64Flag{
+		Name:    "healthcheck.healthy-threshold",
+		Usage:   "Number of consecutive passed health checks required to consider an unhealthy sequencer healthy again",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_HEALTHY_THRESHOLD"),
+		Value:   2,
+	}
+	HealthCheckEngineTimeout = &cli.Uint64Flag{
+		Name:    "healthcheck.engine-timeout",
+		Usage:   "Timeout of the execution engine responsiveness probe measured in seconds",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_ENGINE_TIMEOUT"),
+		Value:   5,
+	}
+	HealthCheckBuilderStrict = &cli.BoolFlag{
+		Name:    "healthcheck.builder-strict",
+		Usage:   "Consider the sequencer unhealthy if its block builder is unavailable or fails to build too many blocks, to fail over to a sequencer with a healthy builder. Requires the op-node to be configured with a block builder",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_BUILDER_STRICT"),
+		Value:   false,
+	}
+	HealthCheckMaxBuilderFailureRate = &cli.Float64Flag{
+		Name:    "healthcheck.max-builder-failure-rate",
+		Usage:   "Maximum fraction of recent blocks that were not built by the block builder, if the builder is strict",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MAX_BUILDER_FAILURE_RATE"),
+		Value:   0.5,
+	}
+	Observer = &cli.BoolFlag{
+		Name:    "observer",
+		Usage:   "Run as an observer, a non-voting member that replicates the unsafe payload log without sequencing",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "OBSERVER"),
+		Value:   false,
+	}
 	Paused = &cli.BoolFlag{
 		Name:    "paused",
 		Usage:   "Whether the conductor is paused",
@@ -93,13 +225,44 @@ var (
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RPC_ENABLE_PROXY"),
 		Value:   true,
 	}
+	TransferLeaderMaxUnsafeLag = &cli.Uint64Flag{
+		Name:    "transfer-leader.max-unsafe-lag",
+		Usage:   "Maximum number of blocks the target's unsafe head may be behind the latest unsafe payload to transfer leadership to it with conductor_transferLeaderToHealthyServer",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "TRANSFER_LEADER_MAX_UNSAFE_LAG"),
+		Value:   5,
+	}
+	AdminRPCAddr = &cli.StringFlag{
+		Name:    "admin-rpc.addr",
+		Usage:   "Listen address of the cluster membership admin RPC server",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ADMIN_RPC_ADDR"),
+		Value:   "127.0.0.1",
+	}
+	AdminRPCPort = &cli.IntFlag{
+		Name:    "admin-rpc.port",
+		Usage:   "Listen port of the cluster membership admin RPC server",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ADMIN_RPC_PORT"),
+		Value:   8547,
+	}
+	AdminRPCJWTSecret = &cli.StringFlag{
+		Name: "admin-rpc.jwt-secret",
+		Usage: "Path to a JWT secret file (32 bytes, hex-encoded) to authenticate requests to the cluster membership " +
+			"admin RPC server with. The admin RPC server is disabled if not set. Additional accepted secrets may be " +
+			"listed one per line, and the file is reloaded when it changes.",
+		EnvVars:   opservice.PrefixEnvVar(EnvVarPrefix, "ADMIN_RPC_JWT_SECRET"),
+		TakesFile: true,
+	}
+	PeerRPCURLs = &cli.StringSliceFlag{
+		Name: "cluster-status.peer-rpcs",
+		Usage: "Conductor RPC URLs of the other cluster members as <server-id>=<url>, to report their status on the " +
+			"cluster status endpoint with. Members without a URL are reported as unreachable.",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "CLUSTER_STATUS_PEER_RPCS"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
 	ConsensusAddr,
 	ConsensusPort,
 	RaftServerID,
-	RaftStorageDir,
 	NodeRPC,
 	ExecutionRPC,
 	HealthCheckInterval,
@@ -108,11 +271,40 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	RaftStorageDir,
 	Paused,
+	Observer,
 	RPCEnableProxy,
 	RaftBootstrap,
 	HealthCheckSafeEnabled,
 	HealthCheckSafeInterval,
+	HealthCheckUnhealthyThreshold,
+	HealthCheckHealthyThreshold,
+	HealthCheckEngineTimeout,
+	HealthCheckBuilderStrict,
+	HealthCheckMaxBuilderFailureRate,
+	TransferLeaderMaxUnsafeLag,
+	ConsensusBackend,
+	ConsensusLeaseTTL,
+	CommitMode,
+	CommitLatencyBudget,
+	RecordProvenance,
+	EtcdEndpoint,
+	EtcdKeyPrefix,
+	EtcdTLSCaCert,
+	EtcdTLSCert,
+	EtcdTLSKey,
+	EtcdUsername,
+	EtcdPassword,
+	RaftSnapshotInterval,
+	RaftSnapshotThreshold,
+	RaftTrailingLogs,
+	RaftSnapshotRetain,
+	AdminRPCAddr,
+	AdminRPCPort,
+	AdminRPCJWTSecret,
+	NodeAdminAPIKey,
+	PeerRPCURLs,
 }
 
 func init() {
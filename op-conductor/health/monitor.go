@@ -3,6 +3,7 @@ package health
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -12,13 +13,27 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 var (
-	ErrSequencerNotHealthy     = errors.New("sequencer is not healthy")
-	ErrSequencerConnectionDown = errors.New("cannot connect to sequencer rpc endpoints")
+	ErrSequencerNotHealthy         = errors.New("sequencer is not healthy")
+	ErrSequencerConnectionDown     = errors.New("cannot connect to sequencer rpc endpoints")
+	ErrSequencerEngineUnresponsive = errors.New("sequencer execution engine is not responding")
+	ErrSequencerBuilderUnhealthy   = errors.New("sequencer block builder is not healthy")
 )
 
+// EngineClient is the execution engine of the sequencer, probed to detect an engine that is up but stalled.
+type EngineClient interface {
+	LatestUnsafeBlock(ctx context.Context) (eth.BlockInfo, error)
+}
+
+// BuilderStatusProvider reports the status of the external block builder of the sequencer.
+// The status is nil if the sequencer has no builder.
+type BuilderStatusProvider interface {
+	BuilderStatus(ctx context.Context) (*eth.BuilderStatus, error)
+}
+
 // HealthMonitor defines the interface for monitoring the health of the sequencer.
 //
 //go:generate mockery --name HealthMonitor --output mocks/ --with-expecter=true
@@ -35,20 +50,31 @@ type HealthMonitor interface {
 // interval is the interval between health checks measured in seconds.
 // safeInterval is the interval between safe head progress measured in seconds.
 // minPeerCount is the minimum number of peers required for the sequencer to be healthy.
-func NewSequencerHealthMonitor(log log.Logger, metrics metrics.Metricer, interval, unsafeInterval, safeInterval, minPeerCount uint64, safeEnabled bool, rollupCfg *rollup.Config, node dial.RollupClientInterface, p2p p2p.API) HealthMonitor {
+// unhealthyThreshold and healthyThreshold are the number of consecutive failed or passed health checks required to
+// report a change in health, so a single slow check does not cause leadership to flap between servers.
+// engine is probed for responsiveness within engineTimeout seconds, unless it is nil.
+// builder is checked to be available, with a rate of recent blocks that were not built by the builder
+// of at most maxBuilderFailureRate, unless it is nil.
+func NewSequencerHealthMonitor(log log.Logger, metrics metrics.Metricer, interval, unsafeInterval, safeInterval, minPeerCount, unhealthyThreshold, healthyThreshold uint64, safeEnabled bool, rollupCfg *rollup.Config, node dial.RollupClientInterface, p2p p2p.API, engine EngineClient, engineTimeout uint64, builder BuilderStatusProvider, maxBuilderFailureRate float64) HealthMonitor {
 	return &SequencerHealthMonitor{
-		log:            log,
-		metrics:        metrics,
-		interval:       interval,
-		healthUpdateCh: make(chan error),
-		rollupCfg:      rollupCfg,
-		unsafeInterval: unsafeInterval,
-		safeEnabled:    safeEnabled,
-		safeInterval:   safeInterval,
-		minPeerCount:   minPeerCount,
-		timeProviderFn: currentTimeProvicer,
-		node:           node,
-		p2p:            p2p,
+		log:                   log,
+		metrics:               metrics,
+		interval:              interval,
+		healthUpdateCh:        make(chan error),
+		rollupCfg:             rollupCfg,
+		unsafeInterval:        unsafeInterval,
+		safeEnabled:           safeEnabled,
+		safeInterval:          safeInterval,
+		minPeerCount:          minPeerCount,
+		unhealthyThreshold:    unhealthyThreshold,
+		healthyThreshold:      healthyThreshold,
+		engineTimeout:         engineTimeout,
+		maxBuilderFailureRate: maxBuilderFailureRate,
+		timeProviderFn:        currentTimeProvicer,
+		node:                  node,
+		p2p:                   p2p,
+		engine:                engine,
+		builder:               builder,
 	}
 }
 
@@ -69,10 +95,21 @@ type SequencerHealthMonitor struct {
 	lastSeenUnsafeNum  uint64
 	lastSeenUnsafeTime uint64
 
+	unhealthyThreshold   uint64
+	healthyThreshold     uint64
+	consecutiveFailures  uint64
+	consecutiveSuccesses uint64
+	reportedErr          error
+
+	engineTimeout         uint64
+	maxBuilderFailureRate float64
+
 	timeProviderFn func() uint64
 
-	node dial.RollupClientInterface
-	p2p  p2p.API
+	node    dial.RollupClientInterface
+	p2p     p2p.API
+	engine  EngineClient
+	builder BuilderStatusProvider
 }
 
 var _ HealthMonitor = (*SequencerHealthMonitor)(nil)
@@ -119,6 +156,7 @@ func (hm *SequencerHealthMonitor) loop(ctx context.Context) {
 		case <-ticker.C:
 			err := hm.healthCheck(ctx)
 			hm.metrics.RecordHealthCheck(err == nil, err)
+			err = hm.applyHysteresis(err)
 			// Ensure that we exit cleanly if told to shutdown while still waiting to publish the health update
 			select {
 			case hm.healthUpdateCh <- err:
@@ -130,11 +168,38 @@ func (hm *SequencerHealthMonitor) loop(ctx context.Context) {
 	}
 }
 
-// healthCheck checks the health of the sequencer by 3 criteria:
+// applyHysteresis returns the health to report for the result of a health check. A change in health is only reported
+// once the configured number of consecutive checks agree, otherwise the previously reported health is kept.
+func (hm *SequencerHealthMonitor) applyHysteresis(err error) error {
+	if err != nil {
+		hm.consecutiveFailures++
+		hm.consecutiveSuccesses = 0
+	} else {
+		hm.consecutiveSuccesses++
+		hm.consecutiveFailures = 0
+	}
+
+	switch {
+	case hm.reportedErr == nil && err != nil && hm.consecutiveFailures < max(hm.unhealthyThreshold, 1):
+		hm.log.Warn("health check failed, waiting for more failures before reporting unhealthy",
+			"failures", hm.consecutiveFailures, "threshold", hm.unhealthyThreshold, "err", err)
+		return nil
+	case hm.reportedErr != nil && err == nil && hm.consecutiveSuccesses < max(hm.healthyThreshold, 1):
+		hm.log.Info("health check passed, waiting for more passes before reporting healthy",
+			"successes", hm.consecutiveSuccesses, "threshold", hm.healthyThreshold)
+		return hm.reportedErr
+	}
+	hm.reportedErr = err
+	return err
+}
+
+// healthCheck checks the health of the sequencer by 6 criteria:
 // 1. unsafe head is progressing per block time
 // 2. unsafe head is not too far behind now (measured by unsafeInterval)
 // 3. safe head is progressing every configured batch submission interval
 // 4. peer count is above the configured minimum
+// 5. execution engine responds within the configured timeout
+// 6. block builder is available and builds enough of the recent blocks, if the builder is checked
 func (hm *SequencerHealthMonitor) healthCheck(ctx context.Context) error {
 	status, err := hm.node.SyncStatus(ctx)
 	if err != nil {
@@ -209,10 +274,77 @@ func (hm *SequencerHealthMonitor) healthCheck(ctx context.Context) error {
 		return ErrSequencerNotHealthy
 	}
 
+	if err := hm.probeEngine(ctx); err != nil {
+		return err
+	}
+
+	if err := hm.checkBuilder(ctx); err != nil {
+		return err
+	}
+
 	hm.log.Info("sequencer is healthy")
 	return nil
 }
 
+// probeEngine checks that the execution engine responds in time, as a stalled engine may keep its RPC endpoint up
+// while no longer producing blocks.
+func (hm *SequencerHealthMonitor) probeEngine(ctx context.Context) error {
+	if hm.engine == nil {
+		return nil
+	}
+	timeout := hm.engineTimeout
+	if timeout == 0 {
+		timeout = hm.interval
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+	if _, err := hm.engine.LatestUnsafeBlock(ctx); err != nil {
+		hm.log.Error("execution engine is not responding", "timeout", timeout, "err", err)
+		return ErrSequencerEngineUnresponsive
+	}
+	return nil
+}
+
+// checkBuilder checks that the block builder of the sequencer is available, and that the builder built enough
+// of the recently sealed blocks, so a builder-strict cluster can fail over to a sequencer with a healthy builder.
+func (hm *SequencerHealthMonitor) checkBuilder(ctx context.Context) error {
+	if hm.builder == nil {
+		return nil
+	}
+	status, err := hm.builder.BuilderStatus(ctx)
+	if err != nil {
+		hm.log.Error("health monitor failed to get builder status", "err", err)
+		return ErrSequencerConnectionDown
+	}
+	if status == nil {
+		hm.log.Error("sequencer has no block builder")
+		return ErrSequencerBuilderUnhealthy
+	}
+	if !status.Available {
+		hm.log.Error("block builder is not available")
+		return ErrSequencerBuilderUnhealthy
+	}
+	if rate := status.FailureRate(); rate > hm.maxBuilderFailureRate {
+		hm.log.Error("block builder failure rate is above maximum",
+			"blocks", status.Blocks, "failures", status.Failures, "rate", rate, "maxRate", hm.maxBuilderFailureRate)
+		return ErrSequencerBuilderUnhealthy
+	}
+	return nil
+}
+
+// CheckBuilderConfigured checks that the sequencer has a block builder that reports its status,
+// so that a builder-strict cluster is not configured for sequencers without a builder.
+func CheckBuilderConfigured(ctx context.Context, builder BuilderStatusProvider) error {
+	status, err := builder.BuilderStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get builder status: %w", err)
+	}
+	if status == nil {
+		return errors.New("sequencer has no block builder")
+	}
+	return nil
+}
+
 func calculateTimeDiff(now, then uint64) uint64 {
 	if now < then {
 		return 0
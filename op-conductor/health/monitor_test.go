@@ -2,11 +2,13 @@ package health
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
@@ -47,6 +49,17 @@ func (s *HealthMonitorTestSuite) SetupMonitor(
 	now, unsafeInterval, safeInterval uint64,
 	mockRollupClient *testutils.MockRollupClient,
 	mockP2P *p2pMocks.API,
+) *SequencerHealthMonitor {
+	monitor := s.newMonitor(now, unsafeInterval, safeInterval, mockRollupClient, mockP2P)
+	err := monitor.Start(context.Background())
+	s.NoError(err)
+	return monitor
+}
+
+func (s *HealthMonitorTestSuite) newMonitor(
+	now, unsafeInterval, safeInterval uint64,
+	mockRollupClient *testutils.MockRollupClient,
+	mockP2P *p2pMocks.API,
 ) *SequencerHealthMonitor {
 	tp := &timeProvider{now: now}
 	if mockP2P == nil {
@@ -70,8 +83,6 @@ func (s *HealthMonitorTestSuite) SetupMonitor(
 		node:           mockRollupClient,
 		p2p:            mockP2P,
 	}
-	err := monitor.Start(context.Background())
-	s.NoError(err)
 	return monitor
 }
 
@@ -205,6 +216,55 @@ func (s *HealthMonitorTestSuite) TestHealthyWithUnsafeLag() {
 	s.NoError(monitor.Stop())
 }
 
+func (s *HealthMonitorTestSuite) TestUnhealthyEngineUnresponsive() {
+	s.T().Parallel()
+	now := uint64(time.Now().Unix())
+
+	rc := &testutils.MockRollupClient{}
+	rc.ExpectSyncStatus(mockSyncStatus(now, 1, now, 1), nil)
+	rc.ExpectSyncStatus(mockSyncStatus(now+2, 2, now, 1), nil)
+
+	monitor := s.newMonitor(now, 60, 60, rc, nil)
+	engine := &stubEngine{}
+	monitor.engine = engine
+	s.NoError(monitor.Start(context.Background()))
+	healthUpdateCh := monitor.Subscribe()
+
+	s.Nil(<-healthUpdateCh)
+	engine.setErr(context.DeadlineExceeded)
+	s.ErrorIs(<-healthUpdateCh, ErrSequencerEngineUnresponsive)
+
+	s.NoError(monitor.Stop())
+}
+
+func TestApplyHysteresis(t *testing.T) {
+	monitor := &SequencerHealthMonitor{
+		log:                testlog.Logger(t, log.LevelDebug),
+		unhealthyThreshold: 2,
+		healthyThreshold:   3,
+	}
+
+	// a single failure is not reported
+	require.NoError(t, monitor.applyHysteresis(ErrSequencerNotHealthy))
+	require.NoError(t, monitor.applyHysteresis(nil))
+	require.NoError(t, monitor.applyHysteresis(ErrSequencerNotHealthy))
+
+	// consecutive failures are reported
+	require.ErrorIs(t, monitor.applyHysteresis(ErrSequencerEngineUnresponsive), ErrSequencerEngineUnresponsive)
+	require.ErrorIs(t, monitor.applyHysteresis(ErrSequencerNotHealthy), ErrSequencerNotHealthy)
+
+	// recovery is only reported after enough consecutive passes
+	require.ErrorIs(t, monitor.applyHysteresis(nil), ErrSequencerNotHealthy)
+	require.ErrorIs(t, monitor.applyHysteresis(nil), ErrSequencerNotHealthy)
+	require.NoError(t, monitor.applyHysteresis(nil))
+	require.NoError(t, monitor.applyHysteresis(nil))
+
+	// thresholds of zero report every change
+	monitor = &SequencerHealthMonitor{log: testlog.Logger(t, log.LevelDebug)}
+	require.ErrorIs(t, monitor.applyHysteresis(ErrSequencerNotHealthy), ErrSequencerNotHealthy)
+	require.NoError(t, monitor.applyHysteresis(nil))
+}
+
 func mockSyncStatus(unsafeTime, unsafeNum, safeTime, safeNum uint64) *eth.SyncStatus {
 	return &eth.SyncStatus{
 		UnsafeL2: eth.L2BlockRef{
@@ -231,3 +291,68 @@ func (tp *timeProvider) Now() uint64 {
 	tp.now++
 	return now
 }
+
+func TestCheckBuilder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   *eth.BuilderStatus
+		err      error
+		expected error
+	}{
+		{name: "healthy", status: &eth.BuilderStatus{Available: true, Blocks: 10, Failures: 5}},
+		{name: "no blocks yet", status: &eth.BuilderStatus{Available: true}},
+		{name: "unavailable", status: &eth.BuilderStatus{Available: false, Blocks: 10}, expected: ErrSequencerBuilderUnhealthy},
+		{name: "failure rate too high", status: &eth.BuilderStatus{Available: true, Blocks: 10, Failures: 6}, expected: ErrSequencerBuilderUnhealthy},
+		{name: "no builder", expected: ErrSequencerBuilderUnhealthy},
+		{name: "node down", err: context.DeadlineExceeded, expected: ErrSequencerConnectionDown},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			monitor := &SequencerHealthMonitor{
+				log:                   testlog.Logger(t, log.LevelDebug),
+				builder:               &stubBuilder{status: tc.status, err: tc.err},
+				maxBuilderFailureRate: 0.5,
+			}
+			require.ErrorIs(t, monitor.checkBuilder(context.Background()), tc.expected)
+		})
+	}
+
+	// the builder is not checked unless the cluster is builder-strict
+	monitor := &SequencerHealthMonitor{log: testlog.Logger(t, log.LevelDebug)}
+	require.NoError(t, monitor.checkBuilder(context.Background()))
+}
+
+func TestCheckBuilderConfigured(t *testing.T) {
+	require.NoError(t, CheckBuilderConfigured(context.Background(), &stubBuilder{status: &eth.BuilderStatus{}}))
+	require.ErrorContains(t, CheckBuilderConfigured(context.Background(), &stubBuilder{}), "no block builder")
+	require.ErrorIs(t, CheckBuilderConfigured(context.Background(), &stubBuilder{err: context.DeadlineExceeded}), context.DeadlineExceeded)
+}
+
+type stubBuilder struct {
+	status *eth.BuilderStatus
+	err    error
+}
+
+func (b *stubBuilder) BuilderStatus(_ context.Context) (*eth.BuilderStatus, error) {
+	return b.status, b.err
+}
+
+type stubEngine struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (e *stubEngine) setErr(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+}
+
+func (e *stubEngine) LatestUnsafeBlock(_ context.Context) (eth.BlockInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return nil, e.err
+	}
+	return &testutils.MockBlockInfo{}, nil
+}
@@ -19,6 +19,11 @@ type Metricer interface {
 	RecordStopSequencer(success bool)
 	RecordHealthCheck(success bool, err error)
 	RecordLoopExecutionTime(duration float64)
+	RecordCommit(mode string, success bool, duration float64)
+	RecordCommitBudgetExceeded(mode string)
+	RecordReplicationLag(unsafeHead uint64, lag uint64)
+
+	opmetrics.RPCServerMetricer
 }
 
 // Metrics implementation must implement RegistryMetricer to allow the metrics server to work.
@@ -29,6 +34,8 @@ type Metrics struct {
 	registry *prometheus.Registry
 	factory  opmetrics.Factory
 
+	opmetrics.RPCServerMetrics
+
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
 
@@ -39,6 +46,12 @@ type Metrics struct {
 	stateChanges    *prometheus.CounterVec
 
 	loopExecutionTime prometheus.Histogram
+
+	commitLatency        *prometheus.HistogramVec
+	commitBudgetExceeded *prometheus.CounterVec
+
+	replicatedUnsafeHead prometheus.Gauge
+	replicationLag       prometheus.Gauge
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -56,6 +69,8 @@ func NewMetrics() *Metrics {
 		registry: registry,
 		factory:  factory,
 
+		RPCServerMetrics: opmetrics.MakeRPCServerMetrics(Namespace, factory),
+
 		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "info",
@@ -103,6 +118,27 @@ func NewMetrics() *Metrics {
 			Help:      "Time (in seconds) to execute conductor loop iteration",
 			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
 		}),
+		commitLatency: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "commit_latency",
+			Help:      "Time (in seconds) to commit an unsafe payload to consensus",
+			Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"mode", "success"}),
+		commitBudgetExceeded: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "commit_budget_exceeded_count",
+			Help:      "Number of unsafe payload commits exceeding the commit latency budget",
+		}, []string{"mode"}),
+		replicatedUnsafeHead: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "replicated_unsafe_head",
+			Help:      "Block number of the latest unsafe payload replicated to this observer",
+		}),
+		replicationLag: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "replication_lag_entries",
+			Help:      "Number of log entries committed by the leader that are not yet applied to this observer",
+		}),
 	}
 }
 
@@ -155,3 +191,20 @@ func (m *Metrics) RecordStopSequencer(success bool) {
 func (m *Metrics) RecordLoopExecutionTime(duration float64) {
 	m.loopExecutionTime.Observe(duration)
 }
+
+// RecordCommit records the time it took to commit an unsafe payload to consensus.
+func (m *Metrics) RecordCommit(mode string, success bool, duration float64) {
+	m.commitLatency.WithLabelValues(mode, strconv.FormatBool(success)).Observe(duration)
+}
+
+// RecordCommitBudgetExceeded increments the commitBudgetExceeded counter.
+func (m *Metrics) RecordCommitBudgetExceeded(mode string) {
+	m.commitBudgetExceeded.WithLabelValues(mode).Inc()
+}
+
+// RecordReplicationLag records the latest unsafe payload replicated to an observer and how many log entries it lags
+// behind the leader.
+func (m *Metrics) RecordReplicationLag(unsafeHead uint64, lag uint64) {
+	m.replicatedUnsafeHead.Set(float64(unsafeHead))
+	m.replicationLag.Set(float64(lag))
+}
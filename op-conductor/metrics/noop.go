@@ -1,6 +1,12 @@
 package metrics
 
-type NoopMetricsImpl struct{}
+import (
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+type NoopMetricsImpl struct {
+	opmetrics.NoopRPCMetrics
+}
 
 var NoopMetrics Metricer = new(NoopMetricsImpl)
 
@@ -12,3 +18,6 @@ func (*NoopMetricsImpl) RecordStartSequencer(success bool)
 func (*NoopMetricsImpl) RecordStopSequencer(success bool)                         {}
 func (*NoopMetricsImpl) RecordHealthCheck(success bool, err error)                {}
 func (*NoopMetricsImpl) RecordLoopExecutionTime(duration float64)                 {}
+func (*NoopMetricsImpl) RecordCommit(mode string, success bool, duration float64) {}
+func (*NoopMetricsImpl) RecordCommitBudgetExceeded(mode string)                   {}
+func (*NoopMetricsImpl) RecordReplicationLag(unsafeHead uint64, lag uint64)       {}
@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var AdminRPCNamespace = "conductoradmin"
+
+// ServerHealth is a member of the cluster together with the health of its sequencer, as reported by its conductor.
+type ServerHealth struct {
+	consensus.ServerInfo
+	Leader bool `json:"leader"`
+	// Reachable is false if the conductor of the server could not be queried, in which case its health is unknown.
+	Reachable  bool        `json:"reachable"`
+	Healthy    bool        `json:"healthy"`
+	UnsafeHead eth.BlockID `json:"unsafeHead"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// ClusterHealth is the cluster membership configuration with the health of each member.
+type ClusterHealth struct {
+	Servers []ServerHealth `json:"servers"`
+	Version uint64         `json:"version"`
+}
+
+// MemberStatus is the status of a member of the cluster, as seen by the leader.
+type MemberStatus struct {
+	ServerHealth
+	// Lag is the number of blocks that the unsafe head of the member is behind the unsafe head of the cluster.
+	Lag uint64 `json:"lag"`
+}
+
+// ClusterStatus is the leader's view of the cluster, served on the cluster status endpoint of every conductor.
+type ClusterStatus struct {
+	// Leader is the server ID of the leader that reported the status.
+	Leader string `json:"leader"`
+	// UnsafeHead is the latest unsafe block committed to the cluster.
+	UnsafeHead eth.BlockID `json:"unsafeHead"`
+	// LastCommitTime is the time of the last unsafe payload committed by the leader, nil if it did not commit yet.
+	LastCommitTime *time.Time     `json:"lastCommitTime,omitempty"`
+	Members        []MemberStatus `json:"members"`
+	Version        uint64         `json:"version"`
+}
+
+// AdminAPI defines the cluster membership admin API of op-conductor, served on a separate, authenticated RPC server.
+type AdminAPI interface {
+	// AddServerAsVoter adds a server as a voter to the cluster.
+	AddServerAsVoter(ctx context.Context, id string, addr string, version uint64) error
+	// AddServerAsNonvoter adds a server as a non-voter to the cluster. non-voter will not participate in leader election.
+	AddServerAsNonvoter(ctx context.Context, id string, addr string, version uint64) error
+	// RemoveServer removes a server from the cluster.
+	RemoveServer(ctx context.Context, id string, version uint64) error
+	// ClusterMembership returns the current cluster membership configuration.
+	ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error)
+	// ClusterHealth returns the current cluster membership configuration with the health of each member. The health
+	// of other members is queried from their conductors, at the RPC URLs in rpcURLs keyed by server ID.
+	ClusterHealth(ctx context.Context, rpcURLs map[string]string) (*ClusterHealth, error)
+	// Bootstrap bootstraps a new cluster with this server as the only voter.
+	Bootstrap(ctx context.Context) error
+}
+
+type adminConductor interface {
+	AddServerAsVoter(ctx context.Context, id string, addr string, version uint64) error
+	AddServerAsNonvoter(ctx context.Context, id string, addr string, version uint64) error
+	RemoveServer(ctx context.Context, id string, version uint64) error
+	ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error)
+	ClusterHealth(ctx context.Context, rpcURLs map[string]string) (*ClusterHealth, error)
+	Bootstrap(ctx context.Context) error
+}
+
+// AdminAPIBackend is the backend implementation of the AdminAPI.
+type AdminAPIBackend struct {
+	log log.Logger
+	con adminConductor
+}
+
+var _ AdminAPI = (*AdminAPIBackend)(nil)
+
+// NewAdminAPIBackend creates a new AdminAPIBackend instance.
+func NewAdminAPIBackend(log log.Logger, con adminConductor) *AdminAPIBackend {
+	return &AdminAPIBackend{
+		log: log,
+		con: con,
+	}
+}
+
+// AddServerAsVoter implements AdminAPI.
+func (api *AdminAPIBackend) AddServerAsVoter(ctx context.Context, id string, addr string, version uint64) error {
+	api.log.Info("adding server as voter", "id", id, "addr", addr, "version", version)
+	return api.con.AddServerAsVoter(ctx, id, addr, version)
+}
+
+// AddServerAsNonvoter implements AdminAPI.
+func (api *AdminAPIBackend) AddServerAsNonvoter(ctx context.Context, id string, addr string, version uint64) error {
+	api.log.Info("adding server as non-voter", "id", id, "addr", addr, "version", version)
+	return api.con.AddServerAsNonvoter(ctx, id, addr, version)
+}
+
+// RemoveServer implements AdminAPI.
+func (api *AdminAPIBackend) RemoveServer(ctx context.Context, id string, version uint64) error {
+	api.log.Info("removing server", "id", id, "version", version)
+	return api.con.RemoveServer(ctx, id, version)
+}
+
+// ClusterMembership implements AdminAPI.
+func (api *AdminAPIBackend) ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error) {
+	return api.con.ClusterMembership(ctx)
+}
+
+// ClusterHealth implements AdminAPI.
+func (api *AdminAPIBackend) ClusterHealth(ctx context.Context, rpcURLs map[string]string) (*ClusterHealth, error) {
+	return api.con.ClusterHealth(ctx, rpcURLs)
+}
+
+// Bootstrap implements AdminAPI.
+func (api *AdminAPIBackend) Bootstrap(ctx context.Context) error {
+	api.log.Info("bootstrapping cluster")
+	return api.con.Bootstrap(ctx)
+}
+
+// AdminAPIClient provides a client for calling AdminAPI methods.
+type AdminAPIClient struct {
+	c *rpc.Client
+}
+
+var _ AdminAPI = (*AdminAPIClient)(nil)
+
+// NewAdminAPIClient creates a new AdminAPIClient instance.
+func NewAdminAPIClient(c *rpc.Client) *AdminAPIClient {
+	return &AdminAPIClient{c: c}
+}
+
+func prefixAdminRPC(method string) string {
+	return AdminRPCNamespace + "_" + method
+}
+
+// AddServerAsVoter implements AdminAPI.
+func (c *AdminAPIClient) AddServerAsVoter(ctx context.Context, id string, addr string, version uint64) error {
+	return c.c.CallContext(ctx, nil, prefixAdminRPC("addServerAsVoter"), id, addr, version)
+}
+
+// AddServerAsNonvoter implements AdminAPI.
+func (c *AdminAPIClient) AddServerAsNonvoter(ctx context.Context, id string, addr string, version uint64) error {
+	return c.c.CallContext(ctx, nil, prefixAdminRPC("addServerAsNonvoter"), id, addr, version)
+}
+
+// RemoveServer implements AdminAPI.
+func (c *AdminAPIClient) RemoveServer(ctx context.Context, id string, version uint64) error {
+	return c.c.CallContext(ctx, nil, prefixAdminRPC("removeServer"), id, version)
+}
+
+// ClusterMembership implements AdminAPI.
+func (c *AdminAPIClient) ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error) {
+	var clusterMembership consensus.ClusterMembership
+	err := c.c.CallContext(ctx, &clusterMembership, prefixAdminRPC("clusterMembership"))
+	return &clusterMembership, err
+}
+
+// ClusterHealth implements AdminAPI.
+func (c *AdminAPIClient) ClusterHealth(ctx context.Context, rpcURLs map[string]string) (*ClusterHealth, error) {
+	var clusterHealth ClusterHealth
+	err := c.c.CallContext(ctx, &clusterHealth, prefixAdminRPC("clusterHealth"), rpcURLs)
+	return &clusterHealth, err
+}
+
+// Bootstrap implements AdminAPI.
+func (c *AdminAPIClient) Bootstrap(ctx context.Context) error {
+	return c.c.CallContext(ctx, nil, prefixAdminRPC("bootstrap"))
+}
@@ -29,6 +29,8 @@ type API interface {
 	Stopped(ctx context.Context) (bool, error)
 	// SequencerHealthy returns true if the sequencer is healthy.
 	SequencerHealthy(ctx context.Context) (bool, error)
+	// SequencerUnsafeHead returns the latest unsafe block of the sequencer.
+	SequencerUnsafeHead(ctx context.Context) (eth.BlockID, error)
 
 	// Consensus related APIs
 	// Leader returns true if the server is the leader.
@@ -45,14 +47,23 @@ type API interface {
 	TransferLeader(ctx context.Context) error
 	// TransferLeaderToServer transfers leadership to a specific server.
 	TransferLeaderToServer(ctx context.Context, id string, addr string) error
+	// TransferLeaderToHealthyServer transfers leadership to a specific server only if its sequencer, checked through
+	// the server's configured peer conductor RPC URL, is healthy and on the chain of the latest unsafe head, within
+	// the configured number of blocks.
+	TransferLeaderToHealthyServer(ctx context.Context, id string, addr string) error
 	// ClusterMembership returns the current cluster membership configuration.
 	ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error)
+	// PayloadProvenance returns the provenance of a recently committed unsafe block, or nil if it is not known.
+	PayloadProvenance(ctx context.Context, number uint64) (*consensus.BlockProvenance, error)
 
 	// APIs called by op-node
 	// Active returns true if op-conductor is active (not paused or stopped).
 	Active(ctx context.Context) (bool, error)
 	// CommitUnsafePayload commits an unsafe payload (latest head) to the consensus layer.
 	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
+	// CommitUnsafePayloadWithMetadata commits an unsafe payload (latest head) to the consensus layer, along with its
+	// provenance, e.g. whether it was built by an external block builder.
+	CommitUnsafePayloadWithMetadata(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *consensus.PayloadMetadata) error
 }
 
 // ExecutionProxyAPI defines the methods proxied to the execution rpc backend
@@ -16,6 +16,7 @@ type conductor interface {
 	Paused() bool
 	Stopped() bool
 	SequencerHealthy(ctx context.Context) bool
+	SequencerUnsafeHead(ctx context.Context) (eth.BlockID, error)
 
 	Leader(ctx context.Context) bool
 	LeaderWithID(ctx context.Context) *consensus.ServerInfo
@@ -24,8 +25,11 @@ type conductor interface {
 	RemoveServer(ctx context.Context, id string, version uint64) error
 	TransferLeader(ctx context.Context) error
 	TransferLeaderToServer(ctx context.Context, id string, addr string) error
+	TransferLeaderToHealthyServer(ctx context.Context, id string, addr string) error
 	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
+	CommitUnsafePayloadWithMetadata(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *consensus.PayloadMetadata) error
 	ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error)
+	PayloadProvenance(ctx context.Context, number uint64) (*consensus.BlockProvenance, error)
 }
 
 // APIBackend is the backend implementation of the API.
@@ -87,6 +91,16 @@ func (api *APIBackend) CommitUnsafePayload(ctx context.Context, payload *eth.Exe
 	return api.con.CommitUnsafePayload(ctx, payload)
 }
 
+// CommitUnsafePayloadWithMetadata implements API.
+func (api *APIBackend) CommitUnsafePayloadWithMetadata(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *consensus.PayloadMetadata) error {
+	return api.con.CommitUnsafePayloadWithMetadata(ctx, payload, metadata)
+}
+
+// PayloadProvenance implements API.
+func (api *APIBackend) PayloadProvenance(ctx context.Context, number uint64) (*consensus.BlockProvenance, error) {
+	return api.con.PayloadProvenance(ctx, number)
+}
+
 // Leader implements API, returns true if current conductor is leader of the cluster.
 func (api *APIBackend) Leader(ctx context.Context) (bool, error) {
 	return api.leaderOverride.Load() || api.con.Leader(ctx), nil
@@ -127,11 +141,21 @@ func (api *APIBackend) TransferLeaderToServer(ctx context.Context, id string, ad
 	return api.con.TransferLeaderToServer(ctx, id, addr)
 }
 
+// TransferLeaderToHealthyServer implements API. As with TransferLeaderToServer, a successful call means that leadership transfer is in progress.
+func (api *APIBackend) TransferLeaderToHealthyServer(ctx context.Context, id string, addr string) error {
+	return api.con.TransferLeaderToHealthyServer(ctx, id, addr)
+}
+
 // SequencerHealthy implements API.
 func (api *APIBackend) SequencerHealthy(ctx context.Context) (bool, error) {
 	return api.con.SequencerHealthy(ctx), nil
 }
 
+// SequencerUnsafeHead implements API.
+func (api *APIBackend) SequencerUnsafeHead(ctx context.Context) (eth.BlockID, error) {
+	return api.con.SequencerUnsafeHead(ctx)
+}
+
 // ClusterMembership implements API.
 func (api *APIBackend) ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error) {
 	return api.con.ClusterMembership(ctx)
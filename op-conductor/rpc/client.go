@@ -11,6 +11,22 @@ import (
 
 var RPCNamespace = "conductor"
 
+// AdminMethods are the methods of the API that control the conductor, the cluster or the unsafe payload log, which
+// require authentication if admin RPC authentication is configured. op-node authenticates to commit unsafe payloads.
+var AdminMethods = []string{
+	prefixRPC("overrideLeader"),
+	prefixRPC("pause"),
+	prefixRPC("resume"),
+	prefixRPC("addServerAsVoter"),
+	prefixRPC("addServerAsNonvoter"),
+	prefixRPC("removeServer"),
+	prefixRPC("transferLeader"),
+	prefixRPC("transferLeaderToServer"),
+	prefixRPC("transferLeaderToHealthyServer"),
+	prefixRPC("commitUnsafePayload"),
+	prefixRPC("commitUnsafePayloadWithMetadata"),
+}
+
 // APIClient provides a client for calling API methods.
 type APIClient struct {
 	c *rpc.Client
@@ -78,6 +94,18 @@ func (c *APIClient) CommitUnsafePayload(ctx context.Context, payload *eth.Execut
 	return c.c.CallContext(ctx, nil, prefixRPC("commitUnsafePayload"), payload)
 }
 
+// CommitUnsafePayloadWithMetadata implements API.
+func (c *APIClient) CommitUnsafePayloadWithMetadata(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *consensus.PayloadMetadata) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("commitUnsafePayloadWithMetadata"), payload, metadata)
+}
+
+// PayloadProvenance implements API.
+func (c *APIClient) PayloadProvenance(ctx context.Context, number uint64) (*consensus.BlockProvenance, error) {
+	var provenance *consensus.BlockProvenance
+	err := c.c.CallContext(ctx, &provenance, prefixRPC("payloadProvenance"), number)
+	return provenance, err
+}
+
 // Leader implements API.
 func (c *APIClient) Leader(ctx context.Context) (bool, error) {
 	var leader bool
@@ -112,6 +140,18 @@ func (c *APIClient) TransferLeaderToServer(ctx context.Context, id string, addr
 	return c.c.CallContext(ctx, nil, prefixRPC("transferLeaderToServer"), id, addr)
 }
 
+// TransferLeaderToHealthyServer implements API.
+func (c *APIClient) TransferLeaderToHealthyServer(ctx context.Context, id string, addr string) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("transferLeaderToHealthyServer"), id, addr)
+}
+
+// SequencerUnsafeHead implements API.
+func (c *APIClient) SequencerUnsafeHead(ctx context.Context) (eth.BlockID, error) {
+	var head eth.BlockID
+	err := c.c.CallContext(ctx, &head, prefixRPC("sequencerUnsafeHead"))
+	return head, err
+}
+
 // SequencerHealthy implements API.
 func (c *APIClient) SequencerHealthy(ctx context.Context) (bool, error) {
 	var healthy bool
@@ -167,14 +167,7 @@ func (s *Service) initL1Client(ctx context.Context, cfg *config.Config) error {
 }
 
 func (s *Service) initPProf(cfg *oppprof.CLIConfig) error {
-	s.pprofService = oppprof.New(
-		cfg.ListenEnabled,
-		cfg.ListenAddr,
-		cfg.ListenPort,
-		cfg.ProfileType,
-		cfg.ProfileDir,
-		cfg.ProfileFilename,
-	)
+	s.pprofService = oppprof.NewFromConfig(*cfg)
 
 	if err := s.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -0,0 +1,200 @@
+package actions
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ChaosCall identifies an engine, builder or conductor interaction of a rollup node that faults can be injected into.
+type ChaosCall string
+
+const (
+	// ChaosStartBuild is a forkchoice update with payload attributes: the builder starts building a block.
+	ChaosStartBuild ChaosCall = "start-build"
+	// ChaosGetPayload retrieves the block that was built by the builder.
+	ChaosGetPayload ChaosCall = "get-payload"
+	// ChaosNewPayload inserts a block into the engine.
+	ChaosNewPayload ChaosCall = "new-payload"
+	// ChaosForkchoiceUpdate is a forkchoice update without payload attributes.
+	ChaosForkchoiceUpdate ChaosCall = "forkchoice-update"
+	// ChaosConductorCommit commits a sealed block to the sequencer conductor.
+	ChaosConductorCommit ChaosCall = "conductor-commit"
+)
+
+// Fault describes how calls are disturbed.
+type Fault struct {
+	// Latency is added before the call is made. The call fails with the context error if the context is done first.
+	Latency time.Duration
+	// Err is returned instead of making the call, if not nil.
+	Err error
+	// Corrupt modifies the payload returned by the builder, to simulate malformed responses.
+	// Only applies to ChaosGetPayload.
+	Corrupt func(envelope *eth.ExecutionPayloadEnvelope)
+	// Times is the number of calls the fault applies to. If zero, the fault applies until it is cleared.
+	Times int
+}
+
+// CorruptBlockHash makes the builder return a block with a block hash that does not match the block contents.
+func CorruptBlockHash(envelope *eth.ExecutionPayloadEnvelope) {
+	envelope.ExecutionPayload.BlockHash[0] ^= 0xff
+}
+
+// CorruptStateRoot makes the builder return an invalid block, with a block hash that is consistent with the invalid contents.
+func CorruptStateRoot(envelope *eth.ExecutionPayloadEnvelope) {
+	envelope.ExecutionPayload.StateRoot[0] ^= 0xff
+	envelope.ExecutionPayload.BlockHash = envelope.BlockHeader().Hash()
+}
+
+// Chaos injects faults into the engine, builder and conductor interactions of a rollup node,
+// through the ChaosEngine and ChaosConductor wrappers.
+// Faults can be injected and cleared mid-test.
+type Chaos struct {
+	mu       sync.Mutex
+	faults   map[ChaosCall]*Fault
+	injected map[ChaosCall]int
+}
+
+func NewChaos() *Chaos {
+	return &Chaos{
+		faults:   make(map[ChaosCall]*Fault),
+		injected: make(map[ChaosCall]int),
+	}
+}
+
+// ActInjectFault disturbs the next calls of the given type with the fault.
+func (c *Chaos) ActInjectFault(t Testing, call ChaosCall, fault Fault) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.faults[call]; ok {
+		t.InvalidAction("already injecting a fault into %s calls", call)
+		return
+	}
+	c.faults[call] = &fault
+}
+
+// ActClearFault stops disturbing the calls of the given type.
+func (c *Chaos) ActClearFault(t Testing, call ChaosCall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.faults, call)
+}
+
+// ActClearFaults stops disturbing all calls.
+func (c *Chaos) ActClearFaults(t Testing) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.faults)
+}
+
+// Injected returns the number of calls of the given type that a fault was injected into.
+func (c *Chaos) Injected(call ChaosCall) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.injected[call]
+}
+
+// inject applies the fault of the call, if any, and returns it.
+func (c *Chaos) inject(ctx context.Context, call ChaosCall) (*Fault, error) {
+	c.mu.Lock()
+	fault, ok := c.faults[call]
+	if ok {
+		c.injected[call]++
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				delete(c.faults, call)
+			}
+		}
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	if fault.Latency > 0 {
+		timer := time.NewTimer(fault.Latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return fault, ctx.Err()
+		}
+	}
+	return fault, fault.Err
+}
+
+// ChaosEngine wraps the engine client of a rollup node, to inject the faults of Chaos into engine and builder calls.
+type ChaosEngine struct {
+	L2API
+	chaos *Chaos
+}
+
+var _ L2API = (*ChaosEngine)(nil)
+
+func NewChaosEngine(eng L2API, chaos *Chaos) *ChaosEngine {
+	return &ChaosEngine{L2API: eng, chaos: chaos}
+}
+
+func (e *ChaosEngine) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	call := ChaosForkchoiceUpdate
+	if attr != nil {
+		call = ChaosStartBuild
+	}
+	if _, err := e.chaos.inject(ctx, call); err != nil {
+		return nil, err
+	}
+	return e.L2API.ForkchoiceUpdate(ctx, state, attr)
+}
+
+func (e *ChaosEngine) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	fault, err := e.chaos.inject(ctx, ChaosGetPayload)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := e.L2API.GetPayload(ctx, payloadInfo)
+	if err != nil {
+		return nil, err
+	}
+	if fault != nil && fault.Corrupt != nil {
+		fault.Corrupt(envelope)
+	}
+	return envelope, nil
+}
+
+func (e *ChaosEngine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	if _, err := e.chaos.inject(ctx, ChaosNewPayload); err != nil {
+		return nil, err
+	}
+	return e.L2API.NewPayload(ctx, payload, parentBeaconBlockRoot)
+}
+
+// ChaosConductor wraps the sequencer conductor of a rollup node, to inject the faults of Chaos into conductor calls.
+// It keeps the payloads that were committed to the conductor.
+type ChaosConductor struct {
+	conductor.SequencerConductor
+	chaos *Chaos
+
+	Committed []*eth.ExecutionPayloadEnvelope
+}
+
+var _ conductor.SequencerConductor = (*ChaosConductor)(nil)
+
+func NewChaosConductor(c conductor.SequencerConductor, chaos *Chaos) *ChaosConductor {
+	return &ChaosConductor{SequencerConductor: c, chaos: chaos}
+}
+
+func (c *ChaosConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *eth.PayloadMetadata) error {
+	if _, err := c.chaos.inject(ctx, ChaosConductorCommit); err != nil {
+		return err
+	}
+	if err := c.SequencerConductor.CommitUnsafePayload(ctx, payload, metadata); err != nil {
+		return err
+	}
+	c.Committed = append(c.Committed, payload)
+	return nil
+}
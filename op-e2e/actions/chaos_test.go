@@ -0,0 +1,204 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// stubL2API records the calls that make it through the chaos wrappers.
+type stubL2API struct {
+	L2API
+	calls []ChaosCall
+}
+
+func (s *stubL2API) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	if attr != nil {
+		s.calls = append(s.calls, ChaosStartBuild)
+	} else {
+		s.calls = append(s.calls, ChaosForkchoiceUpdate)
+	}
+	return &eth.ForkchoiceUpdatedResult{}, nil
+}
+
+func (s *stubL2API) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	s.calls = append(s.calls, ChaosGetPayload)
+	return &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockHash: common.Hash{1}}}, nil
+}
+
+func (s *stubL2API) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	s.calls = append(s.calls, ChaosNewPayload)
+	return &eth.PayloadStatusV1{Status: eth.ExecutionValid}, nil
+}
+
+func TestChaos(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	stub := &stubL2API{}
+	chaos := NewChaos()
+	eng := NewChaosEngine(stub, chaos)
+	errEngine := errors.New("engine unavailable")
+
+	// faults only apply to the calls of the given type, for the given number of times
+	chaos.ActInjectFault(t, ChaosStartBuild, Fault{Err: errEngine, Times: 2})
+	_, err := eng.ForkchoiceUpdate(t.Ctx(), &eth.ForkchoiceState{}, nil)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = eng.ForkchoiceUpdate(t.Ctx(), &eth.ForkchoiceState{}, &eth.PayloadAttributes{})
+		require.ErrorIs(t, err, errEngine)
+	}
+	_, err = eng.ForkchoiceUpdate(t.Ctx(), &eth.ForkchoiceState{}, &eth.PayloadAttributes{})
+	require.NoError(t, err)
+	require.Equal(t, []ChaosCall{ChaosForkchoiceUpdate, ChaosStartBuild}, stub.calls)
+	require.Equal(t, 2, chaos.Injected(ChaosStartBuild))
+	require.Zero(t, chaos.Injected(ChaosForkchoiceUpdate))
+
+	// malformed responses are returned until the fault is cleared
+	chaos.ActInjectFault(t, ChaosGetPayload, Fault{Corrupt: CorruptBlockHash})
+	for i := 0; i < 3; i++ {
+		envelope, err := eng.GetPayload(t.Ctx(), eth.PayloadInfo{})
+		require.NoError(t, err)
+		require.NotEqual(t, common.Hash{1}, envelope.ExecutionPayload.BlockHash)
+	}
+	chaos.ActClearFault(t, ChaosGetPayload)
+	envelope, err := eng.GetPayload(t.Ctx(), eth.PayloadInfo{})
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{1}, envelope.ExecutionPayload.BlockHash)
+	require.Equal(t, 3, chaos.Injected(ChaosGetPayload))
+
+	// latency is bounded by the context of the call
+	chaos.ActInjectFault(t, ChaosNewPayload, Fault{Latency: time.Minute})
+	ctx, cancel := context.WithTimeout(t.Ctx(), 10*time.Millisecond)
+	defer cancel()
+	_, err = eng.NewPayload(ctx, &eth.ExecutionPayload{}, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotContains(t, stub.calls, ChaosNewPayload)
+	chaos.ActClearFaults(t)
+	chaos.ActInjectFault(t, ChaosNewPayload, Fault{Latency: time.Millisecond, Times: 1})
+	status, err := eng.NewPayload(t.Ctx(), &eth.ExecutionPayload{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, eth.ExecutionValid, status.Status)
+
+	// conductor commits that fail are not recorded
+	cond := NewChaosConductor(&conductor.NoOpConductor{}, chaos)
+	chaos.ActInjectFault(t, ChaosConductorCommit, Fault{Err: errors.New("not the leader"), Times: 1})
+	require.ErrorContains(t, cond.CommitUnsafePayload(t.Ctx(), envelope, nil), "not the leader")
+	require.Empty(t, cond.Committed)
+	require.NoError(t, cond.CommitUnsafePayload(t.Ctx(), envelope, nil))
+	require.Equal(t, []*eth.ExecutionPayloadEnvelope{envelope}, cond.Committed)
+}
+
+func setupChaosSequencerTest(t Testing, sd *e2eutils.SetupData, log log.Logger) (*Chaos, *ChaosConductor, *L2Engine, *L2Sequencer) {
+	jwtPath := e2eutils.WriteDefaultJWT(t)
+
+	miner := NewL1Miner(t, log, sd.L1Cfg)
+	l1F, err := sources.NewL1Client(miner.RPCClient(), log, nil, sources.L1ClientDefaultConfig(sd.RollupCfg, false, sources.RPCKindStandard))
+	require.NoError(t, err)
+	engine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath)
+	l2Cl, err := sources.NewEngineClient(engine.RPCClient(), log, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
+	require.NoError(t, err)
+
+	chaos := NewChaos()
+	sequencer := NewL2Sequencer(t, log, l1F, miner.BlobStore(), plasma.Disabled, NewChaosEngine(l2Cl, chaos), sd.RollupCfg, 0)
+	cond := NewChaosConductor(&conductor.NoOpConductor{}, chaos)
+	sequencer.conductor = cond
+	return chaos, cond, engine, sequencer
+}
+
+// TestChaos_InvalidPayloadDuringConductorFailover covers a builder that returns invalid payloads
+// while the sequencer conductor is failing over to another leader.
+func TestChaos_InvalidPayloadDuringConductorFailover(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LevelDebug)
+	chaos, cond, _, sequencer := setupChaosSequencerTest(t, sd, log)
+
+	sequencer.ActL2PipelineFull(t)
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlock(t)
+	require.Len(t, cond.Committed, 1)
+
+	// the conductor is not the leader anymore, the block is not inserted
+	chaos.ActInjectFault(t, ChaosConductorCommit, Fault{Err: errors.New("not the leader"), Times: 1})
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlockExpectErr(t, "not the leader")
+	sequencer.ActL2CancelBlock(t)
+	require.Len(t, cond.Committed, 1)
+
+	// the builder returns a payload with a bad block hash, it is rejected before the conductor commit
+	chaos.ActInjectFault(t, ChaosGetPayload, Fault{Corrupt: CorruptBlockHash, Times: 1})
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlockExpectErr(t, "bad block hash")
+	sequencer.ActL2CancelBlock(t)
+	require.Len(t, cond.Committed, 1)
+
+	// the builder returns an invalid block with a consistent block hash, the engine rejects it after the conductor commit
+	chaos.ActInjectFault(t, ChaosGetPayload, Fault{Corrupt: CorruptStateRoot, Times: 1})
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlockExpectErr(t, "INVALID")
+	sequencer.ActL2CancelBlock(t)
+	require.Len(t, cond.Committed, 2)
+
+	// once the faults are gone, the sequencer continues building on the last valid block
+	head := sequencer.L2Unsafe()
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlock(t)
+	require.Equal(t, head.Hash, sequencer.L2Unsafe().ParentHash)
+	require.Len(t, cond.Committed, 3)
+	require.Equal(t, sequencer.L2Unsafe().Hash, cond.Committed[2].ExecutionPayload.BlockHash)
+}
+
+// TestChaos_EngineLatencyAndErrors covers temporary engine and builder failures during block building.
+func TestChaos_EngineLatencyAndErrors(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LevelDebug)
+	chaos, _, _, sequencer := setupChaosSequencerTest(t, sd, log)
+
+	sequencer.ActL2PipelineFull(t)
+
+	// the builder is unavailable, no block building is started
+	errBuilder := errors.New("builder unavailable")
+	chaos.ActInjectFault(t, ChaosStartBuild, Fault{Err: errBuilder, Times: 1})
+	sequencer.ActL2StartBlockCheckErr(t, errBuilder)
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlock(t)
+	require.Equal(t, uint64(1), sequencer.L2Unsafe().Number)
+
+	// the engine fails to insert the block, the payload was retrieved already so block building is restarted
+	chaos.ActInjectFault(t, ChaosNewPayload, Fault{Err: errors.New("engine unavailable"), Times: 1})
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlockExpectErr(t, "engine unavailable")
+	sequencer.ActL2CancelBlock(t)
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlock(t)
+	require.Equal(t, uint64(2), sequencer.L2Unsafe().Number)
+
+	// the builder is too slow to respond, the payload can still be retrieved afterwards
+	chaos.ActInjectFault(t, ChaosGetPayload, Fault{Latency: time.Minute, Times: 1})
+	sequencer.ActL2StartBlock(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	t.Reset(ctx)
+	sequencer.ActL2EndBlockExpectErr(t, context.DeadlineExceeded.Error())
+	t.Reset(context.Background())
+	sequencer.ActL2EndBlock(t)
+	require.Equal(t, uint64(3), sequencer.L2Unsafe().Number)
+
+	require.Equal(t, 1, chaos.Injected(ChaosStartBuild))
+	require.Equal(t, 1, chaos.Injected(ChaosNewPayload))
+	require.Equal(t, 1, chaos.Injected(ChaosGetPayload))
+}
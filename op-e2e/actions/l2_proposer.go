@@ -65,6 +65,10 @@ func (f fakeTxMgr) Send(_ context.Context, _ txmgr.TxCandidate) (*types.Receipt,
 	panic("unimplemented")
 }
 
+func (f fakeTxMgr) SuggestGasPriceCaps(_ context.Context) (*big.Int, *big.Int, *big.Int, error) {
+	panic("unimplemented")
+}
+
 func (f fakeTxMgr) Close() {
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 // MockL1OriginSelector is a shim to override the origin as sequencer, so we can force it to stay on an older origin.
@@ -40,6 +41,7 @@ type L2Sequencer struct {
 	*L2Verifier
 
 	sequencer *driver.Sequencer
+	conductor conductor.SequencerConductor
 
 	failL2GossipUnsafeBlock error // mock error
 
@@ -56,7 +58,8 @@ func NewL2Sequencer(t Testing, log log.Logger, l1 derive.L1Fetcher, blobSrc deri
 	}
 	return &L2Sequencer{
 		L2Verifier:              ver,
-		sequencer:               driver.NewSequencer(log, cfg, ver.engine, attrBuilder, l1OriginSelector, metrics.NoopMetrics),
+		sequencer:               driver.NewSequencer(log, cfg, ver.engine, attrBuilder, l1OriginSelector, metrics.NoopMetrics, tracing.NoopTracer{}),
+		conductor:               &conductor.NoOpConductor{},
 		mockL1OriginSelector:    l1OriginSelector,
 		failL2GossipUnsafeBlock: nil,
 	}
@@ -101,7 +104,7 @@ func (s *L2Sequencer) ActL2EndBlock(t Testing) {
 	}
 	s.l2Building = false
 
-	_, err := s.sequencer.CompleteBuildingBlock(t.Ctx(), async.NoOpGossiper{}, &conductor.NoOpConductor{})
+	_, err := s.sequencer.CompleteBuildingBlock(t.Ctx(), async.NoOpGossiper{}, s.conductor)
 	// TODO: there may be legitimate temporary errors here, if we mock engine API RPC-failure.
 	// For advanced tests we can catch those and print a warning instead.
 	require.NoError(t, err)
@@ -118,6 +121,29 @@ func (s *L2Sequencer) ActL2EndBlock(t Testing) {
 		"sync status must be accurate after block building")
 }
 
+// ActL2EndBlockExpectErr attempts to complete the L2 block that is being built, and expects it to fail with an error containing msg.
+// The block building job is kept: it can be completed with ActL2EndBlock, or abandoned with ActL2CancelBlock.
+func (s *L2Sequencer) ActL2EndBlockExpectErr(t Testing, msg string) {
+	if !s.l2Building {
+		t.InvalidAction("cannot end L2 block building when no block is being built")
+		return
+	}
+	head := s.engine.UnsafeL2Head()
+	_, err := s.sequencer.CompleteBuildingBlock(t.Ctx(), async.NoOpGossiper{}, s.conductor)
+	require.ErrorContains(t, err, msg)
+	require.Equal(t, head, s.engine.UnsafeL2Head(), "failed block building must not change the unsafe head")
+}
+
+// ActL2CancelBlock abandons the L2 block that is being built.
+func (s *L2Sequencer) ActL2CancelBlock(t Testing) {
+	if !s.l2Building {
+		t.InvalidAction("cannot cancel L2 block building when no block is being built")
+		return
+	}
+	s.l2Building = false
+	s.sequencer.CancelBuildingBlock(t.Ctx())
+}
+
 // ActL2KeepL1Origin makes the sequencer use the current L1 origin, even if the next origin is available.
 func (s *L2Sequencer) ActL2KeepL1Origin(t Testing) {
 	parent := s.engine.UnsafeL2Head()
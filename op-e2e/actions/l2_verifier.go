@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/safego"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 // L2Verifier is an actor that functions like a rollup node,
@@ -100,36 +101,37 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc deri
 	ec := engine.NewEngineController(eng, log, metrics, cfg, syncCfg, synchronousEvents)
 	engineResetDeriver := engine.NewEngineResetDeriver(ctx, log, cfg, l1, eng, syncCfg, synchronousEvents)
 
-	clSync := clsync.NewCLSync(log, cfg, metrics, synchronousEvents)
+	clSync := clsync.NewCLSync(log, cfg, metrics, synchronousEvents, derive.DefaultMaxUnsafePayloadsMemory)
 
 	var finalizer driver.Finalizer
 	if cfg.PlasmaEnabled() {
-		finalizer = finality.NewPlasmaFinalizer(ctx, log, cfg, l1, synchronousEvents, plasmaSrc)
+		finalizer = finality.NewPlasmaFinalizer(ctx, log, cfg, l1, synchronousEvents, plasmaSrc, finality.L1Finality{})
 	} else {
-		finalizer = finality.NewFinalizer(ctx, log, cfg, l1, synchronousEvents)
+		finalizer = finality.NewFinalizer(ctx, log, cfg, l1, synchronousEvents, finality.L1Finality{})
 	}
 
 	attributesHandler := attributes.NewAttributesHandler(log, cfg, ctx, eng, synchronousEvents)
 
-	pipeline := derive.NewDerivationPipeline(log, cfg, l1, blobsSrc, plasmaSrc, eng, metrics)
-	pipelineDeriver := derive.NewPipelineDeriver(ctx, pipeline, synchronousEvents)
+	pipeline := derive.NewDerivationPipeline(log, cfg, l1, blobsSrc, plasmaSrc, eng, metrics, derive.MemoryBudget{})
+	pipelineDeriver := derive.NewPipelineDeriver(ctx, pipeline, synchronousEvents, tracing.NoopTracer{})
 
 	syncStatusTracker := status.NewStatusTracker(log, metrics)
 
 	syncDeriver := &driver.SyncDeriver{
-		Derivation:     pipeline,
-		Finalizer:      finalizer,
-		SafeHeadNotifs: safeHeadListener,
-		CLSync:         clSync,
-		Engine:         ec,
-		SyncCfg:        syncCfg,
-		Config:         cfg,
-		L1:             l1,
-		L2:             eng,
-		Emitter:        synchronousEvents,
-		Log:            log,
-		Ctx:            ctx,
-		Drain:          synchronousEvents.Drain,
+		Derivation:         pipeline,
+		Finalizer:          finalizer,
+		SafeHeadNotifs:     safeHeadListener,
+		DerivationProgress: pipeline.Progress(),
+		CLSync:             clSync,
+		Engine:             ec,
+		SyncCfg:            syncCfg,
+		Config:             cfg,
+		L1:                 l1,
+		L2:                 eng,
+		Emitter:            synchronousEvents,
+		Log:                log,
+		Ctx:                ctx,
+		Drain:              synchronousEvents.Drain,
 	}
 
 	engDeriv := engine.NewEngDeriver(log, ctx, cfg, ec, synchronousEvents)
@@ -168,19 +170,18 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc deri
 	t.Cleanup(rollupNode.rpc.Stop)
 
 	// setup RPC server for rollup node, hooked to the actor as backend
-	m := &testutils.TestRPCMetrics{}
 	backend := &l2VerifierBackend{verifier: rollupNode}
 	apis := []rpc.API{
 		{
 			Namespace:     "optimism",
-			Service:       node.NewNodeAPI(cfg, eng, backend, safeHeadListener, log, m),
+			Service:       node.NewNodeAPI(cfg, eng, backend, safeHeadListener, log),
 			Public:        true,
 			Authenticated: false,
 		},
 		{
 			Namespace:     "admin",
 			Version:       "",
-			Service:       node.NewAdminAPI(backend, m, log),
+			Service:       node.NewAdminAPI(backend, nil, log),
 			Public:        true, // TODO: this field is deprecated. Do we even need this anymore?
 			Authenticated: false,
 		},
@@ -202,6 +203,14 @@ func (s *l2VerifierBackend) SyncStatus(ctx context.Context) (*eth.SyncStatus, er
 	return s.verifier.SyncStatus(), nil
 }
 
+func (s *l2VerifierBackend) SubscribeSyncStatus(ch chan<- *eth.SyncStatus) (unsubscribe func()) {
+	return s.verifier.syncStatus.Subscribe(ch)
+}
+
+func (s *l2VerifierBackend) DerivationProgressAtL1(ctx context.Context, num uint64) (*eth.DerivationProgress, error) {
+	return s.verifier.derivation.Progress().AtL1(num)
+}
+
 func (s *l2VerifierBackend) ResetDerivationPipeline(ctx context.Context) error {
 	s.verifier.derivation.Reset()
 	return nil
@@ -223,6 +232,47 @@ func (s *l2VerifierBackend) OverrideLeader(ctx context.Context) error {
 	return nil
 }
 
+func (s *l2VerifierBackend) ExportSequencerState(ctx context.Context) (*eth.SequencerState, error) {
+	return nil, errors.New("exporting the L2Verifier sequencer state is not supported")
+}
+
+func (s *l2VerifierBackend) ImportSequencerState(ctx context.Context, state *eth.SequencerState) error {
+	return errors.New("importing the L2Verifier sequencer state is not supported")
+}
+
+func (s *l2VerifierBackend) AddInclusionDeadline(ctx context.Context, txHash common.Hash, blocks uint64) (eth.InclusionDeadline, error) {
+	return eth.InclusionDeadline{}, errors.New("inclusion deadlines are not supported by the L2Verifier")
+}
+
+func (s *l2VerifierBackend) RemoveInclusionDeadline(ctx context.Context, txHash common.Hash) error {
+	return errors.New("inclusion deadlines are not supported by the L2Verifier")
+}
+
+func (s *l2VerifierBackend) InclusionDeadlines(ctx context.Context) ([]eth.InclusionDeadline, error) {
+	return nil, errors.New("inclusion deadlines are not supported by the L2Verifier")
+}
+
+func (s *l2VerifierBackend) SetSequencerPolicy(ctx context.Context, policy *eth.TxPoolPolicy) error {
+	return errors.New("sequencer policies are not supported by the L2Verifier")
+}
+
+func (s *l2VerifierBackend) SequencerPolicy(ctx context.Context) (*eth.TxPoolPolicy, error) {
+	return nil, errors.New("sequencer policies are not supported by the L2Verifier")
+}
+
+func (s *l2VerifierBackend) UnsafeReorgHalt(ctx context.Context) (*eth.UnsafeReorgHalt, error) {
+	return s.verifier.engine.ReorgGuard().Halt(), nil
+}
+
+func (s *l2VerifierBackend) ConfirmUnsafeReorg(ctx context.Context) error {
+	_, err := s.verifier.engine.ReorgGuard().Confirm()
+	return err
+}
+
+func (s *l2VerifierBackend) BuilderStatus(ctx context.Context) (*eth.BuilderStatus, error) {
+	return s.verifier.engine.BuilderStats().Status(), nil
+}
+
 func (s *l2VerifierBackend) OnUnsafeL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
 	return nil
 }
@@ -0,0 +1,25 @@
+package op_e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuilder runs the system with an external block builder, and checks that the sequencer seals builder blocks.
+func TestBuilder(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	cfg.Builder = true
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	// the transaction is only in the mempool of the builder, it can only be included by a builder block
+	receipt := SendL2Tx(t, cfg, sys.Clients["builder"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.VerifyOnClients(sys.Clients["sequencer"], sys.Clients["verifier"])
+	})
+	require.NotZero(t, receipt.BlockNumber.Uint64())
+	require.NotZero(t, sys.BuilderSidecar.BuilderBlocks())
+}
@@ -0,0 +1,396 @@
+// Package builder provides a block builder sidecar for local devnets and e2e tests.
+//
+// The sidecar is an authenticated engine API proxy between a sequencer rollup node and its execution engine.
+// Engine API calls are mirrored to an external builder, so the builder follows the chain and builds blocks
+// for the same payload attributes. When the sequencer seals a block, the block of the builder is used
+// if the execution engine of the sequencer accepts it, and the locally built block otherwise.
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+)
+
+// maxRequestSize bounds the size of the JSON-RPC requests that are proxied, blocks can be large.
+const maxRequestSize = 64 * 1024 * 1024
+
+// builderTimeout bounds the calls to the builder, so a slow builder does not stall block building.
+const builderTimeout = 2 * time.Second
+
+// statusWindow is the number of recently sealed blocks of the builder status.
+const statusWindow = 100
+
+// statusMethod reports the builder status to the sequencer, see [eth.BuilderStatus].
+const statusMethod = "builder_status"
+
+// internalErrorCode is the JSON-RPC error code of errors that do not carry a code.
+const internalErrorCode = -32603
+
+// newPayloadMethods maps the getPayload version to the newPayload version that accepts its payloads.
+var newPayloadMethods = map[string]eth.EngineAPIMethod{
+	string(eth.GetPayloadV2): eth.NewPayloadV2,
+	string(eth.GetPayloadV3): eth.NewPayloadV3,
+}
+
+// builderPayload is a block that the builder is building.
+type builderPayload struct {
+	id eth.PayloadID
+	// deposits are the transactions of the payload attributes, that the deposits of the block must match
+	deposits []eth.Data
+}
+
+type jsonError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+type jsonrpcMessage struct {
+	Version string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id,omitempty"`
+	Method  string            `json:"method,omitempty"`
+	Params  []json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage   `json:"result,omitempty"`
+	Error   *jsonError        `json:"error,omitempty"`
+}
+
+// Sidecar proxies the engine API of a sequencer to its execution engine, and sources blocks from a builder.
+type Sidecar struct {
+	log     log.Logger
+	secrets *client.JWTSecrets
+
+	engine  *rpc.Client
+	builder *rpc.Client
+
+	srv *httputil.HTTPServer
+
+	mu sync.Mutex
+	// payloads maps the payload IDs of the execution engine to the blocks that the builder is building
+	payloads map[eth.PayloadID]builderPayload
+	// sealed is a ring buffer of whether the recently sealed blocks were built by the builder
+	sealed     [statusWindow]bool
+	sealedNext int
+	sealedLen  int
+
+	// builderDown is true if the last call to the builder failed
+	builderDown atomic.Bool
+
+	builderBlocks atomic.Uint64
+	localBlocks   atomic.Uint64
+}
+
+// NewSidecar dials the authenticated RPC endpoints of the execution engine and the builder.
+// The builder may also be a Unix domain socket ("unix://<path>"), or a builder registered in-process
+// with RegisterInProc ("inproc://<name>"), to avoid the HTTP overhead for co-located builders.
+// The sequencer authenticates with the same JWT secret as the execution engine.
+func NewSidecar(ctx context.Context, log log.Logger, engineAddr string, builderAddr string, jwtSecret [32]byte) (*Sidecar, error) {
+	secrets := client.NewJWTSecrets(jwtSecret)
+	engine, err := rpc.DialOptions(ctx, engineAddr, rpc.WithHTTPAuth(client.NewJWTAuth(secrets)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial execution engine: %w", err)
+	}
+	builder, err := dialBuilder(ctx, builderAddr, secrets)
+	if err != nil {
+		engine.Close()
+		return nil, fmt.Errorf("failed to dial builder: %w", err)
+	}
+	return &Sidecar{
+		log:      log,
+		secrets:  secrets,
+		engine:   engine,
+		builder:  builder,
+		payloads: make(map[eth.PayloadID]builderPayload),
+	}, nil
+}
+
+// Start serves the proxied engine API over HTTP on addr.
+func (s *Sidecar) Start(addr string) error {
+	srv, err := httputil.StartHTTPServer(addr, oprpc.NewJWTHandler(s.secrets, s))
+	if err != nil {
+		return fmt.Errorf("failed to start builder sidecar: %w", err)
+	}
+	s.srv = srv
+	s.log.Info("Started builder sidecar", "addr", srv.Addr())
+	return nil
+}
+
+// Endpoint is the authenticated RPC endpoint that the sequencer connects to instead of its execution engine.
+func (s *Sidecar) Endpoint() string {
+	return "http://" + s.srv.Addr().String()
+}
+
+// BuilderBlocks returns the number of sealed blocks that were built by the builder.
+func (s *Sidecar) BuilderBlocks() uint64 {
+	return s.builderBlocks.Load()
+}
+
+// LocalBlocks returns the number of sealed blocks that were built by the execution engine of the sequencer,
+// because the builder had no block, or the block of the builder was rejected.
+func (s *Sidecar) LocalBlocks() uint64 {
+	return s.localBlocks.Load()
+}
+
+// Status returns the availability of the builder, and how many recently sealed blocks were not built by the builder.
+func (s *Sidecar) Status() *eth.BuilderStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := &eth.BuilderStatus{
+		Available: !s.builderDown.Load(),
+		Blocks:    hexutil.Uint64(s.sealedLen),
+	}
+	for i := 0; i < s.sealedLen; i++ {
+		if !s.sealed[i] {
+			status.Failures++
+		}
+	}
+	return status
+}
+
+// recordSealed records whether a sealed block was built by the builder.
+func (s *Sidecar) recordSealed(built bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sealed[s.sealedNext] = built
+	s.sealedNext = (s.sealedNext + 1) % statusWindow
+	s.sealedLen = min(s.sealedLen+1, statusWindow)
+}
+
+func (s *Sidecar) Close() error {
+	var result error
+	if s.srv != nil {
+		result = s.srv.Close()
+	}
+	s.engine.Close()
+	s.builder.Close()
+	return result
+}
+
+func (s *Sidecar) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	body = bytes.TrimSpace(body)
+	batch := len(body) > 0 && body[0] == '['
+	var msgs []*jsonrpcMessage
+	if batch {
+		err = json.Unmarshal(body, &msgs)
+	} else {
+		msg := new(jsonrpcMessage)
+		err = json.Unmarshal(body, msg)
+		msgs = []*jsonrpcMessage{msg}
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid JSON-RPC request: %v", err), http.StatusBadRequest)
+		return
+	}
+	responses := make([]*jsonrpcMessage, len(msgs))
+	for i, msg := range msgs {
+		responses[i] = s.handle(r.Context(), msg)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	if batch {
+		err = enc.Encode(responses)
+	} else {
+		err = enc.Encode(responses[0])
+	}
+	if err != nil {
+		s.log.Warn("Failed to write response", "err", err)
+	}
+}
+
+func (s *Sidecar) handle(ctx context.Context, msg *jsonrpcMessage) *jsonrpcMessage {
+	var result json.RawMessage
+	var err error
+	switch msg.Method {
+	case string(eth.FCUV1), string(eth.FCUV2), string(eth.FCUV3):
+		result, err = s.forkchoiceUpdated(ctx, msg.Method, msg.Params)
+	case string(eth.NewPayloadV2), string(eth.NewPayloadV3):
+		result, err = s.newPayload(ctx, msg.Method, msg.Params)
+	case string(eth.GetPayloadV2), string(eth.GetPayloadV3):
+		result, err = s.getPayload(ctx, msg.Method, msg.Params)
+	case statusMethod:
+		result, err = json.Marshal(s.Status())
+	default:
+		result, err = call(ctx, s.engine, msg.Method, msg.Params)
+	}
+	res := &jsonrpcMessage{Version: "2.0", ID: msg.ID}
+	if err != nil {
+		res.Error = toJSONError(err)
+	} else {
+		res.Result = result
+	}
+	return res
+}
+
+// forkchoiceUpdated updates the forkchoice of the execution engine and the builder.
+// If block building is started, the builder starts building a block with the same payload attributes.
+func (s *Sidecar) forkchoiceUpdated(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	result, err := call(ctx, s.engine, method, params)
+	if err != nil {
+		return nil, err
+	}
+	builderResult, err := s.callBuilder(ctx, method, params)
+	if err != nil {
+		s.log.Warn("Builder failed to update forkchoice", "method", method, "err", err)
+		return result, nil
+	}
+	if len(params) < 2 || bytes.Equal(params[1], []byte("null")) {
+		return result, nil
+	}
+	var attrs eth.PayloadAttributes
+	if err := json.Unmarshal(params[1], &attrs); err != nil {
+		return nil, fmt.Errorf("invalid payload attributes: %w", err)
+	}
+	var local, built eth.ForkchoiceUpdatedResult
+	if err := json.Unmarshal(result, &local); err != nil {
+		return nil, fmt.Errorf("invalid forkchoice update result of execution engine: %w", err)
+	}
+	if err := json.Unmarshal(builderResult, &built); err != nil {
+		s.log.Warn("Invalid forkchoice update result of builder", "err", err)
+		return result, nil
+	}
+	if local.PayloadID == nil || built.PayloadID == nil {
+		s.log.Warn("Builder did not start building a block", "status", built.PayloadStatus.Status)
+		return result, nil
+	}
+	s.mu.Lock()
+	s.payloads[*local.PayloadID] = builderPayload{id: *built.PayloadID, deposits: attrs.Transactions}
+	s.mu.Unlock()
+	return result, nil
+}
+
+// newPayload inserts the block into the execution engine and the builder.
+func (s *Sidecar) newPayload(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	result, err := call(ctx, s.engine, method, params)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.callBuilder(ctx, method, params); err != nil {
+		s.log.Warn("Builder failed to insert block", "method", method, "err", err)
+	}
+	return result, nil
+}
+
+// getPayload returns the block of the builder if the execution engine accepts it, and the locally built block otherwise.
+func (s *Sidecar) getPayload(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	if len(params) == 1 {
+		var id eth.PayloadID
+		if err := json.Unmarshal(params[0], &id); err == nil {
+			s.mu.Lock()
+			built, ok := s.payloads[id]
+			delete(s.payloads, id)
+			s.mu.Unlock()
+			if ok {
+				result, err := s.builderPayload(ctx, method, built)
+				if err == nil {
+					s.builderBlocks.Add(1)
+					s.recordSealed(true)
+					return result, nil
+				}
+				s.log.Warn("Using locally built block, builder block is not available", "id", id, "err", err)
+			}
+		}
+	}
+	result, err := call(ctx, s.engine, method, params)
+	if err != nil {
+		return nil, err
+	}
+	s.localBlocks.Add(1)
+	s.recordSealed(false)
+	return result, nil
+}
+
+// builderPayload retrieves the block of the builder, and inserts it into the execution engine to validate it.
+// The deposits of the block must match the deposits of the payload attributes.
+func (s *Sidecar) builderPayload(ctx context.Context, method string, built builderPayload) (json.RawMessage, error) {
+	rawID, err := json.Marshal(built.id)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.callBuilder(ctx, method, []json.RawMessage{rawID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block from builder: %w", err)
+	}
+	var envelope eth.ExecutionPayloadEnvelope
+	if err := json.Unmarshal(result, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid block of builder: %w", err)
+	}
+	if envelope.ExecutionPayload == nil {
+		return nil, errors.New("builder returned no block")
+	}
+	if _, err := eth.CheckDeposits(envelope.ExecutionPayload.Transactions, built.deposits); err != nil {
+		return nil, fmt.Errorf("invalid deposits in builder block %s: %w", envelope.ExecutionPayload.ID(), err)
+	}
+	var status eth.PayloadStatusV1
+	newPayloadMethod := newPayloadMethods[method]
+	if newPayloadMethod == eth.NewPayloadV3 {
+		err = s.engine.CallContext(ctx, &status, string(newPayloadMethod), envelope.ExecutionPayload, []common.Hash{}, envelope.ParentBeaconBlockRoot)
+	} else {
+		err = s.engine.CallContext(ctx, &status, string(newPayloadMethod), envelope.ExecutionPayload)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert builder block %s: %w", envelope.ExecutionPayload.ID(), err)
+	}
+	if err := eth.NewPayloadErr(envelope.ExecutionPayload, &status); err != nil {
+		return nil, fmt.Errorf("execution engine rejected builder block: %w", err)
+	}
+	s.log.Info("Using builder block", "block", envelope.ExecutionPayload.ID(), "txs", len(envelope.ExecutionPayload.Transactions))
+	return result, nil
+}
+
+func (s *Sidecar) callBuilder(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, builderTimeout)
+	defer cancel()
+	result, err := call(ctx, s.builder, method, params)
+	s.builderDown.Store(err != nil)
+	return result, err
+}
+
+// call forwards the call with the raw params.
+func call(ctx context.Context, cl *rpc.Client, method string, params []json.RawMessage) (json.RawMessage, error) {
+	args := make([]any, len(params))
+	for i, p := range params {
+		args[i] = p
+	}
+	var result json.RawMessage
+	if err := cl.CallContext(ctx, &result, method, args...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// toJSONError keeps the error code and data of errors of the execution engine,
+// as the rollup node acts on engine API error codes.
+func toJSONError(err error) *jsonError {
+	out := &jsonError{Code: internalErrorCode, Message: err.Error()}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		out.Code = rpcErr.ErrorCode()
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		out.Data = dataErr.ErrorData()
+	}
+	return out
+}
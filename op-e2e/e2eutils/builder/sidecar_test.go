@@ -0,0 +1,232 @@
+package builder
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var testSecret = [32]byte{1, 2, 3}
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string  { return "engine error" }
+func (e *codeError) ErrorCode() int { return e.code }
+
+type fakeEngine struct {
+	mu        sync.Mutex
+	id        eth.PayloadID
+	block     common.Hash
+	txs       []eth.Data // overrides the transactions of the payload attributes
+	status    eth.ExecutePayloadStatus
+	fcuErr    error
+	fcus      []*eth.PayloadAttributes
+	newBlocks []common.Hash
+}
+
+func (f *fakeEngine) ForkchoiceUpdatedV3(state eth.ForkchoiceState, attrs *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fcuErr != nil {
+		return nil, f.fcuErr
+	}
+	f.fcus = append(f.fcus, attrs)
+	res := &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}
+	if attrs != nil {
+		id := f.id
+		res.PayloadID = &id
+	}
+	return res, nil
+}
+
+func (f *fakeEngine) GetPayloadV3(id eth.PayloadID) (*eth.ExecutionPayloadEnvelope, error) {
+	if id != f.id {
+		return nil, &codeError{code: int(eth.UnknownPayload)}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// the block includes the transactions of the payload attributes, unless overridden
+	txs := f.txs
+	if txs == nil && len(f.fcus) > 0 && f.fcus[len(f.fcus)-1] != nil {
+		txs = f.fcus[len(f.fcus)-1].Transactions
+	}
+	root := common.Hash{0xbe}
+	return &eth.ExecutionPayloadEnvelope{
+		ParentBeaconBlockRoot: &root,
+		ExecutionPayload:      &eth.ExecutionPayload{BlockHash: f.block, BlockNumber: 1, Transactions: txs},
+	}, nil
+}
+
+func (f *fakeEngine) NewPayloadV3(payload *eth.ExecutionPayload, versionedHashes []common.Hash, root *common.Hash) (*eth.PayloadStatusV1, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.newBlocks = append(f.newBlocks, payload.BlockHash)
+	return &eth.PayloadStatusV1{Status: f.status}, nil
+}
+
+type fakeEth struct{}
+
+func (fakeEth) ChainId() hexutil.Uint64 { return 901 }
+
+func startFakeEngine(t *testing.T, f *fakeEngine) string {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("engine", f))
+	require.NoError(t, srv.RegisterName("eth", fakeEth{}))
+	httpSrv := httptest.NewServer(oprpc.NewJWTHandler(client.NewJWTSecrets(testSecret), srv))
+	t.Cleanup(httpSrv.Close)
+	t.Cleanup(srv.Stop)
+	return httpSrv.URL
+}
+
+func depositTx(t *testing.T, sourceHash common.Hash) eth.Data {
+	tx, err := types.NewTx(&types.DepositTx{SourceHash: sourceHash, Value: new(big.Int)}).MarshalBinary()
+	require.NoError(t, err)
+	return tx
+}
+
+func setupSidecar(t *testing.T) (*fakeEngine, *fakeEngine, *Sidecar, *rpc.Client) {
+	local := &fakeEngine{id: eth.PayloadID{1}, block: common.Hash{0xaa}, status: eth.ExecutionValid}
+	builder := &fakeEngine{id: eth.PayloadID{2}, block: common.Hash{0xbb}, status: eth.ExecutionValid}
+	sidecar, cl := startSidecar(t, local, startFakeEngine(t, builder))
+	return local, builder, sidecar, cl
+}
+
+func startSidecar(t *testing.T, local *fakeEngine, builderAddr string) (*Sidecar, *rpc.Client) {
+	ctx := context.Background()
+	sidecar, err := NewSidecar(ctx, testlog.Logger(t, log.LevelDebug), startFakeEngine(t, local), builderAddr, testSecret)
+	require.NoError(t, err)
+	require.NoError(t, sidecar.Start("127.0.0.1:0"))
+	t.Cleanup(func() { require.NoError(t, sidecar.Close()) })
+	cl, err := rpc.DialOptions(ctx, sidecar.Endpoint(), rpc.WithHTTPAuth(client.NewJWTAuth(client.NewJWTSecrets(testSecret))))
+	require.NoError(t, err)
+	t.Cleanup(cl.Close)
+	return sidecar, cl
+}
+
+func buildBlock(t *testing.T, cl *rpc.Client) *eth.ExecutionPayloadEnvelope {
+	ctx := context.Background()
+	var fcRes eth.ForkchoiceUpdatedResult
+	require.NoError(t, cl.CallContext(ctx, &fcRes, string(eth.FCUV3), &eth.ForkchoiceState{}, &eth.PayloadAttributes{Timestamp: 2, Transactions: []eth.Data{depositTx(t, common.Hash{0x01})}}))
+	require.NotNil(t, fcRes.PayloadID)
+	var envelope eth.ExecutionPayloadEnvelope
+	require.NoError(t, cl.CallContext(ctx, &envelope, string(eth.GetPayloadV3), fcRes.PayloadID))
+	return &envelope
+}
+
+func TestSidecar(t *testing.T) {
+	t.Run("BuilderBlock", func(t *testing.T) {
+		local, builder, sidecar, cl := setupSidecar(t)
+		envelope := buildBlock(t, cl)
+		require.Equal(t, builder.block, envelope.ExecutionPayload.BlockHash)
+		require.Equal(t, common.Hash{0xbe}, *envelope.ParentBeaconBlockRoot)
+		require.Len(t, builder.fcus, 1)
+		// the builder block was validated by the execution engine of the sequencer
+		require.Equal(t, []common.Hash{builder.block}, local.newBlocks)
+		require.Equal(t, uint64(1), sidecar.BuilderBlocks())
+		require.Zero(t, sidecar.LocalBlocks())
+
+		// inserted blocks and forkchoice updates reach the builder too
+		var status eth.PayloadStatusV1
+		require.NoError(t, cl.CallContext(context.Background(), &status, string(eth.NewPayloadV3), envelope.ExecutionPayload, []common.Hash{}, envelope.ParentBeaconBlockRoot))
+		require.Equal(t, eth.ExecutionValid, status.Status)
+		require.Equal(t, []common.Hash{builder.block}, builder.newBlocks)
+		var fcRes eth.ForkchoiceUpdatedResult
+		require.NoError(t, cl.CallContext(context.Background(), &fcRes, string(eth.FCUV3), &eth.ForkchoiceState{HeadBlockHash: builder.block}, nil))
+		require.Len(t, builder.fcus, 2)
+	})
+	t.Run("RejectedBuilderBlock", func(t *testing.T) {
+		local, _, sidecar, cl := setupSidecar(t)
+		local.status = eth.ExecutionInvalid
+		envelope := buildBlock(t, cl)
+		require.Equal(t, local.block, envelope.ExecutionPayload.BlockHash)
+		require.Zero(t, sidecar.BuilderBlocks())
+		require.Equal(t, uint64(1), sidecar.LocalBlocks())
+	})
+	t.Run("InvalidBuilderDeposits", func(t *testing.T) {
+		local, builder, sidecar, cl := setupSidecar(t)
+		builder.txs = []eth.Data{depositTx(t, common.Hash{0x02})}
+		envelope := buildBlock(t, cl)
+		require.Equal(t, local.block, envelope.ExecutionPayload.BlockHash)
+		require.Empty(t, local.newBlocks)
+		require.Equal(t, uint64(1), sidecar.LocalBlocks())
+	})
+	t.Run("BuilderUnavailable", func(t *testing.T) {
+		local, builder, sidecar, cl := setupSidecar(t)
+		builder.fcuErr = &codeError{code: int(eth.InvalidForkchoiceState)}
+		envelope := buildBlock(t, cl)
+		require.Equal(t, local.block, envelope.ExecutionPayload.BlockHash)
+		require.Empty(t, local.newBlocks)
+		require.Equal(t, uint64(1), sidecar.LocalBlocks())
+	})
+	t.Run("Status", func(t *testing.T) {
+		local, builder, _, cl := setupSidecar(t)
+		var status eth.BuilderStatus
+		require.NoError(t, cl.CallContext(context.Background(), &status, statusMethod))
+		require.Equal(t, eth.BuilderStatus{Available: true}, status)
+
+		buildBlock(t, cl)
+		builder.fcuErr = &codeError{code: int(eth.InvalidForkchoiceState)}
+		buildBlock(t, cl)
+		require.NoError(t, cl.CallContext(context.Background(), &status, statusMethod))
+		require.Equal(t, eth.BuilderStatus{Available: false, Blocks: 2, Failures: 1}, status)
+		require.Equal(t, 0.5, status.FailureRate())
+
+		// only the recently sealed blocks are reported
+		builder.fcuErr = nil
+		for i := 0; i < statusWindow; i++ {
+			buildBlock(t, cl)
+		}
+		require.NoError(t, cl.CallContext(context.Background(), &status, statusMethod))
+		require.Equal(t, eth.BuilderStatus{Available: true, Blocks: statusWindow}, status)
+		require.Len(t, local.newBlocks, statusWindow+1)
+	})
+	t.Run("EngineErrorCodes", func(t *testing.T) {
+		local, _, _, cl := setupSidecar(t)
+		local.fcuErr = &codeError{code: int(eth.InvalidForkchoiceState)}
+		var fcRes eth.ForkchoiceUpdatedResult
+		err := cl.CallContext(context.Background(), &fcRes, string(eth.FCUV3), &eth.ForkchoiceState{}, nil)
+		var rpcErr rpc.Error
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, int(eth.InvalidForkchoiceState), rpcErr.ErrorCode())
+	})
+	t.Run("ProxiesOtherCalls", func(t *testing.T) {
+		_, _, _, cl := setupSidecar(t)
+		var chainID hexutil.Uint64
+		require.NoError(t, cl.CallContext(context.Background(), &chainID, "eth_chainId"))
+		require.Equal(t, hexutil.Uint64(901), chainID)
+
+		batch := []rpc.BatchElem{
+			{Method: "eth_chainId", Result: new(hexutil.Uint64)},
+			{Method: "eth_nope", Result: new(hexutil.Uint64)},
+		}
+		require.NoError(t, cl.BatchCallContext(context.Background(), batch))
+		require.NoError(t, batch[0].Error)
+		require.Equal(t, hexutil.Uint64(901), *batch[0].Result.(*hexutil.Uint64))
+		require.Error(t, batch[1].Error)
+	})
+	t.Run("RequiresJWT", func(t *testing.T) {
+		_, _, sidecar, _ := setupSidecar(t)
+		resp, err := http.Post(sidecar.Endpoint(), "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
@@ -0,0 +1,115 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// UnixScheme prefixes the path of a builder that serves the engine API on a Unix domain socket.
+	// The socket is not authenticated with the JWT secret, access is controlled by the file permissions of the socket.
+	UnixScheme = "unix://"
+	// InProcScheme prefixes the name of a builder that is registered in-process with RegisterInProc.
+	InProcScheme = "inproc://"
+)
+
+// Builder is a block builder that runs in the same process as the sidecar, e.g. a co-located builder,
+// and is called without a network transport.
+type Builder interface {
+	ForkchoiceUpdated(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error)
+	GetPayload(ctx context.Context, id eth.PayloadID) (*eth.ExecutionPayloadEnvelope, error)
+}
+
+var (
+	inProcMu       sync.Mutex
+	inProcBuilders = make(map[string]*rpc.Server)
+)
+
+// RegisterInProc registers the builder under the given name, for sidecars with the builder address "inproc://<name>".
+// The returned function unregisters the builder.
+func RegisterInProc(name string, b Builder) (func(), error) {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("engine", &inProcAPI{b: b}); err != nil {
+		return nil, fmt.Errorf("failed to register builder API: %w", err)
+	}
+	inProcMu.Lock()
+	defer inProcMu.Unlock()
+	if _, ok := inProcBuilders[name]; ok {
+		srv.Stop()
+		return nil, fmt.Errorf("builder %q is already registered", name)
+	}
+	inProcBuilders[name] = srv
+	return func() {
+		inProcMu.Lock()
+		defer inProcMu.Unlock()
+		if inProcBuilders[name] == srv {
+			delete(inProcBuilders, name)
+		}
+		srv.Stop()
+	}, nil
+}
+
+// dialBuilder dials the builder at addr: an in-process builder, a Unix domain socket, or an authenticated HTTP or websocket endpoint.
+func dialBuilder(ctx context.Context, addr string, secrets *client.JWTSecrets) (*rpc.Client, error) {
+	switch {
+	case strings.HasPrefix(addr, InProcScheme):
+		name := strings.TrimPrefix(addr, InProcScheme)
+		inProcMu.Lock()
+		srv, ok := inProcBuilders[name]
+		inProcMu.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("no in-process builder %q registered", name)
+		}
+		return rpc.DialInProc(srv), nil
+	case strings.HasPrefix(addr, UnixScheme):
+		path := strings.TrimPrefix(addr, UnixScheme)
+		if err := client.CheckUnixSocket(path); err != nil {
+			return nil, fmt.Errorf("invalid builder socket: %w", err)
+		}
+		return rpc.DialIPC(ctx, path)
+	default:
+		return rpc.DialOptions(ctx, addr, rpc.WithHTTPAuth(client.NewJWTAuth(secrets)))
+	}
+}
+
+// inProcAPI serves the versioned engine API methods that the sidecar calls on a builder.
+type inProcAPI struct {
+	b Builder
+}
+
+func (api *inProcAPI) ForkchoiceUpdatedV1(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	return api.b.ForkchoiceUpdated(ctx, state, attr)
+}
+
+func (api *inProcAPI) ForkchoiceUpdatedV2(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	return api.b.ForkchoiceUpdated(ctx, state, attr)
+}
+
+func (api *inProcAPI) ForkchoiceUpdatedV3(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	return api.b.ForkchoiceUpdated(ctx, state, attr)
+}
+
+func (api *inProcAPI) NewPayloadV2(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
+	return api.b.NewPayload(ctx, payload, nil)
+}
+
+func (api *inProcAPI) NewPayloadV3(ctx context.Context, payload *eth.ExecutionPayload, versionedHashes []common.Hash, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	return api.b.NewPayload(ctx, payload, parentBeaconBlockRoot)
+}
+
+func (api *inProcAPI) GetPayloadV2(ctx context.Context, id eth.PayloadID) (*eth.ExecutionPayloadEnvelope, error) {
+	return api.b.GetPayload(ctx, id)
+}
+
+func (api *inProcAPI) GetPayloadV3(ctx context.Context, id eth.PayloadID) (*eth.ExecutionPayloadEnvelope, error) {
+	return api.b.GetPayload(ctx, id)
+}
@@ -0,0 +1,80 @@
+package builder
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// inProcBuilder serves a fakeEngine as an in-process Builder.
+type inProcBuilder struct {
+	f *fakeEngine
+}
+
+func (b *inProcBuilder) ForkchoiceUpdated(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	return b.f.ForkchoiceUpdatedV3(*state, attr)
+}
+
+func (b *inProcBuilder) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	return b.f.NewPayloadV3(payload, nil, parentBeaconBlockRoot)
+}
+
+func (b *inProcBuilder) GetPayload(ctx context.Context, id eth.PayloadID) (*eth.ExecutionPayloadEnvelope, error) {
+	return b.f.GetPayloadV3(id)
+}
+
+func TestBuilderTransports(t *testing.T) {
+	t.Run("Unix", func(t *testing.T) {
+		builder := &fakeEngine{id: eth.PayloadID{2}, block: common.Hash{0xbb}, status: eth.ExecutionValid}
+		srv := rpc.NewServer()
+		require.NoError(t, srv.RegisterName("engine", builder))
+		path := filepath.Join(t.TempDir(), "builder.ipc")
+		l, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		go func() { _ = srv.ServeListener(l) }()
+		t.Cleanup(func() {
+			srv.Stop()
+			_ = l.Close()
+		})
+
+		local := &fakeEngine{id: eth.PayloadID{1}, block: common.Hash{0xaa}, status: eth.ExecutionValid}
+		sidecar, cl := startSidecar(t, local, UnixScheme+path)
+		envelope := buildBlock(t, cl)
+		require.Equal(t, builder.block, envelope.ExecutionPayload.BlockHash)
+		require.Equal(t, uint64(1), sidecar.BuilderBlocks())
+	})
+	t.Run("InProc", func(t *testing.T) {
+		builder := &fakeEngine{id: eth.PayloadID{2}, block: common.Hash{0xbb}, status: eth.ExecutionValid}
+		unregister, err := RegisterInProc("test", &inProcBuilder{f: builder})
+		require.NoError(t, err)
+		t.Cleanup(unregister)
+		_, err = RegisterInProc("test", &inProcBuilder{f: builder})
+		require.ErrorContains(t, err, "already registered")
+
+		local := &fakeEngine{id: eth.PayloadID{1}, block: common.Hash{0xaa}, status: eth.ExecutionValid}
+		sidecar, cl := startSidecar(t, local, InProcScheme+"test")
+		envelope := buildBlock(t, cl)
+		require.Equal(t, builder.block, envelope.ExecutionPayload.BlockHash)
+		require.Equal(t, common.Hash{0xbe}, *envelope.ParentBeaconBlockRoot)
+		require.Equal(t, uint64(1), sidecar.BuilderBlocks())
+
+		// inserted blocks reach the in-process builder too
+		var status eth.PayloadStatusV1
+		require.NoError(t, cl.CallContext(context.Background(), &status, string(eth.NewPayloadV3), envelope.ExecutionPayload, []common.Hash{}, envelope.ParentBeaconBlockRoot))
+		require.Equal(t, []common.Hash{builder.block}, builder.newBlocks)
+	})
+	t.Run("UnknownInProc", func(t *testing.T) {
+		local := &fakeEngine{id: eth.PayloadID{1}, block: common.Hash{0xaa}, status: eth.ExecutionValid}
+		_, err := NewSidecar(context.Background(), testlog.Logger(t, log.LevelDebug), startFakeEngine(t, local), InProcScheme+"unknown", testSecret)
+		require.ErrorContains(t, err, "no in-process builder")
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/batcher"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/builder"
 	ds "github.com/ipfs/go-datastore"
 	dsSync "github.com/ipfs/go-datastore/sync"
 	ic "github.com/libp2p/go-libp2p/core/crypto"
@@ -230,6 +231,11 @@ type SystemConfig struct {
 	// MaxPendingTransactions determines how many transactions the batcher will try to send
 	// concurrently. 0 means unlimited.
 	MaxPendingTransactions uint64
+
+	// Builder launches an external block builder, an additional L2 execution engine named "builder",
+	// and a builder sidecar between the sequencer and its execution engine. The sequencer seals the blocks
+	// of the builder, if its execution engine accepts them, and falls back to locally built blocks otherwise.
+	Builder bool
 }
 
 type GethInstance struct {
@@ -285,6 +291,9 @@ type System struct {
 
 	L1BeaconAPIAddr string
 
+	// BuilderSidecar is nil unless SystemConfig.Builder was set to true
+	BuilderSidecar *builder.Sidecar
+
 	// TimeTravelClock is nil unless SystemConfig.SupportL1TimeTravel was set to true
 	// It provides access to the clock instance used by the L1 node. Calling TimeTravelClock.AdvanceBy
 	// allows tests to quickly time travel L1 into the future.
@@ -383,6 +392,11 @@ func (sys *System) Close() {
 			combinedErr = errors.Join(combinedErr, fmt.Errorf("stop rollup node %v: %w", name, err))
 		}
 	}
+	if sys.BuilderSidecar != nil {
+		if err := sys.BuilderSidecar.Close(); err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf("stop builder sidecar: %w", err))
+		}
+	}
 	for name, ei := range sys.EthInstances {
 		if err := ei.Close(); err != nil && !errors.Is(err, node.ErrNodeStopped) {
 			combinedErr = errors.Join(combinedErr, fmt.Errorf("stop EthInstance %v: %w", name, err))
@@ -603,6 +617,22 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 		}
 		sys.EthInstances[name] = ethClient
 	}
+	if cfg.Builder {
+		if cfg.ExternalL2Shim != "" {
+			t.Skip("External L2 nodes do not support a builder")
+		}
+		node, backend, err := geth.InitL2("builder", big.NewInt(int64(cfg.DeployConfig.L2ChainID)), l2Genesis, cfg.JWTFilePath, cfg.GethOptions["builder"]...)
+		if err != nil {
+			return nil, err
+		}
+		if err := node.Start(); err != nil {
+			return nil, err
+		}
+		sys.EthInstances["builder"] = &GethInstance{
+			Backend: backend,
+			Node:    node,
+		}
+	}
 
 	// Configure connections to L1 and L2 for rollup nodes.
 	// TODO: refactor testing to allow use of in-process rpc connections instead
@@ -614,6 +644,21 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 			nodeCfg.Beacon = &rollupNode.L1BeaconEndpointConfig{BeaconAddr: sys.L1BeaconAPIAddr}
 		}
 	}
+	if cfg.Builder {
+		sidecar, err := builder.NewSidecar(context.Background(), testlog.Logger(t, log.LevelInfo).New("role", "builder-sidecar"),
+			sys.EthInstances["sequencer"].HTTPAuthEndpoint(), sys.EthInstances["builder"].HTTPAuthEndpoint(), cfg.JWTSecret)
+		if err != nil {
+			return nil, err
+		}
+		if err := sidecar.Start("127.0.0.1:0"); err != nil {
+			return nil, err
+		}
+		sys.BuilderSidecar = sidecar
+		cfg.Nodes["sequencer"].L2 = &rollupNode.L2EndpointConfig{
+			L2EngineAddr:      sidecar.Endpoint(),
+			L2EngineJWTSecret: cfg.JWTSecret,
+		}
+	}
 
 	// Geth Clients
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
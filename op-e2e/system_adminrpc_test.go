@@ -78,6 +78,40 @@ func TestStopStartSequencer(t *testing.T) {
 	)
 }
 
+func TestExportImportSequencerState(t *testing.T) {
+	InitParallel(t)
+	ctx := context.Background()
+
+	cfg := DefaultSystemConfig(t)
+	sys, err := cfg.Start(t)
+	require.NoError(t, err)
+	defer sys.Close()
+
+	l2Seq := sys.Clients["sequencer"]
+	rollupClient := sys.RollupClient("sequencer")
+
+	_, err = rollupClient.ExportSequencerState(ctx)
+	require.ErrorContains(t, err, "sequencer must be stopped")
+
+	require.NoError(t, wait.ForNextBlock(ctx, l2Seq))
+	head, err := rollupClient.StopSequencer(ctx)
+	require.NoError(t, err)
+	state, err := rollupClient.ExportSequencerState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, head, state.UnsafeL2.Hash)
+	require.Equal(t, sys.RollupConfig.Genesis.L2, state.L2Genesis)
+
+	modified := *state
+	modified.UnsafeL2.Number++
+	require.ErrorContains(t, rollupClient.ImportSequencerState(ctx, &modified), "checksum mismatch")
+
+	require.NoError(t, rollupClient.ImportSequencerState(ctx, state))
+	active, err := rollupClient.SequencerActive(ctx)
+	require.NoError(t, err)
+	require.True(t, active, "sequencer should be active after import")
+	require.NoError(t, wait.ForNextBlock(ctx, l2Seq), "Chain did not advance after importing sequencer state")
+}
+
 func TestPersistSequencerStateWhenChanged(t *testing.T) {
 	InitParallel(t)
 	ctx := context.Background()
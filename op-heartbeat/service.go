@@ -94,14 +94,7 @@ func Start(ctx context.Context, l log.Logger, cfg Config, version string) (*Hear
 	}
 
 	pprofCfg := cfg.Pprof
-	hs.pprofService = oppprof.New(
-		pprofCfg.ListenEnabled,
-		pprofCfg.ListenAddr,
-		pprofCfg.ListenPort,
-		pprofCfg.ProfileType,
-		pprofCfg.ProfileDir,
-		pprofCfg.ProfileFilename,
-	)
+	hs.pprofService = oppprof.NewFromConfig(pprofCfg)
 
 	if err := hs.pprofService.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start pprof service: %w", err)
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-indexer/bridge"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+
+	pathPrefix = "/api/v0"
+)
+
+type Status struct {
+	// L1NextBlock and L2NextBlock are the next blocks to index, all blocks before are indexed.
+	L1NextBlock hexutil.Uint64 `json:"l1NextBlock"`
+	L2NextBlock hexutil.Uint64 `json:"l2NextBlock"`
+}
+
+type DepositsResponse struct {
+	Deposits []bridge.Deposit `json:"deposits"`
+}
+
+type WithdrawalsResponse struct {
+	Withdrawals []bridge.Withdrawal `json:"withdrawals"`
+}
+
+// NewHandler serves the indexed deposits and withdrawals as JSON:
+//   - GET /api/v0/status: the indexing progress, see Status.
+//   - GET /api/v0/deposits?address=<address>&offset=<n>&limit=<n>: the deposits from or to the address, newest first.
+//   - GET /api/v0/deposits/<hash>: the deposit with the L2 tx hash, or the deposits of the L1 tx hash.
+//   - GET /api/v0/withdrawals?address=<address>&offset=<n>&limit=<n>: the withdrawals from or to the address, newest first.
+//   - GET /api/v0/withdrawals/<hash>: the withdrawal with the withdrawal hash, or the withdrawals of the L2 tx hash.
+func NewHandler(log log.Logger, store *bridge.Store) http.Handler {
+	h := &handler{log: log, store: store}
+	mux := http.NewServeMux()
+	mux.HandleFunc(pathPrefix+"/status", h.status)
+	mux.HandleFunc(pathPrefix+"/deposits", h.depositsByAddress)
+	mux.HandleFunc(pathPrefix+"/deposits/", h.depositsByHash)
+	mux.HandleFunc(pathPrefix+"/withdrawals", h.withdrawalsByAddress)
+	mux.HandleFunc(pathPrefix+"/withdrawals/", h.withdrawalsByHash)
+	return mux
+}
+
+type handler struct {
+	log   log.Logger
+	store *bridge.Store
+}
+
+func (h *handler) status(w http.ResponseWriter, r *http.Request) {
+	if !isGet(w, r) {
+		return
+	}
+	l1Next, l2Next := h.store.Progress()
+	h.writeJSON(w, Status{L1NextBlock: hexutil.Uint64(l1Next), L2NextBlock: hexutil.Uint64(l2Next)})
+}
+
+func (h *handler) depositsByAddress(w http.ResponseWriter, r *http.Request) {
+	if !isGet(w, r) {
+		return
+	}
+	addr, offset, limit, err := addressQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.writeJSON(w, DepositsResponse{Deposits: h.store.DepositsByAddress(addr, offset, limit)})
+}
+
+func (h *handler) depositsByHash(w http.ResponseWriter, r *http.Request) {
+	if !isGet(w, r) {
+		return
+	}
+	hash, err := pathHash(r, pathPrefix+"/deposits/")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	deposits := h.store.DepositsByL1Tx(hash)
+	if d, ok := h.store.Deposit(hash); ok {
+		deposits = []bridge.Deposit{d}
+	}
+	if len(deposits) == 0 {
+		http.Error(w, "deposit not found", http.StatusNotFound)
+		return
+	}
+	h.writeJSON(w, DepositsResponse{Deposits: deposits})
+}
+
+func (h *handler) withdrawalsByAddress(w http.ResponseWriter, r *http.Request) {
+	if !isGet(w, r) {
+		return
+	}
+	addr, offset, limit, err := addressQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.writeJSON(w, WithdrawalsResponse{Withdrawals: h.store.WithdrawalsByAddress(addr, offset, limit)})
+}
+
+func (h *handler) withdrawalsByHash(w http.ResponseWriter, r *http.Request) {
+	if !isGet(w, r) {
+		return
+	}
+	hash, err := pathHash(r, pathPrefix+"/withdrawals/")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	withdrawals := h.store.WithdrawalsByL2Tx(hash)
+	if wd, ok := h.store.Withdrawal(hash); ok {
+		withdrawals = []bridge.Withdrawal{wd}
+	}
+	if len(withdrawals) == 0 {
+		http.Error(w, "withdrawal not found", http.StatusNotFound)
+		return
+	}
+	h.writeJSON(w, WithdrawalsResponse{Withdrawals: withdrawals})
+}
+
+func (h *handler) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.log.Warn("Failed to write API response", "err", err)
+	}
+}
+
+func isGet(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+func pathHash(r *http.Request, prefix string) (common.Hash, error) {
+	var hash common.Hash
+	if err := hash.UnmarshalText([]byte(strings.TrimPrefix(r.URL.Path, prefix))); err != nil {
+		return common.Hash{}, fmt.Errorf("invalid hash: %w", err)
+	}
+	return hash, nil
+}
+
+func addressQuery(r *http.Request) (addr common.Address, offset int, limit int, err error) {
+	query := r.URL.Query()
+	if err := addr.UnmarshalText([]byte(query.Get("address"))); err != nil {
+		return common.Address{}, 0, 0, fmt.Errorf("invalid address: %w", err)
+	}
+	offset, limit = 0, DefaultLimit
+	if v := query.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return common.Address{}, 0, 0, fmt.Errorf("invalid offset: %q", v)
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 || limit > MaxLimit {
+			return common.Address{}, 0, 0, fmt.Errorf("invalid limit, must be between 1 and %d: %q", MaxLimit, v)
+		}
+	}
+	return addr, offset, limit, nil
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-indexer/bridge"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var (
+	alice = common.Address{0x01}
+	bob   = common.Address{0x02}
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *bridge.Store) {
+	store := bridge.NewStore(10, 20)
+	srv := httptest.NewServer(NewHandler(testlog.Logger(t, log.LevelInfo), store))
+	t.Cleanup(srv.Close)
+	return srv, store
+}
+
+func getJSON(t *testing.T, url string, out any) int {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK && out != nil {
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func addDeposit(t *testing.T, store *bridge.Store, l1Tx byte, l2Tx byte, from common.Address) {
+	require.NoError(t, store.AddDeposit(bridge.Deposit{
+		From:              from,
+		To:                &bob,
+		OriginalSender:    from,
+		OriginalRecipient: &bob,
+		Mint:              (*hexutil.Big)(big.NewInt(1)),
+		Value:             (*hexutil.Big)(big.NewInt(1)),
+		L1TxHash:          common.Hash{l1Tx},
+		L1BlockNumber:     hexutil.Uint64(l1Tx),
+		L2TxHash:          common.Hash{l2Tx},
+		Status:            bridge.DepositPending,
+	}))
+}
+
+func TestStatus(t *testing.T) {
+	srv, store := newTestServer(t)
+	var status Status
+	require.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/v0/status", &status))
+	require.Equal(t, Status{L1NextBlock: 10, L2NextBlock: 20}, status)
+
+	require.NoError(t, store.SetL1Next(15))
+	require.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/v0/status", &status))
+	require.EqualValues(t, 15, status.L1NextBlock)
+
+	resp, err := http.Post(srv.URL+"/api/v0/status", "application/json", nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestDeposits(t *testing.T) {
+	srv, store := newTestServer(t)
+	addDeposit(t, store, 0x10, 0x20, alice)
+	addDeposit(t, store, 0x11, 0x21, alice)
+	addDeposit(t, store, 0x11, 0x22, bob)
+
+	var res DepositsResponse
+	require.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/v0/deposits?address="+alice.Hex(), &res))
+	require.Len(t, res.Deposits, 2)
+	require.Equal(t, common.Hash{0x21}, res.Deposits[0].L2TxHash)
+	require.Equal(t, common.Hash{0x20}, res.Deposits[1].L2TxHash)
+
+	require.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/v0/deposits?address="+alice.Hex()+"&offset=1&limit=1", &res))
+	require.Len(t, res.Deposits, 1)
+	require.Equal(t, common.Hash{0x20}, res.Deposits[0].L2TxHash)
+
+	// by L2 tx hash
+	require.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/v0/deposits/"+common.Hash{0x22}.Hex(), &res))
+	require.Len(t, res.Deposits, 1)
+	require.Equal(t, bob, res.Deposits[0].From)
+
+	// by L1 tx hash
+	require.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/v0/deposits/"+common.Hash{0x11}.Hex(), &res))
+	require.Len(t, res.Deposits, 2)
+
+	require.Equal(t, http.StatusNotFound, getJSON(t, srv.URL+"/api/v0/deposits/"+common.Hash{0x99}.Hex(), nil))
+	require.Equal(t, http.StatusBadRequest, getJSON(t, srv.URL+"/api/v0/deposits/0x1234", nil))
+}
+
+func TestWithdrawals(t *testing.T) {
+	srv, store := newTestServer(t)
+	l2Tx := common.Hash{0x30}
+	require.NoError(t, store.InitiateWithdrawal(bridge.Withdrawal{
+		WithdrawalHash:    common.Hash{0xa},
+		Nonce:             (*hexutil.Big)(big.NewInt(0)),
+		Sender:            alice,
+		Target:            bob,
+		Value:             (*hexutil.Big)(big.NewInt(1)),
+		GasLimit:          (*hexutil.Big)(big.NewInt(21000)),
+		OriginalSender:    alice,
+		OriginalRecipient: bob,
+		L2TxHash:          &l2Tx,
+	}))
+	require.NoError(t, store.ProveWithdrawal(common.Hash{0xa}, bridge.L1Event{TxHash: common.Hash{0x40}}))
+
+	var res WithdrawalsResponse
+	require.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/v0/withdrawals?address="+bob.Hex(), &res))
+	require.Len(t, res.Withdrawals, 1)
+	require.Equal(t, bridge.WithdrawalProven, res.Withdrawals[0].Status)
+
+	require.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/v0/withdrawals/"+common.Hash{0xa}.Hex(), &res))
+	require.Len(t, res.Withdrawals, 1)
+	require.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/v0/withdrawals/"+l2Tx.Hex(), &res))
+	require.Len(t, res.Withdrawals, 1)
+	require.Equal(t, common.Hash{0xa}, res.Withdrawals[0].WithdrawalHash)
+
+	require.Equal(t, http.StatusNotFound, getJSON(t, srv.URL+"/api/v0/withdrawals/"+common.Hash{0xb}.Hex(), nil))
+}
+
+func TestInvalidQuery(t *testing.T) {
+	srv, _ := newTestServer(t)
+	for _, query := range []string{
+		"",
+		"?address=0x1234",
+		"?address=" + alice.Hex() + "&offset=-1",
+		"?address=" + alice.Hex() + "&limit=0",
+		"?address=" + alice.Hex() + "&limit=501",
+		"?address=" + alice.Hex() + "&limit=abc",
+	} {
+		require.Equal(t, http.StatusBadRequest, getJSON(t, srv.URL+"/api/v0/deposits"+query, nil), query)
+		require.Equal(t, http.StatusBadRequest, getJSON(t, srv.URL+"/api/v0/withdrawals"+query, nil), query)
+	}
+}
@@ -0,0 +1,278 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+var (
+	portalABI, _         = bindings.OptimismPortalMetaData.GetAbi()
+	messagePasserABI, _  = bindings.L2ToL1MessagePasserMetaData.GetAbi()
+	withdrawalProvenID   = portalABI.Events["WithdrawalProven"].ID
+	withdrawalFinalizeID = portalABI.Events["WithdrawalFinalized"].ID
+	messagePassedID      = messagePasserABI.Events["MessagePassed"].ID
+
+	// the bindings only use the ABI to parse logs
+	portalFilterer, _        = bindings.NewOptimismPortalFilterer(common.Address{}, nil)
+	messagePasserFilterer, _ = bindings.NewL2ToL1MessagePasserFilterer(predeploys.L2ToL1MessagePasserAddr, nil)
+)
+
+// Layer labels the chain that is indexed.
+type Layer string
+
+const (
+	L1 Layer = "l1"
+	L2 Layer = "l2"
+)
+
+type LogClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+type L2Client interface {
+	LogClient
+	// BatchCallContext is used to fetch the receipts of the pending deposits in batches.
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// receiptsBatchSize is the maximum number of deposit receipts to fetch in a single batch request.
+const receiptsBatchSize = 100
+
+// errInvalidEvent is returned for events that can't be parsed, which are skipped to not halt the indexer.
+var errInvalidEvent = errors.New("invalid event")
+
+type Metrics interface {
+	RecordIndexedBlock(layer Layer, number uint64)
+	RecordPendingDeposits(count int)
+}
+
+type Config struct {
+	// OptimismPortal is the address of the OptimismPortal proxy in L1.
+	OptimismPortal common.Address
+	// Confirmations is the number of blocks to stay behind the head of each chain, to not index reorged events.
+	Confirmations uint64
+	// MaxBlockRange is the maximum number of blocks to fetch logs for in a single request.
+	MaxBlockRange uint64
+}
+
+// Indexer follows the L1 and L2 bridge events into the store:
+//   - the deposits of the OptimismPortal, and their inclusion in L2.
+//   - the withdrawals of the L2ToL1MessagePasser, and their proofs and finalization by the OptimismPortal.
+type Indexer struct {
+	log   log.Logger
+	m     Metrics
+	cfg   Config
+	l1    LogClient
+	l2    L2Client
+	store *Store
+}
+
+func NewIndexer(log log.Logger, m Metrics, cfg Config, l1 LogClient, l2 L2Client, store *Store) *Indexer {
+	return &Indexer{
+		log:   log,
+		m:     m,
+		cfg:   cfg,
+		l1:    l1,
+		l2:    l2,
+		store: store,
+	}
+}
+
+// Step indexes the L1 and L2 events up to the confirmed heads, and checks the L2 inclusion of pending deposits.
+func (i *Indexer) Step(ctx context.Context) error {
+	l1Next, l2Next := i.store.Progress()
+	if err := i.index(ctx, L1, i.l1, l1Next, i.cfg.OptimismPortal,
+		[]common.Hash{derive.DepositEventABIHash, withdrawalProvenID, withdrawalFinalizeID}, i.processL1Log, i.store.SetL1Next); err != nil {
+		return err
+	}
+	if err := i.index(ctx, L2, i.l2, l2Next, predeploys.L2ToL1MessagePasserAddr,
+		[]common.Hash{messagePassedID}, i.processL2Log, i.store.SetL2Next); err != nil {
+		return err
+	}
+	return i.checkPendingDeposits(ctx)
+}
+
+func (i *Indexer) index(ctx context.Context, layer Layer, cl LogClient, next uint64, addr common.Address, topics []common.Hash,
+	process func(log *types.Log) error, setNext func(n uint64) error) error {
+	head, err := cl.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s head: %w", layer, err)
+	}
+	if head.Number.Uint64() < i.cfg.Confirmations {
+		return nil
+	}
+	confirmed := head.Number.Uint64() - i.cfg.Confirmations
+	for next <= confirmed {
+		to := min(confirmed, next+i.cfg.MaxBlockRange-1)
+		logs, err := cl.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(next),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{addr},
+			Topics:    [][]common.Hash{topics},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s logs of blocks %d-%d: %w", layer, next, to, err)
+		}
+		for j := range logs {
+			if logs[j].Removed {
+				continue
+			}
+			if err := process(&logs[j]); errors.Is(err, errInvalidEvent) {
+				i.log.Warn("Skipping invalid bridge event", "layer", layer, "tx", logs[j].TxHash, "index", logs[j].Index, "err", err)
+			} else if err != nil {
+				return fmt.Errorf("failed to store %s event: %w", layer, err)
+			}
+		}
+		i.log.Debug("Indexed blocks", "layer", layer, "from", next, "to", to, "events", len(logs))
+		next = to + 1
+		if err := setNext(next); err != nil {
+			return fmt.Errorf("failed to store %s progress: %w", layer, err)
+		}
+		i.m.RecordIndexedBlock(layer, to)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (i *Indexer) processL1Log(l *types.Log) error {
+	if len(l.Topics) == 0 {
+		return fmt.Errorf("%w: missing event topic", errInvalidEvent)
+	}
+	ev := L1Event{TxHash: l.TxHash, BlockHash: l.BlockHash, BlockNumber: hexutil.Uint64(l.BlockNumber)}
+	switch l.Topics[0] {
+	case derive.DepositEventABIHash:
+		dep, err := derive.UnmarshalDepositLogEvent(l)
+		if err != nil {
+			return fmt.Errorf("%w: deposit: %w", errInvalidEvent, err)
+		}
+		mint := dep.Mint
+		if mint == nil {
+			mint = new(big.Int)
+		}
+		sender, recipient := dep.From, dep.To
+		if s, r, ok := depositParties(dep.To, dep.Data); ok {
+			sender, recipient = s, &r
+		}
+		return i.store.AddDeposit(Deposit{
+			SourceHash: dep.SourceHash,
+			From:       dep.From,
+			To:         dep.To,
+			Mint:       (*hexutil.Big)(mint),
+			Value:      (*hexutil.Big)(dep.Value),
+			GasLimit:   hexutil.Uint64(dep.Gas),
+			Data:       dep.Data,
+
+			OriginalSender:    sender,
+			OriginalRecipient: recipient,
+
+			L1TxHash:      l.TxHash,
+			L1BlockHash:   l.BlockHash,
+			L1BlockNumber: hexutil.Uint64(l.BlockNumber),
+			L2TxHash:      types.NewTx(dep).Hash(),
+			Status:        DepositPending,
+		})
+	case withdrawalProvenID:
+		proven, err := portalFilterer.ParseWithdrawalProven(*l)
+		if err != nil {
+			return fmt.Errorf("%w: withdrawal proven: %w", errInvalidEvent, err)
+		}
+		return i.store.ProveWithdrawal(proven.WithdrawalHash, ev)
+	case withdrawalFinalizeID:
+		finalized, err := portalFilterer.ParseWithdrawalFinalized(*l)
+		if err != nil {
+			return fmt.Errorf("%w: withdrawal finalized: %w", errInvalidEvent, err)
+		}
+		return i.store.FinalizeWithdrawal(finalized.WithdrawalHash, ev, finalized.Success)
+	}
+	return nil
+}
+
+func (i *Indexer) processL2Log(l *types.Log) error {
+	passed, err := messagePasserFilterer.ParseMessagePassed(*l)
+	if err != nil {
+		return fmt.Errorf("%w: message passed: %w", errInvalidEvent, err)
+	}
+	blockNumber := hexutil.Uint64(l.BlockNumber)
+	txHash, blockHash := l.TxHash, l.BlockHash
+	sender, recipient := passed.Sender, passed.Target
+	if s, r, ok := withdrawalParties(passed.Sender, passed.Data); ok {
+		sender, recipient = s, r
+	}
+	return i.store.InitiateWithdrawal(Withdrawal{
+		WithdrawalHash: passed.WithdrawalHash,
+		Nonce:          (*hexutil.Big)(passed.Nonce),
+		Sender:         passed.Sender,
+		Target:         passed.Target,
+		Value:          (*hexutil.Big)(passed.Value),
+		GasLimit:       (*hexutil.Big)(passed.GasLimit),
+		Data:           passed.Data,
+
+		OriginalSender:    sender,
+		OriginalRecipient: recipient,
+
+		L2TxHash:      &txHash,
+		L2BlockHash:   &blockHash,
+		L2BlockNumber: &blockNumber,
+	})
+}
+
+// checkPendingDeposits looks up the L2 receipts of the deposits that are not yet included in L2, in batches.
+// The deposit transaction hash is known upfront, so no L2 blocks have to be scanned for deposits.
+func (i *Indexer) checkPendingDeposits(ctx context.Context) error {
+	pending := i.store.PendingDeposits()
+	if len(pending) == 0 {
+		i.m.RecordPendingDeposits(0)
+		return nil
+	}
+	head, err := i.l2.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s head: %w", L2, err)
+	}
+	for start := 0; start < len(pending); start += receiptsBatchSize {
+		batch := pending[start:min(start+receiptsBatchSize, len(pending))]
+		receipts := make([]*types.Receipt, len(batch))
+		elems := make([]rpc.BatchElem, len(batch))
+		for j, txHash := range batch {
+			elems[j] = rpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []any{txHash},
+				Result: &receipts[j],
+			}
+		}
+		if err := i.l2.BatchCallContext(ctx, elems); err != nil {
+			return fmt.Errorf("failed to fetch receipts of deposits: %w", err)
+		}
+		for j, txHash := range batch {
+			if elems[j].Error != nil {
+				return fmt.Errorf("failed to fetch receipt of deposit %s: %w", txHash, elems[j].Error)
+			}
+			receipt := receipts[j]
+			// the receipt is null if the deposit is not yet included.
+			// like the indexed events, only consider the deposit included once it is confirmed
+			if receipt == nil || receipt.BlockNumber.Uint64()+i.cfg.Confirmations > head.Number.Uint64() {
+				continue
+			}
+			if err := i.store.IncludeDeposit(txHash, receipt.BlockHash, receipt.BlockNumber.Uint64(), receipt.Status == types.ReceiptStatusSuccessful); err != nil {
+				return fmt.Errorf("failed to store inclusion of deposit %s: %w", txHash, err)
+			}
+		}
+	}
+	i.m.RecordPendingDeposits(len(i.store.PendingDeposits()))
+	return nil
+}
@@ -0,0 +1,394 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var (
+	portalAddr = common.Address{0xaa}
+	alice      = common.Address{0x01}
+	bob        = common.Address{0x02}
+)
+
+type fakeChain struct {
+	head     uint64
+	logs     []types.Log
+	receipts map[common.Hash]*types.Receipt
+	queries  []ethereum.FilterQuery
+	batches  [][]rpc.BatchElem
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: new(big.Int).SetUint64(c.head)}, nil
+}
+
+func (c *fakeChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.queries = append(c.queries, q)
+	var out []types.Log
+	for _, l := range c.logs {
+		if l.BlockNumber < q.FromBlock.Uint64() || l.BlockNumber > q.ToBlock.Uint64() || l.Address != q.Addresses[0] {
+			continue
+		}
+		for _, topic := range q.Topics[0] {
+			if l.Topics[0] == topic {
+				out = append(out, l)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (c *fakeChain) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	c.batches = append(c.batches, b)
+	for i := range b {
+		if b[i].Method != "eth_getTransactionReceipt" {
+			b[i].Error = errors.New("unexpected method")
+			continue
+		}
+		// unknown receipts are returned as null
+		*b[i].Result.(**types.Receipt) = c.receipts[b[i].Args[0].(common.Hash)]
+	}
+	return nil
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) RecordIndexedBlock(layer Layer, number uint64) {}
+func (noopMetrics) RecordPendingDeposits(count int)               {}
+
+func withPosition(l *types.Log, block uint64, tx byte, index uint) types.Log {
+	l.BlockNumber = block
+	l.BlockHash = common.Hash{byte(block)}
+	l.TxHash = common.Hash{tx}
+	l.Index = index
+	return *l
+}
+
+func depositLog(t *testing.T, block uint64, tx byte, from common.Address, to common.Address) types.Log {
+	l, err := derive.MarshalDepositLogEvent(portalAddr, &types.DepositTx{
+		From:  from,
+		To:    &to,
+		Mint:  big.NewInt(100),
+		Value: big.NewInt(100),
+		Gas:   100_000,
+		Data:  []byte{},
+	})
+	require.NoError(t, err)
+	return withPosition(l, block, tx, 0)
+}
+
+func messagePassedLog(t *testing.T, block uint64, tx byte, nonce int64, sender, target common.Address, withdrawalHash common.Hash) types.Log {
+	ev := messagePasserABI.Events["MessagePassed"]
+	data, err := ev.Inputs.NonIndexed().Pack(big.NewInt(5), big.NewInt(21000), []byte{0x01}, withdrawalHash)
+	require.NoError(t, err)
+	return withPosition(&types.Log{
+		Address: predeploys.L2ToL1MessagePasserAddr,
+		Topics:  []common.Hash{messagePassedID, common.BigToHash(big.NewInt(nonce)), common.BytesToHash(sender[:]), common.BytesToHash(target[:])},
+		Data:    data,
+	}, block, tx, 0)
+}
+
+func provenLog(block uint64, tx byte, withdrawalHash common.Hash, from, to common.Address) types.Log {
+	return withPosition(&types.Log{
+		Address: portalAddr,
+		Topics:  []common.Hash{withdrawalProvenID, withdrawalHash, common.BytesToHash(from[:]), common.BytesToHash(to[:])},
+	}, block, tx, 1)
+}
+
+func finalizedLog(block uint64, tx byte, withdrawalHash common.Hash, success bool) types.Log {
+	data := make([]byte, 32)
+	if success {
+		data[31] = 1
+	}
+	return withPosition(&types.Log{
+		Address: portalAddr,
+		Topics:  []common.Hash{withdrawalFinalizeID, withdrawalHash},
+		Data:    data,
+	}, block, tx, 2)
+}
+
+func newTestIndexer(t *testing.T, l1, l2 *fakeChain, confirmations uint64) (*Indexer, *Store) {
+	return newTestIndexerWithStore(t, l1, l2, confirmations, NewStore(0, 0))
+}
+
+func newTestIndexerWithStore(t *testing.T, l1, l2 *fakeChain, confirmations uint64, store *Store) (*Indexer, *Store) {
+	cfg := Config{OptimismPortal: portalAddr, Confirmations: confirmations, MaxBlockRange: 10}
+	return NewIndexer(testlog.Logger(t, log.LevelInfo), noopMetrics{}, cfg, l1, l2, store), store
+}
+
+func TestIndexDeposits(t *testing.T) {
+	l1 := &fakeChain{head: 25, logs: []types.Log{
+		depositLog(t, 3, 0x10, alice, bob),
+		depositLog(t, 14, 0x11, bob, bob),
+		// not yet confirmed
+		depositLog(t, 24, 0x12, alice, alice),
+	}}
+	l2 := &fakeChain{head: 100, receipts: map[common.Hash]*types.Receipt{}}
+	indexer, store := newTestIndexer(t, l1, l2, 5)
+	require.NoError(t, indexer.Step(context.Background()))
+
+	// the logs are fetched in ranges of at most MaxBlockRange blocks, up to the confirmed head
+	require.Len(t, l1.queries, 3)
+	require.Equal(t, uint64(20), l1.queries[2].ToBlock.Uint64())
+	l1Next, l2Next := store.Progress()
+	require.Equal(t, uint64(21), l1Next)
+	require.Equal(t, uint64(96), l2Next)
+
+	aliceDeposits := store.DepositsByAddress(alice, 0, 10)
+	require.Len(t, aliceDeposits, 1)
+	deposit := aliceDeposits[0]
+	require.Equal(t, DepositPending, deposit.Status)
+	require.Equal(t, common.Hash{0x10}, deposit.L1TxHash)
+	require.Equal(t, alice, deposit.From)
+	require.Equal(t, bob, *deposit.To)
+	require.Equal(t, big.NewInt(100), deposit.Mint.ToInt())
+
+	// the L2 tx hash is derived from the deposit event
+	dep, err := derive.UnmarshalDepositLogEvent(&l1.logs[0])
+	require.NoError(t, err)
+	require.Equal(t, types.NewTx(dep).Hash(), deposit.L2TxHash)
+	require.Equal(t, dep.SourceHash, deposit.SourceHash)
+
+	// newest first
+	bobDeposits := store.DepositsByAddress(bob, 0, 10)
+	require.Len(t, bobDeposits, 2)
+	require.Equal(t, common.Hash{0x11}, bobDeposits[0].L1TxHash)
+	require.Equal(t, common.Hash{0x10}, bobDeposits[1].L1TxHash)
+	require.Len(t, store.DepositsByAddress(bob, 1, 10), 1)
+	require.Len(t, store.DepositsByAddress(bob, 0, 1), 1)
+	require.Len(t, store.DepositsByL1Tx(common.Hash{0x11}), 1)
+
+	// included deposits are only considered included once confirmed
+	l2.receipts[deposit.L2TxHash] = &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockHash: common.Hash{0xb1}, BlockNumber: big.NewInt(97)}
+	l2.receipts[bobDeposits[0].L2TxHash] = &types.Receipt{Status: types.ReceiptStatusFailed, BlockHash: common.Hash{0xb2}, BlockNumber: big.NewInt(90)}
+	require.NoError(t, indexer.Step(context.Background()))
+	require.Len(t, store.PendingDeposits(), 1)
+	failed, ok := store.Deposit(bobDeposits[0].L2TxHash)
+	require.True(t, ok)
+	require.Equal(t, DepositFailed, failed.Status)
+	require.Equal(t, common.Hash{0xb2}, *failed.L2BlockHash)
+	require.EqualValues(t, 90, *failed.L2BlockNumber)
+
+	l2.head = 102
+	require.NoError(t, indexer.Step(context.Background()))
+	require.Empty(t, store.PendingDeposits())
+	// the receipts of all pending deposits are fetched in a single batch
+	require.Len(t, l2.batches, 3)
+	require.Len(t, l2.batches[0], 2)
+	included, ok := store.Deposit(deposit.L2TxHash)
+	require.True(t, ok)
+	require.Equal(t, DepositIncluded, included.Status)
+	// copies returned before are not modified
+	require.Nil(t, deposit.L2BlockNumber)
+}
+
+func TestIndexWithdrawals(t *testing.T) {
+	hashA, hashB, hashC := common.Hash{0xa}, common.Hash{0xb}, common.Hash{0xc}
+	l2 := &fakeChain{head: 50, logs: []types.Log{
+		messagePassedLog(t, 5, 0x20, 0, alice, bob, hashA),
+		messagePassedLog(t, 6, 0x21, 1, alice, alice, hashB),
+	}}
+	l1 := &fakeChain{head: 50, logs: []types.Log{
+		provenLog(10, 0x30, hashA, alice, bob),
+		finalizedLog(12, 0x31, hashA, true),
+		// proven before the L2 initiation is indexed
+		provenLog(11, 0x32, hashC, bob, bob),
+	}}
+	indexer, store := newTestIndexer(t, l1, l2, 0)
+	require.NoError(t, indexer.Step(context.Background()))
+
+	a, ok := store.Withdrawal(hashA)
+	require.True(t, ok)
+	require.Equal(t, WithdrawalFinalized, a.Status)
+	require.True(t, a.Success)
+	require.Equal(t, common.Hash{0x30}, a.Proven.TxHash)
+	require.Equal(t, common.Hash{0x31}, a.Finalized.TxHash)
+	require.Equal(t, common.Hash{0x20}, *a.L2TxHash)
+	require.Equal(t, alice, a.Sender)
+	require.Equal(t, bob, a.Target)
+	require.Equal(t, big.NewInt(5), a.Value.ToInt())
+
+	b, ok := store.Withdrawal(hashB)
+	require.True(t, ok)
+	require.Equal(t, WithdrawalInitiated, b.Status)
+	require.Equal(t, []Withdrawal{b, a}, store.WithdrawalsByAddress(alice, 0, 10))
+	require.Equal(t, []Withdrawal{a}, store.WithdrawalsByL2Tx(common.Hash{0x20}))
+
+	c, ok := store.Withdrawal(hashC)
+	require.True(t, ok)
+	require.Equal(t, WithdrawalProven, c.Status)
+	require.Nil(t, c.L2TxHash)
+
+	// the initiation of a proven withdrawal keeps the proof
+	l2.logs = append(l2.logs, messagePassedLog(t, 51, 0x22, 2, bob, bob, hashC))
+	l2.head = 51
+	l1.logs = append(l1.logs, finalizedLog(51, 0x33, hashC, false))
+	l1.head = 51
+	require.NoError(t, indexer.Step(context.Background()))
+	c, ok = store.Withdrawal(hashC)
+	require.True(t, ok)
+	require.Equal(t, WithdrawalFinalized, c.Status)
+	require.False(t, c.Success)
+	require.Equal(t, common.Hash{0x32}, c.Proven.TxHash)
+	require.Equal(t, common.Hash{0x22}, *c.L2TxHash)
+	require.Equal(t, []Withdrawal{c}, store.WithdrawalsByAddress(bob, 0, 1))
+}
+
+func TestIndexSkipsInvalidEvents(t *testing.T) {
+	invalid := depositLog(t, 1, 0x10, alice, bob)
+	invalid.Data = invalid.Data[:10]
+	l1 := &fakeChain{head: 5, logs: []types.Log{invalid, depositLog(t, 2, 0x11, alice, bob)}}
+	l2 := &fakeChain{head: 5}
+	indexer, store := newTestIndexer(t, l1, l2, 0)
+	require.NoError(t, indexer.Step(context.Background()))
+	require.Len(t, store.DepositsByAddress(alice, 0, 10), 1)
+}
+
+func TestIndexReceiptsInBatches(t *testing.T) {
+	l1 := &fakeChain{head: 1}
+	for j := 0; j < receiptsBatchSize+1; j++ {
+		l1.logs = append(l1.logs, depositLog(t, 1, byte(j), alice, common.Address{byte(j)}))
+	}
+	l2 := &fakeChain{head: 5}
+	indexer, store := newTestIndexer(t, l1, l2, 0)
+	require.NoError(t, indexer.Step(context.Background()))
+	require.Len(t, store.PendingDeposits(), receiptsBatchSize+1)
+	require.Len(t, l2.batches, 2)
+	require.Len(t, l2.batches[0], receiptsBatchSize)
+	require.Len(t, l2.batches[1], 1)
+}
+
+func TestIndexMessagesByOriginalParties(t *testing.T) {
+	l1Messenger, l1Bridge := common.Address{0xee}, common.Address{0xef}
+	relay := func(sender, target common.Address, message []byte) []byte {
+		data, err := relayMessage.Inputs.Pack(big.NewInt(1), sender, target, big.NewInt(0), big.NewInt(100_000), message)
+		require.NoError(t, err)
+		return append(append([]byte{}, relayMessage.ID...), data...)
+	}
+	bridgeETH := func(from, to common.Address) []byte {
+		data, err := finalizeBridgeETH.Inputs.Pack(from, to, big.NewInt(10), []byte{})
+		require.NoError(t, err)
+		return append(append([]byte{}, finalizeBridgeETH.ID...), data...)
+	}
+	deposit := func(block uint64, tx byte, data []byte) types.Log {
+		l, err := derive.MarshalDepositLogEvent(portalAddr, &types.DepositTx{
+			From:  crossdomain.ApplyL1ToL2Alias(l1Messenger),
+			To:    &predeploys.L2CrossDomainMessengerAddr,
+			Mint:  big.NewInt(0),
+			Value: big.NewInt(0),
+			Gas:   200_000,
+			Data:  data,
+		})
+		require.NoError(t, err)
+		return withPosition(l, block, tx, 0)
+	}
+	carol := common.Address{0x03}
+	l1 := &fakeChain{head: 5, logs: []types.Log{
+		deposit(1, 0x10, relay(alice, bob, []byte{0x01})),
+		deposit(2, 0x11, relay(l1Bridge, predeploys.L2StandardBridgeAddr, bridgeETH(carol, alice))),
+	}}
+	withdrawal := messagePassedLog(t, 3, 0x20, 0, predeploys.L2CrossDomainMessengerAddr, l1Messenger, common.Hash{0xa})
+	withdrawalData, err := messagePasserABI.Events["MessagePassed"].Inputs.NonIndexed().Pack(
+		big.NewInt(0), big.NewInt(200_000), relay(predeploys.L2StandardBridgeAddr, l1Bridge, bridgeETH(bob, carol)), common.Hash{0xa})
+	require.NoError(t, err)
+	withdrawal.Data = withdrawalData
+	l2 := &fakeChain{head: 5, logs: []types.Log{withdrawal}}
+	indexer, store := newTestIndexer(t, l1, l2, 0)
+	require.NoError(t, indexer.Step(context.Background()))
+
+	// the messenger is not indexed as sender
+	require.Empty(t, store.DepositsByAddress(crossdomain.ApplyL1ToL2Alias(l1Messenger), 0, 10))
+	require.Empty(t, store.WithdrawalsByAddress(predeploys.L2CrossDomainMessengerAddr, 0, 10))
+
+	aliceDeposits := store.DepositsByAddress(alice, 0, 10)
+	require.Len(t, aliceDeposits, 2)
+	require.Equal(t, carol, aliceDeposits[0].OriginalSender)
+	require.Equal(t, alice, *aliceDeposits[0].OriginalRecipient)
+	require.Equal(t, predeploys.L2CrossDomainMessengerAddr, *aliceDeposits[0].To)
+	require.Equal(t, alice, aliceDeposits[1].OriginalSender)
+	require.Equal(t, bob, *aliceDeposits[1].OriginalRecipient)
+	require.Len(t, store.DepositsByAddress(bob, 0, 10), 1)
+
+	bobWithdrawals := store.WithdrawalsByAddress(bob, 0, 10)
+	require.Len(t, bobWithdrawals, 1)
+	require.Equal(t, bob, bobWithdrawals[0].OriginalSender)
+	require.Equal(t, carol, bobWithdrawals[0].OriginalRecipient)
+	require.Equal(t, predeploys.L2CrossDomainMessengerAddr, bobWithdrawals[0].Sender)
+	require.Len(t, store.WithdrawalsByAddress(carol, 0, 10), 1)
+}
+
+func TestIndexPersisted(t *testing.T) {
+	dir := t.TempDir()
+	hashA := common.Hash{0xa}
+	l1 := &fakeChain{head: 20, logs: []types.Log{
+		depositLog(t, 3, 0x10, alice, bob),
+		provenLog(4, 0x30, hashA, alice, bob),
+	}}
+	l2 := &fakeChain{head: 20, logs: []types.Log{messagePassedLog(t, 5, 0x20, 0, alice, bob, hashA)}}
+	store, err := OpenStore(dir, 0, 0)
+	require.NoError(t, err)
+	indexer, _ := newTestIndexerWithStore(t, l1, l2, 0, store)
+	require.NoError(t, indexer.Step(context.Background()))
+	deposit := store.DepositsByAddress(alice, 0, 1)[0]
+	withdrawal, ok := store.Withdrawal(hashA)
+	require.True(t, ok)
+	require.NoError(t, store.Close())
+
+	// the index and progress are loaded again, the start blocks are ignored
+	reopened, err := OpenStore(dir, 100, 100)
+	require.NoError(t, err)
+	l1Next, l2Next := reopened.Progress()
+	require.Equal(t, uint64(21), l1Next)
+	require.Equal(t, uint64(21), l2Next)
+	require.Equal(t, []Deposit{deposit}, reopened.DepositsByAddress(alice, 0, 10))
+	require.Equal(t, []Deposit{deposit}, reopened.DepositsByL1Tx(common.Hash{0x10}))
+	require.Equal(t, []common.Hash{deposit.L2TxHash}, reopened.PendingDeposits())
+	require.Equal(t, []Withdrawal{withdrawal}, reopened.WithdrawalsByAddress(bob, 0, 10))
+	require.Equal(t, WithdrawalProven, withdrawal.Status)
+
+	// updates are persisted on top of the loaded index
+	require.NoError(t, reopened.IncludeDeposit(deposit.L2TxHash, common.Hash{0xb1}, 10, true))
+	require.NoError(t, reopened.Close())
+	reopened, err = OpenStore(dir, 0, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, reopened.Close()) })
+	included, ok := reopened.Deposit(deposit.L2TxHash)
+	require.True(t, ok)
+	require.Equal(t, DepositIncluded, included.Status)
+	require.Empty(t, reopened.PendingDeposits())
+	require.Len(t, reopened.DepositsByAddress(alice, 0, 10), 1)
+}
+
+func TestStoreCompacts(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenStore(dir, 0, 0)
+	require.NoError(t, err)
+	for n := uint64(1); n <= minCompactRecords+10; n++ {
+		require.NoError(t, store.SetL1Next(n))
+	}
+	require.NoError(t, store.AddDeposit(Deposit{L2TxHash: common.Hash{0x01}, OriginalSender: alice, Status: DepositPending}))
+	require.NoError(t, store.Close())
+	require.Less(t, store.records, 20)
+
+	reopened, err := OpenStore(dir, 0, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, reopened.Close()) })
+	l1Next, _ := reopened.Progress()
+	require.Equal(t, uint64(minCompactRecords+10), l1Next)
+	require.Len(t, reopened.DepositsByAddress(alice, 0, 10), 1)
+}
@@ -0,0 +1,94 @@
+package bridge
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+// messagesABI holds the calls of the CrossDomainMessenger and the StandardBridge that are relayed by deposits and
+// withdrawals, to find the original sender and recipient of the messages and bridge transfers.
+const messagesABI = `[
+	{"name":"relayMessage","type":"function","inputs":[{"name":"_nonce","type":"uint256"},{"name":"_sender","type":"address"},{"name":"_target","type":"address"},{"name":"_value","type":"uint256"},{"name":"_minGasLimit","type":"uint256"},{"name":"_message","type":"bytes"}]},
+	{"name":"finalizeBridgeETH","type":"function","inputs":[{"name":"_from","type":"address"},{"name":"_to","type":"address"},{"name":"_amount","type":"uint256"},{"name":"_extraData","type":"bytes"}]},
+	{"name":"finalizeBridgeERC20","type":"function","inputs":[{"name":"_localToken","type":"address"},{"name":"_remoteToken","type":"address"},{"name":"_from","type":"address"},{"name":"_to","type":"address"},{"name":"_amount","type":"uint256"},{"name":"_extraData","type":"bytes"}]}
+]`
+
+var (
+	messages, _         = abi.JSON(strings.NewReader(messagesABI))
+	relayMessage        = messages.Methods["relayMessage"]
+	finalizeBridgeETH   = messages.Methods["finalizeBridgeETH"]
+	finalizeBridgeERC20 = messages.Methods["finalizeBridgeERC20"]
+)
+
+// depositParties returns the original sender and recipient of a deposit from the L1CrossDomainMessenger to the
+// L2CrossDomainMessenger, and of the transfer of the L1StandardBridge to the L2StandardBridge it carries, if any.
+// Returns false for deposits that don't relay a message.
+func depositParties(to *common.Address, data []byte) (sender, recipient common.Address, ok bool) {
+	if to == nil || *to != predeploys.L2CrossDomainMessengerAddr {
+		return common.Address{}, common.Address{}, false
+	}
+	sender, target, message, ok := decodeRelayMessage(data)
+	if !ok {
+		return common.Address{}, common.Address{}, false
+	}
+	if target == predeploys.L2StandardBridgeAddr {
+		if from, to, ok := decodeBridgeTransfer(message); ok {
+			return from, to, true
+		}
+	}
+	return sender, target, true
+}
+
+// withdrawalParties returns the original sender and recipient of a withdrawal from the L2CrossDomainMessenger to the
+// L1CrossDomainMessenger, and of the transfer of the L2StandardBridge to the L1StandardBridge it carries, if any.
+// Returns false for withdrawals that don't relay a message.
+func withdrawalParties(sender common.Address, data []byte) (common.Address, common.Address, bool) {
+	if sender != predeploys.L2CrossDomainMessengerAddr {
+		return common.Address{}, common.Address{}, false
+	}
+	msgSender, target, message, ok := decodeRelayMessage(data)
+	if !ok {
+		return common.Address{}, common.Address{}, false
+	}
+	if msgSender == predeploys.L2StandardBridgeAddr {
+		if from, to, ok := decodeBridgeTransfer(message); ok {
+			return from, to, true
+		}
+	}
+	return msgSender, target, true
+}
+
+func decodeRelayMessage(data []byte) (sender, target common.Address, message []byte, ok bool) {
+	args, ok := unpackCall(relayMessage, data)
+	if !ok {
+		return common.Address{}, common.Address{}, nil, false
+	}
+	return args[1].(common.Address), args[2].(common.Address), args[5].([]byte), true
+}
+
+// decodeBridgeTransfer returns the sender and recipient of a finalizeBridgeETH or finalizeBridgeERC20 call.
+func decodeBridgeTransfer(data []byte) (from, to common.Address, ok bool) {
+	if args, ok := unpackCall(finalizeBridgeETH, data); ok {
+		return args[0].(common.Address), args[1].(common.Address), true
+	}
+	if args, ok := unpackCall(finalizeBridgeERC20, data); ok {
+		return args[2].(common.Address), args[3].(common.Address), true
+	}
+	return common.Address{}, common.Address{}, false
+}
+
+func unpackCall(method abi.Method, data []byte) ([]any, bool) {
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return nil, false
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, false
+	}
+	return args, true
+}
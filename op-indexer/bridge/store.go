@@ -0,0 +1,418 @@
+package bridge
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const storeFile = "bridge.jsonl"
+
+// minCompactRecords is the number of records the store file can hold before it is compacted, regardless of the
+// number of stored deposits and withdrawals.
+const minCompactRecords = 10_000
+
+// record is a single line of the store file: the updated state of a deposit or withdrawal, or the indexing progress.
+type record struct {
+	Deposit    *Deposit    `json:"deposit,omitempty"`
+	Withdrawal *Withdrawal `json:"withdrawal,omitempty"`
+	Progress   *progress   `json:"progress,omitempty"`
+}
+
+type progress struct {
+	L1Next uint64 `json:"l1Next"`
+	L2Next uint64 `json:"l2Next"`
+}
+
+// Store keeps the indexed deposits and withdrawals in memory, and persists them to a JSON lines file if it is
+// opened with a directory, so the index survives restarts.
+// Updates are appended to the file, which is compacted once it holds more than twice as many records as
+// deposits and withdrawals. The progress is appended after the events of the indexed blocks, so a restarted
+// store indexes again at most the blocks of which the events were stored.
+// The deposits and withdrawals are returned as copies: the pointer fields are never modified in place,
+// they are replaced when the deposit or withdrawal progresses.
+type Store struct {
+	mu sync.RWMutex
+
+	deposits          map[common.Hash]*Deposit // by L2 tx hash
+	depositsByL1Tx    map[common.Hash][]common.Hash
+	depositsByAddress map[common.Address][]common.Hash
+	pendingDeposits   map[common.Hash]struct{}
+	depositOrder      []common.Hash
+
+	withdrawals          map[common.Hash]*Withdrawal // by withdrawal hash
+	withdrawalsByL2Tx    map[common.Hash][]common.Hash
+	withdrawalsByAddress map[common.Address][]common.Hash
+	withdrawalOrder      []common.Hash
+
+	// the next blocks to index
+	l1Next, l2Next uint64
+
+	// path is the store file, empty if the store is not persisted
+	path    string
+	file    *os.File
+	records int
+}
+
+// NewStore creates a store that is kept in memory only.
+func NewStore(l1Start, l2Start uint64) *Store {
+	return &Store{
+		deposits:             make(map[common.Hash]*Deposit),
+		depositsByL1Tx:       make(map[common.Hash][]common.Hash),
+		depositsByAddress:    make(map[common.Address][]common.Hash),
+		pendingDeposits:      make(map[common.Hash]struct{}),
+		withdrawals:          make(map[common.Hash]*Withdrawal),
+		withdrawalsByL2Tx:    make(map[common.Hash][]common.Hash),
+		withdrawalsByAddress: make(map[common.Address][]common.Hash),
+		l1Next:               l1Start,
+		l2Next:               l2Start,
+	}
+}
+
+// OpenStore creates a store persisted in dir, loading the index previously stored there, if any.
+// The start blocks are only used if nothing was indexed yet.
+func OpenStore(dir string, l1Start, l2Start uint64) (*Store, error) {
+	s := NewStore(l1Start, l2Start)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %w", err)
+	}
+	s.path = filepath.Join(dir, storeFile)
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load bridge index: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store file: %w", err)
+	}
+	s.file = f
+	return s, nil
+}
+
+func (s *Store) load() error {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		var r record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return fmt.Errorf("invalid record %v: %w", s.records, err)
+		}
+		s.records++
+		if r.Deposit != nil {
+			s.putDeposit(*r.Deposit)
+		}
+		if r.Withdrawal != nil {
+			s.putWithdrawal(*r.Withdrawal)
+		}
+		if r.Progress != nil {
+			s.l1Next, s.l2Next = r.Progress.L1Next, r.Progress.L2Next
+		}
+	}
+	return scanner.Err()
+}
+
+// Close closes the store file, if any.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// Progress returns the next L1 and L2 blocks to index.
+func (s *Store) Progress() (l1Next, l2Next uint64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.l1Next, s.l2Next
+}
+
+func (s *Store) SetL1Next(n uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.l1Next = n
+	return s.append(record{Progress: &progress{L1Next: s.l1Next, L2Next: s.l2Next}})
+}
+
+func (s *Store) SetL2Next(n uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.l2Next = n
+	return s.append(record{Progress: &progress{L1Next: s.l1Next, L2Next: s.l2Next}})
+}
+
+// AddDeposit adds a new deposit, ignoring deposits that are already known.
+func (s *Store) AddDeposit(d Deposit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.deposits[d.L2TxHash]; ok {
+		return nil
+	}
+	s.putDeposit(d)
+	return s.append(record{Deposit: &d})
+}
+
+// putDeposit stores the deposit, replacing the previous state of the deposit if it is already known.
+func (s *Store) putDeposit(d Deposit) {
+	if prev, ok := s.deposits[d.L2TxHash]; ok {
+		*prev = d
+	} else {
+		s.deposits[d.L2TxHash] = &d
+		s.depositOrder = append(s.depositOrder, d.L2TxHash)
+		s.depositsByL1Tx[d.L1TxHash] = append(s.depositsByL1Tx[d.L1TxHash], d.L2TxHash)
+		s.depositsByAddress[d.OriginalSender] = append(s.depositsByAddress[d.OriginalSender], d.L2TxHash)
+		if d.OriginalRecipient != nil && *d.OriginalRecipient != d.OriginalSender {
+			s.depositsByAddress[*d.OriginalRecipient] = append(s.depositsByAddress[*d.OriginalRecipient], d.L2TxHash)
+		}
+	}
+	if d.Status == DepositPending {
+		s.pendingDeposits[d.L2TxHash] = struct{}{}
+	} else {
+		delete(s.pendingDeposits, d.L2TxHash)
+	}
+}
+
+// PendingDeposits returns the L2 tx hashes of the deposits that are not yet included in L2.
+func (s *Store) PendingDeposits() []common.Hash {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]common.Hash, 0, len(s.pendingDeposits))
+	for h := range s.pendingDeposits {
+		out = append(out, h)
+	}
+	return out
+}
+
+// IncludeDeposit marks the deposit as included in the given L2 block.
+func (s *Store) IncludeDeposit(l2TxHash common.Hash, l2Block common.Hash, l2Number uint64, success bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.deposits[l2TxHash]
+	if !ok {
+		return nil
+	}
+	d := *prev
+	n := hexutil.Uint64(l2Number)
+	d.L2BlockHash, d.L2BlockNumber = &l2Block, &n
+	if success {
+		d.Status = DepositIncluded
+	} else {
+		d.Status = DepositFailed
+	}
+	s.putDeposit(d)
+	return s.append(record{Deposit: &d})
+}
+
+// Deposit returns the deposit by its L2 tx hash.
+func (s *Store) Deposit(l2TxHash common.Hash) (Deposit, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	d, ok := s.deposits[l2TxHash]
+	if !ok {
+		return Deposit{}, false
+	}
+	return *d, true
+}
+
+// DepositsByL1Tx returns the deposits of an L1 transaction.
+func (s *Store) DepositsByL1Tx(l1TxHash common.Hash) []Deposit {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.depositList(s.depositsByL1Tx[l1TxHash], 0, len(s.depositsByL1Tx[l1TxHash]))
+}
+
+// DepositsByAddress returns the deposits from or to the address, newest first.
+// Deposits that relay a message of the CrossDomainMessenger are indexed by the original sender and recipient
+// of the message, or of the StandardBridge transfer it carries.
+func (s *Store) DepositsByAddress(addr common.Address, offset, limit int) []Deposit {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.depositList(s.depositsByAddress[addr], offset, limit)
+}
+
+func (s *Store) depositList(hashes []common.Hash, offset, limit int) []Deposit {
+	out := make([]Deposit, 0)
+	for i := len(hashes) - 1 - offset; i >= 0 && len(out) < limit; i-- {
+		out = append(out, *s.deposits[hashes[i]])
+	}
+	return out
+}
+
+// withdrawal returns a copy of the withdrawal with the given hash, or a new withdrawal if it is not yet known.
+func (s *Store) withdrawal(hash common.Hash) Withdrawal {
+	if w, ok := s.withdrawals[hash]; ok {
+		return *w
+	}
+	return Withdrawal{WithdrawalHash: hash, Status: WithdrawalInitiated}
+}
+
+// putWithdrawal stores the withdrawal, replacing the previous state of the withdrawal if it is already known.
+func (s *Store) putWithdrawal(w Withdrawal) {
+	prev, ok := s.withdrawals[w.WithdrawalHash]
+	if !ok {
+		prev = new(Withdrawal)
+		s.withdrawals[w.WithdrawalHash] = prev
+		s.withdrawalOrder = append(s.withdrawalOrder, w.WithdrawalHash)
+	}
+	initiated := prev.L2TxHash == nil && w.L2TxHash != nil
+	*prev = w
+	if !initiated {
+		return
+	}
+	s.withdrawalsByL2Tx[*w.L2TxHash] = append(s.withdrawalsByL2Tx[*w.L2TxHash], w.WithdrawalHash)
+	s.withdrawalsByAddress[w.OriginalSender] = append(s.withdrawalsByAddress[w.OriginalSender], w.WithdrawalHash)
+	if w.OriginalRecipient != w.OriginalSender {
+		s.withdrawalsByAddress[w.OriginalRecipient] = append(s.withdrawalsByAddress[w.OriginalRecipient], w.WithdrawalHash)
+	}
+}
+
+// InitiateWithdrawal adds the L2 initiation of a withdrawal,
+// to a new withdrawal or to a withdrawal that was already proven or finalized.
+func (s *Store) InitiateWithdrawal(initiated Withdrawal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := s.withdrawal(initiated.WithdrawalHash)
+	if w.L2TxHash != nil {
+		return nil
+	}
+	initiated.Proven, initiated.Finalized, initiated.Success = w.Proven, w.Finalized, w.Success
+	initiated.updateStatus()
+	s.putWithdrawal(initiated)
+	return s.append(record{Withdrawal: &initiated})
+}
+
+// ProveWithdrawal records the latest proof of a withdrawal.
+func (s *Store) ProveWithdrawal(hash common.Hash, ev L1Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := s.withdrawal(hash)
+	w.Proven = &ev
+	w.updateStatus()
+	s.putWithdrawal(w)
+	return s.append(record{Withdrawal: &w})
+}
+
+// FinalizeWithdrawal records the finalization of a withdrawal.
+func (s *Store) FinalizeWithdrawal(hash common.Hash, ev L1Event, success bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := s.withdrawal(hash)
+	w.Finalized, w.Success = &ev, success
+	w.updateStatus()
+	s.putWithdrawal(w)
+	return s.append(record{Withdrawal: &w})
+}
+
+// Withdrawal returns the withdrawal by its withdrawal hash.
+func (s *Store) Withdrawal(hash common.Hash) (Withdrawal, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	w, ok := s.withdrawals[hash]
+	if !ok {
+		return Withdrawal{}, false
+	}
+	return *w, true
+}
+
+// WithdrawalsByL2Tx returns the withdrawals initiated by an L2 transaction.
+func (s *Store) WithdrawalsByL2Tx(l2TxHash common.Hash) []Withdrawal {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.withdrawalList(s.withdrawalsByL2Tx[l2TxHash], 0, len(s.withdrawalsByL2Tx[l2TxHash]))
+}
+
+// WithdrawalsByAddress returns the withdrawals from or to the address, newest first.
+// Withdrawals of the CrossDomainMessenger are indexed by the original sender and recipient of the message,
+// or of the StandardBridge transfer it carries.
+func (s *Store) WithdrawalsByAddress(addr common.Address, offset, limit int) []Withdrawal {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.withdrawalList(s.withdrawalsByAddress[addr], offset, limit)
+}
+
+func (s *Store) withdrawalList(hashes []common.Hash, offset, limit int) []Withdrawal {
+	out := make([]Withdrawal, 0)
+	for i := len(hashes) - 1 - offset; i >= 0 && len(out) < limit; i-- {
+		out = append(out, *s.withdrawals[hashes[i]])
+	}
+	return out
+}
+
+// append writes the record to the end of the store file, if any,
+// compacting the file if it holds too many stale records. The lock must be held.
+func (s *Store) append(r record) error {
+	if s.file == nil {
+		return nil
+	}
+	if s.records >= max(2*(len(s.deposits)+len(s.withdrawals)), minCompactRecords) {
+		return s.compact()
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write store file: %w", err)
+	}
+	s.records++
+	return nil
+}
+
+// compact replaces the store file with one holding a single record per deposit and withdrawal,
+// in the order they were first stored, followed by the progress. The lock must be held.
+func (s *Store) compact() error {
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, h := range s.depositOrder {
+		if err := enc.Encode(record{Deposit: s.deposits[h]}); err != nil {
+			return errors.Join(err, f.Close())
+		}
+	}
+	for _, h := range s.withdrawalOrder {
+		if err := enc.Encode(record{Withdrawal: s.withdrawals[h]}); err != nil {
+			return errors.Join(err, f.Close())
+		}
+	}
+	if err := enc.Encode(record{Progress: &progress{L1Next: s.l1Next, L2Next: s.l2Next}}); err != nil {
+		return errors.Join(err, f.Close())
+	}
+	if err := w.Flush(); err != nil {
+		return errors.Join(err, f.Close())
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file, err = os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to reopen store file: %w", err)
+	}
+	s.records = len(s.depositOrder) + len(s.withdrawalOrder) + 1
+	return nil
+}
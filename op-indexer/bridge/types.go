@@ -0,0 +1,110 @@
+package bridge
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type DepositStatus string
+
+const (
+	// DepositPending is a deposit that is not yet included in L2.
+	DepositPending DepositStatus = "pending"
+	// DepositIncluded is a deposit that was included and executed successfully in L2.
+	DepositIncluded DepositStatus = "included"
+	// DepositFailed is a deposit that was included in L2, but failed to execute.
+	// The minted ETH is still credited to the sender on L2.
+	DepositFailed DepositStatus = "failed"
+)
+
+// Deposit is an L1 to L2 deposit, initiated by a TransactionDeposited event of the OptimismPortal.
+type Deposit struct {
+	// SourceHash uniquely identifies the deposit, see the deposit source-hash of the specs.
+	SourceHash common.Hash     `json:"sourceHash"`
+	From       common.Address  `json:"from"`
+	To         *common.Address `json:"to"` // nil for contract creations
+	Mint       *hexutil.Big    `json:"mint"`
+	Value      *hexutil.Big    `json:"value"`
+	GasLimit   hexutil.Uint64  `json:"gasLimit"`
+	Data       hexutil.Bytes   `json:"data"`
+
+	// OriginalSender and OriginalRecipient are the sender and recipient of the message of the CrossDomainMessenger
+	// that the deposit relays, or of the StandardBridge transfer the message carries,
+	// rather than the aliased messenger and the L2CrossDomainMessenger. They equal From and To for other deposits.
+	OriginalSender    common.Address  `json:"originalSender"`
+	OriginalRecipient *common.Address `json:"originalRecipient"`
+
+	L1TxHash      common.Hash    `json:"l1TxHash"`
+	L1BlockHash   common.Hash    `json:"l1BlockHash"`
+	L1BlockNumber hexutil.Uint64 `json:"l1BlockNumber"`
+
+	// L2TxHash is the hash of the deposit transaction in L2, known before the deposit is included.
+	L2TxHash      common.Hash     `json:"l2TxHash"`
+	L2BlockHash   *common.Hash    `json:"l2BlockHash,omitempty"`
+	L2BlockNumber *hexutil.Uint64 `json:"l2BlockNumber,omitempty"`
+
+	Status DepositStatus `json:"status"`
+}
+
+type WithdrawalStatus string
+
+const (
+	// WithdrawalInitiated is a withdrawal that was initiated in L2, but not yet proven in L1.
+	WithdrawalInitiated WithdrawalStatus = "initiated"
+	// WithdrawalProven is a withdrawal that was proven in L1, but not yet finalized.
+	WithdrawalProven WithdrawalStatus = "proven"
+	// WithdrawalFinalized is a withdrawal that was finalized in L1.
+	// The withdrawal may still have failed to execute, see Withdrawal.Success.
+	WithdrawalFinalized WithdrawalStatus = "finalized"
+)
+
+// L1Event is an event of a withdrawal in L1.
+type L1Event struct {
+	TxHash      common.Hash    `json:"txHash"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+}
+
+// Withdrawal is an L2 to L1 withdrawal, initiated by a MessagePassed event of the L2ToL1MessagePasser.
+// Withdrawals may be proven before the indexer processed their initiation in L2,
+// in which case only the withdrawal hash and the L1 events are known.
+type Withdrawal struct {
+	WithdrawalHash common.Hash `json:"withdrawalHash"`
+
+	Nonce    *hexutil.Big   `json:"nonce,omitempty"`
+	Sender   common.Address `json:"sender"`
+	Target   common.Address `json:"target"`
+	Value    *hexutil.Big   `json:"value,omitempty"`
+	GasLimit *hexutil.Big   `json:"gasLimit,omitempty"`
+	Data     hexutil.Bytes  `json:"data,omitempty"`
+
+	// OriginalSender and OriginalRecipient are the sender and recipient of the message of the CrossDomainMessenger
+	// that the withdrawal relays, or of the StandardBridge transfer the message carries,
+	// rather than the L2CrossDomainMessenger and the L1CrossDomainMessenger. They equal Sender and Target for other
+	// withdrawals.
+	OriginalSender    common.Address `json:"originalSender"`
+	OriginalRecipient common.Address `json:"originalRecipient"`
+
+	L2TxHash      *common.Hash    `json:"l2TxHash,omitempty"`
+	L2BlockHash   *common.Hash    `json:"l2BlockHash,omitempty"`
+	L2BlockNumber *hexutil.Uint64 `json:"l2BlockNumber,omitempty"`
+
+	// Proven is the latest proof of the withdrawal. Withdrawals may be proven again, e.g. after a dispute game was lost.
+	Proven    *L1Event `json:"proven,omitempty"`
+	Finalized *L1Event `json:"finalized,omitempty"`
+	// Success is whether the finalized withdrawal executed successfully.
+	Success bool `json:"success"`
+
+	Status WithdrawalStatus `json:"status"`
+}
+
+func (w *Withdrawal) updateStatus() {
+	switch {
+	case w.Finalized != nil:
+		w.Status = WithdrawalFinalized
+	case w.Proven != nil:
+		w.Status = WithdrawalProven
+	default:
+		w.Status = WithdrawalInitiated
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-indexer/config"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	l1EthRpc                   = "http://example.com:8545"
+	l2EthRpc                   = "http://example.com:9545"
+	optimismPortalAddressValue = "0xbb00000000000000000000000000000000000000"
+)
+
+func TestLogLevel(t *testing.T) {
+	t.Run("RejectInvalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "unknown level: foo", addRequiredArgs("--log.level=foo"))
+	})
+
+	for _, lvl := range []string{"trace", "debug", "info", "error", "crit"} {
+		lvl := lvl
+		t.Run("AcceptValid_"+lvl, func(t *testing.T) {
+			logger, _, err := dryRunWithArgs(addRequiredArgs("--log.level", lvl))
+			require.NoError(t, err)
+			require.NotNil(t, logger)
+		})
+	}
+}
+
+func TestDefaultCLIOptionsMatchDefaultConfig(t *testing.T) {
+	cfg := configForArgs(t, addRequiredArgs())
+	defaultCfg := config.NewConfig(common.HexToAddress(optimismPortalAddressValue), l1EthRpc, l2EthRpc)
+	require.Equal(t, defaultCfg, cfg)
+}
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	cfg := config.NewConfig(common.HexToAddress(optimismPortalAddressValue), l1EthRpc, l2EthRpc)
+	require.NoError(t, cfg.Check())
+}
+
+func TestL1EthRpc(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag l1-eth-rpc is required", addRequiredArgsExcept("--l1-eth-rpc"))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		url := "http://example.com:9999"
+		cfg := configForArgs(t, addRequiredArgsExcept("--l1-eth-rpc", "--l1-eth-rpc", url))
+		require.Equal(t, url, cfg.L1EthRpc)
+	})
+}
+
+func TestL2EthRpc(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag l2-eth-rpc is required", addRequiredArgsExcept("--l2-eth-rpc"))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		url := "http://example.com:9999"
+		cfg := configForArgs(t, addRequiredArgsExcept("--l2-eth-rpc", "--l2-eth-rpc", url))
+		require.Equal(t, url, cfg.L2EthRpc)
+	})
+}
+
+func TestOptimismPortalAddress(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag optimism-portal-address is required", addRequiredArgsExcept("--optimism-portal-address"))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		addr := common.Address{0x11, 0x22}
+		cfg := configForArgs(t, addRequiredArgsExcept("--optimism-portal-address", "--optimism-portal-address", addr.Hex()))
+		require.Equal(t, addr, cfg.OptimismPortalAddress)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid address: foo", addRequiredArgsExcept("--optimism-portal-address", "--optimism-portal-address", "foo"))
+	})
+}
+
+func TestStartBlocks(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Zero(t, cfg.L1StartBlock)
+		require.Zero(t, cfg.L2StartBlock)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l1-start-block=100", "--l2-start-block=200"))
+		require.EqualValues(t, 100, cfg.L1StartBlock)
+		require.EqualValues(t, 200, cfg.L2StartBlock)
+	})
+}
+
+func TestPollInterval(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultPollInterval, cfg.PollInterval)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--poll-interval=30s"))
+		require.Equal(t, 30*time.Second, cfg.PollInterval)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid value \"abc\" for flag -poll-interval", addRequiredArgs("--poll-interval=abc"))
+	})
+}
+
+func TestConfirmations(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultConfirmations, cfg.Confirmations)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--confirmations=3"))
+		require.EqualValues(t, 3, cfg.Confirmations)
+	})
+}
+
+func TestMaxBlockRange(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultMaxBlockRange, cfg.MaxBlockRange)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--max-block-range=50"))
+		require.EqualValues(t, 50, cfg.MaxBlockRange)
+	})
+}
+
+func TestDataDir(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.DataDir)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--data-dir=/tmp/indexer"))
+		require.Equal(t, "/tmp/indexer", cfg.DataDir)
+	})
+}
+
+func TestHTTPListen(t *testing.T) {
+	cfg := configForArgs(t, addRequiredArgs("--http.addr=0.0.0.0", "--http.port=9000"))
+	require.Equal(t, "0.0.0.0", cfg.HTTPAddr)
+	require.Equal(t, 9000, cfg.HTTPPort)
+}
+
+func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
+	_, _, err := dryRunWithArgs(cliArgs)
+	require.ErrorContains(t, err, messageContains)
+}
+
+func configForArgs(t *testing.T, cliArgs []string) config.Config {
+	_, cfg, err := dryRunWithArgs(cliArgs)
+	require.NoError(t, err)
+	return cfg
+}
+
+func dryRunWithArgs(cliArgs []string) (log.Logger, config.Config, error) {
+	cfg := new(config.Config)
+	var logger log.Logger
+	fullArgs := append([]string{"op-indexer"}, cliArgs...)
+	testErr := errors.New("dry-run")
+	err := run(context.Background(), fullArgs, func(ctx context.Context, log log.Logger, config *config.Config) (cliapp.Lifecycle, error) {
+		logger = log
+		cfg = config
+		return nil, testErr
+	})
+	if errors.Is(err, testErr) { // expected error
+		err = nil
+	}
+	return logger, *cfg, err
+}
+
+func addRequiredArgs(args ...string) []string {
+	req := requiredArgs()
+	combined := toArgList(req)
+	return append(combined, args...)
+}
+
+func addRequiredArgsExcept(name string, optionalArgs ...string) []string {
+	req := requiredArgs()
+	delete(req, name)
+	return append(toArgList(req), optionalArgs...)
+}
+
+func requiredArgs() map[string]string {
+	args := map[string]string{
+		"--l1-eth-rpc":              l1EthRpc,
+		"--l2-eth-rpc":              l2EthRpc,
+		"--optimism-portal-address": optimismPortalAddressValue,
+	}
+	return args
+}
+
+func toArgList(req map[string]string) []string {
+	var combined []string
+	for name, value := range req {
+		combined = append(combined, fmt.Sprintf("%s=%s", name, value))
+	}
+	return combined
+}
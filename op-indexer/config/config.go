@@ -0,0 +1,103 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+)
+
+var (
+	ErrMissingL1EthRPC              = errors.New("missing l1 eth rpc url")
+	ErrMissingL2EthRPC              = errors.New("missing l2 eth rpc url")
+	ErrMissingOptimismPortalAddress = errors.New("missing optimism portal address")
+	ErrMissingPollInterval          = errors.New("missing poll interval")
+	ErrMissingMaxBlockRange         = errors.New("missing max block range")
+	ErrMissingHTTPAddr              = errors.New("missing http listen address")
+	ErrInvalidHTTPPort              = errors.New("invalid http listen port")
+)
+
+const (
+	// DefaultPollInterval is the default interval at which the indexer checks for new blocks.
+	DefaultPollInterval = 12 * time.Second
+	// DefaultConfirmations is the default number of blocks to stay behind the head of each chain.
+	DefaultConfirmations = uint64(10)
+	// DefaultMaxBlockRange is the default maximum number of blocks to fetch logs for in a single request.
+	DefaultMaxBlockRange = uint64(1000)
+	DefaultHTTPAddr      = "127.0.0.1"
+	DefaultHTTPPort      = 8100
+)
+
+// Config is a well typed config that is parsed from the CLI params.
+// It also contains config options for auxiliary services.
+type Config struct {
+	L1EthRpc              string         // L1 RPC Url
+	L2EthRpc              string         // L2 RPC Url
+	OptimismPortalAddress common.Address // Address of the OptimismPortal proxy in L1
+
+	L1StartBlock  uint64        // First L1 block to index, usually the L1 block of the deployment of the OptimismPortal
+	L2StartBlock  uint64        // First L2 block to index
+	PollInterval  time.Duration // Frequency to check for new blocks
+	Confirmations uint64        // Number of blocks to stay behind the head of each chain
+	MaxBlockRange uint64        // Maximum number of blocks to fetch logs for in a single request
+	DataDir       string        // Directory to persist the index in, the index is kept in memory only if empty
+
+	HTTPAddr string // Address to serve the API on
+	HTTPPort int    // Port to serve the API on
+
+	MetricsConfig opmetrics.CLIConfig
+	PprofConfig   oppprof.CLIConfig
+}
+
+func NewConfig(optimismPortalAddress common.Address, l1EthRpc string, l2EthRpc string) Config {
+	return Config{
+		L1EthRpc:              l1EthRpc,
+		L2EthRpc:              l2EthRpc,
+		OptimismPortalAddress: optimismPortalAddress,
+
+		PollInterval:  DefaultPollInterval,
+		Confirmations: DefaultConfirmations,
+		MaxBlockRange: DefaultMaxBlockRange,
+
+		HTTPAddr: DefaultHTTPAddr,
+		HTTPPort: DefaultHTTPPort,
+
+		MetricsConfig: opmetrics.DefaultCLIConfig(),
+		PprofConfig:   oppprof.DefaultCLIConfig(),
+	}
+}
+
+func (c Config) Check() error {
+	if c.L1EthRpc == "" {
+		return ErrMissingL1EthRPC
+	}
+	if c.L2EthRpc == "" {
+		return ErrMissingL2EthRPC
+	}
+	if c.OptimismPortalAddress == (common.Address{}) {
+		return ErrMissingOptimismPortalAddress
+	}
+	if c.PollInterval == 0 {
+		return ErrMissingPollInterval
+	}
+	if c.MaxBlockRange == 0 {
+		return ErrMissingMaxBlockRange
+	}
+	if c.HTTPAddr == "" {
+		return ErrMissingHTTPAddr
+	}
+	if c.HTTPPort < 0 || c.HTTPPort > 65535 {
+		return ErrInvalidHTTPPort
+	}
+	if err := c.MetricsConfig.Check(); err != nil {
+		return fmt.Errorf("metrics config: %w", err)
+	}
+	if err := c.PprofConfig.Check(); err != nil {
+		return fmt.Errorf("pprof config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	validL1EthRpc              = "http://localhost:8545"
+	validL2EthRpc              = "http://localhost:9545"
+	validOptimismPortalAddress = common.Address{0x23}
+)
+
+func validConfig() Config {
+	return NewConfig(validOptimismPortalAddress, validL1EthRpc, validL2EthRpc)
+}
+
+func TestValidConfigIsValid(t *testing.T) {
+	require.NoError(t, validConfig().Check())
+}
+
+func TestL1EthRpcRequired(t *testing.T) {
+	config := validConfig()
+	config.L1EthRpc = ""
+	require.ErrorIs(t, config.Check(), ErrMissingL1EthRPC)
+}
+
+func TestL2EthRpcRequired(t *testing.T) {
+	config := validConfig()
+	config.L2EthRpc = ""
+	require.ErrorIs(t, config.Check(), ErrMissingL2EthRPC)
+}
+
+func TestOptimismPortalAddressRequired(t *testing.T) {
+	config := validConfig()
+	config.OptimismPortalAddress = common.Address{}
+	require.ErrorIs(t, config.Check(), ErrMissingOptimismPortalAddress)
+}
+
+func TestPollIntervalRequired(t *testing.T) {
+	config := validConfig()
+	config.PollInterval = 0
+	require.ErrorIs(t, config.Check(), ErrMissingPollInterval)
+}
+
+func TestMaxBlockRangeRequired(t *testing.T) {
+	config := validConfig()
+	config.MaxBlockRange = 0
+	require.ErrorIs(t, config.Check(), ErrMissingMaxBlockRange)
+}
+
+func TestZeroConfirmationsAllowed(t *testing.T) {
+	config := validConfig()
+	config.Confirmations = 0
+	require.NoError(t, config.Check())
+}
+
+func TestHTTPAddrRequired(t *testing.T) {
+	config := validConfig()
+	config.HTTPAddr = ""
+	require.ErrorIs(t, config.Check(), ErrMissingHTTPAddr)
+}
+
+func TestHTTPPortValid(t *testing.T) {
+	config := validConfig()
+	config.HTTPPort = 65536
+	require.ErrorIs(t, config.Check(), ErrInvalidHTTPPort)
+}
@@ -0,0 +1,155 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-indexer/config"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+)
+
+const (
+	envVarPrefix = "OP_INDEXER"
+)
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(envVarPrefix, name)
+}
+
+var (
+	// Required Flags
+	L1EthRpcFlag = &cli.StringFlag{
+		Name:    "l1-eth-rpc",
+		Usage:   "HTTP provider URL for L1.",
+		EnvVars: prefixEnvVars("L1_ETH_RPC"),
+	}
+	L2EthRpcFlag = &cli.StringFlag{
+		Name:    "l2-eth-rpc",
+		Usage:   "HTTP provider URL for L2.",
+		EnvVars: prefixEnvVars("L2_ETH_RPC"),
+	}
+	OptimismPortalAddressFlag = &cli.StringFlag{
+		Name:    "optimism-portal-address",
+		Usage:   "Address of the OptimismPortal proxy contract in L1.",
+		EnvVars: prefixEnvVars("OPTIMISM_PORTAL_ADDRESS"),
+	}
+	// Optional Flags
+	L1StartBlockFlag = &cli.Uint64Flag{
+		Name:    "l1-start-block",
+		Usage:   "First L1 block to index, e.g. the L1 block the OptimismPortal was deployed in.",
+		EnvVars: prefixEnvVars("L1_START_BLOCK"),
+	}
+	L2StartBlockFlag = &cli.Uint64Flag{
+		Name:    "l2-start-block",
+		Usage:   "First L2 block to index.",
+		EnvVars: prefixEnvVars("L2_START_BLOCK"),
+	}
+	PollIntervalFlag = &cli.DurationFlag{
+		Name:    "poll-interval",
+		Usage:   "The interval at which the indexer checks for new blocks.",
+		EnvVars: prefixEnvVars("POLL_INTERVAL"),
+		Value:   config.DefaultPollInterval,
+	}
+	ConfirmationsFlag = &cli.Uint64Flag{
+		Name:    "confirmations",
+		Usage:   "Number of blocks to stay behind the head of each chain, to not index events that are reorged out.",
+		EnvVars: prefixEnvVars("CONFIRMATIONS"),
+		Value:   config.DefaultConfirmations,
+	}
+	MaxBlockRangeFlag = &cli.Uint64Flag{
+		Name:    "max-block-range",
+		Usage:   "Maximum number of blocks to fetch logs for in a single request.",
+		EnvVars: prefixEnvVars("MAX_BLOCK_RANGE"),
+		Value:   config.DefaultMaxBlockRange,
+	}
+	DataDirFlag = &cli.StringFlag{
+		Name:    "data-dir",
+		Usage:   "Directory to persist the index in, so a restarted indexer continues where it stopped. The index is kept in memory only if not set.",
+		EnvVars: prefixEnvVars("DATA_DIR"),
+	}
+	HTTPAddrFlag = &cli.StringFlag{
+		Name:    "http.addr",
+		Usage:   "Address to serve the API on.",
+		EnvVars: prefixEnvVars("HTTP_ADDR"),
+		Value:   config.DefaultHTTPAddr,
+	}
+	HTTPPortFlag = &cli.IntFlag{
+		Name:    "http.port",
+		Usage:   "Port to serve the API on.",
+		EnvVars: prefixEnvVars("HTTP_PORT"),
+		Value:   config.DefaultHTTPPort,
+	}
+)
+
+// requiredFlags are checked by [CheckRequired]
+var requiredFlags = []cli.Flag{
+	L1EthRpcFlag,
+	L2EthRpcFlag,
+	OptimismPortalAddressFlag,
+}
+
+// optionalFlags is a list of unchecked cli flags
+var optionalFlags = []cli.Flag{
+	L1StartBlockFlag,
+	L2StartBlockFlag,
+	PollIntervalFlag,
+	ConfirmationsFlag,
+	MaxBlockRangeFlag,
+	DataDirFlag,
+	HTTPAddrFlag,
+	HTTPPortFlag,
+}
+
+func init() {
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
+
+	Flags = append(requiredFlags, optionalFlags...)
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func CheckRequired(ctx *cli.Context) error {
+	for _, f := range requiredFlags {
+		if !ctx.IsSet(f.Names()[0]) {
+			return fmt.Errorf("flag %s is required", f.Names()[0])
+		}
+	}
+	return nil
+}
+
+// NewConfigFromCLI parses the Config from the provided flags or environment variables.
+func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
+	if err := CheckRequired(ctx); err != nil {
+		return nil, err
+	}
+	portalAddress, err := opservice.ParseAddress(ctx.String(OptimismPortalAddressFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid optimism portal address: %w", err)
+	}
+
+	return &config.Config{
+		L1EthRpc:              ctx.String(L1EthRpcFlag.Name),
+		L2EthRpc:              ctx.String(L2EthRpcFlag.Name),
+		OptimismPortalAddress: portalAddress,
+
+		L1StartBlock:  ctx.Uint64(L1StartBlockFlag.Name),
+		L2StartBlock:  ctx.Uint64(L2StartBlockFlag.Name),
+		PollInterval:  ctx.Duration(PollIntervalFlag.Name),
+		Confirmations: ctx.Uint64(ConfirmationsFlag.Name),
+		MaxBlockRange: ctx.Uint64(MaxBlockRangeFlag.Name),
+		DataDir:       ctx.String(DataDirFlag.Name),
+
+		HTTPAddr: ctx.String(HTTPAddrFlag.Name),
+		HTTPPort: ctx.Int(HTTPPortFlag.Name),
+
+		MetricsConfig: opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:   oppprof.ReadCLIConfig(ctx),
+	}, nil
+}
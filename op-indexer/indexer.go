@@ -0,0 +1,18 @@
+package op_indexer
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-indexer/config"
+	"github.com/ethereum-optimism/optimism/op-indexer/indexer"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+)
+
+func Main(ctx context.Context, logger log.Logger, cfg *config.Config) (cliapp.Lifecycle, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	return indexer.NewService(ctx, logger, cfg)
+}
@@ -0,0 +1,246 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-indexer/api"
+	"github.com/ethereum-optimism/optimism/op-indexer/bridge"
+	"github.com/ethereum-optimism/optimism/op-indexer/config"
+	"github.com/ethereum-optimism/optimism/op-indexer/metrics"
+	"github.com/ethereum-optimism/optimism/op-indexer/version"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+)
+
+// Service runs the bridge indexer, and serves the indexed deposits and withdrawals.
+// The index is persisted in the data directory, if configured, so a restarted service continues where it stopped.
+type Service struct {
+	logger  log.Logger
+	metrics metrics.Metricer
+
+	pollInterval time.Duration
+
+	l1Client *ethclient.Client
+	l2Client *ethclient.Client
+
+	store   *bridge.Store
+	indexer *bridge.Indexer
+
+	apiSrv       *httputil.HTTPServer
+	pprofService *oppprof.Service
+	metricsSrv   *httputil.HTTPServer
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	stopped atomic.Bool
+}
+
+// NewService creates a new Service.
+func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Service, error) {
+	s := &Service{
+		logger:       logger,
+		metrics:      metrics.NewMetrics(),
+		pollInterval: cfg.PollInterval,
+	}
+
+	if err := s.initFromConfig(ctx, cfg); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to init service: %w", err), s.Stop(ctx))
+	}
+
+	return s, nil
+}
+
+func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error {
+	if err := s.initClients(ctx, cfg); err != nil {
+		return err
+	}
+	if err := s.initPProf(&cfg.PprofConfig); err != nil {
+		return fmt.Errorf("failed to init profiling: %w", err)
+	}
+	if err := s.initMetricsServer(&cfg.MetricsConfig); err != nil {
+		return fmt.Errorf("failed to init metrics server: %w", err)
+	}
+
+	if err := s.initStore(cfg); err != nil {
+		return fmt.Errorf("failed to init store: %w", err)
+	}
+	s.indexer = bridge.NewIndexer(s.logger, s.metrics, bridge.Config{
+		OptimismPortal: cfg.OptimismPortalAddress,
+		Confirmations:  cfg.Confirmations,
+		MaxBlockRange:  cfg.MaxBlockRange,
+	}, s.l1Client, &l2Client{s.l2Client}, s.store)
+
+	if err := s.initAPIServer(cfg); err != nil {
+		return fmt.Errorf("failed to init API server: %w", err)
+	}
+
+	s.metrics.RecordInfo(version.SimpleWithMeta)
+	s.metrics.RecordUp()
+	return nil
+}
+
+func (s *Service) initClients(ctx context.Context, cfg *config.Config) error {
+	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.L1EthRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	s.l1Client = l1Client
+	l2Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.L2EthRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L2: %w", err)
+	}
+	s.l2Client = l2Client
+	return nil
+}
+
+func (s *Service) initStore(cfg *config.Config) error {
+	if cfg.DataDir == "" {
+		s.logger.Warn("No data directory configured, the index is kept in memory only")
+		s.store = bridge.NewStore(cfg.L1StartBlock, cfg.L2StartBlock)
+		return nil
+	}
+	store, err := bridge.OpenStore(cfg.DataDir, cfg.L1StartBlock, cfg.L2StartBlock)
+	if err != nil {
+		return err
+	}
+	l1Next, l2Next := store.Progress()
+	s.logger.Info("Opened bridge index", "dir", cfg.DataDir, "l1Next", l1Next, "l2Next", l2Next)
+	s.store = store
+	return nil
+}
+
+// l2Client adds batch requests of the underlying RPC client to the L2 client.
+type l2Client struct {
+	*ethclient.Client
+}
+
+func (c *l2Client) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return c.Client.Client().BatchCallContext(ctx, b)
+}
+
+func (s *Service) initPProf(cfg *oppprof.CLIConfig) error {
+	s.pprofService = oppprof.NewFromConfig(*cfg)
+
+	if err := s.pprofService.Start(); err != nil {
+		return fmt.Errorf("failed to start pprof service: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	s.logger.Debug("starting metrics server", "addr", cfg.ListenAddr, "port", cfg.ListenPort)
+	m, ok := s.metrics.(opmetrics.RegistryMetricer)
+	if !ok {
+		return fmt.Errorf("metrics were enabled, but metricer %T does not expose registry for metrics-server", s.metrics)
+	}
+	metricsSrv, err := opmetrics.StartServer(m.Registry(), cfg.ListenAddr, cfg.ListenPort)
+	if err != nil {
+		return fmt.Errorf("failed to start metrics server: %w", err)
+	}
+	s.logger.Info("started metrics server", "addr", metricsSrv.Addr())
+	s.metricsSrv = metricsSrv
+	return nil
+}
+
+func (s *Service) initAPIServer(cfg *config.Config) error {
+	addr := net.JoinHostPort(cfg.HTTPAddr, strconv.Itoa(cfg.HTTPPort))
+	apiSrv, err := httputil.StartHTTPServer(addr, api.NewHandler(s.logger, s.store))
+	if err != nil {
+		return fmt.Errorf("failed to start API server: %w", err)
+	}
+	s.logger.Info("started API server", "addr", apiSrv.Addr())
+	s.apiSrv = apiSrv
+	return nil
+}
+
+// APIAddr returns the address the API is served on.
+func (s *Service) APIAddr() net.Addr {
+	return s.apiSrv.Addr()
+}
+
+func (s *Service) Start(ctx context.Context) error {
+	s.logger.Info("Starting indexer")
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.wg.Add(1)
+	go s.loop(ctx)
+	return nil
+}
+
+func (s *Service) loop(ctx context.Context) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.indexer.Step(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Warn("Failed to index bridge events", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Service) Stopped() bool {
+	return s.stopped.Load()
+}
+
+func (s *Service) Stop(ctx context.Context) error {
+	s.logger.Info("Stopping indexer service")
+
+	if s.cancel != nil {
+		s.cancel()
+		s.wg.Wait()
+	}
+
+	var result error
+	if s.apiSrv != nil {
+		if err := s.apiSrv.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close API server: %w", err))
+		}
+	}
+	if s.pprofService != nil {
+		if err := s.pprofService.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close pprof server: %w", err))
+		}
+	}
+	if s.metricsSrv != nil {
+		if err := s.metricsSrv.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close metrics server: %w", err))
+		}
+	}
+	if s.store != nil {
+		if err := s.store.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close store: %w", err))
+		}
+	}
+	if s.l1Client != nil {
+		s.l1Client.Close()
+	}
+	if s.l2Client != nil {
+		s.l2Client.Close()
+	}
+	s.stopped.Store(true)
+	s.logger.Info("stopped indexer service", "err", result)
+	return result
+}
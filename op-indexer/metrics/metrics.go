@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/op-indexer/bridge"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "op_indexer"
+
+type Metricer interface {
+	RecordInfo(version string)
+	RecordUp()
+
+	bridge.Metrics
+}
+
+type Metrics struct {
+	registry *prometheus.Registry
+	factory  opmetrics.Factory
+
+	info prometheus.GaugeVec
+	up   prometheus.Gauge
+
+	indexedBlock    prometheus.GaugeVec
+	pendingDeposits prometheus.Gauge
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+
+	return &Metrics{
+		registry: registry,
+		factory:  factory,
+
+		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "info",
+			Help:      "Pseudo-metric tracking version and config info",
+		}, []string{
+			"version",
+		}),
+		up: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "up",
+			Help:      "1 if the op-indexer has finished starting up",
+		}),
+		indexedBlock: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "indexed_block",
+			Help:      "Latest block indexed, by layer",
+		}, []string{
+			"layer",
+		}),
+		pendingDeposits: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "pending_deposits",
+			Help:      "Number of indexed deposits that are not yet included in L2",
+		}),
+	}
+}
+
+// RecordInfo sets a pseudo-metric that contains versioning and
+// config info for the op-indexer.
+func (m *Metrics) RecordInfo(version string) {
+	m.info.WithLabelValues(version).Set(1)
+}
+
+// RecordUp sets the up metric to 1.
+func (m *Metrics) RecordUp() {
+	m.up.Set(1)
+}
+
+func (m *Metrics) RecordIndexedBlock(layer bridge.Layer, number uint64) {
+	m.indexedBlock.WithLabelValues(string(layer)).Set(float64(number))
+}
+
+func (m *Metrics) RecordPendingDeposits(count int) {
+	m.pendingDeposits.Set(float64(count))
+}
@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"github.com/ethereum-optimism/optimism/op-indexer/bridge"
+)
+
+type NoopMetricsImpl struct{}
+
+var NoopMetrics Metricer = new(NoopMetricsImpl)
+
+func (*NoopMetricsImpl) RecordInfo(_ string) {}
+func (*NoopMetricsImpl) RecordUp()           {}
+
+func (*NoopMetricsImpl) RecordIndexedBlock(_ bridge.Layer, _ uint64) {}
+func (*NoopMetricsImpl) RecordPendingDeposits(_ int)                 {}
@@ -0,0 +1,77 @@
+package actionlog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	ActionLogFlag = &cli.PathFlag{
+		Name:     "action-log",
+		Usage:    "Path of the sequencer action log, as recorded with --sequencer.action-log. The rotated previous log is read too, if it exists.",
+		Required: true,
+	}
+	FromFlag = &cli.Uint64Flag{
+		Name:  "from",
+		Usage: "Only export the actions on top of L2 blocks with at least this number",
+	}
+	ToFlag = &cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Only export the actions on top of L2 blocks with at most this number. Unlimited if 0.",
+	}
+	OutFlag = &cli.PathFlag{
+		Name:  "out",
+		Usage: "Path to write the actions to, as JSON array. Compressed if the path ends with .gz. Written to stdout if -.",
+		Value: "-",
+	}
+)
+
+var Command = &cli.Command{
+	Name:  "export-action-log",
+	Usage: "Exports the sequencing decisions of a sequencer action log",
+	Description: "Reads the append-only action log of the sequencer, and writes the actions in the given L2 block range " +
+		"as JSON array, e.g. for post-mortems, or as trace of a conformance test.",
+	Flags:  []cli.Flag{ActionLogFlag, FromFlag, ToFlag, OutFlag},
+	Action: ExportActionLog,
+}
+
+// ExportActionLog exports the actions of the action log of the CLI context.
+func ExportActionLog(ctx *cli.Context) error {
+	path := ctx.Path(ActionLogFlag.Name)
+	rotated, err := readActionLog(path+driver.RotatedActionLogSuffix, true)
+	if err != nil {
+		return err
+	}
+	current, err := readActionLog(path, false)
+	if err != nil {
+		return err
+	}
+	actions := append(rotated, current...)
+	from, to := ctx.Uint64(FromFlag.Name), ctx.Uint64(ToFlag.Name)
+	out := make([]driver.SequencerAction, 0, len(actions))
+	for _, action := range actions {
+		if action.Parent.Number < from || (to != 0 && action.Parent.Number > to) {
+			continue
+		}
+		out = append(out, action)
+	}
+	return jsonutil.WriteJSON(ctx.Path(OutFlag.Name), out, 0o644)
+}
+
+// readActionLog reads the actions of the action log at path. Missing logs are empty if optional.
+func readActionLog(path string, optional bool) ([]driver.SequencerAction, error) {
+	f, err := os.Open(path)
+	if optional && errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open action log: %w", err)
+	}
+	defer f.Close()
+	return driver.ReadActionLog(f)
+}
@@ -0,0 +1,61 @@
+package actionlog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestExportActionLog(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "actions.log")
+	newAction := func(i uint64) driver.SequencerAction {
+		return driver.SequencerAction{
+			Time:   i * 1000,
+			Kind:   driver.ActionStart,
+			Parent: eth.BlockID{Hash: common.Hash{byte(i)}, Number: i},
+			Result: driver.ResultOK,
+		}
+	}
+	data, err := json.Marshal(newAction(10))
+	require.NoError(t, err)
+	// rotated after three actions
+	actionLog, err := driver.OpenActionLog(testlog.Logger(t, log.LevelError), logPath, uint64(3*(len(data)+1)))
+	require.NoError(t, err)
+	var actions []driver.SequencerAction
+	for i := uint64(10); i < 15; i++ {
+		action := newAction(i)
+		actionLog.Record(action)
+		actions = append(actions, action)
+	}
+	require.NoError(t, actionLog.Close())
+
+	export := func(args ...string) []driver.SequencerAction {
+		out := filepath.Join(dir, "out.json")
+		app := cli.NewApp()
+		app.Commands = []*cli.Command{Command}
+		require.NoError(t, app.Run(append([]string{"op-node", "export-action-log", "--action-log", logPath, "--out", out}, args...)))
+		exported, err := jsonutil.LoadJSON[[]driver.SequencerAction](out)
+		require.NoError(t, err)
+		require.NoError(t, os.Remove(out))
+		return *exported
+	}
+	// the actions are split over the rotated and the current log
+	_, err = os.Stat(logPath + driver.RotatedActionLogSuffix)
+	require.NoError(t, err)
+	require.Equal(t, actions, export())
+	require.Equal(t, actions[1:4], export("--from", "11", "--to", "13"))
+	require.Empty(t, export("--from", "20"))
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var Subcommands = []*cli.Command{
+	{
+		Name:  "check",
+		Usage: "Validates the node config of a config file, combined with flags and env vars, without starting the node",
+		Description: "Loads the node config as the node does at startup: this also generates the p2p private key file, " +
+			"if it does not exist yet. Flags are only read after the check subcommand, e.g. op-node config check --config=node.toml",
+		Flags:  cliapp.ProtectFlags(flags.Flags),
+		Action: Check,
+	},
+}
+
+// Check validates the config file, and the node config it results in.
+func Check(ctx *cli.Context) error {
+	if !ctx.IsSet(flags.ConfigFileFlag.Name) {
+		return fmt.Errorf("flag %s is required", flags.ConfigFileFlag.Name)
+	}
+	cfgFile, overridden, err := opnode.ApplyConfigFile(ctx)
+	if err != nil {
+		return err
+	}
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	if _, err := opnode.NewConfig(ctx, logger); err != nil {
+		return fmt.Errorf("invalid node config: %w", err)
+	}
+	path := ctx.String(flags.ConfigFileFlag.Name)
+	fmt.Fprintf(ctx.App.Writer, "Config file %s is valid, setting %d flags\n", path, len(cfgFile))
+	for _, name := range overridden {
+		fmt.Fprintf(ctx.App.Writer, "Flag %s of the config file is overridden by a CLI argument or env var\n", name)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func runCheck(t *testing.T, args ...string) (string, error) {
+	var out bytes.Buffer
+	app := cli.NewApp()
+	app.Writer = &out
+	app.ErrWriter = &out
+	app.Commands = []*cli.Command{{Name: "config", Subcommands: Subcommands}}
+	err := app.Run(append([]string{"op-node", "config", "check"}, args...))
+	return out.String(), err
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	jwtPath := writeFile(t, dir, "jwt.txt", "0x0000000000000000000000000000000000000000000000000000000000000001")
+
+	t.Run("Valid", func(t *testing.T) {
+		path := writeFile(t, dir, "node.toml", `
+l1 = "http://localhost:8545"
+l2 = "http://localhost:8551"
+"l1.beacon" = "http://localhost:5052"
+network = "op-sepolia"
+"l2.jwt-secret" = "`+jwtPath+`"
+
+[p2p]
+disable = true
+
+[log]
+level = "warn"
+`)
+		out, err := runCheck(t, "--config", path, "--log.level=error")
+		require.NoError(t, err)
+		require.Contains(t, out, "Config file "+path+" is valid, setting 7 flags")
+		require.Contains(t, out, "Flag log.level of the config file is overridden by a CLI argument or env var")
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		path := writeFile(t, dir, "node.yaml", `
+l1: http://localhost:8545
+l2: http://localhost:8551
+l1.beacon: http://localhost:5052
+network: op-sepolia
+l2.jwt-secret: `+jwtPath+`
+p2p:
+  disable: true
+`)
+		_, err := runCheck(t, "--config", path)
+		require.NoError(t, err)
+	})
+
+	t.Run("MissingConfigFlag", func(t *testing.T) {
+		_, err := runCheck(t)
+		require.ErrorContains(t, err, "flag config is required")
+	})
+
+	t.Run("UnknownFlag", func(t *testing.T) {
+		path := writeFile(t, dir, "unknown.toml", `
+l1 = "http://localhost:8545"
+"l1.rpc-kind" = "basic"
+`)
+		_, err := runCheck(t, "--config", path)
+		require.ErrorContains(t, err, "unknown flags in config file: l1.rpc-kind")
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		path := writeFile(t, dir, "invalid.toml", `
+"verifier.l1-confs" = "many"
+`)
+		_, err := runCheck(t, "--config", path)
+		require.ErrorContains(t, err, `invalid value "many" for flag verifier.l1-confs`)
+	})
+
+	t.Run("InvalidNodeConfig", func(t *testing.T) {
+		path := writeFile(t, dir, "incomplete.toml", `
+l1 = "http://localhost:8545"
+`)
+		_, err := runCheck(t, "--config", path)
+		require.ErrorContains(t, err, "invalid node config: flag l2 is required")
+	})
+}
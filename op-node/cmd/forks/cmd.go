@@ -0,0 +1,100 @@
+package forks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/urfave/cli/v2"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var EnginesFlag = &cli.StringSliceFlag{
+	Name:     "engines",
+	Usage:    "RPC endpoints of the execution engines to check, with the debug namespace enabled. May be repeated, or comma-separated.",
+	Required: true,
+}
+
+var errNotReady = errors.New("not all execution engines are ready for the scheduled forks")
+
+var Command = &cli.Command{
+	Name:  "check-fork-readiness",
+	Usage: "Checks that the fork schedule of a fleet of execution engines matches the rollup config",
+	Description: "Fetches the chain config of each execution engine with debug_chainConfig, " +
+		"and compares its fork activation times with the rollup config, e.g. before scheduling an upgrade. " +
+		"Exits with an error if any engine is unreachable or scheduled differently.",
+	Flags:  append(append([]cli.Flag{EnginesFlag}, opflags.CLIFlags("OP_NODE", "")...), oplog.CLIFlags("OP_NODE")...),
+	Action: CheckForkReadiness,
+}
+
+// CheckForkReadiness checks the fork schedule of all the given execution engines against the rollup config.
+func CheckForkReadiness(ctx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	rollupCfg, err := opnode.NewRollupConfigFromCLI(logger, ctx)
+	if err != nil {
+		return err
+	}
+	out := ctx.App.Writer
+
+	now := uint64(time.Now().Unix())
+	for _, f := range []struct {
+		fork rollup.ForkName
+		time *uint64
+	}{
+		{rollup.Canyon, rollupCfg.CanyonTime},
+		{rollup.Delta, rollupCfg.DeltaTime},
+		{rollup.Ecotone, rollupCfg.EcotoneTime},
+		{rollup.Fjord, rollupCfg.FjordTime},
+		{rollup.Interop, rollupCfg.InteropTime},
+	} {
+		if f.time != nil && *f.time > now {
+			fmt.Fprintf(out, "Upcoming fork %s at %d (%s)\n", f.fork, *f.time, time.Unix(int64(*f.time), 0).UTC())
+		}
+	}
+
+	ready := true
+	for _, endpoint := range ctx.StringSlice(EnginesFlag.Name) {
+		mismatches, err := checkEngine(ctx.Context, logger, endpoint, rollupCfg)
+		if err != nil {
+			ready = false
+			fmt.Fprintf(out, "%s: ERROR %v\n", endpoint, err)
+			continue
+		}
+		if len(mismatches) == 0 {
+			fmt.Fprintf(out, "%s: OK\n", endpoint)
+			continue
+		}
+		ready = false
+		fmt.Fprintf(out, "%s: MISMATCH\n", endpoint)
+		for _, m := range mismatches {
+			fmt.Fprintf(out, "  %s\n", m)
+		}
+	}
+	if !ready {
+		return errNotReady
+	}
+	return nil
+}
+
+func checkEngine(ctx context.Context, logger log.Logger, endpoint string, rollupCfg *rollup.Config) ([]rollup.ForkMismatch, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	rpc, err := client.NewRPC(ctx, logger, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial: %w", err)
+	}
+	defer rpc.Close()
+	var engineCfg params.ChainConfig
+	if err := rpc.CallContext(ctx, &engineCfg, "debug_chainConfig"); err != nil {
+		return nil, fmt.Errorf("failed to fetch chain config: %w", err)
+	}
+	return rollupCfg.CheckEngineForks(&engineCfg), nil
+}
@@ -0,0 +1,66 @@
+package forks
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type testDebugAPI struct {
+	cfg *params.ChainConfig
+}
+
+func (api *testDebugAPI) ChainConfig() *params.ChainConfig {
+	return api.cfg
+}
+
+func newTestEngine(t *testing.T, engineCfg *params.ChainConfig) string {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("debug", &testDebugAPI{cfg: engineCfg}))
+	t.Cleanup(srv.Stop)
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(httpSrv.Close)
+	return httpSrv.URL
+}
+
+func runCheckForkReadiness(t *testing.T, args ...string) (string, error) {
+	var out bytes.Buffer
+	app := cli.NewApp()
+	app.Writer = &out
+	app.ErrWriter = &out
+	app.Commands = []*cli.Command{Command}
+	err := app.Run(append([]string{"op-node", "check-fork-readiness", "--log.level=crit"}, args...))
+	return out.String(), err
+}
+
+func TestCheckForkReadiness(t *testing.T) {
+	rollupCfgPath := filepath.Join(t.TempDir(), "rollup.json")
+	require.NoError(t, os.WriteFile(rollupCfgPath, []byte(`{"canyon_time": 0, "ecotone_time": 4102444800}`), 0o600))
+	ecotone := uint64(4102444800)
+	ready := newTestEngine(t, &params.ChainConfig{CanyonTime: new(uint64), ShanghaiTime: new(uint64), EcotoneTime: &ecotone, CancunTime: &ecotone})
+	notReady := newTestEngine(t, &params.ChainConfig{CanyonTime: new(uint64), ShanghaiTime: new(uint64)})
+
+	t.Run("Ready", func(t *testing.T) {
+		out, err := runCheckForkReadiness(t, "--rollup.config", rollupCfgPath, "--engines", ready)
+		require.NoError(t, err)
+		require.Contains(t, out, "Upcoming fork ecotone at 4102444800 (2100-01-01 00:00:00 +0000 UTC)")
+		require.Contains(t, out, ready+": OK")
+	})
+
+	t.Run("NotReady", func(t *testing.T) {
+		out, err := runCheckForkReadiness(t, "--rollup.config", rollupCfgPath, "--engines", ready+","+notReady)
+		require.ErrorIs(t, err, errNotReady)
+		require.Contains(t, out, ready+": OK")
+		require.Contains(t, out, notReady+": MISMATCH")
+		require.Contains(t, out, "ecotone: rollup config at 4102444800, execution engine ecotoneTime not scheduled")
+		require.Contains(t, out, "ecotone: rollup config at 4102444800, execution engine cancunTime not scheduled")
+	})
+}
@@ -11,6 +11,9 @@ import (
 
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/actionlog"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/config"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/forks"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/genesis"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/networks"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/p2p"
@@ -62,6 +65,12 @@ func main() {
 			Name:        "networks",
 			Subcommands: networks.Subcommands,
 		},
+		{
+			Name:        "config",
+			Subcommands: config.Subcommands,
+		},
+		forks.Command,
+		actionlog.Command,
 	}
 
 	ctx := opio.WithInterruptBlocker(context.Background())
@@ -72,6 +81,12 @@ func main() {
 }
 
 func RollupNodeMain(ctx *cli.Context, closeApp context.CancelCauseFunc) (cliapp.Lifecycle, error) {
+	// The config file is applied first, as it may configure the logging too.
+	cfgFile, overridden, err := opnode.ApplyConfigFile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply the config file: %w", err)
+	}
+
 	logCfg := oplog.ReadCLIConfig(ctx)
 	log := oplog.NewLogger(oplog.AppOut(ctx), logCfg)
 	oplog.SetGlobalLogHandler(log.Handler())
@@ -97,5 +112,10 @@ func RollupNodeMain(ctx *cli.Context, closeApp context.CancelCauseFunc) (cliapp.
 		return nil, fmt.Errorf("unable to create the rollup node: %w", err)
 	}
 
+	if cfgFile != nil {
+		// reload the config file on SIGHUP, until the node is closed
+		go opnode.NewConfigReloader(ctx, log, n, cfgFile, overridden).Run(ctx.Context)
+	}
+
 	return n, nil
 }
@@ -0,0 +1,57 @@
+package opnode
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/node"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+// ApplyConfigFile applies the config file of the --config flag to the flags that are not set by CLI arguments or env vars.
+// It returns the applied config file, or nil if no config file is set,
+// and the flags of the config file that are overridden by CLI arguments or env vars.
+func ApplyConfigFile(ctx *cli.Context) (cliapp.ConfigFile, []string, error) {
+	path := ctx.String(flags.ConfigFileFlag.Name)
+	if path == "" {
+		return nil, nil, nil
+	}
+	cfgFile, err := cliapp.LoadConfigFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	overridden, err := cfgFile.Apply(ctx, flags.Flags)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid config file %q: %w", path, err)
+	}
+	return cfgFile, overridden, nil
+}
+
+// NewConfigReloader creates a reloader of the config file, which applies changes of log.level and rollup.halt to the node.
+func NewConfigReloader(ctx *cli.Context, log log.Logger, n *node.OpNode, cfgFile cliapp.ConfigFile, overridden []string) *cliapp.ConfigReloader {
+	r := cliapp.NewConfigReloader(log, ctx.String(flags.ConfigFileFlag.Name), flags.Flags, cfgFile, overridden)
+	r.OnChange(oplog.LevelFlagName, func(value string) error {
+		lvl, err := oplog.LevelFromString(value)
+		if err != nil {
+			return err
+		}
+		h := log.Handler()
+		lvlSetter, ok := h.(oplog.LvlSetter)
+		if !ok {
+			return fmt.Errorf("log handler type %T cannot change log level", h)
+		}
+		lvlSetter.SetLogLevel(lvl)
+		return nil
+	})
+	r.OnChange(flags.RollupHalt.Name, func(value string) error {
+		if value == "none" {
+			value = ""
+		}
+		return n.SetRollupHalt(value)
+	})
+	return r
+}
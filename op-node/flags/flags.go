@@ -2,18 +2,25 @@ package flags
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 // Flags
@@ -59,8 +66,9 @@ var (
 		Category: RollupCategory,
 	}
 	L2EngineJWTSecret = &cli.StringFlag{
-		Name:        "l2.jwt-secret",
-		Usage:       "Path to JWT secret key. Keys are 32 bytes, hex encoded in a file. A new key will be generated if the file is empty.",
+		Name: "l2.jwt-secret",
+		Usage: "Path to JWT secret key. Keys are 32 bytes, hex encoded in a file. A new key will be generated if the file is empty. " +
+			"The first key is used, and the file is reloaded when it changes, to rotate the key without a restart.",
 		EnvVars:     prefixEnvVars("L2_ENGINE_AUTH"),
 		Value:       "",
 		Destination: new(string),
@@ -104,6 +112,28 @@ var (
 		EnvVars:  prefixEnvVars("L1_BEACON_FETCH_ALL_SIDECARS"),
 		Category: L1RPCCategory,
 	}
+	BeaconBlobCacheDir = &cli.StringFlag{
+		Name: "l1.beacon.blob-cache-dir",
+		Usage: "Optional directory to cache fetched blob sidecars in, so they don't have to be fetched again, " +
+			"and remain available once pruned by the L1 Beacon endpoints. The least recently used blobs are evicted " +
+			"beyond the size set with l1.beacon.blob-cache-size.",
+		EnvVars:  prefixEnvVars("L1_BEACON_BLOB_CACHE_DIR"),
+		Category: L1RPCCategory,
+	}
+	BeaconBlobCacheSize = &cli.IntFlag{
+		Name:     "l1.beacon.blob-cache-size",
+		Usage:    "Maximum number of blob sidecars to keep in the blob cache dir, each takes 128 KiB on disk.",
+		Value:    8192,
+		EnvVars:  prefixEnvVars("L1_BEACON_BLOB_CACHE_SIZE"),
+		Category: L1RPCCategory,
+	}
+	BeaconHTTPProfile = &cli.StringFlag{
+		Name: "l1.beacon.http-profile",
+		Usage: "Profile of the HTTP client of the L1 Beacon endpoints, for its connection pool, timeouts and protocol. Valid options: " +
+			strings.Join(client.HTTPProfiles, ", ") + ". The default profile is used if empty.",
+		EnvVars:  prefixEnvVars("L1_BEACON_HTTP_PROFILE"),
+		Category: L1RPCCategory,
+	}
 	SyncModeFlag = &cli.GenericFlag{
 		Name:    "syncmode",
 		Usage:   fmt.Sprintf("Blockchain sync mode (options: %s)", openum.EnumString(sync.ModeStrings)),
@@ -114,6 +144,14 @@ var (
 		}(),
 		Category: RollupCategory,
 	}
+	MaxUnsafeReorgDepthFlag = &cli.Uint64Flag{
+		Name: "l2.max-unsafe-reorg-depth",
+		Usage: "Maximum number of unsafe L2 blocks that may be reorged automatically. " +
+			"A deeper reorg halts the node until it is confirmed with admin_confirmUnsafeReorg, also across restarts if rpc.admin-state is set. Disabled if 0.",
+		EnvVars:  prefixEnvVars("L2_MAX_UNSAFE_REORG_DEPTH"),
+		Value:    0,
+		Category: RollupCategory,
+	}
 	RPCListenAddr = &cli.StringFlag{
 		Name:     "rpc.addr",
 		Usage:    "RPC listening address",
@@ -134,6 +172,12 @@ var (
 		EnvVars:  prefixEnvVars("RPC_ENABLE_ADMIN"),
 		Category: OperationsCategory,
 	}
+	RPCEnableDebug = &cli.BoolFlag{
+		Name:     "rpc.enable-debug",
+		Usage:    "Enable the debug API, to build payload attributes with (debug_buildPayloadAttributes) and to inspect the engine queue (debug_engineQueueState)",
+		EnvVars:  prefixEnvVars("RPC_ENABLE_DEBUG"),
+		Category: OperationsCategory,
+	}
 	RPCAdminPersistence = &cli.StringFlag{
 		Name:     "rpc.admin-state",
 		Usage:    "File path used to persist state changes made via the admin API so they persist across restarts. Disabled if not set.",
@@ -152,7 +196,7 @@ var (
 			openum.EnumString(sources.RPCProviderKinds),
 		EnvVars: prefixEnvVars("L1_RPC_KIND"),
 		Value: func() *sources.RPCProviderKind {
-			out := sources.RPCKindStandard
+			out := sources.RPCKindAuto
 			return &out
 		}(),
 		Category: L1RPCCategory,
@@ -192,6 +236,28 @@ var (
 		Value:    time.Second * 12,
 		Category: L1RPCCategory,
 	}
+	L1HTTPProfile = &cli.StringFlag{
+		Name: "l1.http-profile",
+		Usage: "Profile of the HTTP client of an HTTP L1 RPC, for its connection pool, timeouts and protocol. Valid options: " +
+			strings.Join(client.HTTPProfiles, ", ") + ". The HTTP client of geth is used if empty.",
+		EnvVars:  prefixEnvVars("L1_HTTP_PROFILE"),
+		Category: L1RPCCategory,
+	}
+	L2EngineHTTPProfile = &cli.StringFlag{
+		Name: "l2.http-profile",
+		Usage: "Profile of the HTTP client of an HTTP L2 engine RPC, for its connection pool, timeouts and protocol. Valid options: " +
+			strings.Join(client.HTTPProfiles, ", ") + ". The default profile is used if empty.",
+		EnvVars:  prefixEnvVars("L2_HTTP_PROFILE"),
+		Category: RollupCategory,
+	}
+	L2EngineMaxResponseSize = &cli.Int64Flag{
+		Name: "l2.max-response-size",
+		Usage: "Maximum size in MiB of responses, and websocket messages, of the L2 engine RPC, before and after decompression. " +
+			"Unbounded if 0.",
+		EnvVars:  prefixEnvVars("L2_MAX_RESPONSE_SIZE"),
+		Value:    client.DefaultMaxResponseSize >> 20,
+		Category: RollupCategory,
+	}
 	L2EngineKind = &cli.GenericFlag{
 		Name: "l2.enginekind",
 		Usage: "The kind of engine client, used to control the behavior of optimism in respect to different types of engine clients. Valid options: " +
@@ -210,6 +276,13 @@ var (
 		Value:    0,
 		Category: L1RPCCategory,
 	}
+	VerifierFinalityDelay = &cli.Uint64Flag{
+		Name:     "verifier.finality-delay",
+		Usage:    "Number of L1 blocks that the L1 data of L2 blocks must be behind the finalized L1 block, before finalizing the L2 blocks.",
+		EnvVars:  prefixEnvVars("VERIFIER_FINALITY_DELAY"),
+		Value:    0,
+		Category: L1RPCCategory,
+	}
 	SequencerEnabledFlag = &cli.BoolFlag{
 		Name:     "sequencer.enabled",
 		Usage:    "Enable sequencing of new L2 blocks. A separate batch submitter has to be deployed to publish the data for verifiers.",
@@ -229,6 +302,60 @@ var (
 		Value:    0,
 		Category: SequencerCategory,
 	}
+	SequencerInclusionDeadlineBypassBuilderFlag = &cli.BoolFlag{
+		Name: "sequencer.inclusion-deadline-bypass-builder",
+		Usage: "Insert the payloads of the execution engine instead of the payloads of the block builder while a transaction registered with " +
+			"admin_addInclusionDeadline is not included by its deadline, instead of only reporting it. Guards against a block builder that keeps excluding transactions.",
+		EnvVars:  prefixEnvVars("SEQUENCER_INCLUSION_DEADLINE_BYPASS_BUILDER"),
+		Category: SequencerCategory,
+	}
+	SequencerBuilderAddrFlag = &cli.StringFlag{
+		Name: "sequencer.builder-addr",
+		Usage: "Address of an external block builder that serves the engine API, to prefer the payloads of over the payloads of the execution engine: " +
+			"an HTTP or websocket URL, a Unix domain socket as unix://<path>, or a builder registered in-process as inproc://<name>. Disabled if empty.",
+		EnvVars:  prefixEnvVars("SEQUENCER_BUILDER_ADDR"),
+		Category: SequencerCategory,
+	}
+	SequencerBuilderJWTSecretFlag = &cli.StringFlag{
+		Name: "sequencer.builder-jwt-secret",
+		Usage: "Path to the JWT secret to authenticate with HTTP and websocket block builders. " +
+			"Unix domain sockets are not authenticated, and must not be writable by other users.",
+		EnvVars:  prefixEnvVars("SEQUENCER_BUILDER_JWT_SECRET"),
+		Category: SequencerCategory,
+	}
+	SequencerBuilderMaxResponseSizeFlag = &cli.Int64Flag{
+		Name:     "sequencer.builder-max-response-size",
+		Usage:    "Maximum size in MiB of responses of HTTP and websocket block builders. Unbounded if 0.",
+		EnvVars:  prefixEnvVars("SEQUENCER_BUILDER_MAX_RESPONSE_SIZE"),
+		Value:    client.DefaultMaxResponseSize >> 20,
+		Category: SequencerCategory,
+	}
+	SequencerInclusionDeadlinesFileFlag = &cli.StringFlag{
+		Name:     "sequencer.inclusion-deadlines-file",
+		Usage:    "File to persist the transactions registered with admin_addInclusionDeadline to, so they are kept across restarts. Disabled if empty.",
+		EnvVars:  prefixEnvVars("SEQUENCER_INCLUSION_DEADLINES_FILE"),
+		Category: SequencerCategory,
+	}
+	SequencerPolicyFileFlag = &cli.StringFlag{
+		Name:     "sequencer.policy-file",
+		Usage:    "File to persist the tx pool policy set with admin_setSequencerPolicy to, so it is kept across restarts. Disabled if empty.",
+		EnvVars:  prefixEnvVars("SEQUENCER_POLICY_FILE"),
+		Category: SequencerCategory,
+	}
+	SequencerActionLogFlag = &cli.StringFlag{
+		Name:     "sequencer.action-log",
+		Usage:    "Path of an append-only log of the sequencing decisions, for post-mortems. Export it with the export-action-log command. Disabled if empty.",
+		EnvVars:  prefixEnvVars("SEQUENCER_ACTION_LOG"),
+		Category: SequencerCategory,
+	}
+	SequencerActionLogMaxSizeFlag = &cli.Uint64Flag{
+		Name: "sequencer.action-log-max-size",
+		Usage: "Size in MiB at which the sequencer action log is rotated. The previous log is kept with the suffix .1, " +
+			"replacing an older one. Unbounded if 0.",
+		EnvVars:  prefixEnvVars("SEQUENCER_ACTION_LOG_MAX_SIZE"),
+		Value:    256,
+		Category: SequencerCategory,
+	}
 	SequencerL1Confs = &cli.Uint64Flag{
 		Name:     "sequencer.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head as a sequencer for picking an L1 origin.",
@@ -296,12 +423,67 @@ var (
 		Value:    "https://heartbeat.optimism.io",
 		Category: OperationsCategory,
 	}
+	HeartbeatIntervalFlag = &cli.DurationFlag{
+		Name:     "heartbeat.interval",
+		Usage:    "Interval between heartbeats. The op-heartbeat server does not count heartbeats that are sent more frequently than every 10 minutes",
+		EnvVars:  prefixEnvVars("HEARTBEAT_INTERVAL"),
+		Value:    heartbeat.SendInterval,
+		Category: OperationsCategory,
+	}
+	HeartbeatStatusFlag = &cli.BoolFlag{
+		Name:     "heartbeat.status",
+		Usage:    "Includes the sync status (L1 and L2 heads) and peer count of the node in heartbeats",
+		EnvVars:  prefixEnvVars("HEARTBEAT_STATUS"),
+		Category: OperationsCategory,
+	}
+	HeartbeatSigningKeyFileFlag = &cli.StringFlag{
+		Name: "heartbeat.signing-key-file",
+		Usage: "Path of a file with the hex-encoded private key to sign heartbeats with, for the heartbeat server to authenticate the node. " +
+			"If not set, heartbeats are signed by the P2P signer of the node if it has one, and are unsigned otherwise",
+		EnvVars:  prefixEnvVars("HEARTBEAT_SIGNING_KEY_FILE"),
+		Category: OperationsCategory,
+	}
 	RollupHalt = &cli.StringFlag{
 		Name:     "rollup.halt",
 		Usage:    "Opt-in option to halt on incompatible protocol version requirements of the given level (major/minor/patch/none), as signaled onchain in L1",
 		EnvVars:  prefixEnvVars("ROLLUP_HALT"),
 		Category: RollupCategory,
 	}
+	L2ForkCheck = &cli.StringFlag{
+		Name:     "l2.fork-check",
+		Usage:    "Check that the execution engine schedules the forks of the rollup config, at startup and periodically: off, warn (log the mismatches) or halt (refuse to run on a mismatch)",
+		EnvVars:  prefixEnvVars("L2_FORK_CHECK"),
+		Value:    "warn",
+		Category: RollupCategory,
+	}
+	L2ForkCheckRPC = &cli.StringFlag{
+		Name:     "l2.fork-check.rpc",
+		Usage:    "Execution engine RPC endpoint that serves debug_chainConfig, to read the fork schedule of the execution engine from. The engine API endpoint is used if not set.",
+		EnvVars:  prefixEnvVars("L2_FORK_CHECK_RPC"),
+		Category: RollupCategory,
+	}
+	L2ForkCheckInterval = &cli.DurationFlag{
+		Name:     "l2.fork-check.interval",
+		Usage:    "Interval between fork schedule checks after startup. Disabled if 0 or negative.",
+		EnvVars:  prefixEnvVars("L2_FORK_CHECK_INTERVAL"),
+		Value:    10 * time.Minute,
+		Category: RollupCategory,
+	}
+	InteropSupervisor = &cli.StringFlag{
+		Name: "interop.supervisor",
+		Usage: "RPC endpoint of the interop supervisor. Blocks with cross-chain messages are only promoted to safe once the supervisor verifies the messages. " +
+			"Disabled if not set.",
+		EnvVars:  prefixEnvVars("INTEROP_SUPERVISOR"),
+		Category: RollupCategory,
+	}
+	InteropFailurePolicy = &cli.StringFlag{
+		Name: "interop.failure-policy",
+		Usage: "Whether blocks with cross-chain messages are promoted to safe while the interop supervisor is unavailable: " +
+			"halt (do not promote until the supervisor verifies the messages) or allow (promote with unverified messages)",
+		EnvVars:  prefixEnvVars("INTEROP_FAILURE_POLICY"),
+		Value:    "halt",
+		Category: RollupCategory,
+	}
 	RollupLoadProtocolVersions = &cli.BoolFlag{
 		Name:     "rollup.load-protocol-versions",
 		Usage:    "Load protocol versions from the superchain L1 ProtocolVersions contract (if available), and report in logs and metrics",
@@ -314,6 +496,45 @@ var (
 		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
 		Category: OperationsCategory,
 	}
+	ChainsConfig = &cli.StringFlag{
+		Name: "chains.config",
+		Usage: "Path to a JSON file with a list of additional chains to host in this process, each with its own rollup config, " +
+			"execution engine and derivation pipeline. The chains share the L1 connections, p2p host and RPC server, " +
+			"and serve their optimism RPC namespace suffixed with the L2 chain ID, e.g. optimism10_syncStatus.",
+		EnvVars:   prefixEnvVars("CHAINS_CONFIG"),
+		TakesFile: true,
+		Category:  OperationsCategory,
+	}
+	MemoryBudgetFrameQueueFlag = &cli.Uint64Flag{
+		Name:     "derivation.memory-budget.frame-queue",
+		Usage:    "Maximum estimated memory in bytes of frames buffered by the derivation pipeline, before they are added to channels. Derivation stops traversing L1 instead of exceeding this. Unlimited if 0.",
+		EnvVars:  prefixEnvVars("DERIVATION_MEMORY_BUDGET_FRAME_QUEUE"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	MemoryBudgetChannelBankFlag = &cli.Uint64Flag{
+		Name:     "derivation.memory-budget.channel-bank",
+		Usage:    "Maximum estimated memory in bytes of channel data buffered by the derivation pipeline. Derivation stops reading and traversing L1 data instead of exceeding this. Only the protocol limit applies if 0.",
+		EnvVars:  prefixEnvVars("DERIVATION_MEMORY_BUDGET_CHANNEL_BANK"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	MemoryBudgetUnsafePayloadsFlag = &cli.Uint64Flag{
+		Name:     "derivation.memory-budget.unsafe-payloads",
+		Usage:    "Maximum estimated memory in bytes of unsafe payloads buffered for processing. The lowest payloads are dropped when this is exceeded.",
+		EnvVars:  prefixEnvVars("DERIVATION_MEMORY_BUDGET_UNSAFE_PAYLOADS"),
+		Value:    derive.DefaultMaxUnsafePayloadsMemory,
+		Category: RollupCategory,
+	}
+	ConfigFileFlag = &cli.StringFlag{
+		Name: "config",
+		Usage: "Path to a TOML (.toml) or YAML (.yaml, .yml) config file with flag values, keyed by flag name. " +
+			"Flags and env vars take precedence over the config file. The config file is reloaded on SIGHUP, " +
+			"applying changes of log.level and rollup.halt at runtime.",
+		EnvVars:   prefixEnvVars("CONFIG"),
+		TakesFile: true,
+		Category:  MiscCategory,
+	}
 	/* Deprecated Flags */
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:    "l2.engine-sync",
@@ -370,6 +591,30 @@ var (
 		Value:    time.Second * 1,
 		Category: SequencerCategory,
 	}
+	ConductorRpcAPIKeyFileFlag = &cli.StringFlag{
+		Name:     "conductor.rpc-api-key-file",
+		Usage:    "Path to a file with the API key to authenticate with to the conductor, if the conductor requires admin RPC authentication",
+		EnvVars:  prefixEnvVars("CONDUCTOR_RPC_API_KEY_FILE"),
+		Category: SequencerCategory,
+	}
+	ConductorRpcTLSCaCertFlag = &cli.StringFlag{
+		Name:     "conductor.rpc-tls.ca",
+		Usage:    "Path to the CA certificate of the conductor RPC server, to connect to the conductor with mutual TLS",
+		EnvVars:  prefixEnvVars("CONDUCTOR_RPC_TLS_CA"),
+		Category: SequencerCategory,
+	}
+	ConductorRpcTLSCertFlag = &cli.StringFlag{
+		Name:     "conductor.rpc-tls.cert",
+		Usage:    "Path to the client certificate to authenticate with to the conductor with mutual TLS",
+		EnvVars:  prefixEnvVars("CONDUCTOR_RPC_TLS_CERT"),
+		Category: SequencerCategory,
+	}
+	ConductorRpcTLSKeyFlag = &cli.StringFlag{
+		Name:     "conductor.rpc-tls.key",
+		Usage:    "Path to the key of the client certificate to authenticate with to the conductor with mutual TLS",
+		EnvVars:  prefixEnvVars("CONDUCTOR_RPC_TLS_KEY"),
+		Category: SequencerCategory,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -384,7 +629,11 @@ var optionalFlags = []cli.Flag{
 	BeaconFallbackAddrs,
 	BeaconCheckIgnore,
 	BeaconFetchAllSidecars,
+	BeaconBlobCacheDir,
+	BeaconBlobCacheSize,
+	BeaconHTTPProfile,
 	SyncModeFlag,
+	MaxUnsafeReorgDepthFlag,
 	RPCListenAddr,
 	RPCListenPort,
 	L1TrustRPC,
@@ -393,14 +642,27 @@ var optionalFlags = []cli.Flag{
 	L1RPCMaxBatchSize,
 	L1RPCMaxConcurrency,
 	L1HTTPPollInterval,
+	L1HTTPProfile,
+	L2EngineHTTPProfile,
+	L2EngineMaxResponseSize,
 	VerifierL1Confs,
+	VerifierFinalityDelay,
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
+	SequencerInclusionDeadlineBypassBuilderFlag,
+	SequencerBuilderAddrFlag,
+	SequencerBuilderJWTSecretFlag,
+	SequencerBuilderMaxResponseSizeFlag,
+	SequencerInclusionDeadlinesFileFlag,
+	SequencerPolicyFileFlag,
+	SequencerActionLogFlag,
+	SequencerActionLogMaxSizeFlag,
 	SequencerL1Confs,
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
 	RPCEnableAdmin,
+	RPCEnableDebug,
 	RPCAdminPersistence,
 	MetricsEnabledFlag,
 	MetricsAddrFlag,
@@ -409,14 +671,31 @@ var optionalFlags = []cli.Flag{
 	HeartbeatEnabledFlag,
 	HeartbeatMonikerFlag,
 	HeartbeatURLFlag,
+	HeartbeatIntervalFlag,
+	HeartbeatStatusFlag,
+	HeartbeatSigningKeyFileFlag,
 	RollupHalt,
+	L2ForkCheck,
+	L2ForkCheckRPC,
+	L2ForkCheckInterval,
+	InteropSupervisor,
+	InteropFailurePolicy,
 	RollupLoadProtocolVersions,
 	L1RethDBPath,
 	ConductorEnabledFlag,
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
+	ConductorRpcAPIKeyFileFlag,
+	ConductorRpcTLSCaCertFlag,
+	ConductorRpcTLSCertFlag,
+	ConductorRpcTLSKeyFlag,
 	SafeDBPath,
+	ChainsConfig,
+	MemoryBudgetFrameQueueFlag,
+	MemoryBudgetChannelBankFlag,
+	MemoryBudgetUnsafePayloadsFlag,
 	L2EngineKind,
+	ConfigFileFlag,
 }
 
 var DeprecatedFlags = []cli.Flag{
@@ -436,9 +715,12 @@ func init() {
 	optionalFlags = append(optionalFlags, P2PFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlagsWithCategory(EnvVarPrefix, OperationsCategory)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlagsWithCategory(EnvVarPrefix, OperationsCategory)...)
+	optionalFlags = append(optionalFlags, optracing.CLIFlagsWithCategory(EnvVarPrefix, OperationsCategory)...)
 	optionalFlags = append(optionalFlags, DeprecatedFlags...)
 	optionalFlags = append(optionalFlags, opflags.CLIFlags(EnvVarPrefix, RollupCategory)...)
 	optionalFlags = append(optionalFlags, plasma.CLIFlags(EnvVarPrefix, AltDACategory)...)
+	optionalFlags = append(optionalFlags, opsigner.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, oprpc.AdminAuthCLIFlagsWithCategory(EnvVarPrefix, OperationsCategory)...)
 	Flags = append(requiredFlags, optionalFlags...)
 }
 
@@ -14,47 +14,51 @@ func p2pEnv(envprefix, v string) []string {
 }
 
 var (
-	DisableP2PName          = "p2p.disable"
-	NoDiscoveryName         = "p2p.no-discovery"
-	ScoringName             = "p2p.scoring"
-	PeerScoringName         = "p2p.scoring.peers"
-	PeerScoreBandsName      = "p2p.score.bands"
-	BanningName             = "p2p.ban.peers"
-	BanningThresholdName    = "p2p.ban.threshold"
-	BanningDurationName     = "p2p.ban.duration"
-	TopicScoringName        = "p2p.scoring.topics"
-	P2PPrivPathName         = "p2p.priv.path"
-	P2PPrivRawName          = "p2p.priv.raw"
-	ListenIPName            = "p2p.listen.ip"
-	ListenTCPPortName       = "p2p.listen.tcp"
-	ListenUDPPortName       = "p2p.listen.udp"
-	AdvertiseIPName         = "p2p.advertise.ip"
-	AdvertiseTCPPortName    = "p2p.advertise.tcp"
-	AdvertiseUDPPortName    = "p2p.advertise.udp"
-	BootnodesName           = "p2p.bootnodes"
-	StaticPeersName         = "p2p.static"
-	NetRestrictName         = "p2p.netrestrict"
-	HostMuxName             = "p2p.mux"
-	HostSecurityName        = "p2p.security"
-	PeersLoName             = "p2p.peers.lo"
-	PeersHiName             = "p2p.peers.hi"
-	PeersGraceName          = "p2p.peers.grace"
-	NATName                 = "p2p.nat"
-	UserAgentName           = "p2p.useragent"
-	TimeoutNegotiationName  = "p2p.timeout.negotiation"
-	TimeoutAcceptName       = "p2p.timeout.accept"
-	TimeoutDialName         = "p2p.timeout.dial"
-	PeerstorePathName       = "p2p.peerstore.path"
-	DiscoveryPathName       = "p2p.discovery.path"
-	SequencerP2PKeyName     = "p2p.sequencer.key"
-	GossipMeshDName         = "p2p.gossip.mesh.d"
-	GossipMeshDloName       = "p2p.gossip.mesh.lo"
-	GossipMeshDhiName       = "p2p.gossip.mesh.dhi"
-	GossipMeshDlazyName     = "p2p.gossip.mesh.dlazy"
-	GossipFloodPublishName  = "p2p.gossip.mesh.floodpublish"
-	SyncReqRespName         = "p2p.sync.req-resp"
-	SyncOnlyReqToStaticName = "p2p.sync.onlyreqtostatic"
-	P2PPingName             = "p2p.ping"
+	DisableP2PName           = "p2p.disable"
+	NoDiscoveryName          = "p2p.no-discovery"
+	ScoringName              = "p2p.scoring"
+	PeerScoringName          = "p2p.scoring.peers"
+	PeerScoreBandsName       = "p2p.score.bands"
+	BanningName              = "p2p.ban.peers"
+	BanningThresholdName     = "p2p.ban.threshold"
+	BanningDurationName      = "p2p.ban.duration"
+	TopicScoringName         = "p2p.scoring.topics"
+	P2PPrivPathName          = "p2p.priv.path"
+	P2PPrivRawName           = "p2p.priv.raw"
+	ListenIPName             = "p2p.listen.ip"
+	ListenTCPPortName        = "p2p.listen.tcp"
+	ListenUDPPortName        = "p2p.listen.udp"
+	AdvertiseIPName          = "p2p.advertise.ip"
+	AdvertiseTCPPortName     = "p2p.advertise.tcp"
+	AdvertiseUDPPortName     = "p2p.advertise.udp"
+	BootnodesName            = "p2p.bootnodes"
+	StaticPeersName          = "p2p.static"
+	StaticMeshName           = "p2p.static-mesh"
+	NetRestrictName          = "p2p.netrestrict"
+	HostMuxName              = "p2p.mux"
+	HostSecurityName         = "p2p.security"
+	PeersLoName              = "p2p.peers.lo"
+	PeersHiName              = "p2p.peers.hi"
+	PeersGraceName           = "p2p.peers.grace"
+	NATName                  = "p2p.nat"
+	UserAgentName            = "p2p.useragent"
+	TimeoutNegotiationName   = "p2p.timeout.negotiation"
+	TimeoutAcceptName        = "p2p.timeout.accept"
+	TimeoutDialName          = "p2p.timeout.dial"
+	PeerstorePathName        = "p2p.peerstore.path"
+	DiscoveryPathName        = "p2p.discovery.path"
+	SequencerP2PKeyName      = "p2p.sequencer.key"
+	GossipMeshDName          = "p2p.gossip.mesh.d"
+	GossipMeshDloName        = "p2p.gossip.mesh.lo"
+	GossipMeshDhiName        = "p2p.gossip.mesh.dhi"
+	GossipMeshDlazyName      = "p2p.gossip.mesh.dlazy"
+	GossipFloodPublishName   = "p2p.gossip.mesh.floodpublish"
+	SyncReqRespName          = "p2p.sync.req-resp"
+	SyncOnlyReqToStaticName  = "p2p.sync.onlyreqtostatic"
+	SyncCompressionName      = "p2p.sync.compression"
+	SafeHeadAttestationsName = "p2p.safe-head-attestations"
+	SafeHeadAttesterName     = "p2p.safe-head-attester"
+	P2PPingName              = "p2p.ping"
 )
 
 func deprecatedP2PFlags(envPrefix string) []cli.Flag {
@@ -222,6 +226,15 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			EnvVars:  p2pEnv(envPrefix, "STATIC"),
 			Category: P2PCategory,
 		},
+		&cli.BoolFlag{
+			Name: StaticMeshName,
+			Usage: "Restrict the node to a fixed mesh of the static peers, for permissioned sequencer/verifier networks. " +
+				"Disables discovery, and rejects connections with peers other than the static peers, whose peer IDs are authenticated by the transport security. " +
+				"Disconnected static peers are reconnected, and a degraded mesh is reported by metrics and the health checks.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "STATIC_MESH"),
+			Category: P2PCategory,
+		},
 		&cli.StringFlag{
 			Name:     NetRestrictName,
 			Usage:    "Comma-separated list of CIDR masks. P2P will only try to connect on these networks",
@@ -336,7 +349,7 @@ func P2PFlags(envPrefix string) []cli.Flag {
 		},
 		&cli.StringFlag{
 			Name:     SequencerP2PKeyName,
-			Usage:    "Hex-encoded private key for signing off on p2p application messages as sequencer.",
+			Usage:    "Hex-encoded private key for signing off on p2p application messages as sequencer. Alternatively, use a remote signer or KMS key with the signer flags.",
 			Required: false,
 			Value:    "",
 			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_KEY"),
@@ -402,6 +415,29 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			EnvVars:  p2pEnv(envPrefix, "SYNC_ONLYREQTOSTATIC"),
 			Category: P2PCategory,
 		},
+		&cli.StringFlag{
+			Name:     SyncCompressionName,
+			Usage:    "Comma-separated list of compression codecs of P2P req-resp sync responses, by preference, negotiated with each peer. Options: 'zstd', 'snappy'. Snappy is always supported, as the codec of the sync protocol spec.",
+			Value:    "zstd,snappy",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SYNC_COMPRESSION"),
+			Category: P2PCategory,
+		},
+		&cli.BoolFlag{
+			Name:     SafeHeadAttestationsName,
+			Usage:    "Enables gossip of signed safe-head attestations. A node with a p2p signer publishes its safe head, other nodes report the attested safe head as claimed safe head in the sync status. Attestations do not affect consensus.",
+			Value:    false,
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SAFE_HEAD_ATTESTATIONS"),
+			Category: P2PCategory,
+		},
+		&cli.StringFlag{
+			Name:     SafeHeadAttesterName,
+			Usage:    "Address of the signer of safe-head attestations. Defaults to the p2p sequencer address of the rollup.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SAFE_HEAD_ATTESTER"),
+			Category: P2PCategory,
+		},
 		&cli.BoolFlag{
 			Name:     P2PPingName,
 			Usage:    "Enables P2P ping-pong background service",
@@ -9,18 +9,48 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 // SendInterval determines the delay between requests. This must be larger than the MinHeartbeatInterval in the server.
 const SendInterval = 10 * time.Minute
 
+// SignatureHeader holds the signature of the request body of signed heartbeats, see Options.Sign.
+const SignatureHeader = "X-Heartbeat-Signature"
+
 type Payload struct {
 	Version string `json:"version"`
 	Meta    string `json:"meta"`
 	Moniker string `json:"moniker"`
 	PeerID  string `json:"peerID"`
 	ChainID uint64 `json:"chainID"`
+
+	// Status is the sync status of the node, if it is included in the heartbeats, see Options.Status.
+	Status *Status `json:"status,omitempty"`
+	// Timestamp is the unix time of signed heartbeats, for the server to reject replayed heartbeats.
+	Timestamp uint64 `json:"timestamp,omitempty"`
+}
+
+// Status is the sync status and peer count of the node at the time of the heartbeat.
+type Status struct {
+	HeadL1      uint64 `json:"headL1"`
+	CurrentL1   uint64 `json:"currentL1"`
+	UnsafeL2    uint64 `json:"unsafeL2"`
+	SafeL2      uint64 `json:"safeL2"`
+	FinalizedL2 uint64 `json:"finalizedL2"`
+	PeerCount   int    `json:"peerCount"`
+}
+
+// Options extend the heartbeats sent by BeatWithOptions.
+type Options struct {
+	// Interval is the delay between heartbeats. SendInterval is used if 0.
+	Interval time.Duration
+	// Status returns the status to include in each heartbeat. Heartbeats have no status if nil.
+	Status func(ctx context.Context) (*Status, error)
+	// Sign returns the signature of the request body of each heartbeat, which is sent in the SignatureHeader.
+	// Signed heartbeats are timestamped. Heartbeats are not signed if nil.
+	Sign func(ctx context.Context, body []byte) ([]byte, error)
 }
 
 // Beat sends a heartbeat to the server at the given URL. It will send a heartbeat immediately, and then every SendInterval.
@@ -31,23 +61,62 @@ func Beat(
 	url string,
 	payload *Payload,
 ) error {
-	payloadJSON, err := json.Marshal(payload)
-	if err != nil {
+	return BeatWithOptions(ctx, log, url, payload, Options{})
+}
+
+// BeatWithOptions is like Beat, but sends heartbeats at the interval of the options,
+// with the status of the node and signed, if set in the options.
+func BeatWithOptions(
+	ctx context.Context,
+	log log.Logger,
+	url string,
+	payload *Payload,
+	opts Options,
+) error {
+	if _, err := json.Marshal(payload); err != nil {
 		return fmt.Errorf("telemetry crashed: %w", err)
 	}
+	interval := opts.Interval
+	if interval == 0 {
+		interval = SendInterval
+	}
 
 	client := &http.Client{
 		Timeout: 10 * time.Second,
 	}
 
 	send := func() {
+		p := *payload
+		if opts.Status != nil {
+			status, err := opts.Status(ctx)
+			if err != nil {
+				log.Warn("error getting the status for the heartbeat", "err", err)
+			}
+			p.Status = status
+		}
+		if opts.Sign != nil {
+			p.Timestamp = uint64(time.Now().Unix())
+		}
+		payloadJSON, err := json.Marshal(&p)
+		if err != nil {
+			log.Error("error encoding heartbeat", "err", err)
+			return
+		}
 		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payloadJSON))
-		req.Header.Set("User-Agent", fmt.Sprintf("op-node/%s", payload.Version))
-		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			log.Error("error creating heartbeat HTTP request", "err", err)
 			return
 		}
+		req.Header.Set("User-Agent", fmt.Sprintf("op-node/%s", payload.Version))
+		req.Header.Set("Content-Type", "application/json")
+		if opts.Sign != nil {
+			sig, err := opts.Sign(ctx, payloadJSON)
+			if err != nil {
+				log.Warn("error signing heartbeat", "err", err)
+				return
+			}
+			req.Header.Set(SignatureHeader, hexutil.Encode(sig))
+		}
 		res, err := client.Do(req)
 		if err != nil {
 			log.Warn("error sending heartbeat", "err", err)
@@ -64,7 +133,7 @@ func Beat(
 	}
 
 	send()
-	tick := time.NewTicker(SendInterval)
+	tick := time.NewTicker(interval)
 	defer tick.Stop()
 	for {
 		select {
@@ -2,6 +2,7 @@ package heartbeat
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -56,3 +57,61 @@ func TestBeat(t *testing.T) {
 		t.Fatalf("error: %v", ctx.Err())
 	}
 }
+
+func TestBeatWithOptions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	type request struct {
+		body string
+		sig  string
+	}
+	reqCh := make(chan request, 2)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		reqCh <- request{body: string(body), sig: r.Header.Get(SignatureHeader)}
+		r.Body.Close()
+	}))
+	defer s.Close()
+
+	status := &Status{HeadL1: 10, CurrentL1: 9, UnsafeL2: 8, SafeL2: 7, FinalizedL2: 6, PeerCount: 5}
+	var signed []byte
+	opts := Options{
+		Status: func(ctx context.Context) (*Status, error) {
+			return status, nil
+		},
+		Sign: func(ctx context.Context, body []byte) ([]byte, error) {
+			signed = body
+			return []byte{0xaa, 0xbb}, nil
+		},
+	}
+	doneCh := make(chan struct{})
+	go func() {
+		_ = BeatWithOptions(ctx, log.Root(), s.URL, &Payload{
+			Version: "v1.2.3",
+			Meta:    "meta",
+			Moniker: "yeet",
+			PeerID:  "1UiUfoobar",
+			ChainID: 1234,
+		}, opts)
+		doneCh <- struct{}{}
+	}()
+
+	select {
+	case req := <-reqCh:
+		cancel()
+		<-doneCh
+		require.Equal(t, "0xaabb", req.sig)
+		require.Equal(t, string(signed), req.body, "must sign the request body")
+
+		var payload Payload
+		require.NoError(t, json.Unmarshal([]byte(req.body), &payload))
+		require.Equal(t, status, payload.Status)
+		require.NotZero(t, payload.Timestamp, "signed heartbeats must be timestamped")
+		require.Equal(t, "yeet", payload.Moniker)
+	case <-ctx.Done():
+		t.Fatalf("error: %v", ctx.Err())
+	}
+}
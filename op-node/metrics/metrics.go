@@ -31,11 +31,14 @@ const Namespace = "op_node"
 type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
-	RecordRPCServerRequest(method string) func()
+	RecordRPCServerRequest(method string, duration time.Duration, requestSize int, responseSize int)
+	RecordRPCServerResponse(method string, err error)
 	RecordRPCClientRequest(method string) func(err error)
 	RecordRPCClientResponse(method string, err error)
+	RecordRPCClientPayloadSizes(method string, requestSize int, responseSize int)
 	SetDerivationIdle(status bool)
 	RecordPipelineReset()
+	RecordDerivationMemSize(stage string, memSize uint64)
 	RecordSequencingError()
 	RecordPublishingError()
 	RecordDerivationError()
@@ -48,6 +51,8 @@ type Metricer interface {
 	RecordL2Ref(name string, ref eth.L2BlockRef)
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
 	RecordDerivedBatches(batchType string)
+	RecordDerivedDeposits(included int, pending int)
+	RecordInclusionDeadlineDropped()
 	CountSequencedTxs(count int)
 	RecordL1ReorgDepth(d uint64)
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
@@ -57,6 +62,7 @@ type Metricer interface {
 	DecPeerCount()
 	IncStreamCount()
 	DecStreamCount()
+	RecordStaticMeshPeers(connected int, total int)
 	RecordBandwidth(ctx context.Context, bwc *libp2pmetrics.BandwidthCounter)
 	RecordSequencerBuildingDiffTime(duration time.Duration)
 	RecordSequencerSealingTime(duration time.Duration)
@@ -70,6 +76,7 @@ type Metricer interface {
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
+	RecordP2PCompression(protocol string, codec string, outbound bool, compressed int, uncompressed int)
 	RecordPeerUnban()
 	RecordIPUnban()
 	RecordDial(allow bool)
@@ -84,10 +91,13 @@ type Metrics struct {
 
 	metrics.RPCMetrics
 
+	HTTPClientMetrics metrics.HTTPClientMetrics
+
 	L1SourceCache *metrics.CacheMetrics
 	L2SourceCache *metrics.CacheMetrics
 
-	DerivationIdle prometheus.Gauge
+	DerivationIdle    prometheus.Gauge
+	DerivationMemSize *prometheus.GaugeVec
 
 	PipelineResets   *metrics.Event
 	UnsafePayloads   *metrics.Event
@@ -102,15 +112,23 @@ type Metrics struct {
 
 	DerivedBatches metrics.EventVec
 
+	DerivedDeposits prometheus.Counter
+	PendingDeposits prometheus.Gauge
+
 	P2PReqDurationSeconds *prometheus.HistogramVec
 	P2PReqTotal           *prometheus.CounterVec
 	P2PPayloadByNumber    *prometheus.GaugeVec
 
+	P2PCompressedBytes   *prometheus.CounterVec
+	P2PUncompressedBytes *prometheus.CounterVec
+
 	PayloadsQuarantineTotal prometheus.Gauge
 
 	SequencerInconsistentL1Origin *metrics.Event
 	SequencerResets               *metrics.Event
 
+	InclusionDeadlinesDropped *metrics.Event
+
 	L1RequestDurationSeconds *prometheus.HistogramVec
 
 	SequencerBuildingDiffDurationSeconds prometheus.Histogram
@@ -138,6 +156,7 @@ type Metrics struct {
 	// P2P Metrics
 	PeerCount         prometheus.Gauge
 	StreamCount       prometheus.Gauge
+	StaticMeshPeers   *prometheus.GaugeVec
 	GossipEventsTotal *prometheus.CounterVec
 	BandwidthTotal    *prometheus.GaugeVec
 	PeerUnbans        prometheus.Counter
@@ -188,6 +207,8 @@ func NewMetrics(procName string) *Metrics {
 
 		RPCMetrics: metrics.MakeRPCMetrics(ns, factory),
 
+		HTTPClientMetrics: metrics.MakeHTTPClientMetrics(ns, factory),
+
 		L1SourceCache: metrics.NewCacheMetrics(factory, ns, "l1_source_cache", "L1 Source cache"),
 		L2SourceCache: metrics.NewCacheMetrics(factory, ns, "l2_source_cache", "L2 Source cache"),
 
@@ -196,6 +217,13 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "derivation_idle",
 			Help:      "1 if the derivation pipeline is idle",
 		}),
+		DerivationMemSize: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "derivation_mem_size",
+			Help:      "Estimated memory size of the data buffered by a stage of the derivation pipeline",
+		}, []string{
+			"stage",
+		}),
 
 		PipelineResets:   metrics.NewEvent(factory, ns, "", "pipeline_resets", "derivation pipeline resets"),
 		UnsafePayloads:   metrics.NewEvent(factory, ns, "", "unsafe_payloads", "unsafe payloads"),
@@ -223,9 +251,22 @@ func NewMetrics(procName string) *Metrics {
 
 		DerivedBatches: metrics.NewEventVec(factory, ns, "", "derived_batches", "derived batches", []string{"type"}),
 
+		DerivedDeposits: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "derived_deposits",
+			Help:      "Number of user deposits included in derived L2 blocks",
+		}),
+		PendingDeposits: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "pending_deposits",
+			Help:      "Number of user deposits of the current epoch that exceed the deposit limits of the derived L2 blocks so far",
+		}),
+
 		SequencerInconsistentL1Origin: metrics.NewEvent(factory, ns, "", "sequencer_inconsistent_l1_origin", "events when the sequencer selects an inconsistent L1 origin"),
 		SequencerResets:               metrics.NewEvent(factory, ns, "", "sequencer_resets", "sequencer resets"),
 
+		InclusionDeadlinesDropped: metrics.NewEvent(factory, ns, "", "inclusion_deadlines_dropped", "transactions dropped after missing their inclusion deadline"),
+
 		UnsafePayloadsBufferLen: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "unsafe_payloads_buffer_len",
@@ -270,6 +311,14 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "stream_count",
 			Help:      "Count of currently connected p2p streams",
 		}),
+		StaticMeshPeers: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "static_mesh_peers",
+			Help:      "Count of static peers of the static peer mesh, by state: connected or total",
+		}, []string{
+			"state",
+		}),
 		GossipEventsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -352,6 +401,26 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"p2p_role", // "client" or "server"
 		}),
+		P2PCompressedBytes: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "compressed_bytes_total",
+			Help:      "Compressed size of gossiped and synced payloads, by codec",
+		}, []string{
+			"protocol", // "gossip_blocks" or "payload_by_number"
+			"codec",
+			"direction", // "in" or "out"
+		}),
+		P2PUncompressedBytes: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "uncompressed_bytes_total",
+			Help:      "Uncompressed size of gossiped and synced payloads, by codec",
+		}, []string{
+			"protocol",
+			"codec",
+			"direction",
+		}),
 		PayloadsQuarantineTotal: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -455,6 +524,10 @@ func (m *Metrics) SetDerivationIdle(status bool) {
 	m.DerivationIdle.Set(val)
 }
 
+func (m *Metrics) RecordDerivationMemSize(stage string, memSize uint64) {
+	m.DerivationMemSize.WithLabelValues(stage).Set(float64(memSize))
+}
+
 func (m *Metrics) RecordPipelineReset() {
 	m.PipelineResets.Record()
 }
@@ -498,6 +571,15 @@ func (m *Metrics) RecordDerivedBatches(batchType string) {
 	m.DerivedBatches.Record(batchType)
 }
 
+func (m *Metrics) RecordDerivedDeposits(included int, pending int) {
+	m.DerivedDeposits.Add(float64(included))
+	m.PendingDeposits.Set(float64(pending))
+}
+
+func (m *Metrics) RecordInclusionDeadlineDropped() {
+	m.InclusionDeadlinesDropped.Record()
+}
+
 func (m *Metrics) CountSequencedTxs(count int) {
 	m.TransactionsSequencedTotal.Add(float64(count))
 }
@@ -536,6 +618,11 @@ func (m *Metrics) DecStreamCount() {
 	m.StreamCount.Dec()
 }
 
+func (m *Metrics) RecordStaticMeshPeers(connected int, total int) {
+	m.StaticMeshPeers.WithLabelValues("connected").Set(float64(connected))
+	m.StaticMeshPeers.WithLabelValues("total").Set(float64(total))
+}
+
 func (m *Metrics) RecordBandwidth(ctx context.Context, bwc *libp2pmetrics.BandwidthCounter) {
 	tick := time.NewTicker(10 * time.Second)
 	defer tick.Stop()
@@ -606,6 +693,15 @@ func (m *Metrics) PayloadsQuarantineSize(n int) {
 	m.PayloadsQuarantineTotal.Set(float64(n))
 }
 
+func (m *Metrics) RecordP2PCompression(protocol string, codec string, outbound bool, compressed int, uncompressed int) {
+	direction := "in"
+	if outbound {
+		direction = "out"
+	}
+	m.P2PCompressedBytes.WithLabelValues(protocol, codec, direction).Add(float64(compressed))
+	m.P2PUncompressedBytes.WithLabelValues(protocol, codec, direction).Add(float64(uncompressed))
+}
+
 func (m *Metrics) RecordChannelInputBytes(inputCompressedBytes int) {
 	m.ChannelInputBytes.Add(float64(inputCompressedBytes))
 }
@@ -671,6 +767,9 @@ func (n *noopMetricer) SetDerivationIdle(status bool) {
 func (n *noopMetricer) RecordPipelineReset() {
 }
 
+func (n *noopMetricer) RecordDerivationMemSize(stage string, memSize uint64) {
+}
+
 func (n *noopMetricer) RecordSequencingError() {
 }
 
@@ -707,6 +806,12 @@ func (n *noopMetricer) RecordUnsafePayloadsBuffer(length uint64, memSize uint64,
 func (n *noopMetricer) RecordDerivedBatches(batchType string) {
 }
 
+func (n *noopMetricer) RecordDerivedDeposits(included int, pending int) {
+}
+
+func (n *noopMetricer) RecordInclusionDeadlineDropped() {
+}
+
 func (n *noopMetricer) CountSequencedTxs(count int) {
 }
 
@@ -728,6 +833,9 @@ func (n *noopMetricer) SetPeerScores(allScores []store.PeerScores) {
 func (n *noopMetricer) IncPeerCount() {
 }
 
+func (n *noopMetricer) RecordStaticMeshPeers(connected int, total int) {
+}
+
 func (n *noopMetricer) DecPeerCount() {
 }
 
@@ -759,6 +867,9 @@ func (n *noopMetricer) ServerPayloadByNumberEvent(num uint64, resultCode byte, d
 func (n *noopMetricer) PayloadsQuarantineSize(int) {
 }
 
+func (n *noopMetricer) RecordP2PCompression(protocol string, codec string, outbound bool, compressed int, uncompressed int) {
+}
+
 func (n *noopMetricer) RecordChannelInputBytes(int) {
 }
 
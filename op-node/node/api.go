@@ -8,12 +8,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
-	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
 
@@ -27,6 +27,8 @@ type l2EthClient interface {
 
 type driverClient interface {
 	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+	SubscribeSyncStatus(ch chan<- *eth.SyncStatus) (unsubscribe func())
+	DerivationProgressAtL1(ctx context.Context, l1BlockNum uint64) (*eth.DerivationProgress, error)
 	BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, *eth.SyncStatus, error)
 	ResetDerivationPipeline(context.Context) error
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
@@ -34,6 +36,16 @@ type driverClient interface {
 	SequencerActive(context.Context) (bool, error)
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 	OverrideLeader(ctx context.Context) error
+	ExportSequencerState(ctx context.Context) (*eth.SequencerState, error)
+	ImportSequencerState(ctx context.Context, state *eth.SequencerState) error
+	AddInclusionDeadline(ctx context.Context, txHash common.Hash, blocks uint64) (eth.InclusionDeadline, error)
+	RemoveInclusionDeadline(ctx context.Context, txHash common.Hash) error
+	InclusionDeadlines(ctx context.Context) ([]eth.InclusionDeadline, error)
+	SetSequencerPolicy(ctx context.Context, policy *eth.TxPoolPolicy) error
+	SequencerPolicy(ctx context.Context) (*eth.TxPoolPolicy, error)
+	UnsafeReorgHalt(ctx context.Context) (*eth.UnsafeReorgHalt, error)
+	ConfirmUnsafeReorg(ctx context.Context) error
+	BuilderStatus(ctx context.Context) (*eth.BuilderStatus, error)
 }
 
 type SafeDBReader interface {
@@ -42,50 +54,43 @@ type SafeDBReader interface {
 
 type adminAPI struct {
 	*rpc.CommonAdminAPI
-	dr driverClient
+	dr        driverClient
+	conductor *ConductorClient
+	log       log.Logger
 }
 
-func NewAdminAPI(dr driverClient, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+// NewAdminAPI creates the admin API. The conductor client is nil if the sequencer conductor is disabled.
+func NewAdminAPI(dr driverClient, conductor *ConductorClient, log log.Logger) *adminAPI {
 	return &adminAPI{
-		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
+		CommonAdminAPI: rpc.NewCommonAdminAPI(log),
 		dr:             dr,
+		conductor:      conductor,
+		log:            log,
 	}
 }
 
 func (n *adminAPI) ResetDerivationPipeline(ctx context.Context) error {
-	recordDur := n.M.RecordRPCServerRequest("admin_resetDerivationPipeline")
-	defer recordDur()
 	return n.dr.ResetDerivationPipeline(ctx)
 }
 
 func (n *adminAPI) StartSequencer(ctx context.Context, blockHash common.Hash) error {
-	recordDur := n.M.RecordRPCServerRequest("admin_startSequencer")
-	defer recordDur()
 	return n.dr.StartSequencer(ctx, blockHash)
 }
 
 func (n *adminAPI) StopSequencer(ctx context.Context) (common.Hash, error) {
-	recordDur := n.M.RecordRPCServerRequest("admin_stopSequencer")
-	defer recordDur()
 	return n.dr.StopSequencer(ctx)
 }
 
 func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
-	recordDur := n.M.RecordRPCServerRequest("admin_sequencerActive")
-	defer recordDur()
 	return n.dr.SequencerActive(ctx)
 }
 
 // PostUnsafePayload is a special API that allows posting an unsafe payload to the L2 derivation pipeline.
 // It should only be used by op-conductor for sequencer failover scenarios.
 func (n *adminAPI) PostUnsafePayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
-	recordDur := n.M.RecordRPCServerRequest("admin_postUnsafePayload")
-	defer recordDur()
-
-	payload := envelope.ExecutionPayload
-	if actual, ok := envelope.CheckBlockHash(); !ok {
-		log.Error("payload has bad block hash", "bad_hash", payload.BlockHash.String(), "actual", actual.String())
-		return fmt.Errorf("payload has bad block hash: %s, actual block hash is: %s", payload.BlockHash.String(), actual.String())
+	if err := envelope.VerifyBlockHash(); err != nil {
+		log.Error("rejecting invalid unsafe payload", "hash", envelope.ExecutionPayload.BlockHash, "err", err)
+		return err
 	}
 
 	return n.dr.OnUnsafeL2Payload(ctx, envelope)
@@ -93,35 +98,149 @@ func (n *adminAPI) PostUnsafePayload(ctx context.Context, envelope *eth.Executio
 
 // OverrideLeader disables sequencer conductor interactions and allow sequencer to run in non-HA mode during disaster recovery scenarios.
 func (n *adminAPI) OverrideLeader(ctx context.Context) error {
-	recordDur := n.M.RecordRPCServerRequest("admin_overrideLeader")
-	defer recordDur()
 	return n.dr.OverrideLeader(ctx)
 }
 
+// ExportSequencerState exports the state of the stopped sequencer, to migrate the sequencer to another host
+// with ImportSequencerState. The sequencer must stay stopped after the export.
+func (n *adminAPI) ExportSequencerState(ctx context.Context) (*eth.SequencerState, error) {
+	state, err := n.dr.ExportSequencerState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state.Conductor = n.conductorState()
+	state.Checksum = state.ComputeChecksum()
+	return state, nil
+}
+
+// ImportSequencerState verifies the state exported by another sequencer, and starts this stopped sequencer
+// if it is at the same unsafe head, with a compatible sequencer conductor setup.
+func (n *adminAPI) ImportSequencerState(ctx context.Context, state *eth.SequencerState) error {
+	if err := state.VerifyChecksum(); err != nil {
+		return err
+	}
+	local := n.conductorState()
+	if state.Conductor.Enabled != local.Enabled {
+		return fmt.Errorf("sequencer conductor mismatch: exported sequencer has conductor enabled %v, this sequencer %v", state.Conductor.Enabled, local.Enabled)
+	}
+	if state.Conductor.LeaderOverridden && !local.LeaderOverridden {
+		n.log.Warn("Overriding conductor leadership, like the exported sequencer")
+		if err := n.dr.OverrideLeader(ctx); err != nil {
+			return fmt.Errorf("failed to override conductor leadership: %w", err)
+		}
+	}
+	return n.dr.ImportSequencerState(ctx, state)
+}
+
+// AddInclusionDeadline registers a pending transaction that the sequencer must include within the given number of blocks.
+// The sequencer reports the transaction if it is not included by the deadline,
+// and, if configured to, bypasses the block builder until the transaction is included, removed or dropped.
+func (n *adminAPI) AddInclusionDeadline(ctx context.Context, txHash common.Hash, blocks hexutil.Uint64) (eth.InclusionDeadline, error) {
+	return n.dr.AddInclusionDeadline(ctx, txHash, uint64(blocks))
+}
+
+// RemoveInclusionDeadline stops tracking the inclusion of a transaction.
+func (n *adminAPI) RemoveInclusionDeadline(ctx context.Context, txHash common.Hash) error {
+	return n.dr.RemoveInclusionDeadline(ctx, txHash)
+}
+
+// InclusionDeadlines returns the pending transactions that the sequencer must include before a deadline.
+func (n *adminAPI) InclusionDeadlines(ctx context.Context) ([]eth.InclusionDeadline, error) {
+	return n.dr.InclusionDeadlines(ctx)
+}
+
+// SetSequencerPolicy sets the tx pool policy that the sequencer passes to the execution engine in the payload attributes
+// of the blocks it builds, e.g. to limit the DA size of blocks or to exclude addresses during incidents.
+// The policy never affects derivation. A nil or empty policy clears it.
+func (n *adminAPI) SetSequencerPolicy(ctx context.Context, policy *eth.TxPoolPolicy) error {
+	return n.dr.SetSequencerPolicy(ctx, policy)
+}
+
+// SequencerPolicy returns the tx pool policy that the sequencer builds blocks with, or nil if none is set.
+func (n *adminAPI) SequencerPolicy(ctx context.Context) (*eth.TxPoolPolicy, error) {
+	return n.dr.SequencerPolicy(ctx)
+}
+
+// UnsafeReorgHalt returns the unsafe reorg that halted the node for being deeper than the maximum reorg depth,
+// or nil if the node is not halted.
+func (n *adminAPI) UnsafeReorgHalt(ctx context.Context) (*eth.UnsafeReorgHalt, error) {
+	return n.dr.UnsafeReorgHalt(ctx)
+}
+
+// ConfirmUnsafeReorg allows the unsafe reorg that halted the node, and resumes the node.
+func (n *adminAPI) ConfirmUnsafeReorg(ctx context.Context) error {
+	return n.dr.ConfirmUnsafeReorg(ctx)
+}
+
+func (n *adminAPI) conductorState() eth.SequencerConductorState {
+	if n.conductor == nil {
+		return eth.SequencerConductorState{}
+	}
+	return eth.SequencerConductorState{
+		Enabled:          true,
+		LeaderOverridden: n.conductor.LeaderOverridden(),
+	}
+}
+
+type payloadAttributesBuilder interface {
+	BuildPayloadAttributes(ctx context.Context, l1Origin eth.BlockID, l2Parent eth.BlockID) (*eth.PayloadAttributes, error)
+}
+
+type engineQueueInspector interface {
+	EngineQueueState(ctx context.Context) (*eth.EngineQueueState, error)
+}
+
+type debugAPI struct {
+	attributes  payloadAttributesBuilder
+	engineQueue engineQueueInspector
+	log         log.Logger
+}
+
+// NewDebugAPI creates the debug API, to build payload attributes with, and to inspect the engine queue.
+func NewDebugAPI(attributes payloadAttributesBuilder, engineQueue engineQueueInspector, log log.Logger) *debugAPI {
+	return &debugAPI{
+		attributes:  attributes,
+		engineQueue: engineQueue,
+		log:         log,
+	}
+}
+
+// BuildPayloadAttributes builds the attributes of the L2 block on top of the given L2 parent, with the given L1 origin,
+// as the sequencer does: builders can add transactions to these, instead of re-implementing the deposits derivation.
+func (d *debugAPI) BuildPayloadAttributes(ctx context.Context, l1Origin eth.BlockID, l2Parent eth.BlockID) (*eth.PayloadAttributes, error) {
+	attrs, err := d.attributes.BuildPayloadAttributes(ctx, l1Origin, l2Parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build payload attributes on top of %s with L1 origin %s: %w", l2Parent, l1Origin, err)
+	}
+	return attrs, nil
+}
+
+// EngineQueueState returns the forkchoice targets, the payload that is being built, the queued unsafe payloads,
+// and the most recent block insertion errors, to diagnose a node that does not make progress.
+// The state is a snapshot that is served without blocking the driver event loop.
+func (d *debugAPI) EngineQueueState(ctx context.Context) (*eth.EngineQueueState, error) {
+	return d.engineQueue.EngineQueueState(ctx)
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
 	dr     driverClient
 	safeDB SafeDBReader
 	log    log.Logger
-	m      metrics.RPCMetricer
 }
 
-func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, safeDB SafeDBReader, log log.Logger, m metrics.RPCMetricer) *nodeAPI {
+func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, safeDB SafeDBReader, log log.Logger) *nodeAPI {
 	return &nodeAPI{
 		config: config,
 		client: l2Client,
 		dr:     dr,
 		safeDB: safeDB,
 		log:    log,
-		m:      m,
 	}
 }
 
 func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*eth.OutputResponse, error) {
-	recordDur := n.m.RecordRPCServerRequest("optimism_outputAtBlock")
-	defer recordDur()
-
 	ref, status, err := n.dr.BlockRefWithStatus(ctx, uint64(number))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get L2 block ref with sync status: %w", err)
@@ -142,8 +261,6 @@ func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*et
 }
 
 func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64) (*eth.SafeHeadResponse, error) {
-	recordDur := n.m.RecordRPCServerRequest("optimism_safeHeadAtL1Block")
-	defer recordDur()
 	l1Block, safeHead, err := n.safeDB.SafeHeadAtL1(ctx, uint64(number))
 	if errors.Is(err, safedb.ErrNotFound) {
 		return nil, err
@@ -156,20 +273,86 @@ func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64)
 	}, nil
 }
 
+// DerivationProgressAtL1Block returns the frames, channels and batches that were read from the L1 block,
+// and the safe L2 blocks derived from it. Only the most recent L1 blocks are kept track of.
+func (n *nodeAPI) DerivationProgressAtL1Block(ctx context.Context, number hexutil.Uint64) (*eth.DerivationProgress, error) {
+	return n.dr.DerivationProgressAtL1(ctx, uint64(number))
+}
+
+// L2BlockByTimestamp returns the L2 block at the given timestamp: the last L2 block with a timestamp at or before it.
+// L2 blocks have a fixed block time, so the block number is computed from the rollup config.
+// An error is returned if the timestamp is before genesis, or if the L2 block was not produced yet.
+func (n *nodeAPI) L2BlockByTimestamp(ctx context.Context, timestamp hexutil.Uint64) (eth.L2BlockRef, error) {
+	num, err := n.config.TargetBlockNumber(uint64(timestamp))
+	if err != nil {
+		return eth.L2BlockRef{}, err
+	}
+	status, err := n.dr.SyncStatus(ctx)
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to get sync status: %w", err)
+	}
+	if num > status.UnsafeL2.Number {
+		return eth.L2BlockRef{}, fmt.Errorf("L2 block %d at timestamp %d is ahead of the unsafe head %s", num, timestamp, status.UnsafeL2)
+	}
+	ref, _, err := n.dr.BlockRefWithStatus(ctx, num)
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to get L2 block %d: %w", num, err)
+	}
+	return ref, nil
+}
+
+// L1OriginAtTimestamp returns the L1 origin of the L2 block at the given timestamp.
+// This is the latest L1 block that L2 state at the timestamp reflects deposits and L1 attributes of.
+func (n *nodeAPI) L1OriginAtTimestamp(ctx context.Context, timestamp hexutil.Uint64) (eth.BlockID, error) {
+	ref, err := n.L2BlockByTimestamp(ctx, timestamp)
+	if err != nil {
+		return eth.BlockID{}, err
+	}
+	return ref.L1Origin, nil
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
-	recordDur := n.m.RecordRPCServerRequest("optimism_syncStatus")
-	defer recordDur()
 	return n.dr.SyncStatus(ctx)
 }
 
+// SyncStatusUpdates is a subscription, served over websockets, notifying of every change of the sync status,
+// e.g. for the batcher to load new unsafe blocks right away. Notifications are dropped for slow subscribers,
+// which always receive the latest sync status with the next notification.
+func (n *nodeAPI) SyncStatusUpdates(ctx context.Context) (*gethrpc.Subscription, error) {
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	updates := make(chan *eth.SyncStatus, 1)
+	unsubscribe := n.dr.SubscribeSyncStatus(updates)
+	go func() {
+		defer unsubscribe()
+		for {
+			select {
+			case status := <-updates:
+				if err := notifier.Notify(sub.ID, status); err != nil {
+					n.log.Debug("Failed to notify sync status subscriber", "err", err)
+					return
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// BuilderStatus returns the status of the external block builder of the sequencer, or nil if the sequencer has no builder.
+// The sequencer conductor uses it to fail over to a sequencer with a healthy builder.
+func (n *nodeAPI) BuilderStatus(ctx context.Context) (*eth.BuilderStatus, error) {
+	return n.dr.BuilderStatus(ctx)
+}
+
 func (n *nodeAPI) RollupConfig(_ context.Context) (*rollup.Config, error) {
-	recordDur := n.m.RecordRPCServerRequest("optimism_rollupConfig")
-	defer recordDur()
 	return n.config, nil
 }
 
 func (n *nodeAPI) Version(ctx context.Context) (string, error) {
-	recordDur := n.m.RecordRPCServerRequest("optimism_version")
-	defer recordDur()
 	return version.Version + "-" + version.Meta, nil
 }
@@ -0,0 +1,165 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+const (
+	// BuilderUnixScheme prefixes the path of a builder that serves the engine API on a Unix domain socket.
+	// The socket is not authenticated with the JWT secret, access is controlled by the file permissions of the socket.
+	BuilderUnixScheme = "unix://"
+	// BuilderInProcScheme prefixes the name of a builder that is registered in-process with RegisterInProcBuilder.
+	BuilderInProcScheme = "inproc://"
+)
+
+var (
+	inProcBuildersMu sync.Mutex
+	inProcBuilders   = make(map[string]engine.BuilderClient)
+)
+
+// RegisterInProcBuilder registers the builder under the given name, for nodes with the builder address
+// "inproc://<name>". Payloads are requested from the builder directly, without encoding them.
+// The node starts and closes the builder. The returned function unregisters the builder.
+func RegisterInProcBuilder(name string, b engine.BuilderClient) (func(), error) {
+	inProcBuildersMu.Lock()
+	defer inProcBuildersMu.Unlock()
+	if _, ok := inProcBuilders[name]; ok {
+		return nil, fmt.Errorf("builder %q is already registered", name)
+	}
+	inProcBuilders[name] = b
+	return func() {
+		inProcBuildersMu.Lock()
+		defer inProcBuildersMu.Unlock()
+		if inProcBuilders[name] == b {
+			delete(inProcBuilders, name)
+		}
+	}, nil
+}
+
+// BuilderEndpointConfig is the BuilderSetup of an external block builder that serves the engine API.
+type BuilderEndpointConfig struct {
+	// BuilderAddr is the address of the builder: an HTTP or websocket URL authenticated with the JWT secret,
+	// a Unix domain socket ("unix://<path>"), or a builder registered in-process ("inproc://<name>").
+	BuilderAddr string
+
+	// BuilderJWTSecretFile is the path of the JWT secret to authenticate HTTP and websocket requests with.
+	BuilderJWTSecretFile string
+
+	// MaxResponseSize limits the size in bytes of the responses of HTTP and websocket builders,
+	// before and after decompression. Unbounded if 0.
+	MaxResponseSize int64
+}
+
+var _ BuilderSetup = (*BuilderEndpointConfig)(nil)
+
+func (cfg *BuilderEndpointConfig) Check() error {
+	switch {
+	case cfg.BuilderAddr == "":
+		return errors.New("empty builder address")
+	case cfg.MaxResponseSize < 0:
+		return fmt.Errorf("builder max response size must not be negative: %d", cfg.MaxResponseSize)
+	case strings.HasPrefix(cfg.BuilderAddr, BuilderInProcScheme), strings.HasPrefix(cfg.BuilderAddr, BuilderUnixScheme):
+		return nil
+	case cfg.BuilderJWTSecretFile == "":
+		return errors.New("builder JWT secret is required for HTTP and websocket builders")
+	}
+	return client.CheckURL(cfg.BuilderAddr)
+}
+
+func (cfg *BuilderEndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (engine.BuilderClient, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	addr := cfg.BuilderAddr
+	switch {
+	case strings.HasPrefix(addr, BuilderInProcScheme):
+		name := strings.TrimPrefix(addr, BuilderInProcScheme)
+		inProcBuildersMu.Lock()
+		b, ok := inProcBuilders[name]
+		inProcBuildersMu.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("no in-process builder %q registered", name)
+		}
+		return b, nil
+	case strings.HasPrefix(addr, BuilderUnixScheme):
+		path := strings.TrimPrefix(addr, BuilderUnixScheme)
+		if err := client.CheckUnixSocket(path); err != nil {
+			return nil, fmt.Errorf("invalid builder socket: %w", err)
+		}
+		cl, err := rpc.DialIPC(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial builder socket: %w", err)
+		}
+		return NewRPCBuilderClient(client.NewBaseRPCClient(cl), log, rollupCfg), nil
+	default:
+		secrets, err := client.NewJWTSecretsFromFile(log, cfg.BuilderJWTSecretFile)
+		if err != nil {
+			return nil, err
+		}
+		cl, err := client.NewRPC(ctx, log, addr,
+			client.WithJWTSecrets(secrets),
+			client.WithMaxResponseSize(cfg.MaxResponseSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial builder: %w", err)
+		}
+		return NewRPCBuilderClient(cl, log, rollupCfg), nil
+	}
+}
+
+// RPCBuilderClient requests payloads from a block builder that serves the engine API: the builder starts building
+// on top of the parent with engine_forkchoiceUpdated, and the payload is retrieved with engine_getPayload.
+// The builder only learns the head of the forkchoice, the safe and finalized blocks are left empty.
+type RPCBuilderClient struct {
+	rpc    client.RPC
+	engine *sources.EngineAPIClient
+}
+
+var _ engine.BuilderClient = (*RPCBuilderClient)(nil)
+
+func NewRPCBuilderClient(rpc client.RPC, log log.Logger, rollupCfg *rollup.Config) *RPCBuilderClient {
+	return &RPCBuilderClient{
+		rpc:    rpc,
+		engine: sources.NewEngineAPIClient(rpc, log.New("role", "builder"), rollupCfg),
+	}
+}
+
+func (c *RPCBuilderClient) Start(ctx context.Context) error {
+	return nil
+}
+
+func (c *RPCBuilderClient) Close() error {
+	c.rpc.Close()
+	return nil
+}
+
+func (c *RPCBuilderClient) Enabled() bool {
+	return true
+}
+
+func (c *RPCBuilderClient) GetPayload(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes) (*eth.ExecutionPayloadEnvelope, error) {
+	fc := &eth.ForkchoiceState{HeadBlockHash: parent.Hash}
+	result, err := c.engine.ForkchoiceUpdate(ctx, fc, attrs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start building on builder: %w", err)
+	}
+	if result.PayloadStatus.Status != eth.ExecutionValid {
+		return nil, eth.ForkchoiceUpdateErr(result.PayloadStatus)
+	}
+	if result.PayloadID == nil {
+		return nil, errors.New("builder did not start building a payload")
+	}
+	return c.engine.GetPayload(ctx, eth.PayloadInfo{ID: *result.PayloadID, Timestamp: uint64(attrs.Timestamp)})
+}
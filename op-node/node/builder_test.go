@@ -0,0 +1,115 @@
+package node
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// fakeBuilderAPI serves the engine API methods that the RPCBuilderClient calls.
+type fakeBuilderAPI struct {
+	id     eth.PayloadID
+	parent common.Hash
+	attrs  *eth.PayloadAttributes
+}
+
+func (f *fakeBuilderAPI) ForkchoiceUpdatedV3(state eth.ForkchoiceState, attrs *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	f.parent, f.attrs = state.HeadBlockHash, attrs
+	return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}, PayloadID: &f.id}, nil
+}
+
+func (f *fakeBuilderAPI) GetPayloadV3(id eth.PayloadID) (*eth.ExecutionPayloadEnvelope, error) {
+	return &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+		ParentHash: f.parent,
+		Timestamp:  f.attrs.Timestamp,
+		BlockHash:  common.Hash{0xbb},
+	}}, nil
+}
+
+// stubBuilder is an in-process engine.BuilderClient.
+type stubBuilder struct{}
+
+func (s *stubBuilder) Start(ctx context.Context) error { return nil }
+func (s *stubBuilder) Close() error                    { return nil }
+func (s *stubBuilder) Enabled() bool                   { return true }
+func (s *stubBuilder) GetPayload(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes) (*eth.ExecutionPayloadEnvelope, error) {
+	return nil, nil
+}
+
+func TestBuilderEndpointConfig(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	zero := uint64(0)
+	rollupCfg := &rollup.Config{CanyonTime: &zero, EcotoneTime: &zero}
+
+	t.Run("Check", func(t *testing.T) {
+		require.ErrorContains(t, (&BuilderEndpointConfig{}).Check(), "empty builder address")
+		require.ErrorContains(t, (&BuilderEndpointConfig{BuilderAddr: "http://localhost:8551"}).Check(), "JWT secret is required")
+		require.NoError(t, (&BuilderEndpointConfig{BuilderAddr: "http://localhost:8551", BuilderJWTSecretFile: "jwt.txt"}).Check())
+		require.NoError(t, (&BuilderEndpointConfig{BuilderAddr: BuilderUnixScheme + "/tmp/builder.ipc"}).Check())
+		require.NoError(t, (&BuilderEndpointConfig{BuilderAddr: BuilderInProcScheme + "builder"}).Check())
+		require.ErrorContains(t, (&BuilderEndpointConfig{BuilderAddr: BuilderInProcScheme + "builder", MaxResponseSize: -1}).Check(), "must not be negative")
+	})
+
+	t.Run("Unix", func(t *testing.T) {
+		api := &fakeBuilderAPI{id: eth.PayloadID{1}}
+		srv := rpc.NewServer()
+		require.NoError(t, srv.RegisterName("engine", api))
+		path := filepath.Join(t.TempDir(), "builder.ipc")
+		l, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		go func() { _ = srv.ServeListener(l) }()
+		t.Cleanup(func() {
+			srv.Stop()
+			_ = l.Close()
+		})
+		cfg := &BuilderEndpointConfig{BuilderAddr: BuilderUnixScheme + path}
+
+		require.NoError(t, os.Chmod(path, 0o777))
+		_, err = cfg.Setup(context.Background(), logger, rollupCfg)
+		require.ErrorContains(t, err, "writable by other users")
+
+		require.NoError(t, os.Chmod(path, 0o700))
+		builder, err := cfg.Setup(context.Background(), logger, rollupCfg)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = builder.Close() })
+		parent := eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 1}
+		envelope, err := builder.GetPayload(context.Background(), parent, &eth.PayloadAttributes{Timestamp: 10, ParentBeaconBlockRoot: &common.Hash{}})
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{0xbb}, envelope.ExecutionPayload.BlockHash)
+		require.Equal(t, parent.Hash, envelope.ExecutionPayload.ParentHash)
+	})
+
+	t.Run("NotASocket", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "builder.ipc")
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+		_, err := (&BuilderEndpointConfig{BuilderAddr: BuilderUnixScheme + path}).Setup(context.Background(), logger, rollupCfg)
+		require.ErrorContains(t, err, "not a Unix domain socket")
+	})
+
+	t.Run("InProc", func(t *testing.T) {
+		b := &stubBuilder{}
+		unregister, err := RegisterInProcBuilder("test", b)
+		require.NoError(t, err)
+		t.Cleanup(unregister)
+		_, err = RegisterInProcBuilder("test", b)
+		require.ErrorContains(t, err, "already registered")
+
+		builder, err := (&BuilderEndpointConfig{BuilderAddr: BuilderInProcScheme + "test"}).Setup(context.Background(), logger, rollupCfg)
+		require.NoError(t, err)
+		require.Same(t, b, builder, "in-process builders are called directly")
+
+		_, err = (&BuilderEndpointConfig{BuilderAddr: BuilderInProcScheme + "unknown"}).Setup(context.Background(), logger, rollupCfg)
+		require.ErrorContains(t, err, "no in-process builder")
+	})
+}
@@ -0,0 +1,291 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/health"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// chainNameRegex restricts chain names, as they are used in logs and health check names.
+var chainNameRegex = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// ChainConfig configures an additional chain to host in the node, next to the primary chain of the node config.
+// All chains share the L1 and L1 Beacon sources, the p2p host and the RPC server,
+// but have their own derivation pipeline and execution engine.
+// Additional chains are verifiers: sequencing, the conductor, Alt-DA and interop are only supported by the primary chain.
+// Their metrics are not exported, to not mix them up with the metrics of the primary chain.
+type ChainConfig struct {
+	// Name identifies the chain in logs and health checks.
+	// It may only contain lowercase letters, digits, dashes and underscores.
+	Name string
+
+	L2 L2EndpointSetup
+
+	Rollup rollup.Config
+
+	// Path to store the safe head database of the chain. Disabled when set to empty string
+	SafeDBPath string
+}
+
+func (c *ChainConfig) Check() error {
+	if !chainNameRegex.MatchString(c.Name) {
+		return fmt.Errorf("invalid chain name %q, must match %s", c.Name, chainNameRegex)
+	}
+	if c.L2 == nil {
+		return errors.New("missing l2 endpoint config")
+	}
+	if err := c.L2.Check(); err != nil {
+		return fmt.Errorf("l2 endpoint config error: %w", err)
+	}
+	if err := c.Rollup.Check(); err != nil {
+		return fmt.Errorf("rollup config error: %w", err)
+	}
+	if c.Rollup.PlasmaEnabled() {
+		return errors.New("Alt-DA is not supported by additional chains")
+	}
+	return nil
+}
+
+// checkChains verifies that the additional chains can be hosted next to the primary chain.
+func (cfg *Config) checkChains() error {
+	names := make(map[string]bool, len(cfg.Chains))
+	chainIDs := map[string]string{cfg.Rollup.L2ChainID.String(): "primary"}
+	for i := range cfg.Chains {
+		chain := &cfg.Chains[i]
+		if err := chain.Check(); err != nil {
+			return fmt.Errorf("chain %q config error: %w", chain.Name, err)
+		}
+		if names[chain.Name] {
+			return fmt.Errorf("duplicate chain name %q", chain.Name)
+		}
+		names[chain.Name] = true
+		// The L1 sources are shared, so all chains must settle on the same L1
+		if chain.Rollup.L1ChainID.Cmp(cfg.Rollup.L1ChainID) != 0 {
+			return fmt.Errorf("chain %q has L1 chain ID %s, but the primary chain has L1 chain ID %s",
+				chain.Name, chain.Rollup.L1ChainID, cfg.Rollup.L1ChainID)
+		}
+		chainID := chain.Rollup.L2ChainID.String()
+		if other, ok := chainIDs[chainID]; ok {
+			return fmt.Errorf("chain %q has the same L2 chain ID %s as chain %q", chain.Name, chainID, other)
+		}
+		chainIDs[chainID] = chain.Name
+	}
+	return nil
+}
+
+// ChainNamespace returns the RPC namespace of the optimism API of the additional chain with the given L2 chain ID,
+// e.g. optimism10_syncStatus serves the sync status of chain 10.
+// The optimism namespace itself serves the primary chain.
+func ChainNamespace(chainID *big.Int) string {
+	return "optimism" + chainID.String()
+}
+
+// hostedChain is an additional chain hosted by the node.
+type hostedChain struct {
+	name      string
+	log       log.Logger
+	rollupCfg *rollup.Config
+
+	metrics  *metrics.Metrics // not served by the metrics server
+	l2Source *sources.EngineClient
+	l2Driver *driver.Driver
+	safeDB   closableSafeDB
+	runCfg   *RuntimeConfig
+
+	gossipOut p2p.GossipOut // nil if p2p is disabled
+	n         *OpNode
+}
+
+var _ p2p.GossipIn = (*hostedChain)(nil)
+
+// initChains initializes the additional chains of the config, after the primary chain got initialized.
+func (n *OpNode) initChains(ctx context.Context, cfg *Config) error {
+	for i := range cfg.Chains {
+		chainCfg := &cfg.Chains[i]
+		chain := &hostedChain{
+			name:      chainCfg.Name,
+			log:       n.log.New("chain", chainCfg.Name),
+			rollupCfg: &chainCfg.Rollup,
+			metrics:   metrics.NewMetrics(""),
+			n:         n,
+		}
+		// Add the chain before initializing it, so that it gets cleaned up on errors.
+		n.chains = append(n.chains, chain)
+		if err := chain.init(ctx, cfg, chainCfg); err != nil {
+			return fmt.Errorf("failed to init chain %s: %w", chain.name, err)
+		}
+		n.log.Info("Initialized additional chain", "chain", chain.name, "l2_chain_id", chain.rollupCfg.L2ChainID)
+	}
+	return nil
+}
+
+func (c *hostedChain) init(ctx context.Context, cfg *Config, chainCfg *ChainConfig) error {
+	rpcClient, rpcCfg, err := chainCfg.L2.Setup(ctx, c.log, c.rollupCfg, c.metrics.HTTPClientMetrics.ForClient("engine-"+c.name))
+	if err != nil {
+		return fmt.Errorf("failed to setup L2 execution-engine RPC client: %w", err)
+	}
+	if cfg.Tracing.Enabled {
+		rpcClient = client.NewTracingClient(rpcClient, c.n.spans)
+	}
+	c.l2Source, err = sources.NewEngineClient(rpcClient, c.log, c.metrics.L2SourceCache, rpcCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Engine client: %w", err)
+	}
+	if err := c.rollupCfg.ValidateL2Config(ctx, c.l2Source, cfg.Sync.SyncMode == sync.ELSync); err != nil {
+		return err
+	}
+	c.n.health.Register("engine-"+c.name, health.ErrorProbe(func(ctx context.Context) error {
+		_, err := c.l2Source.ChainID(ctx)
+		return err
+	}))
+
+	if chainCfg.SafeDBPath != "" {
+		c.log.Info("Safe head database enabled", "path", chainCfg.SafeDBPath)
+		safeDB, err := safedb.NewSafeDB(c.log, chainCfg.SafeDBPath)
+		if err != nil {
+			return fmt.Errorf("failed to create safe head database at %v: %w", chainCfg.SafeDBPath, err)
+		}
+		c.safeDB = safeDB
+	} else {
+		c.safeDB = safedb.Disabled
+	}
+	c.runCfg = NewRuntimeConfig(c.log, c.n.l1Source, c.rollupCfg)
+
+	driverCfg := cfg.Driver
+	driverCfg.SequencerEnabled = false
+	driverCfg.SequencerStopped = false
+	driverCfg.SequencerActionLog = ""
+	driverCfg.InclusionDeadlineBypassBuilder = false
+	driverCfg.InclusionDeadlinesFile = ""
+	driverCfg.SequencerPolicyFile = ""
+	plasmaDA := plasma.NewPlasmaDA(c.log, plasma.CLIConfig{}, plasma.Config{}, &plasma.NoopMetrics{})
+	c.l2Driver = driver.NewDriver(&driverCfg, c.rollupCfg, c.l2Source, c.n.l1Source, c.n.beacon, c, c, c.log,
+		c.metrics, c.n.spans, DisabledConfigPersistence{}, c.safeDB, &cfg.Sync, &conductor.NoOpConductor{}, nil, nil, nil, plasmaDA, nil, nil)
+	return nil
+}
+
+// initChainsP2P joins the gossip of the additional chains on the p2p host of the primary chain, if p2p is enabled.
+func (n *OpNode) initChainsP2P() error {
+	if n.p2pNode == nil {
+		return nil
+	}
+	for _, chain := range n.chains {
+		gossipOut, err := n.p2pNode.JoinChainGossip(chain.log, chain.rollupCfg, chain.runCfg, chain)
+		if err != nil {
+			return fmt.Errorf("failed to join gossip of chain %s: %w", chain.name, err)
+		}
+		chain.gossipOut = gossipOut
+	}
+	return nil
+}
+
+// loadChainsRuntimeConfig loads the runtime config of the additional chains at the given L1 block.
+func (n *OpNode) loadChainsRuntimeConfig(ctx context.Context, l1Ref eth.L1BlockRef) error {
+	var result error
+	for _, chain := range n.chains {
+		fetchCtx, fetchCancel := context.WithTimeout(ctx, time.Second*10)
+		if err := chain.runCfg.Load(fetchCtx, l1Ref); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to load runtime config of chain %s: %w", chain.name, err))
+		}
+		fetchCancel()
+	}
+	return result
+}
+
+// startChains starts the drivers of the additional chains.
+func (n *OpNode) startChains() error {
+	for _, chain := range n.chains {
+		if err := chain.l2Driver.Start(); err != nil {
+			return fmt.Errorf("failed to start chain %s: %w", chain.name, err)
+		}
+	}
+	return nil
+}
+
+// forEachChain passes on an L1 signal to the drivers of the additional chains.
+func (n *OpNode) forEachChain(ctx context.Context, signal string, fn func(ctx context.Context, d *driver.Driver) error) {
+	for _, chain := range n.chains {
+		if chain.l2Driver == nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+		if err := fn(ctx, chain.l2Driver); err != nil {
+			chain.log.Warn("failed to notify engine driver of "+signal, "err", err)
+		}
+		cancel()
+	}
+}
+
+// stopChains closes the drivers and engine clients of the additional chains.
+// Their gossip is closed before the p2p node, and the shared L1 sources are closed with the primary chain.
+func (n *OpNode) stopChains() error {
+	var result error
+	for _, chain := range n.chains {
+		if chain.l2Driver != nil {
+			if err := chain.l2Driver.Close(); err != nil {
+				result = errors.Join(result, fmt.Errorf("failed to close engine driver of chain %s: %w", chain.name, err))
+			}
+		}
+		if chain.safeDB != nil {
+			if err := chain.safeDB.Close(); err != nil {
+				result = errors.Join(result, fmt.Errorf("failed to close safe head db of chain %s: %w", chain.name, err))
+			}
+		}
+		if chain.l2Source != nil {
+			chain.l2Source.Close()
+		}
+	}
+	return result
+}
+
+func (c *hostedChain) OnUnsafeL2Payload(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
+	// ignore if it's from ourselves
+	if c.n.p2pNode != nil && from == c.n.p2pNode.Host().ID() {
+		return nil
+	}
+	c.log.Info("Received signed execution payload from p2p", "id", envelope.ExecutionPayload.ID(), "peer", from,
+		"txs", len(envelope.ExecutionPayload.Transactions))
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	if err := c.l2Driver.OnUnsafeL2Payload(ctx, envelope); err != nil {
+		c.log.Warn("failed to notify engine driver of new L2 payload", "err", err, "id", envelope.ExecutionPayload.ID())
+	}
+	return nil
+}
+
+// OnSafeHeadAttestation is a no-op, additional chains do not join the safe head attestations topic.
+func (c *hostedChain) OnSafeHeadAttestation(ctx context.Context, from peer.ID, att *p2p.SafeHeadAttestation) error {
+	return nil
+}
+
+// PublishL2Payload is a no-op, additional chains do not sequence.
+func (c *hostedChain) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
+	return nil
+}
+
+// RequestL2Range is a no-op, req-resp sync is only available to the primary chain.
+func (c *hostedChain) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error {
+	c.log.Debug("ignoring request to sync L2 range, no sync method available", "start", start, "end", end)
+	return nil
+}
@@ -0,0 +1,42 @@
+package node
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+func TestCheckChains(t *testing.T) {
+	base, err := chaincfg.GetRollupConfig("base-mainnet")
+	require.NoError(t, err)
+	chain := func(name string, rollupCfg *rollup.Config) ChainConfig {
+		return ChainConfig{
+			Name:   name,
+			L2:     &L2EndpointConfig{L2EngineAddr: "http://localhost:8551"},
+			Rollup: *rollupCfg,
+		}
+	}
+	check := func(chains ...ChainConfig) error {
+		cfg := &Config{Rollup: *chaincfg.Mainnet, Chains: chains}
+		return cfg.checkChains()
+	}
+
+	require.NoError(t, check())
+	require.NoError(t, check(chain("base", base)))
+	require.ErrorContains(t, check(chain("Base", base)), "invalid chain name")
+	require.ErrorContains(t, check(chain("base", base), chain("base", base)), "duplicate chain name")
+	require.ErrorContains(t, check(chain("op", chaincfg.Mainnet)), "same L2 chain ID")
+	require.ErrorContains(t, check(chain("sepolia", chaincfg.Sepolia)), "L1 chain ID")
+
+	noEngine := chain("base", base)
+	noEngine.L2 = &L2EndpointConfig{}
+	require.ErrorContains(t, check(noEngine), "l2 endpoint config error")
+}
+
+func TestChainNamespace(t *testing.T) {
+	require.Equal(t, "optimism902", ChainNamespace(big.NewInt(902)))
+}
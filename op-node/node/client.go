@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -19,7 +21,8 @@ import (
 
 type L2EndpointSetup interface {
 	// Setup a RPC client to a L2 execution engine to process rollup blocks with.
-	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (cl client.RPC, rpcCfg *sources.EngineClientConfig, err error)
+	// The connections of the HTTP client are recorded with m.
+	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.HTTPClientMetricer) (cl client.RPC, rpcCfg *sources.EngineClientConfig, err error)
 	Check() error
 }
 
@@ -27,15 +30,46 @@ type L1EndpointSetup interface {
 	// Setup a RPC client to a L1 node to pull rollup input-data from.
 	// The results of the RPC client may be trusted for faster processing, or strictly validated.
 	// The kind of the RPC may be non-basic, to optimize RPC usage.
-	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (cl client.RPC, rpcCfg *sources.L1ClientConfig, err error)
+	// The connections of the HTTP client are recorded with m.
+	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.HTTPClientMetricer) (cl client.RPC, rpcCfg *sources.L1ClientConfig, err error)
 	Check() error
 }
 
+// BuilderSetup creates the client of an external block builder, for embedders of the op-node to integrate
+// their own builder with. See engine.BuilderClient.
+type BuilderSetup interface {
+	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (engine.BuilderClient, error)
+	Check() error
+}
+
+// PreparedBuilder is a BuilderSetup of an already created builder client.
+type PreparedBuilder struct {
+	Client engine.BuilderClient
+}
+
+var _ BuilderSetup = (*PreparedBuilder)(nil)
+
+func (p *PreparedBuilder) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (engine.BuilderClient, error) {
+	return p.Client, nil
+}
+
+func (p *PreparedBuilder) Check() error {
+	if p.Client == nil {
+		return errors.New("builder client cannot be nil")
+	}
+	return nil
+}
+
 type L1BeaconEndpointSetup interface {
-	Setup(ctx context.Context, log log.Logger) (cl sources.BeaconClient, fb []sources.BlobSideCarsFetcher, err error)
+	// The connections of the HTTP clients are recorded with m.
+	Setup(ctx context.Context, log log.Logger, m opmetrics.HTTPClientMetricer) (cl sources.BeaconClient, fb []sources.BlobSideCarsFetcher, err error)
 	// ShouldIgnoreBeaconCheck returns true if the Beacon-node version check should not halt startup.
 	ShouldIgnoreBeaconCheck() bool
 	ShouldFetchAllSidecars() bool
+	// BlobCacheDir returns the directory to cache blob sidecars in, or an empty string to not cache them.
+	BlobCacheDir() string
+	// BlobCacheSize returns the maximum number of blob sidecars to cache.
+	BlobCacheSize() int
 	Check() error
 }
 
@@ -47,6 +81,20 @@ type L2EndpointConfig struct {
 	// JWT secrets for L2 Engine API authentication during HTTP or initial Websocket communication.
 	// Any value for an IPC connection.
 	L2EngineJWTSecret [32]byte
+
+	// L2EngineJWTSecretFile is the optional path of the file the JWT secret was read from.
+	// If set, the secret to sign requests with is reloaded from the file when it changes,
+	// so it can be rotated without a restart, see client.JWTSecrets.
+	L2EngineJWTSecretFile string
+
+	// HTTPProfile is the name of the HTTP client profile of an HTTP engine, see client.HTTPConfigProfile.
+	// The default profile is used if empty.
+	HTTPProfile string
+
+	// MaxResponseSize limits the size in bytes of responses, and websocket messages, of the engine,
+	// before and after decompression. The engine may be served by an external block builder,
+	// which must not be able to exhaust our memory. Unbounded if 0.
+	MaxResponseSize int64
 }
 
 var _ L2EndpointSetup = (*L2EndpointConfig)(nil)
@@ -55,18 +103,45 @@ func (cfg *L2EndpointConfig) Check() error {
 	if cfg.L2EngineAddr == "" {
 		return errors.New("empty L2 Engine Address")
 	}
+	if cfg.MaxResponseSize < 0 {
+		return fmt.Errorf("L2 engine max response size must not be negative: %d", cfg.MaxResponseSize)
+	}
+	if cfg.HTTPProfile != "" {
+		if _, err := client.HTTPConfigProfile(cfg.HTTPProfile); err != nil {
+			return fmt.Errorf("invalid L2 engine HTTP profile: %w", err)
+		}
+	}
 
 	return nil
 }
 
-func (cfg *L2EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.EngineClientConfig, error) {
+func (cfg *L2EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.HTTPClientMetricer) (client.RPC, *sources.EngineClientConfig, error) {
 	if err := cfg.Check(); err != nil {
 		return nil, nil, err
 	}
-	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.L2EngineJWTSecret))
+	auth := client.WithGethRPCOptions(rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.L2EngineJWTSecret)))
+	if cfg.L2EngineJWTSecretFile != "" {
+		secrets, err := client.NewJWTSecretsFromFile(log, cfg.L2EngineJWTSecretFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		auth = client.WithJWTSecrets(secrets)
+	}
 	opts := []client.RPCOption{
-		client.WithGethRPCOptions(auth),
+		auth,
 		client.WithDialBackoff(10),
+		client.WithMaxResponseSize(cfg.MaxResponseSize),
+		client.WithHTTPClientMetrics(m),
+	}
+	if cfg.HTTPProfile != "" {
+		httpCfg, err := client.HTTPConfigProfile(cfg.HTTPProfile)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cfg.MaxResponseSize == 0 {
+			httpCfg.MaxResponseSize, httpCfg.MaxDecompressedSize = 0, 0
+		}
+		opts = append(opts, client.WithHTTPClientConfig(httpCfg))
 	}
 	l2Node, err := client.NewRPC(ctx, log, cfg.L2EngineAddr, opts...)
 	if err != nil {
@@ -90,7 +165,7 @@ func (p *PreparedL2Endpoints) Check() error {
 
 var _ L2EndpointSetup = (*PreparedL2Endpoints)(nil)
 
-func (p *PreparedL2Endpoints) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.EngineClientConfig, error) {
+func (p *PreparedL2Endpoints) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.HTTPClientMetricer) (client.RPC, *sources.EngineClientConfig, error) {
 	return p.Client, sources.EngineClientDefaultConfig(rollupCfg), nil
 }
 
@@ -120,6 +195,10 @@ type L1EndpointConfig struct {
 	// It is recommended to use websockets or IPC for efficient following of the changing block.
 	// Setting this to 0 disables polling.
 	HttpPollInterval time.Duration
+
+	// HTTPProfile is the name of the HTTP client profile of an HTTP L1 RPC, see client.HTTPConfigProfile.
+	// The HTTP client of geth is used if empty.
+	HTTPProfile string
 }
 
 var _ L1EndpointSetup = (*L1EndpointConfig)(nil)
@@ -134,13 +213,26 @@ func (cfg *L1EndpointConfig) Check() error {
 	if cfg.MaxConcurrency < 1 {
 		return fmt.Errorf("max concurrent requests cannot be less than 1, was %d", cfg.MaxConcurrency)
 	}
+	if cfg.HTTPProfile != "" {
+		if _, err := client.HTTPConfigProfile(cfg.HTTPProfile); err != nil {
+			return fmt.Errorf("invalid L1 HTTP profile: %w", err)
+		}
+	}
 	return nil
 }
 
-func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.L1ClientConfig, error) {
+func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.HTTPClientMetricer) (client.RPC, *sources.L1ClientConfig, error) {
 	opts := []client.RPCOption{
 		client.WithHttpPollInterval(cfg.HttpPollInterval),
 		client.WithDialBackoff(10),
+		client.WithHTTPClientMetrics(m),
+	}
+	if cfg.HTTPProfile != "" {
+		httpCfg, err := client.HTTPConfigProfile(cfg.HTTPProfile)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, client.WithHTTPClientConfig(httpCfg))
 	}
 	if cfg.RateLimit != 0 {
 		opts = append(opts, client.WithRateLimit(cfg.RateLimit, cfg.BatchSize))
@@ -165,7 +257,7 @@ type PreparedL1Endpoint struct {
 
 var _ L1EndpointSetup = (*PreparedL1Endpoint)(nil)
 
-func (p *PreparedL1Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.L1ClientConfig, error) {
+func (p *PreparedL1Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.HTTPClientMetricer) (client.RPC, *sources.L1ClientConfig, error) {
 	return p.Client, sources.L1ClientDefaultConfig(rollupCfg, p.TrustRPC, p.RPCProviderKind), nil
 }
 
@@ -183,12 +275,23 @@ type L1BeaconEndpointConfig struct {
 	BeaconFallbackAddrs    []string // Addresses of L1 Beacon-API fallback endpoints (only for blob sidecars retrieval)
 	BeaconCheckIgnore      bool     // When false, halt startup if the beacon version endpoint fails
 	BeaconFetchAllSidecars bool     // Whether to fetch all blob sidecars and filter locally
+	BeaconBlobCacheDir     string   // Optional directory to cache fetched blob sidecars in
+	BeaconBlobCacheSize    int      // Maximum number of blob sidecars to cache in the blob cache dir
+	BeaconHTTPProfile      string   // Optional HTTP client profile of the L1 Beacon endpoints, see client.HTTPConfigProfile
 }
 
 var _ L1BeaconEndpointSetup = (*L1BeaconEndpointConfig)(nil)
 
-func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (cl sources.BeaconClient, fb []sources.BlobSideCarsFetcher, err error) {
-	var opts []client.BasicHTTPClientOption
+func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger, m opmetrics.HTTPClientMetricer) (cl sources.BeaconClient, fb []sources.BlobSideCarsFetcher, err error) {
+	httpOpts := []client.BasicHTTPClientOption{client.WithHTTPMetrics(m)}
+	if cfg.BeaconHTTPProfile != "" {
+		httpCfg, err := client.HTTPConfigProfile(cfg.BeaconHTTPProfile)
+		if err != nil {
+			return nil, nil, err
+		}
+		httpOpts = append(httpOpts, client.WithHTTPConfig(httpCfg))
+	}
+	opts := append([]client.BasicHTTPClientOption{}, httpOpts...)
 	if cfg.BeaconHeader != "" {
 		hdr, err := parseHTTPHeader(cfg.BeaconHeader)
 		if err != nil {
@@ -198,7 +301,7 @@ func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (c
 	}
 
 	for _, addr := range cfg.BeaconFallbackAddrs {
-		b := client.NewBasicHTTPClient(addr, log)
+		b := client.NewBasicHTTPClient(addr, log, httpOpts...)
 		fb = append(fb, sources.NewBeaconHTTPClient(b))
 	}
 
@@ -210,6 +313,14 @@ func (cfg *L1BeaconEndpointConfig) Check() error {
 	if cfg.BeaconAddr == "" && !cfg.BeaconCheckIgnore {
 		return errors.New("expected L1 Beacon API endpoint, but got none")
 	}
+	if cfg.BeaconHTTPProfile != "" {
+		if _, err := client.HTTPConfigProfile(cfg.BeaconHTTPProfile); err != nil {
+			return fmt.Errorf("invalid L1 Beacon HTTP profile: %w", err)
+		}
+	}
+	if cfg.BeaconBlobCacheDir != "" && cfg.BeaconBlobCacheSize <= 0 {
+		return fmt.Errorf("L1 Beacon blob cache size must be positive: %d", cfg.BeaconBlobCacheSize)
+	}
 	return nil
 }
 
@@ -221,6 +332,14 @@ func (cfg *L1BeaconEndpointConfig) ShouldFetchAllSidecars() bool {
 	return cfg.BeaconFetchAllSidecars
 }
 
+func (cfg *L1BeaconEndpointConfig) BlobCacheDir() string {
+	return cfg.BeaconBlobCacheDir
+}
+
+func (cfg *L1BeaconEndpointConfig) BlobCacheSize() int {
+	return cfg.BeaconBlobCacheSize
+}
+
 func parseHTTPHeader(headerStr string) (http.Header, error) {
 	h := make(http.Header, 1)
 	s := strings.SplitN(headerStr, ": ", 2)
@@ -3,9 +3,23 @@ package node
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 func TestParseHTTPHeader(t *testing.T) {
@@ -84,9 +98,59 @@ func TestL1BeaconEndpointConfig_Setup(t *testing.T) {
 	} {
 		t.Run(test.desc, func(t *testing.T) {
 			cfg := L1BeaconEndpointConfig{BeaconFallbackAddrs: test.baa}
-			_, fb, err := cfg.Setup(context.Background(), nil)
+			_, fb, err := cfg.Setup(context.Background(), nil, &opmetrics.NoopHTTPClientMetrics{})
 			require.NoError(t, err)
 			require.Len(t, fb, test.len)
 		})
 	}
 }
+
+type testEngineAPI struct{}
+
+func (testEngineAPI) ChainId() hexutil.Uint64 { return 1 }
+
+func TestL2EndpointConfig_SetupRotatesJWTSecret(t *testing.T) {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", testEngineAPI{}))
+	defer srv.Stop()
+	var handler atomic.Pointer[http.Handler]
+	setServerSecret := func(secret [32]byte) {
+		h := oprpc.NewJWTHandler(client.NewJWTSecrets(secret), srv)
+		handler.Store(&h)
+	}
+	setServerSecret([32]byte{1})
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		(*handler.Load()).ServeHTTP(w, r)
+	}))
+	defer httpSrv.Close()
+
+	path := filepath.Join(t.TempDir(), "jwt.hex")
+	writeSecret := func(secret [32]byte, modTime time.Time) {
+		require.NoError(t, os.WriteFile(path, []byte(hexutil.Encode(secret[:])), 0o600))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	start := time.Now().Add(-time.Minute)
+	writeSecret([32]byte{1}, start)
+	cfg := L2EndpointConfig{L2EngineAddr: httpSrv.URL, L2EngineJWTSecretFile: path}
+	cl, _, err := cfg.Setup(context.Background(), testlog.Logger(t, log.LevelInfo), &rollup.Config{}, &opmetrics.NoopHTTPClientMetrics{})
+	require.NoError(t, err)
+	defer cl.Close()
+	var id hexutil.Uint64
+	require.NoError(t, cl.CallContext(context.Background(), &id, "eth_chainId"))
+
+	// the client picks up the new secret after the file changes, without a restart
+	writeSecret([32]byte{2}, start.Add(time.Second))
+	require.Eventually(t, func() bool {
+		return cl.CallContext(context.Background(), &id, "eth_chainId") != nil
+	}, 5*time.Second, 100*time.Millisecond)
+	setServerSecret([32]byte{2})
+	require.NoError(t, cl.CallContext(context.Background(), &id, "eth_chainId"))
+}
+
+func TestL2EndpointConfig_Check(t *testing.T) {
+	require.ErrorContains(t, (&L2EndpointConfig{}).Check(), "empty L2 Engine Address")
+	require.NoError(t, (&L2EndpointConfig{L2EngineAddr: "http://localhost:8551", MaxResponseSize: 1 << 20}).Check())
+	require.ErrorContains(t, (&L2EndpointConfig{L2EngineAddr: "http://localhost:8551", MaxResponseSize: -1}).Check(), "must not be negative")
+	require.NoError(t, (&L2EndpointConfig{L2EngineAddr: "http://localhost:8551", HTTPProfile: client.HTTPProfileLatencyCritical}).Check())
+	require.ErrorContains(t, (&L2EndpointConfig{L2EngineAddr: "http://localhost:8551", HTTPProfile: "fast"}).Check(), "unknown HTTP client profile")
+}
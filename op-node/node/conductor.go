@@ -2,18 +2,28 @@ package node
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	conductorRpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/health"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 )
 
 // ConductorClient is a client for the op-conductor RPC service.
@@ -22,13 +32,21 @@ type ConductorClient struct {
 	metrics   *metrics.Metrics
 	log       log.Logger
 	apiClient *conductorRpc.APIClient
+	// initLock guards the lazy initialization of the apiClient, which may be initialized by the health probe.
+	initLock sync.Mutex
 
 	// overrideLeader is used to override the leader check for disaster recovery purposes.
 	// During disaster situations where the cluster is unhealthy (no leader, only 1 or less nodes up),
 	// set this to true to allow the node to assume sequencing responsibilities without being the leader.
 	overrideLeader atomic.Bool
+
+	// noMetadata is set if the conductor does not accept the provenance of payloads, i.e. it was not upgraded yet.
+	noMetadata atomic.Bool
 }
 
+// methodNotFoundCode is the JSON-RPC error code of calls to methods that do not exist.
+const methodNotFoundCode = -32601
+
 var _ conductor.SequencerConductor = &ConductorClient{}
 
 // NewConductorClient returns a new conductor client for the op-conductor RPC service.
@@ -42,10 +60,16 @@ func NewConductorClient(cfg *Config, log log.Logger, metrics *metrics.Metrics) *
 
 // Initialize initializes the conductor client.
 func (c *ConductorClient) initialize() error {
+	c.initLock.Lock()
+	defer c.initLock.Unlock()
 	if c.apiClient != nil {
 		return nil
 	}
-	conductorRpcClient, err := dial.DialRPCClientWithTimeout(context.Background(), time.Minute*1, c.log, c.cfg.ConductorRpc)
+	opts, err := conductorClientOptions(c.cfg)
+	if err != nil {
+		return err
+	}
+	conductorRpcClient, err := dial.DialRPCClientWithTimeout(context.Background(), time.Minute*1, c.log, c.cfg.ConductorRpc, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to dial conductor RPC: %w", err)
 	}
@@ -53,6 +77,47 @@ func (c *ConductorClient) initialize() error {
 	return nil
 }
 
+// conductorClientOptions returns the options to authenticate to the conductor with, as the commit methods called by
+// the node require authentication if the conductor is configured with admin RPC authentication.
+func conductorClientOptions(cfg *Config) ([]rpc.ClientOption, error) {
+	var opts []rpc.ClientOption
+	if cfg.ConductorRpcAPIKeyFile != "" {
+		data, err := os.ReadFile(cfg.ConductorRpcAPIKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read conductor API key file: %w", err)
+		}
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			return nil, fmt.Errorf("conductor API key file %q is empty", cfg.ConductorRpcAPIKeyFile)
+		}
+		opts = append(opts, rpc.WithHeader(oprpc.APIKeyHeader, key))
+	}
+	if cfg.ConductorRpcTLS.TLSEnabled() {
+		caCert, err := os.ReadFile(cfg.ConductorRpcTLS.TLSCaCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read conductor TLS CA certificate: %w", err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to parse conductor TLS CA certificate")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.ConductorRpcTLS.TLSCert, cfg.ConductorRpcTLS.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load conductor TLS client certificate: %w", err)
+		}
+		opts = append(opts, rpc.WithHTTPClient(&http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion:   tls.VersionTLS12,
+					RootCAs:      rootCAs,
+					Certificates: []tls.Certificate{cert},
+				},
+			},
+		}))
+	}
+	return opts, nil
+}
+
 // Leader returns true if this node is the leader sequencer.
 func (c *ConductorClient) Leader(ctx context.Context) (bool, error) {
 	if c.overrideLeader.Load() {
@@ -74,8 +139,9 @@ func (c *ConductorClient) Leader(ctx context.Context) (bool, error) {
 	return isLeader, err
 }
 
-// CommitUnsafePayload commits an unsafe payload to the conductor log.
-func (c *ConductorClient) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
+// CommitUnsafePayload commits an unsafe payload to the conductor log, along with its provenance if known.
+// The provenance is dropped if the conductor does not support it.
+func (c *ConductorClient) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *eth.PayloadMetadata) error {
 	if c.overrideLeader.Load() {
 		return nil
 	}
@@ -88,6 +154,17 @@ func (c *ConductorClient) CommitUnsafePayload(ctx context.Context, payload *eth.
 
 	// extra bool return value is required for the generic, can be ignored.
 	_, err := retry.Do(ctx, 2, retry.Fixed(50*time.Millisecond), func() (bool, error) {
+		if metadata != nil && !c.noMetadata.Load() {
+			record := c.metrics.RecordRPCClientRequest("conductor_commitUnsafePayloadWithMetadata")
+			err := c.apiClient.CommitUnsafePayloadWithMetadata(ctx, payload, metadata)
+			record(err)
+			var rpcErr rpc.Error
+			if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != methodNotFoundCode {
+				return true, err
+			}
+			c.log.Warn("Conductor does not support payload provenance, committing payloads without it")
+			c.noMetadata.Store(true)
+		}
 		record := c.metrics.RecordRPCClientRequest("conductor_commitUnsafePayload")
 		err := c.apiClient.CommitUnsafePayload(ctx, payload)
 		record(err)
@@ -102,6 +179,31 @@ func (c *ConductorClient) OverrideLeader(ctx context.Context) error {
 	return nil
 }
 
+// LeaderOverridden returns true if the leader check is overridden for disaster recovery.
+func (c *ConductorClient) LeaderOverridden() bool {
+	return c.overrideLeader.Load()
+}
+
+// HealthProbe checks that the conductor is reachable and active.
+func (c *ConductorClient) HealthProbe(ctx context.Context) health.Result {
+	if c.overrideLeader.Load() {
+		return health.Degraded("conductor leadership is overridden")
+	}
+	if err := c.initialize(); err != nil {
+		return health.Failing("%v", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.ConductorRpcTimeout)
+	defer cancel()
+	active, err := c.apiClient.Active(ctx)
+	if err != nil {
+		return health.Failing("failed to get conductor status: %v", err)
+	}
+	if !active {
+		return health.Degraded("conductor is paused or stopped")
+	}
+	return health.OK()
+}
+
 func (c *ConductorClient) Close() {
 	if c.apiClient == nil {
 		return
@@ -0,0 +1,115 @@
+package node
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	conductorRpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// legacyConductorAPI serves the conductor API of conductors without payload provenance.
+type legacyConductorAPI struct {
+	committed []*eth.ExecutionPayloadEnvelope
+}
+
+func (a *legacyConductorAPI) CommitUnsafePayload(_ context.Context, payload *eth.ExecutionPayloadEnvelope) error {
+	a.committed = append(a.committed, payload)
+	return nil
+}
+
+type conductorAPI struct {
+	legacyConductorAPI
+	metadata []*eth.PayloadMetadata
+}
+
+func (a *conductorAPI) CommitUnsafePayloadWithMetadata(_ context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *eth.PayloadMetadata) error {
+	a.committed = append(a.committed, payload)
+	a.metadata = append(a.metadata, metadata)
+	return nil
+}
+
+func newTestConductorClient(t *testing.T, api any) *ConductorClient {
+	return newTestConductorClientWithConfig(t, api, nil, &Config{})
+}
+
+func newTestConductorClientWithConfig(t *testing.T, api any, auth *oprpc.AdminAuth, cfg *Config) *ConductorClient {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("conductor", api))
+	var handler http.Handler = server
+	if auth != nil {
+		handler = oprpc.NewAdminAuthHandler(auth, handler)
+	}
+	httpServer := httptest.NewServer(handler)
+	t.Cleanup(httpServer.Close)
+	cfg.ConductorRpc = httpServer.URL
+	cfg.ConductorRpcTimeout = time.Second
+	client := NewConductorClient(cfg, testlog.Logger(t, log.LevelError), metrics.NewMetrics("test"))
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestConductorClientCommitUnsafePayload(t *testing.T) {
+	payload := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 1, Transactions: []eth.Data{}}}
+	metadata := &eth.PayloadMetadata{Source: eth.PayloadSourceBuilder}
+
+	t.Run("WithMetadata", func(t *testing.T) {
+		api := &conductorAPI{}
+		client := newTestConductorClient(t, api)
+		require.NoError(t, client.CommitUnsafePayload(context.Background(), payload, metadata))
+		require.Equal(t, []*eth.PayloadMetadata{metadata}, api.metadata)
+		require.Len(t, api.committed, 1)
+	})
+
+	t.Run("WithoutMetadata", func(t *testing.T) {
+		api := &conductorAPI{}
+		client := newTestConductorClient(t, api)
+		require.NoError(t, client.CommitUnsafePayload(context.Background(), payload, nil))
+		require.Empty(t, api.metadata)
+		require.Len(t, api.committed, 1)
+	})
+
+	t.Run("LegacyConductor", func(t *testing.T) {
+		api := &legacyConductorAPI{}
+		client := newTestConductorClient(t, api)
+		require.NoError(t, client.CommitUnsafePayload(context.Background(), payload, metadata))
+		require.NoError(t, client.CommitUnsafePayload(context.Background(), payload, metadata))
+		require.Len(t, api.committed, 2)
+		require.True(t, client.noMetadata.Load())
+	})
+}
+
+func TestConductorClientAPIKey(t *testing.T) {
+	payload := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 1, Transactions: []eth.Data{}}}
+	dir := t.TempDir()
+	keysFile := filepath.Join(dir, "keys")
+	require.NoError(t, os.WriteFile(keysFile, []byte("secret\n"), 0o600))
+	auth, err := oprpc.NewAdminAuth(oprpc.AdminAuthCLIConfig{APIKeysFile: keysFile}, nil, conductorRpc.AdminMethods)
+	require.NoError(t, err)
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		api := &conductorAPI{}
+		client := newTestConductorClientWithConfig(t, api, auth, &Config{})
+		require.ErrorContains(t, client.CommitUnsafePayload(context.Background(), payload, nil), "401")
+		require.Empty(t, api.committed)
+	})
+
+	t.Run("Authenticated", func(t *testing.T) {
+		api := &conductorAPI{}
+		client := newTestConductorClientWithConfig(t, api, auth, &Config{ConductorRpcAPIKeyFile: keysFile})
+		require.NoError(t, client.CommitUnsafePayload(context.Background(), payload, nil))
+		require.Len(t, api.committed, 1)
+	})
+}
@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math"
@@ -11,9 +12,13 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -39,6 +44,10 @@ type Config struct {
 
 	Pprof oppprof.CLIConfig
 
+	// Tracing configures the export of OpenTelemetry spans,
+	// not to be confused with the event Tracer used for testing/debugging.
+	Tracing optracing.CLIConfig
+
 	// Used to poll the L1 for new finalized or safe blocks
 	L1EpochPollInterval time.Duration
 
@@ -63,6 +72,9 @@ type Config struct {
 	// change of the given severity (major/minor/patch). Disabled if empty.
 	RollupHalt string
 
+	// ForkCheck configures the check of the fork schedule of the execution engine against the rollup config.
+	ForkCheck ForkCheckConfig
+
 	// Cancel to request a premature shutdown of the node itself, e.g. when halting. This may be nil.
 	Cancel context.CancelCauseFunc
 
@@ -73,15 +85,82 @@ type Config struct {
 	ConductorEnabled    bool
 	ConductorRpc        string
 	ConductorRpcTimeout time.Duration
+	// ConductorRpcAPIKeyFile and ConductorRpcTLS authenticate the node to a conductor that requires admin RPC
+	// authentication, with an API key or a client certificate.
+	ConductorRpcAPIKeyFile string
+	ConductorRpcTLS        optls.CLIConfig
 
 	// Plasma DA config
 	Plasma plasma.CLIConfig
+
+	// Interop configures the verification of cross-chain messages with the interop supervisor.
+	Interop InteropConfig
+
+	// Chains are additional chains to host in the node, next to the primary chain configured above.
+	Chains []ChainConfig
+
+	// [OPTIONAL] Builder sets up an external block builder to build the blocks of the sequencer with,
+	// see BuilderEndpointConfig. Embedders can also register their own builder integration.
+	Builder BuilderSetup
+}
+
+const (
+	ForkCheckOff  = "off"
+	ForkCheckWarn = "warn"
+	ForkCheckHalt = "halt"
+)
+
+type ForkCheckConfig struct {
+	// Mode is one of ForkCheckOff, ForkCheckWarn or ForkCheckHalt. Disabled if empty.
+	// In halt mode the node refuses to start, or halts, when the fork schedules do not match.
+	Mode string
+	// RPC is an execution engine RPC endpoint that serves debug_chainConfig.
+	// The authenticated engine RPC endpoint is used if empty.
+	RPC string
+	// Interval is the interval between checks after startup. Disabled if <= 0.
+	Interval time.Duration
+}
+
+func (cfg *ForkCheckConfig) Check() error {
+	switch cfg.Mode {
+	case "", ForkCheckOff, ForkCheckWarn, ForkCheckHalt:
+		return nil
+	default:
+		return fmt.Errorf("invalid fork check mode: %q", cfg.Mode)
+	}
+}
+
+func (cfg *ForkCheckConfig) Enabled() bool {
+	return cfg.Mode == ForkCheckWarn || cfg.Mode == ForkCheckHalt
+}
+
+type InteropConfig struct {
+	// SupervisorRpc is the RPC endpoint of the interop supervisor. Disabled if empty.
+	// Blocks with cross-chain messages are only promoted to safe once the supervisor verifies the messages.
+	SupervisorRpc string
+	// FailurePolicy is one of interop.FailurePolicyHalt or interop.FailurePolicyAllow,
+	// and determines whether blocks are promoted to safe while the supervisor is unavailable.
+	FailurePolicy string
+}
+
+func (cfg *InteropConfig) Check() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	return interop.CheckFailurePolicy(cfg.FailurePolicy)
+}
+
+func (cfg *InteropConfig) Enabled() bool {
+	return cfg.SupervisorRpc != ""
 }
 
 type RPCConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	EnableDebug bool
+	// AdminAuth authenticates the admin RPC methods, optional.
+	AdminAuth oprpc.AdminAuthCLIConfig
 }
 
 func (cfg *RPCConfig) HttpEndpoint() string {
@@ -110,6 +189,23 @@ type HeartbeatConfig struct {
 	Enabled bool
 	Moniker string
 	URL     string
+	// Interval is the delay between heartbeats, heartbeat.SendInterval if 0.
+	Interval time.Duration
+	// Status includes the sync status and peer count of the node in heartbeats.
+	Status bool
+	// SigningKey signs the heartbeats, for the server to authenticate the node.
+	// If nil, heartbeats are signed by the P2P signer of the node if it has one.
+	SigningKey *ecdsa.PrivateKey
+}
+
+func (cfg *HeartbeatConfig) Check() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Interval < 0 {
+		return fmt.Errorf("heartbeat interval must not be negative: %v", cfg.Interval)
+	}
+	return nil
 }
 
 func (cfg *Config) LoadPersisted(log log.Logger) error {
@@ -149,19 +245,31 @@ func (cfg *Config) Check() error {
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
+	if err := cfg.RPC.AdminAuth.Check(); err != nil {
+		return fmt.Errorf("rpc config error: %w", err)
+	}
 	if err := cfg.Metrics.Check(); err != nil {
 		return fmt.Errorf("metrics config error: %w", err)
 	}
 	if err := cfg.Pprof.Check(); err != nil {
 		return fmt.Errorf("pprof config error: %w", err)
 	}
+	if err := cfg.Tracing.Check(); err != nil {
+		return fmt.Errorf("tracing config error: %w", err)
+	}
+	if err := cfg.Heartbeat.Check(); err != nil {
+		return fmt.Errorf("heartbeat config error: %w", err)
+	}
 	if cfg.P2P != nil {
 		if err := cfg.P2P.Check(); err != nil {
 			return fmt.Errorf("p2p config error: %w", err)
 		}
 	}
-	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
-		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
+	if err := checkRollupHalt(cfg.RollupHalt); err != nil {
+		return err
+	}
+	if err := cfg.ForkCheck.Check(); err != nil {
+		return err
 	}
 	if cfg.ConductorEnabled {
 		if state, _ := cfg.ConfigPersistence.SequencerState(); state != StateUnset {
@@ -170,12 +278,48 @@ func (cfg *Config) Check() error {
 		if !cfg.Driver.SequencerEnabled {
 			return fmt.Errorf("sequencer must be enabled when conductor is enabled")
 		}
+		if err := cfg.ConductorRpcTLS.Check(); err != nil {
+			return fmt.Errorf("invalid conductor rpc tls config: %w", err)
+		}
+	}
+	if !cfg.Driver.SequencerEnabled {
+		// The sequencer-only components are not instantiated in verifier mode, so their options have no effect.
+		if cfg.Driver.SequencerActionLog != "" {
+			return fmt.Errorf("sequencer must be enabled when the sequencer action log is enabled")
+		}
+		if cfg.Driver.InclusionDeadlineBypassBuilder || cfg.Driver.InclusionDeadlinesFile != "" {
+			return fmt.Errorf("sequencer must be enabled when inclusion deadlines are configured")
+		}
+		if cfg.Driver.SequencerPolicyFile != "" {
+			return fmt.Errorf("sequencer must be enabled when the sequencer policy file is configured")
+		}
+	}
+	if cfg.Builder != nil {
+		if !cfg.Driver.SequencerEnabled {
+			return fmt.Errorf("sequencer must be enabled when a block builder is configured")
+		}
+		if err := cfg.Builder.Check(); err != nil {
+			return fmt.Errorf("builder config error: %w", err)
+		}
+	}
+	if err := cfg.Interop.Check(); err != nil {
+		return fmt.Errorf("interop config error: %w", err)
 	}
 	if err := cfg.Plasma.Check(); err != nil {
 		return fmt.Errorf("plasma config error: %w", err)
 	}
+	if err := cfg.checkChains(); err != nil {
+		return err
+	}
 	if cfg.Plasma.Enabled {
 		log.Warn("Alt-DA Mode is a Beta feature of the MIT licensed OP Stack.  While it has received initial review from core contributors, it is still undergoing testing, and may have bugs or other issues.")
 	}
 	return nil
 }
+
+func checkRollupHalt(opt string) error {
+	if !(opt == "" || opt == "major" || opt == "minor" || opt == "patch") {
+		return fmt.Errorf("invalid rollup halting option: %q", opt)
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 type RunningState int
@@ -19,13 +21,18 @@ const (
 )
 
 type persistedState struct {
-	SequencerStarted *bool `json:"sequencerStarted,omitempty"`
+	SequencerStarted *bool                `json:"sequencerStarted,omitempty"`
+	UnsafeReorgHalt  *eth.UnsafeReorgHalt `json:"unsafeReorgHalt,omitempty"`
 }
 
 type ConfigPersistence interface {
 	SequencerStarted() error
 	SequencerStopped() error
 	SequencerState() (RunningState, error)
+	// PersistUnsafeReorgHalt persists the unsafe reorg that halted the node, or clears it if nil,
+	// so that a restart does not bypass the admin confirmation of the reorg.
+	PersistUnsafeReorgHalt(halt *eth.UnsafeReorgHalt) error
+	UnsafeReorgHalt() (*eth.UnsafeReorgHalt, error)
 }
 
 var _ ConfigPersistence = (*ActiveConfigPersistence)(nil)
@@ -41,21 +48,43 @@ func NewConfigPersistence(file string) *ActiveConfigPersistence {
 }
 
 func (p *ActiveConfigPersistence) SequencerStarted() error {
-	return p.persist(true)
+	return p.update(func(state *persistedState) {
+		started := true
+		state.SequencerStarted = &started
+	})
 }
 
 func (p *ActiveConfigPersistence) SequencerStopped() error {
-	return p.persist(false)
+	return p.update(func(state *persistedState) {
+		started := false
+		state.SequencerStarted = &started
+	})
+}
+
+func (p *ActiveConfigPersistence) PersistUnsafeReorgHalt(halt *eth.UnsafeReorgHalt) error {
+	return p.update(func(state *persistedState) {
+		state.UnsafeReorgHalt = halt
+	})
 }
 
-// persist writes the new config state to the file as safely as possible.
+// update applies the change to the persisted state, keeping the values that it does not change.
+func (p *ActiveConfigPersistence) update(change func(state *persistedState)) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	state, err := p.readLocked()
+	if err != nil {
+		return err
+	}
+	change(&state)
+	return p.persistLocked(state)
+}
+
+// persistLocked writes the new config state to the file as safely as possible.
 // It uses sync to ensure the data is actually persisted to disk and initially writes to a temp file
 // before renaming it into place. On UNIX systems this rename is typically atomic, ensuring the
 // actual file isn't corrupted if IO errors occur during writing.
-func (p *ActiveConfigPersistence) persist(sequencerStarted bool) error {
-	p.lock.Lock()
-	defer p.lock.Unlock()
-	data, err := json.Marshal(persistedState{SequencerStarted: &sequencerStarted})
+func (p *ActiveConfigPersistence) persistLocked(state persistedState) error {
+	data, err := json.Marshal(state)
 	if err != nil {
 		return fmt.Errorf("marshall new config: %w", err)
 	}
@@ -102,9 +131,21 @@ func (p *ActiveConfigPersistence) SequencerState() (RunningState, error) {
 	}
 }
 
+func (p *ActiveConfigPersistence) UnsafeReorgHalt() (*eth.UnsafeReorgHalt, error) {
+	config, err := p.read()
+	if err != nil {
+		return nil, err
+	}
+	return config.UnsafeReorgHalt, nil
+}
+
 func (p *ActiveConfigPersistence) read() (persistedState, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	return p.readLocked()
+}
+
+func (p *ActiveConfigPersistence) readLocked() (persistedState, error) {
 	data, err := os.ReadFile(p.file)
 	if errors.Is(err, os.ErrNotExist) {
 		// persistedState.SequencerStarted == nil: SequencerState() will return StateUnset if no state is found
@@ -118,9 +159,6 @@ func (p *ActiveConfigPersistence) read() (persistedState, error) {
 	if err = dec.Decode(&config); err != nil {
 		return persistedState{}, fmt.Errorf("invalid config file (%v): %w", p.file, err)
 	}
-	if config.SequencerStarted == nil {
-		return persistedState{}, fmt.Errorf("missing sequencerStarted value in config file (%v)", p.file)
-	}
 	return config, nil
 }
 
@@ -140,3 +178,11 @@ func (d DisabledConfigPersistence) SequencerStarted() error {
 func (d DisabledConfigPersistence) SequencerStopped() error {
 	return nil
 }
+
+func (d DisabledConfigPersistence) PersistUnsafeReorgHalt(halt *eth.UnsafeReorgHalt) error {
+	return nil
+}
+
+func (d DisabledConfigPersistence) UnsafeReorgHalt() (*eth.UnsafeReorgHalt, error) {
+	return nil, nil
+}
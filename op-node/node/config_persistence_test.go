@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 func TestActive(t *testing.T) {
@@ -59,6 +61,29 @@ func TestActive(t *testing.T) {
 		require.Equal(t, StateStopped, state)
 	})
 
+	t.Run("PersistUnsafeReorgHalt", func(t *testing.T) {
+		config1 := create()
+		halt := &eth.UnsafeReorgHalt{Parent: eth.BlockID{Number: 7}, Depth: 3, MaxDepth: 2}
+		require.NoError(t, config1.SequencerStopped())
+		require.NoError(t, config1.PersistUnsafeReorgHalt(halt))
+
+		config2 := NewConfigPersistence(config1.file)
+		persisted, err := config2.UnsafeReorgHalt()
+		require.NoError(t, err)
+		require.Equal(t, halt, persisted)
+		state, err := config2.SequencerState()
+		require.NoError(t, err)
+		require.Equal(t, StateStopped, state, "must keep the sequencer state")
+
+		require.NoError(t, config2.PersistUnsafeReorgHalt(nil))
+		persisted, err = config2.UnsafeReorgHalt()
+		require.NoError(t, err)
+		require.Nil(t, persisted)
+		state, err = config2.SequencerState()
+		require.NoError(t, err)
+		require.Equal(t, StateStopped, state)
+	})
+
 	t.Run("CreateParentDirs", func(t *testing.T) {
 		dir := t.TempDir()
 		config := NewConfigPersistence(dir + "/some/dir/state")
@@ -0,0 +1,111 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/health"
+)
+
+var errForkMismatch = errors.New("execution engine fork schedule does not match the rollup config")
+
+// checkEngineForks fetches the chain config of the execution engine, and compares its fork schedule with the rollup config.
+func checkEngineForks(ctx context.Context, rpc client.RPC, rollupCfg *rollup.Config) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	var engineCfg params.ChainConfig
+	if err := rpc.CallContext(ctx, &engineCfg, "debug_chainConfig"); err != nil {
+		return fmt.Errorf("failed to fetch execution engine chain config: %w", err)
+	}
+	mismatches := rollupCfg.CheckEngineForks(&engineCfg)
+	if len(mismatches) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(mismatches))
+	for i, m := range mismatches {
+		msgs[i] = m.String()
+	}
+	return fmt.Errorf("%w: %s", errForkMismatch, strings.Join(msgs, "; "))
+}
+
+// initForkCheck checks the fork schedule of the execution engine against the rollup config,
+// and keeps checking it in the background, as the engine may be restarted with a different config.
+func (n *OpNode) initForkCheck(ctx context.Context, cfg *Config, engineRPC client.RPC) error {
+	if !cfg.ForkCheck.Enabled() {
+		return nil
+	}
+	halt := cfg.ForkCheck.Mode == ForkCheckHalt
+	rpc := engineRPC
+	if cfg.ForkCheck.RPC != "" {
+		var err error
+		rpc, err = client.NewRPC(ctx, n.log, cfg.ForkCheck.RPC)
+		if err != nil {
+			return fmt.Errorf("failed to dial fork check RPC: %w", err)
+		}
+		n.forkCheckRPC = rpc
+	}
+
+	if err := checkEngineForks(ctx, rpc, &cfg.Rollup); errors.Is(err, errForkMismatch) {
+		if halt {
+			return err
+		}
+		n.log.Error("Execution engine is not configured for the scheduled forks, it will diverge at activation", "err", err)
+	} else if err != nil {
+		if halt {
+			return fmt.Errorf("cannot verify execution engine fork schedule, consider setting %s: %w", "--l2.fork-check.rpc", err)
+		}
+		// Keep checking in the background, the engine may not be available yet at startup.
+		n.log.Warn("Cannot verify execution engine fork schedule", "err", err)
+	} else {
+		n.log.Info("Execution engine fork schedule matches the rollup config")
+	}
+
+	n.health.Register("forks", func(ctx context.Context) health.Result {
+		if err := checkEngineForks(ctx, rpc, &cfg.Rollup); errors.Is(err, errForkMismatch) {
+			return health.Failing("%v", err)
+		} else if err != nil {
+			return health.Degraded("%v", err)
+		}
+		return health.OK()
+	})
+
+	if cfg.ForkCheck.Interval <= 0 {
+		return nil
+	}
+	go func(ctx context.Context) {
+		ticker := time.NewTicker(cfg.ForkCheck.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := checkEngineForks(ctx, rpc, &cfg.Rollup)
+				if err == nil {
+					continue
+				}
+				if !errors.Is(err, errForkMismatch) {
+					n.log.Debug("Failed to check execution engine fork schedule", "err", err)
+					continue
+				}
+				n.log.Error("Execution engine is not configured for the scheduled forks, it will diverge at activation", "err", err)
+				if halt {
+					n.halted.Store(true)
+					if n.cancel != nil {
+						n.cancel(errNodeHalt)
+						return
+					}
+					n.log.Debug("opted to halt, but cannot halt node")
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}(n.resourcesCtx)
+	return nil
+}
@@ -0,0 +1,84 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/health"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testDebugAPI struct {
+	cfg *params.ChainConfig
+}
+
+func (api *testDebugAPI) ChainConfig() *params.ChainConfig {
+	return api.cfg
+}
+
+func newTestForkCheckRPC(t *testing.T, engineCfg *params.ChainConfig) client.RPC {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("debug", &testDebugAPI{cfg: engineCfg}))
+	t.Cleanup(srv.Stop)
+	return client.NewBaseRPCClient(rpc.DialInProc(srv))
+}
+
+func TestInitForkCheck(t *testing.T) {
+	ecotone := uint64(100)
+	rollupCfg := rollup.Config{CanyonTime: new(uint64), EcotoneTime: &ecotone}
+	matching := &params.ChainConfig{CanyonTime: new(uint64), ShanghaiTime: new(uint64), EcotoneTime: &ecotone, CancunTime: &ecotone}
+	mismatching := &params.ChainConfig{CanyonTime: new(uint64), ShanghaiTime: new(uint64)}
+
+	initForkCheck := func(t *testing.T, mode string, engineRPC client.RPC) (*OpNode, error) {
+		n := &OpNode{
+			log:          testlog.Logger(t, log.LevelError),
+			health:       health.NewChecks("test"),
+			resourcesCtx: context.Background(),
+		}
+		cfg := &Config{Rollup: rollupCfg, ForkCheck: ForkCheckConfig{Mode: mode}}
+		return n, n.initForkCheck(context.Background(), cfg, engineRPC)
+	}
+
+	t.Run("Matching", func(t *testing.T) {
+		n, err := initForkCheck(t, ForkCheckHalt, newTestForkCheckRPC(t, matching))
+		require.NoError(t, err)
+		require.Equal(t, health.StatusOK, n.health.Readiness(context.Background()).Status)
+	})
+
+	t.Run("MismatchHalt", func(t *testing.T) {
+		_, err := initForkCheck(t, ForkCheckHalt, newTestForkCheckRPC(t, mismatching))
+		require.ErrorIs(t, err, errForkMismatch)
+		require.ErrorContains(t, err, "ecotone: rollup config at 100, execution engine ecotoneTime not scheduled")
+	})
+
+	t.Run("MismatchWarn", func(t *testing.T) {
+		n, err := initForkCheck(t, ForkCheckWarn, newTestForkCheckRPC(t, mismatching))
+		require.NoError(t, err)
+		require.Equal(t, health.StatusFailing, n.health.Readiness(context.Background()).Status)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		srv := rpc.NewServer()
+		t.Cleanup(srv.Stop)
+		unavailable := client.NewBaseRPCClient(rpc.DialInProc(srv))
+		_, err := initForkCheck(t, ForkCheckHalt, unavailable)
+		require.ErrorContains(t, err, "cannot verify execution engine fork schedule")
+		n, err := initForkCheck(t, ForkCheckWarn, unavailable)
+		require.NoError(t, err)
+		// the check is still registered, to verify the fork schedule once the engine is available
+		require.Equal(t, health.StatusDegraded, n.health.Readiness(context.Background()).Status)
+	})
+
+	t.Run("Off", func(t *testing.T) {
+		_, err := initForkCheck(t, ForkCheckOff, nil)
+		require.NoError(t, err)
+	})
+}
@@ -21,16 +21,21 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/health"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 var ErrAlreadyClosed = errors.New("node is already closed")
@@ -57,14 +62,27 @@ type OpNode struct {
 	p2pNode   *p2p.NodeP2P          // P2P node functionality
 	p2pSigner p2p.Signer            // p2p gossip application messages will be signed with this signer
 	tracer    Tracer                // tracer to get events for testing/debugging
+	spans     optracing.Tracer      // tracer of the spans exported to OpenTelemetry, if enabled
 	runCfg    *RuntimeConfig        // runtime configurables
+	health    *health.Checks        // health probes of the subsystems, served by the RPC server
+	conductor *ConductorClient      // client of the sequencer conductor, nil if the conductor is disabled
+	chains    []*hostedChain        // additional chains hosted next to the primary chain
 
 	safeDB closableSafeDB
 
-	rollupHalt string // when to halt the rollup, disabled if empty
+	actionLog *driver.ActionLog // log of the sequencing decisions, nil if disabled
+
+	forkCheckRPC client.RPC // optional RPC client to check the fork schedule of the execution engine with
+
+	supervisor *sources.SupervisorClient // client of the interop supervisor, nil if interop message verification is disabled
+
+	builder engine.BuilderClient // client of the external block builder, nil if disabled
+
+	rollupHalt atomic.Pointer[string] // when to halt the rollup, disabled if empty
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
+	closeSpans   func(ctx context.Context) error
 
 	beacon *sources.L1BeaconClient
 
@@ -98,9 +116,10 @@ func New(ctx context.Context, cfg *Config, log log.Logger, appVersion string, m
 		log:        log,
 		appVersion: appVersion,
 		metrics:    m,
-		rollupHalt: cfg.RollupHalt,
 		cancel:     cfg.Cancel,
+		health:     health.NewChecks(appVersion),
 	}
+	n.rollupHalt.Store(&cfg.RollupHalt)
 	// not a context leak, gossipsub is closed with a context.
 	n.resourcesCtx, n.resourcesClose = context.WithCancel(context.Background())
 
@@ -130,6 +149,9 @@ func (n *OpNode) init(ctx context.Context, cfg *Config) error {
 	if err := n.initL2(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L2: %w", err)
 	}
+	if err := n.initChains(ctx, cfg); err != nil { // depends on the L1 sources of the primary chain
+		return err
+	}
 	if err := n.initRuntimeConfig(ctx, cfg); err != nil { // depends on L2, to signal initial runtime values to
 		return fmt.Errorf("failed to init the runtime config: %w", err)
 	}
@@ -139,6 +161,9 @@ func (n *OpNode) init(ctx context.Context, cfg *Config) error {
 	if err := n.initP2P(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the P2P stack: %w", err)
 	}
+	if err := n.initChainsP2P(); err != nil {
+		return err
+	}
 	// Only expose the server at the end, ensuring all RPC backend components are initialized.
 	if err := n.initRPCServer(cfg); err != nil {
 		return fmt.Errorf("failed to init the RPC server: %w", err)
@@ -161,14 +186,23 @@ func (n *OpNode) initTracer(ctx context.Context, cfg *Config) error {
 	} else {
 		n.tracer = new(noOpTracer)
 	}
+	spans, closeSpans, err := optracing.NewTracer(n.log, "op-node", cfg.Tracing)
+	if err != nil {
+		return err
+	}
+	n.spans = spans
+	n.closeSpans = closeSpans
 	return nil
 }
 
 func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
-	l1Node, rpcCfg, err := cfg.L1.Setup(ctx, n.log, &cfg.Rollup)
+	l1Node, rpcCfg, err := cfg.L1.Setup(ctx, n.log, &cfg.Rollup, n.metrics.HTTPClientMetrics.ForClient("l1"))
 	if err != nil {
 		return fmt.Errorf("failed to get L1 RPC client: %w", err)
 	}
+	if cfg.Tracing.Enabled {
+		l1Node = client.NewTracingClient(l1Node, n.spans)
+	}
 
 	// Set the RethDB path in the EthClientConfig, if there is one configured.
 	rpcCfg.EthClientConfig.RethDBPath = cfg.RethDBPath
@@ -182,6 +216,10 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	if err := cfg.Rollup.ValidateL1Config(ctx, n.l1Source); err != nil {
 		return fmt.Errorf("failed to validate the L1 config: %w", err)
 	}
+	n.health.Register("l1", health.ErrorProbe(func(ctx context.Context) error {
+		_, err := n.l1Source.ChainID(ctx)
+		return err
+	}))
 
 	// Keep subscribed to the L1 heads, which keeps the L1 maintainer pointing to the best headers to sync
 	n.l1HeadsSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
@@ -241,6 +279,10 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 			n.log.Error("failed to fetch runtime config data", "err", err)
 			return l1Head, err
 		}
+		if err := n.loadChainsRuntimeConfig(ctx, confirmed); err != nil {
+			n.log.Error("failed to fetch runtime config data of additional chains", "err", err)
+			return l1Head, err
+		}
 
 		err = n.handleProtocolVersionsUpdate(ctx)
 		return l1Head, err
@@ -313,13 +355,19 @@ func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
 
 	// We always initialize a client. We will get an error on requests if the client does not work.
 	// This way the op-node can continue non-L1 functionality when the user chooses to ignore the Beacon API requirement.
-	beaconClient, fallbacks, err := cfg.Beacon.Setup(ctx, n.log)
+	beaconClient, fallbacks, err := cfg.Beacon.Setup(ctx, n.log, n.metrics.HTTPClientMetrics.ForClient("l1_beacon"))
 	if err != nil {
 		return fmt.Errorf("failed to setup L1 Beacon API client: %w", err)
 	}
 	beaconCfg := sources.L1BeaconClientConfig{
 		FetchAllSidecars: cfg.Beacon.ShouldFetchAllSidecars(),
 	}
+	if dir := cfg.Beacon.BlobCacheDir(); dir != "" {
+		beaconCfg.Cache, err = sources.NewBlobSidecarCache(n.log, dir, cfg.Beacon.BlobCacheSize())
+		if err != nil {
+			return err
+		}
+	}
 	n.beacon = sources.NewL1BeaconClient(beaconClient, beaconCfg, fallbacks...)
 
 	// Retry retrieval of the Beacon API version, to be more robust on startup against Beacon API connection issues.
@@ -364,10 +412,13 @@ func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
-	rpcClient, rpcCfg, err := cfg.L2.Setup(ctx, n.log, &cfg.Rollup)
+	rpcClient, rpcCfg, err := cfg.L2.Setup(ctx, n.log, &cfg.Rollup, n.metrics.HTTPClientMetrics.ForClient("engine"))
 	if err != nil {
 		return fmt.Errorf("failed to setup L2 execution-engine RPC client: %w", err)
 	}
+	if cfg.Tracing.Enabled {
+		rpcClient = client.NewTracingClient(rpcClient, n.spans)
+	}
 
 	n.l2Source, err = sources.NewEngineClient(
 		client.NewInstrumentedRPC(rpcClient, &n.metrics.RPCClientMetrics), n.log, n.metrics.L2SourceCache, rpcCfg,
@@ -380,9 +431,21 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 		return err
 	}
 
+	if err := n.initForkCheck(ctx, cfg, rpcClient); err != nil {
+		return err
+	}
+
+	n.health.Register("engine", health.ErrorProbe(func(ctx context.Context) error {
+		_, err := n.l2Source.ChainID(ctx)
+		return err
+	}))
+
 	var sequencerConductor conductor.SequencerConductor = &conductor.NoOpConductor{}
 	if cfg.ConductorEnabled {
-		sequencerConductor = NewConductorClient(cfg, n.log, n.metrics)
+		conductorClient := NewConductorClient(cfg, n.log, n.metrics)
+		n.health.Register("conductor", conductorClient.HealthProbe)
+		sequencerConductor = conductorClient
+		n.conductor = conductorClient
 	}
 
 	// if plasma is not explicitly activated in the node CLI, the config + any error will be ignored.
@@ -401,7 +464,65 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 	} else {
 		n.safeDB = safedb.Disabled
 	}
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n.beacon, n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, plasmaDA)
+	if cfg.Driver.SequencerActionLog != "" {
+		actionLog, err := driver.OpenActionLog(n.log, cfg.Driver.SequencerActionLog, cfg.Driver.SequencerActionLogMaxSize)
+		if err != nil {
+			return err
+		}
+		n.actionLog = actionLog
+	}
+	var inclusionDeadlines *driver.InclusionDeadlines
+	var sequencerPolicy *driver.SequencerPolicy
+	if cfg.Driver.SequencerEnabled {
+		var err error
+		inclusionDeadlines, err = driver.NewInclusionDeadlines(n.log, n.metrics, cfg.Driver.InclusionDeadlineBypassBuilder, cfg.Driver.InclusionDeadlinesFile)
+		if err != nil {
+			return err
+		}
+		sequencerPolicy, err = driver.NewSequencerPolicy(n.log, n.l2Source, cfg.Driver.SequencerPolicyFile)
+		if err != nil {
+			return err
+		}
+	}
+	var safeHeadListener rollup.SafeHeadListener = n.safeDB
+	if cfg.P2P != nil && cfg.P2P.SafeHeadAttestationsConfig() != nil {
+		safeHeadListener = newSafeHeadAttester(n, n.safeDB)
+	}
+	var safetyGate interop.SafetyGate
+	if cfg.Interop.Enabled() {
+		supervisorRPC, err := client.NewRPC(ctx, n.log, cfg.Interop.SupervisorRpc)
+		if err != nil {
+			return fmt.Errorf("failed to dial interop supervisor RPC: %w", err)
+		}
+		n.supervisor = sources.NewSupervisorClient(supervisorRPC)
+		safetyGate = interop.NewGate(n.log, &cfg.Rollup, n.supervisor, n.l2Source, cfg.Interop.FailurePolicy)
+	}
+	if cfg.Builder != nil {
+		n.builder, err = cfg.Builder.Setup(ctx, n.log, &cfg.Rollup)
+		if err != nil {
+			return fmt.Errorf("failed to setup block builder: %w", err)
+		}
+	}
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n.beacon, n, n, n.log, n.metrics, n.spans, cfg.ConfigPersistence, safeHeadListener, &cfg.Sync, sequencerConductor, n.actionLog, inclusionDeadlines, sequencerPolicy, plasmaDA, safetyGate, n.builder)
+	if cfg.Sync.MaxUnsafeReorgDepth > 0 {
+		if err := n.l2Driver.RestoreUnsafeReorgHalt(cfg.ConfigPersistence); err != nil {
+			return err
+		}
+		n.health.Register("reorg-guard", func(ctx context.Context) health.Result {
+			if halt, _ := n.l2Driver.UnsafeReorgHalt(ctx); halt != nil {
+				return health.Failing("halted on unsafe reorg of %d blocks onto %s", uint64(halt.Depth), halt.Parent)
+			}
+			return health.OK()
+		})
+	}
+	if cfg.Driver.SequencerEnabled {
+		n.health.Register("inclusion", func(ctx context.Context) health.Result {
+			if missed := n.l2Driver.MissedInclusionDeadlines(); missed > 0 {
+				return health.Failing("%d transactions missed their inclusion deadline", missed)
+			}
+			return health.OK()
+		})
+	}
 	return nil
 }
 
@@ -411,12 +532,23 @@ func (n *OpNode) initRPCServer(cfg *Config) error {
 		return err
 	}
 	if n.p2pNode != nil {
-		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
+		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log))
 	}
 	if cfg.RPC.EnableAdmin {
-		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
+		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.conductor, n.log))
 		n.log.Info("Admin RPC enabled")
 	}
+	if cfg.RPC.EnableDebug {
+		server.EnableDebugAPI(NewDebugAPI(derive.NewPayloadAttributesService(&cfg.Rollup, n.l1Source, n.l2Source), n.l2Driver, n.log))
+		n.log.Info("Debug RPC enabled")
+	}
+	for _, chain := range n.chains {
+		server.EnableChainAPI(chain.rollupCfg.L2ChainID, NewNodeAPI(chain.rollupCfg, chain.l2Source.L2Client, chain.l2Driver, chain.safeDB, chain.log.New("rpc", "node")))
+	}
+	if cfg.Tracing.Enabled {
+		server.EnableTracing(n.spans)
+	}
+	server.EnableHealthChecks(n.health)
 	n.log.Info("Starting JSON-RPC server")
 	if err := server.Start(); err != nil {
 		return fmt.Errorf("unable to start RPC server: %w", err)
@@ -459,22 +591,57 @@ func (n *OpNode) initHeartbeat(cfg *Config) {
 		ChainID: cfg.Rollup.L2ChainID.Uint64(),
 	}
 
+	opts := heartbeat.Options{Interval: cfg.Heartbeat.Interval}
+	if cfg.Heartbeat.Status {
+		opts.Status = n.heartbeatStatus
+	}
+	var signer p2p.Signer
+	if cfg.Heartbeat.SigningKey != nil {
+		signer = p2p.NewLocalSigner(cfg.Heartbeat.SigningKey)
+	} else if n.p2pSigner != nil {
+		signer = n.p2pSigner
+	}
+	if signer != nil {
+		chainID := cfg.Rollup.L2ChainID
+		opts.Sign = func(ctx context.Context, body []byte) ([]byte, error) {
+			sig, err := signer.Sign(ctx, p2p.SigningDomainHeartbeatsV1, chainID, body)
+			if err != nil {
+				return nil, err
+			}
+			return sig[:], nil
+		}
+	}
+
 	go func(url string) {
-		if err := heartbeat.Beat(n.resourcesCtx, n.log, url, payload); err != nil {
+		if err := heartbeat.BeatWithOptions(n.resourcesCtx, n.log, url, payload, opts); err != nil {
 			log.Error("heartbeat goroutine crashed", "err", err)
 		}
 	}(cfg.Heartbeat.URL)
 }
 
+// heartbeatStatus returns the sync status and peer count of the node, to include in heartbeats.
+func (n *OpNode) heartbeatStatus(ctx context.Context) (*heartbeat.Status, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	syncStatus, err := n.l2Driver.SyncStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync status: %w", err)
+	}
+	status := &heartbeat.Status{
+		HeadL1:      syncStatus.HeadL1.Number,
+		CurrentL1:   syncStatus.CurrentL1.Number,
+		UnsafeL2:    syncStatus.UnsafeL2.Number,
+		SafeL2:      syncStatus.SafeL2.Number,
+		FinalizedL2: syncStatus.FinalizedL2.Number,
+	}
+	if n.p2pNode != nil {
+		status.PeerCount = len(n.p2pNode.Host().Network().Peers())
+	}
+	return status, nil
+}
+
 func (n *OpNode) initPProf(cfg *Config) error {
-	n.pprofService = oppprof.New(
-		cfg.Pprof.ListenEnabled,
-		cfg.Pprof.ListenAddr,
-		cfg.Pprof.ListenPort,
-		cfg.Pprof.ProfileType,
-		cfg.Pprof.ProfileDir,
-		cfg.Pprof.ProfileFilename,
-	)
+	n.pprofService = oppprof.NewFromConfig(cfg.Pprof)
 
 	if err := n.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -491,6 +658,24 @@ func (n *OpNode) initP2P(ctx context.Context, cfg *Config) error {
 			return err
 		}
 		n.p2pNode = p2pNode
+		n.health.Register("p2p", func(ctx context.Context) health.Result {
+			if len(p2pNode.Peers()) == 0 {
+				return health.Degraded("no peers")
+			}
+			return health.OK()
+		})
+		if _, _, enabled := p2pNode.StaticMesh(); enabled {
+			n.health.Register("p2p-mesh", func(ctx context.Context) health.Result {
+				connected, total, _ := p2pNode.StaticMesh()
+				if connected == 0 {
+					return health.Failing("not connected to any of the %d static peers", total)
+				}
+				if connected < total {
+					return health.Degraded("connected to %d of %d static peers", connected, total)
+				}
+				return health.OK()
+			})
+		}
 		if n.p2pNode.Dv5Udp() != nil {
 			go n.p2pNode.DiscoveryProcess(n.resourcesCtx, n.log, &cfg.Rollup, cfg.P2P.TargetPeers())
 		}
@@ -510,12 +695,22 @@ func (n *OpNode) initP2PSigner(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) Start(ctx context.Context) error {
+	if n.builder != nil {
+		if err := n.builder.Start(ctx); err != nil {
+			n.log.Error("Could not start the block builder client", "err", err)
+			return err
+		}
+	}
 	n.log.Info("Starting execution engine driver")
 	// start driving engine: sync blocks by deriving them from L1 and driving them into the engine
 	if err := n.l2Driver.Start(); err != nil {
 		n.log.Error("Could not start a rollup node", "err", err)
 		return err
 	}
+	if err := n.startChains(); err != nil {
+		n.log.Error("Could not start additional chains", "err", err)
+		return err
+	}
 	log.Info("Rollup node started")
 	return nil
 }
@@ -532,6 +727,9 @@ func (n *OpNode) OnNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	if err := n.l2Driver.OnL1Head(ctx, sig); err != nil {
 		n.log.Warn("failed to notify engine driver of L1 head change", "err", err)
 	}
+	n.forEachChain(ctx, "L1 head change", func(ctx context.Context, d *driver.Driver) error {
+		return d.OnL1Head(ctx, sig)
+	})
 }
 
 func (n *OpNode) OnNewL1Safe(ctx context.Context, sig eth.L1BlockRef) {
@@ -544,6 +742,9 @@ func (n *OpNode) OnNewL1Safe(ctx context.Context, sig eth.L1BlockRef) {
 	if err := n.l2Driver.OnL1Safe(ctx, sig); err != nil {
 		n.log.Warn("failed to notify engine driver of L1 safe block change", "err", err)
 	}
+	n.forEachChain(ctx, "L1 safe block change", func(ctx context.Context, d *driver.Driver) error {
+		return d.OnL1Safe(ctx, sig)
+	})
 }
 
 func (n *OpNode) OnNewL1Finalized(ctx context.Context, sig eth.L1BlockRef) {
@@ -556,6 +757,9 @@ func (n *OpNode) OnNewL1Finalized(ctx context.Context, sig eth.L1BlockRef) {
 	if err := n.l2Driver.OnL1Finalized(ctx, sig); err != nil {
 		n.log.Warn("failed to notify engine driver of L1 finalized block change", "err", err)
 	}
+	n.forEachChain(ctx, "L1 finalized block change", func(ctx context.Context, d *driver.Driver) error {
+		return d.OnL1Finalized(ctx, sig)
+	})
 }
 
 func (n *OpNode) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
@@ -635,6 +839,13 @@ func (n *OpNode) Stop(ctx context.Context) error {
 			result = multierror.Append(result, fmt.Errorf("failed to close RPC server: %w", err))
 		}
 	}
+	for _, chain := range n.chains {
+		if chain.gossipOut != nil {
+			if err := chain.gossipOut.Close(); err != nil {
+				result = multierror.Append(result, fmt.Errorf("failed to close gossip of chain %s: %w", chain.name, err))
+			}
+		}
+	}
 	if n.p2pNode != nil {
 		if err := n.p2pNode.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close p2p node: %w", err))
@@ -670,12 +881,28 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
+	// close the additional chains, before the L1 sources they share
+	if err := n.stopChains(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	// close the block builder client, after the driver stopped requesting payloads
+	if n.builder != nil {
+		if err := n.builder.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close block builder client: %w", err))
+		}
+	}
+
 	if n.safeDB != nil {
 		if err := n.safeDB.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close safe head db: %w", err))
 		}
 	}
 
+	if err := n.actionLog.Close(); err != nil {
+		result = multierror.Append(result, fmt.Errorf("failed to close sequencer action log: %w", err))
+	}
+
 	// Wait for the runtime config loader to be done using the data sources before closing them
 	if n.runtimeConfigReloaderDone != nil {
 		<-n.runtimeConfigReloaderDone
@@ -686,6 +913,14 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		n.l2Source.Close()
 	}
 
+	if n.forkCheckRPC != nil {
+		n.forkCheckRPC.Close()
+	}
+
+	if n.supervisor != nil {
+		n.supervisor.Close()
+	}
+
 	// close L1 data source
 	if n.l1Source != nil {
 		n.l1Source.Close()
@@ -717,6 +952,11 @@ func (n *OpNode) Stop(ctx context.Context) error {
 			result = multierror.Append(result, fmt.Errorf("failed to close metrics server: %w", err))
 		}
 	}
+	if n.closeSpans != nil {
+		if err := n.closeSpans(ctx); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close span tracer: %w", err))
+		}
+	}
 
 	return result.ErrorOrNil()
 }
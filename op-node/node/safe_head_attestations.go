@@ -0,0 +1,115 @@
+package node
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// safeHeadAttesterPublishTimeout bounds the signing and publishing of a single attestation.
+const safeHeadAttesterPublishTimeout = time.Second * 10
+
+// safeHeadAttester publishes safe head updates as signed attestations on p2p,
+// and forwards all safe head updates to the wrapped safe head listener.
+// Updates are coalesced: a single worker publishes the latest safe head, and updates that arrive while
+// an attestation is being signed and published replace each other, so the signer is not flooded during catch-up.
+type safeHeadAttester struct {
+	rollup.SafeHeadListener
+	n *OpNode
+
+	publish func(ctx context.Context, att *p2p.SafeHeadAttestation) error
+
+	mu      sync.Mutex
+	latest  *p2p.SafeHeadAttestation
+	updated chan struct{}
+	start   sync.Once
+}
+
+var _ rollup.SafeHeadListener = (*safeHeadAttester)(nil)
+
+func newSafeHeadAttester(n *OpNode, listener rollup.SafeHeadListener) *safeHeadAttester {
+	a := &safeHeadAttester{
+		SafeHeadListener: listener,
+		n:                n,
+		updated:          make(chan struct{}, 1),
+	}
+	a.publish = func(ctx context.Context, att *p2p.SafeHeadAttestation) error {
+		return n.p2pNode.GossipOut().PublishSafeHeadAttestation(ctx, att, n.p2pSigner)
+	}
+	return a
+}
+
+// canAttest returns true if the node has a p2p signer to sign attestations with.
+// The p2p setup completes after the driver is created, so this is checked on every update.
+func (a *safeHeadAttester) canAttest() bool {
+	return a.n.p2pNode != nil && a.n.p2pSigner != nil
+}
+
+func (a *safeHeadAttester) Enabled() bool {
+	return a.SafeHeadListener.Enabled() || a.canAttest()
+}
+
+func (a *safeHeadAttester) SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID) error {
+	if a.canAttest() {
+		a.mu.Lock()
+		a.latest = &p2p.SafeHeadAttestation{Safe: newSafeHead, DerivedFrom: l1Block}
+		a.mu.Unlock()
+		// publish in the background, the signer may be remote and should not hold up derivation.
+		a.start.Do(func() { go a.publishLoop(a.n.resourcesCtx) })
+		select {
+		case a.updated <- struct{}{}:
+		default: // the worker has yet to pick up a previous update, and will publish this one instead
+		}
+	}
+	if !a.SafeHeadListener.Enabled() {
+		return nil
+	}
+	return a.SafeHeadListener.SafeHeadUpdated(newSafeHead, l1Block)
+}
+
+// publishLoop publishes the latest safe head attestation whenever the safe head is updated, until ctx is done.
+func (a *safeHeadAttester) publishLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-a.updated:
+		}
+		a.mu.Lock()
+		att := a.latest
+		a.latest = nil
+		a.mu.Unlock()
+		if att == nil {
+			continue
+		}
+		pctx, cancel := context.WithTimeout(ctx, safeHeadAttesterPublishTimeout)
+		err := a.publish(pctx, att)
+		cancel()
+		if err != nil {
+			a.n.log.Warn("Failed to publish safe head attestation", "safe", att.Safe, "l1", att.DerivedFrom, "err", err)
+		}
+	}
+}
+
+func (n *OpNode) OnSafeHeadAttestation(ctx context.Context, from peer.ID, att *p2p.SafeHeadAttestation) error {
+	// ignore if it's from ourselves
+	if n.p2pNode != nil && from == n.p2pNode.Host().ID() {
+		return nil
+	}
+
+	n.log.Debug("Received safe head attestation from p2p", "safe", att.Safe, "l1", att.DerivedFrom, "peer", from)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	if err := n.l2Driver.OnClaimedSafeHead(ctx, att.Safe); err != nil {
+		n.log.Warn("failed to notify engine driver of claimed safe head", "err", err, "safe", att.Safe)
+	}
+	return nil
+}
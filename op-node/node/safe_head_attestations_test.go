@@ -0,0 +1,63 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type disabledSafeHeadListener struct{}
+
+func (disabledSafeHeadListener) Enabled() bool { return false }
+func (disabledSafeHeadListener) SafeHeadUpdated(eth.L2BlockRef, eth.BlockID) error {
+	return nil
+}
+func (disabledSafeHeadListener) SafeHeadReset(eth.L2BlockRef) error { return nil }
+
+func TestSafeHeadAttesterCoalescesUpdates(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	n := &OpNode{
+		log:          testlog.Logger(t, log.LevelCrit),
+		p2pNode:      &p2p.NodeP2P{},
+		p2pSigner:    p2p.NewLocalSigner(key),
+		resourcesCtx: ctx,
+	}
+	a := newSafeHeadAttester(n, disabledSafeHeadListener{})
+
+	published := make(chan uint64)
+	release := make(chan struct{})
+	a.publish = func(ctx context.Context, att *p2p.SafeHeadAttestation) error {
+		published <- att.Safe.Number
+		<-release
+		return nil
+	}
+	update := func(num uint64) {
+		require.NoError(t, a.SafeHeadUpdated(eth.L2BlockRef{Hash: common.Hash{byte(num)}, Number: num}, eth.BlockID{Number: num}))
+	}
+
+	update(1)
+	require.Equal(t, uint64(1), <-published)
+	// updates made while the signer is busy are coalesced into the latest one
+	for i := uint64(2); i <= 100; i++ {
+		update(i)
+	}
+	close(release)
+	require.Equal(t, uint64(100), <-published)
+	select {
+	case num := <-published:
+		t.Fatalf("unexpected publication of safe head %d", num)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -3,11 +3,16 @@ package node
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/ethereum-optimism/optimism/op-service/health"
 	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -16,6 +21,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 type rpcServer struct {
@@ -24,13 +30,21 @@ type rpcServer struct {
 	httpServer *ophttp.HTTPServer
 	appVersion string
 	log        log.Logger
+	metrics    opmetrics.RPCServerMetricer
+	tracer     optracing.Tracer
+	health     *health.Checks
+	adminAuth  *oprpc.AdminAuth
 	sources.L2Client
 }
 
 func newRPCServer(rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, safedb SafeDBReader, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
-	api := NewNodeAPI(rollupCfg, l2Client, dr, safedb, log.New("rpc", "node"), m)
+	api := NewNodeAPI(rollupCfg, l2Client, dr, safedb, log.New("rpc", "node"))
 	// TODO: extend RPC config with options for WS, IPC and HTTP RPC connections
 	endpoint := net.JoinHostPort(rpcCfg.ListenAddr, strconv.Itoa(rpcCfg.ListenPort))
+	adminAuth, err := oprpc.NewAdminAuth(rpcCfg.AdminAuth, []string{"admin"}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure admin RPC authentication: %w", err)
+	}
 	r := &rpcServer{
 		endpoint: endpoint,
 		apis: []rpc.API{{
@@ -40,10 +54,22 @@ func newRPCServer(rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthCli
 		}},
 		appVersion: appVersion,
 		log:        log,
+		metrics:    m,
+		adminAuth:  adminAuth,
 	}
 	return r, nil
 }
 
+// EnableChainAPI serves the optimism API of an additional chain in the namespace of its L2 chain ID, see ChainNamespace.
+func (s *rpcServer) EnableChainAPI(chainID *big.Int, api *nodeAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     ChainNamespace(chainID),
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
 func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 	s.apis = append(s.apis, rpc.API{
 		Namespace:     "admin",
@@ -53,6 +79,15 @@ func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 	})
 }
 
+func (s *rpcServer) EnableDebugAPI(api *debugAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "debug",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
 func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	s.apis = append(s.apis, rpc.API{
 		Namespace:     p2p.NamespaceRPC,
@@ -62,6 +97,17 @@ func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	})
 }
 
+// EnableTracing traces the requests to the RPC server with the given tracer.
+func (s *rpcServer) EnableTracing(t optracing.Tracer) {
+	s.tracer = t
+}
+
+// EnableHealthChecks serves the liveness and readiness reports of the health checks on /healthz and /readyz,
+// instead of only the version on /healthz.
+func (s *rpcServer) EnableHealthChecks(c *health.Checks) {
+	s.health = c
+}
+
 func (s *rpcServer) Start() error {
 	srv := rpc.NewServer()
 	if err := node.RegisterApis(s.apis, nil, srv); err != nil {
@@ -72,13 +118,44 @@ func (s *rpcServer) Start() error {
 	// other services to connect to the opnode. VHosts in particular
 	// defaults to localhost, which will prevent containers from
 	// calling into the opnode without an "invalid host" error.
-	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
+	nodeHandler := node.NewHTTPHandlerStack(opmetrics.NewRPCServerMiddleware(s.metrics, srv), []string{"*"}, []string{"*"}, nil)
+	if s.adminAuth != nil {
+		nodeHandler = oprpc.NewAdminAuthHandler(s.adminAuth, nodeHandler)
+	}
+	if s.tracer != nil {
+		nodeHandler = optracing.NewHTTPMiddleware(s.tracer, "node-rpc")(nodeHandler)
+	}
+
+	// Subscriptions are served over websockets. Only the public APIs are served there, since the admin
+	// authentication can not inspect the requests of websocket connections.
+	wsSrv := rpc.NewServer()
+	if err := node.RegisterApis(s.publicAPIs(), nil, wsSrv); err != nil {
+		return err
+	}
+	wsHandler := opmetrics.NewRPCWebsocketHandler(s.metrics, wsSrv)
+	httpHandler := nodeHandler
+	nodeHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		httpHandler.ServeHTTP(w, r)
+	})
 
 	mux := http.NewServeMux()
 	mux.Handle("/", nodeHandler)
-	mux.HandleFunc("/healthz", healthzHandler(s.appVersion))
+	if s.health != nil {
+		mux.Handle("/healthz", s.health.LivenessHandler())
+		mux.Handle("/readyz", s.health.ReadinessHandler())
+	} else {
+		mux.HandleFunc("/healthz", healthzHandler(s.appVersion))
+	}
 
-	hs, err := ophttp.StartHTTPServer(s.endpoint, mux)
+	var opts []ophttp.HTTPOption
+	if s.adminAuth != nil && s.adminAuth.TLSConfig() != nil {
+		opts = append(opts, ophttp.WithTLSConfig(s.adminAuth.TLSConfig()))
+	}
+	hs, err := ophttp.StartHTTPServer(s.endpoint, mux, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to start HTTP RPC server: %w", err)
 	}
@@ -86,6 +163,23 @@ func (s *rpcServer) Start() error {
 	return nil
 }
 
+// publicAPIs returns the optimism APIs of the node and of any additional chains.
+func (s *rpcServer) publicAPIs() []rpc.API {
+	var apis []rpc.API
+	for _, api := range s.apis {
+		// the namespaces of additional chains are prefixed with the optimism namespace, see ChainNamespace
+		if strings.HasPrefix(api.Namespace, "optimism") {
+			apis = append(apis, api)
+		}
+	}
+	return apis
+}
+
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
 func (r *rpcServer) Stop(ctx context.Context) error {
 	return r.httpServer.Stop(ctx)
 }
@@ -3,22 +3,28 @@ package node
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"math/big"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
@@ -200,6 +206,190 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestSyncStatusUpdates(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	drClient := &mockDriverClient{syncStatusSubs: make(chan chan<- *eth.SyncStatus, 1)}
+	rng := rand.New(rand.NewSource(1234))
+	status := randomSyncStatus(rng)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, drClient, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, nil, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	cl, err := gethrpc.DialContext(context.Background(), "ws://"+server.Addr().String())
+	require.NoError(t, err)
+	defer cl.Close()
+	rollupClient := sources.NewRollupClient(rpcclient.NewBaseRPCClient(cl))
+
+	updates := make(chan *eth.SyncStatus, 1)
+	sub, err := rollupClient.SubscribeSyncStatus(context.Background(), updates)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	ch := <-drClient.syncStatusSubs
+	ch <- status
+	require.Equal(t, status, <-updates)
+
+	// the admin API is not served over websockets, since its authentication can not be enforced there
+	err = cl.CallContext(context.Background(), nil, "admin_sequencerActive")
+	var rpcErr gethrpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, -32601, rpcErr.ErrorCode())
+}
+
+func TestChainAPI(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	rng := rand.New(rand.NewSource(1234))
+	drClient := &mockDriverClient{}
+	status := randomSyncStatus(rng)
+	drClient.On("SyncStatus").Return(status)
+	chainDrClient := &mockDriverClient{}
+	chainStatus := randomSyncStatus(rng)
+	chainDrClient.On("SyncStatus").Return(chainStatus)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{L2ChainID: big.NewInt(901)}, &testutils.MockL2Client{}, drClient, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	chainCfg := &rollup.Config{L2ChainID: big.NewInt(902)}
+	server.EnableChainAPI(chainCfg.L2ChainID, NewNodeAPI(chainCfg, &testutils.MockL2Client{}, chainDrClient, &mockSafeDBReader{}, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.SyncStatus
+	require.NoError(t, client.CallContext(context.Background(), &out, "optimism_syncStatus"))
+	require.Equal(t, status, out)
+	require.NoError(t, client.CallContext(context.Background(), &out, "optimism902_syncStatus"))
+	require.Equal(t, chainStatus, out)
+
+	var rollupCfg *rollup.Config
+	require.NoError(t, client.CallContext(context.Background(), &rollupCfg, "optimism902_rollupConfig"))
+	require.Equal(t, chainCfg.L2ChainID, rollupCfg.L2ChainID)
+}
+
+func TestBlocksByTimestamp(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	drClient := &mockDriverClient{}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		Genesis:   rollup.Genesis{L2: eth.BlockID{Number: 100}, L2Time: 1000},
+		BlockTime: 2,
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, &testutils.MockL2Client{}, drClient, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client)
+
+	status := &eth.SyncStatus{UnsafeL2: eth.L2BlockRef{Hash: common.Hash{0xff}, Number: 110, Time: 1020}}
+	drClient.On("SyncStatus").Return(status)
+	ref := eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 105, Time: 1010, L1Origin: eth.BlockID{Hash: common.Hash{0x11}, Number: 7}}
+	drClient.ExpectBlockRefWithStatus(105, ref, status, nil)
+
+	// timestamps between two L2 blocks map to the earlier block
+	for _, ts := range []uint64{1010, 1011} {
+		out, err := rollupClient.L2BlockByTimestamp(context.Background(), ts)
+		require.NoError(t, err)
+		require.Equal(t, ref, out)
+		origin, err := rollupClient.L1OriginAtTimestamp(context.Background(), ts)
+		require.NoError(t, err)
+		require.Equal(t, ref.L1Origin, origin)
+	}
+
+	_, err = rollupClient.L2BlockByTimestamp(context.Background(), 999)
+	require.ErrorContains(t, err, "did not reach genesis time")
+	_, err = rollupClient.L2BlockByTimestamp(context.Background(), 1022)
+	require.ErrorContains(t, err, "ahead of the unsafe head")
+}
+
+func TestExportImportSequencerState(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	ctx := context.Background()
+	var nilErr error
+	driverState := func() *eth.SequencerState {
+		return &eth.SequencerState{
+			L2Genesis: eth.BlockID{Hash: common.Hash{0x01}},
+			UnsafeL2:  eth.L2BlockRef{Hash: common.Hash{0x0a}, Number: 10},
+			SafeL2:    eth.L2BlockRef{Hash: common.Hash{0x05}, Number: 5},
+		}
+	}
+	export := func(t *testing.T, conductor *ConductorClient) *eth.SequencerState {
+		drClient := &mockDriverClient{}
+		drClient.Mock.On("ExportSequencerState").Return(driverState(), &nilErr)
+		state, err := NewAdminAPI(drClient, conductor, log).ExportSequencerState(ctx)
+		require.NoError(t, err)
+		require.NoError(t, state.VerifyChecksum())
+		return state
+	}
+	newConductor := func(t *testing.T, overridden bool) *ConductorClient {
+		c := NewConductorClient(&Config{}, log, nil)
+		if overridden {
+			require.NoError(t, c.OverrideLeader(ctx))
+		}
+		return c
+	}
+
+	t.Run("Import", func(t *testing.T) {
+		state := export(t, nil)
+		require.Equal(t, eth.SequencerConductorState{}, state.Conductor)
+		drClient := &mockDriverClient{}
+		drClient.Mock.On("ImportSequencerState", state).Return(&nilErr)
+		require.NoError(t, NewAdminAPI(drClient, nil, log).ImportSequencerState(ctx, state))
+		drClient.Mock.AssertExpectations(t)
+	})
+	t.Run("Modified", func(t *testing.T) {
+		state := export(t, nil)
+		state.UnsafeL2.Number = 11
+		drClient := &mockDriverClient{}
+		require.ErrorContains(t, NewAdminAPI(drClient, nil, log).ImportSequencerState(ctx, state), "checksum mismatch")
+		drClient.Mock.AssertExpectations(t)
+	})
+	t.Run("ConductorMismatch", func(t *testing.T) {
+		state := export(t, newConductor(t, false))
+		require.Equal(t, eth.SequencerConductorState{Enabled: true}, state.Conductor)
+		drClient := &mockDriverClient{}
+		require.ErrorContains(t, NewAdminAPI(drClient, nil, log).ImportSequencerState(ctx, state), "sequencer conductor mismatch")
+		drClient.Mock.AssertExpectations(t)
+	})
+	t.Run("LeaderOverride", func(t *testing.T) {
+		state := export(t, newConductor(t, true))
+		require.Equal(t, eth.SequencerConductorState{Enabled: true, LeaderOverridden: true}, state.Conductor)
+		drClient := &mockDriverClient{}
+		drClient.Mock.On("OverrideLeader").Return(nil).Once()
+		drClient.Mock.On("ImportSequencerState", state).Return(&nilErr)
+		require.NoError(t, NewAdminAPI(drClient, newConductor(t, false), log).ImportSequencerState(ctx, state))
+		drClient.Mock.AssertExpectations(t)
+	})
+	t.Run("DriverError", func(t *testing.T) {
+		state := export(t, nil)
+		drClient := &mockDriverClient{}
+		importErr := errors.New("unsafe head mismatch")
+		drClient.Mock.On("ImportSequencerState", state).Return(&importErr)
+		require.ErrorIs(t, NewAdminAPI(drClient, nil, log).ImportSequencerState(ctx, state), importErr)
+	})
+}
+
 func TestSafeHeadAtL1Block(t *testing.T) {
 	log := testlog.Logger(t, log.LevelError)
 	l2Client := &testutils.MockL2Client{}
@@ -246,8 +436,253 @@ func TestSafeHeadAtL1Block(t *testing.T) {
 	safeReader.Mock.AssertExpectations(t)
 }
 
+func TestDerivationProgressAtL1Block(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	l1BlockNum := uint64(5223)
+	expected := &eth.DerivationProgress{
+		L1Block: eth.BlockID{Hash: common.Hash{0xdd}, Number: l1BlockNum},
+		Frames:  []eth.DerivedFrame{{ChannelID: hexutil.Bytes{0x01}, FrameNumber: 1, DataLength: 100, IsLast: true}},
+		Channels: []eth.DerivedChannel{{
+			ChannelID: hexutil.Bytes{0x01},
+			Status:    eth.ChannelReady,
+			OpenedAt:  eth.BlockID{Hash: common.Hash{0xcc}, Number: l1BlockNum - 1},
+			Frames:    2,
+			Size:      300,
+		}},
+		Batches:  []eth.DerivedBatch{{Type: "span", Timestamp: 1000, Blocks: 3, Status: eth.BatchAccepted}},
+		L2Blocks: []eth.L2BlockRef{{Hash: common.Hash{0xee}, Number: 223}},
+	}
+	var noErr error
+	drClient.On("DerivationProgressAtL1", l1BlockNum).Return(expected, &noErr)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.DerivationProgress
+	err = client.CallContext(context.Background(), &out, "optimism_derivationProgressAtL1Block", hexutil.Uint64(l1BlockNum).String())
+	require.NoError(t, err)
+	require.Equal(t, expected, out)
+	drClient.Mock.AssertExpectations(t)
+}
+
+type testAttributesBuilder struct {
+	attrs *eth.PayloadAttributes
+}
+
+func (b *testAttributesBuilder) BuildPayloadAttributes(ctx context.Context, l1Origin eth.BlockID, l2Parent eth.BlockID) (*eth.PayloadAttributes, error) {
+	if l2Parent.Number == 0 {
+		return nil, errors.New("unknown parent")
+	}
+	return b.attrs, nil
+}
+
+func TestBuildPayloadAttributes(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	gasLimit := eth.Uint64Quantity(30_000_000)
+	expected := &eth.PayloadAttributes{
+		Timestamp:    1234,
+		Transactions: []eth.Data{{0x7e, 0x01}},
+		NoTxPool:     true,
+		GasLimit:     &gasLimit,
+	}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, &mockDriverClient{}, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableDebugAPI(NewDebugAPI(&testAttributesBuilder{attrs: expected}, nil, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	l1Origin := eth.BlockID{Hash: common.Hash{0xaa}, Number: 100}
+	var out *eth.PayloadAttributes
+	err = client.CallContext(context.Background(), &out, "debug_buildPayloadAttributes", l1Origin, eth.BlockID{Hash: common.Hash{0xbb}, Number: 10})
+	require.NoError(t, err)
+	require.Equal(t, expected, out)
+
+	err = client.CallContext(context.Background(), &out, "debug_buildPayloadAttributes", l1Origin, eth.BlockID{})
+	require.ErrorContains(t, err, "unknown parent")
+}
+
+type testEngineQueue struct {
+	state *eth.EngineQueueState
+}
+
+func (q *testEngineQueue) EngineQueueState(ctx context.Context) (*eth.EngineQueueState, error) {
+	return q.state, nil
+}
+
+func TestEngineQueueState(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	expected := &eth.EngineQueueState{
+		UnsafeL2:    eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 12},
+		SafeL2:      eth.L2BlockRef{Hash: common.Hash{0x02}, Number: 10},
+		NeedFCUCall: true,
+		PendingPayload: &eth.PendingPayload{
+			ID:        eth.PayloadID{0x03},
+			Onto:      eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 12},
+			Timestamp: 1234,
+			State:     "started",
+			StartedAt: time.Unix(1000, 0).UTC(),
+		},
+		QueuedUnsafePayloads: []eth.BlockID{{Hash: common.Hash{0x04}, Number: 14}},
+		InsertionErrors: []eth.BlockInsertionError{
+			{Time: time.Unix(900, 0).UTC(), Type: "temporary", Onto: eth.L2BlockRef{Number: 11}, Error: "engine offline"},
+		},
+	}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, &mockDriverClient{}, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableDebugAPI(NewDebugAPI(nil, &testEngineQueue{state: expected}, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	out, err := sources.NewRollupClient(client).EngineQueueState(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expected, out)
+}
+
+func TestInclusionDeadlines(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	drClient := &mockDriverClient{}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, drClient, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, nil, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client)
+
+	var nilErr error
+	txHash := common.Hash{0xaa}
+	expected := eth.InclusionDeadline{TxHash: txHash, RegisteredAt: 10, Deadline: 15}
+	drClient.Mock.On("AddInclusionDeadline", txHash, uint64(5)).Return(expected, &nilErr)
+	deadline, err := rollupClient.AddInclusionDeadline(context.Background(), txHash, 5)
+	require.NoError(t, err)
+	require.Equal(t, expected, deadline)
+
+	drClient.Mock.On("InclusionDeadlines").Return([]eth.InclusionDeadline{expected}, &nilErr)
+	deadlines, err := rollupClient.InclusionDeadlines(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []eth.InclusionDeadline{expected}, deadlines)
+
+	notFound := driver.ErrInclusionDeadlineNotFound
+	drClient.Mock.On("RemoveInclusionDeadline", txHash).Return(&nilErr)
+	drClient.Mock.On("RemoveInclusionDeadline", common.Hash{0xbb}).Return(&notFound)
+	require.NoError(t, rollupClient.RemoveInclusionDeadline(context.Background(), txHash))
+	require.ErrorContains(t, rollupClient.RemoveInclusionDeadline(context.Background(), common.Hash{0xbb}), notFound.Error())
+	drClient.Mock.AssertExpectations(t)
+}
+
+func TestSequencerPolicy(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	drClient := &mockDriverClient{}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, drClient, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, nil, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client)
+
+	var nilErr error
+	maxDABytes := hexutil.Uint64(1000)
+	policy := &eth.TxPoolPolicy{MaxDABytes: &maxDABytes, ExcludedAddresses: []common.Address{{0xaa}}}
+	drClient.Mock.On("SetSequencerPolicy", policy).Return(&nilErr)
+	require.NoError(t, rollupClient.SetSequencerPolicy(context.Background(), policy))
+
+	drClient.Mock.On("SequencerPolicy").Return(policy, &nilErr)
+	out, err := rollupClient.SequencerPolicy(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, policy, out)
+	drClient.Mock.AssertExpectations(t)
+}
+
+func TestBuilderStatus(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	drClient := &mockDriverClient{}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, drClient, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client)
+
+	expected := &eth.BuilderStatus{Available: true, Blocks: 10, Failures: 2}
+	drClient.ExpectBuilderStatus(expected, nil)
+	status, err := rollupClient.BuilderStatus(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expected, status)
+
+	// sequencers without builder report no status
+	drClient.ExpectBuilderStatus(nil, nil)
+	status, err = rollupClient.BuilderStatus(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, status)
+	drClient.Mock.AssertExpectations(t)
+}
+
 type mockDriverClient struct {
 	mock.Mock
+	// syncStatusSubs receives the channels of sync status subscriptions, if set
+	syncStatusSubs chan chan<- *eth.SyncStatus
+}
+
+func (c *mockDriverClient) SubscribeSyncStatus(ch chan<- *eth.SyncStatus) func() {
+	c.syncStatusSubs <- ch
+	return func() {}
 }
 
 func (c *mockDriverClient) ExpectBlockRefWithStatus(num uint64, ref eth.L2BlockRef, status *eth.SyncStatus, err error) {
@@ -263,6 +698,11 @@ func (c *mockDriverClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, err
 	return c.Mock.MethodCalled("SyncStatus").Get(0).(*eth.SyncStatus), nil
 }
 
+func (c *mockDriverClient) DerivationProgressAtL1(ctx context.Context, num uint64) (*eth.DerivationProgress, error) {
+	m := c.Mock.MethodCalled("DerivationProgressAtL1", num)
+	return m[0].(*eth.DerivationProgress), *m[1].(*error)
+}
+
 func (c *mockDriverClient) ResetDerivationPipeline(ctx context.Context) error {
 	return c.Mock.MethodCalled("ResetDerivationPipeline").Get(0).(error)
 }
@@ -284,7 +724,58 @@ func (c *mockDriverClient) OnUnsafeL2Payload(ctx context.Context, payload *eth.E
 }
 
 func (c *mockDriverClient) OverrideLeader(ctx context.Context) error {
-	return c.Mock.MethodCalled("OverrideLeader").Get(0).(error)
+	return c.Mock.MethodCalled("OverrideLeader").Error(0)
+}
+
+func (c *mockDriverClient) ExportSequencerState(ctx context.Context) (*eth.SequencerState, error) {
+	m := c.Mock.MethodCalled("ExportSequencerState")
+	return m[0].(*eth.SequencerState), *m[1].(*error)
+}
+
+func (c *mockDriverClient) ImportSequencerState(ctx context.Context, state *eth.SequencerState) error {
+	return *c.Mock.MethodCalled("ImportSequencerState", state).Get(0).(*error)
+}
+
+func (c *mockDriverClient) AddInclusionDeadline(ctx context.Context, txHash common.Hash, blocks uint64) (eth.InclusionDeadline, error) {
+	m := c.Mock.MethodCalled("AddInclusionDeadline", txHash, blocks)
+	return m[0].(eth.InclusionDeadline), *m[1].(*error)
+}
+
+func (c *mockDriverClient) RemoveInclusionDeadline(ctx context.Context, txHash common.Hash) error {
+	return *c.Mock.MethodCalled("RemoveInclusionDeadline", txHash).Get(0).(*error)
+}
+
+func (c *mockDriverClient) InclusionDeadlines(ctx context.Context) ([]eth.InclusionDeadline, error) {
+	m := c.Mock.MethodCalled("InclusionDeadlines")
+	return m[0].([]eth.InclusionDeadline), *m[1].(*error)
+}
+
+func (c *mockDriverClient) SetSequencerPolicy(ctx context.Context, policy *eth.TxPoolPolicy) error {
+	return *c.Mock.MethodCalled("SetSequencerPolicy", policy).Get(0).(*error)
+}
+
+func (c *mockDriverClient) SequencerPolicy(ctx context.Context) (*eth.TxPoolPolicy, error) {
+	m := c.Mock.MethodCalled("SequencerPolicy")
+	return m[0].(*eth.TxPoolPolicy), *m[1].(*error)
+}
+
+func (c *mockDriverClient) UnsafeReorgHalt(ctx context.Context) (*eth.UnsafeReorgHalt, error) {
+	m := c.Mock.MethodCalled("UnsafeReorgHalt")
+	return m[0].(*eth.UnsafeReorgHalt), *m[1].(*error)
+}
+
+func (c *mockDriverClient) ConfirmUnsafeReorg(ctx context.Context) error {
+	m := c.Mock.MethodCalled("ConfirmUnsafeReorg")
+	return *m[0].(*error)
+}
+
+func (c *mockDriverClient) ExpectBuilderStatus(status *eth.BuilderStatus, err error) {
+	c.Mock.On("BuilderStatus").Once().Return(status, &err)
+}
+
+func (c *mockDriverClient) BuilderStatus(ctx context.Context) (*eth.BuilderStatus, error) {
+	m := c.Mock.MethodCalled("BuilderStatus")
+	return m[0].(*eth.BuilderStatus), *m[1].(*error)
 }
 
 type mockSafeDBReader struct {
@@ -41,7 +41,7 @@ func (n *OpNode) handleProtocolVersionsUpdate(ctx context.Context) error {
 func (n *OpNode) haltMaybe() error {
 	local := rollup.OPStackSupport
 	required := n.runCfg.RequiredProtocolVersion()
-	if haltMaybe(n.rollupHalt, local.Compare(required)) { // halt if we opted in to do so at this granularity
+	if haltMaybe(*n.rollupHalt.Load(), local.Compare(required)) { // halt if we opted in to do so at this granularity
 		n.log.Error("Opted to halt, unprepared for protocol change", "required", required, "local", local)
 		// Avoid deadlocking the runtime config reloader by closing the OpNode elsewhere
 		return errNodeHalt
@@ -49,6 +49,17 @@ func (n *OpNode) haltMaybe() error {
 	return nil
 }
 
+// SetRollupHalt changes when to halt the rollup, see Config.RollupHalt.
+// The new option is checked against the current protocol versions on the next update of the runtime config.
+func (n *OpNode) SetRollupHalt(opt string) error {
+	if err := checkRollupHalt(opt); err != nil {
+		return err
+	}
+	n.rollupHalt.Store(&opt)
+	n.log.Info("Changed rollup halting option", "halt", opt)
+	return nil
+}
+
 // haltMaybe returns true when we should halt, given the halt-option and required-version comparison
 func haltMaybe(haltOption string, reqCmp params.ProtocolVersionComparison) bool {
 	var needLevel int
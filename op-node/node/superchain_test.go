@@ -6,7 +6,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 func TestHaltMaybe(t *testing.T) {
@@ -37,3 +40,17 @@ func TestHaltMaybe(t *testing.T) {
 	haltTest("minor", params.OutdatedMajor, params.OutdatedMinor)
 	haltTest("patch", params.OutdatedMajor, params.OutdatedMinor, params.OutdatedPatch)
 }
+
+func TestSetRollupHalt(t *testing.T) {
+	n := &OpNode{log: testlog.Logger(t, log.LevelInfo)}
+	initial := "major"
+	n.rollupHalt.Store(&initial)
+
+	require.NoError(t, n.SetRollupHalt("patch"))
+	require.Equal(t, "patch", *n.rollupHalt.Load())
+	require.NoError(t, n.SetRollupHalt(""))
+	require.Equal(t, "", *n.rollupHalt.Load())
+
+	require.ErrorContains(t, n.SetRollupHalt("none"), "invalid rollup halting option")
+	require.Equal(t, "", *n.rollupHalt.Load())
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
 )
@@ -68,9 +69,36 @@ func NewConfig(ctx *cli.Context, rollupCfg *rollup.Config) (*p2p.Config, error)
 	conf.EnablePingService = ctx.Bool(flags.P2PPingName)
 	conf.SyncOnlyReqToStatic = ctx.Bool(flags.SyncOnlyReqToStaticName)
 
+	for _, v := range strings.Split(ctx.String(flags.SyncCompressionName), ",") {
+		codec, err := p2p.ParseCodec(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load p2p sync compression: %w", err)
+		}
+		conf.SyncCompression = append(conf.SyncCompression, codec)
+	}
+
+	if err := loadSafeHeadAttestationOptions(conf, ctx); err != nil {
+		return nil, fmt.Errorf("failed to load safe head attestation options: %w", err)
+	}
+
 	return conf, nil
 }
 
+func loadSafeHeadAttestationOptions(conf *p2p.Config, ctx *cli.Context) error {
+	if !ctx.Bool(flags.SafeHeadAttestationsName) {
+		return nil
+	}
+	var attCfg p2p.SafeHeadAttestationConfig
+	if addr := ctx.String(flags.SafeHeadAttesterName); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return fmt.Errorf("invalid safe head attester address: %q", addr)
+		}
+		attCfg.Attester = common.HexToAddress(addr)
+	}
+	conf.SafeHeadAttestations = &attCfg
+	return nil
+}
+
 func validatePort(p uint) (uint16, error) {
 	if p == 0 {
 		return 0, nil
@@ -134,7 +162,8 @@ func loadListenOpts(conf *p2p.Config, ctx *cli.Context) error {
 }
 
 func loadDiscoveryOpts(conf *p2p.Config, ctx *cli.Context) error {
-	if ctx.Bool(flags.NoDiscoveryName) {
+	// a static peer mesh never discovers peers
+	if ctx.Bool(flags.NoDiscoveryName) || ctx.Bool(flags.StaticMeshName) {
 		conf.NoDiscovery = true
 	}
 
@@ -220,6 +249,7 @@ func loadLibp2pOpts(conf *p2p.Config, ctx *cli.Context) error {
 		}
 		conf.StaticPeers = append(conf.StaticPeers, a)
 	}
+	conf.StaticMesh = ctx.Bool(flags.StaticMeshName)
 
 	for _, v := range strings.Split(ctx.String(flags.HostMuxName), ",") {
 		v = strings.ToLower(strings.TrimSpace(v))
@@ -1,22 +1,27 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 )
 
-// TODO: implement remote signer setup (config to authenticated endpoint)
-// and remote signer itself (e.g. a open http client to make signing requests)
-
 // LoadSignerSetup loads a configuration for a Signer to be set up later
-func LoadSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
+func LoadSignerSetup(ctx *cli.Context, logger log.Logger) (p2p.SignerSetup, error) {
 	key := ctx.String(flags.SequencerP2PKeyName)
+	signerCfg := opsigner.ReadCLIConfig(ctx)
+	remote := signerCfg.Endpoint != "" || signerCfg.KMSEnabled()
+	if key != "" && remote {
+		return nil, fmt.Errorf("cannot use both %s and a remote signer", flags.SequencerP2PKeyName)
+	}
 	if key != "" {
 		// Mnemonics are bad because they leak *all* keys when they leak.
 		// Unencrypted keys from file are bad because they are easy to leak (and we are not checking file permissions).
@@ -28,7 +33,21 @@ func LoadSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
 		return &p2p.PreparedSigner{Signer: p2p.NewLocalSigner(priv)}, nil
 	}
 
-	// TODO: create remote signer
+	if remote {
+		// Unlike for transaction signing, the address is optional: without it the remote signer signs with its current key,
+		// so the key can be rotated without restarting the op-node.
+		if err := signerCfg.TLSConfig.Check(); err != nil {
+			return nil, err
+		}
+		if signerCfg.KMSEnabled() {
+			if err := signerCfg.Check(); err != nil {
+				return nil, err
+			}
+		}
+		return &p2p.RemoteSignerSetup{Log: logger, Config: signerCfg}, nil
+	} else if signerCfg.Address != "" {
+		return nil, errors.New("signer address is set, but no signer endpoint or KMS provider")
+	}
 
 	return nil, nil
 }
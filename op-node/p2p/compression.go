@@ -0,0 +1,212 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// Codec is a compression codec of req/resp sync responses.
+type Codec string
+
+const (
+	// CodecSnappy is the snappy framed compression of the sync protocol spec, supported by all peers.
+	CodecSnappy Codec = "snappy"
+	// CodecZstd compresses payloads better than snappy. It is only used with peers that support it.
+	CodecZstd Codec = "zstd"
+)
+
+// Codecs are all the supported codecs.
+var Codecs = []Codec{CodecZstd, CodecSnappy}
+
+// DefaultSyncCodecs are the codecs of sync responses, by preference.
+var DefaultSyncCodecs = []Codec{CodecZstd, CodecSnappy}
+
+// Protocol names of the compression metrics.
+const (
+	compressionProtocolGossipBlocks    = "gossip_blocks"
+	compressionProtocolPayloadByNumber = "payload_by_number"
+)
+
+// CompressionMetrics records the compressed and uncompressed sizes of payloads, per protocol and codec.
+type CompressionMetrics interface {
+	RecordP2PCompression(protocol string, codec string, outbound bool, compressed int, uncompressed int)
+}
+
+func ParseCodec(s string) (Codec, error) {
+	c := Codec(strings.ToLower(strings.TrimSpace(s)))
+	if !slices.Contains(Codecs, c) {
+		return "", fmt.Errorf("unknown codec %q, expected one of %v", s, Codecs)
+	}
+	return c, nil
+}
+
+// syncCodecs returns the codecs by preference, or the defaults if none are configured.
+// Snappy is always supported, as the codec of the spec.
+func syncCodecs(codecs []Codec) []Codec {
+	if len(codecs) == 0 {
+		return DefaultSyncCodecs
+	}
+	if !slices.Contains(codecs, CodecSnappy) {
+		codecs = append(slices.Clone(codecs), CodecSnappy)
+	}
+	return codecs
+}
+
+// PayloadByNumberCodecProtocolID is the protocol ID of the payload-by-number sync protocol,
+// with responses compressed by the given codec.
+// The codec is negotiated with the peer when opening a stream, by offering the protocol IDs by preference.
+func PayloadByNumberCodecProtocolID(l2ChainID *big.Int, codec Codec) protocol.ID {
+	if codec == CodecSnappy {
+		return PayloadByNumberProtocolID(l2ChainID)
+	}
+	return protocol.ID(fmt.Sprintf("%s/%s", PayloadByNumberProtocolID(l2ChainID), codec))
+}
+
+// The zstd encoders and decoders allocate large buffers, so they are reused across sync requests and responses.
+// With a concurrency of 1 they do not run background goroutines, and need no closing when dropped from the pool.
+var (
+	zstdEncoders sync.Pool
+	zstdDecoders sync.Pool
+)
+
+// newWriter compresses the data written to w with the codec. The writer must be closed to flush the data.
+func (c Codec) newWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case CodecSnappy:
+		return snappy.NewBufferedWriter(w), nil
+	case CodecZstd:
+		if enc, ok := zstdEncoders.Get().(*zstd.Encoder); ok {
+			enc.Reset(w)
+			return &zstdWriter{enc: enc}, nil
+		}
+		enc, err := zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return &zstdWriter{enc: enc}, nil
+	default:
+		return nil, fmt.Errorf("unknown codec %q", c)
+	}
+}
+
+// newReader decompresses the data read from r with the codec.
+// The memory used by decompression is limited to maxGossipSize.
+func (c Codec) newReader(r io.Reader) (io.ReadCloser, error) {
+	switch c {
+	case CodecSnappy:
+		return io.NopCloser(snappy.NewReader(r)), nil
+	case CodecZstd:
+		if dec, ok := zstdDecoders.Get().(*zstd.Decoder); ok {
+			if err := dec.Reset(r); err != nil {
+				return nil, err
+			}
+			return &zstdReader{dec: dec}, nil
+		}
+		dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(maxGossipSize))
+		if err != nil {
+			return nil, err
+		}
+		return &zstdReader{dec: dec}, nil
+	default:
+		return nil, fmt.Errorf("unknown codec %q", c)
+	}
+}
+
+// zstdWriter returns the pooled encoder to the pool when closed.
+type zstdWriter struct {
+	enc *zstd.Encoder
+}
+
+func (w *zstdWriter) Write(p []byte) (int, error) {
+	if w.enc == nil {
+		return 0, io.ErrClosedPipe
+	}
+	return w.enc.Write(p)
+}
+
+func (w *zstdWriter) Close() error {
+	if w.enc == nil {
+		return nil
+	}
+	err := w.enc.Close()
+	// Drop the reference to the destination, and only reuse encoders that are in a good state.
+	w.enc.Reset(nil)
+	if err == nil {
+		zstdEncoders.Put(w.enc)
+	}
+	w.enc = nil
+	return err
+}
+
+// zstdReader returns the pooled decoder to the pool when closed.
+type zstdReader struct {
+	dec *zstd.Decoder
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.dec == nil {
+		return 0, io.ErrClosedPipe
+	}
+	return r.dec.Read(p)
+}
+
+func (r *zstdReader) Close() error {
+	if r.dec == nil {
+		return nil
+	}
+	// Reset drops the reference to the source, the decoder is reusable after any decoding error.
+	if err := r.dec.Reset(nil); err == nil {
+		zstdDecoders.Put(r.dec)
+	}
+	r.dec = nil
+	return nil
+}
+
+// countingReader counts the bytes read, to measure the compressed size of a stream.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+// countingWriter counts the bytes written, to measure the compressed size of a stream.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+// recordGossipCompression wraps the validator of a gossip topic, to record the compressed and uncompressed sizes of the messages.
+// The uncompressed size is read from the snappy header, the message is not decompressed.
+func recordGossipCompression(m CompressionMetrics, fn pubsub.ValidatorEx) pubsub.ValidatorEx {
+	if m == nil {
+		return fn
+	}
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		if n, err := snappy.DecodedLen(message.Data); err == nil {
+			m.RecordP2PCompression(compressionProtocolGossipBlocks, string(CodecSnappy), false, len(message.Data), n)
+		}
+		return fn(ctx, id, message)
+	}
+}
@@ -0,0 +1,157 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// compressionMetrics records the codecs of the compression metrics, by direction.
+type compressionMetrics struct {
+	metrics.Metricer
+	mu       sync.Mutex
+	inbound  []string
+	outbound []string
+}
+
+func (m *compressionMetrics) RecordP2PCompression(protocol string, codec string, outbound bool, compressed int, uncompressed int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if outbound {
+		m.outbound = append(m.outbound, codec)
+	} else {
+		m.inbound = append(m.inbound, codec)
+	}
+}
+
+func (m *compressionMetrics) codecs() (inbound []string, outbound []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.inbound...), append([]string(nil), m.outbound...)
+}
+
+func TestParseCodec(t *testing.T) {
+	c, err := ParseCodec(" ZSTD")
+	require.NoError(t, err)
+	require.Equal(t, CodecZstd, c)
+	_, err = ParseCodec("gzip")
+	require.ErrorContains(t, err, "unknown codec")
+
+	require.Equal(t, DefaultSyncCodecs, syncCodecs(nil))
+	require.Equal(t, []Codec{CodecZstd, CodecSnappy}, syncCodecs([]Codec{CodecZstd}))
+	require.Equal(t, []Codec{CodecSnappy}, syncCodecs([]Codec{CodecSnappy}))
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("optimism"), 1000)
+	for _, codec := range Codecs {
+		t.Run(string(codec), func(t *testing.T) {
+			// the zstd encoders and decoders are pooled, round-trip several times to reuse them
+			for i := 0; i < 3; i++ {
+				var buf bytes.Buffer
+				w, err := codec.newWriter(&buf)
+				require.NoError(t, err)
+				_, err = w.Write(data[i:])
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+				if codec == CodecZstd {
+					require.NoError(t, w.Close(), "closing twice must not return the encoder to the pool twice")
+				}
+				require.Less(t, buf.Len(), len(data))
+
+				// a corrupt stream must not break the decoder that is returned to the pool
+				corrupt, err := codec.newReader(bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+				require.NoError(t, err)
+				_, err = io.ReadAll(corrupt)
+				require.Error(t, err)
+				require.NoError(t, corrupt.Close())
+
+				r, err := codec.newReader(&buf)
+				require.NoError(t, err)
+				out, err := io.ReadAll(r)
+				require.NoError(t, err)
+				require.Equal(t, data[i:], out)
+				require.NoError(t, r.Close())
+			}
+		})
+	}
+}
+
+func TestSyncCompressionNegotiation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		server   []Codec
+		client   []Codec
+		expected string
+	}{
+		{name: "zstd", server: DefaultSyncCodecs, client: DefaultSyncCodecs, expected: "zstd"},
+		{name: "snappy-only server", server: []Codec{CodecSnappy}, client: DefaultSyncCodecs, expected: "snappy"},
+		{name: "snappy-only client", server: DefaultSyncCodecs, client: []Codec{CodecSnappy}, expected: "snappy"},
+		{name: "snappy preferred", server: DefaultSyncCodecs, client: []Codec{CodecSnappy, CodecZstd}, expected: "snappy"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			log := testlog.Logger(t, log.LevelError)
+			cfg, payloads := setupSyncTestData(10)
+			servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+				p, ok := payloads.getPayload(n)
+				if !ok {
+					return nil, ethereum.NotFound
+				}
+				return p, nil
+			})
+			received := make(chan *eth.ExecutionPayloadEnvelope, 10)
+			receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
+				received <- payload
+				return nil
+			})
+
+			mnet, err := mocknet.FullMeshConnected(2)
+			require.NoError(t, err, "failed to setup mocknet")
+			defer mnet.Close()
+			hostA, hostB := mnet.Hosts()[0], mnet.Hosts()[1]
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			srvMetrics := &compressionMetrics{Metricer: metrics.NoopMetrics}
+			srv := NewReqRespServer(cfg, servePayload, srvMetrics)
+			payloadByNumber := MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleSyncRequest)
+			for _, codec := range syncCodecs(tc.server) {
+				hostA.SetStreamHandler(PayloadByNumberCodecProtocolID(cfg.L2ChainID, codec), payloadByNumber)
+			}
+
+			clMetrics := &compressionMetrics{Metricer: metrics.NoopMetrics}
+			cl := NewSyncClient(log.New("role", "client"), cfg, hostB, receivePayload, clMetrics, &NoopApplicationScorer{})
+			cl.setCodecs(tc.client)
+			cl.AddPeer(hostA.ID())
+			cl.Start()
+			defer cl.Close()
+
+			_, err = cl.RequestL2Range(ctx, payloads.getBlockRef(5), payloads.getBlockRef(7))
+			require.NoError(t, err)
+			for i := uint64(6); i > 5; i-- {
+				p := <-received
+				require.Equal(t, i, uint64(p.ExecutionPayload.BlockNumber))
+			}
+
+			inbound, _ := clMetrics.codecs()
+			require.Equal(t, []string{tc.expected}, inbound)
+			_, outbound := srvMetrics.codecs()
+			require.Equal(t, []string{tc.expected}, outbound)
+		})
+	}
+}
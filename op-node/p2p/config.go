@@ -43,6 +43,7 @@ var DefaultBootnodes = []*enode.Node{
 type HostMetrics interface {
 	gating.UnbanMetrics
 	gating.ConnectionGaterMetrics
+	RecordStaticMeshPeers(connected int, total int)
 }
 
 // SetupP2P provides a host and discovery service for usage in the rollup node.
@@ -59,6 +60,10 @@ type SetupP2P interface {
 	BanDuration() time.Duration
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
+	// SafeHeadAttestationsConfig returns the safe head attestations gossip config, or nil if disabled.
+	SafeHeadAttestationsConfig() *SafeHeadAttestationConfig
+	// SyncCodecs returns the compression codecs of req/resp sync responses by preference, or nil for the defaults.
+	SyncCodecs() []Codec
 }
 
 // ScoringParams defines the various types of peer scoring parameters.
@@ -97,6 +102,9 @@ type Config struct {
 	NetRestrict      *netutil.Netlist
 
 	StaticPeers []core.Multiaddr
+	// StaticMesh restricts the node to a fixed mesh of the static peers, for permissioned networks:
+	// discovery is disabled, and connections with peers other than the static peers are rejected.
+	StaticMesh bool
 
 	HostMux             []libp2p.Option
 	HostSecurity        []libp2p.Option
@@ -128,8 +136,13 @@ type Config struct {
 
 	EnableReqRespSync   bool
 	SyncOnlyReqToStatic bool
+	// SyncCodecs are the compression codecs of req/resp sync responses, by preference.
+	SyncCompression []Codec
 
 	EnablePingService bool
+
+	// SafeHeadAttestations enables the safe head attestations gossip topic, if not nil.
+	SafeHeadAttestations *SafeHeadAttestationConfig
 }
 
 func DefaultConnManager(conf *Config) (connmgr.ConnManager, error) {
@@ -172,6 +185,14 @@ func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
 
+func (conf *Config) SafeHeadAttestationsConfig() *SafeHeadAttestationConfig {
+	return conf.SafeHeadAttestations
+}
+
+func (conf *Config) SyncCodecs() []Codec {
+	return conf.SyncCompression
+}
+
 const maxMeshParam = 1000
 
 func (conf *Config) Check() error {
@@ -181,10 +202,18 @@ func (conf *Config) Check() error {
 		}
 		return nil
 	}
+	if conf.StaticMesh {
+		if len(conf.StaticPeers) == 0 {
+			return errors.New("static peer mesh requires static peers")
+		}
+		if conf.NoTransportSecurity {
+			return errors.New("static peer mesh requires transport security, to authenticate the static peers")
+		}
+	}
 	if conf.Store == nil {
 		return errors.New("p2p requires a persistent or in-memory peerstore, but found none")
 	}
-	if !conf.NoDiscovery {
+	if !conf.NoDiscovery && !conf.StaticMesh {
 		if conf.DiscoveryDB == nil {
 			return errors.New("discovery requires a persistent or in-memory discv5 db, but found none")
 		}
@@ -0,0 +1,42 @@
+package gating
+
+import (
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// AllowlistConnectionGater enhances a BlockingConnectionGater by only allowing connections with allowlisted peers.
+// Inbound connections are checked once the transport security handshake authenticated the peer ID.
+type AllowlistConnectionGater struct {
+	BlockingConnectionGater
+	log     log.Logger
+	allowed map[peer.ID]struct{}
+}
+
+func AddAllowlist(gater BlockingConnectionGater, log log.Logger, allowed map[peer.ID]struct{}) *AllowlistConnectionGater {
+	return &AllowlistConnectionGater{BlockingConnectionGater: gater, log: log, allowed: allowed}
+}
+
+func (g *AllowlistConnectionGater) isAllowed(p peer.ID) bool {
+	_, ok := g.allowed[p]
+	return ok
+}
+
+func (g *AllowlistConnectionGater) InterceptPeerDial(p peer.ID) (allow bool) {
+	return g.isAllowed(p) && g.BlockingConnectionGater.InterceptPeerDial(p)
+}
+
+func (g *AllowlistConnectionGater) InterceptAddrDial(id peer.ID, ma multiaddr.Multiaddr) (allow bool) {
+	return g.isAllowed(id) && g.BlockingConnectionGater.InterceptAddrDial(id, ma)
+}
+
+func (g *AllowlistConnectionGater) InterceptSecured(dir network.Direction, id peer.ID, mas network.ConnMultiaddrs) (allow bool) {
+	if !g.isAllowed(id) {
+		g.log.Warn("rejecting connection of peer that is not allowlisted", "peer_id", id, "dir", dir)
+		return false
+	}
+	return g.BlockingConnectionGater.InterceptSecured(dir, id, mas)
+}
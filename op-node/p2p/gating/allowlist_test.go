@@ -0,0 +1,42 @@
+package gating
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	log "github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p/gating/mocks"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestAllowlistConnectionGater(t *testing.T) {
+	alice := peer.ID("alice")
+	mallory := peer.ID("mallory")
+	setup := func(t *testing.T) (*mocks.BlockingConnectionGater, *AllowlistConnectionGater) {
+		mockGater := mocks.NewBlockingConnectionGater(t)
+		return mockGater, AddAllowlist(mockGater, testlog.Logger(t, log.LevelError), map[peer.ID]struct{}{alice: {}})
+	}
+
+	t.Run("allowlisted peer", func(t *testing.T) {
+		mockGater, gater := setup(t)
+		mockGater.EXPECT().InterceptPeerDial(alice).Return(true)
+		require.True(t, gater.InterceptPeerDial(alice))
+		mockGater.EXPECT().InterceptSecured(network.DirInbound, alice, nil).Return(true)
+		require.True(t, gater.InterceptSecured(network.DirInbound, alice, nil))
+	})
+	t.Run("blocked allowlisted peer", func(t *testing.T) {
+		mockGater, gater := setup(t)
+		mockGater.EXPECT().InterceptPeerDial(alice).Return(false)
+		require.False(t, gater.InterceptPeerDial(alice))
+	})
+	t.Run("other peer", func(t *testing.T) {
+		_, gater := setup(t)
+		require.False(t, gater.InterceptPeerDial(mallory))
+		require.False(t, gater.InterceptAddrDial(mallory, nil))
+		require.False(t, gater.InterceptSecured(network.DirInbound, mallory, nil))
+	})
+}
@@ -78,10 +78,48 @@ func blocksTopicV3(cfg *rollup.Config) string {
 	return fmt.Sprintf("/optimism/%s/2/blocks", cfg.L2ChainID.String())
 }
 
+func chainTopics(cfg *rollup.Config) []string {
+	return []string{blocksTopicV1(cfg), blocksTopicV2(cfg), blocksTopicV3(cfg), safeHeadsTopicV1(cfg)} // add more topics here in the future, if any.
+}
+
 // BuildSubscriptionFilter builds a simple subscription filter,
 // to help protect against peers spamming useless subscriptions.
 func BuildSubscriptionFilter(cfg *rollup.Config) pubsub.SubscriptionFilter {
-	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg), blocksTopicV3(cfg)) // add more topics here in the future, if any.
+	return pubsub.NewAllowlistSubscriptionFilter(chainTopics(cfg)...)
+}
+
+// chainsSubscriptionFilter is a subscription filter like BuildSubscriptionFilter,
+// that allows the topics of additional chains to be added while the gossip router is running.
+type chainsSubscriptionFilter struct {
+	mu     sync.RWMutex
+	topics map[string]struct{}
+}
+
+var _ pubsub.SubscriptionFilter = (*chainsSubscriptionFilter)(nil)
+
+func newChainsSubscriptionFilter(cfg *rollup.Config) *chainsSubscriptionFilter {
+	f := &chainsSubscriptionFilter{topics: make(map[string]struct{})}
+	f.addChain(cfg)
+	return f
+}
+
+func (f *chainsSubscriptionFilter) addChain(cfg *rollup.Config) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, topic := range chainTopics(cfg) {
+		f.topics[topic] = struct{}{}
+	}
+}
+
+func (f *chainsSubscriptionFilter) CanSubscribe(topic string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	_, ok := f.topics[topic]
+	return ok
+}
+
+func (f *chainsSubscriptionFilter) FilterIncomingSubscriptions(_ peer.ID, subs []*pb.RPC_SubOpts) ([]*pb.RPC_SubOpts, error) {
+	return pubsub.FilterSubscriptions(subs, f.CanSubscribe), nil
 }
 
 var msgBufPool = sync.Pool{New: func() any {
@@ -164,7 +202,8 @@ func BuildGlobalGossipParams(cfg *rollup.Config) pubsub.GossipSubParams {
 
 // NewGossipSub configures a new pubsub instance with the specified parameters.
 // PubSub uses a GossipSubRouter as it's router under the hood.
-func NewGossipSub(p2pCtx context.Context, h host.Host, cfg *rollup.Config, gossipConf GossipSetupConfigurables, scorer Scorer, m GossipMetricer, log log.Logger) (*pubsub.PubSub, error) {
+// Subscriptions are limited to the topics allowed by filter, see BuildSubscriptionFilter.
+func NewGossipSub(p2pCtx context.Context, h host.Host, cfg *rollup.Config, filter pubsub.SubscriptionFilter, gossipConf GossipSetupConfigurables, scorer Scorer, m GossipMetricer, log log.Logger) (*pubsub.PubSub, error) {
 	denyList, err := pubsub.NewTimeCachedBlacklist(30 * time.Second)
 	if err != nil {
 		return nil, err
@@ -174,7 +213,7 @@ func NewGossipSub(p2pCtx context.Context, h host.Host, cfg *rollup.Config, gossi
 		pubsub.WithMessageIdFn(BuildMsgIdFn(cfg)),
 		pubsub.WithNoAuthor(),
 		pubsub.WithMessageSignaturePolicy(pubsub.StrictNoSign),
-		pubsub.WithSubscriptionFilter(BuildSubscriptionFilter(cfg)),
+		pubsub.WithSubscriptionFilter(filter),
 		pubsub.WithValidateQueueSize(maxValidateQueue),
 		pubsub.WithPeerOutboundQueueSize(maxOutboundQueue),
 		pubsub.WithValidateThrottle(globalValidateThrottle),
@@ -299,18 +338,9 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 		var envelope eth.ExecutionPayloadEnvelope
 
 		// [REJECT] if the block encoding is not valid
-		if blockVersion == eth.BlockV3 {
-			if err := envelope.UnmarshalSSZ(uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
-				log.Warn("invalid envelope payload", "err", err, "peer", id)
-				return pubsub.ValidationReject
-			}
-		} else {
-			var payload eth.ExecutionPayload
-			if err := payload.UnmarshalSSZ(blockVersion, uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
-				log.Warn("invalid execution payload", "err", err, "peer", id)
-				return pubsub.ValidationReject
-			}
-			envelope = eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload}
+		if err := envelope.UnmarshalVersionedSSZ(blockVersion, uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
+			log.Warn("invalid execution payload envelope", "err", err, "peer", id, "version", blockVersion)
+			return pubsub.ValidationReject
 		}
 
 		payload := envelope.ExecutionPayload
@@ -331,8 +361,8 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 		}
 
 		// [REJECT] if the `block_hash` in the `payload` is not valid
-		if actual, ok := envelope.CheckBlockHash(); !ok {
-			log.Warn("payload has bad block hash", "bad_hash", payload.BlockHash.String(), "actual", actual.String())
+		if err := envelope.VerifyBlockHash(); err != nil {
+			log.Warn("payload has bad block hash", "bad_hash", payload.BlockHash.String(), "err", err)
 			return pubsub.ValidationReject
 		}
 
@@ -443,6 +473,7 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 
 type GossipIn interface {
 	OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error
+	OnSafeHeadAttestation(ctx context.Context, from peer.ID, msg *SafeHeadAttestation) error
 }
 
 type GossipTopicInfo interface {
@@ -450,11 +481,15 @@ type GossipTopicInfo interface {
 	BlocksTopicV1Peers() []peer.ID
 	BlocksTopicV2Peers() []peer.ID
 	BlocksTopicV3Peers() []peer.ID
+	SafeHeadsTopicPeers() []peer.ID
 }
 
 type GossipOut interface {
 	GossipTopicInfo
 	PublishL2Payload(ctx context.Context, msg *eth.ExecutionPayloadEnvelope, signer Signer) error
+	// PublishSafeHeadAttestation publishes a signed safe head attestation.
+	// This returns an error if the safe head attestations topic is disabled.
+	PublishSafeHeadAttestation(ctx context.Context, msg *SafeHeadAttestation, signer Signer) error
 	Close() error
 }
 
@@ -485,8 +520,13 @@ type publisher struct {
 	blocksV1 *blockTopic
 	blocksV2 *blockTopic
 	blocksV3 *blockTopic
+	// safeHeads is the optional safe head attestations topic, nil if disabled.
+	safeHeads *blockTopic
 
 	runCfg GossipRuntimeConfig
+
+	// metrics is optional, nil if disabled.
+	metrics CompressionMetrics
 }
 
 var _ GossipOut = (*publisher)(nil)
@@ -532,14 +572,8 @@ func (p *publisher) PublishL2Payload(ctx context.Context, envelope *eth.Executio
 
 	buf.Write(make([]byte, 65))
 
-	if envelope.ParentBeaconBlockRoot != nil {
-		if _, err := envelope.MarshalSSZ(buf); err != nil {
-			return fmt.Errorf("failed to encoded execution payload envelope to publish: %w", err)
-		}
-	} else {
-		if _, err := envelope.ExecutionPayload.MarshalSSZ(buf); err != nil {
-			return fmt.Errorf("failed to encoded execution payload to publish: %w", err)
-		}
+	if _, err := envelope.MarshalVersionedSSZ(envelope.BlockVersion(), buf); err != nil {
+		return fmt.Errorf("failed to encoded execution payload envelope to publish: %w", err)
 	}
 
 	data := buf.Bytes()
@@ -553,6 +587,9 @@ func (p *publisher) PublishL2Payload(ctx context.Context, envelope *eth.Executio
 	// compress the full message
 	// This also copies the data, freeing up the original buffer to go back into the pool
 	out := snappy.Encode(nil, data)
+	if p.metrics != nil {
+		p.metrics.RecordP2PCompression(compressionProtocolGossipBlocks, string(CodecSnappy), true, len(out), len(data))
+	}
 
 	if p.cfg.IsEcotone(uint64(envelope.ExecutionPayload.Timestamp)) {
 		return p.blocksV3.topic.Publish(ctx, out)
@@ -567,14 +604,20 @@ func (p *publisher) Close() error {
 	p.p2pCancel()
 	e1 := p.blocksV1.Close()
 	e2 := p.blocksV2.Close()
-	return errors.Join(e1, e2)
+	var e3 error
+	if p.safeHeads != nil {
+		e3 = p.safeHeads.Close()
+	}
+	return errors.Join(e1, e2, e3)
 }
 
-func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
+// JoinGossip joins the blocks gossip topics, and the safe head attestations topic if attCfg is not nil.
+// The compression of block messages is recorded with m, if not nil.
+func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, attCfg *SafeHeadAttestationConfig, gossipIn GossipIn, m CompressionMetrics) (GossipOut, error) {
 	p2pCtx, p2pCancel := context.WithCancel(context.Background())
 
 	v1Logger := log.New("topic", "blocksV1")
-	blocksV1Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, BuildBlocksValidator(v1Logger, cfg, runCfg, eth.BlockV1)))
+	blocksV1Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, recordGossipCompression(m, BuildBlocksValidator(v1Logger, cfg, runCfg, eth.BlockV1))))
 	blocksV1, err := newBlockTopic(p2pCtx, blocksTopicV1(cfg), ps, v1Logger, gossipIn, blocksV1Validator)
 	if err != nil {
 		p2pCancel()
@@ -582,7 +625,7 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 	}
 
 	v2Logger := log.New("topic", "blocksV2")
-	blocksV2Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, BuildBlocksValidator(v2Logger, cfg, runCfg, eth.BlockV2)))
+	blocksV2Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, recordGossipCompression(m, BuildBlocksValidator(v2Logger, cfg, runCfg, eth.BlockV2))))
 	blocksV2, err := newBlockTopic(p2pCtx, blocksTopicV2(cfg), ps, v2Logger, gossipIn, blocksV2Validator)
 	if err != nil {
 		p2pCancel()
@@ -590,13 +633,24 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 	}
 
 	v3Logger := log.New("topic", "blocksV3")
-	blocksV3Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv3", v3Logger, BuildBlocksValidator(v3Logger, cfg, runCfg, eth.BlockV3)))
+	blocksV3Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv3", v3Logger, recordGossipCompression(m, BuildBlocksValidator(v3Logger, cfg, runCfg, eth.BlockV3))))
 	blocksV3, err := newBlockTopic(p2pCtx, blocksTopicV3(cfg), ps, v3Logger, gossipIn, blocksV3Validator)
 	if err != nil {
 		p2pCancel()
 		return nil, fmt.Errorf("failed to setup blocks v3 p2p: %w", err)
 	}
 
+	var safeHeads *blockTopic
+	if attCfg != nil {
+		safeHeadsLogger := log.New("topic", "safeHeads")
+		safeHeadsValidator := guardGossipValidator(log, logValidationResult(self, "validated safe head attestation", safeHeadsLogger, BuildSafeHeadAttestationValidator(safeHeadsLogger, cfg, runCfg, attCfg)))
+		safeHeads, err = newTopic(p2pCtx, safeHeadsTopicV1(cfg), ps, safeHeadsLogger, SafeHeadAttestationsHandler(gossipIn.OnSafeHeadAttestation), safeHeadsValidator)
+		if err != nil {
+			p2pCancel()
+			return nil, fmt.Errorf("failed to setup safe head attestations p2p: %w", err)
+		}
+	}
+
 	return &publisher{
 		log:       log,
 		cfg:       cfg,
@@ -604,11 +658,17 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 		blocksV1:  blocksV1,
 		blocksV2:  blocksV2,
 		blocksV3:  blocksV3,
+		safeHeads: safeHeads,
 		runCfg:    runCfg,
+		metrics:   m,
 	}, nil
 }
 
 func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, gossipIn GossipIn, validator pubsub.ValidatorEx) (*blockTopic, error) {
+	return newTopic(ctx, topicId, ps, log, BlocksHandler(gossipIn.OnUnsafeL2Payload), validator)
+}
+
+func newTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, handler MessageHandler, validator pubsub.ValidatorEx) (*blockTopic, error) {
 	err := ps.RegisterTopicValidator(topicId,
 		validator,
 		pubsub.WithValidatorTimeout(3*time.Second),
@@ -636,7 +696,7 @@ func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log l
 		return nil, fmt.Errorf("failed to subscribe to blocks gossip topic: %w", err)
 	}
 
-	subscriber := MakeSubscriber(log, BlocksHandler(gossipIn.OnUnsafeL2Payload))
+	subscriber := MakeSubscriber(log, handler)
 	go subscriber(ctx, subscription)
 
 	return &blockTopic{
@@ -56,6 +56,31 @@ func TestCombinePeers(t *testing.T) {
 	require.Equal(t, []peer.ID{"foo", "bar", "baz"}, res)
 }
 
+func TestChainsSubscriptionFilter(t *testing.T) {
+	primary := &rollup.Config{L2ChainID: big.NewInt(901)}
+	additional := &rollup.Config{L2ChainID: big.NewInt(902)}
+	f := newChainsSubscriptionFilter(primary)
+	require.True(t, f.CanSubscribe(blocksTopicV3(primary)))
+	require.False(t, f.CanSubscribe(blocksTopicV3(additional)))
+
+	subscribe := true
+	subs := []*pubsub_pb.RPC_SubOpts{
+		{Subscribe: &subscribe, Topicid: &[]string{blocksTopicV1(primary)}[0]},
+		{Subscribe: &subscribe, Topicid: &[]string{blocksTopicV1(additional)}[0]},
+	}
+	filtered, err := f.FilterIncomingSubscriptions("alice", subs)
+	require.NoError(t, err)
+	require.ElementsMatch(t, subs[:1], filtered)
+
+	f.addChain(additional)
+	for _, topic := range chainTopics(additional) {
+		require.True(t, f.CanSubscribe(topic))
+	}
+	filtered, err = f.FilterIncomingSubscriptions("alice", subs)
+	require.NoError(t, err)
+	require.ElementsMatch(t, subs, filtered)
+}
+
 func TestVerifyBlockSignature(t *testing.T) {
 	logger := testlog.Logger(t, log.LevelCrit)
 	cfg := &rollup.Config{
@@ -207,4 +232,12 @@ func TestBlockValidator(t *testing.T) {
 			require.Equal(t, res, test.result)
 		})
 	}
+	t.Run("ExecutionPayloadEnvelope_V3RejectBadBlockHash", func(t *testing.T) {
+		envelope := createEnvelope(&beaconHash, types.Withdrawals{}, &zero, &zero)
+		envelope.ExecutionPayload.BlockHash = common.Hash{0x01}
+		data, err := createSignedP2Payload(envelope, signer, cfg.L2ChainID)
+		require.NoError(t, err)
+		message := &pubsub.Message{Message: &pubsub_pb.Message{Data: data}}
+		require.Equal(t, pubsub.ValidationReject, v3Validator(context.TODO(), peerID, message))
+	})
 }
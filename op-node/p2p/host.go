@@ -38,6 +38,12 @@ import (
 
 const (
 	staticPeerTag = "static"
+
+	// staticPeersInterval is the interval of reconnecting to disconnected static peers.
+	staticPeersInterval = time.Minute
+	// staticMeshInterval is the interval of reconnecting to disconnected static peers of a static peer mesh,
+	// which is shorter, since the mesh is the only source of peers.
+	staticMeshInterval = time.Second * 10
 )
 
 type HostNewStream interface {
@@ -50,6 +56,9 @@ type ExtraHostFeatures interface {
 	ConnectionManager() connmgr.ConnManager
 	IsStatic(peerID peer.ID) bool
 	SyncOnlyReqToStatic() bool
+	// StaticMesh returns the number of connected static peers and the total number of static peers,
+	// and whether the host is restricted to a static peer mesh.
+	StaticMesh() (connected int, total int, enabled bool)
 }
 
 type extraHost struct {
@@ -60,6 +69,8 @@ type extraHost struct {
 
 	staticPeers   []*peer.AddrInfo
 	staticPeerIDs map[peer.ID]struct{}
+	staticMesh    bool
+	metrics       HostMetrics
 
 	pinging *PingService
 
@@ -85,6 +96,20 @@ func (e *extraHost) SyncOnlyReqToStatic() bool {
 	return e.syncOnlyReqToStatic
 }
 
+func (e *extraHost) StaticMesh() (connected int, total int, enabled bool) {
+	return e.connectedStaticPeers(), len(e.staticPeers), e.staticMesh
+}
+
+func (e *extraHost) connectedStaticPeers() int {
+	connected := 0
+	for _, addr := range e.staticPeers {
+		if e.Network().Connectedness(addr.ID) == network.Connected {
+			connected++
+		}
+	}
+	return connected
+}
+
 func (e *extraHost) Close() error {
 	close(e.quitC)
 	if e.pinging != nil {
@@ -119,7 +144,11 @@ func (e *extraHost) dialStaticPeer(ctx context.Context, addr *peer.AddrInfo) err
 }
 
 func (e *extraHost) monitorStaticPeers() {
-	tick := time.NewTicker(time.Minute)
+	interval := staticPeersInterval
+	if e.staticMesh {
+		interval = staticMeshInterval
+	}
+	tick := time.NewTicker(interval)
 	defer tick.Stop()
 
 	for {
@@ -149,12 +178,28 @@ func (e *extraHost) monitorStaticPeers() {
 
 			wg.Wait()
 			cancel()
+			if e.staticMesh {
+				e.checkStaticMesh()
+			}
 		case <-e.quitC:
 			return
 		}
 	}
 }
 
+// checkStaticMesh records the number of connected peers of the static peer mesh, and alerts if the mesh is degraded.
+func (e *extraHost) checkStaticMesh() {
+	connected := e.connectedStaticPeers()
+	if e.metrics != nil {
+		e.metrics.RecordStaticMeshPeers(connected, len(e.staticPeers))
+	}
+	if connected == 0 {
+		e.log.Error("static peer mesh is down, not connected to any static peer", "total", len(e.staticPeers))
+	} else if connected < len(e.staticPeers) {
+		e.log.Warn("static peer mesh is degraded", "connected", connected, "total", len(e.staticPeers))
+	}
+}
+
 var _ ExtraHostFeatures = (*extraHost)(nil)
 
 func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics HostMetrics) (host.Host, error) {
@@ -199,6 +244,25 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		return nil, fmt.Errorf("failed to open connection gater: %w", err)
 	}
 	connGtr = gating.AddBanExpiry(connGtr, ps, log, clock.SystemClock, metrics)
+
+	staticPeers := make([]*peer.AddrInfo, 0, len(conf.StaticPeers))
+	staticPeerIDs := make(map[peer.ID]struct{})
+	for _, peerAddr := range conf.StaticPeers {
+		addr, err := peer.AddrInfoFromP2pAddr(peerAddr)
+		if err != nil {
+			return nil, fmt.Errorf("bad peer address: %w", err)
+		}
+		if addr.ID == pid {
+			log.Info("Static-peer list contains address of local peer, ignoring the address.", "peer_id", addr.ID, "addrs", addr.Addrs)
+			continue
+		}
+		staticPeers = append(staticPeers, addr)
+		staticPeerIDs[addr.ID] = struct{}{}
+	}
+	if conf.StaticMesh {
+		// The peer IDs of the static peers are pinned, and authenticated by the transport security handshake.
+		connGtr = gating.AddAllowlist(connGtr, log, staticPeerIDs)
+	}
 	connGtr = gating.AddMetering(connGtr, metrics)
 
 	connMngr, err := DefaultConnManager(conf)
@@ -254,27 +318,14 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		return nil, err
 	}
 
-	staticPeers := make([]*peer.AddrInfo, 0, len(conf.StaticPeers))
-	staticPeerIDs := make(map[peer.ID]struct{})
-	for _, peerAddr := range conf.StaticPeers {
-		addr, err := peer.AddrInfoFromP2pAddr(peerAddr)
-		if err != nil {
-			return nil, fmt.Errorf("bad peer address: %w", err)
-		}
-		if addr.ID == h.ID() {
-			log.Info("Static-peer list contains address of local peer, ignoring the address.", "peer_id", addr.ID, "addrs", addr.Addrs)
-			continue
-		}
-		staticPeers = append(staticPeers, addr)
-		staticPeerIDs[addr.ID] = struct{}{}
-	}
-
 	out := &extraHost{
 		Host:                h,
 		connMgr:             connMngr,
 		log:                 log,
 		staticPeers:         staticPeers,
 		staticPeerIDs:       staticPeerIDs,
+		staticMesh:          conf.StaticMesh,
+		metrics:             metrics,
 		quitC:               make(chan struct{}),
 		syncOnlyReqToStatic: conf.SyncOnlyReqToStatic,
 	}
@@ -72,8 +72,58 @@ func TestP2PSimple(t *testing.T) {
 	require.Equal(t, hostB.Network().Connectedness(hostA.ID()), network.Connected)
 }
 
+func TestStaticMesh(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	newConf := func() *Config {
+		conf := TestingConfig(t)
+		conf.NoTransportSecurity = false
+		conf.HostSecurity = []libp2p.Option{NoiseC()}
+		return conf
+	}
+
+	hostB, err := newConf().Host(logger.New("host", "B"), nil, metrics.NoopMetrics)
+	require.NoError(t, err, "failed to launch host B")
+	defer hostB.Close()
+	hostC, err := newConf().Host(logger.New("host", "C"), nil, metrics.NoopMetrics)
+	require.NoError(t, err, "failed to launch host C")
+	defer hostC.Close()
+
+	confA := newConf()
+	confA.StaticMesh = true
+	require.ErrorContains(t, confA.Check(), "requires static peers")
+	confA.StaticPeers, err = peer.AddrInfoToP2pAddrs(&peer.AddrInfo{ID: hostB.ID(), Addrs: hostB.Addrs()})
+	require.NoError(t, err)
+	hostA, err := confA.Host(logger.New("host", "A"), nil, metrics.NoopMetrics)
+	require.NoError(t, err, "failed to launch host A")
+	defer hostA.Close()
+	extraA := hostA.(ExtraHostFeatures)
+
+	// host A dials its static peer B
+	require.Eventually(t, func() bool {
+		connected, total, enabled := extraA.StaticMesh()
+		return enabled && connected == 1 && total == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// host C is not part of the mesh: A rejects it, outbound and inbound.
+	// The inbound connection is rejected by A after the security handshake, which C may complete first.
+	err = hostA.Connect(context.Background(), peer.AddrInfo{ID: hostC.ID(), Addrs: hostC.Addrs()})
+	require.Error(t, err)
+	_ = hostC.Connect(context.Background(), peer.AddrInfo{ID: hostA.ID(), Addrs: hostA.Addrs()})
+	require.Eventually(t, func() bool {
+		return hostC.Network().Connectedness(hostA.ID()) != network.Connected
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, []peer.ID{hostB.ID()}, hostA.Network().Peers())
+
+	// the mesh is degraded without B
+	require.NoError(t, hostA.Network().ClosePeer(hostB.ID()))
+	connected, total, _ := extraA.StaticMesh()
+	require.Equal(t, 0, connected)
+	require.Equal(t, 1, total)
+}
+
 type mockGossipIn struct {
-	OnUnsafeL2PayloadFn func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error
+	OnUnsafeL2PayloadFn     func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error
+	OnSafeHeadAttestationFn func(ctx context.Context, from peer.ID, msg *SafeHeadAttestation) error
 }
 
 func (m *mockGossipIn) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error {
@@ -83,6 +133,13 @@ func (m *mockGossipIn) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg
 	return nil
 }
 
+func (m *mockGossipIn) OnSafeHeadAttestation(ctx context.Context, from peer.ID, msg *SafeHeadAttestation) error {
+	if m.OnSafeHeadAttestationFn != nil {
+		return m.OnSafeHeadAttestationFn(ctx, from, msg)
+	}
+	return nil
+}
+
 // Full setup, using negotiated transport security and muxes
 func TestP2PFull(t *testing.T) {
 	pA, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
@@ -131,7 +188,7 @@ func TestP2PFull(t *testing.T) {
 			conns <- conn
 		}})
 
-	backend := NewP2PAPIBackend(nodeA, logA, nil)
+	backend := NewP2PAPIBackend(nodeA, logA)
 	srv := rpc.NewServer()
 	require.NoError(t, srv.RegisterName("opp2p", backend))
 	client := rpc.DialInProc(srv)
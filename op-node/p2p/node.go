@@ -46,6 +46,7 @@ type NodeP2P struct {
 	dv5Udp   *discover.UDPv5  // p2p discovery service
 	gs       *pubsub.PubSub   // p2p gossip router
 	gsOut    GossipOut        // p2p gossip application interface for publishing
+	topics   *chainsSubscriptionFilter
 	syncCl   *SyncClient
 	syncSrv  *ReqRespServer
 }
@@ -107,6 +108,7 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		// Activate the P2P req-resp sync if enabled by feature-flag.
 		if setup.ReqRespSyncEnabled() && !elSyncEnabled {
 			n.syncCl = NewSyncClient(log, rollupCfg, n.host, gossipIn.OnUnsafeL2Payload, metrics, n.appScorer)
+			n.syncCl.setCodecs(setup.SyncCodecs())
 			n.host.Network().Notify(&network.NotifyBundle{
 				ConnectedF: func(nw network.Network, conn network.Conn) {
 					n.syncCl.AddPeer(conn.RemotePeer())
@@ -127,18 +129,21 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 				n.syncSrv = NewReqRespServer(rollupCfg, l2Chain, metrics)
 				// register the sync protocol with libp2p host
 				payloadByNumber := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_number"), n.syncSrv.HandleSyncRequest)
-				n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
+				for _, codec := range syncCodecs(setup.SyncCodecs()) {
+					n.host.SetStreamHandler(PayloadByNumberCodecProtocolID(rollupCfg.L2ChainID, codec), payloadByNumber)
+				}
 			}
 		}
 		n.scorer = NewScorer(rollupCfg, eps, metrics, n.appScorer, log)
 		// notify of any new connections/streams/etc.
 		n.host.Network().Notify(NewNetworkNotifier(log, metrics))
 		// note: the IDDelta functionality was removed from libP2P, and no longer needs to be explicitly disabled.
-		n.gs, err = NewGossipSub(resourcesCtx, n.host, rollupCfg, setup, n.scorer, metrics, log)
+		n.topics = newChainsSubscriptionFilter(rollupCfg)
+		n.gs, err = NewGossipSub(resourcesCtx, n.host, rollupCfg, n.topics, setup, n.scorer, metrics, log)
 		if err != nil {
 			return fmt.Errorf("failed to start gossipsub router: %w", err)
 		}
-		n.gsOut, err = JoinGossip(n.host.ID(), n.gs, log, rollupCfg, runCfg, gossipIn)
+		n.gsOut, err = JoinGossip(n.host.ID(), n.gs, log, rollupCfg, runCfg, setup.SafeHeadAttestationsConfig(), gossipIn, metrics)
 		if err != nil {
 			return fmt.Errorf("failed to join blocks gossip topic: %w", err)
 		}
@@ -180,6 +185,18 @@ func (n *NodeP2P) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef)
 	return err
 }
 
+// JoinChainGossip joins the blocks gossip topics of an additional chain, to host multiple chains on the p2p host.
+// Blocks of the chain are passed to gossipIn, and the caller is responsible for closing the returned GossipOut.
+// Discovery, req-resp sync, safe head attestations and peer scores only apply to the primary chain of the node,
+// so the peers of the additional chain must be found through the primary chain or be configured as static peers.
+func (n *NodeP2P) JoinChainGossip(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
+	if n.gs == nil {
+		return nil, errors.New("gossip is not enabled")
+	}
+	n.topics.addChain(cfg)
+	return JoinGossip(n.host.ID(), n.gs, log, cfg, runCfg, nil, gossipIn, nil)
+}
+
 func (n *NodeP2P) Host() host.Host {
 	return n.host
 }
@@ -220,6 +237,15 @@ func (n *NodeP2P) IsStatic(id peer.ID) bool {
 	return n.connMgr != nil && n.connMgr.IsProtected(id, staticPeerTag)
 }
 
+// StaticMesh returns the number of connected static peers and the total number of static peers,
+// and whether the node is restricted to a static peer mesh.
+func (n *NodeP2P) StaticMesh() (connected int, total int, enabled bool) {
+	if extra, ok := n.host.(ExtraHostFeatures); ok {
+		return extra.StaticMesh()
+	}
+	return 0, 0, false
+}
+
 func (n *NodeP2P) BanPeer(id peer.ID, expiration time.Time) error {
 	if err := n.store.SetPeerBanExpiration(id, expiration); err != nil {
 		return fmt.Errorf("failed to set peer ban expiry: %w", err)
@@ -92,3 +92,11 @@ func (p *Prepared) Disabled() bool {
 func (p *Prepared) ReqRespSyncEnabled() bool {
 	return p.EnableReqRespSync
 }
+
+func (p *Prepared) SafeHeadAttestationsConfig() *SafeHeadAttestationConfig {
+	return nil
+}
+
+func (p *Prepared) SyncCodecs() []Codec {
+	return nil
+}
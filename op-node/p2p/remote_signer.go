@@ -0,0 +1,103 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+)
+
+// RemoteSigningClient signs gossip messages with a key that is not held by the op-node,
+// e.g. the op-signer service, or a cloud KMS.
+type RemoteSigningClient interface {
+	SignBlockPayload(ctx context.Context, args *opsigner.BlockPayloadArgs) ([65]byte, error)
+}
+
+// RemoteSigner is a Signer backed by a RemoteSigningClient.
+//
+// The signing key can be rotated without restarting the op-node:
+// if no address is configured, the remote signer signs with its current key,
+// and the unsafe block signer of the SystemConfig is updated to the new key on L1, which verifiers pick up at runtime.
+// Every signature is verified, and a change of the signing key is logged.
+type RemoteSigner struct {
+	log    log.Logger
+	client RemoteSigningClient
+	// address is the address to sign for, nil to sign with the current key of the remote signer.
+	address *common.Address
+
+	mu         sync.Mutex
+	lastSigner common.Address
+}
+
+var _ Signer = (*RemoteSigner)(nil)
+
+func NewRemoteSigner(log log.Logger, client RemoteSigningClient, address *common.Address) *RemoteSigner {
+	return &RemoteSigner{log: log, client: client, address: address}
+}
+
+func (s *RemoteSigner) Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (*[65]byte, error) {
+	args := opsigner.NewBlockPayloadArgs(domain, chainID, encodedMsg, s.address)
+	signingHash, err := args.ToSigningHash()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.client.SignBlockPayload(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign: %w", err)
+	}
+	pub, err := crypto.SigToPub(signingHash[:], sig[:])
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid signature: %w", err)
+	}
+	signer := crypto.PubkeyToAddress(*pub)
+	if s.address != nil && signer != *s.address {
+		return nil, fmt.Errorf("remote signer signed with %s, expected %s", signer, *s.address)
+	}
+	s.mu.Lock()
+	if signer != s.lastSigner {
+		if s.lastSigner == (common.Address{}) {
+			s.log.Info("Signing gossip messages with remote signer", "address", signer)
+		} else {
+			s.log.Warn("Remote signer key changed", "previous", s.lastSigner, "address", signer)
+		}
+		s.lastSigner = signer
+	}
+	s.mu.Unlock()
+	return &sig, nil
+}
+
+func (s *RemoteSigner) Close() error {
+	return nil
+}
+
+// RemoteSignerSetup sets up a RemoteSigner with the op-signer service or the cloud KMS of the signer config.
+type RemoteSignerSetup struct {
+	Log    log.Logger
+	Config opsigner.CLIConfig
+}
+
+func (r *RemoteSignerSetup) SetupSigner(ctx context.Context) (Signer, error) {
+	var address *common.Address
+	if r.Config.Address != "" {
+		addr := common.HexToAddress(r.Config.Address)
+		address = &addr
+	}
+	if r.Config.KMSEnabled() {
+		kmsSigner, err := opsigner.NewKMSSignerFromConfig(ctx, r.Log, r.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create KMS signer: %w", err)
+		}
+		return NewRemoteSigner(r.Log, kmsSigner, address), nil
+	}
+	client, err := opsigner.NewSignerClientFromConfig(r.Log, r.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create remote signer client: %w", err)
+	}
+	return NewRemoteSigner(r.Log, client, address), nil
+}
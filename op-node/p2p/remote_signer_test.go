@@ -0,0 +1,144 @@
+package p2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// testSigningClient signs with a local key, which can be swapped to simulate a key rotation.
+type testSigningClient struct {
+	key  *ecdsa.PrivateKey
+	args *opsigner.BlockPayloadArgs
+}
+
+func (c *testSigningClient) SignBlockPayload(ctx context.Context, args *opsigner.BlockPayloadArgs) ([65]byte, error) {
+	c.args = args
+	if c.key == nil {
+		return [65]byte{}, errors.New("no key")
+	}
+	hash, err := args.ToSigningHash()
+	if err != nil {
+		return [65]byte{}, err
+	}
+	sig, err := crypto.Sign(hash[:], c.key)
+	if err != nil {
+		return [65]byte{}, err
+	}
+	return [65]byte(sig), nil
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func TestBlockPayloadArgsSigningHash(t *testing.T) {
+	chainID := big.NewInt(100)
+	payload := []byte("arbitraryData")
+	expected, err := SigningHash(SigningDomainBlocksV1, chainID, payload)
+	require.NoError(t, err)
+	actual, err := opsigner.NewBlockPayloadArgs(SigningDomainBlocksV1, chainID, payload, nil).ToSigningHash()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual, "remote signer must sign the same hash as the local signer")
+}
+
+func TestRemoteSigner(t *testing.T) {
+	ctx := context.Background()
+	chainID := big.NewInt(100)
+	payload := []byte("arbitraryData")
+	hash, err := SigningHash(SigningDomainBlocksV1, chainID, payload)
+	require.NoError(t, err)
+	signerOf := func(sig *[65]byte) common.Address {
+		pub, err := crypto.SigToPub(hash[:], sig[:])
+		require.NoError(t, err)
+		return crypto.PubkeyToAddress(*pub)
+	}
+
+	t.Run("KeyRotation", func(t *testing.T) {
+		client := &testSigningClient{key: newTestKey(t)}
+		s := NewRemoteSigner(testlog.Logger(t, log.LevelInfo), client, nil)
+		sig, err := s.Sign(ctx, SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(client.key.PublicKey), signerOf(sig))
+		require.Nil(t, client.args.SenderAddress)
+
+		client.key = newTestKey(t)
+		sig, err = s.Sign(ctx, SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(client.key.PublicKey), signerOf(sig), "should sign with the rotated key")
+	})
+
+	t.Run("Address", func(t *testing.T) {
+		client := &testSigningClient{key: newTestKey(t)}
+		addr := crypto.PubkeyToAddress(client.key.PublicKey)
+		s := NewRemoteSigner(testlog.Logger(t, log.LevelInfo), client, &addr)
+		sig, err := s.Sign(ctx, SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+		require.Equal(t, addr, signerOf(sig))
+		require.Equal(t, &addr, client.args.SenderAddress)
+
+		client.key = newTestKey(t)
+		_, err = s.Sign(ctx, SigningDomainBlocksV1, chainID, payload)
+		require.ErrorContains(t, err, "expected "+addr.String())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		s := NewRemoteSigner(testlog.Logger(t, log.LevelInfo), &testSigningClient{}, nil)
+		_, err := s.Sign(ctx, SigningDomainBlocksV1, chainID, payload)
+		require.ErrorContains(t, err, "no key")
+	})
+}
+
+type testOpsignerAPI struct {
+	client *testSigningClient
+}
+
+func (a *testOpsignerAPI) SignBlockPayload(ctx context.Context, args opsigner.BlockPayloadArgs) (hexutil.Bytes, error) {
+	sig, err := a.client.SignBlockPayload(ctx, &args)
+	return sig[:], err
+}
+
+type testHealthAPI struct{}
+
+func (testHealthAPI) Status() string {
+	return "ok"
+}
+
+func TestRemoteSignerSetup(t *testing.T) {
+	client := &testSigningClient{key: newTestKey(t)}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("health", testHealthAPI{}))
+	require.NoError(t, server.RegisterName("opsigner", &testOpsignerAPI{client: client}))
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	cfg := opsigner.CLIConfig{Endpoint: srv.URL}
+	setup := &RemoteSignerSetup{Log: testlog.Logger(t, log.LevelInfo), Config: cfg}
+	s, err := setup.SetupSigner(context.Background())
+	require.NoError(t, err)
+	defer s.Close()
+
+	payload := []byte("arbitraryData")
+	sig, err := s.Sign(context.Background(), SigningDomainBlocksV1, big.NewInt(100), payload)
+	require.NoError(t, err)
+	hash, err := SigningHash(SigningDomainBlocksV1, big.NewInt(100), payload)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(hash[:], sig[:])
+	require.NoError(t, err)
+	require.Equal(t, client.key.PublicKey, *pub)
+}
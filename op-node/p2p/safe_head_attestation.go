@@ -0,0 +1,222 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SigningDomainSafeHeadAttestationsV1 separates signed safe-head attestations from signed blocks.
+var SigningDomainSafeHeadAttestationsV1 = [32]byte{31: 1}
+
+// safeHeadAttestationSize is the size of an encoded SafeHeadAttestation:
+// safe hash, number, parent hash, time, L1 origin hash, L1 origin number, sequence number,
+// derived-from hash and derived-from number.
+const safeHeadAttestationSize = 32 + 8 + 32 + 8 + 32 + 8 + 8 + 32 + 8
+
+// SafeHeadAttestation is a claim, signed by the attester, that the given L2 block is safe,
+// derived from L1 data up to and including the DerivedFrom L1 block.
+// Attestations are informational only: they do not affect the consensus of the receiving node.
+type SafeHeadAttestation struct {
+	Safe        eth.L2BlockRef
+	DerivedFrom eth.BlockID
+}
+
+func (a *SafeHeadAttestation) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, safeHeadAttestationSize)
+	out = append(out, a.Safe.Hash[:]...)
+	out = binary.BigEndian.AppendUint64(out, a.Safe.Number)
+	out = append(out, a.Safe.ParentHash[:]...)
+	out = binary.BigEndian.AppendUint64(out, a.Safe.Time)
+	out = append(out, a.Safe.L1Origin.Hash[:]...)
+	out = binary.BigEndian.AppendUint64(out, a.Safe.L1Origin.Number)
+	out = binary.BigEndian.AppendUint64(out, a.Safe.SequenceNumber)
+	out = append(out, a.DerivedFrom.Hash[:]...)
+	out = binary.BigEndian.AppendUint64(out, a.DerivedFrom.Number)
+	return out, nil
+}
+
+func (a *SafeHeadAttestation) UnmarshalBinary(data []byte) error {
+	if len(data) != safeHeadAttestationSize {
+		return fmt.Errorf("invalid safe head attestation size %d, expected %d", len(data), safeHeadAttestationSize)
+	}
+	readHash := func() (h common.Hash) {
+		copy(h[:], data[:32])
+		data = data[32:]
+		return
+	}
+	readUint64 := func() (v uint64) {
+		v = binary.BigEndian.Uint64(data[:8])
+		data = data[8:]
+		return
+	}
+	a.Safe.Hash = readHash()
+	a.Safe.Number = readUint64()
+	a.Safe.ParentHash = readHash()
+	a.Safe.Time = readUint64()
+	a.Safe.L1Origin.Hash = readHash()
+	a.Safe.L1Origin.Number = readUint64()
+	a.Safe.SequenceNumber = readUint64()
+	a.DerivedFrom.Hash = readHash()
+	a.DerivedFrom.Number = readUint64()
+	return nil
+}
+
+// SafeHeadAttestationConfig configures the optional safe-head attestations gossip topic.
+type SafeHeadAttestationConfig struct {
+	// Attester is the address that signs attestations.
+	// If zero, attestations are expected to be signed by the p2p sequencer address.
+	Attester common.Address
+}
+
+func safeHeadsTopicV1(cfg *rollup.Config) string {
+	return fmt.Sprintf("/optimism/%s/0/safe-heads", cfg.L2ChainID.String())
+}
+
+func SafeHeadAttestationSigningHash(cfg *rollup.Config, payloadBytes []byte) (common.Hash, error) {
+	return SigningHash(SigningDomainSafeHeadAttestationsV1, cfg.L2ChainID, payloadBytes)
+}
+
+func BuildSafeHeadAttestationValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, attCfg *SafeHeadAttestationConfig) pubsub.ValidatorEx {
+	// the attestation of the highest attested safe block, older attestations are not propagated.
+	var (
+		highestLock sync.Mutex
+		highest     *SafeHeadAttestation
+	)
+
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		// [REJECT] if the message is not a signature followed by an attestation
+		outLen, err := snappy.DecodedLen(message.Data)
+		if err != nil {
+			log.Warn("invalid snappy compression length data", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+		if outLen != 65+safeHeadAttestationSize {
+			log.Warn("invalid safe head attestation message size", "decoded_length", outLen, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		// [REJECT] if the compression is not valid
+		data, err := snappy.Decode(nil, message.Data)
+		if err != nil {
+			log.Warn("invalid snappy compression", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+		signatureBytes, payloadBytes := data[:65], data[65:]
+
+		// [REJECT] if the signature by the attester is not valid
+		signingHash, err := SafeHeadAttestationSigningHash(cfg, payloadBytes)
+		if err != nil {
+			log.Warn("failed to compute safe head attestation signing hash", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+		pub, err := crypto.SigToPub(signingHash[:], signatureBytes)
+		if err != nil {
+			log.Warn("invalid safe head attestation signature", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+		addr := crypto.PubkeyToAddress(*pub)
+		expected := attCfg.Attester
+		if expected == (common.Address{}) {
+			expected = runCfg.P2PSequencerAddress()
+		}
+		if expected == (common.Address{}) {
+			log.Warn("no configured safe head attester address, ignoring attestation", "peer", id, "addr", addr)
+			return pubsub.ValidationIgnore
+		} else if addr != expected {
+			log.Warn("unexpected safe head attester", "peer", id, "addr", addr, "expected", expected)
+			return pubsub.ValidationReject
+		}
+
+		var att SafeHeadAttestation
+		if err := att.UnmarshalBinary(payloadBytes); err != nil {
+			log.Warn("invalid safe head attestation", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		// [REJECT] if the attested block is more than 5 seconds into the future
+		if now := uint64(time.Now().Unix()); att.Safe.Time > now+5 {
+			log.Warn("safe head attestation is too new", "timestamp", att.Safe.Time)
+			return pubsub.ValidationReject
+		}
+
+		// [IGNORE] if the attestation does not advance the safe head, and is not a safe head reorg
+		highestLock.Lock()
+		if highest != nil && att.Safe.Number <= highest.Safe.Number && !isSafeHeadReorg(highest, &att) {
+			highestLock.Unlock()
+			log.Debug("ignoring safe head attestation that does not advance", "safe", att.Safe, "highest", highest.Safe)
+			return pubsub.ValidationIgnore
+		}
+		if highest != nil && att.Safe.Number <= highest.Safe.Number {
+			log.Info("safe head attestation reorged the attested safe head", "safe", att.Safe, "l1", att.DerivedFrom,
+				"previous", highest.Safe, "previous_l1", highest.DerivedFrom)
+		}
+		highest = &att
+		highestLock.Unlock()
+
+		message.ValidatorData = &att
+		return pubsub.ValidationAccept
+	}
+}
+
+// isSafeHeadReorg returns true if att, which does not advance the safe head of the highest attestation,
+// replaces it after a reorg of the safe chain: the attester derived a different safe head from the same or later
+// L1 data. Delayed attestations of older safe heads were derived from earlier L1 data, and are not a reorg.
+func isSafeHeadReorg(highest *SafeHeadAttestation, att *SafeHeadAttestation) bool {
+	if att.Safe.Hash == highest.Safe.Hash {
+		return false
+	}
+	return att.DerivedFrom.Number > highest.DerivedFrom.Number ||
+		(att.DerivedFrom.Number == highest.DerivedFrom.Number && att.DerivedFrom.Hash != highest.DerivedFrom.Hash)
+}
+
+func SafeHeadAttestationsHandler(onAttestation func(ctx context.Context, from peer.ID, msg *SafeHeadAttestation) error) MessageHandler {
+	return func(ctx context.Context, from peer.ID, msg any) error {
+		att, ok := msg.(*SafeHeadAttestation)
+		if !ok {
+			return fmt.Errorf("expected topic validator to parse and validate data into safe head attestation, but got %T", msg)
+		}
+		return onAttestation(ctx, from, att)
+	}
+}
+
+var errSafeHeadAttestationsDisabled = errors.New("safe head attestations gossip is disabled")
+
+func (p *publisher) PublishSafeHeadAttestation(ctx context.Context, att *SafeHeadAttestation, signer Signer) error {
+	if p.safeHeads == nil {
+		return errSafeHeadAttestationsDisabled
+	}
+	payloadData, err := att.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode safe head attestation: %w", err)
+	}
+	sig, err := signer.Sign(ctx, SigningDomainSafeHeadAttestationsV1, p.cfg.L2ChainID, payloadData)
+	if err != nil {
+		return fmt.Errorf("failed to sign safe head attestation with signer: %w", err)
+	}
+	data := make([]byte, 0, 65+len(payloadData))
+	data = append(data, sig[:]...)
+	data = append(data, payloadData...)
+	return p.safeHeads.topic.Publish(ctx, snappy.Encode(nil, data))
+}
+
+func (p *publisher) SafeHeadsTopicPeers() []peer.ID {
+	if p.safeHeads == nil {
+		return nil
+	}
+	return p.safeHeads.topic.ListPeers()
+}
@@ -0,0 +1,163 @@
+package p2p
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestSafeHeadAttestationEncoding(t *testing.T) {
+	att := &SafeHeadAttestation{
+		Safe: eth.L2BlockRef{
+			Hash:           common.Hash{0x01},
+			Number:         100,
+			ParentHash:     common.Hash{0x02},
+			Time:           1234,
+			L1Origin:       eth.BlockID{Hash: common.Hash{0x03}, Number: 50},
+			SequenceNumber: 3,
+		},
+		DerivedFrom: eth.BlockID{Hash: common.Hash{0x04}, Number: 55},
+	}
+	data, err := att.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, data, safeHeadAttestationSize)
+
+	var decoded SafeHeadAttestation
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, *att, decoded)
+
+	require.Error(t, decoded.UnmarshalBinary(data[1:]))
+}
+
+func signSafeHeadAttestation(t *testing.T, att *SafeHeadAttestation, signer Signer, l2ChainID *big.Int) []byte {
+	payload, err := att.MarshalBinary()
+	require.NoError(t, err)
+	sig, err := signer.Sign(context.Background(), SigningDomainSafeHeadAttestationsV1, l2ChainID, payload)
+	require.NoError(t, err)
+	return snappy.Encode(nil, append(sig[:], payload...))
+}
+
+func TestSafeHeadAttestationValidator(t *testing.T) {
+	cfg := &rollup.Config{
+		L2ChainID: big.NewInt(100),
+	}
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	seqAddr := crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)
+	seqSigner := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
+	peerID := peer.ID("foo")
+	now := uint64(time.Now().Unix())
+
+	attestation := func(num uint64) *SafeHeadAttestation {
+		return &SafeHeadAttestation{
+			Safe:        eth.L2BlockRef{Hash: common.Hash{byte(num)}, Number: num, Time: now},
+			DerivedFrom: eth.BlockID{Hash: common.Hash{0xaa}, Number: 10},
+		}
+	}
+	validate := func(v pubsub.ValidatorEx, data []byte) (pubsub.ValidationResult, any) {
+		msg := &pubsub.Message{Message: &pubsub_pb.Message{Data: data}}
+		return v(context.Background(), peerID, msg), msg.ValidatorData
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: seqAddr}
+		v := BuildSafeHeadAttestationValidator(testlog.Logger(t, log.LevelCrit), cfg, runCfg, &SafeHeadAttestationConfig{})
+		res, data := validate(v, signSafeHeadAttestation(t, attestation(10), seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationAccept, res)
+		require.Equal(t, attestation(10), data)
+
+		// attestations that do not advance the safe head are not propagated
+		res, _ = validate(v, signSafeHeadAttestation(t, attestation(10), seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationIgnore, res)
+		res, _ = validate(v, signSafeHeadAttestation(t, attestation(9), seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationIgnore, res)
+		res, _ = validate(v, signSafeHeadAttestation(t, attestation(11), seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationAccept, res)
+	})
+
+	t.Run("Reorg", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: seqAddr}
+		v := BuildSafeHeadAttestationValidator(testlog.Logger(t, log.LevelCrit), cfg, runCfg, &SafeHeadAttestationConfig{})
+		res, _ := validate(v, signSafeHeadAttestation(t, attestation(10), seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationAccept, res)
+
+		// a lower safe head derived from later L1 data replaces the highest attestation
+		reorged := attestation(8)
+		reorged.Safe.Hash = common.Hash{0xee}
+		reorged.DerivedFrom = eth.BlockID{Hash: common.Hash{0xbb}, Number: 11}
+		res, _ = validate(v, signSafeHeadAttestation(t, reorged, seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationAccept, res)
+
+		// as does a different safe head derived from a reorged L1 block
+		l1Reorged := attestation(8)
+		l1Reorged.DerivedFrom = eth.BlockID{Hash: common.Hash{0xcc}, Number: 11}
+		res, _ = validate(v, signSafeHeadAttestation(t, l1Reorged, seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationAccept, res)
+
+		// a delayed attestation derived from earlier L1 data is not a reorg
+		res, _ = validate(v, signSafeHeadAttestation(t, attestation(7), seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationIgnore, res)
+		res, _ = validate(v, signSafeHeadAttestation(t, attestation(9), seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationAccept, res, "attestations advance from the reorged safe head")
+	})
+
+	t.Run("DesignatedAttester", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: seqAddr}
+		attCfg := &SafeHeadAttestationConfig{Attester: crypto.PubkeyToAddress(secrets.Alice.PublicKey)}
+		v := BuildSafeHeadAttestationValidator(testlog.Logger(t, log.LevelCrit), cfg, runCfg, attCfg)
+		res, _ := validate(v, signSafeHeadAttestation(t, attestation(10), seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationReject, res)
+
+		attSigner := &PreparedSigner{Signer: NewLocalSigner(secrets.Alice)}
+		res, _ = validate(v, signSafeHeadAttestation(t, attestation(10), attSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationAccept, res)
+	})
+
+	t.Run("NoAttester", func(t *testing.T) {
+		v := BuildSafeHeadAttestationValidator(testlog.Logger(t, log.LevelCrit), cfg, &testutils.MockRuntimeConfig{}, &SafeHeadAttestationConfig{})
+		res, _ := validate(v, signSafeHeadAttestation(t, attestation(10), seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationIgnore, res)
+	})
+
+	t.Run("WrongChain", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: seqAddr}
+		v := BuildSafeHeadAttestationValidator(testlog.Logger(t, log.LevelCrit), cfg, runCfg, &SafeHeadAttestationConfig{})
+		res, _ := validate(v, signSafeHeadAttestation(t, attestation(10), seqSigner, big.NewInt(101)))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+
+	t.Run("FutureBlock", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: seqAddr}
+		v := BuildSafeHeadAttestationValidator(testlog.Logger(t, log.LevelCrit), cfg, runCfg, &SafeHeadAttestationConfig{})
+		att := attestation(10)
+		att.Safe.Time = now + 60
+		res, _ := validate(v, signSafeHeadAttestation(t, att, seqSigner, cfg.L2ChainID))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+
+	t.Run("InvalidEncoding", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: seqAddr}
+		v := BuildSafeHeadAttestationValidator(testlog.Logger(t, log.LevelCrit), cfg, runCfg, &SafeHeadAttestationConfig{})
+		res, _ := validate(v, snappy.Encode(nil, make([]byte, 65)))
+		require.Equal(t, pubsub.ValidationReject, res)
+		res, _ = validate(v, []byte("not snappy"))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 
@@ -15,6 +16,9 @@ import (
 
 var SigningDomainBlocksV1 = [32]byte{}
 
+// SigningDomainHeartbeatsV1 separates signed heartbeats from signed blocks and safe-head attestations.
+var SigningDomainHeartbeatsV1 = [32]byte{31: 2}
+
 type Signer interface {
 	Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (sig *[65]byte, err error)
 	io.Closer
@@ -39,6 +43,20 @@ func BlockSigningHash(cfg *rollup.Config, payloadBytes []byte) (common.Hash, err
 	return SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, payloadBytes)
 }
 
+// RecoverHeartbeatSigner returns the address that signed the body of a heartbeat of a node of the given chain,
+// for heartbeat servers to authenticate the node, see heartbeat.SignatureHeader.
+func RecoverHeartbeatSigner(chainID *big.Int, body []byte, sig []byte) (common.Address, error) {
+	signingHash, err := SigningHash(SigningDomainHeartbeatsV1, chainID, body)
+	if err != nil {
+		return common.Address{}, err
+	}
+	pub, err := crypto.SigToPub(signingHash[:], sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid heartbeat signature: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
 // LocalSigner is suitable for testing
 type LocalSigner struct {
 	priv   *ecdsa.PrivateKey
@@ -1,9 +1,12 @@
 package p2p
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
+
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/stretchr/testify/require"
 )
@@ -65,3 +68,23 @@ func TestSigningHash_LimitChainID(t *testing.T) {
 	_, err := SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, []byte("arbitraryData"))
 	require.ErrorContains(t, err, "chain_id is too large")
 }
+
+func TestRecoverHeartbeatSigner(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := NewLocalSigner(priv)
+	chainID := big.NewInt(100)
+	body := []byte(`{"moniker":"yeet"}`)
+
+	sig, err := signer.Sign(context.Background(), SigningDomainHeartbeatsV1, chainID, body)
+	require.NoError(t, err)
+	addr, err := RecoverHeartbeatSigner(chainID, body, sig[:])
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(priv.PublicKey), addr)
+
+	blockSig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, chainID, body)
+	require.NoError(t, err)
+	addr, err = RecoverHeartbeatSigner(chainID, body, blockSig[:])
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(priv.PublicKey), addr, "block signatures must not authenticate heartbeats")
+}
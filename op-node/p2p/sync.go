@@ -12,7 +12,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/golang/snappy"
 	"github.com/hashicorp/golang-lru/v2/simplelru"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -151,6 +150,7 @@ func (r *requestIdMap) delete(key uint64) {
 type SyncClientMetrics interface {
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
+	CompressionMetrics
 }
 
 type SyncPeerScorer interface {
@@ -231,8 +231,10 @@ type SyncClient struct {
 	metrics   SyncClientMetrics
 	appScorer SyncPeerScorer
 
-	newStreamFn     newStreamFn
-	payloadByNumber protocol.ID
+	newStreamFn newStreamFn
+	// payloadByNumber are the protocol IDs of the sync protocol by codec preference, negotiated with the peer per stream.
+	payloadByNumber []protocol.ID
+	codecs          map[protocol.ID]Codec
 
 	peersLock sync.Mutex
 	// syncing worker per peer
@@ -290,7 +292,6 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, host HostNewStream, rcv r
 		metrics:             metrics,
 		appScorer:           appScorer,
 		newStreamFn:         host.NewStream,
-		payloadByNumber:     PayloadByNumberProtocolID(cfg.L2ChainID),
 		peers:               make(map[peer.ID]context.CancelFunc),
 		quarantineByNum:     make(map[uint64]common.Hash),
 		rangeRequests:       make(chan rangeRequest), // blocking
@@ -304,6 +305,7 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, host HostNewStream, rcv r
 		resCancel:           cancel,
 		receivePayload:      rcv,
 	}
+	c.setCodecs(DefaultSyncCodecs)
 	if extra, ok := host.(ExtraHostFeatures); ok && extra.SyncOnlyReqToStatic() {
 		c.extra = extra
 		c.syncOnlyReqToStatic = true
@@ -319,6 +321,17 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, host HostNewStream, rcv r
 	return c
 }
 
+// setCodecs sets the codecs to request sync responses with, by preference. It must be called before Start.
+func (s *SyncClient) setCodecs(codecs []Codec) {
+	codecs = syncCodecs(codecs)
+	s.payloadByNumber = make([]protocol.ID, len(codecs))
+	s.codecs = make(map[protocol.ID]Codec, len(codecs))
+	for i, codec := range codecs {
+		s.payloadByNumber[i] = PayloadByNumberCodecProtocolID(s.cfg.L2ChainID, codec)
+		s.codecs[s.payloadByNumber[i]] = codec
+	}
+}
+
 func (s *SyncClient) Start() {
 	s.peersLock.Lock()
 	s.wg.Add(1)
@@ -653,7 +666,7 @@ func (r requestResultErr) ResultCode() byte {
 func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum uint64) error {
 	// open stream to peer
 	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
-	str, err := s.newStreamFn(reqCtx, id, s.payloadByNumber)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadByNumber...)
 	reqCancel()
 	if err != nil {
 		return fmt.Errorf("failed to open stream: %w", err)
@@ -687,16 +700,25 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 		return fmt.Errorf("failed to read version part of response: %w", err)
 	}
 
-	// payload is SSZ encoded with Snappy framed compression
-	r = snappy.NewReader(r)
-	r = io.LimitReader(r, maxGossipSize)
+	// payload is SSZ encoded with the negotiated compression, Snappy framed compression by default
+	codec, ok := s.codecs[str.Protocol()]
+	if !ok {
+		codec = CodecSnappy
+	}
+	compressed := &countingReader{r: r}
+	dec, err := codec.newReader(compressed)
+	if err != nil {
+		return fmt.Errorf("failed to decompress response with %s: %w", codec, err)
+	}
+	defer dec.Close()
 
 	// We cannot stream straight into the SSZ decoder, since we need the scope of the SSZ payload.
 	// The server does not prepend it, nor would we trust a claimed length anyway, so we buffer the data we get.
-	data, err := io.ReadAll(r)
+	data, err := io.ReadAll(io.LimitReader(dec, maxGossipSize))
 	if err != nil {
 		return fmt.Errorf("failed to read response: %w", err)
 	}
+	s.metrics.RecordP2PCompression(compressionProtocolPayloadByNumber, string(codec), false, compressed.n, len(data))
 
 	version := binary.LittleEndian.Uint32(versionData[:])
 	isCanyon := s.cfg.IsCanyon(s.cfg.TimestampForBlock(expectedBlockNum))
@@ -733,26 +755,23 @@ func panicGuard[T, S, U any](fn func(T, S, U) error) func(T, S, U) error {
 
 // readExecutionPayload will unmarshal the supplied data into an ExecutionPayloadEnvelope.
 func readExecutionPayload(version uint32, data []byte, isCanyon bool) (*eth.ExecutionPayloadEnvelope, error) {
+	var blockVersion eth.BlockVersion
 	switch version {
 	case 0:
-		blockVersion := eth.BlockV1
+		blockVersion = eth.BlockV1
 		if isCanyon {
 			blockVersion = eth.BlockV2
 		}
-		var res eth.ExecutionPayload
-		if err := res.UnmarshalSSZ(blockVersion, uint32(len(data)), bytes.NewReader(data)); err != nil {
-			return nil, fmt.Errorf("failed to decode response: %w", err)
-		}
-		return &eth.ExecutionPayloadEnvelope{ExecutionPayload: &res}, nil
 	case 1:
-		envelope := &eth.ExecutionPayloadEnvelope{}
-		if err := envelope.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
-			return nil, fmt.Errorf("failed to decode execution payload envelope response: %w", err)
-		}
-		return envelope, nil
+		blockVersion = eth.BlockV3
 	default:
 		return nil, fmt.Errorf("unrecognized version: %d", version)
 	}
+	envelope := &eth.ExecutionPayloadEnvelope{}
+	if err := envelope.UnmarshalVersionedSSZ(blockVersion, uint32(len(data)), bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to decode execution payload envelope response: %w", err)
+	}
+	return envelope, nil
 }
 
 func verifyBlock(envelope *eth.ExecutionPayloadEnvelope, expectedNum uint64) error {
@@ -762,9 +781,8 @@ func verifyBlock(envelope *eth.ExecutionPayloadEnvelope, expectedNum uint64) err
 	if expectedNum != uint64(payload.BlockNumber) {
 		return fmt.Errorf("received execution payload for block %d, but expected block %d", payload.BlockNumber, expectedNum)
 	}
-	actual, ok := envelope.CheckBlockHash()
-	if !ok { // payload itself contains bad block hash
-		return fmt.Errorf("received execution payload for block %d with bad block hash %s, expected %s", expectedNum, payload.BlockHash, actual)
+	if err := envelope.VerifyBlockHash(); err != nil { // payload itself contains bad block hash
+		return fmt.Errorf("received invalid execution payload for block %d: %w", expectedNum, err)
 	}
 	return nil
 }
@@ -781,6 +799,7 @@ type L2Chain interface {
 
 type ReqRespServerMetrics interface {
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	CompressionMetrics
 }
 
 type ReqRespServer struct {
@@ -794,6 +813,9 @@ type ReqRespServer struct {
 	peerStatsLock  sync.Mutex
 
 	globalRequestsRL *rate.Limiter
+
+	// codecs maps the protocol IDs of the sync protocol to the codec of the responses.
+	codecs map[protocol.ID]Codec
 }
 
 func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetrics) *ReqRespServer {
@@ -803,12 +825,18 @@ func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetri
 	peerRateLimits, _ := simplelru.NewLRU[peer.ID, *peerStat](1000, nil)
 	globalRequestsRL := rate.NewLimiter(globalServerBlocksRateLimit, globalServerBlocksBurst)
 
+	codecs := make(map[protocol.ID]Codec, len(Codecs))
+	for _, codec := range Codecs {
+		codecs[PayloadByNumberCodecProtocolID(cfg.L2ChainID, codec)] = codec
+	}
+
 	return &ReqRespServer{
 		cfg:              cfg,
 		l2:               l2,
 		metrics:          metrics,
 		peerRateLimits:   peerRateLimits,
 		globalRequestsRL: globalRequestsRL,
+		codecs:           codecs,
 	}
 }
 
@@ -914,33 +942,36 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	// We set write deadline, if available, to safely write without blocking on a throttling peer connection
 	_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
 
-	w := snappy.NewBufferedWriter(stream)
+	codec, ok := srv.codecs[stream.Protocol()]
+	if !ok {
+		codec = CodecSnappy
+	}
+	compressed := &countingWriter{w: stream}
+	w, err := codec.newWriter(compressed)
+	if err != nil {
+		return req, fmt.Errorf("failed to compress sync response with %s: %w", codec, err)
+	}
 
+	// 0 - resultCode: success = 0
+	// 1:5 - version: 0, or 1 (little endian) for envelopes with a parent beacon block root
+	var tmp [5]byte
+	blockVersion := envelope.BlockVersion()
 	if srv.cfg.IsEcotone(uint64(envelope.ExecutionPayload.Timestamp)) {
-		// 0 - resultCode: success = 0
-		// 1:5 - version: 1 (little endian)
-		tmp := [5]byte{0, 1, 0, 0, 0}
-		if _, err := stream.Write(tmp[:]); err != nil {
-			return req, fmt.Errorf("failed to write response header data: %w", err)
-		}
-		if _, err := envelope.MarshalSSZ(w); err != nil {
-			return req, fmt.Errorf("failed to write payload to sync response: %w", err)
-		}
-	} else {
-		// 0 - resultCode: success = 0
-		// 1:5 - version: 0
-		var tmp [5]byte
-		if _, err := stream.Write(tmp[:]); err != nil {
-			return req, fmt.Errorf("failed to write response header data: %w", err)
-		}
-		if _, err := envelope.ExecutionPayload.MarshalSSZ(w); err != nil {
-			return req, fmt.Errorf("failed to write payload to sync response: %w", err)
-		}
+		tmp[1] = 1
+		blockVersion = eth.BlockV3
+	}
+	if _, err := stream.Write(tmp[:]); err != nil {
+		return req, fmt.Errorf("failed to write response header data: %w", err)
+	}
+	uncompressed, err := envelope.MarshalVersionedSSZ(blockVersion, w)
+	if err != nil {
+		return req, fmt.Errorf("failed to write payload to sync response: %w", err)
 	}
 
 	if err := w.Close(); err != nil {
 		return req, fmt.Errorf("failed to finishing writing payload to sync response: %w", err)
 	}
+	srv.metrics.RecordP2PCompression(compressionProtocolPayloadByNumber, string(codec), true, compressed.n, uncompressed)
 
 	return req, nil
 }
@@ -12,9 +12,6 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
-// Max memory used for buffering unsafe payloads
-const maxUnsafePayloadsMemory = 500 * 1024 * 1024
-
 type Metrics interface {
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
 }
@@ -33,13 +30,14 @@ type CLSync struct {
 	unsafePayloads *PayloadsQueue // queue of unsafe payloads, ordered by ascending block number, may have gaps and duplicates
 }
 
-func NewCLSync(log log.Logger, cfg *rollup.Config, metrics Metrics, emitter event.Emitter) *CLSync {
+// NewCLSync creates a CLSync, which buffers up to maxMemory bytes of unsafe payloads.
+func NewCLSync(log log.Logger, cfg *rollup.Config, metrics Metrics, emitter event.Emitter, maxMemory uint64) *CLSync {
 	return &CLSync{
 		log:            log,
 		cfg:            cfg,
 		metrics:        metrics,
 		emitter:        emitter,
-		unsafePayloads: NewPayloadsQueue(log, maxUnsafePayloadsMemory, payloadMemSize),
+		unsafePayloads: NewPayloadsQueue(log, maxMemory, payloadMemSize),
 	}
 }
 
@@ -56,6 +54,13 @@ func (eq *CLSync) LowestQueuedUnsafeBlock() eth.L2BlockRef {
 	return ref
 }
 
+// QueuedUnsafePayloads returns the block IDs of the queued-up L2 unsafe payloads, ordered by ascending block number.
+func (eq *CLSync) QueuedUnsafePayloads() []eth.BlockID {
+	eq.mu.Lock()
+	defer eq.mu.Unlock()
+	return eq.unsafePayloads.IDs()
+}
+
 type ReceivedUnsafePayloadEvent struct {
 	Envelope *eth.ExecutionPayloadEnvelope
 }
@@ -127,7 +127,7 @@ func TestCLSync(t *testing.T) {
 		logger := testlog.Logger(t, log.LevelError)
 
 		emitter := &testutils.MockEmitter{}
-		cl := NewCLSync(logger, cfg, metrics, emitter)
+		cl := NewCLSync(logger, cfg, metrics, emitter, derive.DefaultMaxUnsafePayloadsMemory)
 
 		emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
 		cl.OnEvent(ReceivedUnsafePayloadEvent{Envelope: payloadA1})
@@ -148,7 +148,7 @@ func TestCLSync(t *testing.T) {
 		logger := testlog.Logger(t, log.LevelError)
 
 		emitter := &testutils.MockEmitter{}
-		cl := NewCLSync(logger, cfg, metrics, emitter)
+		cl := NewCLSync(logger, cfg, metrics, emitter, derive.DefaultMaxUnsafePayloadsMemory)
 
 		emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
 		cl.OnEvent(ReceivedUnsafePayloadEvent{Envelope: payloadA1})
@@ -170,7 +170,7 @@ func TestCLSync(t *testing.T) {
 		logger := testlog.Logger(t, log.LevelError)
 
 		emitter := &testutils.MockEmitter{}
-		cl := NewCLSync(logger, cfg, metrics, emitter)
+		cl := NewCLSync(logger, cfg, metrics, emitter, derive.DefaultMaxUnsafePayloadsMemory)
 
 		emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
 		cl.OnEvent(ReceivedUnsafePayloadEvent{Envelope: payloadA1})
@@ -190,7 +190,7 @@ func TestCLSync(t *testing.T) {
 		logger := testlog.Logger(t, log.LevelError)
 
 		emitter := &testutils.MockEmitter{}
-		cl := NewCLSync(logger, cfg, metrics, emitter)
+		cl := NewCLSync(logger, cfg, metrics, emitter, derive.DefaultMaxUnsafePayloadsMemory)
 
 		emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
 		cl.OnEvent(ReceivedUnsafePayloadEvent{Envelope: payloadA2})
@@ -210,7 +210,7 @@ func TestCLSync(t *testing.T) {
 		logger := testlog.Logger(t, log.LevelError)
 
 		emitter := &testutils.MockEmitter{}
-		cl := NewCLSync(logger, cfg, metrics, emitter)
+		cl := NewCLSync(logger, cfg, metrics, emitter, derive.DefaultMaxUnsafePayloadsMemory)
 		emitter.AssertExpectations(t) // nothing to process yet
 
 		require.Nil(t, cl.unsafePayloads.Peek(), "no payloads yet")
@@ -268,7 +268,7 @@ func TestCLSync(t *testing.T) {
 		logger := testlog.Logger(t, log.LevelError)
 
 		emitter := &testutils.MockEmitter{}
-		cl := NewCLSync(logger, cfg, metrics, emitter)
+		cl := NewCLSync(logger, cfg, metrics, emitter, derive.DefaultMaxUnsafePayloadsMemory)
 
 		emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
 		cl.OnEvent(ReceivedUnsafePayloadEvent{Envelope: payloadA1})
@@ -312,7 +312,7 @@ func TestCLSync(t *testing.T) {
 		logger := testlog.Logger(t, log.LevelError)
 
 		emitter := &testutils.MockEmitter{}
-		cl := NewCLSync(logger, cfg, metrics, emitter)
+		cl := NewCLSync(logger, cfg, metrics, emitter, derive.DefaultMaxUnsafePayloadsMemory)
 
 		emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
 		cl.OnEvent(ReceivedUnsafePayloadEvent{Envelope: payloadA1})
@@ -352,7 +352,7 @@ func TestCLSync(t *testing.T) {
 	t.Run("invalid payload error", func(t *testing.T) {
 		logger := testlog.Logger(t, log.LevelError)
 		emitter := &testutils.MockEmitter{}
-		cl := NewCLSync(logger, cfg, metrics, emitter)
+		cl := NewCLSync(logger, cfg, metrics, emitter, derive.DefaultMaxUnsafePayloadsMemory)
 
 		// CLSync gets payload and requests engine state, to later determine if payload should be forwarded
 		emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
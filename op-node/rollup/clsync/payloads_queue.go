@@ -1,9 +1,11 @@
 package clsync
 
 import (
+	"cmp"
 	"container/heap"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -104,6 +106,18 @@ func (upq *PayloadsQueue) MemSize() uint64 {
 	return upq.currentSize
 }
 
+// IDs returns the block IDs of the queued payloads, ordered by ascending block number, in O(N*log(N)).
+func (upq *PayloadsQueue) IDs() []eth.BlockID {
+	ids := make([]eth.BlockID, 0, len(upq.pq))
+	for _, p := range upq.pq {
+		ids = append(ids, p.envelope.ExecutionPayload.ID())
+	}
+	slices.SortFunc(ids, func(a, b eth.BlockID) int {
+		return cmp.Compare(a.Number, b.Number)
+	})
+	return ids
+}
+
 // Push adds the payload to the queue, in O(log(N)).
 //
 // Don't DoS ourselves by buffering too many unsafe payloads.
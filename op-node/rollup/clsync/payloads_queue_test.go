@@ -135,6 +135,7 @@ func TestPayloadsQueue(t *testing.T) {
 	require.NoError(t, pq.Push(a))
 	require.Equal(t, pq.Len(), 3, "expecting a, b, c")
 	require.Equal(t, pq.Peek(), a)
+	require.Equal(t, []eth.BlockID{a.ExecutionPayload.ID(), b.ExecutionPayload.ID(), c.ExecutionPayload.ID()}, pq.IDs())
 
 	// No duplicates allowed
 	require.Error(t, pq.Push(bDup))
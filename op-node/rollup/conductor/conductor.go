@@ -11,8 +11,8 @@ import (
 type SequencerConductor interface {
 	// Leader returns true if this node is the leader sequencer.
 	Leader(ctx context.Context) (bool, error)
-	// CommitUnsafePayload commits an unsafe payload to the conductor FSM.
-	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
+	// CommitUnsafePayload commits an unsafe payload to the conductor FSM, along with its provenance if known.
+	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *eth.PayloadMetadata) error
 	// OverrideLeader forces current node to be considered leader and be able to start sequencing during disaster situations in HA mode.
 	OverrideLeader(ctx context.Context) error
 	// Close closes the conductor client.
@@ -30,7 +30,7 @@ func (c *NoOpConductor) Leader(ctx context.Context) (bool, error) {
 }
 
 // CommitUnsafePayload commits an unsafe payload to the conductor log.
-func (c *NoOpConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
+func (c *NoOpConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *eth.PayloadMetadata) error {
 	return nil
 }
 
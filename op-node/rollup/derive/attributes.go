@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	lru "github.com/hashicorp/golang-lru/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -23,18 +24,41 @@ type SystemConfigL2Fetcher interface {
 	SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error)
 }
 
+// AttributesL2Fetcher fetches the L2 inputs for the payload attributes derivation:
+// the system config, and the deposits of the parent block when deposit limits are active.
+type AttributesL2Fetcher interface {
+	SystemConfigL2Fetcher
+	PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayloadEnvelope, error)
+}
+
 // FetchingAttributesBuilder fetches inputs for the building of L2 payload attributes on the fly.
 type FetchingAttributesBuilder struct {
 	rollupCfg *rollup.Config
 	l1        L1ReceiptsFetcher
-	l2        SystemConfigL2Fetcher
+	l2        AttributesL2Fetcher
+
+	// metrics records the deposits of derived blocks, optional
+	metrics DepositMetrics
+
+	// depositQueues caches the pending deposits after recently built blocks, when deposit limits are active
+	depositQueues *lru.Cache[depositQueueKey, [][]*types.DepositTx]
 }
 
-func NewFetchingAttributesBuilder(rollupCfg *rollup.Config, l1 L1ReceiptsFetcher, l2 SystemConfigL2Fetcher) *FetchingAttributesBuilder {
+// DepositMetrics records the user deposits that are included in derived L2 blocks.
+type DepositMetrics interface {
+	// RecordDerivedDeposits records the deposits of a block,
+	// and the deposits that are pending for later blocks because of the deposit limits.
+	RecordDerivedDeposits(included int, pending int)
+}
+
+func NewFetchingAttributesBuilder(rollupCfg *rollup.Config, l1 L1ReceiptsFetcher, l2 AttributesL2Fetcher) *FetchingAttributesBuilder {
+	// only errors if the size is not positive
+	depositQueues, _ := lru.New[depositQueueKey, [][]*types.DepositTx](depositQueueCacheSize)
 	return &FetchingAttributesBuilder{
-		rollupCfg: rollupCfg,
-		l1:        l1,
-		l2:        l2,
+		rollupCfg:     rollupCfg,
+		l1:            l1,
+		l2:            l2,
+		depositQueues: depositQueues,
 	}
 }
 
@@ -47,6 +71,7 @@ func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Contex
 	var l1Info eth.BlockInfo
 	var depositTxs []hexutil.Bytes
 	var seqNumber uint64
+	nextL2Time := l2Parent.Time + ba.rollupCfg.BlockTime
 
 	sysConfig, err := ba.l2.SystemConfigByL2Hash(ctx, l2Parent.Hash)
 	if err != nil {
@@ -67,10 +92,9 @@ func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Contex
 					epoch, info.ParentHash(), l2Parent.L1Origin))
 		}
 
-		deposits, err := DeriveDeposits(receipts, ba.rollupCfg.DepositContractAddress)
+		deposits, err := ba.deposits(ctx, l2Parent, epoch, receipts)
 		if err != nil {
-			// deposits may never be ignored. Failing to process them is a critical error.
-			return nil, NewCriticalError(fmt.Errorf("failed to derive some deposits: %w", err))
+			return nil, err
 		}
 		// apply sysCfg changes
 		if err := UpdateSystemConfigWithL1Receipts(&sysConfig, receipts, ba.rollupCfg, info.Time()); err != nil {
@@ -84,36 +108,38 @@ func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Contex
 		if l2Parent.L1Origin.Hash != epoch.Hash {
 			return nil, NewResetError(fmt.Errorf("cannot create new block with L1 origin %s in conflict with L1 origin %s", epoch, l2Parent.L1Origin))
 		}
-		info, err := ba.l1.InfoByHash(ctx, epoch.Hash)
-		if err != nil {
-			return nil, NewTemporaryError(fmt.Errorf("failed to fetch L1 block info: %w", err))
-		}
-		l1Info = info
-		depositTxs = nil
 		seqNumber = l2Parent.SequenceNumber + 1
+		if ba.rollupCfg.IsDepositLimits(nextL2Time) {
+			// deposits that exceeded the limits of earlier blocks are included in later blocks
+			info, receipts, err := ba.l1.FetchReceipts(ctx, epoch.Hash)
+			if err != nil {
+				return nil, NewTemporaryError(fmt.Errorf("failed to fetch L1 block info and receipts: %w", err))
+			}
+			deposits, err := ba.deposits(ctx, l2Parent, epoch, receipts)
+			if err != nil {
+				return nil, err
+			}
+			l1Info = info
+			depositTxs = deposits
+		} else {
+			info, err := ba.l1.InfoByHash(ctx, epoch.Hash)
+			if err != nil {
+				return nil, NewTemporaryError(fmt.Errorf("failed to fetch L1 block info: %w", err))
+			}
+			l1Info = info
+			depositTxs = nil
+		}
 	}
 
 	// Sanity check the L1 origin was correctly selected to maintain the time invariant between L1 and L2
-	nextL2Time := l2Parent.Time + ba.rollupCfg.BlockTime
 	if nextL2Time < l1Info.Time() {
 		return nil, NewResetError(fmt.Errorf("cannot build L2 block on top %s for time %d before L1 origin %s at time %d",
 			l2Parent, nextL2Time, eth.ToBlockID(l1Info), l1Info.Time()))
 	}
 
-	var upgradeTxs []hexutil.Bytes
-	if ba.rollupCfg.IsEcotoneActivationBlock(nextL2Time) {
-		upgradeTxs, err = EcotoneNetworkUpgradeTransactions()
-		if err != nil {
-			return nil, NewCriticalError(fmt.Errorf("failed to build ecotone network upgrade txs: %w", err))
-		}
-	}
-
-	if ba.rollupCfg.IsFjordActivationBlock(nextL2Time) {
-		fjord, err := FjordNetworkUpgradeTransactions()
-		if err != nil {
-			return nil, NewCriticalError(fmt.Errorf("failed to build fjord network upgrade txs: %w", err))
-		}
-		upgradeTxs = append(upgradeTxs, fjord...)
+	upgradeTxs, err := upgradeTransactions(ba.rollupCfg, nextL2Time)
+	if err != nil {
+		return nil, err
 	}
 
 	l1InfoTx, err := L1InfoDepositBytes(ba.rollupCfg, sysConfig, seqNumber, l1Info, nextL2Time)
@@ -150,3 +176,52 @@ func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Contex
 		ParentBeaconBlockRoot: parentBeaconRoot,
 	}, nil
 }
+
+// upgradeTransactions returns the network upgrade transactions of the L2 block at the given time,
+// which are included after the user deposits.
+func upgradeTransactions(rollupCfg *rollup.Config, l2Time uint64) ([]hexutil.Bytes, error) {
+	var upgradeTxs []hexutil.Bytes
+	if rollupCfg.IsEcotoneActivationBlock(l2Time) {
+		ecotone, err := EcotoneNetworkUpgradeTransactions()
+		if err != nil {
+			return nil, NewCriticalError(fmt.Errorf("failed to build ecotone network upgrade txs: %w", err))
+		}
+		upgradeTxs = append(upgradeTxs, ecotone...)
+	}
+	if rollupCfg.IsFjordActivationBlock(l2Time) {
+		fjord, err := FjordNetworkUpgradeTransactions()
+		if err != nil {
+			return nil, NewCriticalError(fmt.Errorf("failed to build fjord network upgrade txs: %w", err))
+		}
+		upgradeTxs = append(upgradeTxs, fjord...)
+	}
+	return upgradeTxs, nil
+}
+
+// deposits returns the encoded user deposits of the L2 block on top of l2Parent with the given L1 origin,
+// within the deposit limits of the rollup config if they are active.
+func (ba *FetchingAttributesBuilder) deposits(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID, receipts types.Receipts) ([]hexutil.Bytes, error) {
+	if !ba.rollupCfg.IsDepositLimits(l2Parent.Time + ba.rollupCfg.BlockTime) {
+		deposits, err := DeriveDeposits(receipts, ba.rollupCfg.DepositContractAddress)
+		if err != nil {
+			// deposits may never be ignored. Failing to process them is a critical error.
+			return nil, NewCriticalError(fmt.Errorf("failed to derive some deposits: %w", err))
+		}
+		if ba.metrics != nil {
+			ba.metrics.RecordDerivedDeposits(len(deposits), 0)
+		}
+		return deposits, nil
+	}
+	userDeposits, pending, err := ba.limitedDeposits(ctx, l2Parent, epoch, receipts)
+	if err != nil {
+		return nil, err
+	}
+	deposits, err := marshalDeposits(userDeposits)
+	if err != nil {
+		return nil, NewCriticalError(fmt.Errorf("failed to encode deposits: %w", err))
+	}
+	if ba.metrics != nil {
+		ba.metrics.RecordDerivedDeposits(len(deposits), pending)
+	}
+	return deposits, nil
+}
@@ -0,0 +1,71 @@
+package derive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SequencerNoTxPool returns whether the sequencer must not include transactions of its tx pool in the L2 block
+// with the given time and L1 origin time: when the block is past the sequencer drift,
+// or when it is the activation block of a network upgrade.
+func SequencerNoTxPool(rollupCfg *rollup.Config, l2Time uint64, l1OriginTime uint64) bool {
+	spec := rollup.NewChainSpec(rollupCfg)
+	return l2Time > l1OriginTime+spec.MaxSequencerDrift(l1OriginTime) ||
+		rollupCfg.IsEcotoneActivationBlock(l2Time) ||
+		rollupCfg.IsFjordActivationBlock(l2Time)
+}
+
+type PayloadAttributesL2Source interface {
+	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
+	AttributesL2Fetcher
+}
+
+// PayloadAttributesService builds the payload attributes of new L2 blocks as the sequencer does,
+// for block builders and other block-production tooling: with the L1 info deposit, the user deposits
+// of a new epoch, the network upgrade transactions, and the fork-dependent fields.
+type PayloadAttributesService struct {
+	rollupCfg *rollup.Config
+	builder   *FetchingAttributesBuilder
+	l1        L1ReceiptsFetcher
+	l2        PayloadAttributesL2Source
+}
+
+func NewPayloadAttributesService(rollupCfg *rollup.Config, l1 L1ReceiptsFetcher, l2 PayloadAttributesL2Source) *PayloadAttributesService {
+	return &PayloadAttributesService{
+		rollupCfg: rollupCfg,
+		builder:   NewFetchingAttributesBuilder(rollupCfg, l1, l2),
+		l1:        l1,
+		l2:        l2,
+	}
+}
+
+// BuildPayloadAttributes builds the attributes of the L2 block on top of the given L2 parent, with the given L1 origin.
+// The L1 origin must be the L1 origin of the parent, or the next L1 block.
+// NoTxPool is set if the sequencer may not include transactions of its tx pool.
+func (s *PayloadAttributesService) BuildPayloadAttributes(ctx context.Context, l1Origin eth.BlockID, l2Parent eth.BlockID) (*eth.PayloadAttributes, error) {
+	parent, err := s.l2.L2BlockRefByHash(ctx, l2Parent.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L2 parent %s: %w", l2Parent, err)
+	}
+	if parent.Number != l2Parent.Number {
+		return nil, fmt.Errorf("L2 parent %s has number %d", l2Parent, parent.Number)
+	}
+	origin, err := s.l1.InfoByHash(ctx, l1Origin.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 origin %s: %w", l1Origin, err)
+	}
+	if origin.NumberU64() != l1Origin.Number {
+		return nil, fmt.Errorf("L1 origin %s has number %d", l1Origin, origin.NumberU64())
+	}
+	attrs, err := s.builder.PreparePayloadAttributes(ctx, parent, l1Origin)
+	if err != nil {
+		return nil, err
+	}
+	attrs.NoTxPool = SequencerNoTxPool(s.rollupCfg, uint64(attrs.Timestamp), origin.Time())
+	return attrs, nil
+}
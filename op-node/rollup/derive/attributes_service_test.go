@@ -0,0 +1,80 @@
+package derive
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestPayloadAttributesService(t *testing.T) {
+	cfg := &rollup.Config{
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+		L1ChainID:         big.NewInt(101),
+		L2ChainID:         big.NewInt(102),
+	}
+	testSysCfg := eth.SystemConfig{BatcherAddr: common.Address{42}, GasLimit: 30_000_000}
+
+	setup := func(t *testing.T, driftOffset uint64) (*PayloadAttributesService, eth.L2BlockRef, *testutils.MockBlockInfo) {
+		rng := rand.New(rand.NewSource(1234))
+		l1Info := testutils.RandomBlockInfo(rng)
+		l2Parent := testutils.RandomL2BlockRef(rng)
+		l2Parent.L1Origin = l1Info.ID()
+		l2Parent.Time = l1Info.InfoTime + driftOffset
+
+		l1 := &testutils.MockL1Source{}
+		t.Cleanup(func() { l1.AssertExpectations(t) })
+		l2 := &testutils.MockL2Client{}
+		t.Cleanup(func() { l2.AssertExpectations(t) })
+		l2.ExpectL2BlockRefByHash(l2Parent.Hash, l2Parent, nil)
+		l2.ExpectSystemConfigByL2Hash(l2Parent.Hash, testSysCfg, nil)
+		l1.ExpectInfoByHash(l1Info.InfoHash, l1Info, nil)
+		l1.ExpectInfoByHash(l1Info.InfoHash, l1Info, nil)
+		return NewPayloadAttributesService(cfg, l1, l2), l2Parent, l1Info
+	}
+
+	t.Run("WithinDrift", func(t *testing.T) {
+		s, l2Parent, l1Info := setup(t, 0)
+		attrs, err := s.BuildPayloadAttributes(context.Background(), l1Info.ID(), l2Parent.ID())
+		require.NoError(t, err)
+		require.Equal(t, l2Parent.Time+cfg.BlockTime, uint64(attrs.Timestamp))
+		require.Len(t, attrs.Transactions, 1, "only the L1 info deposit")
+		require.Equal(t, testSysCfg.GasLimit, uint64(*attrs.GasLimit))
+		require.False(t, attrs.NoTxPool)
+	})
+
+	t.Run("PastDrift", func(t *testing.T) {
+		s, l2Parent, l1Info := setup(t, cfg.MaxSequencerDrift)
+		attrs, err := s.BuildPayloadAttributes(context.Background(), l1Info.ID(), l2Parent.ID())
+		require.NoError(t, err)
+		require.True(t, attrs.NoTxPool)
+	})
+
+	t.Run("InconsistentParent", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1234))
+		l2Parent := testutils.RandomL2BlockRef(rng)
+		l2 := &testutils.MockL2Client{}
+		l2.ExpectL2BlockRefByHash(l2Parent.Hash, l2Parent, nil)
+		s := NewPayloadAttributesService(cfg, &testutils.MockL1Source{}, l2)
+		_, err := s.BuildPayloadAttributes(context.Background(), eth.BlockID{}, eth.BlockID{Hash: l2Parent.Hash, Number: l2Parent.Number + 1})
+		require.ErrorContains(t, err, "has number")
+	})
+}
+
+func TestSequencerNoTxPool(t *testing.T) {
+	ecotone := uint64(1000)
+	cfg := &rollup.Config{BlockTime: 2, MaxSequencerDrift: 600, EcotoneTime: &ecotone}
+	require.False(t, SequencerNoTxPool(cfg, 500, 0))
+	require.True(t, SequencerNoTxPool(cfg, 602, 0))
+	require.True(t, SequencerNoTxPool(cfg, 1000, 900), "ecotone activation block")
+	require.False(t, SequencerNoTxPool(cfg, 1002, 900))
+}
@@ -60,6 +60,8 @@ type BatchQueue struct {
 	nextSpan []*SingularBatch
 
 	l2 SafeBlockFetcher
+
+	progress *DerivationProgressTracker
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
@@ -230,6 +232,7 @@ func (bq *BatchQueue) AddBatch(ctx context.Context, batch Batch, parent eth.L2Bl
 	}
 	validity := CheckBatch(ctx, bq.config, bq.log, bq.l1Blocks, parent, &data, bq.l2)
 	if validity == BatchDrop {
+		bq.progress.recordBatch(bq.origin, batch, eth.BatchDropped)
 		return // if we do drop the batch, CheckBatch will log the drop reason with WARN level.
 	}
 	batch.LogContext(bq.log).Debug("Adding batch")
@@ -275,9 +278,11 @@ batchLoop:
 				"parent", parent.ID(),
 				"parent_time", parent.Time,
 			)
+			bq.progress.recordBatch(batch.L1InclusionBlock, batch.Batch, eth.BatchDropped)
 			continue
 		case BatchAccept:
 			nextBatch = batch
+			bq.progress.recordBatch(batch.L1InclusionBlock, batch.Batch, eth.BatchAccepted)
 			// don't keep the current batch in the remaining items since we are processing it now,
 			// but retain every batch we didn't get to yet.
 			remaining = append(remaining, bq.batches[i+1:]...)
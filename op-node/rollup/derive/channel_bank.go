@@ -39,6 +39,12 @@ type ChannelBank struct {
 
 	prev    NextFrameProvider
 	fetcher L1Fetcher
+
+	// budget is the memory budget of the buffered channels, below the protocol limit. Unlimited if zero.
+	// No more frames are ingested while it is exceeded, channels are only pruned at the protocol limit.
+	budget uint64
+
+	progress *DerivationProgressTracker
 }
 
 var _ ResettableStage = (*ChannelBank)(nil)
@@ -60,12 +66,18 @@ func (cb *ChannelBank) Origin() eth.L1BlockRef {
 	return cb.prev.Origin()
 }
 
-func (cb *ChannelBank) prune() {
-	// check total size
+// MemSize returns the estimated memory size of the buffered channels.
+func (cb *ChannelBank) MemSize() uint64 {
 	totalSize := uint64(0)
 	for _, ch := range cb.channels {
 		totalSize += ch.size
 	}
+	return totalSize
+}
+
+func (cb *ChannelBank) prune() {
+	// check total size
+	totalSize := cb.MemSize()
 	// prune until it is reasonable again. The high-priority channel failed to be read, so we start pruning there.
 	for totalSize > cb.spec.MaxChannelBankSize(cb.Origin().Time) {
 		id := cb.channelQueue[0]
@@ -73,6 +85,7 @@ func (cb *ChannelBank) prune() {
 		cb.channelQueue = cb.channelQueue[1:]
 		delete(cb.channels, id)
 		cb.log.Info("pruning channel", "channel", id, "totalSize", totalSize, "channel_size", ch.size, "remaining_channel_count", len(cb.channels))
+		cb.progress.recordChannel(cb.Origin(), ch, eth.ChannelPruned)
 		totalSize -= ch.size
 	}
 }
@@ -129,6 +142,7 @@ func (cb *ChannelBank) Read() (data []byte, err error) {
 	if timedOut {
 		cb.log.Info("channel timed out", "channel", first, "frames", len(ch.inputs))
 		cb.metrics.RecordChannelTimedOut()
+		cb.progress.recordChannel(cb.Origin(), ch, eth.ChannelTimedOut)
 		delete(cb.channels, first)
 		cb.channelQueue = cb.channelQueue[1:]
 		return nil, nil // multiple different channels may all be timed out
@@ -162,6 +176,7 @@ func (cb *ChannelBank) tryReadChannelAtIndex(i int) (data []byte, err error) {
 		return nil, io.EOF
 	}
 	cb.log.Info("Reading channel", "channel", chanID, "frames", len(ch.inputs))
+	cb.progress.recordChannel(cb.Origin(), ch, eth.ChannelReady)
 
 	delete(cb.channels, chanID)
 	cb.channelQueue = slices.Delete(cb.channelQueue, i, i+1)
@@ -188,6 +203,11 @@ func (cb *ChannelBank) NextData(ctx context.Context) ([]byte, error) {
 		return data, nil
 	}
 
+	// Apply backpressure: do not take in more L1 data while the buffered channels exceed the memory budget.
+	if err := checkMemoryBudget("channel bank", cb.MemSize(), cb.budget); err != nil {
+		return nil, err
+	}
+
 	// Then load data into the channel bank
 	if frame, err := cb.prev.NextFrame(ctx); err == io.EOF {
 		return nil, io.EOF
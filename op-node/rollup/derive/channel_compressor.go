@@ -68,8 +68,8 @@ func (bc *BrotliCompressor) Reset() {
 
 func NewChannelCompressor(algo CompressionAlgo) (ChannelCompressor, error) {
 	compressed := &bytes.Buffer{}
-	if algo == Zlib {
-		writer, err := zlib.NewWriterLevel(compressed, zlib.BestCompression)
+	if algo.IsZlib() {
+		writer, err := zlib.NewWriterLevel(compressed, GetZlibLevel(algo))
 		if err != nil {
 			return nil, err
 		}
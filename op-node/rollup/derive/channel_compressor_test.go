@@ -31,6 +31,11 @@ func TestChannelCompressor_NewReset(t *testing.T) {
 			algo:              Zlib,
 			expectedResetSize: 0,
 		},
+		{
+			name:              "zlib1",
+			algo:              Zlib1,
+			expectedResetSize: 0,
+		},
 		{
 			name:              "brotli10",
 			algo:              Brotli10,
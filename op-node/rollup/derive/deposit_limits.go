@@ -0,0 +1,168 @@
+package derive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SplitDeposits splits the user deposits of an L1 block into the chunks of the consecutive L2 blocks of the epoch,
+// see rollup.DepositLimits. The order of the deposits is preserved.
+func SplitDeposits(limits *rollup.DepositLimits, deposits []*types.DepositTx) [][]*types.DepositTx {
+	var chunks [][]*types.DepositTx
+	var chunk []*types.DepositTx
+	var gas uint64
+	for _, dep := range deposits {
+		// gas never exceeds the limit if the chunk contains more than one deposit
+		full := limits.MaxDeposits != 0 && uint64(len(chunk)) >= limits.MaxDeposits
+		full = full || (limits.MaxDepositGas != 0 && (gas >= limits.MaxDepositGas || dep.Gas > limits.MaxDepositGas-gas))
+		if len(chunk) > 0 && full {
+			chunks = append(chunks, chunk)
+			chunk, gas = nil, 0
+		}
+		chunk = append(chunk, dep)
+		gas += dep.Gas
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// depositQueueCacheSize is the number of deposit queue positions that are remembered,
+// enough to build a couple of blocks on top of recent blocks without fetching older L1 receipts again.
+const depositQueueCacheSize = 64
+
+// depositQueueKey identifies the position in the deposit queue after an L2 block:
+// the last user deposit included in the block, and the L1 origin up to which deposits are queued.
+type depositQueueKey struct {
+	lastDeposit common.Hash
+	l1Origin    common.Hash
+}
+
+// limitedDeposits returns the user deposits of the L2 block on top of l2Parent with the given L1 origin,
+// and the number of deposits that are left for later L2 blocks.
+//
+// The user deposits of all L1 blocks form a queue, and every L2 block includes the next chunk of the queue,
+// see SplitDeposits. The deposits of the L1 origin of an L2 block become available in the first block of its epoch.
+// The position in the queue is determined by the last user deposit of the parent block: if the parent block has
+// no user deposits, all deposits up to its L1 origin are included already.
+func (ba *FetchingAttributesBuilder) limitedDeposits(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID, receipts types.Receipts) ([]*types.DepositTx, int, error) {
+	// The deposits of the L1 genesis block are never derived.
+	if epoch.Number <= ba.rollupCfg.Genesis.L1.Number {
+		return nil, 0, nil
+	}
+	queue, err := ba.pendingDeposits(ctx, l2Parent)
+	if err != nil {
+		return nil, 0, err
+	}
+	if epoch != l2Parent.L1Origin {
+		deposits, err := UserDeposits(receipts, ba.rollupCfg.DepositContractAddress)
+		if err != nil {
+			// deposits may never be ignored. Failing to process them is a critical error.
+			return nil, 0, NewCriticalError(fmt.Errorf("failed to derive some deposits of %s: %w", epoch, err))
+		}
+		queue = append(queue, deposits)
+	}
+	var out []*types.DepositTx
+	var pending int
+	for i, deposits := range queue {
+		if out == nil && len(deposits) > 0 {
+			// chunks never span multiple L1 blocks
+			out = SplitDeposits(ba.rollupCfg.DepositLimits, deposits)[0]
+			pending += len(deposits) - len(out)
+			queue[i] = deposits[len(out):]
+		} else {
+			pending += len(deposits)
+		}
+	}
+	// Remember the remaining queue, so the next block does not have to find its position in the L1 chain again.
+	if len(out) > 0 {
+		key := depositQueueKey{lastDeposit: out[len(out)-1].SourceHash, l1Origin: epoch.Hash}
+		ba.depositQueues.Add(key, queue)
+	}
+	return out, pending, nil
+}
+
+// pendingDeposits returns the user deposits up to the L1 origin of l2Parent that are not included in
+// l2Parent or its ancestors, per L1 block in queue order.
+func (ba *FetchingAttributesBuilder) pendingDeposits(ctx context.Context, l2Parent eth.L2BlockRef) ([][]*types.DepositTx, error) {
+	// Before the deposit limits, all deposits of an epoch are included in its first block.
+	if !ba.rollupCfg.IsDepositLimits(l2Parent.Time) || l2Parent.L1Origin.Number <= ba.rollupCfg.Genesis.L1.Number {
+		return nil, nil
+	}
+	included, err := ba.userDepositSources(ctx, l2Parent)
+	if err != nil {
+		return nil, err
+	}
+	if len(included) == 0 {
+		return nil, nil
+	}
+	last := included[len(included)-1]
+	if queue, ok := ba.depositQueues.Get(depositQueueKey{lastDeposit: last, l1Origin: l2Parent.L1Origin.Hash}); ok {
+		return slices.Clone(queue), nil
+	}
+	// Walk back the L1 chain from the L1 origin of the parent, to the L1 block of the last included deposit.
+	var queue [][]*types.DepositTx
+	l1Block := l2Parent.L1Origin.Hash
+	for {
+		info, receipts, err := ba.l1.FetchReceipts(ctx, l1Block)
+		if err != nil {
+			return nil, NewTemporaryError(fmt.Errorf("failed to fetch L1 receipts of %s: %w", l1Block, err))
+		}
+		if info.NumberU64() <= ba.rollupCfg.Genesis.L1.Number {
+			return nil, NewCriticalError(fmt.Errorf("failed to find the L1 block of the deposits of L2 block %s", l2Parent))
+		}
+		deposits, err := UserDeposits(receipts, ba.rollupCfg.DepositContractAddress)
+		if err != nil {
+			return nil, NewCriticalError(fmt.Errorf("failed to derive some deposits of %s: %w", l1Block, err))
+		}
+		i := slices.IndexFunc(deposits, func(dep *types.DepositTx) bool { return dep.SourceHash == last })
+		if i >= 0 {
+			return append([][]*types.DepositTx{deposits[i+1:]}, queue...), nil
+		}
+		queue = append([][]*types.DepositTx{deposits}, queue...)
+		l1Block = info.ParentHash()
+	}
+}
+
+// userDepositSources returns the source hashes of the user deposits of the L2 block, in block order.
+// The L1 info deposit at the start of the block, and the network upgrade deposits after the user deposits,
+// are excluded.
+func (ba *FetchingAttributesBuilder) userDepositSources(ctx context.Context, l2Block eth.L2BlockRef) ([]common.Hash, error) {
+	payload, err := ba.l2.PayloadByHash(ctx, l2Block.Hash)
+	if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to fetch L2 block %s: %w", l2Block, err))
+	}
+	upgradeTxs, err := upgradeTransactions(ba.rollupCfg, l2Block.Time)
+	if err != nil {
+		return nil, err
+	}
+	var sources []common.Hash
+	for i, tx := range payload.ExecutionPayload.Transactions {
+		if i == 0 {
+			continue
+		}
+		if deposit, err := eth.IsDepositTx(tx); err != nil {
+			return nil, NewCriticalError(fmt.Errorf("invalid tx %d in L2 block %s: %w", i, l2Block, err))
+		} else if !deposit {
+			// deposits are always at the start of the block
+			break
+		}
+		dep, err := eth.DecodeDepositTx(tx)
+		if err != nil {
+			return nil, NewCriticalError(fmt.Errorf("invalid deposit %d in L2 block %s: %w", i, l2Block, err))
+		}
+		sources = append(sources, dep.SourceHash)
+	}
+	if len(sources) < len(upgradeTxs) {
+		return nil, NewCriticalError(fmt.Errorf("L2 block %s is missing network upgrade deposits", l2Block))
+	}
+	return sources[:len(sources)-len(upgradeTxs)], nil
+}
@@ -0,0 +1,229 @@
+package derive
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestSplitDeposits(t *testing.T) {
+	deps := func(gas ...uint64) []*types.DepositTx {
+		out := make([]*types.DepositTx, len(gas))
+		for i, g := range gas {
+			out[i] = &types.DepositTx{Gas: g}
+		}
+		return out
+	}
+	gasOf := func(chunks [][]*types.DepositTx) (out [][]uint64) {
+		for _, chunk := range chunks {
+			var gas []uint64
+			for _, dep := range chunk {
+				gas = append(gas, dep.Gas)
+			}
+			out = append(out, gas)
+		}
+		return out
+	}
+
+	t.Run("count", func(t *testing.T) {
+		chunks := SplitDeposits(&rollup.DepositLimits{MaxDeposits: 2}, deps(1, 2, 3, 4, 5))
+		require.Equal(t, [][]uint64{{1, 2}, {3, 4}, {5}}, gasOf(chunks))
+	})
+	t.Run("gas", func(t *testing.T) {
+		chunks := SplitDeposits(&rollup.DepositLimits{MaxDepositGas: 100}, deps(60, 40, 50, 70, 30, 10))
+		require.Equal(t, [][]uint64{{60, 40}, {50}, {70, 30}, {10}}, gasOf(chunks))
+	})
+	t.Run("oversized deposit", func(t *testing.T) {
+		chunks := SplitDeposits(&rollup.DepositLimits{MaxDepositGas: 100}, deps(10, 500, 20, ^uint64(0), 1))
+		require.Equal(t, [][]uint64{{10}, {500}, {20}, {^uint64(0)}, {1}}, gasOf(chunks))
+	})
+	t.Run("count and gas", func(t *testing.T) {
+		chunks := SplitDeposits(&rollup.DepositLimits{MaxDeposits: 3, MaxDepositGas: 100}, deps(10, 10, 10, 10, 90, 10))
+		require.Equal(t, [][]uint64{{10, 10, 10}, {10, 90}, {10}}, gasOf(chunks))
+	})
+	t.Run("none", func(t *testing.T) {
+		require.Empty(t, SplitDeposits(&rollup.DepositLimits{MaxDeposits: 1}, nil))
+	})
+}
+
+type depositMetrics struct {
+	included, pending int
+}
+
+func (m *depositMetrics) RecordDerivedDeposits(included int, pending int) {
+	m.included += included
+	m.pending = pending
+}
+
+func TestPreparePayloadAttributesDepositLimits(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	activation := uint64(0)
+	cfg := &rollup.Config{
+		BlockTime:              2,
+		L1ChainID:              big.NewInt(101),
+		L2ChainID:              big.NewInt(102),
+		DepositContractAddress: common.Address{0xbb},
+		L1SystemConfigAddress:  common.Address{0xcc},
+		DepositLimits:          &rollup.DepositLimits{MaxDeposits: 2},
+		DepositLimitsTime:      &activation,
+	}
+	sysCfg := eth.SystemConfig{BatcherAddr: common.Address{42}}
+
+	// two epochs with 5 deposits each, split into chunks of 2, 2 and 1 deposits
+	epoch1 := testutils.RandomBlockInfo(rng)
+	epoch1.InfoNum = 100
+	epoch1.InfoTime = 1000
+	epoch2 := testutils.RandomBlockInfo(rng)
+	epoch2.InfoNum = 101
+	epoch2.InfoParentHash = epoch1.InfoHash
+	epoch2.InfoTime = 1004
+	receiptsOf := func(info *testutils.MockBlockInfo) (types.Receipts, []eth.Data) {
+		receipts, deposits, err := makeReceipts(rng, info.InfoHash, cfg.DepositContractAddress, []receiptData{
+			{goodReceipt: true, DepositLogs: []bool{true, true, false}},
+			{goodReceipt: true, DepositLogs: []bool{true}},
+			{goodReceipt: true, DepositLogs: []bool{true, true}},
+		})
+		require.NoError(t, err)
+		encoded, err := encodeDeposits(deposits)
+		require.NoError(t, err)
+		return receipts, encoded
+	}
+	receipts1, deposits1 := receiptsOf(epoch1)
+	receipts2, deposits2 := receiptsOf(epoch2)
+
+	setup := func(t *testing.T) (*FetchingAttributesBuilder, *testutils.MockL1Source, *testutils.MockL2Client, *depositMetrics, eth.L2BlockRef) {
+		l1Fetcher := &testutils.MockL1Source{}
+		t.Cleanup(func() { l1Fetcher.AssertExpectations(t) })
+		l2 := &testutils.MockL2Client{}
+		t.Cleanup(func() { l2.AssertExpectations(t) })
+		metrics := &depositMetrics{}
+		attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, l2)
+		attrBuilder.metrics = metrics
+		l2Parent := eth.L2BlockRef{
+			Hash:           testutils.RandomHash(rng),
+			Number:         500,
+			Time:           1000,
+			L1Origin:       eth.BlockID{Hash: epoch1.InfoParentHash, Number: 99},
+			SequenceNumber: 7,
+		}
+		return attrBuilder, l1Fetcher, l2, metrics, l2Parent
+	}
+
+	for _, cached := range []bool{true, false} {
+		name := "carry over one chunk per block"
+		if !cached {
+			name += " without cached queue"
+		}
+		t.Run(name, func(t *testing.T) {
+			attrBuilder, l1Fetcher, l2, metrics, l2Parent := setup(t)
+			// the parent block includes no user deposits
+			parentTxs := []eth.Data{{0x7e}}
+
+			// build prepares the attributes of the next block, and returns its deposits.
+			// The receipts of the epoch are expected to be fetched, and the receipts of the given earlier L1 blocks
+			// if the position in the deposit queue is not cached.
+			build := func(epoch *testutils.MockBlockInfo, walked ...*testutils.MockBlockInfo) []eth.Data {
+				if !cached {
+					attrBuilder.depositQueues.Purge()
+				} else {
+					walked = nil
+				}
+				l2.ExpectSystemConfigByL2Hash(l2Parent.Hash, sysCfg, nil)
+				l2.ExpectPayloadByHash(l2Parent.Hash, &eth.ExecutionPayloadEnvelope{
+					ExecutionPayload: &eth.ExecutionPayload{Transactions: parentTxs},
+				}, nil)
+				for _, info := range append([]*testutils.MockBlockInfo{epoch}, walked...) {
+					receipts := receipts1
+					if info == epoch2 {
+						receipts = receipts2
+					}
+					l1Fetcher.ExpectFetchReceipts(info.InfoHash, info, receipts, nil)
+				}
+				attrs, err := attrBuilder.PreparePayloadAttributes(context.Background(), l2Parent, epoch.ID())
+				require.NoError(t, err)
+				l2Parent = testutils.NextRandomL2Ref(rng, cfg.BlockTime, l2Parent, epoch.ID())
+				l2Parent.L1Origin = epoch.ID()
+				parentTxs = attrs.Transactions
+				return attrs.Transactions[1:]
+			}
+
+			// the first block of the epoch only includes the first chunk
+			require.Equal(t, deposits1[0:2], build(epoch1))
+			require.Equal(t, depositMetrics{included: 2, pending: 3}, *metrics)
+
+			// the next epoch starts after one block, only the next chunk of the previous epoch is included
+			require.Equal(t, deposits1[2:4], build(epoch2, epoch1))
+			require.Equal(t, depositMetrics{included: 4, pending: 6}, *metrics)
+			require.Equal(t, deposits1[4:5], build(epoch2, epoch2, epoch1))
+			require.Equal(t, depositMetrics{included: 5, pending: 5}, *metrics)
+
+			// then the deposits of the new epoch follow
+			require.Equal(t, deposits2[0:2], build(epoch2, epoch2, epoch1))
+			require.Equal(t, deposits2[2:4], build(epoch2, epoch2))
+			require.Equal(t, deposits2[4:5], build(epoch2, epoch2))
+			require.Equal(t, depositMetrics{included: 10, pending: 0}, *metrics)
+
+			// no deposits are left for later blocks of the epoch
+			require.Empty(t, build(epoch2, epoch2))
+			require.Empty(t, build(epoch2))
+			require.Equal(t, depositMetrics{included: 10, pending: 0}, *metrics)
+		})
+	}
+
+	t.Run("parent with upgrade deposits only", func(t *testing.T) {
+		attrBuilder, l1Fetcher, l2, metrics, l2Parent := setup(t)
+		// the parent block activates Ecotone, and has network upgrade deposits but no user deposits
+		cfgCopy := *cfg
+		ecotoneTime := l2Parent.Time
+		cfgCopy.EcotoneTime = &ecotoneTime
+		attrBuilder.rollupCfg = &cfgCopy
+		upgradeTxs, err := EcotoneNetworkUpgradeTransactions()
+		require.NoError(t, err)
+		parentTxs := append([]eth.Data{{0x7e}}, upgradeTxs...)
+
+		// the pending deposits are not searched for in older L1 blocks
+		l2.ExpectSystemConfigByL2Hash(l2Parent.Hash, sysCfg, nil)
+		l2.ExpectPayloadByHash(l2Parent.Hash, &eth.ExecutionPayloadEnvelope{
+			ExecutionPayload: &eth.ExecutionPayload{Transactions: parentTxs},
+		}, nil)
+		l1Fetcher.ExpectFetchReceipts(epoch1.InfoHash, epoch1, receipts1, nil)
+		attrs, err := attrBuilder.PreparePayloadAttributes(context.Background(), l2Parent, epoch1.ID())
+		require.NoError(t, err)
+		require.Equal(t, deposits1[0:2], attrs.Transactions[1:])
+		require.Equal(t, depositMetrics{included: 2, pending: 3}, *metrics)
+	})
+
+	t.Run("before activation", func(t *testing.T) {
+		attrBuilder, l1Fetcher, l2, metrics, l2Parent := setup(t)
+		future := uint64(2000)
+		cfgCopy := *cfg
+		cfgCopy.DepositLimitsTime = &future
+		attrBuilder.rollupCfg = &cfgCopy
+
+		// all deposits of the epoch are included in its first block
+		l2.ExpectSystemConfigByL2Hash(l2Parent.Hash, sysCfg, nil)
+		l1Fetcher.ExpectFetchReceipts(epoch1.InfoHash, epoch1, receipts1, nil)
+		attrs, err := attrBuilder.PreparePayloadAttributes(context.Background(), l2Parent, epoch1.ID())
+		require.NoError(t, err)
+		require.Equal(t, deposits1, attrs.Transactions[1:])
+		require.Equal(t, depositMetrics{included: 5, pending: 0}, *metrics)
+
+		l2Parent = testutils.NextRandomL2Ref(rng, cfg.BlockTime, l2Parent, epoch1.ID())
+		l2Parent.L1Origin = epoch1.ID()
+		l2.ExpectSystemConfigByL2Hash(l2Parent.Hash, sysCfg, nil)
+		l1Fetcher.ExpectInfoByHash(epoch1.InfoHash, epoch1, nil)
+		attrs, err = attrBuilder.PreparePayloadAttributes(context.Background(), l2Parent, epoch1.ID())
+		require.NoError(t, err)
+		require.Len(t, attrs.Transactions, 1)
+	})
+}
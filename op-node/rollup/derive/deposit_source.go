@@ -1,10 +1,9 @@
 package derive
 
 import (
-	"encoding/binary"
-
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 type UserDepositSource struct {
@@ -13,20 +12,13 @@ type UserDepositSource struct {
 }
 
 const (
-	UserDepositSourceDomain    = 0
-	L1InfoDepositSourceDomain  = 1
-	UpgradeDepositSourceDomain = 2
+	UserDepositSourceDomain    = eth.UserDepositSourceDomain
+	L1InfoDepositSourceDomain  = eth.L1InfoDepositSourceDomain
+	UpgradeDepositSourceDomain = eth.UpgradeDepositSourceDomain
 )
 
 func (dep *UserDepositSource) SourceHash() common.Hash {
-	var input [32 * 2]byte
-	copy(input[:32], dep.L1BlockHash[:])
-	binary.BigEndian.PutUint64(input[32*2-8:], dep.LogIndex)
-	depositIDHash := crypto.Keccak256Hash(input[:])
-	var domainInput [32 * 2]byte
-	binary.BigEndian.PutUint64(domainInput[32-8:32], UserDepositSourceDomain)
-	copy(domainInput[32:], depositIDHash[:])
-	return crypto.Keccak256Hash(domainInput[:])
+	return eth.UserDepositSourceHash(dep.L1BlockHash, dep.LogIndex)
 }
 
 type L1InfoDepositSource struct {
@@ -35,15 +27,7 @@ type L1InfoDepositSource struct {
 }
 
 func (dep *L1InfoDepositSource) SourceHash() common.Hash {
-	var input [32 * 2]byte
-	copy(input[:32], dep.L1BlockHash[:])
-	binary.BigEndian.PutUint64(input[32*2-8:], dep.SeqNumber)
-	depositIDHash := crypto.Keccak256Hash(input[:])
-
-	var domainInput [32 * 2]byte
-	binary.BigEndian.PutUint64(domainInput[32-8:32], L1InfoDepositSourceDomain)
-	copy(domainInput[32:], depositIDHash[:])
-	return crypto.Keccak256Hash(domainInput[:])
+	return eth.L1InfoDepositSourceHash(dep.L1BlockHash, dep.SeqNumber)
 }
 
 // UpgradeDepositSource implements the translation of upgrade-tx identity information to a deposit source-hash,
@@ -56,10 +40,5 @@ type UpgradeDepositSource struct {
 }
 
 func (dep *UpgradeDepositSource) SourceHash() common.Hash {
-	intentHash := crypto.Keccak256Hash([]byte(dep.Intent))
-
-	var domainInput [32 * 2]byte
-	binary.BigEndian.PutUint64(domainInput[32-8:32], UpgradeDepositSourceDomain)
-	copy(domainInput[32:], intentHash[:])
-	return crypto.Keccak256Hash(domainInput[:])
+	return eth.UpgradeDepositSourceHash(dep.Intent)
 }
@@ -38,6 +38,15 @@ func DeriveDeposits(receipts []*types.Receipt, depositContractAddr common.Addres
 	if err != nil {
 		result = multierror.Append(result, err)
 	}
+	encodedTxs, err := marshalDeposits(userDeposits)
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+	return encodedTxs, result
+}
+
+func marshalDeposits(userDeposits []*types.DepositTx) ([]hexutil.Bytes, error) {
+	var result error
 	encodedTxs := make([]hexutil.Bytes, 0, len(userDeposits))
 	for i, tx := range userDeposits {
 		opaqueTx, err := types.NewTx(tx).MarshalBinary()
@@ -0,0 +1,160 @@
+package derive
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// DerivationProgressOrigins is the number of most recent L1 blocks that derivation progress is kept for.
+	DerivationProgressOrigins = 256
+	// maxProgressRecords is the maximum number of frames, channels, batches and L2 blocks each,
+	// that are kept per L1 block. The progress is marked as truncated if more are derived.
+	maxProgressRecords = 512
+)
+
+// ErrDerivationProgressNotFound is returned when there is no derivation progress for the requested L1 block,
+// i.e. when it is older than the kept L1 blocks, or not derived from yet.
+var ErrDerivationProgressNotFound = errors.New("derivation progress not found")
+
+// DerivationProgressTracker keeps track of what the derivation pipeline derived from each of the recent L1 blocks,
+// to debug batch submission from the verifier side. It is safe for concurrent use.
+// A nil tracker does not record anything.
+type DerivationProgressTracker struct {
+	mu sync.Mutex
+	// origins is sorted by L1 block number, and holds at most maxOrigins entries.
+	origins    []*eth.DerivationProgress
+	maxOrigins int
+}
+
+func NewDerivationProgressTracker(maxOrigins int) *DerivationProgressTracker {
+	return &DerivationProgressTracker{maxOrigins: maxOrigins}
+}
+
+// progress returns the progress of the given L1 block, or nil if it is too old to be kept.
+// The progress of a different L1 block at the same height, i.e. of a reorged block, is replaced.
+// The lock must be held.
+func (t *DerivationProgressTracker) progress(origin eth.L1BlockRef) *eth.DerivationProgress {
+	i, found := slices.BinarySearchFunc(t.origins, origin.Number, func(p *eth.DerivationProgress, num uint64) int {
+		if p.L1Block.Number < num {
+			return -1
+		} else if p.L1Block.Number > num {
+			return 1
+		}
+		return 0
+	})
+	if found {
+		if t.origins[i].L1Block.Hash != origin.Hash {
+			t.origins[i] = &eth.DerivationProgress{L1Block: origin.ID()}
+		}
+		return t.origins[i]
+	}
+	if len(t.origins) >= t.maxOrigins {
+		if i == 0 {
+			return nil
+		}
+		t.origins = slices.Delete(t.origins, 0, 1)
+		i--
+	}
+	p := &eth.DerivationProgress{L1Block: origin.ID()}
+	t.origins = slices.Insert(t.origins, i, p)
+	return p
+}
+
+// record appends the record to the records of the given L1 block.
+func record[T any](t *DerivationProgressTracker, origin eth.L1BlockRef, records func(p *eth.DerivationProgress) *[]T, v T) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p := t.progress(origin)
+	if p == nil {
+		return
+	}
+	if rs := records(p); len(*rs) < maxProgressRecords {
+		*rs = append(*rs, v)
+	} else {
+		p.Truncated = true
+	}
+}
+
+func (t *DerivationProgressTracker) recordFrame(origin eth.L1BlockRef, f Frame) {
+	record(t, origin, func(p *eth.DerivationProgress) *[]eth.DerivedFrame { return &p.Frames }, eth.DerivedFrame{
+		ChannelID:   hexutil.Bytes(f.ID[:]),
+		FrameNumber: f.FrameNumber,
+		DataLength:  hexutil.Uint64(len(f.Data)),
+		IsLast:      f.IsLast,
+	})
+}
+
+func (t *DerivationProgressTracker) recordChannel(origin eth.L1BlockRef, ch *Channel, status string) {
+	record(t, origin, func(p *eth.DerivationProgress) *[]eth.DerivedChannel { return &p.Channels }, eth.DerivedChannel{
+		ChannelID: hexutil.Bytes(ch.id[:]),
+		Status:    status,
+		OpenedAt:  ch.openBlock.ID(),
+		Frames:    hexutil.Uint64(len(ch.inputs)),
+		Size:      hexutil.Uint64(ch.size),
+	})
+}
+
+func (t *DerivationProgressTracker) recordBatch(origin eth.L1BlockRef, b Batch, status string) {
+	batch := eth.DerivedBatch{Timestamp: hexutil.Uint64(b.GetTimestamp()), Blocks: 1, Status: status}
+	if span, ok := b.AsSpanBatch(); ok {
+		batch.Type = "span"
+		batch.Blocks = hexutil.Uint64(span.GetBlockCount())
+	} else {
+		batch.Type = "singular"
+	}
+	record(t, origin, func(p *eth.DerivationProgress) *[]eth.DerivedBatch { return &p.Batches }, batch)
+}
+
+// RecordSafeL2Block records a L2 block that became safe, derived from the given L1 block.
+func (t *DerivationProgressTracker) RecordSafeL2Block(ref eth.L2BlockRef, derivedFrom eth.L1BlockRef) {
+	record(t, derivedFrom, func(p *eth.DerivationProgress) *[]eth.L2BlockRef { return &p.L2Blocks }, ref)
+}
+
+// Rewind forgets what was read from the given L1 block and all later blocks, and the L2 blocks after the given safe head,
+// as these are derived again after a pipeline reset.
+func (t *DerivationProgressTracker) Rewind(l1Number uint64, l2Safe uint64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, p := range t.origins {
+		if p.L1Block.Number >= l1Number {
+			p.Frames, p.Channels, p.Batches, p.Truncated = nil, nil, nil, false
+		}
+		p.L2Blocks = slices.DeleteFunc(p.L2Blocks, func(ref eth.L2BlockRef) bool {
+			return ref.Number > l2Safe
+		})
+	}
+}
+
+// AtL1 returns a copy of the derivation progress of the L1 block with the given number.
+func (t *DerivationProgressTracker) AtL1(number uint64) (*eth.DerivationProgress, error) {
+	if t == nil {
+		return nil, ErrDerivationProgressNotFound
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, p := range t.origins {
+		if p.L1Block.Number == number {
+			return &eth.DerivationProgress{
+				L1Block:   p.L1Block,
+				Frames:    slices.Clone(p.Frames),
+				Channels:  slices.Clone(p.Channels),
+				Batches:   slices.Clone(p.Batches),
+				L2Blocks:  slices.Clone(p.L2Blocks),
+				Truncated: p.Truncated,
+			}, nil
+		}
+	}
+	return nil, ErrDerivationProgressNotFound
+}
@@ -0,0 +1,130 @@
+package derive
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func l1Ref(num uint64, hash byte) eth.L1BlockRef {
+	return eth.L1BlockRef{Number: num, Hash: common.Hash{hash}}
+}
+
+func TestDerivationProgressTracker(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		var tracker *DerivationProgressTracker
+		tracker.RecordSafeL2Block(eth.L2BlockRef{Number: 1}, l1Ref(1, 1))
+		tracker.Rewind(0, 0)
+		_, err := tracker.AtL1(1)
+		require.ErrorIs(t, err, ErrDerivationProgressNotFound)
+	})
+
+	t.Run("Bounded", func(t *testing.T) {
+		tracker := NewDerivationProgressTracker(3)
+		for _, num := range []uint64{2, 1, 3, 4} {
+			tracker.RecordSafeL2Block(eth.L2BlockRef{Number: num * 10}, l1Ref(num, byte(num)))
+		}
+		_, err := tracker.AtL1(1)
+		require.ErrorIs(t, err, ErrDerivationProgressNotFound, "oldest L1 block is evicted")
+		p, err := tracker.AtL1(4)
+		require.NoError(t, err)
+		require.Equal(t, l1Ref(4, 4).ID(), p.L1Block)
+		require.Equal(t, []eth.L2BlockRef{{Number: 40}}, p.L2Blocks)
+
+		// blocks older than the kept blocks are not recorded
+		tracker.RecordSafeL2Block(eth.L2BlockRef{Number: 10}, l1Ref(1, 1))
+		_, err = tracker.AtL1(1)
+		require.ErrorIs(t, err, ErrDerivationProgressNotFound)
+	})
+
+	t.Run("Reorg", func(t *testing.T) {
+		tracker := NewDerivationProgressTracker(3)
+		tracker.RecordSafeL2Block(eth.L2BlockRef{Number: 10}, l1Ref(1, 1))
+		tracker.RecordSafeL2Block(eth.L2BlockRef{Number: 11}, l1Ref(1, 2))
+		p, err := tracker.AtL1(1)
+		require.NoError(t, err)
+		require.Equal(t, l1Ref(1, 2).ID(), p.L1Block)
+		require.Equal(t, []eth.L2BlockRef{{Number: 11}}, p.L2Blocks)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		tracker := NewDerivationProgressTracker(3)
+		for i := uint64(0); i <= maxProgressRecords; i++ {
+			tracker.RecordSafeL2Block(eth.L2BlockRef{Number: i}, l1Ref(1, 1))
+		}
+		p, err := tracker.AtL1(1)
+		require.NoError(t, err)
+		require.Len(t, p.L2Blocks, maxProgressRecords)
+		require.True(t, p.Truncated)
+	})
+
+	t.Run("Rewind", func(t *testing.T) {
+		tracker := NewDerivationProgressTracker(3)
+		tracker.recordFrame(l1Ref(1, 1), testFrame("a:0:data").ToFrame())
+		tracker.recordFrame(l1Ref(2, 2), testFrame("a:1:data!").ToFrame())
+		tracker.RecordSafeL2Block(eth.L2BlockRef{Number: 10}, l1Ref(2, 2))
+		tracker.RecordSafeL2Block(eth.L2BlockRef{Number: 11}, l1Ref(2, 2))
+		tracker.Rewind(2, 10)
+
+		p, err := tracker.AtL1(1)
+		require.NoError(t, err)
+		require.Len(t, p.Frames, 1)
+		p, err = tracker.AtL1(2)
+		require.NoError(t, err)
+		require.Empty(t, p.Frames)
+		require.Equal(t, []eth.L2BlockRef{{Number: 10}}, p.L2Blocks)
+	})
+}
+
+func TestDerivationProgressChannelBank(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	input := &fakeChannelBankInput{origin: testutils.RandomBlockRef(rng)}
+	input.AddFrames("a:0:first", "a:1:second!")
+	input.AddFrame(Frame{}, io.EOF)
+
+	cfg := &rollup.Config{ChannelTimeout: 10}
+	cb := NewChannelBank(testlog.Logger(t, log.LevelCrit), cfg, input, nil, metrics.NoopMetrics)
+	cb.progress = NewDerivationProgressTracker(DerivationProgressOrigins)
+
+	for {
+		if _, err := cb.NextData(context.Background()); err == io.EOF {
+			break
+		}
+	}
+	p, err := cb.progress.AtL1(input.origin.Number)
+	require.NoError(t, err)
+	id := testFrame("a:0:first").ChannelID()
+	require.Equal(t, []eth.DerivedChannel{{
+		ChannelID: hexutil.Bytes(id[:]),
+		Status:    eth.ChannelReady,
+		OpenedAt:  input.origin.ID(),
+		Frames:    2,
+		Size:      hexutil.Uint64(frameSize(testFrame("a:0:first").ToFrame()) + frameSize(testFrame("a:1:second!").ToFrame())),
+	}}, p.Channels)
+}
+
+func TestDerivationProgressBatches(t *testing.T) {
+	tracker := NewDerivationProgressTracker(DerivationProgressOrigins)
+	origin := l1Ref(1, 1)
+	tracker.recordBatch(origin, &SingularBatch{Timestamp: 10}, eth.BatchAccepted)
+	tracker.recordBatch(origin, &SpanBatch{Batches: []*SpanBatchElement{{Timestamp: 12}, {Timestamp: 14}}}, eth.BatchDropped)
+	p, err := tracker.AtL1(1)
+	require.NoError(t, err)
+	require.Equal(t, []eth.DerivedBatch{
+		{Type: "singular", Timestamp: 10, Blocks: 1, Status: eth.BatchAccepted},
+		{Type: "span", Timestamp: 12, Blocks: 2, Status: eth.BatchDropped},
+	}, p.Batches)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 type DeriverIdleEvent struct {
@@ -70,14 +71,17 @@ type PipelineDeriver struct {
 
 	emitter event.Emitter
 
+	tracer tracing.Tracer
+
 	needAttributesConfirmation bool
 }
 
-func NewPipelineDeriver(ctx context.Context, pipeline *DerivationPipeline, emitter event.Emitter) *PipelineDeriver {
+func NewPipelineDeriver(ctx context.Context, pipeline *DerivationPipeline, emitter event.Emitter, tracer tracing.Tracer) *PipelineDeriver {
 	return &PipelineDeriver{
 		pipeline: pipeline,
 		ctx:      ctx,
 		emitter:  emitter,
+		tracer:   tracer,
 	}
 }
 
@@ -93,7 +97,14 @@ func (d *PipelineDeriver) OnEvent(ev event.Event) {
 		}
 		d.pipeline.log.Trace("Derivation pipeline step", "onto_origin", d.pipeline.Origin())
 		preOrigin := d.pipeline.Origin()
-		attrib, err := d.pipeline.Step(d.ctx, x.PendingSafe)
+		ctx, span := d.tracer.Start(d.ctx, "derive.step",
+			tracing.Uint64("l1.origin", preOrigin.Number),
+			tracing.Uint64("l2.pending_safe", x.PendingSafe.Number))
+		attrib, err := d.pipeline.Step(ctx, x.PendingSafe)
+		if err != io.EOF {
+			span.RecordError(err)
+		}
+		span.End()
 		postOrigin := d.pipeline.Origin()
 		if preOrigin != postOrigin {
 			d.emitter.Emit(DeriverL1StatusEvent{Origin: postOrigin})
@@ -20,12 +20,21 @@ type FrameQueue struct {
 	log    log.Logger
 	frames []Frame
 	prev   NextDataProvider
+
+	// memSize is the estimated memory size of the queued frames
+	memSize uint64
+	// budget is the maximum memory size of the queued frames, unlimited if zero.
+	// The pipeline does not traverse more L1 data while it is exceeded.
+	budget uint64
+
+	progress *DerivationProgressTracker
 }
 
-func NewFrameQueue(log log.Logger, prev NextDataProvider) *FrameQueue {
+func NewFrameQueue(log log.Logger, prev NextDataProvider, budget uint64) *FrameQueue {
 	return &FrameQueue{
-		log:  log,
-		prev: prev,
+		log:    log,
+		prev:   prev,
+		budget: budget,
 	}
 }
 
@@ -41,6 +50,10 @@ func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
 		} else {
 			if new, err := ParseFrames(data); err == nil {
 				fq.frames = append(fq.frames, new...)
+				for _, f := range new {
+					fq.memSize += frameSize(f)
+					fq.progress.recordFrame(fq.prev.Origin(), f)
+				}
 			} else {
 				fq.log.Warn("Failed to parse frames", "origin", fq.prev.Origin(), "err", err)
 			}
@@ -53,10 +66,17 @@ func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
 
 	ret := fq.frames[0]
 	fq.frames = fq.frames[1:]
+	fq.memSize -= frameSize(ret)
 	return ret, nil
 }
 
+// MemSize returns the estimated memory size of the queued frames.
+func (fq *FrameQueue) MemSize() uint64 {
+	return fq.memSize
+}
+
 func (fq *FrameQueue) Reset(_ context.Context, _ eth.L1BlockRef, _ eth.SystemConfig) error {
 	fq.frames = fq.frames[:0]
+	fq.memSize = 0
 	return io.EOF
 }
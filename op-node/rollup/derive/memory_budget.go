@@ -0,0 +1,48 @@
+package derive
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxUnsafePayloadsMemory is the default memory budget for buffering unsafe payloads.
+const DefaultMaxUnsafePayloadsMemory = 500 * 1024 * 1024
+
+// ErrMemoryBudgetExceeded is returned, wrapped as a temporary error,
+// when a stage of the derivation pipeline holds more data than its memory budget.
+var ErrMemoryBudgetExceeded = errors.New("memory budget exceeded")
+
+// MemoryBudget limits the estimated memory held by the buffering stages of the derivation pipeline,
+// and by the unsafe payload queue.
+//
+// The channel bank is pruned to the protocol limit (see rollup.ChainSpec.MaxChannelBankSize) regardless of this budget.
+// When the frame queue or channel bank exceeds a lower budget, derivation stops reading L1 data and
+// traversing L1 until the buffered data is consumed. Data is never dropped below the protocol limit,
+// as that would make the node derive a different chain than other nodes.
+type MemoryBudget struct {
+	// FrameQueue is the budget of frames parsed from L1 data, but not yet ingested by the channel bank.
+	// L1 traversal is paused while it is exceeded. Unlimited if zero.
+	FrameQueue uint64 `json:"frame_queue"`
+	// ChannelBank is the budget of frames buffered in channels of the channel bank.
+	// No more frames are ingested while it is exceeded. Unlimited (only limited by the protocol) if zero.
+	ChannelBank uint64 `json:"channel_bank"`
+	// UnsafePayloads is the budget of unsafe payloads buffered for consolidation with the chain.
+	// Unlike the derivation stages, the lowest payloads are dropped when this budget is exceeded.
+	// DefaultMaxUnsafePayloadsMemory is used if zero.
+	UnsafePayloads uint64 `json:"unsafe_payloads"`
+}
+
+// MaxUnsafePayloadsMemory returns the unsafe payloads budget, or the default if none is set.
+func (b *MemoryBudget) MaxUnsafePayloadsMemory() uint64 {
+	if b.UnsafePayloads == 0 {
+		return DefaultMaxUnsafePayloadsMemory
+	}
+	return b.UnsafePayloads
+}
+
+func checkMemoryBudget(stage string, size uint64, budget uint64) error {
+	if budget != 0 && size > budget {
+		return NewTemporaryError(fmt.Errorf("%w: %s holds %d bytes, budget is %d bytes", ErrMemoryBudgetExceeded, stage, size, budget))
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeFrameQueueInput struct {
+	origin eth.L1BlockRef
+	data   [][]byte
+}
+
+func (f *fakeFrameQueueInput) Origin() eth.L1BlockRef {
+	return f.origin
+}
+
+func (f *fakeFrameQueueInput) NextData(_ context.Context) ([]byte, error) {
+	if len(f.data) == 0 {
+		return nil, io.EOF
+	}
+	out := f.data[0]
+	f.data = f.data[1:]
+	return out, nil
+}
+
+func encodeFrames(t *testing.T, frames ...testFrame) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(DerivationVersion0)
+	for _, tf := range frames {
+		f := tf.ToFrame()
+		require.NoError(t, f.MarshalBinary(&buf))
+	}
+	return buf.Bytes()
+}
+
+func TestFrameQueueMemoryBudget(t *testing.T) {
+	frames := []testFrame{"a:0:first", "a:1:second", "a:2:third!"}
+	var total uint64
+	for _, f := range frames {
+		total += frameSize(f.ToFrame())
+	}
+
+	t.Run("WithinBudget", func(t *testing.T) {
+		input := &fakeFrameQueueInput{data: [][]byte{encodeFrames(t, frames...)}}
+		fq := NewFrameQueue(testlog.Logger(t, log.LevelCrit), input, total)
+		for i, expected := range frames {
+			f, err := fq.NextFrame(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, expected.ToFrame(), f)
+			if i == 0 {
+				require.Equal(t, total-frameSize(f), fq.MemSize())
+			}
+		}
+		require.Zero(t, fq.MemSize())
+		_, err := fq.NextFrame(context.Background())
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("ExceedsBudget", func(t *testing.T) {
+		input := &fakeFrameQueueInput{data: [][]byte{encodeFrames(t, frames...)}}
+		fq := NewFrameQueue(testlog.Logger(t, log.LevelCrit), input, total-1)
+		// frames are never dropped, the pipeline stops traversing L1 instead
+		for _, expected := range frames {
+			f, err := fq.NextFrame(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, expected.ToFrame(), f)
+		}
+		require.Zero(t, fq.MemSize())
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		input := &fakeFrameQueueInput{data: [][]byte{encodeFrames(t, frames...)}}
+		fq := NewFrameQueue(testlog.Logger(t, log.LevelCrit), input, 0)
+		_, err := fq.NextFrame(context.Background())
+		require.NoError(t, err)
+	})
+}
+
+func TestChannelBankMemSize(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	input := &fakeChannelBankInput{origin: testutils.RandomBlockRef(rng)}
+	input.AddFrames("a:0:first", "b:0:other", "a:1:second!")
+
+	cfg := &rollup.Config{ChannelTimeout: 10}
+	cb := NewChannelBank(testlog.Logger(t, log.LevelCrit), cfg, input, nil, metrics.NoopMetrics)
+	require.Zero(t, cb.MemSize())
+
+	var expected uint64
+	for _, f := range []testFrame{"a:0:first", "b:0:other", "a:1:second!"} {
+		_, err := cb.NextData(context.Background())
+		require.ErrorIs(t, err, NotEnoughData)
+		expected += frameSize(f.ToFrame())
+		require.Equal(t, expected, cb.MemSize())
+	}
+
+	// reading the completed channel releases its frames
+	out, err := cb.NextData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "firstsecond", string(out))
+	require.Equal(t, frameSize(testFrame("b:0:other").ToFrame()), cb.MemSize())
+}
+
+func TestChannelBankMemoryBudget(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	input := &fakeChannelBankInput{origin: testutils.RandomBlockRef(rng)}
+	input.AddFrames("a:0:first", "b:0:other", "a:1:second!")
+
+	cfg := &rollup.Config{ChannelTimeout: 10}
+	cb := NewChannelBank(testlog.Logger(t, log.LevelCrit), cfg, input, nil, metrics.NoopMetrics)
+	// the budget fits the first frame, but not the first two frames
+	cb.budget = frameSize(testFrame("a:0:first").ToFrame())
+
+	for i := 0; i < 2; i++ {
+		_, err := cb.NextData(context.Background())
+		require.ErrorIs(t, err, NotEnoughData)
+	}
+	held := cb.MemSize()
+	require.Greater(t, held, cb.budget)
+
+	// no more frames are taken in once the budget is exceeded, and no channels are pruned
+	for i := 0; i < 3; i++ {
+		_, err := cb.NextData(context.Background())
+		require.ErrorIs(t, err, ErrMemoryBudgetExceeded)
+		require.ErrorIs(t, err, ErrTemporary)
+	}
+	require.Equal(t, held, cb.MemSize())
+	require.Len(t, cb.channelQueue, 2)
+
+	// derivation continues where it stopped once the budget allows it
+	cb.budget = 0
+	_, err := cb.NextData(context.Background())
+	require.ErrorIs(t, err, NotEnoughData)
+	out, err := cb.NextData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "firstsecond", string(out))
+}
+
+func TestMaxUnsafePayloadsMemory(t *testing.T) {
+	require.Equal(t, uint64(DefaultMaxUnsafePayloadsMemory), (&MemoryBudget{}).MaxUnsafePayloadsMemory())
+	require.Equal(t, uint64(1000), (&MemoryBudget{UnsafePayloads: 1000}).MaxUnsafePayloadsMemory())
+}
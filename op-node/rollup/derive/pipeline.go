@@ -23,6 +23,8 @@ type Metrics interface {
 	RecordDerivedBatches(batchType string)
 	SetDerivationIdle(idle bool)
 	RecordPipelineReset()
+	RecordDerivationMemSize(stage string, memSize uint64)
+	DepositMetrics
 }
 
 type L1Fetcher interface {
@@ -62,7 +64,12 @@ type DerivationPipeline struct {
 	stages    []ResettableStage
 
 	// Special stages to keep track of
-	traversal *L1Traversal
+	traversal  *L1Traversal
+	frameQueue *FrameQueue
+	bank       *ChannelBank
+
+	// progress keeps track of what is derived from the recent L1 blocks, for debugging
+	progress *DerivationProgressTracker
 
 	attrib *AttributesQueue
 
@@ -77,17 +84,19 @@ type DerivationPipeline struct {
 
 // NewDerivationPipeline creates a DerivationPipeline, to turn L1 data into L2 block-inputs.
 func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L1Fetcher, l1Blobs L1BlobsFetcher,
-	plasma PlasmaInputFetcher, l2Source L2Source, metrics Metrics) *DerivationPipeline {
+	plasma PlasmaInputFetcher, l2Source L2Source, metrics Metrics, memoryBudget MemoryBudget) *DerivationPipeline {
 
 	// Pull stages
 	l1Traversal := NewL1Traversal(log, rollupCfg, l1Fetcher)
 	dataSrc := NewDataSourceFactory(log, rollupCfg, l1Fetcher, l1Blobs, plasma) // auxiliary stage for L1Retrieval
 	l1Src := NewL1Retrieval(log, dataSrc, l1Traversal)
-	frameQueue := NewFrameQueue(log, l1Src)
+	frameQueue := NewFrameQueue(log, l1Src, memoryBudget.FrameQueue)
 	bank := NewChannelBank(log, rollupCfg, frameQueue, l1Fetcher, metrics)
+	bank.budget = memoryBudget.ChannelBank
 	chInReader := NewChannelInReader(rollupCfg, log, bank, metrics)
 	batchQueue := NewBatchQueue(log, rollupCfg, chInReader, l2Source)
 	attrBuilder := NewFetchingAttributesBuilder(rollupCfg, l1Fetcher, l2Source)
+	attrBuilder.metrics = metrics
 	attributesQueue := NewAttributesQueue(log, rollupCfg, attrBuilder, batchQueue)
 
 	// Reset from ResetEngine then up from L1 Traversal. The stages do not talk to each other during
@@ -95,17 +104,25 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 	// Note: The ResetEngine is the only reset that can fail.
 	stages := []ResettableStage{l1Traversal, l1Src, plasma, frameQueue, bank, chInReader, batchQueue, attributesQueue}
 
+	progress := NewDerivationProgressTracker(DerivationProgressOrigins)
+	frameQueue.progress = progress
+	bank.progress = progress
+	batchQueue.progress = progress
+
 	return &DerivationPipeline{
-		log:       log,
-		rollupCfg: rollupCfg,
-		l1Fetcher: l1Fetcher,
-		plasma:    plasma,
-		resetting: 0,
-		stages:    stages,
-		metrics:   metrics,
-		traversal: l1Traversal,
-		attrib:    attributesQueue,
-		l2:        l2Source,
+		log:        log,
+		rollupCfg:  rollupCfg,
+		l1Fetcher:  l1Fetcher,
+		plasma:     plasma,
+		resetting:  0,
+		stages:     stages,
+		metrics:    metrics,
+		traversal:  l1Traversal,
+		frameQueue: frameQueue,
+		bank:       bank,
+		progress:   progress,
+		attrib:     attributesQueue,
+		l2:         l2Source,
 	}
 }
 
@@ -183,6 +200,10 @@ func (dp *DerivationPipeline) Step(ctx context.Context, pendingSafeHead eth.L2Bl
 	if attrib, err := dp.attrib.NextAttributes(ctx, pendingSafeHead); err == nil {
 		return attrib, nil
 	} else if err == io.EOF {
+		// Apply backpressure: do not traverse more L1 data while the buffered data exceeds the memory budget.
+		if err := dp.checkMemoryBudget(); err != nil {
+			return nil, err
+		}
 		// If every stage has returned io.EOF, try to advance the L1 Origin
 		return nil, dp.traversal.AdvanceL1Block(ctx)
 	} else if errors.Is(err, EngineELSyncing) {
@@ -192,6 +213,18 @@ func (dp *DerivationPipeline) Step(ctx context.Context, pendingSafeHead eth.L2Bl
 	}
 }
 
+// checkMemoryBudget records the memory held by the buffering stages,
+// and returns a temporary error if they hold more data than their budget allows.
+func (dp *DerivationPipeline) checkMemoryBudget() error {
+	fqSize, bankSize := dp.frameQueue.MemSize(), dp.bank.MemSize()
+	dp.metrics.RecordDerivationMemSize("frame_queue", fqSize)
+	dp.metrics.RecordDerivationMemSize("channel_bank", bankSize)
+	if err := checkMemoryBudget("frame queue", fqSize, dp.frameQueue.budget); err != nil {
+		return err
+	}
+	return checkMemoryBudget("channel bank", bankSize, dp.bank.budget)
+}
+
 // initialReset does the initial reset work of finding the L1 point to rewind back to
 func (dp *DerivationPipeline) initialReset(ctx context.Context, resetL2Safe eth.L2BlockRef) error {
 	dp.log.Info("Rewinding derivation-pipeline L1 traversal to handle reset")
@@ -228,9 +261,15 @@ func (dp *DerivationPipeline) initialReset(ctx context.Context, resetL2Safe eth.
 	dp.origin = pipelineOrigin
 	dp.resetSysConfig = sysCfg
 	dp.resetL2Safe = resetL2Safe
+	dp.progress.Rewind(pipelineOrigin.Number, resetL2Safe.Number)
 	return nil
 }
 
+// Progress returns the bookkeeping of what the pipeline derived from the recent L1 blocks.
+func (dp *DerivationPipeline) Progress() *DerivationProgressTracker {
+	return dp.progress
+}
+
 func (dp *DerivationPipeline) ConfirmEngineReset() {
 	dp.engineIsReset = true
 }
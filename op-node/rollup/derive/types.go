@@ -9,7 +9,10 @@ type CompressionAlgo string
 
 const (
 	// compression algo types
-	Zlib     CompressionAlgo = "zlib"
+	Zlib     CompressionAlgo = "zlib" // best compression
+	Zlib1    CompressionAlgo = "zlib-1"
+	Zlib6    CompressionAlgo = "zlib-6"
+	Zlib9    CompressionAlgo = "zlib-9"
 	Brotli   CompressionAlgo = "brotli" // default level
 	Brotli9  CompressionAlgo = "brotli-9"
 	Brotli10 CompressionAlgo = "brotli-10"
@@ -18,13 +21,19 @@ const (
 
 var CompressionAlgos = []CompressionAlgo{
 	Zlib,
+	Zlib1,
+	Zlib6,
+	Zlib9,
 	Brotli,
 	Brotli9,
 	Brotli10,
 	Brotli11,
 }
 
-var brotliRegexp = regexp.MustCompile(`^brotli(|-(9|10|11))$`)
+var (
+	zlibRegexp   = regexp.MustCompile(`^zlib(|-(1|6|9))$`)
+	brotliRegexp = regexp.MustCompile(`^brotli(|-(9|10|11))$`)
+)
 
 func (algo CompressionAlgo) String() string {
 	return string(algo)
@@ -43,10 +52,27 @@ func (algo *CompressionAlgo) Clone() any {
 	return &cpy
 }
 
+func (algo *CompressionAlgo) IsZlib() bool {
+	return zlibRegexp.MatchString(algo.String())
+}
+
 func (algo *CompressionAlgo) IsBrotli() bool {
 	return brotliRegexp.MatchString(algo.String())
 }
 
+func GetZlibLevel(algo CompressionAlgo) int {
+	switch algo {
+	case Zlib1:
+		return 1
+	case Zlib6:
+		return 6
+	case Zlib9, Zlib: // plain zlib uses best compression
+		return 9
+	default:
+		panic("Unsupported zlib level")
+	}
+}
+
 func GetBrotliLevel(algo CompressionAlgo) int {
 	switch algo {
 	case Brotli9:
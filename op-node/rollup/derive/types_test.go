@@ -11,6 +11,8 @@ func TestCompressionAlgo(t *testing.T) {
 		name                       string
 		algo                       CompressionAlgo
 		isValidCompressionAlgoType bool
+		isZlib                     bool
+		zlibLevel                  int
 		isBrotli                   bool
 		brotliLevel                int
 	}{
@@ -18,7 +20,29 @@ func TestCompressionAlgo(t *testing.T) {
 			name:                       "zlib",
 			algo:                       Zlib,
 			isValidCompressionAlgoType: true,
-			isBrotli:                   false,
+			isZlib:                     true,
+			zlibLevel:                  9,
+		},
+		{
+			name:                       "zlib-1",
+			algo:                       Zlib1,
+			isValidCompressionAlgoType: true,
+			isZlib:                     true,
+			zlibLevel:                  1,
+		},
+		{
+			name:                       "zlib-6",
+			algo:                       Zlib6,
+			isValidCompressionAlgoType: true,
+			isZlib:                     true,
+			zlibLevel:                  6,
+		},
+		{
+			name:                       "zlib-9",
+			algo:                       Zlib9,
+			isValidCompressionAlgoType: true,
+			isZlib:                     true,
+			zlibLevel:                  9,
 		},
 		{
 			name:                       "brotli",
@@ -58,6 +82,12 @@ func TestCompressionAlgo(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.isZlib, tc.algo.IsZlib())
+			if tc.isZlib {
+				require.Equal(t, tc.zlibLevel, GetZlibLevel(tc.algo))
+			} else {
+				require.Panics(t, func() { GetZlibLevel(tc.algo) })
+			}
 			require.Equal(t, tc.isBrotli, tc.algo.IsBrotli())
 			if tc.isBrotli {
 				require.NotPanics(t, func() {
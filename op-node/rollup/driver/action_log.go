@@ -0,0 +1,221 @@
+package driver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SequencerActionKind is the kind of decision of the sequencer.
+type SequencerActionKind string
+
+const (
+	// ActionStart is the start of building a block with new payload attributes.
+	ActionStart SequencerActionKind = "start"
+	// ActionSeal is the completion of a block: retrieving the payload, committing it to the conductor, and inserting it.
+	ActionSeal SequencerActionKind = "seal"
+)
+
+// PayloadSource is where the sequencer took the payload of a sealed block from.
+type PayloadSource string
+
+const (
+	// PayloadFromEngine is a payload built by the execution engine.
+	PayloadFromEngine PayloadSource = "engine"
+	// PayloadFromGossiper is a previously built payload of the async gossiper, that was not inserted yet.
+	PayloadFromGossiper PayloadSource = "gossiper"
+)
+
+// ResultOK is the result of an action that succeeded.
+const ResultOK = "ok"
+
+// SequencerAction is a single decision of the sequencer, as recorded in the action log.
+type SequencerAction struct {
+	// Time is the unix time of the action, in milliseconds.
+	Time uint64 `json:"time"`
+	// Kind is the kind of the action.
+	Kind SequencerActionKind `json:"kind"`
+	// Parent is the L2 block that the block is built on top of.
+	Parent eth.BlockID `json:"parent"`
+	// Attributes are the payload attributes of a started block, to replay the decision.
+	Attributes *eth.PayloadAttributes `json:"attributes,omitempty"`
+	// AttributesHash is the hash of the JSON encoding of the payload attributes of the block, linking the sealing of
+	// a block to its start. Zero if the gossiper payload was sealed without a building job.
+	AttributesHash common.Hash `json:"attributes_hash"`
+	// Source is where the payload of a sealed block was taken from.
+	Source PayloadSource `json:"source,omitempty"`
+	// Block is the sealed block, if it was inserted.
+	Block *eth.BlockID `json:"block,omitempty"`
+	// Conductor is the result of committing the payload to the conductor. Empty if the payload was not committed.
+	Conductor string `json:"conductor,omitempty"`
+	// Result is ResultOK, or the error of the action.
+	Result string `json:"result"`
+}
+
+// RotatedActionLogSuffix is appended to the path of the action log for the previous log, after rotating it.
+const RotatedActionLogSuffix = ".1"
+
+// ActionLog is an append-only log of the decisions of the sequencer, one JSON-encoded SequencerAction per line.
+// The log is rotated when it reaches its maximum size: the previous log is kept at the path with the
+// RotatedActionLogSuffix, replacing an older one, so the logs take at most twice the maximum size on disk.
+// A nil ActionLog does not record anything.
+type ActionLog struct {
+	log     log.Logger
+	path    string
+	maxSize uint64
+
+	mu   sync.Mutex
+	f    *os.File
+	size uint64
+}
+
+// OpenActionLog opens the action log at the given path. New actions are appended to an existing log.
+// The log is rotated when it would exceed maxSize bytes, it is unbounded if maxSize is 0.
+func OpenActionLog(logger log.Logger, path string, maxSize uint64) (*ActionLog, error) {
+	a := &ActionLog{log: logger, path: path, maxSize: maxSize}
+	if err := a.open(os.O_APPEND); err != nil {
+		return nil, err
+	}
+	logger.Info("Recording sequencer actions", "path", path, "maxSize", maxSize)
+	return a, nil
+}
+
+func (a *ActionLog) open(flag int) error {
+	f, err := os.OpenFile(a.path, os.O_WRONLY|os.O_CREATE|flag, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open sequencer action log at %v: %w", a.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to stat sequencer action log at %v: %w", a.path, err)
+	}
+	a.f, a.size = f, uint64(info.Size())
+	return nil
+}
+
+// rotate moves the log to the path of the previous log, and starts a new log.
+func (a *ActionLog) rotate() error {
+	if err := a.f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(a.path, a.path+RotatedActionLogSuffix); err != nil {
+		// keep appending to the log rather than losing actions
+		a.log.Warn("Failed to rotate sequencer action log", "err", err)
+		return a.open(os.O_APPEND)
+	}
+	return a.open(os.O_TRUNC)
+}
+
+// Record appends the action to the log. Failures are logged, and never interrupt sequencing.
+func (a *ActionLog) Record(action SequencerAction) {
+	if a == nil {
+		return
+	}
+	data, err := json.Marshal(action)
+	if err != nil {
+		a.log.Warn("Failed to encode sequencer action", "err", err)
+		return
+	}
+	data = append(data, '\n')
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.f == nil {
+		return
+	}
+	if a.maxSize > 0 && a.size > 0 && a.size+uint64(len(data)) > a.maxSize {
+		if err := a.rotate(); err != nil {
+			a.log.Warn("Failed to reopen sequencer action log, no longer recording actions", "err", err)
+			a.f = nil
+			return
+		}
+	}
+	n, err := a.f.Write(data)
+	a.size += uint64(n)
+	if err != nil {
+		a.log.Warn("Failed to record sequencer action", "err", err)
+	}
+}
+
+func (a *ActionLog) Close() error {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.f == nil {
+		return nil
+	}
+	return a.f.Close()
+}
+
+// ReadActionLog reads all actions of an action log. An incomplete last line,
+// of which the write was interrupted, is ignored.
+func ReadActionLog(r io.Reader) ([]SequencerAction, error) {
+	var actions []SequencerAction
+	reader := bufio.NewReader(r)
+	for i := 1; ; i++ {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// the line is incomplete, or empty at the end of the log
+			return actions, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read action log: %w", err)
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var action SequencerAction
+		if err := json.Unmarshal(line, &action); err != nil {
+			return nil, fmt.Errorf("invalid action on line %d: %w", i, err)
+		}
+		actions = append(actions, action)
+	}
+}
+
+// attributesHash returns the hash of the JSON encoding of the payload attributes.
+func attributesHash(attrs *eth.PayloadAttributes) common.Hash {
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return common.Hash{}
+	}
+	return crypto.Keccak256Hash(data)
+}
+
+func actionTime(t time.Time) uint64 {
+	return uint64(t.UnixMilli())
+}
+
+func actionResult(err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return ResultOK
+}
+
+// recordingConductor records the result of committing a payload to the conductor.
+type recordingConductor struct {
+	conductor.SequencerConductor
+	result string
+}
+
+func (c *recordingConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *eth.PayloadMetadata) error {
+	err := c.SequencerConductor.CommitUnsafePayload(ctx, payload, metadata)
+	c.result = actionResult(err)
+	return err
+}
@@ -0,0 +1,173 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/async"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
+)
+
+// committingEngine commits the payload to the conductor before sealing, like the engine controller.
+type committingEngine struct {
+	*FakeEngineControl
+}
+
+func (m *committingEngine) SealJob(ctx context.Context, job *engine.BlockBuildingJob, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (*eth.ExecutionPayloadEnvelope, engine.BlockInsertionErrType, error) {
+	if err := sequencerConductor.CommitUnsafePayload(ctx, &eth.ExecutionPayloadEnvelope{}, nil); err != nil {
+		return nil, engine.BlockInsertTemporaryErr, derive.NewTemporaryError(err)
+	}
+	return m.FakeEngineControl.SealJob(ctx, job, agossip, sequencerConductor)
+}
+
+type failingConductor struct {
+	conductor.NoOpConductor
+	err error
+}
+
+func (c *failingConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, metadata *eth.PayloadMetadata) error {
+	return c.err
+}
+
+func TestSequencerActionLog(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     eth.BlockID{Hash: common.Hash{0xa1}, Number: 100},
+			L2:     eth.BlockID{Hash: common.Hash{0xb1}, Number: 200},
+			L2Time: 1000,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+	}
+	l1Origin := eth.L1BlockRef{Hash: cfg.Genesis.L1.Hash, Number: cfg.Genesis.L1.Number, Time: 990}
+	genesis := eth.L2BlockRef{Hash: cfg.Genesis.L2.Hash, Number: cfg.Genesis.L2.Number, Time: cfg.Genesis.L2Time, L1Origin: cfg.Genesis.L1}
+	now := time.Unix(1001, 0)
+	engControl := &FakeEngineControl{
+		finalized: genesis,
+		safe:      genesis,
+		unsafe:    genesis,
+		cfg:       cfg,
+		timeNow:   func() time.Time { return now },
+		makePayload: func(onto eth.L2BlockRef, attrs *eth.PayloadAttributes) *eth.ExecutionPayload {
+			return &eth.ExecutionPayload{
+				ParentHash:   onto.Hash,
+				BlockNumber:  eth.Uint64Quantity(onto.Number) + 1,
+				Timestamp:    attrs.Timestamp,
+				BlockHash:    common.Hash{0xb2},
+				Transactions: attrs.Transactions,
+			}
+		},
+	}
+	attrs := func() *eth.PayloadAttributes {
+		l1Info := &testutils.MockBlockInfo{InfoHash: l1Origin.Hash, InfoNum: l1Origin.Number, InfoTime: l1Origin.Time, InfoBaseFee: big.NewInt(7)}
+		infoDep, err := derive.L1InfoDepositBytes(cfg, cfg.Genesis.SystemConfig, 1, l1Info, 0)
+		require.NoError(t, err)
+		return &eth.PayloadAttributes{Timestamp: eth.Uint64Quantity(genesis.Time + cfg.BlockTime), Transactions: []eth.Data{infoDep}}
+	}
+	attrBuilder := testAttrBuilderFn(func(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (*eth.PayloadAttributes, error) {
+		return attrs(), nil
+	})
+	originSelector := testOriginSelectorFn(func(ctx context.Context, l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return l1Origin, nil
+	})
+
+	path := filepath.Join(t.TempDir(), "actions.log")
+	actionLog, err := OpenActionLog(logger, path, 0)
+	require.NoError(t, err)
+	seq := NewSequencer(logger, cfg, &committingEngine{engControl}, attrBuilder, originSelector, metrics.NoopMetrics, tracing.NoopTracer{})
+	seq.timeNow = engControl.timeNow
+	seq.actionLog = actionLog
+
+	agossip := async.NoOpGossiper{}
+	require.NoError(t, seq.StartBuildingBlock(context.Background()))
+	_, err = seq.CompleteBuildingBlock(context.Background(), agossip, &failingConductor{err: errors.New("not the leader")})
+	require.ErrorContains(t, err, "not the leader")
+	envelope, err := seq.CompleteBuildingBlock(context.Background(), agossip, &conductor.NoOpConductor{})
+	require.NoError(t, err)
+	require.NoError(t, actionLog.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	actions, err := ReadActionLog(f)
+	require.NoError(t, err)
+	attrsHash := attributesHash(attrs())
+	block := envelope.ExecutionPayload.ID()
+	require.Equal(t, []SequencerAction{
+		{Time: 1001000, Kind: ActionStart, Parent: genesis.ID(), Attributes: attrs(), AttributesHash: attrsHash, Result: ResultOK},
+		{Time: 1001000, Kind: ActionSeal, Parent: genesis.ID(), AttributesHash: attrsHash, Source: PayloadFromEngine,
+			Conductor: "not the leader", Result: "temp: not the leader"},
+		{Time: 1001000, Kind: ActionSeal, Parent: genesis.ID(), AttributesHash: attrsHash, Source: PayloadFromEngine,
+			Block: &block, Conductor: ResultOK, Result: ResultOK},
+	}, actions)
+}
+
+func TestActionLogRotation(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	path := filepath.Join(t.TempDir(), "actions.log")
+	action := func(i uint64) SequencerAction {
+		return SequencerAction{Time: i, Kind: ActionStart, Parent: eth.BlockID{Number: i}, Result: ResultOK}
+	}
+	read := func(path string) []SequencerAction {
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+		actions, err := ReadActionLog(f)
+		require.NoError(t, err)
+		return actions
+	}
+	data, err := json.Marshal(action(0))
+	require.NoError(t, err)
+	// fits three actions per log
+	maxSize := uint64(3*(len(data)+1) + 1)
+
+	actionLog, err := OpenActionLog(logger, path, maxSize)
+	require.NoError(t, err)
+	for i := uint64(0); i < 5; i++ {
+		actionLog.Record(action(i))
+	}
+	require.NoError(t, actionLog.Close())
+	require.Equal(t, []SequencerAction{action(0), action(1), action(2)}, read(path+RotatedActionLogSuffix))
+	require.Equal(t, []SequencerAction{action(3), action(4)}, read(path))
+
+	// the size of an existing log is taken into account after reopening it, older logs are replaced
+	actionLog, err = OpenActionLog(logger, path, maxSize)
+	require.NoError(t, err)
+	actionLog.Record(action(5))
+	actionLog.Record(action(6))
+	require.NoError(t, actionLog.Close())
+	require.Equal(t, []SequencerAction{action(3), action(4), action(5)}, read(path+RotatedActionLogSuffix))
+	require.Equal(t, []SequencerAction{action(6)}, read(path))
+}
+
+func TestReadActionLog(t *testing.T) {
+	t.Run("interrupted write", func(t *testing.T) {
+		actions, err := ReadActionLog(strings.NewReader("{\"kind\":\"start\",\"result\":\"ok\"}\n\n{\"kind\":\"seal\",\"res"))
+		require.NoError(t, err)
+		require.Equal(t, []SequencerAction{{Kind: ActionStart, Result: ResultOK}}, actions)
+	})
+	t.Run("invalid action", func(t *testing.T) {
+		_, err := ReadActionLog(strings.NewReader("{\"kind\":\"start\"}\nnot json\n{\"kind\":\"seal\"}\n"))
+		require.ErrorContains(t, err, "line 2")
+	})
+}
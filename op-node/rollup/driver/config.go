@@ -1,5 +1,10 @@
 package driver
 
+import (
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+)
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
@@ -20,4 +25,44 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// InclusionDeadlineBypassBuilder makes the sequencer insert the payloads of its execution engine instead of the
+	// payloads of the block builder while a transaction registered with an inclusion deadline is not included by its
+	// deadline. Missed deadlines are only reported if false.
+	InclusionDeadlineBypassBuilder bool `json:"inclusion_deadline_bypass_builder"`
+
+	// InclusionDeadlinesFile is the path that the transactions registered with an inclusion deadline are persisted to.
+	// Disabled if empty.
+	InclusionDeadlinesFile string `json:"inclusion_deadlines_file"`
+
+	// SequencerPolicyFile is the path that the tx pool policy of the sequencer is persisted to. Disabled if empty.
+	SequencerPolicyFile string `json:"sequencer_policy_file"`
+
+	// SequencerActionLog is the path of the log that the sequencing decisions are appended to. Disabled if empty.
+	SequencerActionLog string `json:"sequencer_action_log"`
+
+	// SequencerActionLogMaxSize is the size in bytes at which the action log is rotated. Unbounded if 0.
+	SequencerActionLogMaxSize uint64 `json:"sequencer_action_log_max_size"`
+
+	// MemoryBudget limits the memory used to buffer derivation data and unsafe payloads.
+	MemoryBudget derive.MemoryBudget `json:"memory_budget"`
+
+	// FinalityDelay is the number of L1 blocks that the L1 data of L2 blocks must be behind the finalized L1 block,
+	// before finalizing the L2 blocks. Disabled if 0.
+	FinalityDelay uint64 `json:"finality_delay"`
+
+	// FinalityRule overrides the rule that maps L1 finality to L2 finality, to customize finality of the chain.
+	// FinalityDelay is ignored if set. L2 blocks are finalized with their L1 data if nil.
+	FinalityRule finality.Rule `json:"-"`
+}
+
+// NewFinalityRule returns the rule to finalize L2 blocks with.
+func (cfg *Config) NewFinalityRule() finality.Rule {
+	if cfg.FinalityRule != nil {
+		return cfg.FinalityRule
+	}
+	if cfg.FinalityDelay > 0 {
+		return &finality.ConfirmationDelay{Delay: cfg.FinalityDelay}
+	}
+	return finality.L1Finality{}
 }
@@ -18,14 +18,17 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 type Metrics interface {
 	RecordPipelineReset()
+	RecordDerivationMemSize(stage string, memSize uint64)
 	RecordPublishingError()
 	RecordDerivationError()
 
@@ -39,6 +42,8 @@ type Metrics interface {
 	RecordFrame()
 
 	RecordDerivedBatches(batchType string)
+	RecordDerivedDeposits(included int, pending int)
+	RecordInclusionDeadlineDropped()
 
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
 
@@ -79,10 +84,12 @@ type EngineController interface {
 	InsertUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, ref eth.L2BlockRef) error
 	TryUpdateEngine(ctx context.Context) error
 	TryBackupUnsafeReorg(ctx context.Context) (bool, error)
+	QueueState() eth.EngineQueueState
 }
 
 type CLSync interface {
 	LowestQueuedUnsafeBlock() eth.L2BlockRef
+	QueuedUnsafePayloads() []eth.BlockID
 }
 
 type AttributesHandler interface {
@@ -114,6 +121,7 @@ type SyncStatusTracker interface {
 	event.Deriver
 	SyncStatus() *eth.SyncStatus
 	L1Head() eth.L1BlockRef
+	Subscribe(ch chan<- *eth.SyncStatus) (unsubscribe func())
 }
 
 type SequencerIface interface {
@@ -173,11 +181,17 @@ func NewDriver(
 	network Network,
 	log log.Logger,
 	metrics Metrics,
+	tracer tracing.Tracer,
 	sequencerStateListener SequencerStateListener,
 	safeHeadListener rollup.SafeHeadListener,
 	syncCfg *sync.Config,
 	sequencerConductor conductor.SequencerConductor,
+	actionLog *ActionLog,
+	inclusionDeadlines *InclusionDeadlines,
+	sequencerPolicy *SequencerPolicy,
 	plasma PlasmaIface,
+	safetyGate interop.SafetyGate,
+	builder engine.BuilderClient,
 ) *Driver {
 	driverCtx, driverCancel := context.WithCancel(context.Background())
 	rootDeriver := &event.DeriverMux{}
@@ -191,42 +205,57 @@ func NewDriver(
 	statusTracker := status.NewStatusTracker(log, metrics)
 
 	l1 = NewMeteredL1Fetcher(l1, metrics)
-	sequencerConfDepth := NewConfDepth(driverCfg.SequencerConfDepth, statusTracker.L1Head, l1)
-	findL1Origin := NewL1OriginSelector(log, cfg, sequencerConfDepth)
 	verifConfDepth := NewConfDepth(driverCfg.VerifierConfDepth, statusTracker.L1Head, l1)
 	ec := engine.NewEngineController(l2, log, metrics, cfg, syncCfg, synchronousEvents)
 	engineResetDeriver := engine.NewEngineResetDeriver(driverCtx, log, cfg, l1, l2, syncCfg, synchronousEvents)
-	clSync := clsync.NewCLSync(log, cfg, metrics, synchronousEvents)
+	clSync := clsync.NewCLSync(log, cfg, metrics, synchronousEvents, driverCfg.MemoryBudget.MaxUnsafePayloadsMemory())
 
 	var finalizer Finalizer
 	if cfg.PlasmaEnabled() {
-		finalizer = finality.NewPlasmaFinalizer(driverCtx, log, cfg, l1, synchronousEvents, plasma)
+		finalizer = finality.NewPlasmaFinalizer(driverCtx, log, cfg, l1, synchronousEvents, plasma, driverCfg.NewFinalityRule())
 	} else {
-		finalizer = finality.NewFinalizer(driverCtx, log, cfg, l1, synchronousEvents)
+		finalizer = finality.NewFinalizer(driverCtx, log, cfg, l1, synchronousEvents, driverCfg.NewFinalityRule())
 	}
 
 	attributesHandler := attributes.NewAttributesHandler(log, cfg, driverCtx, l2, synchronousEvents)
-	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, plasma, l2, metrics)
-	pipelineDeriver := derive.NewPipelineDeriver(driverCtx, derivationPipeline, synchronousEvents)
-	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
-	meteredEngine := NewMeteredEngine(cfg, ec, metrics, log) // Only use the metered engine in the sequencer b/c it records sequencing metrics.
-	sequencer := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics)
-	asyncGossiper := async.NewAsyncGossiper(driverCtx, network, log, metrics)
+	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, plasma, l2, metrics, driverCfg.MemoryBudget)
+	pipelineDeriver := derive.NewPipelineDeriver(driverCtx, derivationPipeline, synchronousEvents, tracer)
+
+	// Sequencer-only components are not instantiated in verifier mode:
+	// the sequencer is never started, and the API methods that use them return an error.
+	var sequencer SequencerIface
+	var asyncGossiper async.AsyncGossiper = async.NoOpGossiper{}
+	if driverCfg.SequencerEnabled {
+		sequencerConfDepth := NewConfDepth(driverCfg.SequencerConfDepth, statusTracker.L1Head, l1)
+		findL1Origin := NewL1OriginSelector(log, cfg, sequencerConfDepth)
+		attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
+		meteredEngine := NewMeteredEngine(cfg, ec, metrics, log) // Only use the metered engine in the sequencer b/c it records sequencing metrics.
+		seq := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics, tracer)
+		seq.inclusion = inclusionDeadlines
+		seq.policy = sequencerPolicy
+		seq.actionLog = actionLog
+		sequencer = seq
+		asyncGossiper = async.NewAsyncGossiper(driverCtx, network, log, metrics)
+		if builder != nil {
+			ec.SetBuilder(&inclusionBuilder{BuilderClient: builder, deadlines: inclusionDeadlines})
+		}
+	}
 
 	syncDeriver := &SyncDeriver{
-		Derivation:     derivationPipeline,
-		Finalizer:      finalizer,
-		SafeHeadNotifs: safeHeadListener,
-		CLSync:         clSync,
-		Engine:         ec,
-		SyncCfg:        syncCfg,
-		Config:         cfg,
-		L1:             l1,
-		L2:             l2,
-		Emitter:        synchronousEvents,
-		Log:            log,
-		Ctx:            driverCtx,
-		Drain:          synchronousEvents.Drain,
+		Derivation:         derivationPipeline,
+		Finalizer:          finalizer,
+		SafeHeadNotifs:     safeHeadListener,
+		DerivationProgress: derivationPipeline.Progress(),
+		CLSync:             clSync,
+		Engine:             ec,
+		SyncCfg:            syncCfg,
+		Config:             cfg,
+		L1:                 l1,
+		L2:                 l2,
+		Emitter:            synchronousEvents,
+		Log:                log,
+		Ctx:                driverCtx,
+		Drain:              synchronousEvents.Drain,
 	}
 	engDeriv := engine.NewEngDeriver(log, driverCtx, cfg, ec, synchronousEvents)
 	schedDeriv := NewStepSchedulingDeriver(log, synchronousEvents)
@@ -253,9 +282,15 @@ func NewDriver(
 		l1SafeSig:          make(chan eth.L1BlockRef, 10),
 		l1FinalizedSig:     make(chan eth.L1BlockRef, 10),
 		unsafeL2Payloads:   make(chan *eth.ExecutionPayloadEnvelope, 10),
+		claimedSafeSig:     make(chan eth.L2BlockRef, 10),
 		altSync:            altSync,
 		asyncGossiper:      asyncGossiper,
 		sequencerConductor: sequencerConductor,
+		inclusionDeadlines: inclusionDeadlines,
+		sequencerPolicy:    sequencerPolicy,
+		reorgGuard:         ec.ReorgGuard(),
+		builderStats:       ec.BuilderStats(),
+		asyncEvents:        make(chan event.Event, 10),
 	}
 
 	*rootDeriver = []event.Deriver{
@@ -270,6 +305,10 @@ func NewDriver(
 		finalizer,
 		statusTracker,
 	}
+	if safetyGate != nil {
+		engDeriv.EnableCrossSafeChecks()
+		*rootDeriver = append(*rootDeriver, interop.NewCrossSafeDeriver(driverCtx, log, safetyGate, event.EmitterFunc(driver.emitAsync)))
+	}
 
 	return driver
 }
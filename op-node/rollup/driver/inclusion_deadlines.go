@@ -0,0 +1,250 @@
+package driver
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+// maxInclusionDeadlines bounds the number of transactions that can be tracked at once.
+const maxInclusionDeadlines = 1000
+
+// MaxMissedInclusionBlocks is the number of blocks after a missed deadline that a transaction is still tracked for.
+// The transaction is dropped afterwards, as it may never be included, e.g. if it was replaced or became invalid.
+const MaxMissedInclusionBlocks = 32
+
+var (
+	ErrInclusionDeadlineNotFound = errors.New("inclusion deadline not found")
+	ErrTooManyInclusionDeadlines = errors.New("too many inclusion deadlines")
+)
+
+type InclusionDeadlineMetrics interface {
+	RecordInclusionDeadlineDropped()
+}
+
+// InclusionDeadlines tracks transactions that operators require the sequencer to include within a number of blocks,
+// to guard against a misbehaving mempool or block builder that keeps excluding them.
+// Every unsafe block, built by the sequencer or received from other nodes, is checked for the transactions:
+// included transactions are forgotten, and transactions that are not included by their deadline are reported as missed.
+// Missed transactions are dropped, with an error, MaxMissedInclusionBlocks after their deadline.
+// If bypassBuilder is set, the sequencer inserts the payloads of its own execution engine instead of the payloads of
+// an external block builder while a deadline is missed, to stop extending the chain with blocks of a misbehaving builder.
+// The tracked transactions are persisted to a file, if any, so that they survive restarts.
+// It is safe for concurrent use. A nil InclusionDeadlines does not track anything.
+type InclusionDeadlines struct {
+	log           log.Logger
+	metrics       InclusionDeadlineMetrics
+	bypassBuilder bool
+	file          string
+
+	mu      sync.Mutex
+	entries map[common.Hash]*eth.InclusionDeadline
+}
+
+// NewInclusionDeadlines creates the tracker, and loads the tracked transactions from the file if it is set and exists.
+func NewInclusionDeadlines(log log.Logger, m InclusionDeadlineMetrics, bypassBuilder bool, file string) (*InclusionDeadlines, error) {
+	d := &InclusionDeadlines{
+		log:           log,
+		metrics:       m,
+		bypassBuilder: bypassBuilder,
+		file:          file,
+		entries:       make(map[common.Hash]*eth.InclusionDeadline),
+	}
+	if file == "" {
+		return d, nil
+	}
+	if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+		return d, nil
+	}
+	entries, err := jsonutil.LoadJSON[[]eth.InclusionDeadline](file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load inclusion deadlines: %w", err)
+	}
+	for _, entry := range *entries {
+		entry := entry
+		d.entries[entry.TxHash] = &entry
+	}
+	return d, nil
+}
+
+// Add registers the transaction, to be included within the given number of blocks after the unsafe L2 head.
+// Registering a transaction again replaces the deadline.
+func (d *InclusionDeadlines) Add(txHash common.Hash, blocks uint64, unsafeHead uint64) (eth.InclusionDeadline, error) {
+	if blocks == 0 {
+		return eth.InclusionDeadline{}, errors.New("deadline must be at least one block")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.entries[txHash]; !ok && len(d.entries) >= maxInclusionDeadlines {
+		return eth.InclusionDeadline{}, ErrTooManyInclusionDeadlines
+	}
+	entry := &eth.InclusionDeadline{
+		TxHash:       txHash,
+		RegisteredAt: hexutil.Uint64(unsafeHead),
+		Deadline:     hexutil.Uint64(unsafeHead + blocks),
+	}
+	prev, replaced := d.entries[txHash]
+	d.entries[txHash] = entry
+	if err := d.persist(); err != nil {
+		if replaced {
+			d.entries[txHash] = prev
+		} else {
+			delete(d.entries, txHash)
+		}
+		return eth.InclusionDeadline{}, err
+	}
+	d.log.Info("Registered transaction inclusion deadline", "tx", txHash, "deadline", unsafeHead+blocks)
+	return *entry, nil
+}
+
+// Remove forgets the transaction.
+func (d *InclusionDeadlines) Remove(txHash common.Hash) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.entries[txHash]
+	if !ok {
+		return ErrInclusionDeadlineNotFound
+	}
+	delete(d.entries, txHash)
+	if err := d.persist(); err != nil {
+		d.entries[txHash] = entry
+		return err
+	}
+	d.log.Info("Removed transaction inclusion deadline", "tx", txHash)
+	return nil
+}
+
+// List returns the tracked transactions, sorted by deadline.
+func (d *InclusionDeadlines) List() []eth.InclusionDeadline {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.list()
+}
+
+func (d *InclusionDeadlines) list() []eth.InclusionDeadline {
+	out := make([]eth.InclusionDeadline, 0, len(d.entries))
+	for _, entry := range d.entries {
+		out = append(out, *entry)
+	}
+	slices.SortFunc(out, func(a, b eth.InclusionDeadline) int {
+		if c := cmp.Compare(a.Deadline, b.Deadline); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.TxHash[:], b.TxHash[:])
+	})
+	return out
+}
+
+// Missed returns the number of transactions that missed their deadline.
+func (d *InclusionDeadlines) Missed() int {
+	if d == nil {
+		return 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	missed := 0
+	for _, entry := range d.entries {
+		if entry.Missed {
+			missed++
+		}
+	}
+	return missed
+}
+
+// BypassBuilder returns true if the next block must not be built by an external block builder,
+// since a transaction missed its deadline.
+func (d *InclusionDeadlines) BypassBuilder() bool {
+	return d != nil && d.bypassBuilder && d.Missed() > 0
+}
+
+// OnUnsafeBlock checks an unsafe block for the tracked transactions.
+func (d *InclusionDeadlines) OnUnsafeBlock(payload *eth.ExecutionPayload) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.entries) == 0 {
+		return
+	}
+	changed := false
+	num := uint64(payload.BlockNumber)
+	for _, tx := range payload.Transactions {
+		// the hash of a transaction is the hash of its binary encoding
+		txHash := crypto.Keccak256Hash(tx)
+		if entry, ok := d.entries[txHash]; ok {
+			d.log.Info("Transaction with inclusion deadline was included", "tx", txHash, "block", payload.ID(), "deadline", uint64(entry.Deadline), "missed", entry.Missed)
+			delete(d.entries, txHash)
+			changed = true
+		}
+	}
+	for txHash, entry := range d.entries {
+		if num < uint64(entry.Deadline) {
+			continue
+		}
+		if num >= uint64(entry.Deadline)+MaxMissedInclusionBlocks {
+			d.log.Error("Dropping transaction that was not included within its inclusion deadline, it may have been replaced or be invalid",
+				"tx", txHash, "deadline", uint64(entry.Deadline), "block", payload.ID())
+			d.metrics.RecordInclusionDeadlineDropped()
+			delete(d.entries, txHash)
+			changed = true
+			continue
+		}
+		if entry.Missed {
+			continue
+		}
+		entry.Missed = true
+		changed = true
+		d.log.Error("Transaction was not included by its inclusion deadline, the mempool or block builder may be excluding it",
+			"tx", entry.TxHash, "deadline", uint64(entry.Deadline), "block", payload.ID(), "bypassBuilder", d.bypassBuilder)
+	}
+	if changed {
+		if err := d.persist(); err != nil {
+			d.log.Error("Failed to persist inclusion deadlines", "err", err)
+		}
+	}
+}
+
+// persist writes the tracked transactions to the file, if any. The lock must be held.
+func (d *InclusionDeadlines) persist() error {
+	if d.file == "" {
+		return nil
+	}
+	if err := jsonutil.WriteJSON(d.file, d.list(), 0o644); err != nil {
+		return fmt.Errorf("failed to persist inclusion deadlines: %w", err)
+	}
+	return nil
+}
+
+// inclusionBuilder disables the external block builder while a transaction missed its inclusion deadline.
+type inclusionBuilder struct {
+	engine.BuilderClient
+	deadlines *InclusionDeadlines
+}
+
+func (b *inclusionBuilder) Enabled() bool {
+	if !b.BuilderClient.Enabled() {
+		return false
+	}
+	if b.deadlines.BypassBuilder() {
+		b.deadlines.log.Warn("Bypassing the block builder, a transaction missed its inclusion deadline", "missed", b.deadlines.Missed())
+		return false
+	}
+	return true
+}
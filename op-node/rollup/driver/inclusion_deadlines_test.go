@@ -0,0 +1,178 @@
+package driver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testDeadlineMetrics struct {
+	dropped int
+}
+
+func (m *testDeadlineMetrics) RecordInclusionDeadlineDropped() {
+	m.dropped++
+}
+
+type testBuilder struct {
+	enabled bool
+}
+
+func (b *testBuilder) Start(ctx context.Context) error { return nil }
+func (b *testBuilder) Close() error                    { return nil }
+func (b *testBuilder) Enabled() bool                   { return b.enabled }
+func (b *testBuilder) GetPayload(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes) (*eth.ExecutionPayloadEnvelope, error) {
+	return nil, nil
+}
+
+var _ engine.BuilderClient = (*testBuilder)(nil)
+
+func newTestInclusionDeadlines(t *testing.T, bypassBuilder bool, file string) (*InclusionDeadlines, *testDeadlineMetrics) {
+	m := &testDeadlineMetrics{}
+	d, err := NewInclusionDeadlines(testlog.Logger(t, log.LevelCrit), m, bypassBuilder, file)
+	require.NoError(t, err)
+	return d, m
+}
+
+func testPayload(num uint64, txs ...eth.Data) *eth.ExecutionPayload {
+	return &eth.ExecutionPayload{BlockNumber: eth.Uint64Quantity(num), Transactions: txs}
+}
+
+func TestInclusionDeadlines(t *testing.T) {
+	tx1, tx2 := eth.Data{0x02, 0x01}, eth.Data{0x02, 0x02}
+	hash1, hash2 := crypto.Keccak256Hash(tx1), crypto.Keccak256Hash(tx2)
+
+	t.Run("Included", func(t *testing.T) {
+		d, _ := newTestInclusionDeadlines(t, true, "")
+		_, err := d.Add(hash1, 2, 10)
+		require.NoError(t, err)
+		d.OnUnsafeBlock(testPayload(11))
+		d.OnUnsafeBlock(testPayload(12, tx1))
+		require.Empty(t, d.List())
+		require.Zero(t, d.Missed())
+		require.False(t, d.BypassBuilder())
+	})
+
+	t.Run("Missed", func(t *testing.T) {
+		for _, bypassBuilder := range []bool{false, true} {
+			d, _ := newTestInclusionDeadlines(t, bypassBuilder, "")
+			_, err := d.Add(hash1, 2, 10)
+			require.NoError(t, err)
+			_, err = d.Add(hash2, 5, 10)
+			require.NoError(t, err)
+			d.OnUnsafeBlock(testPayload(11))
+			require.Zero(t, d.Missed())
+			d.OnUnsafeBlock(testPayload(12))
+			require.Equal(t, 1, d.Missed())
+			require.Equal(t, bypassBuilder, d.BypassBuilder())
+			require.Equal(t, []eth.InclusionDeadline{
+				{TxHash: hash1, RegisteredAt: 10, Deadline: 12, Missed: true},
+				{TxHash: hash2, RegisteredAt: 10, Deadline: 15},
+			}, d.List())
+
+			// a late inclusion stops bypassing the builder
+			d.OnUnsafeBlock(testPayload(13, tx1))
+			require.Zero(t, d.Missed())
+			require.False(t, d.BypassBuilder())
+			require.Len(t, d.List(), 1)
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		d, _ := newTestInclusionDeadlines(t, true, "")
+		_, err := d.Add(hash1, 1, 10)
+		require.NoError(t, err)
+		d.OnUnsafeBlock(testPayload(11))
+		require.True(t, d.BypassBuilder())
+		require.NoError(t, d.Remove(hash1))
+		require.False(t, d.BypassBuilder())
+		require.ErrorIs(t, d.Remove(hash1), ErrInclusionDeadlineNotFound)
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		d, _ := newTestInclusionDeadlines(t, false, "")
+		_, err := d.Add(hash1, 0, 10)
+		require.ErrorContains(t, err, "at least one block")
+		for i := 0; i < maxInclusionDeadlines; i++ {
+			_, err := d.Add(common.Hash{byte(i), byte(i >> 8)}, 10, 10)
+			require.NoError(t, err)
+		}
+		_, err = d.Add(hash1, 10, 10)
+		require.ErrorIs(t, err, ErrTooManyInclusionDeadlines)
+		// updating a registered deadline is still possible
+		updated, err := d.Add(common.Hash{}, 20, 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 30, updated.Deadline)
+	})
+
+	t.Run("Dropped", func(t *testing.T) {
+		d, m := newTestInclusionDeadlines(t, true, "")
+		_, err := d.Add(hash1, 1, 10)
+		require.NoError(t, err)
+		d.OnUnsafeBlock(testPayload(11))
+		require.True(t, d.BypassBuilder())
+		d.OnUnsafeBlock(testPayload(11 + MaxMissedInclusionBlocks - 1))
+		require.True(t, d.BypassBuilder())
+		require.Zero(t, m.dropped)
+		d.OnUnsafeBlock(testPayload(11 + MaxMissedInclusionBlocks))
+		require.False(t, d.BypassBuilder())
+		require.Empty(t, d.List())
+		require.Equal(t, 1, m.dropped)
+	})
+
+	t.Run("Persisted", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "deadlines.json")
+		d, _ := newTestInclusionDeadlines(t, true, file)
+		_, err := d.Add(hash1, 1, 10)
+		require.NoError(t, err)
+		_, err = d.Add(hash2, 5, 10)
+		require.NoError(t, err)
+		d.OnUnsafeBlock(testPayload(11))
+
+		restarted, _ := newTestInclusionDeadlines(t, true, file)
+		require.Equal(t, d.List(), restarted.List())
+		require.True(t, restarted.BypassBuilder())
+
+		require.NoError(t, restarted.Remove(hash1))
+		restarted, _ = newTestInclusionDeadlines(t, true, file)
+		require.Equal(t, []eth.InclusionDeadline{{TxHash: hash2, RegisteredAt: 10, Deadline: 15}}, restarted.List())
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var d *InclusionDeadlines
+		d.OnUnsafeBlock(testPayload(1))
+		require.False(t, d.BypassBuilder())
+		require.Empty(t, d.List())
+	})
+}
+
+func TestInclusionBuilder(t *testing.T) {
+	tx := eth.Data{0x02, 0x01}
+	d, _ := newTestInclusionDeadlines(t, true, "")
+	builder := &testBuilder{enabled: true}
+	b := &inclusionBuilder{BuilderClient: builder, deadlines: d}
+	require.True(t, b.Enabled())
+
+	_, err := d.Add(crypto.Keccak256Hash(tx), 1, 10)
+	require.NoError(t, err)
+	d.OnUnsafeBlock(testPayload(11))
+	require.False(t, b.Enabled(), "bypassed while a deadline is missed")
+
+	d.OnUnsafeBlock(testPayload(12, tx))
+	require.True(t, b.Enabled())
+
+	builder.enabled = false
+	require.False(t, b.Enabled())
+
+	b = &inclusionBuilder{BuilderClient: &testBuilder{enabled: true}}
+	require.True(t, b.Enabled(), "nil deadlines never bypass the builder")
+}
@@ -54,19 +54,19 @@ func (m *MeteredEngine) SafeL2Head() eth.L2BlockRef {
 	return m.inner.SafeL2Head()
 }
 
-func (m *MeteredEngine) StartPayload(ctx context.Context, parent eth.L2BlockRef, attrs *derive.AttributesWithParent, updateSafe bool) (errType engine.BlockInsertionErrType, err error) {
+func (m *MeteredEngine) StartBuildingJob(ctx context.Context, parent eth.L2BlockRef, attrs *derive.AttributesWithParent, updateSafe bool) (*engine.BlockBuildingJob, engine.BlockInsertionErrType, error) {
 	m.buildingStartTime = time.Now()
-	errType, err = m.inner.StartPayload(ctx, parent, attrs, updateSafe)
+	job, errType, err := m.inner.StartBuildingJob(ctx, parent, attrs, updateSafe)
 	if err != nil {
 		m.metrics.RecordSequencingError()
 	}
-	return errType, err
+	return job, errType, err
 }
 
-func (m *MeteredEngine) ConfirmPayload(ctx context.Context, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (out *eth.ExecutionPayloadEnvelope, errTyp engine.BlockInsertionErrType, err error) {
+func (m *MeteredEngine) SealJob(ctx context.Context, job *engine.BlockBuildingJob, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (out *eth.ExecutionPayloadEnvelope, errTyp engine.BlockInsertionErrType, err error) {
 	sealingStart := time.Now()
 	// Actually execute the block and add it to the head of the chain.
-	payload, errType, err := m.inner.ConfirmPayload(ctx, agossip, sequencerConductor)
+	payload, errType, err := m.inner.SealJob(ctx, job, agossip, sequencerConductor)
 	if err != nil {
 		m.metrics.RecordSequencingError()
 		return payload, errType, err
@@ -88,10 +88,10 @@ func (m *MeteredEngine) ConfirmPayload(ctx context.Context, agossip async.AsyncG
 	return payload, errType, err
 }
 
-func (m *MeteredEngine) CancelPayload(ctx context.Context, force bool) error {
-	return m.inner.CancelPayload(ctx, force)
+func (m *MeteredEngine) CancelJob(ctx context.Context, job *engine.BlockBuildingJob, force bool) error {
+	return m.inner.CancelJob(ctx, job, force)
 }
 
-func (m *MeteredEngine) BuildingPayload() (onto eth.L2BlockRef, id eth.PayloadID, safe bool) {
-	return m.inner.BuildingPayload()
+func (m *MeteredEngine) BuildingJob() *engine.BlockBuildingJob {
+	return m.inner.BuildingJob()
 }
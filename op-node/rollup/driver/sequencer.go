@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 type Downloader interface {
@@ -36,7 +37,6 @@ type SequencerMetrics interface {
 type Sequencer struct {
 	log       log.Logger
 	rollupCfg *rollup.Config
-	spec      *rollup.ChainSpec
 
 	engine engine.EngineControl
 
@@ -45,28 +45,44 @@ type Sequencer struct {
 
 	metrics SequencerMetrics
 
+	tracer tracing.Tracer
+
+	// inclusion tracks the transactions that must be included before a deadline, may be nil.
+	inclusion *InclusionDeadlines
+
+	// policy is the tx pool policy to build blocks with, may be nil.
+	policy *SequencerPolicy
+
+	// actionLog records the sequencing decisions, may be nil.
+	actionLog *ActionLog
+
 	// timeNow enables sequencer testing to mock the time
 	timeNow func() time.Time
 
 	nextAction time.Time
 }
 
-func NewSequencer(log log.Logger, rollupCfg *rollup.Config, engine engine.EngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, metrics SequencerMetrics) *Sequencer {
+func NewSequencer(log log.Logger, rollupCfg *rollup.Config, engine engine.EngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, metrics SequencerMetrics, tracer tracing.Tracer) *Sequencer {
 	return &Sequencer{
 		log:              log,
 		rollupCfg:        rollupCfg,
-		spec:             rollup.NewChainSpec(rollupCfg),
 		engine:           engine,
 		timeNow:          time.Now,
 		attrBuilder:      attributesBuilder,
 		l1OriginSelector: l1OriginSelector,
 		metrics:          metrics,
+		tracer:           tracer,
 	}
 }
 
 // StartBuildingBlock initiates a block building job on top of the given L2 head, safe and finalized blocks, and using the provided l1Origin.
-func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
+func (d *Sequencer) StartBuildingBlock(ctx context.Context) (outErr error) {
 	l2Head := d.engine.UnsafeL2Head()
+	ctx, span := d.tracer.Start(ctx, "sequencer.start_block", tracing.Uint64("l2.parent", l2Head.Number))
+	defer func() {
+		span.RecordError(outErr)
+		span.End()
+	}()
 
 	// Figure out which L1 origin block we're going to be building on top of.
 	l1Origin, err := d.l1OriginSelector.FindL1Origin(ctx, l2Head)
@@ -93,42 +109,76 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	// If our next L2 block timestamp is beyond the Sequencer drift threshold, then we must produce
 	// empty blocks (other than the L1 info deposit and any user deposits). We handle this by
 	// setting NoTxPool to true, which will cause the Sequencer to not include any transactions
-	// from the transaction pool.
-	attrs.NoTxPool = uint64(attrs.Timestamp) > l1Origin.Time+d.spec.MaxSequencerDrift(l1Origin.Time)
+	// from the transaction pool. For network upgrade activation blocks we shouldn't include any
+	// sequencer transactions either.
+	attrs.NoTxPool = derive.SequencerNoTxPool(d.rollupCfg, uint64(attrs.Timestamp), l1Origin.Time)
+	d.policy.Apply(attrs)
 
-	// For the Ecotone activation block we shouldn't include any sequencer transactions.
 	if d.rollupCfg.IsEcotoneActivationBlock(uint64(attrs.Timestamp)) {
-		attrs.NoTxPool = true
 		d.log.Info("Sequencing Ecotone upgrade block")
 	}
-
-	// For the Fjord activation block we shouldn't include any sequencer transactions.
 	if d.rollupCfg.IsFjordActivationBlock(uint64(attrs.Timestamp)) {
-		attrs.NoTxPool = true
 		d.log.Info("Sequencing Fjord upgrade block")
 	}
 
 	d.log.Debug("prepared attributes for new block",
 		"num", l2Head.Number+1, "time", uint64(attrs.Timestamp),
-		"origin", l1Origin, "origin_time", l1Origin.Time, "noTxPool", attrs.NoTxPool)
+		"origin", l1Origin, "origin_time", l1Origin.Time, "noTxPool", attrs.NoTxPool, "txPoolPolicy", !attrs.TxPoolPolicy.IsEmpty())
 
 	// Start a payload building process.
 	withParent := &derive.AttributesWithParent{Attributes: attrs, Parent: l2Head, IsLastInSpan: false}
-	errTyp, err := d.engine.StartPayload(ctx, l2Head, withParent, false)
+	_, errTyp, err := d.engine.StartBuildingJob(ctx, l2Head, withParent, false)
+	d.actionLog.Record(SequencerAction{
+		Time:           actionTime(d.timeNow()),
+		Kind:           ActionStart,
+		Parent:         l2Head.ID(),
+		Attributes:     attrs,
+		AttributesHash: attributesHash(attrs),
+		Result:         actionResult(err),
+	})
 	if err != nil {
 		return fmt.Errorf("failed to start building on top of L2 chain %s, error (%d): %w", l2Head, errTyp, err)
 	}
 	return nil
 }
 
-// CompleteBuildingBlock takes the current block that is being built, and asks the engine to complete the building, seal the block, and persist it as canonical.
+// CompleteBuildingBlock takes the current block building job, and asks the engine to complete the building, seal the block, and persist it as canonical.
+// If no block is being built, the payload of the async gossiper is persisted instead.
 // Warning: the safe and finalized L2 blocks as viewed during the initiation of the block building are reused for completion of the block building.
 // The Execution engine should not change the safe and finalized blocks between start and completion of block building.
 func (d *Sequencer) CompleteBuildingBlock(ctx context.Context, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (*eth.ExecutionPayloadEnvelope, error) {
-	envelope, errTyp, err := d.engine.ConfirmPayload(ctx, agossip, sequencerConductor)
+	ctx, span := d.tracer.Start(ctx, "sequencer.complete_block")
+	defer span.End()
+	job := d.engine.BuildingJob()
+	action := SequencerAction{Kind: ActionSeal, Source: PayloadFromEngine}
+	if job != nil {
+		action.Parent = job.Onto().ID()
+		if attrs := job.Attributes(); attrs != nil {
+			action.AttributesHash = attributesHash(attrs.Attributes)
+		}
+	}
+	if cached := agossip.Get(); cached != nil {
+		// the sealing reuses the payload of the gossiper, see engine.confirmPayload
+		action.Source = PayloadFromGossiper
+		if job == nil {
+			action.Parent = eth.BlockID{Hash: cached.ExecutionPayload.ParentHash, Number: uint64(cached.ExecutionPayload.BlockNumber) - 1}
+		}
+	}
+	if d.actionLog != nil {
+		recorder := &recordingConductor{SequencerConductor: sequencerConductor}
+		defer func() { action.Conductor = recorder.result; d.actionLog.Record(action) }()
+		sequencerConductor = recorder
+	}
+	envelope, errTyp, err := d.engine.SealJob(ctx, job, agossip, sequencerConductor)
+	action.Time = actionTime(d.timeNow())
+	action.Result = actionResult(err)
 	if err != nil {
+		span.RecordError(err)
 		return nil, fmt.Errorf("failed to complete building block: error (%d): %w", errTyp, err)
 	}
+	span.SetAttributes(tracing.Uint64("l2.block", uint64(envelope.ExecutionPayload.BlockNumber)))
+	block := envelope.ExecutionPayload.ID()
+	action.Block = &block
 	return envelope, nil
 }
 
@@ -136,15 +186,19 @@ func (d *Sequencer) CompleteBuildingBlock(ctx context.Context, agossip async.Asy
 // This sequencer only maintains one block building job at a time.
 func (d *Sequencer) CancelBuildingBlock(ctx context.Context) {
 	// force-cancel, we can always continue block building, and any error is logged by the engine state
-	_ = d.engine.CancelPayload(ctx, true)
+	_ = d.engine.CancelJob(ctx, d.engine.BuildingJob(), true)
 }
 
 // PlanNextSequencerAction returns a desired delay till the RunNextSequencerAction call.
 func (d *Sequencer) PlanNextSequencerAction() time.Duration {
-	buildingOnto, buildingID, safe := d.engine.BuildingPayload()
+	job := d.engine.BuildingJob()
+	var buildingOnto eth.L2BlockRef
+	if job != nil {
+		buildingOnto = job.Onto()
+	}
 	// If the engine is busy building safe blocks (and thus changing the head that we would sync on top of),
 	// then give it time to sync up.
-	if safe {
+	if job != nil && job.Safe() {
 		d.log.Warn("delaying sequencing to not interrupt safe-head changes", "onto", buildingOnto, "onto_time", buildingOnto.Time)
 		// approximates the worst-case time it takes to build a block, to reattempt sequencing after.
 		return time.Second * time.Duration(d.rollupCfg.BlockTime)
@@ -165,7 +219,7 @@ func (d *Sequencer) PlanNextSequencerAction() time.Duration {
 
 	// If we started building a block already, and if that work is still consistent,
 	// then we would like to finish it by sealing the block.
-	if buildingID != (eth.PayloadID{}) && buildingOnto.Hash == head.Hash {
+	if job != nil && buildingOnto.Hash == head.Hash {
 		// if we started building already, then we will schedule the sealing.
 		if remainingTime < sealingDuration {
 			return 0 // if there's not enough time for sealing, don't wait.
@@ -187,8 +241,10 @@ func (d *Sequencer) PlanNextSequencerAction() time.Duration {
 
 // BuildingOnto returns the L2 head reference that the latest block is or was being built on top of.
 func (d *Sequencer) BuildingOnto() eth.L2BlockRef {
-	ref, _, _ := d.engine.BuildingPayload()
-	return ref
+	if job := d.engine.BuildingJob(); job != nil {
+		return job.Onto()
+	}
+	return eth.L2BlockRef{}
 }
 
 // RunNextSequencerAction starts new block building work, or seals existing work,
@@ -215,9 +271,9 @@ func (d *Sequencer) BuildingOnto() eth.L2BlockRef {
 // If the engine is currently building safe blocks, then that building is not interrupted, and sequencing is delayed.
 func (d *Sequencer) RunNextSequencerAction(ctx context.Context, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (*eth.ExecutionPayloadEnvelope, error) {
 	// if the engine returns a non-empty payload, OR if the async gossiper already has a payload, we can CompleteBuildingBlock
-	if onto, buildingID, safe := d.engine.BuildingPayload(); buildingID != (eth.PayloadID{}) || agossip.Get() != nil {
-		if safe {
-			d.log.Warn("avoiding sequencing to not interrupt safe-head changes", "onto", onto, "onto_time", onto.Time)
+	if job := d.engine.BuildingJob(); job != nil || agossip.Get() != nil {
+		if job != nil && job.Safe() {
+			d.log.Warn("avoiding sequencing to not interrupt safe-head changes", "onto", job.Onto(), "onto_time", job.Onto().Time)
 			// approximates the worst-case time it takes to build a block, to reattempt sequencing after.
 			d.nextAction = d.timeNow().Add(time.Second * time.Duration(d.rollupCfg.BlockTime))
 			return nil, nil
@@ -246,6 +302,7 @@ func (d *Sequencer) RunNextSequencerAction(ctx context.Context, agossip async.As
 		} else {
 			payload := envelope.ExecutionPayload
 			d.log.Info("sequencer successfully built a new block", "block", payload.ID(), "time", uint64(payload.Timestamp), "txs", len(payload.Transactions))
+			d.inclusion.OnUnsafeBlock(payload)
 			return envelope, nil
 		}
 	} else {
@@ -266,8 +323,8 @@ func (d *Sequencer) RunNextSequencerAction(ctx context.Context, agossip async.As
 				d.nextAction = d.timeNow().Add(time.Second)
 			}
 		} else {
-			parent, buildingID, _ := d.engine.BuildingPayload() // we should have a new payload ID now that we're building a block
-			d.log.Info("sequencer started building new block", "payload_id", buildingID, "l2_parent_block", parent, "l2_parent_block_time", parent.Time)
+			job := d.engine.BuildingJob() // we should have a new job now that we're building a block
+			d.log.Info("sequencer started building new block", "payload_id", job.ID(), "l2_parent_block", job.Onto(), "l2_parent_block_time", job.Onto().Time)
 		}
 		return nil, nil
 	}
@@ -0,0 +1,137 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+// maxExcludedAddresses bounds the number of addresses that the tx pool policy can exclude.
+const maxExcludedAddresses = 1000
+
+// ErrTxPoolPolicyUnsupported is returned when setting a tx pool policy that the execution engine does not apply.
+var ErrTxPoolPolicyUnsupported = errors.New("execution engine does not support tx pool policies")
+
+// TxPoolPolicySupport reports whether the execution engine applies the tx pool policy of payload attributes.
+type TxPoolPolicySupport interface {
+	SupportsTxPoolPolicy(ctx context.Context) (bool, error)
+}
+
+// SequencerPolicy holds the tx pool policy that operators set at runtime, e.g. during incidents,
+// and that the sequencer passes to the execution engine in the payload attributes of the blocks it builds.
+// The policy is not applied to blocks built without tx pool transactions, and never to derived blocks.
+// The policy is persisted to a file, if any, so that it survives restarts.
+// It is safe for concurrent use. A nil SequencerPolicy does not apply any policy.
+type SequencerPolicy struct {
+	log    log.Logger
+	engine TxPoolPolicySupport
+	file   string
+
+	mu     sync.Mutex
+	policy *eth.TxPoolPolicy
+}
+
+// NewSequencerPolicy creates the sequencer policy, and loads the policy from the file if it is set and exists.
+func NewSequencerPolicy(log log.Logger, engine TxPoolPolicySupport, file string) (*SequencerPolicy, error) {
+	p := &SequencerPolicy{log: log, engine: engine, file: file}
+	if file == "" {
+		return p, nil
+	}
+	if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	}
+	policy, err := jsonutil.LoadJSON[eth.TxPoolPolicy](file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sequencer policy: %w", err)
+	}
+	if !policy.IsEmpty() {
+		p.policy = policy
+		log.Info("Loaded sequencer tx pool policy", "maxDABytes", policy.MaxDABytes, "excluded", len(policy.ExcludedAddresses))
+	}
+	return p, nil
+}
+
+// Set replaces the tx pool policy. An empty policy clears it.
+// It returns ErrTxPoolPolicyUnsupported if the execution engine would ignore the policy.
+func (p *SequencerPolicy) Set(ctx context.Context, policy *eth.TxPoolPolicy) error {
+	if policy != nil && len(policy.ExcludedAddresses) > maxExcludedAddresses {
+		return fmt.Errorf("too many excluded addresses: %d, max %d", len(policy.ExcludedAddresses), maxExcludedAddresses)
+	}
+	if !policy.IsEmpty() {
+		supported, err := p.engine.SupportsTxPoolPolicy(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check tx pool policy support of the execution engine: %w", err)
+		}
+		if !supported {
+			return ErrTxPoolPolicyUnsupported
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if policy.IsEmpty() {
+		policy = nil
+	}
+	if err := p.persist(policy); err != nil {
+		return err
+	}
+	p.policy = copyTxPoolPolicy(policy)
+	if p.policy == nil {
+		p.log.Info("Cleared sequencer tx pool policy")
+	} else {
+		p.log.Info("Set sequencer tx pool policy", "maxDABytes", p.policy.MaxDABytes, "excluded", len(p.policy.ExcludedAddresses))
+	}
+	return nil
+}
+
+// persist writes the policy to the file, if any. The lock must be held.
+func (p *SequencerPolicy) persist(policy *eth.TxPoolPolicy) error {
+	if p.file == "" {
+		return nil
+	}
+	if policy == nil {
+		policy = &eth.TxPoolPolicy{}
+	}
+	if err := jsonutil.WriteJSON(p.file, policy, 0o644); err != nil {
+		return fmt.Errorf("failed to persist sequencer policy: %w", err)
+	}
+	return nil
+}
+
+// Get returns a copy of the tx pool policy, or nil if no policy is set.
+func (p *SequencerPolicy) Get() *eth.TxPoolPolicy {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return copyTxPoolPolicy(p.policy)
+}
+
+// Apply sets the tx pool policy in the payload attributes of a block built by the sequencer.
+func (p *SequencerPolicy) Apply(attrs *eth.PayloadAttributes) {
+	if attrs.NoTxPool {
+		return
+	}
+	attrs.TxPoolPolicy = p.Get()
+}
+
+func copyTxPoolPolicy(policy *eth.TxPoolPolicy) *eth.TxPoolPolicy {
+	if policy == nil {
+		return nil
+	}
+	out := &eth.TxPoolPolicy{ExcludedAddresses: slices.Clone(policy.ExcludedAddresses)}
+	if policy.MaxDABytes != nil {
+		maxDABytes := hexutil.Uint64(*policy.MaxDABytes)
+		out.MaxDABytes = &maxDABytes
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package driver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubTxPoolPolicySupport bool
+
+func (s stubTxPoolPolicySupport) SupportsTxPoolPolicy(_ context.Context) (bool, error) {
+	return bool(s), nil
+}
+
+func TestSequencerPolicy(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewSequencerPolicy(testlog.Logger(t, log.LevelInfo), stubTxPoolPolicySupport(true), "")
+	require.NoError(t, err)
+	require.Nil(t, p.Get())
+
+	maxDABytes := hexutil.Uint64(1000)
+	policy := &eth.TxPoolPolicy{MaxDABytes: &maxDABytes, ExcludedAddresses: []common.Address{{0xaa}}}
+	require.NoError(t, p.Set(ctx, policy))
+	require.Equal(t, policy, p.Get())
+
+	// the policy is copied
+	policy.ExcludedAddresses[0] = common.Address{0xbb}
+	*policy.MaxDABytes = 2000
+	require.Equal(t, []common.Address{{0xaa}}, p.Get().ExcludedAddresses)
+	require.EqualValues(t, 1000, *p.Get().MaxDABytes)
+
+	attrs := &eth.PayloadAttributes{}
+	p.Apply(attrs)
+	require.Equal(t, p.Get(), attrs.TxPoolPolicy)
+	attrs = &eth.PayloadAttributes{NoTxPool: true}
+	p.Apply(attrs)
+	require.Nil(t, attrs.TxPoolPolicy, "not applied without tx pool transactions")
+
+	require.ErrorContains(t, p.Set(ctx, &eth.TxPoolPolicy{ExcludedAddresses: make([]common.Address, maxExcludedAddresses+1)}), "too many")
+	require.NotNil(t, p.Get(), "invalid policy is not set")
+
+	require.NoError(t, p.Set(ctx, &eth.TxPoolPolicy{}))
+	require.Nil(t, p.Get(), "empty policy clears")
+
+	var disabled *SequencerPolicy
+	attrs = &eth.PayloadAttributes{}
+	disabled.Apply(attrs)
+	require.Nil(t, attrs.TxPoolPolicy)
+}
+
+func TestSequencerPolicyUnsupported(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewSequencerPolicy(testlog.Logger(t, log.LevelInfo), stubTxPoolPolicySupport(false), "")
+	require.NoError(t, err)
+	require.ErrorIs(t, p.Set(ctx, &eth.TxPoolPolicy{ExcludedAddresses: []common.Address{{0xaa}}}), ErrTxPoolPolicyUnsupported)
+	require.Nil(t, p.Get())
+	require.NoError(t, p.Set(ctx, nil), "clearing the policy is always supported")
+}
+
+func TestSequencerPolicyPersisted(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "policy.json")
+	logger := testlog.Logger(t, log.LevelInfo)
+	p, err := NewSequencerPolicy(logger, stubTxPoolPolicySupport(true), file)
+	require.NoError(t, err)
+	policy := &eth.TxPoolPolicy{ExcludedAddresses: []common.Address{{0xaa}}}
+	require.NoError(t, p.Set(ctx, policy))
+
+	p, err = NewSequencerPolicy(logger, stubTxPoolPolicySupport(true), file)
+	require.NoError(t, err)
+	require.Equal(t, policy, p.Get())
+
+	require.NoError(t, p.Set(ctx, nil))
+	p, err = NewSequencerPolicy(logger, stubTxPoolPolicySupport(true), file)
+	require.NoError(t, err)
+	require.Nil(t, p.Get())
+}
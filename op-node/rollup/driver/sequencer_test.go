@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 var mockResetErr = fmt.Errorf("mock reset err: %w", derive.ErrReset)
@@ -34,12 +35,7 @@ type FakeEngineControl struct {
 	safe      eth.L2BlockRef
 	unsafe    eth.L2BlockRef
 
-	buildingOnto eth.L2BlockRef
-	buildingID   eth.PayloadID
-	buildingSafe bool
-
-	buildingAttrs *eth.PayloadAttributes
-	buildingStart time.Time
+	building *engine.BlockBuildingJob
 
 	cfg *rollup.Config
 
@@ -63,50 +59,48 @@ func (m *FakeEngineControl) avgTxsPerBlock() float64 {
 	return float64(m.totalTxs) / float64(m.totalBuiltBlocks)
 }
 
-func (m *FakeEngineControl) StartPayload(ctx context.Context, parent eth.L2BlockRef, attrs *derive.AttributesWithParent, updateSafe bool) (errType engine.BlockInsertionErrType, err error) {
+func (m *FakeEngineControl) StartBuildingJob(ctx context.Context, parent eth.L2BlockRef, attrs *derive.AttributesWithParent, updateSafe bool) (*engine.BlockBuildingJob, engine.BlockInsertionErrType, error) {
 	if m.err != nil {
-		return m.errTyp, m.err
+		return nil, m.errTyp, m.err
 	}
-	m.buildingID = eth.PayloadID{}
-	_, _ = crand.Read(m.buildingID[:])
-	m.buildingOnto = parent
-	m.buildingSafe = updateSafe
-	m.buildingAttrs = attrs.Attributes
-	m.buildingStart = m.timeNow()
-	return engine.BlockInsertOK, nil
+	var id eth.PayloadID
+	_, _ = crand.Read(id[:])
+	info := eth.PayloadInfo{ID: id, Timestamp: uint64(attrs.Attributes.Timestamp)}
+	m.building = engine.NewBlockBuildingJob(m, parent, info, attrs, updateSafe, m.timeNow())
+	return m.building, engine.BlockInsertOK, nil
 }
 
-func (m *FakeEngineControl) ConfirmPayload(ctx context.Context, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (out *eth.ExecutionPayloadEnvelope, errTyp engine.BlockInsertionErrType, err error) {
+func (m *FakeEngineControl) SealJob(ctx context.Context, job *engine.BlockBuildingJob, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (out *eth.ExecutionPayloadEnvelope, errTyp engine.BlockInsertionErrType, err error) {
 	if m.err != nil {
 		return nil, m.errTyp, m.err
 	}
-	buildTime := m.timeNow().Sub(m.buildingStart)
+	buildTime := m.timeNow().Sub(job.StartedAt())
 	m.totalBuildingTime += buildTime
 	m.totalBuiltBlocks += 1
-	payload := m.makePayload(m.buildingOnto, m.buildingAttrs)
+	payload := m.makePayload(job.Onto(), job.Attributes().Attributes)
 	ref, err := derive.PayloadToBlockRef(m.cfg, payload)
 	if err != nil {
 		panic(err)
 	}
 	m.unsafe = ref
-	if m.buildingSafe {
+	if job.Safe() {
 		m.safe = ref
 	}
 
-	m.resetBuildingState()
+	m.building = nil
 	m.totalTxs += len(payload.Transactions)
 	return &eth.ExecutionPayloadEnvelope{ExecutionPayload: payload}, engine.BlockInsertOK, nil
 }
 
-func (m *FakeEngineControl) CancelPayload(ctx context.Context, force bool) error {
+func (m *FakeEngineControl) CancelJob(ctx context.Context, job *engine.BlockBuildingJob, force bool) error {
 	if force {
-		m.resetBuildingState()
+		m.building = nil
 	}
 	return m.err
 }
 
-func (m *FakeEngineControl) BuildingPayload() (onto eth.L2BlockRef, id eth.PayloadID, safe bool) {
-	return m.buildingOnto, m.buildingID, m.buildingSafe
+func (m *FakeEngineControl) BuildingJob() *engine.BlockBuildingJob {
+	return m.building
 }
 
 func (m *FakeEngineControl) Finalized() eth.L2BlockRef {
@@ -121,13 +115,6 @@ func (m *FakeEngineControl) SafeL2Head() eth.L2BlockRef {
 	return m.safe
 }
 
-func (m *FakeEngineControl) resetBuildingState() {
-	m.buildingID = eth.PayloadID{}
-	m.buildingOnto = eth.L2BlockRef{}
-	m.buildingSafe = false
-	m.buildingAttrs = nil
-}
-
 var _ engine.EngineControl = (*FakeEngineControl)(nil)
 
 type testAttrBuilderFn func(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (attrs *eth.PayloadAttributes, err error)
@@ -301,7 +288,7 @@ func TestSequencerChaosMonkey(t *testing.T) {
 		}
 	})
 
-	seq := NewSequencer(log, cfg, engControl, attrBuilder, originSelector, metrics.NoopMetrics)
+	seq := NewSequencer(log, cfg, engControl, attrBuilder, originSelector, metrics.NoopMetrics, tracing.NoopTracer{})
 	seq.timeNow = clockFn
 
 	// try to build 1000 blocks, with 5x as many planning attempts, to handle errors and clock problems
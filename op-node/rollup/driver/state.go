@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	gosync "sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -27,6 +28,7 @@ import (
 var (
 	ErrSequencerAlreadyStarted = errors.New("sequencer already running")
 	ErrSequencerAlreadyStopped = errors.New("sequencer not running")
+	ErrSequencerNotStopped     = errors.New("sequencer must be stopped")
 )
 
 // Deprecated: use eth.SyncStatus instead.
@@ -67,8 +69,30 @@ type Driver struct {
 	// sequencerNotifs is notified when the sequencer is started or stopped
 	sequencerNotifs SequencerStateListener
 
+	// stoppedBuild is the parent of the block that was being built when the sequencer was last stopped, if any.
+	stoppedBuild *eth.L2BlockRef
+
 	sequencerConductor conductor.SequencerConductor
 
+	// inclusionDeadlines tracks the transactions that the sequencer must include before a deadline.
+	inclusionDeadlines *InclusionDeadlines
+
+	// sequencerPolicy is the tx pool policy that the sequencer builds blocks with.
+	sequencerPolicy *SequencerPolicy
+
+	// reorgGuard halts the node on unsafe reorgs that are deeper than the configured maximum.
+	reorgGuard *engine.ReorgGuard
+
+	// builderStats tracks the payloads of the external block builder of the sequencer.
+	builderStats *engine.BuilderStats
+
+	// engineQueueState is the snapshot of the engine queue, published by the event loop after processing events,
+	// so that it can be inspected without blocking the event loop, also while the event loop is stuck.
+	engineQueueState atomic.Pointer[eth.EngineQueueState]
+
+	// asyncEvents carries events of derivers that process work outside of the event loop, e.g. cross-safe checks.
+	asyncEvents chan event.Event
+
 	// Driver config: verifier and sequencer settings
 	driverConfig *Config
 
@@ -92,9 +116,10 @@ type Driver struct {
 	// L2 Signals:
 
 	unsafeL2Payloads chan *eth.ExecutionPayloadEnvelope
+	claimedSafeSig   chan eth.L2BlockRef
 
-	sequencer SequencerIface
-	network   Network // may be nil, network for is optional
+	sequencer SequencerIface // nil if the sequencer is not enabled
+	network   Network        // may be nil, network for is optional
 
 	metrics Metrics
 	log     log.Logger
@@ -179,6 +204,17 @@ func (s *Driver) OnUnsafeL2Payload(ctx context.Context, envelope *eth.ExecutionP
 	}
 }
 
+// OnClaimedSafeHead signals the driver that another node attested the given L2 block to be safe.
+// The claim is only reported in the sync status, and does not affect derivation.
+func (s *Driver) OnClaimedSafeHead(ctx context.Context, claimed eth.L2BlockRef) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.claimedSafeSig <- claimed:
+		return nil
+	}
+}
+
 // the eventLoop responds to L1 changes and internal timers to produce L2 blocks.
 func (s *Driver) eventLoop() {
 	defer s.wg.Done()
@@ -228,6 +264,7 @@ func (s *Driver) eventLoop() {
 			}
 			s.log.Error("unexpected error from event-draining", "err", err)
 		}
+		s.publishEngineQueueState()
 
 		// If we are sequencing, and the L1 state is ready, update the trigger for the next sequencer action.
 		// This may adjust at any time based on fork-choice changes or previous errors.
@@ -284,6 +321,7 @@ func (s *Driver) eventLoop() {
 				s.log.Warn("failed to check for unsafe L2 blocks to sync", "err", err)
 			}
 		case envelope := <-s.unsafeL2Payloads:
+			s.inclusionDeadlines.OnUnsafeBlock(envelope.ExecutionPayload)
 			// If we are doing CL sync or done with engine syncing, fallback to the unsafe payload queue & CL P2P sync.
 			if s.SyncCfg.SyncMode == sync.CLSync || !s.Engine.IsEngineSyncing() {
 				s.log.Info("Optimistically queueing unsafe L2 execution payload", "id", envelope.ExecutionPayload.ID())
@@ -310,6 +348,9 @@ func (s *Driver) eventLoop() {
 		case newL1Safe := <-s.l1SafeSig:
 			s.Emitter.Emit(status.L1SafeEvent{L1Safe: newL1Safe})
 			// no step, justified L1 information does not do anything for L2 derivation or status
+		case claimedSafe := <-s.claimedSafeSig:
+			s.Emitter.Emit(status.ClaimedSafeHeadEvent{Claimed: claimedSafe})
+			// no step, the claimed safe head is informational only
 		case newL1Finalized := <-s.l1FinalizedSig:
 			s.Emit(finality.FinalizeL1Event{FinalizedL1: newL1Finalized})
 			reqStep() // we may be able to mark more L2 data as finalized now
@@ -317,6 +358,8 @@ func (s *Driver) eventLoop() {
 			s.Emit(StepAttemptEvent{})
 		case <-s.sched.NextStep():
 			s.Emit(StepAttemptEvent{})
+		case ev := <-s.asyncEvents:
+			s.Emit(ev)
 		case respCh := <-s.stateReq:
 			respCh <- struct{}{}
 		case respCh := <-s.forceReset:
@@ -337,6 +380,7 @@ func (s *Driver) eventLoop() {
 				}
 				s.log.Info("Sequencer has been started")
 				s.driverConfig.SequencerStopped = false
+				s.stoppedBuild = nil
 				close(resp.err)
 				planSequencerAction() // resume sequencing
 			}
@@ -350,6 +394,9 @@ func (s *Driver) eventLoop() {
 				}
 				s.log.Warn("Sequencer has been stopped")
 				s.driverConfig.SequencerStopped = true
+				if onto := s.sequencer.BuildingOnto(); onto != (eth.L2BlockRef{}) {
+					s.stoppedBuild = &onto
+				}
 				// Cancel any inflight block building. If we don't cancel this, we can resume sequencing an old block
 				// even if we've received new unsafe heads in the interim, causing us to introduce a re-org.
 				s.sequencer.CancelBuildingBlock(s.driverCtx)
@@ -386,6 +433,14 @@ func (s *Driver) Emit(ev event.Event) {
 	s.synchronousEvents.Emit(ev)
 }
 
+// emitAsync queues up an event from outside of the event loop, to be emitted by the event loop.
+func (s *Driver) emitAsync(ev event.Event) {
+	select {
+	case <-s.driverCtx.Done():
+	case s.asyncEvents <- ev:
+	}
+}
+
 type SyncDeriver struct {
 	// The derivation pipeline is reset whenever we reorg.
 	// The derivation pipeline determines the new l2Safe.
@@ -395,6 +450,9 @@ type SyncDeriver struct {
 
 	SafeHeadNotifs rollup.SafeHeadListener // notified when safe head is updated
 
+	// DerivationProgress records the safe L2 blocks derived from each L1 block. This may be nil.
+	DerivationProgress *derive.DerivationProgressTracker
+
 	CLSync CLSync
 
 	// The engine controller is used by the sequencer & Derivation components.
@@ -450,6 +508,7 @@ func (s *SyncDeriver) OnEvent(ev event.Event) {
 }
 
 func (s *SyncDeriver) onSafeDerivedBlock(x engine.SafeDerivedEvent) {
+	s.DerivationProgress.RecordSafeL2Block(x.Safe, x.DerivedFrom)
 	if s.SafeHeadNotifs != nil && s.SafeHeadNotifs.Enabled() {
 		if err := s.SafeHeadNotifs.SafeHeadUpdated(x.Safe, x.DerivedFrom.ID()); err != nil {
 			// At this point our state is in a potentially inconsistent state as we've updated the safe head
@@ -635,15 +694,176 @@ func (s *Driver) SequencerActive(ctx context.Context) (bool, error) {
 	}
 }
 
+// ExportSequencerState captures the state of the stopped sequencer, to migrate the sequencer to another host.
+// The sequencer must stay stopped, so the exported state remains accurate.
+func (s *Driver) ExportSequencerState(ctx context.Context) (*eth.SequencerState, error) {
+	if !s.driverConfig.SequencerEnabled {
+		return nil, errors.New("sequencer is not enabled")
+	}
+	wait := make(chan struct{})
+	select {
+	case s.stateReq <- wait:
+		defer func() { <-wait }()
+		if !s.driverConfig.SequencerStopped {
+			return nil, fmt.Errorf("cannot export sequencer state: %w", ErrSequencerNotStopped)
+		}
+		return &eth.SequencerState{
+			L2Genesis:    s.Config.Genesis.L2,
+			UnsafeL2:     s.Engine.UnsafeL2Head(),
+			SafeL2:       s.Engine.SafeL2Head(),
+			FinalizedL2:  s.Engine.Finalized(),
+			PendingBuild: s.stoppedBuild,
+		}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ImportSequencerState verifies the state exported by another sequencer against the state of this stopped sequencer,
+// and starts the sequencer once it is at the exported unsafe head.
+func (s *Driver) ImportSequencerState(ctx context.Context, state *eth.SequencerState) error {
+	local, err := s.ExportSequencerState(ctx)
+	if err != nil {
+		return err
+	}
+	if local.L2Genesis != state.L2Genesis {
+		return fmt.Errorf("sequencer state is of another chain: L2 genesis %s, expected %s", state.L2Genesis, local.L2Genesis)
+	}
+	if local.UnsafeL2 != state.UnsafeL2 {
+		return fmt.Errorf("unsafe head %s does not match exported unsafe head %s, the unsafe chain must be synced first", local.UnsafeL2, state.UnsafeL2)
+	}
+	if local.FinalizedL2.Number < state.FinalizedL2.Number {
+		s.log.Warn("Importing sequencer state with finalized head ahead of local finalized head", "local", local.FinalizedL2, "exported", state.FinalizedL2)
+	}
+	s.log.Info("Importing sequencer state", "unsafe", state.UnsafeL2, "safe", state.SafeL2, "finalized", state.FinalizedL2, "pending_build", state.PendingBuild)
+	return s.StartSequencer(ctx, state.UnsafeL2.Hash)
+}
+
 func (s *Driver) OverrideLeader(ctx context.Context) error {
 	return s.sequencerConductor.OverrideLeader(ctx)
 }
 
+// AddInclusionDeadline registers a transaction that the sequencer must include within the given number of blocks.
+func (s *Driver) AddInclusionDeadline(ctx context.Context, txHash common.Hash, blocks uint64) (eth.InclusionDeadline, error) {
+	if !s.driverConfig.SequencerEnabled {
+		return eth.InclusionDeadline{}, errors.New("sequencer is not enabled")
+	}
+	return s.inclusionDeadlines.Add(txHash, blocks, s.statusTracker.SyncStatus().UnsafeL2.Number)
+}
+
+// RemoveInclusionDeadline stops tracking the inclusion of a transaction.
+func (s *Driver) RemoveInclusionDeadline(ctx context.Context, txHash common.Hash) error {
+	if !s.driverConfig.SequencerEnabled {
+		return errors.New("sequencer is not enabled")
+	}
+	return s.inclusionDeadlines.Remove(txHash)
+}
+
+// InclusionDeadlines returns the transactions that the sequencer must include before a deadline.
+func (s *Driver) InclusionDeadlines(ctx context.Context) ([]eth.InclusionDeadline, error) {
+	if !s.driverConfig.SequencerEnabled {
+		return nil, errors.New("sequencer is not enabled")
+	}
+	return s.inclusionDeadlines.List(), nil
+}
+
+// MissedInclusionDeadlines returns the number of transactions that the sequencer did not include by their deadline.
+func (s *Driver) MissedInclusionDeadlines() int {
+	if !s.driverConfig.SequencerEnabled {
+		return 0
+	}
+	return s.inclusionDeadlines.Missed()
+}
+
+// SetSequencerPolicy sets the tx pool policy that the sequencer passes to the execution engine, to build blocks with.
+// A nil or empty policy clears it.
+func (s *Driver) SetSequencerPolicy(ctx context.Context, policy *eth.TxPoolPolicy) error {
+	if !s.driverConfig.SequencerEnabled {
+		return errors.New("sequencer is not enabled")
+	}
+	return s.sequencerPolicy.Set(ctx, policy)
+}
+
+// SequencerPolicy returns the tx pool policy that the sequencer builds blocks with, or nil if none is set.
+func (s *Driver) SequencerPolicy(ctx context.Context) (*eth.TxPoolPolicy, error) {
+	if !s.driverConfig.SequencerEnabled {
+		return nil, errors.New("sequencer is not enabled")
+	}
+	return s.sequencerPolicy.Get(), nil
+}
+
+// UnsafeReorgHalt returns the unsafe reorg that halted the node, or nil if the node is not halted.
+func (s *Driver) UnsafeReorgHalt(ctx context.Context) (*eth.UnsafeReorgHalt, error) {
+	return s.reorgGuard.Halt(), nil
+}
+
+// RestoreUnsafeReorgHalt restores the unsafe reorg halt that was persisted before a restart,
+// and persists the unsafe reorg halts and confirmations from now on.
+func (s *Driver) RestoreUnsafeReorgHalt(persistence engine.ReorgHaltPersistence) error {
+	halt, err := s.reorgGuard.Restore(persistence)
+	if err != nil {
+		return err
+	}
+	if halt != nil {
+		s.log.Error("Halted on deep unsafe reorg before restart, confirm with admin_confirmUnsafeReorg to resume",
+			"unsafe", halt.UnsafeL2, "parent", halt.Parent, "depth", uint64(halt.Depth))
+	}
+	return nil
+}
+
+// BuilderStatus returns the status of the external block builder of the sequencer, or nil if it has no builder.
+func (s *Driver) BuilderStatus(ctx context.Context) (*eth.BuilderStatus, error) {
+	return s.builderStats.Status(), nil
+}
+
+// ConfirmUnsafeReorg allows the unsafe reorg that halted the node, and resumes the node.
+func (s *Driver) ConfirmUnsafeReorg(ctx context.Context) error {
+	halt, err := s.reorgGuard.Confirm()
+	if err != nil {
+		return err
+	}
+	s.log.Warn("Confirmed deep unsafe reorg, resuming", "unsafe", halt.UnsafeL2, "parent", halt.Parent, "depth", uint64(halt.Depth))
+	return nil
+}
+
+// publishEngineQueueState updates the snapshot of the engine queue that is served by EngineQueueState.
+// It must be called from the event loop.
+func (s *Driver) publishEngineQueueState() {
+	state := s.Engine.QueueState()
+	state.UpdatedAt = time.Now()
+	s.engineQueueState.Store(&state)
+}
+
+// EngineQueueState returns the forkchoice targets, the payload that is being built, the queued unsafe payloads
+// and the most recent block insertion errors, to diagnose a node that does not make progress.
+// It does not block the event loop: the engine state is the snapshot of the last event loop iteration,
+// which is stale if the event loop is stuck, see eth.EngineQueueState.UpdatedAt.
+func (s *Driver) EngineQueueState(ctx context.Context) (*eth.EngineQueueState, error) {
+	snapshot := s.engineQueueState.Load()
+	if snapshot == nil {
+		return nil, errors.New("engine queue state is not available until the driver started")
+	}
+	state := *snapshot
+	state.QueuedUnsafePayloads = s.CLSync.QueuedUnsafePayloads()
+	return &state, nil
+}
+
 // SyncStatus blocks the driver event loop and captures the syncing status.
 func (s *Driver) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return s.statusTracker.SyncStatus(), nil
 }
 
+// SubscribeSyncStatus notifies ch of every change of the syncing status, until unsubscribed.
+// Notifications are dropped while ch is full.
+func (s *Driver) SubscribeSyncStatus(ch chan<- *eth.SyncStatus) (unsubscribe func()) {
+	return s.statusTracker.Subscribe(ch)
+}
+
+// DerivationProgressAtL1 returns what was derived from the recent L1 block with the given number.
+func (s *Driver) DerivationProgressAtL1(ctx context.Context, num uint64) (*eth.DerivationProgress, error) {
+	return s.DerivationProgress.AtL1(num)
+}
+
 // BlockRefWithStatus blocks the driver event loop and captures the syncing status,
 // along with an L2 block reference by number consistent with that same status.
 // If the event loop is too busy and the context expires, a context error is returned.
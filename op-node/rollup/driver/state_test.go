@@ -0,0 +1,49 @@
+package driver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/clsync"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestEngineQueueStateWithoutEventLoop(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	cfg := &rollup.Config{}
+	emitter := &testutils.MockEmitter{}
+	ec := engine.NewEngineController(nil, logger, metrics.NoopMetrics, cfg, &sync.Config{}, emitter)
+	cl := clsync.NewCLSync(logger, cfg, metrics.NoopMetrics, emitter, 1<<20)
+	// The event loop is not running: requests that block the event loop would never be served.
+	s := &Driver{
+		SyncDeriver: &SyncDeriver{Engine: ec, CLSync: cl},
+		stateReq:    make(chan chan struct{}),
+	}
+
+	_, err := s.EngineQueueState(context.Background())
+	require.ErrorContains(t, err, "not available")
+
+	unsafe := eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 12}
+	ec.SetUnsafeHead(unsafe)
+	s.publishEngineQueueState()
+	payload := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockHash: common.Hash{0x02}, BlockNumber: 14}}
+	emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+	cl.OnEvent(clsync.ReceivedUnsafePayloadEvent{Envelope: payload})
+
+	state, err := s.EngineQueueState(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, unsafe, state.UnsafeL2)
+	require.NotZero(t, state.UpdatedAt)
+	require.Equal(t, []eth.BlockID{payload.ExecutionPayload.ID()}, state.QueuedUnsafePayloads, "queued payloads are not part of the snapshot")
+}
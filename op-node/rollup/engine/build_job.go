@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/async"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrBuildingJobCancelled is the error of a block building job that was cancelled,
+// replaced by a new job, or dropped by an engine reset before its block was inserted.
+var ErrBuildingJobCancelled = errors.New("block building job was cancelled")
+
+// BuildingState is the state of a block building job.
+type BuildingState int32
+
+const (
+	// BuildingStarted is the state of a job that the execution engine is building a block for.
+	// Jobs also return to this state if sealing failed temporarily, to be sealed again.
+	BuildingStarted BuildingState = iota
+	// BuildingSealing is the state of a job of which the block is being retrieved and inserted.
+	BuildingSealing
+	// BuildingInserted is the final state of a job of which the block was inserted as the unsafe head.
+	BuildingInserted
+	// BuildingFailed is the final state of a job that failed to seal, or that was cancelled.
+	BuildingFailed
+)
+
+func (s BuildingState) String() string {
+	switch s {
+	case BuildingStarted:
+		return "started"
+	case BuildingSealing:
+		return "sealing"
+	case BuildingInserted:
+		return "inserted"
+	case BuildingFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown(%d)", int32(s))
+	}
+}
+
+// JobEngine seals and cancels block building jobs. It is implemented by the EngineController.
+type JobEngine interface {
+	// SealJob completes the block of the job, and persists it as the canonical unsafe head.
+	// If job is nil, the payload of the async gossiper is inserted, if any.
+	SealJob(ctx context.Context, job *BlockBuildingJob, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (*eth.ExecutionPayloadEnvelope, BlockInsertionErrType, error)
+	// CancelJob stops building the block of the job without making it canonical.
+	// If force, the job is dropped even if the engine fails to stop building.
+	CancelJob(ctx context.Context, job *BlockBuildingJob, force bool) error
+}
+
+// BlockBuildingJob is a block that the execution engine is building. The job is created when block building starts,
+// and is sealed to insert the block, or cancelled. Its state can be inspected concurrently, e.g. by an external
+// orchestrator of in-flight jobs.
+type BlockBuildingJob struct {
+	eng       JobEngine
+	onto      eth.L2BlockRef
+	info      eth.PayloadInfo
+	attrs     *derive.AttributesWithParent
+	safe      bool
+	startedAt time.Time
+
+	mu       sync.Mutex
+	state    BuildingState
+	envelope *eth.ExecutionPayloadEnvelope
+	err      error
+}
+
+// NewBlockBuildingJob creates a started job of the payload that the engine is building on top of onto.
+// If safe, the block is built from derived attributes, and becomes the pending safe block.
+func NewBlockBuildingJob(eng JobEngine, onto eth.L2BlockRef, info eth.PayloadInfo, attrs *derive.AttributesWithParent, safe bool, startedAt time.Time) *BlockBuildingJob {
+	return &BlockBuildingJob{
+		eng:       eng,
+		onto:      onto,
+		info:      info,
+		attrs:     attrs,
+		safe:      safe,
+		startedAt: startedAt,
+		state:     BuildingStarted,
+	}
+}
+
+// Onto returns the block that the block is built on top of.
+func (j *BlockBuildingJob) Onto() eth.L2BlockRef {
+	return j.onto
+}
+
+// ID returns the payload ID of the execution engine.
+func (j *BlockBuildingJob) ID() eth.PayloadID {
+	return j.info.ID
+}
+
+// PayloadInfo returns the payload ID and the timestamp of the block.
+func (j *BlockBuildingJob) PayloadInfo() eth.PayloadInfo {
+	return j.info
+}
+
+// Attributes returns the attributes that the block is built with.
+func (j *BlockBuildingJob) Attributes() *derive.AttributesWithParent {
+	return j.attrs
+}
+
+// Safe returns whether the block is built from derived attributes, to become the pending safe block.
+func (j *BlockBuildingJob) Safe() bool {
+	return j.safe
+}
+
+// StartedAt returns the time that block building started.
+func (j *BlockBuildingJob) StartedAt() time.Time {
+	return j.startedAt
+}
+
+// State returns the current state of the job.
+func (j *BlockBuildingJob) State() BuildingState {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state
+}
+
+// Envelope returns the inserted block, nil unless the job is in the BuildingInserted state.
+func (j *BlockBuildingJob) Envelope() *eth.ExecutionPayloadEnvelope {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.envelope
+}
+
+// Err returns why the job failed, nil unless the job is in the BuildingFailed state.
+func (j *BlockBuildingJob) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Seal completes the block of the job with the engine that started it, see JobEngine.SealJob.
+func (j *BlockBuildingJob) Seal(ctx context.Context, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (*eth.ExecutionPayloadEnvelope, BlockInsertionErrType, error) {
+	return j.eng.SealJob(ctx, j, agossip, sequencerConductor)
+}
+
+// Cancel stops building the block of the job with the engine that started it, see JobEngine.CancelJob.
+func (j *BlockBuildingJob) Cancel(ctx context.Context, force bool) error {
+	return j.eng.CancelJob(ctx, j, force)
+}
+
+func (j *BlockBuildingJob) String() string {
+	return fmt.Sprintf("job(onto: %s, id: %s, safe: %t, state: %s)", j.onto, j.info.ID, j.safe, j.State())
+}
+
+// startSealing moves the job into the sealing state, if it is started.
+func (j *BlockBuildingJob) startSealing() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.state != BuildingStarted {
+		return fmt.Errorf("cannot seal block building job in state %s", j.state)
+	}
+	j.state = BuildingSealing
+	return nil
+}
+
+// sealFailed moves the job back into the started state if sealing can be retried, and fails it otherwise.
+func (j *BlockBuildingJob) sealFailed(errTyp BlockInsertionErrType, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if errTyp == BlockInsertTemporaryErr {
+		j.state = BuildingStarted
+		return
+	}
+	j.state, j.err = BuildingFailed, err
+}
+
+func (j *BlockBuildingJob) inserted(envelope *eth.ExecutionPayloadEnvelope) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.state, j.envelope = BuildingInserted, envelope
+}
+
+// fail fails the job, unless it already reached a final state.
+func (j *BlockBuildingJob) fail(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.state == BuildingInserted || j.state == BuildingFailed {
+		return
+	}
+	j.state, j.err = BuildingFailed, err
+}
@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/async"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestBlockBuildingJob(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	head := testutils.RandomL2BlockRef(rng)
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*EngineController, *testutils.MockEngine) {
+		eng := &testutils.MockEngine{}
+		emitter := &testutils.MockEmitter{}
+		emitter.ExpectMaybeRun(func(ev event.Event) {})
+		ec := NewEngineController(eng, testlog.Logger(t, log.LevelError), metrics.NoopMetrics, &rollup.Config{},
+			&sync.Config{}, emitter)
+		ec.SetUnsafeHead(head)
+		return ec, eng
+	}
+	start := func(t *testing.T, ec *EngineController, eng *testutils.MockEngine, id eth.PayloadID) *BlockBuildingJob {
+		attrs := &derive.AttributesWithParent{Attributes: &eth.PayloadAttributes{Timestamp: eth.Uint64Quantity(head.Time + 2)}, Parent: head}
+		fc := &eth.ForkchoiceState{HeadBlockHash: head.Hash}
+		eng.ExpectForkchoiceUpdate(fc, attrs.Attributes, &eth.ForkchoiceUpdatedResult{
+			PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid},
+			PayloadID:     &id,
+		}, nil)
+		job, errTyp, err := ec.StartBuildingJob(ctx, head, attrs, false)
+		require.NoError(t, err)
+		require.Equal(t, BlockInsertOK, errTyp)
+		require.Same(t, job, ec.BuildingJob())
+		require.Equal(t, BuildingStarted, job.State())
+		require.Equal(t, head, job.Onto())
+		require.Equal(t, id, job.ID())
+		return job
+	}
+
+	t.Run("temporary seal error", func(t *testing.T) {
+		ec, eng := setup(t)
+		job := start(t, ec, eng, eth.PayloadID{1})
+		eng.ExpectGetPayload(job.ID(), nil, errors.New("unavailable"))
+		_, errTyp, err := job.Seal(ctx, async.NoOpGossiper{}, &conductor.NoOpConductor{})
+		require.Error(t, err)
+		require.Equal(t, BlockInsertTemporaryErr, errTyp)
+		// the job can be sealed again
+		require.Equal(t, BuildingStarted, job.State())
+		require.NoError(t, job.Err())
+		require.Same(t, job, ec.BuildingJob())
+
+		state := ec.QueueState()
+		require.Equal(t, head, state.UnsafeL2)
+		require.NotNil(t, state.PendingPayload)
+		require.Equal(t, job.ID(), state.PendingPayload.ID)
+		require.Equal(t, "started", state.PendingPayload.State)
+		require.Len(t, state.InsertionErrors, 1)
+		require.Equal(t, "temporary", state.InsertionErrors[0].Type)
+		require.Equal(t, head, state.InsertionErrors[0].Onto)
+		require.Contains(t, state.InsertionErrors[0].Error, "unavailable")
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		ec, eng := setup(t)
+		job := start(t, ec, eng, eth.PayloadID{1})
+		eng.ExpectGetPayload(job.ID(), &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{}}, nil)
+		_, errTyp, err := job.Seal(ctx, async.NoOpGossiper{}, &conductor.NoOpConductor{})
+		require.Error(t, err)
+		require.Equal(t, BlockInsertPayloadErr, errTyp)
+		require.Equal(t, BuildingFailed, job.State())
+		require.ErrorContains(t, job.Err(), "no transactions")
+		require.Nil(t, job.Envelope())
+
+		_, errTyp, err = job.Seal(ctx, async.NoOpGossiper{}, &conductor.NoOpConductor{})
+		require.ErrorContains(t, err, "cannot seal")
+		require.Equal(t, BlockInsertPrestateErr, errTyp)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ec, eng := setup(t)
+		job := start(t, ec, eng, eth.PayloadID{1})
+		eng.ExpectGetPayload(job.ID(), nil, nil)
+		require.NoError(t, job.Cancel(ctx, false))
+		require.Equal(t, BuildingFailed, job.State())
+		require.ErrorIs(t, job.Err(), ErrBuildingJobCancelled)
+		require.Nil(t, ec.BuildingJob())
+
+		// cancelling again is a no-op
+		require.NoError(t, job.Cancel(ctx, false))
+		eng.AssertExpectations(t)
+	})
+
+	t.Run("replaced job", func(t *testing.T) {
+		ec, eng := setup(t)
+		prev := start(t, ec, eng, eth.PayloadID{1})
+		job := start(t, ec, eng, eth.PayloadID{2})
+		require.Equal(t, BuildingFailed, prev.State())
+		require.ErrorIs(t, prev.Err(), ErrBuildingJobCancelled)
+		require.Equal(t, BuildingStarted, job.State())
+
+		_, errTyp, err := prev.Seal(ctx, async.NoOpGossiper{}, &conductor.NoOpConductor{})
+		require.ErrorContains(t, err, "not the current building job")
+		require.Equal(t, BlockInsertPrestateErr, errTyp)
+	})
+}
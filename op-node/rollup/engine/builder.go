@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// defaultBuilderTimeout bounds the time the builder may take if the payload retrieval has no deadline.
+const defaultBuilderTimeout = time.Second
+
+// BuilderClient is the client of an external block builder, which builds the blocks of the sequencer
+// instead of the execution engine. It allows embedders of the op-node to integrate a builder over
+// any transport, rather than serving the engine API through a builder sidecar.
+//
+// The engine still builds every block, so its payload can be used as fallback:
+// if the builder fails, times out, or returns a payload that does not match the job or that the engine rejects,
+// the payload of the engine is inserted.
+type BuilderClient interface {
+	// Start starts the client, before the first payload is requested.
+	Start(ctx context.Context) error
+	// Close stops the client. No payloads are requested after closing.
+	Close() error
+	// Enabled returns whether payloads should be requested from the builder.
+	// This may change at runtime, e.g. to disable an unhealthy builder.
+	Enabled() bool
+	// GetPayload returns the payload that the builder built on top of parent, with the given attributes.
+	// The builder must return before the deadline of ctx, which leaves time to fall back to the payload of the engine.
+	GetPayload(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes) (*eth.ExecutionPayloadEnvelope, error)
+}
+
+// getPayload retrieves the payload of the building job, from the builder if it is enabled, and from the engine otherwise.
+// The payload of the engine is retrieved if the builder fails. The builder is only used for blocks that may include
+// transactions from the tx pool with known attributes: derived blocks and forced empty blocks are built by the engine.
+// Payloads of the builder are executed by the engine before they are returned, so that they are not
+// distributed to the conductor and the network before the engine accepted them.
+// Whether the payload of the builder was used is recorded in stats, and returned as the source of the payload.
+func getPayload(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, eng ExecEngine, builder BuilderClient, stats *BuilderStats,
+	onto eth.L2BlockRef, payloadInfo eth.PayloadInfo, attrs *eth.PayloadAttributes) (*eth.ExecutionPayloadEnvelope, eth.PayloadSource, error) {
+	if builder == nil || attrs == nil || attrs.NoTxPool || !builder.Enabled() {
+		return getEnginePayload(ctx, eng, payloadInfo)
+	}
+	envelope, err := getBuilderPayload(ctx, rollupCfg, builder, onto, attrs)
+	if err == nil {
+		err = executeBuilderPayload(ctx, eng, envelope)
+	}
+	stats.record(err != nil)
+	if err != nil {
+		log.Warn("Failed to get payload from builder, using payload of the engine", "onto", onto, "err", err)
+		return getEnginePayload(ctx, eng, payloadInfo)
+	}
+	log.Debug("Using payload of the builder", "hash", envelope.ExecutionPayload.BlockHash, "onto", onto,
+		"txs", len(envelope.ExecutionPayload.Transactions))
+	return envelope, eth.PayloadSourceBuilder, nil
+}
+
+func getEnginePayload(ctx context.Context, eng ExecEngine, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, eth.PayloadSource, error) {
+	envelope, err := eng.GetPayload(ctx, payloadInfo)
+	return envelope, eth.PayloadSourceLocal, err
+}
+
+// getBuilderPayload requests the payload from the builder, within half of the time that is left to retrieve the payload,
+// so that the payload of the engine can still be retrieved if the builder does not respond in time.
+func getBuilderPayload(ctx context.Context, rollupCfg *rollup.Config, builder BuilderClient,
+	onto eth.L2BlockRef, attrs *eth.PayloadAttributes) (*eth.ExecutionPayloadEnvelope, error) {
+	timeout := defaultBuilderTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline) / 2
+	}
+	builderCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	envelope, err := builder.GetPayload(builderCtx, onto, attrs)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBuilderPayload(rollupCfg, envelope, onto, attrs); err != nil {
+		return nil, err
+	}
+	return envelope, nil
+}
+
+// executeBuilderPayload executes the payload of the builder with the engine, and returns an error if the engine
+// did not find it valid. Inserting the payload again afterwards is a no-op for the engine.
+func executeBuilderPayload(ctx context.Context, eng ExecEngine, envelope *eth.ExecutionPayloadEnvelope) error {
+	status, err := eng.NewPayload(ctx, envelope.ExecutionPayload, envelope.ParentBeaconBlockRoot)
+	if err != nil {
+		return fmt.Errorf("failed to execute builder payload: %w", err)
+	}
+	if status.Status != eth.ExecutionValid {
+		return eth.NewPayloadErr(envelope.ExecutionPayload, status)
+	}
+	return nil
+}
+
+// checkBuilderPayload verifies that the payload of the builder is built on top of the parent with the given attributes,
+// before it is preferred over the payload of the engine.
+func checkBuilderPayload(rollupCfg *rollup.Config, envelope *eth.ExecutionPayloadEnvelope, parent eth.L2BlockRef, attrs *eth.PayloadAttributes) error {
+	if envelope == nil || envelope.ExecutionPayload == nil {
+		return fmt.Errorf("no payload")
+	}
+	payload := envelope.ExecutionPayload
+	if payload.ParentHash != parent.Hash {
+		return fmt.Errorf("payload parent %s does not match %s", payload.ParentHash, parent.Hash)
+	}
+	if payload.Timestamp != attrs.Timestamp {
+		return fmt.Errorf("payload timestamp %d does not match attributes timestamp %d", payload.Timestamp, attrs.Timestamp)
+	}
+	if err := sanityCheckPayload(rollupCfg, payload, attrs); err != nil {
+		return err
+	}
+	return envelope.VerifyBlockHash()
+}
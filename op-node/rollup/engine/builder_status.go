@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// builderStatusWindow is the number of recent payloads of the builder that the failure rate is computed over.
+const builderStatusWindow = 100
+
+// BuilderStats tracks the payloads that were requested from the external block builder of the sequencer,
+// to report the health of the builder, e.g. to the sequencer conductor. It is safe for concurrent use.
+// A nil BuilderStats does not track anything.
+type BuilderStats struct {
+	mu sync.Mutex
+	// builder is the block builder, nil if the sequencer has no builder
+	builder BuilderClient
+	// lastFailed is true if the builder did not provide the last requested payload
+	lastFailed bool
+	// failed is a ring buffer of the outcomes of the recent payload requests, true if the payload of the engine was used
+	failed [builderStatusWindow]bool
+	next   int
+	size   int
+}
+
+func (s *BuilderStats) setBuilder(b BuilderClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.builder = b
+}
+
+// record records whether the payload of the builder was used, or the payload of the engine as fallback.
+func (s *BuilderStats) record(failed bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastFailed = failed
+	s.failed[s.next] = failed
+	s.next = (s.next + 1) % builderStatusWindow
+	s.size = min(s.size+1, builderStatusWindow)
+}
+
+// Status returns the status of the builder, or nil if the sequencer has no builder.
+// The builder is unavailable if it is disabled, or did not provide the last requested payload.
+func (s *BuilderStats) Status() *eth.BuilderStatus {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.builder == nil {
+		return nil
+	}
+	status := &eth.BuilderStatus{
+		Available: s.builder.Enabled() && !s.lastFailed,
+		Blocks:    hexutil.Uint64(s.size),
+	}
+	for i := 0; i < s.size; i++ {
+		if s.failed[i] {
+			status.Failures++
+		}
+	}
+	return status
+}
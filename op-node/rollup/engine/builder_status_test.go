@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestBuilderStats(t *testing.T) {
+	var nilStats *BuilderStats
+	nilStats.record(true)
+	require.Nil(t, nilStats.Status())
+
+	stats := &BuilderStats{}
+	require.Nil(t, stats.Status(), "no status without builder")
+
+	builder := &mockBuilder{enabled: true}
+	stats.setBuilder(builder)
+	require.Equal(t, &eth.BuilderStatus{Available: true}, stats.Status())
+
+	stats.record(false)
+	stats.record(true)
+	require.Equal(t, &eth.BuilderStatus{Available: false, Blocks: 2, Failures: 1}, stats.Status())
+
+	stats.record(false)
+	require.Equal(t, &eth.BuilderStatus{Available: true, Blocks: 3, Failures: 1}, stats.Status())
+
+	// the failure rate only covers the recent payloads
+	for i := 0; i < builderStatusWindow; i++ {
+		stats.record(false)
+	}
+	require.Equal(t, &eth.BuilderStatus{Available: true, Blocks: builderStatusWindow}, stats.Status())
+
+	builder.enabled = false
+	require.False(t, stats.Status().Available, "disabled builder is unavailable")
+}
@@ -0,0 +1,240 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type mockBuilder struct {
+	mock.Mock
+	enabled bool
+}
+
+var _ BuilderClient = (*mockBuilder)(nil)
+
+func (m *mockBuilder) Start(ctx context.Context) error { return nil }
+
+func (m *mockBuilder) Close() error { return nil }
+
+func (m *mockBuilder) Enabled() bool { return m.enabled }
+
+func (m *mockBuilder) GetPayload(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes) (*eth.ExecutionPayloadEnvelope, error) {
+	out := m.Mock.Called(parent)
+	return out.Get(0).(*eth.ExecutionPayloadEnvelope), out.Error(1)
+}
+
+func (m *mockBuilder) ExpectGetPayload(parent eth.L2BlockRef, envelope *eth.ExecutionPayloadEnvelope, err error) {
+	m.Mock.On("GetPayload", parent).Once().Return(envelope, err)
+}
+
+// stallingBuilder does not return a payload before the deadline.
+type stallingBuilder struct{}
+
+func (stallingBuilder) Start(ctx context.Context) error { return nil }
+
+func (stallingBuilder) Close() error { return nil }
+
+func (stallingBuilder) Enabled() bool { return true }
+
+func (stallingBuilder) GetPayload(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes) (*eth.ExecutionPayloadEnvelope, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestGetPayload(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	rollupCfg := &rollup.Config{}
+	onto := testutils.RandomL2BlockRef(rng)
+	info := eth.PayloadInfo{ID: eth.PayloadID{0x01}, Timestamp: onto.Time + 2}
+	ctx := context.Background()
+
+	l1InfoTx, err := derive.L1InfoDepositBytes(rollupCfg, eth.SystemConfig{}, 0, testutils.RandomBlockInfo(rng), info.Timestamp)
+	require.NoError(t, err)
+	attrs := &eth.PayloadAttributes{
+		Timestamp:    eth.Uint64Quantity(info.Timestamp),
+		Transactions: []eth.Data{l1InfoTx},
+	}
+
+	// payload builds a payload with the given parent, that matches the attributes
+	payload := func(t *testing.T, parent common.Hash) *eth.ExecutionPayloadEnvelope {
+		var tx types.Transaction
+		require.NoError(t, tx.UnmarshalBinary(l1InfoTx))
+		header := &types.Header{
+			ParentHash: parent,
+			Difficulty: common.Big0,
+			Number:     new(big.Int).SetUint64(onto.Number + 1),
+			GasLimit:   30_000_000,
+			Time:       info.Timestamp,
+			BaseFee:    big.NewInt(7),
+		}
+		envelope, err := eth.BlockAsPayloadEnv(types.NewBlock(header, []*types.Transaction{&tx}, nil, nil, trie.NewStackTrie(nil)), nil)
+		require.NoError(t, err)
+		return envelope
+	}
+	engPayload := payload(t, onto.Hash)
+	engPayload.ExecutionPayload.ExtraData = eth.BytesMax32{0x01}
+
+	setup := func(t *testing.T, enabled bool) (*testutils.MockEngine, *mockBuilder) {
+		return &testutils.MockEngine{}, &mockBuilder{enabled: enabled}
+	}
+	get := func(t *testing.T, eng *testutils.MockEngine, builder BuilderClient, attrs *eth.PayloadAttributes) *eth.ExecutionPayloadEnvelope {
+		envelope, source, err := getPayload(ctx, testlog.Logger(t, log.LevelError), rollupCfg, eng, builder, nil, onto, info, attrs)
+		require.NoError(t, err)
+		if envelope == engPayload {
+			require.Equal(t, eth.PayloadSourceLocal, source)
+		} else {
+			require.Equal(t, eth.PayloadSourceBuilder, source)
+		}
+		return envelope
+	}
+
+	t.Run("no builder", func(t *testing.T) {
+		eng, _ := setup(t, true)
+		eng.ExpectGetPayload(info.ID, engPayload, nil)
+		require.Equal(t, engPayload, get(t, eng, nil, attrs))
+		eng.AssertExpectations(t)
+	})
+
+	t.Run("builder disabled", func(t *testing.T) {
+		eng, builder := setup(t, false)
+		eng.ExpectGetPayload(info.ID, engPayload, nil)
+		require.Equal(t, engPayload, get(t, eng, builder, attrs))
+		eng.AssertExpectations(t)
+		builder.AssertExpectations(t)
+	})
+
+	t.Run("no tx pool", func(t *testing.T) {
+		eng, builder := setup(t, true)
+		eng.ExpectGetPayload(info.ID, engPayload, nil)
+		noTxPool := *attrs
+		noTxPool.NoTxPool = true
+		require.Equal(t, engPayload, get(t, eng, builder, &noTxPool))
+		eng.AssertExpectations(t)
+		builder.AssertExpectations(t)
+	})
+
+	t.Run("builder payload", func(t *testing.T) {
+		eng, builder := setup(t, true)
+		builderPayload := payload(t, onto.Hash)
+		builder.ExpectGetPayload(onto, builderPayload, nil)
+		eng.ExpectNewPayload(builderPayload.ExecutionPayload, nil, &eth.PayloadStatusV1{Status: eth.ExecutionValid}, nil)
+		require.Equal(t, builderPayload, get(t, eng, builder, attrs))
+		eng.AssertExpectations(t)
+		builder.AssertExpectations(t)
+	})
+
+	t.Run("builder payload rejected by engine", func(t *testing.T) {
+		for _, status := range []eth.ExecutePayloadStatus{eth.ExecutionInvalid, eth.ExecutionSyncing} {
+			t.Run(string(status), func(t *testing.T) {
+				eng, builder := setup(t, true)
+				builderPayload := payload(t, onto.Hash)
+				builder.ExpectGetPayload(onto, builderPayload, nil)
+				eng.ExpectNewPayload(builderPayload.ExecutionPayload, nil, &eth.PayloadStatusV1{Status: status}, nil)
+				eng.ExpectGetPayload(info.ID, engPayload, nil)
+				require.Equal(t, engPayload, get(t, eng, builder, attrs))
+				eng.AssertExpectations(t)
+				builder.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("builder timeout", func(t *testing.T) {
+		eng, _ := setup(t, true)
+		eng.ExpectGetPayload(info.ID, engPayload, nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		// the builder uses up its deadline, which leaves time for the engine fallback
+		envelope, _, err := getPayload(ctx, testlog.Logger(t, log.LevelError), rollupCfg, eng, stallingBuilder{}, nil, onto, info, attrs)
+		require.NoError(t, err)
+		require.Equal(t, engPayload, envelope)
+		require.NoError(t, ctx.Err())
+		eng.AssertExpectations(t)
+	})
+
+	t.Run("builder error", func(t *testing.T) {
+		eng, builder := setup(t, true)
+		builder.ExpectGetPayload(onto, (*eth.ExecutionPayloadEnvelope)(nil), errors.New("builder unavailable"))
+		eng.ExpectGetPayload(info.ID, engPayload, nil)
+		stats := &BuilderStats{}
+		stats.setBuilder(builder)
+		envelope, _, err := getPayload(ctx, testlog.Logger(t, log.LevelError), rollupCfg, eng, builder, stats, onto, info, attrs)
+		require.NoError(t, err)
+		require.Equal(t, engPayload, envelope)
+		require.Equal(t, &eth.BuilderStatus{Available: false, Blocks: 1, Failures: 1}, stats.Status())
+		eng.AssertExpectations(t)
+		builder.AssertExpectations(t)
+	})
+
+	t.Run("invalid builder payload", func(t *testing.T) {
+		invalid := map[string]func() *eth.ExecutionPayloadEnvelope{
+			"nil": func() *eth.ExecutionPayloadEnvelope { return nil },
+			"wrong parent": func() *eth.ExecutionPayloadEnvelope {
+				return payload(t, common.Hash{0xaa})
+			},
+			"wrong timestamp": func() *eth.ExecutionPayloadEnvelope {
+				envelope := payload(t, onto.Hash)
+				envelope.ExecutionPayload.Timestamp++
+				return envelope
+			},
+			"missing deposits": func() *eth.ExecutionPayloadEnvelope {
+				envelope := payload(t, onto.Hash)
+				envelope.ExecutionPayload.Transactions = nil
+				return envelope
+			},
+			"bad block hash": func() *eth.ExecutionPayloadEnvelope {
+				envelope := payload(t, onto.Hash)
+				envelope.ExecutionPayload.BlockHash = common.Hash{0xbb}
+				return envelope
+			},
+		}
+		for name, fn := range invalid {
+			t.Run(name, func(t *testing.T) {
+				eng, builder := setup(t, true)
+				builder.ExpectGetPayload(onto, fn(), nil)
+				eng.ExpectGetPayload(info.ID, engPayload, nil)
+				require.Equal(t, engPayload, get(t, eng, builder, attrs))
+				eng.AssertExpectations(t)
+				builder.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdateForkchoiceRetries(t *testing.T) {
+	fc := &eth.ForkchoiceState{HeadBlockHash: common.Hash{1}}
+	valid := &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}
+
+	eng := new(testutils.MockEngine)
+	eng.ExpectForkchoiceUpdate(fc, nil, nil, errors.New("connection refused"))
+	eng.ExpectForkchoiceUpdate(fc, nil, valid, nil)
+	res, err := updateForkchoice(context.Background(), eng, fc)
+	require.NoError(t, err)
+	require.Equal(t, valid, res)
+	eng.AssertExpectations(t)
+
+	// the engine rejecting the forkchoice state is not retried
+	eng = new(testutils.MockEngine)
+	inputErr := eth.InputError{Inner: errors.New("invalid"), Code: eth.InvalidForkchoiceState}
+	eng.ExpectForkchoiceUpdate(fc, nil, nil, inputErr)
+	_, err = updateForkchoice(context.Background(), eng, fc)
+	require.ErrorIs(t, err, inputErr)
+	eng.AssertExpectations(t)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -37,11 +38,16 @@ const (
 
 var ErrNoFCUNeeded = errors.New("no FCU call was needed")
 
+// maxInsertionErrors is the number of most recent block insertion errors that are kept, for diagnostics.
+const maxInsertionErrors = 16
+
 type ExecEngine interface {
 	GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error)
 	ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
 	NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error)
 	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
 }
 
 type EngineController struct {
@@ -57,6 +63,12 @@ type EngineController struct {
 
 	emitter event.Emitter
 
+	reorgGuard *ReorgGuard
+
+	// builder of the sequencer payloads, nil if the payloads are only built by the engine
+	builder      BuilderClient
+	builderStats *BuilderStats
+
 	// Block Head State
 	unsafeHead       eth.L2BlockRef
 	pendingSafeHead  eth.L2BlockRef // L2 block processed from the middle of a span batch, but not marked as the safe block yet.
@@ -72,10 +84,10 @@ type EngineController struct {
 	needFCUCallForBackupUnsafeReorg bool
 
 	// Building State
-	buildingOnto eth.L2BlockRef
-	buildingInfo eth.PayloadInfo
-	buildingSafe bool
-	safeAttrs    *derive.AttributesWithParent
+	building *BlockBuildingJob
+
+	// most recent block insertion errors, oldest first
+	insertionErrors []eth.BlockInsertionError
 }
 
 func NewEngineController(engine ExecEngine, log log.Logger, metrics derive.Metrics,
@@ -86,18 +98,31 @@ func NewEngineController(engine ExecEngine, log log.Logger, metrics derive.Metri
 	}
 
 	return &EngineController{
-		engine:     engine,
-		log:        log,
-		metrics:    metrics,
-		chainSpec:  rollup.NewChainSpec(rollupCfg),
-		rollupCfg:  rollupCfg,
-		syncCfg:    syncCfg,
-		syncStatus: syncStatus,
-		clock:      clock.SystemClock,
-		emitter:    emitter,
+		engine:       engine,
+		log:          log,
+		metrics:      metrics,
+		chainSpec:    rollup.NewChainSpec(rollupCfg),
+		rollupCfg:    rollupCfg,
+		syncCfg:      syncCfg,
+		syncStatus:   syncStatus,
+		clock:        clock.SystemClock,
+		emitter:      emitter,
+		reorgGuard:   NewReorgGuard(syncCfg.MaxUnsafeReorgDepth),
+		builderStats: &BuilderStats{},
 	}
 }
 
+// SetClock replaces the clock of the engine controller, e.g. with a deterministic clock in simulations.
+func (e *EngineController) SetClock(c clock.Clock) {
+	e.clock = c
+}
+
+// SetBuilder sets the external block builder to prefer the payloads of, over the payloads of the engine.
+func (e *EngineController) SetBuilder(b BuilderClient) {
+	e.builder = b
+	e.builderStats.setBuilder(b)
+}
+
 // State Getters
 
 func (e *EngineController) UnsafeL2Head() eth.L2BlockRef {
@@ -120,8 +145,58 @@ func (e *EngineController) BackupUnsafeL2Head() eth.L2BlockRef {
 	return e.backupUnsafeHead
 }
 
-func (e *EngineController) BuildingPayload() (eth.L2BlockRef, eth.PayloadID, bool) {
-	return e.buildingOnto, e.buildingInfo.ID, e.buildingSafe
+// BuildingJob returns the job of the block that is being built, or nil if no block is being built.
+func (e *EngineController) BuildingJob() *BlockBuildingJob {
+	return e.building
+}
+
+// ReorgGuard returns the guard against deep unsafe reorgs.
+func (e *EngineController) ReorgGuard() *ReorgGuard {
+	return e.reorgGuard
+}
+
+// BuilderStats returns the statistics of the external block builder.
+func (e *EngineController) BuilderStats() *BuilderStats {
+	return e.builderStats
+}
+
+// QueueState returns a snapshot of the forkchoice targets, the block that is being built,
+// and the most recent block insertion errors. The queued unsafe payloads are not tracked by the engine controller.
+func (e *EngineController) QueueState() eth.EngineQueueState {
+	state := eth.EngineQueueState{
+		UnsafeL2:        e.unsafeHead,
+		PendingSafeL2:   e.pendingSafeHead,
+		SafeL2:          e.safeHead,
+		FinalizedL2:     e.finalizedHead,
+		BackupUnsafeL2:  e.backupUnsafeHead,
+		NeedFCUCall:     e.needFCUCall,
+		EngineSyncing:   e.IsEngineSyncing(),
+		InsertionErrors: append([]eth.BlockInsertionError(nil), e.insertionErrors...),
+	}
+	if job := e.building; job != nil {
+		state.PendingPayload = &eth.PendingPayload{
+			ID:        job.ID(),
+			Onto:      job.Onto(),
+			Timestamp: hexutil.Uint64(job.PayloadInfo().Timestamp),
+			Safe:      job.Safe(),
+			State:     job.State().String(),
+			StartedAt: job.StartedAt(),
+		}
+	}
+	return state
+}
+
+// recordInsertionError keeps the error of building or inserting a block on top of onto, for diagnostics.
+func (e *EngineController) recordInsertionError(onto eth.L2BlockRef, errTyp BlockInsertionErrType, err error) {
+	if len(e.insertionErrors) >= maxInsertionErrors {
+		e.insertionErrors = e.insertionErrors[1:]
+	}
+	e.insertionErrors = append(e.insertionErrors, eth.BlockInsertionError{
+		Time:  e.clock.Now(),
+		Type:  errTyp.String(),
+		Onto:  onto,
+		Error: err.Error(),
+	})
 }
 
 func (e *EngineController) IsEngineSyncing() bool {
@@ -211,14 +286,24 @@ func (e *EngineController) logSyncProgressMaybe() func() {
 
 // Engine Methods
 
-func (e *EngineController) StartPayload(ctx context.Context, parent eth.L2BlockRef, attrs *derive.AttributesWithParent, updateSafe bool) (errType BlockInsertionErrType, err error) {
+// StartBuildingJob starts building a block on top of parent with the given attributes, replacing any previous job.
+// If updateSafe, the resulting block will be marked as a safe block.
+func (e *EngineController) StartBuildingJob(ctx context.Context, parent eth.L2BlockRef, attrs *derive.AttributesWithParent, updateSafe bool) (job *BlockBuildingJob, errTyp BlockInsertionErrType, err error) {
+	defer func() {
+		if err != nil {
+			e.recordInsertionError(parent, errTyp, err)
+		}
+	}()
 	if e.IsEngineSyncing() {
-		return BlockInsertTemporaryErr, fmt.Errorf("engine is in progess of p2p sync")
+		return nil, BlockInsertTemporaryErr, fmt.Errorf("engine is in progess of p2p sync")
 	}
-	if e.buildingInfo != (eth.PayloadInfo{}) {
-		e.log.Warn("did not finish previous block building, starting new building now", "prev_onto", e.buildingOnto, "prev_payload_id", e.buildingInfo.ID, "new_onto", parent)
+	if e.building != nil {
+		e.log.Warn("did not finish previous block building, starting new building now", "prev_onto", e.building.Onto(), "prev_payload_id", e.building.ID(), "new_onto", parent)
 		// TODO(8841): maybe worth it to force-cancel the old payload ID here.
 	}
+	if err := e.checkUnsafeReorg(ctx, parent.ID()); err != nil {
+		return nil, BlockInsertTemporaryErr, err
+	}
 	fc := eth.ForkchoiceState{
 		HeadBlockHash:      parent.Hash,
 		SafeBlockHash:      e.safeHead.Hash,
@@ -227,7 +312,7 @@ func (e *EngineController) StartPayload(ctx context.Context, parent eth.L2BlockR
 
 	id, errTyp, err := startPayload(ctx, e.engine, fc, attrs.Attributes)
 	if err != nil {
-		return errTyp, err
+		return nil, errTyp, err
 	}
 	e.emitter.Emit(ForkchoiceUpdateEvent{
 		UnsafeL2Head:    parent,
@@ -235,39 +320,62 @@ func (e *EngineController) StartPayload(ctx context.Context, parent eth.L2BlockR
 		FinalizedL2Head: e.finalizedHead,
 	})
 
-	e.buildingInfo = eth.PayloadInfo{ID: id, Timestamp: uint64(attrs.Attributes.Timestamp)}
-	e.buildingSafe = updateSafe
-	e.buildingOnto = parent
-	if updateSafe {
-		e.safeAttrs = attrs
-	}
-
-	return BlockInsertOK, nil
+	e.resetBuildingState()
+	info := eth.PayloadInfo{ID: id, Timestamp: uint64(attrs.Attributes.Timestamp)}
+	e.building = NewBlockBuildingJob(e, parent, info, attrs, updateSafe, e.clock.Now())
+	return e.building, BlockInsertOK, nil
 }
 
-func (e *EngineController) ConfirmPayload(ctx context.Context, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error) {
+// SealJob implements JobEngine. The job must be the current building job.
+func (e *EngineController) SealJob(ctx context.Context, job *BlockBuildingJob, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error) {
 	// don't create a BlockInsertPrestateErr if we have a cached gossip payload
-	if e.buildingInfo == (eth.PayloadInfo{}) && agossip.Get() == nil {
+	if job == nil && agossip.Get() == nil {
 		return nil, BlockInsertPrestateErr, fmt.Errorf("cannot complete payload building: not currently building a payload")
 	}
-	if p := agossip.Get(); p != nil && e.buildingOnto == (eth.L2BlockRef{}) {
+	if job != nil && job != e.building {
+		return nil, BlockInsertPrestateErr, fmt.Errorf("cannot complete payload building: %s is not the current building job", job)
+	}
+	var onto eth.L2BlockRef
+	var info eth.PayloadInfo
+	var updateSafe bool
+	var attrs *eth.PayloadAttributes
+	defer func() {
+		if err != nil {
+			e.recordInsertionError(onto, errTyp, err)
+		}
+	}()
+	if job != nil {
+		if err := job.startSealing(); err != nil {
+			return nil, BlockInsertPrestateErr, err
+		}
+		defer func() {
+			if err != nil {
+				job.sealFailed(errTyp, err)
+			}
+		}()
+		onto, info = job.Onto(), job.PayloadInfo()
+		// Update the safe head if the payload is built with the last attributes in the batch.
+		updateSafe = job.Safe() && job.Attributes() != nil && job.Attributes().IsLastInSpan
+		if job.Attributes() != nil {
+			attrs = job.Attributes().Attributes
+		}
+	}
+	if p := agossip.Get(); p != nil && job == nil {
 		e.log.Warn("Found reusable payload from async gossiper, and no block was being built. Reusing payload.",
 			"hash", p.ExecutionPayload.BlockHash,
 			"number", uint64(p.ExecutionPayload.BlockNumber),
 			"parent", p.ExecutionPayload.ParentHash)
-	} else if e.buildingOnto.Hash != e.unsafeHead.Hash { // E.g. when safe-attributes consolidation fails, it will drop the existing work.
-		e.log.Warn("engine is building block that reorgs previous unsafe head", "onto", e.buildingOnto, "unsafe", e.unsafeHead)
+	} else if onto.Hash != e.unsafeHead.Hash { // E.g. when safe-attributes consolidation fails, it will drop the existing work.
+		e.log.Warn("engine is building block that reorgs previous unsafe head", "onto", onto, "unsafe", e.unsafeHead)
 	}
 	fc := eth.ForkchoiceState{
 		HeadBlockHash:      common.Hash{}, // gets overridden
 		SafeBlockHash:      e.safeHead.Hash,
 		FinalizedBlockHash: e.finalizedHead.Hash,
 	}
-	// Update the safe head if the payload is built with the last attributes in the batch.
-	updateSafe := e.buildingSafe && e.safeAttrs != nil && e.safeAttrs.IsLastInSpan
-	envelope, errTyp, err := confirmPayload(ctx, e.log, e.engine, fc, e.buildingInfo, updateSafe, agossip, sequencerConductor)
+	envelope, errTyp, err := confirmPayload(ctx, e.log, e.rollupCfg, e.engine, e.builder, e.builderStats, onto, fc, info, attrs, updateSafe, agossip, sequencerConductor)
 	if err != nil {
-		return nil, errTyp, fmt.Errorf("failed to complete building on top of L2 chain %s, id: %s, error (%d): %w", e.buildingOnto, e.buildingInfo.ID, errTyp, err)
+		return nil, errTyp, fmt.Errorf("failed to complete building on top of L2 chain %s, id: %s, error (%d): %w", onto, info.ID, errTyp, err)
 	}
 	ref, err := derive.PayloadToBlockRef(e.rollupCfg, envelope.ExecutionPayload)
 	if err != nil {
@@ -280,7 +388,7 @@ func (e *EngineController) ConfirmPayload(ctx context.Context, agossip async.Asy
 	e.unsafeHead = ref
 
 	e.metrics.RecordL2Ref("l2_unsafe", ref)
-	if e.buildingSafe {
+	if job != nil && job.Safe() {
 		e.metrics.RecordL2Ref("l2_pending_safe", ref)
 		e.pendingSafeHead = ref
 		if updateSafe {
@@ -296,19 +404,23 @@ func (e *EngineController) ConfirmPayload(ctx context.Context, agossip async.Asy
 		FinalizedL2Head: e.finalizedHead,
 	})
 
+	if job != nil {
+		job.inserted(envelope)
+	}
 	e.resetBuildingState()
 	return envelope, BlockInsertOK, nil
 }
 
-func (e *EngineController) CancelPayload(ctx context.Context, force bool) error {
-	if e.buildingInfo == (eth.PayloadInfo{}) { // only cancel if there is something to cancel.
+// CancelJob implements JobEngine. Jobs other than the current building job are already finished, and not cancelled.
+func (e *EngineController) CancelJob(ctx context.Context, job *BlockBuildingJob, force bool) error {
+	if job == nil || job != e.building { // only cancel if there is something to cancel.
 		return nil
 	}
 	// the building job gets wrapped up as soon as the payload is retrieved, there's no explicit cancel in the Engine API
-	e.log.Error("cancelling old block sealing job", "payload", e.buildingInfo.ID)
-	_, err := e.engine.GetPayload(ctx, e.buildingInfo)
+	e.log.Error("cancelling old block sealing job", "payload", job.ID())
+	_, err := e.engine.GetPayload(ctx, job.PayloadInfo())
 	if err != nil {
-		e.log.Error("failed to cancel block building job", "payload", e.buildingInfo.ID, "err", err)
+		e.log.Error("failed to cancel block building job", "payload", job.ID(), "err", err)
 		if !force {
 			return err
 		}
@@ -317,11 +429,12 @@ func (e *EngineController) CancelPayload(ctx context.Context, force bool) error
 	return nil
 }
 
+// resetBuildingState drops the current building job. A job that did not insert its block yet fails as cancelled.
 func (e *EngineController) resetBuildingState() {
-	e.buildingInfo = eth.PayloadInfo{}
-	e.buildingOnto = eth.L2BlockRef{}
-	e.buildingSafe = false
-	e.safeAttrs = nil
+	if e.building != nil {
+		e.building.fail(ErrBuildingJobCancelled)
+	}
+	e.building = nil
 }
 
 // Misc Setters only used by the engine queue
@@ -368,13 +481,13 @@ func (e *EngineController) TryUpdateEngine(ctx context.Context) error {
 	}
 	logFn := e.logSyncProgressMaybe()
 	defer logFn()
-	fcRes, err := e.engine.ForkchoiceUpdate(ctx, &fc, nil)
+	fcRes, err := updateForkchoice(ctx, e.engine, &fc)
 	if err != nil {
 		var inputErr eth.InputError
 		if errors.As(err, &inputErr) {
 			switch inputErr.Code {
 			case eth.InvalidForkchoiceState:
-				return derive.NewResetError(fmt.Errorf("forkchoice update was inconsistent with engine, need reset to resolve: %w", inputErr.Unwrap()))
+				return derive.NewResetError(fmt.Errorf("forkchoice update was inconsistent with engine: %w: %w", errInconsistentForkchoice, inputErr.Unwrap()))
 			default:
 				return derive.NewTemporaryError(fmt.Errorf("unexpected error code in forkchoice-updated response: %w", err))
 			}
@@ -410,6 +523,9 @@ func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *et
 			return derive.NewTemporaryError(fmt.Errorf("failed to fetch finalized head: %w", err))
 		}
 	}
+	if err := e.checkUnsafeReorg(ctx, ref.ParentID()); err != nil {
+		return derive.NewTemporaryError(err)
+	}
 	// Insert the payload & then call FCU
 	status, err := e.engine.NewPayload(ctx, envelope.ExecutionPayload, envelope.ParentBeaconBlockRoot)
 	if err != nil {
@@ -444,7 +560,7 @@ func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *et
 		if errors.As(err, &inputErr) {
 			switch inputErr.Code {
 			case eth.InvalidForkchoiceState:
-				return derive.NewResetError(fmt.Errorf("pre-unsafe-block forkchoice update was inconsistent with engine, need reset to resolve: %w", inputErr.Unwrap()))
+				return derive.NewResetError(fmt.Errorf("pre-unsafe-block forkchoice update was inconsistent with engine: %w: %w", errInconsistentForkchoice, inputErr.Unwrap()))
 			default:
 				return derive.NewTemporaryError(fmt.Errorf("unexpected error code in forkchoice-updated response: %w", err))
 			}
@@ -522,7 +638,7 @@ func (e *EngineController) TryBackupUnsafeReorg(ctx context.Context) (bool, erro
 			e.SetBackupUnsafeL2Head(eth.L2BlockRef{}, false)
 			switch inputErr.Code {
 			case eth.InvalidForkchoiceState:
-				return true, derive.NewResetError(fmt.Errorf("forkchoice update was inconsistent with engine, need reset to resolve: %w", inputErr.Unwrap()))
+				return true, derive.NewResetError(fmt.Errorf("forkchoice update was inconsistent with engine: %w: %w", errInconsistentForkchoice, inputErr.Unwrap()))
 			default:
 				return true, derive.NewTemporaryError(fmt.Errorf("unexpected error code in forkchoice-updated response: %w", err))
 			}
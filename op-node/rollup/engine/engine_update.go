@@ -4,68 +4,54 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/async"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 )
 
-// isDepositTx checks an opaqueTx to determine if it is a Deposit Transaction
-// It has to return an error in the case the transaction is empty
-func isDepositTx(opaqueTx eth.Data) (bool, error) {
-	if len(opaqueTx) == 0 {
-		return false, errors.New("empty transaction")
-	}
-	return opaqueTx[0] == types.DepositTxType, nil
+// forkchoiceRetryPolicy retries forkchoice updates without payload attributes, which are idempotent, when they failed
+// to reach the engine. The engine rejecting the forkchoice state, as eth.InputError, is not retried.
+var forkchoiceRetryPolicy = retry.Policy{
+	Strategy:    retry.Fixed(100 * time.Millisecond),
+	MaxAttempts: 3,
+	Classify:    retry.FatalOn(eth.InputError{}),
+	Jitter:      0.5,
 }
 
-// lastDeposit finds the index of last deposit at the start of the transactions.
-// It walks the transactions from the start until it finds a non-deposit tx.
-// An error is returned if any looked at transaction cannot be decoded
-func lastDeposit(txns []eth.Data) (int, error) {
-	var lastDeposit int
-	for i, tx := range txns {
-		deposit, err := isDepositTx(tx)
-		if err != nil {
-			return 0, fmt.Errorf("invalid transaction at idx %d", i)
-		}
-		if deposit {
-			lastDeposit = i
-		} else {
-			break
-		}
-	}
-	return lastDeposit, nil
+// updateForkchoice updates the forkchoice of the engine without building a block, see forkchoiceRetryPolicy.
+func updateForkchoice(ctx context.Context, eng ExecEngine, fc *eth.ForkchoiceState) (*eth.ForkchoiceUpdatedResult, error) {
+	return retry.DoWithPolicy(ctx, forkchoiceRetryPolicy, func() (*eth.ForkchoiceUpdatedResult, error) {
+		return eng.ForkchoiceUpdate(ctx, fc, nil)
+	})
 }
 
-func sanityCheckPayload(payload *eth.ExecutionPayload) error {
-	// Sanity check payload before inserting it
-	if len(payload.Transactions) == 0 {
-		return errors.New("no transactions in returned payload")
-	}
-	if payload.Transactions[0][0] != types.DepositTxType {
-		return fmt.Errorf("first transaction was not deposit tx. Got %v", payload.Transactions[0][0])
+// sanityCheckPayload verifies the deposits of the payload before inserting it, as the payload may have been built by an
+// external block builder. The source-hash of the L1 info deposit is recomputed from the L1 info it carries.
+// If the payload was built with known attributes, the deposits must match the deposits of the attributes.
+func sanityCheckPayload(rollupCfg *rollup.Config, payload *eth.ExecutionPayload, attrs *eth.PayloadAttributes) error {
+	var expected []eth.Data
+	if attrs != nil {
+		expected = attrs.Transactions
+	}
+	if _, err := eth.CheckDeposits(payload.Transactions, expected); err != nil {
+		return err
+	}
+	l1InfoTx, err := eth.DecodeDepositTx(payload.Transactions[0])
+	if err != nil {
+		return fmt.Errorf("failed to decode L1 info deposit: %w", err)
 	}
-	// Ensure that the deposits are first
-	lastDeposit, err := lastDeposit(payload.Transactions)
+	l1Info, err := derive.L1BlockInfoFromBytes(rollupCfg, uint64(payload.Timestamp), l1InfoTx.Data)
 	if err != nil {
-		return fmt.Errorf("failed to find last deposit: %w", err)
-	}
-	// Ensure no deposits after last deposit
-	for i := lastDeposit + 1; i < len(payload.Transactions); i++ {
-		tx := payload.Transactions[i]
-		deposit, err := isDepositTx(tx)
-		if err != nil {
-			return fmt.Errorf("failed to decode transaction idx %d: %w", i, err)
-		}
-		if deposit {
-			return fmt.Errorf("deposit tx (%d) after other tx in l2 block with prev deposit at idx %d", i, lastDeposit)
-		}
+		return fmt.Errorf("failed to parse L1 info deposit: %w", err)
 	}
-	return nil
+	return eth.CheckL1InfoDepositSource(l1InfoTx, l1Info.BlockHash, l1Info.SequenceNumber)
 }
 
 type BlockInsertionErrType uint
@@ -81,6 +67,21 @@ const (
 	BlockInsertPayloadErr
 )
 
+func (t BlockInsertionErrType) String() string {
+	switch t {
+	case BlockInsertOK:
+		return "ok"
+	case BlockInsertTemporaryErr:
+		return "temporary"
+	case BlockInsertPrestateErr:
+		return "prestate"
+	case BlockInsertPayloadErr:
+		return "payload"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint(t))
+	}
+}
+
 // startPayload starts an execution payload building process in the provided Engine, with the given attributes.
 // The severity of the error is distinguished to determine whether the same payload attributes may be re-attempted later.
 func startPayload(ctx context.Context, eng ExecEngine, fc eth.ForkchoiceState, attrs *eth.PayloadAttributes) (id eth.PayloadID, errType BlockInsertionErrType, err error) {
@@ -90,7 +91,7 @@ func startPayload(ctx context.Context, eng ExecEngine, fc eth.ForkchoiceState, a
 		if errors.As(err, &inputErr) {
 			switch inputErr.Code {
 			case eth.InvalidForkchoiceState:
-				return eth.PayloadID{}, BlockInsertPrestateErr, fmt.Errorf("pre-block-creation forkchoice update was inconsistent with engine, need reset to resolve: %w", inputErr.Unwrap())
+				return eth.PayloadID{}, BlockInsertPrestateErr, fmt.Errorf("pre-block-creation forkchoice update was inconsistent with engine: %w: %w", errInconsistentForkchoice, inputErr.Unwrap())
 			case eth.InvalidPayloadAttributes:
 				return eth.PayloadID{}, BlockInsertPayloadErr, fmt.Errorf("payload attributes are not valid, cannot build block: %w", inputErr.Unwrap())
 			default:
@@ -122,17 +123,26 @@ func startPayload(ctx context.Context, eng ExecEngine, fc eth.ForkchoiceState, a
 func confirmPayload(
 	ctx context.Context,
 	log log.Logger,
+	rollupCfg *rollup.Config,
 	eng ExecEngine,
+	builder BuilderClient,
+	builderStats *BuilderStats,
+	onto eth.L2BlockRef,
 	fc eth.ForkchoiceState,
 	payloadInfo eth.PayloadInfo,
+	attrs *eth.PayloadAttributes,
 	updateSafe bool,
 	agossip async.AsyncGossiper,
 	sequencerConductor conductor.SequencerConductor,
 ) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error) {
 	var envelope *eth.ExecutionPayloadEnvelope
+	// the provenance of the payload, unknown for payloads reused from the async gossiper
+	var metadata *eth.PayloadMetadata
 	// if the payload is available from the async gossiper, it means it was not yet imported, so we reuse it
 	if cached := agossip.Get(); cached != nil {
 		envelope = cached
+		// the cached payload may not have been built with the attributes of the current job
+		attrs = nil
 		// log a limited amount of information about the reused payload, more detailed logging happens later down
 		log.Debug("found uninserted payload from async gossiper, reusing it and bypassing engine",
 			"hash", envelope.ExecutionPayload.BlockHash,
@@ -140,17 +150,24 @@ func confirmPayload(
 			"parent", envelope.ExecutionPayload.ParentHash,
 			"txs", len(envelope.ExecutionPayload.Transactions))
 	} else {
-		envelope, err = eng.GetPayload(ctx, payloadInfo)
+		var source eth.PayloadSource
+		envelope, source, err = getPayload(ctx, log, rollupCfg, eng, builder, builderStats, onto, payloadInfo, attrs)
+		metadata = &eth.PayloadMetadata{Source: source}
 	}
 	if err != nil {
 		// even if it is an input-error (unknown payload ID), it is temporary, since we will re-attempt the full payload building, not just the retrieval of the payload.
 		return nil, BlockInsertTemporaryErr, fmt.Errorf("failed to get execution payload: %w", err)
 	}
 	payload := envelope.ExecutionPayload
-	if err := sanityCheckPayload(payload); err != nil {
+	if err := sanityCheckPayload(rollupCfg, payload, attrs); err != nil {
+		return nil, BlockInsertPayloadErr, err
+	}
+	// the payload may have been built by an external block builder, verify it before distributing it.
+	// Payloads of the builder are also executed by the engine already, see getPayload.
+	if err := envelope.VerifyBlockHash(); err != nil {
 		return nil, BlockInsertPayloadErr, err
 	}
-	if err := sequencerConductor.CommitUnsafePayload(ctx, envelope); err != nil {
+	if err := sequencerConductor.CommitUnsafePayload(ctx, envelope, metadata); err != nil {
 		return nil, BlockInsertTemporaryErr, fmt.Errorf("failed to commit unsafe payload to conductor: %w", err)
 	}
 	// begin gossiping as soon as possible
@@ -173,7 +190,7 @@ func confirmPayload(
 	if updateSafe {
 		fc.SafeBlockHash = payload.BlockHash
 	}
-	fcRes, err := eng.ForkchoiceUpdate(ctx, &fc, nil)
+	fcRes, err := updateForkchoice(ctx, eng, &fc)
 	if err != nil {
 		var inputErr eth.InputError
 		if errors.As(err, &inputErr) {
@@ -72,6 +72,29 @@ func (ev PromotePendingSafeEvent) String() string {
 	return "promote-pending-safe"
 }
 
+// CrossSafeCheckRequestEvent requests the cross-chain messages of the blocks after Safe,
+// up to and including Ref, to be verified before Ref is promoted to safe.
+type CrossSafeCheckRequestEvent struct {
+	Safe        eth.L2BlockRef
+	Ref         eth.L2BlockRef
+	DerivedFrom eth.L1BlockRef
+}
+
+func (ev CrossSafeCheckRequestEvent) String() string {
+	return "cross-safe-check-request"
+}
+
+// CrossSafeVerifiedEvent signals that the cross-chain messages of the blocks up to and including Ref are verified,
+// and that Ref can be promoted to safe.
+type CrossSafeVerifiedEvent struct {
+	Ref         eth.L2BlockRef
+	DerivedFrom eth.L1BlockRef
+}
+
+func (ev CrossSafeVerifiedEvent) String() string {
+	return "cross-safe-verified"
+}
+
 // SafeDerivedEvent signals that a block was determined to be safe, and derived from the given L1 block
 type SafeDerivedEvent struct {
 	Safe        eth.L2BlockRef
@@ -150,6 +173,9 @@ type EngDeriver struct {
 	ec      *EngineController
 	ctx     context.Context
 	emitter event.Emitter
+
+	// crossSafeChecks makes blocks wait for a CrossSafeVerifiedEvent before they are promoted to safe
+	crossSafeChecks bool
 }
 
 var _ event.Deriver = (*EngDeriver)(nil)
@@ -165,6 +191,12 @@ func NewEngDeriver(log log.Logger, ctx context.Context, cfg *rollup.Config,
 	}
 }
 
+// EnableCrossSafeChecks makes the deriver request the cross-chain messages of blocks to be verified,
+// with a CrossSafeCheckRequestEvent, before the blocks are promoted to safe.
+func (d *EngDeriver) EnableCrossSafeChecks() {
+	d.crossSafeChecks = true
+}
+
 func (d *EngDeriver) OnEvent(ev event.Event) {
 	switch x := ev.(type) {
 	case TryBackupUnsafeReorgEvent:
@@ -180,7 +212,9 @@ func (d *EngDeriver) OnEvent(ev event.Event) {
 		}
 		if err != nil {
 			// If we needed to perform a network call, then we should yield even if we did not encounter an error.
-			if errors.Is(err, derive.ErrReset) {
+			if errors.Is(err, errInconsistentForkchoice) {
+				d.emitter.Emit(RepairPrestateEvent{Err: err})
+			} else if errors.Is(err, derive.ErrReset) {
 				d.emitter.Emit(rollup.ResetEvent{Err: err})
 			} else if errors.Is(err, derive.ErrTemporary) {
 				d.emitter.Emit(rollup.EngineTemporaryErrorEvent{Err: err})
@@ -192,7 +226,9 @@ func (d *EngDeriver) OnEvent(ev event.Event) {
 		// If we don't need to call FCU, keep going b/c this was a no-op. If we needed to
 		// perform a network call, then we should yield even if we did not encounter an error.
 		if err := d.ec.TryUpdateEngine(d.ctx); err != nil && !errors.Is(err, ErrNoFCUNeeded) {
-			if errors.Is(err, derive.ErrReset) {
+			if errors.Is(err, errInconsistentForkchoice) {
+				d.emitter.Emit(RepairPrestateEvent{Err: err})
+			} else if errors.Is(err, derive.ErrReset) {
 				d.emitter.Emit(rollup.ResetEvent{Err: err})
 			} else if errors.Is(err, derive.ErrTemporary) {
 				d.emitter.Emit(rollup.EngineTemporaryErrorEvent{Err: err})
@@ -213,7 +249,9 @@ func (d *EngDeriver) OnEvent(ev event.Event) {
 			// through events, we can drop the engine-controller interface:
 			// unify the events handler with the engine-controller,
 			// remove a lot of code, and not do this error translation.
-			if errors.Is(err, derive.ErrReset) {
+			if errors.Is(err, errInconsistentForkchoice) {
+				d.emitter.Emit(RepairPrestateEvent{Err: err})
+			} else if errors.Is(err, derive.ErrReset) {
 				d.emitter.Emit(rollup.ResetEvent{Err: err})
 			} else if errors.Is(err, derive.ErrTemporary) {
 				d.emitter.Emit(rollup.EngineTemporaryErrorEvent{Err: err})
@@ -223,6 +261,19 @@ func (d *EngDeriver) OnEvent(ev event.Event) {
 		} else {
 			d.log.Info("successfully processed payload", "ref", ref, "txs", len(x.Envelope.ExecutionPayload.Transactions))
 		}
+	case RepairPrestateEvent:
+		if err := d.ec.RepairPrestate(d.ctx); err != nil {
+			if errors.Is(err, derive.ErrReset) {
+				d.emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("cannot repair pre-state after %v: %w", x.Err, err)})
+			} else if errors.Is(err, derive.ErrTemporary) {
+				d.emitter.Emit(rollup.EngineTemporaryErrorEvent{Err: err})
+			} else {
+				d.emitter.Emit(rollup.CriticalErrorEvent{Err: fmt.Errorf("unexpected RepairPrestate error type: %w", err)})
+			}
+			return
+		}
+		// Apply the repaired forkchoice state to the engine
+		d.emitter.Emit(TryUpdateEngineEvent{})
 	case ForkchoiceRequestEvent:
 		d.emitter.Emit(ForkchoiceUpdateEvent{
 			UnsafeL2Head:    d.ec.UnsafeL2Head(),
@@ -253,6 +304,16 @@ func (d *EngDeriver) OnEvent(ev event.Event) {
 			d.ec.SetPendingSafeL2Head(x.Ref)
 		}
 		if x.Safe && x.Ref.Number > d.ec.SafeL2Head().Number {
+			d.promoteSafe(x.Ref, x.DerivedFrom)
+		}
+	case CrossSafeVerifiedEvent:
+		// The pending-safe chain may have been reset while the messages were verified.
+		if pending := d.ec.PendingSafeL2Head(); x.Ref.Number > pending.Number ||
+			(x.Ref.Number == pending.Number && x.Ref.Hash != pending.Hash) {
+			d.log.Info("Ignoring stale cross-safe verification", "ref", x.Ref, "pending_safe", pending)
+			return
+		}
+		if x.Ref.Number > d.ec.SafeL2Head().Number {
 			d.ec.SetSafeHead(x.Ref)
 			d.emitter.Emit(SafeDerivedEvent{Safe: x.Ref, DerivedFrom: x.DerivedFrom})
 		}
@@ -277,10 +338,10 @@ func (eq *EngDeriver) onForceNextSafeAttributes(attributes *derive.AttributesWit
 	defer cancel()
 
 	attrs := attributes.Attributes
-	errType, err := eq.ec.StartPayload(ctx, eq.ec.PendingSafeL2Head(), attributes, true)
+	job, errType, err := eq.ec.StartBuildingJob(ctx, eq.ec.PendingSafeL2Head(), attributes, true)
 	var envelope *eth.ExecutionPayloadEnvelope
 	if err == nil {
-		envelope, errType, err = eq.ec.ConfirmPayload(ctx, async.NoOpGossiper{}, &conductor.NoOpConductor{})
+		envelope, errType, err = job.Seal(ctx, async.NoOpGossiper{}, &conductor.NoOpConductor{})
 	}
 	if err != nil {
 		switch errType {
@@ -289,14 +350,18 @@ func (eq *EngDeriver) onForceNextSafeAttributes(attributes *derive.AttributesWit
 			eq.emitter.Emit(rollup.EngineTemporaryErrorEvent{Err: fmt.Errorf("temporarily cannot insert new safe block: %w", err)})
 			return
 		case BlockInsertPrestateErr:
-			_ = eq.ec.CancelPayload(ctx, true)
-			eq.emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("need reset to resolve pre-state problem: %w", err)})
+			_ = eq.ec.CancelJob(ctx, eq.ec.BuildingJob(), true)
+			if errors.Is(err, errInconsistentForkchoice) {
+				eq.emitter.Emit(RepairPrestateEvent{Err: err})
+			} else {
+				eq.emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("need reset to resolve pre-state problem: %w", err)})
+			}
 			return
 		case BlockInsertPayloadErr:
 			if !errors.Is(err, derive.ErrTemporary) {
 				eq.emitter.Emit(InvalidPayloadAttributesEvent{Attributes: attributes})
 			}
-			_ = eq.ec.CancelPayload(ctx, true)
+			_ = eq.ec.CancelJob(ctx, eq.ec.BuildingJob(), true)
 			eq.log.Warn("could not process payload derived from L1 data, dropping attributes", "err", err)
 			// Count the number of deposits to see if the tx list is deposit only.
 			depositCount := 0
@@ -334,8 +399,7 @@ func (eq *EngDeriver) onForceNextSafeAttributes(attributes *derive.AttributesWit
 	}
 	eq.ec.SetPendingSafeL2Head(ref)
 	if attributes.IsLastInSpan {
-		eq.ec.SetSafeHead(ref)
-		eq.emitter.Emit(SafeDerivedEvent{Safe: ref, DerivedFrom: attributes.DerivedFrom})
+		eq.promoteSafe(ref, attributes.DerivedFrom)
 	}
 	eq.emitter.Emit(PendingSafeUpdateEvent{
 		PendingSafe: eq.ec.PendingSafeL2Head(),
@@ -343,6 +407,17 @@ func (eq *EngDeriver) onForceNextSafeAttributes(attributes *derive.AttributesWit
 	})
 }
 
+// promoteSafe promotes the block to safe,
+// or requests its cross-chain messages to be verified first if cross-safe checks are enabled.
+func (d *EngDeriver) promoteSafe(ref eth.L2BlockRef, derivedFrom eth.L1BlockRef) {
+	if d.crossSafeChecks {
+		d.emitter.Emit(CrossSafeCheckRequestEvent{Safe: d.ec.SafeL2Head(), Ref: ref, DerivedFrom: derivedFrom})
+		return
+	}
+	d.ec.SetSafeHead(ref)
+	d.emitter.Emit(SafeDerivedEvent{Safe: ref, DerivedFrom: derivedFrom})
+}
+
 type ResetEngineControl interface {
 	SetUnsafeHead(eth.L2BlockRef)
 	SetSafeHead(eth.L2BlockRef)
@@ -3,8 +3,6 @@ package engine
 import (
 	"context"
 
-	"github.com/ethereum-optimism/optimism/op-node/rollup/async"
-	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -27,16 +25,14 @@ type Engine interface {
 type EngineControl interface {
 	EngineState
 
-	// StartPayload requests the engine to start building a block with the given attributes.
-	// If updateSafe, the resulting block will be marked as a safe block.
-	StartPayload(ctx context.Context, parent eth.L2BlockRef, attrs *derive.AttributesWithParent, updateSafe bool) (errType BlockInsertionErrType, err error)
-	// ConfirmPayload requests the engine to complete the current block. If no block is being built, or if it fails, an error is returned.
-	ConfirmPayload(ctx context.Context, agossip async.AsyncGossiper, sequencerConductor conductor.SequencerConductor) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error)
-	// CancelPayload requests the engine to stop building the current block without making it canonical.
-	// This is optional, as the engine expires building jobs that are left uncompleted, but can still save resources.
-	CancelPayload(ctx context.Context, force bool) error
-	// BuildingPayload indicates if a payload is being built, and onto which block it is being built, and whether or not it is a safe payload.
-	BuildingPayload() (onto eth.L2BlockRef, id eth.PayloadID, safe bool)
+	JobEngine
+
+	// StartBuildingJob requests the engine to start building a block with the given attributes, and returns the job
+	// of the block, to seal or cancel it. If updateSafe, the resulting block will be marked as a safe block.
+	StartBuildingJob(ctx context.Context, parent eth.L2BlockRef, attrs *derive.AttributesWithParent, updateSafe bool) (*BlockBuildingJob, BlockInsertionErrType, error)
+	// BuildingJob returns the job of the block that is being built, or nil if no block is being built.
+	// The job may be a sealing job that failed, until it is cancelled.
+	BuildingJob() *BlockBuildingJob
 }
 
 type LocalEngineState interface {
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// maxPrestateRepairDepth bounds the number of unsafe blocks that are walked back to find a common ancestor with the engine.
+// If no common ancestor is found within this depth, the unsafe head is rewound to the pending safe head.
+const maxPrestateRepairDepth = 1000
+
+// errInconsistentForkchoice is wrapped by the errors of forkchoice updates that the engine rejected as inconsistent
+// with its chain. These may be repaired without a derivation pipeline reset, see EngineController.RepairPrestate.
+var errInconsistentForkchoice = errors.New("forkchoice state inconsistent with engine")
+
+// RepairPrestateEvent requests a repair of the forkchoice state, after the engine rejected it as inconsistent.
+// A derivation pipeline reset is only requested if the repair is not possible.
+type RepairPrestateEvent struct {
+	Err error
+}
+
+func (ev RepairPrestateEvent) String() string {
+	return "repair-prestate"
+}
+
+// RepairPrestate repairs the forkchoice state after the engine rejected it as inconsistent with its chain,
+// e.g. when the engine lost or reorged part of the unsafe chain.
+// The unsafe head is rewound to the highest common ancestor of the unsafe chain and the canonical chain of the engine,
+// so the safe chain, and thus the derivation pipeline, is left untouched.
+// A reset error is returned if the engine disagrees with the safe chain, or if there is nothing to repair.
+func (e *EngineController) RepairPrestate(ctx context.Context) error {
+	heads := []struct {
+		name string
+		ref  eth.L2BlockRef
+	}{
+		{"finalized", e.finalizedHead},
+		{"safe", e.safeHead},
+		{"pending safe", e.pendingSafeHead},
+	}
+	for _, h := range heads {
+		ok, err := e.isCanonical(ctx, h.ref)
+		if err != nil {
+			return derive.NewTemporaryError(fmt.Errorf("failed to check %s block %s: %w", h.name, h.ref, err))
+		}
+		if !ok {
+			return derive.NewResetError(fmt.Errorf("%s block %s is not canonical in engine", h.name, h.ref))
+		}
+	}
+	ancestor, err := e.unsafeCommonAncestor(ctx)
+	if err != nil {
+		return derive.NewTemporaryError(fmt.Errorf("failed to find common ancestor of unsafe chain with engine: %w", err))
+	}
+	if ancestor == e.unsafeHead {
+		return derive.NewResetError(fmt.Errorf("engine rejected forkchoice state that is consistent with its chain, unsafe: %s", e.unsafeHead))
+	}
+	e.log.Warn("Repairing forkchoice state, rewinding unsafe head to common ancestor with engine",
+		"unsafe", e.unsafeHead, "ancestor", ancestor, "pending_safe", e.pendingSafeHead)
+	e.SetUnsafeHead(ancestor)
+	e.SetBackupUnsafeL2Head(eth.L2BlockRef{}, false)
+	return nil
+}
+
+// isCanonical returns whether the block is part of the canonical chain of the engine.
+// An unset block, e.g. the finalized block before anything is finalized, is considered canonical.
+func (e *EngineController) isCanonical(ctx context.Context, ref eth.L2BlockRef) (bool, error) {
+	if ref == (eth.L2BlockRef{}) {
+		return true, nil
+	}
+	canonical, err := e.engine.L2BlockRefByNumber(ctx, ref.Number)
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return canonical.Hash == ref.Hash, nil
+}
+
+// unsafeCommonAncestor walks back the unsafe chain, down to the pending safe head,
+// and returns the first block that is canonical in the engine.
+// The pending safe head is returned if the engine does not know the unsafe chain far enough back.
+func (e *EngineController) unsafeCommonAncestor(ctx context.Context) (eth.L2BlockRef, error) {
+	ref := e.unsafeHead
+	for i := 0; i < maxPrestateRepairDepth && ref.Number > e.pendingSafeHead.Number; i++ {
+		ok, err := e.isCanonical(ctx, ref)
+		if err != nil {
+			return eth.L2BlockRef{}, err
+		}
+		if ok {
+			return ref, nil
+		}
+		parent, err := e.engine.L2BlockRefByHash(ctx, ref.ParentHash)
+		if errors.Is(err, ethereum.NotFound) {
+			break
+		} else if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("failed to fetch parent of unsafe block %s: %w", ref, err)
+		}
+		ref = parent
+	}
+	return e.pendingSafeHead, nil
+}
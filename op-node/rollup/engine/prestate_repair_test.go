@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// chainEngine is an ExecEngine that only serves block refs: of its canonical chain by number,
+// and of all known blocks, canonical or not, by hash.
+type chainEngine struct {
+	ExecEngine
+	canonical map[uint64]eth.L2BlockRef
+	known     map[common.Hash]eth.L2BlockRef
+}
+
+func newChainEngine() *chainEngine {
+	return &chainEngine{canonical: make(map[uint64]eth.L2BlockRef), known: make(map[common.Hash]eth.L2BlockRef)}
+}
+
+func (c *chainEngine) addCanonical(refs ...eth.L2BlockRef) {
+	for _, ref := range refs {
+		c.canonical[ref.Number] = ref
+		c.known[ref.Hash] = ref
+	}
+}
+
+func (c *chainEngine) L2BlockRefByNumber(_ context.Context, num uint64) (eth.L2BlockRef, error) {
+	ref, ok := c.canonical[num]
+	if !ok {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+func (c *chainEngine) L2BlockRefByHash(_ context.Context, hash common.Hash) (eth.L2BlockRef, error) {
+	ref, ok := c.known[hash]
+	if !ok {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+func randomChain(rng *rand.Rand, parent eth.L2BlockRef, n int) []eth.L2BlockRef {
+	refs := make([]eth.L2BlockRef, n)
+	for i := range refs {
+		parent = testutils.NextRandomL2Ref(rng, 2, parent, parent.L1Origin)
+		refs[i] = parent
+	}
+	return refs
+}
+
+func TestRepairPrestate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	genesis := testutils.RandomL2BlockRef(rng)
+	genesis.Number = 0
+	chain := append([]eth.L2BlockRef{genesis}, randomChain(rng, genesis, 10)...)
+
+	setup := func(t *testing.T) (*EngineController, *chainEngine) {
+		eng := newChainEngine()
+		eng.addCanonical(chain...)
+		ec := NewEngineController(eng, testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics, &rollup.Config{}, &sync.Config{}, &testutils.MockEmitter{})
+		ec.SetFinalizedHead(chain[2])
+		ec.SetSafeHead(chain[4])
+		ec.SetPendingSafeL2Head(chain[5])
+		ec.SetUnsafeHead(chain[10])
+		return ec, eng
+	}
+
+	t.Run("engine reorged unsafe chain", func(t *testing.T) {
+		ec, eng := setup(t)
+		eng.addCanonical(randomChain(rng, chain[7], 5)...)
+		require.NoError(t, ec.RepairPrestate(context.Background()))
+		require.Equal(t, chain[7], ec.UnsafeL2Head())
+		require.Equal(t, chain[4], ec.SafeL2Head())
+		require.True(t, ec.needFCUCall)
+	})
+
+	t.Run("engine lost unsafe chain", func(t *testing.T) {
+		ec, eng := setup(t)
+		for _, ref := range chain[6:] {
+			delete(eng.canonical, ref.Number)
+			delete(eng.known, ref.Hash)
+		}
+		require.NoError(t, ec.RepairPrestate(context.Background()))
+		require.Equal(t, chain[5], ec.UnsafeL2Head())
+	})
+
+	t.Run("engine behind unsafe chain", func(t *testing.T) {
+		ec, eng := setup(t)
+		delete(eng.canonical, 9)
+		delete(eng.canonical, 10)
+		require.NoError(t, ec.RepairPrestate(context.Background()))
+		require.Equal(t, chain[8], ec.UnsafeL2Head())
+	})
+
+	t.Run("engine reorged safe chain", func(t *testing.T) {
+		ec, eng := setup(t)
+		eng.addCanonical(randomChain(rng, chain[3], 8)...)
+		err := ec.RepairPrestate(context.Background())
+		require.ErrorIs(t, err, derive.ErrReset)
+		require.Equal(t, chain[10], ec.UnsafeL2Head())
+	})
+
+	t.Run("nothing to repair", func(t *testing.T) {
+		ec, _ := setup(t)
+		err := ec.RepairPrestate(context.Background())
+		require.ErrorIs(t, err, derive.ErrReset)
+		require.Equal(t, chain[10], ec.UnsafeL2Head())
+	})
+}
+
+func TestRepairPrestateEvent(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	genesis := testutils.RandomL2BlockRef(rng)
+	genesis.Number = 0
+	chain := append([]eth.L2BlockRef{genesis}, randomChain(rng, genesis, 4)...)
+	eng := newChainEngine()
+	eng.addCanonical(chain[:3]...)
+	eng.known[chain[3].Hash] = chain[3]
+	emitter := &testutils.MockEmitter{}
+	ec := NewEngineController(eng, testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics, &rollup.Config{}, &sync.Config{}, emitter)
+	ec.SetSafeHead(chain[1])
+	ec.SetPendingSafeL2Head(chain[1])
+	ec.SetUnsafeHead(chain[4])
+	deriver := NewEngDeriver(testlog.Logger(t, log.LevelInfo), context.Background(), &rollup.Config{}, ec, emitter)
+
+	inconsistent := derive.NewResetError(errors.Join(errInconsistentForkchoice, errors.New("test")))
+	emitter.ExpectOnce(TryUpdateEngineEvent{})
+	deriver.OnEvent(RepairPrestateEvent{Err: inconsistent})
+	emitter.AssertExpectations(t)
+	require.Equal(t, chain[2], ec.UnsafeL2Head())
+
+	// once repaired, another inconsistency cannot be repaired, and needs a reset
+	emitter.ExpectOnceType("rollup.ResetEvent")
+	deriver.OnEvent(RepairPrestateEvent{Err: inconsistent})
+	emitter.AssertExpectations(t)
+}
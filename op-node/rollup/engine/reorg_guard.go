@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gosync "sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	// ErrUnsafeReorgHalted is returned while the node is halted on an unsafe reorg that was deeper than the maximum depth.
+	ErrUnsafeReorgHalted = errors.New("halted on deep unsafe reorg, awaiting admin confirmation")
+	// ErrNotHalted is returned when confirming an unsafe reorg while the node is not halted.
+	ErrNotHalted = errors.New("not halted on an unsafe reorg")
+)
+
+// ReorgHaltPersistence persists the unsafe reorg that halted the node,
+// so that a restart does not bypass the admin confirmation of the reorg.
+type ReorgHaltPersistence interface {
+	// PersistUnsafeReorgHalt persists the halt, or clears it if nil.
+	PersistUnsafeReorgHalt(halt *eth.UnsafeReorgHalt) error
+	UnsafeReorgHalt() (*eth.UnsafeReorgHalt, error)
+}
+
+// ReorgGuard halts the node when the unsafe chain is about to be reorged deeper than the maximum depth,
+// e.g. due to a bug or a malicious payload, until the reorg is confirmed by an admin.
+// While halted, no blocks are inserted or built. It is safe for concurrent use.
+type ReorgGuard struct {
+	// maxDepth is the maximum number of unsafe blocks that may be reorged automatically, 0 to disable the guard.
+	maxDepth uint64
+
+	mu   gosync.Mutex
+	halt *eth.UnsafeReorgHalt
+	// confirmed is the parent of the reorg that was confirmed by the admin, to allow it once regardless of its depth.
+	confirmed *eth.BlockID
+	// persistence persists the halt across restarts, if set.
+	persistence ReorgHaltPersistence
+}
+
+func NewReorgGuard(maxDepth uint64) *ReorgGuard {
+	return &ReorgGuard{maxDepth: maxDepth}
+}
+
+func (g *ReorgGuard) Enabled() bool {
+	return g.maxDepth > 0
+}
+
+// Restore restores the halt that was persisted before a restart, and persists the halts and confirmations from now on.
+// It returns the restored halt, or nil if the node was not halted.
+func (g *ReorgGuard) Restore(persistence ReorgHaltPersistence) (*eth.UnsafeReorgHalt, error) {
+	halt, err := persistence.UnsafeReorgHalt()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the persisted unsafe reorg halt: %w", err)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.persistence = persistence
+	if halt == nil {
+		return nil, nil
+	}
+	g.halt = halt
+	restored := *halt
+	return &restored, nil
+}
+
+// check returns an error if the node is halted,
+// or if building on top of the parent reorgs the unsafe chain by more than the maximum depth, which halts the node.
+// It returns the halt if the check halted the node, and nil if the node was already halted or is not halted.
+func (g *ReorgGuard) check(unsafe eth.L2BlockRef, parent eth.BlockID, depth uint64) (*eth.UnsafeReorgHalt, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.halt != nil {
+		return nil, fmt.Errorf("%w: reorg of %d blocks onto %s", ErrUnsafeReorgHalted, g.halt.Depth, g.halt.Parent)
+	}
+	if depth <= g.maxDepth || !g.Enabled() {
+		return nil, nil
+	}
+	if g.confirmed != nil && *g.confirmed == parent {
+		g.confirmed = nil
+		return nil, nil
+	}
+	g.halt = &eth.UnsafeReorgHalt{
+		UnsafeL2: unsafe,
+		Parent:   parent,
+		Depth:    hexutil.Uint64(depth),
+		MaxDepth: hexutil.Uint64(g.maxDepth),
+	}
+	err := fmt.Errorf("%w: reorg of %d blocks onto %s exceeds the maximum depth of %d blocks", ErrUnsafeReorgHalted, depth, parent, g.maxDepth)
+	if g.persistence != nil {
+		if perr := g.persistence.PersistUnsafeReorgHalt(g.halt); perr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to persist the halt, a restart resumes the node without confirmation: %w", perr))
+		}
+	}
+	halt := *g.halt
+	return &halt, err
+}
+
+// Halt returns the unsafe reorg that halted the node, or nil if the node is not halted.
+func (g *ReorgGuard) Halt() *eth.UnsafeReorgHalt {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.halt == nil {
+		return nil
+	}
+	halt := *g.halt
+	return &halt
+}
+
+// Confirm allows the unsafe reorg that halted the node, and resumes the node.
+func (g *ReorgGuard) Confirm() (eth.UnsafeReorgHalt, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.halt == nil {
+		return eth.UnsafeReorgHalt{}, ErrNotHalted
+	}
+	if g.persistence != nil {
+		if err := g.persistence.PersistUnsafeReorgHalt(nil); err != nil {
+			return eth.UnsafeReorgHalt{}, fmt.Errorf("failed to clear the persisted halt: %w", err)
+		}
+	}
+	halt := *g.halt
+	g.confirmed = &halt.Parent
+	g.halt = nil
+	return halt, nil
+}
+
+// checkUnsafeReorg checks with the reorg guard that building on top of the given parent
+// does not reorg the unsafe chain deeper than allowed.
+func (e *EngineController) checkUnsafeReorg(ctx context.Context, parent eth.BlockID) error {
+	if !e.reorgGuard.Enabled() || e.IsEngineSyncing() {
+		return nil
+	}
+	depth, err := e.unsafeReorgDepth(ctx, parent)
+	if err != nil {
+		return fmt.Errorf("failed to determine unsafe reorg depth: %w", err)
+	}
+	halt, err := e.reorgGuard.check(e.unsafeHead, parent, depth)
+	if halt != nil {
+		// Only log the halt once, the halted node retries the insertion and building of blocks until it is confirmed.
+		e.log.Error("Refusing unsafe reorg, confirm with admin_confirmUnsafeReorg to resume",
+			"unsafe", e.unsafeHead, "parent", parent, "depth", depth, "err", err)
+	} else if err != nil {
+		e.log.Debug("Halted on deep unsafe reorg", "parent", parent, "err", err)
+	}
+	return err
+}
+
+// unsafeReorgDepth returns the number of unsafe blocks that are removed from the canonical chain by building on top of the parent.
+// If the parent is not canonical, the common ancestor is unknown, and all unsafe blocks above the safe head are counted.
+func (e *EngineController) unsafeReorgDepth(ctx context.Context, parent eth.BlockID) (uint64, error) {
+	if parent.Hash == e.unsafeHead.Hash || parent.Number > e.unsafeHead.Number {
+		return 0, nil
+	}
+	if parent.Number < e.unsafeHead.Number {
+		canonical, err := e.engine.L2BlockRefByNumber(ctx, parent.Number)
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return 0, err
+		}
+		if err == nil && canonical.Hash == parent.Hash {
+			return e.unsafeHead.Number - parent.Number, nil
+		}
+	}
+	if e.unsafeHead.Number <= e.safeHead.Number {
+		return 0, nil
+	}
+	return e.unsafeHead.Number - e.safeHead.Number, nil
+}
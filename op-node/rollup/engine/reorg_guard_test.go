@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestReorgGuard(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	genesis := testutils.RandomL2BlockRef(rng)
+	genesis.Number = 0
+	chain := append([]eth.L2BlockRef{genesis}, randomChain(rng, genesis, 10)...)
+
+	setup := func(t *testing.T, maxDepth uint64) *EngineController {
+		eng := newChainEngine()
+		eng.addCanonical(chain...)
+		ec := NewEngineController(eng, testlog.Logger(t, log.LevelError), metrics.NoopMetrics, &rollup.Config{},
+			&sync.Config{MaxUnsafeReorgDepth: maxDepth}, &testutils.MockEmitter{})
+		ec.SetSafeHead(chain[5])
+		ec.SetUnsafeHead(chain[10])
+		return ec
+	}
+	ctx := context.Background()
+
+	t.Run("disabled", func(t *testing.T) {
+		ec := setup(t, 0)
+		require.NoError(t, ec.checkUnsafeReorg(ctx, chain[5].ID()))
+		require.Nil(t, ec.ReorgGuard().Halt())
+	})
+
+	t.Run("shallow reorg", func(t *testing.T) {
+		ec := setup(t, 2)
+		require.NoError(t, ec.checkUnsafeReorg(ctx, chain[10].ID()))
+		require.NoError(t, ec.checkUnsafeReorg(ctx, chain[8].ID()))
+		require.Nil(t, ec.ReorgGuard().Halt())
+	})
+
+	t.Run("deep reorg halts until confirmed", func(t *testing.T) {
+		ec := setup(t, 2)
+		require.ErrorIs(t, ec.checkUnsafeReorg(ctx, chain[7].ID()), ErrUnsafeReorgHalted)
+		require.Equal(t, &eth.UnsafeReorgHalt{UnsafeL2: chain[10], Parent: chain[7].ID(), Depth: 3, MaxDepth: 2}, ec.ReorgGuard().Halt())
+
+		// no blocks are inserted or built while halted
+		require.ErrorIs(t, ec.checkUnsafeReorg(ctx, chain[10].ID()), ErrUnsafeReorgHalted)
+		job, errTyp, err := ec.StartBuildingJob(ctx, chain[10], &derive.AttributesWithParent{Attributes: &eth.PayloadAttributes{}}, false)
+		require.ErrorIs(t, err, ErrUnsafeReorgHalted)
+		require.Equal(t, BlockInsertTemporaryErr, errTyp)
+		require.Nil(t, job)
+
+		halt, err := ec.ReorgGuard().Confirm()
+		require.NoError(t, err)
+		require.Equal(t, chain[7].ID(), halt.Parent)
+		require.Nil(t, ec.ReorgGuard().Halt())
+		_, err = ec.ReorgGuard().Confirm()
+		require.ErrorIs(t, err, ErrNotHalted)
+
+		// the confirmed reorg is allowed once
+		require.NoError(t, ec.checkUnsafeReorg(ctx, chain[7].ID()))
+		require.ErrorIs(t, ec.checkUnsafeReorg(ctx, chain[7].ID()), ErrUnsafeReorgHalted)
+	})
+
+	t.Run("halt is logged once", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelDebug)
+		ec := setup(t, 2)
+		ec.log = logger
+		for i := 0; i < 3; i++ {
+			require.ErrorIs(t, ec.checkUnsafeReorg(ctx, chain[7].ID()), ErrUnsafeReorgHalted)
+		}
+		require.Len(t, logs.FindLogs(testlog.NewLevelFilter(log.LevelError)), 1)
+	})
+
+	t.Run("halt persists across restarts", func(t *testing.T) {
+		persistence := &memReorgHaltPersistence{}
+		ec := setup(t, 2)
+		restored, err := ec.ReorgGuard().Restore(persistence)
+		require.NoError(t, err)
+		require.Nil(t, restored)
+		require.ErrorIs(t, ec.checkUnsafeReorg(ctx, chain[7].ID()), ErrUnsafeReorgHalted)
+		require.Equal(t, ec.ReorgGuard().Halt(), persistence.halt)
+
+		// the restarted node is still halted
+		ec = setup(t, 2)
+		restored, err = ec.ReorgGuard().Restore(persistence)
+		require.NoError(t, err)
+		require.Equal(t, persistence.halt, restored)
+		require.ErrorIs(t, ec.checkUnsafeReorg(ctx, chain[10].ID()), ErrUnsafeReorgHalted)
+
+		_, err = ec.ReorgGuard().Confirm()
+		require.NoError(t, err)
+		require.Nil(t, persistence.halt)
+		require.NoError(t, ec.checkUnsafeReorg(ctx, chain[7].ID()))
+	})
+
+	t.Run("confirmation fails if the halt is not cleared", func(t *testing.T) {
+		persistence := &memReorgHaltPersistence{}
+		ec := setup(t, 2)
+		_, err := ec.ReorgGuard().Restore(persistence)
+		require.NoError(t, err)
+		require.ErrorIs(t, ec.checkUnsafeReorg(ctx, chain[7].ID()), ErrUnsafeReorgHalted)
+
+		persistence.err = errors.New("disk full")
+		_, err = ec.ReorgGuard().Confirm()
+		require.ErrorIs(t, err, persistence.err)
+		require.NotNil(t, ec.ReorgGuard().Halt())
+	})
+
+	t.Run("reorg onto unknown fork", func(t *testing.T) {
+		ec := setup(t, 4)
+		fork := randomChain(rng, chain[8], 1)[0]
+		// the common ancestor of an unknown fork is not known, so all unsafe blocks count towards the depth
+		require.ErrorIs(t, ec.checkUnsafeReorg(ctx, fork.ID()), ErrUnsafeReorgHalted)
+		require.Equal(t, eth.UnsafeReorgHalt{UnsafeL2: chain[10], Parent: fork.ID(), Depth: 5, MaxDepth: 4}, *ec.ReorgGuard().Halt())
+	})
+}
+
+type memReorgHaltPersistence struct {
+	halt *eth.UnsafeReorgHalt
+	err  error
+}
+
+func (m *memReorgHaltPersistence) PersistUnsafeReorgHalt(halt *eth.UnsafeReorgHalt) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.halt = halt
+	return nil
+}
+
+func (m *memReorgHaltPersistence) UnsafeReorgHalt() (*eth.UnsafeReorgHalt, error) {
+	return m.halt, m.err
+}
@@ -3,6 +3,7 @@ package finality
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -91,11 +92,14 @@ type Finalizer struct {
 	// Maximum amount of L2 blocks to store in finalityData.
 	finalityLookback uint64
 
+	// rule selects the L2 blocks to finalize, given the finalized L1 block.
+	rule Rule
+
 	l1Fetcher FinalizerL1Interface
 }
 
-func NewFinalizer(ctx context.Context, log log.Logger, cfg *rollup.Config, l1Fetcher FinalizerL1Interface, emitter event.Emitter) *Finalizer {
-	lookback := calcFinalityLookback(cfg)
+func NewFinalizer(ctx context.Context, log log.Logger, cfg *rollup.Config, l1Fetcher FinalizerL1Interface, emitter event.Emitter, rule Rule) *Finalizer {
+	lookback := calcFinalityLookback(cfg) + rule.ExtraLookback()
 	return &Finalizer{
 		ctx:              ctx,
 		log:              log,
@@ -103,6 +107,7 @@ func NewFinalizer(ctx context.Context, log log.Logger, cfg *rollup.Config, l1Fet
 		triedFinalizeAt:  0,
 		finalityData:     make([]FinalityData, 0, lookback),
 		finalityLookback: lookback,
+		rule:             rule,
 		l1Fetcher:        l1Fetcher,
 		emitter:          emitter,
 	}
@@ -195,34 +200,38 @@ func (fi *Finalizer) onDerivationIdle(derivedFrom eth.L1BlockRef) {
 }
 
 func (fi *Finalizer) tryFinalize() {
+	// The finality rule and the sanity checks fetch data, which must not block the readers of the finality state.
+	// The finality state is only modified by the events that are processed in order with this one,
+	// so it is copied and the lock is released during the fetches.
 	fi.mu.Lock()
-	defer fi.mu.Unlock()
+	finalizedL1 := fi.finalizedL1
+	candidates := slices.Clone(fi.finalityData)
+	fi.mu.Unlock()
 
-	// overwritten if we finalize
-	finalizedL2 := fi.lastFinalizedL2 // may be zeroed if nothing was finalized since startup.
-	var finalizedDerivedFrom eth.BlockID
-	// go through the latest inclusion data, and find the last L2 block that was derived from a finalized L1 block
-	for _, fd := range fi.finalityData {
-		if fd.L2Block.Number > finalizedL2.Number && fd.L1Block.Number <= fi.finalizedL1.Number {
-			finalizedL2 = fd.L2Block
-			finalizedDerivedFrom = fd.L1Block
-			// keep iterating, there may be later L2 blocks that can also be finalized
-		}
+	ctx, cancel := context.WithTimeout(fi.ctx, time.Second*10)
+	defer cancel()
+
+	// go through the latest inclusion data, and find the last L2 block that the finality rule allows to finalize
+	selected, ok, err := fi.rule.Select(ctx, finalizedL1, candidates)
+	if err != nil {
+		fi.log.Warn("Failed to select L2 blocks to finalize", "l1_finalized", finalizedL1, "err", err)
+		return
 	}
-	if finalizedDerivedFrom != (eth.BlockID{}) {
-		ctx, cancel := context.WithTimeout(fi.ctx, time.Second*10)
-		defer cancel()
+	// lastFinalizedL2 may be zeroed if nothing was finalized since startup.
+	if ok && selected.L2Block.Number > fi.lastFinalizedL2.Number {
+		finalizedL2 := selected.L2Block
+		finalizedDerivedFrom := selected.L1Block
 		// Sanity check the finality signal of L1.
 		// Even though the signal is trusted and we do the below check also,
 		// the signal itself has to be canonical to proceed.
 		// TODO(#10724): This check could be removed if the finality signal is fully trusted, and if tests were more flexible for this case.
-		signalRef, err := fi.l1Fetcher.L1BlockRefByNumber(ctx, fi.finalizedL1.Number)
+		signalRef, err := fi.l1Fetcher.L1BlockRefByNumber(ctx, finalizedL1.Number)
 		if err != nil {
-			fi.emitter.Emit(rollup.L1TemporaryErrorEvent{Err: fmt.Errorf("failed to check if on finalizing L1 chain, could not fetch block %d: %w", fi.finalizedL1.Number, err)})
+			fi.emitter.Emit(rollup.L1TemporaryErrorEvent{Err: fmt.Errorf("failed to check if on finalizing L1 chain, could not fetch block %d: %w", finalizedL1.Number, err)})
 			return
 		}
-		if signalRef.Hash != fi.finalizedL1.Hash {
-			fi.emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("need to reset, we assumed %s is finalized, but canonical chain is %s", fi.finalizedL1, signalRef)})
+		if signalRef.Hash != finalizedL1.Hash {
+			fi.emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("need to reset, we assumed %s is finalized, but canonical chain is %s", finalizedL1, signalRef)})
 			return
 		}
 
@@ -235,7 +244,7 @@ func (fi *Finalizer) tryFinalize() {
 		}
 		if derivedRef.Hash != finalizedDerivedFrom.Hash {
 			fi.emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("need to reset, we are on %s, not on the finalizing L1 chain %s (towards %s)",
-				finalizedDerivedFrom, derivedRef, fi.finalizedL1)})
+				finalizedDerivedFrom, derivedRef, finalizedL1)})
 			return
 		}
 		fi.emitter.Emit(engine.PromoteFinalizedEvent{Ref: finalizedL2})
@@ -29,9 +29,9 @@ type PlasmaFinalizer struct {
 
 func NewPlasmaFinalizer(ctx context.Context, log log.Logger, cfg *rollup.Config,
 	l1Fetcher FinalizerL1Interface, emitter event.Emitter,
-	backend PlasmaBackend) *PlasmaFinalizer {
+	backend PlasmaBackend, rule Rule) *PlasmaFinalizer {
 
-	inner := NewFinalizer(ctx, log, cfg, l1Fetcher, emitter)
+	inner := NewFinalizer(ctx, log, cfg, l1Fetcher, emitter, rule)
 
 	// In alt-da mode, the finalization signal is proxied through the plasma manager.
 	// Finality signal will come from the DA contract or L1 finality whichever is last.
@@ -0,0 +1,107 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Rule maps L1 finality to L2 finality: it selects which of the recently derived L2 blocks may be finalized.
+// Chains can customize finality with a different rule, e.g. to wait for extra confirmations,
+// or to follow an external finality feed.
+type Rule interface {
+	// Select returns the latest of the candidates that may be finalized given the finalized L1 block,
+	// or false if none of them may be finalized yet.
+	// The candidates are sorted by L2 block number, and by the L1 block number they were derived from.
+	Select(ctx context.Context, finalizedL1 eth.L1BlockRef, candidates []FinalityData) (FinalityData, bool, error)
+	// ExtraLookback is the number of L1 blocks to keep finality data of, in addition to the default lookback.
+	ExtraLookback() uint64
+}
+
+// selectLast returns the last of the candidates that matches the condition.
+func selectLast(candidates []FinalityData, cond func(fd FinalityData) bool) (FinalityData, bool) {
+	for i := len(candidates) - 1; i >= 0; i-- {
+		if cond(candidates[i]) {
+			return candidates[i], true
+		}
+	}
+	return FinalityData{}, false
+}
+
+// L1Finality is the default rule: a L2 block is finalized when the L1 block it was fully derived from is finalized.
+type L1Finality struct{}
+
+var _ Rule = L1Finality{}
+
+func (L1Finality) Select(_ context.Context, finalizedL1 eth.L1BlockRef, candidates []FinalityData) (FinalityData, bool, error) {
+	fd, ok := selectLast(candidates, func(fd FinalityData) bool {
+		return fd.L1Block.Number <= finalizedL1.Number
+	})
+	return fd, ok, nil
+}
+
+func (L1Finality) ExtraLookback() uint64 {
+	return 0
+}
+
+// ConfirmationDelay finalizes a L2 block when the L1 block it was fully derived from
+// is at least Delay blocks behind the finalized L1 block.
+type ConfirmationDelay struct {
+	Delay uint64
+}
+
+var _ Rule = (*ConfirmationDelay)(nil)
+
+func (r *ConfirmationDelay) Select(_ context.Context, finalizedL1 eth.L1BlockRef, candidates []FinalityData) (FinalityData, bool, error) {
+	if finalizedL1.Number < r.Delay {
+		return FinalityData{}, false, nil
+	}
+	fd, ok := selectLast(candidates, func(fd FinalityData) bool {
+		return fd.L1Block.Number <= finalizedL1.Number-r.Delay
+	})
+	return fd, ok, nil
+}
+
+func (r *ConfirmationDelay) ExtraLookback() uint64 {
+	return r.Delay
+}
+
+// ExternalFinalitySource is an external feed of L2 finality, e.g. a finality gadget.
+type ExternalFinalitySource interface {
+	// FinalizedL2 returns the latest L2 block the source considers finalized.
+	FinalizedL2(ctx context.Context) (eth.BlockID, error)
+}
+
+// ExternalFinality finalizes the L2 blocks that are both selected by the wrapped rule,
+// and finalized by the external source.
+type ExternalFinality struct {
+	Rule   Rule
+	Source ExternalFinalitySource
+}
+
+var _ Rule = (*ExternalFinality)(nil)
+
+func (r *ExternalFinality) Select(ctx context.Context, finalizedL1 eth.L1BlockRef, candidates []FinalityData) (FinalityData, bool, error) {
+	selected, ok, err := r.Rule.Select(ctx, finalizedL1, candidates)
+	if err != nil || !ok {
+		return FinalityData{}, false, err
+	}
+	external, err := r.Source.FinalizedL2(ctx)
+	if err != nil {
+		return FinalityData{}, false, fmt.Errorf("failed to fetch finalized L2 block from external source: %w", err)
+	}
+	for _, fd := range candidates {
+		if fd.L2Block.Number == external.Number && fd.L2Block.Hash != external.Hash {
+			return FinalityData{}, false, fmt.Errorf("external source finalized %s, but derived %s", external, fd.L2Block)
+		}
+	}
+	fd, ok := selectLast(candidates, func(fd FinalityData) bool {
+		return fd.L2Block.Number <= selected.L2Block.Number && fd.L2Block.Number <= external.Number
+	})
+	return fd, ok, nil
+}
+
+func (r *ExternalFinality) ExtraLookback() uint64 {
+	return r.Rule.ExtraLookback()
+}
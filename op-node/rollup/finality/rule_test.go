@@ -0,0 +1,81 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type testFinalitySource struct {
+	finalized eth.BlockID
+	err       error
+}
+
+func (s *testFinalitySource) FinalizedL2(ctx context.Context) (eth.BlockID, error) {
+	return s.finalized, s.err
+}
+
+func TestRules(t *testing.T) {
+	candidate := func(l2 uint64, l1 uint64) FinalityData {
+		return FinalityData{
+			L2Block: eth.L2BlockRef{Hash: common.Hash{byte(l2)}, Number: l2},
+			L1Block: eth.BlockID{Hash: common.Hash{0xaa, byte(l1)}, Number: l1},
+		}
+	}
+	candidates := []FinalityData{candidate(10, 100), candidate(12, 101), candidate(14, 102), candidate(16, 104)}
+	finalizedL1 := eth.L1BlockRef{Number: 103}
+	ctx := context.Background()
+
+	t.Run("L1Finality", func(t *testing.T) {
+		fd, ok, err := L1Finality{}.Select(ctx, finalizedL1, candidates)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, candidate(14, 102), fd)
+
+		_, ok, err = L1Finality{}.Select(ctx, eth.L1BlockRef{Number: 99}, candidates)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("ConfirmationDelay", func(t *testing.T) {
+		rule := &ConfirmationDelay{Delay: 2}
+		require.Equal(t, uint64(2), rule.ExtraLookback())
+		fd, ok, err := rule.Select(ctx, finalizedL1, candidates)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, candidate(12, 101), fd)
+
+		_, ok, err = rule.Select(ctx, eth.L1BlockRef{Number: 1}, candidates)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("ExternalFinality", func(t *testing.T) {
+		src := &testFinalitySource{finalized: eth.BlockID{Hash: common.Hash{13}, Number: 13}}
+		rule := &ExternalFinality{Rule: L1Finality{}, Source: src}
+		fd, ok, err := rule.Select(ctx, finalizedL1, candidates)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, candidate(12, 101), fd, "limited by the external source")
+
+		src.finalized = eth.BlockID{Hash: common.Hash{20}, Number: 20}
+		fd, ok, err = rule.Select(ctx, finalizedL1, candidates)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, candidate(14, 102), fd, "limited by L1 finality")
+
+		src.finalized = eth.BlockID{Hash: common.Hash{0xff}, Number: 12}
+		_, _, err = rule.Select(ctx, finalizedL1, candidates)
+		require.ErrorContains(t, err, "external source finalized")
+
+		src.err = errors.New("unavailable")
+		_, _, err = rule.Select(ctx, finalizedL1, candidates)
+		require.ErrorIs(t, err, src.err)
+	})
+}
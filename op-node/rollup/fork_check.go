@@ -0,0 +1,58 @@
+package rollup
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ForkMismatch is a fork that is scheduled differently by the rollup config and the execution engine.
+type ForkMismatch struct {
+	Fork ForkName
+	// EngineField is the chain config field of the execution engine that schedules the fork.
+	EngineField string
+	// Rollup is the activation time in the rollup config, nil if not scheduled.
+	Rollup *uint64
+	// Engine is the activation time in the execution engine, nil if not scheduled.
+	Engine *uint64
+}
+
+func fmtForkTime(t *uint64) string {
+	if t == nil {
+		return "not scheduled"
+	}
+	return "at " + strconv.FormatUint(*t, 10)
+}
+
+func (m ForkMismatch) String() string {
+	return fmt.Sprintf("%s: rollup config %s, execution engine %s %s",
+		m.Fork, fmtForkTime(m.Rollup), m.EngineField, fmtForkTime(m.Engine))
+}
+
+// CheckEngineForks compares the fork schedule of the rollup config with the chain config of the execution engine,
+// and returns the forks that are scheduled differently. Forks without execution changes, like Delta, are not compared.
+// The L1 forks that the execution engine activates with an OP Stack fork must be scheduled at the same time too.
+func (cfg *Config) CheckEngineForks(engineCfg *params.ChainConfig) []ForkMismatch {
+	forks := []struct {
+		fork        ForkName
+		engineField string
+		rollup      *uint64
+		engine      *uint64
+	}{
+		{Regolith, "regolithTime", cfg.RegolithTime, engineCfg.RegolithTime},
+		{Canyon, "canyonTime", cfg.CanyonTime, engineCfg.CanyonTime},
+		{Canyon, "shanghaiTime", cfg.CanyonTime, engineCfg.ShanghaiTime},
+		{Ecotone, "ecotoneTime", cfg.EcotoneTime, engineCfg.EcotoneTime},
+		{Ecotone, "cancunTime", cfg.EcotoneTime, engineCfg.CancunTime},
+		{Fjord, "fjordTime", cfg.FjordTime, engineCfg.FjordTime},
+		{Interop, "interopTime", cfg.InteropTime, engineCfg.InteropTime},
+	}
+	var mismatches []ForkMismatch
+	for _, f := range forks {
+		if (f.rollup == nil) != (f.engine == nil) || (f.rollup != nil && *f.rollup != *f.engine) {
+			mismatches = append(mismatches, ForkMismatch{Fork: f.fork, EngineField: f.engineField, Rollup: f.rollup, Engine: f.engine})
+		}
+	}
+	return mismatches
+}
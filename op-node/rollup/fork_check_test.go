@@ -0,0 +1,48 @@
+package rollup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestCheckEngineForks(t *testing.T) {
+	cfg := &Config{
+		RegolithTime: u64ptr(0),
+		CanyonTime:   u64ptr(10),
+		DeltaTime:    u64ptr(20),
+		EcotoneTime:  u64ptr(30),
+	}
+	engineCfg := &params.ChainConfig{
+		RegolithTime: u64ptr(0),
+		CanyonTime:   u64ptr(10),
+		ShanghaiTime: u64ptr(10),
+		EcotoneTime:  u64ptr(30),
+		CancunTime:   u64ptr(30),
+	}
+	require.Empty(t, cfg.CheckEngineForks(engineCfg))
+
+	t.Run("DifferentTime", func(t *testing.T) {
+		engineCfg := *engineCfg
+		engineCfg.CancunTime = u64ptr(31)
+		mismatches := cfg.CheckEngineForks(&engineCfg)
+		require.Equal(t, []ForkMismatch{{Fork: Ecotone, EngineField: "cancunTime", Rollup: u64ptr(30), Engine: u64ptr(31)}}, mismatches)
+		require.Equal(t, "ecotone: rollup config at 30, execution engine cancunTime at 31", mismatches[0].String())
+	})
+
+	t.Run("NotScheduledByEngine", func(t *testing.T) {
+		cfg := *cfg
+		cfg.FjordTime = u64ptr(40)
+		mismatches := cfg.CheckEngineForks(engineCfg)
+		require.Equal(t, []ForkMismatch{{Fork: Fjord, EngineField: "fjordTime", Rollup: u64ptr(40)}}, mismatches)
+		require.Equal(t, "fjord: rollup config at 40, execution engine fjordTime not scheduled", mismatches[0].String())
+	})
+
+	t.Run("NotScheduledByRollup", func(t *testing.T) {
+		engineCfg := *engineCfg
+		engineCfg.InteropTime = u64ptr(50)
+		require.Equal(t, []ForkMismatch{{Fork: Interop, EngineField: "interopTime", Engine: u64ptr(50)}}, cfg.CheckEngineForks(&engineCfg))
+	})
+}
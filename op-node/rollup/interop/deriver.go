@@ -0,0 +1,129 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// checkTimeout is the timeout of a single attempt to verify the messages of a range of blocks.
+const checkTimeout = time.Second * 10
+
+// SafetyGate verifies the cross-chain messages of L2 blocks, before the blocks are promoted to safe.
+type SafetyGate interface {
+	// CheckSafe returns an error if any block after the safe head, up to and including ref, cannot become safe yet.
+	CheckSafe(ctx context.Context, safe eth.L2BlockRef, ref eth.L2BlockRef) error
+}
+
+// CrossSafeDeriver verifies the cross-chain messages of the blocks that the engine requests to promote to safe.
+// The checks run outside of the event loop, and the results are emitted as events once a check completes:
+// the emitter must be safe for concurrent use.
+// Checks that fail are retried with a backoff, e.g. while the supervisor is unavailable,
+// or while the initiating messages are not cross-safe yet.
+// If the supervisor finds messages of a block to be invalid, the node halts with a critical error:
+// deriving the block again from the same L1 data would produce the same invalid block.
+type CrossSafeDeriver struct {
+	ctx     context.Context
+	log     log.Logger
+	gate    SafetyGate
+	emitter event.Emitter
+
+	backoff retry.Strategy
+
+	mu sync.Mutex
+	// next is the latest check request that has not been started yet, if any
+	next *engine.CrossSafeCheckRequestEvent
+	// running is true while a goroutine processes the check requests
+	running bool
+	// resets counts the resets, to drop the results of checks that were requested before a reset
+	resets uint64
+}
+
+var _ event.Deriver = (*CrossSafeDeriver)(nil)
+
+func NewCrossSafeDeriver(ctx context.Context, log log.Logger, gate SafetyGate, emitter event.Emitter) *CrossSafeDeriver {
+	return &CrossSafeDeriver{
+		ctx:     ctx,
+		log:     log,
+		gate:    gate,
+		emitter: emitter,
+		backoff: retry.Exponential(),
+	}
+}
+
+func (d *CrossSafeDeriver) OnEvent(ev event.Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch x := ev.(type) {
+	case engine.CrossSafeCheckRequestEvent:
+		// A later request covers the blocks of any earlier request that has not been started yet.
+		d.next = &x
+		if !d.running {
+			d.running = true
+			go d.run()
+		}
+	case rollup.ResetEvent, engine.EngineResetConfirmedEvent:
+		d.next = nil
+		d.resets++
+	}
+}
+
+// run processes the check requests until there are none left.
+func (d *CrossSafeDeriver) run() {
+	attempts := 0
+	for {
+		d.mu.Lock()
+		req, resets := d.next, d.resets
+		d.next = nil
+		if req == nil || d.ctx.Err() != nil {
+			d.running = false
+			d.mu.Unlock()
+			return
+		}
+		d.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(d.ctx, checkTimeout)
+		err := d.gate.CheckSafe(ctx, req.Safe, req.Ref)
+		cancel()
+
+		d.mu.Lock()
+		stale := d.resets != resets
+		if err != nil && !stale && !errors.Is(err, ErrInvalidMessages) && d.next == nil {
+			// retry the request, unless a later request replaced it
+			d.next = req
+		}
+		d.mu.Unlock()
+
+		switch {
+		case stale:
+			d.log.Debug("Dropping result of cross-safe check from before reset", "ref", req.Ref, "err", err)
+			attempts = 0
+		case err == nil:
+			attempts = 0
+			d.emitter.Emit(engine.CrossSafeVerifiedEvent{Ref: req.Ref, DerivedFrom: req.DerivedFrom})
+		case errors.Is(err, ErrInvalidMessages):
+			attempts = 0
+			d.log.Error("Cross-chain messages are invalid, halting", "safe", req.Safe, "ref", req.Ref, "err", err)
+			d.emitter.Emit(rollup.CriticalErrorEvent{Err: fmt.Errorf("cannot promote %s to safe: %w", req.Ref, err)})
+		default:
+			attempts++
+			delay := d.backoff.Duration(attempts)
+			d.log.Warn("Failed to verify cross-chain messages, retrying", "ref", req.Ref, "attempts", attempts, "delay", delay, "err", err)
+			select {
+			case <-time.After(delay):
+			case <-d.ctx.Done():
+			}
+		}
+	}
+}
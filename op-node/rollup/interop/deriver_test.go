@@ -0,0 +1,131 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// scriptedGate returns the scripted errors of consecutive checks, and no error once the script is exhausted.
+type scriptedGate struct {
+	mu      sync.Mutex
+	errs    []error
+	checked []eth.L2BlockRef
+	// block, if not nil, is waited on before each check completes
+	block chan struct{}
+}
+
+func (g *scriptedGate) CheckSafe(ctx context.Context, safe eth.L2BlockRef, ref eth.L2BlockRef) error {
+	if g.block != nil {
+		<-g.block
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.checked = append(g.checked, ref)
+	if len(g.errs) == 0 {
+		return nil
+	}
+	err := g.errs[0]
+	g.errs = g.errs[1:]
+	return err
+}
+
+func TestCrossSafeDeriver(t *testing.T) {
+	safe := eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 1}
+	ref := eth.L2BlockRef{Hash: common.Hash{0x02}, Number: 2}
+	derivedFrom := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 10}
+	req := engine.CrossSafeCheckRequestEvent{Safe: safe, Ref: ref, DerivedFrom: derivedFrom}
+
+	setup := func(t *testing.T, gate *scriptedGate) (*CrossSafeDeriver, chan event.Event) {
+		events := make(chan event.Event, 10)
+		d := NewCrossSafeDeriver(context.Background(), testlog.Logger(t, log.LevelDebug), gate, event.EmitterFunc(func(ev event.Event) {
+			events <- ev
+		}))
+		d.backoff = retry.Fixed(time.Millisecond)
+		return d, events
+	}
+	next := func(t *testing.T, events chan event.Event) event.Event {
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for event")
+			return nil
+		}
+	}
+
+	t.Run("Verified", func(t *testing.T) {
+		d, events := setup(t, &scriptedGate{})
+		d.OnEvent(req)
+		require.Equal(t, engine.CrossSafeVerifiedEvent{Ref: ref, DerivedFrom: derivedFrom}, next(t, events))
+	})
+	t.Run("RetryUnavailable", func(t *testing.T) {
+		gate := &scriptedGate{errs: []error{ErrSupervisorUnavailable, fmt.Errorf("block: %w", ErrSupervisorUnavailable)}}
+		d, events := setup(t, gate)
+		d.OnEvent(req)
+		require.Equal(t, engine.CrossSafeVerifiedEvent{Ref: ref, DerivedFrom: derivedFrom}, next(t, events))
+		require.Len(t, gate.checked, 3)
+	})
+	t.Run("RetryNotSafeYet", func(t *testing.T) {
+		gate := &scriptedGate{errs: []error{fmt.Errorf("block: %w", ErrMessagesNotSafe)}}
+		d, events := setup(t, gate)
+		d.OnEvent(req)
+		require.Equal(t, engine.CrossSafeVerifiedEvent{Ref: ref, DerivedFrom: derivedFrom}, next(t, events))
+		require.Len(t, gate.checked, 2)
+	})
+	t.Run("InvalidMessages", func(t *testing.T) {
+		gate := &scriptedGate{errs: []error{fmt.Errorf("block: %w", ErrInvalidMessages)}}
+		d, events := setup(t, gate)
+		d.OnEvent(req)
+		ev := next(t, events)
+		require.IsType(t, rollup.CriticalErrorEvent{}, ev)
+		require.ErrorIs(t, ev.(rollup.CriticalErrorEvent).Err, ErrInvalidMessages)
+		require.Len(t, gate.checked, 1, "invalid messages are not retried")
+	})
+	t.Run("LaterRequestReplacesPending", func(t *testing.T) {
+		gate := &scriptedGate{block: make(chan struct{})}
+		d, events := setup(t, gate)
+		d.OnEvent(req)
+		// wait for the check of the first request to start
+		require.Eventually(t, func() bool {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			return d.next == nil
+		}, 10*time.Second, time.Millisecond)
+		later := eth.L2BlockRef{Hash: common.Hash{0x03}, Number: 3}
+		replaced := eth.L2BlockRef{Hash: common.Hash{0x04}, Number: 4}
+		d.OnEvent(engine.CrossSafeCheckRequestEvent{Safe: safe, Ref: replaced, DerivedFrom: derivedFrom})
+		d.OnEvent(engine.CrossSafeCheckRequestEvent{Safe: safe, Ref: later, DerivedFrom: derivedFrom})
+		close(gate.block)
+		require.Equal(t, engine.CrossSafeVerifiedEvent{Ref: ref, DerivedFrom: derivedFrom}, next(t, events))
+		require.Equal(t, engine.CrossSafeVerifiedEvent{Ref: later, DerivedFrom: derivedFrom}, next(t, events))
+		require.Equal(t, []eth.L2BlockRef{ref, later}, gate.checked)
+	})
+	t.Run("DropAfterReset", func(t *testing.T) {
+		gate := &scriptedGate{block: make(chan struct{})}
+		d, events := setup(t, gate)
+		d.OnEvent(req)
+		d.OnEvent(rollup.ResetEvent{Err: errors.New("reset")})
+		close(gate.block)
+		require.Eventually(t, func() bool {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			return !d.running
+		}, 10*time.Second, time.Millisecond)
+		require.Empty(t, events, "result of check from before the reset is dropped")
+	})
+}
@@ -0,0 +1,212 @@
+// Package interop verifies the cross-chain messages of L2 blocks with the interop supervisor,
+// before the blocks are promoted to safe.
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// FailurePolicyHalt stops promoting blocks with cross-chain messages to safe while the supervisor is unavailable.
+	FailurePolicyHalt = "halt"
+	// FailurePolicyAllow promotes blocks with cross-chain messages to safe while the supervisor is unavailable.
+	// Blocks with messages that the supervisor rejects are never promoted.
+	FailurePolicyAllow = "allow"
+)
+
+// verifiedCacheSize is the number of blocks of which the messages are remembered to be verified.
+const verifiedCacheSize = 1000
+
+var (
+	// CrossL2InboxAddr is the address of the CrossL2Inbox predeploy, that emits the executing messages.
+	CrossL2InboxAddr = common.HexToAddress("0x4200000000000000000000000000000000000022")
+	// ExecutingMessageEventTopic is the topic of the ExecutingMessage(bytes encodedId, bytes message) event.
+	ExecutingMessageEventTopic = crypto.Keccak256Hash([]byte("ExecutingMessage(bytes,bytes)"))
+
+	// ErrInvalidMessages is returned if the supervisor finds cross-chain messages of a block to be invalid.
+	// The messages will never become safe.
+	ErrInvalidMessages = errors.New("cross-chain messages are invalid")
+	// ErrMessagesNotSafe is returned if the supervisor does not consider the cross-chain messages of a block safe,
+	// e.g. because their initiating messages are not cross-safe yet. The check may succeed later.
+	ErrMessagesNotSafe = errors.New("cross-chain messages are not safe yet")
+	// ErrSupervisorUnavailable is returned if the messages of a block cannot be checked with the supervisor.
+	ErrSupervisorUnavailable = errors.New("supervisor unavailable")
+)
+
+var executingMessageArgs = func() abi.Arguments {
+	bytesType, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return abi.Arguments{{Type: bytesType}, {Type: bytesType}}
+}()
+
+// CheckFailurePolicy returns an error if the policy is not a known failure policy.
+func CheckFailurePolicy(policy string) error {
+	switch policy {
+	case FailurePolicyHalt, FailurePolicyAllow:
+		return nil
+	default:
+		return fmt.Errorf("invalid supervisor failure policy: %q", policy)
+	}
+}
+
+type Supervisor interface {
+	// CheckMessages returns an eth.InputError with the eth.InvalidExecutingMessage code if the messages are invalid.
+	CheckMessages(ctx context.Context, messages []eth.Message, minSafety eth.SafetyLevel) error
+}
+
+type L2Source interface {
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
+}
+
+// Gate verifies the executing messages of L2 blocks with the supervisor, before the blocks are promoted to safe.
+// Blocks without executing messages, and blocks before the interop activation, are not checked with the supervisor.
+type Gate struct {
+	log           log.Logger
+	cfg           *rollup.Config
+	supervisor    Supervisor
+	l2            L2Source
+	failurePolicy string
+
+	// verified is the set of recent block hashes of which the messages were verified
+	verified *lru.Cache[common.Hash, struct{}]
+}
+
+var _ SafetyGate = (*Gate)(nil)
+
+func NewGate(log log.Logger, cfg *rollup.Config, supervisor Supervisor, l2 L2Source, failurePolicy string) *Gate {
+	verified, _ := lru.New[common.Hash, struct{}](verifiedCacheSize)
+	return &Gate{
+		log:           log,
+		cfg:           cfg,
+		supervisor:    supervisor,
+		l2:            l2,
+		failurePolicy: failurePolicy,
+		verified:      verified,
+	}
+}
+
+// CheckSafe verifies the messages of the blocks after the safe head, up to and including ref.
+func (g *Gate) CheckSafe(ctx context.Context, safe eth.L2BlockRef, ref eth.L2BlockRef) error {
+	if !g.cfg.IsInterop(ref.Time) {
+		return nil
+	}
+	for num := safe.Number + 1; num <= ref.Number; num++ {
+		block := ref
+		if num < ref.Number {
+			var err error
+			block, err = g.l2.L2BlockRefByNumber(ctx, num)
+			if err != nil {
+				return fmt.Errorf("failed to fetch L2 block %d: %w", num, err)
+			}
+		}
+		if !g.cfg.IsInterop(block.Time) {
+			continue
+		}
+		if err := g.checkBlock(ctx, block); err != nil {
+			return fmt.Errorf("block %s: %w", block, err)
+		}
+	}
+	return nil
+}
+
+func (g *Gate) checkBlock(ctx context.Context, block eth.L2BlockRef) error {
+	if g.verified.Contains(block.Hash) {
+		return nil
+	}
+	_, receipts, err := g.l2.FetchReceipts(ctx, block.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch receipts: %w", err)
+	}
+	messages, err := ExecutingMessages(receipts)
+	if err != nil {
+		return err
+	}
+	if len(messages) > 0 {
+		if err := g.supervisor.CheckMessages(ctx, messages, eth.SafetyCrossSafe); err != nil {
+			var inputErr eth.InputError
+			if errors.As(err, &inputErr) && inputErr.Code == eth.InvalidExecutingMessage {
+				return fmt.Errorf("%w: %w", ErrInvalidMessages, err)
+			}
+			// The supervisor responded, but does not consider the messages safe: the failure policy does not apply.
+			var rpcErr rpc.Error
+			if errors.As(err, &rpcErr) {
+				return fmt.Errorf("%w: %w", ErrMessagesNotSafe, err)
+			}
+			if g.failurePolicy != FailurePolicyAllow {
+				return fmt.Errorf("%w: %w", ErrSupervisorUnavailable, err)
+			}
+			// Not remembered as verified: if the block is promoted again, e.g. after a reset, its messages are checked again.
+			g.log.Warn("Promoting block with unverified cross-chain messages, supervisor is unavailable",
+				"block", block, "messages", len(messages), "err", err)
+			return nil
+		}
+	}
+	g.verified.Add(block.Hash, struct{}{})
+	return nil
+}
+
+// ExecutingMessages returns the executing messages emitted by the CrossL2Inbox in the given receipts.
+func ExecutingMessages(receipts types.Receipts) ([]eth.Message, error) {
+	var messages []eth.Message
+	for _, rec := range receipts {
+		for _, l := range rec.Logs {
+			if l.Address != CrossL2InboxAddr || len(l.Topics) == 0 || l.Topics[0] != ExecutingMessageEventTopic {
+				continue
+			}
+			msg, err := decodeExecutingMessage(l.Data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid executing message in tx %s, log %d: %w", l.TxHash, l.Index, err)
+			}
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+func decodeExecutingMessage(data []byte) (eth.Message, error) {
+	values, err := executingMessageArgs.Unpack(data)
+	if err != nil {
+		return eth.Message{}, err
+	}
+	encodedID, payload := values[0].([]byte), values[1].([]byte)
+	// abi.encode of the (address origin, uint256 blockNumber, uint256 logIndex, uint256 timestamp, uint256 chainId) tuple
+	if len(encodedID) != 32*5 {
+		return eth.Message{}, fmt.Errorf("invalid identifier length %d", len(encodedID))
+	}
+	var words [4]uint64
+	for i := range words {
+		word := new(big.Int).SetBytes(encodedID[32*(i+1) : 32*(i+2)])
+		if !word.IsUint64() {
+			return eth.Message{}, fmt.Errorf("identifier field %d overflows: %v", i+1, word)
+		}
+		words[i] = word.Uint64()
+	}
+	return eth.Message{
+		Identifier: eth.Identifier{
+			Origin:      common.BytesToAddress(encodedID[:32]),
+			BlockNumber: eth.Uint64Quantity(words[0]),
+			LogIndex:    eth.Uint64Quantity(words[1]),
+			Timestamp:   eth.Uint64Quantity(words[2]),
+			ChainID:     eth.Uint64Quantity(words[3]),
+		},
+		PayloadHash: crypto.Keccak256Hash(payload),
+	}, nil
+}
@@ -0,0 +1,149 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type rpcErr int
+
+func (e rpcErr) Error() string  { return fmt.Sprintf("rpc error %d", int(e)) }
+func (e rpcErr) ErrorCode() int { return int(e) }
+
+var rejectedErr = eth.InputError{Inner: rpcErr(eth.InvalidExecutingMessage), Code: eth.InvalidExecutingMessage}
+
+type fakeSupervisor struct {
+	err   error
+	calls [][]eth.Message
+}
+
+func (f *fakeSupervisor) CheckMessages(ctx context.Context, messages []eth.Message, minSafety eth.SafetyLevel) error {
+	f.calls = append(f.calls, messages)
+	return f.err
+}
+
+type fakeL2 struct {
+	blocks   map[uint64]eth.L2BlockRef
+	receipts map[common.Hash]types.Receipts
+}
+
+func (f *fakeL2) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	ref, ok := f.blocks[num]
+	if !ok {
+		return eth.L2BlockRef{}, errors.New("not found")
+	}
+	return ref, nil
+}
+
+func (f *fakeL2) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	return nil, f.receipts[blockHash], nil
+}
+
+func executingMessageLog(t *testing.T, id eth.Identifier, payload []byte) *types.Log {
+	encodedID := make([]byte, 32*5)
+	copy(encodedID[12:32], id.Origin[:])
+	for i, v := range []uint64{uint64(id.BlockNumber), uint64(id.LogIndex), uint64(id.Timestamp), uint64(id.ChainID)} {
+		new(big.Int).SetUint64(v).FillBytes(encodedID[32*(i+1) : 32*(i+2)])
+	}
+	data, err := executingMessageArgs.Pack(encodedID, payload)
+	require.NoError(t, err)
+	return &types.Log{Address: CrossL2InboxAddr, Topics: []common.Hash{ExecutingMessageEventTopic}, Data: data}
+}
+
+func TestExecutingMessages(t *testing.T) {
+	id := eth.Identifier{Origin: common.Address{0xaa}, BlockNumber: 10, LogIndex: 2, Timestamp: 1000, ChainID: 902}
+	receipts := types.Receipts{
+		{Logs: []*types.Log{
+			{Address: common.Address{0x01}, Topics: []common.Hash{ExecutingMessageEventTopic}},
+			executingMessageLog(t, id, []byte("hello")),
+		}},
+	}
+	messages, err := ExecutingMessages(receipts)
+	require.NoError(t, err)
+	require.Equal(t, []eth.Message{{Identifier: id, PayloadHash: crypto.Keccak256Hash([]byte("hello"))}}, messages)
+
+	receipts[0].Logs[1].Data = []byte{1, 2, 3}
+	_, err = ExecutingMessages(receipts)
+	require.Error(t, err)
+}
+
+func TestGate(t *testing.T) {
+	interopTime := uint64(100)
+	cfg := &rollup.Config{InteropTime: &interopTime}
+	safe := eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 1, Time: 98}
+	preInterop := eth.L2BlockRef{Hash: common.Hash{0x02}, Number: 2, Time: 99}
+	noMessages := eth.L2BlockRef{Hash: common.Hash{0x03}, Number: 3, Time: 100}
+	withMessages := eth.L2BlockRef{Hash: common.Hash{0x04}, Number: 4, Time: 101}
+	id := eth.Identifier{Origin: common.Address{0xaa}, BlockNumber: 10, ChainID: 902}
+
+	setup := func(t *testing.T, policy string) (*Gate, *fakeSupervisor) {
+		l2 := &fakeL2{
+			blocks: map[uint64]eth.L2BlockRef{2: preInterop, 3: noMessages, 4: withMessages},
+			receipts: map[common.Hash]types.Receipts{
+				withMessages.Hash: {{Logs: []*types.Log{executingMessageLog(t, id, []byte("hello"))}}},
+			},
+		}
+		supervisor := &fakeSupervisor{}
+		return NewGate(testlog.Logger(t, log.LevelDebug), cfg, supervisor, l2, policy), supervisor
+	}
+
+	t.Run("PreInterop", func(t *testing.T) {
+		gate, supervisor := setup(t, FailurePolicyHalt)
+		require.NoError(t, gate.CheckSafe(context.Background(), safe, preInterop))
+		require.Empty(t, supervisor.calls)
+	})
+	t.Run("Verified", func(t *testing.T) {
+		gate, supervisor := setup(t, FailurePolicyHalt)
+		require.NoError(t, gate.CheckSafe(context.Background(), safe, withMessages))
+		require.Len(t, supervisor.calls, 1, "only the block with messages is checked")
+		// verified blocks are cached
+		require.NoError(t, gate.CheckSafe(context.Background(), safe, withMessages))
+		require.Len(t, supervisor.calls, 1)
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		for _, policy := range []string{FailurePolicyHalt, FailurePolicyAllow} {
+			gate, supervisor := setup(t, policy)
+			supervisor.err = rejectedErr
+			require.ErrorIs(t, gate.CheckSafe(context.Background(), safe, withMessages), ErrInvalidMessages)
+			require.NoError(t, gate.CheckSafe(context.Background(), safe, noMessages))
+		}
+	})
+	t.Run("NotSafeYet", func(t *testing.T) {
+		for _, policy := range []string{FailurePolicyHalt, FailurePolicyAllow} {
+			gate, supervisor := setup(t, policy)
+			supervisor.err = rpcErr(-32000)
+			err := gate.CheckSafe(context.Background(), safe, withMessages)
+			require.ErrorIs(t, err, ErrMessagesNotSafe)
+			require.NotErrorIs(t, err, ErrInvalidMessages)
+			// not cached, the messages are checked again
+			supervisor.err = nil
+			require.NoError(t, gate.CheckSafe(context.Background(), safe, withMessages))
+			require.Len(t, supervisor.calls, 2)
+		}
+	})
+	t.Run("Unavailable", func(t *testing.T) {
+		gate, supervisor := setup(t, FailurePolicyHalt)
+		supervisor.err = errors.New("connection refused")
+		require.ErrorIs(t, gate.CheckSafe(context.Background(), safe, withMessages), ErrSupervisorUnavailable)
+
+		gate, supervisor = setup(t, FailurePolicyAllow)
+		supervisor.err = errors.New("connection refused")
+		require.NoError(t, gate.CheckSafe(context.Background(), safe, withMessages))
+		// not cached as verified
+		supervisor.err = rejectedErr
+		require.ErrorIs(t, gate.CheckSafe(context.Background(), safe, withMessages), ErrInvalidMessages)
+	})
+}
@@ -0,0 +1,296 @@
+package sim
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const gasLimit = 30_000_000
+
+// invalidTxData marks a transaction as invalid: blocks that include it fail to execute.
+var invalidTxData = []byte("sim: invalid transaction")
+
+// ErrInjected is returned by the engine API calls that the simulated engine was instructed to fail.
+var ErrInjected = errors.New("sim: injected engine error")
+
+// Engine is an in-memory execution engine, which builds and executes blocks deterministically.
+// Engine API calls fail at random with a temporary error, at the configured fault rate.
+// Blocks are not executed: a block is invalid if it includes a transaction with invalidTxData.
+type Engine struct {
+	cfg *rollup.Config
+	rng *rand.Rand
+
+	// faultRate is the probability of an engine API call failing with ErrInjected
+	faultRate float64
+
+	blocks map[common.Hash]*eth.ExecutionPayloadEnvelope
+	refs   map[common.Hash]eth.L2BlockRef
+
+	head, safe, finalized common.Hash
+
+	payloads      map[eth.PayloadID]*eth.ExecutionPayloadEnvelope
+	nextPayloadID uint64
+}
+
+var _ engine.Engine = (*Engine)(nil)
+
+// NewEngine creates an engine with the given genesis block as head, safe and finalized block.
+func NewEngine(cfg *rollup.Config, genesis *eth.ExecutionPayloadEnvelope, rng *rand.Rand) *Engine {
+	e := &Engine{
+		cfg:      cfg,
+		rng:      rng,
+		blocks:   make(map[common.Hash]*eth.ExecutionPayloadEnvelope),
+		refs:     make(map[common.Hash]eth.L2BlockRef),
+		payloads: make(map[eth.PayloadID]*eth.ExecutionPayloadEnvelope),
+	}
+	hash := genesis.ExecutionPayload.BlockHash
+	e.blocks[hash] = genesis
+	e.refs[hash] = eth.L2BlockRef{
+		Hash:     hash,
+		Number:   uint64(genesis.ExecutionPayload.BlockNumber),
+		Time:     uint64(genesis.ExecutionPayload.Timestamp),
+		L1Origin: cfg.Genesis.L1,
+	}
+	e.head, e.safe, e.finalized = hash, hash, hash
+	return e
+}
+
+// SetFaultRate sets the probability of an engine API call failing with a temporary error.
+func (e *Engine) SetFaultRate(rate float64) {
+	e.faultRate = rate
+}
+
+// Rewind moves the head of the canonical chain back by depth blocks, but not beyond the safe block,
+// like an engine that lost the tip of the chain when restarting. The blocks remain known to the engine.
+func (e *Engine) Rewind(depth uint64) {
+	head, safe := e.refs[e.head], e.refs[e.safe]
+	target := safe.Number
+	if head.Number > target+depth {
+		target = head.Number - depth
+	}
+	e.head = e.ancestor(head, target).Hash
+}
+
+func (e *Engine) fail() bool {
+	return e.faultRate > 0 && e.rng.Float64() < e.faultRate
+}
+
+// ancestor returns the ancestor of the block at the given number, or a zeroed reference if it is not known.
+func (e *Engine) ancestor(ref eth.L2BlockRef, number uint64) eth.L2BlockRef {
+	for ref.Number > number {
+		parent, ok := e.refs[ref.ParentHash]
+		if !ok {
+			return eth.L2BlockRef{}
+		}
+		ref = parent
+	}
+	if ref.Number != number {
+		return eth.L2BlockRef{}
+	}
+	return ref
+}
+
+// isAncestor returns whether a is an ancestor of b, or b itself.
+func (e *Engine) isAncestor(a, b eth.L2BlockRef) bool {
+	return a.Number <= b.Number && e.ancestor(b, a.Number).Hash == a.Hash
+}
+
+func (e *Engine) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	if e.fail() {
+		return nil, ErrInjected
+	}
+	envelope, ok := e.payloads[payloadInfo.ID]
+	if !ok {
+		return nil, eth.InputError{Inner: fmt.Errorf("unknown payload %s", payloadInfo.ID), Code: eth.UnknownPayload}
+	}
+	return envelope, nil
+}
+
+func (e *Engine) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	if e.fail() {
+		return nil, ErrInjected
+	}
+	head, ok := e.refs[state.HeadBlockHash]
+	if !ok {
+		return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionSyncing}}, nil
+	}
+	for _, h := range []common.Hash{state.SafeBlockHash, state.FinalizedBlockHash} {
+		if h == (common.Hash{}) {
+			continue
+		}
+		if ref, ok := e.refs[h]; !ok || !e.isAncestor(ref, head) {
+			return nil, eth.InputError{Inner: fmt.Errorf("block %s is not an ancestor of head %s", h, head), Code: eth.InvalidForkchoiceState}
+		}
+	}
+	e.head = head.Hash
+	if state.SafeBlockHash != (common.Hash{}) {
+		e.safe = state.SafeBlockHash
+	}
+	if state.FinalizedBlockHash != (common.Hash{}) {
+		e.finalized = state.FinalizedBlockHash
+	}
+	valid := eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &head.Hash}
+	if attr == nil {
+		return &eth.ForkchoiceUpdatedResult{PayloadStatus: valid}, nil
+	}
+	if uint64(attr.Timestamp) <= head.Time {
+		return nil, eth.InputError{Inner: fmt.Errorf("timestamp %d is not after head %s", attr.Timestamp, head), Code: eth.InvalidPayloadAttributes}
+	}
+	if invalidTxs(attr.Transactions) {
+		return nil, eth.InputError{Inner: errors.New("cannot include invalid transaction"), Code: eth.InvalidPayloadAttributes}
+	}
+	var id eth.PayloadID
+	e.nextPayloadID++
+	binary.BigEndian.PutUint64(id[:], e.nextPayloadID)
+	e.payloads[id] = buildPayload(head, attr)
+	return &eth.ForkchoiceUpdatedResult{PayloadStatus: valid, PayloadID: &id}, nil
+}
+
+func (e *Engine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	if e.fail() {
+		return nil, ErrInjected
+	}
+	envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: payload, ParentBeaconBlockRoot: parentBeaconBlockRoot}
+	if err := envelope.VerifyBlockHash(); err != nil {
+		return &eth.PayloadStatusV1{Status: eth.ExecutionInvalidBlockHash}, nil
+	}
+	if _, ok := e.refs[payload.BlockHash]; ok {
+		return &eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &payload.BlockHash}, nil
+	}
+	parent, ok := e.refs[payload.ParentHash]
+	if !ok {
+		return &eth.PayloadStatusV1{Status: eth.ExecutionSyncing}, nil
+	}
+	if uint64(payload.Timestamp) <= parent.Time {
+		msg := "block timestamp is not after parent timestamp"
+		return &eth.PayloadStatusV1{Status: eth.ExecutionInvalid, LatestValidHash: &parent.Hash, ValidationError: &msg}, nil
+	}
+	if invalidTxs(payload.Transactions) {
+		msg := "block includes invalid transaction"
+		return &eth.PayloadStatusV1{Status: eth.ExecutionInvalid, LatestValidHash: &parent.Hash, ValidationError: &msg}, nil
+	}
+	ref, err := derive.PayloadToBlockRef(e.cfg, payload)
+	if err != nil {
+		msg := err.Error()
+		return &eth.PayloadStatusV1{Status: eth.ExecutionInvalid, LatestValidHash: &parent.Hash, ValidationError: &msg}, nil
+	}
+	e.blocks[ref.Hash] = envelope
+	e.refs[ref.Hash] = ref
+	return &eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &ref.Hash}, nil
+}
+
+func (e *Engine) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayloadEnvelope, error) {
+	if e.fail() {
+		return nil, ErrInjected
+	}
+	envelope, ok := e.blocks[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return envelope, nil
+}
+
+func (e *Engine) PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	ref, err := e.L2BlockRefByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return e.blocks[ref.Hash], nil
+}
+
+func (e *Engine) SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error) {
+	envelope, err := e.PayloadByHash(ctx, hash)
+	if err != nil {
+		return eth.SystemConfig{}, err
+	}
+	return derive.PayloadToSystemConfig(e.cfg, envelope.ExecutionPayload)
+}
+
+func (e *Engine) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	if e.fail() {
+		return eth.L2BlockRef{}, ErrInjected
+	}
+	switch label {
+	case eth.Unsafe:
+		return e.refs[e.head], nil
+	case eth.Safe:
+		return e.refs[e.safe], nil
+	case eth.Finalized:
+		return e.refs[e.finalized], nil
+	default:
+		return eth.L2BlockRef{}, fmt.Errorf("unsupported block label %s", label)
+	}
+}
+
+func (e *Engine) L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error) {
+	if e.fail() {
+		return eth.L2BlockRef{}, ErrInjected
+	}
+	ref, ok := e.refs[l2Hash]
+	if !ok {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+func (e *Engine) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	if e.fail() {
+		return eth.L2BlockRef{}, ErrInjected
+	}
+	ref := e.ancestor(e.refs[e.head], num)
+	if ref == (eth.L2BlockRef{}) {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+// buildPayload deterministically builds a block with the given attributes on top of the parent.
+func buildPayload(parent eth.L2BlockRef, attrs *eth.PayloadAttributes) *eth.ExecutionPayloadEnvelope {
+	limit := eth.Uint64Quantity(gasLimit)
+	if attrs.GasLimit != nil {
+		limit = *attrs.GasLimit
+	}
+	stateRoot := crypto.Keccak256Hash(parent.Hash[:])
+	for _, tx := range attrs.Transactions {
+		stateRoot = crypto.Keccak256Hash(stateRoot[:], tx)
+	}
+	payload := &eth.ExecutionPayload{
+		ParentHash:   parent.Hash,
+		FeeRecipient: attrs.SuggestedFeeRecipient,
+		StateRoot:    eth.Bytes32(stateRoot),
+		PrevRandao:   attrs.PrevRandao,
+		BlockNumber:  eth.Uint64Quantity(parent.Number + 1),
+		GasLimit:     limit,
+		Timestamp:    attrs.Timestamp,
+		Transactions: attrs.Transactions,
+		Withdrawals:  attrs.Withdrawals,
+	}
+	envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: payload, ParentBeaconBlockRoot: attrs.ParentBeaconBlockRoot}
+	payload.BlockHash = envelope.BlockHeader().Hash()
+	return envelope
+}
+
+// invalidTxs returns whether any of the transactions cannot be executed.
+func invalidTxs(txs []eth.Data) bool {
+	for _, otx := range txs {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(otx); err != nil || bytes.Equal(tx.Data(), invalidTxData) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,479 @@
+// Package sim simulates the engine state machine of the rollup node deterministically.
+//
+// The engine deriver, the engine controller and the CL-sync payload queue are wired together through a single
+// synchronous event queue, which schedules all events in a deterministic order, against an in-memory execution engine.
+// A sequence of actions then applies derived and gossiped blocks, L1 reorgs, invalid payloads and engine errors,
+// and the invariants of the forkchoice state are checked after every action.
+//
+// Blocks are derived either by emitting the attributes directly, or, with NewPipelineHarness, by the derivation pipeline
+// from batches in an in-memory L1 chain. With the pipeline, the engine is also reset and finalized like the driver does.
+// Unlike the timing-dependent end-to-end tests, any failure can be reproduced from the seed and the actions.
+package sim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/clsync"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+const (
+	genesisTime = 1_700_000_000
+	l1BlockTime = 4
+	l2BlockTime = 2
+
+	// settleBlocks is the number of blocks the safe head has to progress by, once faults stop, for the run to pass.
+	settleBlocks = 8
+	// maxSettleSteps bounds the number of steps the safe head has to progress within.
+	maxSettleSteps = 200
+	// maxDriverSteps bounds the number of driver steps, and engine resets with the derivation pipeline,
+	// that are scheduled within a single step of the simulation.
+	maxDriverSteps = 64
+)
+
+// Action is a step of a simulation, such as deriving a block or reorging L1.
+type Action byte
+
+const (
+	// ActionDerive derives the next block from L1, and inserts it as pending safe block.
+	// With the derivation pipeline, an L1 block is mined instead, with the batches of up to 3 blocks after the safe block.
+	ActionDerive Action = iota
+	// ActionDeriveLast derives the next block from L1, as last block of a span batch, which makes it safe.
+	// With the derivation pipeline, the driver steps the derivation instead.
+	ActionDeriveLast
+	// ActionGossip receives the next unsafe block from the sequencer. The block may conflict with the L1 data,
+	// be invalid, or be received out of order.
+	ActionGossip
+	// ActionReorgL1 reorgs the non-finalized part of the L1 chain.
+	ActionReorgL1
+	// ActionFinalize finalizes the safe block, and the L1 chain up to its L1 origin.
+	// With the derivation pipeline, the L1 chain is finalized instead, and the finalizer finalizes the derived blocks.
+	ActionFinalize
+	// ActionRewindEngine rewinds the unsafe chain of the engine, like an engine restart that lost blocks.
+	ActionRewindEngine
+	// ActionDeriveInvalid derives a block from an invalid batch, with a transaction that cannot be included.
+	// With the derivation pipeline, the invalid batch is submitted to L1 instead.
+	ActionDeriveInvalid
+	// ActionPoke requests the engine to be updated, like the driver does every step.
+	ActionPoke
+
+	numActions
+)
+
+// Harness runs simulations of the engine state machine.
+type Harness struct {
+	log     log.Logger
+	ctx     context.Context
+	cfg     *rollup.Config
+	syncCfg *sync.Config
+	clock   *clock.DeterministicClock
+
+	l1     *L1Chain
+	eng    *Engine
+	ec     *engine.EngineController
+	clSync *clsync.CLSync
+
+	derivers *event.DeriverMux
+	events   *event.Queue
+
+	// pipeline derives the blocks from L1, if the harness was created with NewPipelineHarness
+	pipeline *derive.DerivationPipeline
+	// driverSteps counts the driver steps and engine resets of the current step of the simulation
+	driverSteps int
+	// stepRequested is set when the driver requested a step, which runs once the events are drained
+	stepRequested bool
+	// channels counts the submitted channels. Channel IDs are derived from it.
+	channels uint64
+
+	// finalized remembers the hashes of all blocks that were ever finalized, by block number
+	finalized map[uint64]common.Hash
+
+	// critical is the first critical error that was emitted
+	critical error
+}
+
+var _ event.Deriver = (*Harness)(nil)
+
+// NewHarness creates a harness, starting at genesis. Engine API calls fail at the given rate,
+// with faults determined by the seed.
+func NewHarness(log log.Logger, seed int64, faultRate float64) *Harness {
+	h := newHarness(log, seed, faultRate)
+	h.setDerivers(
+		engine.NewEngDeriver(log, h.ctx, h.cfg, h.ec, h.events),
+		h.clSync,
+		h,
+	)
+	return h
+}
+
+func newHarness(log log.Logger, seed int64, faultRate float64) *Harness {
+	h := &Harness{
+		log:       log,
+		ctx:       context.Background(),
+		clock:     clock.NewDeterministicClock(time.Unix(genesisTime, 0)),
+		syncCfg:   &sync.Config{SyncMode: sync.CLSync},
+		l1:        NewL1Chain(),
+		finalized: make(map[uint64]common.Hash),
+	}
+	genesis := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+		GasLimit:  gasLimit,
+		Timestamp: genesisTime,
+	}}
+	genesis.ExecutionPayload.BlockHash = genesis.BlockHeader().Hash()
+	h.cfg = &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     h.l1.ID(0),
+			L2:     genesis.ExecutionPayload.ID(),
+			L2Time: genesisTime,
+			SystemConfig: eth.SystemConfig{
+				BatcherAddr: batcherAddr,
+				GasLimit:    gasLimit,
+			},
+		},
+		BlockTime:              l2BlockTime,
+		MaxSequencerDrift:      600,
+		SeqWindowSize:          8,
+		ChannelTimeout:         4,
+		L1ChainID:              big.NewInt(900),
+		L2ChainID:              big.NewInt(901),
+		BatchInboxAddress:      batchInboxAddr,
+		DepositContractAddress: depositContractAddr,
+	}
+	h.eng = NewEngine(h.cfg, genesis, rand.New(rand.NewSource(seed)))
+	h.eng.SetFaultRate(faultRate)
+
+	h.derivers = &event.DeriverMux{}
+	h.events = event.NewQueue(log, h.ctx, h.derivers, event.NoopMetrics{})
+	h.ec = engine.NewEngineController(h.eng, log, metrics.NoopMetrics, h.cfg, h.syncCfg, h.events)
+	h.ec.SetClock(h.clock)
+	h.clSync = clsync.NewCLSync(log, h.cfg, metrics.NoopMetrics, h.events, 10_000_000)
+	return h
+}
+
+// setDerivers sets the derivers that process the events.
+func (h *Harness) setDerivers(derivers ...event.Deriver) {
+	*h.derivers = derivers
+}
+
+// OnEvent handles the events that the rest of the rollup node would handle, outside of the engine state machine.
+func (h *Harness) OnEvent(ev event.Event) {
+	switch x := ev.(type) {
+	case rollup.ResetEvent:
+		// with the pipeline, the sync deriver requests the engine reset deriver to find the blocks to reset to
+		if h.pipeline == nil {
+			h.reset()
+		}
+	case driver.StepReqEvent:
+		// like the step scheduler, but without delay, see drain.
+		// The delayed steps, that are requested after a successful step, are left to the actions of the simulation.
+		h.stepRequested = true
+	case rollup.CriticalErrorEvent:
+		if h.critical == nil {
+			h.critical = x.Err
+		}
+	}
+}
+
+// EngineController returns the engine controller under simulation.
+func (h *Harness) EngineController() *engine.EngineController {
+	return h.ec
+}
+
+// Run starts at genesis, applies the actions, and then lets the chain settle without faults.
+// The actions are interpreted as Action, with the remaining bits as parameter of the action.
+// An error is returned if any invariant of the forkchoice state is violated,
+// or if the safe chain does not progress once faults stop.
+func (h *Harness) Run(actions []byte) error {
+	h.events.Emit(rollup.ResetEvent{Err: errors.New("sim: start")})
+	if err := h.drain(); err != nil {
+		return fmt.Errorf("failed to start: %w", err)
+	}
+	if h.pipeline != nil {
+		if err := h.warmUp(); err != nil {
+			return fmt.Errorf("failed to warm up: %w", err)
+		}
+	}
+	for i, b := range actions {
+		h.Step(Action(b%byte(numActions)), uint64(b/byte(numActions)))
+		if err := h.drain(); err != nil {
+			return fmt.Errorf("action %d (%d): %w", i, b, err)
+		}
+	}
+	return h.Settle()
+}
+
+// Step applies a single action, without processing the resulting events.
+func (h *Harness) Step(action Action, param uint64) {
+	h.clock.AdvanceTime(l2BlockTime * time.Second)
+	h.driverSteps = 0
+	if h.pipeline != nil {
+		switch action {
+		case ActionDerive:
+			h.submit(param%4, false)
+			return
+		case ActionDeriveLast:
+			h.step()
+			return
+		case ActionDeriveInvalid:
+			h.submit(1, true)
+			return
+		case ActionFinalize:
+			h.finalizeL1(param % 4)
+			return
+		}
+	}
+	switch action {
+	case ActionDerive, ActionDeriveLast:
+		h.derive(action == ActionDeriveLast, false)
+	case ActionDeriveInvalid:
+		h.derive(param%2 == 0, true)
+	case ActionGossip:
+		h.gossip(param)
+	case ActionReorgL1:
+		h.l1.Reorg(1 + param%4)
+	case ActionFinalize:
+		h.finalize()
+	case ActionRewindEngine:
+		h.eng.Rewind(1 + param%4)
+	case ActionPoke:
+		h.poke()
+	}
+}
+
+// Settle stops all faults, and checks that the safe chain progresses.
+func (h *Harness) Settle() error {
+	h.eng.SetFaultRate(0)
+	start := h.ec.SafeL2Head()
+	for i := 0; i < maxSettleSteps; i++ {
+		if safe := h.ec.SafeL2Head(); safe.Number >= start.Number+settleBlocks &&
+			safe == h.ec.PendingSafeL2Head() && safe == h.ec.UnsafeL2Head() {
+			if h.pipeline != nil {
+				// the driver updates the forkchoice of the last derived blocks on its next step
+				h.events.Emit(engine.TryUpdateEngineEvent{})
+				if err := h.drain(); err != nil {
+					return fmt.Errorf("settle step %d: %w", i, err)
+				}
+			}
+			return h.checkEngine()
+		}
+		h.driverSteps = 0
+		h.poke()
+		if h.pipeline != nil {
+			h.submit(l1BlockTime/l2BlockTime, false)
+			h.step()
+		} else {
+			h.derive(true, false)
+		}
+		if err := h.drain(); err != nil {
+			return fmt.Errorf("settle step %d: %w", i, err)
+		}
+	}
+	return fmt.Errorf("safe head did not progress from %s, stuck at %s", start, h.ec.SafeL2Head())
+}
+
+// drain processes all events, and then the requested driver steps, like the driver does.
+// The number of steps is limited, as the next steps may be requested forever.
+func (h *Harness) drain() error {
+	for {
+		if err := h.events.Drain(); err != nil {
+			return fmt.Errorf("failed to drain events: %w", err)
+		}
+		if !h.stepRequested || h.driverSteps >= maxDriverSteps {
+			break
+		}
+		h.stepRequested = false
+		h.driverSteps++
+		h.events.Emit(driver.StepEvent{})
+	}
+	h.stepRequested = false
+	return h.checkInvariants()
+}
+
+// checkInvariants checks the forkchoice state of the engine controller.
+func (h *Harness) checkInvariants() error {
+	if h.critical != nil {
+		return fmt.Errorf("critical error: %w", h.critical)
+	}
+	finalized, safe, pendingSafe, unsafe := h.ec.Finalized(), h.ec.SafeL2Head(), h.ec.PendingSafeL2Head(), h.ec.UnsafeL2Head()
+	if !(finalized.Number <= safe.Number && safe.Number <= pendingSafe.Number && pendingSafe.Number <= unsafe.Number) {
+		return fmt.Errorf("heads out of order: finalized %s, safe %s, pending safe %s, unsafe %s", finalized, safe, pendingSafe, unsafe)
+	}
+	if _, ok := h.eng.refs[unsafe.Hash]; !ok {
+		return fmt.Errorf("unsafe block %s is unknown to the engine", unsafe)
+	}
+	// The pending safe block may briefly not be part of the unsafe chain, when a stale unsafe payload is inserted
+	// after it. The next derived block builds on the pending safe block again, and Settle checks that the chains agree.
+	for _, ref := range []eth.L2BlockRef{finalized, safe} {
+		if !h.eng.isAncestor(ref, unsafe) {
+			return fmt.Errorf("block %s is not part of the unsafe chain %s", ref, unsafe)
+		}
+	}
+	if prev, ok := h.finalized[finalized.Number]; ok && prev != finalized.Hash {
+		return fmt.Errorf("finalized block %s was reorged, previously %s", finalized, prev)
+	}
+	h.finalized[finalized.Number] = finalized.Hash
+	for num, hash := range h.finalized {
+		if num <= safe.Number && h.eng.ancestor(safe, num).Hash != hash {
+			return fmt.Errorf("safe chain %s does not include finalized block %d:%s", safe, num, hash)
+		}
+	}
+	return nil
+}
+
+// checkEngine checks that the engine agrees with the forkchoice state of the engine controller.
+func (h *Harness) checkEngine() error {
+	if h.eng.head != h.ec.UnsafeL2Head().Hash {
+		return fmt.Errorf("engine head %s does not match unsafe block %s", h.eng.head, h.ec.UnsafeL2Head())
+	}
+	if h.eng.safe != h.ec.SafeL2Head().Hash {
+		return fmt.Errorf("engine safe block %s does not match safe block %s", h.eng.safe, h.ec.SafeL2Head())
+	}
+	if h.eng.finalized != h.ec.Finalized().Hash {
+		return fmt.Errorf("engine finalized block %s does not match finalized block %s", h.eng.finalized, h.ec.Finalized())
+	}
+	return nil
+}
+
+// derive emits the next attributes on top of the pending safe block, like the derivation pipeline does.
+// If the pending safe block was derived from L1 data that has since been reorged, a reset is emitted instead.
+func (h *Harness) derive(lastInSpan bool, invalid bool) {
+	parent := h.ec.PendingSafeL2Head()
+	if !h.l1.Canonical(parent.L1Origin) {
+		h.events.Emit(rollup.ResetEvent{Err: fmt.Errorf("sim: L1 origin %s of pending safe block was reorged", parent.L1Origin)})
+		return
+	}
+	attrs := h.attributes(parent, false)
+	if invalid {
+		attrs.Transactions = append(attrs.Transactions, tx(parent.Number+1, invalidTxData))
+	}
+	h.events.Emit(engine.ProcessAttributesEvent{Attributes: &derive.AttributesWithParent{
+		Attributes:   attrs,
+		Parent:       parent,
+		IsLastInSpan: lastInSpan,
+		DerivedFrom:  h.l1.Ref(originOf(parent.Number + 1)),
+	}})
+}
+
+// gossip emits the next unsafe block of the sequencer, on top of the unsafe block.
+// The param selects whether the block matches the L1 data, conflicts with it, is invalid,
+// or is received after the block that follows it.
+// With the derivation pipeline, blocks are only sequenced once their L1 origin is part of the L1 chain.
+func (h *Harness) gossip(param uint64) {
+	parent := h.ec.UnsafeL2Head()
+	if !h.l1.Canonical(parent.L1Origin) || (h.pipeline != nil && originOf(parent.Number+2) > h.l1.Head()) {
+		return
+	}
+	var envelope *eth.ExecutionPayloadEnvelope
+	switch param % 4 {
+	case 0:
+		envelope = buildPayload(parent, h.attributes(parent, false))
+	case 1:
+		envelope = buildPayload(parent, h.attributes(parent, true))
+	case 2:
+		attrs := h.attributes(parent, false)
+		attrs.Transactions = append(attrs.Transactions, tx(parent.Number+1, invalidTxData))
+		envelope = buildPayload(parent, attrs)
+	case 3:
+		first := buildPayload(parent, h.attributes(parent, false))
+		firstRef, err := derive.PayloadToBlockRef(h.cfg, first.ExecutionPayload)
+		if err != nil {
+			h.critical = fmt.Errorf("sim: invalid gossiped block: %w", err)
+			return
+		}
+		h.events.Emit(clsync.ReceivedUnsafePayloadEvent{Envelope: buildPayload(firstRef, h.attributes(firstRef, false))})
+		envelope = first
+	}
+	h.events.Emit(clsync.ReceivedUnsafePayloadEvent{Envelope: envelope})
+}
+
+// finalize finalizes the safe block, if its L1 origin is still canonical.
+func (h *Harness) finalize() {
+	safe := h.ec.SafeL2Head()
+	if !h.l1.Canonical(safe.L1Origin) || safe.Number <= h.ec.Finalized().Number {
+		return
+	}
+	h.l1.Finalize(safe.L1Origin.Number)
+	h.events.Emit(engine.PromoteFinalizedEvent{Ref: safe})
+}
+
+// poke requests the engine to be updated, like the driver does every step.
+func (h *Harness) poke() {
+	h.events.Emit(engine.TryBackupUnsafeReorgEvent{})
+	h.events.Emit(engine.TryUpdateEngineEvent{})
+	h.events.Emit(engine.ForkchoiceRequestEvent{})
+}
+
+// reset finds the forkchoice state to reset to, like the engine reset deriver does:
+// the chains of the engine are rewound until they are consistent with the L1 chain.
+func (h *Harness) reset() {
+	unsafe := h.canonicalAncestor(h.eng.refs[h.eng.head])
+	safe := h.canonicalAncestor(h.eng.refs[h.eng.safe])
+	finalized := h.eng.refs[h.eng.finalized]
+	h.events.Emit(engine.ForceEngineResetEvent{Unsafe: unsafe, Safe: safe, Finalized: finalized})
+}
+
+// canonicalAncestor returns the first block, starting at ref, that is derived from a canonical L1 block.
+func (h *Harness) canonicalAncestor(ref eth.L2BlockRef) eth.L2BlockRef {
+	for !h.l1.Canonical(ref.L1Origin) {
+		ref = h.eng.refs[ref.ParentHash]
+	}
+	return ref
+}
+
+// attributes returns the attributes of the block after the parent. If conflicting, the attributes
+// include a different sequenced transaction than the batch of the block in L1.
+func (h *Harness) attributes(parent eth.L2BlockRef, conflicting bool) *eth.PayloadAttributes {
+	number := parent.Number + 1
+	origin := originOf(number)
+	info := h.l1.Info(origin)
+	timestamp := h.cfg.Genesis.L2Time + number*l2BlockTime
+	l1InfoTx, err := derive.L1InfoDepositBytes(h.cfg, h.cfg.Genesis.SystemConfig, number-origin*(l1BlockTime/l2BlockTime), info, timestamp)
+	if err != nil {
+		panic(fmt.Errorf("sim: failed to create L1 info deposit: %w", err))
+	}
+	data := []byte("batched")
+	if conflicting {
+		data = []byte("conflicting")
+	}
+	limit := eth.Uint64Quantity(gasLimit)
+	return &eth.PayloadAttributes{
+		Timestamp:             eth.Uint64Quantity(timestamp),
+		PrevRandao:            eth.Bytes32(info.MixDigest()),
+		SuggestedFeeRecipient: predeploys.SequencerFeeVaultAddr,
+		Transactions:          []eth.Data{l1InfoTx, tx(number, data)},
+		NoTxPool:              true,
+		GasLimit:              &limit,
+	}
+}
+
+// originOf returns the number of the L1 origin of the L2 block at the given number.
+func originOf(number uint64) uint64 {
+	return number * l2BlockTime / l1BlockTime
+}
+
+// tx returns an encoded sequenced transaction with the given data.
+func tx(nonce uint64, data []byte) eth.Data {
+	out, err := types.NewTx(&types.LegacyTx{Nonce: nonce, Gas: 21_000, GasPrice: big.NewInt(1), Data: data}).MarshalBinary()
+	if err != nil {
+		panic(fmt.Errorf("sim: failed to encode transaction: %w", err))
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package sim
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+const faultRate = 0.1
+
+func randomActions(rng *rand.Rand, n int) []byte {
+	actions := make([]byte, n)
+	rng.Read(actions)
+	return actions
+}
+
+func TestSimulation(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		actions := randomActions(rand.New(rand.NewSource(seed)), 200)
+		h := NewHarness(testlog.Logger(t, log.LevelCrit), seed, faultRate)
+		require.NoError(t, h.Run(actions), "seed %d", seed)
+	}
+}
+
+func TestSimulationDeterministic(t *testing.T) {
+	actions := randomActions(rand.New(rand.NewSource(1234)), 200)
+	a := NewHarness(testlog.Logger(t, log.LevelCrit), 1234, faultRate)
+	require.NoError(t, a.Run(actions))
+	b := NewHarness(testlog.Logger(t, log.LevelCrit), 1234, faultRate)
+	require.NoError(t, b.Run(actions))
+	require.Equal(t, a.EngineController().QueueState(), b.EngineController().QueueState())
+}
+
+func TestSimulationProgress(t *testing.T) {
+	actions := make([]byte, 20)
+	for i := range actions {
+		actions[i] = byte(ActionDeriveLast)
+	}
+	h := NewHarness(testlog.Logger(t, log.LevelCrit), 0, 0)
+	require.NoError(t, h.Run(actions))
+	require.Equal(t, uint64(20+settleBlocks), h.EngineController().SafeL2Head().Number)
+}
+
+func FuzzSimulation(f *testing.F) {
+	for seed := int64(0); seed < 4; seed++ {
+		f.Add(seed, randomActions(rand.New(rand.NewSource(seed)), 100))
+	}
+	f.Fuzz(func(t *testing.T, seed int64, actions []byte) {
+		h := NewHarness(testlog.Logger(t, log.LevelCrit), seed, faultRate)
+		require.NoError(t, h.Run(actions))
+	})
+}
+
+func TestPipelineSimulation(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		actions := randomActions(rand.New(rand.NewSource(seed)), 200)
+		h := NewPipelineHarness(testlog.Logger(t, log.LevelCrit), seed, faultRate)
+		require.NoError(t, h.Run(actions), "seed %d", seed)
+	}
+}
+
+func TestPipelineSimulationDeterministic(t *testing.T) {
+	actions := randomActions(rand.New(rand.NewSource(1234)), 200)
+	a := NewPipelineHarness(testlog.Logger(t, log.LevelCrit), 1234, faultRate)
+	require.NoError(t, a.Run(actions))
+	b := NewPipelineHarness(testlog.Logger(t, log.LevelCrit), 1234, faultRate)
+	require.NoError(t, b.Run(actions))
+	require.Equal(t, a.EngineController().QueueState(), b.EngineController().QueueState())
+}
+
+func TestPipelineSimulationProgress(t *testing.T) {
+	// every L1 block has the batches of two blocks, and the driver derives a block in between
+	actions := make([]byte, 20)
+	for i := range actions {
+		if i%2 == 0 {
+			actions[i] = byte(ActionDerive) + 2*byte(numActions)
+		} else {
+			actions[i] = byte(ActionDeriveLast)
+		}
+	}
+	h := NewPipelineHarness(testlog.Logger(t, log.LevelCrit), 0, 0)
+	require.NoError(t, h.Run(actions))
+	// compared to a run without actions, as the harness derives the first blocks before applying the actions
+	base := NewPipelineHarness(testlog.Logger(t, log.LevelCrit), 0, 0)
+	require.NoError(t, base.Run(nil))
+	require.Equal(t, base.EngineController().SafeL2Head().Number+10, h.EngineController().SafeL2Head().Number)
+}
+
+func FuzzPipelineSimulation(f *testing.F) {
+	for seed := int64(0); seed < 4; seed++ {
+		f.Add(seed, randomActions(rand.New(rand.NewSource(seed)), 100))
+	}
+	f.Fuzz(func(t *testing.T, seed int64, actions []byte) {
+		h := NewPipelineHarness(testlog.Logger(t, log.LevelCrit), seed, faultRate)
+		require.NoError(t, h.Run(actions))
+	})
+}
@@ -0,0 +1,180 @@
+package sim
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// L1Chain is an in-memory L1 chain. Block hashes are derived from the block number,
+// and from how often the block has been reorged.
+// The chain serves its blocks and batcher transactions to the derivation pipeline, and never fails.
+type L1Chain struct {
+	// forks counts how often each block number has been reorged. The chain extends when a later block is requested.
+	forks     []uint64
+	finalized uint64
+
+	// known has all blocks that were ever part of the chain, including reorged blocks, by hash
+	known map[common.Hash]eth.L1BlockRef
+	// txs has the transactions of the blocks that include any, by hash
+	txs map[common.Hash]types.Transactions
+}
+
+var _ derive.L1Fetcher = (*L1Chain)(nil)
+
+// NewL1Chain creates a chain with only the genesis block.
+func NewL1Chain() *L1Chain {
+	return &L1Chain{
+		forks: []uint64{0},
+		known: make(map[common.Hash]eth.L1BlockRef),
+		txs:   make(map[common.Hash]types.Transactions),
+	}
+}
+
+// Head returns the number of the last block of the chain.
+func (c *L1Chain) Head() uint64 {
+	return uint64(len(c.forks)) - 1
+}
+
+// ID returns the ID of the canonical block at the given number, extending the chain with empty blocks if needed.
+func (c *L1Chain) ID(number uint64) eth.BlockID {
+	for c.Head() < number {
+		c.forks = append(c.forks, 0)
+	}
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], number)
+	binary.BigEndian.PutUint64(buf[8:], c.forks[number])
+	return eth.BlockID{Hash: crypto.Keccak256Hash(buf[:]), Number: number}
+}
+
+// Ref returns the canonical block at the given number, extending the chain with empty blocks if needed.
+func (c *L1Chain) Ref(number uint64) eth.L1BlockRef {
+	id := c.ID(number)
+	ref := eth.L1BlockRef{Hash: id.Hash, Number: number, Time: l1Time(number)}
+	if number > 0 {
+		ref.ParentHash = c.ID(number - 1).Hash
+	}
+	c.known[ref.Hash] = ref
+	return ref
+}
+
+// Info returns the block info of the canonical block at the given number, as included in L2 blocks.
+func (c *L1Chain) Info(number uint64) *testutils.MockBlockInfo {
+	return blockInfo(c.Ref(number))
+}
+
+// Canonical returns whether the block is part of the canonical chain.
+func (c *L1Chain) Canonical(id eth.BlockID) bool {
+	return c.ID(id.Number) == id
+}
+
+// Mine extends the chain with a block with the given transactions.
+func (c *L1Chain) Mine(txs types.Transactions) eth.L1BlockRef {
+	ref := c.Ref(c.Head() + 1)
+	if len(txs) > 0 {
+		c.txs[ref.Hash] = txs
+	}
+	return ref
+}
+
+// Reorg replaces the last depth blocks of the chain with empty blocks, but never the finalized blocks.
+func (c *L1Chain) Reorg(depth uint64) {
+	tip := c.Head()
+	from := c.finalized + 1
+	if tip >= depth && tip-depth+1 > from {
+		from = tip - depth + 1
+	}
+	for i := from; i <= tip; i++ {
+		c.forks[i]++
+	}
+}
+
+// Finalize finalizes the chain up to the given block number. Finality never goes backwards.
+func (c *L1Chain) Finalize(number uint64) {
+	if number > c.finalized {
+		c.finalized = number
+	}
+}
+
+// Finalized returns the finalized block.
+func (c *L1Chain) Finalized() eth.L1BlockRef {
+	return c.Ref(c.finalized)
+}
+
+func (c *L1Chain) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	switch label {
+	case eth.Unsafe, eth.Safe:
+		return c.Ref(c.Head()), nil
+	case eth.Finalized:
+		return c.Finalized(), nil
+	default:
+		return eth.L1BlockRef{}, fmt.Errorf("unsupported block label %s", label)
+	}
+}
+
+func (c *L1Chain) L1BlockRefByNumber(ctx context.Context, number uint64) (eth.L1BlockRef, error) {
+	if number > c.Head() {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return c.Ref(number), nil
+}
+
+func (c *L1Chain) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	ref, ok := c.known[hash]
+	if !ok {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+func (c *L1Chain) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	ref, err := c.L1BlockRefByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return blockInfo(ref), nil
+}
+
+func (c *L1Chain) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	info, err := c.InfoByHash(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, c.txs[hash], nil
+}
+
+// FetchReceipts returns the receipts of the block. Blocks have no deposits or system config updates,
+// so no receipts are returned.
+func (c *L1Chain) FetchReceipts(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	info, err := c.InfoByHash(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, types.Receipts{}, nil
+}
+
+// blockInfo returns the block info of the given block. The block hash doubles as randomness.
+func blockInfo(ref eth.L1BlockRef) *testutils.MockBlockInfo {
+	return &testutils.MockBlockInfo{
+		InfoHash:       ref.Hash,
+		InfoParentHash: ref.ParentHash,
+		InfoNum:        ref.Number,
+		InfoTime:       ref.Time,
+		InfoMixDigest:  ref.Hash,
+		InfoBaseFee:    big.NewInt(7),
+	}
+}
+
+func l1Time(number uint64) uint64 {
+	return genesisTime + number*l1BlockTime
+}
@@ -0,0 +1,195 @@
+package sim
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/attributes"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
+)
+
+var (
+	// batcherKey signs the batcher transactions
+	batcherKey, _  = crypto.ToECDSA(crypto.Keccak256([]byte("sim: batcher")))
+	batcherAddr    = crypto.PubkeyToAddress(batcherKey.PublicKey)
+	batchInboxAddr = common.Address{0xff, 0x00, 0x09, 0x01}
+
+	depositContractAddr = common.Address{0xde, 0x90, 0x51, 0x7e}
+)
+
+// NewPipelineHarness creates a harness like NewHarness, but the blocks are derived by the derivation pipeline,
+// from the batches that are submitted to the L1 chain. Like the driver does, the sync deriver steps the derivation,
+// the engine reset deriver resets the engine, and the finalizer finalizes the blocks derived from finalized L1 blocks.
+// Engine API calls, which includes the L2 data that the pipeline fetches, fail at the given rate.
+func NewPipelineHarness(log log.Logger, seed int64, faultRate float64) *Harness {
+	h := newHarness(log, seed, faultRate)
+	h.pipeline = derive.NewDerivationPipeline(log, h.cfg, h.l1, nil, plasma.Disabled, h.eng, metrics.NoopMetrics, derive.MemoryBudget{})
+	finalizer := finality.NewFinalizer(h.ctx, log, h.cfg, h.l1, h.events, finality.L1Finality{})
+	resetDeriver := engine.NewEngineResetDeriver(h.ctx, log, h.cfg, h.l1, h.eng, h.syncCfg, h.events)
+	engDeriver := engine.NewEngDeriver(log, h.ctx, h.cfg, h.ec, h.events)
+	h.setDerivers(
+		&driver.SyncDeriver{
+			Derivation:         h.pipeline,
+			Finalizer:          finalizer,
+			DerivationProgress: h.pipeline.Progress(),
+			CLSync:             h.clSync,
+			Engine:             h.ec,
+			SyncCfg:            h.syncCfg,
+			Config:             h.cfg,
+			L1:                 h.l1,
+			L2:                 h.eng,
+			Emitter:            h.events,
+			Log:                log,
+			Ctx:                h.ctx,
+			Drain:              h.events.Drain,
+		},
+		// a failed engine reset is retried right away, so resets count towards the driver step limit,
+		// and the pipeline requests the reset again on the next step once the limit is reached
+		event.DeriverFunc(func(ev event.Event) {
+			if _, ok := ev.(engine.ResetEngineRequestEvent); ok {
+				if h.driverSteps >= maxDriverSteps {
+					return
+				}
+				h.driverSteps++
+			}
+			resetDeriver.OnEvent(ev)
+		}),
+		// queued pending-safe requests may be answered before the last attributes were applied, so the attributes
+		// handler may process the same attributes again, on top of the block they built: that is out of scope here,
+		// so the harness drops attributes that do not build on the pending safe block
+		event.DeriverFunc(func(ev event.Event) {
+			if x, ok := ev.(engine.ProcessAttributesEvent); ok && x.Attributes.Parent != h.ec.PendingSafeL2Head() {
+				log.Warn("dropping stale attributes", "parent", x.Attributes.Parent, "pending_safe", h.ec.PendingSafeL2Head())
+				return
+			}
+			engDeriver.OnEvent(ev)
+		}),
+		h,
+		h.clSync,
+		derive.NewPipelineDeriver(h.ctx, h.pipeline, h.events, tracing.NoopTracer{}),
+		attributes.NewAttributesHandler(log, h.cfg, h.ctx, h.eng, h.events),
+		finalizer,
+	)
+	return h
+}
+
+// step steps the driver, like the step scheduler does once the next step is due.
+func (h *Harness) step() {
+	h.events.Emit(driver.StepEvent{})
+}
+
+// submit mines an L1 block, with the batches of the next count blocks after the safe block, like the batcher does.
+// Blocks are only batched once their L1 origin is part of the L1 chain, and are batched again until they are safe,
+// which covers batches that were reorged out of L1, or that were included too late.
+// If invalid, the batch of the first block includes a transaction that cannot be included.
+func (h *Harness) submit(count uint64, invalid bool) {
+	parent := h.ec.SafeL2Head()
+	var batches []*derive.SingularBatch
+	for ; count > 0 && h.l1.Canonical(parent.L1Origin) && originOf(parent.Number+1) <= h.l1.Head(); count-- {
+		attrs := h.attributes(parent, false)
+		if invalid && len(batches) == 0 {
+			attrs.Transactions = append(attrs.Transactions, tx(parent.Number+1, invalidTxData))
+		}
+		origin := h.l1.ID(originOf(parent.Number + 1))
+		batches = append(batches, &derive.SingularBatch{
+			ParentHash:   parent.Hash,
+			EpochNum:     rollup.Epoch(origin.Number),
+			EpochHash:    origin.Hash,
+			Timestamp:    uint64(attrs.Timestamp),
+			Transactions: attrs.Transactions[1:],
+		})
+		ref, err := derive.PayloadToBlockRef(h.cfg, buildPayload(parent, attrs).ExecutionPayload)
+		if err != nil {
+			h.critical = fmt.Errorf("sim: invalid batched block: %w", err)
+			return
+		}
+		parent = ref
+	}
+	var txs types.Transactions
+	if len(batches) > 0 {
+		batchTx, err := h.batcherTx(batches)
+		if err != nil {
+			h.critical = fmt.Errorf("sim: failed to create batcher transaction: %w", err)
+			return
+		}
+		txs = append(txs, batchTx)
+	}
+	h.l1.Mine(txs)
+}
+
+// batcherTx returns a batcher transaction, with a channel of the batches in a single frame.
+func (h *Harness) batcherTx(batches []*derive.SingularBatch) (*types.Transaction, error) {
+	var channel bytes.Buffer
+	w := zlib.NewWriter(&channel)
+	for _, batch := range batches {
+		if err := rlp.Encode(w, derive.NewBatchData(batch)); err != nil {
+			return nil, fmt.Errorf("failed to encode batch: %w", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress channel: %w", err)
+	}
+	h.channels++
+	var id derive.ChannelID
+	binary.BigEndian.PutUint64(id[:], h.channels)
+	frame := derive.Frame{ID: id, Data: channel.Bytes(), IsLast: true}
+	data := bytes.NewBuffer([]byte{derive.DerivationVersion0})
+	if err := frame.MarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("failed to encode frame: %w", err)
+	}
+	return types.SignNewTx(batcherKey, h.cfg.L1Signer(), &types.LegacyTx{
+		Nonce:    h.channels,
+		To:       &batchInboxAddr,
+		Gas:      1_000_000,
+		GasPrice: big.NewInt(1),
+		Data:     data.Bytes(),
+	})
+}
+
+// warmUp derives and finalizes the first blocks without faults, until the L1 origin of the finalized block is
+// at least two blocks after L1 genesis. Resets never go back beyond the finalized block, and the batch queue
+// does not recover from a reset to a safe block with the L1 origin right after L1 genesis, which is out of scope here.
+func (h *Harness) warmUp() error {
+	rate := h.eng.faultRate
+	h.eng.SetFaultRate(0)
+	defer h.eng.SetFaultRate(rate)
+	for i := 0; i < maxSettleSteps; i++ {
+		if h.ec.Finalized().L1Origin.Number >= h.cfg.Genesis.L1.Number+2 {
+			return nil
+		}
+		h.driverSteps = 0
+		h.submit(l1BlockTime/l2BlockTime, false)
+		h.step()
+		h.finalizeL1(0)
+		if err := h.drain(); err != nil {
+			return fmt.Errorf("warm up step %d: %w", i, err)
+		}
+	}
+	return fmt.Errorf("finalized block did not progress, stuck at %s", h.ec.Finalized())
+}
+
+// finalizeL1 finalizes the L1 chain up to depth blocks below the head.
+// The finalizer then finalizes the blocks that were derived from the finalized L1 blocks.
+func (h *Harness) finalizeL1(depth uint64) {
+	if head := h.l1.Head(); head >= depth {
+		h.l1.Finalize(head - depth)
+	}
+	h.events.Emit(finality.FinalizeL1Event{FinalizedL1: h.l1.Finalized()})
+}
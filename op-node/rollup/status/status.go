@@ -30,6 +30,15 @@ func (ev L1SafeEvent) String() string {
 	return "l1-safe"
 }
 
+// ClaimedSafeHeadEvent signals a safe head attested by another node. It does not affect derivation.
+type ClaimedSafeHeadEvent struct {
+	Claimed eth.L2BlockRef
+}
+
+func (ev ClaimedSafeHeadEvent) String() string {
+	return "claimed-safe-head"
+}
+
 type Metrics interface {
 	RecordL1ReorgDepth(d uint64)
 	RecordL1Ref(name string, ref eth.L1BlockRef)
@@ -45,6 +54,9 @@ type StatusTracker struct {
 	metrics Metrics
 
 	mu sync.RWMutex
+
+	subsLock sync.Mutex
+	subs     map[chan<- *eth.SyncStatus]struct{}
 }
 
 func NewStatusTracker(log log.Logger, metrics Metrics) *StatusTracker {
@@ -54,6 +66,7 @@ func NewStatusTracker(log log.Logger, metrics Metrics) *StatusTracker {
 	}
 	st.data = eth.SyncStatus{}
 	st.published.Store(&eth.SyncStatus{})
+	st.subs = make(map[chan<- *eth.SyncStatus]struct{})
 	return st
 }
 
@@ -96,6 +109,12 @@ func (st *StatusTracker) OnEvent(ev event.Event) {
 		st.log.Info("New L1 safe block", "l1_safe", x.L1Safe)
 		st.metrics.RecordL1Ref("l1_safe", x.L1Safe)
 		st.data.SafeL1 = x.L1Safe
+	case ClaimedSafeHeadEvent:
+		if x.Claimed.Number <= st.data.ClaimedSafeL2.Number && st.data.ClaimedSafeL2 != (eth.L2BlockRef{}) {
+			return
+		}
+		st.log.Debug("New claimed L2 safe block", "claimed_safe_l2", x.Claimed, "safe_l2", st.data.SafeL2)
+		st.data.ClaimedSafeL2 = x.Claimed
 	case finality.FinalizeL1Event:
 		st.log.Info("New L1 finalized block", "l1_finalized", x.FinalizedL1)
 		st.metrics.RecordL1Ref("l1_finalized", x.FinalizedL1)
@@ -120,6 +139,32 @@ func (st *StatusTracker) OnEvent(ev event.Event) {
 	if st.data != published {
 		published = st.data
 		st.published.Store(&published)
+		st.notify(&published)
+	}
+}
+
+// Subscribe notifies ch of every change of the sync status, until unsubscribed.
+// Notifications are dropped while ch is full, so subscribers should read the latest sync status from a
+// channel with a buffer of one.
+func (st *StatusTracker) Subscribe(ch chan<- *eth.SyncStatus) (unsubscribe func()) {
+	st.subsLock.Lock()
+	defer st.subsLock.Unlock()
+	st.subs[ch] = struct{}{}
+	return func() {
+		st.subsLock.Lock()
+		defer st.subsLock.Unlock()
+		delete(st.subs, ch)
+	}
+}
+
+func (st *StatusTracker) notify(status *eth.SyncStatus) {
+	st.subsLock.Lock()
+	defer st.subsLock.Unlock()
+	for ch := range st.subs {
+		select {
+		case ch <- status:
+		default: // subscriber did not process the previous notification yet
+		}
 	}
 }
 
@@ -72,4 +72,8 @@ type Config struct {
 	SkipSyncStartCheck bool `json:"skip_sync_start_check"`
 
 	SupportsPostFinalizationELSync bool `json:"supports_post_finalization_elsync"`
+
+	// MaxUnsafeReorgDepth is the maximum number of unsafe blocks that may be reorged automatically.
+	// A deeper reorg halts the node until it is confirmed by an admin. Disabled if 0.
+	MaxUnsafeReorgDepth uint64 `json:"max_unsafe_reorg_depth"`
 }
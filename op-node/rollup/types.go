@@ -34,6 +34,9 @@ var (
 	ErrChainIDsSame                  = errors.New("L1 and L2 chain IDs must be different")
 	ErrL1ChainIDNotPositive          = errors.New("L1 chain ID must be non-zero and positive")
 	ErrL2ChainIDNotPositive          = errors.New("L2 chain ID must be non-zero and positive")
+	ErrMissingDepositLimit           = errors.New("deposit limits must limit the deposit count or gas")
+	ErrMissingDepositLimitsTime      = errors.New("deposit limits require an activation time")
+	ErrMissingDepositLimits          = errors.New("deposit limits activation time is set without deposit limits")
 )
 
 type Genesis struct {
@@ -62,6 +65,34 @@ type PlasmaConfig struct {
 	DAResolveWindow uint64 `json:"da_resolve_window"`
 }
 
+// DepositLimits caps the user deposits of a single L2 block, to bound the size of L2 blocks when L1 blocks
+// contain many deposits. The deposits of an L1 block are split into consecutive chunks within the limits,
+// and every L2 block includes the next chunk that is not included yet, in L1 order. The deposits of an
+// L1 block become available with the first L2 block of its epoch. Chunks that are not included when the next
+// epoch starts are carried over to the following blocks, one chunk per block, before the deposits of the
+// new L1 origin. A chunk contains at least one deposit, even if the deposit exceeds the gas limit by itself.
+//
+// The position in the queue of user deposits is defined by the last user deposit of the parent block:
+// the deposits after it, up to and including the deposits of the L1 origin of the parent block, are pending.
+// If the parent block has no user deposits, no deposits are pending. The L1 info deposit, and the network upgrade
+// deposits that follow the user deposits in upgrade blocks, are not part of the queue.
+//
+// This is an extension of the deposit derivation rules, and must only be activated on chains
+// where all nodes in the network enforce the same limits.
+type DepositLimits struct {
+	// MaxDeposits is the maximum number of user deposits per L2 block. Zero means no limit.
+	MaxDeposits uint64 `json:"max_deposits,omitempty"`
+	// MaxDepositGas is the maximum sum of the gas limits of the user deposits per L2 block. Zero means no limit.
+	MaxDepositGas uint64 `json:"max_deposit_gas,omitempty"`
+}
+
+func (l *DepositLimits) Check() error {
+	if l.MaxDeposits == 0 && l.MaxDepositGas == 0 {
+		return ErrMissingDepositLimit
+	}
+	return nil
+}
+
 type Config struct {
 	// Genesis anchor point of the rollup
 	Genesis Genesis `json:"genesis"`
@@ -113,6 +144,10 @@ type Config struct {
 	// Active if InteropTime != nil && L2 block timestamp >= *InteropTime, inactive otherwise.
 	InteropTime *uint64 `json:"interop_time,omitempty"`
 
+	// DepositLimitsTime sets the activation time of the DepositLimits, activated like a hardfork.
+	// Active if DepositLimitsTime != nil && L2 block timestamp >= *DepositLimitsTime, inactive otherwise.
+	DepositLimitsTime *uint64 `json:"deposit_limits_time,omitempty"`
+
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
 
@@ -126,6 +161,10 @@ type Config struct {
 	// L1 address that declares the protocol versions, optional (Beta feature)
 	ProtocolVersionsAddress common.Address `json:"protocol_versions_address,omitempty"`
 
+	// DepositLimits caps the user deposits per L2 block from DepositLimitsTime, optional.
+	// Deposits are derived per spec if nil, or before the activation time.
+	DepositLimits *DepositLimits `json:"deposit_limits,omitempty"`
+
 	// Plasma Config. We are in the process of migrating to the PlasmaConfig from these legacy top level values
 	PlasmaConfig *PlasmaConfig `json:"plasma_config,omitempty"`
 
@@ -309,6 +348,16 @@ func (cfg *Config) Check() error {
 	if err := validatePlasmaConfig(cfg); err != nil {
 		return err
 	}
+	if cfg.DepositLimits != nil {
+		if err := cfg.DepositLimits.Check(); err != nil {
+			return err
+		}
+		if cfg.DepositLimitsTime == nil {
+			return ErrMissingDepositLimitsTime
+		}
+	} else if cfg.DepositLimitsTime != nil {
+		return ErrMissingDepositLimits
+	}
 
 	if err := checkFork(cfg.RegolithTime, cfg.CanyonTime, Regolith, Canyon); err != nil {
 		return err
@@ -422,6 +471,11 @@ func (c *Config) IsInterop(timestamp uint64) bool {
 	return c.InteropTime != nil && timestamp >= *c.InteropTime
 }
 
+// IsDepositLimits returns true if the deposit limits are active at or past the given timestamp.
+func (c *Config) IsDepositLimits(timestamp uint64) bool {
+	return c.DepositLimitsTime != nil && timestamp >= *c.DepositLimitsTime
+}
+
 func (c *Config) IsRegolithActivationBlock(l2BlockTime uint64) bool {
 	return c.IsRegolith(l2BlockTime) &&
 		l2BlockTime >= c.BlockTime &&
@@ -454,44 +508,33 @@ func (c *Config) IsInteropActivationBlock(l2BlockTime uint64) bool {
 		!c.IsInterop(l2BlockTime-c.BlockTime)
 }
 
+// EngineFork returns the Engine API fork of the chain hard fork version at the given timestamp.
+func (c *Config) EngineFork(timestamp uint64) eth.EngineFork {
+	if c.IsEcotone(timestamp) {
+		return eth.EngineCancun
+	} else if c.IsCanyon(timestamp) {
+		return eth.EngineShanghai
+	}
+	return eth.EngineParis
+}
+
 // ForkchoiceUpdatedVersion returns the EngineAPIMethod suitable for the chain hard fork version.
 func (c *Config) ForkchoiceUpdatedVersion(attr *eth.PayloadAttributes) eth.EngineAPIMethod {
 	if attr == nil {
 		// Don't begin payload build process.
 		return eth.FCUV3
 	}
-	ts := uint64(attr.Timestamp)
-	if c.IsEcotone(ts) {
-		// Cancun
-		return eth.FCUV3
-	} else if c.IsCanyon(ts) {
-		// Shanghai
-		return eth.FCUV2
-	} else {
-		// According to Ethereum engine API spec, we can use fcuV2 here,
-		// but upstream Geth v1.13.11 does not accept V2 before Shanghai.
-		return eth.FCUV1
-	}
+	return eth.FCUForFork(c.EngineFork(uint64(attr.Timestamp)))
 }
 
 // NewPayloadVersion returns the EngineAPIMethod suitable for the chain hard fork version.
 func (c *Config) NewPayloadVersion(timestamp uint64) eth.EngineAPIMethod {
-	if c.IsEcotone(timestamp) {
-		// Cancun
-		return eth.NewPayloadV3
-	} else {
-		return eth.NewPayloadV2
-	}
+	return eth.NewPayloadForFork(c.EngineFork(timestamp))
 }
 
 // GetPayloadVersion returns the EngineAPIMethod suitable for the chain hard fork version.
 func (c *Config) GetPayloadVersion(timestamp uint64) eth.EngineAPIMethod {
-	if c.IsEcotone(timestamp) {
-		// Cancun
-		return eth.GetPayloadV3
-	} else {
-		return eth.GetPayloadV2
-	}
+	return eth.GetPayloadForFork(c.EngineFork(timestamp))
 }
 
 // GetOPPlasmaConfig validates and returns the plasma config from the rollup config.
@@ -564,6 +607,9 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	banner += fmt.Sprintf("  - Ecotone: %s\n", fmtForkTimeOrUnset(c.EcotoneTime))
 	banner += fmt.Sprintf("  - Fjord: %s\n", fmtForkTimeOrUnset(c.FjordTime))
 	banner += fmt.Sprintf("  - Interop: %s\n", fmtForkTimeOrUnset(c.InteropTime))
+	if c.DepositLimits != nil {
+		banner += fmt.Sprintf("  - Deposit limits: %s\n", fmtForkTimeOrUnset(c.DepositLimitsTime))
+	}
 	// Report the protocol version
 	banner += fmt.Sprintf("Node supports up to OP-Stack Protocol Version: %s\n", OPStackSupport)
 	if c.PlasmaConfig != nil {
@@ -598,6 +644,7 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 		"ecotone_time", fmtForkTimeOrUnset(c.EcotoneTime),
 		"fjord_time", fmtForkTimeOrUnset(c.FjordTime),
 		"interop_time", fmtForkTimeOrUnset(c.InteropTime),
+		"deposit_limits_time", fmtForkTimeOrUnset(c.DepositLimitsTime),
 		"plasma_mode", c.PlasmaConfig != nil,
 	)
 }
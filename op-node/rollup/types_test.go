@@ -460,6 +460,24 @@ func TestConfig_Check(t *testing.T) {
 			modifier:    func(cfg *Config) { cfg.L2ChainID = big.NewInt(0) },
 			expectedErr: ErrL2ChainIDNotPositive,
 		},
+		{
+			name:        "NoDepositLimit",
+			modifier:    func(cfg *Config) { cfg.DepositLimits = &DepositLimits{} },
+			expectedErr: ErrMissingDepositLimit,
+		},
+		{
+			name:        "NoDepositLimitsTime",
+			modifier:    func(cfg *Config) { cfg.DepositLimits = &DepositLimits{MaxDeposits: 10} },
+			expectedErr: ErrMissingDepositLimitsTime,
+		},
+		{
+			name: "NoDepositLimitsWithTime",
+			modifier: func(cfg *Config) {
+				activation := uint64(1)
+				cfg.DepositLimitsTime = &activation
+			},
+			expectedErr: ErrMissingDepositLimits,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
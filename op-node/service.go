@@ -11,10 +11,15 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 
@@ -22,6 +27,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/node"
 	p2pcli "github.com/ethereum-optimism/optimism/op-node/p2p/cli"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
@@ -48,7 +54,7 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	driverConfig := NewDriverConfig(ctx)
 
-	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx)
+	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load p2p signer: %w", err)
 	}
@@ -70,21 +76,43 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to create the sync config: %w", err)
 	}
 
+	heartbeatConfig, err := NewHeartbeatConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load heartbeat config: %w", err)
+	}
+
+	var builder node.BuilderSetup
+	if addr := ctx.String(flags.SequencerBuilderAddrFlag.Name); addr != "" {
+		builder = &node.BuilderEndpointConfig{
+			BuilderAddr:          addr,
+			BuilderJWTSecretFile: ctx.String(flags.SequencerBuilderJWTSecretFlag.Name),
+			MaxResponseSize:      ctx.Int64(flags.SequencerBuilderMaxResponseSizeFlag.Name) << 20,
+		}
+	}
+
+	chains, err := NewChainConfigs(ctx, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chains config: %w", err)
+	}
+
 	haltOption := ctx.String(flags.RollupHalt.Name)
 	if haltOption == "none" {
 		haltOption = ""
 	}
 
 	cfg := &node.Config{
-		L1:     l1Endpoint,
-		L2:     l2Endpoint,
-		Rollup: *rollupConfig,
-		Driver: *driverConfig,
-		Beacon: NewBeaconEndpointConfig(ctx),
+		L1:      l1Endpoint,
+		L2:      l2Endpoint,
+		Rollup:  *rollupConfig,
+		Driver:  *driverConfig,
+		Beacon:  NewBeaconEndpointConfig(ctx),
+		Builder: builder,
 		RPC: node.RPCConfig{
 			ListenAddr:  ctx.String(flags.RPCListenAddr.Name),
 			ListenPort:  ctx.Int(flags.RPCListenPort.Name),
 			EnableAdmin: ctx.Bool(flags.RPCEnableAdmin.Name),
+			EnableDebug: ctx.Bool(flags.RPCEnableDebug.Name),
+			AdminAuth:   oprpc.ReadAdminAuthCLIConfig(ctx),
 		},
 		Metrics: node.MetricsConfig{
 			Enabled:    ctx.Bool(flags.MetricsEnabledFlag.Name),
@@ -92,26 +120,41 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			ListenPort: ctx.Int(flags.MetricsPortFlag.Name),
 		},
 		Pprof:                       oppprof.ReadCLIConfig(ctx),
+		Tracing:                     optracing.ReadCLIConfig(ctx),
 		P2P:                         p2pConfig,
 		P2PSigner:                   p2pSignerSetup,
 		L1EpochPollInterval:         ctx.Duration(flags.L1EpochPollIntervalFlag.Name),
 		RuntimeConfigReloadInterval: ctx.Duration(flags.RuntimeConfigReloadIntervalFlag.Name),
-		Heartbeat: node.HeartbeatConfig{
-			Enabled: ctx.Bool(flags.HeartbeatEnabledFlag.Name),
-			Moniker: ctx.String(flags.HeartbeatMonikerFlag.Name),
-			URL:     ctx.String(flags.HeartbeatURLFlag.Name),
+		Heartbeat:                   heartbeatConfig,
+		ConfigPersistence:           configPersistence,
+		SafeDBPath:                  ctx.String(flags.SafeDBPath.Name),
+		Sync:                        *syncConfig,
+		RollupHalt:                  haltOption,
+		ForkCheck: node.ForkCheckConfig{
+			Mode:     ctx.String(flags.L2ForkCheck.Name),
+			RPC:      ctx.String(flags.L2ForkCheckRPC.Name),
+			Interval: ctx.Duration(flags.L2ForkCheckInterval.Name),
+		},
+		RethDBPath: ctx.String(flags.L1RethDBPath.Name),
+
+		ConductorEnabled:       ctx.Bool(flags.ConductorEnabledFlag.Name),
+		ConductorRpc:           ctx.String(flags.ConductorRpcFlag.Name),
+		ConductorRpcTimeout:    ctx.Duration(flags.ConductorRpcTimeoutFlag.Name),
+		ConductorRpcAPIKeyFile: ctx.String(flags.ConductorRpcAPIKeyFileFlag.Name),
+		ConductorRpcTLS: optls.CLIConfig{
+			TLSCaCert: ctx.String(flags.ConductorRpcTLSCaCertFlag.Name),
+			TLSCert:   ctx.String(flags.ConductorRpcTLSCertFlag.Name),
+			TLSKey:    ctx.String(flags.ConductorRpcTLSKeyFlag.Name),
 		},
-		ConfigPersistence: configPersistence,
-		SafeDBPath:        ctx.String(flags.SafeDBPath.Name),
-		Sync:              *syncConfig,
-		RollupHalt:        haltOption,
-		RethDBPath:        ctx.String(flags.L1RethDBPath.Name),
-
-		ConductorEnabled:    ctx.Bool(flags.ConductorEnabledFlag.Name),
-		ConductorRpc:        ctx.String(flags.ConductorRpcFlag.Name),
-		ConductorRpcTimeout: ctx.Duration(flags.ConductorRpcTimeoutFlag.Name),
 
 		Plasma: plasma.ReadCLIConfig(ctx),
+
+		Interop: node.InteropConfig{
+			SupervisorRpc: ctx.String(flags.InteropSupervisor.Name),
+			FailurePolicy: ctx.String(flags.InteropFailurePolicy.Name),
+		},
+
+		Chains: chains,
 	}
 
 	if err := cfg.LoadPersisted(log); err != nil {
@@ -129,6 +172,28 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 	return cfg, nil
 }
 
+func NewHeartbeatConfig(ctx *cli.Context) (node.HeartbeatConfig, error) {
+	cfg := node.HeartbeatConfig{
+		Enabled:  ctx.Bool(flags.HeartbeatEnabledFlag.Name),
+		Moniker:  ctx.String(flags.HeartbeatMonikerFlag.Name),
+		URL:      ctx.String(flags.HeartbeatURLFlag.Name),
+		Interval: ctx.Duration(flags.HeartbeatIntervalFlag.Name),
+		Status:   ctx.Bool(flags.HeartbeatStatusFlag.Name),
+	}
+	if path := ctx.String(flags.HeartbeatSigningKeyFileFlag.Name); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return node.HeartbeatConfig{}, fmt.Errorf("failed to read heartbeat signing key file: %w", err)
+		}
+		priv, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+		if err != nil {
+			return node.HeartbeatConfig{}, fmt.Errorf("failed to parse heartbeat signing key: %w", err)
+		}
+		cfg.SigningKey = priv
+	}
+	return cfg, nil
+}
+
 func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
 	return &node.L1BeaconEndpointConfig{
 		BeaconAddr:             ctx.String(flags.BeaconAddr.Name),
@@ -136,6 +201,9 @@ func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
 		BeaconFallbackAddrs:    ctx.StringSlice(flags.BeaconFallbackAddrs.Name),
 		BeaconCheckIgnore:      ctx.Bool(flags.BeaconCheckIgnore.Name),
 		BeaconFetchAllSidecars: ctx.Bool(flags.BeaconFetchAllSidecars.Name),
+		BeaconBlobCacheDir:     ctx.String(flags.BeaconBlobCacheDir.Name),
+		BeaconBlobCacheSize:    ctx.Int(flags.BeaconBlobCacheSize.Name),
+		BeaconHTTPProfile:      ctx.String(flags.BeaconHTTPProfile.Name),
 	}
 }
 
@@ -148,23 +216,28 @@ func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 		BatchSize:        ctx.Int(flags.L1RPCMaxBatchSize.Name),
 		HttpPollInterval: ctx.Duration(flags.L1HTTPPollInterval.Name),
 		MaxConcurrency:   ctx.Int(flags.L1RPCMaxConcurrency.Name),
+		HTTPProfile:      ctx.String(flags.L1HTTPProfile.Name),
 	}
 }
 
 func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConfig, error) {
-	l2Addr := ctx.String(flags.L2EngineAddr.Name)
-	fileName := ctx.String(flags.L2EngineJWTSecret.Name)
+	return newL2EndpointConfig(ctx, log, ctx.String(flags.L2EngineAddr.Name), ctx.String(flags.L2EngineJWTSecret.Name))
+}
+
+// newL2EndpointConfig creates the config of the engine at l2Addr. The HTTP client of the engine is configured with the flags,
+// which apply to the engines of all chains.
+func newL2EndpointConfig(ctx *cli.Context, log log.Logger, l2Addr string, fileName string) (*node.L2EndpointConfig, error) {
 	var secret [32]byte
 	fileName = strings.TrimSpace(fileName)
 	if fileName == "" {
 		return nil, fmt.Errorf("file-name of jwt secret is empty")
 	}
 	if data, err := os.ReadFile(fileName); err == nil {
-		jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
-		if len(jwtSecret) != 32 {
-			return nil, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", fileName)
+		secrets, err := client.ParseJWTSecrets(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwt secret in path %s: %w", fileName, err)
 		}
-		copy(secret[:], jwtSecret)
+		secret = secrets[0]
 	} else {
 		log.Warn("Failed to read JWT secret from file, generating a new one now. Configure L2 geth with --authrpc.jwt-secret=" + fmt.Sprintf("%q", fileName))
 		if _, err := io.ReadFull(rand.Reader, secret[:]); err != nil {
@@ -176,11 +249,71 @@ func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConf
 	}
 
 	return &node.L2EndpointConfig{
-		L2EngineAddr:      l2Addr,
-		L2EngineJWTSecret: secret,
+		L2EngineAddr:          l2Addr,
+		L2EngineJWTSecret:     secret,
+		L2EngineJWTSecretFile: fileName,
+		HTTPProfile:           ctx.String(flags.L2EngineHTTPProfile.Name),
+		MaxResponseSize:       ctx.Int64(flags.L2EngineMaxResponseSize.Name) << 20,
 	}, nil
 }
 
+// chainFileConfig is an entry of the JSON list of additional chains in the file of the chains config flag.
+type chainFileConfig struct {
+	Name string `json:"name"`
+
+	// Network or RollupConfig selects the rollup config of the chain,
+	// like the equally named flags of the primary chain.
+	Network      string `json:"network,omitempty"`
+	RollupConfig string `json:"rollupConfig,omitempty"`
+
+	L2EngineAddr      string `json:"l2EngineAddr"`
+	L2EngineJWTSecret string `json:"l2EngineJwtSecret"`
+
+	SafeDBPath string `json:"safeDbPath,omitempty"`
+}
+
+// NewChainConfigs loads the additional chains to host from the chains config file, if any.
+func NewChainConfigs(ctx *cli.Context, log log.Logger) ([]node.ChainConfig, error) {
+	path := ctx.String(flags.ChainsConfig.Name)
+	if path == "" {
+		return nil, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chains config: %w", err)
+	}
+	defer file.Close()
+
+	var fileChains []chainFileConfig
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&fileChains); err != nil {
+		return nil, fmt.Errorf("failed to decode chains config: %w", err)
+	}
+	chains := make([]node.ChainConfig, 0, len(fileChains))
+	for _, fc := range fileChains {
+		chainLog := log.New("chain", fc.Name)
+		rollupConfig, err := NewRollupConfig(chainLog, fc.Network, fc.RollupConfig)
+		if err != nil {
+			return nil, fmt.Errorf("chain %q: %w", fc.Name, err)
+		}
+		if !ctx.Bool(flags.RollupLoadProtocolVersions.Name) {
+			rollupConfig.ProtocolVersionsAddress = common.Address{}
+		}
+		l2Endpoint, err := newL2EndpointConfig(ctx, chainLog, fc.L2EngineAddr, fc.L2EngineJWTSecret)
+		if err != nil {
+			return nil, fmt.Errorf("chain %q: failed to load l2 endpoints info: %w", fc.Name, err)
+		}
+		chains = append(chains, node.ChainConfig{
+			Name:       fc.Name,
+			L2:         l2Endpoint,
+			Rollup:     *rollupConfig,
+			SafeDBPath: fc.SafeDBPath,
+		})
+	}
+	return chains, nil
+}
+
 func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 	stateFile := ctx.String(flags.RPCAdminPersistence.Name)
 	if stateFile == "" {
@@ -191,11 +324,22 @@ func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 
 func NewDriverConfig(ctx *cli.Context) *driver.Config {
 	return &driver.Config{
-		VerifierConfDepth:   ctx.Uint64(flags.VerifierL1Confs.Name),
-		SequencerConfDepth:  ctx.Uint64(flags.SequencerL1Confs.Name),
-		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
-		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
-		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		VerifierConfDepth:              ctx.Uint64(flags.VerifierL1Confs.Name),
+		SequencerConfDepth:             ctx.Uint64(flags.SequencerL1Confs.Name),
+		SequencerEnabled:               ctx.Bool(flags.SequencerEnabledFlag.Name),
+		SequencerStopped:               ctx.Bool(flags.SequencerStoppedFlag.Name),
+		SequencerMaxSafeLag:            ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		InclusionDeadlineBypassBuilder: ctx.Bool(flags.SequencerInclusionDeadlineBypassBuilderFlag.Name),
+		InclusionDeadlinesFile:         ctx.String(flags.SequencerInclusionDeadlinesFileFlag.Name),
+		SequencerPolicyFile:            ctx.String(flags.SequencerPolicyFileFlag.Name),
+		SequencerActionLog:             ctx.String(flags.SequencerActionLogFlag.Name),
+		SequencerActionLogMaxSize:      ctx.Uint64(flags.SequencerActionLogMaxSizeFlag.Name) << 20,
+		MemoryBudget: derive.MemoryBudget{
+			FrameQueue:     ctx.Uint64(flags.MemoryBudgetFrameQueueFlag.Name),
+			ChannelBank:    ctx.Uint64(flags.MemoryBudgetChannelBankFlag.Name),
+			UnsafePayloads: ctx.Uint64(flags.MemoryBudgetUnsafePayloadsFlag.Name),
+		},
+		FinalityDelay: ctx.Uint64(flags.VerifierFinalityDelay.Name),
 	}
 }
 
@@ -278,6 +422,7 @@ func NewSyncConfig(ctx *cli.Context, log log.Logger) (*sync.Config, error) {
 		SyncMode:                       mode,
 		SkipSyncStartCheck:             ctx.Bool(flags.SkipSyncStartCheck.Name),
 		SupportsPostFinalizationELSync: engineKind.SupportsPostFinalizationELSync(),
+		MaxUnsafeReorgDepth:            ctx.Uint64(flags.MaxUnsafeReorgDepthFlag.Name),
 	}
 	if ctx.Bool(flags.L2EngineSyncEnabled.Name) {
 		cfg.SyncMode = sync.ELSync
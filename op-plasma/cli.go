@@ -82,7 +82,7 @@ func (c CLIConfig) Check() error {
 }
 
 func (c CLIConfig) NewDAClient() *DAClient {
-	return &DAClient{url: c.DAServerURL, verify: c.VerifyOnRead, precompute: !c.GenericDA}
+	return NewDAClient(c.DAServerURL, c.VerifyOnRead, !c.GenericDA)
 }
 
 func ReadCLIConfig(c *cli.Context) CLIConfig {
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
 )
 
 // ErrNotFound is returned when the server could not find the input.
@@ -23,10 +25,27 @@ type DAClient struct {
 	verify bool
 	// whether commitment is precomputable (only applicable to keccak256)
 	precompute bool
+	// client keeps a pool of connections to the DA server
+	client *http.Client
 }
 
 func NewDAClient(url string, verify bool, pc bool) *DAClient {
-	return &DAClient{url, verify, pc}
+	return &DAClient{
+		url:        url,
+		verify:     verify,
+		precompute: pc,
+		client:     client.NewHTTPClient(daHTTPConfig(), nil),
+	}
+}
+
+// daHTTPConfig is the HTTP client config of the DA client. Requests are only bounded by their context,
+// as with the default HTTP client, since storing and retrieving large inputs may take long.
+func daHTTPConfig() client.HTTPConfig {
+	cfg := client.DefaultHTTPConfig()
+	cfg.Timeout = 0
+	cfg.MaxResponseSize = 0
+	cfg.MaxDecompressedSize = 0
+	return cfg
 }
 
 // GetInput returns the input data for the given encoded commitment bytes.
@@ -35,7 +54,7 @@ func (c *DAClient) GetInput(ctx context.Context, comm CommitmentData) ([]byte, e
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +110,7 @@ func (c *DAClient) setInputWithCommit(ctx context.Context, comm CommitmentData,
 		return fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/octet-stream")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -116,7 +135,7 @@ func (c *DAClient) setInput(ctx context.Context, img []byte) (CommitmentData, er
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/octet-stream")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
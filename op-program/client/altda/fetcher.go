@@ -0,0 +1,59 @@
+package altda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+)
+
+// ErrUnsupportedCommitment is returned for commitments whose inputs can not be verified by the preimage oracle.
+var ErrUnsupportedCommitment = errors.New("unsupported alt-DA commitment type")
+
+// OracleDAStorage is a read-only plasma.DAStorage that loads alt-DA inputs from the pre-image oracle.
+type OracleDAStorage struct {
+	oracle Oracle
+}
+
+var _ plasma.DAStorage = (*OracleDAStorage)(nil)
+
+func NewOracleDAStorage(oracle Oracle) *OracleDAStorage {
+	return &OracleDAStorage{oracle: oracle}
+}
+
+// GetInput returns the input for the given commitment, or plasma.ErrNotFound if the input is not available.
+func (s *OracleDAStorage) GetInput(_ context.Context, comm plasma.CommitmentData) ([]byte, error) {
+	keccakComm, ok := comm.(plasma.Keccak256Commitment)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedCommitment, comm.CommitmentType())
+	}
+	return s.oracle.GetInput(keccakComm)
+}
+
+// SetInput is not supported as the fault proof program never submits inputs.
+func (s *OracleDAStorage) SetInput(_ context.Context, _ []byte) (plasma.CommitmentData, error) {
+	return nil, errors.New("alt-DA inputs can not be stored by the fault proof program")
+}
+
+// NewInputFetcher creates the alt-DA input fetcher for the derivation pipeline. Inputs are loaded from the pre-image
+// oracle, while the DA challenge state is tracked from L1 exactly as op-node does.
+// If alt-DA is not enabled for the chain, plasma.Disabled is returned.
+func NewInputFetcher(logger log.Logger, cfg *rollup.Config, oracle Oracle) (derive.PlasmaInputFetcher, error) {
+	if !cfg.PlasmaEnabled() {
+		return plasma.Disabled, nil
+	}
+	daCfg, err := cfg.GetOPPlasmaConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid alt-DA config: %w", err)
+	}
+	// Generic commitments are opaque so their inputs can't be verified by the pre-image oracle.
+	if daCfg.CommitmentType != plasma.Keccak256CommitmentType {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedCommitment, cfg.PlasmaConfig.CommitmentType)
+	}
+	return plasma.NewPlasmaDAWithStorage(logger, daCfg, NewOracleDAStorage(oracle), &plasma.NoopMetrics{}), nil
+}
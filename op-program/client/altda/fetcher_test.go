@@ -0,0 +1,88 @@
+package altda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestOracleDAStorage(t *testing.T) {
+	input := []byte{1, 2, 3}
+	storage := NewOracleDAStorage(stubOracle{input: input})
+
+	t.Run("Keccak256Commitment", func(t *testing.T) {
+		result, err := storage.GetInput(context.Background(), plasma.NewKeccak256Commitment(input))
+		require.NoError(t, err)
+		require.Equal(t, input, result)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		storage := NewOracleDAStorage(stubOracle{err: plasma.ErrNotFound})
+		_, err := storage.GetInput(context.Background(), plasma.NewKeccak256Commitment(input))
+		require.ErrorIs(t, err, plasma.ErrNotFound)
+	})
+
+	t.Run("GenericCommitment", func(t *testing.T) {
+		_, err := storage.GetInput(context.Background(), plasma.NewGenericCommitment(input))
+		require.ErrorIs(t, err, ErrUnsupportedCommitment)
+	})
+
+	t.Run("SetInput", func(t *testing.T) {
+		_, err := storage.SetInput(context.Background(), input)
+		require.Error(t, err)
+	})
+}
+
+func TestNewInputFetcher(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	plasmaConfig := func(commitmentType string) *rollup.Config {
+		return &rollup.Config{
+			PlasmaConfig: &rollup.PlasmaConfig{
+				DAChallengeAddress: common.Address{0xaa},
+				DAChallengeWindow:  10,
+				DAResolveWindow:    10,
+				CommitmentType:     commitmentType,
+			},
+		}
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		fetcher, err := NewInputFetcher(logger, &rollup.Config{}, stubOracle{})
+		require.NoError(t, err)
+		require.Equal(t, plasma.Disabled, fetcher)
+	})
+
+	t.Run("Keccak256Commitments", func(t *testing.T) {
+		fetcher, err := NewInputFetcher(logger, plasmaConfig(plasma.KeccakCommitmentString), stubOracle{})
+		require.NoError(t, err)
+		require.IsType(t, &plasma.DA{}, fetcher)
+	})
+
+	t.Run("GenericCommitments", func(t *testing.T) {
+		_, err := NewInputFetcher(logger, plasmaConfig(plasma.GenericCommitmentString), stubOracle{})
+		require.ErrorIs(t, err, ErrUnsupportedCommitment)
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		cfg := plasmaConfig(plasma.KeccakCommitmentString)
+		cfg.PlasmaConfig.DAChallengeWindow = 0
+		_, err := NewInputFetcher(logger, cfg, stubOracle{})
+		require.ErrorContains(t, err, "invalid alt-DA config")
+	})
+}
+
+type stubOracle struct {
+	input []byte
+	err   error
+}
+
+func (s stubOracle) GetInput(comm plasma.Keccak256Commitment) ([]byte, error) {
+	return s.input, s.err
+}
@@ -0,0 +1,20 @@
+package altda
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+const (
+	HintAltDAInput = "altda-input"
+)
+
+// InputHint is the encoded commitment of an alt-DA input.
+type InputHint []byte
+
+var _ preimage.Hint = InputHint{}
+
+func (l InputHint) Hint() string {
+	return HintAltDAInput + " " + hexutil.Encode(l)
+}
@@ -0,0 +1,65 @@
+package altda
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+const (
+	// InputAvailable and InputNotAvailable are the availability pre-images of alt-DA inputs.
+	InputAvailable    byte = 1
+	InputNotAvailable byte = 0
+)
+
+type Oracle interface {
+	// GetInput retrieves the alt-DA input committed to by the given keccak256 commitment.
+	// plasma.ErrNotFound is returned if the input is not available from the DA server.
+	GetInput(comm plasma.Keccak256Commitment) ([]byte, error)
+}
+
+// AvailabilityKey is the key of the pre-image that reports whether the input of an alt-DA commitment is available.
+// The pre-image is a single byte, InputAvailable or InputNotAvailable. Unlike the input itself, the availability
+// can not be verified by its key, so it is a global generic pre-image provided by the host.
+type AvailabilityKey common.Hash
+
+// NewAvailabilityKey returns the key of the availability pre-image of the input of the commitment.
+func NewAvailabilityKey(comm plasma.Keccak256Commitment) AvailabilityKey {
+	return AvailabilityKey(crypto.Keccak256Hash([]byte(HintAltDAInput), comm.Encode()))
+}
+
+func (k AvailabilityKey) PreimageKey() (out [32]byte) {
+	out = k
+	out[0] = byte(preimage.GlobalGenericKeyType)
+	return
+}
+
+// PreimageOracle implements Oracle by interfacing with the pure preimage.Oracle
+// to fetch pre-images of alt-DA commitments.
+type PreimageOracle struct {
+	oracle preimage.Oracle
+	hint   preimage.Hinter
+}
+
+var _ Oracle = (*PreimageOracle)(nil)
+
+func NewPreimageOracle(raw preimage.Oracle, hint preimage.Hinter) *PreimageOracle {
+	return &PreimageOracle{
+		oracle: raw,
+		hint:   hint,
+	}
+}
+
+func (p *PreimageOracle) GetInput(comm plasma.Keccak256Commitment) ([]byte, error) {
+	p.hint.Hint(InputHint(comm.Encode()))
+	// Missing inputs are resolved by the DA challenge contract, so the derivation must know they are missing,
+	// rather than wait for a pre-image that the host can not provide.
+	availability := p.oracle.Get(NewAvailabilityKey(comm))
+	if len(availability) != 1 || availability[0] != InputAvailable {
+		return nil, plasma.ErrNotFound
+	}
+	// A keccak256 commitment is the hash of the input, so the input is its keccak256 pre-image.
+	return p.oracle.Get(preimage.Keccak256Key(common.BytesToHash(comm))), nil
+}
@@ -0,0 +1,57 @@
+package altda
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestPreimageOracleGetInput(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	input := testutils.RandomData(rng, 100)
+	comm := plasma.NewKeccak256Commitment(input)
+
+	newOracle := func(t *testing.T, availability byte) (*PreimageOracle, *mock.Mock) {
+		var hints mock.Mock
+		po := &PreimageOracle{
+			oracle: preimage.OracleFn(func(key preimage.Key) []byte {
+				switch key.PreimageKey() {
+				case NewAvailabilityKey(comm).PreimageKey():
+					return []byte{availability}
+				case preimage.Keccak256Key(common.BytesToHash(comm)).PreimageKey():
+					require.Equal(t, InputAvailable, availability, "input of unavailable commitment requested")
+					return input
+				}
+				t.Fatalf("unexpected pre-image key %v", key)
+				return nil
+			}),
+			hint: preimage.HinterFn(func(v preimage.Hint) {
+				hints.MethodCalled("hint", v.Hint())
+			}),
+		}
+		hints.On("hint", InputHint(comm.Encode()).Hint()).Once().Return()
+		return po, &hints
+	}
+
+	t.Run("Available", func(t *testing.T) {
+		po, hints := newOracle(t, InputAvailable)
+		result, err := po.GetInput(comm)
+		require.NoError(t, err)
+		require.Equal(t, input, result)
+		hints.AssertExpectations(t)
+	})
+
+	t.Run("NotAvailable", func(t *testing.T) {
+		po, hints := newOracle(t, InputNotAvailable)
+		_, err := po.GetInput(comm)
+		require.ErrorIs(t, err, plasma.ErrNotFound)
+		hints.AssertExpectations(t)
+	})
+}
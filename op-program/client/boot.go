@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 )
@@ -22,11 +23,18 @@ const (
 	// These local keys are only used for custom chains
 	L2ChainConfigLocalIndex
 	RollupConfigLocalIndex
+
+	// InteropDependenciesLocalIndex is only used when interop dependencies are configured
+	InteropDependenciesLocalIndex
 )
 
 // CustomChainIDIndicator is used to detect when the program should load custom chain configuration
 const CustomChainIDIndicator = uint64(math.MaxUint64)
 
+// InteropChainIDIndicator is used to detect when the program should load custom chain configuration
+// along with the interop dependencies of the chain
+const InteropChainIDIndicator = uint64(math.MaxUint64 - 1)
+
 type BootInfo struct {
 	L1Head             common.Hash
 	L2OutputRoot       common.Hash
@@ -36,6 +44,10 @@ type BootInfo struct {
 
 	L2ChainConfig *params.ChainConfig
 	RollupConfig  *rollup.Config
+
+	// InteropDependencies are the chains the L2 chain depends on, with their agreed output roots.
+	// Only set if the L2 chain ID is the InteropChainIDIndicator.
+	InteropDependencies []interop.Dependency
 }
 
 type oracleClient interface {
@@ -59,7 +71,7 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 
 	var l2ChainConfig *params.ChainConfig
 	var rollupConfig *rollup.Config
-	if l2ChainID == CustomChainIDIndicator {
+	if l2ChainID == CustomChainIDIndicator || l2ChainID == InteropChainIDIndicator {
 		l2ChainConfig = new(params.ChainConfig)
 		err := json.Unmarshal(br.r.Get(L2ChainConfigLocalIndex), &l2ChainConfig)
 		if err != nil {
@@ -82,13 +94,22 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 		}
 	}
 
+	var interopDeps []interop.Dependency
+	if l2ChainID == InteropChainIDIndicator {
+		err := json.Unmarshal(br.r.Get(InteropDependenciesLocalIndex), &interopDeps)
+		if err != nil {
+			panic("failed to bootstrap interop dependencies")
+		}
+	}
+
 	return &BootInfo{
-		L1Head:             l1Head,
-		L2OutputRoot:       l2OutputRoot,
-		L2Claim:            l2Claim,
-		L2ClaimBlockNumber: l2ClaimBlockNumber,
-		L2ChainID:          l2ChainID,
-		L2ChainConfig:      l2ChainConfig,
-		RollupConfig:       rollupConfig,
+		L1Head:              l1Head,
+		L2OutputRoot:        l2OutputRoot,
+		L2Claim:             l2Claim,
+		L2ClaimBlockNumber:  l2ClaimBlockNumber,
+		L2ChainID:           l2ChainID,
+		L2ChainConfig:       l2ChainConfig,
+		RollupConfig:        rollupConfig,
+		InteropDependencies: interopDeps,
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -43,6 +44,47 @@ func TestBootstrapClient_CustomChain(t *testing.T) {
 	require.EqualValues(t, bootInfo, readBootInfo)
 }
 
+func TestBootstrapClient_Interop(t *testing.T) {
+	rollupCfg := *chaincfg.Sepolia
+	interopTime := uint64(1000)
+	rollupCfg.InteropTime = &interopTime
+	bootInfo := &BootInfo{
+		L1Head:             common.HexToHash("0x1111"),
+		L2OutputRoot:       common.HexToHash("0x2222"),
+		L2Claim:            common.HexToHash("0x3333"),
+		L2ClaimBlockNumber: 1,
+		L2ChainID:          InteropChainIDIndicator,
+		L2ChainConfig:      chainconfig.OPSepoliaChainConfig,
+		RollupConfig:       &rollupCfg,
+		InteropDependencies: []interop.Dependency{
+			{ChainID: 901, OutputRoot: common.HexToHash("0x4444")},
+			{ChainID: 902, OutputRoot: common.HexToHash("0x5555")},
+		},
+	}
+	mockOracle := &mockBoostrapOracle{bootInfo, true}
+	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
+	require.EqualValues(t, bootInfo, readBootInfo)
+}
+
+func TestBootstrapClient_InteropWithoutDependencies(t *testing.T) {
+	// The dependencies are not read for chains with interop scheduled unless they are configured
+	rollupCfg := *chaincfg.Sepolia
+	interopTime := uint64(1000)
+	rollupCfg.InteropTime = &interopTime
+	bootInfo := &BootInfo{
+		L1Head:             common.HexToHash("0x1111"),
+		L2OutputRoot:       common.HexToHash("0x2222"),
+		L2Claim:            common.HexToHash("0x3333"),
+		L2ClaimBlockNumber: 1,
+		L2ChainID:          CustomChainIDIndicator,
+		L2ChainConfig:      chainconfig.OPSepoliaChainConfig,
+		RollupConfig:       &rollupCfg,
+	}
+	mockOracle := &mockBoostrapOracle{bootInfo, true}
+	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
+	require.EqualValues(t, bootInfo, readBootInfo)
+}
+
 func TestBootstrapClient_UnknownChainPanics(t *testing.T) {
 	bootInfo := &BootInfo{
 		L1Head:             common.HexToHash("0x1111"),
@@ -85,6 +127,12 @@ func (o *mockBoostrapOracle) Get(key preimage.Key) []byte {
 		}
 		b, _ := json.Marshal(o.b.RollupConfig)
 		return b
+	case InteropDependenciesLocalIndex.PreimageKey():
+		if o.b.L2ChainID != InteropChainIDIndicator {
+			panic(fmt.Sprintf("unexpected oracle request for preimage key %x", key.PreimageKey()))
+		}
+		b, _ := json.Marshal(o.b.InteropDependencies)
+		return b
 	default:
 		panic("unknown key")
 	}
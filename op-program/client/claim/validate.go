@@ -18,6 +18,14 @@ type L2Source interface {
 }
 
 func ValidateClaim(log log.Logger, l2ClaimBlockNum uint64, claimedOutputRoot eth.Bytes32, src L2Source) error {
+	return ValidateClaimCommitment(log, l2ClaimBlockNum, claimedOutputRoot, src, func(outputRoot eth.Bytes32) eth.Bytes32 {
+		return outputRoot
+	})
+}
+
+// ValidateClaimCommitment validates a claim that commits to the output root of the claimed block,
+// with the commitment computed from the output root by commit.
+func ValidateClaimCommitment(log log.Logger, l2ClaimBlockNum uint64, claim eth.Bytes32, src L2Source, commit func(outputRoot eth.Bytes32) eth.Bytes32) error {
 	l2Head, err := src.L2BlockRefByLabel(context.Background(), eth.Safe)
 	if err != nil {
 		return fmt.Errorf("cannot retrieve safe head: %w", err)
@@ -26,9 +34,10 @@ func ValidateClaim(log log.Logger, l2ClaimBlockNum uint64, claimedOutputRoot eth
 	if err != nil {
 		return fmt.Errorf("calculate L2 output root: %w", err)
 	}
-	log.Info("Validating claim", "head", l2Head, "output", outputRoot, "claim", claimedOutputRoot)
-	if claimedOutputRoot != outputRoot {
-		return fmt.Errorf("%w: claim: %v actual: %v", ErrClaimNotValid, claimedOutputRoot, outputRoot)
+	commitment := commit(outputRoot)
+	log.Info("Validating claim", "head", l2Head, "output", outputRoot, "commitment", commitment, "claim", claim)
+	if claim != commitment {
+		return fmt.Errorf("%w: claim: %v actual: %v", ErrClaimNotValid, claim, commitment)
 	}
 	return nil
 }
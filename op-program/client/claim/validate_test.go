@@ -111,3 +111,23 @@ func TestValidateClaim(t *testing.T) {
 		require.ErrorIs(t, err, expectedErr)
 	})
 }
+
+func TestValidateClaimCommitment(t *testing.T) {
+	commit := func(outputRoot eth.Bytes32) eth.Bytes32 {
+		return eth.Bytes32{0xcc, outputRoot[0]}
+	}
+	t.Run("Valid", func(t *testing.T) {
+		l2 := &mockL2{outputRoot: eth.Bytes32{0x11}}
+		logger := testlog.Logger(t, log.LevelError)
+		err := ValidateClaimCommitment(logger, uint64(0), eth.Bytes32{0xcc, 0x11}, l2, commit)
+		require.NoError(t, err)
+	})
+
+	t.Run("Invalid-OutputRoot", func(t *testing.T) {
+		// the output root itself is not a valid claim when a commitment is expected
+		l2 := &mockL2{outputRoot: eth.Bytes32{0x11}}
+		logger := testlog.Logger(t, log.LevelError)
+		err := ValidateClaimCommitment(logger, uint64(0), eth.Bytes32{0x11}, l2, commit)
+		require.ErrorIs(t, err, ErrClaimNotValid)
+	})
+}
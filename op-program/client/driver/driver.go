@@ -12,7 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
-	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 type EndCondition interface {
@@ -30,14 +30,14 @@ type Driver struct {
 }
 
 func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher,
-	l1BlobsSource derive.L1BlobsFetcher, l2Source engine.Engine, targetBlockNum uint64) *Driver {
+	l1BlobsSource derive.L1BlobsFetcher, plasmaSrc derive.PlasmaInputFetcher, l2Source engine.Engine, targetBlockNum uint64) *Driver {
 
 	d := &Driver{
 		logger: logger,
 	}
 
-	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, l1BlobsSource, plasma.Disabled, l2Source, metrics.NoopMetrics)
-	pipelineDeriver := derive.NewPipelineDeriver(context.Background(), pipeline, d)
+	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, l1BlobsSource, plasmaSrc, l2Source, metrics.NoopMetrics, derive.MemoryBudget{})
+	pipelineDeriver := derive.NewPipelineDeriver(context.Background(), pipeline, d, tracing.NoopTracer{})
 
 	ec := engine.NewEngineController(l2Source, logger, metrics.NoopMetrics, cfg, &sync.Config{SyncMode: sync.CLSync}, d)
 	engineDeriv := engine.NewEngDeriver(logger, context.Background(), cfg, ec, d)
@@ -50,6 +50,7 @@ func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher,
 		closing:        false,
 		result:         nil,
 		targetBlockNum: targetBlockNum,
+		progress:       progress{l2Target: targetBlockNum},
 	}
 
 	d.deriver = &event.DeriverMux{
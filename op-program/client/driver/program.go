@@ -23,6 +23,8 @@ type ProgramDeriver struct {
 	closing        bool
 	result         error
 	targetBlockNum uint64
+
+	progress progress
 }
 
 func (d *ProgramDeriver) Closing() bool {
@@ -36,6 +38,7 @@ func (d *ProgramDeriver) Result() error {
 func (d *ProgramDeriver) OnEvent(ev event.Event) {
 	switch x := ev.(type) {
 	case engine.EngineResetConfirmedEvent:
+		d.progress.onReset(x.Safe)
 		d.Emitter.Emit(derive.ConfirmPipelineResetEvent{})
 		// After initial reset we can request the pending-safe block,
 		// where attributes will be generated on top of.
@@ -58,14 +61,23 @@ func (d *ProgramDeriver) OnEvent(ev event.Event) {
 		// and continue with the next.
 		d.Emitter.Emit(engine.PendingSafeRequestEvent{})
 	case engine.ForkchoiceUpdateEvent:
+		if d.progress.onSafeHead(x.SafeL2Head) {
+			d.progress.Log(d.logger, "Derivation progress")
+		}
 		if x.SafeL2Head.Number >= d.targetBlockNum {
 			d.logger.Info("Derivation complete: reached L2 block", "head", x.SafeL2Head)
+			d.progress.Log(d.logger, "Derivation stats")
 			d.closing = true
 		}
+	case derive.DeriverL1StatusEvent:
+		if d.progress.onL1Origin(x.Origin) {
+			d.progress.Log(d.logger, "Derivation progress")
+		}
 	case derive.DeriverIdleEvent:
 		// Not enough data to reach target
 		d.closing = true
 		d.logger.Info("Derivation complete: no further data to process")
+		d.progress.Log(d.logger, "Derivation stats")
 	case rollup.ResetEvent:
 		d.closing = true
 		d.result = fmt.Errorf("unexpected reset error: %w", x.Err)
@@ -104,6 +104,20 @@ func TestProgramDeriver(t *testing.T) {
 			require.NoError(t, p.result)
 		})
 	})
+	// progress is reported as derivation advances
+	t.Run("progress", func(t *testing.T) {
+		p, m := newProgram(t, 42)
+		m.ExpectOnce(derive.ConfirmPipelineResetEvent{})
+		m.ExpectOnce(engine.PendingSafeRequestEvent{})
+		p.OnEvent(engine.EngineResetConfirmedEvent{Safe: eth.L2BlockRef{Number: 30}})
+		p.OnEvent(derive.DeriverL1StatusEvent{Origin: eth.L1BlockRef{Number: 5}})
+		p.OnEvent(derive.DeriverL1StatusEvent{Origin: eth.L1BlockRef{Number: 6}})
+		p.OnEvent(engine.ForkchoiceUpdateEvent{SafeL2Head: eth.L2BlockRef{Number: 32}})
+		m.AssertExpectations(t)
+		require.False(t, p.closing)
+		require.EqualValues(t, 1, p.progress.l1Blocks)
+		require.EqualValues(t, 2, p.progress.l2Blocks())
+	})
 	// on exhaustion of input data: stop without error
 	t.Run("deriver idle", func(t *testing.T) {
 		p, m := newProgram(t, 1000)
@@ -0,0 +1,80 @@
+package driver
+
+import (
+	"runtime"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// progress tracks how far derivation has advanced, so it can be reported in a machine-readable form.
+// Long running executions (e.g. in cannon) can be monitored, and an ETA computed, from the reported progress.
+type progress struct {
+	l1Origin eth.L1BlockRef
+	l1Blocks uint64
+
+	l2Start  eth.L2BlockRef
+	l2Head   eth.L2BlockRef
+	l2Target uint64
+}
+
+// onReset records the safe head derivation starts from.
+func (p *progress) onReset(safe eth.L2BlockRef) {
+	p.l2Start = safe
+	p.l2Head = safe
+}
+
+// onL1Origin records the L1 origin of derivation, returning true if derivation advanced to a new L1 block.
+func (p *progress) onL1Origin(origin eth.L1BlockRef) bool {
+	if origin == p.l1Origin {
+		return false
+	}
+	if p.l1Origin != (eth.L1BlockRef{}) {
+		p.l1Blocks++
+	}
+	p.l1Origin = origin
+	return true
+}
+
+// onSafeHead records the derived safe head, returning true if a new L2 block was derived.
+func (p *progress) onSafeHead(head eth.L2BlockRef) bool {
+	if head.Number <= p.l2Head.Number {
+		return false
+	}
+	p.l2Head = head
+	return true
+}
+
+// l2Blocks returns the number of L2 blocks derived so far.
+func (p *progress) l2Blocks() uint64 {
+	if p.l2Head.Number < p.l2Start.Number {
+		return 0
+	}
+	return p.l2Head.Number - p.l2Start.Number
+}
+
+// l2Remaining returns the number of L2 blocks left to derive to reach the target.
+func (p *progress) l2Remaining() uint64 {
+	if p.l2Head.Number >= p.l2Target {
+		return 0
+	}
+	return p.l2Target - p.l2Head.Number
+}
+
+// Log writes the current progress as a single structured log line.
+// The message and keys are stable so the output can be parsed by external tooling.
+func (p *progress) Log(logger log.Logger, msg string) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	logger.Info(msg,
+		"l1Origin", p.l1Origin.Number,
+		"l1Blocks", p.l1Blocks,
+		"l2Head", p.l2Head.Number,
+		"l2Blocks", p.l2Blocks(),
+		"l2Target", p.l2Target,
+		"l2Remaining", p.l2Remaining(),
+		"memHeapAlloc", mem.HeapAlloc,
+		"memSys", mem.Sys,
+		"numGC", mem.NumGC)
+}
@@ -0,0 +1,63 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestProgress(t *testing.T) {
+	t.Run("L1Origin", func(t *testing.T) {
+		p := &progress{}
+		require.True(t, p.onL1Origin(eth.L1BlockRef{Number: 10}))
+		require.Zero(t, p.l1Blocks, "first origin is the starting point")
+		require.False(t, p.onL1Origin(eth.L1BlockRef{Number: 10}), "unchanged origin")
+		require.True(t, p.onL1Origin(eth.L1BlockRef{Number: 11}))
+		require.True(t, p.onL1Origin(eth.L1BlockRef{Number: 12}))
+		require.EqualValues(t, 2, p.l1Blocks)
+		require.EqualValues(t, 12, p.l1Origin.Number)
+	})
+
+	t.Run("L2Blocks", func(t *testing.T) {
+		p := &progress{l2Target: 110}
+		p.onReset(eth.L2BlockRef{Number: 100})
+		require.Zero(t, p.l2Blocks())
+		require.EqualValues(t, 10, p.l2Remaining())
+
+		require.False(t, p.onSafeHead(eth.L2BlockRef{Number: 100}), "not a new block")
+		require.True(t, p.onSafeHead(eth.L2BlockRef{Number: 101}))
+		require.True(t, p.onSafeHead(eth.L2BlockRef{Number: 103}))
+		require.False(t, p.onSafeHead(eth.L2BlockRef{Number: 102}), "older block")
+		require.EqualValues(t, 3, p.l2Blocks())
+		require.EqualValues(t, 7, p.l2Remaining())
+
+		require.True(t, p.onSafeHead(eth.L2BlockRef{Number: 111}))
+		require.Zero(t, p.l2Remaining())
+	})
+
+	t.Run("Log", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		p := &progress{l2Target: 110}
+		p.onReset(eth.L2BlockRef{Number: 100})
+		p.onL1Origin(eth.L1BlockRef{Number: 10})
+		p.onL1Origin(eth.L1BlockRef{Number: 11})
+		p.onSafeHead(eth.L2BlockRef{Number: 104})
+		p.Log(logger, "Derivation progress")
+
+		rec := logs.FindLog(testlog.NewMessageFilter("Derivation progress"))
+		require.NotNil(t, rec)
+		require.EqualValues(t, 11, rec.AttrValue("l1Origin"))
+		require.EqualValues(t, 1, rec.AttrValue("l1Blocks"))
+		require.EqualValues(t, 104, rec.AttrValue("l2Head"))
+		require.EqualValues(t, 4, rec.AttrValue("l2Blocks"))
+		require.EqualValues(t, 110, rec.AttrValue("l2Target"))
+		require.EqualValues(t, 6, rec.AttrValue("l2Remaining"))
+		require.NotZero(t, rec.AttrValue("memHeapAlloc"))
+		require.NotZero(t, rec.AttrValue("memSys"))
+	})
+}
@@ -0,0 +1,88 @@
+package interop
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrInvalidDependency = errors.New("invalid interop dependency")
+
+// Dependency is a chain that the proven L2 chain depends on, with the agreed output root of that chain.
+type Dependency struct {
+	ChainID    uint64      `json:"chainId"`
+	OutputRoot common.Hash `json:"outputRoot"`
+}
+
+// RemoteOutput is the output of a dependent chain, loaded via the pre-image oracle.
+type RemoteOutput struct {
+	Dependency
+	Output eth.Output
+}
+
+// ValidateDependencies checks that each dependency is a distinct chain other than the proven chain, with an output root.
+func ValidateDependencies(chainID uint64, deps []Dependency) error {
+	seen := make(map[uint64]bool, len(deps))
+	for _, dep := range deps {
+		if dep.ChainID == 0 {
+			return fmt.Errorf("%w: missing chain ID", ErrInvalidDependency)
+		}
+		if dep.ChainID == chainID {
+			return fmt.Errorf("%w: chain %d can not depend on itself", ErrInvalidDependency, dep.ChainID)
+		}
+		if seen[dep.ChainID] {
+			return fmt.Errorf("%w: duplicate chain %d", ErrInvalidDependency, dep.ChainID)
+		}
+		if dep.OutputRoot == (common.Hash{}) {
+			return fmt.Errorf("%w: missing output root for chain %d", ErrInvalidDependency, dep.ChainID)
+		}
+		seen[dep.ChainID] = true
+	}
+	return nil
+}
+
+// LoadDependencies validates the dependencies of the chain with the given chain ID and loads their outputs.
+// The outputs are read via the oracle, so the pre-images of the agreed output roots of dependent chains
+// become part of the proof.
+func LoadDependencies(logger log.Logger, chainID uint64, deps []Dependency, oracle Oracle) ([]RemoteOutput, error) {
+	if err := ValidateDependencies(chainID, deps); err != nil {
+		return nil, err
+	}
+	outputs := make([]RemoteOutput, 0, len(deps))
+	for _, dep := range deps {
+		output := oracle.OutputByRoot(dep.ChainID, dep.OutputRoot)
+		logger.Info("Loaded dependency output", "chainId", dep.ChainID, "outputRoot", dep.OutputRoot)
+		outputs = append(outputs, RemoteOutput{Dependency: dep, Output: output})
+	}
+	return outputs, nil
+}
+
+// SuperRoot is the commitment that claims about a chain with interop dependencies are made over. It commits to the
+// output root of the proven chain and the outputs of its dependencies, ordered by chain ID:
+// keccak256(outputRoot ++ chainID_1 ++ outputRoot_1 ++ ... ++ chainID_n ++ outputRoot_n),
+// with chain IDs encoded as 32 byte big-endian integers.
+// The output roots of the dependencies are computed from the loaded outputs.
+func SuperRoot(outputRoot eth.Bytes32, outputs []RemoteOutput) eth.Bytes32 {
+	sorted := slices.Clone(outputs)
+	slices.SortFunc(sorted, func(a, b RemoteOutput) int {
+		return cmp.Compare(a.ChainID, b.ChainID)
+	})
+	data := make([]byte, 0, 32+64*len(sorted))
+	data = append(data, outputRoot[:]...)
+	for _, output := range sorted {
+		var chainID [32]byte
+		binary.BigEndian.PutUint64(chainID[24:], output.ChainID)
+		root := eth.OutputRoot(output.Output)
+		data = append(data, chainID[:]...)
+		data = append(data, root[:]...)
+	}
+	return eth.Bytes32(crypto.Keccak256Hash(data))
+}
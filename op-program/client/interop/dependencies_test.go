@@ -0,0 +1,102 @@
+package interop
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubOracle map[uint64]map[common.Hash]eth.Output
+
+func (s stubOracle) OutputByRoot(chainID uint64, root common.Hash) eth.Output {
+	output, ok := s[chainID][root]
+	if !ok {
+		panic("unknown output")
+	}
+	return output
+}
+
+func TestValidateDependencies(t *testing.T) {
+	tests := []struct {
+		name string
+		deps []Dependency
+		err  bool
+	}{
+		{name: "None"},
+		{name: "Valid", deps: []Dependency{{ChainID: 2, OutputRoot: common.Hash{0x2}}, {ChainID: 3, OutputRoot: common.Hash{0x3}}}},
+		{name: "MissingChainID", deps: []Dependency{{OutputRoot: common.Hash{0x2}}}, err: true},
+		{name: "Self", deps: []Dependency{{ChainID: 1, OutputRoot: common.Hash{0x1}}}, err: true},
+		{name: "Duplicate", deps: []Dependency{{ChainID: 2, OutputRoot: common.Hash{0x2}}, {ChainID: 2, OutputRoot: common.Hash{0x3}}}, err: true},
+		{name: "MissingOutputRoot", deps: []Dependency{{ChainID: 2}}, err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateDependencies(1, test.deps)
+			if test.err {
+				require.ErrorIs(t, err, ErrInvalidDependency)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadDependencies(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	output2 := &eth.OutputV0{BlockHash: common.Hash{0x22}}
+	output3 := &eth.OutputV0{BlockHash: common.Hash{0x33}}
+	root2 := common.Hash(eth.OutputRoot(output2))
+	root3 := common.Hash(eth.OutputRoot(output3))
+	oracle := stubOracle{
+		2: {root2: output2},
+		3: {root3: output3},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		deps := []Dependency{{ChainID: 2, OutputRoot: root2}, {ChainID: 3, OutputRoot: root3}}
+		outputs, err := LoadDependencies(logger, 1, deps, oracle)
+		require.NoError(t, err)
+		require.Equal(t, []RemoteOutput{
+			{Dependency: deps[0], Output: output2},
+			{Dependency: deps[1], Output: output3},
+		}, outputs)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := LoadDependencies(logger, 2, []Dependency{{ChainID: 2, OutputRoot: root2}}, oracle)
+		require.ErrorIs(t, err, ErrInvalidDependency)
+	})
+}
+
+func TestSuperRoot(t *testing.T) {
+	outputA := &eth.OutputV0{StateRoot: eth.Bytes32{0xa}, BlockHash: common.Hash{0xa}}
+	outputB := &eth.OutputV0{StateRoot: eth.Bytes32{0xb}, BlockHash: common.Hash{0xb}}
+	a := RemoteOutput{Dependency: Dependency{ChainID: 2, OutputRoot: common.Hash(eth.OutputRoot(outputA))}, Output: outputA}
+	b := RemoteOutput{Dependency: Dependency{ChainID: 3, OutputRoot: common.Hash(eth.OutputRoot(outputB))}, Output: outputB}
+	root := eth.Bytes32{0x1}
+
+	superRoot := SuperRoot(root, []RemoteOutput{a, b})
+	require.Equal(t, superRoot, SuperRoot(root, []RemoteOutput{b, a}), "independent of the order of the dependencies")
+	require.NotEqual(t, superRoot, SuperRoot(eth.Bytes32{0x2}, []RemoteOutput{a, b}))
+	require.NotEqual(t, superRoot, SuperRoot(root, []RemoteOutput{a}))
+
+	swapped := a
+	swapped.ChainID = 4
+	require.NotEqual(t, superRoot, SuperRoot(root, []RemoteOutput{swapped, b}))
+
+	var expected []byte
+	expected = append(expected, root[:]...)
+	expected = append(expected, common.BigToHash(big.NewInt(2)).Bytes()...)
+	expected = append(expected, a.OutputRoot.Bytes()...)
+	expected = append(expected, common.BigToHash(big.NewInt(3)).Bytes()...)
+	expected = append(expected, b.OutputRoot.Bytes()...)
+	require.Equal(t, eth.Bytes32(crypto.Keccak256Hash(expected)), superRoot)
+}
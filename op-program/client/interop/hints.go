@@ -0,0 +1,39 @@
+package interop
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+const (
+	HintL2RemoteOutput = "l2-remote-output"
+)
+
+// RemoteOutputHint requests the output with the given root from the dependent chain with the given chain ID.
+type RemoteOutputHint struct {
+	ChainID    uint64
+	OutputRoot common.Hash
+}
+
+var _ preimage.Hint = RemoteOutputHint{}
+
+func (l RemoteOutputHint) Hint() string {
+	data := binary.BigEndian.AppendUint64(nil, l.ChainID)
+	data = append(data, l.OutputRoot.Bytes()...)
+	return HintL2RemoteOutput + " " + hexutil.Encode(data)
+}
+
+// ParseRemoteOutputHint decodes the data of a RemoteOutputHint.
+func ParseRemoteOutputHint(data []byte) (RemoteOutputHint, bool) {
+	if len(data) != 8+common.HashLength {
+		return RemoteOutputHint{}, false
+	}
+	return RemoteOutputHint{
+		ChainID:    binary.BigEndian.Uint64(data[:8]),
+		OutputRoot: common.BytesToHash(data[8:]),
+	}, true
+}
@@ -0,0 +1,43 @@
+package interop
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type Oracle interface {
+	// OutputByRoot retrieves the output with the given root from the dependent chain with the given chain ID.
+	OutputByRoot(chainID uint64, root common.Hash) eth.Output
+}
+
+// PreimageOracle implements Oracle by interfacing with the pure preimage.Oracle
+// to fetch pre-images of the outputs of dependent chains.
+type PreimageOracle struct {
+	oracle preimage.Oracle
+	hint   preimage.Hinter
+}
+
+var _ Oracle = (*PreimageOracle)(nil)
+
+func NewPreimageOracle(raw preimage.Oracle, hint preimage.Hinter) *PreimageOracle {
+	return &PreimageOracle{
+		oracle: raw,
+		hint:   hint,
+	}
+}
+
+func (p *PreimageOracle) OutputByRoot(chainID uint64, root common.Hash) eth.Output {
+	// The output is the keccak256 pre-image of its root, regardless of the chain it is from,
+	// but the host needs the chain ID to know where to fetch it from.
+	p.hint.Hint(RemoteOutputHint{ChainID: chainID, OutputRoot: root})
+	data := p.oracle.Get(preimage.Keccak256Key(root))
+	output, err := eth.UnmarshalOutput(data)
+	if err != nil {
+		panic(fmt.Errorf("invalid output data for root %s of chain %d: %w", root, chainID, err))
+	}
+	return output
+}
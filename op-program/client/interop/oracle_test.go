@@ -0,0 +1,64 @@
+package interop
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestPreimageOracleOutputByRoot(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	output := &eth.OutputV0{
+		StateRoot:                eth.Bytes32(testutils.RandomHash(rng)),
+		MessagePasserStorageRoot: eth.Bytes32(testutils.RandomHash(rng)),
+		BlockHash:                testutils.RandomHash(rng),
+	}
+	root := common.Hash(eth.OutputRoot(output))
+
+	var hints mock.Mock
+	po := &PreimageOracle{
+		oracle: preimage.OracleFn(func(key preimage.Key) []byte {
+			require.Equal(t, preimage.Keccak256Key(root).PreimageKey(), key.PreimageKey())
+			return output.Marshal()
+		}),
+		hint: preimage.HinterFn(func(v preimage.Hint) {
+			hints.MethodCalled("hint", v.Hint())
+		}),
+	}
+	hints.On("hint", RemoteOutputHint{ChainID: 901, OutputRoot: root}.Hint()).Once().Return()
+
+	require.Equal(t, output, po.OutputByRoot(901, root))
+	hints.AssertExpectations(t)
+}
+
+func TestPreimageOracleOutputByRootInvalid(t *testing.T) {
+	po := &PreimageOracle{
+		oracle: preimage.OracleFn(func(key preimage.Key) []byte {
+			return []byte{1, 2, 3}
+		}),
+		hint: preimage.HinterFn(func(v preimage.Hint) {}),
+	}
+	require.Panics(t, func() {
+		po.OutputByRoot(901, common.Hash{0xaa})
+	})
+}
+
+func TestRemoteOutputHint(t *testing.T) {
+	hint := RemoteOutputHint{ChainID: 0x0102, OutputRoot: common.Hash{0xaa, 0xbb}}
+	require.Equal(t, "l2-remote-output 0x0000000000000102aabb"+common.Bytes2Hex(make([]byte, 30)), hint.Hint())
+
+	data := common.FromHex(hint.Hint()[len(HintL2RemoteOutput)+1:])
+	parsed, ok := ParseRemoteOutputHint(data)
+	require.True(t, ok)
+	require.Equal(t, hint, parsed)
+
+	_, ok = ParseRemoteOutputHint(data[1:])
+	require.False(t, ok)
+}
@@ -12,8 +12,10 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/altda"
 	"github.com/ethereum-optimism/optimism/op-program/client/claim"
 	cldr "github.com/ethereum-optimism/optimism/op-program/client/driver"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	oppio "github.com/ethereum-optimism/optimism/op-program/io"
@@ -44,6 +46,8 @@ func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter
 	hClient := preimage.NewHintWriter(preimageHinter)
 	l1PreimageOracle := l1.NewCachingOracle(l1.NewPreimageOracle(pClient, hClient))
 	l2PreimageOracle := l2.NewCachingOracle(l2.NewPreimageOracle(pClient, hClient))
+	altDAPreimageOracle := altda.NewPreimageOracle(pClient, hClient)
+	interopPreimageOracle := interop.NewPreimageOracle(pClient, hClient)
 
 	bootInfo := NewBootstrapClient(pClient).BootInfo()
 	logger.Info("Program Bootstrapped", "bootInfo", bootInfo)
@@ -57,25 +61,45 @@ func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter
 		bootInfo.L2ClaimBlockNumber,
 		l1PreimageOracle,
 		l2PreimageOracle,
+		altDAPreimageOracle,
+		bootInfo.InteropDependencies,
+		interopPreimageOracle,
 	)
 }
 
 // runDerivation executes the L2 state transition, given a minimal interface to retrieve data.
-func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle) error {
+// The outputs of any interop dependencies are loaded via the oracle, and the claim is validated against the
+// interop.SuperRoot of the claimed output and the outputs of the dependencies, so it commits to the agreed outputs of
+// the dependent chains it was proven against.
+func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle, altDAOracle altda.Oracle, interopDeps []interop.Dependency, interopOracle interop.Oracle) error {
 	l1Source := l1.NewOracleL1Client(logger, l1Oracle, l1Head)
 	l1BlobsSource := l1.NewBlobFetcher(logger, l1Oracle)
 	engineBackend, err := l2.NewOracleBackedL2Chain(logger, l2Oracle, l1Oracle /* kzg oracle */, l2Cfg, l2OutputRoot)
 	if err != nil {
 		return fmt.Errorf("failed to create oracle-backed L2 chain: %w", err)
 	}
+	remoteOutputs, err := interop.LoadDependencies(logger, l2Cfg.ChainID.Uint64(), interopDeps, interopOracle)
+	if err != nil {
+		return fmt.Errorf("failed to load interop dependencies: %w", err)
+	}
 	l2Source := l2.NewOracleEngine(cfg, logger, engineBackend)
+	plasmaSrc, err := altda.NewInputFetcher(logger, cfg, altDAOracle)
+	if err != nil {
+		return fmt.Errorf("failed to create alt-DA input fetcher: %w", err)
+	}
 
 	logger.Info("Starting derivation")
-	d := cldr.NewDriver(logger, cfg, l1Source, l1BlobsSource, l2Source, l2ClaimBlockNum)
+	d := cldr.NewDriver(logger, cfg, l1Source, l1BlobsSource, plasmaSrc, l2Source, l2ClaimBlockNum)
 	if err := d.RunComplete(); err != nil {
 		return fmt.Errorf("failed to run program to completion: %w", err)
 	}
-	return claim.ValidateClaim(logger, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source)
+	if len(remoteOutputs) == 0 {
+		return claim.ValidateClaim(logger, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source)
+	}
+	// The claim of a chain with interop dependencies commits to the outputs of the dependencies it was proven against.
+	return claim.ValidateClaimCommitment(logger, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source, func(outputRoot eth.Bytes32) eth.Bytes32 {
+		return interop.SuperRoot(outputRoot, remoteOutputs)
+	})
 }
 
 func CreateHinterChannel() oppio.FileChannel {
@@ -44,6 +44,9 @@ func run(args []string, action ConfigAction) error {
 	app.Name = "op-program"
 	app.Usage = "Optimism Fault Proof Program"
 	app.Description = "The Optimism Fault Proof Program fault proof program that runs through the rollup state-transition to verify an L2 output from L1 inputs."
+	app.Commands = []*cli.Command{
+		PrestateCommand,
+	}
 	app.Action = func(ctx *cli.Context) error {
 		logger, err := setupLogging(ctx)
 		if err != nil {
@@ -215,9 +215,9 @@ func TestL1TrustRPC(t *testing.T) {
 }
 
 func TestL1RPCKind(t *testing.T) {
-	t.Run("DefaultBasic", func(t *testing.T) {
+	t.Run("DefaultAuto", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
-		require.Equal(t, sources.RPCKindStandard, cfg.L1RPCKind)
+		require.Equal(t, sources.RPCKindAuto, cfg.L1RPCKind)
 	})
 	for _, kind := range sources.RPCProviderKinds {
 		t.Run(kind.String(), func(t *testing.T) {
@@ -273,6 +273,77 @@ func TestL2BlockNumber(t *testing.T) {
 	})
 }
 
+func TestAltDAServer(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.AltDAServerURL)
+	})
+	t.Run("Set", func(t *testing.T) {
+		url := "http://localhost:3100"
+		cfg := configForArgs(t, addRequiredArgs("--altda.da-server", url))
+		require.Equal(t, url, cfg.AltDAServerURL)
+	})
+	t.Run("PlasmaAlias", func(t *testing.T) {
+		url := "http://localhost:3100"
+		cfg := configForArgs(t, addRequiredArgs("--plasma.da-server", url))
+		require.Equal(t, url, cfg.AltDAServerURL)
+	})
+}
+
+func TestPrefetchConcurrency(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, uint(4), cfg.PrefetchConcurrency)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch.concurrency", "16"))
+		require.Equal(t, uint(16), cfg.PrefetchConcurrency)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch.concurrency", "0"))
+		require.Equal(t, uint(0), cfg.PrefetchConcurrency)
+	})
+}
+
+func TestInteropDependencies(t *testing.T) {
+	writeDeps := func(t *testing.T, deps []config.InteropDependency) string {
+		j, err := json.Marshal(deps)
+		require.NoError(t, err)
+		depsFile := t.TempDir() + "/deps.json"
+		require.NoError(t, os.WriteFile(depsFile, j, 0666))
+		return depsFile
+	}
+	dep := config.InteropDependency{
+		ChainID:    901,
+		OutputRoot: common.HexToHash("0x1111"),
+		L2Head:     common.HexToHash("0x2222"),
+		L2URL:      "http://localhost:9545",
+	}
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.InteropDependencies)
+	})
+	t.Run("Set", func(t *testing.T) {
+		rollupCfg := *chaincfg.Sepolia
+		interopTime := uint64(1000)
+		rollupCfg.InteropTime = &interopTime
+		j, err := json.Marshal(rollupCfg)
+		require.NoError(t, err)
+		rollupCfgFile := t.TempDir() + "/rollup.json"
+		require.NoError(t, os.WriteFile(rollupCfgFile, j, 0666))
+		depsFile := writeDeps(t, []config.InteropDependency{dep})
+
+		cfg := configForArgs(t, addRequiredArgsExcept("--network", "--rollup.config", rollupCfgFile,
+			"--l2.genesis", writeValidGenesis(t), "--interop.dependencies", depsFile))
+		require.Equal(t, []config.InteropDependency{dep}, cfg.InteropDependencies)
+	})
+	t.Run("InvalidFile", func(t *testing.T) {
+		depsFile := t.TempDir() + "/deps.json"
+		require.NoError(t, os.WriteFile(depsFile, []byte("not json"), 0666))
+		verifyArgsInvalid(t, "invalid interop dependencies", addRequiredArgs("--interop.dependencies", depsFile))
+	})
+}
+
 func TestExec(t *testing.T) {
 	t.Run("DefaultEmpty", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-program/host/prestate"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var (
+	PrestateELFFlag = &cli.PathFlag{
+		Name:      "elf",
+		Usage:     "Path to a prebuilt op-program-client ELF. If not set, the client is built from the source directory.",
+		TakesFile: true,
+	}
+	PrestateSourceFlag = &cli.PathFlag{
+		Name:  "source",
+		Usage: "Path to the op-program source directory to build the client from.",
+		Value: ".",
+	}
+	PrestateELFOutFlag = &cli.PathFlag{
+		Name:      "elf-out",
+		Usage:     "Path to write the built client ELF to. Not written if empty.",
+		TakesFile: true,
+	}
+	PrestateOutFlag = &cli.PathFlag{
+		Name:      "out",
+		Usage:     "Path to write the prestate JSON to. Not written if empty.",
+		TakesFile: true,
+	}
+	PrestateGoVersionFlag = &cli.StringFlag{
+		Name:  "go-version",
+		Usage: "Go toolchain to build the client with, downloaded by the go command if not installed. The ELF must have been built with this version.",
+		Value: prestate.ClientGoVersion,
+	}
+	PrestateExpectedFlag = &cli.StringFlag{
+		Name:  "expected",
+		Usage: "Expected absolute prestate hash. Fails if the prestate does not match.",
+	}
+)
+
+func Prestate(ctx *cli.Context) error {
+	// Log to stderr so only the prestate hash is written to stdout
+	logger := oplog.NewLogger(os.Stderr, oplog.ReadCLIConfig(ctx))
+	var expected common.Hash
+	if ctx.IsSet(PrestateExpectedFlag.Name) {
+		if err := expected.UnmarshalText([]byte(ctx.String(PrestateExpectedFlag.Name))); err != nil {
+			return fmt.Errorf("invalid expected prestate hash: %w", err)
+		}
+	}
+
+	goVersion := ctx.String(PrestateGoVersionFlag.Name)
+	elfPath := ctx.Path(PrestateELFFlag.Name)
+	if elfPath == "" {
+		elfPath = ctx.Path(PrestateELFOutFlag.Name)
+		if elfPath == "" {
+			dir, err := os.MkdirTemp("", "op-program-prestate-*")
+			if err != nil {
+				return fmt.Errorf("failed to create temporary directory: %w", err)
+			}
+			defer os.RemoveAll(dir)
+			elfPath = filepath.Join(dir, "op-program-client.elf")
+		}
+		if err := prestate.BuildClient(ctx.Context, logger, ctx.Path(PrestateSourceFlag.Name), goVersion, elfPath); err != nil {
+			return err
+		}
+	}
+
+	state, err := prestate.LoadState(elfPath)
+	if err != nil {
+		return err
+	}
+	// A prestate of a build that can't be reproduced is meaningless, even if it happens to match
+	if err := prestate.CheckBuild(elfPath, goVersion); err != nil {
+		return err
+	}
+	if err := jsonutil.WriteJSON[*mipsevm.State](ctx.Path(PrestateOutFlag.Name), state, 0o644); err != nil {
+		return fmt.Errorf("failed to write prestate: %w", err)
+	}
+	hash := prestate.Hash(state)
+	logger.Info("Computed absolute prestate", "elf", elfPath, "hash", hash)
+	if _, err := fmt.Fprintln(oplog.AppOut(ctx), hash.Hex()); err != nil {
+		return fmt.Errorf("failed to write prestate hash: %w", err)
+	}
+	if ctx.IsSet(PrestateExpectedFlag.Name) {
+		if err := prestate.Verify(hash, expected); err != nil {
+			return err
+		}
+		logger.Info("Absolute prestate matches expected value")
+	}
+	return nil
+}
+
+var PrestateCommand = &cli.Command{
+	Name:  "prestate",
+	Usage: "Build the fault proof program client and compute its absolute prestate",
+	Description: "Build the op-program-client ELF like the op-program-client-mips Makefile target, load it into the cannon VM and print the absolute prestate hash. " +
+		"If an expected hash is supplied, fails unless the computed prestate matches.",
+	Action: Prestate,
+	Flags: []cli.Flag{
+		PrestateELFFlag,
+		PrestateSourceFlag,
+		PrestateELFOutFlag,
+		PrestateOutFlag,
+		PrestateGoVersionFlag,
+		PrestateExpectedFlag,
+	},
+}
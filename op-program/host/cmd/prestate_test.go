@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-program/host/prestate"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestPrestateCommand(t *testing.T) {
+	t.Run("InvalidExpected", func(t *testing.T) {
+		_, _, err := runWithArgs([]string{"prestate", "--elf", "missing.elf", "--expected", "foo"})
+		require.ErrorContains(t, err, "invalid expected prestate hash")
+	})
+
+	t.Run("MissingELF", func(t *testing.T) {
+		_, _, err := runWithArgs([]string{"prestate", "--elf", filepath.Join(t.TempDir(), "missing.elf")})
+		require.ErrorContains(t, err, "failed to open ELF file")
+	})
+
+	if testing.Short() {
+		t.Skip("skipping cross-compilation in short mode")
+	}
+	elfPath := filepath.Join(t.TempDir(), "hello.elf")
+	require.NoError(t, prestate.BuildELF(context.Background(), testlog.Logger(t, log.LevelInfo), "../../../cannon/example/hello", ".", "", "", elfPath))
+	state, err := prestate.LoadState(elfPath)
+	require.NoError(t, err)
+	expected := prestate.Hash(state)
+
+	t.Run("Matches", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "prestate.json")
+		_, _, err := runWithArgs([]string{"prestate", "--elf", elfPath, "--go-version", runtime.Version(), "--out", out, "--expected", expected.Hex()})
+		require.NoError(t, err)
+
+		written, err := jsonutil.LoadJSON[mipsevm.State](out)
+		require.NoError(t, err)
+		require.Equal(t, expected, prestate.Hash(written))
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		_, _, err := runWithArgs([]string{"prestate", "--elf", elfPath, "--go-version", runtime.Version(), "--expected", common.Hash{0xaa}.Hex()})
+		require.ErrorIs(t, err, prestate.ErrPrestateMismatch)
+	})
+
+	t.Run("OtherGoVersion", func(t *testing.T) {
+		_, _, err := runWithArgs([]string{"prestate", "--elf", elfPath, "--go-version", "go1.0", "--expected", expected.Hex()})
+		require.ErrorIs(t, err, prestate.ErrNotReproducible)
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
@@ -30,12 +31,28 @@ var (
 	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
 	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
 	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrMissingAltDAServer  = errors.New("alt-da server must be specified when fetching for an alt-da chain")
+	ErrInteropNotScheduled = errors.New("interop dependencies require the interop hardfork to be scheduled")
+	ErrMissingInteropL2    = errors.New("interop dependencies must specify an l2 rpc and head when fetching is enabled")
 )
 
+// InteropDependency is a chain that the L2 chain depends on, with the agreed output of that chain.
+type InteropDependency struct {
+	ChainID uint64 `json:"chainId"`
+	// OutputRoot is the agreed output root of the dependent chain
+	OutputRoot common.Hash `json:"outputRoot"`
+	// L2Head is the block hash of the dependent chain contained in the output referenced by OutputRoot.
+	// Only required when fetching is enabled.
+	L2Head common.Hash `json:"l2Head,omitempty"`
+	// L2URL is the JSON-RPC endpoint of the dependent chain to fetch its output from.
+	// Only required when fetching is enabled.
+	L2URL string `json:"l2Rpc,omitempty"`
+}
+
 type Config struct {
 	Rollup *rollup.Config
 	// DataDir is the directory to read/write pre-image data from/to.
-	// If not set, an in-memory key-value store is used and fetching data must be enabled
+	// If not set, a temporary directory is used and fetching data must be enabled
 	DataDir string
 
 	// L1Head is the block hash of the L1 chain head block
@@ -57,6 +74,15 @@ type Config struct {
 	L2ClaimBlockNumber uint64
 	// L2ChainConfig is the op-geth chain config for the L2 execution engine
 	L2ChainConfig *params.ChainConfig
+	// AltDAServerURL is the DA server to fetch alt-DA inputs from.
+	// Only used for chains with alt-DA enabled.
+	AltDAServerURL string
+	// PrefetchConcurrency is the maximum number of hints to fetch data for concurrently in the background.
+	// If 0, data is only fetched when requested by the client program.
+	PrefetchConcurrency uint
+	// InteropDependencies are the chains the L2 chain depends on.
+	// Only supported for chains with the interop hardfork scheduled.
+	InteropDependencies []InteropDependency
 	// ExecCmd specifies the client program to execute in a separate process.
 	// If unset, the fault proof client is run in the same process.
 	ExecCmd string
@@ -100,9 +126,34 @@ func (c *Config) Check() error {
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if c.FetchingEnabled() && c.Rollup.PlasmaEnabled() && c.AltDAServerURL == "" {
+		return ErrMissingAltDAServer
+	}
+	if len(c.InteropDependencies) > 0 && c.Rollup.InteropTime == nil {
+		return ErrInteropNotScheduled
+	}
+	if err := interop.ValidateDependencies(c.L2ChainConfig.ChainID.Uint64(), c.ClientInteropDependencies()); err != nil {
+		return err
+	}
+	if c.FetchingEnabled() {
+		for _, dep := range c.InteropDependencies {
+			if dep.L2URL == "" || dep.L2Head == (common.Hash{}) {
+				return fmt.Errorf("%w: chain %d", ErrMissingInteropL2, dep.ChainID)
+			}
+		}
+	}
 	return nil
 }
 
+// ClientInteropDependencies returns the interop dependencies as provided to the client program.
+func (c *Config) ClientInteropDependencies() []interop.Dependency {
+	deps := make([]interop.Dependency, 0, len(c.InteropDependencies))
+	for _, dep := range c.InteropDependencies {
+		deps = append(deps, interop.Dependency{ChainID: dep.ChainID, OutputRoot: dep.OutputRoot})
+	}
+	return deps
+}
+
 func (c *Config) FetchingEnabled() bool {
 	// TODO: Include Beacon URL once cancun is active on all chains we fault prove.
 	return c.L1URL != "" && c.L2URL != ""
@@ -128,7 +179,8 @@ func NewConfig(
 		L2OutputRoot:        l2OutputRoot,
 		L2Claim:             l2Claim,
 		L2ClaimBlockNumber:  l2ClaimBlockNum,
-		L1RPCKind:           sources.RPCKindStandard,
+		L1RPCKind:           sources.RPCKindAuto,
+		PrefetchConcurrency: flags.PrefetchConcurrency.Value,
 		IsCustomChainConfig: isCustomConfig,
 	}
 }
@@ -183,6 +235,13 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid genesis: %w", err)
 	}
+	var interopDeps []InteropDependency
+	if path := ctx.String(flags.InteropDependencies.Name); path != "" {
+		interopDeps, err = loadInteropDependencies(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interop dependencies: %w", err)
+		}
+	}
 	return &Config{
 		Rollup:              rollupCfg,
 		DataDir:             ctx.String(flags.DataDir.Name),
@@ -197,6 +256,9 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 		L1BeaconURL:         ctx.String(flags.L1BeaconAddr.Name),
 		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
 		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
+		AltDAServerURL:      ctx.String(flags.AltDAServerAddr.Name),
+		PrefetchConcurrency: ctx.Uint(flags.PrefetchConcurrency.Name),
+		InteropDependencies: interopDeps,
 		ExecCmd:             ctx.String(flags.Exec.Name),
 		ServerMode:          ctx.Bool(flags.Server.Name),
 		IsCustomChainConfig: isCustomConfig,
@@ -215,3 +277,15 @@ func loadChainConfigFromGenesis(path string) (*params.ChainConfig, error) {
 	}
 	return genesis.Config, nil
 }
+
+func loadInteropDependencies(path string) ([]InteropDependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read interop dependencies file: %w", err)
+	}
+	var deps []InteropDependency
+	if err := json.Unmarshal(data, &deps); err != nil {
+		return nil, fmt.Errorf("parse interop dependencies file: %w", err)
+	}
+	return deps, nil
+}
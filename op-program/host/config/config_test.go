@@ -6,7 +6,9 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
@@ -160,6 +162,90 @@ func TestRejectExecAndServerMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoExecInServerMode)
 }
 
+func TestRequireAltDAServerForAltDAChain(t *testing.T) {
+	altDARollupConfig := *validRollupConfig
+	altDARollupConfig.PlasmaConfig = &rollup.PlasmaConfig{
+		DAChallengeAddress: common.Address{0xaa},
+		DAChallengeWindow:  10,
+		DAResolveWindow:    10,
+		CommitmentType:     plasma.KeccakCommitmentString,
+	}
+
+	t.Run("Missing", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Rollup = &altDARollupConfig
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:5678"
+		require.ErrorIs(t, cfg.Check(), ErrMissingAltDAServer)
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Rollup = &altDARollupConfig
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:5678"
+		cfg.AltDAServerURL = "http://localhost:3100"
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("NotRequiredWhenNotFetching", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Rollup = &altDARollupConfig
+		require.NoError(t, cfg.Check())
+	})
+}
+
+func TestInteropDependencies(t *testing.T) {
+	interopRollupConfig := *validRollupConfig
+	interopTime := uint64(1000)
+	interopRollupConfig.InteropTime = &interopTime
+	validDep := InteropDependency{
+		ChainID:    901,
+		OutputRoot: common.Hash{0x11},
+		L2Head:     common.Hash{0x22},
+		L2URL:      "https://example.com:9012",
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Rollup = &interopRollupConfig
+		cfg.InteropDependencies = []InteropDependency{validDep}
+		require.NoError(t, cfg.Check())
+		require.Equal(t, []interop.Dependency{{ChainID: 901, OutputRoot: common.Hash{0x11}}}, cfg.ClientInteropDependencies())
+	})
+
+	t.Run("NotScheduled", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.InteropDependencies = []InteropDependency{validDep}
+		require.ErrorIs(t, cfg.Check(), ErrInteropNotScheduled)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Rollup = &interopRollupConfig
+		cfg.InteropDependencies = []InteropDependency{validDep, validDep}
+		require.ErrorIs(t, cfg.Check(), interop.ErrInvalidDependency)
+	})
+
+	t.Run("MissingL2WhenFetching", func(t *testing.T) {
+		dep := validDep
+		dep.L2URL = ""
+		cfg := validConfig()
+		cfg.Rollup = &interopRollupConfig
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:5678"
+		cfg.InteropDependencies = []InteropDependency{dep}
+		require.ErrorIs(t, cfg.Check(), ErrMissingInteropL2)
+	})
+
+	t.Run("L2NotRequiredWhenNotFetching", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Rollup = &interopRollupConfig
+		cfg.InteropDependencies = []InteropDependency{{ChainID: 901, OutputRoot: common.Hash{0x11}}}
+		require.NoError(t, cfg.Check())
+	})
+}
+
 func TestIsCustomChainConfig(t *testing.T) {
 	t.Run("nonCustom", func(t *testing.T) {
 		cfg := validConfig()
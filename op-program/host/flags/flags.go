@@ -32,7 +32,7 @@ var (
 	}
 	DataDir = &cli.StringFlag{
 		Name:    "datadir",
-		Usage:   "Directory to use for preimage data storage. Default uses in-memory storage",
+		Usage:   "Directory to use for preimage data storage. Default uses a temporary directory that is removed on exit",
 		EnvVars: prefixEnvVars("DATADIR"),
 	}
 	L2NodeAddr = &cli.StringFlag{
@@ -91,10 +91,27 @@ var (
 			openum.EnumString(sources.RPCProviderKinds),
 		EnvVars: prefixEnvVars("L1_RPC_KIND"),
 		Value: func() *sources.RPCProviderKind {
-			out := sources.RPCKindStandard
+			out := sources.RPCKindAuto
 			return &out
 		}(),
 	}
+	AltDAServerAddr = &cli.StringFlag{
+		Name:    "altda.da-server",
+		Aliases: []string{"plasma.da-server"},
+		Usage:   "HTTP address of the DA server to fetch alt-DA inputs from. Required for chains using alt-DA when fetching is enabled.",
+		EnvVars: prefixEnvVars("ALTDA_DA_SERVER"),
+	}
+	PrefetchConcurrency = &cli.UintFlag{
+		Name:    "prefetch.concurrency",
+		Usage:   "Maximum number of hinted pre-images to fetch concurrently in the background. 0 only fetches pre-images when they are requested.",
+		EnvVars: prefixEnvVars("PREFETCH_CONCURRENCY"),
+		Value:   4,
+	}
+	InteropDependencies = &cli.StringFlag{
+		Name:    "interop.dependencies",
+		Usage:   "Path to a JSON file listing the chains the L2 chain depends on, with the chain ID, agreed output root, L2 head and L2 RPC of each. Only supported for chains with the interop hardfork scheduled.",
+		EnvVars: prefixEnvVars("INTEROP_DEPENDENCIES"),
+	}
 	Exec = &cli.StringFlag{
 		Name:    "exec",
 		Usage:   "Run the specified client program as a separate process detached from the host. Default is to run the client program in the host process.",
@@ -128,6 +145,9 @@ var programFlags = []cli.Flag{
 	L1BeaconAddr,
 	L1TrustRPC,
 	L1RPCProviderKind,
+	AltDAServerAddr,
+	PrefetchConcurrency,
+	InteropDependencies,
 	Exec,
 	Server,
 }
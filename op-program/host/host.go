@@ -10,6 +10,7 @@ import (
 	"os/exec"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	cl "github.com/ethereum-optimism/optimism/op-program/client"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
@@ -123,9 +124,18 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel oppio.FileChannel, hintChannel oppio.FileChannel) error {
 	var serverDone chan error
 	var hinterDone chan error
+	var prefetch *prefetcher.Prefetcher
+	var tempDataDir string
+	stats := &serverStats{}
+	statsCtx, statsCancel := context.WithCancel(ctx)
 	defer func() {
+		statsCancel()
 		preimageChannel.Close()
 		hintChannel.Close()
+		if prefetch != nil {
+			// Stop background fetches so pending pre-image requests are not left waiting on them
+			prefetch.Close()
+		}
 		if serverDone != nil {
 			// Wait for pre-image server to complete
 			<-serverDone
@@ -134,12 +144,25 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 			// Wait for hinter to complete
 			<-hinterDone
 		}
+		stats.Log(logger, "Pre-image server stats")
+		if tempDataDir != "" {
+			if err := os.RemoveAll(tempDataDir); err != nil {
+				logger.Warn("Failed to remove temporary datadir", "datadir", tempDataDir, "err", err)
+			}
+		}
 	}()
 	logger.Info("Starting preimage server")
 	var kv kvstore.KV
 	if cfg.DataDir == "" {
-		logger.Info("Using in-memory storage")
-		kv = kvstore.NewMemKV()
+		// Use disk storage even when the data doesn't need to be kept, as large derivations may require more pre-image
+		// data than available memory.
+		dataDir, err := os.MkdirTemp("", "op-program-preimages-*")
+		if err != nil {
+			return fmt.Errorf("creating temporary datadir: %w", err)
+		}
+		tempDataDir = dataDir
+		logger.Info("Using temporary disk storage", "datadir", dataDir)
+		kv = kvstore.NewDiskKV(dataDir)
 	} else {
 		logger.Info("Creating disk storage", "datadir", cfg.DataDir)
 		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
@@ -153,7 +176,8 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		hinter      preimage.HintHandler
 	)
 	if cfg.FetchingEnabled() {
-		prefetch, err := makePrefetcher(ctx, logger, kv, cfg)
+		var err error
+		prefetch, err = makePrefetcher(ctx, logger, kv, cfg)
 		if err != nil {
 			return fmt.Errorf("failed to create prefetcher: %w", err)
 		}
@@ -172,8 +196,9 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 	splitter := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, getPreimage)
 	preimageGetter := preimage.WithVerification(splitter.Get)
 
-	serverDone = launchOracleServer(logger, preimageChannel, preimageGetter)
-	hinterDone = routeHints(logger, hintChannel, hinter)
+	go stats.logPeriodically(statsCtx, logger, statsLogInterval)
+	serverDone = launchOracleServer(logger, preimageChannel, stats.Getter(preimageGetter))
+	hinterDone = routeHints(logger, hintChannel, stats.Hinter(hinter))
 	select {
 	case err := <-serverDone:
 		return err
@@ -208,7 +233,26 @@ func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *
 		return nil, fmt.Errorf("failed to create L2 client: %w", err)
 	}
 	l2DebugCl := &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
-	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2DebugCl, kv), nil
+	var altDACl prefetcher.AltDASource
+	if cfg.AltDAServerURL != "" {
+		logger.Info("Using alt-DA server", "url", cfg.AltDAServerURL)
+		altDACl = plasma.NewDAClient(cfg.AltDAServerURL, true, false)
+	}
+	remoteL2Cls := make(map[uint64]prefetcher.RemoteL2Source, len(cfg.InteropDependencies))
+	for _, dep := range cfg.InteropDependencies {
+		logger.Info("Connecting to dependent L2 node", "chainId", dep.ChainID, "l2", dep.L2URL)
+		remoteRPC, err := client.NewRPC(ctx, logger, dep.L2URL, client.WithDialBackoff(10))
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup L2 RPC of dependent chain %d: %w", dep.ChainID, err)
+		}
+		// Only outputs are fetched from dependent chains, which does not depend on their rollup config.
+		remoteCl, err := NewL2Client(remoteRPC, logger.New("chainId", dep.ChainID), nil, &L2ClientConfig{L2ClientConfig: l2ClCfg, L2Head: dep.L2Head})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create L2 client of dependent chain %d: %w", dep.ChainID, err)
+		}
+		remoteL2Cls[dep.ChainID] = remoteCl
+	}
+	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2DebugCl, altDACl, remoteL2Cls, kv, cfg.PrefetchConcurrency), nil
 }
 
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
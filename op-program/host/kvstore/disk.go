@@ -7,7 +7,6 @@ import (
 	"io"
 	"os"
 	"path"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -16,11 +15,11 @@ import (
 const diskPermission = 0666
 
 // DiskKV is a disk-backed key-value store, every key-value pair is a hex-encoded .txt file, with the value as content.
-// DiskKV is safe for concurrent use with a single DiskKV instance.
-// DiskKV is safe for concurrent use between different DiskKV instances of the same disk directory as long as the
-// file system supports atomic renames.
+// DiskKV is safe for concurrent use, both with a single DiskKV instance and between different DiskKV instances of the
+// same disk directory, as long as the file system supports atomic renames.
+// Values are written to a temporary file and renamed into place, so no locking is required and concurrent
+// fetchers can write pre-images in parallel.
 type DiskKV struct {
-	sync.RWMutex
 	path string
 }
 
@@ -35,8 +34,6 @@ func (d *DiskKV) pathKey(k common.Hash) string {
 }
 
 func (d *DiskKV) Put(k common.Hash, v []byte) error {
-	d.Lock()
-	defer d.Unlock()
 	f, err := openTempFile(d.path, k.String()+".txt.*")
 	if err != nil {
 		return fmt.Errorf("failed to open temp file for pre-image %s: %w", k, err)
@@ -73,8 +70,6 @@ func openTempFile(dir string, nameTemplate string) (*os.File, error) {
 }
 
 func (d *DiskKV) Get(k common.Hash) ([]byte, error) {
-	d.RLock()
-	defer d.RUnlock()
 	f, err := os.OpenFile(d.pathKey(k), os.O_RDONLY, diskPermission)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -1,6 +1,7 @@
 package kvstore
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -45,6 +46,29 @@ func kvTest(t *testing.T, kv KV) {
 		require.Equal(t, []byte{4, 2}, dat, "pre-image must match")
 	})
 
+	t.Run("concurrent writes", func(t *testing.T) {
+		t.Parallel()
+		var wg sync.WaitGroup
+		for i := 0; i < 32; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				// All goroutines write the same pre-image and a pre-image of their own
+				require.NoError(t, kv.Put(common.Hash{0xee}, []byte{1, 2, 3}))
+				require.NoError(t, kv.Put(common.Hash{0xef, byte(i)}, []byte{byte(i)}))
+			}(i)
+		}
+		wg.Wait()
+		dat, err := kv.Get(common.Hash{0xee})
+		require.NoError(t, err, "pre-image must exist now")
+		require.Equal(t, []byte{1, 2, 3}, dat, "pre-image must match")
+		for i := 0; i < 32; i++ {
+			dat, err := kv.Get(common.Hash{0xef, byte(i)})
+			require.NoError(t, err, "pre-image must exist now")
+			require.Equal(t, []byte{byte(i)}, dat, "pre-image must match")
+		}
+	})
+
 	t.Run("allowing multiple writes for same pre-image", func(t *testing.T) {
 		t.Parallel()
 		require.NoError(t, kv.Put(common.Hash{0xdd}, []byte{4, 2}))
@@ -25,6 +25,7 @@ var (
 	l2ChainIDKey          = client.L2ChainIDLocalIndex.PreimageKey()
 	l2ChainConfigKey      = client.L2ChainConfigLocalIndex.PreimageKey()
 	rollupKey             = client.RollupConfigLocalIndex.PreimageKey()
+	interopDepsKey        = client.InteropDependenciesLocalIndex.PreimageKey()
 )
 
 func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
@@ -39,9 +40,12 @@ func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
 		return binary.BigEndian.AppendUint64(nil, s.config.L2ClaimBlockNumber), nil
 	case l2ChainIDKey:
 		// The CustomChainIDIndicator informs the client to rely on the L2ChainConfigKey to
-		// read the chain config. Otherwise, it'll attempt to read a non-existent hardcoded chain config
+		// read the chain config. Otherwise, it'll attempt to read a non-existent hardcoded chain config.
+		// The InteropChainIDIndicator additionally informs the client to read the interop dependencies.
 		var chainID uint64
-		if s.config.IsCustomChainConfig {
+		if len(s.config.InteropDependencies) > 0 {
+			chainID = client.InteropChainIDIndicator
+		} else if s.config.IsCustomChainConfig {
 			chainID = client.CustomChainIDIndicator
 		} else {
 			chainID = s.config.L2ChainConfig.ChainID.Uint64()
@@ -51,6 +55,11 @@ func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
 		return json.Marshal(s.config.L2ChainConfig)
 	case rollupKey:
 		return json.Marshal(s.config.Rollup)
+	case interopDepsKey:
+		if len(s.config.InteropDependencies) == 0 {
+			return nil, ErrNotFound
+		}
+		return json.Marshal(s.config.ClientInteropDependencies())
 	default:
 		return nil, ErrNotFound
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
@@ -21,6 +22,9 @@ func TestLocalPreimageSource(t *testing.T) {
 		L2Claim:            common.HexToHash("0x3333"),
 		L2ClaimBlockNumber: 1234,
 		L2ChainConfig:      params.GoerliChainConfig,
+		InteropDependencies: []config.InteropDependency{
+			{ChainID: 901, OutputRoot: common.HexToHash("0x4444"), L2Head: common.HexToHash("0x5555"), L2URL: "http://localhost:1234"},
+		},
 	}
 	source := NewLocalPreimageSource(cfg)
 	tests := []struct {
@@ -32,9 +36,10 @@ func TestLocalPreimageSource(t *testing.T) {
 		{"L2OutputRoot", l2OutputRootKey, cfg.L2OutputRoot.Bytes()},
 		{"L2Claim", l2ClaimKey, cfg.L2Claim.Bytes()},
 		{"L2ClaimBlockNumber", l2ClaimBlockNumberKey, binary.BigEndian.AppendUint64(nil, cfg.L2ClaimBlockNumber)},
-		{"L2ChainID", l2ChainIDKey, binary.BigEndian.AppendUint64(nil, cfg.L2ChainConfig.ChainID.Uint64())},
+		{"L2ChainID", l2ChainIDKey, binary.BigEndian.AppendUint64(nil, client.InteropChainIDIndicator)},
 		{"Rollup", rollupKey, asJson(t, cfg.Rollup)},
 		{"ChainConfig", l2ChainConfigKey, asJson(t, cfg.L2ChainConfig)},
+		{"InteropDependencies", interopDepsKey, []byte(`[{"chainId":901,"outputRoot":"0x0000000000000000000000000000000000000000000000000000000000004444"}]`)},
 		{"Unknown", preimage.LocalIndexKey(1000).PreimageKey(), nil},
 	}
 	for _, test := range tests {
@@ -50,6 +55,19 @@ func TestLocalPreimageSource(t *testing.T) {
 	}
 }
 
+func TestLocalPreimageSourceWithoutInterop(t *testing.T) {
+	cfg := &config.Config{
+		Rollup:        chaincfg.Sepolia,
+		L2ChainConfig: params.GoerliChainConfig,
+	}
+	source := NewLocalPreimageSource(cfg)
+	chainID, err := source.Get(l2ChainIDKey)
+	require.NoError(t, err)
+	require.Equal(t, binary.BigEndian.AppendUint64(nil, cfg.L2ChainConfig.ChainID.Uint64()), chainID)
+	_, err = source.Get(interopDepsKey)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
 func asJson(t *testing.T, v any) []byte {
 	d, err := json.Marshal(v)
 	require.NoError(t, err)
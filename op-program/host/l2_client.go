@@ -2,6 +2,7 @@ package host
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -36,16 +37,18 @@ func NewL2Client(client client.RPC, log log.Logger, metrics caching.Metrics, con
 }
 
 func (s *L2Client) OutputByRoot(ctx context.Context, l2OutputRoot common.Hash) (eth.Output, error) {
-	output, err := s.OutputV0AtBlock(ctx, s.l2Head)
+	head, err := s.InfoByHash(ctx, s.l2Head)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get L2 head %s: %w", s.l2Head, err)
 	}
-	actualOutputRoot := eth.OutputRoot(output)
-	if actualOutputRoot != eth.Bytes32(l2OutputRoot) {
+	output, err := s.VerifyOutputRoot(ctx, eth.ToBlockID(head), eth.Bytes32(l2OutputRoot))
+	if errors.Is(err, sources.ErrOutputRootMismatch) {
 		// For fault proofs, we only reference outputs at the l2 head at boot time
 		// The caller shouldn't be requesting outputs at any other block
 		// If they are, there is no chance of recovery and we should panic to avoid retrying forever
-		panic(fmt.Errorf("output root %v from specified L2 block %v does not match requested output root %v", actualOutputRoot, s.l2Head, l2OutputRoot))
+		panic(fmt.Errorf("output of specified L2 block %v does not match requested output root %v: %w", s.l2Head, l2OutputRoot, err))
+	} else if err != nil {
+		return nil, err
 	}
 	return output, nil
 }
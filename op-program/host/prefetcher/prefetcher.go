@@ -7,8 +7,12 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
 
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/altda"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
@@ -21,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
@@ -52,39 +57,102 @@ type L2Source interface {
 	OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error)
 }
 
+type AltDASource interface {
+	GetInput(ctx context.Context, comm plasma.CommitmentData) ([]byte, error)
+}
+
+// RemoteL2Source provides the outputs of a chain the L2 chain depends on.
+type RemoteL2Source interface {
+	OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error)
+}
+
+// maxConcurrentPuts is the maximum number of pre-images written to the kv store in parallel by a single fetch.
+const maxConcurrentPuts = 16
+
 type Prefetcher struct {
 	logger        log.Logger
 	l1Fetcher     L1Source
 	l1BlobFetcher L1BlobSource
 	l2Fetcher     L2Source
-	lastHint      string
-	kvStore       kvstore.KV
+	altDAFetcher  AltDASource
+	// remoteL2Fetchers are the sources of the chains the L2 chain depends on, by chain ID.
+	remoteL2Fetchers map[uint64]RemoteL2Source
+	kvStore          kvstore.KV
+
+	lastHintLock sync.Mutex
+	lastHint     string
+
+	// Background fetching of hinted data, so fetching overlaps with the execution of the client program.
+	// Disabled when fetchSlots is nil.
+	fetchCtx     context.Context
+	fetchCancel  context.CancelFunc
+	fetchSlots   chan struct{}
+	fetchesLock  sync.Mutex
+	fetches      map[string]*backgroundFetch
+	fetchWorkers sync.WaitGroup
+}
+
+type backgroundFetch struct {
+	done chan struct{}
+	err  error
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
+// NewPrefetcher creates a new Prefetcher. altDAFetcher may be nil if the chain does not use alt-DA.
+// remoteL2Fetchers provides the sources of any interop dependencies by chain ID, and may be nil if there are none.
+// Up to concurrency hints are fetched in the background as soon as they are received. If concurrency is 0, data is
+// only fetched when a pre-image for the last hint is requested but not yet available.
+func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, altDAFetcher AltDASource, remoteL2Fetchers map[uint64]RemoteL2Source, kvStore kvstore.KV, concurrency uint) *Prefetcher {
+	var retryingAltDAFetcher AltDASource
+	if altDAFetcher != nil {
+		retryingAltDAFetcher = NewRetryingAltDASource(logger, altDAFetcher)
+	}
+	retryingRemoteL2Fetchers := make(map[uint64]RemoteL2Source, len(remoteL2Fetchers))
+	for chainID, fetcher := range remoteL2Fetchers {
+		retryingRemoteL2Fetchers[chainID] = NewRetryingRemoteL2Source(logger.New("chainId", chainID), fetcher)
+	}
+	fetchCtx, fetchCancel := context.WithCancel(context.Background())
+	var fetchSlots chan struct{}
+	if concurrency > 0 {
+		fetchSlots = make(chan struct{}, concurrency)
+	}
 	return &Prefetcher{
-		logger:        logger,
-		l1Fetcher:     NewRetryingL1Source(logger, l1Fetcher),
-		l1BlobFetcher: NewRetryingL1BlobSource(logger, l1BlobFetcher),
-		l2Fetcher:     NewRetryingL2Source(logger, l2Fetcher),
-		kvStore:       kvStore,
+		logger:           logger,
+		l1Fetcher:        NewRetryingL1Source(logger, l1Fetcher),
+		l1BlobFetcher:    NewRetryingL1BlobSource(logger, l1BlobFetcher),
+		l2Fetcher:        NewRetryingL2Source(logger, l2Fetcher),
+		altDAFetcher:     retryingAltDAFetcher,
+		remoteL2Fetchers: retryingRemoteL2Fetchers,
+		kvStore:          kvStore,
+		fetchCtx:         fetchCtx,
+		fetchCancel:      fetchCancel,
+		fetchSlots:       fetchSlots,
+		fetches:          make(map[string]*backgroundFetch),
 	}
 }
 
 func (p *Prefetcher) Hint(hint string) error {
 	p.logger.Trace("Received hint", "hint", hint)
+	p.lastHintLock.Lock()
 	p.lastHint = hint
+	p.lastHintLock.Unlock()
+	if p.fetchSlots != nil {
+		p.fetchInBackground(hint)
+	}
 	return nil
 }
 
 func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte, error) {
 	p.logger.Trace("Pre-image requested", "key", key)
 	pre, err := p.kvStore.Get(key)
+	hint := p.getLastHint()
+	if errors.Is(err, kvstore.ErrNotFound) && hint != "" && p.waitForBackgroundFetch(ctx, hint) {
+		pre, err = p.kvStore.Get(key)
+	}
 	// Use a loop to keep retrying the prefetch as long as the key is not found
 	// This handles the case where the prefetch downloads a preimage, but it is then deleted unexpectedly
 	// before we get to read it.
-	for errors.Is(err, kvstore.ErrNotFound) && p.lastHint != "" {
-		hint := p.lastHint
+	// It also surfaces the error if a background fetch for the hint failed.
+	for errors.Is(err, kvstore.ErrNotFound) && hint != "" {
 		if err := p.prefetch(ctx, hint); err != nil {
 			return nil, fmt.Errorf("prefetch failed: %w", err)
 		}
@@ -96,6 +164,67 @@ func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte,
 	return pre, err
 }
 
+// Close stops any background fetches and waits for them to exit.
+func (p *Prefetcher) Close() {
+	p.fetchCancel()
+	p.fetchWorkers.Wait()
+}
+
+func (p *Prefetcher) getLastHint() string {
+	p.lastHintLock.Lock()
+	defer p.lastHintLock.Unlock()
+	return p.lastHint
+}
+
+// fetchInBackground starts fetching the data for hint, unless it is already being fetched.
+func (p *Prefetcher) fetchInBackground(hint string) {
+	p.fetchesLock.Lock()
+	defer p.fetchesLock.Unlock()
+	if _, ok := p.fetches[hint]; ok {
+		return
+	}
+	f := &backgroundFetch{done: make(chan struct{})}
+	p.fetches[hint] = f
+	p.fetchWorkers.Add(1)
+	go func() {
+		defer p.fetchWorkers.Done()
+		defer func() {
+			p.fetchesLock.Lock()
+			delete(p.fetches, hint)
+			p.fetchesLock.Unlock()
+			close(f.done)
+		}()
+		select {
+		case p.fetchSlots <- struct{}{}:
+		case <-p.fetchCtx.Done():
+			f.err = p.fetchCtx.Err()
+			return
+		}
+		defer func() { <-p.fetchSlots }()
+		f.err = p.prefetch(p.fetchCtx, hint)
+		if f.err != nil {
+			p.logger.Warn("Background prefetch failed", "hint", hint, "err", f.err)
+		}
+	}()
+}
+
+// waitForBackgroundFetch waits for a background fetch of hint to complete.
+// Returns true if a background fetch was in progress and completed successfully.
+func (p *Prefetcher) waitForBackgroundFetch(ctx context.Context, hint string) bool {
+	p.fetchesLock.Lock()
+	f, ok := p.fetches[hint]
+	p.fetchesLock.Unlock()
+	if !ok {
+		return false
+	}
+	select {
+	case <-f.done:
+		return f.err == nil
+	case <-ctx.Done():
+		return false
+	}
+}
+
 func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 	hintType, hintBytes, err := parseHint(hint)
 	if err != nil {
@@ -165,19 +294,17 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 
 		// Put all of the blob's field elements into the kv store. There should be 4096. The preimage oracle key for
 		// each field element is the keccak256 hash of `abi.encodePacked(sidecar.KZGCommitment, uint256(i))`
-		blobKey := make([]byte, 80)
-		copy(blobKey[:48], sidecar.KZGCommitment[:])
+		preimages := make([]keyedPreimage, 0, 2*params.BlobTxFieldElementsPerBlob)
 		for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+			blobKey := make([]byte, 80)
+			copy(blobKey[:48], sidecar.KZGCommitment[:])
 			binary.BigEndian.PutUint64(blobKey[72:], uint64(i))
 			blobKeyHash := crypto.Keccak256Hash(blobKey)
-			if err := p.kvStore.Put(preimage.Keccak256Key(blobKeyHash).PreimageKey(), blobKey); err != nil {
-				return err
-			}
-			if err = p.kvStore.Put(preimage.BlobKey(blobKeyHash).PreimageKey(), sidecar.Blob[i<<5:(i+1)<<5]); err != nil {
-				return err
-			}
-		}
-		return nil
+			preimages = append(preimages,
+				keyedPreimage{preimage.Keccak256Key(blobKeyHash).PreimageKey(), blobKey},
+				keyedPreimage{preimage.BlobKey(blobKeyHash).PreimageKey(), sidecar.Blob[i<<5 : (i+1)<<5]})
+		}
+		return p.putAll(preimages)
 	case l1.HintL1Precompile:
 		if len(hintBytes) < 20 {
 			return fmt.Errorf("invalid precompile hint: %x", hint)
@@ -253,6 +380,49 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 			return fmt.Errorf("failed to fetch L2 output root %s: %w", hash, err)
 		}
 		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), output.Marshal())
+	case interop.HintL2RemoteOutput:
+		remoteHint, ok := interop.ParseRemoteOutputHint(hintBytes)
+		if !ok {
+			return fmt.Errorf("invalid L2 remote output hint: %x", hint)
+		}
+		fetcher, ok := p.remoteL2Fetchers[remoteHint.ChainID]
+		if !ok {
+			return fmt.Errorf("output requested from unknown dependent chain %d", remoteHint.ChainID)
+		}
+		output, err := fetcher.OutputByRoot(ctx, remoteHint.OutputRoot)
+		if err != nil {
+			return fmt.Errorf("failed to fetch output root %s of chain %d: %w", remoteHint.OutputRoot, remoteHint.ChainID, err)
+		}
+		return p.kvStore.Put(preimage.Keccak256Key(remoteHint.OutputRoot).PreimageKey(), output.Marshal())
+	case altda.HintAltDAInput:
+		if p.altDAFetcher == nil {
+			return errors.New("alt-DA input requested but no alt-DA server is configured")
+		}
+		comm, err := plasma.DecodeCommitmentData(hintBytes)
+		if err != nil {
+			return fmt.Errorf("invalid alt-DA input hint: %x: %w", hint, err)
+		}
+		// Only keccak256 commitments can be verified by the pre-image oracle.
+		keccakComm, ok := comm.(plasma.Keccak256Commitment)
+		if !ok {
+			return fmt.Errorf("unsupported alt-DA commitment type: %v", comm.CommitmentType())
+		}
+		availabilityKey := altda.NewAvailabilityKey(keccakComm).PreimageKey()
+		input, err := p.altDAFetcher.GetInput(ctx, keccakComm)
+		if errors.Is(err, plasma.ErrNotFound) {
+			// the derivation resolves missing inputs through the DA challenge contract
+			p.logger.Warn("Alt-DA input not found", "comm", keccakComm)
+			return p.kvStore.Put(availabilityKey, []byte{altda.InputNotAvailable})
+		} else if err != nil {
+			return fmt.Errorf("failed to fetch alt-DA input %s: %w", keccakComm, err)
+		}
+		if err := keccakComm.Verify(input); err != nil {
+			return fmt.Errorf("invalid alt-DA input for %s: %w", keccakComm, err)
+		}
+		if err := p.kvStore.Put(preimage.Keccak256Key(common.BytesToHash(keccakComm)).PreimageKey(), input); err != nil {
+			return err
+		}
+		return p.kvStore.Put(availabilityKey, []byte{altda.InputAvailable})
 	}
 	return fmt.Errorf("unknown hint type: %v", hintType)
 }
@@ -275,15 +445,34 @@ func (p *Prefetcher) storeTransactions(txs types.Transactions) error {
 
 func (p *Prefetcher) storeTrieNodes(values []hexutil.Bytes) error {
 	_, nodes := mpt.WriteTrie(values)
+	preimages := make([]keyedPreimage, 0, len(nodes))
 	for _, node := range nodes {
-		key := preimage.Keccak256Key(crypto.Keccak256Hash(node)).PreimageKey()
-		if err := p.kvStore.Put(key, node); err != nil {
-			return fmt.Errorf("failed to store node: %w", err)
-		}
+		preimages = append(preimages, keyedPreimage{preimage.Keccak256Key(crypto.Keccak256Hash(node)).PreimageKey(), node})
+	}
+	if err := p.putAll(preimages); err != nil {
+		return fmt.Errorf("failed to store node: %w", err)
 	}
 	return nil
 }
 
+type keyedPreimage struct {
+	key   common.Hash
+	value []byte
+}
+
+// putAll stores the given pre-images, writing up to maxConcurrentPuts of them in parallel.
+func (p *Prefetcher) putAll(preimages []keyedPreimage) error {
+	var g errgroup.Group
+	g.SetLimit(maxConcurrentPuts)
+	for _, pre := range preimages {
+		pre := pre
+		g.Go(func() error {
+			return p.kvStore.Put(pre.key, pre.value)
+		})
+	}
+	return g.Wait()
+}
+
 // parseHint parses a hint string in wire protocol. Returns the hint type, requested hash and error (if any).
 func parseHint(hint string) (string, []byte, error) {
 	hintType, bytesStr, found := strings.Cut(hint, " ")
@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -15,7 +16,10 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/require"
 
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/altda"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
@@ -410,6 +414,160 @@ func TestFetchL2Code(t *testing.T) {
 	})
 }
 
+func TestFetchL2RemoteOutput(t *testing.T) {
+	const chainID = uint64(901)
+	output := &eth.OutputV0{
+		StateRoot:                eth.Bytes32{0x11},
+		MessagePasserStorageRoot: eth.Bytes32{0x22},
+		BlockHash:                common.Hash{0x33},
+	}
+	root := common.Hash(eth.OutputRoot(output))
+	key := preimage.Keccak256Key(root).PreimageKey()
+
+	t.Run("AlreadyKnown", func(t *testing.T) {
+		prefetcher, _, kv := createRemoteL2Prefetcher(t, chainID)
+		require.NoError(t, kv.Put(key, output.Marshal()))
+
+		oracle := interop.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result := oracle.OutputByRoot(chainID, root)
+		require.Equal(t, output, result)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		prefetcher, remoteCl, _ := createRemoteL2Prefetcher(t, chainID)
+		remoteCl.ExpectOutputByRoot(root, output, nil)
+		defer remoteCl.AssertExpectations(t)
+
+		oracle := interop.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result := oracle.OutputByRoot(chainID, root)
+		require.Equal(t, output, result)
+	})
+
+	t.Run("UnknownChain", func(t *testing.T) {
+		prefetcher, _, _ := createRemoteL2Prefetcher(t, chainID)
+
+		require.NoError(t, prefetcher.Hint(interop.RemoteOutputHint{ChainID: 902, OutputRoot: root}.Hint()))
+		result, err := prefetcher.GetPreimage(context.Background(), key)
+		require.ErrorContains(t, err, "unknown dependent chain 902")
+		require.Nil(t, result)
+	})
+}
+
+func TestFetchAltDAInput(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	input := testutils.RandomData(rng, 100)
+	comm := plasma.NewKeccak256Commitment(input)
+	key := preimage.Keccak256Key(common.BytesToHash(comm)).PreimageKey()
+
+	t.Run("AlreadyKnown", func(t *testing.T) {
+		prefetcher, _, _, _, kv := createPrefetcher(t)
+		require.NoError(t, kv.Put(key, input))
+		require.NoError(t, kv.Put(altda.NewAvailabilityKey(comm).PreimageKey(), []byte{altda.InputAvailable}))
+
+		oracle := altda.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result, err := oracle.GetInput(comm)
+		require.NoError(t, err)
+		require.EqualValues(t, input, result)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		prefetcher, altDACl, _ := createAltDAPrefetcher(t)
+		altDACl.ExpectGetInput(comm, input, nil)
+		defer altDACl.AssertExpectations(t)
+
+		oracle := altda.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result, err := oracle.GetInput(comm)
+		require.NoError(t, err)
+		require.EqualValues(t, input, result)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		prefetcher, altDACl, kv := createAltDAPrefetcher(t)
+		altDACl.ExpectGetInput(comm, nil, plasma.ErrNotFound)
+		defer altDACl.AssertExpectations(t)
+
+		oracle := altda.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		_, err := oracle.GetInput(comm)
+		require.ErrorIs(t, err, plasma.ErrNotFound)
+		_, err = kv.Get(key)
+		require.ErrorIs(t, err, kvstore.ErrNotFound)
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		prefetcher, altDACl, _ := createAltDAPrefetcher(t)
+		altDACl.ExpectGetInput(comm, []byte{1, 2, 3}, nil)
+		defer altDACl.AssertExpectations(t)
+
+		require.NoError(t, prefetcher.Hint(altda.InputHint(comm.Encode()).Hint()))
+		result, err := prefetcher.GetPreimage(context.Background(), key)
+		require.ErrorIs(t, err, plasma.ErrCommitmentMismatch)
+		require.Nil(t, result)
+	})
+
+	t.Run("GenericCommitment", func(t *testing.T) {
+		prefetcher, _, _ := createAltDAPrefetcher(t)
+		genericComm := plasma.NewGenericCommitment([]byte{0xaa, 0xbb})
+
+		require.NoError(t, prefetcher.Hint(altda.InputHint(genericComm.Encode()).Hint()))
+		result, err := prefetcher.GetPreimage(context.Background(), key)
+		require.ErrorContains(t, err, "unsupported alt-DA commitment type")
+		require.Nil(t, result)
+	})
+
+	t.Run("NoAltDAServer", func(t *testing.T) {
+		prefetcher, _, _, _, _ := createPrefetcher(t)
+
+		require.NoError(t, prefetcher.Hint(altda.InputHint(comm.Encode()).Hint()))
+		result, err := prefetcher.GetPreimage(context.Background(), key)
+		require.ErrorContains(t, err, "no alt-DA server is configured")
+		require.Nil(t, result)
+	})
+}
+
+func TestBackgroundFetching(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	node := testutils.RandomData(rng, 30)
+	hash := crypto.Keccak256Hash(node)
+	key := preimage.Keccak256Key(hash).PreimageKey()
+
+	t.Run("FetchOnHint", func(t *testing.T) {
+		prefetcher, l2Cl, kv := createBackgroundPrefetcher(t)
+		// Only expect a single request, GetPreimage must not fetch the data again
+		l2Cl.ExpectNodeByHash(hash, node, nil)
+		defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+		require.NoError(t, prefetcher.Hint(l2.StateNodeHint(hash).Hint()))
+		// Data is fetched without waiting for the pre-image to be requested
+		require.Eventually(t, func() bool {
+			_, err := kv.Get(key)
+			return err == nil
+		}, 10*time.Second, 10*time.Millisecond)
+
+		result, err := prefetcher.GetPreimage(context.Background(), key)
+		require.NoError(t, err)
+		require.EqualValues(t, node, result)
+	})
+
+	t.Run("WaitForFetch", func(t *testing.T) {
+		prefetcher, l2Cl, _ := createBackgroundPrefetcher(t)
+		l2Cl.ExpectNodeByHash(hash, node, nil)
+		defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result := oracle.NodeByHash(hash)
+		require.EqualValues(t, node, result)
+	})
+
+	t.Run("ReportFailedFetch", func(t *testing.T) {
+		prefetcher, _, _ := createBackgroundPrefetcher(t)
+
+		require.NoError(t, prefetcher.Hint(l2.HintL2StateNode+" 0x1234"))
+		pre, err := prefetcher.GetPreimage(context.Background(), key)
+		require.ErrorContains(t, err, "invalid L2 state node hint")
+		require.Nil(t, pre)
+	})
+}
+
 func TestBadHints(t *testing.T) {
 	prefetcher, _, _, _, kv := createPrefetcher(t)
 	hash := common.Hash{0xad}
@@ -467,7 +625,7 @@ func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	_, l1Source, l1BlobSource, l2Cl, kv := createPrefetcher(t)
 	putsToIgnore := 2
 	kv = &unreliableKvStore{KV: kv, putsToIgnore: putsToIgnore}
-	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelInfo), l1Source, l1BlobSource, l2Cl, kv)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelInfo), l1Source, l1BlobSource, l2Cl, nil, nil, kv, 0)
 
 	// Expect one call for each ignored put, plus one more request for when the put succeeds
 	for i := 0; i < putsToIgnore+1; i++ {
@@ -519,10 +677,39 @@ func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *test
 		MockDebugClient: new(testutils.MockDebugClient),
 	}
 
-	prefetcher := NewPrefetcher(logger, l1Source, l1BlobSource, l2Source, kv)
+	prefetcher := NewPrefetcher(logger, l1Source, l1BlobSource, l2Source, nil, nil, kv, 0)
 	return prefetcher, l1Source, l1BlobSource, l2Source, kv
 }
 
+func createBackgroundPrefetcher(t *testing.T) (*Prefetcher, *l2Client, kvstore.KV) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	kv := kvstore.NewMemKV()
+	l2Source := &l2Client{
+		MockL2Client:    new(testutils.MockL2Client),
+		MockDebugClient: new(testutils.MockDebugClient),
+	}
+	prefetcher := NewPrefetcher(logger, new(testutils.MockL1Source), new(testutils.MockBlobsFetcher), l2Source, nil, nil, kv, 4)
+	t.Cleanup(prefetcher.Close)
+	return prefetcher, l2Source, kv
+}
+
+func createAltDAPrefetcher(t *testing.T) (*Prefetcher, *MockAltDASource, kvstore.KV) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	kv := kvstore.NewMemKV()
+	altDASource := new(MockAltDASource)
+	prefetcher := NewPrefetcher(logger, new(testutils.MockL1Source), new(testutils.MockBlobsFetcher), new(l2Client), altDASource, nil, kv, 0)
+	return prefetcher, altDASource, kv
+}
+
+func createRemoteL2Prefetcher(t *testing.T, chainID uint64) (*Prefetcher, *MockRemoteL2Source, kvstore.KV) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	kv := kvstore.NewMemKV()
+	remoteSource := new(MockRemoteL2Source)
+	remoteSources := map[uint64]RemoteL2Source{chainID: remoteSource}
+	prefetcher := NewPrefetcher(logger, new(testutils.MockL1Source), new(testutils.MockBlobsFetcher), new(l2Client), nil, remoteSources, kv, 0)
+	return prefetcher, remoteSource, kv
+}
+
 func storeBlock(t *testing.T, kv kvstore.KV, block *types.Block, receipts types.Receipts) {
 	// Pre-store receipts
 	opaqueRcpts, err := eth.EncodeReceipts(receipts)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"math"
 
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum/go-ethereum/common"
@@ -151,3 +152,57 @@ func NewRetryingL2Source(logger log.Logger, source L2Source) *RetryingL2Source {
 }
 
 var _ L2Source = (*RetryingL2Source)(nil)
+
+type RetryingAltDASource struct {
+	logger   log.Logger
+	source   AltDASource
+	strategy retry.Strategy
+}
+
+func NewRetryingAltDASource(logger log.Logger, source AltDASource) *RetryingAltDASource {
+	return &RetryingAltDASource{
+		logger:   logger,
+		source:   source,
+		strategy: retry.Exponential(),
+	}
+}
+
+// GetInput retries to retrieve the input, unless the DA server reports that the input is not found.
+func (s *RetryingAltDASource) GetInput(ctx context.Context, comm plasma.CommitmentData) ([]byte, error) {
+	policy := retry.Policy{Strategy: s.strategy, MaxAttempts: maxAttempts, Classify: retry.FatalOn(plasma.ErrNotFound)}
+	return retry.DoWithPolicy(ctx, policy, func() ([]byte, error) {
+		input, err := s.source.GetInput(ctx, comm)
+		if err != nil {
+			s.logger.Warn("Failed to retrieve alt-DA input", "comm", comm, "err", err)
+		}
+		return input, err
+	})
+}
+
+var _ AltDASource = (*RetryingAltDASource)(nil)
+
+type RetryingRemoteL2Source struct {
+	logger   log.Logger
+	source   RemoteL2Source
+	strategy retry.Strategy
+}
+
+func NewRetryingRemoteL2Source(logger log.Logger, source RemoteL2Source) *RetryingRemoteL2Source {
+	return &RetryingRemoteL2Source{
+		logger:   logger,
+		source:   source,
+		strategy: retry.Exponential(),
+	}
+}
+
+func (s *RetryingRemoteL2Source) OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error) {
+	return retry.Do(ctx, maxAttempts, s.strategy, func() (eth.Output, error) {
+		o, err := s.source.OutputByRoot(ctx, root)
+		if err != nil {
+			s.logger.Warn("Failed to fetch remote l2 output", "root", root, "err", err)
+		}
+		return o, err
+	})
+}
+
+var _ RemoteL2Source = (*RetryingRemoteL2Source)(nil)
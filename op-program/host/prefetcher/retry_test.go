@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -375,3 +376,120 @@ func (m *MockL2Source) ExpectOutputByRoot(root common.Hash, output eth.Output, e
 }
 
 var _ L2Source = (*MockL2Source)(nil)
+
+func TestRetryingAltDASource(t *testing.T) {
+	ctx := context.Background()
+	input := []byte{1, 2, 3, 4, 5}
+	comm := plasma.NewKeccak256Commitment(input)
+
+	t.Run("GetInput Success", func(t *testing.T) {
+		source, mock := createAltDASource(t)
+		defer mock.AssertExpectations(t)
+		mock.ExpectGetInput(comm, input, nil)
+
+		actual, err := source.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, actual)
+	})
+
+	t.Run("GetInput Error", func(t *testing.T) {
+		source, mock := createAltDASource(t)
+		defer mock.AssertExpectations(t)
+		expectedErr := errors.New("boom")
+		mock.ExpectGetInput(comm, nil, expectedErr)
+		mock.ExpectGetInput(comm, input, nil)
+
+		actual, err := source.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, actual)
+	})
+
+	t.Run("GetInput NotFound", func(t *testing.T) {
+		source, mock := createAltDASource(t)
+		defer mock.AssertExpectations(t)
+		mock.ExpectGetInput(comm, nil, plasma.ErrNotFound)
+
+		_, err := source.GetInput(ctx, comm)
+		require.ErrorIs(t, err, plasma.ErrNotFound)
+	})
+}
+
+func createAltDASource(t *testing.T) (*RetryingAltDASource, *MockAltDASource) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	mock := &MockAltDASource{}
+	source := NewRetryingAltDASource(logger, mock)
+	// Avoid sleeping in tests by using a fixed retry strategy with no delay
+	source.strategy = retry.Fixed(0)
+	return source, mock
+}
+
+type MockAltDASource struct {
+	mock.Mock
+}
+
+func (m *MockAltDASource) GetInput(ctx context.Context, comm plasma.CommitmentData) ([]byte, error) {
+	out := m.Mock.MethodCalled("GetInput", comm.Encode())
+	return out[0].([]byte), *out[1].(*error)
+}
+
+func (m *MockAltDASource) ExpectGetInput(comm plasma.CommitmentData, input []byte, err error) {
+	m.Mock.On("GetInput", comm.Encode()).Once().Return(input, &err)
+}
+
+var _ AltDASource = (*MockAltDASource)(nil)
+
+func TestRetryingRemoteL2Source(t *testing.T) {
+	ctx := context.Background()
+	output := &eth.OutputV0{
+		StateRoot:                eth.Bytes32{0x11},
+		MessagePasserStorageRoot: eth.Bytes32{0x22},
+		BlockHash:                common.Hash{0x33},
+	}
+	root := common.Hash(eth.OutputRoot(output))
+
+	t.Run("OutputByRoot Success", func(t *testing.T) {
+		source, mock := createRemoteL2Source(t)
+		defer mock.AssertExpectations(t)
+		mock.ExpectOutputByRoot(root, output, nil)
+
+		actual, err := source.OutputByRoot(ctx, root)
+		require.NoError(t, err)
+		require.Equal(t, output, actual)
+	})
+
+	t.Run("OutputByRoot Error", func(t *testing.T) {
+		source, mock := createRemoteL2Source(t)
+		defer mock.AssertExpectations(t)
+		expectedErr := errors.New("boom")
+		mock.ExpectOutputByRoot(root, (*eth.OutputV0)(nil), expectedErr)
+		mock.ExpectOutputByRoot(root, output, nil)
+
+		actual, err := source.OutputByRoot(ctx, root)
+		require.NoError(t, err)
+		require.Equal(t, output, actual)
+	})
+}
+
+func createRemoteL2Source(t *testing.T) (*RetryingRemoteL2Source, *MockRemoteL2Source) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	mock := &MockRemoteL2Source{}
+	source := NewRetryingRemoteL2Source(logger, mock)
+	// Avoid sleeping in tests by using a fixed retry strategy with no delay
+	source.strategy = retry.Fixed(0)
+	return source, mock
+}
+
+type MockRemoteL2Source struct {
+	mock.Mock
+}
+
+func (m *MockRemoteL2Source) OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error) {
+	out := m.Mock.MethodCalled("OutputByRoot", root)
+	return out[0].(eth.Output), *out[1].(*error)
+}
+
+func (m *MockRemoteL2Source) ExpectOutputByRoot(root common.Hash, output eth.Output, err error) {
+	m.Mock.On("OutputByRoot", root).Once().Return(output, &err)
+}
+
+var _ RemoteL2Source = (*MockRemoteL2Source)(nil)
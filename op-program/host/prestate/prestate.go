@@ -0,0 +1,129 @@
+package prestate
+
+import (
+	"context"
+	"debug/buildinfo"
+	"debug/elf"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// ClientPackage is the main package of the fault proof program client, relative to the op-program directory.
+// It is built like the op-program-client-mips Makefile target does, since the package path is part of the symbols in
+// the binary.
+const ClientPackage = "./client/cmd"
+
+// ClientGoVersion is the go toolchain the client is built with, as pinned in Dockerfile.repro.
+// The compiled code differs between go versions, so the prestate is only reproducible with this toolchain.
+const ClientGoVersion = "go1.21.3"
+
+// ClientLdflags pin the version of the client so the build is reproducible.
+// Must match the flags used by the op-program-client-mips Makefile target.
+const ClientLdflags = "-X github.com/ethereum-optimism/optimism/op-program/version.Version=v0.0.0 " +
+	"-X github.com/ethereum-optimism/optimism/op-program/version.Meta="
+
+var (
+	ErrPrestateMismatch = errors.New("absolute prestate mismatch")
+	ErrNotReproducible  = errors.New("ELF was not built reproducibly")
+)
+
+// BuildELF compiles the main package pkg, relative to dir, into a 32-bit big-endian MIPS ELF that can be loaded by
+// cannon. The build flags match the op-program-client-mips Makefile target, so the output is the ELF that the
+// published prestates are built from. The build is trimmed of source paths so the output only depends on the source
+// and the go toolchain, which is downloaded by the go command if goVersion is not the local version.
+// The local toolchain is used if goVersion is empty.
+func BuildELF(ctx context.Context, logger log.Logger, dir string, pkg string, ldflags string, goVersion string, out string) error {
+	toolchain := "GOTOOLCHAIN=local"
+	if goVersion != "" {
+		toolchain = "GOTOOLCHAIN=" + goVersion
+	}
+	env := append(os.Environ(), toolchain, "GOOS=linux", "GOARCH=mips", "GOMIPS=softfloat", "CGO_ENABLED=0")
+	versionCmd := exec.CommandContext(ctx, "go", "env", "GOVERSION")
+	versionCmd.Dir = dir
+	versionCmd.Env = env
+	actualVersion, err := versionCmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to determine go version: %w", err)
+	}
+	logger.Info("Building ELF", "dir", dir, "package", pkg, "go", strings.TrimSpace(string(actualVersion)), "out", out)
+	cmd := exec.CommandContext(ctx, "go", "build", "-trimpath", "-buildvcs=false", "-ldflags", ldflags, "-o", out, pkg)
+	cmd.Dir = dir
+	cmd.Env = env
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to build %v: %w\n%s", pkg, err, output)
+	}
+	return nil
+}
+
+// BuildClient compiles the fault proof program client from the op-program source directory programDir into an ELF,
+// with the go toolchain goVersion.
+func BuildClient(ctx context.Context, logger log.Logger, programDir string, goVersion string, out string) error {
+	return BuildELF(ctx, logger, programDir, ClientPackage, ClientLdflags, goVersion, out)
+}
+
+// CheckBuild returns ErrNotReproducible unless the ELF at path was built with -trimpath by the go toolchain goVersion,
+// as recorded in the build info of the binary. Other builds can't reproduce the published prestates.
+func CheckBuild(path string, goVersion string) error {
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read build info of %q: %w", path, err)
+	}
+	if info.GoVersion != goVersion {
+		return fmt.Errorf("%w: built with %v but expected %v", ErrNotReproducible, info.GoVersion, goVersion)
+	}
+	trimmed := slices.ContainsFunc(info.Settings, func(setting debug.BuildSetting) bool {
+		return setting.Key == "-trimpath" && setting.Value == "true"
+	})
+	if !trimmed {
+		return fmt.Errorf("%w: built without -trimpath", ErrNotReproducible)
+	}
+	return nil
+}
+
+// LoadState loads the ELF at path into the cannon VM state it starts executing from.
+// The same patches are applied as by the cannon load-elf command.
+func LoadState(path string) (*mipsevm.State, error) {
+	elfProgram, err := elf.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ELF file %q: %w", path, err)
+	}
+	defer elfProgram.Close()
+	if elfProgram.Machine != elf.EM_MIPS {
+		return nil, fmt.Errorf("ELF is not big-endian MIPS R3000, but got %q", elfProgram.Machine.String())
+	}
+	state, err := mipsevm.LoadELF(elfProgram)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ELF data into VM state: %w", err)
+	}
+	if err := mipsevm.PatchGo(elfProgram, state); err != nil {
+		return nil, fmt.Errorf("failed to apply go patch: %w", err)
+	}
+	if err := mipsevm.PatchStack(state); err != nil {
+		return nil, fmt.Errorf("failed to apply stack patch: %w", err)
+	}
+	return state, nil
+}
+
+// Hash returns the absolute prestate hash of the VM state.
+func Hash(state *mipsevm.State) common.Hash {
+	_, hash := state.EncodeWitness()
+	return hash
+}
+
+// Verify checks that the absolute prestate hash matches the expected hash.
+func Verify(actual common.Hash, expected common.Hash) error {
+	if actual != expected {
+		return fmt.Errorf("%w: expected %v but got %v", ErrPrestateMismatch, expected, actual)
+	}
+	return nil
+}
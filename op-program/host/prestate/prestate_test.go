@@ -0,0 +1,69 @@
+package prestate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestBuildELFIsReproducible(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping cross-compilation in short mode")
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+	src := "../../../cannon/example/hello"
+
+	// Build the same source twice to check the output is deterministic.
+	elf1 := filepath.Join(t.TempDir(), "hello1.elf")
+	elf2 := filepath.Join(t.TempDir(), "hello2.elf")
+	require.NoError(t, BuildELF(context.Background(), logger, src, ".", "", runtime.Version(), elf1))
+	require.NoError(t, BuildELF(context.Background(), logger, src, ".", "", runtime.Version(), elf2))
+	require.NoError(t, CheckBuild(elf1, runtime.Version()))
+	require.ErrorIs(t, CheckBuild(elf1, "go1.0"), ErrNotReproducible)
+
+	data1, err := os.ReadFile(elf1)
+	require.NoError(t, err)
+	data2, err := os.ReadFile(elf2)
+	require.NoError(t, err)
+	require.Equal(t, data1, data2, "builds must be identical")
+
+	state1, err := LoadState(elf1)
+	require.NoError(t, err)
+	state2, err := LoadState(elf2)
+	require.NoError(t, err)
+	require.NotEqual(t, common.Hash{}, Hash(state1))
+	require.NoError(t, Verify(Hash(state1), Hash(state2)))
+}
+
+func TestLoadStateRejectsNonMIPS(t *testing.T) {
+	// The test binary itself is an ELF, but not for MIPS
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	_, err = LoadState(exe)
+	require.ErrorContains(t, err, "ELF is not big-endian MIPS R3000")
+}
+
+func TestCheckBuildRequiresTrimpath(t *testing.T) {
+	// The test binary is not built with -trimpath
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	require.ErrorContains(t, CheckBuild(exe, runtime.Version()), "built without -trimpath")
+}
+
+func TestLoadStateMissingFile(t *testing.T) {
+	_, err := LoadState(filepath.Join(t.TempDir(), "missing.elf"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestVerify(t *testing.T) {
+	require.NoError(t, Verify(common.Hash{0xaa}, common.Hash{0xaa}))
+	require.ErrorIs(t, Verify(common.Hash{0xaa}, common.Hash{0xbb}), ErrPrestateMismatch)
+}
@@ -0,0 +1,65 @@
+package host
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// statsLogInterval is how often the pre-image server statistics are logged while the server is running.
+const statsLogInterval = 30 * time.Second
+
+// serverStats counts the hints and pre-images served to the client.
+// The counts are reported in a machine-readable form so long-running executions can be monitored.
+type serverStats struct {
+	hints         atomic.Uint64
+	preimages     atomic.Uint64
+	preimageBytes atomic.Uint64
+}
+
+// Hinter wraps hinter to count the hints it processes.
+func (s *serverStats) Hinter(hinter preimage.HintHandler) preimage.HintHandler {
+	return func(hint string) error {
+		s.hints.Add(1)
+		return hinter(hint)
+	}
+}
+
+// Getter wraps getter to count the pre-images it serves.
+func (s *serverStats) Getter(getter preimage.PreimageGetter) preimage.PreimageGetter {
+	return func(key [32]byte) ([]byte, error) {
+		data, err := getter(key)
+		if err == nil {
+			s.preimages.Add(1)
+			s.preimageBytes.Add(uint64(len(data)))
+		}
+		return data, err
+	}
+}
+
+// Log writes the current statistics as a single structured log line.
+// The message and keys are stable so the output can be parsed by external tooling.
+func (s *serverStats) Log(logger log.Logger, msg string) {
+	logger.Info(msg,
+		"hints", s.hints.Load(),
+		"preimages", s.preimages.Load(),
+		"preimageBytes", s.preimageBytes.Load())
+}
+
+// logPeriodically logs the statistics every interval until ctx is done.
+func (s *serverStats) logPeriodically(ctx context.Context, logger log.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Log(logger, "Pre-image server progress")
+		}
+	}
+}
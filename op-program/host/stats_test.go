@@ -0,0 +1,84 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestServerStats(t *testing.T) {
+	t.Run("CountHints", func(t *testing.T) {
+		stats := &serverStats{}
+		errHint := errors.New("boom")
+		var received []string
+		hinter := stats.Hinter(func(hint string) error {
+			received = append(received, hint)
+			if hint == "bad" {
+				return errHint
+			}
+			return nil
+		})
+		require.NoError(t, hinter("a"))
+		require.ErrorIs(t, hinter("bad"), errHint)
+		require.Equal(t, []string{"a", "bad"}, received)
+		require.EqualValues(t, 2, stats.hints.Load())
+	})
+
+	t.Run("CountPreimages", func(t *testing.T) {
+		stats := &serverStats{}
+		errMissing := errors.New("missing")
+		getter := stats.Getter(func(key [32]byte) ([]byte, error) {
+			if key[0] == 0xff {
+				return nil, errMissing
+			}
+			return make([]byte, key[0]), nil
+		})
+		data, err := getter([32]byte{3})
+		require.NoError(t, err)
+		require.Len(t, data, 3)
+		_, err = getter([32]byte{5})
+		require.NoError(t, err)
+		_, err = getter([32]byte{0xff})
+		require.ErrorIs(t, err, errMissing)
+		require.EqualValues(t, 2, stats.preimages.Load(), "failed requests are not counted")
+		require.EqualValues(t, 8, stats.preimageBytes.Load())
+	})
+
+	t.Run("Log", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		stats := &serverStats{}
+		stats.hints.Store(4)
+		stats.preimages.Store(3)
+		stats.preimageBytes.Store(100)
+		stats.Log(logger, "Pre-image server stats")
+
+		rec := logs.FindLog(testlog.NewMessageFilter("Pre-image server stats"))
+		require.NotNil(t, rec)
+		require.EqualValues(t, 4, rec.AttrValue("hints"))
+		require.EqualValues(t, 3, rec.AttrValue("preimages"))
+		require.EqualValues(t, 100, rec.AttrValue("preimageBytes"))
+	})
+
+	t.Run("LogPeriodically", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		stats := &serverStats{}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			stats.logPeriodically(ctx, logger, time.Millisecond)
+		}()
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		<-done
+		// The capturing logger is not thread safe so only inspect the logs after logging has stopped.
+		require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Pre-image server progress")))
+	})
+}
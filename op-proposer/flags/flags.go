@@ -7,10 +7,12 @@ import (
 	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -50,6 +52,14 @@ var (
 		Usage:   "Allow the proposer to submit proposals for L2 blocks derived from non-finalized L1 blocks.",
 		EnvVars: prefixEnvVars("ALLOW_NON_FINALIZED"),
 	}
+	ProposalSafetyFlag = &cli.GenericFlag{
+		Name: "proposal-safety",
+		Usage: "The L2 head to propose outputs up to. Proposals of non-finalized outputs are checked for reorgs before " +
+			"sending them, and after they got finalized. Defaults to finalized, or safe if --allow-non-finalized is set. " +
+			"Valid options: " + openum.EnumString(ProposalSafeties),
+		Value:   new(ProposalSafety),
+		EnvVars: prefixEnvVars("PROPOSAL_SAFETY"),
+	}
 	DisputeGameFactoryAddressFlag = &cli.StringFlag{
 		Name:    "game-factory-address",
 		Usage:   "Address of the DisputeGameFactory contract",
@@ -66,6 +76,90 @@ var (
 		Value:   0,
 		EnvVars: prefixEnvVars("GAME_TYPE"),
 	}
+	DisputeGameMaxBondFlag = &cli.Float64Flag{
+		Name:    "game-max-bond",
+		Usage:   "Maximum initial bond in ETH to pay for creating a dispute game. Proposals are refused if the DisputeGameFactory requires a larger bond. 0 for no limit.",
+		Value:   0,
+		EnvVars: prefixEnvVars("GAME_MAX_BOND"),
+	}
+	MaxGasSpendPerDayFlag = &cli.Float64Flag{
+		Name:    "max-gas-spend-per-day",
+		Usage:   "Maximum ETH to spend on gas for proposals within 24 hours. Proposals pause when reached, until resumed via the admin RPC. Tracked since the proposer started, unless persisted with --spend-file. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_GAS_SPEND_PER_DAY"),
+	}
+	MaxGasSpendPerWeekFlag = &cli.Float64Flag{
+		Name:    "max-gas-spend-per-week",
+		Usage:   "Maximum ETH to spend on gas for proposals within 7 days. Proposals pause when reached, until resumed via the admin RPC. Tracked since the proposer started, unless persisted with --spend-file. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_GAS_SPEND_PER_WEEK"),
+	}
+	MaxBondSpendPerDayFlag = &cli.Float64Flag{
+		Name:    "max-bond-spend-per-day",
+		Usage:   "Maximum ETH to spend on dispute game bonds within 24 hours. Proposals pause when reached, until resumed via the admin RPC. Tracked since the proposer started, unless persisted with --spend-file. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_BOND_SPEND_PER_DAY"),
+	}
+	MaxBondSpendPerWeekFlag = &cli.Float64Flag{
+		Name:    "max-bond-spend-per-week",
+		Usage:   "Maximum ETH to spend on dispute game bonds within 7 days. Proposals pause when reached, until resumed via the admin RPC. Tracked since the proposer started, unless persisted with --spend-file. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_BOND_SPEND_PER_WEEK"),
+	}
+	SpendFileFlag = &cli.StringFlag{
+		Name:    "spend-file",
+		Usage:   "Path to a file to persist the spends tracked for the spend limits in, so they are enforced across restarts. The name of an additional chain is appended for its proposer. Not persisted if empty.",
+		EnvVars: prefixEnvVars("SPEND_FILE"),
+	}
+	VerifyRollupRpcFlag = &cli.StringFlag{
+		Name:    "verify-rollup-rpc",
+		Usage:   "HTTP provider URL of an independent rollup node, backed by its own execution engine, to cross-verify output roots with before proposing them",
+		EnvVars: prefixEnvVars("VERIFY_ROLLUP_RPC"),
+	}
+	VerifyL2EthRpcFlag = &cli.StringFlag{
+		Name:    "verify-l2-eth-rpc",
+		Usage:   "HTTP provider URL of an L2 archive node to recompute and cross-verify output roots with before proposing them",
+		EnvVars: prefixEnvVars("VERIFY_L2_ETH_RPC"),
+	}
+	MaxProposalsPerL1BlockFlag = &cli.Uint64Flag{
+		Name: "max-proposals-per-l1-block",
+		Usage: "Maximum number of L2OutputOracle proposals to send for inclusion in the same L1 block when the proposer " +
+			"fell behind by multiple submission intervals. 1 disables catch-up mode.",
+		Value:   1,
+		EnvVars: prefixEnvVars("MAX_PROPOSALS_PER_L1_BLOCK"),
+	}
+	ProposalTxDeadlineFlag = &cli.DurationFlag{
+		Name: "proposal-tx-deadline",
+		Usage: "Time after which an unconfirmed proposal tx is cancelled and the output is proposed again. " +
+			"Must be shorter than the proposal timeout of 10 minutes. 0 disables the deadline.",
+		Value:   8 * time.Minute,
+		EnvVars: prefixEnvVars("PROPOSAL_TX_DEADLINE"),
+	}
+	RemediateDivergingProposalsFlag = &cli.BoolFlag{
+		Name: "remediate-diverging-proposals",
+		Usage: "Automatically remediate own proposals whose output root diverges from the finalized output of the rollup node: " +
+			"delete them from the L2OutputOracle, which requires the proposer to be its challenger, or flag their dispute games.",
+		EnvVars: prefixEnvVars("REMEDIATE_DIVERGING_PROPOSALS"),
+	}
+	SignerHealthCheckFlag = &cli.BoolFlag{
+		Name: "signer-health-check",
+		Usage: "Check at startup that the signer, e.g. a remote signer backed by a KMS key, can sign transactions " +
+			"for the proposer address. No transaction is sent.",
+		Value:   true,
+		EnvVars: prefixEnvVars("SIGNER_HEALTH_CHECK"),
+	}
+	ChainsConfigFlag = &cli.StringFlag{
+		Name: "chains-config",
+		Usage: "Path to a JSON file with a list of additional chains to propose for, each with its own rollup RPC, " +
+			"contracts, signer and intervals. The chains share the L1 RPC, tx manager settings, metrics and RPC server. " +
+			"Signer keys are not part of the file, but read from the environment variables it names, or held by a remote signer or KMS.",
+		EnvVars:   prefixEnvVars("CHAINS_CONFIG"),
+		TakesFile: true,
+	}
+	RPCJWTSecretFlag = &cli.StringFlag{
+		Name: "rpc.jwt-secret",
+		Usage: "Path to a JWT secret file (32 bytes, hex-encoded) to authenticate requests to the RPC server, " +
+			"including the admin RPC, with. Unauthenticated if not set. Additional accepted secrets may be listed " +
+			"one per line, and the file is reloaded when it changes.",
+		EnvVars:   prefixEnvVars("RPC_JWT_SECRET"),
+		TakesFile: true,
+	}
 	ActiveSequencerCheckDurationFlag = &cli.DurationFlag{
 		Name:    "active-sequencer-check-duration",
 		Usage:   "The duration between checks to determine the active sequencer endpoint.",
@@ -79,6 +173,35 @@ var (
 		Value:   false,
 		EnvVars: prefixEnvVars("WAIT_NODE_SYNC"),
 	}
+	BondBalanceMinGamesFlag = &cli.Uint64Flag{
+		Name: "bond-balance-min-games",
+		Usage: "Number of dispute game initial bonds that the proposer balance should cover. A warning is logged when " +
+			"the balance covers fewer, and the balance is topped up from the bond treasury, if configured. 0 disables the bond balance monitoring.",
+		Value:   3,
+		EnvVars: prefixEnvVars("BOND_BALANCE_MIN_GAMES"),
+	}
+	BondTreasurySignerEndpointFlag = &cli.StringFlag{
+		Name: "bond-treasury.signer-endpoint",
+		Usage: "Remote signer endpoint of the treasury to top up the proposer balance from, when it covers too few initial bonds. " +
+			"The signer policy must allow the treasury to send ETH to the proposer address. Top-ups are disabled if not set.",
+		EnvVars: prefixEnvVars("BOND_TREASURY_SIGNER_ENDPOINT"),
+	}
+	BondTreasuryAddressFlag = &cli.StringFlag{
+		Name:    "bond-treasury.address",
+		Usage:   "Address of the treasury that the remote signer signs top-ups for",
+		EnvVars: prefixEnvVars("BOND_TREASURY_ADDRESS"),
+	}
+	BondTopUpGamesFlag = &cli.Uint64Flag{
+		Name:    "bond-treasury.top-up-games",
+		Usage:   "Number of dispute game initial bonds that top-ups from the treasury fund the proposer balance up to",
+		Value:   10,
+		EnvVars: prefixEnvVars("BOND_TREASURY_TOP_UP_GAMES"),
+	}
+	BondMaxTopUpPerDayFlag = &cli.Float64Flag{
+		Name:    "bond-treasury.max-top-up-per-day",
+		Usage:   "Maximum ETH to top up the proposer balance with from the treasury within 24 hours. Tracked since the proposer started. Required if the bond treasury is set.",
+		EnvVars: prefixEnvVars("BOND_TREASURY_MAX_TOP_UP_PER_DAY"),
+	}
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
 )
@@ -92,12 +215,32 @@ var optionalFlags = []cli.Flag{
 	L2OOAddressFlag,
 	PollIntervalFlag,
 	AllowNonFinalizedFlag,
+	ProposalSafetyFlag,
 	L2OutputHDPathFlag,
 	DisputeGameFactoryAddressFlag,
 	ProposalIntervalFlag,
 	DisputeGameTypeFlag,
+	DisputeGameMaxBondFlag,
+	MaxGasSpendPerDayFlag,
+	MaxGasSpendPerWeekFlag,
+	MaxBondSpendPerDayFlag,
+	MaxBondSpendPerWeekFlag,
+	SpendFileFlag,
+	VerifyRollupRpcFlag,
+	VerifyL2EthRpcFlag,
+	MaxProposalsPerL1BlockFlag,
+	ProposalTxDeadlineFlag,
+	RemediateDivergingProposalsFlag,
+	SignerHealthCheckFlag,
+	ChainsConfigFlag,
+	RPCJWTSecretFlag,
 	ActiveSequencerCheckDurationFlag,
 	WaitNodeSyncFlag,
+	BondBalanceMinGamesFlag,
+	BondTreasurySignerEndpointFlag,
+	BondTreasuryAddressFlag,
+	BondTopUpGamesFlag,
+	BondMaxTopUpPerDayFlag,
 }
 
 func init() {
@@ -105,6 +248,7 @@ func init() {
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, optracing.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
@@ -0,0 +1,49 @@
+package flags
+
+import "fmt"
+
+// ProposalSafety is the L2 head that the proposer proposes outputs up to.
+type ProposalSafety string
+
+const (
+	// UnsafeSafety proposes outputs up to the unsafe L2 head. Proposals may
+	// become invalid on L2 reorgs.
+	UnsafeSafety ProposalSafety = "unsafe"
+	// SafeSafety proposes outputs up to the safe L2 head. Proposals may become
+	// invalid on L1 reorgs.
+	SafeSafety ProposalSafety = "safe"
+	// FinalizedSafety proposes outputs up to the finalized L2 head.
+	FinalizedSafety ProposalSafety = "finalized"
+)
+
+var ProposalSafeties = []ProposalSafety{
+	UnsafeSafety,
+	SafeSafety,
+	FinalizedSafety,
+}
+
+func (kind ProposalSafety) String() string {
+	return string(kind)
+}
+
+func (kind *ProposalSafety) Set(value string) error {
+	if !ValidProposalSafety(ProposalSafety(value)) {
+		return fmt.Errorf("unknown proposal safety: %q", value)
+	}
+	*kind = ProposalSafety(value)
+	return nil
+}
+
+func (kind *ProposalSafety) Clone() any {
+	cpy := *kind
+	return &cpy
+}
+
+func ValidProposalSafety(value ProposalSafety) bool {
+	for _, k := range ProposalSafeties {
+		if k == value {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,9 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -34,6 +37,17 @@ type Metricer interface {
 	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
 
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+	RecordDisputeGameCreated(gameType uint32, bond *big.Int)
+	RecordOutputVerification(result string)
+	RecordProposalReorged()
+	RecordBudgetExceeded(exceeded bool)
+	RecordBondableGames(games uint64)
+	RecordBondTopUp(amount *big.Int)
+
+	RecordProposalStage(stage string, latency time.Duration)
+	RecordProposalFailure(stage string)
+	RecordDisputeGameResolved(status string)
+	RecordProposalRemediated(action string)
 }
 
 type Metrics struct {
@@ -47,17 +61,41 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	gamesCreated prometheus.CounterVec
+	bondsPaid    prometheus.CounterVec
+
+	outputVerifications  prometheus.CounterVec
+	outputRootMismatched prometheus.Gauge
+
+	proposalsReorged prometheus.Counter
+
+	budgetExceeded prometheus.Gauge
+
+	bondableGames prometheus.Gauge
+	bondTopUps    prometheus.Counter
+
+	proposalStageLatency prometheus.HistogramVec
+	proposalFailures     prometheus.CounterVec
+	gamesResolved        prometheus.CounterVec
+	proposalsRemediated  prometheus.CounterVec
 }
 
 var _ Metricer = (*Metrics)(nil)
 
 func NewMetrics(procName string) *Metrics {
+	return NewMetricsWithRegistry(procName, opmetrics.NewRegistry())
+}
+
+// NewMetricsWithRegistry creates the metrics of the proposer process procName
+// in an existing registry. This is used to serve the metrics of all chains of
+// a multi-chain proposer from one registry, namespaced by chain.
+func NewMetricsWithRegistry(procName string, registry *prometheus.Registry) *Metrics {
 	if procName == "" {
 		procName = "default"
 	}
 	ns := Namespace + "_" + procName
 
-	registry := opmetrics.NewRegistry()
 	factory := opmetrics.With(registry)
 
 	return &Metrics{
@@ -81,6 +119,81 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+		gamesCreated: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "dispute_games_created_total",
+			Help:      "Number of dispute games created via the DisputeGameFactory, by game type",
+		}, []string{
+			"game_type",
+		}),
+		bondsPaid: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "dispute_game_bonds_paid_eth_total",
+			Help:      "Total initial bonds in ETH paid for creating dispute games, by game type",
+		}, []string{
+			"game_type",
+		}),
+		outputVerifications: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_verifications_total",
+			Help:      "Number of output root cross-verifications before proposing, by result",
+		}, []string{
+			"result",
+		}),
+		outputRootMismatched: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "output_root_mismatch",
+			Help:      "1 if the last cross-verified output root mismatched the verification source, 0 otherwise. Proposing is halted while mismatching.",
+		}),
+		proposalsReorged: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "proposals_reorged_total",
+			Help:      "Number of proposals of non-finalized outputs that got reorged out before finalization, and are thus invalid",
+		}),
+		budgetExceeded: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "budget_exceeded",
+			Help:      "1 if a gas or bond spend limit is reached and proposals are paused, 0 otherwise",
+		}),
+		bondableGames: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "bondable_games",
+			Help:      "Number of dispute game initial bonds that the proposer balance covers",
+		}),
+		bondTopUps: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "bond_top_ups_eth_total",
+			Help:      "Total ETH that the bond treasury topped up the proposer balance with",
+		}),
+		proposalStageLatency: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "proposal_stage_latency_seconds",
+			Help:      "Latency of proposals to reach a lifecycle stage from the previous one, by stage",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 12),
+		}, []string{
+			"stage",
+		}),
+		proposalFailures: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "proposal_failures_total",
+			Help:      "Number of proposals that failed to reach a lifecycle stage, by stage",
+		}, []string{
+			"stage",
+		}),
+		gamesResolved: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "dispute_games_resolved_total",
+			Help:      "Number of dispute games created by the proposer that got resolved, by status",
+		}, []string{
+			"status",
+		}),
+		proposalsRemediated: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "proposals_remediated_total",
+			Help:      "Number of own proposals diverging from the rollup node that got remediated, by action",
+		}, []string{
+			"action",
+		}),
 	}
 }
 
@@ -106,6 +219,18 @@ func (m *Metrics) RecordUp() {
 
 const (
 	BlockProposed = "proposed"
+
+	VerificationMatch    = "match"
+	VerificationMismatch = "mismatch"
+	VerificationError    = "error"
+
+	StageSubmission   = "submission"
+	StageConfirmation = "confirmation"
+	StageResolution   = "resolution"
+
+	RemediationDeleted = "deleted"
+	RemediationFlagged = "flagged"
+	RemediationFailed  = "failed"
 )
 
 // RecordL2BlocksProposed should be called when new L2 block is proposed
@@ -113,6 +238,77 @@ func (m *Metrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(BlockProposed, l2ref)
 }
 
+// RecordDisputeGameCreated should be called when a dispute game got created,
+// with the initial bond in wei that got paid for it.
+func (m *Metrics) RecordDisputeGameCreated(gameType uint32, bond *big.Int) {
+	label := strconv.FormatUint(uint64(gameType), 10)
+	m.gamesCreated.WithLabelValues(label).Inc()
+	m.bondsPaid.WithLabelValues(label).Add(eth.WeiToEther(bond))
+}
+
+// RecordOutputVerification records the result of an output root
+// cross-verification, one of the Verification* constants.
+func (m *Metrics) RecordOutputVerification(result string) {
+	m.outputVerifications.WithLabelValues(result).Inc()
+	switch result {
+	case VerificationMismatch:
+		m.outputRootMismatched.Set(1)
+	case VerificationMatch:
+		m.outputRootMismatched.Set(0)
+	}
+}
+
+// RecordProposalReorged should be called when a proposed non-finalized output
+// got reorged out before finalization.
+func (m *Metrics) RecordProposalReorged() {
+	m.proposalsReorged.Inc()
+}
+
+// RecordBudgetExceeded records whether a spend limit is reached.
+func (m *Metrics) RecordBudgetExceeded(exceeded bool) {
+	if exceeded {
+		m.budgetExceeded.Set(1)
+	} else {
+		m.budgetExceeded.Set(0)
+	}
+}
+
+// RecordBondableGames records the number of dispute game initial bonds that
+// the proposer balance covers.
+func (m *Metrics) RecordBondableGames(games uint64) {
+	m.bondableGames.Set(float64(games))
+}
+
+// RecordBondTopUp records a top-up of the proposer balance by the bond
+// treasury, with the amount in wei.
+func (m *Metrics) RecordBondTopUp(amount *big.Int) {
+	m.bondTopUps.Add(eth.WeiToEther(amount))
+}
+
+// RecordProposalStage records the latency of a proposal to reach the lifecycle
+// stage, one of the Stage* constants, from the previous stage.
+func (m *Metrics) RecordProposalStage(stage string, latency time.Duration) {
+	m.proposalStageLatency.WithLabelValues(stage).Observe(latency.Seconds())
+}
+
+// RecordProposalFailure records a proposal that failed to reach the lifecycle
+// stage, one of the Stage* constants.
+func (m *Metrics) RecordProposalFailure(stage string) {
+	m.proposalFailures.WithLabelValues(stage).Inc()
+}
+
+// RecordDisputeGameResolved records the resolution of a dispute game created
+// by the proposer.
+func (m *Metrics) RecordDisputeGameResolved(status string) {
+	m.gamesResolved.WithLabelValues(status).Inc()
+}
+
+// RecordProposalRemediated records the remediation of a diverging proposal,
+// with action one of the Remediation* constants.
+func (m *Metrics) RecordProposalRemediated(action string) {
+	m.proposalsRemediated.WithLabelValues(action).Inc()
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
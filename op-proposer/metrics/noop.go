@@ -2,6 +2,8 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -23,7 +25,18 @@ var NoopMetrics Metricer = new(noopMetrics)
 func (*noopMetrics) RecordInfo(version string) {}
 func (*noopMetrics) RecordUp()                 {}
 
-func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {}
+func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef)             {}
+func (*noopMetrics) RecordDisputeGameCreated(gameType uint32, bond *big.Int) {}
+func (*noopMetrics) RecordOutputVerification(result string)                  {}
+func (*noopMetrics) RecordProposalReorged()                                  {}
+func (*noopMetrics) RecordBudgetExceeded(bool)                               {}
+func (*noopMetrics) RecordBondableGames(games uint64)                        {}
+func (*noopMetrics) RecordBondTopUp(amount *big.Int)                         {}
+
+func (*noopMetrics) RecordProposalStage(stage string, latency time.Duration) {}
+func (*noopMetrics) RecordProposalFailure(stage string)                      {}
+func (*noopMetrics) RecordDisputeGameResolved(status string)                 {}
+func (*noopMetrics) RecordProposalRemediated(action string)                  {}
 
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
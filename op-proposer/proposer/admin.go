@@ -0,0 +1,97 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// PauseL2OutputSubmitting pauses the automatic proposals of the proposer.
+// Unlike StopL2OutputSubmitting, the driver loop keeps running, so proposals can
+// still be forced with ProposeL2Block.
+func (l *L2OutputSubmitter) PauseL2OutputSubmitting() {
+	l.Log.Info("Pausing proposals")
+	l.paused.Store(true)
+}
+
+// ResumeL2OutputSubmitting resumes the automatic proposals after
+// PauseL2OutputSubmitting.
+func (l *L2OutputSubmitter) ResumeL2OutputSubmitting() {
+	l.Log.Info("Resuming proposals")
+	l.paused.Store(false)
+}
+
+// SetProposalInterval overrides the interval of the driver loop, which is the
+// poll interval when proposing to the L2OutputOracle and the proposal interval
+// when creating dispute games.
+func (l *L2OutputSubmitter) SetProposalInterval(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid proposal interval %v, must be positive", interval)
+	}
+	l.interval.Store(int64(interval))
+	select {
+	case l.intervalUpdated <- struct{}{}:
+	default: // the loop is already notified, or not running
+	}
+	l.Log.Info("Updated proposal interval", "interval", interval)
+	return nil
+}
+
+// proposalInterval returns the current interval of the driver loop.
+func (l *L2OutputSubmitter) proposalInterval() time.Duration {
+	if interval := l.interval.Load(); interval > 0 {
+		return time.Duration(interval)
+	}
+	if l.Cfg.DisputeGameFactoryAddr != nil {
+		return l.Cfg.ProposalInterval
+	}
+	return l.Cfg.PollInterval
+}
+
+// ProposeL2Block immediately proposes the output at the given L2 block and
+// waits for the proposal tx to be included, also if the proposer is paused. The
+// block must be ready for proposal, i.e., finalized, or safe if non-finalized
+// proposals are allowed. When proposing to the L2OutputOracle, it must be the
+// next block number expected by the oracle.
+func (l *L2OutputSubmitter) ProposeL2Block(ctx context.Context, block uint64) error {
+	if l.l2ooContract != nil {
+		cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+		next, err := l.l2ooContract.NextBlockNumber(&bind.CallOpts{From: l.Txmgr.From(), Context: cCtx})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to fetch next block number: %w", err)
+		}
+		if next.Uint64() != block {
+			return fmt.Errorf("L2OutputOracle expects a proposal for L2 block %d, not %d", next, block)
+		}
+	}
+
+	output, shouldPropose, err := l.FetchOutput(ctx, new(big.Int).SetUint64(block))
+	if err != nil {
+		return fmt.Errorf("failed to fetch output at L2 block %d: %w", block, err)
+	}
+	if !shouldPropose {
+		return fmt.Errorf("L2 block %d is not ready for proposal yet", block)
+	}
+	p := l.proposals.created(output)
+	if err := l.verifyOutput(ctx, output); err != nil {
+		l.proposals.failed(p, err)
+		return err
+	}
+
+	l.Log.Warn("Forcing proposal", "block", output.BlockRef, "output", output.OutputRoot)
+	cCtx, cancel := context.WithTimeout(ctx, proposalTimeout)
+	defer cancel()
+
+	l.proposeMu.Lock()
+	defer l.proposeMu.Unlock()
+	if err := l.sendTransaction(cCtx, output, p); err != nil {
+		l.proposals.failed(p, err)
+		return fmt.Errorf("failed to send proposal transaction: %w", err)
+	}
+	l.recordProposed(output)
+	return nil
+}
@@ -0,0 +1,74 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/health"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	txmgrmocks "github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+func TestL2OutputSubmitter_SetProposalInterval(t *testing.T) {
+	l := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log: testlog.Logger(t, log.LevelCrit),
+			Cfg: ProposerConfig{PollInterval: time.Second, ProposalInterval: time.Minute},
+		},
+		intervalUpdated: make(chan struct{}, 1),
+	}
+	require.Equal(t, time.Second, l.proposalInterval(), "poll interval for L2OutputOracle")
+	l.Cfg.DisputeGameFactoryAddr = &common.Address{0x01}
+	require.Equal(t, time.Minute, l.proposalInterval(), "proposal interval for DisputeGameFactory")
+
+	require.Error(t, l.SetProposalInterval(0))
+	require.Equal(t, time.Minute, l.proposalInterval())
+
+	require.NoError(t, l.SetProposalInterval(5*time.Second))
+	require.NoError(t, l.SetProposalInterval(10*time.Second), "must not block if loop wasn't notified yet")
+	require.Equal(t, 10*time.Second, l.proposalInterval())
+	require.Len(t, l.intervalUpdated, 1)
+}
+
+func TestL2OutputSubmitter_Pause(t *testing.T) {
+	l := &L2OutputSubmitter{DriverSetup: DriverSetup{Log: testlog.Logger(t, log.LevelCrit)}}
+	l.PauseL2OutputSubmitting()
+	require.True(t, l.paused.Load())
+	l.PauseL2OutputSubmitting()
+	require.True(t, l.paused.Load())
+	l.ResumeL2OutputSubmitting()
+	require.False(t, l.paused.Load())
+}
+
+func TestL2OutputSubmitter_Liveness(t *testing.T) {
+	txMgr := txmgrmocks.NewTxManager(t)
+	txMgr.On("BlockNumber", mock.Anything).Return(uint64(0), errors.New("L1 is down"))
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:   testlog.Logger(t, log.LevelCrit),
+			Cfg:   ProposerConfig{WaitNodeSync: true, NetworkTimeout: time.Second},
+			Txmgr: txMgr,
+		},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	require.Equal(t, health.StatusOK, l.Liveness(context.Background()).Status)
+
+	l.wg.Add(1)
+	l.loop()
+	require.Equal(t, health.StatusFailing, l.Liveness(context.Background()).Status, "the loop exited while running")
+
+	l.loopFailed.Store(false)
+	cancel()
+	l.wg.Add(1)
+	l.loop()
+	require.Equal(t, health.StatusOK, l.Liveness(context.Background()).Status, "the loop exited on shutdown")
+}
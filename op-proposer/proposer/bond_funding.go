@@ -0,0 +1,159 @@
+package proposer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// BondFunding configures the monitoring of the proposer balance for the initial
+// bonds of dispute games, so proposals don't stop due to insufficient funds.
+type BondFunding struct {
+	// MinGames is the number of initial bonds that the proposer balance should
+	// cover. A warning is logged, or the balance is topped up from the bond
+	// treasury, when it covers fewer. 0 disables the monitoring.
+	MinGames uint64
+	// TopUpGames is the number of initial bonds that top-ups fund the proposer
+	// balance up to.
+	TopUpGames uint64
+	// MaxTopUpPerDay is the maximum amount in wei topped up from the bond
+	// treasury within 24 hours. Top-ups are reduced to the remaining amount,
+	// and skipped once it is reached. A nil limit is unlimited.
+	MaxTopUpPerDay *big.Int
+}
+
+// topUpRecord is a top-up sent from the bond treasury, tracked to enforce
+// BondFunding.MaxTopUpPerDay.
+type topUpRecord struct {
+	time   time.Time
+	amount *big.Int
+}
+
+// bondableGames returns the number of initial bonds that the balance covers.
+func bondableGames(balance, bond *big.Int) uint64 {
+	games := new(big.Int).Div(balance, bond)
+	if !games.IsUint64() {
+		return ^uint64(0)
+	}
+	return games.Uint64()
+}
+
+// topUpAmount returns the amount in wei to fund the balance with, to cover the
+// given number of initial bonds.
+func topUpAmount(balance, bond *big.Int, games uint64) *big.Int {
+	target := new(big.Int).Mul(bond, new(big.Int).SetUint64(games))
+	if target.Cmp(balance) <= 0 {
+		return new(big.Int)
+	}
+	return target.Sub(target, balance)
+}
+
+// topUpAllowance returns the amount in wei that may still be topped up within
+// 24 hours of now, and drops the older top-ups.
+func (l *L2OutputSubmitter) topUpAllowance(now time.Time) *big.Int {
+	i := 0
+	for i < len(l.topUps) && !l.topUps[i].time.After(now.Add(-day)) {
+		i++
+	}
+	l.topUps = l.topUps[i:]
+	allowance := new(big.Int).Set(l.Cfg.BondFunding.MaxTopUpPerDay)
+	for _, t := range l.topUps {
+		allowance.Sub(allowance, t.amount)
+	}
+	if allowance.Sign() < 0 {
+		allowance.SetUint64(0)
+	}
+	return allowance
+}
+
+// checkBondFunding checks that the proposer balance covers the configured
+// number of initial bonds of dispute games. If it does not, a warning is
+// logged, or the balance is topped up from the bond treasury, if set. Top-ups
+// are sent in the background, one at a time, and count towards the daily
+// top-up limit when sent, even if they fail. It must only be called by the
+// proposal loop.
+func (l *L2OutputSubmitter) checkBondFunding(ctx context.Context) {
+	if l.Cfg.BondFunding.MinGames == 0 || l.toppingUp.Load() {
+		return
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	bond, err := l.dgfContract.InitBonds(&bind.CallOpts{Context: cCtx}, l.Cfg.DisputeGameType)
+	if err != nil {
+		l.Log.Warn("Failed to fetch initial bond, not checking bond funding", "err", err)
+		return
+	}
+	if bond.Sign() == 0 {
+		return
+	}
+	balance, err := l.L1Client.BalanceAt(cCtx, l.Txmgr.From(), nil)
+	if err != nil {
+		l.Log.Warn("Failed to fetch proposer balance, not checking bond funding", "err", err)
+		return
+	}
+	games := bondableGames(balance, bond)
+	l.Metr.RecordBondableGames(games)
+	if games >= l.Cfg.BondFunding.MinGames {
+		return
+	}
+	if l.Treasury == nil {
+		l.Log.Warn("Proposer balance covers too few dispute game bonds, fund the proposer to not stop proposing",
+			"proposer", l.Txmgr.From(), "balance", eth.WeiToEther(balance), "bond", eth.WeiToEther(bond),
+			"games", games, "min_games", l.Cfg.BondFunding.MinGames)
+		return
+	}
+	amount := topUpAmount(balance, bond, l.Cfg.BondFunding.TopUpGames)
+	if limit := l.Cfg.BondFunding.MaxTopUpPerDay; limit != nil {
+		now := time.Now()
+		allowance := l.topUpAllowance(now)
+		if allowance.Sign() == 0 {
+			l.Log.Warn("Proposer balance covers too few dispute game bonds, but the daily top-up limit is reached, fund the proposer to not stop proposing",
+				"proposer", l.Txmgr.From(), "balance", eth.WeiToEther(balance), "bond", eth.WeiToEther(bond),
+				"games", games, "max_top_up_per_day", eth.WeiToEther(limit))
+			return
+		}
+		if amount.Cmp(allowance) > 0 {
+			l.Log.Info("Reducing top-up to the remaining daily top-up limit", "amount", eth.WeiToEther(amount),
+				"allowance", eth.WeiToEther(allowance))
+			amount = allowance
+		}
+		l.topUps = append(l.topUps, topUpRecord{time: now, amount: amount})
+	}
+	l.Log.Warn("Proposer balance covers too few dispute game bonds, topping up from treasury",
+		"proposer", l.Txmgr.From(), "treasury", l.Treasury.From(), "balance", eth.WeiToEther(balance),
+		"bond", eth.WeiToEther(bond), "games", games, "amount", eth.WeiToEther(amount))
+	l.toppingUp.Store(true)
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		defer l.toppingUp.Store(false)
+		l.topUp(ctx, amount)
+	}()
+}
+
+// topUp sends the amount in wei from the bond treasury to the proposer.
+func (l *L2OutputSubmitter) topUp(ctx context.Context, amount *big.Int) {
+	to := l.Txmgr.From()
+	receipt, err := l.Treasury.Send(ctx, txmgr.TxCandidate{
+		To:       &to,
+		GasLimit: params.TxGas,
+		Value:    amount,
+	})
+	if err != nil {
+		l.Log.Error("Failed to top up proposer balance from treasury", "err", err)
+		return
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		l.Log.Error("Top-up of proposer balance from treasury reverted", "tx_hash", receipt.TxHash)
+		return
+	}
+	l.Metr.RecordBondTopUp(amount)
+	l.Log.Info("Topped up proposer balance from treasury", "tx_hash", receipt.TxHash, "amount", eth.WeiToEther(amount))
+}
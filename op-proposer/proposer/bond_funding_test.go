@@ -0,0 +1,181 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	txmgrmocks "github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+var (
+	testDGFAddr  = common.Address{0x0d}
+	testTreasury = common.Address{0x0e}
+)
+
+// bondL1Client serves the initial bond of the DisputeGameFactory, and the
+// proposer balance.
+type bondL1Client struct {
+	L1Client
+	abi     *abi.ABI
+	bond    *big.Int
+	balance *big.Int
+}
+
+func (c *bondL1Client) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	method, err := c.abi.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if method.Name != "initBonds" {
+		return nil, errors.New("unexpected call")
+	}
+	return method.Outputs.Pack(c.bond)
+}
+
+func (c *bondL1Client) BalanceAt(_ context.Context, account common.Address, _ *big.Int) (*big.Int, error) {
+	if account != testProposer {
+		return nil, errors.New("unexpected account")
+	}
+	return c.balance, nil
+}
+
+// setupBondFunding returns a submitter with a bond of 100 wei and a proposer
+// balance of 250 wei, which covers 2 of the 3 min games. Top-ups fund 10 games.
+// The treasury is only set if withTreasury is true.
+func setupBondFunding(t *testing.T, withTreasury bool) (*L2OutputSubmitter, *bondL1Client, *txmgrmocks.TxManager, *testlog.CapturingHandler) {
+	dgfABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	l1 := &bondL1Client{abi: dgfABI, bond: big.NewInt(100), balance: big.NewInt(250)}
+	dgfContract, err := bindings.NewDisputeGameFactoryCaller(testDGFAddr, l1)
+	require.NoError(t, err)
+	txMgr := txmgrmocks.NewTxManager(t)
+	txMgr.On("From").Return(testProposer).Maybe()
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	l := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:  logger,
+			Metr: metrics.NoopMetrics,
+			Cfg: ProposerConfig{
+				DisputeGameFactoryAddr: &testDGFAddr,
+				NetworkTimeout:         time.Second,
+				BondFunding:            BondFunding{MinGames: 3, TopUpGames: 10},
+			},
+			Txmgr:    txMgr,
+			L1Client: l1,
+		},
+		dgfContract: dgfContract,
+		dgfABI:      dgfABI,
+	}
+	var treasury *txmgrmocks.TxManager
+	if withTreasury {
+		treasury = txmgrmocks.NewTxManager(t)
+		treasury.On("From").Return(testTreasury).Maybe()
+		l.Treasury = treasury
+	}
+	return l, l1, treasury, logs
+}
+
+// expectTopUp expects a top-up of amount wei to be sent through the treasury.
+func expectTopUp(treasury *txmgrmocks.TxManager, amount int64) *mock.Call {
+	return treasury.On("Send", mock.Anything, txmgr.TxCandidate{
+		To:       &testProposer,
+		GasLimit: params.TxGas,
+		Value:    big.NewInt(amount),
+	}).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Once()
+}
+
+func TestCheckBondFunding_Warning(t *testing.T) {
+	l, l1, _, logs := setupBondFunding(t, false)
+
+	l.checkBondFunding(context.Background())
+	warn := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageContainsFilter("covers too few dispute game bonds"))
+	require.NotNil(t, warn)
+	require.EqualValues(t, 2, warn.AttrValue("games"))
+
+	logs.Clear()
+	l1.balance = big.NewInt(300)
+	l.checkBondFunding(context.Background())
+	require.Nil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn)), "balance covers the min games")
+}
+
+func TestCheckBondFunding_TopUp(t *testing.T) {
+	l, l1, treasury, _ := setupBondFunding(t, true)
+
+	expectTopUp(treasury, 750)
+	l.checkBondFunding(context.Background())
+	l.wg.Wait()
+	require.False(t, l.toppingUp.Load())
+
+	l1.balance = big.NewInt(1000)
+	l.checkBondFunding(context.Background())
+	l.wg.Wait()
+	treasury.AssertExpectations(t)
+}
+
+func TestCheckBondFunding_SingleTopUpInFlight(t *testing.T) {
+	l, _, treasury, _ := setupBondFunding(t, true)
+
+	sent := make(chan struct{})
+	release := make(chan struct{})
+	expectTopUp(treasury, 750).Run(func(mock.Arguments) {
+		close(sent)
+		<-release
+	})
+	l.checkBondFunding(context.Background())
+	<-sent
+	require.True(t, l.toppingUp.Load())
+
+	// no second top-up is sent while the first one is in flight
+	l.checkBondFunding(context.Background())
+	close(release)
+	l.wg.Wait()
+	require.False(t, l.toppingUp.Load())
+	treasury.AssertNumberOfCalls(t, "Send", 1)
+
+	expectTopUp(treasury, 750)
+	l.checkBondFunding(context.Background())
+	l.wg.Wait()
+	treasury.AssertNumberOfCalls(t, "Send", 2)
+}
+
+func TestCheckBondFunding_MaxTopUpPerDay(t *testing.T) {
+	l, _, treasury, logs := setupBondFunding(t, true)
+	l.Cfg.BondFunding.MaxTopUpPerDay = big.NewInt(1000)
+
+	// the balance stays at 250 wei, as if the top-ups didn't land
+	expectTopUp(treasury, 750)
+	l.checkBondFunding(context.Background())
+	l.wg.Wait()
+	expectTopUp(treasury, 250)
+	l.checkBondFunding(context.Background())
+	l.wg.Wait()
+
+	l.checkBondFunding(context.Background())
+	l.wg.Wait()
+	require.NotNil(t, logs.FindLog(testlog.NewMessageContainsFilter("daily top-up limit is reached")))
+	treasury.AssertNumberOfCalls(t, "Send", 2)
+
+	// top-ups older than a day don't count towards the limit
+	l.topUps[0].time = l.topUps[0].time.Add(-day)
+	expectTopUp(treasury, 750)
+	l.checkBondFunding(context.Background())
+	l.wg.Wait()
+	treasury.AssertNumberOfCalls(t, "Send", 3)
+	require.Len(t, l.topUps, 2)
+}
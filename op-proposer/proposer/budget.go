@@ -0,0 +1,172 @@
+package proposer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var ErrBudgetExceeded = errors.New("spend limit exceeded")
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// SpendLimits are the maximum amounts in wei that the proposer may spend on
+// gas and dispute game bonds per day and week. A nil limit is unlimited.
+type SpendLimits struct {
+	GasPerDay   *big.Int
+	GasPerWeek  *big.Int
+	BondPerDay  *big.Int
+	BondPerWeek *big.Int
+	// File is the path to persist the spends in, so the limits are enforced
+	// across restarts. Spends are only tracked in memory if empty.
+	File string
+}
+
+// Enabled returns whether any limit is set.
+func (s SpendLimits) Enabled() bool {
+	return s.GasPerDay != nil || s.GasPerWeek != nil || s.BondPerDay != nil || s.BondPerWeek != nil
+}
+
+type spend struct {
+	Time time.Time `json:"time"`
+	Gas  *big.Int  `json:"gas,omitempty"`
+	Bond *big.Int  `json:"bond,omitempty"`
+}
+
+// spendBudget tracks the spends of the proposer within the last week, to
+// enforce the SpendLimits over sliding windows. Spends are tracked since the
+// start of the proposer, or across restarts if the spends file is set.
+type spendBudget struct {
+	mu     sync.Mutex
+	limits SpendLimits
+	spends []spend
+	now    func() time.Time
+}
+
+// newSpendBudget returns nil if no limit is set. Otherwise the spends of the
+// spends file are loaded, if it exists.
+func newSpendBudget(limits SpendLimits) (*spendBudget, error) {
+	if !limits.Enabled() {
+		return nil, nil
+	}
+	b := &spendBudget{limits: limits, now: time.Now}
+	if limits.File == "" {
+		return b, nil
+	}
+	spends, err := jsonutil.LoadJSON[[]spend](limits.File)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load spends: %w", err)
+	}
+	b.spends = *spends
+	return b, nil
+}
+
+// record records the gas and bond in wei spent by a tx. Both may be nil.
+// The spend is tracked even if persisting it fails.
+func (b *spendBudget) record(gas, bond *big.Int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	b.prune(now)
+	b.spends = append(b.spends, spend{Time: now, Gas: gas, Bond: bond})
+	if err := jsonutil.WriteJSON(b.limits.File, b.spends, 0o644); err != nil {
+		return fmt.Errorf("failed to persist spends: %w", err)
+	}
+	return nil
+}
+
+// check returns an error wrapping ErrBudgetExceeded if a spend limit is reached.
+func (b *spendBudget) check() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	b.prune(now)
+	gasDay, bondDay := b.sum(now.Add(-day))
+	gasWeek, bondWeek := b.sum(now.Add(-week))
+	for _, c := range []struct {
+		what         string
+		spent, limit *big.Int
+	}{
+		{"gas per day", gasDay, b.limits.GasPerDay},
+		{"gas per week", gasWeek, b.limits.GasPerWeek},
+		{"bonds per day", bondDay, b.limits.BondPerDay},
+		{"bonds per week", bondWeek, b.limits.BondPerWeek},
+	} {
+		if c.limit != nil && c.spent.Cmp(c.limit) >= 0 {
+			return fmt.Errorf("%w: spent %v ETH on %s, limit is %v ETH",
+				ErrBudgetExceeded, eth.WeiToEther(c.spent), c.what, eth.WeiToEther(c.limit))
+		}
+	}
+	return nil
+}
+
+// sum returns the gas and bonds spent after since.
+func (b *spendBudget) sum(since time.Time) (gas, bond *big.Int) {
+	gas, bond = new(big.Int), new(big.Int)
+	for _, s := range b.spends {
+		if !s.Time.After(since) {
+			continue
+		}
+		if s.Gas != nil {
+			gas.Add(gas, s.Gas)
+		}
+		if s.Bond != nil {
+			bond.Add(bond, s.Bond)
+		}
+	}
+	return gas, bond
+}
+
+// prune drops the spends older than a week.
+func (b *spendBudget) prune(now time.Time) {
+	i := 0
+	for i < len(b.spends) && !b.spends[i].Time.After(now.Add(-week)) {
+		i++
+	}
+	b.spends = b.spends[i:]
+}
+
+// checkBudget returns an error wrapping ErrBudgetExceeded if a spend limit is
+// reached. The proposer is paused then, and must be resumed with the admin RPC
+// once the limit is raised or enough time passed. It must be called before
+// every tx the proposer sends, including forced proposals.
+func (l *L2OutputSubmitter) checkBudget() error {
+	if l.budget == nil {
+		return nil
+	}
+	err := l.budget.check()
+	l.Metr.RecordBudgetExceeded(err != nil)
+	if err != nil && !l.paused.Swap(true) {
+		l.Log.Error("Pausing proposals, resume with admin_resumeProposer once resolved", "err", err)
+	}
+	return err
+}
+
+// recordSpend records the gas cost of the receipt and the bond in wei paid by
+// a tx of the proposer, if spend limits are set. The bond may be nil.
+func (l *L2OutputSubmitter) recordSpend(receipt *types.Receipt, bond *big.Int) {
+	if l.budget == nil {
+		return
+	}
+	var gas *big.Int
+	if receipt.EffectiveGasPrice != nil {
+		gas = new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	}
+	if err := l.budget.record(gas, bond); err != nil {
+		l.Log.Error("Failed to persist spend", "err", err, "tx_hash", receipt.TxHash)
+	}
+	_ = l.checkBudget()
+}
@@ -0,0 +1,96 @@
+package proposer
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.Ether))
+}
+
+func TestSpendBudget(t *testing.T) {
+	b, err := newSpendBudget(SpendLimits{})
+	require.NoError(t, err)
+	require.Nil(t, b, "no limits")
+
+	now := time.Unix(1_000_000, 0)
+	b, err = newSpendBudget(SpendLimits{GasPerDay: ether(2), GasPerWeek: ether(5), BondPerDay: ether(10)})
+	require.NoError(t, err)
+	b.now = func() time.Time { return now }
+
+	require.NoError(t, b.record(ether(1), nil))
+	require.NoError(t, b.check())
+	require.NoError(t, b.record(ether(1), nil))
+	require.ErrorIs(t, b.check(), ErrBudgetExceeded, "daily gas limit reached")
+	require.ErrorContains(t, b.check(), "gas per day")
+
+	now = now.Add(day)
+	require.NoError(t, b.check(), "daily window slid")
+	require.NoError(t, b.record(ether(1), ether(9)))
+	require.NoError(t, b.record(ether(1), nil))
+	require.ErrorContains(t, b.check(), "gas per day")
+
+	now = now.Add(day)
+	require.NoError(t, b.record(ether(1), nil))
+	require.ErrorContains(t, b.check(), "gas per week")
+
+	now = now.Add(week)
+	require.NoError(t, b.check())
+	require.Empty(t, b.spends, "old spends pruned")
+	require.NoError(t, b.record(nil, ether(10)))
+	require.ErrorContains(t, b.check(), "bonds per day")
+}
+
+func TestSpendBudgetPersisted(t *testing.T) {
+	limits := SpendLimits{GasPerDay: ether(2), File: filepath.Join(t.TempDir(), "spends.json")}
+	b, err := newSpendBudget(limits)
+	require.NoError(t, err)
+	require.NoError(t, b.record(ether(1), nil))
+	require.NoError(t, b.record(ether(1), ether(3)))
+	require.ErrorIs(t, b.check(), ErrBudgetExceeded)
+
+	restarted, err := newSpendBudget(limits)
+	require.NoError(t, err)
+	require.ErrorIs(t, restarted.check(), ErrBudgetExceeded, "spends persisted across restarts")
+	require.Len(t, restarted.spends, 2)
+	require.Equal(t, ether(3), restarted.spends[1].Bond)
+
+	require.NoError(t, os.WriteFile(limits.File, []byte("{"), 0o644))
+	_, err = newSpendBudget(limits)
+	require.ErrorContains(t, err, "failed to load spends")
+}
+
+func TestL2OutputSubmitter_RecordSpendPauses(t *testing.T) {
+	budget, err := newSpendBudget(SpendLimits{GasPerDay: big.NewInt(1_000_000)})
+	require.NoError(t, err)
+	l := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:  testlog.Logger(t, log.LevelCrit),
+			Metr: metrics.NoopMetrics,
+		},
+		budget: budget,
+	}
+	require.NoError(t, l.checkBudget())
+
+	l.recordSpend(&types.Receipt{GasUsed: 100_000, EffectiveGasPrice: big.NewInt(5)}, nil)
+	require.False(t, l.paused.Load())
+	l.recordSpend(&types.Receipt{GasUsed: 100_000, EffectiveGasPrice: big.NewInt(5)}, nil)
+	require.True(t, l.paused.Load(), "paused when limit reached")
+	require.ErrorIs(t, l.checkBudget(), ErrBudgetExceeded)
+
+	l.ResumeL2OutputSubmitting()
+	require.ErrorIs(t, l.checkBudget(), ErrBudgetExceeded)
+	require.True(t, l.paused.Load(), "paused again while limit is reached")
+}
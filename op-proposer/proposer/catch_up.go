@@ -0,0 +1,219 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+const (
+	// catchUpGasMarginPercent is added to the estimated gas of the first proposal
+	// of a catch-up batch, to be used as gas limit of all proposals of the batch.
+	// Later proposals can't be estimated because they depend on the earlier ones.
+	catchUpGasMarginPercent = 20
+
+	pendingNoncePollInterval = 250 * time.Millisecond
+	pendingNonceTimeout      = time.Minute
+)
+
+// FetchNextOutputs returns up to max consecutive outputs that are ready to be
+// proposed to the L2OutputOracle, starting at its next block number. More than
+// one output is only returned if the proposer fell behind by multiple
+// submission intervals.
+func (l *L2OutputSubmitter) FetchNextOutputs(ctx context.Context, max uint64) ([]*eth.OutputResponse, error) {
+	output, shouldPropose, err := l.FetchNextOutputInfo(ctx)
+	if err != nil || !shouldPropose {
+		return nil, err
+	}
+	outputs := []*eth.OutputResponse{output}
+	if max <= 1 {
+		return outputs, nil
+	}
+
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	interval, err := l.l2ooContract.SubmissionInterval(&bind.CallOpts{From: l.Txmgr.From(), Context: cCtx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch submission interval: %w", err)
+	}
+	currentBlockNumber, err := l.FetchCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	next := new(big.Int).SetUint64(output.BlockRef.Number)
+	for uint64(len(outputs)) < max {
+		next = new(big.Int).Add(next, interval)
+		if next.Cmp(currentBlockNumber) > 0 {
+			break
+		}
+		output, shouldPropose, err := l.FetchOutput(ctx, next)
+		if err != nil || !shouldPropose {
+			break
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// proposeOutputs proposes a backlog of consecutive outputs to the
+// L2OutputOracle. The proposal txs are sent concurrently, so that they can be
+// included in the same L1 block. Each tx is only sent after the previous one got
+// into the L1 mempool, so that they get consecutive nonces in proposal order.
+func (l *L2OutputSubmitter) proposeOutputs(ctx context.Context, outputs []*eth.OutputResponse) {
+	proposals := make([]*proposal, len(outputs))
+	for i, output := range outputs {
+		proposals[i] = l.proposals.created(output)
+	}
+	// failFrom fails the tracked proposals of outputs[i:], which won't be sent.
+	failFrom := func(i int, err error) {
+		for _, p := range proposals[i:len(outputs)] {
+			l.proposals.failed(p, err)
+		}
+	}
+
+	for i, output := range outputs {
+		if err := l.verifyOutput(ctx, output); err != nil {
+			failFrom(i, err)
+			outputs = outputs[:i]
+			break
+		}
+	}
+	if len(outputs) == 0 {
+		return
+	}
+	if err := l.checkBudget(); err != nil {
+		failFrom(0, err)
+		return
+	}
+
+	cCtx, cancel := context.WithTimeout(ctx, proposalTimeout)
+	defer cancel()
+
+	l.proposeMu.Lock()
+	defer l.proposeMu.Unlock()
+
+	last := outputs[len(outputs)-1]
+	if err := l.waitForL1Head(cCtx, last.Status.HeadL1.Number+1); err != nil {
+		l.Log.Error("Failed to wait for L1 head", "err", err)
+		failFrom(0, err)
+		return
+	}
+	for i, output := range outputs {
+		if err := l.checkOutputCanonical(cCtx, output); err != nil {
+			l.Log.Error("Not proposing non-canonical output", "err", err, "block", output.BlockRef)
+			failFrom(i, err)
+			outputs = outputs[:i]
+			break
+		}
+	}
+	if len(outputs) == 0 {
+		return
+	}
+	txDatas := make([][]byte, 0, len(outputs))
+	for _, output := range outputs {
+		data, err := l.ProposeL2OutputTxData(output)
+		if err != nil {
+			l.Log.Error("Failed to create proposal tx data", "err", err, "block", output.BlockRef)
+			failFrom(0, err)
+			return
+		}
+		txDatas = append(txDatas, data)
+	}
+	gasLimit, err := l.L1Client.EstimateGas(cCtx, ethereum.CallMsg{
+		From: l.Txmgr.From(),
+		To:   l.Cfg.L2OutputOracleAddr,
+		Data: txDatas[0],
+	})
+	if err != nil {
+		l.Log.Error("Failed to estimate gas of first catch-up proposal", "err", err, "block", outputs[0].BlockRef)
+		failFrom(0, err)
+		return
+	}
+	gasLimit += gasLimit * catchUpGasMarginPercent / 100
+	nonce, err := l.L1Client.PendingNonceAt(cCtx, l.Txmgr.From())
+	if err != nil {
+		l.Log.Error("Failed to fetch pending nonce", "err", err)
+		failFrom(0, err)
+		return
+	}
+
+	l.Log.Info("Proposing backlog of output roots", "count", len(outputs),
+		"first_block", outputs[0].BlockRef, "last_block", outputs[len(outputs)-1].BlockRef, "gas_limit", gasLimit)
+	queue := txmgr.NewQueue[int](cCtx, l.Txmgr, uint64(len(outputs)))
+	receiptsCh := make(chan txmgr.TxReceipt[int], len(outputs))
+	for i, data := range txDatas {
+		l.Log.Info("Proposing output root", "output", outputs[i].OutputRoot, "block", outputs[i].BlockRef)
+		l.proposals.submitted(proposals[i])
+		queue.Send(i, txmgr.TxCandidate{
+			TxData:   data,
+			To:       l.Cfg.L2OutputOracleAddr,
+			GasLimit: gasLimit,
+		}, receiptsCh)
+		if i == len(txDatas)-1 {
+			break
+		}
+		nonce++
+		if err := l.waitForPendingNonce(cCtx, nonce); err != nil {
+			l.Log.Warn("Proposal tx didn't reach the mempool, not sending remaining backlog proposals",
+				"err", err, "block", outputs[i].BlockRef)
+			failFrom(i+1, err)
+			break
+		}
+	}
+	queue.Wait()
+	close(receiptsCh)
+
+	receipts := make([]txmgr.TxReceipt[int], 0, len(outputs))
+	for r := range receiptsCh {
+		receipts = append(receipts, r)
+	}
+	sort.Slice(receipts, func(i, j int) bool { return receipts[i].ID < receipts[j].ID })
+	for _, r := range receipts {
+		output := outputs[r.ID]
+		if r.Receipt != nil {
+			l.recordSpend(r.Receipt, nil)
+			l.proposals.confirmed(proposals[r.ID], r.Receipt)
+		}
+		switch {
+		case r.Err != nil:
+			l.proposals.failed(proposals[r.ID], r.Err)
+			l.Log.Error("Failed to send proposal transaction", "err", r.Err, "block", output.BlockRef)
+		case r.Receipt.Status == types.ReceiptStatusFailed:
+			l.Log.Error("Proposer tx successfully published but reverted", "tx_hash", r.Receipt.TxHash, "block", output.BlockRef)
+		default:
+			l.Log.Info("Proposer tx successfully published", "tx_hash", r.Receipt.TxHash, "block", output.BlockRef)
+			l.recordProposed(output)
+		}
+	}
+}
+
+// waitForPendingNonce waits until the pending nonce of the proposer reaches
+// nonce, i.e., all txs with lower nonces reached the L1 mempool.
+func (l *L2OutputSubmitter) waitForPendingNonce(ctx context.Context, nonce uint64) error {
+	ctx, cancel := context.WithTimeout(ctx, pendingNonceTimeout)
+	defer cancel()
+	ticker := time.NewTicker(pendingNoncePollInterval)
+	defer ticker.Stop()
+	for {
+		pending, err := l.L1Client.PendingNonceAt(ctx, l.Txmgr.From())
+		if err != nil {
+			l.Log.Warn("Failed to fetch pending nonce", "err", err)
+		} else if pending >= nonce {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for pending nonce %d: %w", nonce, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package proposer
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	txmgrmocks "github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+// catchUpL1Client counts the sent txs as pending nonce.
+type catchUpL1Client struct {
+	L1Client
+
+	mu   sync.Mutex
+	sent uint64
+	gas  uint64
+}
+
+func (c *catchUpL1Client) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sent, nil
+}
+
+func (c *catchUpL1Client) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return c.gas, nil
+}
+
+func TestL2OutputSubmitter_ProposeOutputs(t *testing.T) {
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	l1 := &catchUpL1Client{gas: 100_000}
+	l2oo := common.Address{0x0a}
+
+	outputs := make([]*eth.OutputResponse, 3)
+	for i := range outputs {
+		outputs[i] = &eth.OutputResponse{
+			OutputRoot: eth.Bytes32{byte(i + 1)},
+			BlockRef:   eth.L2BlockRef{Number: uint64(10 * (i + 1))},
+			Status:     &eth.SyncStatus{HeadL1: eth.L1BlockRef{Number: 5}, FinalizedL2: eth.L2BlockRef{Number: 30}},
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		proposed []uint64
+	)
+	txMgr := txmgrmocks.NewTxManager(t)
+	txMgr.On("From").Return(common.Address{0x01})
+	txMgr.On("BlockNumber", mock.Anything).Return(uint64(7), nil)
+	txMgr.On("Send", mock.Anything, mock.Anything).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(8)}, nil).
+		Run(func(args mock.Arguments) {
+			candidate := args.Get(1).(txmgr.TxCandidate)
+			require.Equal(t, &l2oo, candidate.To)
+			require.Equal(t, uint64(120_000), candidate.GasLimit, "estimated gas plus margin")
+			unpacked, err := l2ooABI.Methods["proposeL2Output"].Inputs.Unpack(candidate.TxData[4:])
+			require.NoError(t, err)
+			mu.Lock()
+			proposed = append(proposed, unpacked[1].(*big.Int).Uint64())
+			mu.Unlock()
+			l1.mu.Lock()
+			l1.sent++
+			l1.mu.Unlock()
+		})
+
+	l := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:      testlog.Logger(t, log.LevelCrit),
+			Metr:     metrics.NoopMetrics,
+			Cfg:      ProposerConfig{L2OutputOracleAddr: &l2oo, PollInterval: time.Millisecond},
+			Txmgr:    txMgr,
+			L1Client: l1,
+		},
+		done:      make(chan struct{}),
+		l2ooABI:   l2ooABI,
+		proposals: newProposalTracker(metrics.NoopMetrics),
+	}
+	l.proposeOutputs(context.Background(), outputs)
+
+	require.Equal(t, []uint64{10, 20, 30}, proposed, "proposals must be sent in order")
+	txMgr.AssertNumberOfCalls(t, "Send", 3)
+	for _, info := range l.proposals.infos() {
+		require.Equal(t, rpc.ProposalConfirmed, info.Status)
+		require.Equal(t, uint64(8), info.InclusionBlock.Number)
+	}
+}
+
+func TestL2OutputSubmitter_ProposeOutputsVerificationFailure(t *testing.T) {
+	l := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:            testlog.Logger(t, log.LevelCrit),
+			Metr:           metrics.NoopMetrics,
+			OutputVerifier: &stubOutputVerifier{err: ErrOutputMismatch},
+		},
+		proposals: newProposalTracker(metrics.NoopMetrics),
+	}
+	// No tx manager or L1 client calls are expected if no output passes verification.
+	l.proposeOutputs(context.Background(), []*eth.OutputResponse{{OutputRoot: eth.Bytes32{0x01}}})
+	infos := l.proposals.infos()
+	require.Len(t, infos, 1)
+	require.Equal(t, rpc.ProposalFailed, infos[0].Status)
+}
+
+func TestL2OutputSubmitter_ProposalTxAbandoned(t *testing.T) {
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	l2oo := common.Address{0x0a}
+	output := &eth.OutputResponse{
+		OutputRoot: eth.Bytes32{0x01},
+		BlockRef:   eth.L2BlockRef{Number: 10},
+		Status:     &eth.SyncStatus{HeadL1: eth.L1BlockRef{Number: 5}, FinalizedL2: eth.L2BlockRef{Number: 10}},
+	}
+	abandoned := &txmgr.AbandonedError{
+		Tx:                  types.NewTx(&types.DynamicFeeTx{Nonce: 3}),
+		Cancellation:        types.NewTx(&types.DynamicFeeTx{Nonce: 3, Gas: 21_000}),
+		CancellationReceipt: &types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: 21_000, EffectiveGasPrice: big.NewInt(1)},
+	}
+
+	txMgr := txmgrmocks.NewTxManager(t)
+	txMgr.On("BlockNumber", mock.Anything).Return(uint64(7), nil)
+	txMgr.On("Send", mock.Anything, mock.Anything).Return(nil, abandoned).Once().
+		Run(func(args mock.Arguments) {
+			candidate := args.Get(1).(txmgr.TxCandidate)
+			require.WithinDuration(t, time.Now().Add(time.Minute), candidate.Deadline, time.Second)
+		})
+
+	budget, err := newSpendBudget(SpendLimits{GasPerDay: big.NewInt(1_000_000)})
+	require.NoError(t, err)
+	l := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:   testlog.Logger(t, log.LevelCrit),
+			Metr:  metrics.NoopMetrics,
+			Cfg:   ProposerConfig{L2OutputOracleAddr: &l2oo, PollInterval: time.Millisecond, ProposalTxDeadline: time.Minute},
+			Txmgr: txMgr,
+		},
+		done:      make(chan struct{}),
+		l2ooABI:   l2ooABI,
+		budget:    budget,
+		proposals: newProposalTracker(metrics.NoopMetrics),
+	}
+	p := l.proposals.created(output)
+	err = l.sendTransaction(context.Background(), output, p)
+	require.ErrorIs(t, err, txmgr.ErrAbandoned)
+	gas, _ := budget.sum(time.Now().Add(-day))
+	require.Equal(t, big.NewInt(21_000), gas, "the cancellation must count towards the spend")
+}
@@ -0,0 +1,241 @@
+package proposer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// chainNameRegex restricts chain names, as they are used as metrics namespaces.
+var chainNameRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Duration is a time.Duration that's encoded as duration string, like "12s", in
+// JSON.
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// ChainConfig configures an additional chain to propose for. All chains share
+// the L1 RPC, the tx manager settings, the metrics and the RPC server of the
+// proposer, but have their own contracts, signer and intervals. The JSON
+// schema matches the chains config of the batcher.
+type ChainConfig struct {
+	// Name identifies the chain in logs and is the metrics namespace of the
+	// chain. It may only contain lowercase letters, digits and underscores.
+	Name      string `json:"name"`
+	RollupRpc string `json:"rollup_rpc"`
+
+	// L2OOAddress or DGFAddress is the contract to propose to.
+	L2OOAddress        string   `json:"l2oo_address,omitempty"`
+	DGFAddress         string   `json:"dgf_address,omitempty"`
+	DisputeGameType    uint32   `json:"game_type,omitempty"`
+	DisputeGameMaxBond float64  `json:"game_max_bond,omitempty"`
+	ProposalInterval   Duration `json:"proposal_interval,omitempty"`
+	// PollInterval defaults to the poll interval of the proposer.
+	PollInterval Duration `json:"poll_interval,omitempty"`
+
+	// Proposer signer of the chain. A remote signer uses the TLS config of
+	// the proposer's signer.
+	opcrypto.ChainSignerConfig
+
+	VerifyRollupRpc string `json:"verify_rollup_rpc,omitempty"`
+	VerifyL2EthRpc  string `json:"verify_l2_eth_rpc,omitempty"`
+}
+
+// LoadChainConfigs reads the JSON list of additional chains from the file at path.
+func LoadChainConfigs(path string) ([]ChainConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chains config: %w", err)
+	}
+	var chains []ChainConfig
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("failed to decode chains config: %w", err)
+	}
+	names := make(map[string]bool, len(chains))
+	for _, chain := range chains {
+		if !chainNameRegex.MatchString(chain.Name) {
+			return nil, fmt.Errorf("invalid chain name %q, must match %s", chain.Name, chainNameRegex)
+		}
+		if names[chain.Name] {
+			return nil, fmt.Errorf("duplicate chain name %q", chain.Name)
+		}
+		if err := chain.ChainSignerConfig.Check(); err != nil {
+			return nil, fmt.Errorf("invalid signer of chain %s: %w", chain.Name, err)
+		}
+		names[chain.Name] = true
+	}
+	return chains, nil
+}
+
+// CLIConfig returns the configuration of the chain's proposer, based on the
+// configuration base of the proposer process. The signer of the proposer is
+// removed from the tx manager config, which only provides the shared settings.
+func (c *ChainConfig) CLIConfig(base *CLIConfig) *CLIConfig {
+	cfg := *base
+	cfg.RollupRpc = c.RollupRpc
+	cfg.L2OOAddress = c.L2OOAddress
+	cfg.DGFAddress = c.DGFAddress
+	cfg.DisputeGameType = c.DisputeGameType
+	cfg.DisputeGameMaxBond = c.DisputeGameMaxBond
+	cfg.ProposalInterval = time.Duration(c.ProposalInterval)
+	if c.PollInterval != 0 {
+		cfg.PollInterval = time.Duration(c.PollInterval)
+	}
+	cfg.TxMgrConfig.PrivateKey = ""
+	cfg.TxMgrConfig.Mnemonic = ""
+	cfg.TxMgrConfig.HDPath = ""
+	cfg.TxMgrConfig.L2OutputHDPath = ""
+	cfg.TxMgrConfig.SignerCLIConfig.Endpoint = ""
+	cfg.TxMgrConfig.SignerCLIConfig.Address = ""
+	cfg.TxMgrConfig.SignerCLIConfig.KMSProvider = ""
+	cfg.VerifyRollupRpc = c.VerifyRollupRpc
+	cfg.VerifyL2EthRpc = c.VerifyL2EthRpc
+	if base.SpendFile != "" {
+		// The spends of each proposer are tracked separately
+		cfg.SpendFile = base.SpendFile + "." + c.Name
+	}
+	cfg.ChainsConfig = ""
+	return &cfg
+}
+
+// sharedL1Backend shares the L1 client that the tx manager config of the
+// proposer dialed with the tx managers of additional chains, which must not
+// close it.
+type sharedL1Backend struct {
+	txmgr.ETHBackend
+}
+
+func (sharedL1Backend) Close() {}
+
+// initChains initializes the proposers of the additional chains of the chains
+// config, after the proposer of the primary chain got initialized.
+func (ps *ProposerService) initChains(ctx context.Context, cfg *CLIConfig) error {
+	if cfg.ChainsConfig == "" {
+		return nil
+	}
+	chains, err := LoadChainConfigs(cfg.ChainsConfig)
+	if err != nil {
+		return err
+	}
+	proposers := map[common.Address]string{ps.TxManager.From(): "primary"}
+	for _, chain := range chains {
+		chainCfg := chain.CLIConfig(cfg)
+		if err := chainCfg.Check(); err != nil {
+			return fmt.Errorf("invalid config of chain %s: %w", chain.Name, err)
+		}
+		cps := &ProposerService{
+			Log:       ps.Log.New("chain", chain.Name),
+			Version:   ps.Version,
+			Tracer:    ps.Tracer,
+			L1Client:  ps.L1Client,
+			chainName: chain.Name,
+		}
+		// Add the chain before initializing it, so that it gets cleaned up on errors.
+		ps.chains = append(ps.chains, cps)
+		if err := cps.initChain(ctx, chainCfg, chain, ps); err != nil {
+			return fmt.Errorf("failed to init chain %s: %w", chain.Name, err)
+		}
+		from := cps.TxManager.From()
+		if other, ok := proposers[from]; ok {
+			// The tx managers would compete for the nonces of the address.
+			return fmt.Errorf("chain %s uses the same proposer address %s as chain %s", chain.Name, from, other)
+		}
+		proposers[from] = chain.Name
+		ps.Log.Info("Initialized additional chain", "chain", chain.Name, "proposer", from)
+	}
+	return nil
+}
+
+// initChain initializes the proposer of an additional chain. Its metrics are
+// added to the registry of the primary metrics, if enabled.
+func (ps *ProposerService) initChain(ctx context.Context, cfg *CLIConfig, chain ChainConfig, primary *ProposerService) error {
+	if m, ok := primary.Metrics.(opmetrics.RegistryMetricer); ok && cfg.MetricsConfig.Enabled {
+		ps.Metrics = metrics.NewMetricsWithRegistry(ps.chainName, m.Registry())
+	} else {
+		ps.Metrics = metrics.NoopMetrics
+	}
+
+	ps.initProposerConfig(cfg)
+	ps.initL2ooAddress(cfg)
+	if err := ps.initDGF(cfg); err != nil {
+		return err
+	}
+	if err := ps.initSpendLimits(cfg); err != nil {
+		return err
+	}
+	if err := ps.initRollupClients(ctx, cfg); err != nil {
+		return err
+	}
+	if err := ps.initChainTxManager(ctx, cfg, chain, primary.txMgrConfig); err != nil {
+		return fmt.Errorf("failed to init Tx manager: %w", err)
+	}
+	ps.initBalanceMonitor(cfg)
+	if err := ps.initDriver(); err != nil {
+		return fmt.Errorf("failed to init Driver: %w", err)
+	}
+
+	ps.Metrics.RecordInfo(ps.Version)
+	ps.Metrics.RecordUp()
+	return nil
+}
+
+// initChainTxManager initializes the tx manager of an additional chain with the
+// signer of the chain and the tx manager config of the primary chain, sharing
+// its L1 client.
+func (ps *ProposerService) initChainTxManager(ctx context.Context, cfg *CLIConfig, chain ChainConfig, primary txmgr.Config) error {
+	signerFactory, from, err := chain.SignerFactory(ps.Log, cfg.TxMgrConfig.SignerCLIConfig)
+	if err != nil {
+		return fmt.Errorf("could not init signer: %w", err)
+	}
+	txCfg := primary
+	txCfg.Signer = signerFactory(txCfg.ChainID)
+	txCfg.From = from
+	txCfg.Backend = sharedL1Backend{primary.Backend}
+	return ps.initTxManagerFromConfig(ctx, cfg, "proposer-"+ps.chainName, txCfg)
+}
+
+// startChains starts the proposers of the additional chains.
+func (ps *ProposerService) startChains() error {
+	var result error
+	for _, chain := range ps.chains {
+		if err := chain.driver.StartL2OutputSubmitting(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to start chain %s: %w", chain.chainName, err))
+		}
+	}
+	return result
+}
+
+// stopChains fully stops the proposers of the additional chains.
+func (ps *ProposerService) stopChains(ctx context.Context) error {
+	var result error
+	for _, chain := range ps.chains {
+		chain.L1Client = nil // shared with the primary chain, closed by its Stop
+		if err := chain.Stop(ctx); err != nil && !errors.Is(err, ErrAlreadyStopped) {
+			result = errors.Join(result, fmt.Errorf("failed to stop chain %s: %w", chain.chainName, err))
+		}
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package proposer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
+)
+
+func writeChainsConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "chains.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadChainConfigs(t *testing.T) {
+	chains, err := LoadChainConfigs(writeChainsConfig(t, `[
+		{"name": "chain_a", "rollup_rpc": "http://a", "l2oo_address": "0x0000000000000000000000000000000000000001", "private_key_env": "CHAIN_A_KEY"},
+		{"name": "chain_b", "rollup_rpc": "http://b", "dgf_address": "0x0000000000000000000000000000000000000002",
+		 "game_type": 1, "proposal_interval": "1h", "poll_interval": "6s", "signer_endpoint": "http://signer", "signer_address": "0x0000000000000000000000000000000000000003"}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, chains, 2)
+	require.Equal(t, "chain_a", chains[0].Name)
+	require.Equal(t, "CHAIN_A_KEY", chains[0].PrivateKeyEnv)
+	require.Equal(t, "http://signer", chains[1].SignerEndpoint)
+	require.Equal(t, Duration(time.Hour), chains[1].ProposalInterval)
+	require.Equal(t, Duration(6*time.Second), chains[1].PollInterval)
+	require.Equal(t, uint32(1), chains[1].DisputeGameType)
+
+	_, err = LoadChainConfigs(writeChainsConfig(t, `[{"name": "Chain-A"}]`))
+	require.ErrorContains(t, err, "invalid chain name")
+
+	_, err = LoadChainConfigs(writeChainsConfig(t, `[{"name": "a", "private_key_env": "A"}, {"name": "a", "private_key_env": "A"}]`))
+	require.ErrorContains(t, err, "duplicate chain name")
+
+	_, err = LoadChainConfigs(writeChainsConfig(t, `[{"name": "a"}]`))
+	require.ErrorContains(t, err, "invalid signer of chain a")
+
+	_, err = LoadChainConfigs(writeChainsConfig(t, `[{"name": "a", "poll_interval": "soon"}]`))
+	require.ErrorContains(t, err, "failed to decode")
+}
+
+func TestChainConfig_CLIConfig(t *testing.T) {
+	base := validConfig()
+	base.ProposalInterval = time.Minute
+	base.DGFAddress = "0x0000000000000000000000000000000000000009"
+	base.L2OOAddress = ""
+	base.VerifyRollupRpc = "http://verify"
+	base.ChainsConfig = "chains.json"
+	base.SpendFile = "spends.json"
+	require.NoError(t, base.Check())
+
+	chain := ChainConfig{
+		Name:        "chain_a",
+		RollupRpc:   "http://a",
+		L2OOAddress: "0x0000000000000000000000000000000000000001",
+		ChainSignerConfig: opcrypto.ChainSignerConfig{
+			PrivateKeyEnv: "CHAIN_A_KEY",
+		},
+	}
+	cfg := chain.CLIConfig(base)
+	require.NoError(t, cfg.Check())
+	require.Equal(t, "http://a", cfg.RollupRpc)
+	require.Empty(t, cfg.DGFAddress, "contracts are not inherited")
+	require.Zero(t, cfg.ProposalInterval)
+	require.Empty(t, cfg.VerifyRollupRpc, "verification nodes are not inherited")
+	require.Empty(t, cfg.ChainsConfig)
+	require.Equal(t, "spends.json.chain_a", cfg.SpendFile, "spends are persisted per chain")
+	require.Empty(t, cfg.TxMgrConfig.PrivateKey, "signer of the proposer is not inherited")
+	require.Empty(t, cfg.TxMgrConfig.Mnemonic)
+	require.Equal(t, base.PollInterval, cfg.PollInterval, "poll interval is inherited")
+	require.Equal(t, base.TxMgrConfig.NumConfirmations, cfg.TxMgrConfig.NumConfirmations)
+
+	chain = ChainConfig{
+		Name:       "chain_b",
+		RollupRpc:  "http://b",
+		DGFAddress: "0x0000000000000000000000000000000000000002",
+	}
+	require.ErrorContains(t, chain.CLIConfig(base).Check(), "ProposalInterval", "DGF chains need their own proposal interval")
+}
@@ -2,6 +2,7 @@ package proposer
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/urfave/cli/v2"
@@ -11,6 +12,7 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -37,6 +39,10 @@ type CLIConfig struct {
 	// for L2 blocks derived from non-finalized L1 data.
 	AllowNonFinalized bool
 
+	// ProposalSafety is the L2 head to propose outputs up to. If empty, it's
+	// finalized, or safe if AllowNonFinalized is set.
+	ProposalSafety flags.ProposalSafety
+
 	TxMgrConfig txmgr.CLIConfig
 
 	RPCConfig oprpc.CLIConfig
@@ -47,6 +53,8 @@ type CLIConfig struct {
 
 	PprofConfig oppprof.CLIConfig
 
+	TracingConfig optracing.CLIConfig
+
 	// DGFAddress is the DisputeGameFactory contract address.
 	DGFAddress string
 
@@ -56,11 +64,77 @@ type CLIConfig struct {
 	// DisputeGameType is the type of dispute game to create when submitting an output proposal.
 	DisputeGameType uint32
 
+	// DisputeGameMaxBond is the maximum initial bond in ETH to pay for creating
+	// a dispute game. 0 for no limit.
+	DisputeGameMaxBond float64
+
+	// MaxGasSpendPerDay, MaxGasSpendPerWeek, MaxBondSpendPerDay and
+	// MaxBondSpendPerWeek are the spend limits in ETH for gas and dispute game
+	// bonds. 0 for no limit.
+	MaxGasSpendPerDay   float64
+	MaxGasSpendPerWeek  float64
+	MaxBondSpendPerDay  float64
+	MaxBondSpendPerWeek float64
+	// SpendFile is the path to persist the tracked spends in. Not persisted if empty.
+	SpendFile string
+
+	// VerifyRollupRpc is the HTTP provider URL of an independent rollup node to
+	// cross-verify output roots with before proposing them.
+	VerifyRollupRpc string
+
+	// VerifyL2EthRpc is the HTTP provider URL of an L2 archive node to recompute
+	// and cross-verify output roots with before proposing them.
+	VerifyL2EthRpc string
+
+	// MaxProposalsPerL1Block is the maximum number of L2OutputOracle proposals
+	// to send for inclusion in the same L1 block when catching up. 0 and 1
+	// disable catch-up mode.
+	MaxProposalsPerL1Block uint64
+
+	// ProposalTxDeadline is the time after which an unconfirmed proposal tx is
+	// cancelled, to propose the output again. 0 disables the deadline.
+	ProposalTxDeadline time.Duration
+
+	// RemediateDivergingProposals enables the automatic remediation of own
+	// proposals that diverge from the finalized outputs of the rollup node.
+	RemediateDivergingProposals bool
+
+	// SignerHealthCheck enables checking at startup that the signer can sign
+	// transactions for the proposer address.
+	SignerHealthCheck bool
+
+	// ChainsConfig is the path to a JSON file with additional chains to propose
+	// for, see ChainConfig.
+	ChainsConfig string
+
+	// RPCJWTSecret is the path to the JWT secret file to authenticate RPC
+	// requests with. RPC requests are unauthenticated if empty.
+	RPCJWTSecret string
+
 	// ActiveSequencerCheckDuration is the duration between checks to determine the active sequencer endpoint.
 	ActiveSequencerCheckDuration time.Duration
 
 	// Whether to wait for the sequencer to sync to a recent block at startup.
 	WaitNodeSync bool
+
+	// BondBalanceMinGames is the number of dispute game initial bonds that the
+	// proposer balance should cover. 0 disables the bond balance monitoring.
+	BondBalanceMinGames uint64
+
+	// BondTreasurySignerEndpoint and BondTreasuryAddress configure the remote
+	// signer of the treasury to top up the proposer balance from, when it
+	// covers fewer than BondBalanceMinGames initial bonds. Top-ups are disabled
+	// if empty.
+	BondTreasurySignerEndpoint string
+	BondTreasuryAddress        string
+
+	// BondTopUpGames is the number of initial bonds that top-ups fund the
+	// proposer balance up to.
+	BondTopUpGames uint64
+
+	// BondMaxTopUpPerDay is the maximum amount in ETH topped up from the bond
+	// treasury within 24 hours. Required if the bond treasury is set.
+	BondMaxTopUpPerDay float64
 }
 
 func (c *CLIConfig) Check() error {
@@ -73,6 +147,9 @@ func (c *CLIConfig) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.TracingConfig.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -89,6 +166,41 @@ func (c *CLIConfig) Check() error {
 	if c.ProposalInterval != 0 && c.DGFAddress == "" {
 		return errors.New("the `ProposalInterval` was provided but the `DisputeGameFactory` address was not set")
 	}
+	if c.ProposalTxDeadline < 0 || c.ProposalTxDeadline >= proposalTimeout {
+		return fmt.Errorf("the proposal tx deadline must be between 0 and the proposal timeout of %v", proposalTimeout)
+	}
+	if c.VerifyRollupRpc != "" && c.VerifyL2EthRpc != "" {
+		return errors.New("only one of the verification rollup RPC and verification L2 RPC can be set")
+	}
+	if c.ProposalSafety != "" && !flags.ValidProposalSafety(c.ProposalSafety) {
+		return fmt.Errorf("unknown proposal safety: %q", c.ProposalSafety)
+	}
+	if c.AllowNonFinalized && c.ProposalSafety == flags.FinalizedSafety {
+		return errors.New("non-finalized proposals are allowed, but the proposal safety is finalized")
+	}
+	if c.DisputeGameMaxBond < 0 {
+		return errors.New("the dispute game max bond must not be negative")
+	}
+	if c.MaxGasSpendPerDay < 0 || c.MaxGasSpendPerWeek < 0 || c.MaxBondSpendPerDay < 0 || c.MaxBondSpendPerWeek < 0 {
+		return errors.New("spend limits must not be negative")
+	}
+	if (c.BondTreasurySignerEndpoint == "") != (c.BondTreasuryAddress == "") {
+		return errors.New("the bond treasury signer endpoint and address must both be set or not set")
+	}
+	if c.BondTreasurySignerEndpoint != "" {
+		if c.DGFAddress == "" {
+			return errors.New("the bond treasury was provided but the `DisputeGameFactory` address was not set")
+		}
+		if c.BondBalanceMinGames == 0 {
+			return errors.New("the bond treasury was provided but the bond balance monitoring is disabled")
+		}
+		if c.BondTopUpGames <= c.BondBalanceMinGames {
+			return errors.New("the bond top-up games must be more than the bond balance min games")
+		}
+		if c.BondMaxTopUpPerDay <= 0 {
+			return errors.New("the bond treasury was provided but the max top-up per day was not set")
+		}
+	}
 
 	return nil
 }
@@ -104,14 +216,35 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		TxMgrConfig:  txmgr.ReadCLIConfig(ctx),
 		// Optional Flags
 		AllowNonFinalized:            ctx.Bool(flags.AllowNonFinalizedFlag.Name),
+		ProposalSafety:               flags.ProposalSafety(ctx.String(flags.ProposalSafetyFlag.Name)),
 		RPCConfig:                    oprpc.ReadCLIConfig(ctx),
 		LogConfig:                    oplog.ReadCLIConfig(ctx),
 		MetricsConfig:                opmetrics.ReadCLIConfig(ctx),
 		PprofConfig:                  oppprof.ReadCLIConfig(ctx),
+		TracingConfig:                optracing.ReadCLIConfig(ctx),
 		DGFAddress:                   ctx.String(flags.DisputeGameFactoryAddressFlag.Name),
 		ProposalInterval:             ctx.Duration(flags.ProposalIntervalFlag.Name),
 		DisputeGameType:              uint32(ctx.Uint(flags.DisputeGameTypeFlag.Name)),
+		DisputeGameMaxBond:           ctx.Float64(flags.DisputeGameMaxBondFlag.Name),
+		MaxGasSpendPerDay:            ctx.Float64(flags.MaxGasSpendPerDayFlag.Name),
+		MaxGasSpendPerWeek:           ctx.Float64(flags.MaxGasSpendPerWeekFlag.Name),
+		MaxBondSpendPerDay:           ctx.Float64(flags.MaxBondSpendPerDayFlag.Name),
+		MaxBondSpendPerWeek:          ctx.Float64(flags.MaxBondSpendPerWeekFlag.Name),
+		SpendFile:                    ctx.String(flags.SpendFileFlag.Name),
+		VerifyRollupRpc:              ctx.String(flags.VerifyRollupRpcFlag.Name),
+		VerifyL2EthRpc:               ctx.String(flags.VerifyL2EthRpcFlag.Name),
+		MaxProposalsPerL1Block:       ctx.Uint64(flags.MaxProposalsPerL1BlockFlag.Name),
+		ProposalTxDeadline:           ctx.Duration(flags.ProposalTxDeadlineFlag.Name),
+		RemediateDivergingProposals:  ctx.Bool(flags.RemediateDivergingProposalsFlag.Name),
+		SignerHealthCheck:            ctx.Bool(flags.SignerHealthCheckFlag.Name),
+		ChainsConfig:                 ctx.String(flags.ChainsConfigFlag.Name),
+		RPCJWTSecret:                 ctx.String(flags.RPCJWTSecretFlag.Name),
 		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
 		WaitNodeSync:                 ctx.Bool(flags.WaitNodeSyncFlag.Name),
+		BondBalanceMinGames:          ctx.Uint64(flags.BondBalanceMinGamesFlag.Name),
+		BondTreasurySignerEndpoint:   ctx.String(flags.BondTreasurySignerEndpointFlag.Name),
+		BondTreasuryAddress:          ctx.String(flags.BondTreasuryAddressFlag.Name),
+		BondTopUpGames:               ctx.Uint64(flags.BondTopUpGamesFlag.Name),
+		BondMaxTopUpPerDay:           ctx.Float64(flags.BondMaxTopUpPerDayFlag.Name),
 	}
 }
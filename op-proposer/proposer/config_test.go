@@ -0,0 +1,60 @@
+package proposer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+func validConfig() *CLIConfig {
+	return &CLIConfig{
+		L1EthRpc:     "http://l1",
+		RollupRpc:    "http://rollup",
+		L2OOAddress:  "0x0000000000000000000000000000000000000001",
+		PollInterval: 6 * time.Second,
+		TxMgrConfig:  txmgr.NewCLIConfig("http://l1", txmgr.DefaultBatcherFlagValues),
+	}
+}
+
+func TestCLIConfig_ProposalSafety(t *testing.T) {
+	cfg := validConfig()
+	for _, safety := range flags.ProposalSafeties {
+		cfg.ProposalSafety = safety
+		require.NoError(t, cfg.Check())
+	}
+
+	cfg.ProposalSafety = "latest"
+	require.ErrorContains(t, cfg.Check(), "unknown proposal safety")
+
+	cfg.ProposalSafety = flags.FinalizedSafety
+	cfg.AllowNonFinalized = true
+	require.ErrorContains(t, cfg.Check(), "non-finalized proposals are allowed")
+}
+
+func TestCLIConfig_BondTreasury(t *testing.T) {
+	cfg := validConfig()
+	cfg.L2OOAddress = ""
+	cfg.DGFAddress = "0x0000000000000000000000000000000000000002"
+	cfg.ProposalInterval = time.Minute
+	cfg.BondBalanceMinGames = 3
+	cfg.BondTopUpGames = 10
+	cfg.BondTreasurySignerEndpoint = "http://treasury-signer"
+	require.ErrorContains(t, cfg.Check(), "must both be set")
+
+	cfg.BondTreasuryAddress = "0x0000000000000000000000000000000000000003"
+	require.ErrorContains(t, cfg.Check(), "max top-up per day was not set")
+
+	cfg.BondMaxTopUpPerDay = 1
+	require.NoError(t, cfg.Check())
+
+	cfg.BondTopUpGames = 3
+	require.ErrorContains(t, cfg.Check(), "top-up games must be more")
+
+	cfg.BondTopUpGames = 10
+	cfg.BondBalanceMinGames = 0
+	require.ErrorContains(t, cfg.Check(), "monitoring is disabled")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -20,6 +21,8 @@ import (
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/health"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -28,6 +31,9 @@ var (
 	ErrProposerNotRunning    = errors.New("proposer is not running")
 )
 
+// proposalTimeout is the time to send a proposal in, including the confirmation of its tx.
+const proposalTimeout = 10 * time.Minute
+
 type L1Client interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	// CodeAt returns the code of the given account. This is needed to differentiate
@@ -37,6 +43,15 @@ type L1Client interface {
 	// CallContract executes an Ethereum contract call with the specified data as the
 	// input.
 	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+
+	// BalanceAt returns the wei balance of the given account. This is needed to
+	// check that the proposer can pay the initial bond of new dispute games.
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+
+	// PendingNonceAt and EstimateGas are needed to send a backlog of proposals
+	// in catch-up mode, see proposeOutputs.
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
 }
 
 type RollupClient interface {
@@ -47,12 +62,19 @@ type RollupClient interface {
 type DriverSetup struct {
 	Log      log.Logger
 	Metr     metrics.Metricer
+	Tracer   optracing.Tracer // optional, defaults to no tracing
 	Cfg      ProposerConfig
 	Txmgr    txmgr.TxManager
 	L1Client L1Client
 
+	// Treasury, if set, tops up the proposer balance for dispute game bonds.
+	Treasury txmgr.TxManager
+
 	// RollupProvider's RollupClient() is used to retrieve output roots from
 	RollupProvider dial.RollupProvider
+
+	// OutputVerifier, if set, cross-verifies output roots before they get proposed.
+	OutputVerifier OutputVerifier
 }
 
 // L2OutputSubmitter is responsible for proposing outputs
@@ -67,6 +89,33 @@ type L2OutputSubmitter struct {
 
 	mutex   sync.Mutex
 	running bool
+	// loopFailed is set when the proposal loop exits before it is stopped, see Liveness
+	loopFailed atomic.Bool
+
+	// paused, interval and intervalUpdated are the runtime controls of the
+	// admin RPC, see admin.go.
+	paused          atomic.Bool
+	interval        atomic.Int64
+	intervalUpdated chan struct{}
+
+	// proposeMu serializes sending proposals of the loop and forced proposals.
+	proposeMu sync.Mutex
+
+	// unfinalized are the proposed outputs of non-finalized L2 blocks, which
+	// are checked for reorgs once finalized, see checkUnfinalizedProposals.
+	unfinalizedMu sync.Mutex
+	unfinalized   []*eth.OutputResponse
+
+	// budget enforces the spend limits, nil if there are none.
+	budget *spendBudget
+
+	// proposals tracks the lifecycle of the recent proposals.
+	proposals *proposalTracker
+
+	// toppingUp is set while a top-up from the bond treasury is in flight.
+	toppingUp atomic.Bool
+	// topUps are the top-ups sent within the last day, see checkBondFunding.
+	topUps []topUpRecord
 
 	l2ooContract *bindings.L2OutputOracleCaller
 	l2ooABI      *abi.ABI
@@ -87,6 +136,9 @@ func NewL2OutputSubmitter(setup DriverSetup) (_ *L2OutputSubmitter, err error) {
 		}
 	}()
 
+	if setup.Tracer == nil {
+		setup.Tracer = optracing.NoopTracer{}
+	}
 	if setup.Cfg.L2OutputOracleAddr != nil {
 		return newL2OOSubmitter(ctx, cancel, setup)
 	} else if setup.Cfg.DisputeGameFactoryAddr != nil {
@@ -117,12 +169,20 @@ func newL2OOSubmitter(ctx context.Context, cancel context.CancelFunc, setup Driv
 		cancel()
 		return nil, err
 	}
+	budget, err := newSpendBudget(setup.Cfg.SpendLimits)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
 
 	return &L2OutputSubmitter{
-		DriverSetup: setup,
-		done:        make(chan struct{}),
-		ctx:         ctx,
-		cancel:      cancel,
+		DriverSetup:     setup,
+		done:            make(chan struct{}),
+		ctx:             ctx,
+		cancel:          cancel,
+		intervalUpdated: make(chan struct{}, 1),
+		budget:          budget,
+		proposals:       newProposalTracker(setup.Metr),
 
 		l2ooContract: l2ooContract,
 		l2ooABI:      parsed,
@@ -145,17 +205,36 @@ func newDGFSubmitter(ctx context.Context, cancel context.CancelFunc, setup Drive
 	}
 	log.Info("Connected to DisputeGameFactory", "address", setup.Cfg.DisputeGameFactoryAddr, "version", version)
 
+	gameImpl, err := dgfCaller.GameImpls(&bind.CallOpts{Context: cCtx}, setup.Cfg.DisputeGameType)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to fetch implementation of game type %d: %w", setup.Cfg.DisputeGameType, err)
+	}
+	if gameImpl == (common.Address{}) {
+		cancel()
+		return nil, fmt.Errorf("DisputeGameFactory has no implementation for game type %d", setup.Cfg.DisputeGameType)
+	}
+	log.Info("Using dispute game implementation", "game_type", setup.Cfg.DisputeGameType, "impl", gameImpl)
+
 	parsed, err := bindings.DisputeGameFactoryMetaData.GetAbi()
 	if err != nil {
 		cancel()
 		return nil, err
 	}
+	budget, err := newSpendBudget(setup.Cfg.SpendLimits)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
 
 	return &L2OutputSubmitter{
-		DriverSetup: setup,
-		done:        make(chan struct{}),
-		ctx:         ctx,
-		cancel:      cancel,
+		DriverSetup:     setup,
+		done:            make(chan struct{}),
+		ctx:             ctx,
+		cancel:          cancel,
+		intervalUpdated: make(chan struct{}, 1),
+		budget:          budget,
+		proposals:       newProposalTracker(setup.Metr),
 
 		dgfContract: dgfCaller,
 		dgfABI:      parsed,
@@ -173,6 +252,7 @@ func (l *L2OutputSubmitter) StartL2OutputSubmitting() error {
 	}
 	l.running = true
 
+	l.loopFailed.Store(false)
 	l.wg.Add(1)
 	go l.loop()
 
@@ -207,6 +287,15 @@ func (l *L2OutputSubmitter) StopL2OutputSubmitting() error {
 	return nil
 }
 
+// Liveness is the liveness probe of the proposal loop, which is failing if the loop exited while the
+// proposer is running, e.g. after failing to wait for the node to sync, and no outputs are proposed anymore.
+func (l *L2OutputSubmitter) Liveness(ctx context.Context) health.Result {
+	if l.loopFailed.Load() {
+		return health.Failing("proposal loop exited")
+	}
+	return health.OK()
+}
+
 // FetchNextOutputInfo gets the block number of the next proposal.
 // It returns: the next block number, if the proposal should be made, error
 func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.OutputResponse, bool, error) {
@@ -240,8 +329,8 @@ func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.Outpu
 	return l.FetchOutput(ctx, nextCheckpointBlock)
 }
 
-// FetchCurrentBlockNumber gets the current block number from the [L2OutputSubmitter]'s [RollupClient]. If the `AllowNonFinalized` configuration
-// option is set, it will return the safe head block number, and if not, it will return the finalized head block number.
+// FetchCurrentBlockNumber gets the current block number from the [L2OutputSubmitter]'s [RollupClient]. It returns the number
+// of the unsafe, safe or finalized head block, depending on the configured proposal safety.
 func (l *L2OutputSubmitter) FetchCurrentBlockNumber(ctx context.Context) (*big.Int, error) {
 	rollupClient, err := l.RollupProvider.RollupClient(ctx)
 	if err != nil {
@@ -258,14 +347,8 @@ func (l *L2OutputSubmitter) FetchCurrentBlockNumber(ctx context.Context) (*big.I
 		return nil, err
 	}
 
-	// Use either the finalized or safe head depending on the config. Finalized head is default & safer.
-	var currentBlockNumber *big.Int
-	if l.Cfg.AllowNonFinalized {
-		currentBlockNumber = new(big.Int).SetUint64(status.SafeL2.Number)
-	} else {
-		currentBlockNumber = new(big.Int).SetUint64(status.FinalizedL2.Number)
-	}
-	return currentBlockNumber, nil
+	// Use the finalized, safe or unsafe head depending on the config. Finalized head is default & safer.
+	return new(big.Int).SetUint64(proposalHead(status, l.Cfg.Safety()).Number), nil
 }
 
 func (l *L2OutputSubmitter) FetchOutput(ctx context.Context, block *big.Int) (*eth.OutputResponse, bool, error) {
@@ -292,13 +375,14 @@ func (l *L2OutputSubmitter) FetchOutput(ctx context.Context, block *big.Int) (*e
 		return nil, false, errors.New("invalid blockNumber")
 	}
 
-	// Always propose if it's part of the Finalized L2 chain. Or if allowed, if it's part of the safe L2 chain.
-	if output.BlockRef.Number > output.Status.FinalizedL2.Number && (!l.Cfg.AllowNonFinalized || output.BlockRef.Number > output.Status.SafeL2.Number) {
+	// Propose if it's part of the L2 chain up to the head of the configured safety. Finalized head is default & safer.
+	if output.BlockRef.Number > proposalHead(output.Status, l.Cfg.Safety()).Number {
 		l.Log.Debug("Not proposing yet, L2 block is not ready for proposal",
 			"l2_proposal", output.BlockRef,
+			"l2_unsafe", output.Status.UnsafeL2,
 			"l2_safe", output.Status.SafeL2,
 			"l2_finalized", output.Status.FinalizedL2,
-			"allow_non_finalized", l.Cfg.AllowNonFinalized)
+			"proposal_safety", l.Cfg.Safety())
 		return nil, false, nil
 	}
 	return output, true, nil
@@ -333,7 +417,58 @@ func (l *L2OutputSubmitter) ProposeL2OutputDGFTxData(output *eth.OutputResponse)
 
 // proposeL2OutputDGFTxData creates the transaction data for the DisputeGameFactory's `create` function
 func proposeL2OutputDGFTxData(abi *abi.ABI, gameType uint32, output *eth.OutputResponse) ([]byte, error) {
-	return abi.Pack("create", gameType, output.OutputRoot, math.U256Bytes(new(big.Int).SetUint64(output.BlockRef.Number)))
+	return abi.Pack("create", gameType, output.OutputRoot, dgfExtraData(output))
+}
+
+// dgfExtraData returns the extra data of the dispute game of an output, which
+// is the L2 block number of the output.
+func dgfExtraData(output *eth.OutputResponse) []byte {
+	return math.U256Bytes(new(big.Int).SetUint64(output.BlockRef.Number))
+}
+
+// checkDGFProposal checks whether a dispute game should be created for the
+// output, and returns the initial bond to pay for it. It returns false if a game
+// for the output already exists. The bond must not exceed the configured max
+// bond and the proposer's balance.
+func (l *L2OutputSubmitter) checkDGFProposal(ctx context.Context, output *eth.OutputResponse) (*big.Int, bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	callOpts := &bind.CallOpts{From: l.Txmgr.From(), Context: cCtx}
+
+	game, err := l.dgfContract.Games(callOpts, l.Cfg.DisputeGameType, output.OutputRoot, dgfExtraData(output))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up existing dispute game: %w", err)
+	}
+	if game.Proxy != (common.Address{}) {
+		l.Log.Info("Dispute game for output already exists, not proposing",
+			"game", game.Proxy, "output", output.OutputRoot, "block", output.BlockRef)
+		return nil, false, nil
+	}
+
+	bond, err := l.dgfContract.InitBonds(callOpts, l.Cfg.DisputeGameType)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch initial bond: %w", err)
+	}
+	balance, err := l.L1Client.BalanceAt(cCtx, l.Txmgr.From(), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch proposer balance: %w", err)
+	}
+	if err := checkBond(bond, l.Cfg.DisputeGameMaxBond, balance); err != nil {
+		return nil, false, err
+	}
+	return bond, true, nil
+}
+
+// checkBond checks that the initial bond of a dispute game doesn't exceed the
+// max bond, if set, and the proposer's balance.
+func checkBond(bond, maxBond, balance *big.Int) error {
+	if maxBond != nil && bond.Cmp(maxBond) > 0 {
+		return fmt.Errorf("initial bond %v exceeds max bond %v", bond, maxBond)
+	}
+	if balance.Cmp(bond) < 0 {
+		return fmt.Errorf("proposer balance %v is less than initial bond %v", balance, bond)
+	}
+	return nil
 }
 
 // We wait until l1head advances beyond blocknum. This is used to make sure proposal tx won't
@@ -364,42 +499,74 @@ func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64)
 	return nil
 }
 
-// sendTransaction creates & sends transactions through the underlying transaction manager.
-func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse) error {
+// sendTransaction creates & sends transactions through the underlying transaction manager,
+// unless a spend limit is reached. The lifecycle of the proposal p is tracked up to its confirmation. Failures
+// must be tracked by the caller.
+func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse, p *proposal) error {
 	err := l.waitForL1Head(ctx, output.Status.HeadL1.Number+1)
 	if err != nil {
 		return err
 	}
+	if err := l.checkOutputCanonical(ctx, output); err != nil {
+		return err
+	}
+	if err := l.checkBudget(); err != nil {
+		return err
+	}
 
 	l.Log.Info("Proposing output root", "output", output.OutputRoot, "block", output.BlockRef)
 	var receipt *types.Receipt
 	if l.Cfg.DisputeGameFactoryAddr != nil {
-		data, bond, err := l.ProposeL2OutputDGFTxData(output)
+		bond, shouldPropose, err := l.checkDGFProposal(ctx, output)
+		if err != nil {
+			return err
+		}
+		if !shouldPropose {
+			l.proposals.skipped(p)
+			return nil
+		}
+		data, err := proposeL2OutputDGFTxData(l.dgfABI, l.Cfg.DisputeGameType, output)
 		if err != nil {
 			return err
 		}
+		l.proposals.submitted(p)
 		receipt, err = l.Txmgr.Send(ctx, txmgr.TxCandidate{
 			TxData:   data,
 			To:       l.Cfg.DisputeGameFactoryAddr,
 			GasLimit: 0,
 			Value:    bond,
+			Deadline: l.proposalTxDeadline(),
 		})
 		if err != nil {
+			l.recordAbandoned(err)
 			return err
 		}
+		l.proposals.confirmed(p, receipt)
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			l.Metr.RecordDisputeGameCreated(l.Cfg.DisputeGameType, bond)
+			l.recordSpend(receipt, bond)
+			l.recordGameCreated(ctx, p, output)
+		} else {
+			l.recordSpend(receipt, nil) // the bond is refunded on revert
+		}
 	} else {
 		data, err := l.ProposeL2OutputTxData(output)
 		if err != nil {
 			return err
 		}
+		l.proposals.submitted(p)
 		receipt, err = l.Txmgr.Send(ctx, txmgr.TxCandidate{
 			TxData:   data,
 			To:       l.Cfg.L2OutputOracleAddr,
 			GasLimit: 0,
+			Deadline: l.proposalTxDeadline(),
 		})
 		if err != nil {
+			l.recordAbandoned(err)
 			return err
 		}
+		l.proposals.confirmed(p, receipt)
+		l.recordSpend(receipt, nil)
 	}
 
 	if receipt.Status == types.ReceiptStatusFailed {
@@ -413,6 +580,34 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 	return nil
 }
 
+// proposalTxDeadline returns the deadline of a proposal tx that is sent now,
+// or the zero time if proposal txs have no deadline.
+func (l *L2OutputSubmitter) proposalTxDeadline() time.Time {
+	if l.Cfg.ProposalTxDeadline == 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(l.Cfg.ProposalTxDeadline)
+}
+
+// recordAbandoned logs a proposal tx that was abandoned after its deadline,
+// and records the spend of its cancellation. The output is not recorded as
+// proposed, so it is proposed again by the driver loop.
+func (l *L2OutputSubmitter) recordAbandoned(err error) {
+	var abandoned *txmgr.AbandonedError
+	if !errors.As(err, &abandoned) {
+		return
+	}
+	if abandoned.Cancelled() {
+		l.recordSpend(abandoned.CancellationReceipt, nil)
+		l.Log.Warn("Proposal tx abandoned after its deadline and cancelled, proposing again",
+			"tx_hash", abandoned.Tx.Hash(), "cancellation", abandoned.CancellationReceipt.TxHash)
+		return
+	}
+	// The next proposal is skipped or reverts if the abandoned tx confirms after all.
+	l.Log.Warn("Proposal tx abandoned after its deadline, but its cancellation did not confirm, it may still confirm",
+		"tx_hash", abandoned.Tx.Hash(), "err", err)
+}
+
 // loop is responsible for creating & submitting the next outputs
 func (l *L2OutputSubmitter) loop() {
 	defer l.wg.Done()
@@ -422,6 +617,7 @@ func (l *L2OutputSubmitter) loop() {
 		err := l.waitNodeSync()
 		if err != nil {
 			l.Log.Error("Error waiting for node sync", "err", err)
+			l.loopFailed.Store(ctx.Err() == nil)
 			return
 		}
 	}
@@ -451,11 +647,31 @@ func (l *L2OutputSubmitter) waitNodeSync() error {
 }
 
 func (l *L2OutputSubmitter) loopL2OO(ctx context.Context) {
-	ticker := time.NewTicker(l.Cfg.PollInterval)
+	ticker := time.NewTicker(l.proposalInterval())
 	defer ticker.Stop()
 	for {
 		select {
+		case <-l.intervalUpdated:
+			ticker.Reset(l.proposalInterval())
 		case <-ticker.C:
+			l.checkUnfinalizedProposals(ctx)
+			if l.paused.Load() {
+				l.Log.Debug("Proposer is paused, skipping proposal")
+				break
+			}
+			if l.Cfg.MaxProposalsPerL1Block > 1 {
+				outputs, err := l.FetchNextOutputs(ctx, l.Cfg.MaxProposalsPerL1Block)
+				if err != nil || len(outputs) == 0 {
+					break
+				}
+				if len(outputs) == 1 {
+					l.proposeOutput(ctx, outputs[0])
+				} else {
+					l.proposeOutputs(ctx, outputs)
+				}
+				break
+			}
+
 			output, shouldPropose, err := l.FetchNextOutputInfo(ctx)
 			if err != nil || !shouldPropose {
 				break
@@ -469,11 +685,20 @@ func (l *L2OutputSubmitter) loopL2OO(ctx context.Context) {
 }
 
 func (l *L2OutputSubmitter) loopDGF(ctx context.Context) {
-	ticker := time.NewTicker(l.Cfg.ProposalInterval)
+	ticker := time.NewTicker(l.proposalInterval())
 	defer ticker.Stop()
 	for {
 		select {
+		case <-l.intervalUpdated:
+			ticker.Reset(l.proposalInterval())
 		case <-ticker.C:
+			l.checkUnfinalizedProposals(ctx)
+			l.updateGameStatuses(ctx)
+			l.checkBondFunding(ctx)
+			if l.paused.Load() {
+				l.Log.Debug("Proposer is paused, skipping proposal")
+				break
+			}
 			blockNumber, err := l.FetchCurrentBlockNumber(ctx)
 			if err != nil {
 				break
@@ -491,11 +716,50 @@ func (l *L2OutputSubmitter) loopDGF(ctx context.Context) {
 	}
 }
 
+// verifyOutput cross-verifies the output with the OutputVerifier, if set. The
+// output must not be proposed if an error is returned.
+func (l *L2OutputSubmitter) verifyOutput(ctx context.Context, output *eth.OutputResponse) error {
+	if l.OutputVerifier == nil {
+		return nil
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	err := l.OutputVerifier.VerifyOutput(cCtx, output)
+	switch {
+	case errors.Is(err, ErrOutputMismatch):
+		l.Log.Error("Output root cross-verification failed, refusing to propose",
+			"err", err, "output", output.OutputRoot, "block", output.BlockRef)
+		l.Metr.RecordOutputVerification(metrics.VerificationMismatch)
+	case err != nil:
+		l.Log.Warn("Unable to cross-verify output root, not proposing",
+			"err", err, "output", output.OutputRoot, "block", output.BlockRef)
+		l.Metr.RecordOutputVerification(metrics.VerificationError)
+	default:
+		l.Log.Debug("Output root cross-verified", "output", output.OutputRoot, "block", output.BlockRef)
+		l.Metr.RecordOutputVerification(metrics.VerificationMatch)
+	}
+	return err
+}
+
 func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.OutputResponse) {
-	cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	ctx, span := l.Tracer.Start(ctx, "proposer.propose_output",
+		optracing.Uint64("l2.block", output.BlockRef.Number),
+		optracing.Uint64("l1.block", output.Status.CurrentL1.Number))
+	defer span.End()
+	p := l.proposals.created(output)
+	if err := l.verifyOutput(ctx, output); err != nil {
+		span.RecordError(err)
+		l.proposals.failed(p, err)
+		return
+	}
+	cCtx, cancel := context.WithTimeout(ctx, proposalTimeout)
 	defer cancel()
 
-	if err := l.sendTransaction(cCtx, output); err != nil {
+	l.proposeMu.Lock()
+	defer l.proposeMu.Unlock()
+	if err := l.sendTransaction(cCtx, output, p); err != nil {
+		span.RecordError(err)
+		l.proposals.failed(p, err)
 		l.Log.Error("Failed to send proposal transaction",
 			"err", err,
 			"l1blocknum", output.Status.CurrentL1.Number,
@@ -503,5 +767,5 @@ func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.Outpu
 			"l1head", output.Status.HeadL1.Number)
 		return
 	}
-	l.Metr.RecordL2BlocksProposed(output.BlockRef)
+	l.recordProposed(output)
 }
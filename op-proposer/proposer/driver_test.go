@@ -0,0 +1,25 @@
+package proposer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBond(t *testing.T) {
+	bond := big.NewInt(100)
+	require.NoError(t, checkBond(bond, nil, big.NewInt(100)))
+	require.NoError(t, checkBond(bond, big.NewInt(100), big.NewInt(1000)))
+	require.ErrorContains(t, checkBond(bond, big.NewInt(99), big.NewInt(1000)), "initial bond 100 exceeds max bond 99")
+	require.ErrorContains(t, checkBond(bond, nil, big.NewInt(99)), "proposer balance 99 is less than initial bond 100")
+}
+
+func TestBondFunding(t *testing.T) {
+	bond := big.NewInt(100)
+	require.Equal(t, uint64(0), bondableGames(big.NewInt(99), bond))
+	require.Equal(t, uint64(3), bondableGames(big.NewInt(350), bond))
+
+	require.Equal(t, big.NewInt(650), topUpAmount(big.NewInt(350), bond, 10))
+	require.Zero(t, topUpAmount(big.NewInt(1000), bond, 10).Sign())
+}
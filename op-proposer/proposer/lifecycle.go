@@ -0,0 +1,254 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// proposalHistorySize is the number of recent proposals to keep track of. It
+// must cover the dispute game resolution period, so that the resolution of
+// the games can be tracked.
+const proposalHistorySize = 256
+
+// gameStatusSelector is the selector of the status() method of dispute games.
+var gameStatusSelector = []byte{0x20, 0x0d, 0x2e, 0xd2}
+
+// proposal is a tracked proposal.
+type proposal struct {
+	info      rpc.ProposalInfo
+	created   time.Time
+	submitted time.Time
+	confirmed time.Time
+}
+
+// proposalTracker tracks the lifecycle of the recent proposals and records
+// the latencies and failures of its stages.
+type proposalTracker struct {
+	mu      sync.Mutex
+	metr    metrics.Metricer
+	history []*proposal
+	now     func() time.Time
+}
+
+func newProposalTracker(metr metrics.Metricer) *proposalTracker {
+	return &proposalTracker{metr: metr, now: time.Now}
+}
+
+// created starts tracking a proposal of the output.
+func (t *proposalTracker) created(output *eth.OutputResponse) *proposal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	p := &proposal{
+		info: rpc.ProposalInfo{
+			L2Block:    output.BlockRef.ID(),
+			OutputRoot: output.OutputRoot,
+			Status:     rpc.ProposalCreated,
+			CreatedAt:  hexutil.Uint64(now.Unix()),
+		},
+		created: now,
+	}
+	if len(t.history) == proposalHistorySize {
+		t.history[0] = nil
+		t.history = t.history[1:]
+	}
+	t.history = append(t.history, p)
+	return p
+}
+
+// submitted marks the proposal as sent to the tx manager.
+func (t *proposalTracker) submitted(p *proposal) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p.submitted = t.now()
+	p.info.Status = rpc.ProposalSubmitted
+	p.info.SubmittedAt = hexutil.Uint64(p.submitted.Unix())
+	t.metr.RecordProposalStage(metrics.StageSubmission, p.submitted.Sub(p.created))
+}
+
+// confirmed marks the proposal as included in L1. A reverted tx fails the
+// proposal.
+func (t *proposalTracker) confirmed(p *proposal, receipt *types.Receipt) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p.info.TxHash = receipt.TxHash
+	p.info.InclusionBlock.Hash = receipt.BlockHash
+	if receipt.BlockNumber != nil {
+		p.info.InclusionBlock.Number = receipt.BlockNumber.Uint64()
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		t.failLocked(p, fmt.Errorf("proposal tx %s reverted", receipt.TxHash))
+		return
+	}
+	p.confirmed = t.now()
+	p.info.Status = rpc.ProposalConfirmed
+	p.info.ConfirmedAt = hexutil.Uint64(p.confirmed.Unix())
+	t.metr.RecordProposalStage(metrics.StageConfirmation, p.confirmed.Sub(p.submitted))
+}
+
+// failed marks the proposal as failed. The failure is counted for the stage
+// that the proposal didn't reach.
+func (t *proposalTracker) failed(p *proposal, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failLocked(p, err)
+}
+
+func (t *proposalTracker) failLocked(p *proposal, err error) {
+	stage := metrics.StageSubmission
+	if p.info.Status == rpc.ProposalSubmitted {
+		stage = metrics.StageConfirmation
+	}
+	p.info.Status = rpc.ProposalFailed
+	p.info.Error = err.Error()
+	t.metr.RecordProposalFailure(stage)
+}
+
+// skipped marks the proposal as not sent, because its dispute game exists.
+func (t *proposalTracker) skipped(p *proposal) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p.info.Status = rpc.ProposalSkipped
+}
+
+// gameCreated sets the address of the dispute game created by the proposal.
+func (t *proposalTracker) gameCreated(p *proposal, game common.Address) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p.info.GameAddress = &game
+	p.info.GameStatus = rpc.GameInProgress
+}
+
+// gameResolved records the resolution of the dispute game of the proposal.
+func (t *proposalTracker) gameResolved(p *proposal, status rpc.GameStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	p.info.GameStatus = status
+	p.info.ResolvedAt = hexutil.Uint64(now.Unix())
+	t.metr.RecordProposalStage(metrics.StageResolution, now.Sub(p.confirmed))
+	t.metr.RecordDisputeGameResolved(string(status))
+}
+
+// diverged marks the proposal as diverging from the finalized output.
+func (t *proposalTracker) diverged(p *proposal) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p.info.Diverged = true
+}
+
+// confirmedAt returns the latest confirmed proposal of the L2 block and a copy
+// of its info, or nil if there's none.
+func (t *proposalTracker) confirmedAt(block uint64) (*proposal, rpc.ProposalInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := len(t.history) - 1; i >= 0; i-- {
+		p := t.history[i]
+		if p.info.L2Block.Number == block && p.info.Status == rpc.ProposalConfirmed {
+			return p, p.info
+		}
+	}
+	return nil, rpc.ProposalInfo{}
+}
+
+type trackedGame struct {
+	p       *proposal
+	game    common.Address
+	l2Block eth.BlockID
+}
+
+// inProgressGames returns the unresolved dispute games of the tracked proposals.
+func (t *proposalTracker) inProgressGames() []trackedGame {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var games []trackedGame
+	for _, p := range t.history {
+		if p.info.GameAddress != nil && p.info.GameStatus == rpc.GameInProgress {
+			games = append(games, trackedGame{p: p, game: *p.info.GameAddress, l2Block: p.info.L2Block})
+		}
+	}
+	return games
+}
+
+// infos returns copies of the tracked proposals, oldest first.
+func (t *proposalTracker) infos() []rpc.ProposalInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	infos := make([]rpc.ProposalInfo, 0, len(t.history))
+	for _, p := range t.history {
+		infos = append(infos, p.info)
+	}
+	return infos
+}
+
+// Proposals returns the recent proposals, oldest first.
+func (l *L2OutputSubmitter) Proposals(_ context.Context) ([]rpc.ProposalInfo, error) {
+	return l.proposals.infos(), nil
+}
+
+// recordGameCreated looks up the dispute game created by the proposal of the
+// output, to track its resolution.
+func (l *L2OutputSubmitter) recordGameCreated(ctx context.Context, p *proposal, output *eth.OutputResponse) {
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	callOpts := &bind.CallOpts{From: l.Txmgr.From(), Context: cCtx}
+	game, err := l.dgfContract.Games(callOpts, l.Cfg.DisputeGameType, output.OutputRoot, dgfExtraData(output))
+	if err != nil {
+		l.Log.Warn("Failed to look up created dispute game", "err", err, "block", output.BlockRef)
+		return
+	}
+	l.Log.Info("Created dispute game", "game", game.Proxy, "block", output.BlockRef)
+	l.proposals.gameCreated(p, game.Proxy)
+}
+
+// updateGameStatuses checks whether the dispute games of the tracked proposals
+// got resolved.
+func (l *L2OutputSubmitter) updateGameStatuses(ctx context.Context) {
+	for _, g := range l.proposals.inProgressGames() {
+		status, err := l.fetchGameStatus(ctx, g.game)
+		if err != nil {
+			l.Log.Warn("Failed to fetch dispute game status", "err", err, "game", g.game)
+			continue
+		}
+		if status == rpc.GameInProgress {
+			continue
+		}
+		l.Log.Info("Dispute game resolved", "game", g.game, "status", status, "block", g.l2Block)
+		l.proposals.gameResolved(g.p, status)
+	}
+}
+
+func (l *L2OutputSubmitter) fetchGameStatus(ctx context.Context, game common.Address) (rpc.GameStatus, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	res, err := l.L1Client.CallContract(cCtx, ethereum.CallMsg{To: &game, Data: gameStatusSelector}, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(res) != 32 {
+		return "", fmt.Errorf("invalid status result length %d", len(res))
+	}
+	switch new(big.Int).SetBytes(res).Uint64() {
+	case 0:
+		return rpc.GameInProgress, nil
+	case 1:
+		return rpc.GameChallengerWins, nil
+	case 2:
+		return rpc.GameDefenderWins, nil
+	default:
+		return "", fmt.Errorf("unknown game status %x", res)
+	}
+}
@@ -0,0 +1,192 @@
+package proposer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type lifecycleMetrics struct {
+	metrics.Metricer
+	stages   map[string][]time.Duration
+	failures map[string]int
+	resolved map[string]int
+}
+
+func newLifecycleMetrics() *lifecycleMetrics {
+	return &lifecycleMetrics{
+		Metricer: metrics.NoopMetrics,
+		stages:   make(map[string][]time.Duration),
+		failures: make(map[string]int),
+		resolved: make(map[string]int),
+	}
+}
+
+func (m *lifecycleMetrics) RecordProposalStage(stage string, latency time.Duration) {
+	m.stages[stage] = append(m.stages[stage], latency)
+}
+
+func (m *lifecycleMetrics) RecordProposalFailure(stage string) { m.failures[stage]++ }
+
+func (m *lifecycleMetrics) RecordDisputeGameResolved(status string) { m.resolved[status]++ }
+
+func setupTracker() (*proposalTracker, *lifecycleMetrics, *time.Time) {
+	m := newLifecycleMetrics()
+	tracker := newProposalTracker(m)
+	now := time.Unix(1000, 0)
+	tracker.now = func() time.Time { return now }
+	return tracker, m, &now
+}
+
+func TestProposalTracker_Lifecycle(t *testing.T) {
+	tracker, m, now := setupTracker()
+	output := &eth.OutputResponse{OutputRoot: eth.Bytes32{0x01}, BlockRef: eth.L2BlockRef{Number: 10}}
+
+	p := tracker.created(output)
+	*now = now.Add(2 * time.Second)
+	tracker.submitted(p)
+	*now = now.Add(24 * time.Second)
+	tracker.confirmed(p, &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      common.Hash{0xaa},
+		BlockHash:   common.Hash{0xbb},
+		BlockNumber: big.NewInt(5),
+	})
+	game := common.Address{0x0f}
+	tracker.gameCreated(p, game)
+	require.Equal(t, []trackedGame{{p: p, game: game, l2Block: output.BlockRef.ID()}}, tracker.inProgressGames())
+	*now = now.Add(week)
+	tracker.gameResolved(p, rpc.GameDefenderWins)
+
+	require.Equal(t, []time.Duration{2 * time.Second}, m.stages[metrics.StageSubmission])
+	require.Equal(t, []time.Duration{24 * time.Second}, m.stages[metrics.StageConfirmation])
+	require.Equal(t, []time.Duration{week}, m.stages[metrics.StageResolution])
+	require.Equal(t, 1, m.resolved[string(rpc.GameDefenderWins)])
+	require.Empty(t, m.failures)
+	require.Empty(t, tracker.inProgressGames())
+
+	infos := tracker.infos()
+	require.Len(t, infos, 1)
+	info := infos[0]
+	require.Equal(t, rpc.ProposalConfirmed, info.Status)
+	require.EqualValues(t, 1000, info.CreatedAt)
+	require.EqualValues(t, 1002, info.SubmittedAt)
+	require.EqualValues(t, 1026, info.ConfirmedAt)
+	require.Equal(t, common.Hash{0xaa}, info.TxHash)
+	require.Equal(t, eth.BlockID{Hash: common.Hash{0xbb}, Number: 5}, info.InclusionBlock)
+	require.Equal(t, &game, info.GameAddress)
+	require.Equal(t, rpc.GameDefenderWins, info.GameStatus)
+	require.EqualValues(t, 1026+week/time.Second, info.ResolvedAt)
+}
+
+func TestProposalTracker_Failures(t *testing.T) {
+	tracker, m, _ := setupTracker()
+	output := &eth.OutputResponse{BlockRef: eth.L2BlockRef{Number: 10}}
+
+	tracker.failed(tracker.created(output), errors.New("verification failed"))
+	require.Equal(t, 1, m.failures[metrics.StageSubmission])
+
+	p := tracker.created(output)
+	tracker.submitted(p)
+	tracker.failed(p, errors.New("send failed"))
+	require.Equal(t, 1, m.failures[metrics.StageConfirmation])
+
+	p = tracker.created(output)
+	tracker.submitted(p)
+	tracker.confirmed(p, &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(5)})
+	require.Equal(t, 2, m.failures[metrics.StageConfirmation], "reverted tx")
+	require.Len(t, m.stages[metrics.StageConfirmation], 0)
+
+	tracker.skipped(tracker.created(output))
+	require.Equal(t, 1, m.failures[metrics.StageSubmission])
+
+	infos := tracker.infos()
+	require.Len(t, infos, 4)
+	require.Equal(t, rpc.ProposalFailed, infos[0].Status)
+	require.Equal(t, "verification failed", infos[0].Error)
+	require.Equal(t, rpc.ProposalFailed, infos[1].Status)
+	require.Equal(t, rpc.ProposalFailed, infos[2].Status)
+	require.Equal(t, rpc.ProposalSkipped, infos[3].Status)
+}
+
+func TestProposalTracker_HistorySize(t *testing.T) {
+	tracker, _, _ := setupTracker()
+	for i := 0; i < proposalHistorySize+10; i++ {
+		tracker.created(&eth.OutputResponse{BlockRef: eth.L2BlockRef{Number: uint64(i)}})
+	}
+	infos := tracker.infos()
+	require.Len(t, infos, proposalHistorySize)
+	require.Equal(t, uint64(10), infos[0].L2Block.Number, "oldest proposals dropped")
+	require.Equal(t, uint64(proposalHistorySize+9), infos[len(infos)-1].L2Block.Number)
+}
+
+// gameStatusL1Client returns the status of the games in statuses.
+type gameStatusL1Client struct {
+	L1Client
+	statuses map[common.Address]uint64
+}
+
+func (c *gameStatusL1Client) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	if !bytes.Equal(msg.Data, gameStatusSelector) {
+		return nil, errors.New("unexpected call")
+	}
+	status, ok := c.statuses[*msg.To]
+	if !ok {
+		return nil, errors.New("unknown game")
+	}
+	return common.LeftPadBytes(new(big.Int).SetUint64(status).Bytes(), 32), nil
+}
+
+func TestL2OutputSubmitter_UpdateGameStatuses(t *testing.T) {
+	tracker, m, _ := setupTracker()
+	l1 := &gameStatusL1Client{statuses: map[common.Address]uint64{}}
+	l := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:      testlog.Logger(t, log.LevelCrit),
+			Metr:     m,
+			Cfg:      ProposerConfig{NetworkTimeout: time.Second},
+			L1Client: l1,
+		},
+		proposals: tracker,
+	}
+
+	games := []common.Address{{0x01}, {0x02}, {0x03}, {0x04}}
+	for i, game := range games {
+		p := tracker.created(&eth.OutputResponse{BlockRef: eth.L2BlockRef{Number: uint64(i)}})
+		tracker.submitted(p)
+		tracker.confirmed(p, &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)})
+		tracker.gameCreated(p, game)
+	}
+	l1.statuses[games[0]] = 0
+	l1.statuses[games[1]] = 1
+	l1.statuses[games[2]] = 2
+	// games[3] fails to be fetched and stays in progress.
+
+	l.updateGameStatuses(context.Background())
+	infos := l.proposals.infos()
+	require.Equal(t, rpc.GameInProgress, infos[0].GameStatus)
+	require.Equal(t, rpc.GameChallengerWins, infos[1].GameStatus)
+	require.Equal(t, rpc.GameDefenderWins, infos[2].GameStatus)
+	require.Equal(t, rpc.GameInProgress, infos[3].GameStatus)
+	require.Equal(t, 1, m.resolved[string(rpc.GameChallengerWins)])
+	require.Equal(t, 1, m.resolved[string(rpc.GameDefenderWins)])
+	require.Len(t, tracker.inProgressGames(), 2)
+
+	l1.statuses[games[0]] = 3
+	_, err := l.fetchGameStatus(context.Background(), games[0])
+	require.ErrorContains(t, err, "unknown game status")
+}
@@ -0,0 +1,112 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// ErrOutputMismatch is returned by an OutputVerifier if the output root to
+// propose doesn't match the output root of the independent source.
+var ErrOutputMismatch = errors.New("output root mismatch")
+
+// OutputVerifier verifies output roots against an independent source before
+// they get proposed.
+type OutputVerifier interface {
+	// VerifyOutput returns an error wrapping ErrOutputMismatch if the output
+	// root doesn't match, or another error if it couldn't be verified.
+	VerifyOutput(ctx context.Context, output *eth.OutputResponse) error
+	Close()
+}
+
+// rollupOutputVerifier verifies output roots against a second rollup node,
+// which should be backed by its own execution engine.
+type rollupOutputVerifier struct {
+	client *sources.RollupClient
+}
+
+func newRollupOutputVerifier(ctx context.Context, lgr log.Logger, url string) (*rollupOutputVerifier, error) {
+	rollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, lgr, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial verification rollup RPC: %w", err)
+	}
+	return &rollupOutputVerifier{client: rollupClient}, nil
+}
+
+func (v *rollupOutputVerifier) VerifyOutput(ctx context.Context, output *eth.OutputResponse) error {
+	expected, err := v.client.OutputAtBlock(ctx, output.BlockRef.Number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch output at block %d from verification rollup node: %w", output.BlockRef.Number, err)
+	}
+	return compareOutputs(output, expected.OutputRoot, expected.BlockRef.Hash)
+}
+
+func (v *rollupOutputVerifier) Close() {
+	v.client.Close()
+}
+
+// L2OutputRootVerifier recomputes output roots from the state of an L2 execution client.
+type L2OutputRootVerifier interface {
+	VerifyOutputRoot(ctx context.Context, block eth.BlockID, outputRoot eth.Bytes32) (*eth.OutputV0, error)
+}
+
+// l2OutputVerifier verifies output roots by recomputing them from the state of
+// an L2 archive node.
+type l2OutputVerifier struct {
+	client L2OutputRootVerifier
+	close  func()
+}
+
+func newL2OutputVerifier(ctx context.Context, lgr log.Logger, url string, rollupCfg *rollup.Config) (*l2OutputVerifier, error) {
+	rpcClient, err := client.NewRPC(ctx, lgr, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial verification L2 RPC: %w", err)
+	}
+	clCfg := sources.L2ClientDefaultConfig(rollupCfg, false)
+	clCfg.ReceiptsCacheSize = 1
+	clCfg.TransactionsCacheSize = 1
+	clCfg.PayloadsCacheSize = 1
+	l2Client, err := sources.NewL2Client(rpcClient, lgr, nil, clCfg)
+	if err != nil {
+		rpcClient.Close()
+		return nil, fmt.Errorf("failed to create verification L2 client: %w", err)
+	}
+	return &l2OutputVerifier{client: l2Client, close: l2Client.Close}, nil
+}
+
+func (v *l2OutputVerifier) VerifyOutput(ctx context.Context, output *eth.OutputResponse) error {
+	_, err := v.client.VerifyOutputRoot(ctx, output.BlockRef.ID(), output.OutputRoot)
+	if errors.Is(err, sources.ErrOutputRootMismatch) {
+		return fmt.Errorf("%w: verification L2 node: %w", ErrOutputMismatch, err)
+	} else if err != nil {
+		return fmt.Errorf("failed to verify output root with verification L2 node: %w", err)
+	}
+	return nil
+}
+
+func (v *l2OutputVerifier) Close() {
+	if v.close != nil {
+		v.close()
+	}
+}
+
+func compareOutputs(output *eth.OutputResponse, expectedRoot eth.Bytes32, expectedBlockHash common.Hash) error {
+	if output.BlockRef.Hash != expectedBlockHash {
+		return fmt.Errorf("%w: block %d has hash %s, verification source has %s",
+			ErrOutputMismatch, output.BlockRef.Number, output.BlockRef.Hash, expectedBlockHash)
+	}
+	if output.OutputRoot != expectedRoot {
+		return fmt.Errorf("%w: output root at block %d is %s, verification source has %s",
+			ErrOutputMismatch, output.BlockRef.Number, output.OutputRoot, expectedRoot)
+	}
+	return nil
+}
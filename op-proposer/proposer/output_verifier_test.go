@@ -0,0 +1,82 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubOutputVerifier struct {
+	err error
+}
+
+func (v *stubOutputVerifier) VerifyOutput(context.Context, *eth.OutputResponse) error {
+	return v.err
+}
+
+func (v *stubOutputVerifier) Close() {}
+
+func TestCompareOutputs(t *testing.T) {
+	output := &eth.OutputResponse{
+		OutputRoot: eth.Bytes32{0x01},
+		BlockRef:   eth.L2BlockRef{Number: 10, Hash: common.Hash{0xaa}},
+	}
+	require.NoError(t, compareOutputs(output, eth.Bytes32{0x01}, common.Hash{0xaa}))
+	require.ErrorIs(t, compareOutputs(output, eth.Bytes32{0x02}, common.Hash{0xaa}), ErrOutputMismatch)
+	require.ErrorIs(t, compareOutputs(output, eth.Bytes32{0x01}, common.Hash{0xbb}), ErrOutputMismatch)
+}
+
+type stubL2OutputRootVerifier struct {
+	err error
+}
+
+func (v *stubL2OutputRootVerifier) VerifyOutputRoot(context.Context, eth.BlockID, eth.Bytes32) (*eth.OutputV0, error) {
+	return nil, v.err
+}
+
+func TestL2OutputVerifier(t *testing.T) {
+	output := &eth.OutputResponse{
+		OutputRoot: eth.Bytes32{0x01},
+		BlockRef:   eth.L2BlockRef{Number: 10, Hash: common.Hash{0xaa}},
+	}
+	client := new(stubL2OutputRootVerifier)
+	v := &l2OutputVerifier{client: client}
+	require.NoError(t, v.VerifyOutput(context.Background(), output))
+
+	client.err = fmt.Errorf("wrapped: %w", sources.ErrOutputRootMismatch)
+	require.ErrorIs(t, v.VerifyOutput(context.Background(), output), ErrOutputMismatch)
+
+	client.err = errors.New("unavailable")
+	err := v.VerifyOutput(context.Background(), output)
+	require.ErrorContains(t, err, "unavailable")
+	require.NotErrorIs(t, err, ErrOutputMismatch)
+}
+
+func TestL2OutputSubmitter_VerifyOutput(t *testing.T) {
+	output := &eth.OutputResponse{OutputRoot: eth.Bytes32{0x01}}
+	l := &L2OutputSubmitter{DriverSetup: DriverSetup{
+		Log:  testlog.Logger(t, log.LevelCrit),
+		Metr: metrics.NoopMetrics,
+	}}
+	require.NoError(t, l.verifyOutput(context.Background(), output), "verification disabled")
+
+	verifier := new(stubOutputVerifier)
+	l.OutputVerifier = verifier
+	require.NoError(t, l.verifyOutput(context.Background(), output))
+
+	verifier.err = ErrOutputMismatch
+	require.ErrorIs(t, l.verifyOutput(context.Background(), output), ErrOutputMismatch)
+
+	verifier.err = errors.New("unavailable")
+	require.ErrorContains(t, l.verifyOutput(context.Background(), output), "unavailable")
+}
@@ -0,0 +1,172 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// ErrProposalNotDiverging is returned when remediating a proposal that matches
+// the finalized output of the rollup node.
+var ErrProposalNotDiverging = errors.New("proposed output root matches the finalized output")
+
+// RemediateProposal remediates the own proposal of the L2 block if its output
+// root diverges from the finalized output of the rollup node, which must agree
+// with the output verifier, if set. A diverging L2OutputOracle proposal gets
+// deleted together with all later ones, which requires the proposer to be the
+// challenger of the oracle. A diverging dispute game gets flagged in the
+// proposals RPC, logs and metrics, to be countered by a challenger before its
+// resolution.
+func (l *L2OutputSubmitter) RemediateProposal(ctx context.Context, block uint64) error {
+	expected, err := l.finalizedOutputAt(ctx, block)
+	if err != nil {
+		return err
+	}
+
+	l.proposeMu.Lock()
+	defer l.proposeMu.Unlock()
+	if l.l2ooContract != nil {
+		err = l.deleteDivergingOutputs(ctx, expected)
+	} else {
+		err = l.flagDivergingGame(expected)
+	}
+	if err != nil && !errors.Is(err, ErrProposalNotDiverging) {
+		l.Metr.RecordProposalRemediated(metrics.RemediationFailed)
+	}
+	return err
+}
+
+// finalizedOutputAt returns the output of the finalized L2 block, cross-verified
+// with the output verifier, if set.
+func (l *L2OutputSubmitter) finalizedOutputAt(ctx context.Context, block uint64) (*eth.OutputResponse, error) {
+	rollupClient, err := l.RollupProvider.RollupClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rollup client: %w", err)
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	output, err := rollupClient.OutputAtBlock(cCtx, block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch output at block %d: %w", block, err)
+	}
+	if block > output.Status.FinalizedL2.Number {
+		return nil, fmt.Errorf("L2 block %d is not finalized yet, finalized head is %d", block, output.Status.FinalizedL2.Number)
+	}
+	if err := l.verifyOutput(ctx, output); err != nil {
+		return nil, fmt.Errorf("output at block %d failed cross-verification, not remediating: %w", block, err)
+	}
+	return output, nil
+}
+
+// deleteDivergingOutputs deletes the L2OutputOracle proposal of the expected
+// output's block, and all later proposals, if it diverges.
+func (l *L2OutputSubmitter) deleteDivergingOutputs(ctx context.Context, expected *eth.OutputResponse) error {
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	callOpts := &bind.CallOpts{From: l.Txmgr.From(), Context: cCtx}
+	block := new(big.Int).SetUint64(expected.BlockRef.Number)
+	index, err := l.l2ooContract.GetL2OutputIndexAfter(callOpts, block)
+	if err != nil {
+		return fmt.Errorf("failed to fetch output index of block %d: %w", block, err)
+	}
+	proposed, err := l.l2ooContract.GetL2Output(callOpts, index)
+	if err != nil {
+		return fmt.Errorf("failed to fetch output at index %d: %w", index, err)
+	}
+	if proposed.L2BlockNumber.Cmp(block) != 0 {
+		return fmt.Errorf("no output proposed for L2 block %d", block)
+	}
+	if proposed.OutputRoot == expected.OutputRoot {
+		return ErrProposalNotDiverging
+	}
+	l.markDiverged(expected.BlockRef.Number)
+
+	challenger, err := l.l2ooContract.Challenger(callOpts)
+	if err != nil {
+		return fmt.Errorf("failed to fetch challenger: %w", err)
+	}
+	if challenger != l.Txmgr.From() {
+		l.Log.Error("Proposed output diverges, but the proposer is not the challenger and can't delete it",
+			"index", index, "block", expected.BlockRef, "proposed_output", eth.Bytes32(proposed.OutputRoot),
+			"expected_output", expected.OutputRoot, "challenger", challenger)
+		return fmt.Errorf("proposal %d diverges, but must be deleted by the challenger %s", index, challenger)
+	}
+
+	data, err := l.l2ooABI.Pack("deleteL2Outputs", index)
+	if err != nil {
+		return err
+	}
+	l.Log.Warn("Deleting diverging proposed outputs", "index", index, "block", expected.BlockRef,
+		"proposed_output", eth.Bytes32(proposed.OutputRoot), "expected_output", expected.OutputRoot)
+	if err := l.checkBudget(); err != nil {
+		return err
+	}
+	sCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+	receipt, err := l.Txmgr.Send(sCtx, txmgr.TxCandidate{
+		TxData: data,
+		To:     l.Cfg.L2OutputOracleAddr,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send deletion tx: %w", err)
+	}
+	l.recordSpend(receipt, nil)
+	if receipt.Status == types.ReceiptStatusFailed {
+		return fmt.Errorf("deletion tx %s reverted", receipt.TxHash)
+	}
+	l.Log.Info("Deleted diverging proposed outputs", "index", index, "tx_hash", receipt.TxHash)
+	l.Metr.RecordProposalRemediated(metrics.RemediationDeleted)
+	return nil
+}
+
+// flagDivergingGame flags the tracked dispute game proposal of the expected
+// output's block if it diverges. Countering the game's root claim requires
+// bonded moves down to the execution trace, which is left to a challenger.
+func (l *L2OutputSubmitter) flagDivergingGame(expected *eth.OutputResponse) error {
+	p, info := l.proposals.confirmedAt(expected.BlockRef.Number)
+	if p == nil || info.GameAddress == nil {
+		return fmt.Errorf("no tracked dispute game for L2 block %d", expected.BlockRef.Number)
+	}
+	if info.OutputRoot == expected.OutputRoot {
+		return ErrProposalNotDiverging
+	}
+	l.proposals.diverged(p)
+	l.Log.Error("Dispute game diverges from the finalized output, it must be challenged before its resolution",
+		"game", info.GameAddress, "block", expected.BlockRef, "proposed_output", info.OutputRoot,
+		"expected_output", expected.OutputRoot)
+	l.Metr.RecordProposalRemediated(metrics.RemediationFlagged)
+	return nil
+}
+
+// markDiverged marks the tracked proposal of the L2 block as diverging, if any.
+func (l *L2OutputSubmitter) markDiverged(block uint64) {
+	if p, _ := l.proposals.confirmedAt(block); p != nil {
+		l.proposals.diverged(p)
+	}
+}
+
+// remediateDiverged automatically remediates the proposals of the L2 blocks
+// that got found to diverge, if enabled. Deleting an L2OutputOracle proposal
+// also deletes all later ones, so only the earliest one is remediated then.
+func (l *L2OutputSubmitter) remediateDiverged(ctx context.Context, blocks []uint64) {
+	if !l.Cfg.RemediateDivergingProposals || len(blocks) == 0 {
+		return
+	}
+	if l.l2ooContract != nil {
+		blocks = blocks[:1]
+	}
+	for _, block := range blocks {
+		if err := l.RemediateProposal(ctx, block); err != nil {
+			l.Log.Error("Failed to remediate diverging proposal", "err", err, "block", block)
+		}
+	}
+}
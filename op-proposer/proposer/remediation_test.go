@@ -0,0 +1,193 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	txmgrmocks "github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+var (
+	testProposer    = common.Address{0x01}
+	testL2OOAddr    = common.Address{0x0a}
+	testFinalizedL2 = eth.L2BlockRef{Number: 100}
+)
+
+// l2ooL1Client serves the L2OutputOracle calls needed for remediation.
+type l2ooL1Client struct {
+	L1Client
+	abi        *abi.ABI
+	proposals  []bindings.TypesOutputProposal
+	challenger common.Address
+}
+
+func (c *l2ooL1Client) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	method, err := c.abi.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "getL2OutputIndexAfter":
+		block := args[0].(*big.Int)
+		for i, p := range c.proposals {
+			if p.L2BlockNumber.Cmp(block) >= 0 {
+				return method.Outputs.Pack(big.NewInt(int64(i)))
+			}
+		}
+		return nil, errors.New("execution reverted")
+	case "getL2Output":
+		return method.Outputs.Pack(c.proposals[args[0].(*big.Int).Uint64()])
+	case "challenger":
+		return method.Outputs.Pack(c.challenger)
+	default:
+		return nil, errors.New("unexpected call")
+	}
+}
+
+func setupRemediation(t *testing.T, proposed eth.Bytes32) (*L2OutputSubmitter, *l2ooL1Client, *txmgrmocks.TxManager, *testutils.MockRollupClient) {
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	l1 := &l2ooL1Client{
+		abi: l2ooABI,
+		proposals: []bindings.TypesOutputProposal{
+			{OutputRoot: eth.Bytes32{0x01}, Timestamp: big.NewInt(1), L2BlockNumber: big.NewInt(40)},
+			{OutputRoot: proposed, Timestamp: big.NewInt(2), L2BlockNumber: big.NewInt(50)},
+		},
+		challenger: testProposer,
+	}
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(testL2OOAddr, l1)
+	require.NoError(t, err)
+	txMgr := txmgrmocks.NewTxManager(t)
+	txMgr.On("From").Return(testProposer).Maybe()
+	rollupClient := new(testutils.MockRollupClient)
+	return &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:            testlog.Logger(t, log.LevelCrit),
+			Metr:           metrics.NoopMetrics,
+			Cfg:            ProposerConfig{L2OutputOracleAddr: &testL2OOAddr, NetworkTimeout: time.Second},
+			Txmgr:          txMgr,
+			L1Client:       l1,
+			RollupProvider: &mockRollupProvider{rollupClient: rollupClient},
+		},
+		proposals:    newProposalTracker(metrics.NoopMetrics),
+		l2ooContract: l2ooContract,
+		l2ooABI:      l2ooABI,
+	}, l1, txMgr, rollupClient
+}
+
+func expectedOutput(num uint64, root byte) *eth.OutputResponse {
+	return &eth.OutputResponse{
+		OutputRoot: eth.Bytes32{root},
+		BlockRef:   eth.L2BlockRef{Number: num},
+		Status:     &eth.SyncStatus{FinalizedL2: testFinalizedL2},
+	}
+}
+
+func TestL2OutputSubmitter_RemediateProposalDeletes(t *testing.T) {
+	l, _, txMgr, rollupClient := setupRemediation(t, eth.Bytes32{0xff})
+	rollupClient.ExpectOutputAtBlock(50, expectedOutput(50, 0x02), nil)
+	txMgr.On("Send", mock.Anything, mock.Anything).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).
+		Run(func(args mock.Arguments) {
+			candidate := args.Get(1).(txmgr.TxCandidate)
+			require.Equal(t, &testL2OOAddr, candidate.To)
+			expected, err := l.l2ooABI.Pack("deleteL2Outputs", big.NewInt(1))
+			require.NoError(t, err)
+			require.Equal(t, expected, candidate.TxData)
+		}).Once()
+
+	require.NoError(t, l.RemediateProposal(context.Background(), 50))
+}
+
+func TestL2OutputSubmitter_RemediateProposalGuards(t *testing.T) {
+	t.Run("NotDiverging", func(t *testing.T) {
+		l, _, _, rollupClient := setupRemediation(t, eth.Bytes32{0x02})
+		rollupClient.ExpectOutputAtBlock(50, expectedOutput(50, 0x02), nil)
+		require.ErrorIs(t, l.RemediateProposal(context.Background(), 50), ErrProposalNotDiverging)
+	})
+	t.Run("NotFinalized", func(t *testing.T) {
+		l, _, _, rollupClient := setupRemediation(t, eth.Bytes32{0xff})
+		rollupClient.ExpectOutputAtBlock(120, expectedOutput(120, 0x02), nil)
+		require.ErrorContains(t, l.RemediateProposal(context.Background(), 120), "not finalized")
+	})
+	t.Run("VerificationFailed", func(t *testing.T) {
+		l, _, _, rollupClient := setupRemediation(t, eth.Bytes32{0xff})
+		l.OutputVerifier = &stubOutputVerifier{err: ErrOutputMismatch}
+		rollupClient.ExpectOutputAtBlock(50, expectedOutput(50, 0x02), nil)
+		require.ErrorIs(t, l.RemediateProposal(context.Background(), 50), ErrOutputMismatch)
+	})
+	t.Run("NoProposal", func(t *testing.T) {
+		l, _, _, rollupClient := setupRemediation(t, eth.Bytes32{0xff})
+		rollupClient.ExpectOutputAtBlock(45, expectedOutput(45, 0x02), nil)
+		require.ErrorContains(t, l.RemediateProposal(context.Background(), 45), "no output proposed")
+	})
+	t.Run("NotChallenger", func(t *testing.T) {
+		l, l1, _, rollupClient := setupRemediation(t, eth.Bytes32{0xff})
+		l1.challenger = common.Address{0xcc}
+		rollupClient.ExpectOutputAtBlock(50, expectedOutput(50, 0x02), nil)
+		require.ErrorContains(t, l.RemediateProposal(context.Background(), 50), "must be deleted by the challenger")
+	})
+	// No Send calls are expected by the tx manager mocks.
+}
+
+func TestL2OutputSubmitter_RemediateProposalFlagsGame(t *testing.T) {
+	rollupClient := new(testutils.MockRollupClient)
+	dgf := common.Address{0x0d}
+	l := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:            testlog.Logger(t, log.LevelCrit),
+			Metr:           metrics.NoopMetrics,
+			Cfg:            ProposerConfig{DisputeGameFactoryAddr: &dgf, NetworkTimeout: time.Second},
+			RollupProvider: &mockRollupProvider{rollupClient: rollupClient},
+		},
+		proposals: newProposalTracker(metrics.NoopMetrics),
+	}
+
+	rollupClient.ExpectOutputAtBlock(50, expectedOutput(50, 0x02), nil)
+	require.ErrorContains(t, l.RemediateProposal(context.Background(), 50), "no tracked dispute game")
+
+	p := l.proposals.created(&eth.OutputResponse{OutputRoot: eth.Bytes32{0xff}, BlockRef: eth.L2BlockRef{Number: 50}})
+	l.proposals.submitted(p)
+	l.proposals.confirmed(p, &types.Receipt{Status: types.ReceiptStatusSuccessful})
+	l.proposals.gameCreated(p, common.Address{0x0e})
+
+	rollupClient.ExpectOutputAtBlock(50, expectedOutput(50, 0x02), nil)
+	require.NoError(t, l.RemediateProposal(context.Background(), 50))
+	infos := l.proposals.infos()
+	require.True(t, infos[0].Diverged)
+	require.Equal(t, rpc.GameInProgress, infos[0].GameStatus, "game is only flagged")
+}
+
+func TestL2OutputSubmitter_RemediateDivergedOnlyIfEnabled(t *testing.T) {
+	l, _, _, _ := setupRemediation(t, eth.Bytes32{0xff})
+	// Disabled, so the rollup client mock expects no calls.
+	l.remediateDiverged(context.Background(), []uint64{50})
+
+	l.Cfg.RemediateDivergingProposals = true
+	rollupClient := l.RollupProvider.(*mockRollupProvider).rollupClient
+	// Deleting the earliest proposal also deletes later ones, so only it is remediated.
+	rollupClient.ExpectOutputAtBlock(40, expectedOutput(40, 0x01), nil)
+	l.remediateDiverged(context.Background(), []uint64{40, 50})
+	rollupClient.AssertExpectations(t)
+}
@@ -2,17 +2,24 @@ package rpc
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
-	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
 
 type ProposerDriver interface {
 	StartL2OutputSubmitting() error
 	StopL2OutputSubmitting() error
+	PauseL2OutputSubmitting()
+	ResumeL2OutputSubmitting()
+	SetProposalInterval(interval time.Duration) error
+	ProposeL2Block(ctx context.Context, block uint64) error
+	RemediateProposal(ctx context.Context, block uint64) error
 }
 
 type adminAPI struct {
@@ -20,9 +27,9 @@ type adminAPI struct {
 	b ProposerDriver
 }
 
-func NewAdminAPI(dr ProposerDriver, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+func NewAdminAPI(dr ProposerDriver, log log.Logger) *adminAPI {
 	return &adminAPI{
-		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
+		CommonAdminAPI: rpc.NewCommonAdminAPI(log),
 		b:              dr,
 	}
 }
@@ -41,3 +48,42 @@ func (a *adminAPI) StartProposer(_ context.Context) error {
 func (a *adminAPI) StopProposer(ctx context.Context) error {
 	return a.b.StopL2OutputSubmitting()
 }
+
+// PauseProposer pauses the automatic proposals, without stopping the proposer.
+// Proposals can still be forced with ProposeL2Block.
+func (a *adminAPI) PauseProposer(_ context.Context) error {
+	a.b.PauseL2OutputSubmitting()
+	return nil
+}
+
+// ResumeProposer resumes the automatic proposals after PauseProposer.
+func (a *adminAPI) ResumeProposer(_ context.Context) error {
+	a.b.ResumeL2OutputSubmitting()
+	return nil
+}
+
+// SetProposalInterval sets the interval of the proposer loop, as a duration
+// string like "30s" or "10m". It's the poll interval when proposing to the
+// L2OutputOracle and the proposal interval when creating dispute games.
+func (a *adminAPI) SetProposalInterval(_ context.Context, interval string) error {
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return fmt.Errorf("invalid proposal interval: %w", err)
+	}
+	return a.b.SetProposalInterval(d)
+}
+
+// ProposeL2Block immediately proposes the output at the given L2 block, also if
+// the proposer is paused, and returns once the proposal tx got included.
+func (a *adminAPI) ProposeL2Block(ctx context.Context, block hexutil.Uint64) error {
+	return a.b.ProposeL2Block(ctx, uint64(block))
+}
+
+// RemediateProposal deletes or flags the proposer's proposal of the given L2
+// block, if its output root diverges from the finalized output of the rollup
+// node. L2OutputOracle proposals get deleted, together with all later ones,
+// which requires the proposer to be the oracle's challenger. Dispute games get
+// flagged, to be countered by a challenger.
+func (a *adminAPI) RemediateProposal(ctx context.Context, block hexutil.Uint64) error {
+	return a.b.RemediateProposal(ctx, uint64(block))
+}
@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ProposalStatus is the lifecycle stage of a proposal.
+type ProposalStatus string
+
+const (
+	// ProposalCreated proposals are being verified and prepared for submission.
+	ProposalCreated ProposalStatus = "created"
+	// ProposalSubmitted proposals are sent to the tx manager.
+	ProposalSubmitted ProposalStatus = "submitted"
+	// ProposalConfirmed proposals got included in L1 successfully.
+	ProposalConfirmed ProposalStatus = "confirmed"
+	// ProposalFailed proposals failed before or after submission, see Error.
+	ProposalFailed ProposalStatus = "failed"
+	// ProposalSkipped proposals weren't sent because a dispute game for the
+	// output already exists.
+	ProposalSkipped ProposalStatus = "skipped"
+)
+
+// GameStatus is the resolution status of a dispute game.
+type GameStatus string
+
+const (
+	GameInProgress     GameStatus = "in_progress"
+	GameChallengerWins GameStatus = "challenger_wins"
+	GameDefenderWins   GameStatus = "defender_wins"
+)
+
+// ProposalInfo describes a proposal of the proposer. Timestamps are unix
+// seconds, 0 if the stage wasn't reached.
+type ProposalInfo struct {
+	L2Block     eth.BlockID    `json:"l2Block"`
+	OutputRoot  eth.Bytes32    `json:"outputRoot"`
+	Status      ProposalStatus `json:"status"`
+	Error       string         `json:"error,omitempty"`
+	CreatedAt   hexutil.Uint64 `json:"createdAt"`
+	SubmittedAt hexutil.Uint64 `json:"submittedAt"`
+	ConfirmedAt hexutil.Uint64 `json:"confirmedAt"`
+	// TxHash and InclusionBlock are set once the proposal is confirmed, also if
+	// the tx reverted.
+	TxHash         common.Hash `json:"txHash"`
+	InclusionBlock eth.BlockID `json:"inclusionBlock"`
+	// GameAddress, GameStatus and ResolvedAt are set for confirmed dispute game
+	// proposals.
+	GameAddress *common.Address `json:"gameAddress,omitempty"`
+	GameStatus  GameStatus      `json:"gameStatus,omitempty"`
+	ResolvedAt  hexutil.Uint64  `json:"resolvedAt"`
+	// Diverged is set if the proposed output root got found to diverge from
+	// the finalized output of the rollup node.
+	Diverged bool `json:"diverged,omitempty"`
+}
+
+// ProposalInfoProvider provides details about the recent proposals.
+type ProposalInfoProvider interface {
+	// Proposals returns the recent proposals, oldest first.
+	Proposals(ctx context.Context) ([]ProposalInfo, error)
+}
+
+type proposerAPI struct {
+	p ProposalInfoProvider
+}
+
+// NewProposerAPI creates the read-only proposer API.
+func NewProposerAPI(p ProposalInfoProvider) *proposerAPI {
+	return &proposerAPI{p: p}
+}
+
+func GetProposerAPI(api *proposerAPI) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "proposer",
+		Service:   api,
+	}
+}
+
+// Proposals returns the recent proposals of the proposer, oldest first,
+// including their lifecycle timestamps and dispute game addresses.
+func (a *proposerAPI) Proposals(ctx context.Context) ([]ProposalInfo, error) {
+	return a.p.Proposals(ctx)
+}
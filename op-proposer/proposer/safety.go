@@ -0,0 +1,115 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// proposalHead returns the L2 head to propose outputs up to for the given safety.
+func proposalHead(status *eth.SyncStatus, safety flags.ProposalSafety) eth.L2BlockRef {
+	switch safety {
+	case flags.UnsafeSafety:
+		return status.UnsafeL2
+	case flags.SafeSafety:
+		return status.SafeL2
+	default:
+		return status.FinalizedL2
+	}
+}
+
+// checkOutputCanonical checks that a non-finalized output is still canonical
+// right before proposing it, to avoid proposals that got invalid by an L2 reorg
+// while waiting for the L1 head.
+func (l *L2OutputSubmitter) checkOutputCanonical(ctx context.Context, output *eth.OutputResponse) error {
+	if output.BlockRef.Number <= output.Status.FinalizedL2.Number {
+		return nil
+	}
+	rollupClient, err := l.RollupProvider.RollupClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get rollup client: %w", err)
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	current, err := rollupClient.OutputAtBlock(cCtx, output.BlockRef.Number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch output at block %d: %w", output.BlockRef.Number, err)
+	}
+	if current.BlockRef.Hash != output.BlockRef.Hash || current.OutputRoot != output.OutputRoot {
+		return fmt.Errorf("L2 block %d reorged from %s to %s", output.BlockRef.Number, output.BlockRef.Hash, current.BlockRef.Hash)
+	}
+	return nil
+}
+
+// recordProposed records a successful proposal. Proposals of non-finalized
+// outputs are tracked until they got finalized, to detect if they got invalid.
+func (l *L2OutputSubmitter) recordProposed(output *eth.OutputResponse) {
+	l.Metr.RecordL2BlocksProposed(output.BlockRef)
+	if output.BlockRef.Number <= output.Status.FinalizedL2.Number {
+		return
+	}
+	l.unfinalizedMu.Lock()
+	defer l.unfinalizedMu.Unlock()
+	l.unfinalized = append(l.unfinalized, output)
+}
+
+// checkUnfinalizedProposals checks the tracked proposals of non-finalized
+// outputs that got finalized in the meantime. If the finalized output root
+// differs, the proposal is invalid. The proposer alerts, such that it can be
+// deleted or challenged in time, and remediates it if enabled.
+func (l *L2OutputSubmitter) checkUnfinalizedProposals(ctx context.Context) {
+	l.remediateDiverged(ctx, l.reorgedProposals(ctx))
+}
+
+// reorgedProposals returns the L2 blocks of the tracked proposals that got
+// reorged out before finalization, in proposal order.
+func (l *L2OutputSubmitter) reorgedProposals(ctx context.Context) []uint64 {
+	l.unfinalizedMu.Lock()
+	defer l.unfinalizedMu.Unlock()
+	if len(l.unfinalized) == 0 {
+		return nil
+	}
+
+	rollupClient, err := l.RollupProvider.RollupClient(ctx)
+	if err != nil {
+		l.Log.Warn("Unable to check unfinalized proposals", "err", err)
+		return nil
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	status, err := rollupClient.SyncStatus(cCtx)
+	if err != nil {
+		l.Log.Warn("Unable to check unfinalized proposals", "err", err)
+		return nil
+	}
+
+	var reorged []uint64
+	remaining := l.unfinalized[:0]
+	for _, proposed := range l.unfinalized {
+		if proposed.BlockRef.Number > status.FinalizedL2.Number {
+			remaining = append(remaining, proposed)
+			continue
+		}
+		finalized, err := rollupClient.OutputAtBlock(cCtx, proposed.BlockRef.Number)
+		if err != nil {
+			l.Log.Warn("Unable to check unfinalized proposal", "err", err, "block", proposed.BlockRef)
+			remaining = append(remaining, proposed)
+			continue
+		}
+		if finalized.OutputRoot != proposed.OutputRoot {
+			l.Log.Error("Proposed output got reorged out before finalization, the proposal is invalid and must be deleted or challenged",
+				"block", proposed.BlockRef, "proposed_output", proposed.OutputRoot,
+				"finalized_block", finalized.BlockRef, "finalized_output", finalized.OutputRoot)
+			l.Metr.RecordProposalReorged()
+			reorged = append(reorged, proposed.BlockRef.Number)
+		} else {
+			l.Log.Debug("Proposed output got finalized", "block", proposed.BlockRef, "output", proposed.OutputRoot)
+		}
+	}
+	// Drop references to checked proposals.
+	clear(l.unfinalized[len(remaining):])
+	l.unfinalized = remaining
+	return reorged
+}
@@ -0,0 +1,106 @@
+package proposer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type mockRollupProvider struct {
+	rollupClient *testutils.MockRollupClient
+}
+
+func (p *mockRollupProvider) RollupClient(context.Context) (dial.RollupClientInterface, error) {
+	return p.rollupClient, nil
+}
+
+func (p *mockRollupProvider) Close() {}
+
+type reorgMetrics struct {
+	metrics.Metricer
+	reorged int
+}
+
+func (m *reorgMetrics) RecordProposalReorged() { m.reorged++ }
+
+func setupSafety(t *testing.T) (*L2OutputSubmitter, *testutils.MockRollupClient, *reorgMetrics) {
+	rollupClient := new(testutils.MockRollupClient)
+	m := &reorgMetrics{Metricer: metrics.NoopMetrics}
+	return &L2OutputSubmitter{DriverSetup: DriverSetup{
+		Log:            testlog.Logger(t, log.LevelCrit),
+		Metr:           m,
+		RollupProvider: &mockRollupProvider{rollupClient: rollupClient},
+	}}, rollupClient, m
+}
+
+func testOutput(num uint64, hash byte, finalized uint64) *eth.OutputResponse {
+	return &eth.OutputResponse{
+		OutputRoot: eth.Bytes32{hash},
+		BlockRef:   eth.L2BlockRef{Number: num, Hash: common.Hash{hash}},
+		Status:     &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: finalized}},
+	}
+}
+
+func TestProposalHead(t *testing.T) {
+	status := &eth.SyncStatus{
+		UnsafeL2:    eth.L2BlockRef{Number: 30},
+		SafeL2:      eth.L2BlockRef{Number: 20},
+		FinalizedL2: eth.L2BlockRef{Number: 10},
+	}
+	require.Equal(t, uint64(30), proposalHead(status, flags.UnsafeSafety).Number)
+	require.Equal(t, uint64(20), proposalHead(status, flags.SafeSafety).Number)
+	require.Equal(t, uint64(10), proposalHead(status, flags.FinalizedSafety).Number)
+
+	cfg := ProposerConfig{}
+	require.Equal(t, flags.FinalizedSafety, cfg.Safety())
+	cfg.AllowNonFinalized = true
+	require.Equal(t, flags.SafeSafety, cfg.Safety())
+	cfg.ProposalSafety = flags.UnsafeSafety
+	require.Equal(t, flags.UnsafeSafety, cfg.Safety())
+}
+
+func TestCheckOutputCanonical(t *testing.T) {
+	l, rollupClient, _ := setupSafety(t)
+	ctx := context.Background()
+
+	require.NoError(t, l.checkOutputCanonical(ctx, testOutput(10, 0x01, 10)), "finalized outputs aren't checked")
+
+	rollupClient.ExpectOutputAtBlock(20, testOutput(20, 0x02, 10), nil)
+	require.NoError(t, l.checkOutputCanonical(ctx, testOutput(20, 0x02, 10)))
+
+	rollupClient.ExpectOutputAtBlock(20, testOutput(20, 0x03, 10), nil)
+	require.ErrorContains(t, l.checkOutputCanonical(ctx, testOutput(20, 0x02, 10)), "reorged")
+	rollupClient.AssertExpectations(t)
+}
+
+func TestCheckUnfinalizedProposals(t *testing.T) {
+	l, rollupClient, m := setupSafety(t)
+	ctx := context.Background()
+
+	l.recordProposed(testOutput(10, 0x01, 10))
+	require.Empty(t, l.unfinalized, "finalized proposals aren't tracked")
+	l.recordProposed(testOutput(20, 0x02, 10))
+	l.recordProposed(testOutput(30, 0x03, 10))
+	l.recordProposed(testOutput(40, 0x04, 10))
+	require.Len(t, l.unfinalized, 3)
+
+	rollupClient.ExpectSyncStatus(&eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 30}}, nil)
+	rollupClient.ExpectOutputAtBlock(20, testOutput(20, 0x02, 30), nil)
+	rollupClient.ExpectOutputAtBlock(30, testOutput(30, 0x05, 30), nil)
+	l.checkUnfinalizedProposals(ctx)
+	rollupClient.AssertExpectations(t)
+
+	require.Equal(t, 1, m.reorged, "proposal at block 30 got reorged")
+	require.Len(t, l.unfinalized, 1)
+	require.Equal(t, uint64(40), l.unfinalized[0].BlockRef.Number)
+}
@@ -5,24 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-proposer/flags"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
 	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/health"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optracing "github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 var ErrAlreadyStopped = errors.New("already stopped")
@@ -38,6 +46,29 @@ type ProposerConfig struct {
 	L2OutputOracleAddr     *common.Address
 	DisputeGameFactoryAddr *common.Address
 	DisputeGameType        uint32
+	// DisputeGameMaxBond is the maximum initial bond in wei to pay for creating
+	// a dispute game, nil for no limit.
+	DisputeGameMaxBond *big.Int
+
+	// SpendLimits limit the gas and bonds spent per day and week. The proposer
+	// pauses when a limit is reached.
+	SpendLimits SpendLimits
+
+	// BondFunding configures the monitoring of the proposer balance for dispute
+	// game bonds, and its top-ups from the bond treasury.
+	BondFunding BondFunding
+
+	// MaxProposalsPerL1Block is the maximum number of L2OutputOracle proposals
+	// to send for inclusion in the same L1 block when the proposer fell behind.
+	MaxProposalsPerL1Block uint64
+
+	// ProposalTxDeadline is the time after which an unconfirmed proposal tx is
+	// cancelled, to propose the output again. 0 disables the deadline.
+	ProposalTxDeadline time.Duration
+
+	// RemediateDivergingProposals enables the automatic remediation of own
+	// proposals that diverge from the finalized outputs, see RemediateProposal.
+	RemediateDivergingProposals bool
 
 	// AllowNonFinalized enables the proposal of safe, but non-finalized L2 blocks.
 	// The L1 block-hash embedded in the proposal TX is checked and should ensure the proposal
@@ -45,26 +76,53 @@ type ProposerConfig struct {
 	// This option is not necessary when higher proposal latency is acceptable and L1 is healthy.
 	AllowNonFinalized bool
 
+	// ProposalSafety is the L2 head to propose outputs up to. If empty, it's
+	// derived from AllowNonFinalized, see Safety.
+	ProposalSafety flags.ProposalSafety
+
 	WaitNodeSync bool
 }
 
+// Safety returns the L2 head to propose outputs up to.
+func (c *ProposerConfig) Safety() flags.ProposalSafety {
+	if c.ProposalSafety != "" {
+		return c.ProposalSafety
+	}
+	if c.AllowNonFinalized {
+		return flags.SafeSafety
+	}
+	return flags.FinalizedSafety
+}
+
 type ProposerService struct {
 	Log     log.Logger
 	Metrics metrics.Metricer
+	Tracer  optracing.Tracer
 
 	ProposerConfig
 
 	TxManager      txmgr.TxManager
+	Treasury       txmgr.TxManager
 	L1Client       *ethclient.Client
 	RollupProvider dial.RollupProvider
+	OutputVerifier OutputVerifier
 
 	driver *L2OutputSubmitter
 
+	// chains are the proposers of the additional chains of the chains config.
+	// chainName is set on these, and empty for the primary chain.
+	chains    []*ProposerService
+	chainName string
+	// txMgrConfig is the tx manager config of the primary chain, which the
+	// tx managers of the additional chains are derived from.
+	txMgrConfig txmgr.Config
+
 	Version string
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
 	rpcServer    *oprpc.Server
+	closeTracer  func(ctx context.Context) error
 
 	balanceMetricer io.Closer
 
@@ -87,21 +145,28 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	ps.Log = log
 
 	ps.initMetrics(cfg)
+	if err := ps.initTracer(cfg); err != nil {
+		return fmt.Errorf("failed to init tracing: %w", err)
+	}
 
-	ps.PollInterval = cfg.PollInterval
-	ps.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
-	ps.AllowNonFinalized = cfg.AllowNonFinalized
-	ps.WaitNodeSync = cfg.WaitNodeSync
-
+	ps.initProposerConfig(cfg)
 	ps.initL2ooAddress(cfg)
-	ps.initDGF(cfg)
+	if err := ps.initDGF(cfg); err != nil {
+		return err
+	}
+	if err := ps.initSpendLimits(cfg); err != nil {
+		return err
+	}
 
 	if err := ps.initRPCClients(ctx, cfg); err != nil {
 		return err
 	}
-	if err := ps.initTxManager(cfg); err != nil {
+	if err := ps.initTxManager(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init Tx manager: %w", err)
 	}
+	if err := ps.initBondTreasury(cfg); err != nil {
+		return fmt.Errorf("failed to init bond treasury: %w", err)
+	}
 	ps.initBalanceMonitor(cfg)
 	if err := ps.initMetricsServer(cfg); err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
@@ -112,6 +177,9 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	if err := ps.initDriver(); err != nil {
 		return fmt.Errorf("failed to init Driver: %w", err)
 	}
+	if err := ps.initChains(ctx, cfg); err != nil {
+		return err
+	}
 	if err := ps.initRPCServer(cfg); err != nil {
 		return fmt.Errorf("failed to start RPC server: %w", err)
 	}
@@ -121,6 +189,17 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	return nil
 }
 
+func (ps *ProposerService) initProposerConfig(cfg *CLIConfig) {
+	ps.PollInterval = cfg.PollInterval
+	ps.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
+	ps.AllowNonFinalized = cfg.AllowNonFinalized
+	ps.ProposalSafety = cfg.ProposalSafety
+	ps.MaxProposalsPerL1Block = cfg.MaxProposalsPerL1Block
+	ps.ProposalTxDeadline = cfg.ProposalTxDeadline
+	ps.RemediateDivergingProposals = cfg.RemediateDivergingProposals
+	ps.WaitNodeSync = cfg.WaitNodeSync
+}
+
 func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) error {
 	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, ps.Log, cfg.L1EthRpc)
 	if err != nil {
@@ -128,6 +207,11 @@ func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) e
 	}
 	ps.L1Client = l1Client
 
+	return ps.initRollupClients(ctx, cfg)
+}
+
+func (ps *ProposerService) initRollupClients(ctx context.Context, cfg *CLIConfig) error {
+	var err error
 	var rollupProvider dial.RollupProvider
 	if strings.Contains(cfg.RollupRpc, ",") {
 		rollupUrls := strings.Split(cfg.RollupRpc, ",")
@@ -139,6 +223,34 @@ func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) e
 		return fmt.Errorf("failed to build L2 endpoint provider: %w", err)
 	}
 	ps.RollupProvider = rollupProvider
+
+	return ps.initOutputVerifier(ctx, cfg)
+}
+
+func (ps *ProposerService) initOutputVerifier(ctx context.Context, cfg *CLIConfig) error {
+	if cfg.VerifyRollupRpc != "" {
+		verifier, err := newRollupOutputVerifier(ctx, ps.Log, cfg.VerifyRollupRpc)
+		if err != nil {
+			return err
+		}
+		ps.OutputVerifier = verifier
+		ps.Log.Info("Cross-verifying output roots with rollup node", "url", cfg.VerifyRollupRpc)
+	} else if cfg.VerifyL2EthRpc != "" {
+		rollupClient, err := ps.RollupProvider.RollupClient(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get rollup client: %w", err)
+		}
+		rollupCfg, err := rollupClient.RollupConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch rollup config: %w", err)
+		}
+		verifier, err := newL2OutputVerifier(ctx, ps.Log, cfg.VerifyL2EthRpc, rollupCfg)
+		if err != nil {
+			return err
+		}
+		ps.OutputVerifier = verifier
+		ps.Log.Info("Cross-verifying output roots with L2 archive node", "url", cfg.VerifyL2EthRpc)
+	}
 	return nil
 }
 
@@ -151,6 +263,16 @@ func (ps *ProposerService) initMetrics(cfg *CLIConfig) {
 	}
 }
 
+func (ps *ProposerService) initTracer(cfg *CLIConfig) error {
+	tracer, closeTracer, err := optracing.NewTracer(ps.Log, "op-proposer", cfg.TracingConfig)
+	if err != nil {
+		return err
+	}
+	ps.Tracer = tracer
+	ps.closeTracer = closeTracer
+	return nil
+}
+
 // initBalanceMonitor depends on Metrics, L1Client and TxManager to start background-monitoring of the Proposer balance.
 func (ps *ProposerService) initBalanceMonitor(cfg *CLIConfig) {
 	if cfg.MetricsConfig.Enabled {
@@ -158,24 +280,87 @@ func (ps *ProposerService) initBalanceMonitor(cfg *CLIConfig) {
 	}
 }
 
-func (ps *ProposerService) initTxManager(cfg *CLIConfig) error {
-	txManager, err := txmgr.NewSimpleTxManager("proposer", ps.Log, ps.Metrics, cfg.TxMgrConfig)
+func (ps *ProposerService) initTxManager(ctx context.Context, cfg *CLIConfig) error {
+	txCfg, err := txmgr.NewConfig(cfg.TxMgrConfig, ps.Log)
+	if err != nil {
+		return err
+	}
+	// Kept for the tx managers of additional chains
+	ps.txMgrConfig = txCfg
+	return ps.initTxManagerFromConfig(ctx, cfg, "proposer", txCfg)
+}
+
+func (ps *ProposerService) initTxManagerFromConfig(ctx context.Context, cfg *CLIConfig, name string, txCfg txmgr.Config) error {
+	txManager, err := txmgr.NewSimpleTxManagerFromConfig(name, ps.Log, ps.Metrics, txCfg)
 	if err != nil {
 		return err
 	}
 	ps.TxManager = txManager
+
+	if cfg.SignerHealthCheck {
+		// Sign for the proposal contract, to pass remote signer policies that
+		// restrict transaction targets.
+		var to common.Address
+		if ps.L2OutputOracleAddr != nil {
+			to = *ps.L2OutputOracleAddr
+		} else if ps.DisputeGameFactoryAddr != nil {
+			to = *ps.DisputeGameFactoryAddr
+		}
+		cCtx, cancel := context.WithTimeout(ctx, cfg.TxMgrConfig.NetworkTimeout)
+		defer cancel()
+		if err := checkSigner(cCtx, txCfg.Signer, txCfg.From, txCfg.ChainID, to); err != nil {
+			return fmt.Errorf("signer health check failed: %w", err)
+		}
+		ps.Log.Info("Signer health check passed", "address", txCfg.From)
+	}
+	return nil
+}
+
+// initBondTreasury depends on the TxManager, to top up its balance from the
+// bond treasury. The top-ups are signed by the remote signer of the treasury,
+// whose policy must allow the treasury to send ETH to the proposer.
+func (ps *ProposerService) initBondTreasury(cfg *CLIConfig) error {
+	ps.BondFunding = BondFunding{
+		MinGames:   cfg.BondBalanceMinGames,
+		TopUpGames: cfg.BondTopUpGames,
+	}
+	if cfg.BondTreasurySignerEndpoint == "" || ps.chainName != "" {
+		// Additional chains don't top up, the treasury nonce is managed by the primary chain only.
+		return nil
+	}
+	maxTopUp, err := eth.GweiToWei(cfg.BondMaxTopUpPerDay * params.GWei)
+	if err != nil {
+		return fmt.Errorf("invalid max top-up per day: %w", err)
+	}
+	ps.BondFunding.MaxTopUpPerDay = maxTopUp
+	treasuryCfg := cfg.TxMgrConfig
+	treasuryCfg.PrivateKey = ""
+	treasuryCfg.Mnemonic = ""
+	treasuryCfg.HDPath = ""
+	treasuryCfg.L2OutputHDPath = ""
+	treasuryCfg.SignerCLIConfig.Endpoint = cfg.BondTreasurySignerEndpoint
+	treasuryCfg.SignerCLIConfig.Address = cfg.BondTreasuryAddress
+	treasuryCfg.SignerCLIConfig.KMSProvider = ""
+	txCfg, err := txmgr.NewConfig(treasuryCfg, ps.Log)
+	if err != nil {
+		return err
+	}
+	txCfg.Backend.Close()
+	txCfg.Backend = sharedL1Backend{ps.L1Client}
+	// The treasury txs are not tracked by the tx metrics, which are of the proposal txs.
+	treasury, err := txmgr.NewSimpleTxManagerFromConfig("proposer-treasury", ps.Log, &txmetrics.NoopTxMetrics{}, txCfg)
+	if err != nil {
+		return err
+	}
+	ps.Treasury = treasury
+	ps.Log.Info("Topping up proposer balance from bond treasury", "treasury", treasury.From(),
+		"min_games", ps.BondFunding.MinGames, "top_up_games", ps.BondFunding.TopUpGames,
+		"max_top_up_per_day", cfg.BondMaxTopUpPerDay)
 	return nil
 }
 
 func (ps *ProposerService) initPProf(cfg *CLIConfig) error {
-	ps.pprofService = oppprof.New(
-		cfg.PprofConfig.ListenEnabled,
-		cfg.PprofConfig.ListenAddr,
-		cfg.PprofConfig.ListenPort,
-		cfg.PprofConfig.ProfileType,
-		cfg.PprofConfig.ProfileDir,
-		cfg.PprofConfig.ProfileFilename,
-	)
+	ps.pprofService = oppprof.NewFromConfig(cfg.PprofConfig)
 
 	if err := ps.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -212,25 +397,59 @@ func (ps *ProposerService) initL2ooAddress(cfg *CLIConfig) {
 	ps.L2OutputOracleAddr = &l2ooAddress
 }
 
-func (ps *ProposerService) initDGF(cfg *CLIConfig) {
+func (ps *ProposerService) initDGF(cfg *CLIConfig) error {
 	dgfAddress, err := opservice.ParseAddress(cfg.DGFAddress)
 	if err != nil {
 		// Return no error & set no DGF related configuration fields.
-		return
+		return nil
 	}
 	ps.DisputeGameFactoryAddr = &dgfAddress
 	ps.ProposalInterval = cfg.ProposalInterval
 	ps.DisputeGameType = cfg.DisputeGameType
+	if cfg.DisputeGameMaxBond > 0 {
+		maxBond, err := eth.GweiToWei(cfg.DisputeGameMaxBond * params.GWei)
+		if err != nil {
+			return fmt.Errorf("invalid dispute game max bond: %w", err)
+		}
+		ps.DisputeGameMaxBond = maxBond
+	}
+	return nil
+}
+
+func (ps *ProposerService) initSpendLimits(cfg *CLIConfig) error {
+	ps.SpendLimits.File = cfg.SpendFile
+	for _, limit := range []struct {
+		eth float64
+		wei **big.Int
+	}{
+		{cfg.MaxGasSpendPerDay, &ps.SpendLimits.GasPerDay},
+		{cfg.MaxGasSpendPerWeek, &ps.SpendLimits.GasPerWeek},
+		{cfg.MaxBondSpendPerDay, &ps.SpendLimits.BondPerDay},
+		{cfg.MaxBondSpendPerWeek, &ps.SpendLimits.BondPerWeek},
+	} {
+		if limit.eth <= 0 {
+			continue
+		}
+		wei, err := eth.GweiToWei(limit.eth * params.GWei)
+		if err != nil {
+			return fmt.Errorf("invalid spend limit: %w", err)
+		}
+		*limit.wei = wei
+	}
+	return nil
 }
 
 func (ps *ProposerService) initDriver() error {
 	driver, err := NewL2OutputSubmitter(DriverSetup{
 		Log:            ps.Log,
 		Metr:           ps.Metrics,
+		Tracer:         ps.Tracer,
 		Cfg:            ps.ProposerConfig,
 		Txmgr:          ps.TxManager,
+		Treasury:       ps.Treasury,
 		L1Client:       ps.L1Client,
 		RollupProvider: ps.RollupProvider,
+		OutputVerifier: ps.OutputVerifier,
 	})
 	if err != nil {
 		return err
@@ -239,17 +458,47 @@ func (ps *ProposerService) initDriver() error {
 	return nil
 }
 
+// healthChecks returns the health probes of the proposer, served by the RPC server.
+func (ps *ProposerService) healthChecks() *health.Checks {
+	checks := health.NewChecks(ps.Version)
+	checks.RegisterLiveness("driver", ps.driver.Liveness)
+	return checks
+}
+
 func (ps *ProposerService) initRPCServer(cfg *CLIConfig) error {
+	adminAuth, err := oprpc.NewAdminAuth(cfg.RPCConfig.AdminAuth, []string{"admin"}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to configure admin RPC authentication: %w", err)
+	}
+	opts := []oprpc.ServerOption{
+		oprpc.WithLogger(ps.Log),
+		oprpc.WithRPCMetrics(ps.Metrics),
+		oprpc.WithMiddleware(optracing.NewHTTPMiddleware(ps.Tracer, "proposer-rpc")),
+		oprpc.WithAdminAuth(adminAuth),
+		oprpc.WithHealthChecks(ps.healthChecks()),
+	}
+	if cfg.RPCJWTSecret != "" {
+		secrets, err := client.NewJWTSecretsFromFile(ps.Log, cfg.RPCJWTSecret)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, oprpc.WithJWTSecrets(secrets))
+	}
 	server := oprpc.NewServer(
 		cfg.RPCConfig.ListenAddr,
 		cfg.RPCConfig.ListenPort,
 		ps.Version,
-		oprpc.WithLogger(ps.Log),
+		opts...,
 	)
+	server.AddAPI(rpc.GetProposerAPI(rpc.NewProposerAPI(ps.driver)))
 	if cfg.RPCConfig.EnableAdmin {
-		adminAPI := rpc.NewAdminAPI(ps.driver, ps.Metrics, ps.Log)
+		adminAPI := rpc.NewAdminAPI(ps.driver, ps.Log)
 		server.AddAPI(rpc.GetAdminAPI(adminAPI))
-		ps.Log.Info("Admin RPC enabled")
+		if cfg.RPCJWTSecret == "" && adminAuth == nil {
+			ps.Log.Warn("Admin RPC enabled without authentication")
+		} else {
+			ps.Log.Info("Admin RPC enabled")
+		}
 	}
 	ps.Log.Info("Starting JSON-RPC server")
 	if err := server.Start(); err != nil {
@@ -263,7 +512,10 @@ func (ps *ProposerService) initRPCServer(cfg *CLIConfig) error {
 // and starts L2Output-submission work if the proposer is configured to start submit data on startup.
 func (ps *ProposerService) Start(_ context.Context) error {
 	ps.Log.Info("Starting Proposer")
-	return ps.driver.StartL2OutputSubmitting()
+	if err := ps.driver.StartL2OutputSubmitting(); err != nil {
+		return err
+	}
+	return ps.startChains()
 }
 
 func (ps *ProposerService) Stopped() bool {
@@ -291,6 +543,9 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to stop L2Output submitting: %w", err))
 		}
 	}
+	if err := ps.stopChains(ctx); err != nil {
+		result = errors.Join(result, err)
+	}
 
 	if ps.rpcServer != nil {
 		// TODO(7685): the op-service RPC server is not built on top of op-service httputil Server, and has poor shutdown
@@ -312,6 +567,9 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 	if ps.TxManager != nil {
 		ps.TxManager.Close()
 	}
+	if ps.Treasury != nil {
+		ps.Treasury.Close()
+	}
 
 	if ps.metricsSrv != nil {
 		if err := ps.metricsSrv.Stop(ctx); err != nil {
@@ -319,6 +577,12 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 		}
 	}
 
+	if ps.closeTracer != nil {
+		if err := ps.closeTracer(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close tracer: %w", err))
+		}
+	}
+
 	if ps.L1Client != nil {
 		ps.L1Client.Close()
 	}
@@ -327,6 +591,10 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 		ps.RollupProvider.Close()
 	}
 
+	if ps.OutputVerifier != nil {
+		ps.OutputVerifier.Close()
+	}
+
 	if result == nil {
 		ps.stopped.Store(true)
 		ps.Log.Info("L2Output Submitter stopped")
@@ -339,6 +607,8 @@ var _ cliapp.Lifecycle = (*ProposerService)(nil)
 
 // Driver returns the handler on the L2Output-submitter driver element,
 // to start/stop/restart the L2Output-submission work, for use in testing.
+// It only controls the primary chain, not the additional chains of the chains
+// config.
 func (ps *ProposerService) Driver() rpc.ProposerDriver {
 	return ps.driver
 }
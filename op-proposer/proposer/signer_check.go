@@ -0,0 +1,41 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
+)
+
+// checkSigner checks that the signer is healthy and holds the key of the
+// proposer address, by signing a dummy transaction to the proposal contract and
+// recovering its sender. The transaction is never sent. This detects
+// misconfigured remote signers, e.g. ones backed by a KMS key, at startup
+// instead of at the first proposal.
+func checkSigner(ctx context.Context, signer opcrypto.SignerFn, from common.Address, chainID *big.Int, to common.Address) error {
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		To:        &to,
+		Gas:       params.TxGas,
+		GasTipCap: new(big.Int),
+		GasFeeCap: new(big.Int),
+		Value:     new(big.Int),
+	})
+	signed, err := signer(ctx, from, tx)
+	if err != nil {
+		return fmt.Errorf("failed to sign test transaction: %w", err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil {
+		return fmt.Errorf("failed to recover sender of test transaction: %w", err)
+	}
+	if sender != from {
+		return fmt.Errorf("signer signed test transaction for %s, expected proposer address %s", sender, from)
+	}
+	return nil
+}
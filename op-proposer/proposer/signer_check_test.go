@@ -0,0 +1,46 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
+)
+
+func TestCheckSigner(t *testing.T) {
+	chainID := big.NewInt(900)
+	to := common.Address{0x42}
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	keySigner := opcrypto.PrivateKeySignerFn(key, chainID)
+	signer := func(_ context.Context, addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return keySigner(addr, tx)
+	}
+
+	require.NoError(t, checkSigner(context.Background(), signer, from, chainID, to))
+
+	t.Run("wrong-key", func(t *testing.T) {
+		other, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		otherSigner := opcrypto.PrivateKeySignerFn(other, chainID)
+		signer := func(_ context.Context, _ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return otherSigner(crypto.PubkeyToAddress(other.PublicKey), tx)
+		}
+		require.ErrorContains(t, checkSigner(context.Background(), signer, from, chainID, to), "expected proposer address")
+	})
+
+	t.Run("signer-error", func(t *testing.T) {
+		signer := func(context.Context, common.Address, *types.Transaction) (*types.Transaction, error) {
+			return nil, errors.New("unreachable")
+		}
+		require.ErrorContains(t, checkSigner(context.Background(), signer, from, chainID, to), "unreachable")
+	})
+}
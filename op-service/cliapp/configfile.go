@@ -0,0 +1,203 @@
+package cliapp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile holds the flag values of a TOML or YAML config file, keyed by flag name.
+// Nested tables are flattened by joining the keys with a dot,
+// e.g. the "tcp" key of the "p2p.listen" table sets the "p2p.listen.tcp" flag.
+// List values are only accepted for flags that can be given multiple times.
+type ConfigFile map[string][]string
+
+// LoadConfigFile reads a config file. The format is determined by the file extension:
+// ".toml" for TOML, and ".yaml" or ".yml" for YAML.
+func LoadConfigFile(path string) (ConfigFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var raw map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q, expected .toml, .yaml or .yml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config file %q: %w", path, err)
+	}
+	out := make(ConfigFile)
+	if err := flattenConfig("", raw, out); err != nil {
+		return nil, fmt.Errorf("invalid config file %q: %w", path, err)
+	}
+	return out, nil
+}
+
+func flattenConfig(prefix string, raw map[string]any, out ConfigFile) error {
+	for k, v := range raw {
+		name := k
+		if prefix != "" {
+			name = prefix + "." + k
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			if err := flattenConfig(name, v, out); err != nil {
+				return err
+			}
+			continue
+		case []any:
+			values := make([]string, 0, len(v))
+			for _, elem := range v {
+				s, err := configValueString(elem)
+				if err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				values = append(values, s)
+			}
+			if _, ok := out[name]; ok {
+				return fmt.Errorf("duplicate key %q", name)
+			}
+			out[name] = values
+		default:
+			s, err := configValueString(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			if _, ok := out[name]; ok {
+				return fmt.Errorf("duplicate key %q", name)
+			}
+			out[name] = []string{s}
+		}
+	}
+	return nil
+}
+
+func configValueString(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	case nil:
+		return "", fmt.Errorf("missing value")
+	default:
+		return "", fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+// Validate checks that every key of the config file is the name of one of the flags,
+// and that only flags which can be given multiple times have a list value.
+func (f ConfigFile) Validate(flags []cli.Flag) error {
+	byName := flagsByName(flags)
+	var unknown []string
+	for _, name := range f.Names() {
+		flag, ok := byName[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		if len(f[name]) != 1 && !isSliceFlag(flag) {
+			return fmt.Errorf("flag %s does not accept a list value", name)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown flags in config file: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Apply sets the flags of the config file that are not already set by the CLI arguments or env vars.
+// It returns the names of the flags that are overridden by CLI arguments or env vars.
+func (f ConfigFile) Apply(ctx *cli.Context, flags []cli.Flag) (overridden []string, err error) {
+	if err := f.Validate(flags); err != nil {
+		return nil, err
+	}
+	for _, name := range f.Names() {
+		if ctx.IsSet(name) {
+			overridden = append(overridden, name)
+			continue
+		}
+		for _, v := range f[name] {
+			if err := ctx.Set(name, v); err != nil {
+				return nil, fmt.Errorf("invalid value %q for flag %s: %w", v, name, err)
+			}
+		}
+	}
+	return overridden, nil
+}
+
+// Names returns the sorted flag names of the config file.
+func (f ConfigFile) Names() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConfigChange is a flag value that changed between two versions of a config file.
+// Old or New is empty if the flag was added or removed.
+type ConfigChange struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// DiffConfigFiles returns the changes from a to b, sorted by flag name.
+func DiffConfigFiles(a, b ConfigFile) []ConfigChange {
+	var changes []ConfigChange
+	for name, v := range a {
+		old := strings.Join(v, ",")
+		if next := strings.Join(b[name], ","); old != next {
+			changes = append(changes, ConfigChange{Name: name, Old: old, New: next})
+		}
+	}
+	for name, v := range b {
+		if _, ok := a[name]; !ok {
+			changes = append(changes, ConfigChange{Name: name, New: strings.Join(v, ",")})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func flagsByName(flags []cli.Flag) map[string]cli.Flag {
+	byName := make(map[string]cli.Flag)
+	for _, f := range flags {
+		for _, name := range f.Names() {
+			byName[name] = f
+		}
+	}
+	return byName
+}
+
+func isSliceFlag(f cli.Flag) bool {
+	sliceFlag, ok := f.(cli.DocGenerationSliceFlag)
+	return ok && sliceFlag.IsSliceFlag()
+}
@@ -0,0 +1,244 @@
+package cliapp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func testConfigFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{Name: "l1"},
+		&cli.IntFlag{Name: "p2p.listen.tcp", Value: 9222},
+		&cli.BoolFlag{Name: "p2p.disable"},
+		&cli.DurationFlag{Name: "l1.http-poll-interval", Value: 12 * time.Second},
+		&cli.StringSliceFlag{Name: "p2p.bootnodes"},
+		&cli.StringFlag{Name: "log.level", Value: "info", EnvVars: []string{"TEST_CONFIG_LOG_LEVEL"}},
+	}
+}
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+const testTOML = `
+l1 = "http://localhost:8545"
+"l1.http-poll-interval" = "4s"
+
+[p2p]
+disable = true
+bootnodes = ["enode://a", "enode://b"]
+listen.tcp = 9000
+`
+
+const testYAML = `
+l1: http://localhost:8545
+l1.http-poll-interval: 4s
+p2p:
+  disable: true
+  bootnodes:
+    - enode://a
+    - enode://b
+  listen:
+    tcp: 9000
+`
+
+func TestLoadConfigFile(t *testing.T) {
+	expected := ConfigFile{
+		"l1":                    {"http://localhost:8545"},
+		"l1.http-poll-interval": {"4s"},
+		"p2p.disable":           {"true"},
+		"p2p.bootnodes":         {"enode://a", "enode://b"},
+		"p2p.listen.tcp":        {"9000"},
+	}
+	t.Run("TOML", func(t *testing.T) {
+		cfg, err := LoadConfigFile(writeConfigFile(t, "config.toml", testTOML))
+		require.NoError(t, err)
+		require.Equal(t, expected, cfg)
+		require.NoError(t, cfg.Validate(testConfigFlags()))
+	})
+	t.Run("YAML", func(t *testing.T) {
+		for _, name := range []string{"config.yaml", "config.yml"} {
+			cfg, err := LoadConfigFile(writeConfigFile(t, name, testYAML))
+			require.NoError(t, err)
+			require.Equal(t, expected, cfg)
+		}
+	})
+	t.Run("UnsupportedExtension", func(t *testing.T) {
+		_, err := LoadConfigFile(writeConfigFile(t, "config.json", "{}"))
+		require.ErrorContains(t, err, "unsupported config file extension")
+	})
+	t.Run("Missing", func(t *testing.T) {
+		_, err := LoadConfigFile(filepath.Join(t.TempDir(), "config.toml"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := LoadConfigFile(writeConfigFile(t, "config.toml", "l1 = "))
+		require.ErrorContains(t, err, "failed to decode config file")
+	})
+	t.Run("DuplicateKey", func(t *testing.T) {
+		_, err := LoadConfigFile(writeConfigFile(t, "config.yaml", "p2p.disable: true\np2p:\n  disable: false\n"))
+		require.ErrorContains(t, err, `duplicate key "p2p.disable"`)
+	})
+	t.Run("NullValue", func(t *testing.T) {
+		_, err := LoadConfigFile(writeConfigFile(t, "config.yaml", "l1:\n"))
+		require.ErrorContains(t, err, "l1: missing value")
+	})
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	t.Run("UnknownFlags", func(t *testing.T) {
+		cfg := ConfigFile{"l1": {"a"}, "l3": {"b"}, "p2p.nope": {"c"}}
+		require.EqualError(t, cfg.Validate(testConfigFlags()), "unknown flags in config file: l3, p2p.nope")
+	})
+	t.Run("ListForSingleValueFlag", func(t *testing.T) {
+		cfg := ConfigFile{"l1": {"a", "b"}}
+		require.EqualError(t, cfg.Validate(testConfigFlags()), "flag l1 does not accept a list value")
+	})
+}
+
+func runWithConfigFile(t *testing.T, cfg ConfigFile, args []string, fn func(ctx *cli.Context, overridden []string)) error {
+	flags := testConfigFlags()
+	app := &cli.App{
+		Name:  "test",
+		Flags: flags,
+		Action: func(ctx *cli.Context) error {
+			overridden, err := cfg.Apply(ctx, flags)
+			if err != nil {
+				return err
+			}
+			fn(ctx, overridden)
+			return nil
+		},
+	}
+	return app.Run(append([]string{"test"}, args...))
+}
+
+func TestApplyConfigFile(t *testing.T) {
+	cfg := ConfigFile{
+		"l1":                    {"http://localhost:8545"},
+		"l1.http-poll-interval": {"4s"},
+		"p2p.disable":           {"true"},
+		"p2p.bootnodes":         {"enode://a", "enode://b"},
+		"p2p.listen.tcp":        {"9000"},
+		"log.level":             {"debug"},
+	}
+	t.Run("SetsFlags", func(t *testing.T) {
+		called := false
+		require.NoError(t, runWithConfigFile(t, cfg, nil, func(ctx *cli.Context, overridden []string) {
+			called = true
+			require.Empty(t, overridden)
+			require.Equal(t, "http://localhost:8545", ctx.String("l1"))
+			require.Equal(t, 4*time.Second, ctx.Duration("l1.http-poll-interval"))
+			require.True(t, ctx.Bool("p2p.disable"))
+			require.Equal(t, []string{"enode://a", "enode://b"}, ctx.StringSlice("p2p.bootnodes"))
+			require.Equal(t, 9000, ctx.Int("p2p.listen.tcp"))
+			require.Equal(t, "debug", ctx.String("log.level"))
+		}))
+		require.True(t, called)
+	})
+	t.Run("FlagsAndEnvVarsTakePrecedence", func(t *testing.T) {
+		t.Setenv("TEST_CONFIG_LOG_LEVEL", "warn")
+		require.NoError(t, runWithConfigFile(t, cfg, []string{"--l1=http://other:8545", "--p2p.bootnodes=enode://c"}, func(ctx *cli.Context, overridden []string) {
+			require.Equal(t, []string{"l1", "log.level", "p2p.bootnodes"}, overridden)
+			require.Equal(t, "http://other:8545", ctx.String("l1"))
+			require.Equal(t, "warn", ctx.String("log.level"))
+			require.Equal(t, []string{"enode://c"}, ctx.StringSlice("p2p.bootnodes"))
+			require.Equal(t, 9000, ctx.Int("p2p.listen.tcp"))
+		}))
+	})
+	t.Run("InvalidValue", func(t *testing.T) {
+		err := runWithConfigFile(t, ConfigFile{"p2p.listen.tcp": {"abc"}}, nil, func(ctx *cli.Context, overridden []string) {})
+		require.ErrorContains(t, err, `invalid value "abc" for flag p2p.listen.tcp`)
+	})
+	t.Run("UnknownFlag", func(t *testing.T) {
+		err := runWithConfigFile(t, ConfigFile{"l3": {"abc"}}, nil, func(ctx *cli.Context, overridden []string) {})
+		require.ErrorContains(t, err, "unknown flags in config file: l3")
+	})
+}
+
+func TestDiffConfigFiles(t *testing.T) {
+	a := ConfigFile{"l1": {"a"}, "p2p.disable": {"true"}, "p2p.bootnodes": {"x", "y"}}
+	b := ConfigFile{"l1": {"b"}, "p2p.bootnodes": {"x", "y"}, "log.level": {"debug"}}
+	require.Equal(t, []ConfigChange{
+		{Name: "l1", Old: "a", New: "b"},
+		{Name: "log.level", New: "debug"},
+		{Name: "p2p.disable", Old: "true"},
+	}, DiffConfigFiles(a, b))
+	require.Empty(t, DiffConfigFiles(a, a))
+}
+
+func TestConfigReloader(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", `
+l1 = "http://localhost:8545"
+"log.level" = "debug"
+"p2p.disable" = true
+`)
+	current, err := LoadConfigFile(path)
+	require.NoError(t, err)
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	r := NewConfigReloader(logger, path, testConfigFlags(), current, []string{"l1"})
+
+	var levels []string
+	failLevel := false
+	r.OnChange("log.level", func(value string) error {
+		if failLevel {
+			return errors.New("boom")
+		}
+		levels = append(levels, value)
+		return nil
+	})
+	var disabled []string
+	r.OnChange("p2p.disable", func(value string) error {
+		disabled = append(disabled, value)
+		return nil
+	})
+
+	require.NoError(t, r.Reload())
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Reloaded config file, no changes")))
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+l1 = "http://other:8545"
+"log.level" = "warn"
+"p2p.listen.tcp" = 9000
+`), 0o644))
+	require.NoError(t, r.Reload())
+	require.Equal(t, []string{"warn"}, levels)
+	// removed flags are reset to their default
+	require.Equal(t, []string{"false"}, disabled)
+	require.NotNil(t, logs.FindLog(
+		testlog.NewMessageFilter("Ignoring config change, flag is set by CLI argument or env var"),
+		testlog.NewAttributesFilter("flag", "l1")))
+	require.NotNil(t, logs.FindLog(
+		testlog.NewMessageFilter("Config change takes effect on restart"),
+		testlog.NewAttributesFilter("flag", "p2p.listen.tcp")))
+	require.NotNil(t, logs.FindLog(
+		testlog.NewMessageFilter("Applied config change"),
+		testlog.NewAttributesFilter("flag", "log.level"),
+		testlog.NewAttributesFilter("old", "debug"),
+		testlog.NewAttributesFilter("new", "warn")))
+
+	// a failed change is applied again on the next reload
+	require.NoError(t, os.WriteFile(path, []byte(`"log.level" = "error"`), 0o644))
+	failLevel = true
+	require.ErrorContains(t, r.Reload(), "failed to apply flag log.level: boom")
+	failLevel = false
+	require.NoError(t, r.Reload())
+	require.Equal(t, []string{"warn", "error"}, levels)
+
+	// invalid config files are rejected as a whole
+	require.NoError(t, os.WriteFile(path, []byte(`"log.level" = "info"
+l3 = "x"`), 0o644))
+	require.ErrorContains(t, r.Reload(), "unknown flags in config file: l3")
+	require.Equal(t, []string{"warn", "error"}, levels)
+}
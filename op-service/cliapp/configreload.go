@@ -0,0 +1,129 @@
+package cliapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+// ReloadFunc applies the new value of a runtime-changeable flag.
+type ReloadFunc func(value string) error
+
+// ConfigReloader reloads a config file, and applies the changes of the runtime-changeable flags.
+// Changes of other flags are logged, and take effect when the service is restarted.
+type ConfigReloader struct {
+	log    log.Logger
+	path   string
+	flags  []cli.Flag
+	byName map[string]cli.Flag
+
+	current    ConfigFile
+	overridden map[string]struct{}
+	reloadable map[string]ReloadFunc
+}
+
+// NewConfigReloader creates a reloader of the config file at path,
+// given the current config file as applied, and the flags that are overridden by CLI arguments or env vars.
+func NewConfigReloader(log log.Logger, path string, flags []cli.Flag, current ConfigFile, overridden []string) *ConfigReloader {
+	r := &ConfigReloader{
+		log:        log,
+		path:       path,
+		flags:      flags,
+		byName:     flagsByName(flags),
+		current:    current,
+		overridden: make(map[string]struct{}),
+		reloadable: make(map[string]ReloadFunc),
+	}
+	for _, name := range overridden {
+		r.overridden[name] = struct{}{}
+	}
+	return r
+}
+
+// OnChange registers the flag as runtime-changeable: fn is called with the new value on reload.
+// If the flag is removed from the config file, fn is called with the default value of the flag.
+func (r *ConfigReloader) OnChange(name string, fn ReloadFunc) {
+	r.reloadable[name] = fn
+}
+
+// Reload reads the config file again, logs the changes, and applies the changes of the runtime-changeable flags.
+// An invalid config file is rejected as a whole. If a change fails to apply,
+// the other changes are still applied, and the failed change is tried again on the next reload.
+func (r *ConfigReloader) Reload() error {
+	next, err := LoadConfigFile(r.path)
+	if err != nil {
+		return err
+	}
+	if err := next.Validate(r.flags); err != nil {
+		return err
+	}
+	changes := DiffConfigFiles(r.current, next)
+	if len(changes) == 0 {
+		r.log.Info("Reloaded config file, no changes", "path", r.path)
+		return nil
+	}
+	var result error
+	for _, c := range changes {
+		if _, ok := r.overridden[c.Name]; ok {
+			r.log.Warn("Ignoring config change, flag is set by CLI argument or env var", "flag", c.Name, "old", c.Old, "new", c.New)
+			continue
+		}
+		fn, ok := r.reloadable[c.Name]
+		if !ok {
+			r.log.Warn("Config change takes effect on restart", "flag", c.Name, "old", c.Old, "new", c.New)
+			continue
+		}
+		value := c.New
+		if _, ok := next[c.Name]; !ok {
+			value = flagDefault(r.byName[c.Name])
+		}
+		if err := fn(value); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to apply flag %s: %w", c.Name, err))
+			// keep the old value, so the change is applied again on the next reload
+			if old, ok := r.current[c.Name]; ok {
+				next[c.Name] = old
+			} else {
+				delete(next, c.Name)
+			}
+			continue
+		}
+		r.log.Info("Applied config change", "flag", c.Name, "old", c.Old, "new", c.New)
+	}
+	r.current = next
+	return result
+}
+
+// Run reloads the config file on every SIGHUP signal, until the context is done.
+func (r *ConfigReloader) Run(ctx context.Context) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigs:
+			r.log.Info("Received SIGHUP, reloading config file", "path", r.path)
+			if err := r.Reload(); err != nil {
+				r.log.Error("Failed to reload config file", "path", r.path, "err", err)
+			}
+		}
+	}
+}
+
+func flagDefault(f cli.Flag) string {
+	if boolFlag, ok := f.(*cli.BoolFlag); ok {
+		return strconv.FormatBool(boolFlag.Value)
+	}
+	if docFlag, ok := f.(cli.DocGenerationFlag); ok {
+		return docFlag.GetValue()
+	}
+	return ""
+}
@@ -2,12 +2,18 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 func TestIsURLAvailableLocal(t *testing.T) {
@@ -55,3 +61,15 @@ func TestIsURLAvailableNonLocal(t *testing.T) {
 	require.False(t, IsURLAvailable(context.Background(), "wss://fakedomainnamethatdoesnotexistandshouldneverexist.com"))
 	require.False(t, IsURLAvailable(context.Background(), "wss://fakedomainnamethatdoesnotexistandshouldneverexist.com/hello"))
 }
+
+func TestNewRPCInvalidURLNotRetried(t *testing.T) {
+	start := time.Now()
+	_, err := NewRPC(context.Background(), testlog.Logger(t, log.LevelInfo), "http://[::1", WithDialBackoff(10))
+	require.ErrorContains(t, err, "invalid address")
+	require.Equal(t, retry.Fatal, retry.DefaultClassifier(err))
+	var failed *retry.ErrFailedPermanently
+	require.False(t, errors.As(err, &failed))
+	require.Less(t, time.Since(start), time.Second)
+
+	require.NoError(t, CheckURL("http://localhost:8545"))
+}
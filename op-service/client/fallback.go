@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+var ErrNoEndpoints = errors.New("at least one endpoint is required")
+
+// FallbackConfig configures how a FallbackClient detects unhealthy endpoints.
+type FallbackConfig struct {
+	// ErrorThreshold is the number of errors within ErrorWindow after which an endpoint is considered unhealthy.
+	ErrorThreshold int
+	// ErrorWindow is the period over which errors are counted.
+	ErrorWindow time.Duration
+	// StallTimeout is how long the head of an endpoint may stay unchanged before the endpoint is considered stalled.
+	StallTimeout time.Duration
+	// HeadPollInterval is how often the head of each endpoint is polled. Zero disables polling, and stall detection.
+	HeadPollInterval time.Duration
+}
+
+// DefaultFallbackConfig returns the FallbackConfig suitable for an L1 chain with 12 second blocks.
+func DefaultFallbackConfig() FallbackConfig {
+	return FallbackConfig{
+		ErrorThreshold:   10,
+		ErrorWindow:      time.Minute,
+		StallTimeout:     time.Minute,
+		HeadPollInterval: 12 * time.Second,
+	}
+}
+
+func (c FallbackConfig) Check() error {
+	if c.ErrorThreshold <= 0 {
+		return errors.New("error threshold must be positive")
+	}
+	if c.ErrorWindow <= 0 {
+		return errors.New("error window must be positive")
+	}
+	if c.HeadPollInterval > 0 && c.StallTimeout <= c.HeadPollInterval {
+		return errors.New("stall timeout must be greater than the head poll interval")
+	}
+	return nil
+}
+
+// FallbackEndpoint is an RPC endpoint of a FallbackClient.
+type FallbackEndpoint struct {
+	// Name identifies the endpoint in logs. It must not contain any secrets, e.g. API keys in URLs.
+	Name string
+	RPC  RPC
+}
+
+type endpointHealth struct {
+	errs        []time.Time
+	head        uint64
+	headUpdated time.Time
+	headErr     error
+}
+
+// FallbackClient is an RPC client that routes requests to one of multiple endpoints of the same chain.
+// Requests are routed to the first healthy endpoint, in the order the endpoints were provided.
+// An endpoint is unhealthy when it returns a burst of errors, or when its head stalls or can't be fetched.
+// When the current endpoint becomes unhealthy, subsequent requests fail over to the next healthy endpoint,
+// and move back once a preferred endpoint recovers. Failed requests are not retried.
+//
+// Subscriptions are made with the endpoint that is current at the time of subscribing,
+// and are not moved to another endpoint on failover.
+type FallbackClient struct {
+	lgr       log.Logger
+	cfg       FallbackConfig
+	clock     clock.Clock
+	endpoints []FallbackEndpoint
+
+	mu      sync.Mutex
+	health  []endpointHealth
+	current int
+	// noneHealthy is set while no endpoint is healthy, to only log it once.
+	noneHealthy bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ RPC = (*FallbackClient)(nil)
+
+// NewFallbackClient creates a FallbackClient with the given endpoints, in order of preference.
+// The head of each endpoint is polled in the background until the client is closed.
+func NewFallbackClient(lgr log.Logger, cfg FallbackConfig, endpoints ...FallbackEndpoint) (*FallbackClient, error) {
+	return newFallbackClient(lgr, cfg, clock.SystemClock, endpoints...)
+}
+
+func newFallbackClient(lgr log.Logger, cfg FallbackConfig, cl clock.Clock, endpoints ...FallbackEndpoint) (*FallbackClient, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid fallback config: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &FallbackClient{
+		lgr:       lgr,
+		cfg:       cfg,
+		clock:     cl,
+		endpoints: endpoints,
+		health:    make([]endpointHealth, len(endpoints)),
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	now := cl.Now()
+	for i := range f.health {
+		f.health[i].headUpdated = now
+	}
+	go f.pollHeads()
+	return f, nil
+}
+
+// DialFallbackClient dials each of the given URLs with NewRPC and creates a FallbackClient using them,
+// in order of preference.
+func DialFallbackClient(ctx context.Context, lgr log.Logger, urls []string, cfg FallbackConfig, opts ...RPCOption) (*FallbackClient, error) {
+	endpoints := make([]FallbackEndpoint, 0, len(urls))
+	closeAll := func() {
+		for _, e := range endpoints {
+			e.RPC.Close()
+		}
+	}
+	for i, addr := range urls {
+		// The URLs may contain API keys, so only identify endpoints by index in logs.
+		name := fmt.Sprintf("endpoint-%d", i)
+		r, err := NewRPC(ctx, lgr.New("endpoint", name), addr, opts...)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to dial %s: %w", name, err)
+		}
+		endpoints = append(endpoints, FallbackEndpoint{Name: name, RPC: r})
+	}
+	f, err := NewFallbackClient(lgr, cfg, endpoints...)
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
+	return f, nil
+}
+
+// Close stops polling the endpoints and closes all of them.
+func (f *FallbackClient) Close() {
+	f.cancel()
+	<-f.done
+	for _, e := range f.endpoints {
+		e.RPC.Close()
+	}
+}
+
+func (f *FallbackClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	idx := f.currentIndex()
+	err := f.endpoints[idx].RPC.CallContext(ctx, result, method, args...)
+	f.recordResult(ctx, idx, err)
+	return err
+}
+
+func (f *FallbackClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	idx := f.currentIndex()
+	err := f.endpoints[idx].RPC.BatchCallContext(ctx, b)
+	f.recordResult(ctx, idx, err)
+	return err
+}
+
+func (f *FallbackClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	idx := f.currentIndex()
+	sub, err := f.endpoints[idx].RPC.EthSubscribe(ctx, channel, args...)
+	f.recordResult(ctx, idx, err)
+	return sub, err
+}
+
+// Current returns the name of the endpoint requests are currently routed to.
+func (f *FallbackClient) Current() string {
+	return f.endpoints[f.currentIndex()].Name
+}
+
+func (f *FallbackClient) currentIndex() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.current
+}
+
+// recordResult records the result of a request to the endpoint at idx, and fails over if the endpoint became unhealthy.
+func (f *FallbackClient) recordResult(ctx context.Context, idx int, err error) {
+	if !isEndpointError(ctx, err) {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.health[idx].errs = append(f.health[idx].errs, f.clock.Now())
+	f.selectEndpoint()
+}
+
+// isEndpointError returns true if err indicates a problem with the endpoint,
+// rather than with the request or the caller giving up on it.
+func isEndpointError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	// JSON-RPC error responses are returned by a functioning endpoint, e.g. for reverted calls.
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// unhealthyReason returns why the endpoint at idx is unhealthy, or an empty string if it is healthy.
+// Must be called with f.mu held.
+func (f *FallbackClient) unhealthyReason(idx int) string {
+	h := &f.health[idx]
+	now := f.clock.Now()
+	// Drop errors that are outside the error window
+	recent := h.errs[:0]
+	for _, t := range h.errs {
+		if now.Sub(t) < f.cfg.ErrorWindow {
+			recent = append(recent, t)
+		}
+	}
+	h.errs = recent
+	if len(h.errs) >= f.cfg.ErrorThreshold {
+		return "error burst"
+	}
+	if f.cfg.HeadPollInterval == 0 {
+		return ""
+	}
+	if h.headErr != nil {
+		return "head unavailable"
+	}
+	if now.Sub(h.headUpdated) > f.cfg.StallTimeout {
+		return "head stalled"
+	}
+	return ""
+}
+
+// selectEndpoint routes requests to the first healthy endpoint. If no endpoint is healthy, the current endpoint is kept.
+// Must be called with f.mu held.
+func (f *FallbackClient) selectEndpoint() {
+	for i := range f.endpoints {
+		if f.unhealthyReason(i) != "" {
+			continue
+		}
+		if i != f.current {
+			f.lgr.Warn("Switching RPC endpoint",
+				"from", f.endpoints[f.current].Name, "to", f.endpoints[i].Name,
+				"reason", f.unhealthyReason(f.current))
+			f.current = i
+		}
+		f.noneHealthy = false
+		return
+	}
+	if !f.noneHealthy {
+		f.lgr.Error("No healthy RPC endpoint available", "current", f.endpoints[f.current].Name,
+			"reason", f.unhealthyReason(f.current))
+		f.noneHealthy = true
+	}
+}
+
+func (f *FallbackClient) pollHeads() {
+	defer close(f.done)
+	if f.cfg.HeadPollInterval == 0 {
+		return
+	}
+	ticker := f.clock.NewTicker(f.cfg.HeadPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.Ch():
+			f.checkHeads(f.ctx)
+		case <-f.ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHeads fetches the head of each endpoint to detect stalls, then re-selects the endpoint to route requests to.
+func (f *FallbackClient) checkHeads(ctx context.Context) {
+	heads := make([]uint64, len(f.endpoints))
+	errs := make([]error, len(f.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range f.endpoints {
+		wg.Add(1)
+		go func(i int, e FallbackEndpoint) {
+			defer wg.Done()
+			cCtx, cancel := context.WithTimeout(ctx, f.cfg.HeadPollInterval)
+			defer cancel()
+			var head hexutil.Uint64
+			errs[i] = e.RPC.CallContext(cCtx, &head, "eth_blockNumber")
+			heads[i] = uint64(head)
+		}(i, e)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.clock.Now()
+	for i := range f.endpoints {
+		h := &f.health[i]
+		h.headErr = errs[i]
+		if errs[i] != nil {
+			f.lgr.Warn("Failed to fetch RPC endpoint head", "endpoint", f.endpoints[i].Name, "err", errs[i])
+			continue
+		}
+		if heads[i] != h.head {
+			h.head = heads[i]
+			h.headUpdated = now
+		}
+	}
+	f.selectEndpoint()
+}
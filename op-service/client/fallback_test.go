@@ -0,0 +1,264 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fallbackTestRPC struct {
+	mu      sync.Mutex
+	head    uint64
+	headErr error
+	callErr error
+	calls   int
+	closed  bool
+}
+
+func (r *fallbackTestRPC) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+}
+
+func (r *fallbackTestRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if method == "eth_blockNumber" {
+		if r.headErr != nil {
+			return r.headErr
+		}
+		*result.(*hexutil.Uint64) = hexutil.Uint64(r.head)
+		return nil
+	}
+	r.calls++
+	return r.callErr
+}
+
+func (r *fallbackTestRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	return r.callErr
+}
+
+func (r *fallbackTestRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func (r *fallbackTestRPC) set(fn func(r *fallbackTestRPC)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(r)
+}
+
+func (r *fallbackTestRPC) callCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls
+}
+
+type jsonRPCError struct{}
+
+func (jsonRPCError) Error() string  { return "execution reverted" }
+func (jsonRPCError) ErrorCode() int { return 3 }
+
+var testFallbackConfig = FallbackConfig{
+	ErrorThreshold:   3,
+	ErrorWindow:      time.Minute,
+	StallTimeout:     30 * time.Second,
+	HeadPollInterval: 10 * time.Second,
+}
+
+// newTestFallbackClient creates a FallbackClient with the background head polling stopped,
+// so heads are only checked when the test calls checkHeads.
+func newTestFallbackClient(t *testing.T, count int) (*FallbackClient, []*fallbackTestRPC, *clock.DeterministicClock) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	rpcs := make([]*fallbackTestRPC, count)
+	endpoints := make([]FallbackEndpoint, count)
+	for i := range rpcs {
+		rpcs[i] = &fallbackTestRPC{}
+		endpoints[i] = FallbackEndpoint{Name: string(rune('a' + i)), RPC: rpcs[i]}
+	}
+	f, err := newFallbackClient(testlog.Logger(t, log.LevelInfo), testFallbackConfig, cl, endpoints...)
+	require.NoError(t, err)
+	f.cancel()
+	<-f.done
+	return f, rpcs, cl
+}
+
+func TestFallbackClientConfig(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	t.Run("NoEndpoints", func(t *testing.T) {
+		_, err := NewFallbackClient(logger, DefaultFallbackConfig())
+		require.ErrorIs(t, err, ErrNoEndpoints)
+	})
+	t.Run("Default", func(t *testing.T) {
+		require.NoError(t, DefaultFallbackConfig().Check())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		cfg := DefaultFallbackConfig()
+		cfg.ErrorThreshold = 0
+		require.Error(t, cfg.Check())
+
+		cfg = DefaultFallbackConfig()
+		cfg.ErrorWindow = 0
+		require.Error(t, cfg.Check())
+
+		cfg = DefaultFallbackConfig()
+		cfg.StallTimeout = cfg.HeadPollInterval
+		require.Error(t, cfg.Check())
+
+		_, err := NewFallbackClient(logger, cfg, FallbackEndpoint{Name: "a", RPC: &fallbackTestRPC{}})
+		require.Error(t, err)
+	})
+	t.Run("StallDetectionDisabled", func(t *testing.T) {
+		cfg := DefaultFallbackConfig()
+		cfg.HeadPollInterval = 0
+		cfg.StallTimeout = 0
+		require.NoError(t, cfg.Check())
+	})
+}
+
+func TestFallbackClientRoutesToPrimary(t *testing.T) {
+	f, rpcs, _ := newTestFallbackClient(t, 2)
+	ctx := context.Background()
+	require.NoError(t, f.CallContext(ctx, nil, "eth_chainId"))
+	require.NoError(t, f.BatchCallContext(ctx, []rpc.BatchElem{{Method: "eth_chainId"}}))
+	require.Equal(t, 2, rpcs[0].callCount())
+	require.Zero(t, rpcs[1].callCount())
+	require.Equal(t, "a", f.Current())
+}
+
+func TestFallbackClientErrorBurst(t *testing.T) {
+	ctx := context.Background()
+	t.Run("FailsOver", func(t *testing.T) {
+		f, rpcs, _ := newTestFallbackClient(t, 2)
+		errBoom := errors.New("boom")
+		rpcs[0].set(func(r *fallbackTestRPC) { r.callErr = errBoom })
+		for i := 0; i < testFallbackConfig.ErrorThreshold; i++ {
+			require.ErrorIs(t, f.CallContext(ctx, nil, "eth_chainId"), errBoom)
+		}
+		require.Equal(t, "b", f.Current())
+		require.NoError(t, f.CallContext(ctx, nil, "eth_chainId"))
+		require.Equal(t, 1, rpcs[1].callCount())
+	})
+
+	t.Run("RecoversAfterWindow", func(t *testing.T) {
+		f, rpcs, cl := newTestFallbackClient(t, 2)
+		rpcs[0].set(func(r *fallbackTestRPC) { r.callErr = errors.New("boom") })
+		for i := 0; i < testFallbackConfig.ErrorThreshold; i++ {
+			require.Error(t, f.CallContext(ctx, nil, "eth_chainId"))
+		}
+		require.Equal(t, "b", f.Current())
+
+		cl.AdvanceTime(testFallbackConfig.ErrorWindow)
+		for _, r := range rpcs {
+			r.set(func(r *fallbackTestRPC) {
+				r.callErr = nil
+				r.head++
+			})
+		}
+		f.checkHeads(ctx)
+		require.Equal(t, "a", f.Current(), "should move back to the preferred endpoint")
+	})
+
+	t.Run("IgnoresJSONRPCErrors", func(t *testing.T) {
+		f, rpcs, _ := newTestFallbackClient(t, 2)
+		rpcs[0].set(func(r *fallbackTestRPC) { r.callErr = jsonRPCError{} })
+		for i := 0; i < testFallbackConfig.ErrorThreshold*2; i++ {
+			require.Error(t, f.CallContext(ctx, nil, "eth_call"))
+		}
+		require.Equal(t, "a", f.Current())
+	})
+
+	t.Run("IgnoresCancelledRequests", func(t *testing.T) {
+		f, rpcs, _ := newTestFallbackClient(t, 2)
+		rpcs[0].set(func(r *fallbackTestRPC) { r.callErr = context.Canceled })
+		cCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		for i := 0; i < testFallbackConfig.ErrorThreshold*2; i++ {
+			require.Error(t, f.CallContext(cCtx, nil, "eth_chainId"))
+		}
+		require.Equal(t, "a", f.Current())
+	})
+
+	t.Run("KeepsCurrentWhenNoneHealthy", func(t *testing.T) {
+		f, rpcs, _ := newTestFallbackClient(t, 2)
+		for _, r := range rpcs {
+			r.set(func(r *fallbackTestRPC) { r.callErr = errors.New("boom") })
+		}
+		for i := 0; i < testFallbackConfig.ErrorThreshold; i++ {
+			require.Error(t, f.CallContext(ctx, nil, "eth_chainId"))
+		}
+		require.Equal(t, "b", f.Current())
+		for i := 0; i < testFallbackConfig.ErrorThreshold; i++ {
+			require.Error(t, f.CallContext(ctx, nil, "eth_chainId"))
+		}
+		require.Equal(t, "b", f.Current())
+	})
+}
+
+func TestFallbackClientStall(t *testing.T) {
+	ctx := context.Background()
+	t.Run("FailsOverWhenHeadStalls", func(t *testing.T) {
+		f, rpcs, cl := newTestFallbackClient(t, 2)
+		rpcs[0].set(func(r *fallbackTestRPC) { r.head = 10 })
+		rpcs[1].set(func(r *fallbackTestRPC) { r.head = 10 })
+		f.checkHeads(ctx)
+		require.Equal(t, "a", f.Current())
+
+		// Only the second endpoint keeps advancing
+		for head := uint64(11); head <= 14; head++ {
+			head := head
+			cl.AdvanceTime(testFallbackConfig.HeadPollInterval)
+			rpcs[1].set(func(r *fallbackTestRPC) { r.head = head })
+			f.checkHeads(ctx)
+		}
+		require.Equal(t, "b", f.Current())
+
+		// The first endpoint recovers
+		rpcs[0].set(func(r *fallbackTestRPC) { r.head = 14 })
+		f.checkHeads(ctx)
+		require.Equal(t, "a", f.Current())
+	})
+
+	t.Run("FailsOverWhenHeadUnavailable", func(t *testing.T) {
+		f, rpcs, _ := newTestFallbackClient(t, 2)
+		rpcs[0].set(func(r *fallbackTestRPC) { r.headErr = errors.New("boom") })
+		f.checkHeads(ctx)
+		require.Equal(t, "b", f.Current())
+
+		rpcs[0].set(func(r *fallbackTestRPC) { r.headErr = nil })
+		f.checkHeads(ctx)
+		require.Equal(t, "a", f.Current())
+	})
+}
+
+func TestFallbackClientPollsHeads(t *testing.T) {
+	cfg := testFallbackConfig
+	cfg.HeadPollInterval = time.Millisecond
+	primary := &fallbackTestRPC{headErr: errors.New("boom")}
+	secondary := &fallbackTestRPC{head: 1}
+	f, err := NewFallbackClient(testlog.Logger(t, log.LevelInfo), cfg,
+		FallbackEndpoint{Name: "a", RPC: primary}, FallbackEndpoint{Name: "b", RPC: secondary})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return f.Current() == "b"
+	}, 10*time.Second, time.Millisecond)
+
+	f.Close()
+	require.True(t, primary.closed)
+	require.True(t, secondary.closed)
+}
@@ -6,9 +6,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
 )
 
 const (
@@ -27,18 +28,25 @@ type BasicHTTPClient struct {
 
 	log    log.Logger
 	client *http.Client
+
+	// used to create the client, after applying the options
+	httpCfg HTTPConfig
+	metrics metrics.HTTPClientMetricer
 }
 
+// NewBasicHTTPClient creates a client for the given endpoint. It uses the DefaultHTTPConfig profile,
+// unless configured otherwise with WithHTTPConfig.
 func NewBasicHTTPClient(endpoint string, log log.Logger, opts ...BasicHTTPClientOption) *BasicHTTPClient {
 	c := &BasicHTTPClient{
 		endpoint: endpoint,
 		log:      log,
-		client:   &http.Client{Timeout: DefaultTimeoutSeconds * time.Second},
+		httpCfg:  DefaultHTTPConfig(),
 	}
 
 	for _, opt := range opts {
 		opt.Apply(c)
 	}
+	c.client = NewHTTPClient(c.httpCfg, c.metrics)
 
 	return c
 }
@@ -59,6 +67,20 @@ func WithHeader(h http.Header) BasicHTTPClientOption {
 	})
 }
 
+// WithHTTPConfig configures the connection pool, timeouts and protocol of the client.
+func WithHTTPConfig(cfg HTTPConfig) BasicHTTPClientOption {
+	return BasicHTTPClientOptionFn(func(c *BasicHTTPClient) {
+		c.httpCfg = cfg
+	})
+}
+
+// WithHTTPMetrics records the connection reuse of the requests of the client.
+func WithHTTPMetrics(m metrics.HTTPClientMetricer) BasicHTTPClientOption {
+	return BasicHTTPClientOptionFn(func(c *BasicHTTPClient) {
+		c.metrics = m
+	})
+}
+
 var ErrNoEndpoint = errors.New("no endpoint is configured")
 
 func (cl *BasicHTTPClient) Get(ctx context.Context, p string, query url.Values, headers http.Header) (*http.Response, error) {
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// HTTPConfig configures the connection pool, timeouts and protocol of an HTTP client.
+type HTTPConfig struct {
+	// Timeout limits each request, including reading the response body. Zero means no timeout.
+	Timeout time.Duration
+	// DialTimeout limits establishing a new connection.
+	DialTimeout time.Duration
+	// TLSHandshakeTimeout limits the TLS handshake of a new connection.
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout limits waiting for the response headers after writing the request.
+	// Zero means no limit, other than Timeout.
+	ResponseHeaderTimeout time.Duration
+
+	// MaxIdleConns limits the number of idle connections kept in the pool, across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits the number of idle connections kept in the pool, per host.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the number of connections per host, including those in use. Zero means no limit.
+	MaxConnsPerHost int
+
+	// KeepAlive is the interval of TCP keep-alive probes. Negative disables the keep-alive probes.
+	KeepAlive time.Duration
+	// IdleConnTimeout is how long an idle connection stays in the pool.
+	IdleConnTimeout time.Duration
+	// DisableKeepAlives disables connection reuse: every request uses a new connection.
+	DisableKeepAlives bool
+
+	// HTTP2 enables HTTP/2 for TLS connections, if the server supports it.
+	HTTP2 bool
+
+	// MaxResponseSize limits the size of a response body, as received. Zero means no limit.
+	MaxResponseSize int64
+	// MaxDecompressedSize limits the size of a compressed response body, after decompression. Zero means no limit.
+	MaxDecompressedSize int64
+}
+
+// DefaultHTTPConfig is the profile for regular calls. It keeps more idle connections per host
+// than the Go defaults, since clients usually talk to a single host.
+func DefaultHTTPConfig() HTTPConfig {
+	return HTTPConfig{
+		Timeout:             DefaultTimeoutSeconds * time.Second,
+		DialTimeout:         10 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 16,
+		KeepAlive:           30 * time.Second,
+		IdleConnTimeout:     90 * time.Second,
+		HTTP2:               true,
+		MaxResponseSize:     DefaultMaxResponseSize,
+		MaxDecompressedSize: DefaultMaxDecompressedSize,
+	}
+}
+
+// LatencyCriticalHTTPConfig is the profile for calls on the critical path of block building and derivation:
+// it fails fast, and keeps enough warm connections around to not pay for new connections.
+func LatencyCriticalHTTPConfig() HTTPConfig {
+	return HTTPConfig{
+		Timeout:               5 * time.Second,
+		DialTimeout:           2 * time.Second,
+		TLSHandshakeTimeout:   2 * time.Second,
+		ResponseHeaderTimeout: 4 * time.Second,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   64,
+		KeepAlive:             15 * time.Second,
+		IdleConnTimeout:       5 * time.Minute,
+		HTTP2:                 true,
+		MaxResponseSize:       DefaultMaxResponseSize,
+		MaxDecompressedSize:   DefaultMaxDecompressedSize,
+	}
+}
+
+const (
+	// HTTPProfileDefault selects DefaultHTTPConfig.
+	HTTPProfileDefault = "default"
+	// HTTPProfileLatencyCritical selects LatencyCriticalHTTPConfig.
+	HTTPProfileLatencyCritical = "latency-critical"
+)
+
+// HTTPProfiles are the names of the HTTP client profiles, see HTTPConfigProfile.
+var HTTPProfiles = []string{HTTPProfileDefault, HTTPProfileLatencyCritical}
+
+// HTTPConfigProfile returns the HTTP client config of the profile with the given name.
+func HTTPConfigProfile(name string) (HTTPConfig, error) {
+	switch name {
+	case HTTPProfileDefault:
+		return DefaultHTTPConfig(), nil
+	case HTTPProfileLatencyCritical:
+		return LatencyCriticalHTTPConfig(), nil
+	default:
+		return HTTPConfig{}, fmt.Errorf("unknown HTTP client profile %q, expected one of %v", name, HTTPProfiles)
+	}
+}
+
+func (c HTTPConfig) Check() error {
+	if c.Timeout < 0 || c.DialTimeout < 0 || c.TLSHandshakeTimeout < 0 || c.ResponseHeaderTimeout < 0 || c.IdleConnTimeout < 0 {
+		return errors.New("HTTP client timeouts must not be negative")
+	}
+	if c.MaxIdleConns < 0 || c.MaxIdleConnsPerHost < 0 || c.MaxConnsPerHost < 0 {
+		return errors.New("HTTP client connection limits must not be negative")
+	}
+	if c.MaxConnsPerHost != 0 && c.MaxIdleConnsPerHost > c.MaxConnsPerHost {
+		return errors.New("HTTP client cannot keep more idle connections per host than the max connections per host")
+	}
+	if c.MaxResponseSize < 0 || c.MaxDecompressedSize < 0 {
+		return errors.New("HTTP client response size limits must not be negative")
+	}
+	return nil
+}
+
+// Transport creates an HTTP transport with the connection pool, timeouts and protocol of the config.
+// Response size limits are not applied by the transport, see NewHTTPClient.
+func (c HTTPConfig) Transport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   c.DialTimeout,
+		KeepAlive: c.KeepAlive,
+	}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     c.HTTP2,
+		TLSHandshakeTimeout:   c.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: c.ResponseHeaderTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		MaxIdleConns:          c.MaxIdleConns,
+		MaxIdleConnsPerHost:   c.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       c.MaxConnsPerHost,
+		IdleConnTimeout:       c.IdleConnTimeout,
+		DisableKeepAlives:     c.DisableKeepAlives,
+	}
+}
+
+// NewHTTPClient creates an HTTP client with the given config,
+// that records to m whether requests reuse pooled connections. m may be nil.
+// Reading a response body that exceeds the size limits of the config fails with a ResponseTooLargeError.
+func NewHTTPClient(cfg HTTPConfig, m metrics.HTTPClientMetricer) *http.Client {
+	base := cfg.Transport()
+	var transport http.RoundTripper = base
+	if cfg.MaxResponseSize > 0 || cfg.MaxDecompressedSize > 0 {
+		// the limiting transport decompresses responses itself, to limit the decompressed size
+		base.DisableCompression = true
+		transport = &limitingTransport{inner: transport, maxSize: cfg.MaxResponseSize, maxDecompressedSize: cfg.MaxDecompressedSize}
+	}
+	if m != nil {
+		transport = &connTracingTransport{inner: transport, m: m}
+	}
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+	}
+}
+
+// connTracingTransport records the connection reuse of every request.
+type connTracingTransport struct {
+	inner http.RoundTripper
+	m     metrics.HTTPClientMetricer
+}
+
+func (t *connTracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.m.RecordHTTPClientConn(info.Reused, info.IdleTime)
+		},
+	}
+	return t.inner.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+}
@@ -2,11 +2,15 @@ package client
 
 import (
 	"context"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/exp/slog"
 
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -98,3 +102,125 @@ func TestAddHTTPHeaders(t *testing.T) {
 		})
 	}
 }
+
+type connMetrics struct {
+	reused, dialed int
+}
+
+func (m *connMetrics) RecordHTTPClientConn(reused bool, idleTime time.Duration) {
+	if reused {
+		m.reused++
+	} else {
+		m.dialed++
+	}
+}
+
+func TestBasicHTTPClientConnReuse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	get := func(c *BasicHTTPClient) {
+		resp, err := c.Get(context.Background(), "/", nil, nil)
+		require.NoError(t, err)
+		_, err = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	logger := testlog.Logger(t, slog.LevelInfo)
+
+	m := new(connMetrics)
+	c := NewBasicHTTPClient(ts.URL, logger, WithHTTPConfig(LatencyCriticalHTTPConfig()), WithHTTPMetrics(m))
+	for i := 0; i < 3; i++ {
+		get(c)
+	}
+	require.Equal(t, 1, m.dialed)
+	require.Equal(t, 2, m.reused)
+
+	cfg := DefaultHTTPConfig()
+	cfg.DisableKeepAlives = true
+	m = new(connMetrics)
+	c = NewBasicHTTPClient(ts.URL, logger, WithHTTPConfig(cfg), WithHTTPMetrics(m))
+	for i := 0; i < 3; i++ {
+		get(c)
+	}
+	require.Equal(t, 3, m.dialed)
+	require.Zero(t, m.reused)
+}
+
+func TestBasicHTTPClientTimeout(t *testing.T) {
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	cfg := LatencyCriticalHTTPConfig()
+	cfg.Timeout = 50 * time.Millisecond
+	c := NewBasicHTTPClient(ts.URL, testlog.Logger(t, slog.LevelInfo), WithHTTPConfig(cfg))
+	_, err := c.Get(context.Background(), "/", nil, nil)
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	require.True(t, netErr.Timeout())
+}
+
+func TestHTTPConfig(t *testing.T) {
+	require.NoError(t, DefaultHTTPConfig().Check())
+	require.NoError(t, LatencyCriticalHTTPConfig().Check())
+
+	cfg := DefaultHTTPConfig()
+	cfg.Timeout = -1
+	require.ErrorContains(t, cfg.Check(), "timeouts")
+
+	cfg = DefaultHTTPConfig()
+	cfg.MaxConnsPerHost = 4
+	require.ErrorContains(t, cfg.Check(), "idle connections")
+	cfg.MaxIdleConnsPerHost = 4
+	require.NoError(t, cfg.Check())
+
+	transport := cfg.Transport()
+	require.Equal(t, 4, transport.MaxConnsPerHost)
+	require.Equal(t, 4, transport.MaxIdleConnsPerHost)
+	require.True(t, transport.ForceAttemptHTTP2)
+}
+
+func TestHTTPConfigProfile(t *testing.T) {
+	cfg, err := HTTPConfigProfile(HTTPProfileDefault)
+	require.NoError(t, err)
+	require.Equal(t, DefaultHTTPConfig(), cfg)
+	cfg, err = HTTPConfigProfile(HTTPProfileLatencyCritical)
+	require.NoError(t, err)
+	require.Equal(t, LatencyCriticalHTTPConfig(), cfg)
+	_, err = HTTPConfigProfile("fast")
+	require.ErrorContains(t, err, "unknown HTTP client profile")
+}
+
+func TestRPCHTTPClientConfig(t *testing.T) {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("test", new(sizedService)))
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	ctx := context.Background()
+	m := new(connMetrics)
+	cl, err := NewRPC(ctx, testlog.Logger(t, slog.LevelInfo), ts.URL,
+		WithHTTPClientConfig(LatencyCriticalHTTPConfig()), WithHTTPClientMetrics(m))
+	require.NoError(t, err)
+	defer cl.Close()
+	var out string
+	for i := 0; i < 3; i++ {
+		require.NoError(t, cl.CallContext(ctx, &out, "test_data", 10))
+	}
+	require.Equal(t, 1, m.dialed)
+	require.Equal(t, 2, m.reused)
+
+	cfg := DefaultHTTPConfig()
+	cfg.Timeout = -1
+	_, err = NewRPC(ctx, testlog.Logger(t, slog.LevelInfo), ts.URL, WithHTTPClientConfig(cfg))
+	require.ErrorContains(t, err, "timeouts")
+}
@@ -0,0 +1,292 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// jwtReloadInterval is the minimum interval between checks of the JWT secrets file for changes.
+	jwtReloadInterval = time.Second
+	// JWTRotationGracePeriod is how long the previous active secret stays accepted after it is removed
+	// from the secrets file, so a file with a single secret can be rotated by replacing the secret.
+	JWTRotationGracePeriod = time.Minute
+)
+
+// JWTSecrets are the JWT secrets used to authenticate RPC requests, like the engine API requests.
+//
+// The first secret is the active secret, which clients sign requests with.
+// All secrets are accepted by servers, so secrets can be rotated across a fleet without downtime:
+// the new secret is first added as a second secret everywhere, then moved to the first position,
+// and the old secret is finally removed once all clients sign with the new secret.
+//
+// Secrets read from a file are reloaded when the file changes, without a restart. A file with a single secret,
+// in the format of the JWT secret file of op-geth, can also be rotated by replacing the secret:
+// the previous secret stays accepted for the JWTRotationGracePeriod.
+type JWTSecrets struct {
+	log  log.Logger
+	path string
+
+	checkInterval time.Duration
+	gracePeriod   time.Duration
+
+	mu            sync.Mutex
+	secrets       [][32]byte
+	modTime       time.Time
+	size          int64
+	lastCheck     time.Time
+	previous      [32]byte
+	previousUntil time.Time
+}
+
+// NewJWTSecrets returns static JWT secrets, the first of which is the active secret.
+func NewJWTSecrets(active [32]byte, accepted ...[32]byte) *JWTSecrets {
+	return &JWTSecrets{secrets: append([][32]byte{active}, accepted...)}
+}
+
+// NewJWTSecretsFromFile reads the JWT secrets from the file at path, see ParseJWTSecrets for the format.
+// The file is checked for changes at most once a second when the secrets are used,
+// and invalid changes are logged and ignored, keeping the previous secrets.
+func NewJWTSecretsFromFile(lgr log.Logger, path string) (*JWTSecrets, error) {
+	s := &JWTSecrets{
+		log:           lgr,
+		path:          path,
+		checkInterval: jwtReloadInterval,
+		gracePeriod:   JWTRotationGracePeriod,
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT secret: %w", err)
+	}
+	if err := s.load(info); err != nil {
+		return nil, err
+	}
+	s.lastCheck = time.Now()
+	return s, nil
+}
+
+// Active returns the secret to sign requests with.
+func (s *JWTSecrets) Active() [32]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maybeReload()
+	return s.secrets[0]
+}
+
+// Accepted returns all the secrets that requests may be signed with, starting with the active secret.
+func (s *JWTSecrets) Accepted() [][32]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maybeReload()
+	accepted := append([][32]byte(nil), s.secrets...)
+	if time.Now().Before(s.previousUntil) {
+		accepted = append(accepted, s.previous)
+	}
+	return accepted
+}
+
+// maybeReload reloads the secrets if the file changed since it was last loaded.
+// The caller must hold the lock.
+func (s *JWTSecrets) maybeReload() {
+	if s.path == "" || time.Since(s.lastCheck) < s.checkInterval {
+		return
+	}
+	s.lastCheck = time.Now()
+	info, err := os.Stat(s.path)
+	if err != nil {
+		s.log.Error("Failed to check JWT secret file, keeping the current secrets", "path", s.path, "err", err)
+		return
+	}
+	if info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return
+	}
+	previous := s.secrets[0]
+	if err := s.load(info); err != nil {
+		s.log.Error("Failed to reload JWT secrets, keeping the current secrets", "err", err)
+		return
+	}
+	if !slices.Contains(s.secrets, previous) {
+		s.previous, s.previousUntil = previous, time.Now().Add(s.gracePeriod)
+	}
+	s.log.Info("Reloaded JWT secrets", "path", s.path, "secrets", len(s.secrets))
+}
+
+// load reads the secrets from the file, which was last modified as described by info.
+func (s *JWTSecrets) load(info os.FileInfo) error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to read JWT secret: %w", err)
+	}
+	secrets, err := ParseJWTSecrets(data)
+	if err != nil {
+		return fmt.Errorf("invalid JWT secret in file %s: %w", s.path, err)
+	}
+	s.secrets = secrets
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return nil
+}
+
+// ParseJWTSecrets parses 32 bytes, hex-encoded JWT secrets, one per line, optionally prefixed with 0x.
+// Empty lines and lines starting with # are ignored. The first secret is the active secret.
+// The JWT secret file of op-geth, with a single secret, is thus a valid secrets file,
+// but op-geth does not read files with several secrets or comments.
+func ParseJWTSecrets(data []byte) ([][32]byte, error) {
+	var secrets [][32]byte
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		line := strings.TrimSpace(string(line))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "0x") || strings.HasPrefix(line, "0X") {
+			line = line[2:]
+		}
+		b, err := hex.DecodeString(line)
+		if err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("line %d is not 32 hex-encoded bytes", i+1)
+		}
+		secrets = append(secrets, [32]byte(b))
+	}
+	if len(secrets) == 0 {
+		return nil, errors.New("no secrets")
+	}
+	return secrets, nil
+}
+
+// NewJWTAuth returns the authentication of RPC clients with the active secret of the given secrets.
+// HTTP requests are signed with the active secret at the time of the request, so clients pick up
+// rotated secrets without redialing. Websocket connections are only authenticated when dialed.
+// Tokens are signed like the engine API expects, see node.NewJWTAuth.
+func NewJWTAuth(secrets *JWTSecrets) rpc.HTTPAuth {
+	return func(h http.Header) error {
+		secret := secrets.Active()
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"iat": &jwt.NumericDate{Time: time.Now()},
+		})
+		s, err := token.SignedString(secret[:])
+		if err != nil {
+			return fmt.Errorf("failed to create JWT token: %w", err)
+		}
+		h.Set("Authorization", "Bearer "+s)
+		return nil
+	}
+}
+
+// WithJWTSecrets authenticates the requests of the RPC with the active secret of the given secrets,
+// see NewJWTAuth. Websocket connections are only authenticated when dialed,
+// so they are redialed with the new active secret when it rotates, see JWTRedialClient.
+func WithJWTSecrets(secrets *JWTSecrets) RPCOption {
+	return func(cfg *rpcConfig) error {
+		cfg.jwtSecrets = secrets
+		cfg.gethRPCOptions = append(cfg.gethRPCOptions, rpc.WithHTTPAuth(NewJWTAuth(secrets)))
+		return nil
+	}
+}
+
+// JWTRedialClient is an RPC client over a websocket connection that was authenticated with the active JWT secret.
+// When the active secret rotates, the next request or subscription redials the connection with the new secret.
+// The previous connection is closed once its in-flight requests complete,
+// which fails its subscriptions so they are resubscribed over the new connection, see ReconnectingClient.
+type JWTRedialClient struct {
+	lgr     log.Logger
+	secrets *JWTSecrets
+	dial    func(ctx context.Context) (*rpc.Client, error)
+
+	mu     sync.Mutex
+	conn   *jwtConn
+	closed bool
+}
+
+var _ RPC = (*JWTRedialClient)(nil)
+
+// jwtConn is a connection that was authenticated with the secret, and the requests in flight on it.
+type jwtConn struct {
+	c        *rpc.Client
+	secret   [32]byte
+	inFlight sync.WaitGroup
+}
+
+// NewJWTRedialClient returns a client of the connection c, that was dialed with the active secret of secrets,
+// and that is redialed with dial when the active secret rotates.
+func NewJWTRedialClient(lgr log.Logger, c *rpc.Client, secrets *JWTSecrets, dial func(ctx context.Context) (*rpc.Client, error)) *JWTRedialClient {
+	return &JWTRedialClient{
+		lgr:     lgr,
+		secrets: secrets,
+		dial:    dial,
+		conn:    &jwtConn{c: c, secret: secrets.Active()},
+	}
+}
+
+// acquire returns the connection to make a request with, redialing it if the active secret rotated.
+// If redialing fails, the current connection is used, and redialing is retried on the next request.
+// The caller must call release on the connection when the request completes.
+func (c *JWTRedialClient) acquire(ctx context.Context) *jwtConn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if active := c.secrets.Active(); !c.closed && active != c.conn.secret {
+		cl, err := c.dial(ctx)
+		if err != nil {
+			c.lgr.Warn("Failed to redial with the rotated JWT secret, keeping the current connection", "err", err)
+		} else {
+			c.lgr.Info("Redialed with the rotated JWT secret")
+			prev := c.conn
+			c.conn = &jwtConn{c: cl, secret: active}
+			go func() {
+				prev.inFlight.Wait()
+				prev.c.Close()
+			}()
+		}
+	}
+	c.conn.inFlight.Add(1)
+	return c.conn
+}
+
+func (conn *jwtConn) release() {
+	conn.inFlight.Done()
+}
+
+func (c *JWTRedialClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	c.conn.c.Close()
+}
+
+func (c *JWTRedialClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	conn := c.acquire(ctx)
+	defer conn.release()
+	return NewBaseRPCClient(conn.c).CallContext(ctx, result, method, args...)
+}
+
+func (c *JWTRedialClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	conn := c.acquire(ctx)
+	defer conn.release()
+	return NewBaseRPCClient(conn.c).BatchCallContext(ctx, batch)
+}
+
+func (c *JWTRedialClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	conn := c.acquire(ctx)
+	defer conn.release()
+	return conn.c.EthSubscribe(ctx, channel, args...)
+}
+
+func (c *JWTRedialClient) Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error) {
+	conn := c.acquire(ctx)
+	defer conn.release()
+	return conn.c.Subscribe(ctx, namespace, channel, args...)
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func hexSecret(b byte) string {
+	return "0x" + strings.Repeat(fmt.Sprintf("%02x", b), 32)
+}
+
+func TestParseJWTSecrets(t *testing.T) {
+	secrets, err := ParseJWTSecrets([]byte(hexSecret(1) + "\n"))
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+	require.Equal(t, byte(1), secrets[0][31])
+
+	secrets, err = ParseJWTSecrets([]byte("# new secret\n" + hexSecret(2) + "\n\n" + strings.TrimPrefix(hexSecret(1), "0x")))
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+	require.Equal(t, byte(2), secrets[0][0])
+	require.Equal(t, byte(1), secrets[1][0])
+
+	_, err = ParseJWTSecrets([]byte(hexSecret(1) + "\n0x0102"))
+	require.ErrorContains(t, err, "line 2 is not 32 hex-encoded bytes")
+	_, err = ParseJWTSecrets([]byte("0x" + strings.Repeat("zz", 32)))
+	require.ErrorContains(t, err, "line 1")
+	_, err = ParseJWTSecrets([]byte("\n# nothing\n"))
+	require.ErrorContains(t, err, "no secrets")
+}
+
+func TestJWTSecretsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwt.hex")
+	_, err := NewJWTSecretsFromFile(testlog.Logger(t, log.LevelInfo), path)
+	require.ErrorContains(t, err, "failed to read JWT secret")
+
+	write := func(content string, modTime time.Time) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	start := time.Now()
+	write(hexSecret(1), start)
+	secrets, err := NewJWTSecretsFromFile(testlog.Logger(t, log.LevelInfo), path)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), secrets.Active()[0])
+
+	// changes are only picked up after the reload interval
+	write(hexSecret(2)+"\n"+hexSecret(1), start.Add(time.Second))
+	require.Equal(t, byte(1), secrets.Active()[0])
+	secrets.checkInterval = 0
+	require.Equal(t, byte(2), secrets.Active()[0])
+	accepted := secrets.Accepted()
+	require.Len(t, accepted, 2)
+	require.Equal(t, byte(1), accepted[1][0])
+
+	// invalid changes are ignored
+	write("0x01", start.Add(2*time.Second))
+	require.Len(t, secrets.Accepted(), 2)
+	require.NoError(t, os.Remove(path))
+	require.Equal(t, byte(2), secrets.Active()[0])
+
+	// replacing a single secret, like in the file of op-geth, keeps the previous secret accepted for a grace period
+	write(hexSecret(3), start.Add(3*time.Second))
+	require.Equal(t, byte(3), secrets.Active()[0])
+	require.Len(t, secrets.Accepted(), 2)
+	write(hexSecret(4)+"\n", start.Add(4*time.Second))
+	require.Equal(t, byte(4), secrets.Active()[0])
+	accepted = secrets.Accepted()
+	require.Len(t, accepted, 2)
+	require.Equal(t, byte(3), accepted[1][0])
+	secrets.gracePeriod = 0
+	write("0X"+strings.TrimPrefix(hexSecret(5), "0x"), start.Add(5*time.Second))
+	require.Equal(t, [][32]byte{secrets.Active()}, secrets.Accepted())
+	require.Equal(t, byte(5), secrets.Active()[0])
+}
+
+type jwtTestService struct{}
+
+func (jwtTestService) Echo(v string) string {
+	return v
+}
+
+func TestJWTRedialClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwt.hex")
+	require.NoError(t, os.WriteFile(path, []byte(hexSecret(1)), 0o600))
+	lgr := testlog.Logger(t, log.LevelInfo)
+	secrets, err := NewJWTSecretsFromFile(lgr, path)
+	require.NoError(t, err)
+
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("test", jwtTestService{}))
+	t.Cleanup(srv.Stop)
+	var mu sync.Mutex
+	var dialed []byte
+	wsHandler := srv.WebsocketHandler([]string{"*"})
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := jwt.Parse(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), func(*jwt.Token) (any, error) {
+			secret := secrets.Active()
+			return secret[:], nil
+		})
+		if err != nil || !token.Valid {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		dialed = append(dialed, secrets.Active()[0])
+		mu.Unlock()
+		wsHandler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(httpSrv.Close)
+
+	cl, err := NewRPC(context.Background(), lgr, "ws"+strings.TrimPrefix(httpSrv.URL, "http"), WithJWTSecrets(secrets))
+	require.NoError(t, err)
+	defer cl.Close()
+	var res string
+	require.NoError(t, cl.CallContext(context.Background(), &res, "test_echo", "a"))
+
+	secrets.checkInterval = 0
+	require.NoError(t, os.WriteFile(path, []byte(hexSecret(2)), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now().Add(time.Second), time.Now().Add(time.Second)))
+	require.NoError(t, cl.CallContext(context.Background(), &res, "test_echo", "b"))
+	require.Equal(t, "b", res)
+	require.NoError(t, cl.CallContext(context.Background(), &res, "test_echo", "c"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []byte{1, 2}, dialed, "the connection is redialed once with the rotated secret")
+}
+
+func TestJWTAuth(t *testing.T) {
+	secrets := NewJWTSecrets([32]byte{1}, [32]byte{2})
+	require.Equal(t, [][32]byte{{1}, {2}}, secrets.Accepted())
+	h := make(http.Header)
+	require.NoError(t, NewJWTAuth(secrets)(h))
+	require.True(t, strings.HasPrefix(h.Get("Authorization"), "Bearer "))
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/time/rate"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// MethodRateLimit is the rate limit for requests of a single RPC method.
+// See NewRateLimitingClient for the meaning of the limit and burst.
+type MethodRateLimit struct {
+	// Limit is the targeted number of requests per second.
+	Limit float64
+	// Burst is the number of requests allowed at once.
+	Burst int
+}
+
+// LimitingClient is a wrapper around a pure RPC that implements per-method rate-limits on requests,
+// and caps the number of requests in flight. This prevents a single kind of request, e.g. receipts fetching,
+// from exhausting the rate limits of the RPC provider and starving other requests.
+type LimitingClient struct {
+	c        RPC
+	limiters map[string]*rate.Limiter
+	// slots caps the number of requests in flight. Nil if there is no cap.
+	slots chan struct{}
+	m     metrics.RPCClientLimiterMetricer
+}
+
+// NewLimitingClient rate-limits requests of the methods in methodLimits, and caps the number of requests in flight
+// at maxConcurrent. Requests of other methods are not rate-limited. A maxConcurrent of 0 disables the cap.
+// The time requests wait for the limits before being sent is recorded with m, which may be nil.
+func NewLimitingClient(c RPC, methodLimits map[string]MethodRateLimit, maxConcurrent int, m metrics.RPCClientLimiterMetricer) *LimitingClient {
+	limiters := make(map[string]*rate.Limiter, len(methodLimits))
+	for method, l := range methodLimits {
+		limiters[method] = rate.NewLimiter(rate.Limit(l.Limit), l.Burst)
+	}
+	var slots chan struct{}
+	if maxConcurrent > 0 {
+		slots = make(chan struct{}, maxConcurrent)
+	}
+	if m == nil {
+		m = &metrics.NoopRPCMetrics{}
+	}
+	return &LimitingClient{c: c, limiters: limiters, slots: slots, m: m}
+}
+
+func (b *LimitingClient) Close() {
+	b.c.Close()
+}
+
+func (b *LimitingClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	release, err := b.acquire(ctx, method, map[string]int{method: 1})
+	if err != nil {
+		return err
+	}
+	defer release()
+	return b.c.CallContext(ctx, result, method, args...)
+}
+
+func (b *LimitingClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	counts := make(map[string]int)
+	for _, elem := range batch {
+		counts[elem.Method]++
+	}
+	release, err := b.acquire(ctx, metrics.BatchMethod, counts)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return b.c.BatchCallContext(ctx, batch)
+}
+
+// EthSubscribe is rate-limited as the eth_subscribe method. Subscriptions are long-lived,
+// so they do not count towards the cap on requests in flight.
+func (b *LimitingClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	start := time.Now()
+	if err := b.waitRateLimit(ctx, "eth_subscribe", 1); err != nil {
+		return nil, err
+	}
+	b.m.RecordRPCClientQueueWait("eth_subscribe", time.Since(start))
+	return b.c.EthSubscribe(ctx, channel, args...)
+}
+
+// acquire waits for the rate limits of each method with the given number of requests,
+// and then for a free request slot. The returned function must be called to release the slot.
+func (b *LimitingClient) acquire(ctx context.Context, label string, counts map[string]int) (func(), error) {
+	start := time.Now()
+	for method, n := range counts {
+		if err := b.waitRateLimit(ctx, method, n); err != nil {
+			return nil, err
+		}
+	}
+	release := func() {}
+	if b.slots != nil {
+		select {
+		case b.slots <- struct{}{}:
+			release = func() { <-b.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	b.m.RecordRPCClientQueueWait(label, time.Since(start))
+	return release, nil
+}
+
+// waitRateLimit waits until n requests of the method are allowed by its rate limit, if it has one.
+func (b *LimitingClient) waitRateLimit(ctx context.Context, method string, n int) error {
+	l, ok := b.limiters[method]
+	if !ok {
+		return nil
+	}
+	// WaitN fails if n exceeds the burst, so wait for large batches in chunks.
+	for n > 0 {
+		chunk := n
+		if burst := l.Burst(); burst > 0 && chunk > burst {
+			chunk = burst
+		}
+		if err := l.WaitN(ctx, chunk); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
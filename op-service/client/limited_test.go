@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+type limitedTestRPC struct {
+	mu       sync.Mutex
+	calls    []string
+	inFlight int
+	maxSeen  int
+	block    chan struct{}
+}
+
+func (r *limitedTestRPC) Close() {}
+
+func (r *limitedTestRPC) enter(method string) {
+	r.mu.Lock()
+	r.calls = append(r.calls, method)
+	r.inFlight++
+	if r.inFlight > r.maxSeen {
+		r.maxSeen = r.inFlight
+	}
+	r.mu.Unlock()
+	if r.block != nil {
+		<-r.block
+	}
+	r.mu.Lock()
+	r.inFlight--
+	r.mu.Unlock()
+}
+
+func (r *limitedTestRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	r.enter(method)
+	return nil
+}
+
+func (r *limitedTestRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	r.enter(metrics.BatchMethod)
+	return nil
+}
+
+func (r *limitedTestRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	r.enter("eth_subscribe")
+	return nil, errors.New("not supported")
+}
+
+func (r *limitedTestRPC) callCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.calls)
+}
+
+type queueWaitRecorder struct {
+	mu    sync.Mutex
+	waits map[string]int
+}
+
+func (q *queueWaitRecorder) RecordRPCClientQueueWait(method string, wait time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.waits == nil {
+		q.waits = make(map[string]int)
+	}
+	q.waits[method]++
+}
+
+func TestLimitingClientMethodRateLimit(t *testing.T) {
+	ctx := context.Background()
+	underlying := &limitedTestRPC{}
+	m := &queueWaitRecorder{}
+	// Allow a burst of 2 receipts requests, and then practically none.
+	c := NewLimitingClient(underlying, map[string]MethodRateLimit{
+		"eth_getBlockReceipts": {Limit: 0.0001, Burst: 2},
+	}, 0, m)
+
+	require.NoError(t, c.CallContext(ctx, nil, "eth_getBlockReceipts"))
+	require.NoError(t, c.CallContext(ctx, nil, "eth_getBlockReceipts"))
+
+	tCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, c.CallContext(tCtx, nil, "eth_getBlockReceipts"), "should exceed rate limit")
+	require.Equal(t, 2, underlying.callCount())
+
+	// Other methods are not limited
+	for i := 0; i < 10; i++ {
+		require.NoError(t, c.CallContext(ctx, nil, "eth_chainId"))
+	}
+	require.Equal(t, 12, underlying.callCount())
+	require.Equal(t, 2, m.waits["eth_getBlockReceipts"])
+	require.Equal(t, 10, m.waits["eth_chainId"])
+}
+
+func TestLimitingClientBatch(t *testing.T) {
+	ctx := context.Background()
+	underlying := &limitedTestRPC{}
+	m := &queueWaitRecorder{}
+	c := NewLimitingClient(underlying, map[string]MethodRateLimit{
+		"eth_getTransactionReceipt": {Limit: 1000, Burst: 2},
+	}, 0, m)
+
+	// Batches larger than the burst are admitted, by waiting for the limit in chunks.
+	batch := make([]rpc.BatchElem, 5)
+	for i := range batch {
+		batch[i] = rpc.BatchElem{Method: "eth_getTransactionReceipt"}
+	}
+	require.NoError(t, c.BatchCallContext(ctx, batch))
+	require.Equal(t, 1, underlying.callCount())
+	require.Equal(t, 1, m.waits[metrics.BatchMethod])
+
+	// Batches count each element towards the rate limit of its method.
+	slow := NewLimitingClient(underlying, map[string]MethodRateLimit{
+		"eth_getTransactionReceipt": {Limit: 0.0001, Burst: 2},
+	}, 0, nil)
+	tCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, slow.BatchCallContext(tCtx, batch[:3]))
+	require.Equal(t, 1, underlying.callCount())
+}
+
+func TestLimitingClientMaxConcurrent(t *testing.T) {
+	ctx := context.Background()
+	underlying := &limitedTestRPC{block: make(chan struct{})}
+	c := NewLimitingClient(underlying, nil, 2, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, c.CallContext(ctx, nil, "eth_chainId"))
+		}()
+	}
+	require.Eventually(t, func() bool {
+		return underlying.callCount() == 2
+	}, 10*time.Second, time.Millisecond)
+
+	// Requests waiting for a free slot can be cancelled
+	tCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, c.CallContext(tCtx, nil, "eth_chainId"), context.DeadlineExceeded)
+
+	close(underlying.block)
+	wg.Wait()
+	require.Equal(t, 5, underlying.callCount())
+	require.Equal(t, 2, underlying.maxSeen)
+}
+
+func TestLimitingClientSubscribeIgnoresMaxConcurrent(t *testing.T) {
+	ctx := context.Background()
+	underlying := &limitedTestRPC{}
+	c := NewLimitingClient(underlying, nil, 1, nil)
+	// Hold the only request slot
+	release, err := c.acquire(ctx, "eth_chainId", map[string]int{"eth_chainId": 1})
+	require.NoError(t, err)
+	defer release()
+
+	_, err = c.EthSubscribe(ctx, nil, "newHeads")
+	require.EqualError(t, err, "not supported")
+	require.Equal(t, 1, underlying.callCount())
+}
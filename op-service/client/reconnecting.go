@@ -0,0 +1,413 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// DefaultMaxBackfill is the default maximum number of blocks of missed events that are backfilled after resubscribing.
+const DefaultMaxBackfill = 128
+
+// resubscriptionBuffer is the buffer size of the channels that the inner subscriptions deliver events to.
+const resubscriptionBuffer = 16
+
+// ReconnectingClient wraps an RPC client with websocket subscriptions, to keep the subscriptions alive
+// when the connection drops: failed subscriptions are resubscribed with a backoff, which reconnects the
+// websocket connection of the underlying go-ethereum RPC client, and events that were missed in the meantime
+// are backfilled, so subscribers don't have to handle subscription errors themselves.
+//
+// Events of newHeads subscriptions to *types.Header channels are backfilled with the headers since the
+// last delivered header, and events of logs subscriptions to types.Log channels with the logs since the
+// last delivered log. At most the configured number of blocks are backfilled.
+// Other subscriptions are resubscribed without backfilling.
+//
+// The subscriptions only fail when they are resubscribed with an error that is not retried, see retry.FatalError.
+type ReconnectingClient struct {
+	RPC
+	lgr         log.Logger
+	strategy    retry.Strategy
+	maxBackfill uint64
+}
+
+var _ RPC = (*ReconnectingClient)(nil)
+
+type ReconnectingClientOption func(c *ReconnectingClient)
+
+// WithResubscribeStrategy configures the backoff between attempts to resubscribe.
+func WithResubscribeStrategy(s retry.Strategy) ReconnectingClientOption {
+	return func(c *ReconnectingClient) {
+		c.strategy = s
+	}
+}
+
+// WithMaxBackfill configures the maximum number of blocks of missed events that are backfilled after resubscribing.
+func WithMaxBackfill(blocks uint64) ReconnectingClientOption {
+	return func(c *ReconnectingClient) {
+		c.maxBackfill = blocks
+	}
+}
+
+// NewReconnectingClient returns a client that resubscribes the subscriptions made with the given client when they fail.
+func NewReconnectingClient(lgr log.Logger, c RPC, opts ...ReconnectingClientOption) *ReconnectingClient {
+	rc := &ReconnectingClient{
+		RPC:         c,
+		lgr:         lgr,
+		strategy:    retry.Exponential(),
+		maxBackfill: DefaultMaxBackfill,
+	}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	return rc
+}
+
+// EthSubscribe subscribes like the underlying client does,
+// but resubscribes and backfills the missed events when the subscription fails.
+func (c *ReconnectingClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	out := reflect.ValueOf(channel)
+	if out.Kind() != reflect.Chan || out.Type().ChanDir()&reflect.SendDir == 0 {
+		return nil, errors.New("channel argument must be a writable channel")
+	}
+	if len(args) == 0 {
+		return nil, errors.New("missing subscription name")
+	}
+	s := &resubscription{
+		c:      c,
+		args:   args,
+		out:    out,
+		inner:  reflect.MakeChan(reflect.ChanOf(reflect.BothDir, out.Type().Elem()), resubscriptionBuffer),
+		events: newEventTracker(out.Type().Elem(), args),
+	}
+	if err := s.subscribe(ctx); err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(s.run), nil
+}
+
+// resubscription is a subscription that is resubscribed when it fails.
+type resubscription struct {
+	c      *ReconnectingClient
+	args   []any
+	out    reflect.Value // channel of the subscriber
+	inner  reflect.Value // channel of the current inner subscription
+	sub    ethereum.Subscription
+	events eventTracker
+}
+
+func (s *resubscription) subscribe(ctx context.Context) error {
+	sub, err := s.c.RPC.EthSubscribe(ctx, s.inner.Interface(), s.args...)
+	if err != nil {
+		return err
+	}
+	s.sub = sub
+	return nil
+}
+
+// run relays the events of the inner subscriptions to the subscriber, until quit is closed.
+func (s *resubscription) run(quit <-chan struct{}) error {
+	defer func() { s.sub.Unsubscribe() }()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	for {
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: s.inner},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(s.sub.Err())},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+		}
+		chosen, v, _ := reflect.Select(cases)
+		switch chosen {
+		case 0:
+			if s.events.deliverable(v.Interface()) && !s.send(v, quit) {
+				return nil
+			}
+		case 1:
+			err := v.Interface()
+			s.c.lgr.Warn("Subscription failed, resubscribing", "subscription", s.args[0], "err", err)
+			s.sub.Unsubscribe()
+			if err := s.resubscribe(ctx); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+			if !s.backfill(ctx, quit) {
+				return nil
+			}
+		case 2:
+			return nil
+		}
+	}
+}
+
+// send sends the event to the subscriber, and returns false if quit was closed before it was sent.
+func (s *resubscription) send(v reflect.Value, quit <-chan struct{}) bool {
+	chosen, _, _ := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: s.out, Send: v},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+	})
+	if chosen == 1 {
+		return false
+	}
+	s.events.delivered(v.Interface())
+	return true
+}
+
+func (s *resubscription) resubscribe(ctx context.Context) error {
+	// A new channel is used, to not mix up any events of the failed subscription that are still in flight.
+	s.inner = reflect.MakeChan(s.inner.Type(), resubscriptionBuffer)
+	_, err := retry.DoWithPolicy(ctx, retry.Policy{Strategy: s.c.strategy}, func() (struct{}, error) {
+		err := s.subscribe(ctx)
+		if err != nil {
+			s.c.lgr.Debug("Failed to resubscribe", "subscription", s.args[0], "err", err)
+		}
+		return struct{}{}, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resubscribe: %w", err)
+	}
+	s.c.lgr.Info("Resubscribed", "subscription", s.args[0])
+	return nil
+}
+
+// backfill delivers the events that were missed while resubscribing, and returns false if quit was closed.
+// Failures to backfill are logged, but don't fail the subscription.
+func (s *resubscription) backfill(ctx context.Context, quit <-chan struct{}) bool {
+	events, err := s.events.missed(ctx, s.c.RPC, s.c.maxBackfill)
+	if err != nil {
+		s.c.lgr.Warn("Failed to backfill missed subscription events", "subscription", s.args[0], "err", err)
+		return true
+	}
+	if len(events) > 0 {
+		s.c.lgr.Info("Backfilling missed subscription events", "subscription", s.args[0], "events", len(events))
+	}
+	for _, ev := range events {
+		if !s.send(reflect.ValueOf(ev), quit) {
+			return false
+		}
+	}
+	return true
+}
+
+// eventTracker tracks the events delivered to a subscriber, to backfill the missed events after resubscribing.
+type eventTracker interface {
+	// deliverable returns false if the event of the subscription was already delivered by a backfill.
+	deliverable(ev any) bool
+	// delivered registers the event as delivered to the subscriber.
+	delivered(ev any)
+	// missed returns the events since the last delivered event, of at most maxBlocks blocks.
+	missed(ctx context.Context, c RPC, maxBlocks uint64) ([]any, error)
+}
+
+func newEventTracker(elem reflect.Type, args []any) eventTracker {
+	switch args[0] {
+	case "newHeads":
+		if elem == reflect.TypeOf((*types.Header)(nil)) {
+			return &headsTracker{}
+		}
+	case "logs":
+		if elem != reflect.TypeOf(types.Log{}) {
+			break
+		}
+		if len(args) == 1 {
+			return &logsTracker{filter: map[string]any{}}
+		}
+		if filter, ok := args[1].(map[string]any); ok {
+			return &logsTracker{filter: filter}
+		}
+	}
+	return noBackfill{}
+}
+
+// noBackfill is the tracker of subscriptions whose events are not backfilled.
+type noBackfill struct{}
+
+func (noBackfill) deliverable(any) bool { return true }
+func (noBackfill) delivered(any)        {}
+func (noBackfill) missed(context.Context, RPC, uint64) ([]any, error) {
+	return nil, nil
+}
+
+// missedRange returns the range of blocks from the given block up to the latest block,
+// limited to the latest maxBlocks blocks. It returns false if the range is empty.
+func missedRange(ctx context.Context, c RPC, from uint64, maxBlocks uint64) (uint64, uint64, bool, error) {
+	var latest hexutil.Uint64
+	if err := c.CallContext(ctx, &latest, "eth_blockNumber"); err != nil {
+		return 0, 0, false, fmt.Errorf("failed to get latest block number: %w", err)
+	}
+	to := uint64(latest)
+	if to < from || maxBlocks == 0 {
+		return 0, 0, false, nil
+	}
+	if to-from >= maxBlocks {
+		from = to - maxBlocks + 1
+	}
+	return from, to, true, nil
+}
+
+// headsTracker tracks the last header delivered to a newHeads subscriber.
+type headsTracker struct {
+	mu   sync.Mutex
+	last *types.Header
+	// backfilled are the hashes of the headers of the last backfill,
+	// which the new subscription may deliver again.
+	backfilled map[common.Hash]struct{}
+}
+
+func (t *headsTracker) deliverable(ev any) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h, ok := ev.(*types.Header)
+	if !ok || h == nil {
+		return true
+	}
+	_, dup := t.backfilled[h.Hash()]
+	return !dup
+}
+
+func (t *headsTracker) delivered(ev any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if h, ok := ev.(*types.Header); ok && h != nil {
+		t.last = h
+	}
+}
+
+func (t *headsTracker) missed(ctx context.Context, c RPC, maxBlocks uint64) ([]any, error) {
+	t.mu.Lock()
+	last := t.last
+	t.mu.Unlock()
+	if last == nil {
+		return nil, nil
+	}
+	from, to, ok, err := missedRange(ctx, c, last.Number.Uint64()+1, maxBlocks)
+	if err != nil || !ok {
+		return nil, err
+	}
+	batch := make([]rpc.BatchElem, 0, to-from+1)
+	headers := make([]*types.Header, to-from+1)
+	for i := range headers {
+		batch = append(batch, rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []any{hexutil.EncodeUint64(from + uint64(i)), false},
+			Result: &headers[i],
+		})
+	}
+	if err := c.BatchCallContext(ctx, batch); err != nil {
+		return nil, fmt.Errorf("failed to fetch missed headers %d - %d: %w", from, to, err)
+	}
+	backfilled := make(map[common.Hash]struct{}, len(headers))
+	var out []any
+	for i, h := range headers {
+		if batch[i].Error != nil {
+			return nil, fmt.Errorf("failed to fetch missed header %d: %w", from+uint64(i), batch[i].Error)
+		}
+		if h == nil {
+			break // not yet available on the node we reconnected to
+		}
+		backfilled[h.Hash()] = struct{}{}
+		out = append(out, h)
+	}
+	t.mu.Lock()
+	t.backfilled = backfilled
+	t.mu.Unlock()
+	return out, nil
+}
+
+// logPosition is the position of a log in the chain.
+type logPosition struct {
+	block uint64
+	index int64 // -1 before the first log of the block
+}
+
+func (p logPosition) after(o logPosition) bool {
+	return p.block > o.block || (p.block == o.block && p.index > o.index)
+}
+
+// logsTracker tracks the position of the last log delivered to a logs subscriber.
+type logsTracker struct {
+	filter map[string]any
+
+	mu      sync.Mutex
+	last    logPosition
+	hasLast bool
+}
+
+func (t *logsTracker) deliverable(ev any) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	l, ok := ev.(types.Log)
+	if !ok || l.Removed || !t.hasLast {
+		return true
+	}
+	return logPosition{block: l.BlockNumber, index: int64(l.Index)}.after(t.last)
+}
+
+func (t *logsTracker) delivered(ev any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	l, ok := ev.(types.Log)
+	if !ok {
+		return
+	}
+	if l.Removed {
+		// logs of the new chain after a reorg may be at earlier positions
+		reorged := logPosition{block: l.BlockNumber, index: -1}
+		if t.hasLast && !reorged.after(t.last) {
+			t.last = reorged
+		}
+		return
+	}
+	t.last = logPosition{block: l.BlockNumber, index: int64(l.Index)}
+	t.hasLast = true
+}
+
+func (t *logsTracker) missed(ctx context.Context, c RPC, maxBlocks uint64) ([]any, error) {
+	t.mu.Lock()
+	last, hasLast := t.last, t.hasLast
+	t.mu.Unlock()
+	if !hasLast {
+		return nil, nil
+	}
+	// the last block is fetched again, in case not all of its logs were delivered
+	from, to, ok, err := missedRange(ctx, c, last.block, maxBlocks)
+	if err != nil || !ok {
+		return nil, err
+	}
+	filter := make(map[string]any, len(t.filter)+2)
+	for k, v := range t.filter {
+		filter[k] = v
+	}
+	filter["fromBlock"] = hexutil.EncodeUint64(from)
+	filter["toBlock"] = hexutil.EncodeUint64(to)
+	var logs []types.Log
+	if err := c.CallContext(ctx, &logs, "eth_getLogs", filter); err != nil {
+		return nil, fmt.Errorf("failed to fetch missed logs %d - %d: %w", from, to, err)
+	}
+	var out []any
+	for _, l := range logs {
+		if (logPosition{block: l.BlockNumber, index: int64(l.Index)}).after(last) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// subscriptionRPC serves subscriptions and the queries to backfill them from a fake chain.
+type subscriptionRPC struct {
+	mu      sync.Mutex
+	headers []*types.Header
+	logs    []types.Log
+
+	subscribeErrs []error
+	subs          []reflect.Value
+	fail          []chan error
+	logFilters    []map[string]any
+}
+
+func (m *subscriptionRPC) Close() {}
+
+func (m *subscriptionRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch method {
+	case "eth_blockNumber":
+		*result.(*hexutil.Uint64) = hexutil.Uint64(len(m.headers) - 1)
+	case "eth_getLogs":
+		filter := args[0].(map[string]any)
+		m.logFilters = append(m.logFilters, filter)
+		from, _ := hexutil.DecodeUint64(filter["fromBlock"].(string))
+		to, _ := hexutil.DecodeUint64(filter["toBlock"].(string))
+		var logs []types.Log
+		for _, l := range m.logs {
+			if l.BlockNumber >= from && l.BlockNumber <= to {
+				logs = append(logs, l)
+			}
+		}
+		*result.(*[]types.Log) = logs
+	default:
+		return errors.New("unexpected method")
+	}
+	return nil
+}
+
+func (m *subscriptionRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, elem := range b {
+		num, _ := hexutil.DecodeUint64(elem.Args[0].(string))
+		if num < uint64(len(m.headers)) {
+			*elem.Result.(**types.Header) = m.headers[num]
+		}
+	}
+	return nil
+}
+
+func (m *subscriptionRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.subscribeErrs) > 0 {
+		err := m.subscribeErrs[0]
+		m.subscribeErrs = m.subscribeErrs[1:]
+		return nil, err
+	}
+	fail := make(chan error, 1)
+	m.subs = append(m.subs, reflect.ValueOf(channel))
+	m.fail = append(m.fail, fail)
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		select {
+		case err := <-fail:
+			return err
+		case <-quit:
+			return nil
+		}
+	}), nil
+}
+
+// mine adds a block to the chain, and returns its header.
+func (m *subscriptionRPC) mine(logs int) *types.Header {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h := &types.Header{Number: big.NewInt(int64(len(m.headers))), Difficulty: new(big.Int)}
+	if len(m.headers) > 0 {
+		h.ParentHash = m.headers[len(m.headers)-1].Hash()
+	}
+	m.headers = append(m.headers, h)
+	for i := 0; i < logs; i++ {
+		m.logs = append(m.logs, types.Log{BlockNumber: h.Number.Uint64(), BlockHash: h.Hash(), Index: uint(i)})
+	}
+	return h
+}
+
+// emit delivers the value to the current subscription.
+func (m *subscriptionRPC) emit(v any) {
+	m.mu.Lock()
+	sub := m.subs[len(m.subs)-1]
+	m.mu.Unlock()
+	sub.Send(reflect.ValueOf(v))
+}
+
+// drop fails the current subscription.
+func (m *subscriptionRPC) drop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fail[len(m.fail)-1] <- errors.New("connection reset")
+}
+
+func (m *subscriptionRPC) subscriptions() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.subs)
+}
+
+func newTestReconnectingClient(t *testing.T, m *subscriptionRPC, opts ...ReconnectingClientOption) *ReconnectingClient {
+	opts = append([]ReconnectingClientOption{WithResubscribeStrategy(retry.Fixed(time.Millisecond))}, opts...)
+	return NewReconnectingClient(testlog.Logger(t, log.LevelDebug), m, opts...)
+}
+
+func receiveHeader(t *testing.T, ch <-chan *types.Header) *types.Header {
+	select {
+	case h := <-ch:
+		return h
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for header")
+		return nil
+	}
+}
+
+func TestReconnectingClientNewHeads(t *testing.T) {
+	m := &subscriptionRPC{}
+	for i := 0; i < 3; i++ {
+		m.mine(0)
+	}
+	c := newTestReconnectingClient(t, m)
+	ch := make(chan *types.Header)
+	sub, err := c.EthSubscribe(context.Background(), (chan<- *types.Header)(ch), "newHeads")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	m.emit(m.headers[2])
+	require.Equal(t, m.headers[2], receiveHeader(t, ch))
+
+	// the connection drops while blocks 3 and 4 are mined, and the first attempt to resubscribe fails
+	m.mu.Lock()
+	m.subscribeErrs = []error{errors.New("dial failed")}
+	m.mu.Unlock()
+	m.mine(0)
+	m.mine(0)
+	m.drop()
+	require.Eventually(t, func() bool { return m.subscriptions() == 2 }, 5*time.Second, time.Millisecond)
+
+	// the missed headers are backfilled, and not delivered again by the new subscription
+	require.Equal(t, m.headers[3], receiveHeader(t, ch))
+	require.Equal(t, m.headers[4], receiveHeader(t, ch))
+	m.emit(m.headers[4])
+	h5 := m.mine(0)
+	m.emit(h5)
+	require.Equal(t, h5, receiveHeader(t, ch))
+}
+
+func TestReconnectingClientMaxBackfill(t *testing.T) {
+	m := &subscriptionRPC{}
+	m.mine(0)
+	c := newTestReconnectingClient(t, m, WithMaxBackfill(2))
+	ch := make(chan *types.Header, 10)
+	sub, err := c.EthSubscribe(context.Background(), ch, "newHeads")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	m.emit(m.headers[0])
+	receiveHeader(t, ch)
+	for i := 0; i < 5; i++ {
+		m.mine(0)
+	}
+	m.drop()
+	require.Equal(t, m.headers[4], receiveHeader(t, ch))
+	require.Equal(t, m.headers[5], receiveHeader(t, ch))
+}
+
+func TestReconnectingClientLogs(t *testing.T) {
+	m := &subscriptionRPC{}
+	m.mine(2)
+	c := newTestReconnectingClient(t, m)
+	ch := make(chan types.Log)
+	filter := map[string]any{"address": "0x01"}
+	sub, err := c.EthSubscribe(context.Background(), ch, "logs", filter)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	receiveLog := func() types.Log {
+		select {
+		case l := <-ch:
+			return l
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for log")
+			return types.Log{}
+		}
+	}
+	// only the first log of block 0 is delivered before the connection drops
+	m.emit(m.logs[0])
+	require.Equal(t, m.logs[0], receiveLog())
+	m.mine(1)
+	m.drop()
+	require.Eventually(t, func() bool { return m.subscriptions() == 2 }, 5*time.Second, time.Millisecond)
+
+	require.Equal(t, m.logs[1], receiveLog())
+	require.Equal(t, m.logs[2], receiveLog())
+	m.mu.Lock()
+	require.Equal(t, []map[string]any{{"address": "0x01", "fromBlock": "0x0", "toBlock": "0x1"}}, m.logFilters)
+	m.mu.Unlock()
+
+	// logs delivered by the backfill are not delivered again, removed logs are
+	m.emit(m.logs[2])
+	removed := m.logs[2]
+	removed.Removed = true
+	m.emit(removed)
+	require.Equal(t, removed, receiveLog())
+	m.emit(m.logs[2])
+	require.Equal(t, m.logs[2], receiveLog())
+}
+
+func TestReconnectingClientUnsubscribe(t *testing.T) {
+	m := &subscriptionRPC{}
+	m.mine(0)
+	c := newTestReconnectingClient(t, m)
+	sub, err := c.EthSubscribe(context.Background(), make(chan *types.Header), "newHeads")
+	require.NoError(t, err)
+	// the subscriber does not receive, but unsubscribing does not block
+	m.emit(m.headers[0])
+	sub.Unsubscribe()
+	_, ok := <-sub.Err()
+	require.False(t, ok)
+
+	// errors of the initial subscription are returned
+	m.subscribeErrs = []error{errors.New("unsupported")}
+	_, err = c.EthSubscribe(context.Background(), make(chan *types.Header), "newHeads")
+	require.ErrorContains(t, err, "unsupported")
+}
+
+func TestReconnectingClientFatalResubscribe(t *testing.T) {
+	m := &subscriptionRPC{}
+	m.mine(0)
+	c := newTestReconnectingClient(t, m)
+	sub, err := c.EthSubscribe(context.Background(), make(chan *types.Header), "newHeads")
+	require.NoError(t, err)
+	m.mu.Lock()
+	m.subscribeErrs = []error{retry.FatalError(errors.New("notifications not supported"))}
+	m.mu.Unlock()
+	m.drop()
+	select {
+	case err := <-sub.Err():
+		require.ErrorContains(t, err, "notifications not supported")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for subscription error")
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultMaxResponseSize is the default limit of the size of a response body, as received.
+	DefaultMaxResponseSize = 128 << 20
+	// DefaultMaxDecompressedSize is the default limit of the size of a compressed response body, after decompression.
+	DefaultMaxDecompressedSize = 256 << 20
+)
+
+// ResponseTooLargeError is returned when reading a response body that exceeds the configured size limit.
+type ResponseTooLargeError struct {
+	// Limit is the exceeded limit, in bytes.
+	Limit int64
+	// Decompressed is true if the limit applies to the decompressed response body.
+	Decompressed bool
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	if e.Decompressed {
+		return fmt.Sprintf("decompressed response body exceeds limit of %d bytes", e.Limit)
+	}
+	return fmt.Sprintf("response body exceeds limit of %d bytes", e.Limit)
+}
+
+// limitingTransport limits the size of response bodies.
+// Responses are decompressed by the transport itself, rather than by the inner transport,
+// so the size of the decompressed body can be limited too: a small compressed response may decompress to gigabytes.
+type limitingTransport struct {
+	inner http.RoundTripper
+
+	maxSize             int64
+	maxDecompressedSize int64
+}
+
+func (t *limitingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// like the default transport, only request compression if the caller did not ask for a specific encoding or range
+	requestedGzip := req.Header.Get("Accept-Encoding") == "" && req.Header.Get("Range") == "" && req.Method != http.MethodHead
+	if requestedGzip {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	resp, err := t.inner.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.maxSize > 0 {
+		if resp.ContentLength > t.maxSize {
+			_ = resp.Body.Close()
+			return nil, &ResponseTooLargeError{Limit: t.maxSize}
+		}
+		resp.Body = &limitedBody{body: resp.Body, remaining: t.maxSize, err: &ResponseTooLargeError{Limit: t.maxSize}}
+	}
+	if requestedGzip && strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+		resp.Body = &gzipBody{body: resp.Body}
+		if t.maxDecompressedSize > 0 {
+			resp.Body = &limitedBody{body: resp.Body, remaining: t.maxDecompressedSize,
+				err: &ResponseTooLargeError{Limit: t.maxDecompressedSize, Decompressed: true}}
+		}
+	}
+	return resp, nil
+}
+
+// limitedBody returns err once more than the remaining number of bytes are read from the body.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, b.err
+	}
+	// read one byte more than remaining, to detect that the limit is exceeded
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n - 1, b.err
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
+
+// gzipBody decompresses the body lazily, on the first read, like the default transport does.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.zr == nil {
+		if b.err == nil {
+			b.zr, b.err = gzip.NewReader(b.body)
+		}
+		if b.err != nil {
+			return 0, b.err
+		}
+	}
+	return b.zr.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func gzipped(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestResponseSizeLimits(t *testing.T) {
+	small := bytes.Repeat([]byte{'a'}, 1000)
+	large := bytes.Repeat([]byte{'a'}, 10_000)
+	bomb := bytes.Repeat([]byte{0}, 1_000_000)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		switch r.URL.Path {
+		case "/small":
+			body = small
+		case "/large", "/chunked":
+			body = large
+		case "/gzip":
+			require.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+			w.Header().Set("Content-Encoding", "gzip")
+			body = gzipped(t, small)
+		case "/bomb":
+			w.Header().Set("Content-Encoding", "gzip")
+			body = gzipped(t, bomb)
+		}
+		if r.URL.Path != "/chunked" {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		_, _ = w.Write(body)
+	}))
+	defer ts.Close()
+
+	cfg := DefaultHTTPConfig()
+	cfg.MaxResponseSize = 5000
+	cfg.MaxDecompressedSize = 100_000
+	cl := NewBasicHTTPClient(ts.URL, testlog.Logger(t, log.LevelInfo), WithHTTPConfig(cfg))
+	ctx := context.Background()
+
+	get := func(path string) ([]byte, error) {
+		resp, err := cl.Get(ctx, path, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		return io.ReadAll(resp.Body)
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+		data, err := get("/small")
+		require.NoError(t, err)
+		require.Equal(t, small, data)
+	})
+
+	t.Run("content length exceeds limit", func(t *testing.T) {
+		_, err := get("/large")
+		var tooLarge *ResponseTooLargeError
+		require.True(t, errors.As(err, &tooLarge), "unexpected error: %v", err)
+		require.Equal(t, int64(5000), tooLarge.Limit)
+		require.False(t, tooLarge.Decompressed)
+	})
+
+	t.Run("body exceeds limit", func(t *testing.T) {
+		data, err := get("/chunked")
+		var tooLarge *ResponseTooLargeError
+		require.True(t, errors.As(err, &tooLarge), "unexpected error: %v", err)
+		require.False(t, tooLarge.Decompressed)
+		require.Len(t, data, 5000)
+	})
+
+	t.Run("decompressed", func(t *testing.T) {
+		data, err := get("/gzip")
+		require.NoError(t, err)
+		require.Equal(t, small, data)
+	})
+
+	t.Run("decompression bomb", func(t *testing.T) {
+		data, err := get("/bomb")
+		var tooLarge *ResponseTooLargeError
+		require.True(t, errors.As(err, &tooLarge), "unexpected error: %v", err)
+		require.Equal(t, int64(100_000), tooLarge.Limit)
+		require.True(t, tooLarge.Decompressed)
+		require.Len(t, data, 100_000)
+	})
+}
+
+func TestRPCMaxResponseSize(t *testing.T) {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("test", new(sizedService)))
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	ctx := context.Background()
+	cl, err := NewRPC(ctx, testlog.Logger(t, log.LevelInfo), ts.URL, WithMaxResponseSize(5000))
+	require.NoError(t, err)
+	defer cl.Close()
+
+	var out string
+	require.NoError(t, cl.CallContext(ctx, &out, "test_data", 1000))
+	require.Len(t, out, 1000)
+
+	err = cl.CallContext(ctx, &out, "test_data", 10_000)
+	var tooLarge *ResponseTooLargeError
+	require.True(t, errors.As(err, &tooLarge), "unexpected error: %v", err)
+}
+
+type sizedService struct{}
+
+func (s *sizedService) Data(size int) string {
+	return strings.Repeat("a", size)
+}
+
+func TestRPCHTTPClientConfigTimeout(t *testing.T) {
+	var cfg rpcConfig
+	_, ok := cfg.httpClientConfig()
+	require.False(t, ok, "geth HTTP client is kept")
+
+	require.NoError(t, WithMaxResponseSize(5000)(&cfg))
+	httpCfg, ok := cfg.httpClientConfig()
+	require.True(t, ok)
+	require.Zero(t, httpCfg.Timeout, "no timeout, like the geth HTTP client")
+	require.Equal(t, int64(5000), httpCfg.MaxResponseSize)
+	require.Equal(t, int64(5000), httpCfg.MaxDecompressedSize)
+
+	require.NoError(t, WithHTTPClientConfig(LatencyCriticalHTTPConfig())(&cfg))
+	httpCfg, ok = cfg.httpClientConfig()
+	require.True(t, ok)
+	require.Equal(t, LatencyCriticalHTTPConfig().Timeout, httpCfg.Timeout)
+	require.Equal(t, int64(5000), httpCfg.MaxResponseSize)
+}
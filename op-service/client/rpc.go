@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/url"
@@ -18,9 +19,16 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
-var httpRegex = regexp.MustCompile("^http(s)?://")
+// ErrSubscriptionsUnsupported is returned when subscribing with an RPC client that does not support subscriptions.
+var ErrSubscriptionsUnsupported = errors.New("subscriptions not supported by RPC client")
+
+var (
+	httpRegex = regexp.MustCompile("^http(s)?://")
+	wsRegex   = regexp.MustCompile("^ws(s)?://")
+)
 
 type RPC interface {
 	Close()
@@ -29,12 +37,25 @@ type RPC interface {
 	EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error)
 }
 
+// Subscriber is implemented by RPC clients that support subscriptions in namespaces other than eth.
+type Subscriber interface {
+	Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error)
+}
+
 type rpcConfig struct {
 	gethRPCOptions   []rpc.ClientOption
 	httpPollInterval time.Duration
 	backoffAttempts  int
 	limit            float64
 	burst            int
+	methodLimits     map[string]MethodRateLimit
+	maxConcurrent    int
+	limiterMetrics   metrics.RPCClientLimiterMetricer
+	tracer           tracing.Tracer
+	maxResponseSize  int64
+	httpCfg          *HTTPConfig
+	httpMetrics      metrics.HTTPClientMetricer
+	jwtSecrets       *JWTSecrets
 }
 
 type RPCOption func(cfg *rpcConfig) error
@@ -74,6 +95,103 @@ func WithRateLimit(rateLimit float64, burst int) RPCOption {
 	}
 }
 
+// WithMethodRateLimit configures the RPC to target the given rate limit (in requests / second)
+// for requests of the given method. It may be applied multiple times to limit different methods.
+// See NewLimitingClient for more details.
+func WithMethodRateLimit(method string, rateLimit float64, burst int) RPCOption {
+	return func(cfg *rpcConfig) error {
+		if cfg.methodLimits == nil {
+			cfg.methodLimits = make(map[string]MethodRateLimit)
+		}
+		cfg.methodLimits[method] = MethodRateLimit{Limit: rateLimit, Burst: burst}
+		return nil
+	}
+}
+
+// WithMaxConcurrentRequests configures the RPC to have at most the given number of requests in flight.
+// See NewLimitingClient for more details.
+func WithMaxConcurrentRequests(n int) RPCOption {
+	return func(cfg *rpcConfig) error {
+		if n < 0 {
+			return fmt.Errorf("max concurrent requests must not be negative: %d", n)
+		}
+		cfg.maxConcurrent = n
+		return nil
+	}
+}
+
+// WithLimiterMetrics configures the RPC to record how long requests wait for the per-method rate limits
+// and the concurrency cap.
+func WithLimiterMetrics(m metrics.RPCClientLimiterMetricer) RPCOption {
+	return func(cfg *rpcConfig) error {
+		cfg.limiterMetrics = m
+		return nil
+	}
+}
+
+// WithTracer configures the RPC to trace requests with the given tracer.
+// See NewTracingClient for more details.
+func WithTracer(t tracing.Tracer) RPCOption {
+	return func(cfg *rpcConfig) error {
+		cfg.tracer = t
+		return nil
+	}
+}
+
+// WithMaxResponseSize configures the RPC to fail reading responses, or websocket messages,
+// that exceed the given size in bytes, before or after decompression, with a ResponseTooLargeError.
+// It replaces the HTTP client of the RPC, unless another HTTP client is configured with WithGethRPCOptions.
+// Without WithHTTPClientConfig, requests are not given a timeout, like with the HTTP client of geth.
+func WithMaxResponseSize(size int64) RPCOption {
+	return func(cfg *rpcConfig) error {
+		if size < 0 {
+			return fmt.Errorf("max response size must not be negative: %d", size)
+		}
+		cfg.maxResponseSize = size
+		return nil
+	}
+}
+
+// WithHTTPClientConfig configures the connection pool, timeouts and protocol of the HTTP client,
+// see HTTPConfigProfile. The HTTP client of geth is used if not configured.
+func WithHTTPClientConfig(httpCfg HTTPConfig) RPCOption {
+	return func(cfg *rpcConfig) error {
+		if err := httpCfg.Check(); err != nil {
+			return err
+		}
+		cfg.httpCfg = &httpCfg
+		return nil
+	}
+}
+
+// WithHTTPClientMetrics records the connection reuse of the HTTP client,
+// if it is configured with WithHTTPClientConfig or WithMaxResponseSize.
+func WithHTTPClientMetrics(m metrics.HTTPClientMetricer) RPCOption {
+	return func(cfg *rpcConfig) error {
+		cfg.httpMetrics = m
+		return nil
+	}
+}
+
+// httpClientConfig returns the config of the HTTP client to replace the HTTP client of geth with, if any.
+func (cfg *rpcConfig) httpClientConfig() (HTTPConfig, bool) {
+	if cfg.httpCfg == nil && cfg.maxResponseSize == 0 {
+		return HTTPConfig{}, false
+	}
+	httpCfg := DefaultHTTPConfig()
+	if cfg.httpCfg != nil {
+		httpCfg = *cfg.httpCfg
+	} else {
+		// requests are bounded by their context only, like with the HTTP client of geth
+		httpCfg.Timeout = 0
+	}
+	if cfg.maxResponseSize > 0 {
+		httpCfg.MaxResponseSize = cfg.maxResponseSize
+		httpCfg.MaxDecompressedSize = cfg.maxResponseSize
+	}
+	return httpCfg, true
+}
+
 // NewRPC returns the correct client.RPC instance for a given RPC url.
 func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption) (RPC, error) {
 	var cfg rpcConfig
@@ -87,32 +205,61 @@ func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption)
 		cfg.backoffAttempts = 1
 	}
 
+	if httpCfg, ok := cfg.httpClientConfig(); ok {
+		httpOpts := []rpc.ClientOption{rpc.WithHTTPClient(NewHTTPClient(httpCfg, cfg.httpMetrics))}
+		if cfg.maxResponseSize > 0 {
+			httpOpts = append(httpOpts, rpc.WithWebsocketMessageSizeLimit(cfg.maxResponseSize))
+		}
+		// prepended, so HTTP client options of the caller take precedence
+		cfg.gethRPCOptions = append(httpOpts, cfg.gethRPCOptions...)
+	}
+
 	underlying, err := dialRPCClientWithBackoff(ctx, lgr, addr, cfg.backoffAttempts, cfg.gethRPCOptions...)
 	if err != nil {
 		return nil, err
 	}
 
 	var wrapped RPC = &BaseRPCClient{c: underlying}
+	if cfg.jwtSecrets != nil && wsRegex.MatchString(addr) {
+		wrapped = NewJWTRedialClient(lgr, underlying, cfg.jwtSecrets, func(ctx context.Context) (*rpc.Client, error) {
+			return dialRPCClientWithBackoff(ctx, lgr, addr, 1, cfg.gethRPCOptions...)
+		})
+	}
 
 	if cfg.limit != 0 {
 		wrapped = NewRateLimitingClient(wrapped, rate.Limit(cfg.limit), cfg.burst)
 	}
 
+	if len(cfg.methodLimits) > 0 || cfg.maxConcurrent > 0 {
+		wrapped = NewLimitingClient(wrapped, cfg.methodLimits, cfg.maxConcurrent, cfg.limiterMetrics)
+	}
+
+	if cfg.tracer != nil {
+		wrapped = NewTracingClient(wrapped, cfg.tracer)
+	}
+
 	return NewRPCWithClient(ctx, lgr, addr, wrapped, cfg.httpPollInterval)
 }
 
-// NewRPCWithClient builds a new polling client with the given underlying RPC client.
+// NewRPCWithClient builds a new polling client with the given underlying RPC client for HTTP addresses,
+// and a client that resubscribes failed subscriptions for websocket addresses, see NewReconnectingClient.
 func NewRPCWithClient(ctx context.Context, lgr log.Logger, addr string, underlying RPC, pollInterval time.Duration) (RPC, error) {
 	if httpRegex.MatchString(addr) {
 		underlying = NewPollingClient(ctx, lgr, underlying, WithPollRate(pollInterval))
+	} else if wsRegex.MatchString(addr) {
+		underlying = NewReconnectingClient(lgr, underlying)
 	}
 	return underlying, nil
 }
 
 // Dials a JSON-RPC endpoint repeatedly, with a backoff, until a client connection is established. Auth is optional.
+// Invalid addresses are not retried.
 func dialRPCClientWithBackoff(ctx context.Context, log log.Logger, addr string, attempts int, opts ...rpc.ClientOption) (*rpc.Client, error) {
 	bOff := retry.Exponential()
 	return retry.Do(ctx, attempts, bOff, func() (*rpc.Client, error) {
+		if err := CheckURL(addr); err != nil {
+			return nil, err
+		}
 		if !IsURLAvailable(ctx, addr) {
 			log.Warn("failed to dial address, but may connect later", "addr", addr)
 			return nil, fmt.Errorf("address unavailable (%s)", addr)
@@ -125,6 +272,15 @@ func dialRPCClientWithBackoff(ctx context.Context, log log.Logger, addr string,
 	})
 }
 
+// CheckURL returns a fatal error, see retry.FatalError, if the address is not a valid URL,
+// since dialing it would fail on every attempt.
+func CheckURL(address string) error {
+	if _, err := url.Parse(address); err != nil {
+		return retry.FatalError(fmt.Errorf("invalid address (%s): %w", address, err))
+	}
+	return nil
+}
+
 func IsURLAvailable(ctx context.Context, address string) bool {
 	u, err := url.Parse(address)
 	if err != nil {
@@ -182,6 +338,10 @@ func (b *BaseRPCClient) EthSubscribe(ctx context.Context, channel any, args ...a
 	return b.c.EthSubscribe(ctx, channel, args...)
 }
 
+func (b *BaseRPCClient) Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error) {
+	return b.c.Subscribe(ctx, namespace, channel, args...)
+}
+
 // InstrumentedRPCClient is an RPC client that tracks
 // Prometheus metrics for each call.
 type InstrumentedRPCClient struct {
@@ -217,6 +377,14 @@ func (ic *InstrumentedRPCClient) EthSubscribe(ctx context.Context, channel any,
 	return ic.c.EthSubscribe(ctx, channel, args...)
 }
 
+func (ic *InstrumentedRPCClient) Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error) {
+	s, ok := ic.c.(Subscriber)
+	if !ok {
+		return nil, ErrSubscriptionsUnsupported
+	}
+	return s.Subscribe(ctx, namespace, channel, args...)
+}
+
 // instrumentBatch handles metrics for batch calls. Request metrics are
 // increased for each batch element. Request durations are tracked for
 // the batch as a whole using a special <batch> method. Errors are tracked
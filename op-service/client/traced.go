@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
+)
+
+// TracingClient is a wrapper around a pure RPC that traces each request with a span,
+// and propagates the span to the RPC server with the traceparent header,
+// so the latency of requests to other services that are traced can be broken down.
+type TracingClient struct {
+	c      RPC
+	tracer tracing.Tracer
+}
+
+var _ RPC = (*TracingClient)(nil)
+
+func NewTracingClient(c RPC, tracer tracing.Tracer) *TracingClient {
+	return &TracingClient{c: c, tracer: tracer}
+}
+
+func (t *TracingClient) Close() {
+	t.c.Close()
+}
+
+func (t *TracingClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	ctx, span := t.tracer.Start(ctx, method,
+		tracing.String("rpc.system", "jsonrpc"),
+		tracing.String("rpc.method", method))
+	defer span.End()
+	err := t.c.CallContext(withTraceHeaders(ctx), result, method, args...)
+	span.RecordError(err)
+	return err
+}
+
+func (t *TracingClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	ctx, span := t.tracer.Start(ctx, metrics.BatchMethod,
+		tracing.String("rpc.system", "jsonrpc"),
+		tracing.Int64("rpc.batch_size", int64(len(b))))
+	defer span.End()
+	err := t.c.BatchCallContext(withTraceHeaders(ctx), b)
+	span.RecordError(err)
+	return err
+}
+
+// EthSubscribe is not traced, since subscriptions are long-lived.
+func (t *TracingClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return t.c.EthSubscribe(ctx, channel, args...)
+}
+
+// withTraceHeaders adds the traceparent header of the span of the context to the HTTP requests made with it.
+func withTraceHeaders(ctx context.Context) context.Context {
+	h := make(http.Header)
+	tracing.InjectHTTPHeaders(ctx, h)
+	return rpc.NewContextWithHeaders(ctx, h)
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
+)
+
+type recordedSpan struct {
+	name  string
+	sc    trace.SpanContext
+	attrs []tracing.Attribute
+	err   error
+	ended bool
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...tracing.Attribute) { s.attrs = append(s.attrs, attrs...) }
+func (s *recordedSpan) RecordError(err error)                    { s.err = err }
+func (s *recordedSpan) End()                                     { s.ended = true }
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, attrs ...tracing.Attribute) (context.Context, tracing.Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := &recordedSpan{
+		name: name,
+		sc: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1},
+			SpanID:     trace.SpanID{byte(len(r.spans) + 1)},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		attrs: attrs,
+	}
+	r.spans = append(r.spans, s)
+	return trace.ContextWithSpanContext(ctx, s.sc), s
+}
+
+type testService struct{}
+
+func (testService) Echo(v string) (string, error) {
+	if v == "fail" {
+		return "", errors.New("failed")
+	}
+	return v, nil
+}
+
+func TestTracingClient(t *testing.T) {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("test", testService{}))
+	var mu sync.Mutex
+	var traceParents []string
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		traceParents = append(traceParents, r.Header.Get("traceparent"))
+		mu.Unlock()
+		srv.ServeHTTP(w, r)
+	}))
+	defer httpSrv.Close()
+	cl, err := rpc.Dial(httpSrv.URL)
+	require.NoError(t, err)
+	tracer := &recordingTracer{}
+	c := NewTracingClient(NewBaseRPCClient(cl), tracer)
+	defer c.Close()
+	ctx := context.Background()
+
+	var res string
+	require.NoError(t, c.CallContext(ctx, &res, "test_echo", "hello"))
+	require.Equal(t, "hello", res)
+	require.Error(t, c.CallContext(ctx, &res, "test_echo", "fail"))
+	require.NoError(t, c.BatchCallContext(ctx, []rpc.BatchElem{
+		{Method: "test_echo", Args: []any{"a"}, Result: new(string)},
+		{Method: "test_echo", Args: []any{"b"}, Result: new(string)},
+	}))
+
+	require.Len(t, tracer.spans, 3)
+	require.Equal(t, "test_echo", tracer.spans[0].name)
+	require.Contains(t, tracer.spans[0].attrs, tracing.String("rpc.method", "test_echo"))
+	require.NoError(t, tracer.spans[0].err)
+	require.Error(t, tracer.spans[1].err)
+	require.Equal(t, metrics.BatchMethod, tracer.spans[2].name)
+	require.Contains(t, tracer.spans[2].attrs, tracing.Int64("rpc.batch_size", 2))
+	for i, s := range tracer.spans {
+		require.True(t, s.ended)
+		traceParent := "00-" + s.sc.TraceID().String() + "-" + s.sc.SpanID().String() + "-01"
+		require.Equal(t, traceParent, traceParents[i], "span should be propagated to the server")
+	}
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"fmt"
+	"os"
+)
+
+// CheckUnixSocket verifies that path is a Unix domain socket that other users cannot connect to.
+// Unix domain sockets are not authenticated with a JWT secret, so access is controlled by the file permissions:
+// connecting requires write permission on the socket.
+func CheckUnixSocket(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat socket: %w", err)
+	}
+	if info.Mode().Type() != os.ModeSocket {
+		return fmt.Errorf("%s is not a Unix domain socket", path)
+	}
+	if perm := info.Mode().Perm(); perm&0o002 != 0 {
+		return fmt.Errorf("socket %s is writable by other users (mode %v), restrict its permissions", path, perm)
+	}
+	return nil
+}
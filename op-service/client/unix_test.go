@@ -0,0 +1,33 @@
+package client
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUnixSocket(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.ipc")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	require.NoError(t, os.Chmod(path, 0o700))
+	require.NoError(t, CheckUnixSocket(path))
+
+	require.NoError(t, os.Chmod(path, 0o770))
+	require.NoError(t, CheckUnixSocket(path))
+
+	require.NoError(t, os.Chmod(path, 0o772))
+	require.ErrorContains(t, CheckUnixSocket(path), "writable by other users")
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	require.ErrorContains(t, CheckUnixSocket(file), "not a Unix domain socket")
+
+	require.ErrorContains(t, CheckUnixSocket(filepath.Join(dir, "missing")), "failed to stat socket")
+}
@@ -0,0 +1,95 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+)
+
+// ChainSignerConfig is the signer of an additional chain in the JSON chains config of a multi-chain service.
+// Keys are never part of the config: they are held by a remote signer or a cloud KMS, or read from the environment
+// variables named by the config.
+type ChainSignerConfig struct {
+	// PrivateKeyEnv or MnemonicEnv name the environment variable holding the private key or mnemonic.
+	PrivateKeyEnv string `json:"private_key_env,omitempty"`
+	MnemonicEnv   string `json:"mnemonic_env,omitempty"`
+	HDPath        string `json:"hd_path,omitempty"`
+
+	SignerEndpoint string `json:"signer_endpoint,omitempty"`
+	SignerAddress  string `json:"signer_address,omitempty"`
+
+	KMSProvider string `json:"kms_provider,omitempty"`
+	KMSKeyID    string `json:"kms_key_id,omitempty"`
+	KMSRegion   string `json:"kms_region,omitempty"`
+	KMSEndpoint string `json:"kms_endpoint,omitempty"`
+}
+
+// Check returns an error unless exactly one kind of signer is configured.
+func (c *ChainSignerConfig) Check() error {
+	kinds := 0
+	if c.PrivateKeyEnv != "" || c.MnemonicEnv != "" {
+		if c.PrivateKeyEnv != "" && c.MnemonicEnv != "" {
+			return errors.New("cannot specify both a private key and a mnemonic")
+		}
+		if c.MnemonicEnv != "" && c.HDPath == "" {
+			return errors.New("mnemonic requires an HD path")
+		}
+		kinds++
+	}
+	if c.SignerEndpoint != "" || c.SignerAddress != "" {
+		if c.SignerEndpoint == "" || c.SignerAddress == "" {
+			return errors.New("signer endpoint and address must both be set")
+		}
+		kinds++
+	}
+	if c.KMSProvider != "" {
+		kinds++
+	}
+	switch kinds {
+	case 0:
+		return errors.New("must provide a private key or mnemonic environment variable, a signer endpoint or a KMS key")
+	case 1:
+		return nil
+	default:
+		return errors.New("only one of a local key, a signer endpoint or a KMS key may be set")
+	}
+}
+
+// SignerFactory creates the signer of the chain. The TLS config of remote signers is taken from base, the signer
+// config of the main chain, so the same client certificate is used for all chains.
+func (c *ChainSignerConfig) SignerFactory(l log.Logger, base opsigner.CLIConfig) (SignerFactory, common.Address, error) {
+	if err := c.Check(); err != nil {
+		return nil, common.Address{}, err
+	}
+	var privateKey, mnemonic string
+	if c.PrivateKeyEnv != "" {
+		privateKey = os.Getenv(c.PrivateKeyEnv)
+		if privateKey == "" {
+			return nil, common.Address{}, fmt.Errorf("private key environment variable %s is not set", c.PrivateKeyEnv)
+		}
+	}
+	if c.MnemonicEnv != "" {
+		mnemonic = os.Getenv(c.MnemonicEnv)
+		if mnemonic == "" {
+			return nil, common.Address{}, fmt.Errorf("mnemonic environment variable %s is not set", c.MnemonicEnv)
+		}
+	}
+	signerCfg := opsigner.CLIConfig{
+		Endpoint:    c.SignerEndpoint,
+		Address:     c.SignerAddress,
+		TLSConfig:   base.TLSConfig,
+		KMSProvider: c.KMSProvider,
+		KMSKeyID:    c.KMSKeyID,
+		KMSRegion:   c.KMSRegion,
+		KMSEndpoint: c.KMSEndpoint,
+	}
+	if err := signerCfg.Check(); err != nil {
+		return nil, common.Address{}, err
+	}
+	return SignerFactoryFromConfig(l, privateKey, mnemonic, c.HDPath, signerCfg)
+}
@@ -0,0 +1,57 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestChainSignerConfig_Check(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       ChainSignerConfig
+		errString string
+	}{
+		{name: "PrivateKey", cfg: ChainSignerConfig{PrivateKeyEnv: "KEY"}},
+		{name: "Mnemonic", cfg: ChainSignerConfig{MnemonicEnv: "MNEMONIC", HDPath: "m/44'/60'/0'/0/0"}},
+		{name: "RemoteSigner", cfg: ChainSignerConfig{SignerEndpoint: "http://signer", SignerAddress: "0x01"}},
+		{name: "KMS", cfg: ChainSignerConfig{KMSProvider: "aws", KMSKeyID: "key"}},
+		{name: "None", cfg: ChainSignerConfig{}, errString: "must provide"},
+		{name: "KeyAndMnemonic", cfg: ChainSignerConfig{PrivateKeyEnv: "KEY", MnemonicEnv: "MNEMONIC", HDPath: "m"},
+			errString: "cannot specify both a private key and a mnemonic"},
+		{name: "MnemonicWithoutPath", cfg: ChainSignerConfig{MnemonicEnv: "MNEMONIC"}, errString: "requires an HD path"},
+		{name: "SignerWithoutAddress", cfg: ChainSignerConfig{SignerEndpoint: "http://signer"},
+			errString: "signer endpoint and address must both be set"},
+		{name: "KeyAndKMS", cfg: ChainSignerConfig{PrivateKeyEnv: "KEY", KMSProvider: "aws"}, errString: "only one of"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cfg.Check()
+			if test.errString == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.errString)
+			}
+		})
+	}
+}
+
+func TestChainSignerConfig_SignerFactoryReadsEnv(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cfg := ChainSignerConfig{PrivateKeyEnv: "TEST_CHAIN_SIGNER_KEY"}
+
+	t.Setenv("TEST_CHAIN_SIGNER_KEY", "")
+	_, _, err := cfg.SignerFactory(logger, opsigner.NewCLIConfig())
+	require.ErrorContains(t, err, "private key environment variable TEST_CHAIN_SIGNER_KEY is not set")
+
+	t.Setenv("TEST_CHAIN_SIGNER_KEY", "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	_, from, err := cfg.SignerFactory(logger, opsigner.NewCLIConfig())
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), from)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -42,13 +43,30 @@ type SignerFn func(context.Context, common.Address, *types.Transaction) (*types.
 // SignerFactory creates a SignerFn that is bound to a specific ChainID
 type SignerFactory func(chainID *big.Int) SignerFn
 
-// SignerFactoryFromConfig considers three ways that signers are created & then creates single factory from those config options.
-// It can either take a remote signer (via opsigner.CLIConfig) or it can be provided either a mnemonic + derivation path or a private key.
-// It prefers the remote signer, then the mnemonic or private key (only one of which can be provided).
+// SignerFactoryFromConfig considers four ways that signers are created & then creates single factory from those config options.
+// It can either take a cloud KMS key or a remote signer (via opsigner.CLIConfig) or it can be provided either a mnemonic + derivation path or a private key.
+// It prefers the KMS key, then the remote signer, then the mnemonic or private key (only one of which can be provided).
 func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string, signerConfig opsigner.CLIConfig) (SignerFactory, common.Address, error) {
 	var signer SignerFactory
 	var fromAddress common.Address
-	if signerConfig.Enabled() {
+	if signerConfig.KMSEnabled() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		kmsSigner, err := opsigner.NewKMSSignerFromConfig(ctx, l, signerConfig)
+		if err != nil {
+			l.Error("Unable to create KMS Signer", "error", err)
+			return nil, common.Address{}, fmt.Errorf("failed to create the KMS signer: %w", err)
+		}
+		fromAddress, err = kmsSigner.Address(ctx)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		signer = func(chainID *big.Int) SignerFn {
+			return func(ctx context.Context, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return kmsSigner.SignTransaction(ctx, chainID, address, tx)
+			}
+		}
+	} else if signerConfig.Enabled() {
 		signerClient, err := opsigner.NewSignerClientFromConfig(l, signerConfig)
 		if err != nil {
 			l.Error("Unable to create Signer Client", "error", err)
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 // EthClientInterface is an interface for providing an ethclient.Client
@@ -12,5 +13,8 @@ import (
 type EthClientInterface interface {
 	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
 
+	// Client returns the underlying RPC client, for calling non-standard RPC methods.
+	Client() *rpc.Client
+
 	Close()
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -24,3 +25,8 @@ type RollupClientInterface interface {
 type SyncStatusProvider interface {
 	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 }
+
+// SyncStatusSubscriber is the interface of a rollup client that notifies of changes of its sync status.
+type SyncStatusSubscriber interface {
+	SubscribeSyncStatus(ctx context.Context, ch chan<- *eth.SyncStatus) (ethereum.Subscription, error)
+}
@@ -17,6 +17,9 @@ const (
 	EncodingVersion = 0
 	VersionOffset   = 1    // offset of the version byte in the blob encoding
 	Rounds          = 1024 // number of encode/decode rounds
+	// MaxBlobsPerBlobTx is the maximum number of blobs a single blob transaction can carry,
+	// bounded by the max blob gas per block.
+	MaxBlobsPerBlobTx = 6
 )
 
 var (
@@ -0,0 +1,43 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BuilderStatus is the status of the external block builder of a sequencer,
+// as reported by the builder sidecar between the sequencer and its execution engine.
+type BuilderStatus struct {
+	// Available is false if the last call to the builder failed.
+	Available bool `json:"available"`
+	// Blocks is the number of recently sealed blocks.
+	Blocks hexutil.Uint64 `json:"blocks"`
+	// Failures is the number of recently sealed blocks that were built locally, because the builder block was not available.
+	Failures hexutil.Uint64 `json:"failures"`
+}
+
+// FailureRate returns the fraction of recently sealed blocks that were not built by the builder.
+func (s *BuilderStatus) FailureRate() float64 {
+	if s.Blocks == 0 {
+		return 0
+	}
+	return float64(s.Failures) / float64(s.Blocks)
+}
+
+// PayloadSource is where an unsafe payload was built.
+type PayloadSource string
+
+const (
+	// PayloadSourceLocal is a payload built by the sequencer's own execution engine.
+	PayloadSourceLocal PayloadSource = "local"
+	// PayloadSourceBuilder is a payload built by an external block builder.
+	PayloadSourceBuilder PayloadSource = "builder"
+)
+
+// PayloadMetadata is the provenance of an unsafe payload, supplied by the sequencer when committing it to the conductor.
+type PayloadMetadata struct {
+	Source PayloadSource `json:"source"`
+	// BuilderID identifies the external block builder, if the payload was externally built.
+	BuilderID string `json:"builderID,omitempty"`
+	// BidValue is the value (in wei) of the winning builder bid, if the payload was externally built.
+	BidValue *hexutil.Big `json:"bidValue,omitempty"`
+}
@@ -0,0 +1,184 @@
+package eth
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Deposit source-hash domains, to not have the source-hashes of the different kinds of deposits conflict.
+const (
+	UserDepositSourceDomain    = 0
+	L1InfoDepositSourceDomain  = 1
+	UpgradeDepositSourceDomain = 2
+)
+
+var (
+	ErrEmptyTx          = errors.New("empty transaction")
+	ErrNotDepositTx     = errors.New("not a deposit transaction")
+	ErrInvalidDepositTx = errors.New("invalid deposit transaction")
+)
+
+// UserDepositSourceHash computes the source-hash of a user deposit, emitted at the given log index of the L1 block.
+func UserDepositSourceHash(l1BlockHash common.Hash, logIndex uint64) common.Hash {
+	return depositSourceHash(UserDepositSourceDomain, depositIDHash(l1BlockHash, logIndex))
+}
+
+// L1InfoDepositSourceHash computes the source-hash of the L1 info deposit
+// of the L2 block with the given L1 origin and sequence number.
+func L1InfoDepositSourceHash(l1BlockHash common.Hash, seqNumber uint64) common.Hash {
+	return depositSourceHash(L1InfoDepositSourceDomain, depositIDHash(l1BlockHash, seqNumber))
+}
+
+// UpgradeDepositSourceHash computes the source-hash of a system-upgrade deposit,
+// identified by its human-readable intent.
+func UpgradeDepositSourceHash(intent string) common.Hash {
+	return depositSourceHash(UpgradeDepositSourceDomain, crypto.Keccak256Hash([]byte(intent)))
+}
+
+func depositIDHash(l1BlockHash common.Hash, index uint64) common.Hash {
+	var input [32 * 2]byte
+	copy(input[:32], l1BlockHash[:])
+	binary.BigEndian.PutUint64(input[32*2-8:], index)
+	return crypto.Keccak256Hash(input[:])
+}
+
+func depositSourceHash(domain uint64, idHash common.Hash) common.Hash {
+	var domainInput [32 * 2]byte
+	binary.BigEndian.PutUint64(domainInput[32-8:32], domain)
+	copy(domainInput[32:], idHash[:])
+	return crypto.Keccak256Hash(domainInput[:])
+}
+
+// IsDepositTx checks if the opaque transaction is a deposit transaction, by its type byte.
+// An error is returned if the transaction is empty.
+func IsDepositTx(opaqueTx Data) (bool, error) {
+	if len(opaqueTx) == 0 {
+		return false, ErrEmptyTx
+	}
+	return opaqueTx[0] == types.DepositTxType, nil
+}
+
+// DecodeDepositTx decodes an opaque deposit transaction, and validates the fields that every deposit must have.
+func DecodeDepositTx(opaqueTx Data) (*types.DepositTx, error) {
+	if deposit, err := IsDepositTx(opaqueTx); err != nil {
+		return nil, err
+	} else if !deposit {
+		return nil, fmt.Errorf("%w: type %d", ErrNotDepositTx, opaqueTx[0])
+	}
+	var dep types.DepositTx
+	if err := rlp.DecodeBytes(opaqueTx[1:], &dep); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDepositTx, err)
+	}
+	if dep.SourceHash == (common.Hash{}) {
+		return nil, fmt.Errorf("%w: no source hash", ErrInvalidDepositTx)
+	}
+	if dep.Value == nil {
+		return nil, fmt.Errorf("%w: no value", ErrInvalidDepositTx)
+	}
+	return &dep, nil
+}
+
+// CheckDeposits verifies the deposits of the transactions of a L2 block, and returns the number of deposits.
+// The block must start with the L1 info deposit, which does not mint nor transfer ETH,
+// followed by the other deposits, each with a unique source-hash, and no deposits after the first other transaction.
+// If expected is not nil, the deposits must match the deposits of the expected transactions (e.g. the payload attributes),
+// by source-hash, mint and value, in the same order.
+func CheckDeposits(txs []Data, expected []Data) (int, error) {
+	if len(txs) == 0 {
+		return 0, errors.New("no transactions in block")
+	}
+	var deposits []*types.DepositTx
+	sourceHashes := make(map[common.Hash]struct{})
+	for i, tx := range txs {
+		deposit, err := IsDepositTx(tx)
+		if err != nil {
+			return 0, fmt.Errorf("invalid transaction at idx %d: %w", i, err)
+		}
+		if !deposit {
+			if i == 0 {
+				return 0, fmt.Errorf("first transaction was not deposit tx. Got %v", tx[0])
+			}
+			continue
+		}
+		if len(deposits) < i {
+			return 0, fmt.Errorf("deposit tx (%d) after other tx in l2 block with prev deposit at idx %d", i, len(deposits)-1)
+		}
+		dep, err := DecodeDepositTx(tx)
+		if err != nil {
+			return 0, fmt.Errorf("invalid deposit at idx %d: %w", i, err)
+		}
+		if _, ok := sourceHashes[dep.SourceHash]; ok {
+			return 0, fmt.Errorf("%w: duplicate source hash %s at idx %d", ErrInvalidDepositTx, dep.SourceHash, i)
+		}
+		sourceHashes[dep.SourceHash] = struct{}{}
+		deposits = append(deposits, dep)
+	}
+	l1Info := deposits[0]
+	if depositMint(l1Info).Sign() != 0 {
+		return 0, fmt.Errorf("%w: L1 info deposit mints %v", ErrInvalidDepositTx, l1Info.Mint)
+	}
+	if l1Info.Value.Sign() != 0 {
+		return 0, fmt.Errorf("%w: L1 info deposit transfers %v", ErrInvalidDepositTx, l1Info.Value)
+	}
+	if expected != nil {
+		if err := checkExpectedDeposits(deposits, expected); err != nil {
+			return 0, err
+		}
+	}
+	return len(deposits), nil
+}
+
+func checkExpectedDeposits(deposits []*types.DepositTx, expected []Data) error {
+	var expectedDeposits []*types.DepositTx
+	for i, tx := range expected {
+		if deposit, err := IsDepositTx(tx); err != nil || !deposit {
+			break
+		}
+		dep, err := DecodeDepositTx(tx)
+		if err != nil {
+			return fmt.Errorf("invalid expected deposit at idx %d: %w", i, err)
+		}
+		expectedDeposits = append(expectedDeposits, dep)
+	}
+	if len(deposits) != len(expectedDeposits) {
+		return fmt.Errorf("expected %d deposits, got %d", len(expectedDeposits), len(deposits))
+	}
+	for i, dep := range deposits {
+		exp := expectedDeposits[i]
+		if dep.SourceHash != exp.SourceHash {
+			return fmt.Errorf("deposit at idx %d has source hash %s, expected %s", i, dep.SourceHash, exp.SourceHash)
+		}
+		if depositMint(dep).Cmp(depositMint(exp)) != 0 {
+			return fmt.Errorf("deposit at idx %d mints %v, expected %v", i, dep.Mint, exp.Mint)
+		}
+		if dep.Value.Cmp(exp.Value) != 0 {
+			return fmt.Errorf("deposit at idx %d transfers %v, expected %v", i, dep.Value, exp.Value)
+		}
+	}
+	return nil
+}
+
+// depositMint returns the amount of ETH minted by the deposit, a nil mint is encoded the same as a zero mint.
+func depositMint(dep *types.DepositTx) *big.Int {
+	if dep.Mint == nil {
+		return new(big.Int)
+	}
+	return dep.Mint
+}
+
+// CheckL1InfoDepositSource verifies the source-hash of the L1 info deposit,
+// by recomputing it from the L1 origin and sequence number of the L2 block.
+func CheckL1InfoDepositSource(dep *types.DepositTx, l1BlockHash common.Hash, seqNumber uint64) error {
+	if expected := L1InfoDepositSourceHash(l1BlockHash, seqNumber); dep.SourceHash != expected {
+		return fmt.Errorf("%w: L1 info deposit has source hash %s, expected %s of L1 origin %s and sequence number %d",
+			ErrInvalidDepositTx, dep.SourceHash, expected, l1BlockHash, seqNumber)
+	}
+	return nil
+}
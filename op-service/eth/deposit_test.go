@@ -0,0 +1,69 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeDeposit(t *testing.T, dep *types.DepositTx) Data {
+	tx, err := types.NewTx(dep).MarshalBinary()
+	require.NoError(t, err)
+	return tx
+}
+
+func TestDecodeDepositTx(t *testing.T) {
+	dep, err := DecodeDepositTx(encodeDeposit(t, &types.DepositTx{SourceHash: common.Hash{1}, Mint: big.NewInt(5), Value: big.NewInt(3)}))
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{1}, dep.SourceHash)
+	require.Equal(t, big.NewInt(5), dep.Mint)
+
+	_, err = DecodeDepositTx(Data{})
+	require.ErrorIs(t, err, ErrEmptyTx)
+	_, err = DecodeDepositTx(Data{types.DynamicFeeTxType, 0xc0})
+	require.ErrorIs(t, err, ErrNotDepositTx)
+	_, err = DecodeDepositTx(Data{types.DepositTxType, 0x01})
+	require.ErrorIs(t, err, ErrInvalidDepositTx)
+	_, err = DecodeDepositTx(encodeDeposit(t, &types.DepositTx{Value: new(big.Int)}))
+	require.ErrorIs(t, err, ErrInvalidDepositTx, "no source hash")
+}
+
+func TestCheckDeposits(t *testing.T) {
+	l1Info := encodeDeposit(t, &types.DepositTx{SourceHash: L1InfoDepositSourceHash(common.Hash{0xaa}, 1), Value: new(big.Int)})
+	user := encodeDeposit(t, &types.DepositTx{SourceHash: UserDepositSourceHash(common.Hash{0xaa}, 3), Mint: big.NewInt(10), Value: big.NewInt(2)})
+	other := Data{types.DynamicFeeTxType, 0xc0}
+
+	n, err := CheckDeposits([]Data{l1Info, user, other}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	n, err = CheckDeposits([]Data{l1Info, user, other}, []Data{l1Info, user})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	_, err = CheckDeposits(nil, nil)
+	require.ErrorContains(t, err, "no transactions")
+	_, err = CheckDeposits([]Data{other, l1Info}, nil)
+	require.ErrorContains(t, err, "first transaction was not deposit tx")
+	_, err = CheckDeposits([]Data{l1Info, other, user}, nil)
+	require.ErrorContains(t, err, "after other tx")
+	_, err = CheckDeposits([]Data{l1Info, l1Info}, nil)
+	require.ErrorContains(t, err, "duplicate source hash")
+	_, err = CheckDeposits([]Data{user}, nil)
+	require.ErrorContains(t, err, "L1 info deposit mints")
+	_, err = CheckDeposits([]Data{l1Info}, []Data{l1Info, user})
+	require.ErrorContains(t, err, "expected 2 deposits, got 1")
+
+	otherUser := encodeDeposit(t, &types.DepositTx{SourceHash: UserDepositSourceHash(common.Hash{0xaa}, 3), Mint: big.NewInt(11), Value: big.NewInt(2)})
+	_, err = CheckDeposits([]Data{l1Info, otherUser}, []Data{l1Info, user})
+	require.ErrorContains(t, err, "mints 11, expected 10")
+}
+
+func TestCheckL1InfoDepositSource(t *testing.T) {
+	dep := &types.DepositTx{SourceHash: L1InfoDepositSourceHash(common.Hash{0xaa}, 1)}
+	require.NoError(t, CheckL1InfoDepositSource(dep, common.Hash{0xaa}, 1))
+	require.ErrorIs(t, CheckL1InfoDepositSource(dep, common.Hash{0xaa}, 2), ErrInvalidDepositTx)
+	require.ErrorIs(t, CheckL1InfoDepositSource(dep, common.Hash{0xbb}, 1), ErrInvalidDepositTx)
+}
@@ -0,0 +1,56 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Statuses of the channels read from a L1 block.
+const (
+	ChannelReady    = "ready"
+	ChannelTimedOut = "timed_out"
+	ChannelPruned   = "pruned"
+)
+
+// Statuses of the batches read from a L1 block.
+const (
+	BatchAccepted = "accepted"
+	BatchDropped  = "dropped"
+)
+
+// DerivedFrame is a frame read from the batcher data of a L1 block.
+type DerivedFrame struct {
+	ChannelID   hexutil.Bytes  `json:"channelId"`
+	FrameNumber uint16         `json:"frameNumber"`
+	DataLength  hexutil.Uint64 `json:"dataLength"`
+	IsLast      bool           `json:"isLast"`
+}
+
+// DerivedChannel is a channel that was closed, timed out or pruned at a L1 block.
+type DerivedChannel struct {
+	ChannelID hexutil.Bytes  `json:"channelId"`
+	Status    string         `json:"status"`
+	OpenedAt  BlockID        `json:"openedAt"`
+	Frames    hexutil.Uint64 `json:"frames"`
+	Size      hexutil.Uint64 `json:"size"`
+}
+
+// DerivedBatch is a batch included in a L1 block, that was accepted or dropped by the batch queue.
+type DerivedBatch struct {
+	Type      string         `json:"type"`
+	Timestamp hexutil.Uint64 `json:"timestamp"`
+	// Blocks is the number of L2 blocks of the batch, which is more than one for span batches.
+	Blocks hexutil.Uint64 `json:"blocks"`
+	Status string         `json:"status"`
+}
+
+// DerivationProgress is what the derivation pipeline derived from a single L1 block:
+// the frames, channels and batches read from it, and the safe L2 blocks that resulted.
+type DerivationProgress struct {
+	L1Block  BlockID          `json:"l1Block"`
+	Frames   []DerivedFrame   `json:"frames"`
+	Channels []DerivedChannel `json:"channels"`
+	Batches  []DerivedBatch   `json:"batches"`
+	L2Blocks []L2BlockRef     `json:"l2Blocks"`
+	// Truncated is true if records were omitted, to bound the memory used by the bookkeeping.
+	Truncated bool `json:"truncated"`
+}
@@ -0,0 +1,57 @@
+package eth
+
+// EngineFork identifies the set of Engine API methods to use with the execution engine.
+// The Engine API changes with the L1 hard forks, which are adopted by L2 hard forks.
+type EngineFork uint8
+
+const (
+	// EngineParis is the Engine API of the Bedrock and Regolith hard forks.
+	EngineParis EngineFork = iota
+	// EngineShanghai is the Engine API from the Canyon hard fork on.
+	EngineShanghai
+	// EngineCancun is the Engine API from the Ecotone hard fork on.
+	EngineCancun
+)
+
+func (f EngineFork) String() string {
+	switch f {
+	case EngineParis:
+		return "paris"
+	case EngineShanghai:
+		return "shanghai"
+	case EngineCancun:
+		return "cancun"
+	default:
+		return "unknown"
+	}
+}
+
+// FCUForFork returns the forkchoice-updated method to build blocks of the given fork with.
+func FCUForFork(f EngineFork) EngineAPIMethod {
+	switch f {
+	case EngineCancun:
+		return FCUV3
+	case EngineShanghai:
+		return FCUV2
+	default:
+		// According to Ethereum engine API spec, we can use fcuV2 here,
+		// but upstream Geth v1.13.11 does not accept V2 before Shanghai.
+		return FCUV1
+	}
+}
+
+// NewPayloadForFork returns the method to insert payloads of the given fork with.
+func NewPayloadForFork(f EngineFork) EngineAPIMethod {
+	if f >= EngineCancun {
+		return NewPayloadV3
+	}
+	return NewPayloadV2
+}
+
+// GetPayloadForFork returns the method to retrieve built payloads of the given fork with.
+func GetPayloadForFork(f EngineFork) EngineAPIMethod {
+	if f >= EngineCancun {
+		return GetPayloadV3
+	}
+	return GetPayloadV2
+}
@@ -0,0 +1,29 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineForkMethods(t *testing.T) {
+	for _, test := range []struct {
+		fork       EngineFork
+		fcu        EngineAPIMethod
+		newPayload EngineAPIMethod
+		getPayload EngineAPIMethod
+	}{
+		{EngineParis, FCUV1, NewPayloadV2, GetPayloadV2},
+		{EngineShanghai, FCUV2, NewPayloadV2, GetPayloadV2},
+		{EngineCancun, FCUV3, NewPayloadV3, GetPayloadV3},
+	} {
+		t.Run(test.fork.String(), func(t *testing.T) {
+			require.Equal(t, test.fcu, FCUForFork(test.fork))
+			require.Equal(t, test.newPayload, NewPayloadForFork(test.fork))
+			require.Equal(t, test.getPayload, GetPayloadForFork(test.fork))
+			require.Contains(t, EngineAPIMethods, test.fcu)
+			require.Contains(t, EngineAPIMethods, test.newPayload)
+			require.Contains(t, EngineAPIMethods, test.getPayload)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package eth
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// EngineQueueState is a snapshot of the internals of the engine queue of the rollup node,
+// to diagnose a node that does not make progress.
+type EngineQueueState struct {
+	// Forkchoice targets of the execution engine.
+	UnsafeL2       L2BlockRef `json:"unsafeL2"`
+	PendingSafeL2  L2BlockRef `json:"pendingSafeL2"`
+	SafeL2         L2BlockRef `json:"safeL2"`
+	FinalizedL2    L2BlockRef `json:"finalizedL2"`
+	BackupUnsafeL2 L2BlockRef `json:"backupUnsafeL2"`
+	// NeedFCUCall is whether the forkchoice targets still have to be sent to the execution engine.
+	NeedFCUCall bool `json:"needFCUCall"`
+	// EngineSyncing is whether the execution engine is syncing the chain itself (EL sync).
+	EngineSyncing bool `json:"engineSyncing"`
+	// PendingPayload is the payload that the execution engine is building, nil if no payload is being built.
+	PendingPayload *PendingPayload `json:"pendingPayload"`
+	// QueuedUnsafePayloads are the received unsafe payloads that await processing, by ascending block number.
+	QueuedUnsafePayloads []BlockID `json:"queuedUnsafePayloads"`
+	// InsertionErrors are the most recent errors of inserting blocks, oldest first.
+	InsertionErrors []BlockInsertionError `json:"insertionErrors"`
+	// UpdatedAt is the time of the snapshot of the engine state. The engine state is only updated
+	// between the processing of events, so a snapshot that does not update indicates a stuck event loop.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// PendingPayload is a payload that the execution engine is building.
+type PendingPayload struct {
+	ID        PayloadID      `json:"id"`
+	Onto      L2BlockRef     `json:"onto"`
+	Timestamp hexutil.Uint64 `json:"timestamp"`
+	// Safe is whether the payload is built from derived attributes, to become the pending safe block.
+	Safe bool `json:"safe"`
+	// State is the state of the building job: started, sealing, inserted or failed.
+	State     string    `json:"state"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// BlockInsertionError is an error of building or inserting a block on top of the Onto block.
+type BlockInsertionError struct {
+	Time time.Time `json:"time"`
+	// Type is the type of the error, which determines how the error is handled: temporary, prestate or payload.
+	Type  string     `json:"type"`
+	Onto  L2BlockRef `json:"onto"`
+	Error string     `json:"error"`
+}
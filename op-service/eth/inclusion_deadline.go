@@ -0,0 +1,17 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// InclusionDeadline is a transaction that the sequencer must include in a L2 block before the deadline.
+type InclusionDeadline struct {
+	TxHash common.Hash `json:"txHash"`
+	// RegisteredAt is the unsafe L2 block number at the time the deadline was registered.
+	RegisteredAt hexutil.Uint64 `json:"registeredAt"`
+	// Deadline is the last L2 block number that the transaction may be included in.
+	Deadline hexutil.Uint64 `json:"deadline"`
+	// Missed is true if the sequencer built the deadline block without including the transaction.
+	Missed bool `json:"missed"`
+}
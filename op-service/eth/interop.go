@@ -0,0 +1,48 @@
+package eth
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SafetyLevel is the cross-chain safety of a message, as determined by the interop supervisor.
+type SafetyLevel string
+
+const (
+	SafetyFinalized   SafetyLevel = "finalized"
+	SafetyCrossSafe   SafetyLevel = "safe"
+	SafetyCrossUnsafe SafetyLevel = "cross-unsafe"
+	SafetyUnsafe      SafetyLevel = "unsafe"
+	SafetyInvalid     SafetyLevel = "invalid"
+	SafetyUnknown     SafetyLevel = "unknown"
+)
+
+func (lvl SafetyLevel) String() string {
+	return string(lvl)
+}
+
+func (lvl *SafetyLevel) UnmarshalText(text []byte) error {
+	switch x := SafetyLevel(text); x {
+	case SafetyFinalized, SafetyCrossSafe, SafetyCrossUnsafe, SafetyUnsafe, SafetyInvalid, SafetyUnknown:
+		*lvl = x
+		return nil
+	default:
+		return fmt.Errorf("unrecognized safety level: %q", text)
+	}
+}
+
+// Identifier points to an initiating message: the log at the given index of a block of a (remote) chain.
+type Identifier struct {
+	Origin      common.Address `json:"origin"`
+	BlockNumber Uint64Quantity `json:"blockNumber"`
+	LogIndex    Uint64Quantity `json:"logIndex"`
+	Timestamp   Uint64Quantity `json:"timestamp"`
+	ChainID     Uint64Quantity `json:"chainID"`
+}
+
+// Message is an executing message: the initiating message it points to, and the hash of the executed payload.
+type Message struct {
+	Identifier  Identifier  `json:"identifier"`
+	PayloadHash common.Hash `json:"payloadHash"`
+}
@@ -0,0 +1,57 @@
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SequencerState is the state of a stopped sequencer, exported to migrate the sequencer to another host.
+// The state is verified when it is imported: the importing sequencer must be of the same chain,
+// at the same unsafe head, and with a compatible sequencer conductor setup.
+type SequencerState struct {
+	// L2Genesis identifies the L2 chain of the sequencer.
+	L2Genesis BlockID `json:"l2_genesis"`
+
+	UnsafeL2    L2BlockRef `json:"unsafe_l2"`
+	SafeL2      L2BlockRef `json:"safe_l2"`
+	FinalizedL2 L2BlockRef `json:"finalized_l2"`
+
+	// PendingBuild is the parent of the block that was being built when the sequencer was stopped, if any.
+	// Block building is cancelled when the sequencer is stopped, the importing sequencer builds on the unsafe head.
+	PendingBuild *L2BlockRef `json:"pending_build,omitempty"`
+
+	Conductor SequencerConductorState `json:"conductor"`
+
+	// Checksum commits to all other fields of the state, to detect modifications in transit.
+	Checksum common.Hash `json:"checksum"`
+}
+
+// SequencerConductorState describes how the sequencer interacts with the sequencer conductor.
+type SequencerConductorState struct {
+	// Enabled is true if the sequencer commits blocks to a sequencer conductor.
+	Enabled bool `json:"enabled"`
+	// LeaderOverridden is true if the leadership of the conductor is overridden for disaster recovery.
+	LeaderOverridden bool `json:"leader_overridden"`
+}
+
+// ComputeChecksum returns the checksum of all fields of the state except the checksum itself.
+func (s *SequencerState) ComputeChecksum() common.Hash {
+	cpy := *s
+	cpy.Checksum = common.Hash{}
+	data, err := json.Marshal(&cpy)
+	if err != nil {
+		panic(fmt.Errorf("failed to encode sequencer state: %w", err))
+	}
+	return crypto.Keccak256Hash(data)
+}
+
+// VerifyChecksum checks that the state was not modified after it was exported.
+func (s *SequencerState) VerifyChecksum() error {
+	if actual := s.ComputeChecksum(); actual != s.Checksum {
+		return fmt.Errorf("sequencer state checksum mismatch: expected %s, computed %s", s.Checksum, actual)
+	}
+	return nil
+}
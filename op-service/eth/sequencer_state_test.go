@@ -0,0 +1,36 @@
+package eth
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequencerStateChecksum(t *testing.T) {
+	state := &SequencerState{
+		L2Genesis:    BlockID{Hash: common.Hash{0x01}},
+		UnsafeL2:     L2BlockRef{Hash: common.Hash{0x0a}, Number: 10},
+		SafeL2:       L2BlockRef{Hash: common.Hash{0x05}, Number: 5},
+		FinalizedL2:  L2BlockRef{Hash: common.Hash{0x02}, Number: 2},
+		PendingBuild: &L2BlockRef{Hash: common.Hash{0x0a}, Number: 10},
+		Conductor:    SequencerConductorState{Enabled: true},
+	}
+	state.Checksum = state.ComputeChecksum()
+	require.NoError(t, state.VerifyChecksum())
+
+	// the checksum survives encoding
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+	var decoded SequencerState
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, decoded.VerifyChecksum())
+
+	decoded.UnsafeL2.Number = 11
+	require.ErrorContains(t, decoded.VerifyChecksum(), "sequencer state checksum mismatch")
+
+	unsigned := *state
+	unsigned.Checksum = common.Hash{}
+	require.Error(t, unsigned.VerifyChecksum())
+}
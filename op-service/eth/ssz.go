@@ -40,6 +40,10 @@ var (
 	ErrBadWithdrawalsOffset = errors.New("withdrawals offset is smaller than transaction offset, aborting")
 
 	ErrMissingData = errors.New("execution payload envelope is missing data")
+
+	// ErrVersionMismatch occurs when the fields of an ExecutionPayloadEnvelope
+	// do not match the version it is encoded as.
+	ErrVersionMismatch = errors.New("execution payload envelope does not match block version")
 )
 
 const (
@@ -63,6 +67,19 @@ const (
 	maxWithdrawalsPerPayload = 1 << 4
 )
 
+func (v BlockVersion) String() string {
+	switch v {
+	case BlockV1:
+		return "v1"
+	case BlockV2:
+		return "v2"
+	case BlockV3:
+		return "v3"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(v))
+	}
+}
+
 func (v BlockVersion) HasBlobProperties() bool {
 	return v == BlockV3
 }
@@ -197,7 +214,7 @@ func (payload *ExecutionPayload) MarshalSSZ(w io.Writer) (n int, err error) {
 		offset += 4
 	}
 
-	if payload.inferVersion() == BlockV3 {
+	if payload.inferVersion().HasBlobProperties() {
 		if payload.BlobGasUsed == nil || payload.ExcessBlobGas == nil {
 			return 0, errors.New("cannot encode ecotone payload without dencun header attributes")
 		}
@@ -324,7 +341,7 @@ func (payload *ExecutionPayload) UnmarshalSSZ(version BlockVersion, scope uint32
 		}
 	}
 
-	if version == BlockV3 {
+	if version.HasBlobProperties() {
 		blobGasUsed := binary.LittleEndian.Uint64(buf[offset : offset+8])
 		payload.BlobGasUsed = (*Uint64Quantity)(&blobGasUsed)
 		offset += 8
@@ -434,36 +451,83 @@ func unmarshalTransactions(in []byte) (txs []Data, err error) {
 	return txs, nil
 }
 
-// UnmarshalSSZ decodes the ExecutionPayloadEnvelope as SSZ type
+// BlockVersion returns the version of the SSZ encoding of the envelope: envelopes with a parent beacon block root
+// are encoded as BlockV3 envelopes, and others as bare execution payloads of the version of the payload.
+func (envelope *ExecutionPayloadEnvelope) BlockVersion() BlockVersion {
+	if envelope.ParentBeaconBlockRoot != nil {
+		return BlockV3
+	}
+	if envelope.ExecutionPayload == nil {
+		return BlockV1
+	}
+	return envelope.ExecutionPayload.inferVersion()
+}
+
+// UnmarshalSSZ decodes the ExecutionPayloadEnvelope as SSZ type, in the BlockV3 encoding.
+// See UnmarshalVersionedSSZ to decode envelopes of any version.
 func (envelope *ExecutionPayloadEnvelope) UnmarshalSSZ(scope uint32, r io.Reader) error {
+	return envelope.UnmarshalVersionedSSZ(BlockV3, scope, r)
+}
+
+// UnmarshalVersionedSSZ decodes the ExecutionPayloadEnvelope as SSZ type, in the encoding of the given version.
+// Versions with a parent beacon block root encode it in front of the execution payload,
+// older versions encode just the execution payload.
+func (envelope *ExecutionPayloadEnvelope) UnmarshalVersionedSSZ(version BlockVersion, scope uint32, r io.Reader) error {
+	if !version.HasParentBeaconBlockRoot() {
+		var payload ExecutionPayload
+		if err := payload.UnmarshalSSZ(version, scope, r); err != nil {
+			return err
+		}
+		envelope.ParentBeaconBlockRoot = nil
+		envelope.ExecutionPayload = &payload
+		return nil
+	}
+
 	if scope < common.HashLength {
 		return fmt.Errorf("scope too small to decode execution payload envelope: %d", scope)
 	}
 
-	data := make([]byte, common.HashLength)
-	n, err := r.Read(data)
-	if err != nil || n != common.HashLength {
-		return err
+	var root common.Hash
+	if _, err := io.ReadFull(r, root[:]); err != nil {
+		return fmt.Errorf("failed to read parent beacon block root: %w", err)
 	}
 
-	envelope.ParentBeaconBlockRoot = &common.Hash{}
-	copy(envelope.ParentBeaconBlockRoot[:], data)
-
 	var payload ExecutionPayload
-	err = payload.UnmarshalSSZ(BlockV3, scope-32, r)
-	if err != nil {
+	if err := payload.UnmarshalSSZ(version, scope-common.HashLength, r); err != nil {
 		return err
 	}
 
+	envelope.ParentBeaconBlockRoot = &root
 	envelope.ExecutionPayload = &payload
 	return nil
 }
 
-// MarshalSSZ encodes the ExecutionPayload as SSZ type
+// MarshalSSZ encodes the ExecutionPayloadEnvelope as SSZ type, in the BlockV3 encoding.
+// See MarshalVersionedSSZ to encode envelopes of any version.
 func (envelope *ExecutionPayloadEnvelope) MarshalSSZ(w io.Writer) (n int, err error) {
-	if envelope.ExecutionPayload == nil || envelope.ParentBeaconBlockRoot == nil {
+	return envelope.MarshalVersionedSSZ(BlockV3, w)
+}
+
+// MarshalVersionedSSZ encodes the ExecutionPayloadEnvelope as SSZ type, in the encoding of the given version.
+// The envelope must have exactly the fields of the version, so it can be decoded with UnmarshalVersionedSSZ.
+func (envelope *ExecutionPayloadEnvelope) MarshalVersionedSSZ(version BlockVersion, w io.Writer) (n int, err error) {
+	if envelope.ExecutionPayload == nil {
+		return 0, ErrMissingData
+	}
+	if version.HasParentBeaconBlockRoot() && envelope.ParentBeaconBlockRoot == nil {
 		return 0, ErrMissingData
 	}
+	if !version.HasParentBeaconBlockRoot() && envelope.ParentBeaconBlockRoot != nil {
+		return 0, fmt.Errorf("%w: parent beacon block root is not supported by %s", ErrVersionMismatch, version)
+	}
+	if payloadVersion := envelope.ExecutionPayload.inferVersion(); payloadVersion != version {
+		return 0, fmt.Errorf("%w: execution payload has fields of version %s, but encoding as %s",
+			ErrVersionMismatch, payloadVersion, version)
+	}
+
+	if !version.HasParentBeaconBlockRoot() {
+		return envelope.ExecutionPayload.MarshalSSZ(w)
+	}
 
 	// write parent beacon block root
 	hashSize, err := w.Write(envelope.ParentBeaconBlockRoot[:])
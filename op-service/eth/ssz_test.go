@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"testing"
 
@@ -240,6 +241,89 @@ func FuzzExecutionPayloadMarshalUnmarshalV3(f *testing.F) {
 	})
 }
 
+// FuzzExecutionPayloadEnvelopeUnmarshal checks that our SSZ decoding of envelopes never panics, for any version
+func FuzzExecutionPayloadEnvelopeUnmarshal(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, version := range []BlockVersion{BlockV1, BlockV2, BlockV3} {
+			var envelope ExecutionPayloadEnvelope
+			// not every input is a valid ExecutionPayloadEnvelope, that's ok. Should just not panic.
+			_ = envelope.UnmarshalVersionedSSZ(version, uint32(len(data)), bytes.NewReader(data))
+		}
+	})
+}
+
+// FuzzExecutionPayloadEnvelopeMarshalUnmarshal checks that our SSZ encoding>decoding of envelopes round trips properly,
+// for every version
+func FuzzExecutionPayloadEnvelopeMarshalUnmarshal(f *testing.F) {
+	f.Fuzz(func(t *testing.T, v uint8, data []byte, a, b uint64, extraData []byte, txs uint16, txsData []byte, wCount uint16) {
+		if len(data) < 32+20+32+32+256+32+32+32 {
+			return
+		}
+		version := BlockVersion(v % 3)
+		var payload ExecutionPayload
+		payload.ParentHash = *(*common.Hash)(data[:32])
+		payload.FeeRecipient = *(*common.Address)(data[32:52])
+		payload.StateRoot = *(*Bytes32)(data[52:84])
+		payload.ReceiptsRoot = *(*Bytes32)(data[84:116])
+		payload.LogsBloom = *(*Bytes256)(data[116:372])
+		payload.PrevRandao = *(*Bytes32)(data[372:404])
+		payload.BlockNumber = Uint64Quantity(a)
+		payload.GasLimit = Uint64Quantity(b)
+		payload.GasUsed = Uint64Quantity(a)
+		payload.Timestamp = Uint64Quantity(b)
+		if len(extraData) > 32 {
+			extraData = extraData[:32]
+		}
+		payload.ExtraData = extraData
+		(*uint256.Int)(&payload.BaseFeePerGas).SetBytes(data[404:436])
+		payload.BlockHash = *(*common.Hash)(data[436:468])
+		payload.Transactions = make([]Data, txs%1000)
+		for i := range payload.Transactions {
+			if len(txsData) < 2 {
+				payload.Transactions[i] = make(Data, 0)
+				continue
+			}
+			txSize := binary.LittleEndian.Uint16(txsData[:2])
+			txsData = txsData[2:]
+			if int(txSize) > len(txsData) {
+				txSize = uint16(len(txsData))
+			}
+			payload.Transactions[i] = txsData[:txSize]
+			txsData = txsData[txSize:]
+		}
+		envelope := ExecutionPayloadEnvelope{ExecutionPayload: &payload}
+		if version.HasWithdrawals() {
+			withdrawals := make(types.Withdrawals, wCount%maxWithdrawalsPerPayload)
+			for i := range withdrawals {
+				withdrawals[i] = &types.Withdrawal{Index: a, Validator: b, Address: payload.FeeRecipient, Amount: a}
+			}
+			payload.Withdrawals = &withdrawals
+		}
+		if version.HasBlobProperties() {
+			payload.BlobGasUsed = (*Uint64Quantity)(&a)
+			payload.ExcessBlobGas = (*Uint64Quantity)(&b)
+		}
+		if version.HasParentBeaconBlockRoot() {
+			root := common.Hash(payload.StateRoot)
+			envelope.ParentBeaconBlockRoot = &root
+		}
+		require.Equal(t, version, envelope.BlockVersion())
+
+		var buf bytes.Buffer
+		if _, err := envelope.MarshalVersionedSSZ(version, &buf); err != nil {
+			t.Fatalf("failed to marshal ExecutionPayloadEnvelope: %v", err)
+		}
+		var roundTripped ExecutionPayloadEnvelope
+		err := roundTripped.UnmarshalVersionedSSZ(version, uint32(buf.Len()), bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("failed to decode previously marshalled envelope: %v", err)
+		}
+		if diff := cmp.Diff(envelope, roundTripped); diff != "" {
+			t.Fatalf("The data did not round trip correctly:\n%s", diff)
+		}
+	})
+}
+
 func FuzzOBP01(f *testing.F) {
 	payload := &ExecutionPayload{
 		ExtraData: make([]byte, 32),
@@ -481,3 +565,55 @@ func TestFailsToDeserializeTooLittleData(t *testing.T) {
 	err := payload.UnmarshalSSZ(1, bytes.NewReader([]byte{0x00}))
 	assert.Equal(t, err, errors.New("scope too small to decode execution payload envelope: 1"))
 }
+
+func TestExecutionPayloadEnvelopeVersions(t *testing.T) {
+	hash := common.HexToHash("0x123")
+	zero := uint64(0)
+	v1 := &ExecutionPayload{ExtraData: BytesMax32{}, Transactions: []Data{}}
+	v2 := createPayloadWithWithdrawals(&types.Withdrawals{})
+	v3 := createPayloadWithWithdrawals(&types.Withdrawals{})
+	v3.ExcessBlobGas = (*Uint64Quantity)(&zero)
+	v3.BlobGasUsed = (*Uint64Quantity)(&zero)
+
+	tests := []struct {
+		name     string
+		version  BlockVersion
+		envelope *ExecutionPayloadEnvelope
+		err      error
+	}{
+		{"V1", BlockV1, &ExecutionPayloadEnvelope{ExecutionPayload: v1}, nil},
+		{"V2", BlockV2, &ExecutionPayloadEnvelope{ExecutionPayload: v2}, nil},
+		{"V3", BlockV3, &ExecutionPayloadEnvelope{ExecutionPayload: v3, ParentBeaconBlockRoot: &hash}, nil},
+		{"V2WithRoot", BlockV2, &ExecutionPayloadEnvelope{ExecutionPayload: v2, ParentBeaconBlockRoot: &hash}, ErrVersionMismatch},
+		{"V2PayloadAsV1", BlockV1, &ExecutionPayloadEnvelope{ExecutionPayload: v2}, ErrVersionMismatch},
+		{"V2PayloadAsV3", BlockV3, &ExecutionPayloadEnvelope{ExecutionPayload: v2, ParentBeaconBlockRoot: &hash}, ErrVersionMismatch},
+		{"V3MissingRoot", BlockV3, &ExecutionPayloadEnvelope{ExecutionPayload: v3}, ErrMissingData},
+		{"MissingPayload", BlockV1, &ExecutionPayloadEnvelope{}, ErrMissingData},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			_, err := test.envelope.MarshalVersionedSSZ(test.version, &buf)
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.version, test.envelope.BlockVersion())
+
+			var output ExecutionPayloadEnvelope
+			require.NoError(t, output.UnmarshalVersionedSSZ(test.version, uint32(buf.Len()), bytes.NewReader(buf.Bytes())))
+			require.Equal(t, test.envelope.ParentBeaconBlockRoot, output.ParentBeaconBlockRoot)
+			if diff := cmp.Diff(*test.envelope.ExecutionPayload, *output.ExecutionPayload); diff != "" {
+				t.Fatalf("The data did not round trip correctly:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFailsToDeserializeTruncatedParentBeaconBlockRoot(t *testing.T) {
+	var envelope ExecutionPayloadEnvelope
+	err := envelope.UnmarshalVersionedSSZ(BlockV3, blockV3FixedPart+32, bytes.NewReader(make([]byte, 16)))
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
@@ -31,4 +31,8 @@ type SyncStatus struct {
 	FinalizedL2 L2BlockRef `json:"finalized_l2"`
 	// PendingSafeL2 points to the L2 block processed from the batch, but not consolidated to the safe block yet.
 	PendingSafeL2 L2BlockRef `json:"pending_safe_l2"`
+	// ClaimedSafeL2 points to the latest L2 block that was attested to be safe by the safe-head attester over p2p.
+	// This is a claim that is not verified by the node, and only serves to monitor the derivation lag of the node.
+	// This is zeroed if safe-head attestations are disabled, or none were received yet.
+	ClaimedSafeL2 L2BlockRef `json:"claimed_safe_l2"`
 }
@@ -0,0 +1,22 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TxPoolPolicy holds hints of the sequencer to the execution engine, on which transactions of the tx pool
+// to include in a locally built block, e.g. to limit DA usage or to exclude addresses during incidents.
+// The hints are only set in the payload attributes of blocks built by the sequencer, never in derived
+// payload attributes, so they never affect derivation.
+type TxPoolPolicy struct {
+	// MaxDABytes limits the total estimated DA size of the tx pool transactions of the block. Unlimited if nil.
+	MaxDABytes *hexutil.Uint64 `json:"maxDABytes,omitempty"`
+	// ExcludedAddresses are the addresses of which the tx pool transactions, sent from or to them, are not included.
+	ExcludedAddresses []common.Address `json:"excludedAddresses,omitempty"`
+}
+
+// IsEmpty returns true if the policy does not restrict the tx pool transactions.
+func (p *TxPoolPolicy) IsEmpty() bool {
+	return p == nil || (p.MaxDABytes == nil && len(p.ExcludedAddresses) == 0)
+}
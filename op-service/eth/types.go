@@ -24,6 +24,7 @@ const (
 	UnknownPayload           ErrorCode = -32001 // Payload does not exist / is not available.
 	InvalidForkchoiceState   ErrorCode = -38002 // Forkchoice state is invalid / inconsistent.
 	InvalidPayloadAttributes ErrorCode = -38003 // Payload attributes are invalid / inconsistent.
+	InvalidExecutingMessage  ErrorCode = -32060 // Executing message does not match an initiating message.
 )
 
 var ErrBedrockScalarPaddingNotEmpty = errors.New("version 0 scalar value has non-empty padding")
@@ -213,14 +214,16 @@ func (payload *ExecutionPayload) CanyonBlock() bool {
 	return payload.Withdrawals != nil
 }
 
-// CheckBlockHash recomputes the block hash and returns if the embedded block hash matches.
-func (envelope *ExecutionPayloadEnvelope) CheckBlockHash() (actual common.Hash, ok bool) {
+// BlockHeader returns the block header of the payload. The fork-specific header fields are set
+// if the payload has them: the withdrawals root (Canyon), the blob gas fields (Ecotone),
+// and the parent beacon block root of the envelope (Ecotone).
+func (envelope *ExecutionPayloadEnvelope) BlockHeader() *types.Header {
 	payload := envelope.ExecutionPayload
 
 	hasher := trie.NewStackTrie(nil)
 	txHash := types.DeriveSha(rawTransactions(payload.Transactions), hasher)
 
-	header := types.Header{
+	header := &types.Header{
 		ParentHash:       payload.ParentHash,
 		UncleHash:        types.EmptyUncleHash,
 		Coinbase:         payload.FeeRecipient,
@@ -237,6 +240,8 @@ func (envelope *ExecutionPayloadEnvelope) CheckBlockHash() (actual common.Hash,
 		MixDigest:        common.Hash(payload.PrevRandao),
 		Nonce:            types.BlockNonce{}, // zeroed, proof-of-work legacy
 		BaseFee:          (*uint256.Int)(&payload.BaseFeePerGas).ToBig(),
+		BlobGasUsed:      (*uint64)(payload.BlobGasUsed),
+		ExcessBlobGas:    (*uint64)(payload.ExcessBlobGas),
 		ParentBeaconRoot: envelope.ParentBeaconBlockRoot,
 	}
 
@@ -244,9 +249,34 @@ func (envelope *ExecutionPayloadEnvelope) CheckBlockHash() (actual common.Hash,
 		withdrawalHash := types.DeriveSha(*payload.Withdrawals, hasher)
 		header.WithdrawalsHash = &withdrawalHash
 	}
+	return header
+}
 
-	blockHash := header.Hash()
-	return blockHash, blockHash == payload.BlockHash
+// CheckBlockHash recomputes the block hash and returns if the embedded block hash matches.
+func (envelope *ExecutionPayloadEnvelope) CheckBlockHash() (actual common.Hash, ok bool) {
+	blockHash := envelope.BlockHeader().Hash()
+	return blockHash, blockHash == envelope.ExecutionPayload.BlockHash
+}
+
+// VerifyBlockHash verifies that the fork-specific fields of the payload are consistent,
+// and that the embedded block hash matches the recomputed block hash.
+// Unlike CheckBlockHash, it rejects payloads with an inconsistent set of fork-specific fields,
+// which the block header encoding could otherwise not distinguish from zeroed fields.
+func (envelope *ExecutionPayloadEnvelope) VerifyBlockHash() error {
+	payload := envelope.ExecutionPayload
+	if (payload.BlobGasUsed == nil) != (payload.ExcessBlobGas == nil) {
+		return errors.New("payload must have both or none of the blob gas used and excess blob gas")
+	}
+	if (payload.BlobGasUsed == nil) != (envelope.ParentBeaconBlockRoot == nil) {
+		return errors.New("payload must have both or none of the blob gas fields and the parent beacon block root")
+	}
+	if payload.BlobGasUsed != nil && payload.Withdrawals == nil {
+		return errors.New("payload with blob gas fields must have withdrawals")
+	}
+	if actual, ok := envelope.CheckBlockHash(); !ok {
+		return fmt.Errorf("payload has bad block hash %s, computed block hash %s", payload.BlockHash, actual)
+	}
+	return nil
 }
 
 func BlockAsPayload(bl *types.Block, canyonForkTime *uint64) (*ExecutionPayload, error) {
@@ -320,6 +350,10 @@ type PayloadAttributes struct {
 	NoTxPool bool `json:"noTxPool,omitempty"`
 	// GasLimit override
 	GasLimit *Uint64Quantity `json:"gasLimit,omitempty"`
+	// TxPoolPolicy restricts the transactions of the tx-pool to build the block with.
+	// Only set by the sequencer for local block building, never by derivation, and only sent to execution engines
+	// that advertise the TxPoolPolicyCapability.
+	TxPoolPolicy *TxPoolPolicy `json:"txPoolPolicy,omitempty"`
 }
 
 type ExecutePayloadStatus string
@@ -510,4 +544,17 @@ const (
 
 	GetPayloadV2 EngineAPIMethod = "engine_getPayloadV2"
 	GetPayloadV3 EngineAPIMethod = "engine_getPayloadV3"
+
+	ExchangeCapabilities EngineAPIMethod = "engine_exchangeCapabilities"
+
+	// TxPoolPolicyCapability is not a method, but advertised in the capability exchange by execution engines that
+	// apply the TxPoolPolicy of payload attributes. Other engines ignore the policy.
+	TxPoolPolicyCapability EngineAPIMethod = "engine_txPoolPolicyV1"
 )
+
+// EngineAPIMethods are the versioned Engine API methods that may be used, depending on the EngineFork.
+var EngineAPIMethods = []EngineAPIMethod{
+	FCUV1, FCUV2, FCUV3,
+	NewPayloadV2, NewPayloadV3,
+	GetPayloadV2, GetPayloadV3,
+}
@@ -3,8 +3,12 @@ package eth
 import (
 	"errors"
 	"math"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/stretchr/testify/require"
 )
 
@@ -66,3 +70,70 @@ func FuzzEncodeScalar(f *testing.F) {
 		require.Equal(t, baseFeeScalar, scalars.BaseFeeScalar)
 	})
 }
+
+func TestExecutionPayloadBlockHash(t *testing.T) {
+	zero := uint64(0)
+	beaconRoot := common.Hash{0xbe}
+	txs := []*types.Transaction{
+		types.NewTx(&types.DynamicFeeTx{Nonce: 1, Gas: 21000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(10)}),
+		types.NewTx(&types.LegacyTx{Nonce: 2, Gas: 21000, GasPrice: big.NewInt(10)}),
+	}
+	header := func() *types.Header {
+		return &types.Header{
+			ParentHash: common.Hash{0x01},
+			Coinbase:   common.Address{0x02},
+			Root:       common.Hash{0x03},
+			Difficulty: common.Big0,
+			Number:     big.NewInt(100),
+			GasLimit:   30_000_000,
+			GasUsed:    42000,
+			Time:       1000,
+			Extra:      []byte{0x04},
+			MixDigest:  common.Hash{0x05},
+			BaseFee:    big.NewInt(7),
+		}
+	}
+	canyonTime := uint64(0)
+	ecotone := header()
+	ecotone.BlobGasUsed, ecotone.ExcessBlobGas, ecotone.ParentBeaconRoot = &zero, &zero, &beaconRoot
+	blocks := map[string]*types.Block{
+		"bedrock": types.NewBlock(header(), txs, nil, nil, trie.NewStackTrie(nil)),
+		"canyon":  types.NewBlockWithWithdrawals(header(), txs, nil, nil, []*types.Withdrawal{}, trie.NewStackTrie(nil)),
+		"ecotone": types.NewBlockWithWithdrawals(ecotone, txs, nil, nil, []*types.Withdrawal{}, trie.NewStackTrie(nil)),
+	}
+	for name, block := range blocks {
+		t.Run(name, func(t *testing.T) {
+			var forkTime *uint64
+			if block.Withdrawals() != nil {
+				forkTime = &canyonTime
+			}
+			envelope, err := BlockAsPayloadEnv(block, forkTime)
+			require.NoError(t, err)
+			require.Equal(t, block.Header(), envelope.BlockHeader())
+			actual, ok := envelope.CheckBlockHash()
+			require.True(t, ok)
+			require.Equal(t, block.Hash(), actual)
+			require.NoError(t, envelope.VerifyBlockHash())
+
+			envelope.ExecutionPayload.GasUsed++
+			require.ErrorContains(t, envelope.VerifyBlockHash(), "bad block hash")
+		})
+	}
+
+	t.Run("inconsistent fork fields", func(t *testing.T) {
+		envelope, err := BlockAsPayloadEnv(blocks["ecotone"], &canyonTime)
+		require.NoError(t, err)
+		envelope.ExecutionPayload.ExcessBlobGas = nil
+		require.ErrorContains(t, envelope.VerifyBlockHash(), "excess blob gas")
+
+		// a missing parent beacon block root would otherwise be encoded like a zero root
+		envelope, err = BlockAsPayloadEnv(blocks["ecotone"], &canyonTime)
+		require.NoError(t, err)
+		envelope.ParentBeaconBlockRoot = nil
+		require.ErrorContains(t, envelope.VerifyBlockHash(), "parent beacon block root")
+
+		envelope, err = BlockAsPayloadEnv(blocks["ecotone"], nil)
+		require.NoError(t, err)
+		require.ErrorContains(t, envelope.VerifyBlockHash(), "must have withdrawals")
+	})
+}
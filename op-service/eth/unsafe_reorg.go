@@ -0,0 +1,17 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UnsafeReorgHalt is an unsafe reorg that was deeper than the maximum reorg depth,
+// which halted the node until the reorg is confirmed by an admin.
+type UnsafeReorgHalt struct {
+	// UnsafeL2 is the unsafe head at the time of the reorg.
+	UnsafeL2 L2BlockRef `json:"unsafeL2"`
+	// Parent is the block that the reorg builds on.
+	Parent BlockID `json:"parent"`
+	// Depth is the number of unsafe blocks that the reorg removes from the canonical chain.
+	Depth    hexutil.Uint64 `json:"depth"`
+	MaxDepth hexutil.Uint64 `json:"maxDepth"`
+}
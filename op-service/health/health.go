@@ -0,0 +1,210 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultProbeTimeout bounds the time a probe may take, before it is reported as failing.
+const DefaultProbeTimeout = 5 * time.Second
+
+// Status is the health status of a subsystem, or of a service as a whole.
+type Status string
+
+const (
+	// StatusOK means the subsystem is fully functional.
+	StatusOK Status = "ok"
+	// StatusDegraded means the subsystem is functional, with partial failures,
+	// e.g. a p2p node without peers. Degraded services are still ready to serve.
+	StatusDegraded Status = "degraded"
+	// StatusFailing means the subsystem is not functional.
+	StatusFailing Status = "failing"
+)
+
+// worse returns whether s is a worse status than other.
+func (s Status) worse(other Status) bool {
+	return statusRank(s) > statusRank(other)
+}
+
+func statusRank(s Status) int {
+	switch s {
+	case StatusOK:
+		return 0
+	case StatusDegraded:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Result is the result of a probe: the status of the subsystem, and the reason if it is not ok.
+type Result struct {
+	Status Status `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// OK returns the result of a fully functional subsystem.
+func OK() Result {
+	return Result{Status: StatusOK}
+}
+
+// Degraded returns the result of a subsystem that is functional with partial failures, for the formatted reason.
+func Degraded(format string, args ...any) Result {
+	return Result{Status: StatusDegraded, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Failing returns the result of a subsystem that is not functional, for the formatted reason.
+func Failing(format string, args ...any) Result {
+	return Result{Status: StatusFailing, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Probe checks the health of a subsystem.
+// Probes are run when the health of the service is requested, and should be cheap.
+type Probe func(ctx context.Context) Result
+
+// ErrorProbe returns a probe that is failing with the error of the check, if any, and ok otherwise.
+func ErrorProbe(check func(ctx context.Context) error) Probe {
+	return func(ctx context.Context) Result {
+		if err := check(ctx); err != nil {
+			return Failing("%v", err)
+		}
+		return OK()
+	}
+}
+
+type probe struct {
+	name     string
+	probe    Probe
+	liveness bool
+}
+
+// Checks are the health probes of the subsystems of a service,
+// which are reported by the liveness (/healthz) and readiness (/readyz) endpoints of the service.
+type Checks struct {
+	version string
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	probes []probe
+}
+
+// NewChecks returns the health checks of a service with the given version, without any probes yet.
+func NewChecks(version string) *Checks {
+	return &Checks{version: version, timeout: DefaultProbeTimeout}
+}
+
+// Register registers the readiness probe of a subsystem.
+// The service is not ready to serve while the probe is failing, but it does not need to be restarted,
+// e.g. while a connection to a dependency is down.
+func (c *Checks) Register(name string, p Probe) {
+	c.register(name, p, false)
+}
+
+// RegisterLiveness registers the liveness probe of a subsystem, which also counts towards the readiness.
+// The service needs to be restarted when the probe is failing, e.g. when a critical routine stopped.
+func (c *Checks) RegisterLiveness(name string, p Probe) {
+	c.register(name, p, true)
+}
+
+func (c *Checks) register(name string, p Probe, liveness bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.probes = append(c.probes, probe{name: name, probe: p, liveness: liveness})
+}
+
+// Report is the health of a service, as served by the health endpoints.
+type Report struct {
+	Version string            `json:"version"`
+	Status  Status            `json:"status"`
+	Checks  map[string]Result `json:"checks,omitempty"`
+}
+
+// Liveness runs the liveness probes concurrently, and reports their results.
+func (c *Checks) Liveness(ctx context.Context) Report {
+	return c.run(ctx, true)
+}
+
+// Readiness runs all probes concurrently, and reports their results.
+func (c *Checks) Readiness(ctx context.Context) Report {
+	return c.run(ctx, false)
+}
+
+func (c *Checks) run(ctx context.Context, livenessOnly bool) Report {
+	c.mu.RLock()
+	var probes []probe
+	for _, p := range c.probes {
+		if p.liveness || !livenessOnly {
+			probes = append(probes, p)
+		}
+	}
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	results := make([]Result, len(probes))
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func(i int, p probe) {
+			defer wg.Done()
+			results[i] = runProbe(ctx, p.probe)
+		}(i, p)
+	}
+	wg.Wait()
+
+	report := Report{Version: c.version, Status: StatusOK}
+	if len(probes) > 0 {
+		report.Checks = make(map[string]Result, len(probes))
+	}
+	for i, p := range probes {
+		res := results[i]
+		if res.Status.worse(report.Status) {
+			report.Status = res.Status
+		}
+		report.Checks[p.name] = res
+	}
+	return report
+}
+
+// runProbe runs the probe, and reports it as failing if it does not complete before the context is done.
+func runProbe(ctx context.Context, p Probe) Result {
+	resCh := make(chan Result, 1)
+	go func() {
+		resCh <- p(ctx)
+	}()
+	select {
+	case res := <-resCh:
+		if res.Status != StatusOK && res.Status != StatusDegraded {
+			res.Status = StatusFailing
+		}
+		return res
+	case <-ctx.Done():
+		return Failing("probe timed out: %v", ctx.Err())
+	}
+}
+
+// LivenessHandler serves the liveness report, with status 503 if any of the liveness probes is failing.
+func (c *Checks) LivenessHandler() http.Handler {
+	return reportHandler(c.Liveness)
+}
+
+// ReadinessHandler serves the readiness report, with status 503 if any of the probes is failing.
+// Degraded subsystems are reported, but don't make the service unready.
+func (c *Checks) ReadinessHandler() http.Handler {
+	return reportHandler(c.Readiness)
+}
+
+func reportHandler(report func(ctx context.Context) Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rep := report(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		if rep.Status == StatusFailing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(&rep)
+	})
+}
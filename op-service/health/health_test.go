@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func serve(t *testing.T, h http.Handler) (int, Report) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	var rep Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rep))
+	return rec.Code, rep
+}
+
+func TestChecks(t *testing.T) {
+	t.Run("no probes", func(t *testing.T) {
+		c := NewChecks("v1")
+		code, rep := serve(t, c.ReadinessHandler())
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, Report{Version: "v1", Status: StatusOK}, rep)
+	})
+
+	t.Run("degraded is ready", func(t *testing.T) {
+		c := NewChecks("v1")
+		c.Register("engine", func(context.Context) Result { return OK() })
+		c.Register("p2p", func(context.Context) Result { return Degraded("%d peers", 0) })
+		code, rep := serve(t, c.ReadinessHandler())
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, StatusDegraded, rep.Status)
+		require.Equal(t, OK(), rep.Checks["engine"])
+		require.Equal(t, Result{Status: StatusDegraded, Reason: "0 peers"}, rep.Checks["p2p"])
+	})
+
+	t.Run("failing is not ready", func(t *testing.T) {
+		c := NewChecks("v1")
+		c.Register("p2p", func(context.Context) Result { return Degraded("no peers") })
+		c.Register("l1", ErrorProbe(func(context.Context) error { return errors.New("connection refused") }))
+		code, rep := serve(t, c.ReadinessHandler())
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.Equal(t, StatusFailing, rep.Status)
+		require.Equal(t, Result{Status: StatusFailing, Reason: "connection refused"}, rep.Checks["l1"])
+
+		// readiness probes don't affect the liveness
+		code, rep = serve(t, c.LivenessHandler())
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, Report{Version: "v1", Status: StatusOK}, rep)
+	})
+
+	t.Run("liveness", func(t *testing.T) {
+		c := NewChecks("v1")
+		c.Register("l1", func(context.Context) Result { return OK() })
+		c.RegisterLiveness("driver", func(context.Context) Result { return Failing("stopped") })
+		code, rep := serve(t, c.LivenessHandler())
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.Equal(t, map[string]Result{"driver": Failing("stopped")}, rep.Checks)
+
+		// liveness probes count towards the readiness
+		code, rep = serve(t, c.ReadinessHandler())
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.Len(t, rep.Checks, 2)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		c := NewChecks("v1")
+		c.timeout = 10 * time.Millisecond
+		block := make(chan struct{})
+		defer close(block)
+		c.Register("stuck", func(context.Context) Result {
+			<-block
+			return OK()
+		})
+		rep := c.Readiness(context.Background())
+		require.Equal(t, StatusFailing, rep.Status)
+		require.Contains(t, rep.Checks["stuck"].Reason, "timed out")
+	})
+
+	t.Run("unknown status is failing", func(t *testing.T) {
+		c := NewChecks("v1")
+		c.Register("odd", func(context.Context) Result { return Result{} })
+		rep := c.Readiness(context.Background())
+		require.Equal(t, StatusFailing, rep.Status)
+	})
+}
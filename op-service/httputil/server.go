@@ -2,6 +2,7 @@ package httputil
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -45,7 +46,7 @@ func StartHTTPServer(addr string, handler http.Handler, opts ...HTTPOption) (*HT
 		}
 	}
 	go func() {
-		err := out.srv.Serve(listener)
+		err := out.srv.Serve(out.listener)
 		srvCancel()
 		// no error, unless ErrServerClosed (or unused base context closes, or unused http2 config error)
 		if errors.Is(err, http.ErrServerClosed) {
@@ -98,3 +99,12 @@ func WithMaxHeaderBytes(max int) HTTPOption {
 		return nil
 	}
 }
+
+// WithTLSConfig serves TLS with the given config.
+func WithTLSConfig(cfg *tls.Config) HTTPOption {
+	return func(srv *HTTPServer) error {
+		srv.srv.TLSConfig = cfg
+		srv.listener = tls.NewListener(srv.listener, cfg)
+		return nil
+	}
+}
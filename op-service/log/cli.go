@@ -21,6 +21,10 @@ const (
 	FormatFlagName = "log.format"
 	ColorFlagName  = "log.color"
 	PidFlagName    = "log.pid"
+
+	RateLimitWindowFlagName = "log.ratelimit.window"
+	RateLimitBurstFlagName  = "log.ratelimit.burst"
+	RateLimitSampleFlagName = "log.ratelimit.sample"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -58,6 +62,25 @@ func CLIFlagsWithCategory(envPrefix string, category string) []cli.Flag {
 			EnvVars:  opservice.PrefixEnvVar(envPrefix, "LOG_PID"),
 			Category: category,
 		},
+		&cli.DurationFlag{
+			Name:     RateLimitWindowFlagName,
+			Usage:    "Rate-limit repetitive log lines with the same level and message over this window. Errors are never rate-limited. Disabled if 0.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "LOG_RATELIMIT_WINDOW"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     RateLimitBurstFlagName,
+			Usage:    "Number of repetitive log lines passed through per rate-limit window",
+			Value:    1,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "LOG_RATELIMIT_BURST"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     RateLimitSampleFlagName,
+			Usage:    "Pass through every n-th rate-limited log line, as a sample. Disabled if 0.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "LOG_RATELIMIT_SAMPLE"),
+			Category: category,
+		},
 	}
 }
 
@@ -195,6 +218,8 @@ type CLIConfig struct {
 	Color  bool
 	Format FormatType
 	Pid    bool
+	// RateLimit rate-limits repetitive log records, if enabled.
+	RateLimit RateLimitConfig
 }
 
 // AppOut returns an io.Writer to write app output to, like logs.
@@ -209,6 +234,9 @@ func AppOut(ctx *cli.Context) io.Writer {
 // NewLogHandler creates a new configured handler, compatible as LvlSetter for log-level changes during runtime.
 func NewLogHandler(wr io.Writer, cfg CLIConfig) slog.Handler {
 	handler := FormatHandler(cfg.Format, cfg.Color)(wr)
+	if cfg.RateLimit.Enabled() {
+		handler = NewRateLimitingHandler(cfg.RateLimit, handler)
+	}
 	return NewDynamicLogHandler(cfg.Level, handler)
 }
 
@@ -239,6 +267,8 @@ func DefaultCLIConfig() CLIConfig {
 		Level:  log.LevelInfo,
 		Format: FormatText,
 		Color:  term.IsTerminal(int(os.Stdout.Fd())),
+		// matches the default of the rate-limit burst flag
+		RateLimit: RateLimitConfig{Burst: 1},
 	}
 }
 
@@ -250,5 +280,10 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		cfg.Color = ctx.Bool(ColorFlagName)
 	}
 	cfg.Pid = ctx.Bool(PidFlagName)
+	cfg.RateLimit = RateLimitConfig{
+		Window:      ctx.Duration(RateLimitWindowFlagName),
+		Burst:       ctx.Int(RateLimitBurstFlagName),
+		SampleEvery: ctx.Int(RateLimitSampleFlagName),
+	}
 	return cfg
 }
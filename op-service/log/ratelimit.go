@@ -0,0 +1,195 @@
+package log
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// SuppressedAttrKey is the attribute of the first record that is passed through after similar records
+// were suppressed, with the number of suppressed records.
+const SuppressedAttrKey = "suppressed"
+
+// maxRateLimitKeys bounds the number of distinct messages that are tracked at once.
+// Messages that were not logged during the last window are pruned once the limit is reached.
+const maxRateLimitKeys = 1024
+
+// RateLimitConfig configures the rate-limiting of repetitive log records.
+type RateLimitConfig struct {
+	// Window is the period over which records with the same level and message are rate-limited.
+	// Rate-limiting is disabled if 0.
+	Window time.Duration
+	// Burst is the number of records with the same level and message that are passed through per window.
+	Burst int
+	// SampleEvery passes through every n-th record of those that exceed the burst, to sample them.
+	// No records are sampled if 0.
+	SampleEvery int
+}
+
+// Enabled returns whether rate-limiting is enabled.
+func (c RateLimitConfig) Enabled() bool {
+	return c.Window > 0
+}
+
+type rateLimitEntry struct {
+	windowStart time.Time
+	count       int // records in the current window
+	suppressed  int // records suppressed since the last passed record
+}
+
+// rateLimitState is shared by a rate-limiting handler and the handlers derived from it.
+type rateLimitState struct {
+	cfg RateLimitConfig
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[rateLimitKey]*rateLimitEntry
+
+	suppressed atomic.Uint64
+	// lastLoggerID is the ID of the last derived handler, see RateLimitingHandler.id
+	lastLoggerID atomic.Uint64
+}
+
+type rateLimitKey struct {
+	logger uint64
+	level  slog.Level
+	msg    string
+}
+
+var (
+	suppressedMu     sync.Mutex
+	suppressedTotals = make(map[slog.Level]uint64)
+)
+
+// SuppressedRecords returns the number of records suppressed by all rate-limiting handlers, by level.
+func SuppressedRecords() map[slog.Level]uint64 {
+	suppressedMu.Lock()
+	defer suppressedMu.Unlock()
+	totals := make(map[slog.Level]uint64, len(suppressedTotals))
+	for lvl, n := range suppressedTotals {
+		totals[lvl] = n
+	}
+	return totals
+}
+
+func recordSuppressed(lvl slog.Level) {
+	suppressedMu.Lock()
+	defer suppressedMu.Unlock()
+	suppressedTotals[lvl]++
+}
+
+// RateLimitingHandler rate-limits repetitive log records, like a warning that is logged every block during an outage.
+// Records are rate-limited by logger, level and message, regardless of their attributes:
+// the first records per window are passed through, and the others are suppressed, except for a sample of them.
+// Errors and more critical records are always passed through.
+// The first record that is passed through after records were suppressed has the number of suppressed records attached,
+// see SuppressedAttrKey.
+// Loggers derived with attributes or groups are rate-limited separately, so the records of one component
+// don't suppress the same message of another. Suppressed records are counted, see SuppressedRecords.
+type RateLimitingHandler struct {
+	h     slog.Handler
+	state *rateLimitState // shared with derived handlers
+	// id identifies the logger of the handler in the rate-limit keys, it is unique among the derived handlers.
+	id uint64
+}
+
+// NewRateLimitingHandler returns a handler that rate-limits the records passed to h.
+// The burst is at least 1, so the first occurrence of a record is always passed through.
+func NewRateLimitingHandler(cfg RateLimitConfig, h slog.Handler) *RateLimitingHandler {
+	if cfg.Burst < 1 {
+		cfg.Burst = 1
+	}
+	return &RateLimitingHandler{
+		h: h,
+		state: &rateLimitState{
+			cfg:     cfg,
+			now:     time.Now,
+			entries: make(map[rateLimitKey]*rateLimitEntry),
+		},
+	}
+}
+
+// Suppressed returns the total number of suppressed records.
+func (r *RateLimitingHandler) Suppressed() uint64 {
+	return r.state.suppressed.Load()
+}
+
+func (r *RateLimitingHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return r.h.Enabled(ctx, lvl)
+}
+
+func (r *RateLimitingHandler) Handle(ctx context.Context, rec slog.Record) error {
+	if rec.Level >= log.LevelError {
+		return r.h.Handle(ctx, rec)
+	}
+	pass, suppressed := r.state.allow(rateLimitKey{logger: r.id, level: rec.Level, msg: rec.Message})
+	if !pass {
+		r.state.suppressed.Add(1)
+		recordSuppressed(rec.Level)
+		return nil
+	}
+	if suppressed > 0 {
+		rec = rec.Clone()
+		rec.AddAttrs(slog.Int(SuppressedAttrKey, suppressed))
+	}
+	return r.h.Handle(ctx, rec)
+}
+
+// allow returns whether a record with the given key passes through,
+// and if so, the number of records with the same key that were suppressed before it.
+func (s *rateLimitState) allow(key rateLimitKey) (bool, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	e, ok := s.entries[key]
+	if !ok {
+		if len(s.entries) >= maxRateLimitKeys {
+			s.prune(now)
+		}
+		e = &rateLimitEntry{windowStart: now}
+		s.entries[key] = e
+	} else if now.Sub(e.windowStart) >= s.cfg.Window {
+		e.windowStart = now
+		e.count = 0
+	}
+	e.count++
+	over := e.count - s.cfg.Burst
+	if over > 0 && (s.cfg.SampleEvery <= 0 || over%s.cfg.SampleEvery != 0) {
+		e.suppressed++
+		return false, 0
+	}
+	suppressed := e.suppressed
+	e.suppressed = 0
+	return true, suppressed
+}
+
+// prune removes the entries of the messages that were not logged during the last window.
+// Suppressed records of pruned entries are only counted in the total.
+func (s *rateLimitState) prune(now time.Time) {
+	for k, e := range s.entries {
+		if now.Sub(e.windowStart) >= s.cfg.Window {
+			delete(s.entries, k)
+		}
+	}
+}
+
+func (r *RateLimitingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &RateLimitingHandler{
+		h:     r.h.WithAttrs(attrs),
+		state: r.state,
+		id:    r.state.lastLoggerID.Add(1),
+	}
+}
+
+func (r *RateLimitingHandler) WithGroup(name string) slog.Handler {
+	return &RateLimitingHandler{
+		h:     r.h.WithGroup(name),
+		state: r.state,
+		id:    r.state.lastLoggerID.Add(1),
+	}
+}
@@ -0,0 +1,110 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func newTestRateLimitingHandler(cfg RateLimitConfig) (*RateLimitingHandler, *testRecorder, *time.Time) {
+	rec := new(testRecorder)
+	h := NewRateLimitingHandler(cfg, rec)
+	now := time.Unix(1000, 0)
+	h.state.now = func() time.Time { return now }
+	return h, rec, &now
+}
+
+func suppressedAttr(r slog.Record) (n int64, ok bool) {
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == SuppressedAttrKey {
+			n, ok = a.Value.Int64(), true
+			return false
+		}
+		return true
+	})
+	return n, ok
+}
+
+func TestRateLimitingHandler(t *testing.T) {
+	h, rec, now := newTestRateLimitingHandler(RateLimitConfig{Window: time.Minute, Burst: 2})
+	logger := log.NewLogger(h)
+	for i := 0; i < 5; i++ {
+		logger.Warn("failed to get payload from builder", "block", i)
+	}
+	logger.Warn("other warning")
+	require.Len(t, rec.records, 3)
+	require.Equal(t, "other warning", rec.records[2].Message)
+	require.Equal(t, uint64(3), h.Suppressed())
+
+	// the same message at another level is rate-limited separately
+	logger.Info("failed to get payload from builder")
+	require.Len(t, rec.records, 4)
+
+	// after the window, records are passed through again, with the number of suppressed records
+	*now = now.Add(time.Minute)
+	logger.Warn("failed to get payload from builder", "block", 5)
+	require.Len(t, rec.records, 5)
+	n, ok := suppressedAttr(rec.records[4])
+	require.True(t, ok)
+	require.Equal(t, int64(3), n)
+	logger.Warn("failed to get payload from builder", "block", 6)
+	require.Len(t, rec.records, 6)
+	_, ok = suppressedAttr(rec.records[5])
+	require.False(t, ok)
+}
+
+func TestRateLimitingHandler_Errors(t *testing.T) {
+	h, rec, _ := newTestRateLimitingHandler(RateLimitConfig{Window: time.Minute})
+	logger := log.NewLogger(h)
+	for i := 0; i < 3; i++ {
+		logger.Error("engine unavailable")
+		logger.Log(log.LevelError+1, "engine gone")
+	}
+	require.Len(t, rec.records, 6)
+	require.Zero(t, h.Suppressed())
+}
+
+func TestRateLimitingHandler_Sample(t *testing.T) {
+	h, rec, _ := newTestRateLimitingHandler(RateLimitConfig{Window: time.Minute, Burst: 1, SampleEvery: 3})
+	logger := log.NewLogger(h)
+	for i := 0; i < 8; i++ {
+		logger.Warn("repetitive")
+	}
+	// the first record, and every 3rd record after it
+	require.Len(t, rec.records, 3)
+	n, _ := suppressedAttr(rec.records[1])
+	require.Equal(t, int64(2), n)
+	require.Equal(t, uint64(5), h.Suppressed())
+}
+
+func TestRateLimitingHandler_WithAttrs(t *testing.T) {
+	h, rec, _ := newTestRateLimitingHandler(RateLimitConfig{Window: time.Minute})
+	logger := log.NewLogger(h)
+	before := SuppressedRecords()[log.LevelWarn]
+	// derived loggers are rate-limited separately
+	derived := logger.New("b", 2)
+	for i := 0; i < 3; i++ {
+		logger.Warn("repetitive")
+		derived.Warn("repetitive")
+	}
+	logger.With("a", 1).Warn("repetitive")
+	require.Len(t, rec.records, 3)
+	require.Equal(t, uint64(4), h.Suppressed())
+	require.Equal(t, before+4, SuppressedRecords()[log.LevelWarn])
+}
+
+func TestRateLimitingHandler_Prune(t *testing.T) {
+	h, _, now := newTestRateLimitingHandler(RateLimitConfig{Window: time.Minute})
+	logger := log.NewLogger(h)
+	for i := 0; i < maxRateLimitKeys; i++ {
+		logger.Warn(time.Duration(i).String())
+	}
+	require.Len(t, h.state.entries, maxRateLimitKeys)
+	*now = now.Add(time.Minute)
+	logger.Warn("new")
+	require.Len(t, h.state.entries, 1)
+}
@@ -0,0 +1,46 @@
+package logwatcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+// Checkpoint is the progress of a watcher.
+type Checkpoint struct {
+	// Processed are the recently processed blocks, oldest first. The logs up to the last block were processed.
+	Processed []eth.BlockID `json:"processed"`
+}
+
+// CheckpointStore persists the checkpoint of a watcher, to resume watching after a restart.
+type CheckpointStore interface {
+	// Load returns the stored checkpoint, or nil if there is none.
+	Load() (*Checkpoint, error)
+	Store(checkpoint *Checkpoint) error
+}
+
+// FileCheckpointStore stores the checkpoint as JSON file. The file is replaced atomically.
+type FileCheckpointStore struct {
+	path string
+}
+
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (s *FileCheckpointStore) Load() (*Checkpoint, error) {
+	checkpoint, err := jsonutil.LoadJSON[Checkpoint](s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+func (s *FileCheckpointStore) Store(checkpoint *Checkpoint) error {
+	return jsonutil.WriteJSON(s.path, checkpoint, 0o644)
+}
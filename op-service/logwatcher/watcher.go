@@ -0,0 +1,321 @@
+// Package logwatcher watches L1 for the event logs of contracts.
+//
+// The watcher follows the L1 chain at a confirmation depth, fetching the logs of a bounded range of blocks per request,
+// with requests to the L1 source rate-limited. The recently processed blocks are kept as checkpoint, so the watcher
+// resumes where it stopped after a restart, and detects L1 reorgs deeper than the confirmation depth: the handler is
+// told to revert the logs of the blocks that are no longer canonical, and the logs are delivered again.
+package logwatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/time/rate"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrReorgTooDeep is returned if none of the recently processed blocks is canonical anymore.
+// The handler cannot revert the logs of the reorg, and the watcher has to be restarted from an earlier block.
+var ErrReorgTooDeep = errors.New("reorg is deeper than the processed block history")
+
+const (
+	// historySize is the number of recently processed blocks that are kept to recover from reorgs.
+	historySize = 64
+	// DefaultMaxRange is the default maximum number of blocks of which the logs are fetched with a single request.
+	DefaultMaxRange = 1000
+)
+
+// L1Source is the L1 chain that the logs are fetched from.
+type L1Source interface {
+	// HeaderByNumber returns the header of the block with the given number, or the latest block if number is nil.
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// Handler processes the watched logs.
+type Handler interface {
+	// HandleLogs is called with the logs of the blocks after the previously processed block, up to and including to.
+	// The logs are ordered by block and log index. If an error is returned, the logs are delivered again.
+	HandleLogs(ctx context.Context, logs []types.Log, to eth.BlockID) error
+	// HandleReorg is called if the blocks after to are no longer canonical.
+	// The logs of these blocks have to be reverted, the logs of the new canonical blocks are delivered next.
+	HandleReorg(ctx context.Context, to eth.BlockID) error
+}
+
+// Config configures the logs to watch, and how to fetch them.
+type Config struct {
+	// Addresses are the contracts of which the logs are watched, all contracts if empty.
+	Addresses []common.Address
+	// Topics filters the logs by topic, see [ethereum.FilterQuery].
+	Topics [][]common.Hash
+
+	// StartBlock is the first block of which the logs are watched, if there is no checkpoint.
+	StartBlock uint64
+	// Confirmations is the number of blocks on top of a block before its logs are processed.
+	Confirmations uint64
+	// MaxRange is the maximum number of blocks of which the logs are fetched with a single request.
+	// Zero means DefaultMaxRange.
+	MaxRange uint64
+
+	// PollInterval is the interval between checks for new blocks.
+	PollInterval time.Duration
+	// RateLimit limits the requests to the L1 source per second. Zero means no limit.
+	RateLimit rate.Limit
+	// RateBurst is the number of requests that may exceed the rate limit at once.
+	RateBurst int
+}
+
+func (c *Config) Check() error {
+	if c.PollInterval <= 0 {
+		return errors.New("poll interval must be positive")
+	}
+	if c.RateLimit < 0 {
+		return errors.New("rate limit must not be negative")
+	}
+	if c.RateLimit > 0 && c.RateBurst <= 0 {
+		return errors.New("rate burst must be positive if requests are rate limited")
+	}
+	return nil
+}
+
+// Watcher delivers the logs of confirmed L1 blocks to its handler.
+type Watcher struct {
+	log     log.Logger
+	cfg     Config
+	src     L1Source
+	store   CheckpointStore
+	handler Handler
+	limiter *rate.Limiter
+
+	mu sync.Mutex
+	// processed are the recently processed blocks, oldest first
+	processed []eth.BlockID
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWatcher creates a watcher of the L1 logs of the config. The checkpoint store may be nil,
+// in which case the watcher starts at the start block of the config after every restart.
+func NewWatcher(log log.Logger, cfg Config, src L1Source, store CheckpointStore, handler Handler) (*Watcher, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid log watcher config: %w", err)
+	}
+	if cfg.MaxRange == 0 {
+		cfg.MaxRange = DefaultMaxRange
+	}
+	limit, burst := cfg.RateLimit, cfg.RateBurst
+	if limit == 0 {
+		limit = rate.Inf
+	}
+	return &Watcher{
+		log:     log,
+		cfg:     cfg,
+		src:     src,
+		store:   store,
+		handler: handler,
+		limiter: rate.NewLimiter(limit, burst),
+	}, nil
+}
+
+// Start loads the checkpoint, and starts to watch the logs.
+func (w *Watcher) Start(ctx context.Context) error {
+	if w.store != nil {
+		checkpoint, err := w.store.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load checkpoint: %w", err)
+		}
+		if checkpoint != nil {
+			w.mu.Lock()
+			w.processed = checkpoint.Processed
+			w.mu.Unlock()
+		}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+	w.wg.Add(1)
+	go w.loop(ctx)
+	w.log.Info("Started log watcher", "next", w.next(), "addresses", w.cfg.Addresses)
+	return nil
+}
+
+// Stop stops watching the logs, and waits for the handler to return.
+func (w *Watcher) Stop() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	return nil
+}
+
+// Processed returns the last processed block, and false if no block was processed yet.
+func (w *Watcher) Processed() (eth.BlockID, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.processed) == 0 {
+		return eth.BlockID{}, false
+	}
+	return w.processed[len(w.processed)-1], true
+}
+
+func (w *Watcher) loop(ctx context.Context) {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		// catch up with the chain, one range of blocks at a time
+		for {
+			more, err := w.poll(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					w.log.Error("Failed to watch logs", "err", err)
+				}
+				break
+			}
+			if !more {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// next returns the number of the next block to process.
+func (w *Watcher) next() uint64 {
+	if last, ok := w.Processed(); ok {
+		return last.Number + 1
+	}
+	return w.cfg.StartBlock
+}
+
+// poll processes the logs of the next range of confirmed blocks, and returns whether more blocks are confirmed.
+func (w *Watcher) poll(ctx context.Context) (bool, error) {
+	head, err := w.header(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get L1 head: %w", err)
+	}
+	if head.Number.Uint64() < w.cfg.Confirmations {
+		return false, nil
+	}
+	confirmed := head.Number.Uint64() - w.cfg.Confirmations
+	if err := w.checkReorg(ctx); err != nil {
+		return false, err
+	}
+
+	from := w.next()
+	if from > confirmed {
+		return false, nil
+	}
+	to := min(confirmed, from+w.cfg.MaxRange-1)
+	toHeader, err := w.header(ctx, new(big.Int).SetUint64(to))
+	if err != nil {
+		return false, fmt.Errorf("failed to get L1 block %d: %w", to, err)
+	}
+	if err := w.limiter.Wait(ctx); err != nil {
+		return false, err
+	}
+	logs, err := w.src.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: w.cfg.Addresses,
+		Topics:    w.cfg.Topics,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get logs of L1 blocks %d to %d: %w", from, to, err)
+	}
+	// The range is only consistent if the last block did not change while the logs were fetched.
+	again, err := w.header(ctx, new(big.Int).SetUint64(to))
+	if err != nil {
+		return false, fmt.Errorf("failed to get L1 block %d: %w", to, err)
+	}
+	if again.Hash() != toHeader.Hash() {
+		w.log.Warn("L1 reorg while fetching logs, retrying", "block", to, "prev", toHeader.Hash(), "new", again.Hash())
+		return true, nil
+	}
+	toID := eth.BlockID{Hash: toHeader.Hash(), Number: to}
+	for _, l := range logs {
+		if l.Removed || l.BlockNumber < from || l.BlockNumber > to || (l.BlockNumber == to && l.BlockHash != toID.Hash) {
+			return false, fmt.Errorf("inconsistent log %d of tx %s in L1 block %d, expected blocks %d to %s", l.Index, l.TxHash, l.BlockNumber, from, toID)
+		}
+	}
+
+	if err := w.handler.HandleLogs(ctx, logs, toID); err != nil {
+		return false, fmt.Errorf("failed to handle logs of L1 blocks %d to %s: %w", from, toID, err)
+	}
+	w.log.Debug("Processed logs", "from", from, "to", toID, "logs", len(logs))
+	w.mu.Lock()
+	w.processed = append(w.processed, toID)
+	if len(w.processed) > historySize {
+		w.processed = w.processed[len(w.processed)-historySize:]
+	}
+	w.mu.Unlock()
+	if err := w.storeCheckpoint(); err != nil {
+		return false, err
+	}
+	return to < confirmed, nil
+}
+
+// checkReorg checks that the last processed block is still canonical. Otherwise, the handler reverts the logs
+// of the blocks after the last processed block that is still canonical.
+func (w *Watcher) checkReorg(ctx context.Context) error {
+	w.mu.Lock()
+	processed := append([]eth.BlockID(nil), w.processed...)
+	w.mu.Unlock()
+	for i := len(processed) - 1; i >= 0; i-- {
+		header, err := w.header(ctx, new(big.Int).SetUint64(processed[i].Number))
+		if err != nil {
+			return fmt.Errorf("failed to get L1 block %d: %w", processed[i].Number, err)
+		}
+		if header.Hash() != processed[i].Hash {
+			continue
+		}
+		if i == len(processed)-1 {
+			return nil
+		}
+		w.log.Warn("L1 reorg, reverting logs", "last", processed[len(processed)-1], "canonical", processed[i])
+		if err := w.handler.HandleReorg(ctx, processed[i]); err != nil {
+			return fmt.Errorf("failed to handle reorg to L1 block %s: %w", processed[i], err)
+		}
+		w.mu.Lock()
+		w.processed = processed[:i+1]
+		w.mu.Unlock()
+		return w.storeCheckpoint()
+	}
+	if len(processed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: processed blocks %s to %s", ErrReorgTooDeep, processed[0], processed[len(processed)-1])
+}
+
+func (w *Watcher) storeCheckpoint() error {
+	if w.store == nil {
+		return nil
+	}
+	w.mu.Lock()
+	checkpoint := &Checkpoint{Processed: append([]eth.BlockID(nil), w.processed...)}
+	w.mu.Unlock()
+	if err := w.store.Store(checkpoint); err != nil {
+		return fmt.Errorf("failed to store checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (w *Watcher) header(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if err := w.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return w.src.HeaderByNumber(ctx, number)
+}
@@ -0,0 +1,264 @@
+package logwatcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var (
+	watchedAddr = common.Address{0xaa}
+	otherAddr   = common.Address{0xbb}
+)
+
+// fakeL1 is an L1 chain with a log of the watched contract in every block, and a log of another contract in every other block.
+type fakeL1 struct {
+	mu      sync.Mutex
+	headers []*types.Header
+	queries []ethereum.FilterQuery
+}
+
+func newFakeL1(n uint64) *fakeL1 {
+	l1 := &fakeL1{}
+	l1.extend(n, 0)
+	return l1
+}
+
+// extend adds n blocks to the chain, fork distinguishes the blocks of reorgs.
+func (l *fakeL1) extend(n uint64, fork byte) {
+	for i := uint64(0); i < n; i++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(uint64(len(l.headers))), Extra: []byte{fork}}
+		if len(l.headers) > 0 {
+			header.ParentHash = l.headers[len(l.headers)-1].Hash()
+		}
+		l.headers = append(l.headers, header)
+	}
+}
+
+// reorg replaces the blocks from the given number with n blocks of a fork.
+func (l *fakeL1) reorg(from uint64, n uint64, fork byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.headers = l.headers[:from]
+	l.extend(n, fork)
+}
+
+func (l *fakeL1) id(num uint64) eth.BlockID {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return eth.BlockID{Hash: l.headers[num].Hash(), Number: num}
+}
+
+func (l *fakeL1) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if number == nil {
+		return l.headers[len(l.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(l.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return l.headers[number.Uint64()], nil
+}
+
+func (l *fakeL1) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.queries = append(l.queries, q)
+	var logs []types.Log
+	for n := q.FromBlock.Uint64(); n <= q.ToBlock.Uint64(); n++ {
+		hash := l.headers[n].Hash()
+		candidates := []types.Log{{Address: watchedAddr, BlockNumber: n, BlockHash: hash}}
+		if n%2 == 0 {
+			candidates = append(candidates, types.Log{Address: otherAddr, BlockNumber: n, BlockHash: hash, Index: 1})
+		}
+		for _, c := range candidates {
+			if len(q.Addresses) == 0 || c.Address == q.Addresses[0] {
+				logs = append(logs, c)
+			}
+		}
+	}
+	return logs, nil
+}
+
+// recordingHandler keeps the logs of the canonical chain, reverting the logs of reorged blocks.
+type recordingHandler struct {
+	logs    []types.Log
+	to      []eth.BlockID
+	reorgs  []eth.BlockID
+	failErr error
+}
+
+func (h *recordingHandler) HandleLogs(_ context.Context, logs []types.Log, to eth.BlockID) error {
+	if h.failErr != nil {
+		return h.failErr
+	}
+	h.logs = append(h.logs, logs...)
+	h.to = append(h.to, to)
+	return nil
+}
+
+func (h *recordingHandler) HandleReorg(_ context.Context, to eth.BlockID) error {
+	h.reorgs = append(h.reorgs, to)
+	var kept []types.Log
+	for _, l := range h.logs {
+		if l.BlockNumber <= to.Number {
+			kept = append(kept, l)
+		}
+	}
+	h.logs = kept
+	return nil
+}
+
+func (h *recordingHandler) blocks() []uint64 {
+	var out []uint64
+	for _, l := range h.logs {
+		out = append(out, l.BlockNumber)
+	}
+	return out
+}
+
+func testConfig() Config {
+	return Config{
+		Addresses:     []common.Address{watchedAddr},
+		StartBlock:    2,
+		Confirmations: 3,
+		MaxRange:      4,
+		PollInterval:  time.Hour,
+	}
+}
+
+// catchUp polls until all confirmed blocks are processed.
+func catchUp(t *testing.T, w *Watcher) {
+	for {
+		more, err := w.poll(context.Background())
+		require.NoError(t, err)
+		if !more {
+			return
+		}
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	t.Run("ConfirmedRanges", func(t *testing.T) {
+		l1 := newFakeL1(14) // head 13, confirmed up to 10
+		h := &recordingHandler{}
+		w, err := NewWatcher(testlog.Logger(t, log.LevelDebug), testConfig(), l1, nil, h)
+		require.NoError(t, err)
+
+		catchUp(t, w)
+		require.Equal(t, []uint64{2, 3, 4, 5, 6, 7, 8, 9, 10}, h.blocks())
+		require.Equal(t, []eth.BlockID{l1.id(5), l1.id(9), l1.id(10)}, h.to)
+		require.Len(t, l1.queries, 3)
+		require.Equal(t, []common.Address{watchedAddr}, l1.queries[0].Addresses)
+
+		// no new confirmed blocks
+		more, err := w.poll(context.Background())
+		require.NoError(t, err)
+		require.False(t, more)
+		require.Len(t, l1.queries, 3)
+
+		l1.mu.Lock()
+		l1.extend(2, 0)
+		l1.mu.Unlock()
+		catchUp(t, w)
+		require.Equal(t, []uint64{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, h.blocks())
+		last, ok := w.Processed()
+		require.True(t, ok)
+		require.Equal(t, l1.id(12), last)
+	})
+	t.Run("Reorg", func(t *testing.T) {
+		l1 := newFakeL1(14)
+		h := &recordingHandler{}
+		w, err := NewWatcher(testlog.Logger(t, log.LevelDebug), testConfig(), l1, nil, h)
+		require.NoError(t, err)
+		catchUp(t, w)
+		// blocks 9 and 10 were processed with the same range, block 5 is the last processed block before the reorg
+		l1.reorg(7, 8, 1)
+		catchUp(t, w)
+		require.Equal(t, []eth.BlockID{l1.id(5)}, h.reorgs)
+		require.Equal(t, []uint64{2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, h.blocks())
+		for _, l := range h.logs {
+			require.Equal(t, l1.id(l.BlockNumber).Hash, l.BlockHash, "logs of block %d must be canonical", l.BlockNumber)
+		}
+	})
+	t.Run("ReorgTooDeep", func(t *testing.T) {
+		l1 := newFakeL1(14)
+		h := &recordingHandler{}
+		w, err := NewWatcher(testlog.Logger(t, log.LevelDebug), testConfig(), l1, nil, h)
+		require.NoError(t, err)
+		catchUp(t, w)
+		l1.reorg(1, 13, 1)
+		_, err = w.poll(context.Background())
+		require.ErrorIs(t, err, ErrReorgTooDeep)
+		require.Empty(t, h.reorgs)
+	})
+	t.Run("HandlerError", func(t *testing.T) {
+		l1 := newFakeL1(8)
+		h := &recordingHandler{failErr: errors.New("boom")}
+		w, err := NewWatcher(testlog.Logger(t, log.LevelDebug), testConfig(), l1, nil, h)
+		require.NoError(t, err)
+		_, err = w.poll(context.Background())
+		require.ErrorIs(t, err, h.failErr)
+		_, ok := w.Processed()
+		require.False(t, ok)
+
+		// the logs are delivered again
+		h.failErr = nil
+		catchUp(t, w)
+		require.Equal(t, []uint64{2, 3, 4}, h.blocks())
+	})
+	t.Run("Checkpoint", func(t *testing.T) {
+		l1 := newFakeL1(14)
+		store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+		checkpoint, err := store.Load()
+		require.NoError(t, err)
+		require.Nil(t, checkpoint)
+
+		h := &recordingHandler{}
+		w, err := NewWatcher(testlog.Logger(t, log.LevelDebug), testConfig(), l1, store, h)
+		require.NoError(t, err)
+		catchUp(t, w)
+		checkpoint, err = store.Load()
+		require.NoError(t, err)
+		require.Equal(t, &Checkpoint{Processed: []eth.BlockID{l1.id(5), l1.id(9), l1.id(10)}}, checkpoint)
+
+		// a restarted watcher resumes after the checkpoint
+		l1.mu.Lock()
+		l1.extend(2, 0)
+		l1.mu.Unlock()
+		h = &recordingHandler{}
+		w, err = NewWatcher(testlog.Logger(t, log.LevelDebug), testConfig(), l1, store, h)
+		require.NoError(t, err)
+		require.NoError(t, w.Start(context.Background()))
+		require.Eventually(t, func() bool {
+			last, ok := w.Processed()
+			return ok && last == l1.id(12)
+		}, 5*time.Second, 10*time.Millisecond)
+		require.NoError(t, w.Stop())
+		require.Equal(t, []uint64{11, 12}, h.blocks())
+	})
+	t.Run("InvalidConfig", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.PollInterval = 0
+		_, err := NewWatcher(testlog.Logger(t, log.LevelDebug), cfg, newFakeL1(1), nil, &recordingHandler{})
+		require.ErrorContains(t, err, "poll interval")
+		cfg = testConfig()
+		cfg.RateLimit = 10
+		_, err = NewWatcher(testlog.Logger(t, log.LevelDebug), cfg, newFakeL1(1), nil, &recordingHandler{})
+		require.ErrorContains(t, err, "rate burst")
+	})
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const HTTPClientSubsystem = "http_client"
+
+// HTTPClientMetricer records how HTTP client requests obtain their connection.
+type HTTPClientMetricer interface {
+	// RecordHTTPClientConn records that a request got a connection, reused from the pool or newly dialed.
+	// idleTime is how long a reused connection was idle in the pool.
+	RecordHTTPClientConn(reused bool, idleTime time.Duration)
+}
+
+// HTTPClientMetrics are the connection metrics of the HTTP clients of a service, labeled by client.
+type HTTPClientMetrics struct {
+	HTTPClientConnsTotal          *prometheus.CounterVec
+	HTTPClientConnIdleTimeSeconds *prometheus.HistogramVec
+}
+
+// MakeHTTPClientMetrics creates a new HTTPClientMetrics instance with the given namespace
+func MakeHTTPClientMetrics(ns string, factory Factory) HTTPClientMetrics {
+	return HTTPClientMetrics{
+		HTTPClientConnsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: HTTPClientSubsystem,
+			Name:      "conns_total",
+			Help:      "Total connections obtained by HTTP client requests, by whether the connection was reused",
+		}, []string{
+			"client",
+			"reused",
+		}),
+		HTTPClientConnIdleTimeSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: HTTPClientSubsystem,
+			Name:      "conn_idle_time_seconds",
+			Buckets:   []float64{.01, .1, .5, 1, 5, 10, 30, 60, 90},
+			Help:      "Histogram of how long reused HTTP client connections were idle",
+		}, []string{
+			"client",
+		}),
+	}
+}
+
+// ForClient returns the metricer of the HTTP client with the given name.
+func (m *HTTPClientMetrics) ForClient(name string) HTTPClientMetricer {
+	return &clientHTTPMetrics{m: m, client: name}
+}
+
+type clientHTTPMetrics struct {
+	m      *HTTPClientMetrics
+	client string
+}
+
+func (c *clientHTTPMetrics) RecordHTTPClientConn(reused bool, idleTime time.Duration) {
+	c.m.HTTPClientConnsTotal.WithLabelValues(c.client, strconv.FormatBool(reused)).Inc()
+	if reused {
+		c.m.HTTPClientConnIdleTimeSeconds.WithLabelValues(c.client).Observe(idleTime.Seconds())
+	}
+}
+
+type NoopHTTPClientMetrics struct{}
+
+func (n *NoopHTTPClientMetrics) RecordHTTPClientConn(reused bool, idleTime time.Duration) {}
+
+var _ HTTPClientMetricer = (*NoopHTTPClientMetrics)(nil)
@@ -1,17 +1,44 @@
 package metrics
 
 import (
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 )
 
 func NewRegistry() *prometheus.Registry {
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	registry.MustRegister(collectors.NewGoCollector())
+	registry.MustRegister(newLogSuppressedCollector())
 	return registry
 }
 
+// logSuppressedCollector exports the number of log records that were suppressed by rate-limiting,
+// see oplog.RateLimitingHandler.
+type logSuppressedCollector struct {
+	desc *prometheus.Desc
+}
+
+func newLogSuppressedCollector() *logSuppressedCollector {
+	return &logSuppressedCollector{
+		desc: prometheus.NewDesc("log_suppressed_records_total",
+			"Number of log records suppressed by rate-limiting, by level", []string{"level"}, nil),
+	}
+}
+
+func (c *logSuppressedCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *logSuppressedCollector) Collect(ch chan<- prometheus.Metric) {
+	for lvl, n := range oplog.SuppressedRecords() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, float64(n), log.LevelString(lvl))
+	}
+}
+
 type RegistryMetricer interface {
 	Registry() *prometheus.Registry
 }
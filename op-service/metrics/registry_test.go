@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+func TestRegistryLogSuppressed(t *testing.T) {
+	logger := log.NewLogger(oplog.NewRateLimitingHandler(oplog.RateLimitConfig{Window: time.Minute}, log.LogfmtHandlerWithLevel(io.Discard, log.LevelDebug)))
+	for i := 0; i < 3; i++ {
+		logger.Debug("repetitive")
+	}
+	expected := oplog.SuppressedRecords()[log.LevelDebug]
+	require.GreaterOrEqual(t, expected, uint64(2))
+
+	err := testutil.GatherAndCompare(NewRegistry(), strings.NewReader(`
+# HELP log_suppressed_records_total Number of log records suppressed by rate-limiting, by level
+# TYPE log_suppressed_records_total counter
+log_suppressed_records_total{level="debug"} `+strconv.FormatUint(expected, 10)+`
+`), "log_suppressed_records_total")
+	require.NoError(t, err)
+}
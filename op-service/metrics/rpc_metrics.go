@@ -3,6 +3,7 @@ package metrics
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -14,15 +15,28 @@ const (
 	RPCClientSubsystem = "rpc_client"
 
 	BatchMethod = "<batch>"
+
+	// UnknownMethod is the method label of the requests to the RPC server of methods that do not exist,
+	// so that callers can't create arbitrary labels.
+	UnknownMethod = "<unknown>"
 )
 
+var payloadSizeBuckets = prometheus.ExponentialBuckets(64, 4, 10)
+
 type RPCClientMetricer interface {
 	RecordRPCClientRequest(method string) func(err error)
 	RecordRPCClientResponse(method string, err error)
+	RecordRPCClientPayloadSizes(method string, requestSize int, responseSize int)
+}
+
+// RPCClientLimiterMetricer records how long RPC client requests wait for rate limits and concurrency caps.
+type RPCClientLimiterMetricer interface {
+	RecordRPCClientQueueWait(method string, wait time.Duration)
 }
 
 type RPCServerMetricer interface {
-	RecordRPCServerRequest(method string) func()
+	RecordRPCServerRequest(method string, duration time.Duration, requestSize int, responseSize int)
+	RecordRPCServerResponse(method string, err error)
 }
 
 type RPCMetricer interface {
@@ -35,12 +49,18 @@ type RPCClientMetrics struct {
 	RPCClientRequestsTotal          *prometheus.CounterVec
 	RPCClientRequestDurationSeconds *prometheus.HistogramVec
 	RPCClientResponsesTotal         *prometheus.CounterVec
+	RPCClientQueueWaitSeconds       *prometheus.HistogramVec
+	RPCClientRequestSizeBytes       *prometheus.HistogramVec
+	RPCClientResponseSizeBytes      *prometheus.HistogramVec
 }
 
 // RPCMetrics tracks server-only RPC metrics
 type RPCServerMetrics struct {
 	RPCServerRequestsTotal          *prometheus.CounterVec
 	RPCServerRequestDurationSeconds *prometheus.HistogramVec
+	RPCServerResponsesTotal         *prometheus.CounterVec
+	RPCServerRequestSizeBytes       *prometheus.HistogramVec
+	RPCServerResponseSizeBytes      *prometheus.HistogramVec
 }
 
 // RPCMetrics tracks all the RPC metrics, both client & server
@@ -86,6 +106,33 @@ func MakeRPCClientMetrics(ns string, factory Factory) RPCClientMetrics {
 			"method",
 			"error",
 		}),
+		RPCClientQueueWaitSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "queue_wait_seconds",
+			Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of time RPC client requests wait for rate limits and concurrency caps before being sent",
+		}, []string{
+			"method",
+		}),
+		RPCClientRequestSizeBytes: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "request_size_bytes",
+			Buckets:   payloadSizeBuckets,
+			Help:      "Histogram of RPC client request payload sizes",
+		}, []string{
+			"method",
+		}),
+		RPCClientResponseSizeBytes: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "response_size_bytes",
+			Buckets:   payloadSizeBuckets,
+			Help:      "Histogram of RPC client response payload sizes",
+		}, []string{
+			"method",
+		}),
 	}
 }
 
@@ -108,21 +155,35 @@ func (m *RPCClientMetrics) RecordRPCClientRequest(method string) func(err error)
 // http_<status code>, and everything else is converted into
 // <unknown>.
 func (m *RPCClientMetrics) RecordRPCClientResponse(method string, err error) {
-	var errStr string
+	m.RPCClientResponsesTotal.WithLabelValues(method, errorLabel(err)).Inc()
+}
+
+// RecordRPCClientPayloadSizes records the sizes of the request and response payloads of a request.
+func (m *RPCClientMetrics) RecordRPCClientPayloadSizes(method string, requestSize int, responseSize int) {
+	m.RPCClientRequestSizeBytes.WithLabelValues(method).Observe(float64(requestSize))
+	m.RPCClientResponseSizeBytes.WithLabelValues(method).Observe(float64(responseSize))
+}
+
+// errorLabel converts an RPC error into a metrics label, see RecordRPCClientResponse.
+func errorLabel(err error) string {
 	var rpcErr rpc.Error
 	var httpErr rpc.HTTPError
 	if err == nil {
-		errStr = "<nil>"
+		return "<nil>"
 	} else if errors.As(err, &rpcErr) {
-		errStr = fmt.Sprintf("rpc_%d", rpcErr.ErrorCode())
+		return fmt.Sprintf("rpc_%d", rpcErr.ErrorCode())
 	} else if errors.As(err, &httpErr) {
-		errStr = fmt.Sprintf("http_%d", httpErr.StatusCode)
+		return fmt.Sprintf("http_%d", httpErr.StatusCode)
 	} else if errors.Is(err, ethereum.NotFound) {
-		errStr = "<not found>"
+		return "<not found>"
 	} else {
-		errStr = "<unknown>"
+		return "<unknown>"
 	}
-	m.RPCClientResponsesTotal.WithLabelValues(method, errStr).Inc()
+}
+
+// RecordRPCClientQueueWait records how long a request waited for rate limits and concurrency caps before being sent.
+func (m *RPCClientMetrics) RecordRPCClientQueueWait(method string, wait time.Duration) {
+	m.RPCClientQueueWaitSeconds.WithLabelValues(method).Observe(wait.Seconds())
 }
 
 // MakeRPCServerMetrics creates a new RPCServerMetrics instance with the given namespace
@@ -145,24 +206,57 @@ func MakeRPCServerMetrics(ns string, factory Factory) RPCServerMetrics {
 		}, []string{
 			"method",
 		}),
+		RPCServerResponsesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: RPCServerSubsystem,
+			Name:      "responses_total",
+			Help:      "Total responses of the RPC server",
+		}, []string{
+			"method",
+			"error",
+		}),
+		RPCServerRequestSizeBytes: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: RPCServerSubsystem,
+			Name:      "request_size_bytes",
+			Buckets:   payloadSizeBuckets,
+			Help:      "Histogram of RPC server request payload sizes",
+		}, []string{
+			"method",
+		}),
+		RPCServerResponseSizeBytes: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: RPCServerSubsystem,
+			Name:      "response_size_bytes",
+			Buckets:   payloadSizeBuckets,
+			Help:      "Histogram of RPC server response payload sizes",
+		}, []string{
+			"method",
+		}),
 	}
 }
 
-// RecordRPCServerRequest is a helper method to record an incoming RPC
-// call to the opnode's RPC server. It bumps the requests metric,
-// and tracks how long it takes to serve a response.
-func (m *RPCServerMetrics) RecordRPCServerRequest(method string) func() {
+// RecordRPCServerRequest records a request served by the RPC server,
+// with how long it took to serve it, and the sizes of its payloads.
+func (m *RPCServerMetrics) RecordRPCServerRequest(method string, duration time.Duration, requestSize int, responseSize int) {
 	m.RPCServerRequestsTotal.WithLabelValues(method).Inc()
-	timer := prometheus.NewTimer(m.RPCServerRequestDurationSeconds.WithLabelValues(method))
-	return func() {
-		timer.ObserveDuration()
-	}
+	m.RPCServerRequestDurationSeconds.WithLabelValues(method).Observe(duration.Seconds())
+	m.RPCServerRequestSizeBytes.WithLabelValues(method).Observe(float64(requestSize))
+	m.RPCServerResponseSizeBytes.WithLabelValues(method).Observe(float64(responseSize))
+}
+
+// RecordRPCServerResponse records a response of the RPC server,
+// with its error converted like RecordRPCClientResponse does.
+func (m *RPCServerMetrics) RecordRPCServerResponse(method string, err error) {
+	m.RPCServerResponsesTotal.WithLabelValues(method, errorLabel(err)).Inc()
 }
 
 type NoopRPCMetrics struct{}
 
-func (n *NoopRPCMetrics) RecordRPCServerRequest(method string) func() {
-	return func() {}
+func (n *NoopRPCMetrics) RecordRPCServerRequest(method string, duration time.Duration, requestSize int, responseSize int) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerResponse(method string, err error) {
 }
 
 func (n *NoopRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
@@ -171,4 +265,11 @@ func (n *NoopRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 func (n *NoopRPCMetrics) RecordRPCClientResponse(method string, err error) {
 }
 
+func (n *NoopRPCMetrics) RecordRPCClientPayloadSizes(method string, requestSize int, responseSize int) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientQueueWait(method string, wait time.Duration) {
+}
+
 var _ RPCMetricer = (*NoopRPCMetrics)(nil)
+var _ RPCClientLimiterMetricer = (*NoopRPCMetrics)(nil)
@@ -0,0 +1,359 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxRPCRecordedSize bounds the prefix of the request and response bodies that is kept, to find the called methods
+// and the errors of the responses. The bodies are streamed, and their sizes are counted in full,
+// but the calls and errors past the prefix of a body are not recorded.
+const maxRPCRecordedSize = 64 * 1024
+
+// methodNotFoundCode is the JSON-RPC error code of calls to methods that do not exist.
+const methodNotFoundCode = -32601
+
+var errNotRPCMessage = errors.New("not a JSON-RPC message")
+
+type jsonrpcMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Error  *jsonrpcError   `json:"error,omitempty"`
+}
+
+func (msg *jsonrpcMessage) err() error {
+	if msg.Error == nil {
+		return nil
+	}
+	return msg.Error
+}
+
+type jsonrpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+var _ rpc.Error = (*jsonrpcError)(nil)
+
+func (e *jsonrpcError) Error() string {
+	return e.Message
+}
+
+func (e *jsonrpcError) ErrorCode() int {
+	return e.Code
+}
+
+// scanRPCMessages reads a JSON-RPC message, or a batch of them, from data, which may be truncated.
+// The messages are returned with the fields that were read before the end of data,
+// and the error reports if data could not be read in full.
+func scanRPCMessages(data []byte) (msgs []jsonrpcMessage, batch bool, err error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, false, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		msg, err := scanRPCMessage(dec)
+		return []jsonrpcMessage{msg}, false, err
+	case json.Delim('['):
+		for dec.More() {
+			if tok, err := dec.Token(); err != nil {
+				return msgs, true, err
+			} else if tok != json.Delim('{') {
+				return msgs, true, errNotRPCMessage
+			}
+			msg, err := scanRPCMessage(dec)
+			msgs = append(msgs, msg)
+			if err != nil {
+				return msgs, true, err
+			}
+		}
+		_, err = dec.Token()
+		return msgs, true, err
+	default:
+		return nil, false, errNotRPCMessage
+	}
+}
+
+// scanRPCMessage reads the fields of a JSON-RPC message, after its opening brace.
+// The values of the other fields, like the params and results, are skipped.
+func scanRPCMessage(dec *json.Decoder) (msg jsonrpcMessage, err error) {
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return msg, err
+		}
+		switch key {
+		case "id":
+			err = dec.Decode(&msg.ID)
+		case "method":
+			err = dec.Decode(&msg.Method)
+		case "error":
+			err = dec.Decode(&msg.Error)
+		default:
+			err = skipJSONValue(dec)
+		}
+		if err != nil {
+			return msg, err
+		}
+	}
+	_, err = dec.Token()
+	return msg, err
+}
+
+func skipJSONValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// recordRPCResponses records the responses to the calls of a request, by the method of the call.
+// If the request failed as a whole, only the failure of the request is recorded.
+// The response may be truncated: responses that were read before its end are recorded, without their later errors.
+func recordRPCResponses(reqs []jsonrpcMessage, batch bool, resp []byte, reqErr error, record func(method string, err error)) {
+	var resps []jsonrpcMessage
+	if reqErr == nil {
+		var err error
+		if resps, _, err = scanRPCMessages(resp); len(resps) == 0 {
+			reqErr = err
+		}
+	}
+	if !batch {
+		if reqErr == nil && len(resps) > 0 {
+			reqErr = resps[0].err()
+		}
+		record(reqs[0].Method, reqErr)
+		return
+	}
+	if reqErr != nil {
+		record(BatchMethod, reqErr)
+		return
+	}
+	methods := make(map[string]string, len(reqs))
+	for _, req := range reqs {
+		methods[string(req.ID)] = req.Method
+	}
+	for _, resp := range resps {
+		if method, ok := methods[string(resp.ID)]; ok {
+			record(method, resp.err())
+		}
+	}
+}
+
+// recordRPCServerCall records a JSON-RPC request served by the server, and the responses to its calls.
+func recordRPCServerCall(m RPCServerMetricer, reqs []jsonrpcMessage, batch bool, duration time.Duration, reqSize int, resp *prefixRecorder, reqErr error) {
+	method := BatchMethod
+	recordResponse := func(callMethod string, err error) {
+		if callMethod == "" || errorCode(err) == methodNotFoundCode {
+			callMethod = UnknownMethod
+		}
+		if !batch {
+			method = callMethod
+		}
+		m.RecordRPCServerResponse(callMethod, err)
+	}
+	recordRPCResponses(reqs, batch, resp.prefix, reqErr, recordResponse)
+	m.RecordRPCServerRequest(method, duration, reqSize, resp.size)
+}
+
+// prefixRecorder counts the bytes written to it, and keeps the first maxRPCRecordedSize of them.
+type prefixRecorder struct {
+	prefix []byte
+	size   int
+}
+
+func (p *prefixRecorder) Write(b []byte) (int, error) {
+	if rem := maxRPCRecordedSize - len(p.prefix); rem > 0 {
+		p.prefix = append(p.prefix, b[:min(rem, len(b))]...)
+	}
+	p.size += len(b)
+	return len(b), nil
+}
+
+// NewRPCServerMiddleware records the JSON-RPC requests served over HTTP by the next handler:
+// the number of requests, their durations and payload sizes, and the errors of the responses, by method.
+// Batch requests are recorded as a whole with the BatchMethod, and their responses by the methods of the calls.
+// Calls of methods that do not exist are recorded with the UnknownMethod.
+// The bodies are streamed to and from the next handler, only their prefixes are kept to find the methods and errors.
+// Other requests, like websocket upgrades, are passed through without being recorded,
+// websocket connections are recorded by NewRPCWebsocketHandler.
+func NewRPCServerMiddleware(m RPCServerMetricer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var body prefixRecorder
+		r.Body = readCloser{Reader: io.TeeReader(r.Body, &body), Closer: r.Body}
+		rec := &rpcResponseRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(rec, r)
+		duration := time.Since(start)
+
+		reqs, batch, _ := scanRPCMessages(body.prefix)
+		if len(reqs) == 0 {
+			return
+		}
+		var reqErr error
+		if rec.status != http.StatusOK {
+			reqErr = rpc.HTTPError{StatusCode: rec.status, Status: http.StatusText(rec.status)}
+		}
+		recordRPCServerCall(m, reqs, batch, duration, body.size, &rec.body, reqErr)
+	})
+}
+
+func errorCode(err error) int {
+	if rpcErr, ok := err.(rpc.Error); ok {
+		return rpcErr.ErrorCode()
+	}
+	return 0
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// rpcResponseRecorder captures the status and the prefix of the body of a response, while writing it.
+type rpcResponseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        prefixRecorder
+}
+
+func (r *rpcResponseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.wroteHeader = true
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *rpcResponseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	_, _ = r.body.Write(b[:n])
+	return n, err
+}
+
+// rpcClientTransport records the JSON-RPC requests made with the base transport, see NewRPCClientTransport.
+type rpcClientTransport struct {
+	m    RPCClientMetricer
+	base http.RoundTripper
+}
+
+// NewRPCClientTransport wraps the HTTP transport of an RPC client, to record the JSON-RPC requests made with it:
+// the number of requests, their durations and payload sizes, and the errors of the responses, by method.
+// Batch requests are recorded like NewRPCServerMiddleware does. A request is recorded when its response body
+// is read to the end or closed, the body is streamed to the client and only its prefix is kept.
+// It is used with rpc.WithHTTPClient, and records the same request metrics as client.InstrumentedRPCClient,
+// so the two should not be combined.
+func NewRPCClientTransport(m RPCClientMetricer, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rpcClientTransport{m: m, base: base}
+}
+
+func (t *rpcClientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.GetBody == nil {
+		return t.base.RoundTrip(req)
+	}
+	bodyReader, err := req.GetBody()
+	if err != nil {
+		return t.base.RoundTrip(req)
+	}
+	var body prefixRecorder
+	_, err = io.Copy(&body, bodyReader)
+	_ = bodyReader.Close()
+	if err != nil {
+		return t.base.RoundTrip(req)
+	}
+	reqs, batch, _ := scanRPCMessages(body.prefix)
+	if len(reqs) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	method := BatchMethod
+	if !batch {
+		method = reqs[0].Method
+	}
+	done := t.m.RecordRPCClientRequest(method)
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	resp.Body = &rpcResponseBody{ReadCloser: resp.Body, done: func(respBody *prefixRecorder, err error) {
+		if err != nil {
+			done(err)
+			return
+		}
+		t.m.RecordRPCClientPayloadSizes(method, body.size, respBody.size)
+		var reqErr error
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			reqErr = rpc.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Body: respBody.prefix}
+		}
+		if !batch {
+			recordRPCResponses(reqs, batch, respBody.prefix, reqErr, func(_ string, err error) { done(err) })
+			return
+		}
+		done(reqErr)
+		if reqErr == nil {
+			recordRPCResponses(reqs, batch, respBody.prefix, nil, t.m.RecordRPCClientResponse)
+		}
+	}}
+	return resp, nil
+}
+
+// rpcResponseBody keeps the prefix of a response body while it is read,
+// and calls done once when it is read to the end, fails to be read, or is closed.
+type rpcResponseBody struct {
+	io.ReadCloser
+	body prefixRecorder
+	once sync.Once
+	done func(body *prefixRecorder, err error)
+}
+
+func (b *rpcResponseBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	_, _ = b.body.Write(p[:n])
+	if errors.Is(err, io.EOF) {
+		b.finish(nil)
+	} else if err != nil {
+		b.finish(err)
+	}
+	return n, err
+}
+
+func (b *rpcResponseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.finish(nil)
+	return err
+}
+
+func (b *rpcResponseBody) finish(err error) {
+	b.once.Do(func() { b.done(&b.body, err) })
+}
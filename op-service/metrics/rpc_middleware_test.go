@@ -0,0 +1,184 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+type testService struct{}
+
+func (testService) Echo(v string) (string, error) {
+	if v == "fail" {
+		return "", errors.New("failed")
+	}
+	return v, nil
+}
+
+func newTestRPCServer(t *testing.T, handler func(http.Handler) http.Handler) string {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("test", testService{}))
+	t.Cleanup(srv.Stop)
+	httpSrv := httptest.NewServer(handler(srv))
+	t.Cleanup(httpSrv.Close)
+	return httpSrv.URL
+}
+
+func histogramCount(t *testing.T, h *prometheus.HistogramVec, method string) uint64 {
+	var m dto.Metric
+	require.NoError(t, h.WithLabelValues(method).(prometheus.Metric).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func callTestRPC(t *testing.T, cl *rpc.Client) {
+	var res string
+	require.NoError(t, cl.Call(&res, "test_echo", "hello"))
+	require.Error(t, cl.Call(&res, "test_echo", "fail"))
+	require.Error(t, cl.Call(&res, "test_nonexistent"))
+	batch := []rpc.BatchElem{
+		{Method: "test_echo", Args: []any{"a"}, Result: new(string)},
+		{Method: "test_echo", Args: []any{"fail"}, Result: new(string)},
+	}
+	require.NoError(t, cl.BatchCall(batch))
+	require.Error(t, batch[1].Error)
+}
+
+func TestRPCServerMiddleware(t *testing.T) {
+	m := MakeRPCServerMetrics("test", With(NewRegistry()))
+	url := newTestRPCServer(t, func(next http.Handler) http.Handler {
+		return NewRPCServerMiddleware(&m, next)
+	})
+	cl, err := rpc.Dial(url)
+	require.NoError(t, err)
+	defer cl.Close()
+	callTestRPC(t, cl)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues("test_echo")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues(UnknownMethod)))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues(BatchMethod)))
+	require.Equal(t, 0.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues("test_nonexistent")))
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerResponsesTotal.WithLabelValues("test_echo", "<nil>")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerResponsesTotal.WithLabelValues("test_echo", "rpc_-32000")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerResponsesTotal.WithLabelValues(UnknownMethod, "rpc_-32601")))
+
+	require.Equal(t, uint64(2), histogramCount(t, m.RPCServerRequestDurationSeconds, "test_echo"))
+	require.Equal(t, uint64(1), histogramCount(t, m.RPCServerRequestSizeBytes, BatchMethod))
+	require.Equal(t, uint64(2), histogramCount(t, m.RPCServerResponseSizeBytes, "test_echo"))
+}
+
+func TestRPCServerMiddlewarePassesThrough(t *testing.T) {
+	m := MakeRPCServerMetrics("test", With(NewRegistry()))
+	var served []string
+	handler := NewRPCServerMiddleware(&m, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = append(served, r.Method)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	resp, err = http.Post(srv.URL, "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, []string{http.MethodGet, http.MethodPost}, served)
+	require.Equal(t, 0, testutil.CollectAndCount(m.RPCServerRequestsTotal))
+}
+
+func TestRPCClientTransport(t *testing.T) {
+	m := MakeRPCClientMetrics("test", With(NewRegistry()))
+	url := newTestRPCServer(t, func(next http.Handler) http.Handler { return next })
+	httpClient := &http.Client{Transport: NewRPCClientTransport(&m, nil)}
+	cl, err := rpc.DialOptions(context.Background(), url, rpc.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+	defer cl.Close()
+	callTestRPC(t, cl)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCClientRequestsTotal.WithLabelValues("test_echo")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientRequestsTotal.WithLabelValues(BatchMethod)))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("test_echo", "<nil>")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("test_echo", "rpc_-32000")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("test_nonexistent", "rpc_-32601")))
+	require.Equal(t, uint64(1), histogramCount(t, m.RPCClientRequestSizeBytes, "test_nonexistent"))
+	require.Equal(t, uint64(1), histogramCount(t, m.RPCClientResponseSizeBytes, BatchMethod))
+}
+
+func TestRPCWebsocketHandler(t *testing.T) {
+	m := MakeRPCServerMetrics("test", With(NewRegistry()))
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("test", testService{}))
+	t.Cleanup(srv.Stop)
+	httpSrv := httptest.NewServer(NewRPCWebsocketHandler(&m, srv))
+	t.Cleanup(httpSrv.Close)
+	cl, err := rpc.Dial("ws" + strings.TrimPrefix(httpSrv.URL, "http"))
+	require.NoError(t, err)
+	defer cl.Close()
+	callTestRPC(t, cl)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues("test_echo")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues(UnknownMethod)))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues(BatchMethod)))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerResponsesTotal.WithLabelValues("test_echo", "<nil>")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerResponsesTotal.WithLabelValues("test_echo", "rpc_-32000")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerResponsesTotal.WithLabelValues(UnknownMethod, "rpc_-32601")))
+	require.Equal(t, uint64(2), histogramCount(t, m.RPCServerRequestDurationSeconds, "test_echo"))
+}
+
+func TestRPCMiddlewareLargeBodies(t *testing.T) {
+	serverMetrics := MakeRPCServerMetrics("test", With(NewRegistry()))
+	clientMetrics := MakeRPCClientMetrics("test", With(NewRegistry()))
+	url := newTestRPCServer(t, func(next http.Handler) http.Handler {
+		return NewRPCServerMiddleware(&serverMetrics, next)
+	})
+	httpClient := &http.Client{Transport: NewRPCClientTransport(&clientMetrics, nil)}
+	cl, err := rpc.DialOptions(context.Background(), url, rpc.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+	defer cl.Close()
+
+	large := strings.Repeat("a", 2*maxRPCRecordedSize)
+	var res string
+	require.NoError(t, cl.Call(&res, "test_echo", large))
+	require.Equal(t, large, res)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(serverMetrics.RPCServerResponsesTotal.WithLabelValues("test_echo", "<nil>")))
+	require.Equal(t, 1.0, testutil.ToFloat64(clientMetrics.RPCClientResponsesTotal.WithLabelValues("test_echo", "<nil>")))
+	var size dto.Metric
+	require.NoError(t, serverMetrics.RPCServerResponseSizeBytes.WithLabelValues("test_echo").(prometheus.Metric).Write(&size))
+	require.Greater(t, size.GetHistogram().GetSampleSum(), float64(len(large)), "sizes are counted past the recorded prefix")
+}
+
+func TestScanRPCMessages(t *testing.T) {
+	req := []byte(`{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["` + strings.Repeat("a", 100) + `"]}`)
+	msgs, batch, err := scanRPCMessages(req)
+	require.NoError(t, err)
+	require.False(t, batch)
+	require.Equal(t, []jsonrpcMessage{{ID: json.RawMessage("1"), Method: "test_echo"}}, msgs)
+
+	msgs, _, err = scanRPCMessages(req[:50])
+	require.Error(t, err)
+	require.Equal(t, []jsonrpcMessage{{ID: json.RawMessage("1"), Method: "test_echo"}}, msgs, "fields before the truncation are read")
+
+	resps := []byte(`[{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"failed"}},{"jsonrpc":"2.0","id":2,"result":"` + strings.Repeat("a", 100) + `"}]`)
+	msgs, batch, err = scanRPCMessages(resps[:100])
+	require.Error(t, err)
+	require.True(t, batch)
+	require.Len(t, msgs, 2)
+	require.Equal(t, -32000, errorCode(msgs[0].err()))
+	require.Equal(t, json.RawMessage("2"), msgs[1].ID)
+	require.NoError(t, msgs[1].err())
+
+	_, _, err = scanRPCMessages([]byte(`"not a message"`))
+	require.ErrorIs(t, err, errNotRPCMessage)
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsReadLimit bounds the size of the messages read from websocket connections,
+	// like the websocket handler of the RPC server does.
+	wsReadLimit        = 32 * 1024 * 1024
+	wsPingInterval     = 30 * time.Second
+	wsPingWriteTimeout = 5 * time.Second
+	wsPongTimeout      = 30 * time.Second
+)
+
+// NewRPCWebsocketHandler serves the RPC server to websocket connections from any origin, like the websocket handler
+// of the server does, and records the JSON-RPC requests made on the connections like NewRPCServerMiddleware does.
+// Subscription notifications sent by the server are not recorded.
+func NewRPCWebsocketHandler(m RPCServerMetricer, srv *rpc.Server) http.Handler {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     func(*http.Request) bool { return true },
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Debug("WebSocket upgrade failed", "err", err)
+			return
+		}
+		conn.SetReadLimit(wsReadLimit)
+		c := &rpcWebsocketConn{m: m, conn: conn, calls: make(map[string]wsCall)}
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Time{})
+		})
+		closed := make(chan struct{})
+		go c.pingLoop(closed)
+		srv.ServeCodec(rpc.NewFuncCodec(conn, c.encode, c.decode), 0)
+		close(closed)
+	})
+}
+
+// wsCall is a request that is waiting for its response.
+type wsCall struct {
+	reqs  []jsonrpcMessage
+	batch bool
+	start time.Time
+	size  int
+}
+
+// rpcWebsocketConn reads and writes the JSON-RPC messages of a websocket connection,
+// and matches the responses to the requests by their IDs to record them.
+type rpcWebsocketConn struct {
+	m    RPCServerMetricer
+	conn *websocket.Conn
+
+	mu    sync.Mutex
+	calls map[string]wsCall
+}
+
+// wsCallKey identifies a request, or its response, by the ID of its first message.
+func wsCallKey(msgs []jsonrpcMessage, batch bool) string {
+	if batch {
+		return "batch/" + string(msgs[0].ID)
+	}
+	return string(msgs[0].ID)
+}
+
+func (c *rpcWebsocketConn) decode(v any) error {
+	if err := c.conn.ReadJSON(v); err != nil {
+		return err
+	}
+	raw, ok := v.(*json.RawMessage)
+	if !ok {
+		return nil
+	}
+	reqs, batch, _ := scanRPCMessages(*raw)
+	if len(reqs) == 0 || len(reqs[0].ID) == 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[wsCallKey(reqs, batch)] = wsCall{reqs: reqs, batch: batch, start: time.Now(), size: len(*raw)}
+	return nil
+}
+
+func (c *rpcWebsocketConn) encode(v any, isErrorResponse bool) error {
+	w, err := c.conn.NextWriter(websocket.TextMessage)
+	if err != nil {
+		return err
+	}
+	var resp prefixRecorder
+	err = json.NewEncoder(io.MultiWriter(w, &resp)).Encode(v)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	resps, batch, _ := scanRPCMessages(resp.prefix)
+	if len(resps) == 0 {
+		return nil
+	}
+	key := wsCallKey(resps, batch)
+	c.mu.Lock()
+	call, ok := c.calls[key]
+	delete(c.calls, key)
+	c.mu.Unlock()
+	if ok {
+		recordRPCServerCall(c.m, call.reqs, call.batch, time.Since(call.start), call.size, &resp, nil)
+	}
+	return nil
+}
+
+// pingLoop pings the connection periodically, and closes it if it does not respond, until closed is closed.
+func (c *rpcWebsocketConn) pingLoop(closed <-chan struct{}) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsPingWriteTimeout)); err != nil {
+				return
+			}
+			_ = c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+		}
+	}
+}
@@ -18,6 +18,9 @@ const (
 	PortFlagName        = "pprof.port"
 	ProfileTypeFlagName = "pprof.type"
 	ProfilePathFlagName = "pprof.path"
+	AuthTokenFlagName   = "pprof.auth-token"
+	BlockRateFlagName   = "pprof.block-profile-rate"
+	MutexFracFlagName   = "pprof.mutex-profile-fraction"
 	defaultListenAddr   = "0.0.0.0"
 	defaultListenPort   = 6060
 )
@@ -104,6 +107,24 @@ func CLIFlagsWithCategory(envPrefix string, category string) []cli.Flag {
 			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_TYPE"),
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name:     AuthTokenFlagName,
+			Usage:    "Bearer token required to access the pprof and debug endpoints. The endpoints are not authenticated if empty.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_AUTH_TOKEN"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     BlockRateFlagName,
+			Usage:    "Block profile rate, see runtime.SetBlockProfileRate. Can be changed at runtime with the /debug/block-profile endpoint. Disabled if 0.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_BLOCK_PROFILE_RATE"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     MutexFracFlagName,
+			Usage:    "Mutex profile fraction, see runtime.SetMutexProfileFraction. Can be changed at runtime with the /debug/mutex-profile endpoint. Disabled if 0.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_MUTEX_PROFILE_FRACTION"),
+			Category: category,
+		},
 	}
 }
 
@@ -115,9 +136,20 @@ type CLIConfig struct {
 	ProfileType     profileType
 	ProfileDir      string
 	ProfileFilename string
+
+	// AuthToken is the bearer token required to access the pprof and debug endpoints, if not empty.
+	AuthToken            string
+	BlockProfileRate     int
+	MutexProfileFraction int
 }
 
 func (m CLIConfig) Check() error {
+	if m.BlockProfileRate < 0 {
+		return errors.New("block profile rate must not be negative")
+	}
+	if m.MutexProfileFraction < 0 {
+		return errors.New("mutex profile fraction must not be negative")
+	}
 	if !m.ListenEnabled {
 		return nil
 	}
@@ -138,5 +170,9 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		ProfileType:     profileType(strings.ToLower(ctx.String(ProfileTypeFlagName))),
 		ProfileDir:      profilePathFlag.Dir(),
 		ProfileFilename: profilePathFlag.Filename(),
+
+		AuthToken:            ctx.String(AuthTokenFlagName),
+		BlockProfileRate:     ctx.Int(BlockRateFlagName),
+		MutexProfileFraction: ctx.Int(MutexFracFlagName),
 	}
 }
@@ -0,0 +1,145 @@
+package oppprof
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// RuntimeStats is the runtime information served by the /debug/runtime endpoint.
+type RuntimeStats struct {
+	GoVersion  string `json:"goVersion"`
+	NumCPU     int    `json:"numCPU"`
+	GOMAXPROCS int    `json:"gomaxprocs"`
+	Goroutines int    `json:"goroutines"`
+	CgoCalls   int64  `json:"cgoCalls"`
+
+	HeapAlloc    uint64    `json:"heapAlloc"`
+	HeapInuse    uint64    `json:"heapInuse"`
+	HeapObjects  uint64    `json:"heapObjects"`
+	StackInuse   uint64    `json:"stackInuse"`
+	Sys          uint64    `json:"sys"`
+	NumGC        uint32    `json:"numGC"`
+	PauseTotalNs uint64    `json:"pauseTotalNs"`
+	LastGC       time.Time `json:"lastGC"`
+
+	BlockProfileRate     int `json:"blockProfileRate"`
+	MutexProfileFraction int `json:"mutexProfileFraction"`
+}
+
+// registerDebugHandlers registers the runtime-debug endpoints, next to the pprof endpoints:
+//   - /debug/goroutines: dump of the stacks of all goroutines.
+//   - /debug/runtime: runtime stats, see RuntimeStats.
+//   - /debug/block-profile?rate=N: sets the block profile rate (POST), see runtime.SetBlockProfileRate.
+//   - /debug/mutex-profile?fraction=N: sets the mutex profile fraction (POST), see runtime.SetMutexProfileFraction.
+//   - /debug/gc: runs a garbage collection (POST).
+func (s *Service) registerDebugHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/debug/goroutines", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_ = pprof.Lookup("goroutine").WriteTo(w, 2)
+	})
+	mux.HandleFunc("/debug/runtime", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.runtimeStats())
+	})
+	mux.HandleFunc("/debug/block-profile", postInt("rate", func(rate int) {
+		s.setBlockProfileRate(rate)
+		log.Info("set block profile rate", "rate", rate)
+	}))
+	mux.HandleFunc("/debug/mutex-profile", postInt("fraction", func(fraction int) {
+		runtime.SetMutexProfileFraction(fraction)
+		log.Info("set mutex profile fraction", "fraction", fraction)
+	}))
+	mux.HandleFunc("/debug/gc", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		runtime.GC()
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// postInt handles POST requests that set a non-negative integer from the given query parameter.
+func postInt(param string, set func(v int)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		v, err := strconv.Atoi(r.URL.Query().Get(param))
+		if err != nil || v < 0 {
+			http.Error(w, fmt.Sprintf("invalid %s: must be a non-negative integer", param), http.StatusBadRequest)
+			return
+		}
+		set(v)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Service) setBlockProfileRate(rate int) {
+	runtime.SetBlockProfileRate(rate)
+	s.blockProfileRate.Store(int64(rate))
+}
+
+func (s *Service) runtimeStats() RuntimeStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	stats := RuntimeStats{
+		GoVersion:  runtime.Version(),
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		Goroutines: runtime.NumGoroutine(),
+		CgoCalls:   runtime.NumCgoCall(),
+
+		HeapAlloc:    mem.HeapAlloc,
+		HeapInuse:    mem.HeapInuse,
+		HeapObjects:  mem.HeapObjects,
+		StackInuse:   mem.StackInuse,
+		Sys:          mem.Sys,
+		NumGC:        mem.NumGC,
+		PauseTotalNs: mem.PauseTotalNs,
+
+		BlockProfileRate: int(s.blockProfileRate.Load()),
+		// a negative fraction reads the current fraction without changing it
+		MutexProfileFraction: runtime.SetMutexProfileFraction(-1),
+	}
+	if mem.LastGC != 0 {
+		stats.LastGC = time.Unix(0, int64(mem.LastGC))
+	}
+	return stats
+}
+
+// authenticate requires requests to the handler to carry the auth token as bearer token,
+// if an auth token is configured.
+func (s *Service) authenticate(h http.Handler) http.Handler {
+	if s.authToken == "" {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.authToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"runtime/pprof"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum/go-ethereum/log"
@@ -25,6 +26,11 @@ type Service struct {
 	profileDir      string
 	profileFilename string
 
+	authToken            string
+	mutexProfileFraction int
+	// blockProfileRate is the current block profile rate, which the runtime does not expose.
+	blockProfileRate atomic.Int64
+
 	cpuFile    io.Closer
 	httpServer *httputil.HTTPServer
 }
@@ -40,14 +46,29 @@ func New(listenEnabled bool, listenAddr string, listenPort int, profType profile
 	}
 }
 
+// NewFromConfig creates the pprof service, and the debug endpoints served with it, from the CLI config.
+func NewFromConfig(cfg CLIConfig) *Service {
+	s := New(cfg.ListenEnabled, cfg.ListenAddr, cfg.ListenPort, cfg.ProfileType, cfg.ProfileDir, cfg.ProfileFilename)
+	s.authToken = cfg.AuthToken
+	s.mutexProfileFraction = cfg.MutexProfileFraction
+	s.blockProfileRate.Store(int64(cfg.BlockProfileRate))
+	return s
+}
+
 func (s *Service) Start() error {
+	if rate := s.blockProfileRate.Load(); rate > 0 {
+		runtime.SetBlockProfileRate(int(rate))
+	}
+	if s.mutexProfileFraction > 0 {
+		runtime.SetMutexProfileFraction(s.mutexProfileFraction)
+	}
 	switch s.profileType {
 	case "cpu":
 		if err := s.startCPUProfile(); err != nil {
 			return err
 		}
 	case "block":
-		runtime.SetBlockProfileRate(1)
+		s.setBlockProfileRate(1)
 	case "mutex":
 		runtime.SetMutexProfileFraction(1)
 	}
@@ -106,11 +127,15 @@ func (s *Service) startServer() error {
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(httpPprof.Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(httpPprof.Symbol))
 	mux.Handle("/debug/pprof/trace", http.HandlerFunc(httpPprof.Trace))
+	s.registerDebugHandlers(mux)
 
 	addr := net.JoinHostPort(s.listenAddr, strconv.Itoa(s.listenPort))
+	if s.authToken == "" && !isLoopback(s.listenAddr) {
+		log.Warn("pprof server is not authenticated, and listens on a non-loopback address", "addr", addr)
+	}
 
 	var err error
-	s.httpServer, err = httputil.StartHTTPServer(addr, mux)
+	s.httpServer, err = httputil.StartHTTPServer(addr, s.authenticate(mux))
 	if err != nil {
 		return err
 	}
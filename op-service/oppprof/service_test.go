@@ -0,0 +1,109 @@
+package oppprof
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func startTestService(t *testing.T, cfg CLIConfig) string {
+	cfg.ListenEnabled = true
+	cfg.ListenAddr = "127.0.0.1"
+	cfg.ListenPort = 0
+	require.NoError(t, cfg.Check())
+	s := NewFromConfig(cfg)
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		require.NoError(t, s.Stop(context.Background()))
+	})
+	return "http://" + s.httpServer.Addr().String()
+}
+
+func request(t *testing.T, method, url, token string) (int, string) {
+	req, err := http.NewRequest(method, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestDebugEndpoints(t *testing.T) {
+	t.Cleanup(func() {
+		runtime.SetBlockProfileRate(0)
+		runtime.SetMutexProfileFraction(0)
+	})
+	addr := startTestService(t, CLIConfig{BlockProfileRate: 5})
+
+	code, body := request(t, http.MethodGet, addr+"/debug/goroutines", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, "goroutine ")
+
+	code, body = request(t, http.MethodGet, addr+"/debug/pprof/", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, "goroutine")
+
+	runtimeStats := func() RuntimeStats {
+		code, body := request(t, http.MethodGet, addr+"/debug/runtime", "")
+		require.Equal(t, http.StatusOK, code)
+		var stats RuntimeStats
+		require.NoError(t, json.Unmarshal([]byte(body), &stats))
+		return stats
+	}
+	stats := runtimeStats()
+	require.Equal(t, runtime.Version(), stats.GoVersion)
+	require.Positive(t, stats.Goroutines)
+	require.Positive(t, stats.HeapAlloc)
+	require.Equal(t, 5, stats.BlockProfileRate)
+
+	code, _ = request(t, http.MethodPost, addr+"/debug/block-profile?rate=1", "")
+	require.Equal(t, http.StatusNoContent, code)
+	code, _ = request(t, http.MethodPost, addr+"/debug/mutex-profile?fraction=2", "")
+	require.Equal(t, http.StatusNoContent, code)
+	stats = runtimeStats()
+	require.Equal(t, 1, stats.BlockProfileRate)
+	require.Equal(t, 2, stats.MutexProfileFraction)
+
+	code, _ = request(t, http.MethodPost, addr+"/debug/block-profile?rate=-1", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = request(t, http.MethodGet, addr+"/debug/block-profile?rate=0", "")
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, _ = request(t, http.MethodPost, addr+"/debug/gc", "")
+	require.Equal(t, http.StatusNoContent, code)
+	require.Positive(t, runtimeStats().NumGC)
+}
+
+func TestDebugAuthentication(t *testing.T) {
+	addr := startTestService(t, CLIConfig{AuthToken: "secret"})
+
+	for _, path := range []string{"/debug/pprof/", "/debug/goroutines", "/debug/runtime"} {
+		code, _ := request(t, http.MethodGet, addr+path, "")
+		require.Equal(t, http.StatusUnauthorized, code, path)
+		code, _ = request(t, http.MethodGet, addr+path, "wrong")
+		require.Equal(t, http.StatusUnauthorized, code, path)
+		code, _ = request(t, http.MethodGet, addr+path, "secret")
+		require.Equal(t, http.StatusOK, code, path)
+	}
+	code, body := request(t, http.MethodPost, addr+"/debug/gc", "")
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.True(t, strings.HasPrefix(body, "unauthorized"))
+}
+
+func TestCLIConfigCheck(t *testing.T) {
+	require.NoError(t, DefaultCLIConfig().Check())
+	require.ErrorContains(t, CLIConfig{BlockProfileRate: -1}.Check(), "block profile rate")
+	require.ErrorContains(t, CLIConfig{MutexProfileFraction: -1}.Check(), "mutex profile fraction")
+	require.ErrorIs(t, CLIConfig{ListenEnabled: true, ListenPort: 1 << 16}.Check(), ErrInvalidPort)
+}
@@ -0,0 +1,71 @@
+package retry
+
+import "errors"
+
+// ErrorClass classifies the errors of an operation, to determine whether the operation is retried.
+type ErrorClass int
+
+const (
+	// Temporary errors may not occur on the next attempt, so the operation is retried.
+	Temporary ErrorClass = iota
+	// Fatal errors occur on every attempt, so the operation is not retried.
+	Fatal
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case Temporary:
+		return "temporary"
+	case Fatal:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// Classifier classifies the errors of an operation.
+type Classifier func(err error) ErrorClass
+
+// fatalError marks an error as Fatal, see FatalError.
+type fatalError struct {
+	err error
+}
+
+func (e *fatalError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fatalError) Unwrap() error {
+	return e.err
+}
+
+// FatalError marks the error as Fatal for the DefaultClassifier, so the operation that returned it is not retried.
+// The error is otherwise unchanged: it has the same message, and unwraps to err.
+func FatalError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &fatalError{err: err}
+}
+
+// DefaultClassifier classifies errors marked with FatalError as Fatal, and all other errors as Temporary.
+func DefaultClassifier(err error) ErrorClass {
+	var fatal *fatalError
+	if errors.As(err, &fatal) {
+		return Fatal
+	}
+	return Temporary
+}
+
+// FatalOn returns a classifier that classifies errors matching any of the targets with errors.Is as Fatal,
+// in addition to the errors classified as Fatal by the DefaultClassifier.
+func FatalOn(targets ...error) Classifier {
+	return func(err error) ErrorClass {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return Fatal
+			}
+		}
+		return DefaultClassifier(err)
+	}
+}
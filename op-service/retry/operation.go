@@ -2,7 +2,9 @@ package retry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 )
 
@@ -37,29 +39,103 @@ func Do2[T, U any](ctx context.Context, maxAttempts int, strategy Strategy, op f
 
 // Do performs the provided Operation up to maxAttempts times
 // with delays in between each retry according to the provided
-// Strategy.
+// Strategy. Errors marked with FatalError are not retried.
 func Do[T any](ctx context.Context, maxAttempts int, strategy Strategy, op func() (T, error)) (T, error) {
-	var empty, ret T
-	var err error
 	if maxAttempts < 1 {
+		var empty T
 		return empty, fmt.Errorf("need at least 1 attempt to run op, but have %d max attempts", maxAttempts)
 	}
+	return DoWithPolicy(ctx, Policy{Strategy: strategy, MaxAttempts: maxAttempts}, op)
+}
+
+// Policy configures how DoWithPolicy retries an operation.
+type Policy struct {
+	// Strategy determines the delays between attempts.
+	Strategy Strategy
+	// MaxAttempts is the maximum number of attempts, or 0 for no limit.
+	MaxAttempts int
+	// MaxElapsed is the time budget of the operation: no attempt is started after it elapsed since
+	// the first attempt started, and the delay before the last attempt is cut short to fit the budget.
+	// 0 for no limit.
+	MaxElapsed time.Duration
+	// Classify classifies the errors of the operation. Defaults to DefaultClassifier.
+	Classify Classifier
+	// Jitter is the fraction, between 0 and 1, by which each delay of the strategy is randomly shortened,
+	// so that clients that failed at the same time do not retry at the same time. 0 for no jitter.
+	Jitter float64
+}
+
+// DoWithPolicy performs the provided Operation until it succeeds, it fails with a Fatal error,
+// the context is done, or the budget of the policy is exhausted.
+// An ErrFailedPermanently is returned when the budget is exhausted,
+// and Fatal errors are returned as-is.
+func DoWithPolicy[T any](ctx context.Context, p Policy, op func() (T, error)) (T, error) {
+	var empty T
+	if p.MaxAttempts < 0 {
+		return empty, fmt.Errorf("max attempts must not be negative, but have %d", p.MaxAttempts)
+	}
+	if p.MaxAttempts == 0 && p.MaxElapsed <= 0 && ctx.Done() == nil {
+		return empty, errors.New("unbounded retries: need max attempts, max elapsed time or a cancellable context")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return empty, fmt.Errorf("jitter must be between 0 and 1, but have %v", p.Jitter)
+	}
+	classify := p.Classify
+	if classify == nil {
+		classify = DefaultClassifier
+	}
+	strategy := p.Strategy
+	if strategy == nil {
+		strategy = Exponential()
+	}
 
-	for i := 0; i < maxAttempts; i++ {
+	start := time.Now()
+	for i := 0; ; i++ {
 		if ctx.Err() != nil {
 			return empty, ctx.Err()
 		}
-		ret, err = op()
+		ret, err := op()
 		if err == nil {
 			return ret, nil
 		}
+		if classify(err) == Fatal {
+			return empty, err
+		}
+		failed := &ErrFailedPermanently{attempts: i + 1, LastErr: err}
 		// Don't sleep when we are about to exit the loop & return ErrFailedPermanently
-		if i != maxAttempts-1 {
-			time.Sleep(strategy.Duration(i))
+		if p.MaxAttempts > 0 && i == p.MaxAttempts-1 {
+			return empty, failed
+		}
+		delay := jitter(strategy.Duration(i), p.Jitter)
+		if p.MaxElapsed > 0 {
+			remaining := p.MaxElapsed - time.Since(start)
+			if remaining <= 0 {
+				return empty, failed
+			}
+			delay = min(delay, remaining)
 		}
+		if err := sleep(ctx, delay); err != nil {
+			return empty, err
+		}
+	}
+}
+
+// jitter shortens the delay by a random fraction of it, of at most the given fraction.
+func jitter(delay time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || delay <= 0 {
+		return delay
 	}
-	return empty, &ErrFailedPermanently{
-		attempts: maxAttempts,
-		LastErr:  err,
+	return delay - time.Duration(rand.Float64()*fraction*float64(delay))
+}
+
+// sleep waits for the given duration, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
 }
@@ -3,6 +3,7 @@ package retry
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -32,3 +33,101 @@ func TestDo(t *testing.T) {
 	require.Equal(t, dummyErr, err.(*ErrFailedPermanently).LastErr)
 	require.True(t, time.Since(start) > 20*time.Millisecond)
 }
+
+func TestDoFatal(t *testing.T) {
+	dummyErr := errors.New("explode")
+	var attempts int
+	_, err := Do(context.Background(), 5, Fixed(time.Millisecond), func() (int, error) {
+		attempts++
+		return 0, fmt.Errorf("wrapped: %w", FatalError(dummyErr))
+	})
+	require.Equal(t, 1, attempts)
+	require.ErrorIs(t, err, dummyErr)
+	require.EqualError(t, err, "wrapped: explode")
+	var failed *ErrFailedPermanently
+	require.False(t, errors.As(err, &failed))
+	require.Nil(t, FatalError(nil))
+}
+
+func TestDoWithPolicy(t *testing.T) {
+	dummyErr := errors.New("explode")
+	fatalErr := errors.New("fatal")
+
+	t.Run("classifier", func(t *testing.T) {
+		var attempts int
+		_, err := DoWithPolicy(context.Background(), Policy{
+			Strategy:    Fixed(time.Millisecond),
+			MaxAttempts: 5,
+			Classify:    FatalOn(fatalErr),
+		}, func() (int, error) {
+			attempts++
+			if attempts == 2 {
+				return 0, fatalErr
+			}
+			return 0, dummyErr
+		})
+		require.Equal(t, 2, attempts)
+		require.Equal(t, fatalErr, err)
+	})
+
+	t.Run("max elapsed", func(t *testing.T) {
+		var attempts int
+		start := time.Now()
+		_, err := DoWithPolicy(context.Background(), Policy{
+			Strategy:   Fixed(20 * time.Millisecond),
+			MaxElapsed: 50 * time.Millisecond,
+		}, func() (int, error) {
+			attempts++
+			return 0, dummyErr
+		})
+		var failed *ErrFailedPermanently
+		require.ErrorAs(t, err, &failed)
+		require.Equal(t, dummyErr, failed.LastErr)
+		require.Equal(t, attempts, failed.attempts)
+		require.LessOrEqual(t, attempts, 4, "delay before the last attempt is cut short")
+		require.GreaterOrEqual(t, attempts, 2)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("context done while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := DoWithPolicy(ctx, Policy{Strategy: Fixed(time.Hour)}, func() (int, error) {
+			return 0, dummyErr
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("jitter", func(t *testing.T) {
+		_, err := DoWithPolicy(context.Background(), Policy{MaxAttempts: 1, Jitter: 1.5}, func() (int, error) {
+			return 0, nil
+		})
+		require.ErrorContains(t, err, "jitter")
+
+		for i := 0; i < 100; i++ {
+			d := jitter(100*time.Millisecond, 0.5)
+			require.GreaterOrEqual(t, d, 50*time.Millisecond)
+			require.LessOrEqual(t, d, 100*time.Millisecond)
+		}
+		require.Equal(t, 100*time.Millisecond, jitter(100*time.Millisecond, 0))
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		_, err := DoWithPolicy(context.Background(), Policy{}, func() (int, error) {
+			return 0, dummyErr
+		})
+		require.ErrorContains(t, err, "unbounded retries")
+	})
+}
+
+func TestDefaultClassifier(t *testing.T) {
+	err := errors.New("explode")
+	require.Equal(t, Temporary, DefaultClassifier(err))
+	require.Equal(t, Fatal, DefaultClassifier(FatalError(err)))
+	require.Equal(t, Fatal, DefaultClassifier(fmt.Errorf("wrapped: %w", FatalError(err))))
+	require.Equal(t, Fatal, FatalOn(err)(fmt.Errorf("wrapped: %w", err)))
+	require.Equal(t, Temporary, FatalOn(err)(errors.New("other")))
+	require.Equal(t, "fatal", Fatal.String())
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
-	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -18,21 +17,16 @@ func ToGethAdminAPI(api *CommonAdminAPI) rpc.API {
 }
 
 type CommonAdminAPI struct {
-	M   metrics.RPCMetricer
 	log log.Logger
 }
 
-func NewCommonAdminAPI(m metrics.RPCMetricer, log log.Logger) *CommonAdminAPI {
+func NewCommonAdminAPI(log log.Logger) *CommonAdminAPI {
 	return &CommonAdminAPI{
-		M:   m,
 		log: log,
 	}
 }
 
 func (n *CommonAdminAPI) SetLogLevel(ctx context.Context, lvlStr string) error {
-	recordDur := n.M.RecordRPCServerRequest("admin_setLogLevel")
-	defer recordDur()
-
 	lvl, err := oplog.LevelFromString(lvlStr)
 	if err != nil {
 		return err
@@ -0,0 +1,231 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+// APIKeyHeader is the HTTP header that clients send their API key with, to authenticate admin requests.
+const APIKeyHeader = "X-API-Key"
+
+// maxAuthBodySize is the maximum size of a request body that is inspected for admin methods,
+// matching the maximum request size of the RPC server.
+const maxAuthBodySize = 5 * 1024 * 1024
+
+// AdminAuth authenticates the requests to admin RPC methods, with API keys or client certificates (mutual TLS).
+// Requests to other methods are not authenticated.
+type AdminAuth struct {
+	// apiKeys are the SHA-256 hashes of the accepted API keys
+	apiKeys [][sha256.Size]byte
+	// tlsConfig serves TLS, verifying client certificates if given. Nil if mutual TLS is disabled.
+	tlsConfig *tls.Config
+	// protected are the namespaces (with trailing "_") and full method names that require authentication
+	protected []string
+}
+
+// NewAdminAuth creates the admin authentication of the config, for the RPC methods of the given namespaces,
+// or the given full method names, like "admin" or "conductor_pause". Nil is returned if authentication is disabled.
+func NewAdminAuth(cfg AdminAuthCLIConfig, namespaces []string, methods []string) (*AdminAuth, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	auth := &AdminAuth{}
+	for _, ns := range namespaces {
+		auth.protected = append(auth.protected, ns+"_")
+	}
+	auth.protected = append(auth.protected, methods...)
+	if cfg.APIKeysFile != "" {
+		keys, err := readAPIKeys(cfg.APIKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			auth.apiKeys = append(auth.apiKeys, sha256.Sum256([]byte(key)))
+		}
+	}
+	if cfg.TLS.TLSEnabled() {
+		cert, err := tls.LoadX509KeyPair(cfg.TLS.TLSCert, cfg.TLS.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load admin TLS certificate: %w", err)
+		}
+		caCert, err := os.ReadFile(cfg.TLS.TLSCaCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read admin TLS CA certificate: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to parse admin TLS CA certificate")
+		}
+		auth.tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientCAs:    clientCAs,
+			// clients of non-admin methods may connect without a certificate
+			ClientAuth: tls.VerifyClientCertIfGiven,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	return auth, nil
+}
+
+// readAPIKeys reads the API keys of a file, one key per line. Empty lines and lines starting with # are ignored.
+func readAPIKeys(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open API keys file: %w", err)
+	}
+	defer f.Close()
+	var keys []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys = append(keys, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read API keys file: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no API keys in file %q", path)
+	}
+	return keys, nil
+}
+
+// TLSConfig returns the TLS config that the RPC server has to serve with for mutual TLS, nil if disabled.
+func (a *AdminAuth) TLSConfig() *tls.Config {
+	return a.tlsConfig
+}
+
+func (a *AdminAuth) isProtected(method string) bool {
+	for _, p := range a.protected {
+		if method == p || (strings.HasSuffix(p, "_") && strings.HasPrefix(method, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticated returns whether the request has an accepted API key, or a verified client certificate.
+func (a *AdminAuth) authenticated(r *http.Request) bool {
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && a.tlsConfig != nil {
+		return true
+	}
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		hash := sha256.Sum256([]byte(key))
+		for _, accepted := range a.apiKeys {
+			if subtle.ConstantTimeCompare(hash[:], accepted[:]) == 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewAdminAuthHandler rejects unauthenticated requests to the admin methods of the auth before they reach next.
+// Batch requests are rejected if any of the calls is to an admin method. Requests that cannot be inspected,
+// like malformed or oversized requests, require authentication too.
+func NewAdminAuthHandler(auth *AdminAuth, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil || auth.authenticated(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxAuthBodySize+1))
+		if err != nil {
+			http.Error(w, "failed to read request", http.StatusBadRequest)
+			return
+		}
+		if len(body) > maxAuthBodySize {
+			http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		methods, err := requestMethods(body)
+		if err != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		for _, method := range methods {
+			if auth.isProtected(method) {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestMethods returns the methods of the calls of a request. Like the RPC server,
+// only the first JSON value of the body is read, which may be a single call or a batch of calls.
+func requestMethods(body []byte) ([]string, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&raw); err != nil {
+		return nil, err
+	}
+	type call struct {
+		Method string `json:"method"`
+	}
+	if trimmed := bytes.TrimLeft(raw, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		var calls []call
+		if err := json.Unmarshal(raw, &calls); err != nil {
+			return nil, err
+		}
+		methods := make([]string, len(calls))
+		for i, c := range calls {
+			methods[i] = c.Method
+		}
+		return methods, nil
+	}
+	var c call
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+	return []string{c.Method}, nil
+}
+
+// WithAdminAuth authenticates the requests to the admin methods of the auth, see NewAdminAuthHandler.
+// The server is served with the TLS config of the auth if mutual TLS is enabled.
+func WithAdminAuth(auth *AdminAuth) ServerOption {
+	return func(b *Server) {
+		b.adminAuth = auth
+		if auth != nil && auth.tlsConfig != nil {
+			b.tls = &ServerTLSConfig{Config: auth.tlsConfig}
+		}
+	}
+}
+
+// AdminAuthCLIConfig configures the authentication of admin RPC methods.
+type AdminAuthCLIConfig struct {
+	// APIKeysFile is the path to a file with the accepted API keys, one per line.
+	APIKeysFile string
+	// TLS serves the RPC server with TLS, and accepts client certificates signed by the CA for admin requests.
+	TLS optls.CLIConfig
+}
+
+func (c AdminAuthCLIConfig) Enabled() bool {
+	return c.APIKeysFile != "" || c.TLS.TLSEnabled()
+}
+
+func (c AdminAuthCLIConfig) Check() error {
+	if err := c.TLS.Check(); err != nil {
+		return fmt.Errorf("invalid admin TLS config: %w", err)
+	}
+	return nil
+}
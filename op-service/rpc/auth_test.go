@@ -0,0 +1,199 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+type testAdminAPI struct{}
+
+func (t *testAdminAPI) Halt() string {
+	return "halted"
+}
+
+func startAuthServer(t *testing.T, cfg AdminAuthCLIConfig) *Server {
+	auth, err := NewAdminAuth(cfg, []string{"admin"}, []string{"test_reset"})
+	require.NoError(t, err)
+	server := NewServer("127.0.0.1", 0, "test",
+		WithAPIs([]rpc.API{
+			{Namespace: "test", Service: new(testAPI)},
+			{Namespace: "admin", Service: new(testAdminAPI)},
+		}),
+		WithAdminAuth(auth),
+	)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() { _ = server.Stop() })
+	return server
+}
+
+func TestAdminAuthAPIKeys(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys")
+	require.NoError(t, os.WriteFile(keysFile, []byte("# operators\nkey-one\n\n  key-two  \n"), 0o600))
+	server := startAuthServer(t, AdminAuthCLIConfig{APIKeysFile: keysFile})
+	endpoint := "http://" + server.Endpoint()
+
+	dial := func(opts ...rpc.ClientOption) *rpc.Client {
+		cl, err := rpc.DialOptions(context.Background(), endpoint, opts...)
+		require.NoError(t, err)
+		t.Cleanup(cl.Close)
+		return cl
+	}
+	var n int
+	var res string
+
+	t.Run("no key", func(t *testing.T) {
+		cl := dial()
+		require.NoError(t, cl.Call(&n, "test_frobnicate", 2), "non-admin methods do not require authentication")
+		require.Equal(t, 4, n)
+		require.ErrorContains(t, cl.Call(&res, "admin_halt"), "401")
+		require.ErrorContains(t, cl.Call(&res, "test_reset"), "401", "protected method")
+		batch := []rpc.BatchElem{
+			{Method: "test_frobnicate", Args: []any{1}, Result: &n},
+			{Method: "admin_halt", Result: &res},
+		}
+		require.ErrorContains(t, cl.BatchCall(batch), "401")
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		cl := dial(rpc.WithHeader(APIKeyHeader, "key-three"))
+		require.ErrorContains(t, cl.Call(&res, "admin_halt"), "401")
+	})
+	t.Run("accepted keys", func(t *testing.T) {
+		for _, key := range []string{"key-one", "key-two"} {
+			cl := dial(rpc.WithHeader(APIKeyHeader, key))
+			require.NoError(t, cl.Call(&res, "admin_halt"))
+			require.Equal(t, "halted", res)
+		}
+	})
+	t.Run("malformed request", func(t *testing.T) {
+		for _, body := range []string{`{"method":"test_frobnicate"`, `not json`} {
+			resp, err := http.Post(endpoint, "application/json", strings.NewReader(body))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		}
+		// the server only reads the first value of the body
+		body := `{"jsonrpc":"2.0","id":1,"method":"admin_halt"} {"method":"test_frobnicate"}`
+		resp, err := http.Post(endpoint, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestAdminAuthMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := newTestCert(t, "ca", nil, nil)
+	serverCert, serverKey := newTestCert(t, "127.0.0.1", ca, caKey)
+	clientCert, clientKey := newTestCert(t, "operator", ca, caKey)
+	otherCA, otherCAKey := newTestCert(t, "other-ca", nil, nil)
+	otherCert, otherKey := newTestCert(t, "intruder", otherCA, otherCAKey)
+
+	writePEM(t, filepath.Join(dir, "ca.crt"), "CERTIFICATE", ca.Raw)
+	writePEM(t, filepath.Join(dir, "tls.crt"), "CERTIFICATE", serverCert.Raw)
+	writeKey(t, filepath.Join(dir, "tls.key"), serverKey)
+	server := startAuthServer(t, AdminAuthCLIConfig{TLS: optls.CLIConfig{
+		TLSCaCert: filepath.Join(dir, "ca.crt"),
+		TLSCert:   filepath.Join(dir, "tls.crt"),
+		TLSKey:    filepath.Join(dir, "tls.key"),
+	}})
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	dial := func(certs ...tls.Certificate) *rpc.Client {
+		httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+		}}}
+		cl, err := rpc.DialOptions(context.Background(), "https://"+server.Endpoint(), rpc.WithHTTPClient(httpClient))
+		require.NoError(t, err)
+		t.Cleanup(cl.Close)
+		return cl
+	}
+	var n int
+	var res string
+
+	t.Run("no client certificate", func(t *testing.T) {
+		cl := dial()
+		require.NoError(t, cl.Call(&n, "test_frobnicate", 2))
+		require.ErrorContains(t, cl.Call(&res, "admin_halt"), "401")
+	})
+	t.Run("client certificate", func(t *testing.T) {
+		cl := dial(tls.Certificate{Certificate: [][]byte{clientCert.Raw}, PrivateKey: clientKey})
+		require.NoError(t, cl.Call(&res, "admin_halt"))
+		require.Equal(t, "halted", res)
+	})
+	t.Run("client certificate of other CA", func(t *testing.T) {
+		cl := dial(tls.Certificate{Certificate: [][]byte{otherCert.Raw}, PrivateKey: otherKey})
+		require.ErrorContains(t, cl.Call(&res, "admin_halt"), "401")
+	})
+}
+
+func TestAdminAuthConfig(t *testing.T) {
+	auth, err := NewAdminAuth(AdminAuthCLIConfig{}, []string{"admin"}, nil)
+	require.NoError(t, err)
+	require.Nil(t, auth, "disabled")
+
+	_, err = NewAdminAuth(AdminAuthCLIConfig{APIKeysFile: filepath.Join(t.TempDir(), "missing")}, []string{"admin"}, nil)
+	require.ErrorContains(t, err, "API keys file")
+
+	_, err = NewAdminAuth(AdminAuthCLIConfig{TLS: optls.CLIConfig{TLSCaCert: "ca.crt"}}, []string{"admin"}, nil)
+	require.ErrorContains(t, err, "invalid admin TLS config")
+}
+
+// newTestCert creates a certificate signed by the parent, or a self-signed CA certificate if the parent is nil.
+func newTestCert(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if ip := net.ParseIP(name); ip != nil {
+		tmpl.IPAddresses = []net.IP{ip}
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func writePEM(t *testing.T, path string, typ string, data []byte) {
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: data}), 0o600))
+}
+
+func writeKey(t *testing.T, path string, key *ecdsa.PrivateKey) {
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err, fmt.Sprintf("marshal key of %s", path))
+	writePEM(t, path, "EC PRIVATE KEY", der)
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 	"github.com/urfave/cli/v2"
 )
 
@@ -12,12 +13,17 @@ const (
 	ListenAddrFlagName  = "rpc.addr"
 	PortFlagName        = "rpc.port"
 	EnableAdminFlagName = "rpc.enable-admin"
+
+	AdminAPIKeysFileFlagName = "rpc.admin-auth.api-keys-file"
+	AdminTLSCaCertFlagName   = "rpc.admin-auth.tls.ca"
+	AdminTLSCertFlagName     = "rpc.admin-auth.tls.cert"
+	AdminTLSKeyFlagName      = "rpc.admin-auth.tls.key"
 )
 
 var ErrInvalidPort = errors.New("invalid RPC port")
 
 func CLIFlags(envPrefix string) []cli.Flag {
-	return []cli.Flag{
+	return append([]cli.Flag{
 		&cli.StringFlag{
 			Name:    ListenAddrFlagName,
 			Usage:   "rpc listening address",
@@ -35,6 +41,41 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:   "Enable the admin API",
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_ADMIN"),
 		},
+	}, AdminAuthCLIFlags(envPrefix)...)
+}
+
+// AdminAuthCLIFlags returns the flags to authenticate admin RPC methods with API keys or mutual TLS.
+func AdminAuthCLIFlags(envPrefix string) []cli.Flag {
+	return AdminAuthCLIFlagsWithCategory(envPrefix, "")
+}
+
+// AdminAuthCLIFlagsWithCategory returns the admin authentication flags, in the given category.
+func AdminAuthCLIFlagsWithCategory(envPrefix string, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     AdminAPIKeysFileFlagName,
+			Usage:    "Path to a file with API keys, one per line, of which one has to be sent with the " + APIKeyHeader + " header to call admin RPC methods",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_ADMIN_AUTH_API_KEYS_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     AdminTLSCaCertFlagName,
+			Usage:    "Path to the CA certificate of the client certificates that may call admin RPC methods. Enables TLS for the RPC server, with the admin TLS certificate and key",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_ADMIN_AUTH_TLS_CA"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     AdminTLSCertFlagName,
+			Usage:    "Path to the TLS certificate of the RPC server, if admin RPC methods are authenticated with client certificates",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_ADMIN_AUTH_TLS_CERT"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     AdminTLSKeyFlagName,
+			Usage:    "Path to the TLS key of the RPC server, if admin RPC methods are authenticated with client certificates",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_ADMIN_AUTH_TLS_KEY"),
+			Category: category,
+		},
 	}
 }
 
@@ -42,6 +83,7 @@ type CLIConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	AdminAuth   AdminAuthCLIConfig
 }
 
 func DefaultCLIConfig() CLIConfig {
@@ -56,6 +98,9 @@ func (c CLIConfig) Check() error {
 	if c.ListenPort < 0 || c.ListenPort > math.MaxUint16 {
 		return ErrInvalidPort
 	}
+	if err := c.AdminAuth.Check(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -65,5 +110,17 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		ListenAddr:  ctx.String(ListenAddrFlagName),
 		ListenPort:  ctx.Int(PortFlagName),
 		EnableAdmin: ctx.Bool(EnableAdminFlagName),
+		AdminAuth:   ReadAdminAuthCLIConfig(ctx),
+	}
+}
+
+func ReadAdminAuthCLIConfig(ctx *cli.Context) AdminAuthCLIConfig {
+	return AdminAuthCLIConfig{
+		APIKeysFile: ctx.String(AdminAPIKeysFileFlagName),
+		TLS: optls.CLIConfig{
+			TLSCaCert: ctx.String(AdminTLSCaCertFlagName),
+			TLSCert:   ctx.String(AdminTLSCertFlagName),
+			TLSKey:    ctx.String(AdminTLSKeyFlagName),
+		},
 	}
 }
@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+// jwtExpiryTimeout is the allowed drift of the issued-at time of tokens, like the engine API allows.
+const jwtExpiryTimeout = 60 * time.Second
+
+// NewJWTHandler authenticates requests to the next handler with JWT tokens, like the engine API does,
+// accepting tokens signed with any of the accepted secrets, so secrets can be rotated without downtime.
+func NewJWTHandler(secrets *client.JWTSecrets, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var strToken string
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			strToken = strings.TrimPrefix(auth, "Bearer ")
+		}
+		if len(strToken) == 0 {
+			http.Error(w, "missing token", http.StatusUnauthorized)
+			return
+		}
+		claims, err := verifyJWT(strToken, secrets.Accepted())
+		switch {
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case !claims.VerifyExpiresAt(time.Now(), false): // optional
+			http.Error(w, "token is expired", http.StatusUnauthorized)
+		case claims.IssuedAt == nil:
+			http.Error(w, "missing issued-at", http.StatusUnauthorized)
+		case time.Since(claims.IssuedAt.Time) > jwtExpiryTimeout:
+			http.Error(w, "stale token", http.StatusUnauthorized)
+		case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
+			http.Error(w, "future token", http.StatusUnauthorized)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// verifyJWT verifies the HS256 signature of the token with each of the secrets,
+// and returns the claims of the token once a secret matches.
+// The claims are validated by the caller, to allow for some drift of the issued-at time.
+func verifyJWT(strToken string, secrets [][32]byte) (*jwt.RegisteredClaims, error) {
+	var lastErr error
+	for _, secret := range secrets {
+		var claims jwt.RegisteredClaims
+		token, err := jwt.ParseWithClaims(strToken, &claims, func(*jwt.Token) (any, error) {
+			return secret[:], nil
+		}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithoutClaimsValidation())
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if !token.Valid {
+			lastErr = jwt.ErrTokenUnverifiable
+			continue
+		}
+		return &claims, nil
+	}
+	return nil, lastErr
+}
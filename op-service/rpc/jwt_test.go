@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+func TestJWTHandler(t *testing.T) {
+	secrets := client.NewJWTSecrets([32]byte{1}, [32]byte{2})
+	handler := NewJWTHandler(secrets, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(auth func(h http.Header)) (int, string) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		auth(req.Header)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code, rec.Body.String()
+	}
+	signed := func(secret [32]byte, iat time.Time) func(h http.Header) {
+		return func(h http.Header) {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(iat)})
+			s, err := token.SignedString(secret[:])
+			require.NoError(t, err)
+			h.Set("Authorization", "Bearer "+s)
+		}
+	}
+
+	for _, secret := range [][32]byte{{1}, {2}} {
+		code, _ := serve(signed(secret, time.Now()))
+		require.Equal(t, http.StatusOK, code, "accepts all secrets")
+		code, _ = serve(func(h http.Header) { require.NoError(t, node.NewJWTAuth(secret)(h)) })
+		require.Equal(t, http.StatusOK, code, "accepts engine API tokens")
+	}
+	code, _ := serve(func(h http.Header) {})
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, _ = serve(signed([32]byte{3}, time.Now()))
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, body := serve(signed([32]byte{1}, time.Now().Add(-2*jwtExpiryTimeout)))
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.Contains(t, body, "stale token")
+	code, body = serve(signed([32]byte{1}, time.Now().Add(2*jwtExpiryTimeout)))
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.Contains(t, body, "future token")
+}
+
+func TestServerJWTSecrets(t *testing.T) {
+	server := NewServer("127.0.0.1", 0, "test",
+		WithAPIs([]rpc.API{{Namespace: "test", Service: new(testAPI)}}),
+		WithJWTSecrets(client.NewJWTSecrets([32]byte{2}, [32]byte{1})))
+	require.NoError(t, server.Start())
+	defer func() {
+		_ = server.Stop()
+	}()
+	url := fmt.Sprintf("http://%s", server.Endpoint())
+
+	for _, secret := range [][32]byte{{1}, {2}} {
+		cl, err := rpc.DialOptions(context.Background(), url,
+			rpc.WithHTTPAuth(client.NewJWTAuth(client.NewJWTSecrets(secret))))
+		require.NoError(t, err)
+		var res int
+		require.NoError(t, cl.Call(&res, "test_frobnicate", 2))
+		require.Equal(t, 4, res)
+		cl.Close()
+	}
+
+	cl, err := rpc.Dial(url)
+	require.NoError(t, err)
+	defer cl.Close()
+	var res int
+	require.ErrorContains(t, cl.Call(&res, "test_frobnicate", 2), "missing token")
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/health"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	optls "github.com/ethereum-optimism/optimism/op-service/tls"
@@ -26,17 +28,23 @@ type Server struct {
 	apis           []rpc.API
 	appVersion     string
 	healthzHandler http.Handler
+	readyzHandler  http.Handler
 	corsHosts      []string
 	vHosts         []string
 	jwtSecret      []byte
+	jwtSecrets     *client.JWTSecrets
 	rpcPath        string
 	healthzPath    string
+	readyzPath     string
 	httpRecorder   opmetrics.HTTPRecorder
+	rpcMetrics     opmetrics.RPCServerMetricer
 	httpServer     *http.Server
 	listener       net.Listener
 	log            log.Logger
 	tls            *ServerTLSConfig
 	middlewares    []Middleware
+	adminAuth      *AdminAuth
+	httpHandlers   map[string]http.Handler
 }
 
 type ServerTLSConfig struct {
@@ -60,6 +68,26 @@ func WithHealthzHandler(hdlr http.Handler) ServerOption {
 	}
 }
 
+// WithHealthChecks serves the liveness and readiness reports of the health checks on the healthz and readyz paths,
+// instead of the default healthz handler, which only reports the version.
+func WithHealthChecks(checks *health.Checks) ServerOption {
+	return func(b *Server) {
+		b.healthzHandler = checks.LivenessHandler()
+		b.readyzHandler = checks.ReadinessHandler()
+	}
+}
+
+// WithHTTPHandler serves the handler on the given path, next to the RPC endpoint. The handler is not subject to the
+// RPC authentication, and should only serve read-only data.
+func WithHTTPHandler(path string, hdlr http.Handler) ServerOption {
+	return func(b *Server) {
+		if b.httpHandlers == nil {
+			b.httpHandlers = make(map[string]http.Handler)
+		}
+		b.httpHandlers[path] = hdlr
+	}
+}
+
 func WithCORSHosts(hosts []string) ServerOption {
 	return func(b *Server) {
 		b.corsHosts = hosts
@@ -78,6 +106,14 @@ func WithJWTSecret(secret []byte) ServerOption {
 	}
 }
 
+// WithJWTSecrets authenticates requests with JWT tokens signed with any of the accepted secrets,
+// which may be rotated without restarting the server, see client.JWTSecrets.
+func WithJWTSecrets(secrets *client.JWTSecrets) ServerOption {
+	return func(b *Server) {
+		b.jwtSecrets = secrets
+	}
+}
+
 func WithRPCPath(path string) ServerOption {
 	return func(b *Server) {
 		b.rpcPath = path
@@ -90,12 +126,26 @@ func WithHealthzPath(path string) ServerOption {
 	}
 }
 
+func WithReadyzPath(path string) ServerOption {
+	return func(b *Server) {
+		b.readyzPath = path
+	}
+}
+
 func WithHTTPRecorder(recorder opmetrics.HTTPRecorder) ServerOption {
 	return func(b *Server) {
 		b.httpRecorder = recorder
 	}
 }
 
+// WithRPCMetrics records the JSON-RPC requests to the server by method,
+// see opmetrics.NewRPCServerMiddleware.
+func WithRPCMetrics(m opmetrics.RPCServerMetricer) ServerOption {
+	return func(b *Server) {
+		b.rpcMetrics = m
+	}
+}
+
 func WithLogger(lgr log.Logger) ServerOption {
 	return func(b *Server) {
 		b.log = lgr
@@ -128,6 +178,7 @@ func NewServer(host string, port int, appVersion string, opts ...ServerOption) *
 		vHosts:         wildcardHosts,
 		rpcPath:        "/",
 		healthzPath:    "/healthz",
+		readyzPath:     "/readyz",
 		httpRecorder:   opmetrics.NoopHTTPRecorder,
 		httpServer: &http.Server{
 			Addr: endpoint,
@@ -165,14 +216,29 @@ func (b *Server) Start() error {
 
 	// rpc middleware
 	var nodeHdlr http.Handler = srv
+	if b.rpcMetrics != nil {
+		nodeHdlr = opmetrics.NewRPCServerMiddleware(b.rpcMetrics, nodeHdlr)
+	}
 	for _, middleware := range b.middlewares {
 		nodeHdlr = middleware(nodeHdlr)
 	}
+	if b.adminAuth != nil {
+		nodeHdlr = NewAdminAuthHandler(b.adminAuth, nodeHdlr)
+	}
+	if b.jwtSecrets != nil {
+		nodeHdlr = NewJWTHandler(b.jwtSecrets, nodeHdlr)
+	}
 	nodeHdlr = node.NewHTTPHandlerStack(nodeHdlr, b.corsHosts, b.vHosts, b.jwtSecret)
 
 	mux := http.NewServeMux()
 	mux.Handle(b.rpcPath, nodeHdlr)
 	mux.Handle(b.healthzPath, b.healthzHandler)
+	if b.readyzHandler != nil {
+		mux.Handle(b.readyzPath, b.readyzHandler)
+	}
+	for path, hdlr := range b.httpHandlers {
+		mux.Handle(path, hdlr)
+	}
 
 	// http middleware
 	var handler http.Handler = mux
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/health"
 )
 
 type testAPI struct{}
@@ -69,3 +72,48 @@ func TestBaseServer(t *testing.T) {
 		require.Greater(t, port, 0)
 	})
 }
+
+func TestServerHealthChecks(t *testing.T) {
+	checks := health.NewChecks("test")
+	checks.Register("p2p", func(context.Context) health.Result { return health.Degraded("no peers") })
+	server := NewServer("127.0.0.1", 0, "test", WithHealthChecks(checks))
+	require.NoError(t, server.Start())
+	defer func() {
+		_ = server.Stop()
+	}()
+
+	get := func(path string) (int, string) {
+		res, err := http.Get(fmt.Sprintf("http://%s%s", server.endpoint, path))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, string(body)
+	}
+	code, body := get("/healthz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "{\"version\":\"test\",\"status\":\"ok\"}\n", body)
+
+	code, body = get("/readyz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "{\"version\":\"test\",\"status\":\"degraded\",\"checks\":{\"p2p\":{\"status\":\"degraded\",\"reason\":\"no peers\"}}}\n", body)
+}
+
+func TestServerHTTPHandler(t *testing.T) {
+	hdlr := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("status"))
+	})
+	server := NewServer("127.0.0.1", 0, "test", WithHTTPHandler("/status", hdlr))
+	require.NoError(t, server.Start())
+	defer func() {
+		_ = server.Stop()
+	}()
+
+	res, err := http.Get(fmt.Sprintf("http://%s/status", server.endpoint))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "status", string(body))
+}
@@ -0,0 +1,52 @@
+package signer
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BlockPayloadArgs represents the arguments to sign a message gossiped by the op-node, e.g. a L2 block payload.
+// Only the hash of the payload is sent, the signer computes the signing hash from the domain, chain ID and payload hash.
+type BlockPayloadArgs struct {
+	Domain      common.Hash  `json:"domain"`
+	ChainID     *hexutil.Big `json:"chainId"`
+	PayloadHash common.Hash  `json:"payloadHash"`
+	// SenderAddress is the address of the key to sign with, optional if the signer holds a single key.
+	SenderAddress *common.Address `json:"senderAddress,omitempty"`
+}
+
+// NewBlockPayloadArgs creates the arguments to sign the encoded payload.
+func NewBlockPayloadArgs(domain [32]byte, chainID *big.Int, payloadBytes []byte, senderAddress *common.Address) *BlockPayloadArgs {
+	return &BlockPayloadArgs{
+		Domain:        domain,
+		ChainID:       (*hexutil.Big)(chainID),
+		PayloadHash:   crypto.Keccak256Hash(payloadBytes),
+		SenderAddress: senderAddress,
+	}
+}
+
+func (args *BlockPayloadArgs) Check() error {
+	if args.ChainID == nil {
+		return errors.New("chainId not specified")
+	}
+	if args.ChainID.ToInt().BitLen() > 256 {
+		return errors.New("chainId is too large")
+	}
+	return nil
+}
+
+// ToSigningHash returns keccak256(domain ++ chain_id ++ payload_hash), the hash that is signed.
+func (args *BlockPayloadArgs) ToSigningHash() (common.Hash, error) {
+	if err := args.Check(); err != nil {
+		return common.Hash{}, err
+	}
+	var msgInput [32 + 32 + 32]byte
+	copy(msgInput[:32], args.Domain[:])
+	args.ChainID.ToInt().FillBytes(msgInput[32:64])
+	copy(msgInput[64:], args.PayloadHash[:])
+	return crypto.Keccak256Hash(msgInput[:]), nil
+}
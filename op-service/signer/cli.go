@@ -2,6 +2,8 @@ package signer
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/urfave/cli/v2"
 
@@ -10,10 +12,21 @@ import (
 )
 
 const (
-	EndpointFlagName = "signer.endpoint"
-	AddressFlagName  = "signer.address"
+	EndpointFlagName    = "signer.endpoint"
+	AddressFlagName     = "signer.address"
+	KMSProviderFlagName = "signer.kms-provider"
+	KMSKeyIDFlagName    = "signer.kms-key-id"
+	KMSRegionFlagName   = "signer.kms-region"
+	KMSEndpointFlagName = "signer.kms-endpoint"
 )
 
+const (
+	KMSProviderAWS = "aws"
+	KMSProviderGCP = "gcp"
+)
+
+var KMSProviders = []string{KMSProviderAWS, KMSProviderGCP}
+
 func CLIFlags(envPrefix string) []cli.Flag {
 	envPrefix += "_SIGNER"
 	flags := []cli.Flag{
@@ -27,6 +40,29 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:   "Address the signer is signing transactions for",
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "ADDRESS"),
 		},
+		&cli.StringFlag{
+			Name: KMSProviderFlagName,
+			Usage: fmt.Sprintf("Cloud KMS holding the signing key, instead of a remote signer endpoint. Options: %s. "+
+				"AWS credentials are resolved by the default credential chain of the AWS SDK, including IAM roles and IRSA. "+
+				"GCP credentials are resolved by the Application Default Credentials, including workload identity.",
+				strings.Join(KMSProviders, ", ")),
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "KMS_PROVIDER"),
+		},
+		&cli.StringFlag{
+			Name:    KMSKeyIDFlagName,
+			Usage:   "ID of the KMS signing key. The key ID, ARN or alias for AWS, or the resource name of the key version for GCP",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "KMS_KEY_ID"),
+		},
+		&cli.StringFlag{
+			Name:    KMSRegionFlagName,
+			Usage:   "Region of the AWS KMS key",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "KMS_REGION"),
+		},
+		&cli.StringFlag{
+			Name:    KMSEndpointFlagName,
+			Usage:   "Overrides the endpoint of the KMS API",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "KMS_ENDPOINT"),
+		},
 	}
 	flags = append(flags, optls.CLIFlagsWithFlagPrefix(envPrefix, "signer")...)
 	return flags
@@ -36,6 +72,12 @@ type CLIConfig struct {
 	Endpoint  string
 	Address   string
 	TLSConfig optls.CLIConfig
+
+	// KMSProvider is the cloud KMS holding the signing key. Empty if no KMS is used.
+	KMSProvider string
+	KMSKeyID    string
+	KMSRegion   string
+	KMSEndpoint string
 }
 
 func NewCLIConfig() CLIConfig {
@@ -48,6 +90,9 @@ func (c CLIConfig) Check() error {
 	if err := c.TLSConfig.Check(); err != nil {
 		return err
 	}
+	if c.KMSEnabled() {
+		return c.checkKMS()
+	}
 	if !((c.Endpoint == "" && c.Address == "") || (c.Endpoint != "" && c.Address != "")) {
 		return errors.New("signer endpoint and address must both be set or not set")
 	}
@@ -61,11 +106,40 @@ func (c CLIConfig) Enabled() bool {
 	return false
 }
 
+func (c CLIConfig) checkKMS() error {
+	switch c.KMSProvider {
+	case KMSProviderAWS:
+		if c.KMSRegion == "" {
+			return errors.New("signer KMS region must be set for AWS KMS")
+		}
+	case KMSProviderGCP:
+	default:
+		return fmt.Errorf("unknown signer KMS provider %q, options: %s", c.KMSProvider, strings.Join(KMSProviders, ", "))
+	}
+	if c.KMSKeyID == "" {
+		return errors.New("signer KMS key ID must be set")
+	}
+	if c.Endpoint != "" {
+		return errors.New("signer endpoint and KMS provider cannot both be set")
+	}
+	return nil
+}
+
+// KMSEnabled returns true if the signing key is held by a cloud KMS.
+// The address is optional with a KMS, since it is derived from the key.
+func (c CLIConfig) KMSEnabled() bool {
+	return c.KMSProvider != ""
+}
+
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	cfg := CLIConfig{
-		Endpoint:  ctx.String(EndpointFlagName),
-		Address:   ctx.String(AddressFlagName),
-		TLSConfig: optls.ReadCLIConfigWithPrefix(ctx, "signer"),
+		Endpoint:    ctx.String(EndpointFlagName),
+		Address:     ctx.String(AddressFlagName),
+		TLSConfig:   optls.ReadCLIConfigWithPrefix(ctx, "signer"),
+		KMSProvider: ctx.String(KMSProviderFlagName),
+		KMSKeyID:    ctx.String(KMSKeyIDFlagName),
+		KMSRegion:   ctx.String(KMSRegionFlagName),
+		KMSEndpoint: ctx.String(KMSEndpointFlagName),
 	}
 	return cfg
 }
@@ -38,6 +38,39 @@ func TestInvalidConfig(t *testing.T) {
 				config.Endpoint = "http://localhost"
 			},
 		},
+		{
+			name:     "UnknownKMSProvider",
+			expected: "unknown signer KMS provider",
+			configChange: func(config *CLIConfig) {
+				config.KMSProvider = "azure"
+				config.KMSKeyID = "key"
+			},
+		},
+		{
+			name:     "MissingKMSKeyID",
+			expected: "signer KMS key ID must be set",
+			configChange: func(config *CLIConfig) {
+				config.KMSProvider = KMSProviderGCP
+			},
+		},
+		{
+			name:     "MissingAWSKMSRegion",
+			expected: "signer KMS region must be set for AWS KMS",
+			configChange: func(config *CLIConfig) {
+				config.KMSProvider = KMSProviderAWS
+				config.KMSKeyID = "key"
+			},
+		},
+		{
+			name:     "KMSAndEndpoint",
+			expected: "signer endpoint and KMS provider cannot both be set",
+			configChange: func(config *CLIConfig) {
+				config.KMSProvider = KMSProviderGCP
+				config.KMSKeyID = "key"
+				config.Endpoint = "http://localhost"
+				config.Address = "0x1234"
+			},
+		},
 		{
 			name:     "InvalidTLSConfig",
 			expected: "all tls flags must be set if at least one is set",
@@ -56,6 +89,15 @@ func TestInvalidConfig(t *testing.T) {
 	}
 }
 
+func TestKMSConfig(t *testing.T) {
+	cfg := configForArgs("test", "--signer.kms-provider=aws", "--signer.kms-key-id=alias/batcher", "--signer.kms-region=us-east-1")
+	require.True(t, cfg.KMSEnabled())
+	require.False(t, cfg.Enabled())
+	require.Equal(t, "alias/batcher", cfg.KMSKeyID)
+	require.Equal(t, "us-east-1", cfg.KMSRegion)
+	require.NoError(t, cfg.Check(), "address is optional with a KMS")
+}
+
 func configForArgs(args ...string) CLIConfig {
 	app := cli.NewApp()
 	app.Flags = CLIFlags("TEST_")
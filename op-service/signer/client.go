@@ -113,3 +113,21 @@ func (s *SignerClient) SignTransaction(ctx context.Context, chainId *big.Int, fr
 
 	return &signed, nil
 }
+
+// SignBlockPayload signs the block payload with the remote signer,
+// and returns the signature in the 65 byte [R || S || V] format, with V being 0 or 1.
+func (s *SignerClient) SignBlockPayload(ctx context.Context, args *BlockPayloadArgs) ([65]byte, error) {
+	var result hexutil.Bytes
+	if err := s.client.CallContext(ctx, &result, "opsigner_signBlockPayload", args); err != nil {
+		return [65]byte{}, fmt.Errorf("opsigner_signBlockPayload failed: %w", err)
+	}
+	if len(result) != 65 {
+		return [65]byte{}, fmt.Errorf("invalid signature length %d", len(result))
+	}
+	sig := [65]byte(result)
+	// the signer may use the 27/28 V values of eth_sign
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	return sig, nil
+}
@@ -0,0 +1,59 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+type testHealthAPI struct{}
+
+func (testHealthAPI) Status() string {
+	return "ok"
+}
+
+type testOpsignerAPI struct {
+	t   *testing.T
+	kms *testKMS
+}
+
+func (a *testOpsignerAPI) SignBlockPayload(args BlockPayloadArgs) (hexutil.Bytes, error) {
+	hash, err := args.ToSigningHash()
+	require.NoError(a.t, err)
+	sig, err := crypto.Sign(hash[:], a.kms.key)
+	require.NoError(a.t, err)
+	sig[64] += 27 // like eth_sign
+	return sig, nil
+}
+
+func TestSignerClientSignBlockPayload(t *testing.T) {
+	kms := newTestKMS(t)
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("health", testHealthAPI{}))
+	require.NoError(t, server.RegisterName("opsigner", &testOpsignerAPI{t: t, kms: kms}))
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client, err := NewSignerClient(testlog.Logger(t, log.LevelInfo), srv.URL, optls.CLIConfig{})
+	require.NoError(t, err)
+
+	args := NewBlockPayloadArgs([32]byte{}, big.NewInt(10), []byte("payload"), nil)
+	sig, err := client.SignBlockPayload(context.Background(), args)
+	require.NoError(t, err)
+	require.Less(t, sig[64], byte(2), "v should be normalized")
+	hash, err := args.ToSigningHash()
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(hash[:], sig[:])
+	require.NoError(t, err)
+	require.Equal(t, kms.key.PublicKey, *pub)
+}
@@ -0,0 +1,268 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+
+	errSignatureKeyMismatch = errors.New("signature does not match the public key")
+)
+
+// KMSBackend is a cloud key management service holding a secp256k1 key, which never leaves the service.
+type KMSBackend interface {
+	// PublicKey returns the DER encoded SubjectPublicKeyInfo of the key.
+	PublicKey(ctx context.Context) ([]byte, error)
+	// SignDigest signs the 32 byte digest, and returns the DER encoded ECDSA signature.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// KMSSigner signs transactions with a key held by a KMSBackend.
+// Signatures returned by the KMS are normalized to the lower half of the curve order, as required by Ethereum,
+// and the recovery id, which the KMS does not provide, is derived from the public key of the key.
+type KMSSigner struct {
+	backend KMSBackend
+
+	mu     sync.Mutex
+	pubKey *ecdsa.PublicKey
+}
+
+func NewKMSSigner(backend KMSBackend) *KMSSigner {
+	return &KMSSigner{backend: backend}
+}
+
+// NewKMSSignerFromConfig creates a KMSSigner for the KMS key of the config, and checks that the key is usable.
+// If the config has an address, it must be the address of the key.
+func NewKMSSignerFromConfig(ctx context.Context, logger log.Logger, cfg CLIConfig) (*KMSSigner, error) {
+	var backend KMSBackend
+	switch cfg.KMSProvider {
+	case KMSProviderAWS:
+		aws, err := NewAWSKMS(ctx, cfg.KMSKeyID, cfg.KMSRegion, cfg.KMSEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		backend = aws
+	case KMSProviderGCP:
+		gcp, err := NewGCPKMS(ctx, cfg.KMSKeyID, cfg.KMSEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		backend = gcp
+	default:
+		return nil, fmt.Errorf("unknown KMS provider %q", cfg.KMSProvider)
+	}
+	s := NewKMSSigner(backend)
+	addr, err := s.Address(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load KMS key: %w", err)
+	}
+	if cfg.Address != "" && common.HexToAddress(cfg.Address) != addr {
+		return nil, fmt.Errorf("configured signer address %s does not match KMS key address %s", cfg.Address, addr)
+	}
+	logger.Info("Loaded KMS signing key", "provider", cfg.KMSProvider, "address", addr)
+	return s, nil
+}
+
+// PublicKey returns the public key of the KMS key. It is fetched once, and cached after.
+func (s *KMSSigner) PublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pubKey != nil {
+		return s.pubKey, nil
+	}
+	der, err := s.backend.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch public key: %w", err)
+	}
+	pubKey, err := parseSecp256k1PublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	s.pubKey = pubKey
+	return pubKey, nil
+}
+
+// Address returns the address of the KMS key.
+func (s *KMSSigner) Address(ctx context.Context) (common.Address, error) {
+	pubKey, err := s.PublicKey(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// SignDigest signs the digest with the KMS key,
+// and returns the signature in the 65 byte [R || S || V] format used by Ethereum, with V being 0 or 1.
+// If the signature does not match the cached public key, the key may have been rotated, e.g. by updating a key alias,
+// and the public key is fetched again.
+func (s *KMSSigner) SignDigest(ctx context.Context, digest common.Hash) ([]byte, error) {
+	pubKey, err := s.PublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	der, err := s.backend.SignDigest(ctx, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign digest: %w", err)
+	}
+	sig, err := toEthSignature(digest, der, crypto.FromECDSAPub(pubKey))
+	if !errors.Is(err, errSignatureKeyMismatch) {
+		return sig, err
+	}
+	s.mu.Lock()
+	if s.pubKey == pubKey {
+		s.pubKey = nil
+	}
+	s.mu.Unlock()
+	pubKey, err = s.PublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toEthSignature(digest, der, crypto.FromECDSAPub(pubKey))
+}
+
+// SignBlockPayload signs the block payload with the KMS key.
+// If the args specify a sender address, it must be the address of the KMS key.
+func (s *KMSSigner) SignBlockPayload(ctx context.Context, args *BlockPayloadArgs) ([65]byte, error) {
+	hash, err := args.ToSigningHash()
+	if err != nil {
+		return [65]byte{}, err
+	}
+	sig, err := s.SignDigest(ctx, hash)
+	if err != nil {
+		return [65]byte{}, err
+	}
+	if args.SenderAddress != nil {
+		addr, err := s.Address(ctx)
+		if err != nil {
+			return [65]byte{}, err
+		}
+		if addr != *args.SenderAddress {
+			return [65]byte{}, fmt.Errorf("attempting to sign for %s, but KMS key is for %s", *args.SenderAddress, addr)
+		}
+	}
+	return [65]byte(sig), nil
+}
+
+// SignTransaction signs the transaction with the KMS key, which must be the key of from.
+func (s *KMSSigner) SignTransaction(ctx context.Context, chainID *big.Int, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	addr, err := s.Address(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if addr != from {
+		return nil, fmt.Errorf("attempting to sign for %s, but KMS key is for %s", from, addr)
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	sig, err := s.SignDigest(ctx, signer.Hash(tx))
+	if err != nil {
+		return nil, err
+	}
+	signed, err := tx.WithSignature(signer, sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction signature: %w", err)
+	}
+	return signed, nil
+}
+
+// parseSecp256k1PublicKey parses a DER or PEM encoded SubjectPublicKeyInfo of a secp256k1 key.
+// The x509 package does not support the secp256k1 curve, so the key is parsed manually.
+func parseSecp256k1PublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	var spki struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}
+	if rest, err := asn1.Unmarshal(data, &spki); err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after public key")
+	}
+	if !spki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) || !spki.Algorithm.Parameters.Equal(oidCurveSecp256k1) {
+		return nil, fmt.Errorf("unsupported public key algorithm %v with parameters %v, expected secp256k1",
+			spki.Algorithm.Algorithm, spki.Algorithm.Parameters)
+	}
+	pubKey, err := crypto.UnmarshalPubkey(spki.PublicKey.RightAlign())
+	if err != nil {
+		return nil, fmt.Errorf("invalid secp256k1 public key: %w", err)
+	}
+	return pubKey, nil
+}
+
+// toEthSignature converts the DER encoded ECDSA signature of the digest into the 65 byte [R || S || V] format.
+// S is normalized to the lower half of the curve order, since Ethereum rejects malleable signatures,
+// and V is determined by finding which recovery id recovers the expected public key.
+func toEthSignature(digest common.Hash, der []byte, pubKey []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if rest, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after signature")
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.Cmp(secp256k1N) >= 0 || sig.S.Cmp(secp256k1N) >= 0 {
+		return nil, errors.New("signature values out of range")
+	}
+	s := sig.S
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+	out := make([]byte, crypto.SignatureLength)
+	sig.R.FillBytes(out[0:32])
+	s.FillBytes(out[32:64])
+	for v := byte(0); v < 2; v++ {
+		out[64] = v
+		recovered, err := crypto.Ecrecover(digest[:], out)
+		if err == nil && bytes.Equal(recovered, pubKey) {
+			return out, nil
+		}
+	}
+	return nil, errSignatureKeyMismatch
+}
+
+// doJSON makes the request to the KMS API, and decodes the JSON response into result.
+func doJSON(client *http.Client, req *http.Request, service string, result any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", service, err)
+	}
+	defer resp.Body.Close()
+	resBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", service, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s request failed with status %d: %s", service, resp.StatusCode, strings.TrimSpace(string(resBody)))
+	}
+	if err := json.Unmarshal(resBody, result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", service, err)
+	}
+	return nil
+}
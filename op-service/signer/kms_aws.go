@@ -0,0 +1,65 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// AWSKMS is a KMSBackend using an ECC_SECG_P256K1 key of AWS KMS.
+type AWSKMS struct {
+	keyID  string
+	client *kms.Client
+}
+
+var _ KMSBackend = (*AWSKMS)(nil)
+
+// NewAWSKMS creates an AWS KMS backend for the key with the given ID, ARN or alias.
+// Credentials are resolved by the default credential chain of the AWS SDK: the environment, the shared config
+// and credentials files, web identity tokens (IRSA), and the ECS task or EC2 instance role. Temporary credentials
+// are refreshed before they expire. The endpoint defaults to the regional KMS endpoint if empty.
+func NewAWSKMS(ctx context.Context, keyID, region, endpoint string) (*AWSKMS, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return NewAWSKMSFromConfig(keyID, endpoint, cfg), nil
+}
+
+// NewAWSKMSFromConfig creates an AWS KMS backend using the region, credentials and HTTP client of the AWS config.
+func NewAWSKMSFromConfig(keyID, endpoint string, cfg aws.Config) *AWSKMS {
+	client := kms.NewFromConfig(cfg, func(o *kms.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+	return &AWSKMS{keyID: keyID, client: client}
+}
+
+func (k *AWSKMS) PublicKey(ctx context.Context) ([]byte, error) {
+	res, err := k.client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(k.keyID)})
+	if err != nil {
+		return nil, fmt.Errorf("AWS KMS GetPublicKey request failed: %w", err)
+	}
+	if res.KeySpec != "" && res.KeySpec != kmstypes.KeySpecEccSecgP256k1 {
+		return nil, fmt.Errorf("unsupported key spec %q, expected %s", res.KeySpec, kmstypes.KeySpecEccSecgP256k1)
+	}
+	return res.PublicKey, nil
+}
+
+func (k *AWSKMS) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	res, err := k.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(k.keyID),
+		Message:          digest,
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("AWS KMS Sign request failed: %w", err)
+	}
+	return res.Signature, nil
+}
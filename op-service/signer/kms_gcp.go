@@ -0,0 +1,103 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	DefaultGCPKMSEndpoint = "https://cloudkms.googleapis.com"
+	// GCPKMSScope is the OAuth2 scope of the Cloud KMS API.
+	GCPKMSScope = "https://www.googleapis.com/auth/cloudkms"
+)
+
+// GCPKMS is a KMSBackend using an EC_SIGN_SECP256K1_SHA256 key version of GCP Cloud KMS.
+// Requests are authorized with the tokens of the token source, which are refreshed when they expire.
+type GCPKMS struct {
+	keyName  string
+	endpoint string
+	client   *http.Client
+}
+
+var _ KMSBackend = (*GCPKMS)(nil)
+
+// NewGCPKMS creates a GCP KMS backend for the key version with the given resource name,
+// e.g. projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1.
+// Credentials are found by the Application Default Credentials of the GCP SDK: the GOOGLE_APPLICATION_CREDENTIALS
+// file, the gcloud credentials, and the service account of the GCE instance or the GKE workload identity.
+// The endpoint defaults to the GCP endpoint if empty.
+func NewGCPKMS(ctx context.Context, keyName, endpoint string) (*GCPKMS, error) {
+	ts, err := google.DefaultTokenSource(ctx, GCPKMSScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find GCP credentials: %w", err)
+	}
+	return NewGCPKMSWithTokenSource(ctx, keyName, endpoint, ts), nil
+}
+
+// NewGCPKMSWithTokenSource creates a GCP KMS backend authorized by the tokens of the token source.
+// The HTTP client of the context, set with the oauth2.HTTPClient context key, is used for requests if any.
+func NewGCPKMSWithTokenSource(ctx context.Context, keyName, endpoint string, ts oauth2.TokenSource) *GCPKMS {
+	if endpoint == "" {
+		endpoint = DefaultGCPKMSEndpoint
+	}
+	return &GCPKMS{
+		keyName:  strings.Trim(keyName, "/"),
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		// Tokens are cached by the token source until shortly before they expire
+		client: oauth2.NewClient(ctx, oauth2.ReuseTokenSource(nil, ts)),
+	}
+}
+
+func (k *GCPKMS) PublicKey(ctx context.Context) ([]byte, error) {
+	var res struct {
+		Pem       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	if err := k.call(ctx, http.MethodGet, "/publicKey", nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Algorithm != "" && res.Algorithm != "EC_SIGN_SECP256K1_SHA256" {
+		return nil, fmt.Errorf("unsupported key algorithm %q, expected EC_SIGN_SECP256K1_SHA256", res.Algorithm)
+	}
+	// The PEM encoded key is decoded by parseSecp256k1PublicKey
+	return []byte(res.Pem), nil
+}
+
+func (k *GCPKMS) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var res struct {
+		Signature []byte `json:"signature"`
+	}
+	req := map[string]any{"digest": map[string][]byte{"sha256": digest}}
+	if err := k.call(ctx, http.MethodPost, ":asymmetricSign", req, &res); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// call makes a request to the REST API of the key version. Byte slices are base64 encoded in JSON, as the API expects.
+func (k *GCPKMS) call(ctx context.Context, method string, suffix string, params any, result any) error {
+	var body io.Reader
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, k.endpoint+"/v1/"+k.keyName+suffix, body)
+	if err != nil {
+		return err
+	}
+	if params != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return doJSON(k.client, req, "GCP KMS", result)
+}
@@ -0,0 +1,394 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// testKMS is a KMSBackend with a local key, which returns high-s signatures if highS is set.
+type testKMS struct {
+	key       *ecdsa.PrivateKey
+	highS     bool
+	pubKeyReq atomic.Int32
+}
+
+func newTestKMS(t *testing.T) *testKMS {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &testKMS{key: key}
+}
+
+func (k *testKMS) PublicKey(ctx context.Context) ([]byte, error) {
+	k.pubKeyReq.Add(1)
+	return marshalTestSPKI(&k.key.PublicKey, oidCurveSecp256k1)
+}
+
+func (k *testKMS) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	sig, err := crypto.Sign(digest, k.key)
+	if err != nil {
+		return nil, err
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if k.highS {
+		s.Sub(secp256k1N, s)
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{r, s})
+}
+
+func marshalTestSPKI(pubKey *ecdsa.PublicKey, curve asn1.ObjectIdentifier) ([]byte, error) {
+	point := crypto.FromECDSAPub(pubKey)
+	return asn1.Marshal(struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}{
+		Algorithm: struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}{oidPublicKeyECDSA, curve},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+}
+
+func TestKMSSignerSignDigest(t *testing.T) {
+	ctx := context.Background()
+	for _, highS := range []bool{false, true} {
+		kms := newTestKMS(t)
+		kms.highS = highS
+		s := NewKMSSigner(kms)
+
+		addr, err := s.Address(ctx)
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(kms.key.PublicKey), addr)
+
+		for i := 0; i < 10; i++ {
+			digest := crypto.Keccak256Hash([]byte{byte(i)})
+			sig, err := s.SignDigest(ctx, digest)
+			require.NoError(t, err)
+			require.Len(t, sig, crypto.SignatureLength)
+			require.LessOrEqual(t, new(big.Int).SetBytes(sig[32:64]).Cmp(secp256k1HalfN), 0, "s must be normalized")
+			require.True(t, crypto.ValidateSignatureValues(sig[64], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64]), true))
+			recovered, err := crypto.SigToPub(digest[:], sig)
+			require.NoError(t, err)
+			require.Equal(t, addr, crypto.PubkeyToAddress(*recovered))
+		}
+		require.EqualValues(t, 1, kms.pubKeyReq.Load(), "public key should be cached")
+	}
+}
+
+func TestKMSSignerSignTransaction(t *testing.T) {
+	ctx := context.Background()
+	kms := newTestKMS(t)
+	kms.highS = true
+	s := NewKMSSigner(kms)
+	from := crypto.PubkeyToAddress(kms.key.PublicKey)
+	chainID := big.NewInt(10)
+
+	sidecar := &types.BlobTxSidecar{Blobs: []kzg4844.Blob{{}}, Commitments: []kzg4844.Commitment{{}}, Proofs: []kzg4844.Proof{{}}}
+	tx := types.NewTx(&types.BlobTx{
+		ChainID:    uint256.NewInt(10),
+		Nonce:      1,
+		Gas:        21000,
+		GasFeeCap:  uint256.NewInt(100),
+		GasTipCap:  uint256.NewInt(1),
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	signed, err := s.SignTransaction(ctx, chainID, from, tx)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+	require.NotNil(t, signed.BlobTxSidecar(), "blob sidecar should be kept")
+
+	_, err = s.SignTransaction(ctx, chainID, common.Address{0xaa}, tx)
+	require.ErrorContains(t, err, "attempting to sign for")
+}
+
+func TestKMSSignerSignBlockPayload(t *testing.T) {
+	ctx := context.Background()
+	kms := newTestKMS(t)
+	s := NewKMSSigner(kms)
+	from := crypto.PubkeyToAddress(kms.key.PublicKey)
+
+	args := NewBlockPayloadArgs([32]byte{1}, big.NewInt(10), []byte("payload"), &from)
+	sig, err := s.SignBlockPayload(ctx, args)
+	require.NoError(t, err)
+	hash, err := args.ToSigningHash()
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(hash[:], sig[:])
+	require.NoError(t, err)
+	require.Equal(t, from, crypto.PubkeyToAddress(*pub))
+
+	args.SenderAddress = &common.Address{0xaa}
+	_, err = s.SignBlockPayload(ctx, args)
+	require.ErrorContains(t, err, "attempting to sign for")
+}
+
+func TestKMSSignerKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	kms := newTestKMS(t)
+	s := NewKMSSigner(kms)
+	_, err := s.Address(ctx)
+	require.NoError(t, err)
+
+	// rotate the key behind the signer, e.g. by pointing a key alias to a new key
+	newKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	kms.key = newKey
+
+	digest := crypto.Keccak256Hash([]byte("hello"))
+	sig, err := s.SignDigest(ctx, digest)
+	require.NoError(t, err)
+	recovered, err := crypto.SigToPub(digest[:], sig)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(newKey.PublicKey), crypto.PubkeyToAddress(*recovered))
+	addr, err := s.Address(ctx)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(newKey.PublicKey), addr, "rotated public key should be cached")
+	require.EqualValues(t, 2, kms.pubKeyReq.Load())
+}
+
+func TestKMSSignerInvalidKMSResponses(t *testing.T) {
+	ctx := context.Background()
+	kms := newTestKMS(t)
+
+	t.Run("WrongCurve", func(t *testing.T) {
+		spki, err := marshalTestSPKI(&kms.key.PublicKey, asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+		require.NoError(t, err)
+		_, err = parseSecp256k1PublicKey(spki)
+		require.ErrorContains(t, err, "expected secp256k1")
+	})
+
+	t.Run("PEM", func(t *testing.T) {
+		spki, err := marshalTestSPKI(&kms.key.PublicKey, oidCurveSecp256k1)
+		require.NoError(t, err)
+		pubKey, err := parseSecp256k1PublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki}))
+		require.NoError(t, err)
+		require.Equal(t, kms.key.PublicKey, *pubKey)
+	})
+
+	t.Run("SignatureOfOtherKey", func(t *testing.T) {
+		other := newTestKMS(t)
+		digest := crypto.Keccak256Hash([]byte("hello"))
+		der, err := other.SignDigest(ctx, digest[:])
+		require.NoError(t, err)
+		_, err = toEthSignature(digest, der, crypto.FromECDSAPub(&kms.key.PublicKey))
+		require.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("SignatureOutOfRange", func(t *testing.T) {
+		der, err := asn1.Marshal(struct{ R, S *big.Int }{big.NewInt(1), secp256k1N})
+		require.NoError(t, err)
+		_, err = toEthSignature(common.Hash{}, der, crypto.FromECDSAPub(&kms.key.PublicKey))
+		require.ErrorContains(t, err, "out of range")
+	})
+
+	t.Run("PublicKeyError", func(t *testing.T) {
+		s := NewKMSSigner(&failingKMS{})
+		_, err := s.Address(ctx)
+		require.ErrorContains(t, err, "failed to fetch public key")
+	})
+}
+
+type failingKMS struct{}
+
+func (failingKMS) PublicKey(ctx context.Context) ([]byte, error) {
+	return nil, errors.New("boom")
+}
+
+func (failingKMS) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	return nil, errors.New("boom")
+}
+
+func TestAWSKMS(t *testing.T) {
+	ctx := context.Background()
+	kms := newTestKMS(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/us-east-1/kms/aws4_request") ||
+			r.Header.Get("X-Amz-Security-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"__type":"AccessDeniedException","message":"bad auth"}`))
+			return
+		}
+		var req struct {
+			KeyId            string
+			Message          []byte
+			MessageType      string
+			SigningAlgorithm string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "alias/batcher", req.KeyId)
+		var res any
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			spki, err := kms.PublicKey(ctx)
+			require.NoError(t, err)
+			res = map[string]any{"PublicKey": spki, "KeySpec": "ECC_SECG_P256K1"}
+		case "TrentService.Sign":
+			require.Equal(t, "DIGEST", req.MessageType)
+			require.Equal(t, "ECDSA_SHA_256", req.SigningAlgorithm)
+			sig, err := kms.SignDigest(ctx, req.Message)
+			require.NoError(t, err)
+			res = map[string]any{"Signature": sig}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	defer srv.Close()
+
+	cfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "secret", "token"),
+		HTTPClient:  srv.Client(),
+	}
+	s := NewKMSSigner(NewAWSKMSFromConfig("alias/batcher", srv.URL, cfg))
+	addr, err := s.Address(ctx)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(kms.key.PublicKey), addr)
+	digest := crypto.Keccak256Hash([]byte("hello"))
+	sig, err := s.SignDigest(ctx, digest)
+	require.NoError(t, err)
+	recovered, err := crypto.SigToPub(digest[:], sig)
+	require.NoError(t, err)
+	require.Equal(t, addr, crypto.PubkeyToAddress(*recovered))
+
+	cfg.Credentials = credentials.NewStaticCredentialsProvider("other", "secret", "")
+	badCreds := NewKMSSigner(NewAWSKMSFromConfig("alias/batcher", srv.URL, cfg))
+	_, err = badCreds.Address(ctx)
+	require.ErrorContains(t, err, "AccessDeniedException")
+}
+
+func TestGCPKMS(t *testing.T) {
+	ctx := context.Background()
+	kms := newTestKMS(t)
+	keyName := "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+	var tokenReqs atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var res any
+		switch r.URL.Path {
+		case "/v1/" + keyName + "/publicKey":
+			spki, err := kms.PublicKey(ctx)
+			require.NoError(t, err)
+			res = map[string]string{
+				"pem":       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki})),
+				"algorithm": "EC_SIGN_SECP256K1_SHA256",
+			}
+		case "/v1/" + keyName + ":asymmetricSign":
+			var req struct {
+				Digest struct {
+					Sha256 string `json:"sha256"`
+				} `json:"digest"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			digest, err := base64.StdEncoding.DecodeString(req.Digest.Sha256)
+			require.NoError(t, err)
+			sig, err := kms.SignDigest(ctx, digest)
+			require.NoError(t, err)
+			res = map[string][]byte{"signature": sig}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	defer srv.Close()
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, srv.Client())
+	ts := tokenSourceFunc(func() (*oauth2.Token, error) {
+		tokenReqs.Add(1)
+		return &oauth2.Token{AccessToken: "valid-token", Expiry: time.Now().Add(time.Hour)}, nil
+	})
+	s := NewKMSSigner(NewGCPKMSWithTokenSource(ctx, keyName, srv.URL, ts))
+	addr, err := s.Address(ctx)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(kms.key.PublicKey), addr)
+	for i := 0; i < 3; i++ {
+		digest := crypto.Keccak256Hash([]byte{byte(i)})
+		sig, err := s.SignDigest(ctx, digest)
+		require.NoError(t, err)
+		recovered, err := crypto.SigToPub(digest[:], sig)
+		require.NoError(t, err)
+		require.Equal(t, addr, crypto.PubkeyToAddress(*recovered))
+	}
+	require.EqualValues(t, 1, tokenReqs.Load(), "access token should be cached")
+
+	invalid := NewKMSSigner(NewGCPKMSWithTokenSource(ctx, keyName, srv.URL,
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "invalid-token"})))
+	_, err = invalid.Address(ctx)
+	require.ErrorContains(t, err, "status 401")
+}
+
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+func TestNewKMSSignerFromConfig(t *testing.T) {
+	kms := newTestKMS(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		spki, err := kms.PublicKey(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"PublicKey": spki}))
+	}))
+	defer srv.Close()
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	// Isolate the test from the shared config of the environment
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	cfg := NewCLIConfig()
+	cfg.KMSProvider = KMSProviderAWS
+	cfg.KMSKeyID = "key"
+	cfg.KMSRegion = "us-east-1"
+	cfg.KMSEndpoint = srv.URL
+	s, err := NewKMSSignerFromConfig(context.Background(), logger, cfg)
+	require.NoError(t, err)
+	addr, err := s.Address(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(kms.key.PublicKey), addr)
+
+	cfg.Address = common.Address{0xaa}.Hex()
+	_, err = NewKMSSignerFromConfig(context.Background(), logger, cfg)
+	require.ErrorContains(t, err, "does not match")
+}
@@ -0,0 +1,223 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// blobSidecarFileSize is the size of a cached blob sidecar file: the KZG commitment and proof, followed by the blob.
+const blobSidecarFileSize = 48 + 48 + eth.BlobSize
+
+// BlobSidecarCache caches blob sidecars on disk, keyed by slot and blob index.
+// Cached sidecars don't have to be fetched again, e.g. after a reset of the derivation pipeline,
+// and remain available after the beacon nodes pruned them.
+// Each slot is a directory, with a file per blob index. The cache holds up to a maximum number of blobs,
+// and evicts the least recently used blobs beyond that. The recency of the blobs is kept in the modification
+// time of their files, so it is restored when the cache is reopened.
+type BlobSidecarCache struct {
+	log log.Logger
+	dir string
+
+	mu      sync.Mutex
+	entries *simplelru.LRU[blobCacheKey, struct{}]
+}
+
+type blobCacheKey struct {
+	slot  uint64
+	index uint64
+}
+
+// NewBlobSidecarCache returns a cache of up to maxBlobs blob sidecars in the given directory,
+// which is created if it doesn't exist. Blobs that are cached in the directory already are loaded,
+// and the least recently used ones are removed if there are more than maxBlobs.
+func NewBlobSidecarCache(log log.Logger, dir string, maxBlobs int) (*BlobSidecarCache, error) {
+	if maxBlobs <= 0 {
+		return nil, fmt.Errorf("blob sidecar cache size must be positive: %d", maxBlobs)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob sidecar cache dir: %w", err)
+	}
+	c := &BlobSidecarCache{log: log, dir: dir}
+	entries, err := simplelru.NewLRU[blobCacheKey, struct{}](maxBlobs, c.onEvict)
+	if err != nil {
+		return nil, err
+	}
+	c.entries = entries
+	if err := c.load(); err != nil {
+		return nil, fmt.Errorf("failed to load blob sidecar cache: %w", err)
+	}
+	return c, nil
+}
+
+// load adds the blobs in the cache dir to the cache, from the least to the most recently used.
+func (c *BlobSidecarCache) load() error {
+	type cachedBlob struct {
+		key     blobCacheKey
+		modTime time.Time
+	}
+	var blobs []cachedBlob
+	slots, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	for _, slotDir := range slots {
+		slot, err := strconv.ParseUint(slotDir.Name(), 10, 64)
+		if err != nil || !slotDir.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(c.dir, slotDir.Name()))
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			if strings.HasPrefix(f.Name(), ".tmp-") {
+				// left behind by an interrupted write
+				_ = os.Remove(filepath.Join(c.dir, slotDir.Name(), f.Name()))
+				continue
+			}
+			index, err := strconv.ParseUint(f.Name(), 10, 64)
+			if err != nil {
+				continue
+			}
+			info, err := f.Info()
+			if err != nil {
+				return err
+			}
+			blobs = append(blobs, cachedBlob{key: blobCacheKey{slot: slot, index: index}, modTime: info.ModTime()})
+		}
+		if len(files) == 0 {
+			_ = os.Remove(filepath.Join(c.dir, slotDir.Name()))
+		}
+	}
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].modTime.Before(blobs[j].modTime) })
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, b := range blobs {
+		c.entries.Add(b.key, struct{}{})
+	}
+	return nil
+}
+
+// onEvict removes the file of a blob that is evicted from the cache, and the directory of its slot if it is empty.
+func (c *BlobSidecarCache) onEvict(key blobCacheKey, _ struct{}) {
+	path := c.path(key.slot, key.index)
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		c.log.Warn("Failed to remove evicted blob sidecar", "slot", key.slot, "index", key.index, "err", err)
+	}
+	// fails if other blobs of the slot are still cached
+	_ = os.Remove(filepath.Dir(path))
+}
+
+// Len returns the number of cached blobs.
+func (c *BlobSidecarCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+func (c *BlobSidecarCache) path(slot uint64, index uint64) string {
+	return filepath.Join(c.dir, strconv.FormatUint(slot, 10), strconv.FormatUint(index, 10))
+}
+
+// Get returns the cached sidecar of the blob with the given versioned hash and index in the slot,
+// or nil if it is not cached. Cached sidecars are verified, and invalid entries are removed.
+func (c *BlobSidecarCache) Get(slot uint64, h eth.IndexedBlobHash) *eth.BlobSidecar {
+	key := blobCacheKey{slot: slot, index: h.Index}
+	c.mu.Lock()
+	_, ok := c.entries.Get(key)
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	path := c.path(slot, h.Index)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil // evicted concurrently
+	} else if err != nil {
+		c.log.Warn("Failed to read cached blob sidecar", "slot", slot, "index", h.Index, "err", err)
+		return nil
+	}
+	sidecar, err := decodeCachedBlobSidecar(h, data)
+	if err != nil {
+		c.log.Warn("Removing invalid cached blob sidecar", "slot", slot, "index", h.Index, "err", err)
+		c.mu.Lock()
+		c.entries.Remove(key)
+		c.mu.Unlock()
+		return nil
+	}
+	// keep the recency of the blob when the cache is reopened
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		c.log.Debug("Failed to update the modification time of cached blob sidecar", "slot", slot, "index", h.Index, "err", err)
+	}
+	return sidecar
+}
+
+// Put caches the sidecar of a blob in the slot, evicting the least recently used blob if the cache is full.
+// Errors are logged, since caching is best-effort.
+func (c *BlobSidecarCache) Put(slot uint64, sidecar *eth.BlobSidecar) {
+	if err := c.put(slot, sidecar); err != nil {
+		c.log.Warn("Failed to cache blob sidecar", "slot", slot, "index", sidecar.Index, "err", err)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.Add(blobCacheKey{slot: slot, index: uint64(sidecar.Index)}, struct{}{})
+}
+
+func (c *BlobSidecarCache) put(slot uint64, sidecar *eth.BlobSidecar) error {
+	path := c.path(slot, uint64(sidecar.Index))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data := make([]byte, 0, blobSidecarFileSize)
+	data = append(data, sidecar.KZGCommitment[:]...)
+	data = append(data, sidecar.KZGProof[:]...)
+	data = append(data, sidecar.Blob[:]...)
+	// write to a temporary file first, so partially written sidecars are never read
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// decodeCachedBlobSidecar decodes a cached sidecar, and verifies it against the versioned hash of the blob.
+func decodeCachedBlobSidecar(h eth.IndexedBlobHash, data []byte) (*eth.BlobSidecar, error) {
+	if len(data) != blobSidecarFileSize {
+		return nil, fmt.Errorf("unexpected size %d", len(data))
+	}
+	sidecar := &eth.BlobSidecar{Index: eth.Uint64String(h.Index)}
+	copy(sidecar.KZGCommitment[:], data[:48])
+	copy(sidecar.KZGProof[:], data[48:96])
+	copy(sidecar.Blob[:], data[96:])
+	if hash := eth.KZGToVersionedHash(kzg4844.Commitment(sidecar.KZGCommitment)); hash != h.Hash {
+		return nil, fmt.Errorf("expected hash %s but got %s", h.Hash, hash)
+	}
+	if err := eth.VerifyBlobProof(&sidecar.Blob, kzg4844.Commitment(sidecar.KZGCommitment), kzg4844.Proof(sidecar.KZGProof)); err != nil {
+		return nil, fmt.Errorf("failed verification: %w", err)
+	}
+	return sidecar, nil
+}
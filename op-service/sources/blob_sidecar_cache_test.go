@@ -0,0 +1,103 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/mocks"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestBlobSidecarCache(t *testing.T) {
+	c, err := NewBlobSidecarCache(testlog.Logger(t, log.LevelInfo), t.TempDir(), 16)
+	require.NoError(t, err)
+	index0, sidecar0 := makeTestBlobSidecar(3)
+	index1, sidecar1 := makeTestBlobSidecar(5)
+
+	require.Nil(t, c.Get(7, index0))
+	c.Put(7, sidecar0)
+	c.Put(7, sidecar1)
+	require.Equal(t, sidecar0, c.Get(7, index0))
+	require.Equal(t, sidecar1, c.Get(7, index1))
+	require.Nil(t, c.Get(8, index0), "keyed by slot")
+
+	// invalid entries are removed
+	require.NoError(t, os.WriteFile(c.path(7, index0.Index), []byte("corrupt"), 0o644))
+	require.Nil(t, c.Get(7, index0))
+	require.NoFileExists(t, c.path(7, index0.Index))
+
+	c.Put(9, sidecar1)
+	wrongHash := index1
+	wrongHash.Hash[3]++
+	require.Nil(t, c.Get(9, wrongHash))
+	require.NoFileExists(t, c.path(9, index1.Index))
+}
+
+func TestBlobSidecarCacheEviction(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	_, err := NewBlobSidecarCache(logger, dir, 0)
+	require.ErrorContains(t, err, "must be positive")
+
+	c, err := NewBlobSidecarCache(logger, dir, 2)
+	require.NoError(t, err)
+	index, sidecar := makeTestBlobSidecar(3)
+	c.Put(1, sidecar)
+	c.Put(2, sidecar)
+	// slot 1 becomes the most recently used blob
+	require.NotNil(t, c.Get(1, index))
+	c.Put(3, sidecar)
+	require.Equal(t, 2, c.Len())
+	require.Nil(t, c.Get(2, index), "least recently used blob is evicted")
+	require.NoFileExists(t, c.path(2, index.Index))
+	require.NoDirExists(t, filepath.Dir(c.path(2, index.Index)), "empty slot dirs are removed")
+
+	// the recency is restored from the files when the cache is reopened
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(c.path(3, index.Index), past, past))
+	c, err = NewBlobSidecarCache(logger, dir, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, c.Len())
+	require.NotNil(t, c.Get(1, index))
+	require.Nil(t, c.Get(3, index))
+	require.NoFileExists(t, c.path(3, index.Index))
+}
+
+func TestBeaconClientCache(t *testing.T) {
+	index0, sidecar0 := makeTestBlobSidecar(5)
+	index1, sidecar1 := makeTestBlobSidecar(7)
+	hashes := []eth.IndexedBlobHash{index0, index1}
+	sidecars := []*eth.BlobSidecar{sidecar0, sidecar1}
+
+	cache, err := NewBlobSidecarCache(testlog.Logger(t, log.LevelInfo), t.TempDir(), 16)
+	require.NoError(t, err)
+	ctx := context.Background()
+	p := mocks.NewBeaconClient(t)
+	archiver := mocks.NewBlobSideCarsFetcher(t)
+	c := NewL1BeaconClient(p, L1BeaconClientConfig{Cache: cache}, archiver)
+	p.EXPECT().BeaconGenesis(ctx).Return(eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: 10}}, nil)
+	p.EXPECT().ConfigSpec(ctx).Return(eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 2}}, nil)
+	// the slot was pruned by the beacon node, so the blobs are fetched from the archiver
+	p.EXPECT().BeaconBlobSideCars(ctx, false, uint64(1), hashes).Return(eth.APIGetBlobSidecarsResponse{}, errors.New("404 not found")).Once()
+	archiver.EXPECT().BeaconBlobSideCars(ctx, false, uint64(1), hashes).Return(eth.APIGetBlobSidecarsResponse{Data: toAPISideCars(sidecars)}, nil).Once()
+
+	resp, err := c.GetBlobSidecars(ctx, eth.L1BlockRef{Time: 12}, hashes)
+	require.NoError(t, err)
+	require.Equal(t, sidecars, resp)
+
+	// served from the cache, in the order of the hashes
+	resp, err = c.GetBlobSidecars(ctx, eth.L1BlockRef{Time: 12}, []eth.IndexedBlobHash{index1, index0})
+	require.NoError(t, err)
+	require.Equal(t, []*eth.BlobSidecar{sidecar1, sidecar0}, resp)
+	blobs, err := c.GetBlobs(ctx, eth.L1BlockRef{Time: 12}, hashes)
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+}
@@ -0,0 +1,147 @@
+package caching
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Invalidator is a cache of which the entries of a block can be removed by block hash.
+type Invalidator interface {
+	Remove(blockHash common.Hash) bool
+}
+
+type ancestryNode struct {
+	number uint64
+	parent common.Hash
+}
+
+// Ancestry is a small index of the parent-child relations of recently cached blocks, and of the canonical block
+// per block number. When a different block is observed to be canonical at a number, the previous canonical block
+// and all of its known descendants were reorged out, and their entries are removed from the tracked caches.
+// This prevents caches keyed by block hash from serving data of reorged-out blocks until LRU eviction.
+type Ancestry struct {
+	mu sync.Mutex
+
+	// size is the number of block numbers, below the highest block, to track blocks for.
+	size uint64
+
+	blocks    map[common.Hash]ancestryNode
+	children  map[common.Hash][]common.Hash
+	canonical map[uint64]common.Hash
+	highest   uint64
+
+	caches []Invalidator
+}
+
+// NewAncestry creates an ancestry index, tracking the blocks of the last size block numbers.
+func NewAncestry(size int) *Ancestry {
+	return &Ancestry{
+		size:      uint64(max(size, 1)),
+		blocks:    make(map[common.Hash]ancestryNode),
+		children:  make(map[common.Hash][]common.Hash),
+		canonical: make(map[uint64]common.Hash),
+	}
+}
+
+// Track registers caches of which the entries of reorged-out blocks are removed.
+func (a *Ancestry) Track(caches ...Invalidator) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.caches = append(a.caches, caches...)
+}
+
+// AddBlock records the parent of a block, that may or may not be canonical.
+func (a *Ancestry) AddBlock(number uint64, hash common.Hash, parent common.Hash) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.addBlock(number, hash, parent)
+}
+
+// AddCanonical records the block as canonical, e.g. as it was fetched by number or label, together with its parent.
+// The entries of the blocks that it reorged out, and their descendants, are removed from the tracked caches.
+// It returns the hashes of the invalidated blocks.
+func (a *Ancestry) AddCanonical(number uint64, hash common.Hash, parent common.Hash) []common.Hash {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.addBlock(number, hash, parent)
+	var reorged []common.Hash
+	// walk back until the canonical chain matches, or the index does not know an ancestor
+	for {
+		if prev, ok := a.canonical[number]; ok && prev != hash {
+			reorged = append(reorged, a.removeDescendants(prev)...)
+		}
+		a.canonical[number] = hash
+		node, ok := a.blocks[hash]
+		if !ok || number == 0 {
+			break
+		}
+		if prev, ok := a.canonical[number-1]; !ok || prev == node.parent {
+			break
+		}
+		number, hash = number-1, node.parent
+	}
+	for _, h := range reorged {
+		for _, c := range a.caches {
+			c.Remove(h)
+		}
+	}
+	return reorged
+}
+
+func (a *Ancestry) addBlock(number uint64, hash common.Hash, parent common.Hash) {
+	if _, ok := a.blocks[hash]; ok {
+		return
+	}
+	if number+a.size <= a.highest {
+		return // too old to track
+	}
+	a.blocks[hash] = ancestryNode{number: number, parent: parent}
+	a.children[parent] = append(a.children[parent], hash)
+	if number > a.highest {
+		a.highest = number
+	}
+	if uint64(len(a.blocks)) > 2*a.size {
+		a.prune()
+	}
+}
+
+// removeDescendants removes the block and all its known descendants from the index, and returns their hashes.
+func (a *Ancestry) removeDescendants(hash common.Hash) []common.Hash {
+	out := []common.Hash{hash}
+	queue := []common.Hash{hash}
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		if node, ok := a.blocks[h]; ok {
+			if a.canonical[node.number] == h {
+				delete(a.canonical, node.number)
+			}
+			delete(a.blocks, h)
+		}
+		for _, child := range a.children[h] {
+			// children may have been removed already, by an earlier reorg
+			if _, ok := a.blocks[child]; ok {
+				out = append(out, child)
+				queue = append(queue, child)
+			}
+		}
+		delete(a.children, h)
+	}
+	return out
+}
+
+// prune removes the blocks that are too old to track.
+func (a *Ancestry) prune() {
+	for h, node := range a.blocks {
+		if node.number+a.size <= a.highest {
+			delete(a.blocks, h)
+			delete(a.children, node.parent)
+		}
+	}
+	for n := range a.canonical {
+		if n+a.size <= a.highest {
+			delete(a.canonical, n)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package caching
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAncestry(t *testing.T) {
+	cache := NewLRUCache[common.Hash, uint64](nil, "test", 100)
+	a := NewAncestry(10)
+	a.Track(cache)
+	add := func(number uint64, hash byte, parent byte, canonical bool) []common.Hash {
+		cache.Add(common.Hash{hash}, number)
+		if canonical {
+			return a.AddCanonical(number, common.Hash{hash}, common.Hash{parent})
+		}
+		a.AddBlock(number, common.Hash{hash}, common.Hash{parent})
+		return nil
+	}
+	cached := func(hash byte) bool {
+		_, ok := cache.Get(common.Hash{hash})
+		return ok
+	}
+
+	// chain 1 <- 2 <- 3 <- 4, with 4 only fetched by hash, and a side block 5 on top of 2
+	require.Empty(t, add(1, 0x01, 0x00, true))
+	require.Empty(t, add(2, 0x02, 0x01, true))
+	require.Empty(t, add(3, 0x03, 0x02, true))
+	add(4, 0x04, 0x03, false)
+	add(3, 0x05, 0x02, false)
+
+	t.Run("same block", func(t *testing.T) {
+		require.Empty(t, add(3, 0x03, 0x02, true))
+	})
+	t.Run("reorg", func(t *testing.T) {
+		// 3 is replaced by 6, so 3 and its descendant 4 are reorged out, and the side block 5 is unaffected
+		require.ElementsMatch(t, []common.Hash{{0x03}, {0x04}}, add(3, 0x06, 0x02, true))
+		require.False(t, cached(0x03))
+		require.False(t, cached(0x04))
+		require.True(t, cached(0x05))
+		require.True(t, cached(0x02))
+	})
+	t.Run("deep reorg", func(t *testing.T) {
+		// 8 builds on 7 at height 2, which replaces 2, and all of its descendants
+		add(2, 0x07, 0x01, false)
+		require.ElementsMatch(t, []common.Hash{{0x02}, {0x05}, {0x06}}, add(3, 0x08, 0x07, true))
+		require.True(t, cached(0x01))
+		require.True(t, cached(0x07))
+		require.False(t, cached(0x02))
+		require.False(t, cached(0x05))
+		require.False(t, cached(0x06))
+	})
+	t.Run("pruning", func(t *testing.T) {
+		for i := uint64(4); i < 40; i++ {
+			add(i, byte(i+0x10), byte(i+0x10-1), true)
+		}
+		require.LessOrEqual(t, len(a.blocks), 20)
+		// blocks beyond the window are not tracked anymore, and reorgs of them are not detected
+		require.Empty(t, add(1, 0xff, 0x00, true))
+		require.True(t, cached(0x01))
+	})
+}
@@ -30,6 +30,11 @@ func (c *LRUCache[K, V]) Add(key K, value V) (evicted bool) {
 	return evicted
 }
 
+// Remove removes the entry of the key, and returns whether it was present.
+func (c *LRUCache[K, V]) Remove(key K) (present bool) {
+	return c.inner.Remove(key)
+}
+
 // NewLRUCache creates a LRU cache with the given metrics, labeling the cache adds/gets.
 // Metrics are optional: no metrics will be tracked if m == nil.
 func NewLRUCache[K comparable, V any](m Metrics, label string, maxSize int) *LRUCache[K, V] {
@@ -2,7 +2,10 @@ package sources
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -14,6 +17,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 )
 
@@ -48,11 +52,32 @@ func NewEngineClient(client client.RPC, log log.Logger, metrics caching.Metrics,
 	}, nil
 }
 
+// ErrEngineMethodUnsupported is returned when the engine doesn't support the Engine API method
+// that the hard fork of the request requires, as determined by the capability exchange.
+var ErrEngineMethodUnsupported = errors.New("engine API method not supported by the engine")
+
+// methodNotFoundCode is the JSON-RPC error code of calls to methods that do not exist.
+const methodNotFoundCode = -32601
+
 // EngineAPIClient is an RPC client for the Engine API functions.
+// It selects the Engine API methods by the hard fork of the requests, and checks that the engine supports them
+// with a capability exchange, see ExchangeCapabilities.
 type EngineAPIClient struct {
 	RPC client.RPC
 	log log.Logger
 	evp EngineVersionProvider
+
+	capsLock sync.Mutex
+	// caps are the capabilities of the engine, or nil if they have to be exchanged (again).
+	caps *engineCapabilities
+}
+
+// engineCapabilities are the results of a capability exchange.
+type engineCapabilities struct {
+	// methods are the methods supported by the engine, or nil if the engine does not support the capability exchange.
+	methods map[eth.EngineAPIMethod]struct{}
+	// txPoolPolicy is true if the engine advertised the eth.TxPoolPolicyCapability.
+	txPoolPolicy bool
 }
 
 type EngineVersionProvider interface {
@@ -73,6 +98,148 @@ func NewEngineAPIClient(rpc client.RPC, l log.Logger, evp EngineVersionProvider)
 // resolving the correct Engine API versions.
 func (s *EngineAPIClient) EngineVersionProvider() EngineVersionProvider { return s.evp }
 
+// ExchangeCapabilities exchanges the supported Engine API methods with the engine, with engine_exchangeCapabilities,
+// and caches the methods that both support. Requests that need other methods fail with ErrEngineMethodUnsupported.
+// Engines that don't support the capability exchange are assumed to support all methods, and nil is returned.
+// The capabilities are exchanged with the first request if this is not called explicitly, and again after the
+// connection to the engine failed, as the engine may have been restarted or replaced in the meantime.
+func (s *EngineAPIClient) ExchangeCapabilities(ctx context.Context) ([]eth.EngineAPIMethod, error) {
+	caps, err := s.exchangeCapabilities(ctx)
+	if err != nil || caps.methods == nil {
+		return nil, err
+	}
+	var common []eth.EngineAPIMethod
+	for _, m := range eth.EngineAPIMethods {
+		if _, ok := caps.methods[m]; ok {
+			common = append(common, m)
+		}
+	}
+	return common, nil
+}
+
+// exchangeCapabilities exchanges the capabilities with the engine, and caches them.
+// The caps lock is not held during the exchange, so requests with known capabilities are not blocked by it.
+func (s *EngineAPIClient) exchangeCapabilities(ctx context.Context) (*engineCapabilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+	var engineCaps []eth.EngineAPIMethod
+	clCaps := append(slices.Clone(eth.EngineAPIMethods), eth.TxPoolPolicyCapability)
+	err := s.RPC.CallContext(ctx, &engineCaps, string(eth.ExchangeCapabilities), clCaps)
+	if rpcErr, ok := err.(rpc.Error); ok && rpcErr.ErrorCode() == methodNotFoundCode {
+		s.log.Warn("Engine does not support the capability exchange, assuming it supports all Engine API methods")
+		caps := &engineCapabilities{}
+		s.setCapabilities(caps)
+		return caps, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to exchange capabilities: %w", err)
+	}
+	supported := make(map[eth.EngineAPIMethod]struct{}, len(engineCaps))
+	for _, m := range engineCaps {
+		supported[m] = struct{}{}
+	}
+	caps := &engineCapabilities{methods: make(map[eth.EngineAPIMethod]struct{})}
+	var common, missing []eth.EngineAPIMethod
+	for _, m := range eth.EngineAPIMethods {
+		if _, ok := supported[m]; ok {
+			caps.methods[m] = struct{}{}
+			common = append(common, m)
+		} else {
+			missing = append(missing, m)
+		}
+	}
+	_, caps.txPoolPolicy = supported[eth.TxPoolPolicyCapability]
+	s.setCapabilities(caps)
+	if len(missing) > 0 {
+		s.log.Warn("Engine does not support all Engine API methods", "missing", missing)
+	} else {
+		s.log.Debug("Exchanged Engine API capabilities", "methods", common)
+	}
+	return caps, nil
+}
+
+func (s *EngineAPIClient) setCapabilities(caps *engineCapabilities) {
+	s.capsLock.Lock()
+	defer s.capsLock.Unlock()
+	s.caps = caps
+}
+
+// capabilities returns the cached capabilities of the engine, exchanging them first if they are unknown.
+func (s *EngineAPIClient) capabilities(ctx context.Context) (*engineCapabilities, error) {
+	s.capsLock.Lock()
+	caps := s.caps
+	s.capsLock.Unlock()
+	if caps != nil {
+		return caps, nil
+	}
+	return s.exchangeCapabilities(ctx)
+}
+
+// checkCallError forgets the capabilities of the engine if the connection to it failed, or if it doesn't know a method
+// it advertised, so they are exchanged again with the next request: the engine may have been restarted or replaced.
+func (s *EngineAPIClient) checkCallError(err error) {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	if rpcErr, ok := err.(rpc.Error); ok && rpcErr.ErrorCode() != methodNotFoundCode {
+		return
+	}
+	s.setCapabilities(nil)
+}
+
+// EngineRetryPolicy retries idempotent Engine API calls that failed to reach the engine, e.g. because of a dropped
+// connection, within a budget that is short compared to the block time. Errors of the engine are not retried.
+func EngineRetryPolicy() retry.Policy {
+	return retry.Policy{
+		Strategy:    retry.Fixed(100 * time.Millisecond),
+		MaxAttempts: 3,
+		Classify:    RPCErrorClassifier,
+		Jitter:      0.5,
+	}
+}
+
+// RPCErrorClassifier classifies the errors that the RPC server responded with, including eth.InputError and
+// HTTP client errors, as retry.Fatal, since the server would respond with them again.
+// Other errors, of the connection to the server, are retry.Temporary.
+func RPCErrorClassifier(err error) retry.ErrorClass {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) || errors.Is(err, eth.InputError{}) {
+		return retry.Fatal
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode >= 400 && httpErr.StatusCode < 500 {
+		return retry.Fatal
+	}
+	return retry.DefaultClassifier(err)
+}
+
+// checkSupported returns an error if the engine does not support the method, exchanging capabilities first if needed.
+// If the capabilities cannot be exchanged, e.g. due to a temporary RPC error, the method is assumed to be supported,
+// and the exchange is retried with the next request.
+func (s *EngineAPIClient) checkSupported(ctx context.Context, method eth.EngineAPIMethod) error {
+	caps, err := s.capabilities(ctx)
+	if err != nil {
+		s.log.Warn("Failed to exchange Engine API capabilities", "err", err)
+		return nil
+	}
+	if caps.methods == nil {
+		return nil
+	}
+	if _, ok := caps.methods[method]; !ok {
+		return fmt.Errorf("%w: %s", ErrEngineMethodUnsupported, method)
+	}
+	return nil
+}
+
+// SupportsTxPoolPolicy returns whether the engine applies the tx pool policy of payload attributes, as advertised in
+// the capability exchange. Engines that don't support the capability exchange are assumed to not support it.
+func (s *EngineAPIClient) SupportsTxPoolPolicy(ctx context.Context) (bool, error) {
+	caps, err := s.capabilities(ctx)
+	if err != nil {
+		return false, err
+	}
+	return caps.txPoolPolicy, nil
+}
+
 // ForkchoiceUpdate updates the forkchoice on the execution client. If attributes is not nil, the engine client will also begin building a block
 // based on attributes after the new head block and return the payload ID.
 //
@@ -87,8 +254,21 @@ func (s *EngineAPIClient) ForkchoiceUpdate(ctx context.Context, fc *eth.Forkchoi
 	fcCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 	var result eth.ForkchoiceUpdatedResult
+	if attributes != nil && attributes.TxPoolPolicy != nil {
+		// Never send the policy to engines that would ignore it, or reject the unknown field.
+		if supported, err := s.SupportsTxPoolPolicy(ctx); err != nil || !supported {
+			llog.Warn("Engine does not support tx pool policies, building block without the policy", "err", err)
+			attrs := *attributes
+			attrs.TxPoolPolicy = nil
+			attributes = &attrs
+		}
+	}
 	method := s.evp.ForkchoiceUpdatedVersion(attributes)
+	if err := s.checkSupported(ctx, method); err != nil {
+		return nil, err
+	}
 	err := s.RPC.CallContext(fcCtx, &result, string(method), fc, attributes)
+	s.checkCallError(err)
 	if err == nil {
 		tlog.Trace("Shared forkchoice-updated signal")
 		if attributes != nil { // block building is optional, we only get a payload ID if we are building a block
@@ -124,15 +304,25 @@ func (s *EngineAPIClient) NewPayload(ctx context.Context, payload *eth.Execution
 	defer cancel()
 	var result eth.PayloadStatusV1
 
-	var err error
-	switch method := s.evp.NewPayloadVersion(uint64(payload.Timestamp)); method {
+	method := s.evp.NewPayloadVersion(uint64(payload.Timestamp))
+	if err := s.checkSupported(ctx, method); err != nil {
+		return nil, err
+	}
+	var args []any
+	switch method {
 	case eth.NewPayloadV3:
-		err = s.RPC.CallContext(execCtx, &result, string(method), payload, []common.Hash{}, parentBeaconBlockRoot)
+		args = []any{payload, []common.Hash{}, parentBeaconBlockRoot}
 	case eth.NewPayloadV2:
-		err = s.RPC.CallContext(execCtx, &result, string(method), payload)
+		args = []any{payload}
 	default:
 		return nil, fmt.Errorf("unsupported NewPayload version: %s", method)
 	}
+	// executing a payload again is a no-op for the engine
+	_, err := retry.DoWithPolicy(execCtx, EngineRetryPolicy(), func() (struct{}, error) {
+		err := s.RPC.CallContext(execCtx, &result, string(method), args...)
+		s.checkCallError(err)
+		return struct{}{}, err
+	})
 
 	e.Trace("Received payload execution result", "status", result.Status, "latestValidHash", result.LatestValidHash, "message", result.ValidationError)
 	if err != nil {
@@ -151,7 +341,15 @@ func (s *EngineAPIClient) GetPayload(ctx context.Context, payloadInfo eth.Payloa
 	e.Trace("getting payload")
 	var result eth.ExecutionPayloadEnvelope
 	method := s.evp.GetPayloadVersion(payloadInfo.Timestamp)
-	err := s.RPC.CallContext(ctx, &result, string(method), payloadInfo.ID)
+	if err := s.checkSupported(ctx, method); err != nil {
+		return nil, err
+	}
+	// retrieving the payload again returns the same payload
+	_, err := retry.DoWithPolicy(ctx, EngineRetryPolicy(), func() (struct{}, error) {
+		err := s.RPC.CallContext(ctx, &result, string(method), payloadInfo.ID)
+		s.checkCallError(err)
+		return struct{}{}, err
+	})
 	if err != nil {
 		e.Warn("Failed to get payload", "payload_id", payloadInfo.ID, "err", err)
 		if rpcErr, ok := err.(rpc.Error); ok {
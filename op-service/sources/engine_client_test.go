@@ -0,0 +1,255 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testEngineAPI struct {
+	caps     []string
+	payloads []string
+	policies []*eth.TxPoolPolicy
+}
+
+func (e *testEngineAPI) GetPayloadV2(id eth.PayloadID) (*eth.ExecutionPayloadEnvelope, error) {
+	e.payloads = append(e.payloads, "v2")
+	return &eth.ExecutionPayloadEnvelope{}, nil
+}
+
+func (e *testEngineAPI) GetPayloadV3(id eth.PayloadID) (*eth.ExecutionPayloadEnvelope, error) {
+	e.payloads = append(e.payloads, "v3")
+	return &eth.ExecutionPayloadEnvelope{}, nil
+}
+
+func (e *testEngineAPI) ForkchoiceUpdatedV3(_ eth.ForkchoiceState, attrs *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	e.policies = append(e.policies, attrs.TxPoolPolicy)
+	return &eth.ForkchoiceUpdatedResult{}, nil
+}
+
+type testCapsAPI struct {
+	*testEngineAPI
+	// block blocks the capability exchange until it is closed, if set.
+	block chan struct{}
+}
+
+func (e testCapsAPI) ExchangeCapabilities(_ []string) []string {
+	if e.block != nil {
+		<-e.block
+	}
+	return e.caps
+}
+
+// disconnectingRPC fails all calls with a connection error while disconnected is set.
+type disconnectingRPC struct {
+	client.RPC
+	disconnected atomic.Bool
+}
+
+func (r *disconnectingRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	if r.disconnected.Load() {
+		return errors.New("connection refused")
+	}
+	return r.RPC.CallContext(ctx, result, method, args...)
+}
+
+func newTestEngineAPIClient(t *testing.T, service any) *EngineAPIClient {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("engine", service))
+	t.Cleanup(srv.Stop)
+	cl := rpc.DialInProc(srv)
+	t.Cleanup(cl.Close)
+	ecotoneTime := uint64(10)
+	cfg := &rollup.Config{EcotoneTime: &ecotoneTime}
+	return NewEngineAPIClient(client.NewBaseRPCClient(cl), testlog.Logger(t, log.LevelInfo), cfg)
+}
+
+func TestEngineAPIClientCapabilities(t *testing.T) {
+	ctx := context.Background()
+	engine := &testEngineAPI{caps: []string{"engine_getPayloadV2", "engine_newPayloadV2", "engine_unknownV1"}}
+	cl := newTestEngineAPIClient(t, testCapsAPI{testEngineAPI: engine})
+
+	_, err := cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 5})
+	require.NoError(t, err)
+	_, err = cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 10})
+	require.ErrorIs(t, err, ErrEngineMethodUnsupported)
+	require.ErrorContains(t, err, string(eth.GetPayloadV3))
+	require.Equal(t, []string{"v2"}, engine.payloads)
+
+	// capabilities are cached, until they are exchanged again
+	engine.caps = append(engine.caps, "engine_getPayloadV3")
+	_, err = cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 10})
+	require.ErrorIs(t, err, ErrEngineMethodUnsupported)
+	caps, err := cl.ExchangeCapabilities(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []eth.EngineAPIMethod{eth.NewPayloadV2, eth.GetPayloadV2, eth.GetPayloadV3}, caps)
+	_, err = cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"v2", "v3"}, engine.payloads)
+}
+
+func TestEngineAPIClientReconnect(t *testing.T) {
+	ctx := context.Background()
+	engine := &testEngineAPI{caps: []string{"engine_getPayloadV2"}}
+	cl := newTestEngineAPIClient(t, testCapsAPI{testEngineAPI: engine})
+	rpc := &disconnectingRPC{RPC: cl.RPC}
+	cl.RPC = rpc
+
+	_, err := cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 10})
+	require.ErrorIs(t, err, ErrEngineMethodUnsupported)
+
+	// the engine is upgraded while the connection is down
+	rpc.disconnected.Store(true)
+	_, err = cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 5})
+	require.ErrorContains(t, err, "connection refused")
+	engine.caps = append(engine.caps, "engine_getPayloadV3")
+	rpc.disconnected.Store(false)
+
+	_, err = cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 10})
+	require.NoError(t, err, "capabilities must be exchanged again after reconnecting")
+	require.Equal(t, []string{"v3"}, engine.payloads)
+}
+
+func TestEngineAPIClientExchangeDoesNotBlockRequests(t *testing.T) {
+	ctx := context.Background()
+	engine := &testEngineAPI{caps: []string{"engine_getPayloadV2"}}
+	block := make(chan struct{})
+	api := testCapsAPI{testEngineAPI: engine}
+	cl := newTestEngineAPIClient(t, &api)
+	_, err := cl.ExchangeCapabilities(ctx)
+	require.NoError(t, err)
+
+	api.block = block
+	done := make(chan error)
+	go func() {
+		_, err := cl.ExchangeCapabilities(ctx)
+		done <- err
+	}()
+	_, err = cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 5})
+	require.NoError(t, err, "requests with known capabilities must not wait for the exchange")
+	supported, err := cl.SupportsTxPoolPolicy(ctx)
+	require.NoError(t, err)
+	require.False(t, supported)
+	close(block)
+	require.NoError(t, <-done)
+}
+
+func TestEngineAPIClientNoCapabilityExchange(t *testing.T) {
+	ctx := context.Background()
+	engine := &testEngineAPI{}
+	cl := newTestEngineAPIClient(t, engine)
+
+	caps, err := cl.ExchangeCapabilities(ctx)
+	require.NoError(t, err)
+	require.Nil(t, caps)
+	_, err = cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 5})
+	require.NoError(t, err)
+	_, err = cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"v2", "v3"}, engine.payloads)
+}
+
+func TestEngineAPIClientTxPoolPolicy(t *testing.T) {
+	ctx := context.Background()
+	beaconRoot := common.Hash{}
+	attrs := &eth.PayloadAttributes{
+		Timestamp:             10,
+		ParentBeaconBlockRoot: &beaconRoot,
+		TxPoolPolicy:          &eth.TxPoolPolicy{ExcludedAddresses: []common.Address{{0x01}}},
+	}
+
+	t.Run("Supported", func(t *testing.T) {
+		engine := &testEngineAPI{caps: []string{string(eth.FCUV3), string(eth.TxPoolPolicyCapability)}}
+		cl := newTestEngineAPIClient(t, testCapsAPI{testEngineAPI: engine})
+		supported, err := cl.SupportsTxPoolPolicy(ctx)
+		require.NoError(t, err)
+		require.True(t, supported)
+		_, err = cl.ForkchoiceUpdate(ctx, &eth.ForkchoiceState{}, attrs)
+		require.NoError(t, err)
+		require.Equal(t, []*eth.TxPoolPolicy{attrs.TxPoolPolicy}, engine.policies)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		engine := &testEngineAPI{caps: []string{string(eth.FCUV3)}}
+		cl := newTestEngineAPIClient(t, testCapsAPI{testEngineAPI: engine})
+		supported, err := cl.SupportsTxPoolPolicy(ctx)
+		require.NoError(t, err)
+		require.False(t, supported)
+		_, err = cl.ForkchoiceUpdate(ctx, &eth.ForkchoiceState{}, attrs)
+		require.NoError(t, err)
+		require.Equal(t, []*eth.TxPoolPolicy{nil}, engine.policies, "policy must not be sent")
+		require.NotNil(t, attrs.TxPoolPolicy, "attributes of the caller must not be modified")
+	})
+
+	t.Run("NoCapabilityExchange", func(t *testing.T) {
+		cl := newTestEngineAPIClient(t, &testEngineAPI{})
+		supported, err := cl.SupportsTxPoolPolicy(ctx)
+		require.NoError(t, err)
+		require.False(t, supported)
+	})
+}
+
+// flakyRPC fails the given number of calls with a connection error.
+type flakyRPC struct {
+	client.RPC
+	failures int
+	calls    int
+}
+
+func (r *flakyRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	r.calls++
+	if r.calls <= r.failures {
+		return errors.New("connection reset by peer")
+	}
+	return r.RPC.CallContext(ctx, result, method, args...)
+}
+
+func TestEngineAPIClientRetries(t *testing.T) {
+	ctx := context.Background()
+	engine := &testEngineAPI{}
+	cl := newTestEngineAPIClient(t, engine)
+	flaky := &flakyRPC{RPC: cl.RPC, failures: 2}
+	cl.RPC = flaky
+
+	// the failed capability exchange is not retried, the calls of the method are
+	_, err := cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"v3"}, engine.payloads)
+
+	flaky.calls, flaky.failures = 0, 10
+	_, err = cl.GetPayload(ctx, eth.PayloadInfo{Timestamp: 10})
+	require.ErrorContains(t, err, "connection reset by peer")
+	require.Equal(t, 1+EngineRetryPolicy().MaxAttempts, flaky.calls, "capability exchange and attempts")
+
+	// errors of the engine are not retried
+	flaky.calls, flaky.failures = 0, 0
+	_, err = cl.NewPayload(ctx, &eth.ExecutionPayload{Timestamp: 10}, &common.Hash{})
+	require.Error(t, err)
+	require.Equal(t, 2, flaky.calls, "capability exchange and one attempt")
+}
+
+func TestRPCErrorClassifier(t *testing.T) {
+	require.Equal(t, retry.Temporary, RPCErrorClassifier(errors.New("connection refused")))
+	require.Equal(t, retry.Temporary, RPCErrorClassifier(rpc.HTTPError{StatusCode: 503}))
+	require.Equal(t, retry.Fatal, RPCErrorClassifier(rpc.HTTPError{StatusCode: 401}))
+	require.Equal(t, retry.Fatal, RPCErrorClassifier(fmt.Errorf("wrapped: %w", eth.InputError{Inner: errors.New("unknown payload"), Code: eth.UnknownPayload})))
+	require.Equal(t, retry.Fatal, RPCErrorClassifier(fmt.Errorf("wrapped: %w", testRPCError{})))
+}
+
+type testRPCError struct{}
+
+func (testRPCError) Error() string  { return "server error" }
+func (testRPCError) ErrorCode() int { return -32000 }
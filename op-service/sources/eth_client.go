@@ -20,6 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -45,7 +46,9 @@ type EthClientConfig struct {
 	PayloadsCacheSize int
 
 	// If the RPC is untrusted, then we should not use cached information from responses,
-	// and instead verify against the block-hash.
+	// and instead verify against the block-hash: the header fields are hashed to verify the block-hash,
+	// and the transactions and withdrawals are verified against the roots in the header.
+	// Receipts are always verified against the receipts-root, regardless of the trust in the RPC.
 	// Of real L1 blocks no deposits can be missed/faked, no batches can be missed/faked,
 	// only the wrong L1 blocks can be retrieved.
 	TrustRPC bool
@@ -109,6 +112,9 @@ type EthClient struct {
 
 	recProvider ReceiptsProvider
 
+	// maxBatchSize is the maximum number of calls per batch request
+	maxBatchSize int
+
 	trustRPC bool
 
 	mustBePostMerge bool
@@ -126,6 +132,9 @@ type EthClient struct {
 	// cache payloads by hash
 	// common.Hash -> *eth.ExecutionPayload
 	payloadsCache *caching.LRUCache[common.Hash, *eth.ExecutionPayloadEnvelope]
+
+	// ancestry tracks the recently fetched blocks, to invalidate the cached data of reorged-out blocks
+	ancestry *caching.Ancestry
 }
 
 // NewEthClient returns an [EthClient], wrapping an RPC with bindings to fetch ethereum data with added error logging,
@@ -140,16 +149,32 @@ func NewEthClient(client client.RPC, log log.Logger, metrics caching.Metrics, co
 	if recProvider.isInnerNil() {
 		return nil, fmt.Errorf("failed to open RethDB")
 	}
-	return &EthClient{
+	s := &EthClient{
 		client:            client,
 		recProvider:       recProvider,
+		maxBatchSize:      max(config.MaxRequestsPerBatch, 1),
 		trustRPC:          config.TrustRPC,
 		mustBePostMerge:   config.MustBePostMerge,
 		log:               log,
 		transactionsCache: caching.NewLRUCache[common.Hash, types.Transactions](metrics, "txs", config.TransactionsCacheSize),
 		headersCache:      caching.NewLRUCache[common.Hash, eth.BlockInfo](metrics, "headers", config.HeadersCacheSize),
 		payloadsCache:     caching.NewLRUCache[common.Hash, *eth.ExecutionPayloadEnvelope](metrics, "payloads", config.PayloadsCacheSize),
-	}, nil
+		ancestry:          caching.NewAncestry(max(config.HeadersCacheSize, config.PayloadsCacheSize)),
+	}
+	s.ancestry.Track(s.transactionsCache, s.headersCache, s.payloadsCache, recProvider)
+	return s, nil
+}
+
+// trackBlock records the fetched block in the ancestry index. Blocks fetched by number or label are canonical,
+// and invalidate the cached data of the blocks that they reorged out.
+func (s *EthClient) trackBlock(id rpcBlockID, block eth.BlockID, parent common.Hash) {
+	if _, byHash := id.(hashID); byHash {
+		s.ancestry.AddBlock(block.Number, block.Hash, parent)
+		return
+	}
+	if reorged := s.ancestry.AddCanonical(block.Number, block.Hash, parent); len(reorged) > 0 {
+		s.log.Info("Invalidated cached data of reorged-out blocks", "canonical", block, "reorged", len(reorged))
+	}
 }
 
 // SubscribeNewHead subscribes to notifications about the current blockchain head on the given channel.
@@ -195,6 +220,11 @@ func (s *EthClient) headerCall(ctx context.Context, method string, id rpcBlockID
 	if err != nil {
 		return nil, err
 	}
+	return s.checkHeader(header, id)
+}
+
+// checkHeader verifies a fetched header matches the requested block identifier, and caches it.
+func (s *EthClient) checkHeader(header *RPCHeader, id rpcBlockID) (eth.BlockInfo, error) {
 	if header == nil {
 		return nil, ethereum.NotFound
 	}
@@ -206,6 +236,7 @@ func (s *EthClient) headerCall(ctx context.Context, method string, id rpcBlockID
 		return nil, fmt.Errorf("fetched block header does not match requested ID: %w", err)
 	}
 	s.headersCache.Add(info.Hash(), info)
+	s.trackBlock(id, eth.ToBlockID(info), info.ParentHash())
 	return info, nil
 }
 
@@ -215,6 +246,11 @@ func (s *EthClient) blockCall(ctx context.Context, method string, id rpcBlockID)
 	if err != nil {
 		return nil, nil, err
 	}
+	return s.checkBlock(block, id)
+}
+
+// checkBlock verifies a fetched block matches the requested block identifier, and caches its header and transactions.
+func (s *EthClient) checkBlock(block *RPCBlock, id rpcBlockID) (eth.BlockInfo, types.Transactions, error) {
 	if block == nil {
 		return nil, nil, ethereum.NotFound
 	}
@@ -227,6 +263,7 @@ func (s *EthClient) blockCall(ctx context.Context, method string, id rpcBlockID)
 	}
 	s.headersCache.Add(info.Hash(), info)
 	s.transactionsCache.Add(info.Hash(), txs)
+	s.trackBlock(id, eth.ToBlockID(info), info.ParentHash())
 	return info, txs, nil
 }
 
@@ -247,6 +284,7 @@ func (s *EthClient) payloadCall(ctx context.Context, method string, id rpcBlockI
 		return nil, fmt.Errorf("fetched payload does not match requested ID: %w", err)
 	}
 	s.payloadsCache.Add(envelope.ExecutionPayload.BlockHash, envelope)
+	s.trackBlock(id, envelope.ExecutionPayload.ID(), envelope.ExecutionPayload.ParentHash)
 	return envelope, nil
 }
 
@@ -272,6 +310,42 @@ func (s *EthClient) InfoByNumber(ctx context.Context, number uint64) (eth.BlockI
 	return s.headerCall(ctx, "eth_getBlockByNumber", numberID(number))
 }
 
+// InfoRangeByNumber fetches the headers of count consecutive blocks, starting at the given block number,
+// with batch requests. It returns an error if any of the blocks is not found,
+// or if the headers do not form a chain, e.g. because of a reorg while fetching them.
+func (s *EthClient) InfoRangeByNumber(ctx context.Context, start uint64, count uint64) ([]eth.BlockInfo, error) {
+	headers := make([]*RPCHeader, count)
+	batch := make([]rpc.BatchElem, count)
+	for i := range batch {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []any{numberID(start + uint64(i)).Arg(), false},
+			Result: &headers[i],
+		}
+	}
+	for i := 0; i < len(batch); i += s.maxBatchSize {
+		if err := s.client.BatchCallContext(ctx, batch[i:min(i+s.maxBatchSize, len(batch))]); err != nil {
+			return nil, fmt.Errorf("failed to fetch headers %d - %d: %w", start, start+count-1, err)
+		}
+	}
+	infos := make([]eth.BlockInfo, count)
+	for i, elem := range batch {
+		num := start + uint64(i)
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to fetch header %d: %w", num, elem.Error)
+		}
+		info, err := s.checkHeader(headers[i], numberID(num))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch header %d: %w", num, err)
+		}
+		if i > 0 && info.ParentHash() != infos[i-1].Hash() {
+			return nil, fmt.Errorf("header %s does not build on header %s", eth.ToBlockID(info), eth.ToBlockID(infos[i-1]))
+		}
+		infos[i] = info
+	}
+	return infos, nil
+}
+
 func (s *EthClient) InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error) {
 	// can't hit the cache when querying the head due to reorgs / changes.
 	return s.headerCall(ctx, "eth_getBlockByNumber", label)
@@ -314,7 +388,15 @@ func (s *EthClient) PayloadByLabel(ctx context.Context, label eth.BlockLabel) (*
 // FetchReceipts returns a block info and all of the receipts associated with transactions in the block.
 // It verifies the receipt hash in the block header against the receipt hash of the fetched receipts
 // to ensure that the execution engine did not fail to return any receipts.
+//
+// If the block is not cached yet, and the receipts provider fetches all the receipts of a block with a single call,
+// the block and its receipts are fetched with a single batch request.
 func (s *EthClient) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	if p, ok := s.recProvider.(batchReceiptsProvider); ok && !s.blockCached(blockHash) {
+		if call, ok := p.receiptsBatchCall(blockHash); ok {
+			return s.fetchBlockAndReceipts(ctx, blockHash, call)
+		}
+	}
 	info, txs, err := s.InfoAndTxsByHash(ctx, blockHash)
 	if err != nil {
 		return nil, nil, fmt.Errorf("querying block: %w", err)
@@ -328,6 +410,40 @@ func (s *EthClient) FetchReceipts(ctx context.Context, blockHash common.Hash) (e
 	return info, receipts, nil
 }
 
+// blockCached returns whether the header and transactions of the block are cached.
+func (s *EthClient) blockCached(blockHash common.Hash) bool {
+	if _, ok := s.headersCache.Get(blockHash); !ok {
+		return false
+	}
+	_, ok := s.transactionsCache.Get(blockHash)
+	return ok
+}
+
+// fetchBlockAndReceipts fetches the block with the given hash and its receipts with a single batch request.
+func (s *EthClient) fetchBlockAndReceipts(ctx context.Context, blockHash common.Hash, call *receiptsBatchElem) (eth.BlockInfo, types.Receipts, error) {
+	defer call.done()
+	var block *RPCBlock
+	batch := []rpc.BatchElem{
+		{Method: "eth_getBlockByHash", Args: []any{blockHash, true}, Result: &block},
+		call.elem,
+	}
+	if err := s.client.BatchCallContext(ctx, batch); err != nil {
+		return nil, nil, fmt.Errorf("querying block and receipts: %w", err)
+	}
+	if err := batch[0].Error; err != nil {
+		return nil, nil, fmt.Errorf("querying block: %w", err)
+	}
+	info, txs, err := s.checkBlock(block, hashID(blockHash))
+	if err != nil {
+		return nil, nil, fmt.Errorf("querying block: %w", err)
+	}
+	receipts, err := call.result(batch[1].Error, info, eth.TransactionsToHashes(txs))
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, receipts, nil
+}
+
 // GetProof returns an account proof result, with any optional requested storage proofs.
 // The retrieval does sanity-check that storage proofs for the expected keys are present in the response,
 // but does not verify the result. Call accountResult.Verify(stateRoot) to verify the result.
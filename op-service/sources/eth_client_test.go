@@ -14,12 +14,14 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 type mockRPC struct {
@@ -180,11 +182,115 @@ func TestEthClient_WrongInfoByHash(t *testing.T) {
 	m.Mock.AssertExpectations(t)
 }
 
+// randHeaderChain returns the headers of count consecutive blocks, starting at block number start.
+func randHeaderChain(start uint64, count int) []*RPCHeader {
+	headers := make([]*RPCHeader, count)
+	for i := range headers {
+		_, rhdr := randHeader()
+		rhdr.Number = hexutil.Uint64(start + uint64(i))
+		if i > 0 {
+			rhdr.ParentHash = headers[i-1].Hash
+		}
+		rhdr.Hash = rhdr.computeBlockHash()
+		headers[i] = rhdr
+	}
+	return headers
+}
+
+// serveHeaders serves the batch requests of the mock RPC with the given headers, by block number.
+func serveHeaders(m *mockRPC, headers []*RPCHeader) {
+	m.On("BatchCallContext", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		for _, elem := range args[1].([]rpc.BatchElem) {
+			num, err := hexutil.DecodeUint64(elem.Args[0].(string))
+			if err != nil || num < uint64(headers[0].Number) || num-uint64(headers[0].Number) >= uint64(len(headers)) {
+				continue // not found
+			}
+			*elem.Result.(**RPCHeader) = headers[num-uint64(headers[0].Number)]
+		}
+	}).Return([]error{nil})
+}
+
+func TestEthClient_InfoRangeByNumber(t *testing.T) {
+	ctx := context.Background()
+	cfg := *testEthClientConfig
+	cfg.MaxRequestsPerBatch = 2
+
+	t.Run("batched", func(t *testing.T) {
+		m := new(mockRPC)
+		headers := randHeaderChain(100, 5)
+		serveHeaders(m, headers)
+		s, err := NewEthClient(m, nil, nil, &cfg)
+		require.NoError(t, err)
+		infos, err := s.InfoRangeByNumber(ctx, 100, 5)
+		require.NoError(t, err)
+		require.Len(t, infos, 5)
+		for i, info := range infos {
+			require.Equal(t, headers[i].Hash, info.Hash())
+		}
+		m.AssertNumberOfCalls(t, "BatchCallContext", 3)
+
+		// the headers are cached by hash
+		info, err := s.InfoByHash(ctx, headers[2].Hash)
+		require.NoError(t, err)
+		require.Equal(t, headers[2].Hash, info.Hash())
+		m.AssertNumberOfCalls(t, "BatchCallContext", 3)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		m := new(mockRPC)
+		serveHeaders(m, randHeaderChain(100, 3))
+		s, err := NewEthClient(m, nil, nil, &cfg)
+		require.NoError(t, err)
+		_, err = s.InfoRangeByNumber(ctx, 100, 4)
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+
+	t.Run("not a chain", func(t *testing.T) {
+		m := new(mockRPC)
+		headers := randHeaderChain(100, 3)
+		headers[2].ParentHash = randHash()
+		headers[2].Hash = headers[2].computeBlockHash()
+		serveHeaders(m, headers)
+		s, err := NewEthClient(m, testlog.Logger(t, log.LevelError), nil, &cfg)
+		require.NoError(t, err)
+		_, err = s.InfoRangeByNumber(ctx, 100, 3)
+		require.ErrorContains(t, err, "does not build on")
+	})
+}
+
+func TestEthClient_ReorgInvalidation(t *testing.T) {
+	ctx := context.Background()
+	m := new(mockRPC)
+	headers := randHeaderChain(100, 4)
+	serveHeaders(m, headers)
+	s, err := NewEthClient(m, testlog.Logger(t, log.LevelError), nil, testEthClientConfig)
+	require.NoError(t, err)
+	chainA, err := s.InfoRangeByNumber(ctx, 100, 4)
+	require.NoError(t, err)
+
+	// reorg blocks 102 and 103 out, and replace them with a different block 102
+	reorged := randHeaderChain(101, 2)
+	reorged[0] = headers[1]
+	reorged[1].ParentHash = headers[1].Hash
+	reorged[1].Hash = reorged[1].computeBlockHash()
+	headers[2] = reorged[1]
+	_, err = s.InfoRangeByNumber(ctx, 102, 1)
+	require.NoError(t, err)
+
+	for i, info := range chainA {
+		_, cached := s.headersCache.Get(info.Hash())
+		require.Equal(t, i < 2, cached, "only the headers of the reorged-out blocks are invalidated")
+	}
+	_, cached := s.headersCache.Get(reorged[1].Hash)
+	require.True(t, cached)
+}
+
 func newEthClientWithCaches(metrics caching.Metrics, cacheSize int) *EthClient {
 	return &EthClient{
 		transactionsCache: caching.NewLRUCache[common.Hash, types.Transactions](metrics, "txs", cacheSize),
 		headersCache:      caching.NewLRUCache[common.Hash, eth.BlockInfo](metrics, "headers", cacheSize),
 		payloadsCache:     caching.NewLRUCache[common.Hash, *eth.ExecutionPayloadEnvelope](metrics, "payloads", cacheSize),
+		ancestry:          caching.NewAncestry(cacheSize),
 	}
 }
 
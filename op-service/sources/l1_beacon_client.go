@@ -28,6 +28,8 @@ const (
 
 type L1BeaconClientConfig struct {
 	FetchAllSidecars bool
+	// Cache is an optional on-disk cache of the fetched blob sidecars.
+	Cache *BlobSidecarCache
 }
 
 // L1BeaconClient is a high level golang client for the Beacon API.
@@ -250,6 +252,12 @@ func (cl *L1BeaconClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRe
 		return nil, fmt.Errorf("error in converting ref.Time to slot: %w", err)
 	}
 
+	if cl.cfg.Cache != nil {
+		if bscs := cl.cachedSidecars(slot, hashes); bscs != nil {
+			return bscs, nil
+		}
+	}
+
 	resp, err := cl.fetchSidecars(ctx, slot, hashes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch blob sidecars for slot %v block %v: %w", slot, ref, err)
@@ -275,9 +283,28 @@ func (cl *L1BeaconClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRe
 		bscs = append(bscs, apisc.BlobSidecar())
 	}
 
+	if cl.cfg.Cache != nil {
+		for _, bsc := range bscs {
+			cl.cfg.Cache.Put(slot, bsc)
+		}
+	}
 	return bscs, nil
 }
 
+// cachedSidecars returns the cached sidecars of the blobs in the slot, ordered like the hashes,
+// or nil if any of them is not cached.
+func (cl *L1BeaconClient) cachedSidecars(slot uint64, hashes []eth.IndexedBlobHash) []*eth.BlobSidecar {
+	bscs := make([]*eth.BlobSidecar, 0, len(hashes))
+	for _, h := range hashes {
+		bsc := cl.cfg.Cache.Get(slot, h)
+		if bsc == nil {
+			return nil
+		}
+		bscs = append(bscs, bsc)
+	}
+	return bscs
+}
+
 // GetBlobs fetches blobs that were confirmed in the specified L1 block with the given indexed
 // hashes. The order of the returned blobs will match the order of `hashes`.  Confirms each
 // blob's validity by checking its proof against the commitment, and confirming the commitment
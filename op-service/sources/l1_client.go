@@ -70,9 +70,11 @@ func NewL1Client(client client.RPC, log log.Logger, metrics caching.Metrics, con
 		return nil, err
 	}
 
+	l1BlockRefsCache := caching.NewLRUCache[common.Hash, eth.L1BlockRef](metrics, "blockrefs", config.L1BlockRefsCacheSize)
+	ethClient.ancestry.Track(l1BlockRefsCache)
 	return &L1Client{
 		EthClient:        ethClient,
-		l1BlockRefsCache: caching.NewLRUCache[common.Hash, eth.L1BlockRef](metrics, "blockrefs", config.L1BlockRefsCacheSize),
+		l1BlockRefsCache: l1BlockRefsCache,
 	}, nil
 }
 
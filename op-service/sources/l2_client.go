@@ -2,6 +2,7 @@ package sources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -18,6 +19,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 )
 
+// ErrOutputRootMismatch is returned by VerifyOutputRoot if the output root does not match the L2 chain.
+var ErrOutputRootMismatch = errors.New("output root mismatch")
+
 type L2ClientConfig struct {
 	EthClientConfig
 
@@ -84,11 +88,14 @@ func NewL2Client(client client.RPC, log log.Logger, metrics caching.Metrics, con
 		return nil, err
 	}
 
+	l2BlockRefsCache := caching.NewLRUCache[common.Hash, eth.L2BlockRef](metrics, "blockrefs", config.L2BlockRefsCacheSize)
+	systemConfigsCache := caching.NewLRUCache[common.Hash, eth.SystemConfig](metrics, "systemconfigs", config.L1ConfigsCacheSize)
+	ethClient.ancestry.Track(l2BlockRefsCache, systemConfigsCache)
 	return &L2Client{
 		EthClient:          ethClient,
 		rollupCfg:          config.RollupCfg,
-		l2BlockRefsCache:   caching.NewLRUCache[common.Hash, eth.L2BlockRef](metrics, "blockrefs", config.L2BlockRefsCacheSize),
-		systemConfigsCache: caching.NewLRUCache[common.Hash, eth.SystemConfig](metrics, "systemconfigs", config.L1ConfigsCacheSize),
+		l2BlockRefsCache:   l2BlockRefsCache,
+		systemConfigsCache: systemConfigsCache,
 	}, nil
 }
 
@@ -198,3 +205,26 @@ func (s *L2Client) OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (
 		BlockHash:                blockHash,
 	}, nil
 }
+
+// VerifyOutputRoot recomputes the output root of the L2 block from its state root,
+// the storage root of the L2ToL1MessagePasser and its block hash, and compares it with the given output root.
+// The block is looked up by number, and must have the given hash, so a block of a different chain is detected.
+// The recomputed output is returned. The error wraps ErrOutputRootMismatch if the block hash or the output root do not match,
+// any other error means that the output root could not be verified.
+func (s *L2Client) VerifyOutputRoot(ctx context.Context, block eth.BlockID, outputRoot eth.Bytes32) (*eth.OutputV0, error) {
+	head, err := s.InfoByNumber(ctx, block.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 block %d: %w", block.Number, err)
+	}
+	if head.Hash() != block.Hash {
+		return nil, fmt.Errorf("%w: L2 block %d has hash %s, expected %s", ErrOutputRootMismatch, block.Number, head.Hash(), block.Hash)
+	}
+	output, err := s.OutputV0AtBlock(ctx, block.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if actual := eth.OutputRoot(output); actual != outputRoot {
+		return output, fmt.Errorf("%w: output root at L2 block %s is %s, expected %s", ErrOutputRootMismatch, block, actual, outputRoot)
+	}
+	return output, nil
+}
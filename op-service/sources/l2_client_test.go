@@ -0,0 +1,133 @@
+package sources
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// testOutputEthAPI serves a single L2 block, with a state of only the L2ToL1MessagePasser account.
+type testOutputEthAPI struct {
+	header *types.Header
+	proof  *eth.AccountResult
+}
+
+func newTestOutputEthAPI(t *testing.T, storageRoot common.Hash) *testOutputEthAPI {
+	tr := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	account, err := rlp.EncodeToBytes(&types.StateAccount{
+		Balance:  uint256.NewInt(0),
+		Root:     storageRoot,
+		CodeHash: types.EmptyCodeHash[:],
+	})
+	require.NoError(t, err)
+	key := crypto.Keccak256(predeploys.L2ToL1MessagePasserAddr[:])
+	tr.MustUpdate(key, account)
+	proofDB := memorydb.New()
+	require.NoError(t, tr.Prove(key, proofDB))
+	var accountProof []hexutil.Bytes
+	it := proofDB.NewIterator(nil, nil)
+	for it.Next() {
+		accountProof = append(accountProof, common.CopyBytes(it.Value()))
+	}
+	it.Release()
+
+	return &testOutputEthAPI{
+		header: &types.Header{
+			ParentHash:  common.Hash{0x01},
+			UncleHash:   types.EmptyUncleHash,
+			Root:        tr.Hash(),
+			TxHash:      types.EmptyTxsHash,
+			ReceiptHash: types.EmptyReceiptsHash,
+			Difficulty:  common.Big0,
+			Number:      big.NewInt(10),
+			GasLimit:    30_000_000,
+			Time:        1234,
+			BaseFee:     big.NewInt(7),
+		},
+		proof: &eth.AccountResult{
+			AccountProof: accountProof,
+			Address:      predeploys.L2ToL1MessagePasserAddr,
+			Balance:      (*hexutil.Big)(common.Big0),
+			CodeHash:     types.EmptyCodeHash,
+			StorageHash:  storageRoot,
+		},
+	}
+}
+
+func (api *testOutputEthAPI) GetBlockByNumber(num rpc.BlockNumber, full bool) *types.Header {
+	if num.Int64() != api.header.Number.Int64() {
+		return nil
+	}
+	return api.header
+}
+
+func (api *testOutputEthAPI) GetBlockByHash(hash common.Hash, full bool) *types.Header {
+	if hash != api.header.Hash() {
+		return nil
+	}
+	return api.header
+}
+
+func (api *testOutputEthAPI) GetProof(address common.Address, keys []common.Hash, blockTag string) *eth.AccountResult {
+	return api.proof
+}
+
+func TestL2ClientVerifyOutputRoot(t *testing.T) {
+	storageRoot := common.Hash{0xaa}
+	api := newTestOutputEthAPI(t, storageRoot)
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", api))
+	t.Cleanup(server.Stop)
+
+	cfg := L2ClientDefaultConfig(&rollup.Config{SeqWindowSize: 10, BlockTime: 2}, false)
+	l2Client, err := NewL2Client(client.NewBaseRPCClient(rpc.DialInProc(server)), testlog.Logger(t, log.LevelError), nil, cfg)
+	require.NoError(t, err)
+	t.Cleanup(l2Client.Close)
+
+	block := eth.HeaderBlockID(api.header)
+	expected := &eth.OutputV0{
+		StateRoot:                eth.Bytes32(api.header.Root),
+		MessagePasserStorageRoot: eth.Bytes32(storageRoot),
+		BlockHash:                block.Hash,
+	}
+	ctx := context.Background()
+
+	output, err := l2Client.VerifyOutputRoot(ctx, block, eth.OutputRoot(expected))
+	require.NoError(t, err)
+	require.Equal(t, expected, output)
+
+	_, err = l2Client.VerifyOutputRoot(ctx, block, eth.Bytes32{0x01})
+	require.ErrorIs(t, err, ErrOutputRootMismatch)
+
+	_, err = l2Client.VerifyOutputRoot(ctx, eth.BlockID{Hash: common.Hash{0xbb}, Number: block.Number}, eth.OutputRoot(expected))
+	require.ErrorIs(t, err, ErrOutputRootMismatch, "block of another chain")
+
+	_, err = l2Client.VerifyOutputRoot(ctx, eth.BlockID{Hash: common.Hash{0xbb}, Number: block.Number + 1}, eth.OutputRoot(expected))
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrOutputRootMismatch, "unknown block cannot be verified")
+
+	api.proof.StorageHash = common.Hash{0xcc}
+	_, err = l2Client.VerifyOutputRoot(ctx, block, eth.OutputRoot(expected))
+	require.ErrorContains(t, err, "invalid withdrawal root hash")
+	require.NotErrorIs(t, err, ErrOutputRootMismatch, "invalid proofs cannot be verified")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
 )
 
@@ -17,6 +18,31 @@ type ReceiptsProvider interface {
 	FetchReceipts(ctx context.Context, blockInfo eth.BlockInfo, txHashes []common.Hash) (types.Receipts, error)
 }
 
+// batchReceiptsProvider is implemented by receipt providers that can fetch the receipts of a block
+// with a single RPC call, which can then be sent in the same batch request as the call to fetch the block.
+type batchReceiptsProvider interface {
+	// receiptsBatchCall returns the call to fetch the receipts of the block with the given hash,
+	// or false if the receipts are not fetched with a single call by the provider.
+	receiptsBatchCall(blockHash common.Hash) (*receiptsBatchElem, bool)
+}
+
+// receiptsBatchElem is the call of a batch request that fetches the receipts of a block.
+type receiptsBatchElem struct {
+	elem rpc.BatchElem
+	// result processes the response to the call, which failed with callErr if not nil,
+	// and returns the validated receipts of the block.
+	result func(callErr error, blockInfo eth.BlockInfo, txHashes []common.Hash) (types.Receipts, error)
+	// release, if not nil, is called once the batch request is done, whether result was called or not.
+	release func()
+}
+
+// done releases the resources held for the call, see receiptsBatchElem.release.
+func (c *receiptsBatchElem) done() {
+	if c.release != nil {
+		c.release()
+	}
+}
+
 // validateReceipts validates that the receipt contents are valid.
 // Warning: contractAddress is not verified, since it is a more expensive operation for data we do not use.
 // See go-ethereum/crypto.CreateAddress to verify contract deployment address data based on sender and tx nonce.
@@ -49,6 +75,9 @@ func validateReceipts(block eth.BlockID, receiptHash common.Hash, txHashes []com
 		if r.BlockHash != block.Hash {
 			return fmt.Errorf("receipt %d has unexpected block hash %s, expected %s", i, r.BlockHash, block.Hash)
 		}
+		if r.TxHash != txHashes[i] {
+			return fmt.Errorf("receipt %d has unexpected tx hash %s, expected %s", i, r.TxHash, txHashes[i])
+		}
 		if expected := r.CumulativeGasUsed - cumulativeGas; r.GasUsed != expected {
 			return fmt.Errorf("receipt %d has invalid gas used metadata: %d, expected %d", i, r.GasUsed, expected)
 		}
@@ -111,6 +111,8 @@ func TestBasicRPCReceiptsFetcher_Concurrency(t *testing.T) {
 	mrpc.On("BatchCallContext", mock.Anything, mock.AnythingOfType("[]rpc.BatchElem")).
 		Run(func(args mock.Arguments) {
 			numCalls.Add(1)
+			// simulate the latency of the RPC, so the fetchers overlap even if they run on a single thread
+			time.Sleep(time.Millisecond)
 			els := args.Get(1).([]rpc.BatchElem)
 			for _, el := range els {
 				if el.Method == "eth_getTransactionReceipt" {
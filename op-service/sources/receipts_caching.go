@@ -81,6 +81,58 @@ func (p *CachingReceiptsProvider) FetchReceipts(ctx context.Context, blockInfo e
 	return r, nil
 }
 
+// receiptsBatchCall implements batchReceiptsProvider if the inner provider does,
+// caching the receipts fetched with the returned call.
+// Like FetchReceipts, it holds the fetching lock of the block until the call is done,
+// to avoid duplicate requests. It returns false if the receipts are already cached, so they are not fetched again.
+func (p *CachingReceiptsProvider) receiptsBatchCall(blockHash common.Hash) (*receiptsBatchElem, bool) {
+	inner, ok := p.inner.(batchReceiptsProvider)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := p.cache.Get(blockHash); ok {
+		return nil, false
+	}
+
+	mu := p.getOrCreateFetchingLock(blockHash)
+	mu.Lock()
+	// Other routine might have fetched in the meantime
+	if _, ok := p.cache.Get(blockHash); ok {
+		p.deleteFetchingLock(blockHash)
+		mu.Unlock()
+		return nil, false
+	}
+	call, ok := inner.receiptsBatchCall(blockHash)
+	if !ok {
+		mu.Unlock()
+		return nil, false
+	}
+	innerResult := call.result
+	call.result = func(callErr error, blockInfo eth.BlockInfo, txHashes []common.Hash) (types.Receipts, error) {
+		r, err := innerResult(callErr, blockInfo, txHashes)
+		if err != nil {
+			return nil, err
+		}
+		p.cache.Add(blockHash, r)
+		// result now in cache, can delete fetching lock
+		p.deleteFetchingLock(blockHash)
+		return r, nil
+	}
+	innerRelease := call.release
+	call.release = func() {
+		if innerRelease != nil {
+			innerRelease()
+		}
+		mu.Unlock()
+	}
+	return call, true
+}
+
+// Remove removes the cached receipts of the block, e.g. when the block was reorged out.
+func (p *CachingReceiptsProvider) Remove(blockHash common.Hash) bool {
+	return p.cache.Remove(blockHash)
+}
+
 func (p *CachingReceiptsProvider) isInnerNil() bool {
 	return p.inner == nil
 }
@@ -3,6 +3,8 @@ package sources
 import (
 	"context"
 	"fmt"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -50,6 +52,19 @@ type RPCReceiptsFetcher struct {
 
 	// methodResetDuration defines how long we take till we reset lastMethodsReset
 	methodResetDuration time.Duration
+
+	// probeLock guards the state of the one-off probing of the supported receipt methods, used with RPCKindAuto.
+	// It is not held while probing, the probe runs in the background.
+	probeLock sync.Mutex
+	// probing is set while the supported receipt methods are probed.
+	probing bool
+	// probeResult holds the supported receipt methods found by the probe, until they are applied by the next fetch.
+	probeResult *ReceiptsFetchingMethod
+	// probed is set once the supported receipt methods have been probed, and applied.
+	probed bool
+	// supportedReceiptMethods is the set of methods that the periodic reset restores.
+	// With RPCKindAuto it is narrowed down to the methods the provider was found to support by probing.
+	supportedReceiptMethods ReceiptsFetchingMethod
 }
 
 type RPCReceiptsConfig struct {
@@ -65,51 +80,172 @@ func NewRPCReceiptsFetcher(client rpcClient, log log.Logger, config RPCReceiptsC
 		log:                     log,
 		provKind:                config.ProviderKind,
 		availableReceiptMethods: AvailableReceiptsFetchingMethods(config.ProviderKind),
+		supportedReceiptMethods: AvailableReceiptsFetchingMethods(config.ProviderKind),
 		lastMethodsReset:        time.Now(),
 		methodResetDuration:     config.MethodResetDuration,
 	}
 }
 
 func (f *RPCReceiptsFetcher) FetchReceipts(ctx context.Context, blockInfo eth.BlockInfo, txHashes []common.Hash) (result types.Receipts, err error) {
+	if f.provKind == RPCKindAuto {
+		f.maybeProbe(blockInfo, txHashes)
+	}
 	m := f.PickReceiptsMethod(len(txHashes))
-	block := eth.ToBlockID(blockInfo)
+	result, err = f.fetchReceiptsWith(ctx, m, blockInfo, txHashes)
+	if err != nil {
+		f.OnReceiptsMethodErr(m, err)
+		return nil, err
+	}
+
+	if err = validateReceipts(eth.ToBlockID(blockInfo), blockInfo.ReceiptHash(), txHashes, result); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// fetchReceiptsWith fetches the receipts of the block with the given method, without validating them.
+func (f *RPCReceiptsFetcher) fetchReceiptsWith(ctx context.Context, m ReceiptsFetchingMethod, blockInfo eth.BlockInfo, txHashes []common.Hash) (types.Receipts, error) {
+	if m == EthGetTransactionReceiptBatch {
+		return f.basic.FetchReceipts(ctx, blockInfo, txHashes)
+	}
+	elem, decode, ok := blockReceiptsCall(m, blockInfo.Hash())
+	if !ok {
+		return nil, fmt.Errorf("unknown receipt fetching method: %d", uint64(m))
+	}
+	if err := f.client.CallContext(ctx, elem.Result, elem.Method, elem.Args...); err != nil {
+		return nil, err
+	}
+	return decode(eth.ToBlockID(blockInfo), txHashes)
+}
+
+// blockReceiptsCall returns the call to fetch all the receipts of a block with the given method,
+// and the function to decode the result of the call, without validating the receipts.
+// It returns false if the method does not fetch the receipts of a block with a single call.
+func blockReceiptsCall(m ReceiptsFetchingMethod, blockHash common.Hash) (elem rpc.BatchElem, decode func(block eth.BlockID, txHashes []common.Hash) (types.Receipts, error), ok bool) {
+	var result types.Receipts
+	decodeResult := func(eth.BlockID, []common.Hash) (types.Receipts, error) { return result, nil }
 	switch m {
-	case EthGetTransactionReceiptBatch:
-		result, err = f.basic.FetchReceipts(ctx, blockInfo, txHashes)
 	case AlchemyGetTransactionReceipts:
 		var tmp receiptsWrapper
-		err = f.client.CallContext(ctx, &tmp, "alchemy_getTransactionReceipts", blockHashParameter{BlockHash: block.Hash})
-		result = tmp.Receipts
+		return rpc.BatchElem{Method: "alchemy_getTransactionReceipts", Args: []any{blockHashParameter{BlockHash: blockHash}}, Result: &tmp},
+			func(eth.BlockID, []common.Hash) (types.Receipts, error) { return tmp.Receipts, nil }, true
 	case DebugGetRawReceipts:
 		var rawReceipts []hexutil.Bytes
-		err = f.client.CallContext(ctx, &rawReceipts, "debug_getRawReceipts", block.Hash)
-		if err == nil {
-			if len(rawReceipts) == len(txHashes) {
-				result, err = eth.DecodeRawReceipts(block, rawReceipts, txHashes)
-			} else {
-				err = fmt.Errorf("got %d raw receipts, but expected %d", len(rawReceipts), len(txHashes))
-			}
-		}
+		return rpc.BatchElem{Method: "debug_getRawReceipts", Args: []any{blockHash}, Result: &rawReceipts},
+			func(block eth.BlockID, txHashes []common.Hash) (types.Receipts, error) {
+				if len(rawReceipts) != len(txHashes) {
+					return nil, fmt.Errorf("got %d raw receipts, but expected %d", len(rawReceipts), len(txHashes))
+				}
+				return eth.DecodeRawReceipts(block, rawReceipts, txHashes)
+			}, true
 	case ParityGetBlockReceipts:
-		err = f.client.CallContext(ctx, &result, "parity_getBlockReceipts", block.Hash)
+		return rpc.BatchElem{Method: "parity_getBlockReceipts", Args: []any{blockHash}, Result: &result}, decodeResult, true
 	case EthGetBlockReceipts:
-		err = f.client.CallContext(ctx, &result, "eth_getBlockReceipts", block.Hash)
+		return rpc.BatchElem{Method: "eth_getBlockReceipts", Args: []any{blockHash}, Result: &result}, decodeResult, true
 	case ErigonGetBlockReceiptsByBlockHash:
-		err = f.client.CallContext(ctx, &result, "erigon_getBlockReceiptsByBlockHash", block.Hash)
+		return rpc.BatchElem{Method: "erigon_getBlockReceiptsByBlockHash", Args: []any{blockHash}, Result: &result}, decodeResult, true
 	default:
-		err = fmt.Errorf("unknown receipt fetching method: %d", uint64(m))
+		return rpc.BatchElem{}, nil, false
 	}
+}
 
-	if err != nil {
-		f.OnReceiptsMethodErr(m, err)
-		return nil, err
+// receiptsBatchCall implements batchReceiptsProvider. The receipts are only fetched along with the block
+// if the preferred method fetches the receipts of a block with a single call, regardless of the number of transactions,
+// since the number of transactions is not known before the block is fetched.
+func (f *RPCReceiptsFetcher) receiptsBatchCall(blockHash common.Hash) (*receiptsBatchElem, bool) {
+	m := f.PickReceiptsMethod(0)
+	if m != PickBestReceiptsFetchingMethod(f.provKind, f.availableReceiptMethods, math.MaxUint64) {
+		return nil, false
 	}
+	elem, decode, ok := blockReceiptsCall(m, blockHash)
+	if !ok {
+		return nil, false
+	}
+	return &receiptsBatchElem{
+		elem: elem,
+		result: func(callErr error, blockInfo eth.BlockInfo, txHashes []common.Hash) (types.Receipts, error) {
+			if callErr != nil {
+				f.OnReceiptsMethodErr(m, callErr)
+				return nil, callErr
+			}
+			block := eth.ToBlockID(blockInfo)
+			result, err := decode(block, txHashes)
+			if err != nil {
+				f.OnReceiptsMethodErr(m, err)
+				return nil, err
+			}
+			if err := validateReceipts(block, blockInfo.ReceiptHash(), txHashes, result); err != nil {
+				return nil, err
+			}
+			return result, nil
+		},
+	}, true
+}
 
-	if err = validateReceipts(block, blockInfo.ReceiptHash(), txHashes, result); err != nil {
-		return nil, err
+// maybeProbe applies the supported receipt methods found by a completed probe, or starts probing them
+// in the background with the given block, if they have not been probed yet and are not being probed.
+// Fetches continue with all the methods, and the per-call fallback, until the probe completes.
+func (f *RPCReceiptsFetcher) maybeProbe(blockInfo eth.BlockInfo, txHashes []common.Hash) {
+	f.probeLock.Lock()
+	defer f.probeLock.Unlock()
+	switch {
+	case f.probed, f.probing:
+		return
+	case f.probeResult != nil:
+		f.probed = true
+		f.supportedReceiptMethods = *f.probeResult
+		f.availableReceiptMethods = *f.probeResult
+		f.lastMethodsReset = time.Now()
+		f.log.Info("Detected supported receipt methods of RPC provider", "methods", *f.probeResult,
+			"preferred", PickBestReceiptsFetchingMethod(f.provKind, *f.probeResult, uint64(len(txHashes))))
+	default:
+		f.probing = true
+		go f.probeReceiptsMethods(blockInfo, txHashes)
 	}
+}
 
-	return
+// probeReceiptsMethods detects which receipt methods the provider supports, by trying each of them once with the given block.
+// Methods the provider rejects as unknown or unsupported are not used again,
+// not even after the periodic reset of the available methods. Other errors, e.g. timeouts,
+// do not rule a method out, and are left to the regular per-call fallback.
+// If the probe times out, the methods are probed again with a later fetch.
+func (f *RPCReceiptsFetcher) probeReceiptsMethods(blockInfo eth.BlockInfo, txHashes []common.Hash) {
+	ctx, cancel := context.WithTimeout(context.Background(), receiptsProbeTimeout)
+	defer cancel()
+	supported := EthGetTransactionReceiptBatch // always supported, as the standard fallback
+	for _, m := range probedReceiptsMethods {
+		_, err := f.fetchReceiptsWith(ctx, m, blockInfo, txHashes)
+		if ctx.Err() != nil {
+			f.log.Warn("Timed out probing the supported receipt methods of RPC provider, probing again later", "method", m)
+			f.probeLock.Lock()
+			f.probing = false
+			f.probeLock.Unlock()
+			return
+		}
+		if err != nil && unusableMethod(err) {
+			f.log.Debug("RPC provider does not support receipt method", "method", m, "err", err)
+			continue
+		}
+		supported |= m
+	}
+	f.probeLock.Lock()
+	defer f.probeLock.Unlock()
+	f.probing = false
+	f.probeResult = &supported
+}
+
+// receiptsProbeTimeout bounds the time probing the supported receipt methods may take.
+const receiptsProbeTimeout = 30 * time.Second
+
+// probedReceiptsMethods are the optimized receipt methods probed with RPCKindAuto,
+// in order of preference between methods of the same cost.
+var probedReceiptsMethods = []ReceiptsFetchingMethod{
+	AlchemyGetTransactionReceipts,
+	DebugGetRawReceipts,
+	ErigonGetBlockReceiptsByBlockHash,
+	EthGetBlockReceipts,
+	ParityGetBlockReceipts,
 }
 
 // receiptsWrapper is a decoding type util. Alchemy in particular wraps the receipts array result.
@@ -120,7 +256,7 @@ type receiptsWrapper struct {
 func (f *RPCReceiptsFetcher) PickReceiptsMethod(txCount int) ReceiptsFetchingMethod {
 	txc := uint64(txCount)
 	if now := time.Now(); now.Sub(f.lastMethodsReset) > f.methodResetDuration {
-		m := AvailableReceiptsFetchingMethods(f.provKind)
+		m := f.supportedReceiptMethods
 		if f.availableReceiptMethods != m {
 			f.log.Warn("resetting back RPC preferences, please review RPC provider kind setting", "kind", f.provKind.String())
 		}
@@ -169,6 +305,7 @@ const (
 	RPCKindBasic      RPCProviderKind = "basic"    // try only the standard most basic receipt fetching
 	RPCKindAny        RPCProviderKind = "any"      // try any method available
 	RPCKindStandard   RPCProviderKind = "standard" // try standard methods, including newer optimized standard RPC methods
+	RPCKindAuto       RPCProviderKind = "auto"     // probe which methods the provider supports, and use the cheapest of those
 )
 
 var RPCProviderKinds = []RPCProviderKind{
@@ -182,6 +319,7 @@ var RPCProviderKinds = []RPCProviderKind{
 	RPCKindBasic,
 	RPCKindAny,
 	RPCKindStandard,
+	RPCKindAuto,
 }
 
 func (kind RPCProviderKind) String() string {
@@ -341,7 +479,7 @@ func AvailableReceiptsFetchingMethods(kind RPCProviderKind) ReceiptsFetchingMeth
 		return ErigonGetBlockReceiptsByBlockHash | EthGetTransactionReceiptBatch
 	case RPCKindBasic:
 		return EthGetTransactionReceiptBatch
-	case RPCKindAny:
+	case RPCKindAny, RPCKindAuto:
 		// if it's any kind of RPC provider, then try all methods
 		return AlchemyGetTransactionReceipts | EthGetBlockReceipts |
 			DebugGetRawReceipts | ErigonGetBlockReceiptsByBlockHash |
@@ -366,6 +504,19 @@ func PickBestReceiptsFetchingMethod(kind RPCProviderKind, available ReceiptsFetc
 			return EthGetBlockReceipts
 		}
 		return EthGetTransactionReceiptBatch
+	} else if kind == RPCKindAuto {
+		// The cheapest available method, see receiptsMethodCost.
+		best, bestCost := EthGetTransactionReceiptBatch, uint64(math.MaxUint64)
+		for _, m := range probedReceiptsMethods {
+			if cost := receiptsMethodCost(m, txCount); available&m != 0 && cost < bestCost {
+				best, bestCost = m, cost
+			}
+		}
+		// The standard per-tx fetching is always available, but only preferred if it is cheaper.
+		if receiptsMethodCost(EthGetTransactionReceiptBatch, txCount) < bestCost {
+			best = EthGetTransactionReceiptBatch
+		}
+		return best
 	} else if kind == RPCKindQuickNode {
 		if available&DebugGetRawReceipts != 0 {
 			return DebugGetRawReceipts
@@ -394,3 +545,25 @@ func PickBestReceiptsFetchingMethod(kind RPCProviderKind, available ReceiptsFetc
 	// otherwise fall back on per-tx fetching
 	return EthGetTransactionReceiptBatch
 }
+
+// receiptsMethodCost estimates the cost of fetching the receipts of a block with the given number of transactions
+// with the method, to rank the methods the provider supports with RPCKindAuto. Costs are in Alchemy compute units,
+// the methods that Alchemy does not serve, debug_getRawReceipts and erigon_getBlockReceiptsByBlockHash,
+// are mostly served by self-hosted nodes, and are counted as free.
+func receiptsMethodCost(m ReceiptsFetchingMethod, txCount uint64) uint64 {
+	switch m {
+	case EthGetTransactionReceiptBatch:
+		if txCount > math.MaxUint64/15 {
+			return math.MaxUint64
+		}
+		return 15 * txCount
+	case AlchemyGetTransactionReceipts:
+		return 250
+	case EthGetBlockReceipts, ParityGetBlockReceipts:
+		return 500
+	case DebugGetRawReceipts, ErigonGetBlockReceiptsByBlockHash:
+		return 0
+	default:
+		return math.MaxUint64
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -339,6 +342,285 @@ func TestEthClient_FetchReceipts(t *testing.T) {
 	}
 }
 
+// countingRPC counts the requests made with the RPC.
+type countingRPC struct {
+	client.RPC
+	calls   atomic.Int32
+	batches atomic.Int32
+}
+
+func (c *countingRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	c.calls.Add(1)
+	return c.RPC.CallContext(ctx, result, method, args...)
+}
+
+func (c *countingRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	c.batches.Add(1)
+	return c.RPC.BatchCallContext(ctx, b)
+}
+
+func TestEthClient_FetchReceiptsBatched(t *testing.T) {
+	block, receipts := randomRpcBlockAndReceipts(rand.New(rand.NewSource(123)), 4)
+	for _, r := range receipts {
+		r.ContractAddress = common.Address{}
+	}
+	var noErr error
+
+	setup := func(t *testing.T, kind RPCProviderKind) (*mock.Mock, *countingRPC, *EthClient) {
+		srv := rpc.NewServer()
+		t.Cleanup(srv.Stop)
+		m := &mock.Mock{}
+		require.NoError(t, srv.RegisterName("eth", &ethBackend{Mock: m}))
+		cl := &countingRPC{RPC: client.NewBaseRPCClient(rpc.DialInProc(srv))}
+		cfg := *testEthClientConfig
+		cfg.RPCProviderKind = kind
+		cfg.MethodResetDuration = time.Minute
+		ethCl, err := NewEthClient(cl, testlog.Logger(t, log.LevelError), nil, &cfg)
+		require.NoError(t, err)
+		return m, cl, ethCl
+	}
+
+	t.Run("block and receipts in one batch", func(t *testing.T) {
+		m, cl, ethCl := setup(t, RPCKindStandard)
+		m.On("eth_getBlockByHash", block.Hash, true).Once().Return(block)
+		m.On("eth_getBlockReceipts", block.Hash.String()).Once().Return(receipts, &noErr)
+		info, result, err := ethCl.FetchReceipts(context.Background(), block.Hash)
+		require.NoError(t, err)
+		require.Equal(t, block.Hash, info.Hash())
+		require.Len(t, result, len(receipts))
+		require.EqualValues(t, 0, cl.calls.Load())
+		require.EqualValues(t, 1, cl.batches.Load())
+
+		// the block and receipts are cached
+		_, _, err = ethCl.FetchReceipts(context.Background(), block.Hash)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, cl.batches.Load())
+		m.AssertExpectations(t)
+	})
+
+	t.Run("receipts method error", func(t *testing.T) {
+		m, cl, ethCl := setup(t, RPCKindStandard)
+		var notFound error = new(methodNotFoundError)
+		m.On("eth_getBlockByHash", block.Hash, true).Once().Return(block)
+		m.On("eth_getBlockReceipts", block.Hash.String()).Once().Return([]*types.Receipt(nil), &notFound)
+		_, _, err := ethCl.FetchReceipts(context.Background(), block.Hash)
+		require.ErrorContains(t, err, "does not exist")
+
+		// the block was cached, and the receipts are fetched with the fallback method
+		for i, tx := range block.Transactions {
+			m.On("eth_getTransactionReceipt", tx.Hash()).Once().Return(receipts[i], &noErr)
+		}
+		_, result, err := ethCl.FetchReceipts(context.Background(), block.Hash)
+		require.NoError(t, err)
+		require.Len(t, result, len(receipts))
+		require.EqualValues(t, 0, cl.calls.Load())
+		require.EqualValues(t, 2, cl.batches.Load())
+		m.AssertExpectations(t)
+	})
+
+	t.Run("concurrent fetches share the batch", func(t *testing.T) {
+		m, cl, ethCl := setup(t, RPCKindStandard)
+		m.On("eth_getBlockByHash", block.Hash, true).Once().After(20 * time.Millisecond).Return(block)
+		m.On("eth_getBlockReceipts", block.Hash.String()).Once().Return(receipts, &noErr)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, result, err := ethCl.FetchReceipts(context.Background(), block.Hash)
+				assert.NoError(t, err)
+				assert.Len(t, result, len(receipts))
+			}()
+		}
+		wg.Wait()
+		require.EqualValues(t, 1, cl.batches.Load())
+		m.AssertExpectations(t)
+	})
+
+	t.Run("per-tx receipts are not batched with the block", func(t *testing.T) {
+		m, cl, ethCl := setup(t, RPCKindBasic)
+		m.On("eth_getBlockByHash", block.Hash, true).Once().Return(block)
+		for i, tx := range block.Transactions {
+			m.On("eth_getTransactionReceipt", tx.Hash()).Once().Return(receipts[i], &noErr)
+		}
+		_, _, err := ethCl.FetchReceipts(context.Background(), block.Hash)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, cl.calls.Load())
+		require.EqualValues(t, 1, cl.batches.Load())
+		m.AssertExpectations(t)
+	})
+}
+
+// TestEthClient_UntrustedRPC tests that data of an untrusted RPC is verified against the block hash.
+func TestEthClient_UntrustedRPC(t *testing.T) {
+	block, receipts := randomRpcBlockAndReceipts(rand.New(rand.NewSource(123)), 4)
+	for _, r := range receipts {
+		r.ContractAddress = common.Address{}
+	}
+	var noErr error
+
+	fetch := func(t *testing.T, trustRPC bool, block *RPCBlock, receipts []*types.Receipt) error {
+		srv := rpc.NewServer()
+		t.Cleanup(srv.Stop)
+		m := &mock.Mock{}
+		require.NoError(t, srv.RegisterName("eth", &ethBackend{Mock: m}))
+		cfg := *testEthClientConfig
+		cfg.TrustRPC = trustRPC
+		ethCl, err := NewEthClient(client.NewBaseRPCClient(rpc.DialInProc(srv)), testlog.Logger(t, log.LevelError), nil, &cfg)
+		require.NoError(t, err)
+		m.On("eth_getBlockByHash", block.Hash, true).Return(block)
+		m.On("eth_getBlockReceipts", block.Hash.String()).Return(receipts, &noErr)
+		_, _, err = ethCl.FetchReceipts(context.Background(), block.Hash)
+		return err
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, fetch(t, false, block, receipts))
+	})
+
+	t.Run("tampered header", func(t *testing.T) {
+		tampered := *block
+		tampered.GasUsed++
+		require.ErrorContains(t, fetch(t, false, &tampered, receipts), "failed to verify block hash")
+		// the header is not verified if the RPC is trusted
+		require.NoError(t, fetch(t, true, &tampered, receipts))
+	})
+
+	t.Run("tampered transactions", func(t *testing.T) {
+		tampered := *block
+		tampered.Transactions = block.Transactions[1:]
+		require.ErrorContains(t, fetch(t, false, &tampered, receipts[1:]), "failed to verify transactions list")
+	})
+
+	t.Run("tampered receipts", func(t *testing.T) {
+		tampered := make([]*types.Receipt, len(receipts))
+		for i, r := range receipts {
+			cpy := *r
+			tampered[i] = &cpy
+		}
+		tampered[2].Status = 1 - tampered[2].Status
+		require.ErrorContains(t, fetch(t, false, block, tampered), "expected receipt root")
+		// receipts are always verified, regardless of the trust in the RPC
+		require.ErrorContains(t, fetch(t, true, block, tampered), "expected receipt root")
+	})
+}
+
+func TestRPCReceiptsFetcher_Auto(t *testing.T) {
+	block, receipts := randomRpcBlockAndReceipts(rand.New(rand.NewSource(123)), 4)
+	for _, r := range receipts {
+		r.ContractAddress = common.Address{}
+	}
+	info, txs, err := block.Info(true, false)
+	require.NoError(t, err)
+	txHashes := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		txHashes[i] = tx.Hash()
+	}
+	var notFound error = new(methodNotFoundError)
+	var noErr error
+
+	setup := func(t *testing.T, methodResetDuration time.Duration) (*mock.Mock, *RPCReceiptsFetcher) {
+		srv := rpc.NewServer()
+		t.Cleanup(srv.Stop)
+		m := &mock.Mock{}
+		require.NoError(t, srv.RegisterName("eth", &ethBackend{Mock: m}))
+		require.NoError(t, srv.RegisterName("alchemy", &alchemyBackend{Mock: m}))
+		require.NoError(t, srv.RegisterName("debug", &debugBackend{Mock: m}))
+		require.NoError(t, srv.RegisterName("parity", &parityBackend{Mock: m}))
+		require.NoError(t, srv.RegisterName("erigon", &erigonBackend{Mock: m}))
+		cl := client.NewBaseRPCClient(rpc.DialInProc(srv))
+		f := NewRPCReceiptsFetcher(cl, testlog.Logger(t, log.LevelError), RPCReceiptsConfig{
+			MaxBatchSize:        20,
+			ProviderKind:        RPCKindAuto,
+			MethodResetDuration: methodResetDuration,
+		})
+		return m, f
+	}
+	blockHash := block.Hash.String()
+	probedMethods := func(f *RPCReceiptsFetcher) ReceiptsFetchingMethod {
+		var result ReceiptsFetchingMethod
+		require.Eventually(t, func() bool {
+			f.probeLock.Lock()
+			defer f.probeLock.Unlock()
+			if f.probeResult != nil {
+				result = *f.probeResult
+			}
+			return f.probeResult != nil
+		}, 5*time.Second, 10*time.Millisecond)
+		return result
+	}
+
+	t.Run("ranks supported methods by cost", func(t *testing.T) {
+		m, f := setup(t, time.Minute)
+		m.On("alchemy_getTransactionReceipts", blockHash).Return([]*types.Receipt(nil), &notFound)
+		m.On("debug_getRawReceipts", blockHash).Return([]hexutil.Bytes(nil), &notFound)
+		m.On("erigon_getBlockReceiptsByBlockHash", blockHash).Return([]*types.Receipt(nil), &notFound)
+		m.On("eth_getBlockReceipts", blockHash).Return(receipts, &noErr)
+		m.On("parity_getBlockReceipts", blockHash).Return(receipts, &noErr)
+		for i, tx := range txs {
+			m.On("eth_getTransactionReceipt", tx.Hash()).Return(receipts[i], &noErr)
+		}
+
+		// The first fetch starts probing, and the next fetch applies the result
+		_, _ = f.FetchReceipts(context.Background(), info, txHashes)
+		require.Equal(t, EthGetBlockReceipts|ParityGetBlockReceipts|EthGetTransactionReceiptBatch, probedMethods(f))
+		_, err := f.FetchReceipts(context.Background(), info, txHashes)
+		require.NoError(t, err)
+		require.Equal(t, EthGetBlockReceipts|ParityGetBlockReceipts|EthGetTransactionReceiptBatch, f.supportedReceiptMethods)
+
+		// Per-tx fetching is cheaper for blocks with few transactions
+		require.Equal(t, EthGetTransactionReceiptBatch, f.PickReceiptsMethod(len(txHashes)))
+		require.Equal(t, EthGetBlockReceipts, f.PickReceiptsMethod(100))
+	})
+
+	t.Run("unsupported methods stay excluded after reset", func(t *testing.T) {
+		// reset instantly, to verify the reset does not restore the unsupported methods
+		m, f := setup(t, 0)
+		m.On("alchemy_getTransactionReceipts", blockHash).Return([]*types.Receipt(nil), &notFound)
+		m.On("debug_getRawReceipts", blockHash).Return([]hexutil.Bytes(nil), &notFound)
+		m.On("erigon_getBlockReceiptsByBlockHash", blockHash).Return([]*types.Receipt(nil), &notFound)
+		m.On("eth_getBlockReceipts", blockHash).Return([]*types.Receipt(nil), &notFound)
+		m.On("parity_getBlockReceipts", blockHash).Return([]*types.Receipt(nil), &notFound)
+		for i, tx := range txs {
+			m.On("eth_getTransactionReceipt", tx.Hash()).Return(receipts[i], &noErr)
+		}
+		_, _ = f.FetchReceipts(context.Background(), info, txHashes)
+		require.Equal(t, EthGetTransactionReceiptBatch, probedMethods(f))
+		_, err := f.FetchReceipts(context.Background(), info, txHashes)
+		require.NoError(t, err)
+		require.Equal(t, EthGetTransactionReceiptBatch, f.PickReceiptsMethod(100))
+	})
+
+	t.Run("fetches while probing", func(t *testing.T) {
+		m, f := setup(t, time.Minute)
+		unblock := make(chan time.Time)
+		m.On("alchemy_getTransactionReceipts", blockHash).WaitUntil(unblock).Return([]*types.Receipt(nil), &notFound)
+		m.On("debug_getRawReceipts", blockHash).Return(rawReceipts(t, receipts), &noErr)
+		m.On("erigon_getBlockReceiptsByBlockHash", blockHash).Return([]*types.Receipt(nil), &notFound)
+		m.On("eth_getBlockReceipts", blockHash).Return([]*types.Receipt(nil), &notFound)
+		m.On("parity_getBlockReceipts", blockHash).Return([]*types.Receipt(nil), &notFound)
+
+		// The probe is blocked on the first method, fetches continue with the cheapest method meanwhile
+		for i := 0; i < 2; i++ {
+			result, err := f.FetchReceipts(context.Background(), info, txHashes)
+			require.NoError(t, err)
+			require.Len(t, result, len(receipts))
+		}
+		close(unblock)
+		require.Equal(t, DebugGetRawReceipts|EthGetTransactionReceiptBatch, probedMethods(f))
+	})
+}
+
+func rawReceipts(t *testing.T, receipts []*types.Receipt) []hexutil.Bytes {
+	var raw []hexutil.Bytes
+	for _, r := range receipts {
+		data, err := r.MarshalBinary()
+		require.NoError(t, err)
+		raw = append(raw, data)
+	}
+	return raw
+}
+
 func TestVerifyReceipts(t *testing.T) {
 	validData := func() (eth.BlockID, common.Hash, []common.Hash, []*types.Receipt) {
 		block := eth.BlockID{
@@ -512,6 +794,13 @@ func TestVerifyReceipts(t *testing.T) {
 		require.ErrorContains(t, err, "has unexpected block hash")
 	})
 
+	t.Run("IncorrectTxHash", func(t *testing.T) {
+		block, receiptHash, txHashes, receipts := validData()
+		receipts[1].TxHash = common.Hash{0x87}
+		err := validateReceipts(block, receiptHash, txHashes, receipts)
+		require.ErrorContains(t, err, "has unexpected tx hash")
+	})
+
 	t.Run("IncorrectCumulativeUsed", func(t *testing.T) {
 		block, receiptHash, txHashes, receipts := validData()
 		receipts[1].CumulativeGasUsed = receipts[0].CumulativeGasUsed
@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"golang.org/x/exp/slog"
@@ -32,18 +33,53 @@ func (r *RollupClient) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (
 	return output, err
 }
 
+func (r *RollupClient) DerivationProgressAtL1Block(ctx context.Context, blockNum uint64) (*eth.DerivationProgress, error) {
+	var output *eth.DerivationProgress
+	err := r.rpc.CallContext(ctx, &output, "optimism_derivationProgressAtL1Block", hexutil.Uint64(blockNum))
+	return output, err
+}
+
+func (r *RollupClient) L2BlockByTimestamp(ctx context.Context, timestamp uint64) (eth.L2BlockRef, error) {
+	var output eth.L2BlockRef
+	err := r.rpc.CallContext(ctx, &output, "optimism_l2BlockByTimestamp", hexutil.Uint64(timestamp))
+	return output, err
+}
+
+func (r *RollupClient) L1OriginAtTimestamp(ctx context.Context, timestamp uint64) (eth.BlockID, error) {
+	var output eth.BlockID
+	err := r.rpc.CallContext(ctx, &output, "optimism_l1OriginAtTimestamp", hexutil.Uint64(timestamp))
+	return output, err
+}
+
 func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	var output *eth.SyncStatus
 	err := r.rpc.CallContext(ctx, &output, "optimism_syncStatus")
 	return output, err
 }
 
+// SubscribeSyncStatus subscribes to changes of the sync status of the rollup node. Notifications may be dropped
+// while ch is full. Subscriptions require a websocket connection to the rollup node.
+func (r *RollupClient) SubscribeSyncStatus(ctx context.Context, ch chan<- *eth.SyncStatus) (ethereum.Subscription, error) {
+	s, ok := r.rpc.(client.Subscriber)
+	if !ok {
+		return nil, client.ErrSubscriptionsUnsupported
+	}
+	return s.Subscribe(ctx, "optimism", ch, "syncStatusUpdates")
+}
+
 func (r *RollupClient) RollupConfig(ctx context.Context) (*rollup.Config, error) {
 	var output *rollup.Config
 	err := r.rpc.CallContext(ctx, &output, "optimism_rollupConfig")
 	return output, err
 }
 
+// BuilderStatus returns the status of the block builder of the sequencer, or nil if the sequencer has no builder.
+func (r *RollupClient) BuilderStatus(ctx context.Context) (*eth.BuilderStatus, error) {
+	var output *eth.BuilderStatus
+	err := r.rpc.CallContext(ctx, &output, "optimism_builderStatus")
+	return output, err
+}
+
 func (r *RollupClient) Version(ctx context.Context) (string, error) {
 	var output string
 	err := r.rpc.CallContext(ctx, &output, "optimism_version")
@@ -70,10 +106,68 @@ func (r *RollupClient) PostUnsafePayload(ctx context.Context, payload *eth.Execu
 	return r.rpc.CallContext(ctx, nil, "admin_postUnsafePayload", payload)
 }
 
+func (r *RollupClient) ExportSequencerState(ctx context.Context) (*eth.SequencerState, error) {
+	var result *eth.SequencerState
+	err := r.rpc.CallContext(ctx, &result, "admin_exportSequencerState")
+	return result, err
+}
+
+func (r *RollupClient) ImportSequencerState(ctx context.Context, state *eth.SequencerState) error {
+	return r.rpc.CallContext(ctx, nil, "admin_importSequencerState", state)
+}
+
 func (r *RollupClient) OverrideLeader(ctx context.Context) error {
 	return r.rpc.CallContext(ctx, nil, "admin_overrideLeader")
 }
 
+func (r *RollupClient) AddInclusionDeadline(ctx context.Context, txHash common.Hash, blocks uint64) (eth.InclusionDeadline, error) {
+	var result eth.InclusionDeadline
+	err := r.rpc.CallContext(ctx, &result, "admin_addInclusionDeadline", txHash, hexutil.Uint64(blocks))
+	return result, err
+}
+
+func (r *RollupClient) RemoveInclusionDeadline(ctx context.Context, txHash common.Hash) error {
+	return r.rpc.CallContext(ctx, nil, "admin_removeInclusionDeadline", txHash)
+}
+
+func (r *RollupClient) InclusionDeadlines(ctx context.Context) ([]eth.InclusionDeadline, error) {
+	var result []eth.InclusionDeadline
+	err := r.rpc.CallContext(ctx, &result, "admin_inclusionDeadlines")
+	return result, err
+}
+
+func (r *RollupClient) SetSequencerPolicy(ctx context.Context, policy *eth.TxPoolPolicy) error {
+	return r.rpc.CallContext(ctx, nil, "admin_setSequencerPolicy", policy)
+}
+
+func (r *RollupClient) SequencerPolicy(ctx context.Context) (*eth.TxPoolPolicy, error) {
+	var result *eth.TxPoolPolicy
+	err := r.rpc.CallContext(ctx, &result, "admin_sequencerPolicy")
+	return result, err
+}
+
+func (r *RollupClient) UnsafeReorgHalt(ctx context.Context) (*eth.UnsafeReorgHalt, error) {
+	var result *eth.UnsafeReorgHalt
+	err := r.rpc.CallContext(ctx, &result, "admin_unsafeReorgHalt")
+	return result, err
+}
+
+func (r *RollupClient) ConfirmUnsafeReorg(ctx context.Context) error {
+	return r.rpc.CallContext(ctx, nil, "admin_confirmUnsafeReorg")
+}
+
+func (r *RollupClient) BuildPayloadAttributes(ctx context.Context, l1Origin eth.BlockID, l2Parent eth.BlockID) (*eth.PayloadAttributes, error) {
+	var output *eth.PayloadAttributes
+	err := r.rpc.CallContext(ctx, &output, "debug_buildPayloadAttributes", l1Origin, l2Parent)
+	return output, err
+}
+
+func (r *RollupClient) EngineQueueState(ctx context.Context) (*eth.EngineQueueState, error) {
+	var output *eth.EngineQueueState
+	err := r.rpc.CallContext(ctx, &output, "debug_engineQueueState")
+	return output, err
+}
+
 func (r *RollupClient) SetLogLevel(ctx context.Context, lvl slog.Level) error {
 	return r.rpc.CallContext(ctx, nil, "admin_setLogLevel", lvl.String())
 }
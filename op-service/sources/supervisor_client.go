@@ -0,0 +1,38 @@
+package sources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SupervisorClient is a client of the interop supervisor, which tracks the cross-chain safety of messages.
+type SupervisorClient struct {
+	rpc client.RPC
+}
+
+func NewSupervisorClient(rpc client.RPC) *SupervisorClient {
+	return &SupervisorClient{rpc}
+}
+
+// CheckMessages checks that the initiating messages of the given executing messages exist,
+// and are at least of the given safety level. The supervisor responds with an error if any message is not.
+//
+// The error is an eth.InputError with the eth.InvalidExecutingMessage code if a message is invalid,
+// and will never become safe. Other errors, like messages that are not safe yet, may be resolved by retrying.
+func (cl *SupervisorClient) CheckMessages(ctx context.Context, messages []eth.Message, minSafety eth.SafetyLevel) error {
+	err := cl.rpc.CallContext(ctx, nil, "supervisor_checkMessages", messages, minSafety)
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && eth.ErrorCode(rpcErr.ErrorCode()) == eth.InvalidExecutingMessage {
+		return eth.InputError{Inner: err, Code: eth.InvalidExecutingMessage}
+	}
+	return err
+}
+
+func (cl *SupervisorClient) Close() {
+	cl.rpc.Close()
+}
@@ -1,6 +1,8 @@
 package testutils
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -56,19 +58,28 @@ func (t *TestDerivationMetrics) RecordFrame() {
 func (n *TestDerivationMetrics) RecordDerivedBatches(batchType string) {
 }
 
+func (n *TestDerivationMetrics) RecordDerivedDeposits(included int, pending int) {
+}
+
 type TestRPCMetrics struct{}
 
-func (n *TestRPCMetrics) RecordRPCServerRequest(method string) func() {
-	return func() {}
+func (n *TestRPCMetrics) RecordRPCServerRequest(method string, duration time.Duration, requestSize int, responseSize int) {
 }
 
+func (n *TestRPCMetrics) RecordRPCServerResponse(method string, err error) {}
+
 func (n *TestRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 	return func(err error) {}
 }
 
 func (n *TestRPCMetrics) RecordRPCClientResponse(method string, err error) {}
 
+func (n *TestRPCMetrics) RecordRPCClientPayloadSizes(method string, requestSize int, responseSize int) {
+}
+
 func (t *TestDerivationMetrics) SetDerivationIdle(idle bool) {}
 
+func (t *TestDerivationMetrics) RecordDerivationMemSize(stage string, memSize uint64) {}
+
 func (t *TestDerivationMetrics) RecordPipelineReset() {
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -142,6 +143,15 @@ func (m *MockEthClient) ExpectBlockByNumber(number *big.Int, block *types.Block,
 	m.Mock.On("BlockByNumber", number).Once().Return(block, err)
 }
 
+func (m *MockEthClient) Client() *rpc.Client {
+	out := m.Mock.Called()
+	return out.Get(0).(*rpc.Client)
+}
+
+func (m *MockEthClient) ExpectClient(client *rpc.Client) {
+	m.Mock.On("Client").Return(client)
+}
+
 func (m *MockEthClient) ExpectClose() {
 	m.Mock.On("Close").Once()
 }
@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const (
+	EnabledFlagName     = "tracing.enabled"
+	EndpointFlagName    = "tracing.endpoint"
+	SampleRatioFlagName = "tracing.sample-ratio"
+
+	defaultEndpoint    = "http://localhost:4318"
+	defaultSampleRatio = 1.0
+)
+
+func DefaultCLIConfig() CLIConfig {
+	return CLIConfig{
+		Enabled:     false,
+		Endpoint:    defaultEndpoint,
+		SampleRatio: defaultSampleRatio,
+	}
+}
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return CLIFlagsWithCategory(envPrefix, "")
+}
+
+func CLIFlagsWithCategory(envPrefix string, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:     EnabledFlagName,
+			Usage:    "Enable OpenTelemetry tracing, exporting spans with OTLP over HTTP",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     EndpointFlagName,
+			Usage:    "OTLP HTTP endpoint of the trace collector. Spans are sent to {endpoint}/v1/traces",
+			Value:    defaultEndpoint,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_ENDPOINT"),
+			Category: category,
+		},
+		&cli.Float64Flag{
+			Name:     SampleRatioFlagName,
+			Usage:    "Ratio of traces to sample, between 0 and 1. Traces continued from other services follow their sampling decision",
+			Value:    defaultSampleRatio,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_SAMPLE_RATIO"),
+			Category: category,
+		},
+	}
+}
+
+type CLIConfig struct {
+	Enabled     bool
+	Endpoint    string
+	SampleRatio float64
+}
+
+func (c CLIConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("tracing endpoint must be an http or https URL")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return errors.New("tracing sample ratio must be between 0 and 1")
+	}
+	return nil
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		Enabled:     ctx.Bool(EnabledFlagName),
+		Endpoint:    ctx.String(EndpointFlagName),
+		SampleRatio: ctx.Float64(SampleRatioFlagName),
+	}
+}
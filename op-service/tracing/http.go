@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+// NewHTTPMiddleware traces each request to the wrapped handler with a span of the given name.
+// Spans continue the trace of the traceparent header of the request, so callers in other services are linked.
+func NewHTTPMiddleware(t Tracer, name string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := ExtractHTTPHeaders(r.Context(), r.Header)
+			ctx, span := t.Start(ctx, name,
+				String("http.method", r.Method),
+				String("http.target", r.URL.Path))
+			defer span.End()
+			rw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r.WithContext(ctx))
+			span.SetAttributes(Int64("http.status_code", int64(rw.status)))
+			if rw.status >= http.StatusInternalServerError {
+				span.RecordError(&statusError{status: rw.status})
+			}
+		})
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack supports websocket upgrades of traced requests.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	r.status = http.StatusSwitchingProtocols
+	return h.Hijack()
+}
+
+type statusError struct {
+	status int
+}
+
+func (e *statusError) Error() string {
+	return "http status " + strconv.Itoa(e.status)
+}
@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// instrumentationName is the name of the instrumentation scope of the spans.
+const instrumentationName = "github.com/ethereum-optimism/optimism/op-service/tracing"
+
+// OTLPTracer is a Tracer that exports sampled spans in batches to an OTLP collector over HTTP,
+// with the OpenTelemetry SDK. Spans are exported in the background. If the collector can't keep up,
+// new spans are dropped.
+type OTLPTracer struct {
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+}
+
+var _ Tracer = (*OTLPTracer)(nil)
+
+// NewOTLPTracer creates a tracer that exports the spans of the named service to the collector of the config,
+// until it is closed. Export errors are logged.
+func NewOTLPTracer(lgr log.Logger, serviceName string, cfg CLIConfig) (*OTLPTracer, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid tracing config: %w", err)
+	}
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpointURL(strings.TrimSuffix(cfg.Endpoint, "/")+"/v1/traces"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	// the error handler is global to the SDK
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		lgr.Warn("Failed to export spans", "err", err)
+	}))
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		// traces continued from other services follow their sampling decision
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	lgr.Info("Started OpenTelemetry tracing", "endpoint", cfg.Endpoint, "sample_ratio", cfg.SampleRatio)
+	return &OTLPTracer{
+		provider: provider,
+		tracer:   provider.Tracer(instrumentationName),
+	}, nil
+}
+
+// NewTracer creates an OTLPTracer if tracing is enabled in the config, or else a NoopTracer.
+// The returned close function stops the tracer, after exporting the remaining spans.
+func NewTracer(lgr log.Logger, serviceName string, cfg CLIConfig) (Tracer, func(ctx context.Context) error, error) {
+	if !cfg.Enabled {
+		return NoopTracer{}, func(ctx context.Context) error { return nil }, nil
+	}
+	t, err := NewOTLPTracer(lgr, serviceName, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, t.Close, nil
+}
+
+func (t *OTLPTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	ctx, s := t.tracer.Start(ctx, name, trace.WithAttributes(toOTelAttributes(attrs)...))
+	return ctx, otelSpan{s}
+}
+
+// Close stops the tracer, after exporting the remaining spans. Spans ended after closing are dropped.
+func (t *OTLPTracer) Close(ctx context.Context) error {
+	return t.provider.Shutdown(ctx)
+}
+
+// otelSpan is a span of the OTLPTracer.
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s otelSpan) SetAttributes(attrs ...Attribute) {
+	s.span.SetAttributes(toOTelAttributes(attrs)...)
+}
+
+func (s otelSpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s otelSpan) End() {
+	s.span.End()
+}
+
+func toOTelAttributes(attrs []Attribute) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch x := a.Value.(type) {
+		case string:
+			out = append(out, attribute.String(a.Key, x))
+		case bool:
+			out = append(out, attribute.Bool(a.Key, x))
+		case int64:
+			out = append(out, attribute.Int64(a.Key, x))
+		case int:
+			out = append(out, attribute.Int(a.Key, x))
+		case float64:
+			out = append(out, attribute.Float64(a.Key, x))
+		default:
+			out = append(out, attribute.String(a.Key, fmt.Sprint(x)))
+		}
+	}
+	return out
+}
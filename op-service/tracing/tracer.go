@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Tracer starts spans, which measure the duration of operations such as RPC calls, derivation steps
+// and block production. Spans started with a context that carries a span are children of that span.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is an operation being traced. End must be called once the operation completes.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	// RecordError marks the span as failed with the given error. Nil errors are ignored.
+	RecordError(err error)
+	End()
+}
+
+// Attribute is a key-value pair describing a span.
+// Values are strings, bools, int64s or float64s. Other values are exported as strings.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Uint64(key string, value uint64) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// NoopTracer is a Tracer for when tracing is disabled. It does not propagate or record any spans.
+type NoopTracer struct{}
+
+var _ Tracer = NoopTracer{}
+
+func (NoopTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...Attribute) {}
+func (noopSpan) RecordError(err error)            {}
+func (noopSpan) End()                             {}
+
+// traceContext propagates spans across services with the W3C Trace Context traceparent header.
+var traceContext = propagation.TraceContext{}
+
+// InjectHTTPHeaders sets the traceparent header to the span carried by the context, if any.
+func InjectHTTPHeaders(ctx context.Context, h http.Header) {
+	traceContext.Inject(ctx, propagation.HeaderCarrier(h))
+}
+
+// ExtractHTTPHeaders returns a context carrying the span of the traceparent header, if it is valid.
+func ExtractHTTPHeaders(ctx context.Context, h http.Header) context.Context {
+	return traceContext.Extract(ctx, propagation.HeaderCarrier(h))
+}
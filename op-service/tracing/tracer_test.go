@@ -0,0 +1,217 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// testCollector is an OTLP collector that receives spans over HTTP with the protobuf encoding.
+type testCollector struct {
+	mu    sync.Mutex
+	spans []*tracepb.Span
+	svc   string
+}
+
+func (c *testCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var req coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range req.ResourceSpans {
+		for _, a := range rs.Resource.Attributes {
+			if a.Key == "service.name" {
+				c.svc = a.Value.GetStringValue()
+			}
+		}
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	out, _ := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+	_, _ = w.Write(out)
+}
+
+func (c *testCollector) collected() []*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*tracepb.Span(nil), c.spans...)
+}
+
+func newTestTracer(t *testing.T, sampleRatio float64) (*OTLPTracer, *testCollector) {
+	collector := &testCollector{}
+	srv := httptest.NewServer(collector)
+	t.Cleanup(srv.Close)
+	cfg := DefaultCLIConfig()
+	cfg.Enabled = true
+	cfg.Endpoint = srv.URL
+	cfg.SampleRatio = sampleRatio
+	tracer, err := NewOTLPTracer(testlog.Logger(t, log.LevelInfo), "test-service", cfg)
+	require.NoError(t, err)
+	return tracer, collector
+}
+
+func attr(s *tracepb.Span, key string) *commonpb.AnyValue {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return nil
+}
+
+func TestOTLPTracerExportsSpans(t *testing.T) {
+	tracer, collector := newTestTracer(t, 1)
+	ctx, parent := tracer.Start(context.Background(), "parent", String("a", "b"))
+	_, child := tracer.Start(ctx, "child", Int64("n", 42), Bool("ok", true))
+	child.RecordError(errors.New("boom"))
+	child.End()
+	parent.End()
+	parent.End() // ending twice is a no-op
+	require.NoError(t, tracer.Close(context.Background()))
+
+	spans := collector.collected()
+	require.Len(t, spans, 2)
+	require.Equal(t, "test-service", collector.svc)
+	c, p := spans[0], spans[1]
+	require.Equal(t, "child", c.Name)
+	require.Equal(t, "parent", p.Name)
+	require.Equal(t, p.TraceId, c.TraceId)
+	require.Equal(t, p.SpanId, c.ParentSpanId)
+	require.Empty(t, p.ParentSpanId)
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, c.Status.Code)
+	require.Equal(t, "boom", c.Status.Message)
+	require.Equal(t, int64(42), attr(c, "n").GetIntValue())
+	require.True(t, attr(c, "ok").GetBoolValue())
+	require.Equal(t, "b", attr(p, "a").GetStringValue())
+}
+
+func TestOTLPTracerSampling(t *testing.T) {
+	tracer, collector := newTestTracer(t, 0)
+	ctx, root := tracer.Start(context.Background(), "root")
+	require.False(t, trace.SpanContextFromContext(ctx).IsSampled())
+	root.End()
+
+	// Traces that are sampled by a caller are sampled, regardless of the ratio
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	_, span := tracer.Start(trace.ContextWithRemoteSpanContext(context.Background(), remote), "continued")
+	span.End()
+	require.NoError(t, tracer.Close(context.Background()))
+
+	spans := collector.collected()
+	require.Len(t, spans, 1)
+	require.Equal(t, "continued", spans[0].Name)
+	require.Equal(t, remote.TraceID().String(), hex.EncodeToString(spans[0].TraceId))
+	require.Equal(t, remote.SpanID().String(), hex.EncodeToString(spans[0].ParentSpanId))
+}
+
+func TestHTTPHeaders(t *testing.T) {
+	h := make(http.Header)
+	InjectHTTPHeaders(context.Background(), h)
+	require.Empty(t, h.Get("traceparent"), "no span to propagate")
+
+	h.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	sc := trace.SpanContextFromContext(ExtractHTTPHeaders(context.Background(), h))
+	require.True(t, sc.IsRemote())
+	require.True(t, sc.IsSampled())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+
+	out := make(http.Header)
+	InjectHTTPHeaders(trace.ContextWithSpanContext(context.Background(), sc), out)
+	require.Equal(t, h.Get("traceparent"), out.Get("traceparent"))
+
+	for _, invalid := range []string{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+	} {
+		h.Set("traceparent", invalid)
+		require.False(t, trace.SpanContextFromContext(ExtractHTTPHeaders(context.Background(), h)).IsValid(), invalid)
+	}
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	tracer, collector := newTestTracer(t, 1)
+	var handlerSC trace.SpanContext
+	handler := NewHTTPMiddleware(tracer, "rpc-server")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerSC = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	ctx, caller := tracer.Start(context.Background(), "caller")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/rpc", nil)
+	require.NoError(t, err)
+	InjectHTTPHeaders(ctx, req.Header)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	caller.End()
+	require.NoError(t, tracer.Close(context.Background()))
+
+	spans := collector.collected()
+	require.Len(t, spans, 2)
+	server, client := spans[0], spans[1]
+	require.Equal(t, "rpc-server", server.Name)
+	require.Equal(t, client.TraceId, server.TraceId)
+	require.Equal(t, client.SpanId, server.ParentSpanId)
+	require.Equal(t, handlerSC.SpanID().String(), hex.EncodeToString(server.SpanId))
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, server.Status.Code)
+}
+
+func TestNoopTracer(t *testing.T) {
+	tr, closeFn, err := NewTracer(testlog.Logger(t, log.LevelInfo), "test", DefaultCLIConfig())
+	require.NoError(t, err)
+	require.IsType(t, NoopTracer{}, tr)
+	ctx, span := tr.Start(context.Background(), "noop")
+	span.RecordError(errors.New("boom"))
+	span.End()
+	require.False(t, trace.SpanContextFromContext(ctx).IsValid(), "noop spans are not propagated")
+	require.NoError(t, closeFn(context.Background()))
+}
+
+func TestCLIConfig(t *testing.T) {
+	require.NoError(t, DefaultCLIConfig().Check())
+	cfg := DefaultCLIConfig()
+	cfg.Enabled = true
+	require.NoError(t, cfg.Check())
+	cfg.SampleRatio = 1.5
+	require.Error(t, cfg.Check())
+	cfg.SampleRatio = 0.5
+	cfg.Endpoint = "localhost:4318"
+	require.Error(t, cfg.Check())
+}
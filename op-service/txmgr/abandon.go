@@ -0,0 +1,120 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrAbandoned is wrapped by the AbandonedError of a send that did not confirm before its deadline.
+var ErrAbandoned = errors.New("transaction abandoned after deadline")
+
+// AbandonedError is returned by Send if the transaction did not confirm before the deadline of the [TxCandidate].
+// The transaction manager then stopped bumping fees, and attempted to cancel the transaction,
+// by replacing it with a self-transfer at a higher fee.
+// Callers can inspect it to decide whether to resubmit, e.g. with a larger fee limit, or to give up.
+type AbandonedError struct {
+	// Tx is the latest fee-bumped transaction of the send.
+	Tx *types.Transaction
+	// Cancellation is the self-transfer that replaces Tx, nil if it could not be created.
+	Cancellation *types.Transaction
+	// CancellationReceipt is the receipt of the confirmed cancellation, nil if the cancellation is still pending.
+	CancellationReceipt *types.Receipt
+	// Err is the error of creating or signing the cancellation, if any.
+	Err error
+}
+
+func (e *AbandonedError) Error() string {
+	switch {
+	case e.Err != nil:
+		return fmt.Sprintf("%v: tx %s with nonce %d, failed to cancel: %v", ErrAbandoned, e.Tx.Hash(), e.Tx.Nonce(), e.Err)
+	case e.CancellationReceipt != nil:
+		return fmt.Sprintf("%v: tx %s with nonce %d, cancelled by tx %s", ErrAbandoned, e.Tx.Hash(), e.Tx.Nonce(), e.Cancellation.Hash())
+	default:
+		return fmt.Sprintf("%v: tx %s with nonce %d, cancellation tx %s is pending", ErrAbandoned, e.Tx.Hash(), e.Tx.Nonce(), e.Cancellation.Hash())
+	}
+}
+
+func (e *AbandonedError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{ErrAbandoned, e.Err}
+	}
+	return []error{ErrAbandoned}
+}
+
+// Cancelled returns whether the cancellation confirmed, i.e. the nonce of the abandoned transaction is used
+// by the cancellation, and the abandoned transaction can no longer be included.
+func (e *AbandonedError) Cancelled() bool {
+	return e.CancellationReceipt != nil
+}
+
+// metricLabel is the cancellation state of the abandonment metric.
+func (e *AbandonedError) metricLabel() string {
+	switch {
+	case e.Err != nil:
+		return "failed"
+	case e.CancellationReceipt != nil:
+		return "confirmed"
+	default:
+		return "pending"
+	}
+}
+
+// craftCancellation creates a signed self-transfer with the nonce of tx, and fees that are high enough to replace it.
+// Blob transactions can only be replaced by blob transactions, so they are cancelled by a self-transfer with an empty blob.
+func (m *SimpleTxManager) craftCancellation(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	tip, baseFee, blobBaseFee, err := m.SuggestGasPriceCaps(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price info: %w", err)
+	}
+	isBlobTx := tx.Type() == types.BlobTxType
+	bumpedTip, bumpedFee := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, baseFee, isBlobTx, m.l)
+	if err := m.checkLimits(tip, baseFee, bumpedTip, bumpedFee); err != nil {
+		return nil, err
+	}
+
+	var cancellation *types.Transaction
+	if isBlobTx {
+		if blobBaseFee == nil {
+			return nil, fmt.Errorf("expected non-nil blobBaseFee")
+		}
+		bumpedBlobFee := calcThresholdValue(tx.BlobGasFeeCap(), true)
+		if bumpedBlobFee.Cmp(blobBaseFee) < 0 {
+			bumpedBlobFee = blobBaseFee
+		}
+		if err := m.checkBlobFeeLimits(blobBaseFee, bumpedBlobFee); err != nil {
+			return nil, err
+		}
+		sidecar, blobHashes, err := MakeSidecar([]*eth.Blob{{}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to make sidecar: %w", err)
+		}
+		message := &types.BlobTx{
+			Nonce:      tx.Nonce(),
+			To:         m.cfg.From,
+			Gas:        params.TxGas,
+			BlobHashes: blobHashes,
+			Sidecar:    sidecar,
+		}
+		if err := finishBlobTx(message, tx.ChainId(), bumpedTip, bumpedFee, bumpedBlobFee, new(big.Int)); err != nil {
+			return nil, err
+		}
+		cancellation = types.NewTx(message)
+	} else {
+		cancellation = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   tx.ChainId(),
+			Nonce:     tx.Nonce(),
+			To:        &m.cfg.From,
+			GasTipCap: bumpedTip,
+			GasFeeCap: bumpedFee,
+			Gas:       params.TxGas,
+		})
+	}
+	return m.sign(ctx, cancellation)
+}
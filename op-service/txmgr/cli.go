@@ -9,6 +9,7 @@ import (
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum/go-ethereum/common"
@@ -36,6 +37,10 @@ const (
 	TxSendTimeoutFlagName             = "txmgr.send-timeout"
 	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
 	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
+	FeeEstimatorFlagName              = "txmgr.fee-estimator"
+	FeeHistoryBlocksFlagName          = "txmgr.fee-history-blocks"
+	FeePercentileFlagName             = "txmgr.fee-percentile"
+	FeeAPIURLFlagName                 = "txmgr.fee-api-url"
 )
 
 var (
@@ -65,6 +70,9 @@ type DefaultFlagValues struct {
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
 	ReceiptQueryInterval      time.Duration
+	FeeEstimator              GasPriceEstimatorKind
+	FeeHistoryBlocks          uint64
+	FeePercentile             float64
 }
 
 var (
@@ -80,6 +88,9 @@ var (
 		TxSendTimeout:             0 * time.Second,
 		TxNotInMempoolTimeout:     2 * time.Minute,
 		ReceiptQueryInterval:      12 * time.Second,
+		FeeEstimator:              NodeEstimator,
+		FeeHistoryBlocks:          20,
+		FeePercentile:             50,
 	}
 	DefaultChallengerFlagValues = DefaultFlagValues{
 		NumConfirmations:          uint64(3),
@@ -93,6 +104,9 @@ var (
 		TxSendTimeout:             2 * time.Minute,
 		TxNotInMempoolTimeout:     1 * time.Minute,
 		ReceiptQueryInterval:      12 * time.Second,
+		FeeEstimator:              NodeEstimator,
+		FeeHistoryBlocks:          20,
+		FeePercentile:             50,
 	}
 )
 
@@ -186,6 +200,33 @@ func CLIFlagsWithDefaults(envPrefix string, defaults DefaultFlagValues) []cli.Fl
 			Value:   defaults.ReceiptQueryInterval,
 			EnvVars: prefixEnvVars("TXMGR_RECEIPT_QUERY_INTERVAL"),
 		},
+		&cli.GenericFlag{
+			Name: FeeEstimatorFlagName,
+			Usage: "The estimator of the tip cap and base fee for new transactions. Valid options: " +
+				openum.EnumString(GasPriceEstimatorKinds),
+			Value: func() *GasPriceEstimatorKind {
+				out := defaults.FeeEstimator
+				return &out
+			}(),
+			EnvVars: prefixEnvVars("TXMGR_FEE_ESTIMATOR"),
+		},
+		&cli.Uint64Flag{
+			Name:    FeeHistoryBlocksFlagName,
+			Usage:   "Number of recent L1 blocks to estimate the tip cap over, with the percentile fee estimator",
+			Value:   defaults.FeeHistoryBlocks,
+			EnvVars: prefixEnvVars("TXMGR_FEE_HISTORY_BLOCKS"),
+		},
+		&cli.Float64Flag{
+			Name:    FeePercentileFlagName,
+			Usage:   "Percentile of the tips in recent L1 blocks to use as tip cap, with the percentile fee estimator",
+			Value:   defaults.FeePercentile,
+			EnvVars: prefixEnvVars("TXMGR_FEE_PERCENTILE"),
+		},
+		&cli.StringFlag{
+			Name:    FeeAPIURLFlagName,
+			Usage:   "URL of the gas API to fetch the tip cap (and optionally the base fee) from, in gwei, with the api fee estimator",
+			EnvVars: prefixEnvVars("TXMGR_FEE_API_URL"),
+		},
 	}, opsigner.CLIFlags(envPrefix)...)
 }
 
@@ -208,6 +249,10 @@ type CLIConfig struct {
 	NetworkTimeout            time.Duration
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
+	FeeEstimator              GasPriceEstimatorKind
+	FeeHistoryBlocks          uint64
+	FeePercentile             float64
+	FeeAPIURL                 string
 }
 
 func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
@@ -224,6 +269,9 @@ func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
 		TxSendTimeout:             defaults.TxSendTimeout,
 		TxNotInMempoolTimeout:     defaults.TxNotInMempoolTimeout,
 		ReceiptQueryInterval:      defaults.ReceiptQueryInterval,
+		FeeEstimator:              defaults.FeeEstimator,
+		FeeHistoryBlocks:          defaults.FeeHistoryBlocks,
+		FeePercentile:             defaults.FeePercentile,
 		SignerCLIConfig:           opsigner.NewCLIConfig(),
 	}
 }
@@ -257,6 +305,22 @@ func (m CLIConfig) Check() error {
 	if m.SafeAbortNonceTooLowCount == 0 {
 		return errors.New("SafeAbortNonceTooLowCount must not be 0")
 	}
+	switch m.FeeEstimator {
+	case "", NodeEstimator, FeeHistoryEstimator:
+	case PercentileEstimator:
+		if m.FeeHistoryBlocks == 0 {
+			return errors.New("FeeHistoryBlocks must not be 0")
+		}
+		if m.FeePercentile < 0 || m.FeePercentile > 100 {
+			return fmt.Errorf("FeePercentile must be between 0 and 100, got %f", m.FeePercentile)
+		}
+	case APIEstimator:
+		if m.FeeAPIURL == "" {
+			return errors.New("must provide FeeAPIURL")
+		}
+	default:
+		return fmt.Errorf("unknown fee estimator: %q", m.FeeEstimator)
+	}
 	if err := m.SignerCLIConfig.Check(); err != nil {
 		return err
 	}
@@ -283,6 +347,10 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		NetworkTimeout:            ctx.Duration(NetworkTimeoutFlagName),
 		TxSendTimeout:             ctx.Duration(TxSendTimeoutFlagName),
 		TxNotInMempoolTimeout:     ctx.Duration(TxNotInMempoolTimeoutFlagName),
+		FeeEstimator:              GasPriceEstimatorKind(ctx.String(FeeEstimatorFlagName)),
+		FeeHistoryBlocks:          ctx.Uint64(FeeHistoryBlocksFlagName),
+		FeePercentile:             ctx.Float64(FeePercentileFlagName),
+		FeeAPIURL:                 ctx.String(FeeAPIURLFlagName),
 	}
 }
 
@@ -333,8 +401,21 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		return Config{}, fmt.Errorf("invalid min tip cap: %w", err)
 	}
 
+	var estimator GasPriceEstimator
+	switch cfg.FeeEstimator {
+	case FeeHistoryEstimator:
+		estimator = NewFeeHistoryGasPriceEstimator(l1)
+	case PercentileEstimator:
+		estimator = NewPercentileGasPriceEstimator(l1, cfg.FeeHistoryBlocks, cfg.FeePercentile)
+	case APIEstimator:
+		estimator = NewAPIGasPriceEstimator(l1, cfg.FeeAPIURL)
+	default:
+		estimator = NewNodeGasPriceEstimator(l1)
+	}
+
 	return Config{
 		Backend:                   l1,
+		GasPriceEstimator:         estimator,
 		ResubmissionTimeout:       cfg.ResubmissionTimeout,
 		FeeLimitMultiplier:        cfg.FeeLimitMultiplier,
 		FeeLimitThreshold:         feeLimitThreshold,
@@ -355,6 +436,9 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 // Config houses parameters for altering the behavior of a SimpleTxManager.
 type Config struct {
 	Backend ETHBackend
+	// GasPriceEstimator estimates the fees of new transactions.
+	// If nil, the tip cap suggested by the Backend and the base fee of the latest block are used.
+	GasPriceEstimator GasPriceEstimator
 	// ResubmissionTimeout is the interval at which, if no previously
 	// published transaction has been mined, the new tx with a bumped gas
 	// price will be published. Only one publication at MaxGasPrice will be
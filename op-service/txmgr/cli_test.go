@@ -39,3 +39,23 @@ func configForArgs(args ...string) CLIConfig {
 	_ = app.Run(args)
 	return config
 }
+
+func TestFeeEstimatorConfig(t *testing.T) {
+	cfg := configForArgs("txmgr", "--txmgr.fee-estimator=percentile", "--txmgr.fee-history-blocks=10", "--txmgr.fee-percentile=90")
+	require.Equal(t, PercentileEstimator, cfg.FeeEstimator)
+	require.Equal(t, uint64(10), cfg.FeeHistoryBlocks)
+	require.Equal(t, 90.0, cfg.FeePercentile)
+	require.NoError(t, cfg.Check())
+
+	cfg.FeePercentile = 101
+	require.ErrorContains(t, cfg.Check(), "FeePercentile")
+
+	cfg = NewCLIConfig(l1EthRpcValue, DefaultBatcherFlagValues)
+	cfg.FeeEstimator = APIEstimator
+	require.ErrorContains(t, cfg.Check(), "FeeAPIURL")
+	cfg.FeeAPIURL = "http://localhost:8080/gas"
+	require.NoError(t, cfg.Check())
+
+	cfg.FeeEstimator = "unknown"
+	require.ErrorContains(t, cfg.Check(), "unknown fee estimator")
+}
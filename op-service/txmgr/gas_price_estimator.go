@@ -0,0 +1,251 @@
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// GasPriceEstimator estimates the fees to use for new transactions.
+// The transaction manager enforces the configured minimum tip cap and base fee on top of the estimates.
+type GasPriceEstimator interface {
+	// EstimateGasPrice returns the tip cap, base fee and blob base fee to use for new transactions,
+	// based on the current L1 conditions. blobBaseFee is nil if 4844 is not yet active.
+	EstimateGasPrice(ctx context.Context) (tipCap *big.Int, baseFee *big.Int, blobBaseFee *big.Int, err error)
+}
+
+type GasPriceEstimatorKind string
+
+const (
+	// NodeEstimator uses the tip cap suggested by the L1 node, and the base fee of the latest L1 block.
+	NodeEstimator GasPriceEstimatorKind = "node"
+	// FeeHistoryEstimator uses eth_feeHistory for the median tip of the latest L1 block,
+	// and the base fee of the next L1 block.
+	FeeHistoryEstimator GasPriceEstimatorKind = "feehistory"
+	// PercentileEstimator uses eth_feeHistory for the configured percentile of the tips
+	// over a window of recent L1 blocks, and the base fee of the next L1 block.
+	PercentileEstimator GasPriceEstimatorKind = "percentile"
+	// APIEstimator fetches the tip cap, and optionally the base fee, from an external gas API.
+	APIEstimator GasPriceEstimatorKind = "api"
+)
+
+var GasPriceEstimatorKinds = []GasPriceEstimatorKind{
+	NodeEstimator,
+	FeeHistoryEstimator,
+	PercentileEstimator,
+	APIEstimator,
+}
+
+func (kind GasPriceEstimatorKind) String() string {
+	return string(kind)
+}
+
+func (kind *GasPriceEstimatorKind) Set(value string) error {
+	if !ValidGasPriceEstimatorKind(GasPriceEstimatorKind(value)) {
+		return fmt.Errorf("unknown gas price estimator: %q", value)
+	}
+	*kind = GasPriceEstimatorKind(value)
+	return nil
+}
+
+func (kind *GasPriceEstimatorKind) Clone() any {
+	cpy := *kind
+	return &cpy
+}
+
+func ValidGasPriceEstimatorKind(value GasPriceEstimatorKind) bool {
+	return slices.Contains(GasPriceEstimatorKinds, value)
+}
+
+// HeaderBackend is the part of the L1 backend that all gas price estimators use,
+// to determine the base fee and blob base fee.
+type HeaderBackend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// headFees returns the latest L1 header, with its base fee and blob base fee.
+func headFees(ctx context.Context, backend HeaderBackend) (*types.Header, *big.Int, *big.Int, error) {
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested base fee: %w", err)
+	} else if head.BaseFee == nil {
+		return nil, nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a base fee")
+	}
+	var blobFee *big.Int
+	if head.ExcessBlobGas != nil {
+		blobFee = eip4844.CalcBlobFee(*head.ExcessBlobGas)
+	}
+	return head, head.BaseFee, blobFee, nil
+}
+
+type NodeBackend interface {
+	HeaderBackend
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+// NodeGasPriceEstimator estimates fees with the tip cap suggested by the L1 node,
+// and the base fee of the latest L1 block.
+type NodeGasPriceEstimator struct {
+	backend NodeBackend
+}
+
+func NewNodeGasPriceEstimator(backend NodeBackend) *NodeGasPriceEstimator {
+	return &NodeGasPriceEstimator{backend: backend}
+}
+
+func (e *NodeGasPriceEstimator) EstimateGasPrice(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
+	tip, err := e.backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
+	} else if tip == nil {
+		return nil, nil, nil, errors.New("the suggested tip was nil")
+	}
+	_, baseFee, blobFee, err := headFees(ctx, e.backend)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return tip, baseFee, blobFee, nil
+}
+
+type FeeHistoryBackend interface {
+	HeaderBackend
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// FeeHistoryGasPriceEstimator estimates fees with eth_feeHistory: the tip cap is the median,
+// over the non-empty blocks of a window of recent L1 blocks, of the tips at a reward percentile of each block.
+// The base fee is the base fee of the next L1 block.
+type FeeHistoryGasPriceEstimator struct {
+	backend    FeeHistoryBackend
+	blocks     uint64
+	percentile float64
+}
+
+// NewFeeHistoryGasPriceEstimator returns an estimator that uses the median tip of the latest L1 block.
+func NewFeeHistoryGasPriceEstimator(backend FeeHistoryBackend) *FeeHistoryGasPriceEstimator {
+	return NewPercentileGasPriceEstimator(backend, 1, 50)
+}
+
+// NewPercentileGasPriceEstimator returns an estimator that uses the given percentile of the tips
+// of the given number of recent L1 blocks.
+func NewPercentileGasPriceEstimator(backend FeeHistoryBackend, blocks uint64, percentile float64) *FeeHistoryGasPriceEstimator {
+	return &FeeHistoryGasPriceEstimator{
+		backend:    backend,
+		blocks:     blocks,
+		percentile: percentile,
+	}
+}
+
+func (e *FeeHistoryGasPriceEstimator) EstimateGasPrice(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
+	head, baseFee, blobFee, err := headFees(ctx, e.backend)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	history, err := e.backend.FeeHistory(ctx, e.blocks, head.Number, []float64{e.percentile})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to fetch the fee history: %w", err)
+	}
+	// The base fees include the base fee of the block after the last block of the history.
+	if n := len(history.BaseFee); n > 0 && history.BaseFee[n-1] != nil {
+		baseFee = history.BaseFee[n-1]
+	}
+	var tips []*big.Int
+	for i, rewards := range history.Reward {
+		// empty blocks have no tips
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 {
+			continue
+		}
+		if len(rewards) == 0 || rewards[0] == nil {
+			return nil, nil, nil, fmt.Errorf("missing reward of block %d in fee history", i)
+		}
+		tips = append(tips, rewards[0])
+	}
+	if len(tips) == 0 {
+		return new(big.Int), baseFee, blobFee, nil
+	}
+	slices.SortFunc(tips, (*big.Int).Cmp)
+	return new(big.Int).Set(tips[len(tips)/2]), baseFee, blobFee, nil
+}
+
+// maxGasAPIResponseSize limits the size of gas API responses that are read.
+const maxGasAPIResponseSize = 1 << 20
+
+// gasAPIResponse is the response of an external gas API, with fees in gwei.
+type gasAPIResponse struct {
+	MaxPriorityFeePerGas *float64 `json:"maxPriorityFeePerGas"`
+	BaseFeePerGas        *float64 `json:"baseFeePerGas,omitempty"`
+}
+
+// APIGasPriceEstimator estimates fees with an external gas API. The API is queried with a GET request,
+// and responds with a JSON object with the tip cap in gwei as maxPriorityFeePerGas,
+// and optionally the base fee in gwei as baseFeePerGas.
+// The base fee of the latest L1 block is used if the API does not provide a base fee.
+type APIGasPriceEstimator struct {
+	backend HeaderBackend
+	url     string
+	client  *http.Client
+}
+
+func NewAPIGasPriceEstimator(backend HeaderBackend, url string) *APIGasPriceEstimator {
+	return &APIGasPriceEstimator{
+		backend: backend,
+		url:     url,
+		client:  &http.Client{},
+	}
+}
+
+func (e *APIGasPriceEstimator) EstimateGasPrice(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
+	res, err := e.fetch(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to fetch the gas price from the gas API: %w", err)
+	}
+	if res.MaxPriorityFeePerGas == nil {
+		return nil, nil, nil, errors.New("gas API response is missing the tip")
+	}
+	tip, err := eth.GweiToWei(*res.MaxPriorityFeePerGas)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid tip from gas API: %w", err)
+	}
+	_, baseFee, blobFee, err := headFees(ctx, e.backend)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if res.BaseFeePerGas != nil {
+		if baseFee, err = eth.GweiToWei(*res.BaseFeePerGas); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid base fee from gas API: %w", err)
+		}
+	}
+	return tip, baseFee, blobFee, nil
+}
+
+func (e *APIGasPriceEstimator) fetch(ctx context.Context) (*gasAPIResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	var res gasAPIResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxGasAPIResponseSize)).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &res, nil
+}
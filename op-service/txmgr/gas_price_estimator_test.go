@@ -0,0 +1,207 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+type feeHistoryBackend struct {
+	head    *types.Header
+	history *ethereum.FeeHistory
+
+	blockCount  uint64
+	lastBlock   *big.Int
+	percentiles []float64
+}
+
+func (b *feeHistoryBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return b.head, nil
+}
+
+func (b *feeHistoryBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(7), nil
+}
+
+func (b *feeHistoryBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	b.blockCount, b.lastBlock, b.percentiles = blockCount, lastBlock, rewardPercentiles
+	if b.history == nil {
+		return nil, errors.New("no fee history")
+	}
+	return b.history, nil
+}
+
+func newFeeHistoryBackend() *feeHistoryBackend {
+	excessBlobGas := uint64(10 * params.BlobTxBlobGasPerBlob)
+	return &feeHistoryBackend{
+		head: &types.Header{
+			Number:        big.NewInt(100),
+			BaseFee:       big.NewInt(1000),
+			ExcessBlobGas: &excessBlobGas,
+		},
+	}
+}
+
+func rewards(tips ...int64) [][]*big.Int {
+	out := make([][]*big.Int, len(tips))
+	for i, tip := range tips {
+		out[i] = []*big.Int{big.NewInt(tip)}
+	}
+	return out
+}
+
+func TestNodeGasPriceEstimator(t *testing.T) {
+	backend := newFeeHistoryBackend()
+	tip, baseFee, blobFee, err := NewNodeGasPriceEstimator(backend).EstimateGasPrice(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(7), tip)
+	require.Equal(t, big.NewInt(1000), baseFee)
+	require.Equal(t, eip4844.CalcBlobFee(*backend.head.ExcessBlobGas), blobFee)
+
+	backend.head.BaseFee = nil
+	_, _, _, err = NewNodeGasPriceEstimator(backend).EstimateGasPrice(context.Background())
+	require.ErrorContains(t, err, "pre-london")
+}
+
+func TestFeeHistoryGasPriceEstimator(t *testing.T) {
+	t.Run("latest block", func(t *testing.T) {
+		backend := newFeeHistoryBackend()
+		backend.history = &ethereum.FeeHistory{
+			Reward:       rewards(30),
+			BaseFee:      []*big.Int{big.NewInt(1000), big.NewInt(1100)},
+			GasUsedRatio: []float64{0.7},
+		}
+		tip, baseFee, blobFee, err := NewFeeHistoryGasPriceEstimator(backend).EstimateGasPrice(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(30), tip)
+		// the base fee of the next block is used
+		require.Equal(t, big.NewInt(1100), baseFee)
+		require.Equal(t, eip4844.CalcBlobFee(*backend.head.ExcessBlobGas), blobFee)
+		require.Equal(t, uint64(1), backend.blockCount)
+		require.Equal(t, backend.head.Number, backend.lastBlock)
+		require.Equal(t, []float64{50}, backend.percentiles)
+	})
+
+	t.Run("percentile", func(t *testing.T) {
+		backend := newFeeHistoryBackend()
+		backend.history = &ethereum.FeeHistory{
+			Reward:       rewards(50, 0, 10, 40, 20),
+			BaseFee:      []*big.Int{big.NewInt(1000), big.NewInt(1000), big.NewInt(1000), big.NewInt(1000), big.NewInt(1000), big.NewInt(900)},
+			GasUsedRatio: []float64{0.5, 0, 0.9, 0.2, 1},
+		}
+		tip, baseFee, _, err := NewPercentileGasPriceEstimator(backend, 5, 75).EstimateGasPrice(context.Background())
+		require.NoError(t, err)
+		// the median of the tips of the non-empty blocks
+		require.Equal(t, big.NewInt(40), tip)
+		require.Equal(t, big.NewInt(900), baseFee)
+		require.Equal(t, uint64(5), backend.blockCount)
+		require.Equal(t, []float64{75}, backend.percentiles)
+	})
+
+	t.Run("empty blocks", func(t *testing.T) {
+		backend := newFeeHistoryBackend()
+		backend.history = &ethereum.FeeHistory{
+			Reward:       rewards(0, 0),
+			BaseFee:      []*big.Int{big.NewInt(1000), big.NewInt(1000), big.NewInt(900)},
+			GasUsedRatio: []float64{0, 0},
+		}
+		tip, _, _, err := NewPercentileGasPriceEstimator(backend, 2, 50).EstimateGasPrice(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, tip.Sign())
+	})
+
+	t.Run("missing reward", func(t *testing.T) {
+		backend := newFeeHistoryBackend()
+		backend.history = &ethereum.FeeHistory{
+			Reward:       [][]*big.Int{{}},
+			GasUsedRatio: []float64{0.5},
+		}
+		_, _, _, err := NewFeeHistoryGasPriceEstimator(backend).EstimateGasPrice(context.Background())
+		require.ErrorContains(t, err, "missing reward")
+	})
+
+	t.Run("fee history error", func(t *testing.T) {
+		_, _, _, err := NewFeeHistoryGasPriceEstimator(newFeeHistoryBackend()).EstimateGasPrice(context.Background())
+		require.ErrorContains(t, err, "no fee history")
+	})
+}
+
+func TestAPIGasPriceEstimator(t *testing.T) {
+	serve := func(t *testing.T, status int, body string) string {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(srv.Close)
+		return srv.URL
+	}
+
+	t.Run("tip and base fee", func(t *testing.T) {
+		backend := newFeeHistoryBackend()
+		url := serve(t, http.StatusOK, `{"maxPriorityFeePerGas": 1.5, "baseFeePerGas": 20}`)
+		tip, baseFee, blobFee, err := NewAPIGasPriceEstimator(backend, url).EstimateGasPrice(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1_500_000_000), tip)
+		require.Equal(t, big.NewInt(20_000_000_000), baseFee)
+		require.Equal(t, eip4844.CalcBlobFee(*backend.head.ExcessBlobGas), blobFee)
+	})
+
+	t.Run("base fee of latest block", func(t *testing.T) {
+		backend := newFeeHistoryBackend()
+		url := serve(t, http.StatusOK, `{"maxPriorityFeePerGas": 2}`)
+		tip, baseFee, _, err := NewAPIGasPriceEstimator(backend, url).EstimateGasPrice(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(2_000_000_000), tip)
+		require.Equal(t, big.NewInt(1000), baseFee)
+	})
+
+	t.Run("missing tip", func(t *testing.T) {
+		url := serve(t, http.StatusOK, `{"baseFeePerGas": 20}`)
+		_, _, _, err := NewAPIGasPriceEstimator(newFeeHistoryBackend(), url).EstimateGasPrice(context.Background())
+		require.ErrorContains(t, err, "missing the tip")
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		url := serve(t, http.StatusTooManyRequests, `rate limited`)
+		_, _, _, err := NewAPIGasPriceEstimator(newFeeHistoryBackend(), url).EstimateGasPrice(context.Background())
+		require.ErrorContains(t, err, "unexpected status code 429")
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		url := serve(t, http.StatusOK, `{"maxPriorityFeePerGas": "fast"}`)
+		_, _, _, err := NewAPIGasPriceEstimator(newFeeHistoryBackend(), url).EstimateGasPrice(context.Background())
+		require.ErrorContains(t, err, "failed to decode response")
+	})
+}
+
+type fixedGasPriceEstimator struct {
+	tip, baseFee *big.Int
+}
+
+func (e *fixedGasPriceEstimator) EstimateGasPrice(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
+	return e.tip, e.baseFee, nil, nil
+}
+
+func TestSuggestGasPriceCapsEstimator(t *testing.T) {
+	cfg := configWithNumConfs(1)
+	cfg.MinTipCap = big.NewInt(100)
+	cfg.MinBaseFee = big.NewInt(1000)
+	cfg.GasPriceEstimator = &fixedGasPriceEstimator{tip: big.NewInt(10), baseFee: big.NewInt(5000)}
+	h := newTestHarnessWithConfig(t, cfg)
+
+	tip, baseFee, blobFee, err := h.mgr.SuggestGasPriceCaps(context.Background())
+	require.NoError(t, err)
+	// the minimum tip cap is enforced on top of the estimate
+	require.Equal(t, big.NewInt(100), tip)
+	require.Equal(t, big.NewInt(5000), baseFee)
+	require.Nil(t, blobFee)
+}
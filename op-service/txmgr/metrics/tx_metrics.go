@@ -17,6 +17,7 @@ type TxMetricer interface {
 	RecordPendingTx(pending int64)
 	TxConfirmed(*types.Receipt)
 	TxPublished(string)
+	TxAbandoned(cancellation string)
 	RecordBaseFee(*big.Int)
 	RecordBlobBaseFee(*big.Int)
 	RecordTipCap(*big.Int)
@@ -34,6 +35,7 @@ type TxMetrics struct {
 	txPublishError     *prometheus.CounterVec
 	publishEvent       *metrics.Event
 	confirmEvent       metrics.EventVec
+	abandonEvent       metrics.EventVec
 	baseFee            prometheus.Gauge
 	blobBaseFee        prometheus.Gauge
 	tipCap             prometheus.Gauge
@@ -106,6 +108,7 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 		}, []string{"error"}),
 		confirmEvent: metrics.NewEventVec(factory, ns, "txmgr", "confirm", "tx confirm", []string{"status"}),
 		publishEvent: metrics.NewEvent(factory, ns, "txmgr", "publish", "tx publish"),
+		abandonEvent: metrics.NewEventVec(factory, ns, "txmgr", "abandon", "tx abandoned after deadline", []string{"cancellation"}),
 		baseFee: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "basefee_wei",
@@ -166,6 +169,12 @@ func (t *TxMetrics) TxPublished(errString string) {
 	}
 }
 
+// TxAbandoned records a tx that did not confirm before its deadline, by the state of its cancellation:
+// confirmed, pending or failed.
+func (t *TxMetrics) TxAbandoned(cancellation string) {
+	t.abandonEvent.Record(cancellation)
+}
+
 func (t *TxMetrics) RecordBaseFee(baseFee *big.Int) {
 	bff, _ := baseFee.Float64()
 	t.baseFee.Set(bff)
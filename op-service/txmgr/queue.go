@@ -2,6 +2,7 @@ package txmgr
 
 import (
 	"context"
+	"errors"
 	"math"
 	"sync"
 
@@ -18,6 +19,50 @@ type TxReceipt[T any] struct {
 	Err error
 }
 
+// ErrQueuedTxCancelled is returned in the receipt of a queued tx that was cancelled before it was confirmed.
+// Note that the tx may still be included, if it was already published.
+var ErrQueuedTxCancelled = errors.New("queued tx cancelled")
+
+// QueuedTx is a tx that was sent through a Queue, and can be cancelled or replaced while it is pending.
+type QueuedTx[T any] struct {
+	id        T
+	receiptCh chan TxReceipt[T]
+	cancel    context.CancelFunc
+	done      chan struct{}
+
+	mu sync.Mutex
+	// cancelled is set when the tx is cancelled, and replaced when it is cancelled to be replaced by another tx.
+	cancelled bool
+	replaced  bool
+	// finished is set once the send completed, and confirmed if it completed with a receipt.
+	finished  bool
+	confirmed bool
+}
+
+// Cancel stops sending the tx. Returns false if the send already completed, in which case
+// its result is delivered as usual. Otherwise, its receipt is delivered with ErrQueuedTxCancelled,
+// unless the tx is confirmed before the send is stopped.
+//
+// Cancelling a tx frees up its nonce, which is reused by the next tx sent with the transaction manager,
+// replacing the cancelled tx if it is still in the mempool.
+func (tx *QueuedTx[T]) Cancel() bool {
+	return tx.stop(false)
+}
+
+func (tx *QueuedTx[T]) stop(replace bool) bool {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.finished {
+		return false
+	}
+	tx.cancelled = true
+	tx.replaced = replace
+	tx.cancel()
+	return true
+}
+
+// Queue sends txs concurrently through a TxManager, which assigns their nonces and waits for their
+// confirmation, also when they are reorged out before reaching the confirmation depth.
 type Queue[T any] struct {
 	ctx        context.Context
 	txMgr      TxManager
@@ -57,11 +102,15 @@ func (q *Queue[T]) Wait() {
 // The actual tx sending is non-blocking, with the receipt returned on the
 // provided receipt channel. If the channel is unbuffered, the goroutine is
 // blocked from completing until the channel is read from.
-func (q *Queue[T]) Send(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) {
+//
+// The returned QueuedTx can be used to cancel or replace the tx while it is pending.
+func (q *Queue[T]) Send(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) *QueuedTx[T] {
 	group, ctx := q.groupContext()
+	tx, ctx := newQueuedTx(ctx, id, receiptCh)
 	group.Go(func() error {
-		return q.sendTx(ctx, id, candidate, receiptCh)
+		return q.sendTx(ctx, tx, candidate)
 	})
+	return tx
 }
 
 // TrySend sends the next tx, but only if the number of pending txs is below the
@@ -75,15 +124,70 @@ func (q *Queue[T]) Send(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]
 // blocked from completing until the channel is read from.
 func (q *Queue[T]) TrySend(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) bool {
 	group, ctx := q.groupContext()
-	return group.TryGo(func() error {
-		return q.sendTx(ctx, id, candidate, receiptCh)
+	tx, ctx := newQueuedTx(ctx, id, receiptCh)
+	ok := group.TryGo(func() error {
+		return q.sendTx(ctx, tx, candidate)
 	})
+	if !ok {
+		tx.cancel()
+	}
+	return ok
 }
 
-func (q *Queue[T]) sendTx(ctx context.Context, id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) error {
+// Replace cancels the pending tx, and sends the candidate in its place, with the same ID and receipt channel.
+// Only a single receipt is delivered for the ID: the receipt of the replacement.
+// The replacement reuses the nonce of the cancelled tx if it was not included yet, replacing it in the mempool.
+//
+// Returns false, without sending the candidate, if the pending tx already completed or was confirmed
+// before it could be cancelled. Its result is delivered as usual then, so the receipt channel must be
+// buffered or read from concurrently.
+func (q *Queue[T]) Replace(tx *QueuedTx[T], candidate TxCandidate) (*QueuedTx[T], bool) {
+	if !tx.stop(true) {
+		return nil, false
+	}
+	<-tx.done
+	tx.mu.Lock()
+	confirmed := tx.confirmed
+	tx.mu.Unlock()
+	if confirmed {
+		return nil, false
+	}
+	return q.Send(tx.id, candidate, tx.receiptCh), true
+}
+
+func newQueuedTx[T any](ctx context.Context, id T, receiptCh chan TxReceipt[T]) (*QueuedTx[T], context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &QueuedTx[T]{
+		id:        id,
+		receiptCh: receiptCh,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}, ctx
+}
+
+func (q *Queue[T]) sendTx(ctx context.Context, tx *QueuedTx[T], candidate TxCandidate) error {
+	defer close(tx.done)
+	defer tx.cancel()
 	receipt, err := q.txMgr.Send(ctx, candidate)
-	receiptCh <- TxReceipt[T]{
-		ID:      id,
+
+	tx.mu.Lock()
+	tx.finished = true
+	tx.confirmed = err == nil
+	cancelled, replaced := tx.cancelled, tx.replaced
+	tx.mu.Unlock()
+
+	if cancelled && err != nil {
+		// Cancelled sends don't fail the other pending sends of the queue.
+		if !replaced {
+			tx.receiptCh <- TxReceipt[T]{
+				ID:  tx.id,
+				Err: errors.Join(ErrQueuedTxCancelled, err),
+			}
+		}
+		return nil
+	}
+	tx.receiptCh <- TxReceipt[T]{
+		ID:      tx.id,
 		Receipt: receipt,
 		Err:     err,
 	}
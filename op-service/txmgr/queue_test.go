@@ -247,3 +247,80 @@ func TestQueue_Send(t *testing.T) {
 		})
 	}
 }
+
+// blockingTxMgr is a TxManager whose sends block until they are released, or their context is cancelled.
+type blockingTxMgr struct {
+	TxManager
+	mu      sync.Mutex
+	sent    []TxCandidate
+	release chan struct{}
+}
+
+func (m *blockingTxMgr) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+	m.mu.Lock()
+	m.sent = append(m.sent, candidate)
+	m.mu.Unlock()
+	select {
+	case <-m.release:
+		return &types.Receipt{GasUsed: candidate.GasLimit}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (m *blockingTxMgr) sentCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sent)
+}
+
+func TestQueue_Cancel(t *testing.T) {
+	mgr := &blockingTxMgr{release: make(chan struct{})}
+	q := NewQueue[int](context.Background(), mgr, 0)
+	receiptCh := make(chan TxReceipt[int], 2)
+
+	tx := q.Send(1, TxCandidate{GasLimit: 1}, receiptCh)
+	other := q.Send(2, TxCandidate{GasLimit: 2}, receiptCh)
+	require.Eventually(t, func() bool { return mgr.sentCount() == 2 }, 10*time.Second, time.Millisecond)
+
+	require.True(t, tx.Cancel())
+	r := <-receiptCh
+	require.Equal(t, 1, r.ID)
+	require.ErrorIs(t, r.Err, ErrQueuedTxCancelled)
+	require.ErrorIs(t, r.Err, context.Canceled)
+	require.False(t, tx.Cancel(), "already completed")
+
+	// The cancellation does not affect other pending sends
+	close(mgr.release)
+	r = <-receiptCh
+	require.Equal(t, 2, r.ID)
+	require.NoError(t, r.Err)
+	require.False(t, other.Cancel(), "already completed")
+	q.Wait()
+}
+
+func TestQueue_Replace(t *testing.T) {
+	mgr := &blockingTxMgr{release: make(chan struct{})}
+	q := NewQueue[int](context.Background(), mgr, 1)
+	receiptCh := make(chan TxReceipt[int], 2)
+
+	tx := q.Send(1, TxCandidate{GasLimit: 1}, receiptCh)
+	require.Eventually(t, func() bool { return mgr.sentCount() == 1 }, 10*time.Second, time.Millisecond)
+
+	replacement, ok := q.Replace(tx, TxCandidate{GasLimit: 2})
+	require.True(t, ok)
+	require.Eventually(t, func() bool { return mgr.sentCount() == 2 }, 10*time.Second, time.Millisecond)
+
+	close(mgr.release)
+	r := <-receiptCh
+	require.Equal(t, 1, r.ID)
+	require.NoError(t, r.Err)
+	require.Equal(t, uint64(2), r.Receipt.GasUsed, "receipt of the replacement")
+	q.Wait()
+	require.Empty(t, receiptCh, "no receipt for the replaced tx")
+
+	// Completed txs can't be replaced
+	_, ok = q.Replace(replacement, TxCandidate{GasLimit: 3})
+	require.False(t, ok)
+	require.Equal(t, 2, mgr.sentCount())
+}
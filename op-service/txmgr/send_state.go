@@ -92,7 +92,9 @@ func (s *SendState) TxMined(txHash common.Hash) {
 
 // TxNotMined records that the txn with txnHash has not been mined or has been
 // reorg'd out. It is safe to call this function multiple times.
-func (s *SendState) TxNotMined(txHash common.Hash) {
+// It returns true if the txn was previously recorded as mined, i.e., if it got
+// reorg'd out.
+func (s *SendState) TxNotMined(txHash common.Hash) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -107,6 +109,7 @@ func (s *SendState) TxNotMined(txHash common.Hash) {
 	if len(s.minedTxs) == 0 && wasMined {
 		s.nonceTooLowCount = 0
 	}
+	return wasMined
 }
 
 // CriticalError returns a non-nil error if the txmgr should give up on trying a given txn with the
@@ -165,6 +165,17 @@ func TestSendStateIsNotWaitingForConfirmationAfterTxUnmined(t *testing.T) {
 	require.False(t, sendState.IsWaitingForConfirmation())
 }
 
+// TestSendStateTxNotMinedReportsReorg asserts that TxNotMined only reports a
+// reorg if the tx was previously recorded as mined.
+func TestSendStateTxNotMinedReportsReorg(t *testing.T) {
+	sendState := newSendState()
+
+	require.False(t, sendState.TxNotMined(testHash))
+	sendState.TxMined(testHash)
+	require.True(t, sendState.TxNotMined(testHash))
+	require.False(t, sendState.TxNotMined(testHash))
+}
+
 func stepClock(step time.Duration) func() time.Time {
 	i := 0
 	return func() time.Time {
@@ -73,6 +73,10 @@ type TxManager interface {
 	// BlockNumber returns the most recent block number from the underlying network.
 	BlockNumber(ctx context.Context) (uint64, error)
 
+	// SuggestGasPriceCaps suggests what the new tip, base fee, and blob base fee should be based on
+	// the current L1 conditions. blobBaseFee will be nil if 4844 is not yet active.
+	SuggestGasPriceCaps(ctx context.Context) (tipCap *big.Int, baseFee *big.Int, blobBaseFee *big.Int, err error)
+
 	// Close the underlying connection
 	Close()
 	IsClosed() bool
@@ -195,6 +199,9 @@ type TxCandidate struct {
 	GasLimit uint64
 	// Value is the value to be used in the constructed tx.
 	Value *big.Int
+	// Deadline is the time after which the tx manager stops bumping the fees of the tx, and attempts to cancel it.
+	// Send then returns an [AbandonedError]. The zero time means no deadline.
+	Deadline time.Time
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -205,6 +212,9 @@ type TxCandidate struct {
 // The transaction manager handles all signing. If and only if the gas limit is 0, the
 // transaction manager will do a gas estimation.
 //
+// If the candidate has a deadline, and the transaction did not confirm before the deadline,
+// Send attempts to cancel the transaction and returns an [AbandonedError].
+//
 // NOTE: Send can be called concurrently, the nonce will be managed internally.
 func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
 	// refuse new requests if the tx manager is closed
@@ -231,10 +241,10 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 	}
 	tx, err := retry.Do(ctx, 30, retry.Fixed(2*time.Second), func() (*types.Transaction, error) {
 		if m.closed.Load() {
-			return nil, ErrClosed
+			return nil, retry.FatalError(ErrClosed)
 		}
 		tx, err := m.craftTx(ctx, candidate)
-		if err != nil {
+		if err != nil && retry.DefaultClassifier(err) == retry.Temporary {
 			m.l.Warn("Failed to create a transaction, will retry", "err", err)
 		}
 		return tx, err
@@ -242,7 +252,7 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the tx: %w", err)
 	}
-	return m.sendTx(ctx, tx)
+	return m.sendTx(ctx, tx, candidate.Deadline)
 }
 
 // craftTx creates the signed transaction
@@ -252,25 +262,46 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 // NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
 func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
 	m.l.Debug("crafting Transaction", "blobs", len(candidate.Blobs), "calldata_size", len(candidate.TxData))
-	gasTipCap, baseFee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
+	gasTipCap, baseFee, blobBaseFee, err := m.SuggestGasPriceCaps(ctx)
 	if err != nil {
 		m.metr.RPCError()
 		return nil, fmt.Errorf("failed to get gas price info: %w", err)
 	}
 	gasFeeCap := calcGasFeeCap(baseFee, gasTipCap)
 
+	var sidecar *types.BlobTxSidecar
+	var blobHashes []common.Hash
+	var blobFeeCap *big.Int
+	if len(candidate.Blobs) > 0 {
+		if candidate.To == nil {
+			return nil, retry.FatalError(errors.New("blob txs cannot deploy contracts"))
+		}
+		if nb := len(candidate.Blobs); nb > eth.MaxBlobsPerBlobTx {
+			return nil, retry.FatalError(fmt.Errorf("too many blobs: %d, max %d", nb, eth.MaxBlobsPerBlobTx))
+		}
+		if blobBaseFee == nil {
+			return nil, fmt.Errorf("expected non-nil blobBaseFee")
+		}
+		if sidecar, blobHashes, err = MakeSidecar(candidate.Blobs); err != nil {
+			return nil, retry.FatalError(fmt.Errorf("failed to make sidecar: %w", err))
+		}
+		blobFeeCap = calcBlobFeeCap(blobBaseFee)
+	}
+
 	gasLimit := candidate.GasLimit
 
 	// If the gas limit is set, we can use that as the gas
 	if gasLimit == 0 {
 		// Calculate the intrinsic gas for the transaction
 		gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{
-			From:      m.cfg.From,
-			To:        candidate.To,
-			GasTipCap: gasTipCap,
-			GasFeeCap: gasFeeCap,
-			Data:      candidate.TxData,
-			Value:     candidate.Value,
+			From:          m.cfg.From,
+			To:            candidate.To,
+			GasTipCap:     gasTipCap,
+			GasFeeCap:     gasFeeCap,
+			Data:          candidate.TxData,
+			Value:         candidate.Value,
+			BlobGasFeeCap: blobFeeCap,
+			BlobHashes:    blobHashes,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to estimate gas: %w", errutil.TryAddRevertReason(err))
@@ -278,23 +309,8 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		gasLimit = gas
 	}
 
-	var sidecar *types.BlobTxSidecar
-	var blobHashes []common.Hash
-	if len(candidate.Blobs) > 0 {
-		if candidate.To == nil {
-			return nil, errors.New("blob txs cannot deploy contracts")
-		}
-		if sidecar, blobHashes, err = MakeSidecar(candidate.Blobs); err != nil {
-			return nil, fmt.Errorf("failed to make sidecar: %w", err)
-		}
-	}
-
 	var txMessage types.TxData
 	if sidecar != nil {
-		if blobBaseFee == nil {
-			return nil, fmt.Errorf("expected non-nil blobBaseFee")
-		}
-		blobFeeCap := calcBlobFeeCap(blobBaseFee)
 		message := &types.BlobTx{
 			To:         *candidate.To,
 			Data:       candidate.TxData,
@@ -374,9 +390,7 @@ func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, txMessage types
 	default:
 		return nil, fmt.Errorf("unrecognized tx type: %T", x)
 	}
-	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-	defer cancel()
-	tx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(txMessage))
+	tx, err := m.sign(ctx, types.NewTx(txMessage))
 	if err != nil {
 		// decrement the nonce, so we can retry signing with the same nonce next time
 		// signWithNextNonce is called
@@ -387,6 +401,28 @@ func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, txMessage types
 	return tx, err
 }
 
+// sign signs the transaction with the configured signer.
+// The blob sidecar of blob txs is carried over to the signed tx, as signers may only return the
+// canonical encoding of the signed tx, without the sidecar. The sidecar is needed to publish the tx,
+// and to re-publish it with bumped fees later.
+func (m *SimpleTxManager) sign(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	signed, err := m.cfg.Signer(ctx, m.cfg.From, tx)
+	if err != nil {
+		return nil, err
+	}
+	if sidecar := tx.BlobTxSidecar(); sidecar != nil && signed.BlobTxSidecar() == nil {
+		if len(signed.BlobHashes()) != len(sidecar.Blobs) {
+			return nil, fmt.Errorf("signed blob tx has %d blob hashes, but sidecar has %d blobs", len(signed.BlobHashes()), len(sidecar.Blobs))
+		}
+		if err := signed.SetBlobTxSidecar(sidecar); err != nil {
+			return nil, fmt.Errorf("failed to attach sidecar to signed blob tx: %w", err)
+		}
+	}
+	return signed, nil
+}
+
 // resetNonce resets the internal nonce tracking. This is called if any pending send
 // returns an error.
 func (m *SimpleTxManager) resetNonce() {
@@ -397,7 +433,9 @@ func (m *SimpleTxManager) resetNonce() {
 
 // send submits the same transaction several times with increasing gas prices as necessary.
 // It waits for the transaction to be confirmed on chain.
-func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+// If the deadline is not zero, and the transaction did not confirm before the deadline,
+// it stops bumping fees and attempts to cancel the transaction, returning an [AbandonedError].
+func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction, deadline time.Time) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
@@ -425,6 +463,15 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 	ticker := time.NewTicker(m.cfg.ResubmissionTimeout)
 	defer ticker.Stop()
 
+	var deadlineC <-chan time.Time
+	if !deadline.IsZero() {
+		deadlineTimer := time.NewTimer(time.Until(deadline))
+		defer deadlineTimer.Stop()
+		deadlineC = deadlineTimer.C
+	}
+	// abandoned is set once the deadline passed, and the tx is being cancelled.
+	var abandoned *AbandonedError
+
 	for {
 		if err := sendState.CriticalError(); err != nil {
 			m.txLogger(tx, false).Warn("Aborting transaction submission", "err", err)
@@ -441,14 +488,43 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 				m.txLogger(tx, false).Warn("TxManager closed, aborting transaction submission")
 				return nil, ErrClosed
 			}
+			// The cancellation is published once, if it did not confirm within a resubmission timeout, give up on it too.
+			if abandoned != nil {
+				m.txLogger(abandoned.Cancellation, false).Warn("Cancellation not confirmed, abandoning transaction", "abandoned", abandoned.Tx.Hash())
+				m.metr.TxAbandoned(abandoned.metricLabel())
+				return nil, abandoned
+			}
 			tx = publishAndWait(tx, true)
 
+		case <-deadlineC:
+			deadlineC = nil
+			// A mined tx is not abandoned, we wait for its confirmation instead.
+			if sendState.IsWaitingForConfirmation() {
+				continue
+			}
+			m.txLogger(tx, true).Warn("Transaction not confirmed before deadline, cancelling it", "deadline", deadline)
+			cancellation, err := m.craftCancellation(ctx, tx)
+			if err != nil {
+				m.txLogger(tx, false).Error("Failed to create cancellation of abandoned transaction", "err", err)
+				abandoned = &AbandonedError{Tx: tx, Err: err}
+				m.metr.TxAbandoned(abandoned.metricLabel())
+				return nil, abandoned
+			}
+			abandoned = &AbandonedError{Tx: tx, Cancellation: publishAndWait(cancellation, false)}
+			ticker.Reset(m.cfg.ResubmissionTimeout)
+
 		case <-ctx.Done():
 			return nil, ctx.Err()
 
 		case receipt := <-receiptChan:
 			m.metr.RecordGasBumpCount(sendState.bumpCount)
 			m.metr.TxConfirmed(receipt)
+			if abandoned != nil && receipt.TxHash == abandoned.Cancellation.Hash() {
+				m.txLogger(abandoned.Cancellation, false).Warn("Transaction cancelled", "abandoned", abandoned.Tx.Hash())
+				abandoned.CancellationReceipt = receipt
+				m.metr.TxAbandoned(abandoned.metricLabel())
+				return nil, abandoned
+			}
 			return receipt, nil
 		}
 	}
@@ -574,8 +650,11 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 	defer cancel()
 	receipt, err := m.backend.TransactionReceipt(ctx, txHash)
 	if errors.Is(err, ethereum.NotFound) {
-		sendState.TxNotMined(txHash)
-		m.l.Trace("Transaction not yet mined", "tx", txHash)
+		if sendState.TxNotMined(txHash) {
+			m.l.Warn("Transaction got reorged out, waiting for re-inclusion", "tx", txHash)
+		} else {
+			m.l.Trace("Transaction not yet mined", "tx", txHash)
+		}
 		return nil
 	} else if err != nil {
 		m.metr.RPCError()
@@ -635,7 +714,7 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 // multiple of the suggested values.
 func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
 	m.txLogger(tx, true).Info("bumping gas price for transaction")
-	tip, baseFee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
+	tip, baseFee, blobBaseFee, err := m.SuggestGasPriceCaps(ctx)
 	if err != nil {
 		m.txLogger(tx, false).Warn("failed to get suggested gas tip and base fee", "err", err)
 		return nil, err
@@ -646,14 +725,32 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 		return nil, err
 	}
 
+	var bumpedBlobFee *big.Int
+	if tx.Type() == types.BlobTxType {
+		// Blob transactions have an additional blob gas price we must specify, so we must make sure it is
+		// getting bumped appropriately.
+		if blobBaseFee == nil {
+			return nil, fmt.Errorf("expected non-nil blobBaseFee")
+		}
+		bumpedBlobFee = calcThresholdValue(tx.BlobGasFeeCap(), true)
+		if bumpedBlobFee.Cmp(blobBaseFee) < 0 {
+			bumpedBlobFee = blobBaseFee
+		}
+		if err := m.checkBlobFeeLimits(blobBaseFee, bumpedBlobFee); err != nil {
+			return nil, err
+		}
+	}
+
 	// Re-estimate gaslimit in case things have changed or a previous gaslimit estimate was wrong
 	gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{
-		From:      m.cfg.From,
-		To:        tx.To(),
-		GasTipCap: bumpedTip,
-		GasFeeCap: bumpedFee,
-		Data:      tx.Data(),
-		Value:     tx.Value(),
+		From:          m.cfg.From,
+		To:            tx.To(),
+		GasTipCap:     bumpedTip,
+		GasFeeCap:     bumpedFee,
+		Data:          tx.Data(),
+		Value:         tx.Value(),
+		BlobGasFeeCap: bumpedBlobFee,
+		BlobHashes:    tx.BlobHashes(),
 	})
 	if err != nil {
 		// If this is a transaction resubmission, we sometimes see this outcome because the
@@ -674,15 +771,6 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 
 	var newTx *types.Transaction
 	if tx.Type() == types.BlobTxType {
-		// Blob transactions have an additional blob gas price we must specify, so we must make sure it is
-		// getting bumped appropriately.
-		bumpedBlobFee := calcThresholdValue(tx.BlobGasFeeCap(), true)
-		if bumpedBlobFee.Cmp(blobBaseFee) < 0 {
-			bumpedBlobFee = blobBaseFee
-		}
-		if err := m.checkBlobFeeLimits(blobBaseFee, bumpedBlobFee); err != nil {
-			return nil, err
-		}
 		message := &types.BlobTx{
 			Nonce:      tx.Nonce(),
 			To:         *tx.To(),
@@ -708,9 +796,7 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 		})
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-	defer cancel()
-	signedTx, err := m.cfg.Signer(ctx, m.cfg.From, newTx)
+	signedTx, err := m.sign(ctx, newTx)
 	if err != nil {
 		m.l.Warn("failed to sign new transaction", "err", err, "tx", tx.Hash())
 		return tx, nil
@@ -718,31 +804,21 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	return signedTx, nil
 }
 
-// suggestGasPriceCaps suggests what the new tip, base fee, and blob base fee should be based on
+// SuggestGasPriceCaps suggests what the new tip, base fee, and blob base fee should be based on
 // the current L1 conditions. blobfee will be nil if 4844 is not yet active.
-func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
+func (m *SimpleTxManager) SuggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
 	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
-	tip, err := m.backend.SuggestGasTipCap(cCtx)
+	tip, baseFee, blobFee, err := m.gasPriceEstimator().EstimateGasPrice(cCtx)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
-	} else if tip == nil {
-		return nil, nil, nil, errors.New("the suggested tip was nil")
+		return nil, nil, nil, err
 	}
-	cCtx, cancel = context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-	defer cancel()
-	head, err := m.backend.HeaderByNumber(cCtx, nil)
-	if err != nil {
-		m.metr.RPCError()
-		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested base fee: %w", err)
-	} else if head.BaseFee == nil {
-		return nil, nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a base fee")
-	}
-
-	baseFee := head.BaseFee
 	m.metr.RecordBaseFee(baseFee)
 	m.metr.RecordTipCap(tip)
+	if blobFee != nil {
+		m.metr.RecordBlobBaseFee(blobFee)
+	}
 
 	// Enforce minimum base fee and tip cap
 	if minTipCap := m.cfg.MinTipCap; minTipCap != nil && tip.Cmp(minTipCap) == -1 {
@@ -754,14 +830,18 @@ func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *b
 		baseFee = new(big.Int).Set(m.cfg.MinBaseFee)
 	}
 
-	var blobFee *big.Int
-	if head.ExcessBlobGas != nil {
-		blobFee = eip4844.CalcBlobFee(*head.ExcessBlobGas)
-		m.metr.RecordBlobBaseFee(blobFee)
-	}
 	return tip, baseFee, blobFee, nil
 }
 
+// gasPriceEstimator returns the configured gas price estimator,
+// or an estimator with the fees suggested by the L1 node if none is configured.
+func (m *SimpleTxManager) gasPriceEstimator() GasPriceEstimator {
+	if m.cfg.GasPriceEstimator != nil {
+		return m.cfg.GasPriceEstimator
+	}
+	return NewNodeGasPriceEstimator(m.backend)
+}
+
 // checkLimits checks that the tip and baseFee have not increased by more than the configured multipliers
 // if FeeLimitThreshold is specified in config, any increase which stays under the threshold are allowed
 func (m *SimpleTxManager) checkLimits(tip, baseFee, bumpedTip, bumpedFee *big.Int) (errs error) {
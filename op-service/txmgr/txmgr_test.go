@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
@@ -208,6 +210,9 @@ type mockBackend struct {
 
 	// minedTxs maps the hash of a mined transaction to its details.
 	minedTxs map[common.Hash]minedTxInfo
+
+	// lastEstimate is the most recent gas estimation request.
+	lastEstimate ethereum.CallMsg
 }
 
 // newMockBackend initializes a new mockBackend.
@@ -270,6 +275,9 @@ func (b *mockBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*typ
 }
 
 func (b *mockBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	b.mu.Lock()
+	b.lastEstimate = msg
+	b.mu.Unlock()
 	if b.g.err != nil {
 		return 0, b.g.err
 	}
@@ -359,7 +367,7 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, time.Time{})
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -387,7 +395,7 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, time.Time{})
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -433,7 +441,7 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, time.Time{})
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -467,7 +475,7 @@ func TestTxMgrConfirmsBlobTxAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, time.Time{})
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	// the fee cap for the blob tx at epoch == 3 should end up higher than the min required gas
@@ -476,6 +484,125 @@ func TestTxMgrConfirmsBlobTxAtHigherGasPrice(t *testing.T) {
 	require.Equal(t, h.gasPricer.expBlobFeeCap().Uint64(), receipt.CumulativeGasUsed)
 }
 
+// TestTxMgrAbandonsAfterDeadline asserts that a tx that is not confirmed before its deadline
+// is cancelled with a self-transfer, and reported as abandoned.
+func TestTxMgrAbandonsAfterDeadline(t *testing.T) {
+	t.Parallel()
+
+	isCancellation := func(h *testHarness, tx *types.Transaction) bool {
+		return tx.To() != nil && *tx.To() == h.cfg.From && tx.Gas() == params.TxGas
+	}
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+		h := newTestHarness(t)
+		gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+		tx := types.NewTx(&types.DynamicFeeTx{
+			Nonce:     startingNonce,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		})
+		// only the cancellation is mined
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			if isCancellation(h, tx) {
+				txHash := tx.Hash()
+				h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+			}
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		receipt, err := h.mgr.sendTx(ctx, tx, time.Now().Add(100*time.Millisecond))
+		require.Nil(t, receipt)
+		require.ErrorIs(t, err, ErrAbandoned)
+		var abandoned *AbandonedError
+		require.ErrorAs(t, err, &abandoned)
+		require.True(t, abandoned.Cancelled())
+		require.Equal(t, uint64(startingNonce), abandoned.Cancellation.Nonce())
+		require.Equal(t, abandoned.Cancellation.Hash(), abandoned.CancellationReceipt.TxHash)
+		require.Equal(t, 1, abandoned.Cancellation.GasFeeCap().Cmp(abandoned.Tx.GasFeeCap()), "cancellation must replace the tx")
+	})
+
+	t.Run("cancellation pending", func(t *testing.T) {
+		t.Parallel()
+		h := newTestHarness(t)
+		gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+		tx := types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		})
+		var cancellations atomic.Int64
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			if isCancellation(h, tx) {
+				cancellations.Add(1)
+			}
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		receipt, err := h.mgr.sendTx(ctx, tx, time.Now().Add(100*time.Millisecond))
+		require.Nil(t, receipt)
+		var abandoned *AbandonedError
+		require.ErrorAs(t, err, &abandoned)
+		require.False(t, abandoned.Cancelled())
+		require.NotNil(t, abandoned.Cancellation)
+		require.NoError(t, ctx.Err(), "abandoned before the context expired")
+		require.Equal(t, int64(1), cancellations.Load(), "cancellation is published once")
+	})
+
+	t.Run("blob tx", func(t *testing.T) {
+		t.Parallel()
+		h := newTestHarness(t)
+		gasTipCap, gasFeeCap, excessBlobGas := h.gasPricer.sample()
+		tx := types.NewTx(&types.BlobTx{
+			GasTipCap:  uint256.MustFromBig(gasTipCap),
+			GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+			BlobFeeCap: uint256.MustFromBig(eip4844.CalcBlobFee(excessBlobGas)),
+		})
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			if isCancellation(h, tx) {
+				txHash := tx.Hash()
+				h.backend.mine(&txHash, tx.GasFeeCap(), tx.BlobGasFeeCap())
+			}
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_, err := h.mgr.sendTx(ctx, tx, time.Now().Add(100*time.Millisecond))
+		var abandoned *AbandonedError
+		require.ErrorAs(t, err, &abandoned)
+		require.True(t, abandoned.Cancelled())
+		require.Equal(t, uint8(types.BlobTxType), abandoned.Cancellation.Type(), "blob txs can only be replaced by blob txs")
+		require.Len(t, abandoned.Cancellation.BlobHashes(), 1)
+	})
+
+	t.Run("confirmed before deadline", func(t *testing.T) {
+		t.Parallel()
+		h := newTestHarness(t)
+		gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+		tx := types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		})
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+				txHash := tx.Hash()
+				h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+			}
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		receipt, err := h.mgr.sendTx(ctx, tx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
+	})
+}
+
 // errRpcFailure is a sentinel error used in testing to fail publications.
 var errRpcFailure = errors.New("rpc failure")
 
@@ -501,7 +628,7 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, time.Time{})
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -576,6 +703,65 @@ func TestTxMgr_CraftBlobTx(t *testing.T) {
 	require.Equal(t, blobData2, d2)
 }
 
+// TestTxMgr_InvalidBlobTxNotRetried ensures that the tx manager doesn't retry crafting
+// transactions from invalid candidates, since that would fail on every attempt.
+func TestTxMgr_InvalidBlobTxNotRetried(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createBlobTxCandidate()
+	candidate.To = nil
+
+	start := time.Now()
+	_, err := h.mgr.Send(context.Background(), candidate)
+	require.ErrorContains(t, err, "blob txs cannot deploy contracts")
+	var failed *retry.ErrFailedPermanently
+	require.False(t, errors.As(err, &failed))
+	require.Less(t, time.Since(start), time.Second)
+}
+
+// TestTxMgr_BlobTxKeepsSidecar ensures that the tx manager keeps the sidecar of blob transactions
+// when the signer only returns the canonical encoding of the signed tx, so it can be published and bumped.
+func TestTxMgr_BlobTxKeepsSidecar(t *testing.T) {
+	t.Parallel()
+	cfg := configWithNumConfs(1)
+	cfg.Signer = func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return tx.WithoutBlobTxSidecar(), nil
+	}
+	h := newTestHarnessWithConfig(t, cfg)
+	candidate := h.createBlobTxCandidate()
+
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.NotNil(t, tx.BlobTxSidecar())
+	require.Len(t, tx.BlobTxSidecar().Blobs, 2)
+
+	bumped, err := h.mgr.increaseGasPrice(context.Background(), tx)
+	require.NoError(t, err)
+	require.Equal(t, tx.BlobTxSidecar(), bumped.BlobTxSidecar())
+	require.Equal(t, tx.BlobHashes(), bumped.BlobHashes())
+	// blob txs require a 100% bump of the blob fee cap to be replaced
+	require.Equal(t, new(big.Int).Mul(tx.BlobGasFeeCap(), two), bumped.BlobGasFeeCap())
+}
+
+// TestTxMgr_EstimateGasBlobTx ensures that the tx manager includes the blob fields
+// when estimating the gas of blob transactions.
+func TestTxMgr_EstimateGasBlobTx(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createBlobTxCandidate()
+	candidate.GasLimit = 0
+
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, tx.BlobHashes(), h.backend.lastEstimate.BlobHashes)
+	require.Equal(t, tx.BlobGasFeeCap(), h.backend.lastEstimate.BlobGasFeeCap)
+
+	bumped, err := h.mgr.increaseGasPrice(context.Background(), tx)
+	require.NoError(t, err)
+	require.Equal(t, tx.BlobHashes(), h.backend.lastEstimate.BlobHashes)
+	require.Equal(t, bumped.BlobGasFeeCap(), h.backend.lastEstimate.BlobGasFeeCap)
+}
+
 // TestTxMgr_EstimateGas ensures that the tx manager will estimate
 // the gas when candidate gas limit is zero in [CraftTx].
 func TestTxMgr_EstimateGas(t *testing.T) {
@@ -685,7 +871,7 @@ func TestTxMgrOnlyOnePublicationSucceeds(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, time.Time{})
 	require.Nil(t, err)
 
 	require.NotNil(t, receipt)
@@ -720,7 +906,7 @@ func TestTxMgrConfirmsMinGasPriceAfterBumping(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, time.Time{})
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -765,7 +951,7 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, time.Time{})
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -1321,7 +1507,7 @@ func TestMinFees(t *testing.T) {
 			conf.MinTipCap = tt.minTipCap
 			h := newTestHarnessWithConfig(t, conf)
 
-			tip, baseFee, _, err := h.mgr.suggestGasPriceCaps(context.TODO())
+			tip, baseFee, _, err := h.mgr.SuggestGasPriceCaps(context.TODO())
 			require.NoError(err)
 
 			if tt.expectMinBaseFee {
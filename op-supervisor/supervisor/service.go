@@ -93,14 +93,7 @@ func (su *SupervisorService) initMetrics(cfg *config.Config) {
 }
 
 func (su *SupervisorService) initPProf(cfg *config.Config) error {
-	su.pprofService = oppprof.New(
-		cfg.PprofConfig.ListenEnabled,
-		cfg.PprofConfig.ListenAddr,
-		cfg.PprofConfig.ListenPort,
-		cfg.PprofConfig.ProfileType,
-		cfg.PprofConfig.ProfileDir,
-		cfg.PprofConfig.ProfileFilename,
-	)
+	su.pprofService = oppprof.NewFromConfig(cfg.PprofConfig)
 
 	if err := su.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -129,12 +122,18 @@ func (su *SupervisorService) initMetricsServer(cfg *config.Config) error {
 }
 
 func (su *SupervisorService) initRPCServer(cfg *config.Config) error {
+	adminAuth, err := oprpc.NewAdminAuth(cfg.RPC.AdminAuth, []string{"admin"}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to configure admin RPC authentication: %w", err)
+	}
 	server := oprpc.NewServer(
 		cfg.RPC.ListenAddr,
 		cfg.RPC.ListenPort,
 		cfg.Version,
 		oprpc.WithLogger(su.log),
+		oprpc.WithRPCMetrics(su.metrics),
 		//oprpc.WithHTTPRecorder(su.metrics), // TODO(protocol-quest#286) hook up metrics to RPC server
+		oprpc.WithAdminAuth(adminAuth),
 	)
 	if cfg.RPC.EnableAdmin {
 		su.log.Info("Admin RPC enabled")
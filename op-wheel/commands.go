@@ -616,12 +616,13 @@ var (
 	}
 
 	EngineRewindCmd = &cli.Command{
-		Name:        "rewind",
-		Description: "Rewind chain by number (destructive!)",
+		Name: "rewind",
+		Description: "Rewind chain to a canonical block by number or hash (destructive!). " +
+			"Sets the unsafe block to the given block, and lowers the safe and finalized blocks to it if they are ahead.",
 		Flags: withEngineFlags(
-			&cli.Uint64Flag{
+			&cli.StringFlag{
 				Name:     "to",
-				Usage:    "Block number to rewind chain to",
+				Usage:    "Block number or hash to rewind chain to",
 				Required: true,
 				EnvVars:  prefixEnvVars("REWIND_TO"),
 			},
@@ -630,13 +631,27 @@ var (
 				Usage:   "Whether to also call debug_setHead when rewinding",
 				EnvVars: prefixEnvVars("REWIND_SET_HEAD"),
 			},
+			&cli.BoolFlag{
+				Name:    "allow-finalized",
+				Usage:   "Allow rewinding to before the finalized block",
+				EnvVars: prefixEnvVars("REWIND_ALLOW_FINALIZED"),
+			},
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Usage:   "Only check and log the rewind, without changing the forkchoice",
+				EnvVars: prefixEnvVars("REWIND_DRY_RUN"),
+			},
 		),
 		Action: EngineAction(func(ctx *cli.Context, client *sources.EngineAPIClient, lgr log.Logger) error {
 			open, err := initOpenEngineRPC(ctx, lgr)
 			if err != nil {
 				return fmt.Errorf("failed to dial open RPC endpoint: %w", err)
 			}
-			return engine.Rewind(ctx.Context, lgr, client, open, ctx.Uint64("to"), ctx.Bool("set-head"))
+			return engine.Rewind(ctx.Context, lgr, client, open, ctx.String("to"), engine.RewindSettings{
+				SetHead:        ctx.Bool("set-head"),
+				AllowFinalized: ctx.Bool("allow-finalized"),
+				DryRun:         ctx.Bool("dry-run"),
+			})
 		}),
 	}
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 
 const (
 	methodEthGetBlockByNumber = "eth_getBlockByNumber"
+	methodEthGetBlockByHash   = "eth_getBlockByHash"
 	methodDebugChainConfig    = "debug_chainConfig"
 	methodDebugSetHead        = "debug_setHead"
 )
@@ -391,41 +393,109 @@ func SetForkchoiceByHash(ctx context.Context, client *sources.EngineAPIClient, f
 	return nil
 }
 
-func Rewind(ctx context.Context, lgr log.Logger, client *sources.EngineAPIClient, open client.RPC, to uint64, setHead bool) error {
-	unsafe, err := getHeader(ctx, open, methodEthGetBlockByNumber, hexutil.Uint64(to).String())
+type RewindSettings struct {
+	// SetHead also calls debug_setHead, to remove the blocks after the target from the database.
+	SetHead bool
+	// AllowFinalized allows rewinding to before the finalized block.
+	AllowFinalized bool
+	// DryRun only checks and logs the rewind, without changing the forkchoice.
+	DryRun bool
+}
+
+// ParseBlockTarget parses a block hash, or a block number in decimal or 0x-prefixed hex,
+// into the method and tag to look up the block with.
+func ParseBlockTarget(v string) (method string, tag any, err error) {
+	if len(v) == 2+2*common.HashLength && strings.HasPrefix(v, "0x") {
+		var h common.Hash
+		if err := h.UnmarshalText([]byte(v)); err != nil {
+			return "", nil, fmt.Errorf("invalid block hash %q: %w", v, err)
+		}
+		return methodEthGetBlockByHash, h, nil
+	}
+	var n uint64
+	if strings.HasPrefix(v, "0x") {
+		n, err = hexutil.DecodeUint64(v)
+	} else {
+		n, err = strconv.ParseUint(v, 10, 64)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get header %d: %w", to, err)
+		return "", nil, fmt.Errorf("invalid block number or hash %q: %w", v, err)
+	}
+	return methodEthGetBlockByNumber, hexutil.Uint64(n).String(), nil
+}
+
+// Rewind sets the forkchoice of the engine back to the given block (hash or number), which must be
+// a canonical block at or below the current head. The safe and finalized blocks are not increased.
+func Rewind(ctx context.Context, lgr log.Logger, client *sources.EngineAPIClient, open client.RPC, to string, settings RewindSettings) error {
+	method, tag, err := ParseBlockTarget(to)
+	if err != nil {
+		return err
+	}
+	var unsafe *types.Header
+	if err := open.CallContext(ctx, &unsafe, method, tag, false); err != nil {
+		return fmt.Errorf("failed to get header %s: %w", to, err)
+	} else if unsafe == nil {
+		return fmt.Errorf("rewind target %s not found", to)
 	}
 	toUnsafe := eth.HeaderBlockID(unsafe)
 
+	canonical, err := getHeader(ctx, open, methodEthGetBlockByNumber, hexutil.Uint64(toUnsafe.Number).String())
+	if err != nil {
+		return fmt.Errorf("failed to get canonical header %d: %w", toUnsafe.Number, err)
+	} else if canonical == nil || canonical.Hash() != toUnsafe.Hash {
+		return fmt.Errorf("rewind target %s is not canonical, use set-forkchoice-by-hash to reorg", toUnsafe)
+	}
+
 	latest, safe, finalized, err := headSafeFinalized(ctx, open)
 	if err != nil {
 		return fmt.Errorf("failed to get current heads: %w", err)
 	}
+	if toUnsafe.Number > latest.Number.Uint64() {
+		return fmt.Errorf("cannot rewind to %s, ahead of latest %s", toUnsafe, eth.HeaderBlockID(latest))
+	}
+	if toUnsafe.Number < finalized.Number.Uint64() && !settings.AllowFinalized {
+		return fmt.Errorf("cannot rewind to %s, before finalized %s, unless rewinding finalized blocks is allowed",
+			toUnsafe, eth.HeaderBlockID(finalized))
+	}
 
 	// when rewinding, don't increase unsafe/finalized tags
 	toSafe, toFinalized := toUnsafe, toUnsafe
-	if safe.Number.Uint64() < to {
+	if safe.Number.Uint64() < toUnsafe.Number {
 		toSafe = eth.HeaderBlockID(safe)
 	}
-	if finalized.Number.Uint64() < to {
+	if finalized.Number.Uint64() < toUnsafe.Number {
 		toFinalized = eth.HeaderBlockID(finalized)
 	}
 
 	lgr.Info("Rewinding chain",
-		"setHead", setHead,
+		"setHead", settings.SetHead,
+		"dryRun", settings.DryRun,
 		"latest", eth.HeaderBlockID(latest),
 		"unsafe", toUnsafe,
 		"safe", toSafe,
 		"finalized", toFinalized,
 	)
-	if setHead {
-		lgr.Debug("Calling "+methodDebugSetHead, "head", to)
-		if err := debugSetHead(ctx, open, to); err != nil {
-			return fmt.Errorf("failed to setHead %d: %w", to, err)
+	if settings.DryRun {
+		return nil
+	}
+	if settings.SetHead {
+		lgr.Debug("Calling "+methodDebugSetHead, "head", toUnsafe.Number)
+		if err := debugSetHead(ctx, open, toUnsafe.Number); err != nil {
+			return fmt.Errorf("failed to setHead %d: %w", toUnsafe.Number, err)
 		}
 	}
-	return SetForkchoiceByHash(ctx, client, toFinalized.Hash, toSafe.Hash, toUnsafe.Hash)
+	if err := SetForkchoiceByHash(ctx, client, toFinalized.Hash, toSafe.Hash, toUnsafe.Hash); err != nil {
+		return err
+	}
+
+	head, err := getHeader(ctx, open, methodEthGetBlockByNumber, "latest")
+	if err != nil {
+		return fmt.Errorf("failed to get latest block after rewind: %w", err)
+	} else if head.Hash() != toUnsafe.Hash {
+		return fmt.Errorf("engine did not rewind, latest block is %s instead of %s", eth.HeaderBlockID(head), toUnsafe)
+	}
+	lgr.Info("Rewound chain", "latest", toUnsafe)
+	return nil
 }
 
 func RawJSONInteraction(ctx context.Context, client client.RPC, method string, args []string, input io.Reader, output io.Writer) error {
@@ -8,37 +8,27 @@ import (
 
 type StaticVersionProvider int
 
-func (v StaticVersionProvider) ForkchoiceUpdatedVersion(*eth.PayloadAttributes) eth.EngineAPIMethod {
+func (v StaticVersionProvider) fork() eth.EngineFork {
 	switch int(v) {
 	case 1:
-		return eth.FCUV1
+		return eth.EngineParis
 	case 2:
-		return eth.FCUV2
+		return eth.EngineShanghai
 	case 3:
-		return eth.FCUV3
+		return eth.EngineCancun
 	default:
 		panic("invalid Engine API version: " + strconv.Itoa(int(v)))
 	}
 }
 
+func (v StaticVersionProvider) ForkchoiceUpdatedVersion(*eth.PayloadAttributes) eth.EngineAPIMethod {
+	return eth.FCUForFork(v.fork())
+}
+
 func (v StaticVersionProvider) NewPayloadVersion(uint64) eth.EngineAPIMethod {
-	switch int(v) {
-	case 1, 2:
-		return eth.NewPayloadV2
-	case 3:
-		return eth.NewPayloadV3
-	default:
-		panic("invalid Engine API version: " + strconv.Itoa(int(v)))
-	}
+	return eth.NewPayloadForFork(v.fork())
 }
 
 func (v StaticVersionProvider) GetPayloadVersion(uint64) eth.EngineAPIMethod {
-	switch int(v) {
-	case 1, 2:
-		return eth.GetPayloadV2
-	case 3:
-		return eth.GetPayloadV3
-	default:
-		panic("invalid Engine API version: " + strconv.Itoa(int(v)))
-	}
+	return eth.GetPayloadForFork(v.fork())
 }
@@ -22,6 +22,9 @@ var mips []byte
 //go:embed abi/DelayedWETH.json
 var delayedWETH []byte
 
+//go:embed abi/L2OutputOracle.json
+var l2OutputOracle []byte
+
 func LoadDisputeGameFactoryABI() *abi.ABI {
 	return loadABI(disputeGameFactory)
 }
@@ -37,6 +40,9 @@ func LoadMIPSABI() *abi.ABI {
 func LoadDelayedWETHABI() *abi.ABI {
 	return loadABI(delayedWETH)
 }
+func LoadL2OutputOracleABI() *abi.ABI {
+	return loadABI(l2OutputOracle)
+}
 
 func loadABI(json []byte) *abi.ABI {
 	if parsed, err := abi.JSON(bytes.NewReader(json)); err != nil {